@@ -288,6 +288,57 @@ func (cs *ClusterSequence) addConstituents(jet *Jet) ([]Jet, error) {
 	return subjets, err
 }
 
+// ConstituentIndices returns the indices of the constituents of a given jet,
+// in the slice of particles the cluster sequence was created with.
+//
+// Constituents are followed through the whole recombination history of the
+// jet, so the returned indices can be used to match jets back to the
+// objects (tracks, calorimeter cells, ...) the particles were built from.
+func (cs *ClusterSequence) ConstituentIndices(jet *Jet) ([]int, error) {
+	if jet.hidx < 0 || jet.hidx >= len(cs.history) {
+		return nil, fmt.Errorf("fastjet: jet is not part of this cluster sequence (history index=%d)", jet.hidx)
+	}
+	return cs.addConstituentIndices(nil, jet.hidx), nil
+}
+
+func (cs *ClusterSequence) addConstituentIndices(indices []int, i int) []int {
+	hh := &cs.history[i]
+	if hh.parent1 == inexistentParent {
+		// original particle: its history index is its index in the
+		// slice of input particles.
+		return append(indices, i)
+	}
+
+	indices = cs.addConstituentIndices(indices, hh.parent1)
+	if hh.parent2 == beamJetIndex {
+		return indices
+	}
+	return cs.addConstituentIndices(indices, hh.parent2)
+}
+
+// ParticleJetIndices returns, for each of the particles the cluster sequence
+// was created with, the index of the jet containing that particle in the
+// provided slice of jets, or -1 if the particle is not part of any of
+// these jets.
+func (cs *ClusterSequence) ParticleJetIndices(jets []Jet) ([]int, error) {
+	indices := make([]int, cs.initn)
+	for i := range indices {
+		indices[i] = -1
+	}
+
+	for ijet := range jets {
+		parts, err := cs.ConstituentIndices(&jets[ijet])
+		if err != nil {
+			return nil, fmt.Errorf("fastjet: could not retrieve constituents of jet %d: %w", ijet, err)
+		}
+		for _, i := range parts {
+			indices[i] = ijet
+		}
+	}
+
+	return indices, nil
+}
+
 func (cs *ClusterSequence) jetScaleForAlgorithm(jet *Jet) float64 {
 	switch cs.alg {
 
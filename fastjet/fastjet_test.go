@@ -5,6 +5,7 @@
 package fastjet_test
 
 import (
+	"reflect"
 	"sort"
 	"testing"
 
@@ -115,3 +116,64 @@ func TestSimple(t *testing.T) {
 		}
 	}
 }
+
+func TestConstituentIndices(t *testing.T) {
+	t.Parallel()
+
+	particles := []fastjet.Jet{
+		fastjet.NewJet(+99.0, +0.1, 0, 100.0),
+		fastjet.NewJet(+04.0, -0.1, 0, 005.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 099.0),
+		fastjet.NewJet(+99.0, +0.1, 0, 199.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 299.0),
+		fastjet.NewJet(-99.0, +1.0, 0, 399.0),
+		fastjet.NewJet(+50.0, +1.0, 100, 399.0),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.7, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatalf("clustering failed: %v", err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatalf("could not retrieve inclusive jets: %v", err)
+	}
+	sort.Sort(fastjet.ByPt(jets))
+
+	want := [][]int{
+		{5, 2, 4},
+		{6, 1, 0, 3},
+	}
+	for i := range jets {
+		jet := &jets[i]
+		got, err := cs.ConstituentIndices(jet)
+		if err != nil {
+			t.Fatalf("could not retrieve constituent indices of jet[%d]: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("jet[%d]: invalid constituent indices:\ngot= %v\nwant=%v", i, got, want[i])
+		}
+		constituents := jet.Constituents()
+		for j := range constituents {
+			if !fmom.Equal(&particles[got[j]], &constituents[j]) {
+				t.Fatalf("jet[%d].constituent[%d]: invalid particle", i, j)
+			}
+		}
+	}
+
+	indices, err := cs.ParticleJetIndices(jets[1:])
+	if err != nil {
+		t.Fatalf("could not retrieve particle jet indices: %v", err)
+	}
+	if got, want := indices, []int{0, 0, -1, 0, -1, -1, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid particle jet indices:\ngot= %v\nwant=%v", got, want)
+	}
+
+	jet := fastjet.NewJet(1, 2, 3, 4)
+	_, err = cs.ConstituentIndices(&jet)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go-hep.org/x/hep/hbook"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/stats"
+)
+
+const (
+	maxCopies   = 2 // maximum number of workers processing the same range
+	maxFailures = 3 // maximum number of failures of a range before giving up
+
+	keepaliveTime    = 30 * time.Second // period of the pings sent to idle workers
+	keepaliveTimeout = 10 * time.Second // time after which a worker not answering a ping is considered dead
+)
+
+// Coordinator distributes ranges of entries to workers and merges the
+// hbook objects they produce.
+type Coordinator struct {
+	srv *grpc.Server
+
+	mu    sync.Mutex
+	tasks []*task
+	queue []*task                 // tasks not handed out yet
+	ndone int                     // number of processed tasks
+	objs  map[string]hbook.Object // merged objects
+	err   error
+
+	wake   chan struct{} // closed when new tasks may be handed out
+	finish chan struct{} // closed when all tasks have been processed, or on error
+}
+
+type task struct {
+	id      int64
+	rng     Range
+	workers map[string]claim // workers processing the task
+	fails   int
+	done    bool
+}
+
+// claim describes a worker processing a task.
+type claim struct {
+	start time.Time // start time of the processing
+	conn  *wconn    // connection of the worker to the coordinator
+}
+
+// wconn identifies a connection of a worker to the coordinator.
+type wconn struct {
+	addr net.Addr // remote address of the worker
+}
+
+type wconnKey struct{}
+
+// NewCoordinator creates a new coordinator distributing the provided
+// ranges of entries.
+func NewCoordinator(rngs []Range) *Coordinator {
+	c := &Coordinator{
+		tasks:  make([]*task, len(rngs)),
+		queue:  make([]*task, len(rngs)),
+		objs:   make(map[string]hbook.Object),
+		wake:   make(chan struct{}),
+		finish: make(chan struct{}),
+	}
+	for i, rng := range rngs {
+		c.tasks[i] = &task{
+			id:      int64(i),
+			rng:     rng,
+			workers: make(map[string]claim),
+		}
+	}
+	copy(c.queue, c.tasks)
+	if len(c.tasks) == 0 {
+		close(c.finish)
+	}
+
+	c.srv = grpc.NewServer(
+		grpc.ForceServerCodec(codec{}),
+		grpc.StatsHandler(connHandler{c}),
+		// detect dead workers whose connection was not properly closed.
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+	)
+	c.srv.RegisterService(&coordinatorDesc, c)
+	return c
+}
+
+// Serve accepts incoming connections from workers on the listener l.
+// Serve blocks until Stop is called.
+func (c *Coordinator) Serve(l net.Listener) error {
+	return c.srv.Serve(l)
+}
+
+// Stop stops the coordinator, closing all connections with workers.
+func (c *Coordinator) Stop() {
+	c.srv.Stop()
+}
+
+// Wait waits for all the ranges to be processed and returns the merged
+// hbook objects, sorted by name.
+func (c *Coordinator) Wait(ctx context.Context) ([]hbook.Object, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.finish:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	return sorted(c.objs), nil
+}
+
+func (c *Coordinator) finished() bool {
+	select {
+	case <-c.finish:
+		return true
+	default:
+		return false
+	}
+}
+
+// broadcast wakes up the workers waiting for a task.
+// broadcast must be called with c.mu held.
+func (c *Coordinator) broadcast() {
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
+
+// fail stops the distribution of tasks with the provided error.
+// fail must be called with c.mu held.
+func (c *Coordinator) fail(err error) {
+	if c.finished() {
+		return
+	}
+	c.err = err
+	close(c.finish)
+	c.broadcast()
+}
+
+func (c *Coordinator) next(ctx context.Context, req *nextRequest) (*nextReply, error) {
+	for {
+		c.mu.Lock()
+		if c.finished() {
+			c.mu.Unlock()
+			return &nextReply{Done: true}, nil
+		}
+
+		t := c.pop(req.Worker)
+		if t != nil {
+			conn, _ := ctx.Value(wconnKey{}).(*wconn)
+			t.workers[req.Worker] = claim{start: time.Now(), conn: conn}
+			c.mu.Unlock()
+			return &nextReply{Task: t.id, Range: t.rng}, nil
+		}
+		wake := c.wake
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// pop returns the next task to hand out to the named worker, or nil if
+// there is none.
+// Once all tasks have been handed out, pop steals the task processed by
+// the fewest workers for the longest time, if any.
+// pop must be called with c.mu held.
+func (c *Coordinator) pop(worker string) *task {
+	if len(c.queue) > 0 {
+		t := c.queue[0]
+		c.queue = c.queue[1:]
+		return t
+	}
+
+	var (
+		stolen *task
+		start  time.Time
+	)
+	for _, t := range c.tasks {
+		if t.done || len(t.workers) == 0 || len(t.workers) >= maxCopies {
+			continue
+		}
+		if _, dup := t.workers[worker]; dup {
+			continue
+		}
+		beg := time.Now()
+		for _, v := range t.workers {
+			if v.start.Before(beg) {
+				beg = v.start
+			}
+		}
+		switch {
+		case stolen == nil,
+			len(t.workers) < len(stolen.workers),
+			len(t.workers) == len(stolen.workers) && beg.Before(start):
+			stolen = t
+			start = beg
+		}
+	}
+	return stolen
+}
+
+func (c *Coordinator) done(ctx context.Context, req *doneRequest) (*doneReply, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if req.Task < 0 || req.Task >= int64(len(c.tasks)) {
+		return nil, fmt.Errorf("dist: invalid task %d", req.Task)
+	}
+
+	t := c.tasks[req.Task]
+	delete(t.workers, req.Worker)
+
+	switch {
+	case c.finished() || t.done:
+		// discard results from a finished job or from a stolen task.
+
+	case req.Err != "":
+		t.fails++
+		if t.fails >= maxFailures {
+			c.fail(fmt.Errorf(
+				"dist: could not process range [%d, %d) (failures=%d): %s",
+				t.rng.Beg, t.rng.End, t.fails, req.Err,
+			))
+			break
+		}
+		c.requeue(t)
+
+	default:
+		err := c.merge(req.Objects)
+		if err != nil {
+			c.fail(fmt.Errorf("dist: could not merge results of range [%d, %d): %w", t.rng.Beg, t.rng.End, err))
+			break
+		}
+		t.done = true
+		c.ndone++
+		if c.ndone == len(c.tasks) {
+			close(c.finish)
+			c.broadcast()
+		}
+	}
+
+	return &doneReply{Done: c.finished()}, nil
+}
+
+// requeue hands out again the provided task, if no worker is processing it.
+// requeue must be called with c.mu held.
+func (c *Coordinator) requeue(t *task) {
+	if len(t.workers) != 0 {
+		return
+	}
+	c.queue = append(c.queue, t)
+	c.broadcast()
+}
+
+// disconnect releases the tasks claimed by the workers of the provided
+// connection, so these tasks can be handed out to other workers.
+// Each released task is accounted as a failure of that task, so ranges
+// crashing workers eventually stop the job.
+func (c *Coordinator) disconnect(conn *wconn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.tasks {
+		if t.done {
+			continue
+		}
+		n := len(t.workers)
+		for name, v := range t.workers {
+			if v.conn == conn {
+				delete(t.workers, name)
+			}
+		}
+		if len(t.workers) == n || c.finished() {
+			continue
+		}
+		t.fails += n - len(t.workers)
+		if t.fails >= maxFailures {
+			c.fail(fmt.Errorf(
+				"dist: could not process range [%d, %d) (failures=%d): worker connection lost",
+				t.rng.Beg, t.rng.End, t.fails,
+			))
+			return
+		}
+		c.requeue(t)
+	}
+}
+
+// connHandler tags the gRPC connections of workers, so the tasks they
+// claimed are released when their connection is closed.
+type connHandler struct {
+	c *Coordinator
+}
+
+func (connHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+func (connHandler) HandleRPC(context.Context, stats.RPCStats)                       {}
+
+func (connHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, wconnKey{}, &wconn{addr: info.RemoteAddr})
+}
+
+func (h connHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	conn, ok := ctx.Value(wconnKey{}).(*wconn)
+	if !ok {
+		return
+	}
+	h.c.disconnect(conn)
+}
+
+// merge merges the provided objects into the objects of the coordinator.
+// merge must be called with c.mu held.
+func (c *Coordinator) merge(objs []object) error {
+	for _, o := range objs {
+		obj, err := decode(o)
+		if err != nil {
+			return err
+		}
+		name := obj.Name()
+		cur, ok := c.objs[name]
+		if !ok {
+			c.objs[name] = obj
+			continue
+		}
+		obj, err = merge(cur, obj)
+		if err != nil {
+			return err
+		}
+		c.objs[name] = obj
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dist provides a distributed job mode for fwk applications.
+//
+// A Coordinator splits the entries of a dataset into ranges and hands them
+// out, over gRPC, to worker processes running Work.
+// Workers pull ranges from the coordinator as soon as they are idle, so fast
+// workers process more ranges than slow ones.
+// Once all ranges have been handed out, idle workers steal ranges still being
+// processed by other workers, so straggling or dead workers do not hold up
+// the whole job: the first result for a range is kept, the others are discarded.
+// Ranges held by workers whose connection to the coordinator is lost are
+// handed out again to the other workers.
+//
+// For each range, workers run a Processor, typically a fwk application
+// configured to process that range, and send back the hbook histograms it
+// produced. The coordinator merges these histograms by name.
+package dist // import "go-hep.org/x/hep/fwk/dist"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/hbooksvc"
+	"go-hep.org/x/hep/hbook"
+)
+
+// Range is a range of entries [Beg, End).
+type Range struct {
+	Beg int64 // first entry of the range
+	End int64 // one past the last entry of the range
+}
+
+// Len returns the number of entries in the range.
+func (rng Range) Len() int64 { return rng.End - rng.Beg }
+
+// Split splits n entries into consecutive ranges of at most size entries.
+func Split(n, size int64) []Range {
+	if size <= 0 {
+		size = n
+	}
+	var rngs []Range
+	for beg := int64(0); beg < n; beg += size {
+		end := beg + size
+		if end > n {
+			end = n
+		}
+		rngs = append(rngs, Range{Beg: beg, End: end})
+	}
+	return rngs
+}
+
+// Processor processes a range of entries and returns the hbook
+// objects it produced.
+type Processor func(ctx context.Context, rng Range) ([]hbook.Object, error)
+
+// RunApp runs the provided fwk application and returns the hbook objects
+// booked in the write-streams of its histogram service, named hsvc.
+func RunApp(app fwk.App, hsvc string) ([]hbook.Object, error) {
+	svc, ok := app.GetSvc(hsvc).(fwk.HistSvc)
+	if !ok {
+		return nil, fmt.Errorf("dist: could not find histogram service %q", hsvc)
+	}
+
+	err := app.Run()
+	if err != nil {
+		return nil, fmt.Errorf("dist: could not run application: %w", err)
+	}
+
+	return hbooksvc.Objects(svc)
+}
+
+// merge merges src into dst and returns the merged object.
+func merge(dst, src hbook.Object) (o hbook.Object, err error) {
+	defer func() {
+		e := recover()
+		if e == nil {
+			return
+		}
+		switch e := e.(type) {
+		case error:
+			err = fmt.Errorf("dist: could not merge %q: %w", dst.Name(), e)
+		default:
+			err = fmt.Errorf("dist: could not merge %q: %v", dst.Name(), e)
+		}
+	}()
+
+	switch dst := dst.(type) {
+	case *hbook.H1D:
+		if src, ok := src.(*hbook.H1D); ok {
+			return hbook.AddH1D(dst, src), nil
+		}
+	case *hbook.H2D:
+		if src, ok := src.(*hbook.H2D); ok {
+			return hbook.AddH2D(dst, src), nil
+		}
+	case *hbook.P1D:
+		if src, ok := src.(*hbook.P1D); ok {
+			return hbook.AddP1D(dst, src), nil
+		}
+	case *hbook.S2D:
+		if src, ok := src.(*hbook.S2D); ok {
+			dst.Fill(src.Points()...)
+			return dst, nil
+		}
+	default:
+		return nil, fmt.Errorf("dist: could not merge %q: unsupported type %T", dst.Name(), dst)
+	}
+	return nil, fmt.Errorf("dist: could not merge %q: type mismatch (%T vs %T)", dst.Name(), dst, src)
+}
+
+// sorted returns the provided objects sorted by name.
+func sorted(objs map[string]hbook.Object) []hbook.Object {
+	o := make([]hbook.Object, 0, len(objs))
+	for _, obj := range objs {
+		o = append(o, obj)
+	}
+	sort.Slice(o, func(i, j int) bool {
+		return o[i].Name() < o[j].Name()
+	})
+	return o
+}
@@ -0,0 +1,344 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/fwk"
+	"go-hep.org/x/hep/fwk/dist"
+	"go-hep.org/x/hep/fwk/hbooksvc"
+	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/hbook"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		n, size int64
+		want    []dist.Range
+	}{
+		{n: 0, size: 10, want: nil},
+		{n: 10, size: 10, want: []dist.Range{{0, 10}}},
+		{n: 10, size: 0, want: []dist.Range{{0, 10}}},
+		{n: 10, size: 4, want: []dist.Range{{0, 4}, {4, 8}, {8, 10}}},
+		{n: 3, size: 1, want: []dist.Range{{0, 1}, {1, 2}, {2, 3}}},
+	} {
+		t.Run(fmt.Sprintf("n=%d-size=%d", tc.n, tc.size), func(t *testing.T) {
+			got := dist.Split(tc.n, tc.size)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid ranges:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+// filler fills histograms with the identifiers of the entries of its range.
+type filler struct {
+	fwk.TaskBase
+
+	beg  int64
+	hsvc fwk.HistSvc
+	h1d  fwk.H1D
+	h2d  fwk.H2D
+	s2d  fwk.S2D
+}
+
+func (tsk *filler) StartTask(ctx fwk.Context) error {
+	svc, err := ctx.Svc("histsvc")
+	if err != nil {
+		return err
+	}
+	tsk.hsvc = svc.(fwk.HistSvc)
+
+	tsk.h1d, err = tsk.hsvc.BookH1D("/out/h1d", 10, 0, 100)
+	if err != nil {
+		return err
+	}
+	tsk.h2d, err = tsk.hsvc.BookH2D("/out/h2d", 10, 0, 100, 10, 0, 100)
+	if err != nil {
+		return err
+	}
+	tsk.s2d, err = tsk.hsvc.BookS2D("/out/s2d")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tsk *filler) StopTask(ctx fwk.Context) error { return nil }
+
+func (tsk *filler) Process(ctx fwk.Context) error {
+	x := float64(tsk.beg + ctx.ID())
+	tsk.hsvc.FillH1D(tsk.h1d.ID, x, 1)
+	tsk.hsvc.FillH2D(tsk.h2d.ID, x, x, 1)
+	tsk.hsvc.FillS2D(tsk.s2d.ID, x, x)
+	return nil
+}
+
+func init() {
+	fwk.Register(
+		reflect.TypeOf(filler{}),
+		func(typ, name string, mgr fwk.App) (fwk.Component, error) {
+			tsk := &filler{TaskBase: fwk.NewTask(typ, name, mgr)}
+			err := tsk.DeclProp("Beg", &tsk.beg)
+			if err != nil {
+				return nil, err
+			}
+			return tsk, nil
+		},
+	)
+}
+
+func newProcessor(dir string) dist.Processor {
+	return func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+		app := job.New(job.P{
+			"EvtMax":   rng.Len(),
+			"NProcs":   1,
+			"MsgLevel": job.MsgLevel("ERROR"),
+		})
+		app.Create(job.C{
+			Type:  "go-hep.org/x/hep/fwk/dist_test.filler",
+			Name:  "filler",
+			Props: job.P{"Beg": rng.Beg},
+		})
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/hbooksvc.hsvc",
+			Name: "histsvc",
+			Props: job.P{
+				"Streams": map[string]hbooksvc.Stream{
+					"/out": {
+						Name: filepath.Join(dir, fmt.Sprintf("out-%d-%d.rio", rng.Beg, rng.End)),
+						Mode: hbooksvc.Write,
+					},
+				},
+			},
+		})
+		return dist.RunApp(app.App(), "histsvc")
+	}
+}
+
+func startCoordinator(t *testing.T, rngs []dist.Range) (*dist.Coordinator, string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	c := dist.NewCoordinator(rngs)
+	go func() {
+		_ = c.Serve(l)
+	}()
+	return c, l.Addr().String()
+}
+
+func TestCoordinator(t *testing.T) {
+	dir, err := os.MkdirTemp("", "fwk-dist-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	const nevts = 100
+	c, addr := startCoordinator(t, dist.Split(nevts, 7))
+	defer c.Stop()
+
+	var (
+		proc  = newProcessor(dir)
+		fails int32
+	)
+	procs := map[string]dist.Processor{
+		"worker-ok": proc,
+		"worker-slow": func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+			time.Sleep(10 * time.Millisecond)
+			return proc(ctx, rng)
+		},
+		"worker-flaky": func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+			if atomic.AddInt32(&fails, 1)%2 == 1 {
+				return nil, fmt.Errorf("flaky worker")
+			}
+			return proc(ctx, rng)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for name, proc := range procs {
+		wg.Add(1)
+		go func(name string, proc dist.Processor) {
+			defer wg.Done()
+			err := dist.Work(ctx, addr, name, proc)
+			if err != nil {
+				t.Errorf("worker %q failed: %+v", name, err)
+			}
+		}(name, proc)
+	}
+
+	objs, err := c.Wait(ctx)
+	if err != nil {
+		t.Fatalf("could not run distributed job: %+v", err)
+	}
+	wg.Wait()
+
+	if got, want := len(objs), 3; got != want {
+		t.Fatalf("invalid number of objects: got=%d, want=%d", got, want)
+	}
+
+	h1 := objs[0].(*hbook.H1D)
+	if got, want := h1.Name(), "out/h1d"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := h1.Entries(), int64(nevts); got != want {
+		t.Fatalf("invalid h1d entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h1.XMean(), 49.5; got != want {
+		t.Fatalf("invalid h1d mean: got=%v, want=%v", got, want)
+	}
+
+	h2 := objs[1].(*hbook.H2D)
+	if got, want := h2.Entries(), int64(nevts); got != want {
+		t.Fatalf("invalid h2d entries: got=%d, want=%d", got, want)
+	}
+
+	s2 := objs[2].(*hbook.S2D)
+	if got, want := s2.Len(), nevts; got != want {
+		t.Fatalf("invalid s2d points: got=%d, want=%d", got, want)
+	}
+}
+
+func TestCodecNotRegistered(t *testing.T) {
+	if codec := encoding.GetCodec("gob"); codec != nil {
+		t.Fatalf("dist codec should not be registered globally: %T", codec)
+	}
+}
+
+func TestCoordinatorStealing(t *testing.T) {
+	c, addr := startCoordinator(t, dist.Split(4, 1))
+	defer c.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	mk := func(v float64) []hbook.Object {
+		h := hbook.NewH1D(4, 0, 4)
+		h.Annotation()["name"] = "h1"
+		h.Fill(v, 1)
+		return []hbook.Object{h}
+	}
+
+	// the stuck worker never completes its range, which has to be stolen
+	// by the other worker.
+	stuck := make(chan struct{})
+	go func() {
+		_ = dist.Work(ctx, addr, "worker-stuck", func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+			close(stuck)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}()
+	<-stuck
+
+	err := dist.Work(ctx, addr, "worker-ok", func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+		return mk(float64(rng.Beg)), nil
+	})
+	if err != nil {
+		t.Fatalf("could not run worker: %+v", err)
+	}
+
+	objs, err := c.Wait(ctx)
+	if err != nil {
+		t.Fatalf("could not run distributed job: %+v", err)
+	}
+	if got, want := objs[0].(*hbook.H1D).Entries(), int64(4); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+}
+
+func TestCoordinatorFailure(t *testing.T) {
+	c, addr := startCoordinator(t, dist.Split(10, 5))
+	defer c.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := dist.Work(ctx, addr, "worker-ko", func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+		return nil, fmt.Errorf("boom")
+	})
+	if err != nil {
+		t.Fatalf("could not run worker: %+v", err)
+	}
+
+	_, err = c.Wait(ctx)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestCoordinatorWorkerDisconnect(t *testing.T) {
+	c, addr := startCoordinator(t, dist.Split(4, 4))
+	defer c.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the dead workers start processing the only range, and die before
+	// reporting their results: the range has to be handed out again.
+	var wg sync.WaitGroup
+	for _, name := range []string{"worker-dead-1", "worker-dead-2"} {
+		started := make(chan struct{})
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			err := dist.Work(ctx, addr, name, func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			if err == nil {
+				t.Errorf("worker %q: expected an error", name)
+			}
+		}(name)
+		<-started
+	}
+
+	// kill the dead workers.
+	cancel()
+	wg.Wait()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := dist.Work(ctx, addr, "worker-ok", func(ctx context.Context, rng dist.Range) ([]hbook.Object, error) {
+		h := hbook.NewH1D(4, 0, 4)
+		h.Annotation()["name"] = "h1"
+		for i := rng.Beg; i < rng.End; i++ {
+			h.Fill(float64(i), 1)
+		}
+		return []hbook.Object{h}, nil
+	})
+	if err != nil {
+		t.Fatalf("could not run worker: %+v", err)
+	}
+
+	objs, err := c.Wait(ctx)
+	if err != nil {
+		t.Fatalf("could not run distributed job: %+v", err)
+	}
+	if got, want := objs[0].(*hbook.H1D).Entries(), int64(4); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/gob"
+	"fmt"
+
+	"go-hep.org/x/hep/hbook"
+	"google.golang.org/grpc"
+	grpcenc "google.golang.org/grpc/encoding"
+)
+
+// codec encodes the messages exchanged between the coordinator and
+// its workers with encoding/gob.
+//
+// codec is not registered with the gRPC encoding registry: it is only
+// forced on the coordinator server and the calls of its clients, so other
+// gRPC services of the process are left untouched.
+type codec struct{}
+
+func (codec) Name() string { return "gob" }
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+var (
+	_ grpcenc.Codec = (*codec)(nil)
+)
+
+type nextRequest struct {
+	Worker string // name of the requesting worker
+}
+
+type nextReply struct {
+	Task  int64 // task identifier
+	Range Range // range of entries to process
+	Done  bool  // whether there are no more ranges to process
+}
+
+type doneRequest struct {
+	Worker  string   // name of the worker
+	Task    int64    // task identifier
+	Objects []object // hbook objects produced by the task
+	Err     string   // error message, if the task failed
+}
+
+type doneReply struct {
+	Done bool // whether there are no more ranges to process
+}
+
+// object is an hbook object on the wire.
+type object struct {
+	Type string
+	Data []byte
+}
+
+func encode(obj hbook.Object) (object, error) {
+	var typ string
+	switch obj.(type) {
+	case *hbook.H1D:
+		typ = "H1D"
+	case *hbook.H2D:
+		typ = "H2D"
+	case *hbook.P1D:
+		typ = "P1D"
+	case *hbook.S2D:
+		typ = "S2D"
+	default:
+		return object{}, fmt.Errorf("dist: unsupported hbook object type %T", obj)
+	}
+
+	data, err := obj.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return object{}, fmt.Errorf("dist: could not marshal %q: %w", obj.Name(), err)
+	}
+	return object{Type: typ, Data: data}, nil
+}
+
+func decode(o object) (hbook.Object, error) {
+	var obj hbook.Object
+	switch o.Type {
+	case "H1D":
+		obj = new(hbook.H1D)
+	case "H2D":
+		obj = new(hbook.H2D)
+	case "P1D":
+		obj = new(hbook.P1D)
+	case "S2D":
+		obj = new(hbook.S2D)
+	default:
+		return nil, fmt.Errorf("dist: unsupported hbook object type %q", o.Type)
+	}
+
+	err := obj.(encoding.BinaryUnmarshaler).UnmarshalBinary(o.Data)
+	if err != nil {
+		return nil, fmt.Errorf("dist: could not unmarshal %s object: %w", o.Type, err)
+	}
+	return obj, nil
+}
+
+// coordinatorServer is the server API of the coordinator service.
+type coordinatorServer interface {
+	next(ctx context.Context, req *nextRequest) (*nextReply, error)
+	done(ctx context.Context, req *doneRequest) (*doneReply, error)
+}
+
+const serviceName = "fwk.dist.Coordinator"
+
+var coordinatorDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*coordinatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Next",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(nextRequest)
+				err := dec(req)
+				if err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(coordinatorServer).next(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{
+					Server:     srv,
+					FullMethod: "/" + serviceName + "/Next",
+				}
+				return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(coordinatorServer).next(ctx, req.(*nextRequest))
+				})
+			},
+		},
+		{
+			MethodName: "Done",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(doneRequest)
+				err := dec(req)
+				if err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(coordinatorServer).done(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{
+					Server:     srv,
+					FullMethod: "/" + serviceName + "/Done",
+				}
+				return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(coordinatorServer).done(ctx, req.(*doneRequest))
+				})
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// coordinatorClient is the client API of the coordinator service.
+type coordinatorClient struct {
+	cc *grpc.ClientConn
+}
+
+func (c *coordinatorClient) next(ctx context.Context, req *nextRequest) (*nextReply, error) {
+	rep := new(nextReply)
+	err := c.cc.Invoke(ctx, "/"+serviceName+"/Next", req, rep, grpc.ForceCodec(codec{}))
+	if err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
+
+func (c *coordinatorClient) done(ctx context.Context, req *doneRequest) (*doneReply, error) {
+	rep := new(doneReply)
+	err := c.cc.Invoke(ctx, "/"+serviceName+"/Done", req, rep, grpc.ForceCodec(codec{}))
+	if err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
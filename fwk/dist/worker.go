@@ -0,0 +1,73 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Work connects to the coordinator at addr and processes the ranges of
+// entries it hands out with proc, until the coordinator has no more
+// ranges to process.
+//
+// name identifies the worker and should be unique among the workers
+// of a coordinator.
+// Errors returned by proc are reported to the coordinator, which may hand
+// out the failing range to another worker.
+func Work(ctx context.Context, addr, name string, proc Processor) error {
+	cc, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return fmt.Errorf("dist: could not dial coordinator %q: %w", addr, err)
+	}
+	defer cc.Close()
+
+	cli := &coordinatorClient{cc: cc}
+	for {
+		rep, err := cli.next(ctx, &nextRequest{Worker: name})
+		if err != nil {
+			return fmt.Errorf("dist: could not retrieve next range: %w", err)
+		}
+		if rep.Done {
+			return nil
+		}
+
+		req := &doneRequest{
+			Worker: name,
+			Task:   rep.Task,
+		}
+		err = func() error {
+			objs, err := proc(ctx, rep.Range)
+			if err != nil {
+				return err
+			}
+			req.Objects = make([]object, len(objs))
+			for i, obj := range objs {
+				req.Objects[i], err = encode(obj)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			req.Objects = nil
+			req.Err = err.Error()
+		}
+
+		done, err := cli.done(ctx, req)
+		if err != nil {
+			return fmt.Errorf("dist: could not send results of range [%d, %d): %w", rep.Range.Beg, rep.Range.End, err)
+		}
+		if done.Done {
+			return nil
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -429,6 +430,31 @@ func (svc *hsvc) FillS2D(id fwk.HID, x, y float64) {
 	h.mu.Unlock()
 }
 
+// Objects returns the hbook objects booked in the write-streams of the
+// provided histogram service, sorted by name.
+func Objects(svc fwk.HistSvc) ([]hbook.Object, error) {
+	hs, ok := svc.(*hsvc)
+	if !ok {
+		return nil, fmt.Errorf("hbooksvc: invalid histogram service type %T", svc)
+	}
+
+	var objs []hbook.Object
+	for _, w := range hs.w {
+		for _, h := range w.objs {
+			obj, ok := h.Value().(hbook.Object)
+			if !ok {
+				return nil, fmt.Errorf("hbooksvc: invalid hbook object type %T", h.Value())
+			}
+			objs = append(objs, obj)
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return objs[i].Name() < objs[j].Name()
+	})
+
+	return objs, nil
+}
+
 func newhsvc(typ, name string, mgr fwk.App) (fwk.Component, error) {
 	var err error
 	svc := &hsvc{
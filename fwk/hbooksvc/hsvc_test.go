@@ -13,6 +13,7 @@ import (
 
 	"go-hep.org/x/hep/fwk"
 	"go-hep.org/x/hep/fwk/job"
+	"go-hep.org/x/hep/hbook"
 )
 
 const (
@@ -90,6 +91,65 @@ func TestHbookSvcConc(t *testing.T) {
 	}
 }
 
+func TestHbookSvcObjects(t *testing.T) {
+	fname := "hist-objs.rio"
+	defer os.Remove(fname)
+
+	app := newapp(nentries, 2)
+	for i := 0; i < nhists; i++ {
+		stream := "/my-hist"
+		if i%2 == 0 {
+			stream = "" // in-memory temporary hist.
+		}
+		app.Create(job.C{
+			Type: "go-hep.org/x/hep/fwk/hbooksvc.testhsvc",
+			Name: fmt.Sprintf("t%03d", i),
+			Props: job.P{
+				"Stream": stream,
+			},
+		})
+	}
+
+	svc := app.Create(job.C{
+		Type: "go-hep.org/x/hep/fwk/hbooksvc.hsvc",
+		Name: "histsvc",
+		Props: job.P{
+			"Streams": map[string]Stream{
+				"/my-hist": {
+					Name: fname,
+					Mode: Write,
+				},
+			},
+		},
+	})
+
+	app.Run()
+
+	objs, err := Objects(svc.(fwk.HistSvc))
+	if err != nil {
+		t.Fatalf("could not retrieve objects: %+v", err)
+	}
+
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.Name())
+		if got, want := obj.(*hbook.H1D).Entries(), int64(nentries); got != want {
+			t.Fatalf("invalid number of entries for %q: got=%d, want=%d", obj.Name(), got, want)
+		}
+	}
+
+	want := []string{
+		"my-hist/h1d-t001",
+		"my-hist/h1d-t003",
+		"my-hist/h1d-t005",
+		"my-hist/h1d-t007",
+		"my-hist/h1d-t009",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid objects:\ngot= %q\nwant=%q", names, want)
+	}
+}
+
 func TestHbookStreamName(t *testing.T) {
 	var svc hsvc
 	for _, test := range []struct {
@@ -9,6 +9,8 @@
 //
 //  $> root-diff ./ref.root ./chk.root
 //  $> root-diff -k=key1,tree,my-tree ./ref.root ./chk.root
+//  $> root-diff -s -rel=1e-6 ./ref.root ./chk.root
+//  $> root-diff -json ./ref.root ./chk.root
 //
 //  $> root-diff -h
 //  Usage: root-diff [options] a.root b.root
@@ -17,8 +19,15 @@
 //   $> root-diff ./testdata/small-flat-tree.root ./testdata/small-flat-tree.root
 //
 //  options:
+//    -abs float
+//      	absolute tolerance on the bin contents of histograms (structural diff only)
+//    -json
+//      	write the structural diff report in JSON (implies -s)
 //    -k string
 //      	comma-separated list of keys to inspect and compare (default=all common keys)
+//    -rel float
+//      	relative tolerance on the bin contents of histograms (structural diff only)
+//    -s	compare the structure and the statistical content of the files
 //
 package main // import "go-hep.org/x/hep/groot/cmd/root-diff"
 
@@ -36,7 +45,13 @@ import (
 )
 
 func main() {
-	keysFlag := flag.String("k", "", "comma-separated list of keys to inspect and compare (default=all common keys)")
+	var (
+		keysFlag = flag.String("k", "", "comma-separated list of keys to inspect and compare (default=all common keys)")
+		statFlag = flag.Bool("s", false, "compare the structure and the statistical content of the files")
+		jsonFlag = flag.Bool("json", false, "write the structural diff report in JSON (implies -s)")
+		absFlag  = flag.Float64("abs", 0, "absolute tolerance on the bin contents of histograms (structural diff only)")
+		relFlag  = flag.Float64("rel", 0, "relative tolerance on the bin contents of histograms (structural diff only)")
+	)
 
 	log.SetPrefix("root-diff: ")
 	log.SetFlags(0)
@@ -60,7 +75,13 @@ options:
 		log.Fatalf("need 2 input ROOT files to compare")
 	}
 
-	err := rootdiff(flag.Arg(0), flag.Arg(1), *keysFlag)
+	var err error
+	switch {
+	case *statFlag || *jsonFlag:
+		err = rootdiffStat(flag.Arg(0), flag.Arg(1), *keysFlag, *jsonFlag, *absFlag, *relFlag)
+	default:
+		err = rootdiff(flag.Arg(0), flag.Arg(1), *keysFlag)
+	}
 	if err != nil {
 		log.Fatalf("%+v", err)
 	}
@@ -91,3 +112,27 @@ func rootdiff(ref, chk string, keysFlag string) error {
 
 	return nil
 }
+
+func rootdiffStat(ref, chk string, keysFlag string, json bool, abs, rel float64) error {
+	fref, err := groot.Open(ref)
+	if err != nil {
+		return fmt.Errorf("could not open reference file: %w", err)
+	}
+	defer fref.Close()
+
+	fchk, err := groot.Open(chk)
+	if err != nil {
+		return fmt.Errorf("could not open check file: %w", err)
+	}
+	defer fchk.Close()
+
+	opts := []rcmd.DiffOption{
+		rcmd.DiffJSON(json),
+		rcmd.DiffTolerance(abs, rel),
+	}
+	if keysFlag != "" {
+		opts = append(opts, rcmd.DiffKeys(strings.Split(keysFlag, ",")...))
+	}
+
+	return rcmd.DiffStat(os.Stdout, fref, fchk, opts...)
+}
@@ -13,3 +13,16 @@ func TestROOTDiff(t *testing.T) {
 		t.Fatalf("%+v", err)
 	}
 }
+
+func TestROOTDiffStat(t *testing.T) {
+	const (
+		allkeys = ""
+		json    = true
+		abs     = 0
+		rel     = 0
+	)
+	err := rootdiffStat("../../testdata/small-flat-tree.root", "../../testdata/small-flat-tree.root", allkeys, json, abs, rel)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
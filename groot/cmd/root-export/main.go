@@ -0,0 +1,151 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-export exports the content of a ROOT tree to CSV, JSON lines or
+// an Arrow IPC stream.
+//
+// Entries are streamed from the input tree to the output file, so trees
+// larger than the available memory can be exported.
+// A subset of the branches and of the entries of the tree can be selected,
+// with a list of branch names, a filter expression and a range of entries.
+//
+// CSV can only hold scalar values: when no branch is explicitly selected,
+// the branches holding arrays, slices or structs are ignored.
+//
+// Usage: root-export [options] file.root
+//
+// ex:
+//
+//  $> root-export -t tree -b Int32,Str -sel "Int32 > 95" ./testdata/small-flat-tree.root
+//  Int32,Str
+//  96,evt-096
+//  97,evt-097
+//  98,evt-098
+//  99,evt-099
+//
+//  $> root-export -fmt jsonl -b Int32,SliceInt32 -beg 1 -end 3 ./testdata/small-flat-tree.root
+//  {"Int32":1,"SliceInt32":[1]}
+//  {"Int32":2,"SliceInt32":[2,2]}
+//
+//  $> root-export -fmt arrow -o out.arrow ./testdata/small-flat-tree.root
+//
+// options:
+//   -b string
+//     	comma-separated list of branches to export (default: all branches)
+//   -beg int
+//     	first entry to export
+//   -end int
+//     	last entry (excluded) to export (-1: up to the end of the tree) (default -1)
+//   -fmt string
+//     	output format (csv, jsonl, arrow) (default "csv")
+//   -o string
+//     	path to output file (default: stdout)
+//   -sel string
+//     	filter expression entries must pass to be exported
+//   -t string
+//     	name of the tree to export (default "tree")
+package main // import "go-hep.org/x/hep/groot/cmd/root-export"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-export: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-export", flag.ContinueOnError)
+
+		oname  = fset.String("o", "", "path to output file (default: stdout)")
+		tname  = fset.String("t", "tree", "name of the tree to export")
+		format = fset.String("fmt", "csv", "output format (csv, jsonl, arrow)")
+		bnames = fset.String("b", "", "comma-separated list of branches to export (default: all branches)")
+		filter = fset.String("sel", "", "filter expression entries must pass to be exported")
+		beg    = fset.Int64("beg", 0, "first entry to export")
+		end    = fset.Int64("end", -1, "last entry (excluded) to export (-1: up to the end of the tree)")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-export [options] file.root
+
+ex:
+ $> root-export -t tree -b Int32,Str -sel "Int32 > 95" ./testdata/small-flat-tree.root
+ $> root-export -fmt jsonl -b Int32,SliceInt32 -beg 1 -end 3 ./testdata/small-flat-tree.root
+ $> root-export -fmt arrow -o out.arrow ./testdata/small-flat-tree.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 1 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	opts := []rcmd.ExportOption{
+		rcmd.ExportFilter(*filter),
+		rcmd.ExportRange(*beg, *end),
+	}
+	if *bnames != "" {
+		opts = append(opts, rcmd.ExportBranches(strings.Split(*bnames, ",")...))
+	}
+
+	out := stdout
+	if *oname != "" {
+		f, err := os.Create(*oname)
+		if err != nil {
+			log.Printf("could not create output file: %+v", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	err = rcmd.Export(out, fset.Arg(0), *tname, rcmd.ExportFormat(*format), opts...)
+	if err != nil {
+		log.Printf("could not export ROOT tree: %+v", err)
+		return 1
+	}
+
+	if f, ok := out.(*os.File); ok && *oname != "" {
+		err = f.Close()
+		if err != nil {
+			log.Printf("could not close output file: %+v", err)
+			return 1
+		}
+	}
+
+	return 0
+}
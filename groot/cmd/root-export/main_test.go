@@ -0,0 +1,95 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTExport(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-export-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		rc   int
+		want string
+	}{
+		{
+			name: "csv",
+			args: []string{"-b=Int32,Str", "-sel=Int32 > 95", fname},
+			want: "Int32,Str\n96,evt-096\n97,evt-097\n98,evt-098\n99,evt-099\n",
+		},
+		{
+			name: "jsonl",
+			args: []string{"-fmt=jsonl", "-b=Int32,SliceInt32", "-beg=1", "-end=3", fname},
+			want: "{\"Int32\":1,\"SliceInt32\":[1]}\n{\"Int32\":2,\"SliceInt32\":[2,2]}\n",
+		},
+		{
+			name: "arrow",
+			args: []string{"-fmt=arrow", "-o=" + filepath.Join(tmp, "out.arrow"), fname},
+		},
+		{
+			name: "invalid-format",
+			args: []string{"-fmt=xml", fname},
+			rc:   1,
+		},
+		{
+			name: "no-tree",
+			args: []string{"-t=not-there", fname},
+			rc:   1,
+		},
+		{
+			name: "no-file",
+			args: []string{},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-export: got=%d, want=%d\n%s",
+					rc, tc.rc, errs.String(),
+				)
+			}
+			if rc != 0 || tc.want == "" {
+				return
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+
+	fi, err := os.Stat(filepath.Join(tmp, "out.arrow"))
+	if err != nil {
+		t.Fatalf("could not stat Arrow output file: %+v", err)
+	}
+	if fi.Size() == 0 {
+		t.Fatalf("empty Arrow output file")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-flight serves the trees of ROOT files as an Apache Arrow Flight
+// service.
+//
+// root-flight serves the trees of all the ROOT files located under the
+// provided directory (or the current directory.)
+// Clients retrieve the entries of a tree as Arrow record batches using
+// tickets holding a JSON-encoded query, as described in the documentation
+// of the go-hep.org/x/hep/groot/rflight package.
+//
+// Usage: root-flight [options] [dir]
+//
+// ex:
+//
+//  $> root-flight -addr=:8815 ./testdata
+//
+//  $> python
+//  >>> import json, pyarrow.flight as flight
+//  >>> client = flight.connect("grpc://localhost:8815")
+//  >>> query = {"file": "small-flat-tree.root", "tree": "tree", "filter": "Int32 > 10"}
+//  >>> client.do_get(flight.Ticket(json.dumps(query))).read_all()
+//
+// options:
+//   -addr string
+//     	address to listen on (default ":8815")
+//   -chunk int
+//     	maximum number of entries per record batch (default 4096)
+package main // import "go-hep.org/x/hep/groot/cmd/root-flight"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+
+	"go-hep.org/x/hep/groot/rflight"
+	_ "go-hep.org/x/hep/groot/ztypes"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	log.SetPrefix("root-flight: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-flight", flag.ContinueOnError)
+
+		addr  = fset.String("addr", ":8815", "address to listen on")
+		chunk = fset.Int64("chunk", 4096, "maximum number of entries per record batch")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-flight [options] [dir]
+
+ex:
+ $> root-flight -addr=:8815 ./testdata
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	dir := "."
+	switch fset.NArg() {
+	case 0:
+		// ok.
+	case 1:
+		dir = fset.Arg(0)
+	default:
+		fmt.Fprintf(stderr, "error: you need to give at most one directory\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	fi, err := os.Stat(dir)
+	if err != nil {
+		log.Printf("could not stat directory %q: %+v", dir, err)
+		return 1
+	}
+	if !fi.IsDir() {
+		log.Printf("%q is not a directory", dir)
+		return 1
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Printf("could not listen on %q: %+v", *addr, err)
+		return 1
+	}
+	defer lis.Close()
+
+	srv := grpc.NewServer()
+	rflight.NewServer(dir, rflight.WithChunk(*chunk)).Register(srv)
+
+	fmt.Fprintf(stdout, "serving ROOT trees under %q on %s...\n", dir, lis.Addr())
+	err = srv.Serve(lis)
+	if err != nil {
+		log.Printf("could not serve: %+v", err)
+		return 1
+	}
+
+	return 0
+}
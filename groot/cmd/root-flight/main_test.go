@@ -0,0 +1,45 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func TestROOTFlightErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "invalid-flag",
+			args: []string{"-not-a-flag"},
+		},
+		{
+			name: "too-many-dirs",
+			args: []string{"../../testdata", "../../rtree"},
+		},
+		{
+			name: "no-such-dir",
+			args: []string{"./not-there"},
+		},
+		{
+			name: "not-a-dir",
+			args: []string{"../../testdata/small-flat-tree.root"},
+		},
+		{
+			name: "invalid-addr",
+			args: []string{"-addr=not-an-address", "../../testdata"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rc := run(io.Discard, io.Discard, tc.args)
+			if rc != 1 {
+				t.Fatalf("invalid return code: got=%d, want=1", rc)
+			}
+		})
+	}
+}
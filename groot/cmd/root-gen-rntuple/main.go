@@ -0,0 +1,163 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-gen-rntuple converts a ROOT TTree into an RNTuple.
+//
+// The RNTuple is created in the same directory than the converted tree,
+// either in the input file or in a new output file.
+// Variable-length arrays and std::vector branches are converted to
+// std::vector fields.
+//
+// Usage: root-gen-rntuple [options] file.root
+//
+// ex:
+//
+//  $> root-gen-rntuple -t tree -o out.root ./testdata/small-flat-tree.root
+//  $> root-gen-rntuple -t dir/tree -n ntuple ./file.root
+//
+// options:
+//   -n string
+//     	name of the output RNTuple (default: name of the tree, with a "_rntuple" suffix when converting in place)
+//   -o string
+//     	path to the output ROOT file (default: convert in place)
+//   -t string
+//     	path to the tree to convert (default "tree")
+package main // import "go-hep.org/x/hep/groot/cmd/root-gen-rntuple"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	stdpath "path"
+	"strings"
+
+	"go-hep.org/x/hep/groot/exp/rntup"
+	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/groot/rtree"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-gen-rntuple: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-gen-rntuple", flag.ContinueOnError)
+
+		tname = fset.String("t", "tree", "path to the tree to convert")
+		oname = fset.String("o", "", "path to the output ROOT file (default: convert in place)")
+		nname = fset.String("n", "", `name of the output RNTuple (default: name of the tree, with a "_rntuple" suffix when converting in place)`)
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-gen-rntuple [options] file.root
+
+ex:
+ $> root-gen-rntuple -t tree -o out.root ./testdata/small-flat-tree.root
+ $> root-gen-rntuple -t dir/tree -n ntuple ./file.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 1 {
+		fmt.Fprintf(stderr, "error: you need to give an input ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	err = process(stdout, fset.Arg(0), *oname, *tname, *nname)
+	if err != nil {
+		log.Printf("could not convert tree: %+v", err)
+		return 1
+	}
+
+	return 0
+}
+
+func process(w io.Writer, fname, oname, tname, nname string) error {
+	tname = strings.Trim(tname, "/")
+	if nname == "" {
+		nname = stdpath.Base(tname)
+		if oname == "" {
+			nname += "_rntuple"
+		}
+	}
+
+	var (
+		f   *riofs.File
+		o   *riofs.File
+		err error
+	)
+	switch oname {
+	case "":
+		f, err = riofs.Update(fname)
+		if err != nil {
+			return fmt.Errorf("could not open input file: %w", err)
+		}
+		defer f.Close()
+		o = f
+	default:
+		f, err = riofs.Open(fname)
+		if err != nil {
+			return fmt.Errorf("could not open input file: %w", err)
+		}
+		defer f.Close()
+
+		o, err = riofs.Create(oname)
+		if err != nil {
+			return fmt.Errorf("could not create output file: %w", err)
+		}
+		defer o.Close()
+	}
+
+	tree, err := riofs.Get[rtree.Tree](f, tname)
+	if err != nil {
+		return fmt.Errorf("could not retrieve tree %q: %w", tname, err)
+	}
+
+	var dir riofs.Directory = o
+	if path := stdpath.Dir(tname); path != "." {
+		dir, err = riofs.Dir(o).Mkdir(path)
+		if err != nil {
+			return fmt.Errorf("could not create directory %q: %w", path, err)
+		}
+	}
+
+	n, err := rntup.FromTree(dir, nname, tree)
+	if err != nil {
+		return fmt.Errorf("could not convert tree %q: %w", tname, err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+
+	fmt.Fprintf(w, "converted %d entries of tree %q into ntuple %q\n", n, tname, nname)
+	return nil
+}
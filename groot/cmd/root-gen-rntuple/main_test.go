@@ -0,0 +1,119 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/exp/rntup"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestROOTGenRNTuple(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-gen-rntuple-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/small-flat-tree.root"
+	oname := filepath.Join(tmp, "out.root")
+
+	// copy input file, to test in-place conversion.
+	iname := filepath.Join(tmp, "in.root")
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read input file: %+v", err)
+	}
+	err = os.WriteFile(iname, raw, 0644)
+	if err != nil {
+		t.Fatalf("could not copy input file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		args  []string
+		rc    int
+		fname string
+		want  string // path to the expected ntuple
+	}{
+		{
+			name:  "new-file",
+			args:  []string{"-o", oname, fname},
+			fname: oname,
+			want:  "tree",
+		},
+		{
+			name:  "new-file-named",
+			args:  []string{"-o", oname, "-n", "nt", fname},
+			fname: oname,
+			want:  "nt",
+		},
+		{
+			name:  "in-place",
+			args:  []string{iname},
+			fname: iname,
+			want:  "tree_rntuple",
+		},
+		{
+			name: "no-tree",
+			args: []string{"-t", "not-there", "-o", oname, fname},
+			rc:   1,
+		},
+		{
+			name: "no-file",
+			args: []string{"-o", oname, "not-there.root"},
+			rc:   1,
+		},
+		{
+			name: "no-input",
+			args: []string{},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-gen-rntuple: got=%d, want=%d\n%s",
+					rc, tc.rc, errs.String(),
+				)
+			}
+			if rc != 0 || tc.want == "" {
+				return
+			}
+
+			f, err := groot.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			nt, err := riofs.Get[*rntup.NTuple](f, tc.want)
+			if err != nil {
+				t.Fatalf("could not retrieve ntuple: %+v", err)
+			}
+
+			if got, want := nt.Entries(), int64(100); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
@@ -38,6 +38,38 @@
 //    SliceFloat32 "SliceFloat32[N]/F"  TBranch
 //    SliceFloat64 "SliceFloat64[N]/D"  TBranch
 //
+// The -sizes flag displays the size of each key (before compression and
+// on disk) and the size of the baskets of each tree and branch (before and
+// after compression.)
+// The -json flag displays the same information as a JSON document, suitable
+// for auditing the storage usage of large files.
+//
+//  $> root-ls -json ./testdata/small-flat-tree.root
+//  {
+//    "file": "./testdata/small-flat-tree.root",
+//    "version": 60806,
+//    "keys": [
+//      {
+//        "name": "tree",
+//        "title": "my tree title",
+//        "class": "TTree",
+//        "cycle": 1,
+//        "obj-len": 10488,
+//        "nbytes": 1908,
+//        "tree": {
+//          "entries": 100,
+//          "tot-bytes": 61368,
+//          "zip-bytes": 8544,
+//          "branches": [
+//            {
+//              "name": "Int32",
+//              "title": "Int32/I",
+//              "class": "TBranch",
+//              "tot-bytes": 472,
+//              "zip-bytes": 244
+//            },
+//  [...]
+//
 package main // import "go-hep.org/x/hep/groot/cmd/root-ls"
 
 import (
@@ -61,6 +93,8 @@ var (
 
 	siFlag   = fset.Bool("sinfos", false, "print StreamerInfos")
 	treeFlag = fset.Bool("t", false, "print Tree(s) (recursively)")
+	sizeFlag = fset.Bool("sizes", false, "print the sizes of keys, trees and branches")
+	jsonFlag = fset.Bool("json", false, "print the content of the file(s) as JSON")
 	cpuFlag  = fset.String("cpu-profile", "", "path to CPU profile output file")
 
 	usage = `Usage: root-ls [options] file1.root [file2.root [...]]
@@ -68,6 +102,8 @@ var (
 ex:
  $> root-ls ./testdata/graphs.root
  $> root-ls -t -sinfos ./testdata/graphs.root
+ $> root-ls -t -sizes ./testdata/small-flat-tree.root
+ $> root-ls -json ./testdata/small-flat-tree.root
 
 options:
 `
@@ -116,10 +152,12 @@ func run(stdout, stderr io.Writer, args []string) int {
 	opts := []rcmd.ListOption{
 		rcmd.ListStreamers(*siFlag),
 		rcmd.ListTrees(*treeFlag),
+		rcmd.ListSizes(*sizeFlag),
+		rcmd.ListJSON(*jsonFlag),
 	}
 
 	for ii, fname := range fset.Args() {
-		if ii > 0 {
+		if ii > 0 && !*jsonFlag {
 			fmt.Fprintf(out, "\n")
 		}
 		err := rcmd.List(out, fname, opts...)
@@ -10,6 +10,11 @@
 //  $> root-merge -o out.root ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
 //
 // options:
+//   -c string
+//     	compression algorithm and level of the output ROOT file (e.g. zlib:6, lz4:1, none)
+//   -j int
+//     	number of input ROOT files to read concurrently (default 1)
+//   -k	skip input files and objects that can not be read or merged
 //   -o string
 //     	path to merged output ROOT file (default "out.root")
 //   -v	enable verbose mode
@@ -21,8 +26,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 )
@@ -34,6 +42,9 @@ func main() {
 	var (
 		oname   = flag.String("o", "out.root", "path to merged output ROOT file")
 		verbose = flag.Bool("v", false, "enable verbose mode")
+		compr   = flag.String("c", "", "compression algorithm and level of the output ROOT file (e.g. zlib:6, lz4:1, none)")
+		nworker = flag.Int("j", 1, "number of input ROOT files to read concurrently")
+		skip    = flag.Bool("k", false, "skip input files and objects that can not be read or merged")
 	)
 
 	flag.Usage = func() {
@@ -59,8 +70,51 @@ options:
 
 	fnames := flag.Args()
 
-	err := rcmd.Merge(*oname, fnames, *verbose)
+	opts := []rcmd.MergeOption{
+		rcmd.MergeWorkers(*nworker),
+		rcmd.MergeSkipErrors(*skip),
+	}
+	if *compr != "" {
+		alg, lvl, err := parseCompression(*compr)
+		if err != nil {
+			log.Fatalf("invalid compression settings: %+v", err)
+		}
+		opts = append(opts, rcmd.MergeCompression(alg, lvl))
+	}
+
+	err := rcmd.Merge(*oname, fnames, *verbose, opts...)
 	if err != nil {
 		log.Fatalf("could not merge ROOT files: %+v", err)
 	}
 }
+
+// parseCompression parses compression settings of the form "alg[:level]".
+func parseCompression(v string) (riofs.CompressionAlgorithm, int, error) {
+	name, level, _ := strings.Cut(v, ":")
+
+	var alg riofs.CompressionAlgorithm
+	switch strings.ToLower(name) {
+	case "none":
+		return riofs.NoCompression, 0, nil
+	case "zlib":
+		alg = riofs.ZLIB
+	case "lzma":
+		alg = riofs.LZMA
+	case "lz4":
+		alg = riofs.LZ4
+	case "zstd":
+		alg = riofs.ZSTD
+	default:
+		return 0, 0, fmt.Errorf("unknown compression algorithm %q", name)
+	}
+
+	lvl := 1
+	if level != "" {
+		var err error
+		lvl, err = strconv.Atoi(level)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid compression level %q: %w", level, err)
+		}
+	}
+	return alg, lvl, nil
+}
@@ -4,12 +4,19 @@
 
 // root-print prints ROOT files contents to PDF, PNG, ... files.
 //
+// root-print walks the whole input ROOT files, or the objects selected by
+// a regular expression (or, with -glob, by a glob pattern) given after the
+// file name, and renders every histogram and graph into the output directory.
+// With -glob, selecting a directory selects all the objects it contains.
+//
 // Examples:
 //
 //  $> root-print -f pdf ./testdata/histos.root
 //  $> root-print -f pdf ./testdata/histos.root:h1
 //  $> root-print -f pdf ./testdata/histos.root:h.*
 //  $> root-print -f pdf -o output ./testdata/histos.root:h1
+//  $> root-print -f png -glob -o output ./testdata/histos.root:dir/h*
+//  $> root-print -f png -width 10cm -height 10cm -norm area ./testdata/histos.root
 //
 //  $> root-print -h
 //  Usage: root-print [options] file.root [file.root [...]]
@@ -17,9 +24,17 @@
 //  options:
 //    -f string
 //      	output format for plots (pdf, png, svg, ...) (default "pdf")
+//    -glob
+//      	interpret object selections as glob patterns
+//    -height string
+//      	height of the plots (e.g. 10cm, 4in) (default: derived from the width)
+//    -norm string
+//      	normalization of histograms (none, area, max) (default "none")
 //    -o string
 //      	output directory for plots
 //    -v	enable verbose mode
+//    -width string
+//      	width of the plots (e.g. 20cm, 8in) (default "20cm")
 //
 package main // import "go-hep.org/x/hep/groot/cmd/root-print"
 
@@ -27,11 +42,13 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	stdpath "path"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
@@ -39,8 +56,10 @@ import (
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
 	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
 )
@@ -57,6 +76,10 @@ func main() {
 		odirFlag    = flag.String("o", "", "output directory for plots")
 		fmtFlag     = flag.String("f", "pdf", "output format for plots (pdf, png, svg, ...)")
 		verboseFlag = flag.Bool("v", false, "enable verbose mode")
+		globFlag    = flag.Bool("glob", false, "interpret object selections as glob patterns")
+		widthFlag   = flag.String("width", "20cm", "width of the plots (e.g. 20cm, 8in)")
+		heightFlag  = flag.String("height", "", "height of the plots (e.g. 10cm, 4in) (default: derived from the width)")
+		normFlag    = flag.String("norm", "none", "normalization of histograms (none, area, max)")
 	)
 
 	flag.Usage = func() {
@@ -68,6 +91,8 @@ ex:
  $> root-print -f pdf ./testdata/histos.root:h1
  $> root-print -f pdf ./testdata/histos.root:h.*
  $> root-print -f pdf -o output ./testdata/histos.root:h1
+ $> root-print -f png -glob -o output ./testdata/histos.root:dir/h*
+ $> root-print -f png -width 10cm -height 10cm -norm area ./testdata/histos.root
 
 options:
 `,
@@ -82,20 +107,97 @@ options:
 		log.Fatalf("need at least 1 input ROOT file")
 	}
 
-	err := rootprint(*odirFlag, flag.Args(), *fmtFlag, *verboseFlag)
+	width, err := vg.ParseLength(*widthFlag)
+	if err != nil {
+		log.Fatalf("invalid plot width %q: %+v", *widthFlag, err)
+	}
+
+	height := vg.Length(-1)
+	if *heightFlag != "" {
+		height, err = vg.ParseLength(*heightFlag)
+		if err != nil {
+			log.Fatalf("invalid plot height %q: %+v", *heightFlag, err)
+		}
+	}
+
+	err = rootprint(
+		*odirFlag, flag.Args(), *fmtFlag, *verboseFlag,
+		withGlob(*globFlag),
+		withSize(width, height),
+		withNorm(*normFlag),
+	)
 	if err != nil {
 		log.Fatalf("%+v", err)
 	}
 }
 
-func rootprint(odir string, fnames []string, otype string, verbose bool) error {
+type printer struct {
+	odir    string
+	otyp    string
+	verbose bool
+
+	glob   bool      // whether object selections are glob patterns
+	width  vg.Length // width of the plots
+	height vg.Length // height of the plots
+	norm   string    // normalization of histograms
+}
+
+type option func(pr *printer)
+
+// withGlob configures whether object selections are interpreted as
+// glob patterns instead of regular expressions.
+func withGlob(v bool) option {
+	return func(pr *printer) {
+		pr.glob = v
+	}
+}
+
+// withSize sets the dimensions of the plots.
+// A negative height derives the height of the plots from their width.
+func withSize(w, h vg.Length) option {
+	return func(pr *printer) {
+		pr.width = w
+		pr.height = h
+	}
+}
+
+// withNorm sets the normalization of histograms:
+//   - "none": histograms are plotted as is,
+//   - "area": histograms are scaled to a unit integral,
+//   - "max": histograms are scaled so their highest bin has a unit height.
+func withNorm(norm string) option {
+	return func(pr *printer) {
+		pr.norm = norm
+	}
+}
+
+func rootprint(odir string, fnames []string, otype string, verbose bool, opts ...option) error {
+	pr := printer{
+		odir:    odir,
+		otyp:    otype,
+		verbose: verbose,
+		width:   20 * vg.Centimeter,
+		height:  -1,
+		norm:    "none",
+	}
+	for _, opt := range opts {
+		opt(&pr)
+	}
+
+	switch pr.norm {
+	case "none", "area", "max":
+		// ok.
+	default:
+		return fmt.Errorf("invalid normalization %q", pr.norm)
+	}
+
 	err := os.MkdirAll(odir, 0755)
 	if err != nil {
 		return fmt.Errorf("could not create output directory %q: %w", odir, err)
 	}
 
 	for _, fname := range fnames {
-		err := process(odir, fname, otype, verbose)
+		err := pr.process(fname)
 		if err != nil {
 			return fmt.Errorf("could not process %q: %w", fname, err)
 		}
@@ -104,8 +206,8 @@ func rootprint(odir string, fnames []string, otype string, verbose bool) error {
 	return nil
 }
 
-func process(odir, name, otyp string, verbose bool) error {
-	fname, hname, err := splitArg(name)
+func (pr *printer) process(name string) error {
+	fname, sel, err := splitArg(name)
 	if err != nil {
 		return fmt.Errorf(
 			"invalid input file format. got %q. want: \"file.root:histo\"",
@@ -113,16 +215,16 @@ func process(odir, name, otyp string, verbose bool) error {
 		)
 	}
 
-	f, err := groot.Open(fname)
+	match, err := newMatcher(sel, pr.glob)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	re, err := regexp.CompilePOSIX(hname)
+	f, err := groot.Open(fname)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	var objs []root.Object
 	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
@@ -133,7 +235,7 @@ func process(odir, name, otyp string, verbose bool) error {
 		if name == "" {
 			return nil
 		}
-		if !re.MatchString(name) {
+		if !match(name) {
 			return nil
 		}
 
@@ -149,7 +251,7 @@ func process(odir, name, otyp string, verbose bool) error {
 	}
 
 	for _, obj := range objs {
-		err := printObject(odir, otyp, obj, verbose)
+		err := pr.printObject(obj)
 		if err != nil {
 			return err
 		}
@@ -157,7 +259,7 @@ func process(odir, name, otyp string, verbose bool) error {
 	return err
 }
 
-func printObject(odir, otyp string, obj root.Object, verbose bool) error {
+func (pr *printer) printObject(obj root.Object) error {
 	p := hplot.New()
 	name := obj.(root.Named).Name()
 	title := obj.(root.Named).Title()
@@ -166,18 +268,26 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 	}
 	p.Title.Text = title
 
-	oname := stdpath.Join(odir, name+"."+otyp)
-	if verbose {
+	oname := stdpath.Join(pr.odir, name+"."+pr.otyp)
+	if pr.verbose {
 		log.Printf("printing %q to %s...", name, oname)
 	}
 
 	switch o := obj.(type) {
 	case rhist.H2:
 		h := rootcnv.H2D(o)
+		if f := pr.normH2D(h); f != 1 {
+			h.Scale(f)
+		}
 		p.Add(hplot.NewH2D(h, nil))
+		timeTicks(&p.X, o.XAxis())
+		timeTicks(&p.Y, o.YAxis())
 
 	case rhist.H1:
 		h := rootcnv.H1D(o)
+		if f := pr.normH1D(h); f != 1 {
+			h.Scale(f)
+		}
 		hh := hplot.NewH1D(h)
 		hh.Color = colors[2]
 		hh.LineStyle.Color = colors[2]
@@ -185,6 +295,7 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 		hh.Infos.Style = hplot.HInfoSummary
 
 		p.Add(hh)
+		timeTicks(&p.X, o.XAxis())
 
 	case rhist.GraphErrors:
 		h := rootcnv.S2D(o)
@@ -194,6 +305,8 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 		g := hplot.NewS2D(h, hplot.WithXErrBars(true), hplot.WithYErrBars(true))
 		g.Color = colors[0]
 		p.Add(g)
+		timeTicks(&p.X, o.XAxis())
+		timeTicks(&p.Y, o.YAxis())
 
 	case rhist.Graph:
 		h := rootcnv.S2D(o)
@@ -203,6 +316,8 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 		g := hplot.NewS2D(h)
 		g.Color = colors[0]
 		p.Add(g)
+		timeTicks(&p.X, o.XAxis())
+		timeTicks(&p.Y, o.YAxis())
 
 	default:
 		return fmt.Errorf("unknown type %T for %q", o, name)
@@ -215,7 +330,7 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 	//		ext = ext[1:]
 	//	}
 
-	err := p.Save(20*vg.Centimeter, -1, oname)
+	err := p.Save(pr.width, pr.height, oname)
 	if err != nil {
 		return fmt.Errorf("could not print %q to %q: %w", name, oname, err)
 	}
@@ -223,6 +338,58 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 	return nil
 }
 
+// normH1D returns the factor to scale the provided histogram with,
+// according to the requested normalization.
+func (pr *printer) normH1D(h *hbook.H1D) float64 {
+	var v float64
+	switch pr.norm {
+	case "area":
+		v = h.Integral()
+	case "max":
+		for _, bin := range h.Binning.Bins {
+			v = math.Max(v, bin.SumW())
+		}
+	}
+	if v == 0 {
+		return 1
+	}
+	return 1 / v
+}
+
+// normH2D returns the factor to scale the provided histogram with,
+// according to the requested normalization.
+func (pr *printer) normH2D(h *hbook.H2D) float64 {
+	var v float64
+	switch pr.norm {
+	case "area":
+		v = h.Integral()
+	case "max":
+		for _, bin := range h.Binning.Bins {
+			v = math.Max(v, bin.SumW())
+		}
+	}
+	if v == 0 {
+		return 1
+	}
+	return 1 / v
+}
+
+// timeTicks displays the ticks of the plot axis as dates, if the ROOT
+// axis displays time values.
+func timeTicks(p *plot.Axis, axis rhist.Axis) {
+	layout, offset, ok := rhist.AxisTime(axis)
+	if !ok {
+		return
+	}
+	p.Tick.Marker = plot.TimeTicks{
+		Ticker: hplot.Ticks{N: 5},
+		Format: layout,
+		Time: func(v float64) time.Time {
+			return offset.Add(time.Duration(v * float64(time.Second))).UTC()
+		},
+	}
+}
+
 func filter(obj root.Object) bool {
 	switch obj.(type) {
 	case rhist.Graph, rhist.GraphErrors:
@@ -240,7 +407,7 @@ func filter(obj root.Object) bool {
 func splitArg(cmd string) (fname, sel string, err error) {
 	fname = cmd
 	prefix := ""
-	for _, p := range []string{"https://", "http://", "root://", "file://"} {
+	for _, p := range []string{"https://", "http://", "root://", "roots://", "file://"} {
 		if strings.HasPrefix(cmd, p) {
 			prefix = p
 			break
@@ -258,18 +425,45 @@ func splitArg(cmd string) (fname, sel string, err error) {
 	}
 
 	i := strings.LastIndex(fname, ":")
-	switch {
-	case i > 0:
+	if i > 0 {
 		sel = fname[i+1:]
 		fname = fname[:i]
-	default:
-		sel = ".*"
-	}
-	if sel == "" {
-		sel = ".*"
 	}
 	fname = prefix + vol + fname
+	return fname, sel, err
+}
+
+// newMatcher returns a function reporting whether the path of an object
+// within a ROOT file matches the provided selection.
+//
+// Selections are regular expressions, unless glob is true.
+// Glob patterns select the objects whose path, or the path of one of their
+// parent directories, matches the pattern.
+func newMatcher(sel string, glob bool) (func(name string) bool, error) {
+	if glob {
+		if sel == "" {
+			return func(string) bool { return true }, nil
+		}
+		if !strings.HasPrefix(sel, "/") {
+			sel = "/" + sel
+		}
+		_, err := stdpath.Match(sel, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", sel, err)
+		}
+		return func(name string) bool {
+			for ; name != "/" && name != "."; name = stdpath.Dir(name) {
+				if ok, _ := stdpath.Match(sel, name); ok {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+
 	switch {
+	case sel == "":
+		sel = "/.*"
 	case strings.HasPrefix(sel, "/"):
 	case strings.HasPrefix(sel, "^/"):
 	case strings.HasPrefix(sel, "^"):
@@ -277,5 +471,10 @@ func splitArg(cmd string) (fname, sel string, err error) {
 	default:
 		sel = "/" + sel
 	}
-	return fname, sel, err
+
+	re, err := regexp.CompilePOSIX(sel)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
 }
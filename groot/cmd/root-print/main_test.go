@@ -5,6 +5,7 @@
 package main
 
 import (
+	"image/png"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
 	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/vg"
 )
 
 func TestPrint(t *testing.T) {
@@ -110,6 +112,7 @@ func TestPrint(t *testing.T) {
 	for _, tc := range []struct {
 		fname string
 		otype string
+		glob  bool
 		want  []string
 	}{
 		{
@@ -179,9 +182,58 @@ func TestPrint(t *testing.T) {
 			otype: "png",
 			want:  []string{},
 		},
+		{
+			fname: refname + ":*",
+			otype: "png",
+			glob:  true,
+			want: []string{
+				"h00.png",
+				"h111.png",
+				"h121.png",
+				"h21.png",
+				"h22.png",
+				"g22.png",
+				"g23.png",
+			},
+		},
+		{
+			fname: refname + ":dir-1/*",
+			otype: "png",
+			glob:  true,
+			want: []string{
+				"h111.png",
+				"h121.png",
+			},
+		},
+		{
+			fname: refname + ":/dir-2/h2*",
+			otype: "png",
+			glob:  true,
+			want: []string{
+				"h21.png",
+				"h22.png",
+			},
+		},
+		{
+			fname: refname + ":dir-1/*/dir-111",
+			otype: "png",
+			glob:  true,
+			want: []string{
+				"h111.png",
+			},
+		},
+		{
+			fname: refname + ":dir-111",
+			otype: "png",
+			glob:  true,
+			want:  []string{},
+		},
 	} {
 		tname := tc.fname
 		tname = tname[len(dir)+1:]
+		if tc.glob {
+			tname = "glob-" + tname
+		}
 		t.Run(tname, func(t *testing.T) {
 			odir, err := os.MkdirTemp("", "groot-root-print-out-")
 			if err != nil {
@@ -190,7 +242,7 @@ func TestPrint(t *testing.T) {
 			defer os.RemoveAll(odir)
 
 			const verbose = false
-			err = rootprint(odir, []string{tc.fname}, tc.otype, verbose)
+			err = rootprint(odir, []string{tc.fname}, tc.otype, verbose, withGlob(tc.glob))
 			if err != nil {
 				t.Fatalf("%+v", err)
 			}
@@ -231,3 +283,95 @@ func TestPrint(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintOptions(t *testing.T) {
+	dir, err := os.MkdirTemp("", "groot-root-print-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "ref.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer f.Close()
+
+	h1 := hbook.NewH1D(10, 0, 10)
+	h1.Annotation()["name"] = "h1"
+	h1.Fill(2, 2)
+	h1.Fill(5, 6)
+	err = f.Put("h1", rootcnv.FromH1D(h1))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	h2 := hbook.NewH2D(10, 0, 10, 10, 0, 10)
+	h2.Annotation()["name"] = "h2"
+	h2.Fill(2, 1, 4)
+	h2.Fill(5, 5, 4)
+	err = f.Put("h2", rootcnv.FromH2D(h2))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, tc := range []struct {
+		norm string
+		h1   float64
+		h2   float64
+	}{
+		{norm: "none", h1: 1, h2: 1},
+		{norm: "area", h1: 1.0 / 8, h2: 1.0 / 8},
+		{norm: "max", h1: 1.0 / 6, h2: 1.0 / 4},
+	} {
+		t.Run("norm-"+tc.norm, func(t *testing.T) {
+			pr := printer{norm: tc.norm}
+			if got, want := pr.normH1D(h1), tc.h1; got != want {
+				t.Fatalf("invalid h1 normalization: got=%v, want=%v", got, want)
+			}
+			if got, want := pr.normH2D(h2), tc.h2; got != want {
+				t.Fatalf("invalid h2 normalization: got=%v, want=%v", got, want)
+			}
+
+			odir := filepath.Join(dir, "out-"+tc.norm)
+			err := rootprint(
+				odir, []string{fname}, "png", false,
+				withNorm(tc.norm),
+				withSize(10*vg.Centimeter, 5*vg.Centimeter),
+			)
+			if err != nil {
+				t.Fatalf("could not print: %+v", err)
+			}
+
+			for _, name := range []string{"h1.png", "h2.png"} {
+				r, err := os.Open(filepath.Join(odir, name))
+				if err != nil {
+					t.Fatalf("could not open %q: %+v", name, err)
+				}
+				defer r.Close()
+
+				cfg, err := png.DecodeConfig(r)
+				if err != nil {
+					t.Fatalf("could not decode %q: %+v", name, err)
+				}
+				if got, want := cfg.Width, 2*cfg.Height; got != want {
+					t.Fatalf("invalid %q dimensions: got=%dx%d", name, cfg.Width, cfg.Height)
+				}
+			}
+		})
+	}
+
+	err = rootprint(filepath.Join(dir, "out-invalid"), []string{fname}, "png", false, withNorm("not-there"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), `invalid normalization "not-there"`; got != want {
+		t.Fatalf("invalid error: got=%q, want=%q", got, want)
+	}
+}
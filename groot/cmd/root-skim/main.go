@@ -0,0 +1,122 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-skim copies a tree from an input ROOT file into an output ROOT file,
+// keeping only the entries passing a selection expression (skimming) and
+// a subset of the branches of the tree (slimming).
+//
+// The selection expression is described in the documentation of
+// go-hep.org/x/hep/groot/rtree.NewFormula.
+// Branches used in the selection expression do not need to be copied.
+//
+// Usage: root-skim [options] in.root out.root
+//
+// ex:
+//
+//  $> root-skim -where "pt>30 && abs(eta)<2.5" -branches pt,eta,phi in.root out.root
+//  $> root-skim -t tree -where "Int32 > 95" -branches Int32,Str ./testdata/small-flat-tree.root out.root
+//
+// options:
+//   -beg int
+//     	first entry to consider
+//   -branches string
+//     	comma-separated list of branches to copy (default: all branches)
+//   -end int
+//     	last entry (excluded) to consider (-1: up to the end of the tree) (default -1)
+//   -t string
+//     	name of the tree to skim (default "tree")
+//   -v	enable verbose mode
+//   -where string
+//     	selection expression entries must pass to be copied
+package main // import "go-hep.org/x/hep/groot/cmd/root-skim"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-skim: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-skim", flag.ContinueOnError)
+
+		tname   = fset.String("t", "tree", "name of the tree to skim")
+		where   = fset.String("where", "", "selection expression entries must pass to be copied")
+		bnames  = fset.String("branches", "", "comma-separated list of branches to copy (default: all branches)")
+		beg     = fset.Int64("beg", 0, "first entry to consider")
+		end     = fset.Int64("end", -1, "last entry (excluded) to consider (-1: up to the end of the tree)")
+		verbose = fset.Bool("v", false, "enable verbose mode")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-skim [options] in.root out.root
+
+ex:
+ $> root-skim -where "pt>30 && abs(eta)<2.5" -branches pt,eta,phi in.root out.root
+ $> root-skim -t tree -where "Int32 > 95" -branches Int32,Str ./testdata/small-flat-tree.root out.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 2 {
+		fmt.Fprintf(stderr, "error: you need to give an input and an output ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	var (
+		fname = fset.Arg(0)
+		oname = fset.Arg(1)
+		opts  = []rcmd.SkimOption{
+			rcmd.SkimWhere(*where),
+			rcmd.SkimRange(*beg, *end),
+		}
+	)
+	if *bnames != "" {
+		opts = append(opts, rcmd.SkimBranches(strings.Split(*bnames, ",")...))
+	}
+
+	n, err := rcmd.Skim(oname, fname, *tname, opts...)
+	if err != nil {
+		log.Printf("could not skim ROOT file: %+v", err)
+		return 1
+	}
+
+	if *verbose {
+		fmt.Fprintf(stdout, "skimmed %d entries from %q into %q\n", n, fname, oname)
+	}
+
+	return 0
+}
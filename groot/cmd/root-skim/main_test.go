@@ -0,0 +1,84 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTSkim(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-skim-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/small-flat-tree.root"
+	oname := filepath.Join(tmp, "out.root")
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		rc   int
+		want string
+	}{
+		{
+			name: "where-branches",
+			args: []string{"-v", "-where=Int32 > 95", "-branches=Int32,Str", fname, oname},
+			want: "skimmed 4 entries from \"" + fname + "\" into \"" + oname + "\"\n",
+		},
+		{
+			name: "range",
+			args: []string{"-v", "-beg=10", "-end=20", "-branches=Int32", fname, oname},
+			want: "skimmed 10 entries from \"" + fname + "\" into \"" + oname + "\"\n",
+		},
+		{
+			name: "invalid-where",
+			args: []string{"-where=Int32 +", "-branches=Int32", fname, oname},
+			rc:   1,
+		},
+		{
+			name: "no-tree",
+			args: []string{"-t=not-there", fname, oname},
+			rc:   1,
+		},
+		{
+			name: "no-output",
+			args: []string{fname},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-skim: got=%d, want=%d\n%s",
+					rc, tc.rc, errs.String(),
+				)
+			}
+			if rc != 0 || tc.want == "" {
+				return
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
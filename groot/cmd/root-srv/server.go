@@ -47,6 +47,10 @@ func newServer(local bool, dir string, mux *http.ServeMux) *server {
 
 	mux.Handle("/", app.wrap(app.rootHandle))
 	mux.HandleFunc("/ping", app.srv.Ping)
+	mux.HandleFunc("/list-files", app.srv.ListFiles)
+	mux.HandleFunc("/list-dirs", app.srv.Dirent)
+	mux.HandleFunc("/list-tree", app.srv.Tree)
+	mux.HandleFunc("/hist", app.srv.Hist)
 	mux.Handle("/root-file-upload", app.wrap(app.uploadHandle))
 	mux.Handle("/root-file-open", app.wrap(app.openHandle))
 	mux.Handle("/refresh", app.wrap(app.refreshHandle))
@@ -55,6 +59,8 @@ func newServer(local bool, dir string, mux *http.ServeMux) *server {
 	mux.HandleFunc("/plot-h2", app.srv.PlotH2)
 	mux.HandleFunc("/plot-s2", app.srv.PlotS2)
 	mux.HandleFunc("/plot-branch", app.srv.PlotTree)
+	mux.HandleFunc("/stream-tree", app.srv.StreamTree)
+	mux.HandleFunc("/watch-plot", app.srv.WatchPlot)
 
 	return app
 }
@@ -0,0 +1,157 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-stat scans one or many ROOT files and produces a JSON summary of
+// the dataset they form.
+//
+// For each tree, root-stat reports the number of entries, the sum of weights
+// and, for each branch, the range of its values and the rate of null entries
+// (NaNs, empty strings, empty variable-length arrays, ...).
+// Trees with the same name in different files are summed up together.
+//
+// The weights are read from the branch named with the -w flag.
+// If the trees have no such branch, the -w flag names a metadata object
+// stored in each file and holding the sum of weights of that file
+// (a TObjString holding a number, or a histogram.)
+//
+// Usage: root-stat [options] file1.root [file2.root [...]]
+//
+// ex:
+//
+//  $> root-stat ./testdata/small-flat-tree.root
+//  $> root-stat -t tree -w Float64 ./testdata/small-flat-tree.root
+//  {
+//    "files": [
+//      "./testdata/small-flat-tree.root"
+//    ],
+//    "trees": [
+//      {
+//        "name": "tree",
+//        "files": 1,
+//        "entries": 100,
+//        "sumw": 4950,
+//        "weight": "branch:Float64",
+//        "branches": [
+//          {
+//            "name": "Int32",
+//            "type": "int32",
+//            "min": 0,
+//            "max": 99,
+//            "nulls": 0,
+//            "null-rate": 0
+//          },
+//  [...]
+//          {
+//            "name": "SliceFloat64",
+//            "type": "[]float64",
+//            "min": 1,
+//            "max": 99,
+//            "nulls": 10,
+//            "null-rate": 0.1
+//          }
+//        ]
+//      }
+//    ]
+//  }
+//
+// options:
+//   -o string
+//     	path to output JSON file (default: stdout)
+//   -t string
+//     	name of the tree to summarize (default: all trees)
+//   -w string
+//     	name of the weight branch or metadata object (default: unit weights)
+package main // import "go-hep.org/x/hep/groot/cmd/root-stat"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-stat: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-stat", flag.ContinueOnError)
+
+		oname  = fset.String("o", "", "path to output JSON file (default: stdout)")
+		tname  = fset.String("t", "", "name of the tree to summarize (default: all trees)")
+		weight = fset.String("w", "", "name of the weight branch or metadata object (default: unit weights)")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-stat [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-stat ./testdata/small-flat-tree.root
+ $> root-stat -t tree -w Float64 -o stats.json ./testdata/small-flat-tree.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() <= 0 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	out := stdout
+	if *oname != "" {
+		f, err := os.Create(*oname)
+		if err != nil {
+			log.Printf("could not create output file: %+v", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	err = rcmd.Stat(out, fset.Args(),
+		rcmd.StatTree(*tname),
+		rcmd.StatWeight(*weight),
+	)
+	if err != nil {
+		log.Printf("could not summarize ROOT files: %+v", err)
+		return 1
+	}
+
+	if f, ok := out.(*os.File); ok && *oname != "" {
+		err = f.Close()
+		if err != nil {
+			log.Printf("could not close output file: %+v", err)
+			return 1
+		}
+	}
+
+	return 0
+}
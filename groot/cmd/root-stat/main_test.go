@@ -0,0 +1,123 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTStat(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-stat-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	oname := filepath.Join(tmp, "out.json")
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		rc   int
+	}{
+		{
+			name: "flat",
+			args: []string{"../../testdata/small-flat-tree.root"},
+		},
+		{
+			name: "weight",
+			args: []string{"-t=tree", "-w=Float64", "../../testdata/small-flat-tree.root"},
+		},
+		{
+			name: "output",
+			args: []string{"-o=" + oname, "../../testdata/small-flat-tree.root", "../../testdata/x-flat-tree.root"},
+		},
+		{
+			name: "struct",
+			args: []string{"../../testdata/small-evnt-tree-fullsplit.root"},
+		},
+		{
+			name: "no-file",
+			args: []string{},
+			rc:   1,
+		},
+		{
+			name: "not-there",
+			args: []string{filepath.Join(tmp, "not-there.root")},
+			rc:   1,
+		},
+		{
+			name: "no-tree",
+			args: []string{"-t=not-there", "../../testdata/small-flat-tree.root"},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			rc := run(out, out, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-stat: got=%d, want=%d\n%s",
+					rc, tc.rc, out.String(),
+				)
+			}
+			if rc != 0 || tc.name == "help" {
+				return
+			}
+
+			raw := out.Bytes()
+			if tc.name == "output" {
+				raw, err = os.ReadFile(oname)
+				if err != nil {
+					t.Fatalf("could not read output file: %+v", err)
+				}
+			}
+
+			var ds struct {
+				Files []string `json:"files"`
+				Trees []struct {
+					Name    string  `json:"name"`
+					Entries int64   `json:"entries"`
+					SumW    float64 `json:"sumw"`
+				} `json:"trees"`
+			}
+			err := json.Unmarshal(raw, &ds)
+			if err != nil {
+				t.Fatalf("could not decode JSON output: %+v\n%s", err, raw)
+			}
+			if len(ds.Trees) == 0 {
+				t.Fatalf("no tree summary:\n%s", raw)
+			}
+
+			switch tc.name {
+			case "flat":
+				if got, want := ds.Trees[0].SumW, 100.0; got != want {
+					t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+				}
+			case "weight":
+				if got, want := ds.Trees[0].SumW, 4950.0; got != want {
+					t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+				}
+			case "output":
+				if got, want := ds.Trees[0].Entries, int64(110); got != want {
+					t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-strip copies a ROOT file while dropping selected objects and
+// branches, renaming keys and clearing user metadata.
+// root-strip is useful to produce shareable test files out of private data.
+//
+// Objects and top-level branches of trees are selected with path.Match
+// patterns on their path in the input file, e.g. "dir/h*" or "tree/secret".
+//
+// Usage: root-strip [options] in.root out.root
+//
+// ex:
+//
+//  $> root-strip -drop "notes,tree/run*" -rename "dir/tree=events" -anonymize in.root out.root
+//
+// options:
+//   -anonymize
+//     	clear user metadata (titles of keys, trees and named objects)
+//   -drop string
+//     	comma-separated list of patterns of objects and branches to drop
+//   -rename string
+//     	comma-separated list of old=new paths of objects to rename
+package main // import "go-hep.org/x/hep/groot/cmd/root-strip"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-strip: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-strip", flag.ContinueOnError)
+
+		drop   = fset.String("drop", "", "comma-separated list of patterns of objects and branches to drop")
+		rename = fset.String("rename", "", "comma-separated list of old=new paths of objects to rename")
+		anon   = fset.Bool("anonymize", false, "clear user metadata (titles of keys, trees and named objects)")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-strip [options] in.root out.root
+
+ex:
+ $> root-strip -drop "notes,tree/run*" -rename "dir/tree=events" -anonymize in.root out.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 2 {
+		fmt.Fprintf(stderr, "error: you need to give an input and an output ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	opts := []rcmd.StripOption{
+		rcmd.StripMetadata(*anon),
+	}
+	if *drop != "" {
+		opts = append(opts, rcmd.StripDrop(strings.Split(*drop, ",")...))
+	}
+	if *rename != "" {
+		for _, v := range strings.Split(*rename, ",") {
+			old, new, ok := strings.Cut(v, "=")
+			if !ok || old == "" || new == "" {
+				log.Printf("invalid rename argument %q (want old=new)", v)
+				return 1
+			}
+			opts = append(opts, rcmd.StripRename(old, new))
+		}
+	}
+
+	err = rcmd.Strip(fset.Arg(1), fset.Arg(0), opts...)
+	if err != nil {
+		log.Printf("could not strip ROOT file: %+v", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestROOTStrip(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-strip-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/dirs-6.14.00.root"
+	oname := filepath.Join(tmp, "out.root")
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		rc   int
+		want []string // keys expected in the output file
+	}{
+		{
+			name: "drop-rename",
+			args: []string{"-drop=dir2,dir3", "-rename=dir1/dir11/h1=hists/h1", "-anonymize", fname, oname},
+			want: []string{"dir1/dir11", "hists/h1"},
+		},
+		{
+			name: "invalid-rename",
+			args: []string{"-rename=dir1", fname, oname},
+			rc:   1,
+		},
+		{
+			name: "invalid-drop",
+			args: []string{"-drop=[", fname, oname},
+			rc:   1,
+		},
+		{
+			name: "no-output",
+			args: []string{fname},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-strip: got=%d, want=%d\n%s",
+					rc, tc.rc, errs.String(),
+				)
+			}
+			if rc != 0 || len(tc.want) == 0 {
+				return
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			for _, name := range tc.want {
+				_, err := riofs.Dir(f).Get(name)
+				if err != nil {
+					t.Fatalf("could not find %q in output file: %+v", name, err)
+				}
+			}
+			for _, name := range []string{"dir2", "dir3", "dir1/dir11/h1"} {
+				_, err := riofs.Dir(f).Get(name)
+				if err == nil {
+					t.Fatalf("object %q should have been dropped", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-tree2rntuple migrates the classic TTrees of an input ROOT file to
+// RNTuples, stored in an output ROOT file.
+//
+// Each tree is converted into an RNTuple with the same name, in the same
+// directory than the tree.
+// For each tree, root-tree2rntuple displays how its branches are mapped to
+// the fields of the RNTuple.
+// Once all the trees have been converted, root-tree2rntuple re-reads each
+// RNTuple from the output file and checks that its values match the values
+// of the tree it was converted from.
+//
+// Usage: root-tree2rntuple [options] in.root out.root
+//
+// ex:
+//
+//  $> root-tree2rntuple ./testdata/small-flat-tree.root out.root
+//  $> root-tree2rntuple -t dir/tree ./file.root out.root
+//
+// options:
+//   -t string
+//     	path to the tree to convert (default: all the trees of the input file)
+package main // import "go-hep.org/x/hep/groot/cmd/root-tree2rntuple"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	stdpath "path"
+	"strings"
+	"text/tabwriter"
+
+	"go-hep.org/x/hep/groot/exp/rntup"
+	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-tree2rntuple: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-tree2rntuple", flag.ContinueOnError)
+
+		tname = fset.String("t", "", "path to the tree to convert (default: all the trees of the input file)")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-tree2rntuple [options] in.root out.root
+
+ex:
+ $> root-tree2rntuple ./testdata/small-flat-tree.root out.root
+ $> root-tree2rntuple -t dir/tree ./file.root out.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 2 {
+		fmt.Fprintf(stderr, "error: you need to give an input and an output ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	err = process(stdout, fset.Arg(0), fset.Arg(1), *tname)
+	if err != nil {
+		log.Printf("could not migrate trees: %+v", err)
+		return 1
+	}
+
+	return 0
+}
+
+func process(w io.Writer, fname, oname, tname string) error {
+	f, err := riofs.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open input file: %w", err)
+	}
+	defer f.Close()
+
+	trees, err := treesOf(f, strings.Trim(tname, "/"))
+	if err != nil {
+		return err
+	}
+	if len(trees) == 0 {
+		return fmt.Errorf("no tree to convert in %q", fname)
+	}
+
+	o, err := riofs.Create(oname)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+	defer o.Close()
+
+	for _, name := range trees {
+		err := convert(w, f, o, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+
+	v, err := riofs.Open(oname)
+	if err != nil {
+		return fmt.Errorf("could not re-open output file: %w", err)
+	}
+	defer v.Close()
+
+	for _, name := range trees {
+		err := validate(w, f, v, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// treesOf returns the paths to the trees to convert.
+func treesOf(f *riofs.File, tname string) ([]string, error) {
+	if tname != "" {
+		_, err := riofs.Get[rtree.Tree](f, tname)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve tree %q: %w", tname, err)
+		}
+		return []string{tname}, nil
+	}
+
+	var trees []string
+	err := riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := obj.(rtree.Tree); !ok {
+			return nil
+		}
+		trees = append(trees, strings.Trim(path[len(f.Name()):], "/"))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not walk input file: %w", err)
+	}
+	return trees, nil
+}
+
+func convert(w io.Writer, f, o *riofs.File, tname string) error {
+	tree, err := riofs.Get[rtree.Tree](f, tname)
+	if err != nil {
+		return fmt.Errorf("could not retrieve tree %q: %w", tname, err)
+	}
+
+	maps, err := rntup.Schema(tree)
+	if err != nil {
+		return fmt.Errorf("could not build schema mapping of tree %q: %w", tname, err)
+	}
+
+	fmt.Fprintf(w, "tree %q:\n", tname)
+	tw := tabwriter.NewWriter(w, 8, 4, 1, ' ', 0)
+	fmt.Fprintf(tw, "branch\ttype\t->\tfield\ttype\n")
+	for _, m := range maps {
+		name := m.Branch
+		if m.Leaf != m.Branch {
+			name += "/" + m.Leaf
+		}
+		fmt.Fprintf(tw, "%s\t%s\t->\t%s\t%s\n", name, m.Type, m.Field, m.FieldType)
+	}
+	tw.Flush()
+
+	var dir riofs.Directory = o
+	if path := stdpath.Dir(tname); path != "." {
+		dir, err = riofs.Dir(o).Mkdir(path)
+		if err != nil {
+			return fmt.Errorf("could not create directory %q: %w", path, err)
+		}
+	}
+
+	n, err := rntup.FromTree(dir, stdpath.Base(tname), tree)
+	if err != nil {
+		return fmt.Errorf("could not convert tree %q: %w", tname, err)
+	}
+
+	fmt.Fprintf(w, "converted %d entries of tree %q\n", n, tname)
+	return nil
+}
+
+func validate(w io.Writer, f, o *riofs.File, tname string) error {
+	tree, err := riofs.Get[rtree.Tree](f, tname)
+	if err != nil {
+		return fmt.Errorf("could not retrieve tree %q: %w", tname, err)
+	}
+
+	nt, err := riofs.Get[*rntup.NTuple](o, tname)
+	if err != nil {
+		return fmt.Errorf("could not retrieve ntuple %q: %w", tname, err)
+	}
+
+	n, err := rntup.Validate(nt, tree)
+	if err != nil {
+		return fmt.Errorf("could not validate ntuple %q: %w", tname, err)
+	}
+
+	fmt.Fprintf(w, "validated %d entries of ntuple %q\n", n, tname)
+	return nil
+}
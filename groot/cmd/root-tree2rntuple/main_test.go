@@ -0,0 +1,191 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/exp/rntup"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestROOTTree2RNTuple(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-tree2rntuple-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/small-flat-tree.root"
+	oname := filepath.Join(tmp, "out.root")
+
+	dname := filepath.Join(tmp, "dirs.root")
+	genDirs(t, dname)
+
+	for _, tc := range []struct {
+		name  string
+		args  []string
+		rc    int
+		want  map[string]int64 // expected ntuples and their number of entries
+		lines []string         // expected lines of the output
+	}{
+		{
+			name: "all-trees",
+			args: []string{fname, oname},
+			want: map[string]int64{"tree": 100},
+			lines: []string{
+				`tree "tree":`,
+				"Int32                  int32       ->      Int32        std::int32_t",
+				"SliceFloat64           []float64   ->      SliceFloat64 std::vector<double>",
+				`converted 100 entries of tree "tree"`,
+				`validated 100 entries of ntuple "tree"`,
+			},
+		},
+		{
+			name: "dirs",
+			args: []string{dname, oname},
+			want: map[string]int64{"t1": 10, "dir/t2": 5},
+			lines: []string{
+				`converted 10 entries of tree "t1"`,
+				`converted 5 entries of tree "dir/t2"`,
+				`validated 10 entries of ntuple "t1"`,
+				`validated 5 entries of ntuple "dir/t2"`,
+			},
+		},
+		{
+			name: "one-tree",
+			args: []string{"-t", "dir/t2", dname, oname},
+			want: map[string]int64{"dir/t2": 5},
+			lines: []string{
+				`tree "dir/t2":`,
+				"x       int32   ->      x       std::int32_t",
+				`validated 5 entries of ntuple "dir/t2"`,
+			},
+		},
+		{
+			name: "no-tree",
+			args: []string{"-t", "not-there", fname, oname},
+			rc:   1,
+		},
+		{
+			name: "no-file",
+			args: []string{"not-there.root", oname},
+			rc:   1,
+		},
+		{
+			name: "no-output",
+			args: []string{fname},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-tree2rntuple: got=%d, want=%d\n%s",
+					rc, tc.rc, errs.String(),
+				)
+			}
+			if rc != 0 || tc.want == nil {
+				return
+			}
+
+			lines := strings.Split(out.String(), "\n")
+			for _, want := range tc.lines {
+				found := false
+				for _, line := range lines {
+					if line == want {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Fatalf("could not find line %q in output:\n%s", want, out.String())
+				}
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			for name, want := range tc.want {
+				nt, err := riofs.Get[*rntup.NTuple](f, name)
+				if err != nil {
+					t.Fatalf("could not retrieve ntuple %q: %+v", name, err)
+				}
+				if got := nt.Entries(); got != want {
+					t.Fatalf("invalid number of entries for %q: got=%d, want=%d", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// genDirs creates a ROOT file with a tree at the top-level and a tree
+// in a sub-directory.
+func genDirs(t *testing.T, fname string) {
+	t.Helper()
+
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create input file: %+v", err)
+	}
+	defer f.Close()
+
+	dir, err := riofs.Dir(f).Mkdir("dir")
+	if err != nil {
+		t.Fatalf("could not create directory: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		dir   riofs.Directory
+		name  string
+		nevts int
+	}{
+		{f, "t1", 10},
+		{dir, "t2", 5},
+	} {
+		var x int32
+		w, err := rtree.NewWriter(tc.dir, tc.name, []rtree.WriteVar{{Name: "x", Value: &x}})
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		for i := 0; i < tc.nevts; i++ {
+			x = int32(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close input file: %+v", err)
+	}
+}
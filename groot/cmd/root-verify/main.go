@@ -0,0 +1,114 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-verify checks the integrity of ROOT files.
+//
+// root-verify reads and decompresses every key of the provided ROOT files,
+// and every basket of the trees they contain, and reports the corrupted
+// objects together with their offsets in the file.
+// Objects that could be read but not decoded are reported separately.
+//
+// root-verify exits with 2 when corrupted objects were found, and with 1
+// on any other error.
+//
+// Usage: root-verify [options] file1.root [file2.root [...]]
+//
+// ex:
+//
+//  $> root-verify ./testdata/small-flat-tree.root
+//  === [./testdata/small-flat-tree.root] ===
+//  checked 1 keys, 1 trees and 20 baskets: 0 corrupted objects, 0 undecodable objects
+//
+//  $> root-verify -json ./testdata/small-flat-tree.root
+//
+// options:
+//   -json
+//     	write the verification report as JSON
+//   -v	enable verbose mode (report valid objects as well)
+package main // import "go-hep.org/x/hep/groot/cmd/root-verify"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+func main() {
+	log.SetPrefix("root-verify: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	var (
+		fset = flag.NewFlagSet("root-verify", flag.ContinueOnError)
+
+		doJSON  = fset.Bool("json", false, "write the verification report as JSON")
+		verbose = fset.Bool("v", false, "enable verbose mode (report valid objects as well)")
+	)
+
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			`Usage: root-verify [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-verify ./testdata/small-flat-tree.root
+ $> root-verify -json ./testdata/small-flat-tree.root
+
+options:
+`,
+		)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() == 0 {
+		fmt.Fprintf(stderr, "error: you need to give at least one ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	opts := []rcmd.VerifyOption{
+		rcmd.VerifyJSON(*doJSON),
+		rcmd.VerifyVerbose(*verbose),
+	}
+
+	rc := 0
+	for _, fname := range fset.Args() {
+		err := rcmd.Verify(stdout, fname, opts...)
+		switch {
+		case err == nil:
+			// ok.
+		case errors.Is(err, rcmd.ErrCorrupted):
+			log.Printf("%+v", err)
+			rc = 2
+		default:
+			log.Printf("could not verify ROOT file %q: %+v", fname, err)
+			if rc == 0 {
+				rc = 1
+			}
+		}
+	}
+
+	return rc
+}
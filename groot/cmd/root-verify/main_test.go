@@ -0,0 +1,104 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTVerify(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "root-verify-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../../testdata/small-flat-tree.root"
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	// corrupt the end of the compressed payload of the first basket, right
+	// before the key header of the second basket.
+	beg := bytes.Index(raw, []byte("TBasket"))
+	end := beg + 1 + bytes.Index(raw[beg+1:], []byte("TBasket"))
+	for i := end - 40; i < end-32; i++ {
+		raw[i] ^= 0xff
+	}
+	corrupted := filepath.Join(tmp, "corrupted.root")
+	err = os.WriteFile(corrupted, raw, 0644)
+	if err != nil {
+		t.Fatalf("could not write corrupted file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		rc   int
+		want string
+	}{
+		{
+			name: "valid",
+			args: []string{fname},
+			want: "=== [" + fname + "] ===\nchecked 1 keys, 1 trees and 20 baskets: 0 corrupted objects, 0 undecodable objects\n",
+		},
+		{
+			name: "verbose",
+			args: []string{"-v", fname},
+			want: "=== [" + fname + "] ===\nkey \"tree;1\" (TTree) at offset 8802: ok\nchecked 1 keys, 1 trees and 20 baskets: 0 corrupted objects, 0 undecodable objects\n",
+		},
+		{
+			name: "json",
+			args: []string{"-json", fname},
+		},
+		{
+			name: "not-there",
+			args: []string{filepath.Join(tmp, "not-there.root")},
+			rc:   1,
+		},
+		{
+			name: "corrupted",
+			args: []string{corrupted},
+			rc:   2,
+		},
+		{
+			name: "no-file",
+			args: []string{},
+			rc:   1,
+		},
+		{
+			name: "help",
+			args: []string{"-h"},
+		},
+		{
+			name: "invalid-flag",
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			errs := new(bytes.Buffer)
+			rc := run(out, errs, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-verify: got=%d, want=%d\n%s\n%s",
+					rc, tc.rc, out.String(), errs.String(),
+				)
+			}
+			if rc != 0 || tc.want == "" {
+				return
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
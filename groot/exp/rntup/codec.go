@@ -0,0 +1,395 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// goTypes maps the C++ types of supported leaf fields to Go types.
+var goTypes = map[string]reflect.Type{
+	"bool":          reflect.TypeOf(false),
+	"char":          reflect.TypeOf(int8(0)),
+	"std::int8_t":   reflect.TypeOf(int8(0)),
+	"std::uint8_t":  reflect.TypeOf(uint8(0)),
+	"std::int16_t":  reflect.TypeOf(int16(0)),
+	"std::uint16_t": reflect.TypeOf(uint16(0)),
+	"std::int32_t":  reflect.TypeOf(int32(0)),
+	"std::uint32_t": reflect.TypeOf(uint32(0)),
+	"std::int64_t":  reflect.TypeOf(int64(0)),
+	"std::uint64_t": reflect.TypeOf(uint64(0)),
+	"float":         reflect.TypeOf(float32(0)),
+	"double":        reflect.TypeOf(float64(0)),
+	"std::string":   reflect.TypeOf(""),
+}
+
+// cppTypes maps the kinds of supported Go values to the C++ types of
+// leaf fields, the types of their columns and the split column types
+// of the 1.0 format written for them, as ROOT does by default.
+var cppTypes = map[reflect.Kind]struct {
+	name string
+	col  colType
+	code uint16
+}{
+	reflect.Bool:    {"bool", colBit, 0x06},
+	reflect.Int8:    {"std::int8_t", colInt8, 0x10},
+	reflect.Uint8:   {"std::uint8_t", colInt8, 0x11},
+	reflect.Int16:   {"std::int16_t", colInt16, 0x1a},
+	reflect.Uint16:  {"std::uint16_t", colInt16, 0x1b},
+	reflect.Int32:   {"std::int32_t", colInt32, 0x18},
+	reflect.Uint32:  {"std::uint32_t", colInt32, 0x19},
+	reflect.Int64:   {"std::int64_t", colInt64, 0x16},
+	reflect.Uint64:  {"std::uint64_t", colInt64, 0x17},
+	reflect.Float32: {"float", colReal32, 0x15},
+	reflect.Float64: {"double", colReal64, 0x14},
+}
+
+// column types of the 1.0 format written for the offsets of collections
+// and for the characters of strings.
+const (
+	codeSplitIndex64 = 0x12
+	codeChar         = 0x05
+)
+
+// typeOf returns the Go type of the provided top-level field and the
+// columns holding its data: the column of the values for scalars,
+// the index and byte columns for strings, the column of the elements
+// for fixed-size arrays and the index and elements columns for
+// collections.
+func (desc *descriptor) typeOf(f *Field) (reflect.Type, []*column, error) {
+	elem := func() (reflect.Type, *column, error) {
+		var sub *Field
+		for _, c := range desc.fields {
+			if c.parent == f.id && c.id != f.id {
+				if sub != nil {
+					return nil, nil, fmt.Errorf("field %q has more than one sub-field", f.Name)
+				}
+				sub = c
+			}
+		}
+		if sub == nil {
+			return nil, nil, fmt.Errorf("field %q has no sub-field", f.Name)
+		}
+		rt, ok := goTypes[sub.Type]
+		if !ok || rt.Kind() == reflect.String || sub.kind != structLeaf || sub.nrep != 0 {
+			return nil, nil, fmt.Errorf("field %q has unsupported element type %q", f.Name, sub.Type)
+		}
+		if len(sub.cols) != 1 {
+			return nil, nil, fmt.Errorf("field %q has %d element columns (want=1)", f.Name, len(sub.cols))
+		}
+		return rt, sub.cols[0], nil
+	}
+
+	switch {
+	case f.kind == structLeaf && f.nrep == 0:
+		rt, ok := goTypes[f.Type]
+		if !ok {
+			return nil, nil, fmt.Errorf("field %q has unsupported type %q", f.Name, f.Type)
+		}
+		want := []colType{cppTypes[rt.Kind()].col}
+		if rt.Kind() == reflect.String {
+			want = []colType{colIndex, colByte}
+		}
+		if len(f.cols) != len(want) {
+			return nil, nil, fmt.Errorf("field %q has %d columns (want=%d)", f.Name, len(f.cols), len(want))
+		}
+		for i, col := range f.cols {
+			if want[i] == colIndex && col.typ.isIndex() {
+				continue
+			}
+			if !compatible(col.typ, want[i]) {
+				return nil, nil, fmt.Errorf("field %q has invalid column type %d (want=%d)", f.Name, col.typ, want[i])
+			}
+		}
+		return rt, f.cols, nil
+
+	case f.kind == structLeaf:
+		rt, col, err := elem()
+		if err != nil {
+			return nil, nil, err
+		}
+		if !compatible(col.typ, cppTypes[rt.Kind()].col) {
+			return nil, nil, fmt.Errorf("field %q has invalid element column type %d", f.Name, col.typ)
+		}
+		return reflect.ArrayOf(int(f.nrep), rt), []*column{col}, nil
+
+	case f.kind == structCollection:
+		rt, col, err := elem()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(f.cols) != 1 || !f.cols[0].typ.isIndex() {
+			return nil, nil, fmt.Errorf("field %q has no index column", f.Name)
+		}
+		if !compatible(col.typ, cppTypes[rt.Kind()].col) {
+			return nil, nil, fmt.Errorf("field %q has invalid element column type %d", f.Name, col.typ)
+		}
+		return reflect.SliceOf(rt), []*column{f.cols[0], col}, nil
+	}
+
+	return nil, nil, fmt.Errorf("field %q has unsupported type %q", f.Name, f.Type)
+}
+
+// compatible returns whether elements of the on-disk column type can be
+// decoded as elements of the wanted column type.
+func compatible(got, want colType) bool {
+	return got == want || (got != colBit && want != colBit && got.size(1) == want.size(1))
+}
+
+// cxxTypeOf returns the C++ type name of the provided Go type.
+func cxxTypeOf(rt reflect.Type) (string, error) {
+	switch rt.Kind() {
+	case reflect.String:
+		return "std::string", nil
+	case reflect.Array:
+		elt, err := cxxTypeOf(rt.Elem())
+		if err != nil || rt.Elem().Kind() == reflect.String {
+			return "", fmt.Errorf("unsupported type %v", rt)
+		}
+		return fmt.Sprintf("std::array<%s,%d>", elt, rt.Len()), nil
+	case reflect.Slice:
+		elt, err := cxxTypeOf(rt.Elem())
+		if err != nil || rt.Elem().Kind() == reflect.String {
+			return "", fmt.Errorf("unsupported type %v", rt)
+		}
+		return fmt.Sprintf("std::vector<%s>", elt), nil
+	}
+	cxx, ok := cppTypes[rt.Kind()]
+	if !ok {
+		return "", fmt.Errorf("unsupported type %v", rt)
+	}
+	return cxx.name, nil
+}
+
+// unpack decodes the split, delta and zigzag encodings of the n elements
+// of a page of the column.
+func (col *column) unpack(buf []byte, n int) []byte {
+	if col.enc == encPlain {
+		return buf
+	}
+
+	size := col.typ.size(1)
+	out := make([]byte, len(buf))
+	for i := 0; i < n; i++ {
+		for b := 0; b < size; b++ {
+			out[i*size+b] = buf[b*n+i]
+		}
+	}
+
+	switch col.enc {
+	case encDelta:
+		switch size {
+		case 4:
+			var v uint32
+			for i := 0; i < n; i++ {
+				v += getU32(out, int64(i))
+				binary.LittleEndian.PutUint32(out[4*i:], v)
+			}
+		case 8:
+			var v uint64
+			for i := 0; i < n; i++ {
+				v += getU64(out, int64(i))
+				binary.LittleEndian.PutUint64(out[8*i:], v)
+			}
+		}
+	case encZigzag:
+		switch size {
+		case 2:
+			for i := 0; i < n; i++ {
+				v := getU16(out, int64(i))
+				binary.LittleEndian.PutUint16(out[2*i:], (v>>1)^-(v&1))
+			}
+		case 4:
+			for i := 0; i < n; i++ {
+				v := getU32(out, int64(i))
+				binary.LittleEndian.PutUint32(out[4*i:], (v>>1)^-(v&1))
+			}
+		case 8:
+			for i := 0; i < n; i++ {
+				v := getU64(out, int64(i))
+				binary.LittleEndian.PutUint64(out[8*i:], (v>>1)^-(v&1))
+			}
+		}
+	}
+	return out
+}
+
+// pack applies the split, delta and zigzag encodings to the n elements
+// of a page of the column.
+func (col *column) pack(buf []byte, n int) []byte {
+	if col.enc == encPlain {
+		return buf
+	}
+
+	size := col.typ.size(1)
+	src := make([]byte, len(buf))
+	copy(src, buf)
+
+	switch col.enc {
+	case encDelta:
+		switch size {
+		case 4:
+			var prev uint32
+			for i := 0; i < n; i++ {
+				v := getU32(src, int64(i))
+				binary.LittleEndian.PutUint32(src[4*i:], v-prev)
+				prev = v
+			}
+		case 8:
+			var prev uint64
+			for i := 0; i < n; i++ {
+				v := getU64(src, int64(i))
+				binary.LittleEndian.PutUint64(src[8*i:], v-prev)
+				prev = v
+			}
+		}
+	case encZigzag:
+		switch size {
+		case 2:
+			for i := 0; i < n; i++ {
+				v := getI16(src, int64(i))
+				binary.LittleEndian.PutUint16(src[2*i:], uint16(v<<1)^uint16(v>>15))
+			}
+		case 4:
+			for i := 0; i < n; i++ {
+				v := getI32(src, int64(i))
+				binary.LittleEndian.PutUint32(src[4*i:], uint32(v<<1)^uint32(v>>31))
+			}
+		case 8:
+			for i := 0; i < n; i++ {
+				v := getI64(src, int64(i))
+				binary.LittleEndian.PutUint64(src[8*i:], uint64(v<<1)^uint64(v>>63))
+			}
+		}
+	}
+
+	out := make([]byte, len(src))
+	for i := 0; i < n; i++ {
+		for b := 0; b < size; b++ {
+			out[b*n+i] = src[i*size+b]
+		}
+	}
+	return out
+}
+
+// appendBits appends the n bits of src to the ndst bits of dst.
+func appendBits(dst []byte, ndst int, src []byte, n int) []byte {
+	if ndst%8 == 0 {
+		return append(dst, src[:(n+7)/8]...)
+	}
+	for j := 0; j < n; j++ {
+		dst = putBool(dst, int64(ndst+j), getBool(src, int64(j)))
+	}
+	return dst
+}
+
+// getter decodes the j-th element of a column.
+type getter[T any] func(b []byte, j int64) T
+
+// putter encodes the j-th element of a column.
+type putter[T any] func(b []byte, j int64, v T) []byte
+
+func getBool(b []byte, j int64) bool {
+	return b[j/8]&(1<<(j%8)) != 0
+}
+
+func getI8(b []byte, j int64) int8 {
+	return int8(b[j])
+}
+
+func getU8(b []byte, j int64) uint8 {
+	return b[j]
+}
+
+func getI16(b []byte, j int64) int16 {
+	return int16(binary.LittleEndian.Uint16(b[2*j:]))
+}
+
+func getU16(b []byte, j int64) uint16 {
+	return binary.LittleEndian.Uint16(b[2*j:])
+}
+
+func getI32(b []byte, j int64) int32 {
+	return int32(binary.LittleEndian.Uint32(b[4*j:]))
+}
+
+func getU32(b []byte, j int64) uint32 {
+	return binary.LittleEndian.Uint32(b[4*j:])
+}
+
+func getI64(b []byte, j int64) int64 {
+	return int64(binary.LittleEndian.Uint64(b[8*j:]))
+}
+
+func getU64(b []byte, j int64) uint64 {
+	return binary.LittleEndian.Uint64(b[8*j:])
+}
+
+func getF32(b []byte, j int64) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(b[4*j:]))
+}
+
+func getF64(b []byte, j int64) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(b[8*j:]))
+}
+
+func putBool(b []byte, j int64, v bool) []byte {
+	if j%8 == 0 {
+		b = append(b, 0)
+	}
+	if v {
+		b[j/8] |= 1 << (j % 8)
+	}
+	return b
+}
+
+func putI8(b []byte, _ int64, v int8) []byte {
+	return append(b, byte(v))
+}
+
+func putU8(b []byte, _ int64, v uint8) []byte {
+	return append(b, v)
+}
+
+func putI16(b []byte, j int64, v int16) []byte {
+	return putU16(b, j, uint16(v))
+}
+
+func putU16(b []byte, _ int64, v uint16) []byte {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func putI32(b []byte, j int64, v int32) []byte {
+	return putU32(b, j, uint32(v))
+}
+
+func putU32(b []byte, _ int64, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func putI64(b []byte, j int64, v int64) []byte {
+	return putU64(b, j, uint64(v))
+}
+
+func putU64(b []byte, _ int64, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func putF32(b []byte, j int64, v float32) []byte {
+	return putU32(b, j, math.Float32bits(v))
+}
+
+func putF64(b []byte, j int64, v float64) []byte {
+	return putU64(b, j, math.Float64bits(v))
+}
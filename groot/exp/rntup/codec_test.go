@@ -0,0 +1,115 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColumnUnpack(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		col  column
+		n    int
+		raw  []byte
+		want []byte
+	}{
+		{
+			name: "plain-i32",
+			col:  column{typ: colInt32, enc: encPlain},
+			n:    2,
+			raw:  []byte{1, 0, 0, 0, 3, 2, 0, 0},
+			want: []byte{1, 0, 0, 0, 3, 2, 0, 0},
+		},
+		{
+			name: "split-i32",
+			col:  column{typ: colInt32, enc: encSplit},
+			n:    2, // {1, 0x0203}
+			raw:  []byte{1, 3, 0, 2, 0, 0, 0, 0},
+			want: []byte{1, 0, 0, 0, 3, 2, 0, 0},
+		},
+		{
+			name: "zigzag-i16",
+			col:  column{typ: colInt16, enc: encZigzag},
+			n:    3, // {-1, 1, -2}
+			raw:  []byte{1, 2, 3, 0, 0, 0},
+			want: []byte{0xff, 0xff, 1, 0, 0xfe, 0xff},
+		},
+		{
+			name: "zigzag-i64",
+			col:  column{typ: colInt64, enc: encZigzag},
+			n:    1, // {-3}
+			raw:  []byte{5, 0, 0, 0, 0, 0, 0, 0},
+			want: []byte{0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			name: "delta-index32",
+			col:  column{typ: colIndex, enc: encDelta},
+			n:    3, // {3, 5, 10}
+			raw:  []byte{3, 2, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			want: []byte{3, 0, 0, 0, 5, 0, 0, 0, 10, 0, 0, 0},
+		},
+		{
+			name: "delta-index64",
+			col:  column{typ: colIndex64, enc: encDelta},
+			n:    2, // {0x100, 0x101}
+			raw:  []byte{0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			want: []byte{0, 1, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.col.unpack(tc.raw, tc.n)
+			if !bytes.Equal(got, tc.want) {
+				t.Fatalf("invalid unpacked data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumnPack(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		col  column
+		raw  []byte
+	}{
+		{"split-f32", column{typ: colReal32, enc: encSplit}, []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"zigzag-i16", column{typ: colInt16, enc: encZigzag}, []byte{0xff, 0xff, 1, 0, 0x00, 0x80}},
+		{"zigzag-i32", column{typ: colInt32, enc: encZigzag}, []byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{"zigzag-i64", column{typ: colInt64, enc: encZigzag}, []byte{0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"delta-index64", column{typ: colIndex64, enc: encDelta}, []byte{0, 1, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := len(tc.raw) / tc.col.typ.size(1)
+			got := tc.col.unpack(tc.col.pack(tc.raw, n), n)
+			if !bytes.Equal(got, tc.raw) {
+				t.Fatalf("invalid pack/unpack round-trip:\ngot= %v\nwant=%v", got, tc.raw)
+			}
+		})
+	}
+
+	// hand-encoded delta-index32 {3, 5, 10}.
+	col := column{typ: colIndex, enc: encDelta}
+	got := col.pack([]byte{3, 0, 0, 0, 5, 0, 0, 0, 10, 0, 0, 0}, 3)
+	if want := []byte{3, 2, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0}; !bytes.Equal(got, want) {
+		t.Fatalf("invalid packed data:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestAppendBits(t *testing.T) {
+	var (
+		dst = []byte{0x05} // 3 bits: 1, 0, 1
+		src = []byte{0x03} // 2 bits: 1, 1
+	)
+	got := appendBits(dst, 3, src, 2)
+	if want := []byte{0x1d}; !bytes.Equal(got, want) {
+		t.Fatalf("invalid bits: got=%08b, want=%08b", got, want)
+	}
+
+	got = appendBits([]byte{0xff}, 8, src, 2)
+	if want := []byte{0xff, 0x03}; !bytes.Equal(got, want) {
+		t.Fatalf("invalid bits: got=%08b, want=%08b", got, want)
+	}
+}
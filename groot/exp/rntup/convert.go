@@ -0,0 +1,305 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// FromTree converts the provided tree into an NTuple with the provided
+// name, created under the directory dir, and returns the number of
+// converted entries.
+//
+// Each leaf of the tree is converted to a top-level field named after its
+// branch, or "branch.leaf" for branches with multiple leaves.
+// Fixed-size arrays are converted to std::array fields, variable-length
+// arrays and std::vector to std::vector fields.
+// Count leaves of variable-length arrays are kept as regular fields.
+// Float16_t and Double32_t values are stored as float and double values.
+func FromTree(dir riofs.Directory, name string, t rtree.Tree, opts ...WriteOption) (int64, error) {
+	rvars, wvars, convs, err := fromTree(t)
+	if err != nil {
+		return 0, err
+	}
+
+	w, err := NewWriter(dir, name, wvars, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("rntup: could not create ntuple writer: %w", err)
+	}
+	defer w.Close()
+
+	r, err := rtree.NewReader(t, rvars)
+	if err != nil {
+		return 0, fmt.Errorf("rntup: could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	var n int64
+	err = r.Read(func(ctx rtree.RCtx) error {
+		for _, conv := range convs {
+			conv()
+		}
+		_, err := w.Write()
+		if err != nil {
+			return fmt.Errorf("could not write entry %d: %w", ctx.Entry, err)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, fmt.Errorf("rntup: could not convert tree %q: %w", t.Name(), err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return n, fmt.Errorf("rntup: could not close ntuple writer: %w", err)
+	}
+
+	return n, nil
+}
+
+// Mapping describes how a leaf of a tree is converted into a field of an
+// NTuple.
+type Mapping struct {
+	Branch    string // name of the branch
+	Leaf      string // name of the leaf
+	Type      string // Go type of the leaf values
+	Field     string // name of the field
+	FieldType string // C++ type of the field
+}
+
+// Schema returns how the leaves of the provided tree are converted into
+// the fields of an NTuple by FromTree.
+func Schema(t rtree.Tree) ([]Mapping, error) {
+	rvars, wvars, _, err := fromTree(t)
+	if err != nil {
+		return nil, err
+	}
+
+	maps := make([]Mapping, len(rvars))
+	for i, rv := range rvars {
+		cxx, err := cxxTypeOf(reflect.TypeOf(wvars[i].Value).Elem())
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not convert branch %q: %w", wvars[i].Name, err)
+		}
+		maps[i] = Mapping{
+			Branch:    rv.Name,
+			Leaf:      rv.Leaf,
+			Type:      reflect.TypeOf(rv.Value).Elem().String(),
+			Field:     wvars[i].Name,
+			FieldType: cxx,
+		}
+	}
+	return maps, nil
+}
+
+// fromTree returns the read-vars of the leaves of the provided tree, the
+// write-vars of the corresponding fields and the functions converting the
+// values read from the tree into the values written to the fields.
+func fromTree(t rtree.Tree) ([]rtree.ReadVar, []WriteVar, []func(), error) {
+	var (
+		rvars = rtree.NewReadVars(t)
+		wvars = make([]WriteVar, len(rvars))
+		convs []func()
+	)
+	for i, rv := range rvars {
+		fname := rv.Name
+		if len(t.Branch(rv.Name).Leaves()) > 1 {
+			fname += "." + rv.Leaf
+		}
+		wvars[i] = WriteVar{Name: fname, Value: rv.Value}
+
+		var (
+			src = reflect.ValueOf(rv.Value).Elem()
+			rt  = canonical(src.Type())
+		)
+		if rt == nil {
+			return nil, nil, nil, fmt.Errorf("rntup: could not convert branch %q: unsupported type %v", fname, src.Type())
+		}
+		if rt == src.Type() {
+			continue
+		}
+		dst := reflect.New(rt)
+		wvars[i].Value = dst.Interface()
+		convs = append(convs, func() { convert(dst.Elem(), src) })
+	}
+	return rvars, wvars, convs, nil
+}
+
+// validateBatch is the number of entries of the tree held in memory at
+// once by Validate.
+const validateBatch = 1024
+
+// Validate checks that the values of the fields of the provided NTuple
+// match the values of the leaves of the tree it was converted from with
+// FromTree, and returns the number of validated entries.
+// Validate reports the first mismatching value, if any.
+func Validate(nt *NTuple, t rtree.Tree) (int64, error) {
+	if got, want := nt.Entries(), t.Entries(); got != want {
+		return 0, fmt.Errorf("rntup: invalid number of entries (got=%d, want=%d)", got, want)
+	}
+
+	rvars, wvars, convs, err := fromTree(t)
+	if err != nil {
+		return 0, err
+	}
+
+	nvars := make([]ReadVar, len(wvars))
+	for i, wv := range wvars {
+		nvars[i] = ReadVar{
+			Name:  wv.Name,
+			Value: reflect.New(reflect.TypeOf(wv.Value).Elem()).Interface(),
+		}
+	}
+
+	var (
+		nevts = t.Entries()
+		want  = make([][]reflect.Value, 0, validateBatch)
+	)
+	for beg := int64(0); beg < nevts; beg += validateBatch {
+		end := beg + validateBatch
+		if end > nevts {
+			end = nevts
+		}
+
+		r, err := rtree.NewReader(t, rvars, rtree.WithRange(beg, end))
+		if err != nil {
+			return beg, fmt.Errorf("rntup: could not create tree reader: %w", err)
+		}
+		want = want[:0]
+		err = r.Read(func(ctx rtree.RCtx) error {
+			for _, conv := range convs {
+				conv()
+			}
+			row := make([]reflect.Value, len(wvars))
+			for i, wv := range wvars {
+				// copy values, as the reader may reuse the memory
+				// of slices from one entry to the next.
+				row[i] = clone(reflect.ValueOf(wv.Value).Elem())
+			}
+			want = append(want, row)
+			return nil
+		})
+		r.Close()
+		if err != nil {
+			return beg, fmt.Errorf("rntup: could not read tree %q: %w", t.Name(), err)
+		}
+
+		nr, err := NewReader(nt, nvars, WithRange(beg, end))
+		if err != nil {
+			return beg, fmt.Errorf("rntup: could not create ntuple reader: %w", err)
+		}
+		err = nr.Read(func(ctx RCtx) error {
+			row := want[ctx.Entry-beg]
+			for i, nv := range nvars {
+				var (
+					got  = reflect.ValueOf(nv.Value).Elem()
+					want = row[i]
+				)
+				if !equal(got, want) {
+					return fmt.Errorf(
+						"invalid value for field %q at entry %d (got=%v, want=%v)",
+						nv.Name, ctx.Entry, got.Interface(), want.Interface(),
+					)
+				}
+			}
+			return nil
+		})
+		nr.Close()
+		if err != nil {
+			return beg, fmt.Errorf("rntup: could not validate ntuple: %w", err)
+		}
+	}
+
+	return nevts, nil
+}
+
+// clone returns a deep copy of the provided value.
+func clone(v reflect.Value) reflect.Value {
+	o := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Slice:
+		o.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		reflect.Copy(o, v)
+	default:
+		o.Set(v)
+	}
+	return o
+}
+
+// equal returns whether the two values are equal, considering NaN values
+// as equal.
+func equal(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Array, reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		x, y := a.Float(), b.Float()
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+// canonical returns the type, made of unnamed Go types, into which values
+// of the provided type are written, or nil if they can not be written.
+func canonical(rt reflect.Type) reflect.Type {
+	switch rt.Kind() {
+	case reflect.String:
+		return reflect.TypeOf("")
+	case reflect.Array:
+		elt := canonical(rt.Elem())
+		if elt == nil {
+			return nil
+		}
+		return reflect.ArrayOf(rt.Len(), elt)
+	case reflect.Slice:
+		elt := canonical(rt.Elem())
+		if elt == nil {
+			return nil
+		}
+		return reflect.SliceOf(elt)
+	}
+	for _, v := range goTypes {
+		if v.Kind() == rt.Kind() {
+			return v
+		}
+	}
+	return nil
+}
+
+// convert stores src into dst, a value of the canonical type of src.
+func convert(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			convert(dst.Index(i), src.Index(i))
+		}
+	case reflect.Slice:
+		n := src.Len()
+		if dst.Cap() < n {
+			dst.Set(reflect.MakeSlice(dst.Type(), n, n))
+		}
+		dst.SetLen(n)
+		for i := 0; i < n; i++ {
+			convert(dst.Index(i), src.Index(i))
+		}
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
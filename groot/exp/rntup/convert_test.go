@@ -0,0 +1,282 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestFromTree(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		tname string
+	}{
+		{"../../testdata/small-flat-tree.root", "tree"},
+		{"../../testdata/x-flat-tree.root", "tree"},
+	} {
+		t.Run(filepath.Base(tc.fname), func(t *testing.T) {
+			f, err := riofs.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			tree, err := riofs.Get[rtree.Tree](f, tc.tname)
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+
+			want := dumpTree(t, tree)
+
+			oname := filepath.Join(t.TempDir(), "out.root")
+			o, err := riofs.Create(oname)
+			if err != nil {
+				t.Fatalf("could not create output file: %+v", err)
+			}
+			defer o.Close()
+
+			n, err := FromTree(o, "ntuple", tree, WithClusterSize(7))
+			if err != nil {
+				t.Fatalf("could not convert tree: %+v", err)
+			}
+			if got, want := n, tree.Entries(); got != want {
+				t.Fatalf("invalid number of converted entries: got=%d, want=%d", got, want)
+			}
+
+			err = o.Close()
+			if err != nil {
+				t.Fatalf("could not close output file: %+v", err)
+			}
+
+			o, err = riofs.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer o.Close()
+
+			nt, err := riofs.Get[*NTuple](o, "ntuple")
+			if err != nil {
+				t.Fatalf("could not retrieve ntuple: %+v", err)
+			}
+
+			got := dumpNTuple(t, nt)
+			if !reflect.DeepEqual(got, want) {
+				for i := range want {
+					if i >= len(got) || got[i] != want[i] {
+						t.Fatalf("invalid entry %d:\ngot= %v\nwant=%v", i, got[i:], want[i])
+					}
+				}
+				t.Fatalf("invalid number of entries: got=%d, want=%d", len(got), len(want))
+			}
+
+			n, err = Validate(nt, tree)
+			if err != nil {
+				t.Fatalf("could not validate ntuple: %+v", err)
+			}
+			if got, want := n, tree.Entries(); got != want {
+				t.Fatalf("invalid number of validated entries: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	f, err := riofs.Open("../../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	maps, err := Schema(tree)
+	if err != nil {
+		t.Fatalf("could not build schema mapping: %+v", err)
+	}
+
+	if got, want := len(maps), len(tree.Branches()); got != want {
+		t.Fatalf("invalid number of mappings: got=%d, want=%d", got, want)
+	}
+
+	for _, want := range []Mapping{
+		{Branch: "Int32", Leaf: "Int32", Type: "int32", Field: "Int32", FieldType: "std::int32_t"},
+		{Branch: "Str", Leaf: "Str", Type: "string", Field: "Str", FieldType: "std::string"},
+		{Branch: "ArrayFloat64", Leaf: "ArrayFloat64", Type: "[10]float64", Field: "ArrayFloat64", FieldType: "std::array<double,10>"},
+		{Branch: "SliceInt64", Leaf: "SliceInt64", Type: "[]int64", Field: "SliceInt64", FieldType: "std::vector<std::int64_t>"},
+	} {
+		found := false
+		for _, got := range maps {
+			if got.Branch != want.Branch {
+				continue
+			}
+			found = true
+			if got != want {
+				t.Fatalf("invalid mapping for branch %q:\ngot= %+v\nwant=%+v", want.Branch, got, want)
+			}
+		}
+		if !found {
+			t.Fatalf("no mapping for branch %q", want.Branch)
+		}
+	}
+}
+
+func TestValidateMismatch(t *testing.T) {
+	const nevts = 10
+
+	fname := filepath.Join(t.TempDir(), "out.root")
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create output file: %+v", err)
+	}
+	defer f.Close()
+
+	var x int32
+	tw, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{{Name: "x", Value: &x}})
+	if err != nil {
+		t.Fatalf("could not create tree writer: %+v", err)
+	}
+	nw, err := NewWriter(f, "ntuple", []WriteVar{{Name: "x", Value: &x}})
+	if err != nil {
+		t.Fatalf("could not create ntuple writer: %+v", err)
+	}
+	for i := 0; i < nevts; i++ {
+		x = int32(i)
+		_, err = tw.Write()
+		if err != nil {
+			t.Fatalf("could not write tree entry %d: %+v", i, err)
+		}
+		if i == 7 {
+			x = 42
+		}
+		_, err = nw.Write()
+		if err != nil {
+			t.Fatalf("could not write ntuple entry %d: %+v", i, err)
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatalf("could not close tree writer: %+v", err)
+	}
+	err = nw.Close()
+	if err != nil {
+		t.Fatalf("could not close ntuple writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close output file: %+v", err)
+	}
+
+	f, err = riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open output file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	nt, err := riofs.Get[*NTuple](f, "ntuple")
+	if err != nil {
+		t.Fatalf("could not retrieve ntuple: %+v", err)
+	}
+
+	_, err = Validate(nt, tree)
+	if err == nil {
+		t.Fatalf("expected a validation error")
+	}
+	const want = `rntup: could not validate ntuple: invalid value for field "x" at entry 7 (got=42, want=7)`
+	if got := err.Error(); got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func dumpTree(t *testing.T, tree rtree.Tree) []string {
+	t.Helper()
+
+	rvars := rtree.NewReadVars(tree)
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	var out []string
+	err = r.Read(func(ctx rtree.RCtx) error {
+		var s string
+		for _, rv := range rvars {
+			name := rv.Name
+			if len(tree.Branch(rv.Name).Leaves()) > 1 {
+				name += "." + rv.Leaf
+			}
+			s += fmt.Sprintf("%s=%v;", name, rv.Deref())
+		}
+		out = append(out, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+	return out
+}
+
+func dumpNTuple(t *testing.T, nt *NTuple) []string {
+	t.Helper()
+
+	rvars := NewReadVars(nt)
+	r, err := NewReader(nt, rvars)
+	if err != nil {
+		t.Fatalf("could not create ntuple reader: %+v", err)
+	}
+	defer r.Close()
+
+	var out []string
+	err = r.Read(func(ctx RCtx) error {
+		var s string
+		for _, rv := range rvars {
+			s += fmt.Sprintf("%s=%v;", rv.Name, reflect.ValueOf(rv.Value).Elem().Interface())
+		}
+		out = append(out, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read ntuple: %+v", err)
+	}
+	return out
+}
+
+func TestFromTreeErrors(t *testing.T) {
+	f, err := riofs.Open("../../testdata/vec-vec-double.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "t")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	o, err := riofs.Create(filepath.Join(t.TempDir(), "out.root"))
+	if err != nil {
+		t.Fatalf("could not create output file: %+v", err)
+	}
+	defer o.Close()
+
+	_, err = FromTree(o, "ntuple", tree)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	t.Logf("%v", err)
+}
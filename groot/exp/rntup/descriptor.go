@@ -0,0 +1,468 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"sort"
+)
+
+// structure describes how a field is laid out in terms of columns
+// and sub-fields.
+type structure uint32
+
+const (
+	structLeaf structure = iota
+	structCollection
+	structRecord
+	structVariant
+	structReference
+)
+
+// colType describes the on-disk representation of column elements.
+type colType uint32
+
+const (
+	colUnknown colType = iota
+	colIndex           // 32b cluster-local offsets of (nested) collections
+	colSwitch
+	colByte
+	colBit
+	colReal64
+	colReal32
+	colReal16
+	colReal8
+	colInt64
+	colInt32
+	colInt16
+	colInt8
+
+	// colIndex64 holds 64b cluster-local offsets of (nested) collections.
+	// It is only found in the 1.0 format.
+	colIndex64
+)
+
+// size returns the number of bytes needed to store n elements.
+func (ct colType) size(n int) int {
+	switch ct {
+	case colBit:
+		return (n + 7) / 8
+	case colByte, colReal8, colInt8:
+		return n
+	case colReal16, colInt16:
+		return 2 * n
+	case colIndex, colReal32, colInt32:
+		return 4 * n
+	case colSwitch, colReal64, colInt64, colIndex64:
+		return 8 * n
+	}
+	return -1
+}
+
+// isIndex returns whether the column holds offsets of collections.
+func (ct colType) isIndex() bool {
+	return ct == colIndex || ct == colIndex64
+}
+
+// encoding describes how the elements of a column are laid out in a
+// page, on top of their on-disk representation.
+type encoding uint8
+
+const (
+	encPlain  encoding = iota
+	encSplit           // bytes of the elements are split in streams
+	encDelta           // split, each element stored as the difference with the previous one
+	encZigzag          // split, each element zigzag-encoded
+)
+
+// Field describes a field of an RNTuple.
+type Field struct {
+	Name string // name of the field
+	Type string // C++ type name of the field
+	Doc  string // description of the field
+
+	id     uint64
+	parent uint64
+	nrep   uint64 // number of repetitions, for fixed-size arrays
+	kind   structure
+	cols   []*column // columns of the field, sorted by index
+}
+
+const noParent = math.MaxUint64
+
+type column struct {
+	id     uint64
+	code   uint16 // column type of the 1.0 format
+	typ    colType
+	enc    encoding
+	sorted bool
+	field  uint64
+	index  uint32
+}
+
+type locator struct {
+	pos    int64
+	nbytes uint32
+	url    string
+	chk    bool // whether the data is followed by its XXH3-64 checksum
+}
+
+type page struct {
+	n   uint32 // number of elements in the page
+	loc locator
+}
+
+type colRange struct {
+	first uint64 // index of the first element of the cluster
+	n     uint32 // number of elements in the cluster
+	comp  int64  // compression settings
+}
+
+type cluster struct {
+	id    uint64
+	first uint64 // first entry of the cluster
+	n     uint64 // number of entries of the cluster
+	loc   locator
+
+	ranges map[uint64]colRange
+	pages  map[uint64][]page
+}
+
+// descriptor holds the decoded header and footer of an RNTuple.
+type descriptor struct {
+	name     string
+	desc     string
+	author   string
+	fields   []*Field
+	cols     []*column
+	aliases  []*column // alias columns of projected fields (1.0 format)
+	clusters []cluster
+
+	hchk uint64 // checksum of the header envelope (1.0 format)
+}
+
+// decoder decodes the little-endian envelopes of RNTuple headers and
+// footers.
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func newDecoder(buf []byte) (*decoder, error) {
+	if len(buf) < 4 {
+		return nil, fmt.Errorf("rntup: envelope too short (len=%d)", len(buf))
+	}
+	n := len(buf) - 4
+	var (
+		want = binary.LittleEndian.Uint32(buf[n:])
+		got  = crc32.ChecksumIEEE(buf[:n])
+	)
+	if got != want {
+		return nil, fmt.Errorf("rntup: invalid envelope checksum (got=0x%x, want=0x%x)", got, want)
+	}
+	return &decoder{buf: buf[:n]}, nil
+}
+
+func (dec *decoder) next(n int) []byte {
+	if dec.err != nil {
+		return nil
+	}
+	if n < 0 || dec.pos+n > len(dec.buf) {
+		dec.err = fmt.Errorf("rntup: could not read %d bytes at offset %d: %w", n, dec.pos, io.ErrUnexpectedEOF)
+		return nil
+	}
+	b := dec.buf[dec.pos : dec.pos+n]
+	dec.pos += n
+	return b
+}
+
+func (dec *decoder) u32() uint32 {
+	b := dec.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (dec *decoder) u64() uint64 {
+	b := dec.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (dec *decoder) str() string {
+	n := dec.u32()
+	return string(dec.next(int(n)))
+}
+
+// frame reads a frame preamble and returns the offset of the end of
+// the frame.
+// A frame with a null size extends to the end of the envelope.
+func (dec *decoder) frame() int {
+	beg := dec.pos
+	_ = dec.next(4) // current and minimal versions
+	n := int(dec.u32())
+	if n == 0 {
+		return len(dec.buf)
+	}
+	return beg + n
+}
+
+// skip moves the decoder to the end of the frame.
+func (dec *decoder) skip(end int) {
+	if dec.err != nil {
+		return
+	}
+	if end < dec.pos || end > len(dec.buf) {
+		dec.err = fmt.Errorf("rntup: invalid frame end %d (pos=%d)", end, dec.pos)
+		return
+	}
+	dec.pos = end
+}
+
+func (dec *decoder) version() {
+	end := dec.frame()
+	_ = dec.u32() // version use
+	_ = dec.u32() // version min
+	_ = dec.u64() // flags
+	dec.skip(end)
+}
+
+func (dec *decoder) uuid() string {
+	end := dec.frame()
+	v := dec.str()
+	dec.skip(end)
+	return v
+}
+
+func (dec *decoder) locator() locator {
+	return locator{
+		pos:    int64(dec.u64()),
+		nbytes: dec.u32(),
+		url:    dec.str(),
+	}
+}
+
+func (dec *decoder) field() *Field {
+	end := dec.frame()
+	f := &Field{id: dec.u64()}
+	dec.version() // field version
+	dec.version() // type version
+	f.Name = dec.str()
+	f.Doc = dec.str()
+	f.Type = dec.str()
+	f.nrep = dec.u64()
+	f.kind = structure(dec.u32())
+	f.parent = dec.u64()
+	n := dec.u32()
+	for i := 0; i < int(n) && dec.err == nil; i++ {
+		_ = dec.u64() // link ids
+	}
+	dec.skip(end)
+	return f
+}
+
+func (dec *decoder) column() *column {
+	end := dec.frame()
+	col := &column{id: dec.u64()}
+	dec.version()
+	{
+		end := dec.frame()
+		col.typ = colType(dec.u32())
+		col.sorted = dec.u32() != 0
+		dec.skip(end)
+	}
+	col.field = dec.u64()
+	col.index = dec.u32()
+	dec.skip(end)
+	return col
+}
+
+func (dec *decoder) cluster() cluster {
+	end := dec.frame()
+	cl := cluster{
+		id:     dec.u64(),
+		ranges: make(map[uint64]colRange),
+		pages:  make(map[uint64][]page),
+	}
+	dec.version()
+	cl.first = dec.u64()
+	cl.n = dec.u64()
+	cl.loc = dec.locator()
+	dec.skip(end)
+	return cl
+}
+
+// readHeader decodes the provided RNTuple header envelope.
+func (desc *descriptor) readHeader(buf []byte) error {
+	dec, err := newDecoder(buf)
+	if err != nil {
+		return fmt.Errorf("rntup: could not decode header: %w", err)
+	}
+
+	end := dec.frame()
+	_ = dec.u64() // reserved
+	desc.name = dec.str()
+	desc.desc = dec.str()
+	desc.author = dec.str()
+	_ = dec.str() // custodian
+	_ = dec.u64() // time stamp of data
+	_ = dec.u64() // time stamp of writing
+	dec.version()
+	_ = dec.uuid() // own uuid
+	_ = dec.uuid() // group uuid
+
+	nfields := dec.u32()
+	for i := 0; i < int(nfields) && dec.err == nil; i++ {
+		desc.fields = append(desc.fields, dec.field())
+	}
+
+	ncols := dec.u32()
+	for i := 0; i < int(ncols) && dec.err == nil; i++ {
+		desc.cols = append(desc.cols, dec.column())
+	}
+	dec.skip(end)
+
+	if dec.err != nil {
+		return fmt.Errorf("rntup: could not decode header: %w", dec.err)
+	}
+
+	return desc.link()
+}
+
+// link attaches the columns of the descriptor to their fields.
+func (desc *descriptor) link() error {
+	sort.Slice(desc.fields, func(i, j int) bool {
+		return desc.fields[i].id < desc.fields[j].id
+	})
+
+	ids := make(map[uint64]*Field, len(desc.fields))
+	for _, f := range desc.fields {
+		ids[f.id] = f
+		f.cols = f.cols[:0]
+	}
+	for _, cols := range [][]*column{desc.cols, desc.aliases} {
+		for _, col := range cols {
+			if col.field == noParent {
+				continue
+			}
+			f, ok := ids[col.field]
+			if !ok {
+				return fmt.Errorf("rntup: column %d refers to unknown field %d", col.id, col.field)
+			}
+			f.cols = append(f.cols, col)
+		}
+	}
+	for _, f := range desc.fields {
+		sort.Slice(f.cols, func(i, j int) bool {
+			return f.cols[i].index < f.cols[j].index
+		})
+		for i, col := range f.cols {
+			if int(col.index) != i {
+				return fmt.Errorf("rntup: field %q has no column with index %d", f.Name, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readFooter decodes the provided RNTuple footer envelope.
+func (desc *descriptor) readFooter(buf []byte) error {
+	dec, err := newDecoder(buf)
+	if err != nil {
+		return fmt.Errorf("rntup: could not decode footer: %w", err)
+	}
+
+	end := dec.frame()
+	_ = dec.u64() // reserved
+
+	nclusters := dec.u64()
+	for i := 0; i < int(nclusters) && dec.err == nil; i++ {
+		_ = dec.uuid() // ntuple uuid
+		cl := dec.cluster()
+		ncols := dec.u32()
+		for j := 0; j < int(ncols) && dec.err == nil; j++ {
+			id := dec.u64()
+			cl.ranges[id] = colRange{
+				first: dec.u64(),
+				n:     dec.u32(),
+				comp:  int64(dec.u64()),
+			}
+			npages := dec.u32()
+			pages := make([]page, 0, npages)
+			for k := 0; k < int(npages) && dec.err == nil; k++ {
+				pages = append(pages, page{
+					n:   dec.u32(),
+					loc: dec.locator(),
+				})
+			}
+			cl.pages[id] = pages
+		}
+		desc.clusters = append(desc.clusters, cl)
+	}
+	if dec.err == nil && end < dec.pos {
+		dec.err = fmt.Errorf("rntup: invalid footer frame end %d (pos=%d)", end, dec.pos)
+	}
+
+	if dec.err != nil {
+		return fmt.Errorf("rntup: could not decode footer: %w", dec.err)
+	}
+	return nil
+}
+
+// field returns the field with the provided id.
+func (desc *descriptor) field(id uint64) *Field {
+	for _, f := range desc.fields {
+		if f.id == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// isTop returns whether the provided field is a top-level field, i.e.
+// a direct child of the (unnamed) zero field.
+func (desc *descriptor) isTop(f *Field) bool {
+	if f.parent == f.id {
+		// 1.0 format: top-level fields are their own parent.
+		return true
+	}
+	if f.parent == noParent {
+		return f.Name != ""
+	}
+	p := desc.field(f.parent)
+	return p != nil && p.parent == noParent && p.Name == ""
+}
+
+// encoder encodes the little-endian envelopes of RNTuple headers and
+// footers.
+type encoder struct {
+	buf []byte
+}
+
+func (enc *encoder) u32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	enc.buf = append(enc.buf, b[:]...)
+}
+
+func (enc *encoder) u64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	enc.buf = append(enc.buf, b[:]...)
+}
+
+func (enc *encoder) str(v string) {
+	enc.u32(uint32(len(v)))
+	enc.buf = append(enc.buf, v...)
+}
@@ -0,0 +1,642 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/zeebo/xxh3"
+)
+
+// envType describes the kind of an envelope of the 1.0 format.
+type envType uint16
+
+const (
+	envHeader   envType = 0x01
+	envFooter   envType = 0x02
+	envPageList envType = 0x03
+)
+
+func (typ envType) String() string {
+	switch typ {
+	case envHeader:
+		return "header"
+	case envFooter:
+		return "footer"
+	case envPageList:
+		return "page list"
+	}
+	return fmt.Sprintf("envelope-0x%x", uint16(typ))
+}
+
+// colTypesV1 maps the column types of the 1.0 format to the on-disk
+// representation and encoding of their elements.
+var colTypesV1 = map[uint16]struct {
+	typ colType
+	enc encoding
+}{
+	0x01: {colIndex64, encPlain}, // Index64
+	0x02: {colIndex, encPlain},   // Index32
+	0x03: {colSwitch, encPlain},  // Switch
+	0x04: {colByte, encPlain},    // Byte
+	0x05: {colByte, encPlain},    // Char
+	0x06: {colBit, encPlain},     // Bit
+	0x07: {colReal64, encPlain},  // Real64
+	0x08: {colReal32, encPlain},  // Real32
+	0x09: {colReal16, encPlain},  // Real16
+	0x0a: {colInt64, encPlain},   // Int64
+	0x0b: {colInt64, encPlain},   // UInt64
+	0x0c: {colInt32, encPlain},   // Int32
+	0x0d: {colInt32, encPlain},   // UInt32
+	0x0e: {colInt16, encPlain},   // Int16
+	0x0f: {colInt16, encPlain},   // UInt16
+	0x10: {colInt8, encPlain},    // Int8
+	0x11: {colInt8, encPlain},    // UInt8
+	0x12: {colIndex64, encDelta}, // SplitIndex64
+	0x13: {colIndex, encDelta},   // SplitIndex32
+	0x14: {colReal64, encSplit},  // SplitReal64
+	0x15: {colReal32, encSplit},  // SplitReal32
+	0x16: {colInt64, encZigzag},  // SplitInt64
+	0x17: {colInt64, encSplit},   // SplitUInt64
+	0x18: {colInt32, encZigzag},  // SplitInt32
+	0x19: {colInt32, encSplit},   // SplitUInt32
+	0x1a: {colInt16, encZigzag},  // SplitInt16
+	0x1b: {colInt16, encSplit},   // SplitUInt16
+}
+
+// newEnvelope checks the preamble and the XXH3-64 checksum of the
+// provided envelope and returns a decoder for its payload.
+func newEnvelope(buf []byte, typ envType) (*decoder, error) {
+	if len(buf) < 16 {
+		return nil, fmt.Errorf("rntup: %v envelope too short (len=%d)", typ, len(buf))
+	}
+	var (
+		pre = binary.LittleEndian.Uint64(buf)
+		n   = len(buf) - 8
+	)
+	if got := envType(pre & 0xffff); got != typ {
+		return nil, fmt.Errorf("rntup: invalid envelope type (got=%v, want=%v)", got, typ)
+	}
+	if got := pre >> 16; got != uint64(len(buf)) {
+		return nil, fmt.Errorf("rntup: invalid %v envelope length (got=%d, want=%d)", typ, got, len(buf))
+	}
+	var (
+		want = binary.LittleEndian.Uint64(buf[n:])
+		got  = xxh3.Hash(buf[:n])
+	)
+	if got != want {
+		return nil, fmt.Errorf("rntup: invalid %v envelope checksum (got=0x%x, want=0x%x)", typ, got, want)
+	}
+	return &decoder{buf: buf[:n], pos: 8}, nil
+}
+
+func (dec *decoder) u16() uint16 {
+	b := dec.next(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+// features reads the feature flags of an envelope.
+// None of the optional features of the 1.0 format is supported.
+func (dec *decoder) features() {
+	for dec.err == nil {
+		v := dec.u64()
+		if v&^(1<<63) != 0 {
+			dec.err = fmt.Errorf("rntup: unsupported feature flags 0x%x", v)
+		}
+		if v&(1<<63) == 0 {
+			return
+		}
+	}
+}
+
+// record reads a record frame preamble and returns the offset of the end
+// of the frame.
+func (dec *decoder) record() int {
+	beg := dec.pos
+	size := int64(dec.u64())
+	if dec.err == nil && size < 0 {
+		dec.err = fmt.Errorf("rntup: invalid record frame at offset %d", beg)
+	}
+	return beg + int(size)
+}
+
+// list reads a list frame preamble and returns the offset of the end of
+// the frame and its number of items.
+func (dec *decoder) list() (end, n int) {
+	beg := dec.pos
+	size := int64(dec.u64())
+	if dec.err == nil && size >= 0 {
+		dec.err = fmt.Errorf("rntup: invalid list frame at offset %d", beg)
+	}
+	n = int(dec.u32())
+	return beg + int(-size), n
+}
+
+func (dec *decoder) locatorV1() locator {
+	size := int32(dec.u32())
+	if dec.err == nil && size < 0 {
+		dec.err = fmt.Errorf("rntup: unsupported non-standard locator (type=0x%x)", uint32(size))
+		return locator{}
+	}
+	return locator{
+		pos:    int64(dec.u64()),
+		nbytes: uint32(size),
+	}
+}
+
+func (dec *decoder) fieldV1(id uint64) *Field {
+	end := dec.record()
+	_ = dec.u32() // field version
+	_ = dec.u32() // type version
+	f := &Field{
+		id:     id,
+		parent: uint64(dec.u32()),
+		kind:   structure(dec.u16()),
+	}
+	flags := dec.u16()
+	f.Name = dec.str()
+	f.Type = dec.str()
+	_ = dec.str() // type alias
+	f.Doc = dec.str()
+	if flags&0x01 != 0 {
+		f.nrep = dec.u64()
+	}
+	if flags&0x02 != 0 {
+		_ = dec.u32() // source field of a projected field
+	}
+	if flags&0x04 != 0 {
+		_ = dec.u32() // type checksum
+	}
+	dec.skip(end)
+	return f
+}
+
+func (dec *decoder) columnV1(id uint64) *column {
+	end := dec.record()
+	var (
+		typ   = dec.u16()
+		bits  = dec.u16()
+		field = dec.u32()
+		flags = dec.u16()
+		repr  = dec.u16()
+	)
+	if flags&0x01 != 0 {
+		_ = dec.u64() // first element index of a deferred column
+	}
+	if flags&0x02 != 0 {
+		_ = dec.u64() // minimum value
+		_ = dec.u64() // maximum value
+	}
+	dec.skip(end)
+
+	col := &column{
+		id:    id,
+		code:  typ,
+		typ:   colTypesV1[typ].typ,
+		enc:   colTypesV1[typ].enc,
+		field: uint64(field),
+	}
+	switch {
+	case col.typ == colIndex || col.typ == colIndex64:
+		col.sorted = true
+	case col.typ == colUnknown:
+		// unsupported column type: fields using it can not be read.
+	case col.typ == colBit && bits != 1, col.typ != colBit && int(bits) != 8*col.typ.size(1):
+		if dec.err == nil {
+			dec.err = fmt.Errorf("rntup: invalid number of bits (%d) for column %d of type 0x%x", bits, id, typ)
+		}
+	}
+	if repr != 0 {
+		// alternative representations of a field are not supported.
+		col.field = noParent
+	}
+	return col
+}
+
+// readSchemaV1 decodes the lists of fields, columns, alias columns and
+// extra type informations of a header or of a schema extension.
+func (desc *descriptor) readSchemaV1(dec *decoder) {
+	end, n := dec.list()
+	for i := 0; i < n && dec.err == nil; i++ {
+		desc.fields = append(desc.fields, dec.fieldV1(uint64(len(desc.fields))))
+	}
+	dec.skip(end)
+
+	end, n = dec.list()
+	for i := 0; i < n && dec.err == nil; i++ {
+		col := dec.columnV1(uint64(len(desc.cols)))
+		col.index = desc.nextIndex(col.field)
+		desc.cols = append(desc.cols, col)
+	}
+	dec.skip(end)
+
+	end, n = dec.list()
+	for i := 0; i < n && dec.err == nil; i++ {
+		end := dec.record()
+		var (
+			phys  = dec.u32()
+			field = dec.u32()
+		)
+		dec.skip(end)
+		if dec.err != nil {
+			break
+		}
+		if int(phys) >= len(desc.cols) {
+			dec.err = fmt.Errorf("rntup: alias column refers to unknown column %d", phys)
+			break
+		}
+		// alias columns of projected fields share the data of their
+		// physical column.
+		alias := *desc.cols[phys]
+		alias.field = uint64(field)
+		alias.index = desc.nextIndex(alias.field)
+		desc.aliases = append(desc.aliases, &alias)
+	}
+	dec.skip(end)
+
+	end, _ = dec.list() // extra type informations
+	dec.skip(end)
+}
+
+// nextIndex returns the index of the next column of the provided field.
+// Columns of the 1.0 format are numbered by order of appearance.
+func (desc *descriptor) nextIndex(field uint64) uint32 {
+	var n uint32
+	for _, cols := range [][]*column{desc.cols, desc.aliases} {
+		for _, col := range cols {
+			if col.field == field {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// readHeaderV1 decodes the provided RNTuple header envelope, with the
+// 1.0 format.
+func (desc *descriptor) readHeaderV1(buf []byte) error {
+	dec, err := newEnvelope(buf, envHeader)
+	if err != nil {
+		return fmt.Errorf("rntup: could not decode header: %w", err)
+	}
+	desc.hchk = binary.LittleEndian.Uint64(buf[len(buf)-8:])
+
+	dec.features()
+	desc.name = dec.str()
+	desc.desc = dec.str()
+	desc.author = dec.str() // writer
+	desc.readSchemaV1(dec)
+
+	if dec.err != nil {
+		return fmt.Errorf("rntup: could not decode header: %w", dec.err)
+	}
+
+	return desc.link()
+}
+
+// readFooterV1 decodes the provided RNTuple footer envelope and the page
+// lists it refers to, with the 1.0 format.
+func (desc *descriptor) readFooterV1(buf []byte, read func(loc locator, n int) ([]byte, error)) error {
+	dec, err := newEnvelope(buf, envFooter)
+	if err != nil {
+		return fmt.Errorf("rntup: could not decode footer: %w", err)
+	}
+
+	dec.features()
+	if hchk := dec.u64(); dec.err == nil && hchk != desc.hchk {
+		return fmt.Errorf("rntup: footer refers to an invalid header checksum (got=0x%x, want=0x%x)", hchk, desc.hchk)
+	}
+
+	{
+		end := dec.record() // schema extension
+		if dec.err == nil && dec.pos < end {
+			desc.readSchemaV1(dec)
+		}
+		dec.skip(end)
+	}
+
+	type group struct {
+		n   int
+		len uint64
+		loc locator
+	}
+	var groups []group
+	end, n := dec.list()
+	for i := 0; i < n && dec.err == nil; i++ {
+		end := dec.record()
+		_ = dec.u64() // minimum entry number
+		_ = dec.u64() // entry span
+		grp := group{n: int(dec.u32())}
+		grp.len = dec.u64()
+		grp.loc = dec.locatorV1()
+		dec.skip(end)
+		groups = append(groups, grp)
+	}
+	dec.skip(end)
+
+	if dec.err != nil {
+		return fmt.Errorf("rntup: could not decode footer: %w", dec.err)
+	}
+
+	err = desc.link()
+	if err != nil {
+		return err
+	}
+
+	for i, grp := range groups {
+		buf, err := read(grp.loc, int(grp.len))
+		if err != nil {
+			return fmt.Errorf("rntup: could not read page list of cluster group %d: %w", i, err)
+		}
+		err = desc.readPageListV1(buf, grp.n)
+		if err != nil {
+			return fmt.Errorf("rntup: could not decode page list of cluster group %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// readPageListV1 decodes the provided page list envelope, describing
+// nclusters clusters.
+func (desc *descriptor) readPageListV1(buf []byte, nclusters int) error {
+	dec, err := newEnvelope(buf, envPageList)
+	if err != nil {
+		return err
+	}
+
+	if hchk := dec.u64(); dec.err == nil && hchk != desc.hchk {
+		return fmt.Errorf("rntup: page list refers to an invalid header checksum (got=0x%x, want=0x%x)", hchk, desc.hchk)
+	}
+
+	first := len(desc.clusters)
+	end, n := dec.list()
+	for i := 0; i < n && dec.err == nil; i++ {
+		end := dec.record()
+		cl := cluster{
+			id:     uint64(len(desc.clusters)),
+			first:  dec.u64(),
+			ranges: make(map[uint64]colRange),
+			pages:  make(map[uint64][]page),
+		}
+		v := dec.u64()
+		cl.n = v & (1<<56 - 1)
+		if flags := v >> 56; dec.err == nil && flags != 0 {
+			dec.err = fmt.Errorf("rntup: unsupported cluster flags 0x%x", flags)
+		}
+		dec.skip(end)
+		desc.clusters = append(desc.clusters, cl)
+	}
+	dec.skip(end)
+
+	if dec.err == nil && n != nclusters {
+		return fmt.Errorf("rntup: invalid number of clusters (got=%d, want=%d)", n, nclusters)
+	}
+
+	end, n = dec.list()
+	if dec.err == nil && n != nclusters {
+		return fmt.Errorf("rntup: invalid number of cluster page locations (got=%d, want=%d)", n, nclusters)
+	}
+	for i := 0; i < n && dec.err == nil; i++ {
+		cl := &desc.clusters[first+i]
+		end, ncols := dec.list()
+		for j := 0; j < ncols && dec.err == nil; j++ {
+			end, npages := dec.list()
+			var (
+				nelts uint64
+				pages = make([]page, 0, npages)
+			)
+			for k := 0; k < npages && dec.err == nil; k++ {
+				var (
+					n   = int32(dec.u32())
+					chk = n < 0
+				)
+				if chk {
+					n = -n
+				}
+				p := page{n: uint32(n), loc: dec.locatorV1()}
+				p.loc.chk = chk
+				pages = append(pages, p)
+				nelts += uint64(n)
+			}
+			offset := int64(dec.u64())
+			if offset >= 0 {
+				// suppressed columns have a negative offset and no
+				// compression settings.
+				cl.ranges[uint64(j)] = colRange{
+					first: uint64(offset),
+					n:     uint32(nelts),
+					comp:  int64(dec.u32()),
+				}
+				cl.pages[uint64(j)] = pages
+			}
+			dec.skip(end)
+		}
+		dec.skip(end)
+	}
+	dec.skip(end)
+
+	if dec.err != nil {
+		return dec.err
+	}
+	return nil
+}
+
+func (enc *encoder) u16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	enc.buf = append(enc.buf, b[:]...)
+}
+
+// record writes a record frame preamble and returns the offset of the
+// beginning of the frame.
+// The size of the frame is set by a subsequent call to closeRecord.
+func (enc *encoder) record() int {
+	beg := len(enc.buf)
+	enc.u64(0)
+	return beg
+}
+
+func (enc *encoder) closeRecord(beg int) {
+	binary.LittleEndian.PutUint64(enc.buf[beg:], uint64(len(enc.buf)-beg))
+}
+
+// list writes a list frame preamble for n items and returns the offset
+// of the beginning of the frame.
+// The size of the frame is set by a subsequent call to closeList.
+func (enc *encoder) list(n int) int {
+	beg := len(enc.buf)
+	enc.u64(0)
+	enc.u32(uint32(n))
+	return beg
+}
+
+func (enc *encoder) closeList(beg int) {
+	binary.LittleEndian.PutUint64(enc.buf[beg:], uint64(-int64(len(enc.buf)-beg)))
+}
+
+func (enc *encoder) locatorV1(loc locator) {
+	enc.u32(loc.nbytes)
+	enc.u64(uint64(loc.pos))
+}
+
+// envelope writes the preamble of an envelope of the provided type.
+// The envelope is completed by a subsequent call to sealV1.
+func (enc *encoder) envelope(typ envType) {
+	enc.buf = enc.buf[:0]
+	enc.u64(uint64(typ))
+}
+
+// sealV1 sets the length of the envelope, appends its XXH3-64 checksum
+// and returns it.
+func (enc *encoder) sealV1() []byte {
+	pre := binary.LittleEndian.Uint64(enc.buf) & 0xffff
+	binary.LittleEndian.PutUint64(enc.buf, pre|uint64(len(enc.buf)+8)<<16)
+	enc.u64(xxh3.Hash(enc.buf))
+	return enc.buf
+}
+
+// emptySchemaV1 writes the empty lists of fields, columns, alias columns
+// and extra type informations of a schema.
+func (enc *encoder) emptySchemaV1() {
+	for i := 0; i < 4; i++ {
+		enc.closeList(enc.list(0))
+	}
+}
+
+// writeHeaderV1 encodes the RNTuple header envelope, with the 1.0 format.
+func (desc *descriptor) writeHeaderV1() []byte {
+	var enc encoder
+	enc.envelope(envHeader)
+	enc.u64(0) // feature flags
+	enc.str(desc.name)
+	enc.str(desc.desc)
+	enc.str(desc.author)
+
+	beg := enc.list(len(desc.fields))
+	for _, f := range desc.fields {
+		beg := enc.record()
+		enc.u32(0) // field version
+		enc.u32(0) // type version
+		enc.u32(uint32(f.parent))
+		enc.u16(uint16(f.kind))
+		flags := uint16(0)
+		if f.nrep != 0 {
+			flags |= 0x01
+		}
+		enc.u16(flags)
+		enc.str(f.Name)
+		enc.str(f.Type)
+		enc.str("") // type alias
+		enc.str(f.Doc)
+		if f.nrep != 0 {
+			enc.u64(f.nrep)
+		}
+		enc.closeRecord(beg)
+	}
+	enc.closeList(beg)
+
+	beg = enc.list(len(desc.cols))
+	for _, col := range desc.cols {
+		beg := enc.record()
+		enc.u16(col.code)
+		bits := 8 * col.typ.size(1)
+		if col.typ == colBit {
+			bits = 1
+		}
+		enc.u16(uint16(bits))
+		enc.u32(uint32(col.field))
+		enc.u16(0) // flags
+		enc.u16(0) // representation index
+		enc.closeRecord(beg)
+	}
+	enc.closeList(beg)
+
+	enc.closeList(enc.list(0)) // alias columns
+	enc.closeList(enc.list(0)) // extra type informations
+
+	buf := enc.sealV1()
+	desc.hchk = binary.LittleEndian.Uint64(buf[len(buf)-8:])
+	return buf
+}
+
+// writePageListV1 encodes the page list envelope of all the clusters of
+// the RNTuple, with the 1.0 format.
+func (desc *descriptor) writePageListV1() []byte {
+	var enc encoder
+	enc.envelope(envPageList)
+	enc.u64(desc.hchk)
+
+	beg := enc.list(len(desc.clusters))
+	for _, cl := range desc.clusters {
+		beg := enc.record()
+		enc.u64(cl.first)
+		enc.u64(cl.n) // number of entries, without flags
+		enc.closeRecord(beg)
+	}
+	enc.closeList(beg)
+
+	beg = enc.list(len(desc.clusters))
+	for _, cl := range desc.clusters {
+		beg := enc.list(len(desc.cols))
+		for _, col := range desc.cols {
+			pages := cl.pages[col.id]
+			beg := enc.list(len(pages))
+			for _, p := range pages {
+				n := int32(p.n)
+				if p.loc.chk {
+					n = -n
+				}
+				enc.u32(uint32(n))
+				enc.locatorV1(p.loc)
+			}
+			rng := cl.ranges[col.id]
+			enc.u64(rng.first)
+			enc.u32(uint32(rng.comp))
+			enc.closeList(beg)
+		}
+		enc.closeList(beg)
+	}
+	enc.closeList(beg)
+
+	return enc.sealV1()
+}
+
+// writeFooterV1 encodes the RNTuple footer envelope, with the 1.0 format.
+// The footer refers to a single cluster group, whose page list is
+// described by the provided length and location.
+func (desc *descriptor) writeFooterV1(n uint64, loc locator) []byte {
+	var enc encoder
+	enc.envelope(envFooter)
+	enc.u64(0) // feature flags
+	enc.u64(desc.hchk)
+
+	beg := enc.record() // schema extension
+	enc.emptySchemaV1()
+	enc.closeRecord(beg)
+
+	var nentries uint64
+	for _, cl := range desc.clusters {
+		nentries += cl.n
+	}
+
+	beg = enc.list(1)
+	{
+		beg := enc.record()
+		enc.u64(0)        // minimum entry number
+		enc.u64(nentries) // entry span
+		enc.u32(uint32(len(desc.clusters)))
+		enc.u64(n)
+		enc.locatorV1(loc)
+		enc.closeRecord(beg)
+	}
+	enc.closeList(beg)
+
+	return enc.sealV1()
+}
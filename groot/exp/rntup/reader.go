@@ -0,0 +1,323 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ReadVar describes a field to be read out of an NTuple.
+type ReadVar struct {
+	Name  string      // name of the field to read
+	Value interface{} // pointer to the value to fill
+}
+
+// NewReadVars returns the complete set of ReadVars to read all the
+// supported top-level fields of the provided NTuple.
+func NewReadVars(nt *NTuple) []ReadVar {
+	var rvars []ReadVar
+	for _, f := range nt.Fields() {
+		rt, _, err := nt.desc.typeOf(&f)
+		if err != nil {
+			continue
+		}
+		rvars = append(rvars, ReadVar{
+			Name:  f.Name,
+			Value: reflect.New(rt).Interface(),
+		})
+	}
+	return rvars
+}
+
+// ReadOption configures how an NTuple should be traversed.
+type ReadOption func(r *Reader) error
+
+// WithRange specifies the half-open interval [beg, end) of entries
+// an NTuple reader will read through.
+func WithRange(beg, end int64) ReadOption {
+	return func(r *Reader) error {
+		r.beg = beg
+		r.end = end
+		return nil
+	}
+}
+
+// Reader reads data from an NTuple.
+type Reader struct {
+	nt  *NTuple
+	beg int64
+	end int64
+
+	rvars []ReadVar
+	rflds []*rfield
+}
+
+// NewReader creates a new NTuple Reader from the provided NTuple and
+// the set of read-variables into which data will be read.
+func NewReader(nt *NTuple, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
+	err := nt.load()
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not create reader: %w", err)
+	}
+
+	r := Reader{
+		nt:    nt,
+		beg:   0,
+		end:   -1,
+		rvars: rvars,
+		rflds: make([]*rfield, len(rvars)),
+	}
+
+	for i, opt := range opts {
+		err := opt(&r)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"rntup: could not set reader option %d: %w",
+				i, err,
+			)
+		}
+	}
+
+	nentries := nt.Entries()
+	if r.end < 0 {
+		r.end = nentries
+	}
+	if r.beg < 0 {
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (start=%d < 0)", r.beg, r.end, r.beg)
+	}
+	if r.beg > r.end {
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (start=%d > end=%d)", r.beg, r.end, r.beg, r.end)
+	}
+	if r.beg > nentries {
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (start=%d > nentries=%d)", r.beg, r.end, r.beg, nentries)
+	}
+	if r.end > nentries {
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (end=%d > nentries=%d)", r.beg, r.end, r.end, nentries)
+	}
+
+	for i, rv := range rvars {
+		r.rflds[i], err = newRField(nt.desc, rv)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not create reader: %w", err)
+		}
+	}
+
+	return &r, nil
+}
+
+// Close closes the Reader.
+func (r *Reader) Close() error {
+	for _, rf := range r.rflds {
+		rf.data = nil
+	}
+	return nil
+}
+
+// RCtx provides an entry-wise local context to the NTuple Reader.
+type RCtx struct {
+	Entry int64 // Current NTuple entry.
+}
+
+// Read will read data from the underlying NTuple over the whole specified
+// range.
+// Read calls the provided user function f for each entry successfully read.
+func (r *Reader) Read(f func(ctx RCtx) error) error {
+	for i := range r.nt.desc.clusters {
+		cl := &r.nt.desc.clusters[i]
+		var (
+			beg = int64(cl.first)
+			end = beg + int64(cl.n)
+		)
+		if end <= r.beg || r.end <= beg {
+			continue
+		}
+		if beg < r.beg {
+			beg = r.beg
+		}
+		if end > r.end {
+			end = r.end
+		}
+
+		for _, rf := range r.rflds {
+			err := rf.load(r.nt, cl)
+			if err != nil {
+				return fmt.Errorf("rntup: could not load cluster %d: %w", cl.id, err)
+			}
+		}
+
+		for ientry := beg; ientry < end; ientry++ {
+			i := ientry - int64(cl.first)
+			for _, rf := range r.rflds {
+				err := rf.read(i)
+				if err != nil {
+					return fmt.Errorf("rntup: could not read entry %d: %w", ientry, err)
+				}
+			}
+			err := f(RCtx{Entry: ientry})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rfield reads the values of a field into a ReadVar.
+type rfield struct {
+	name string
+	cols []*column
+	data [][]byte // decoded elements of each column for the current cluster
+	read func(i int64) error
+}
+
+func newRField(desc *descriptor, rv ReadVar) (*rfield, error) {
+	var fd *Field
+	for _, f := range desc.fields {
+		if f.Name == rv.Name && desc.isTop(f) {
+			fd = f
+			break
+		}
+	}
+	if fd == nil {
+		return nil, fmt.Errorf("could not find field %q", rv.Name)
+	}
+
+	rt, cols, err := desc.typeOf(fd)
+	if err != nil {
+		return nil, err
+	}
+	if got, want := reflect.TypeOf(rv.Value), reflect.PtrTo(rt); got != want {
+		return nil, fmt.Errorf("field %q of type %q can not be read into %v (want=%v)", fd.Name, fd.Type, got, want)
+	}
+
+	rf := &rfield{name: fd.Name, cols: cols}
+
+	v := rv.Value
+	if rt.Kind() == reflect.Array {
+		// read directly into the user array, through a slice.
+		v = reflect.ValueOf(v).Elem().Slice(0, rt.Len()).Interface()
+	}
+
+	if ptr, ok := v.(*string); ok {
+		rf.read = func(i int64) error {
+			beg, end, err := rf.offsets(i)
+			if err != nil {
+				return err
+			}
+			*ptr = string(rf.data[1][beg:end])
+			return nil
+		}
+		return rf, nil
+	}
+
+	ok := bind(rf, v, getBool) ||
+		bind(rf, v, getI8) || bind(rf, v, getU8) ||
+		bind(rf, v, getI16) || bind(rf, v, getU16) ||
+		bind(rf, v, getI32) || bind(rf, v, getU32) ||
+		bind(rf, v, getI64) || bind(rf, v, getU64) ||
+		bind(rf, v, getF32) || bind(rf, v, getF64)
+	if !ok {
+		panic(fmt.Errorf("rntup: unhandled type %T", rv.Value)) // impossible, types checked above.
+	}
+
+	return rf, nil
+}
+
+// bind configures the field to read values of type T, []T (for fixed-size
+// arrays) or *[]T (for collections) into v.
+func bind[T any](rf *rfield, v interface{}, get getter[T]) bool {
+	switch v := v.(type) {
+	case *T:
+		rf.read = func(i int64) error {
+			*v = get(rf.data[0], i)
+			return nil
+		}
+	case []T:
+		n := int64(len(v))
+		rf.read = func(i int64) error {
+			for j := range v {
+				v[j] = get(rf.data[0], i*n+int64(j))
+			}
+			return nil
+		}
+	case *[]T:
+		rf.read = func(i int64) error {
+			beg, end, err := rf.offsets(i)
+			if err != nil {
+				return err
+			}
+			n := int(end - beg)
+			if cap(*v) < n {
+				*v = make([]T, n)
+			}
+			*v = (*v)[:n]
+			for j := range *v {
+				(*v)[j] = get(rf.data[1], beg+int64(j))
+			}
+			return nil
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// offsets returns the half-open range of elements of the i-th entry of
+// the current cluster, for fields with an index column.
+func (rf *rfield) offsets(i int64) (beg, end int64, err error) {
+	get := func(j int64) int64 {
+		if rf.cols[0].typ == colIndex64 {
+			return int64(getU64(rf.data[0], j))
+		}
+		return int64(getU32(rf.data[0], j))
+	}
+	end = get(i)
+	if i > 0 {
+		beg = get(i - 1)
+	}
+	if beg > end || rf.cols[1].typ.size(int(end)) > len(rf.data[1]) {
+		return 0, 0, fmt.Errorf("invalid offsets [%d, %d) for field %q", beg, end, rf.name)
+	}
+	return beg, end, nil
+}
+
+// load reads and decompresses the pages of the provided cluster for all
+// the columns of the field.
+func (rf *rfield) load(nt *NTuple, cl *cluster) error {
+	rf.data = rf.data[:0]
+	for _, col := range rf.cols {
+		rng, ok := cl.ranges[col.id]
+		if !ok {
+			return fmt.Errorf("no range for column %d of field %q", col.id, rf.name)
+		}
+
+		var (
+			n    = 0
+			data = make([]byte, 0, col.typ.size(int(rng.n)))
+		)
+		for _, p := range cl.pages[col.id] {
+			raw, err := nt.read(p.loc, col.typ.size(int(p.n)))
+			if err != nil {
+				return fmt.Errorf("could not read page of column %d of field %q: %w", col.id, rf.name, err)
+			}
+			raw = col.unpack(raw, int(p.n))
+			if col.typ == colBit {
+				data = appendBits(data, n, raw, int(p.n))
+			} else {
+				data = append(data, raw...)
+			}
+			n += int(p.n)
+		}
+		if n != int(rng.n) {
+			return fmt.Errorf(
+				"invalid number of elements for column %d of field %q (got=%d, want=%d)",
+				col.id, rf.name, n, rng.n,
+			)
+		}
+		rf.data = append(rf.data, data)
+	}
+	return nil
+}
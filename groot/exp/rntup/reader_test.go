@@ -0,0 +1,335 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+type staff struct {
+	Category int32
+	Flag     uint32
+	Age      int32
+	Service  int32
+	Children int32
+	Grade    int32
+	Step     int32
+	Hrweek   int32
+	Cost     int32
+	Division string
+	Nation   string
+}
+
+func openStaff(t *testing.T) *NTuple {
+	t.Helper()
+
+	f, err := riofs.Open("../../testdata/ntpl001_staff.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	nt, err := riofs.Get[*NTuple](f, "Staff")
+	if err != nil {
+		t.Fatalf("could not retrieve ntuple: %+v", err)
+	}
+	return nt
+}
+
+func TestNTupleFields(t *testing.T) {
+	nt := openStaff(t)
+
+	if got, want := nt.Entries(), int64(3354); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	var (
+		names []string
+		types []string
+	)
+	for _, f := range nt.Fields() {
+		names = append(names, f.Name)
+		types = append(types, f.Type)
+	}
+
+	want := []string{
+		"Category", "Flag", "Age", "Service", "Children", "Grade",
+		"Step", "Hrweek", "Cost", "Division", "Nation",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid fields:\ngot= %q\nwant=%q", names, want)
+	}
+	if got, want := types[1], "std::uint32_t"; got != want {
+		t.Fatalf("invalid type for %q: got=%q, want=%q", names[1], got, want)
+	}
+	if got, want := types[9], "std::string"; got != want {
+		t.Fatalf("invalid type for %q: got=%q, want=%q", names[9], got, want)
+	}
+}
+
+func TestReader(t *testing.T) {
+	nt := openStaff(t)
+
+	for _, tc := range []struct {
+		name string
+		beg  int64
+		end  int64
+		want map[int64]staff
+		n    int64
+		cost int64
+	}{
+		{
+			name: "all",
+			beg:  0,
+			end:  -1,
+			want: map[int64]staff{
+				0:    {202, 15, 58, 28, 0, 10, 13, 40, 11975, "PS", "DE"},
+				1:    {530, 15, 63, 33, 0, 9, 13, 40, 10228, "EP", "CH"},
+				2:    {316, 15, 56, 31, 2, 9, 13, 40, 10730, "PS", "FR"},
+				3353: {500, 5, 43, 0, 2, 12, 4, 40, 12716, "DG", "ZZ"},
+			},
+			n:    3354,
+			cost: 29083929,
+		},
+		{
+			name: "range",
+			beg:  1,
+			end:  3,
+			want: map[int64]staff{
+				1: {530, 15, 63, 33, 0, 9, 13, 40, 10228, "EP", "CH"},
+				2: {316, 15, 56, 31, 2, 9, 13, 40, 10730, "PS", "FR"},
+			},
+			n:    2,
+			cost: 10228 + 10730,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var data staff
+			rvars := []ReadVar{
+				{Name: "Category", Value: &data.Category},
+				{Name: "Flag", Value: &data.Flag},
+				{Name: "Age", Value: &data.Age},
+				{Name: "Service", Value: &data.Service},
+				{Name: "Children", Value: &data.Children},
+				{Name: "Grade", Value: &data.Grade},
+				{Name: "Step", Value: &data.Step},
+				{Name: "Hrweek", Value: &data.Hrweek},
+				{Name: "Cost", Value: &data.Cost},
+				{Name: "Division", Value: &data.Division},
+				{Name: "Nation", Value: &data.Nation},
+			}
+
+			r, err := NewReader(nt, rvars, WithRange(tc.beg, tc.end))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			var (
+				n    int64
+				cost int64
+			)
+			err = r.Read(func(ctx RCtx) error {
+				if want, ok := tc.want[ctx.Entry]; ok && data != want {
+					t.Fatalf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, data, want)
+				}
+				n++
+				cost += int64(data.Cost)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read ntuple: %+v", err)
+			}
+
+			if n != tc.n {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", n, tc.n)
+			}
+			if cost != tc.cost {
+				t.Fatalf("invalid sum of costs: got=%d, want=%d", cost, tc.cost)
+			}
+		})
+	}
+}
+
+func TestNewReadVars(t *testing.T) {
+	nt := openStaff(t)
+
+	rvars := NewReadVars(nt)
+	if got, want := len(rvars), 11; got != want {
+		t.Fatalf("invalid number of read-vars: got=%d, want=%d", got, want)
+	}
+
+	r, err := NewReader(nt, rvars, WithRange(0, 1))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx RCtx) error {
+		if got, want := *rvars[1].Value.(*uint32), uint32(15); got != want {
+			t.Fatalf("invalid flag: got=%d, want=%d", got, want)
+		}
+		if got, want := *rvars[10].Value.(*string), "DE"; got != want {
+			t.Fatalf("invalid nation: got=%q, want=%q", got, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read ntuple: %+v", err)
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	nt := openStaff(t)
+
+	for _, tc := range []struct {
+		name  string
+		rvars []ReadVar
+		opts  []ReadOption
+		err   string
+	}{
+		{
+			name:  "no-such-field",
+			rvars: []ReadVar{{Name: "not-there", Value: new(int32)}},
+			err:   `rntup: could not create reader: could not find field "not-there"`,
+		},
+		{
+			name:  "invalid-type",
+			rvars: []ReadVar{{Name: "Age", Value: new(float64)}},
+			err:   `rntup: could not create reader: field "Age" of type "std::int32_t" can not be read into *float64 (want=*int32)`,
+		},
+		{
+			name: "invalid-range",
+			opts: []ReadOption{WithRange(0, 4000)},
+			err:  "rntup: invalid event reader range [0, 4000) (end=4000 > nentries=3354)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader(nt, tc.rvars, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+type eventV1 struct {
+	B   bool
+	I8  int8
+	I16 int16
+	I32 int32
+	I64 int64
+	U8  uint8
+	U16 uint16
+	U32 uint32
+	U64 uint64
+	F32 float32
+	F64 float64
+	Str string
+	Arr [3]float64
+	Vec []float32
+}
+
+func TestReaderV1(t *testing.T) {
+	f, err := riofs.Open("../../testdata/rntuple-v1.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	nt, err := riofs.Get[*NTuple](f, "ntpl")
+	if err != nil {
+		t.Fatalf("could not retrieve ntuple: %+v", err)
+	}
+
+	if got, want := nt.Entries(), int64(10); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	if got, want := len(nt.desc.clusters), 2; got != want {
+		t.Fatalf("invalid number of clusters: got=%d, want=%d", got, want)
+	}
+
+	var types []string
+	for _, f := range nt.Fields() {
+		types = append(types, f.Name+":"+f.Type)
+	}
+	want := []string{
+		"b:bool",
+		"i8:std::int8_t", "i16:std::int16_t", "i32:std::int32_t", "i64:std::int64_t",
+		"u8:std::uint8_t", "u16:std::uint16_t", "u32:std::uint32_t", "u64:std::uint64_t",
+		"f32:float", "f64:double",
+		"str:std::string",
+		"arr:std::array<double,3>",
+		"vec:std::vector<float>",
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("invalid fields:\ngot= %q\nwant=%q", types, want)
+	}
+
+	var data eventV1
+	rvars := []ReadVar{
+		{Name: "b", Value: &data.B},
+		{Name: "i8", Value: &data.I8},
+		{Name: "i16", Value: &data.I16},
+		{Name: "i32", Value: &data.I32},
+		{Name: "i64", Value: &data.I64},
+		{Name: "u8", Value: &data.U8},
+		{Name: "u16", Value: &data.U16},
+		{Name: "u32", Value: &data.U32},
+		{Name: "u64", Value: &data.U64},
+		{Name: "f32", Value: &data.F32},
+		{Name: "f64", Value: &data.F64},
+		{Name: "str", Value: &data.Str},
+		{Name: "arr", Value: &data.Arr},
+		{Name: "vec", Value: &data.Vec},
+	}
+
+	r, err := NewReader(nt, rvars)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	n := 0
+	err = r.Read(func(ctx RCtx) error {
+		i := ctx.Entry
+		want := eventV1{
+			B:   i%2 == 0,
+			I8:  int8(-i),
+			I16: int16(-i),
+			I32: int32(-i),
+			I64: -i,
+			U8:  uint8(i),
+			U16: uint16(i),
+			U32: uint32(i),
+			U64: uint64(i),
+			F32: float32(i),
+			F64: float64(i),
+			Str: fmt.Sprintf("str-%d", i),
+			Arr: [3]float64{float64(i), float64(i + 1), float64(i + 2)},
+		}
+		for j := int64(0); j < i; j++ {
+			want.Vec = append(want.Vec, float32(i))
+		}
+		if !reflect.DeepEqual(data, want) {
+			t.Fatalf("invalid entry %d:\ngot= %+v\nwant=%+v", i, data, want)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read ntuple: %+v", err)
+	}
+	if n != 10 {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", n, 10)
+	}
+}
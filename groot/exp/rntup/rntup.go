@@ -3,45 +3,216 @@
 // license that can be found in the LICENSE file.
 
 // Package rntup contains types to handle RNTuple-related data.
+//
+// RNTuple is the columnar successor of TTree.
+// rntup can read RNTuples with the pre-release (v0) on-disk format of
+// ROOT 6.22 and 6.24 and with the 1.0 on-disk format of ROOT 6.34 and
+// later (ROOT::RNTuple anchor, typed envelopes and XXH3-64 checksums).
+// The release candidates of the 1.0 format, written by ROOT 6.30 and 6.32,
+// are not supported.
+// Supported top-level fields are booleans, integers, floating point
+// values, std::string or std::array and std::vector of booleans, integers
+// and floating point values.
 package rntup // import "go-hep.org/x/hep/groot/exp/rntup"
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"reflect"
 
+	"github.com/zeebo/xxh3"
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 )
 
 type span struct {
 	seek   uint64
-	nbytes uint32
-	length uint32
+	nbytes uint64
+	length uint64
 }
 
 type NTuple struct {
-	rvers uint32
-	size  uint32
+	// version of the on-disk format.
+	// epoch is 0 for the pre-release format and 1 for the 1.0 format.
+	epoch uint16
+	major uint16
+	minor uint16
+	patch uint16
+
+	rvers uint32 // version of the pre-release format
+	size  uint32 // size of the pre-release anchor
 
 	header span
 	footer span
 
-	reserved uint64
+	reserved   uint64 // pre-release format only
+	maxKeySize uint64 // maximum size of a key, 1.0 format only
+
+	f    *riofs.File // underlying file
+	desc *descriptor // decoded header and footer
+	err  error       // error encountered while decoding header and footer
 }
 
-func (*NTuple) Class() string {
-	return "ROOT::Experimental::RNTuple"
+const (
+	classV0 = "ROOT::Experimental::RNTuple"
+	classV1 = "ROOT::RNTuple"
+
+	versV0 = 1 // FIXME(sbinet): generate through gen.rboot
+	versV1 = 2
+
+	// versRC is the first class version of the ROOT::Experimental::RNTuple
+	// anchor written by the release candidates of the 1.0 format.
+	versRC = 4
+
+	// anchorSize is the size of the fields of the 1.0 anchor.
+	anchorSize = 4*2 + 7*8
+
+	// defaultMaxKeySize is the default maximum size of a key, as set by ROOT.
+	defaultMaxKeySize = 1 << 30
+)
+
+func (nt *NTuple) Class() string {
+	if nt.epoch == 0 {
+		return classV0
+	}
+	return classV1
 }
 
-func (*NTuple) RVersion() int16 {
-	return 0 // FIXME(sbinet): generate through gen.rboot
+func (nt *NTuple) RVersion() int16 {
+	if nt.epoch == 0 {
+		return versV0
+	}
+	return versV1
 }
 
 func (nt *NTuple) String() string {
-	return fmt.Sprintf("NTuple{version:%d, size:%d, header:%v, footer:%v}",
-		nt.rvers, nt.size, nt.header, nt.footer,
+	if nt.epoch == 0 {
+		return fmt.Sprintf("NTuple{version:%d, size:%d, header:%v, footer:%v}",
+			nt.rvers, nt.size, nt.header, nt.footer,
+		)
+	}
+	return fmt.Sprintf("NTuple{version:%d.%d.%d.%d, header:%v, footer:%v, max-key-size:%d}",
+		nt.epoch, nt.major, nt.minor, nt.patch, nt.header, nt.footer, nt.maxKeySize,
+	)
+}
+
+// SetFile sets the file holding the NTuple data.
+func (nt *NTuple) SetFile(f *riofs.File) {
+	nt.f = f
+	nt.desc = nil
+	nt.err = nil
+}
+
+// Entries returns the number of entries of the NTuple.
+func (nt *NTuple) Entries() int64 {
+	if nt.load() != nil {
+		return 0
+	}
+	var n int64
+	for _, cl := range nt.desc.clusters {
+		n += int64(cl.n)
+	}
+	return n
+}
+
+// Fields returns the top-level fields of the NTuple.
+func (nt *NTuple) Fields() []Field {
+	if nt.load() != nil {
+		return nil
+	}
+	var fields []Field
+	for _, f := range nt.desc.fields {
+		if nt.desc.isTop(f) {
+			fields = append(fields, *f)
+		}
+	}
+	return fields
+}
+
+// load reads and decodes the header and footer of the NTuple.
+func (nt *NTuple) load() error {
+	if nt.desc != nil || nt.err != nil {
+		return nt.err
+	}
+	if nt.f == nil {
+		nt.err = fmt.Errorf("rntup: no file attached to NTuple")
+		return nt.err
+	}
+
+	var (
+		desc       descriptor
+		readHeader = desc.readHeader
+		readFooter = desc.readFooter
 	)
+	if nt.epoch != 0 {
+		readHeader = desc.readHeaderV1
+		readFooter = func(buf []byte) error { return desc.readFooterV1(buf, nt.read) }
+	}
+
+	hdr, err := nt.read(locator{pos: int64(nt.header.seek), nbytes: uint32(nt.header.nbytes)}, int(nt.header.length))
+	if err == nil {
+		err = readHeader(hdr)
+	}
+	if err != nil {
+		nt.err = fmt.Errorf("rntup: could not read header: %w", err)
+		return nt.err
+	}
+
+	ftr, err := nt.read(locator{pos: int64(nt.footer.seek), nbytes: uint32(nt.footer.nbytes)}, int(nt.footer.length))
+	if err == nil {
+		err = readFooter(ftr)
+	}
+	if err != nil {
+		nt.err = fmt.Errorf("rntup: could not read footer: %w", err)
+		return nt.err
+	}
+
+	nt.desc = &desc
+	return nil
+}
+
+// read reads the n bytes of (possibly compressed) data at the provided
+// location.
+func (nt *NTuple) read(loc locator, n int) ([]byte, error) {
+	if loc.url != "" {
+		return nil, fmt.Errorf("rntup: unsupported external locator %q", loc.url)
+	}
+	size := int(loc.nbytes)
+	if loc.chk {
+		size += 8
+	}
+	src := make([]byte, size)
+	_, err := nt.f.ReadAt(src, loc.pos)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not read %d bytes at %d: %w", size, loc.pos, err)
+	}
+	if loc.chk {
+		var (
+			want = binary.LittleEndian.Uint64(src[loc.nbytes:])
+			got  = xxh3.Hash(src[:loc.nbytes])
+		)
+		if got != want {
+			return nil, fmt.Errorf("rntup: invalid checksum of %d bytes at %d (got=0x%x, want=0x%x)", loc.nbytes, loc.pos, got, want)
+		}
+		src = src[:loc.nbytes]
+	}
+	if int(loc.nbytes) == n {
+		return src, nil
+	}
+
+	dst := make([]byte, n)
+	err = rcompress.Decompress(dst, bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not decompress %d bytes at %d: %w", loc.nbytes, loc.pos, err)
+	}
+	return dst, nil
 }
 
 func (nt *NTuple) MarshalROOT(w *rbytes.WBuffer) (int, error) {
@@ -51,16 +222,39 @@ func (nt *NTuple) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 
 	hdr := w.WriteHeader(nt.Class(), nt.RVersion())
 
+	if nt.epoch != 0 {
+		var buf [anchorSize]byte
+		binary.BigEndian.PutUint16(buf[0:], nt.epoch)
+		binary.BigEndian.PutUint16(buf[2:], nt.major)
+		binary.BigEndian.PutUint16(buf[4:], nt.minor)
+		binary.BigEndian.PutUint16(buf[6:], nt.patch)
+		binary.BigEndian.PutUint64(buf[8:], nt.header.seek)
+		binary.BigEndian.PutUint64(buf[16:], nt.header.nbytes)
+		binary.BigEndian.PutUint64(buf[24:], nt.header.length)
+		binary.BigEndian.PutUint64(buf[32:], nt.footer.seek)
+		binary.BigEndian.PutUint64(buf[40:], nt.footer.nbytes)
+		binary.BigEndian.PutUint64(buf[48:], nt.footer.length)
+		binary.BigEndian.PutUint64(buf[56:], nt.maxKeySize)
+		_, _ = w.Write(buf[:])
+		n, err := w.SetHeader(hdr)
+		if err != nil {
+			return n, err
+		}
+		// the checksum of the anchor is not part of its byte count.
+		w.WriteU64(xxh3.Hash(buf[:]))
+		return n + 8, w.Err()
+	}
+
 	w.WriteU32(nt.rvers)
 	w.WriteU32(nt.size)
 
 	w.WriteU64(nt.header.seek)
-	w.WriteU32(nt.header.nbytes)
-	w.WriteU32(nt.header.length)
+	w.WriteU32(uint32(nt.header.nbytes))
+	w.WriteU32(uint32(nt.header.length))
 
 	w.WriteU64(nt.footer.seek)
-	w.WriteU32(nt.footer.nbytes)
-	w.WriteU32(nt.footer.length)
+	w.WriteU32(uint32(nt.footer.nbytes))
+	w.WriteU32(uint32(nt.footer.length))
 
 	w.WriteU64(nt.reserved)
 
@@ -74,16 +268,60 @@ func (nt *NTuple) UnmarshalROOT(r *rbytes.RBuffer) error {
 
 	hdr := r.ReadHeader(nt.Class())
 
+	if nt.epoch == 0 && hdr.Vers >= versRC {
+		return fmt.Errorf(
+			"rntup: unsupported %s anchor version %d (release candidate of the 1.0 format)",
+			classV0, hdr.Vers,
+		)
+	}
+
+	if nt.epoch != 0 {
+		var buf [anchorSize]byte
+		_, err := r.Read(buf[:])
+		if err != nil {
+			return err
+		}
+		r.CheckHeader(hdr)
+		// the checksum of the anchor is not part of its byte count.
+		var (
+			want = r.ReadU64()
+			got  = xxh3.Hash(buf[:])
+		)
+		if r.Err() != nil {
+			return r.Err()
+		}
+		if got != want {
+			return fmt.Errorf("rntup: invalid anchor checksum (got=0x%x, want=0x%x)", got, want)
+		}
+
+		nt.epoch = binary.BigEndian.Uint16(buf[0:])
+		nt.major = binary.BigEndian.Uint16(buf[2:])
+		nt.minor = binary.BigEndian.Uint16(buf[4:])
+		nt.patch = binary.BigEndian.Uint16(buf[6:])
+		nt.header.seek = binary.BigEndian.Uint64(buf[8:])
+		nt.header.nbytes = binary.BigEndian.Uint64(buf[16:])
+		nt.header.length = binary.BigEndian.Uint64(buf[24:])
+		nt.footer.seek = binary.BigEndian.Uint64(buf[32:])
+		nt.footer.nbytes = binary.BigEndian.Uint64(buf[40:])
+		nt.footer.length = binary.BigEndian.Uint64(buf[48:])
+		nt.maxKeySize = binary.BigEndian.Uint64(buf[56:])
+
+		if nt.epoch != 1 {
+			return fmt.Errorf("rntup: unsupported RNTuple format epoch %d", nt.epoch)
+		}
+		return nil
+	}
+
 	nt.rvers = r.ReadU32()
 	nt.size = r.ReadU32()
 
 	nt.header.seek = r.ReadU64()
-	nt.header.nbytes = r.ReadU32()
-	nt.header.length = r.ReadU32()
+	nt.header.nbytes = uint64(r.ReadU32())
+	nt.header.length = uint64(r.ReadU32())
 
 	nt.footer.seek = r.ReadU64()
-	nt.footer.nbytes = r.ReadU32()
-	nt.footer.length = r.ReadU32()
+	nt.footer.nbytes = uint64(r.ReadU32())
+	nt.footer.length = uint64(r.ReadU32())
 
 	nt.reserved = r.ReadU64()
 
@@ -97,7 +335,71 @@ func init() {
 			o := &NTuple{}
 			return reflect.ValueOf(o)
 		}
-		rtypes.Factory.Add("ROOT::Experimental::RNTuple", f)
+		rtypes.Factory.Add(classV0, f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &NTuple{epoch: 1}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add(classV1, f)
+	}
+	{
+		elem := func(name string, typ rmeta.Enum) rbytes.StreamerElement {
+			var (
+				size  = int32(4)
+				ename = "unsigned int"
+			)
+			switch typ {
+			case rmeta.UShort:
+				size = 2
+				ename = "unsigned short"
+			case rmeta.ULong:
+				size = 8
+				ename = "unsigned long"
+			}
+			return &rdict.StreamerBasicType{
+				StreamerElement: rdict.Element{
+					Name:  *rbase.NewNamed(name, ""),
+					Type:  typ,
+					Size:  size,
+					EName: ename,
+				}.New(),
+			}
+		}
+		// Streamer for ROOT::Experimental::RNTuple, as written by ROOT-6.24.
+		rdict.StreamerInfos.Add(rdict.NewCxxStreamerInfo(
+			classV0, versV0, 0x655b8f56,
+			[]rbytes.StreamerElement{
+				elem("fVersion", rmeta.UInt),
+				elem("fSize", rmeta.UInt),
+				elem("fSeekHeader", rmeta.ULong),
+				elem("fNBytesHeader", rmeta.UInt),
+				elem("fLenHeader", rmeta.UInt),
+				elem("fSeekFooter", rmeta.ULong),
+				elem("fNBytesFooter", rmeta.UInt),
+				elem("fLenFooter", rmeta.UInt),
+				elem("fReserved", rmeta.ULong),
+			},
+		))
+
+		// Streamer for ROOT::RNTuple, as written by ROOT-6.34.
+		rdict.StreamerInfos.Add(rdict.NewCxxStreamerInfo(
+			classV1, versV1, 0x28e632ec,
+			[]rbytes.StreamerElement{
+				elem("fVersionEpoch", rmeta.UShort),
+				elem("fVersionMajor", rmeta.UShort),
+				elem("fVersionMinor", rmeta.UShort),
+				elem("fVersionPatch", rmeta.UShort),
+				elem("fSeekHeader", rmeta.ULong),
+				elem("fNBytesHeader", rmeta.ULong),
+				elem("fLenHeader", rmeta.ULong),
+				elem("fSeekFooter", rmeta.ULong),
+				elem("fNBytesFooter", rmeta.ULong),
+				elem("fLenFooter", rmeta.ULong),
+				elem("fMaxKeySize", rmeta.ULong),
+			},
+		))
 	}
 }
 
@@ -106,4 +408,5 @@ var (
 	_ rbytes.RVersioner  = (*NTuple)(nil)
 	_ rbytes.Marshaler   = (*NTuple)(nil)
 	_ rbytes.Unmarshaler = (*NTuple)(nil)
+	_ riofs.SetFiler     = (*NTuple)(nil)
 )
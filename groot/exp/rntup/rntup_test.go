@@ -6,6 +6,7 @@ package rntup
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"go-hep.org/x/hep/groot/internal/rtests"
@@ -18,7 +19,24 @@ func TestNTuple(t *testing.T) {
 		want rtests.ROOTer
 	}{
 		{
-			want: &NTuple{1, 2, span{1, 2, 3}, span{4, 5, 6}, 7},
+			want: &NTuple{
+				rvers:    1,
+				size:     2,
+				header:   span{1, 2, 3},
+				footer:   span{4, 5, 6},
+				reserved: 7,
+			},
+		},
+		{
+			want: &NTuple{
+				epoch:      1,
+				major:      2,
+				minor:      3,
+				patch:      4,
+				header:     span{1, 2, 3},
+				footer:     span{4, 5, 6},
+				maxKeySize: 7,
+			},
 		},
 	} {
 		t.Run("", func(t *testing.T) {
@@ -30,6 +48,7 @@ func TestNTuple(t *testing.T) {
 
 			rt := reflect.Indirect(reflect.ValueOf(tc.want)).Type()
 			got := reflect.New(rt).Interface().(rtests.ROOTer)
+			got.(*NTuple).epoch = tc.want.(*NTuple).epoch // as set by the factory, from the class name.
 			rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil)
 
 			err = got.UnmarshalROOT(rbuf)
@@ -75,6 +94,7 @@ func TestReadNTuple(t *testing.T) {
 			length: 804,
 		},
 		reserved: 0,
+		f:        f,
 	}
 
 	if got, want := *nt, want; got != want {
@@ -85,3 +105,65 @@ func TestReadNTuple(t *testing.T) {
 		t.Fatalf("error:\ngot= %v\nwant=%v", got, want)
 	}
 }
+
+func TestReadNTupleV1(t *testing.T) {
+	f, err := riofs.Open("../../testdata/rntuple-v1.root")
+	if err != nil {
+		t.Fatalf("could not open file: +%v", err)
+	}
+	defer f.Close()
+
+	nt, err := riofs.Get[*NTuple](f, "ntpl")
+	if err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+
+	if got, want := nt.Class(), "ROOT::RNTuple"; got != want {
+		t.Fatalf("invalid class: got=%q, want=%q", got, want)
+	}
+
+	want := NTuple{
+		epoch: 1,
+		header: span{
+			seek:   2686,
+			nbytes: 457,
+			length: 1293,
+		},
+		footer: span{
+			seek:   3500,
+			nbytes: 148,
+			length: 148,
+		},
+		maxKeySize: 1 << 30,
+		f:          f,
+	}
+
+	if got, want := *nt, want; got != want {
+		t.Fatalf("error:\ngot= %#v\nwant=%#v", got, want)
+	}
+}
+
+func TestNTupleV1Checksum(t *testing.T) {
+	nt := &NTuple{
+		epoch:      1,
+		header:     span{1, 2, 3},
+		footer:     span{4, 5, 6},
+		maxKeySize: 1 << 30,
+	}
+	wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+	_, err := nt.MarshalROOT(wbuf)
+	if err != nil {
+		t.Fatalf("could not marshal: %+v", err)
+	}
+
+	raw := wbuf.Bytes()
+	raw[len(raw)-9] ^= 0xff // corrupt max-key-size
+
+	err = (&NTuple{epoch: 1}).UnmarshalROOT(rbytes.NewRBuffer(raw, nil, 0, nil))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "rntup: invalid anchor checksum"; !strings.HasPrefix(got, want) {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+	}
+}
@@ -0,0 +1,508 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/zeebo/xxh3"
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// WriteVar describes a field to be written out to an NTuple.
+type WriteVar struct {
+	Name  string      // name of the field
+	Value interface{} // pointer to the value to write
+}
+
+// WriteVarsFromStruct creates a slice of WriteVars from the ptr value.
+// WriteVarsFromStruct panics if ptr is not a pointer to a struct value.
+// WriteVarsFromStruct ignores fields that are not exported.
+// The name of a field may be modified with a `groot:"name"` struct tag.
+func WriteVarsFromStruct(ptr interface{}) []WriteVar {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr {
+		panic(fmt.Errorf("rntup: expect a pointer value, got %T", ptr))
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Errorf("rntup: expect a pointer to struct value, got %T", ptr))
+	}
+
+	var (
+		rt    = rv.Type()
+		wvars = make([]WriteVar, 0, rt.NumField())
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		ft := rt.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		name := ft.Name
+		if tag, ok := ft.Tag.Lookup("groot"); ok && tag != "" {
+			name = tag
+		}
+		wvars = append(wvars, WriteVar{
+			Name:  name,
+			Value: rv.Field(i).Addr().Interface(),
+		})
+	}
+	return wvars
+}
+
+// WriteOption configures how an NTuple should be created.
+type WriteOption func(opt *wopt) error
+
+type wopt struct {
+	title    string // description of the NTuple
+	compress int32  // compression algorithm name and compression level
+	csize    int64  // number of entries per cluster
+}
+
+// WithLZ4 configures an NTuple to use LZ4 as a compression mechanism.
+func WithLZ4(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.LZ4, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithZlib configures an NTuple to use zlib as a compression mechanism.
+func WithZlib(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZLIB, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithZstd configures an NTuple to use zstd as a compression mechanism.
+func WithZstd(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZSTD, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithoutCompression configures an NTuple to not use any compression mechanism.
+func WithoutCompression() WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = 0
+		return nil
+	}
+}
+
+// WithClusterSize sets the number of entries of each cluster of an NTuple.
+// The default is 65536.
+func WithClusterSize(n int64) WriteOption {
+	return func(opt *wopt) error {
+		if n <= 0 {
+			return fmt.Errorf("rntup: invalid cluster size (%d)", n)
+		}
+		opt.csize = n
+		return nil
+	}
+}
+
+// WithTitle sets the description of an NTuple.
+func WithTitle(title string) WriteOption {
+	return func(opt *wopt) error {
+		opt.title = title
+		return nil
+	}
+}
+
+const defaultClusterSize = 65536
+
+// Writer writes data to an NTuple.
+//
+// Writer creates NTuples with the 1.0 RNTuple on-disk format, readable by
+// ROOT 6.34 and later.
+// As ROOT does by default, integers, floating point values and offsets
+// are stored in split columns and pages are followed by their XXH3-64
+// checksum.
+type Writer struct {
+	f    *riofs.File
+	dir  riofs.Directory
+	name string
+	cfg  wopt
+
+	desc  descriptor
+	wflds []*wfield
+	nelts []uint64 // number of elements written so far, per column
+	first int64    // first entry of the current cluster
+	n     int64    // number of entries of the current cluster
+
+	closed bool
+}
+
+// NewWriter creates a new NTuple with the given name and under the given
+// directory dir, ready to be filled with data.
+func NewWriter(dir riofs.Directory, name string, wvars []WriteVar, opts ...WriteOption) (*Writer, error) {
+	if dir == nil {
+		return nil, fmt.Errorf("rntup: missing parent directory")
+	}
+
+	cfg := wopt{
+		compress: fileOf(dir).Compression(),
+		csize:    defaultClusterSize,
+	}
+	for _, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not configure ntuple writer: %w", err)
+		}
+	}
+
+	w := &Writer{
+		f:    fileOf(dir),
+		dir:  dir,
+		name: name,
+		cfg:  cfg,
+		desc: descriptor{
+			name:   name,
+			desc:   cfg.title,
+			author: "go-hep.org/x/hep/groot",
+		},
+		wflds: make([]*wfield, len(wvars)),
+	}
+
+	names := make(map[string]struct{}, len(wvars))
+	for i, wv := range wvars {
+		if _, dup := names[wv.Name]; dup {
+			return nil, fmt.Errorf("rntup: duplicate field %q", wv.Name)
+		}
+		names[wv.Name] = struct{}{}
+
+		wf, err := w.newWField(wv)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not create field for write-var %q: %w", wv.Name, err)
+		}
+		w.wflds[i] = wf
+	}
+	w.nelts = make([]uint64, len(w.desc.cols))
+
+	return w, nil
+}
+
+// Write writes the event data to ROOT storage and returns the number
+// of bytes (before compression, if any) written.
+func (w *Writer) Write() (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("rntup: write to closed ntuple writer")
+	}
+
+	var n int
+	for _, wf := range w.wflds {
+		n += wf.write()
+	}
+	w.n++
+
+	if w.n >= w.cfg.csize {
+		err := w.flush()
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Close writes the pending cluster, the header, the footer and the anchor
+// of the NTuple.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	err := w.flush()
+	if err != nil {
+		return err
+	}
+
+	nt := NTuple{
+		epoch:      1,
+		maxKeySize: defaultMaxKeySize,
+	}
+
+	hdr := w.desc.writeHeaderV1()
+	loc, err := w.writeBlob(hdr, false)
+	if err != nil {
+		return fmt.Errorf("rntup: could not write header: %w", err)
+	}
+	nt.header = span{seek: uint64(loc.pos), nbytes: uint64(loc.nbytes), length: uint64(len(hdr))}
+
+	plst := w.desc.writePageListV1()
+	loc, err = w.writeBlob(plst, false)
+	if err != nil {
+		return fmt.Errorf("rntup: could not write page list: %w", err)
+	}
+
+	ftr := w.desc.writeFooterV1(uint64(len(plst)), loc)
+	loc, err = w.writeBlob(ftr, false)
+	if err != nil {
+		return fmt.Errorf("rntup: could not write footer: %w", err)
+	}
+	nt.footer = span{seek: uint64(loc.pos), nbytes: uint64(loc.nbytes), length: uint64(len(ftr))}
+
+	err = w.dir.Put(w.name, &nt)
+	if err != nil {
+		return fmt.Errorf("rntup: could not write anchor: %w", err)
+	}
+	return nil
+}
+
+// flush writes the pages of the current cluster.
+func (w *Writer) flush() error {
+	if w.n == 0 {
+		return nil
+	}
+
+	cl := cluster{
+		id:     uint64(len(w.desc.clusters)),
+		first:  uint64(w.first),
+		n:      uint64(w.n),
+		ranges: make(map[uint64]colRange),
+		pages:  make(map[uint64][]page),
+	}
+
+	for _, wf := range w.wflds {
+		for i, col := range wf.cols {
+			n := wf.nelts[i]
+			loc, err := w.writeBlob(col.pack(wf.data[i], int(n)), true)
+			if err != nil {
+				return fmt.Errorf("rntup: could not write page of field %q: %w", wf.name, err)
+			}
+
+			cl.ranges[col.id] = colRange{
+				first: w.nelts[col.id],
+				n:     n,
+				comp:  int64(w.cfg.compress),
+			}
+			cl.pages[col.id] = []page{{n: n, loc: loc}}
+			w.nelts[col.id] += uint64(n)
+
+			wf.data[i] = wf.data[i][:0]
+			wf.nelts[i] = 0
+		}
+	}
+
+	w.desc.clusters = append(w.desc.clusters, cl)
+	w.first += w.n
+	w.n = 0
+	return nil
+}
+
+// writeBlob writes the (possibly compressed) provided data to the file,
+// followed by its XXH3-64 checksum if chk is true, and returns its location.
+func (w *Writer) writeBlob(data []byte, chk bool) (locator, error) {
+	buf, err := rcompress.Compress(nil, data, w.cfg.compress)
+	if err != nil {
+		return locator{}, fmt.Errorf("could not compress blob: %w", err)
+	}
+	if len(buf) >= len(data) {
+		buf = data
+	}
+	n := len(buf)
+	if chk {
+		buf = putU64(buf[:n:n], 0, xxh3.Hash(buf))
+	}
+
+	key, err := riofs.NewKeyWithCompression(nil, "", "", "RBlob", 1, buf, w.f, 0)
+	if err != nil {
+		return locator{}, fmt.Errorf("could not create blob key: %w", err)
+	}
+
+	wbuf := rbytes.NewWBuffer(nil, nil, 0, w.f)
+	_, err = key.MarshalROOT(wbuf)
+	if err != nil {
+		return locator{}, fmt.Errorf("could not marshal blob key: %w", err)
+	}
+
+	_, err = w.f.WriteAt(append(wbuf.Bytes(), buf...), key.SeekKey())
+	if err != nil {
+		return locator{}, fmt.Errorf("could not write blob: %w", err)
+	}
+
+	return locator{
+		pos:    key.SeekKey() + int64(key.KeyLen()),
+		nbytes: uint32(n),
+		chk:    chk,
+	}, nil
+}
+
+// wfield writes the values of a WriteVar into the columns of a field.
+type wfield struct {
+	name  string
+	cols  []*column
+	data  [][]byte // encoded elements of each column for the current cluster
+	nelts []uint32 // number of elements of each column for the current cluster
+	write func() int
+}
+
+func (w *Writer) newWField(wv WriteVar) (*wfield, error) {
+	rv := reflect.ValueOf(wv.Value)
+	if rv.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("invalid value type %T (expect a pointer)", wv.Value)
+	}
+	if wv.Name == "" {
+		return nil, fmt.Errorf("invalid empty field name")
+	}
+
+	rt := rv.Elem().Type()
+	cxx, err := cxxTypeOf(rt)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported type %T", wv.Value)
+	}
+
+	var (
+		fd = &Field{
+			Name: wv.Name,
+			Type: cxx,
+			id:   uint64(len(w.desc.fields)),
+			kind: structLeaf,
+		}
+		sub   *Field   // sub-field holding the elements of arrays and collections
+		codes []uint16 // column types of the 1.0 format
+		v     = wv.Value
+	)
+	fd.parent = fd.id // top-level fields are their own parent.
+	w.desc.fields = append(w.desc.fields, fd)
+
+	switch rt.Kind() {
+	case reflect.String:
+		codes = []uint16{codeSplitIndex64, codeChar}
+	case reflect.Array, reflect.Slice:
+		elt := cppTypes[rt.Elem().Kind()]
+		sub = &Field{
+			Name:   "_0",
+			Type:   elt.name,
+			id:     uint64(len(w.desc.fields)),
+			parent: fd.id,
+			kind:   structLeaf,
+		}
+		w.desc.fields = append(w.desc.fields, sub)
+		switch rt.Kind() {
+		case reflect.Array:
+			fd.nrep = uint64(rt.Len())
+			codes = []uint16{elt.code}
+			// write directly from the user array, through a slice.
+			v = rv.Elem().Slice(0, rt.Len()).Interface()
+		default:
+			fd.kind = structCollection
+			codes = []uint16{codeSplitIndex64, elt.code}
+		}
+	default:
+		codes = []uint16{cppTypes[rt.Kind()].code}
+	}
+
+	wf := &wfield{
+		name:  wv.Name,
+		data:  make([][]byte, len(codes)),
+		nelts: make([]uint32, len(codes)),
+	}
+	for _, code := range codes {
+		ct := colTypesV1[code]
+		owner := fd
+		if sub != nil && !ct.typ.isIndex() {
+			owner = sub
+		}
+		col := &column{
+			id:     uint64(len(w.desc.cols)),
+			code:   code,
+			typ:    ct.typ,
+			enc:    ct.enc,
+			sorted: ct.typ.isIndex(),
+			field:  owner.id,
+			index:  uint32(len(owner.cols)),
+		}
+		w.desc.cols = append(w.desc.cols, col)
+		owner.cols = append(owner.cols, col)
+		wf.cols = append(wf.cols, col)
+	}
+
+	if ptr, ok := v.(*string); ok {
+		wf.write = func() int {
+			wf.data[1] = append(wf.data[1], *ptr...)
+			wf.nelts[1] += uint32(len(*ptr))
+			return len(*ptr) + wf.index()
+		}
+		return wf, nil
+	}
+
+	ok := wbind(wf, v, putBool) ||
+		wbind(wf, v, putI8) || wbind(wf, v, putU8) ||
+		wbind(wf, v, putI16) || wbind(wf, v, putU16) ||
+		wbind(wf, v, putI32) || wbind(wf, v, putU32) ||
+		wbind(wf, v, putI64) || wbind(wf, v, putU64) ||
+		wbind(wf, v, putF32) || wbind(wf, v, putF64)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T", wv.Value)
+	}
+
+	return wf, nil
+}
+
+// wbind configures the field to write values of type T, []T (for fixed-size
+// arrays) or *[]T (for collections) from v.
+func wbind[T any](wf *wfield, v interface{}, put putter[T]) bool {
+	switch v := v.(type) {
+	case *T:
+		wf.write = func() int {
+			return wf.put(0, func(b []byte, j int64) []byte { return put(b, j, *v) })
+		}
+	case []T:
+		wf.write = func() int {
+			n := 0
+			for i := range v {
+				n += wf.put(0, func(b []byte, j int64) []byte { return put(b, j, v[i]) })
+			}
+			return n
+		}
+	case *[]T:
+		wf.write = func() int {
+			n := 0
+			for i := range *v {
+				n += wf.put(1, func(b []byte, j int64) []byte { return put(b, j, (*v)[i]) })
+			}
+			return n + wf.index()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// put appends an element to the icol-th column and returns the number of
+// bytes written.
+func (wf *wfield) put(icol int, f func(b []byte, j int64) []byte) int {
+	n := len(wf.data[icol])
+	wf.data[icol] = f(wf.data[icol], int64(wf.nelts[icol]))
+	wf.nelts[icol]++
+	return len(wf.data[icol]) - n
+}
+
+// index appends the cluster-local offset of the end of the current
+// entry's elements to the index column and returns the number of bytes
+// written.
+func (wf *wfield) index() int {
+	end := wf.nelts[1]
+	return wf.put(0, func(b []byte, j int64) []byte { return putU64(b, j, uint64(end)) })
+}
+
+func fileOf(d riofs.Directory) *riofs.File {
+	const max = 1<<31 - 1
+	for i := 0; i < max; i++ {
+		p := d.Parent()
+		if p == nil {
+			return d.(*riofs.File)
+		}
+		d = p
+	}
+	panic("impossible")
+}
@@ -0,0 +1,251 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+type event struct {
+	B   bool
+	I8  int8
+	I16 int16
+	I32 int32
+	I64 int64
+	U8  uint8
+	U16 uint16
+	U32 uint32
+	U64 uint64
+	F32 float32
+	F64 float64
+	Str string `groot:"str"`
+	Arr [3]float32
+	Vec []int16
+	Bit []bool
+
+	private int
+}
+
+func newEvent(i int) event {
+	return event{
+		B:   i%3 == 0,
+		I8:  int8(-i),
+		I16: int16(-i * 2),
+		I32: int32(-i * 3),
+		I64: int64(-i * 4),
+		U8:  uint8(i),
+		U16: uint16(i * 2),
+		U32: uint32(i * 3),
+		U64: uint64(i * 4),
+		F32: float32(i) + 0.5,
+		F64: float64(i) + 0.25,
+		Str: fmt.Sprintf("evt-%d", i),
+		Arr: [3]float32{float32(i), float32(i + 1), float32(i + 2)},
+		Vec: []int16{int16(i), int16(-i), int16(2 * i)}[:i%4%3],
+		Bit: []bool{true, false, i%2 == 0, true, i%5 == 0, false, true, true, false}[:i%10],
+	}
+}
+
+// eqEvent compares events, treating nil and empty slices as equal.
+func eqEvent(a, b event) bool {
+	if len(a.Vec) == 0 && len(b.Vec) == 0 {
+		a.Vec, b.Vec = nil, nil
+	}
+	if len(a.Bit) == 0 && len(b.Bit) == 0 {
+		a.Bit, b.Bit = nil, nil
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestWriter(t *testing.T) {
+	const nevts = 1000
+
+	for _, tc := range []struct {
+		name string
+		opts []WriteOption
+	}{
+		{name: "default"},
+		{name: "no-compression", opts: []WriteOption{WithoutCompression()}},
+		{name: "lz4-clusters", opts: []WriteOption{WithLZ4(1), WithClusterSize(7)}},
+		{name: "zlib-clusters", opts: []WriteOption{WithZlib(9), WithClusterSize(100)}},
+		{name: "zstd", opts: []WriteOption{WithZstd(1), WithTitle("my events")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "ntuple.root")
+
+			func() {
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				dir, err := riofs.Dir(f).Mkdir("dir")
+				if err != nil {
+					t.Fatalf("could not create directory: %+v", err)
+				}
+
+				var evt event
+				wvars := WriteVarsFromStruct(&evt)
+				if got, want := len(wvars), 15; got != want {
+					t.Fatalf("invalid number of write-vars: got=%d, want=%d", got, want)
+				}
+
+				w, err := NewWriter(dir, "evts", wvars, tc.opts...)
+				if err != nil {
+					t.Fatalf("could not create writer: %+v", err)
+				}
+				defer w.Close()
+
+				for i := 0; i < nevts; i++ {
+					evt = newEvent(i)
+					_, err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write entry %d: %+v", i, err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}()
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			nt, err := riofs.Get[*NTuple](f, "dir/evts")
+			if err != nil {
+				t.Fatalf("could not retrieve ntuple: %+v", err)
+			}
+
+			if got, want := nt.Class(), "ROOT::RNTuple"; got != want {
+				t.Fatalf("invalid anchor class: got=%q, want=%q", got, want)
+			}
+			if got, want := nt.epoch, uint16(1); got != want {
+				t.Fatalf("invalid format epoch: got=%d, want=%d", got, want)
+			}
+
+			if got, want := nt.Entries(), int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var fields []string
+			for _, f := range nt.Fields() {
+				fields = append(fields, f.Name+":"+f.Type)
+			}
+			want := []string{
+				"B:bool",
+				"I8:std::int8_t", "I16:std::int16_t", "I32:std::int32_t", "I64:std::int64_t",
+				"U8:std::uint8_t", "U16:std::uint16_t", "U32:std::uint32_t", "U64:std::uint64_t",
+				"F32:float", "F64:double",
+				"str:std::string",
+				"Arr:std::array<float,3>",
+				"Vec:std::vector<std::int16_t>",
+				"Bit:std::vector<bool>",
+			}
+			if !reflect.DeepEqual(fields, want) {
+				t.Fatalf("invalid fields:\ngot= %q\nwant=%q", fields, want)
+			}
+
+			var evt event
+			r, err := NewReader(nt, []ReadVar{
+				{Name: "B", Value: &evt.B},
+				{Name: "I8", Value: &evt.I8},
+				{Name: "I16", Value: &evt.I16},
+				{Name: "I32", Value: &evt.I32},
+				{Name: "I64", Value: &evt.I64},
+				{Name: "U8", Value: &evt.U8},
+				{Name: "U16", Value: &evt.U16},
+				{Name: "U32", Value: &evt.U32},
+				{Name: "U64", Value: &evt.U64},
+				{Name: "F32", Value: &evt.F32},
+				{Name: "F64", Value: &evt.F64},
+				{Name: "str", Value: &evt.Str},
+				{Name: "Arr", Value: &evt.Arr},
+				{Name: "Vec", Value: &evt.Vec},
+				{Name: "Bit", Value: &evt.Bit},
+			})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				if got, want := evt, newEvent(int(ctx.Entry)); !eqEvent(got, want) {
+					return fmt.Errorf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, got, want)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read ntuple: %+v", err)
+			}
+			if n != nevts {
+				t.Fatalf("invalid number of entries read: got=%d, want=%d", n, nevts)
+			}
+		})
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	f, err := riofs.Create(filepath.Join(t.TempDir(), "ntuple.root"))
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		name  string
+		wvars []WriteVar
+		opts  []WriteOption
+		err   string
+	}{
+		{
+			name:  "not-a-pointer",
+			wvars: []WriteVar{{Name: "x", Value: 42}},
+			err:   `rntup: could not create field for write-var "x": invalid value type int (expect a pointer)`,
+		},
+		{
+			name:  "unsupported-type",
+			wvars: []WriteVar{{Name: "x", Value: new([]string)}},
+			err:   `rntup: could not create field for write-var "x": unsupported type *[]string`,
+		},
+		{
+			name:  "duplicate",
+			wvars: []WriteVar{{Name: "x", Value: new(int32)}, {Name: "x", Value: new(int32)}},
+			err:   `rntup: duplicate field "x"`,
+		},
+		{
+			name: "cluster-size",
+			opts: []WriteOption{WithClusterSize(0)},
+			err:  "rntup: could not configure ntuple writer: rntup: invalid cluster size (0)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWriter(f, "evts", tc.wvars, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
@@ -28,12 +28,13 @@ import (
 var (
 	classes = []string{
 		// rbase
-		"TAttAxis", "TAttFill", "TAttLine", "TAttMarker",
+		"TAtt3D", "TAttAxis", "TAttBBox2D", "TAttFill", "TAttLine", "TAttMarker", "TAttPad", "TAttText",
 		"TDatime",
 		"TNamed",
 		"TObject", "TObjString",
-		"TProcessID", "TProcessUUID", "TRef", "TUUID",
+		"TProcessID", "TProcessUUID", "TQObject", "TRef", "TUUID",
 		"TString",
+		"TVirtualPad",
 
 		// rcont
 		"TArray", "TArrayC", "TArrayS", "TArrayI", "TArrayL", "TArrayL64", "TArrayF", "TArrayD",
@@ -71,10 +72,14 @@ var (
 		"TEfficiency",
 		"TF1",
 		"TF1AbsComposition", "TF1Convolution", "TF1NormSum", "TF1Parameters",
+		"TF2",
 		"TFormula",
 		"TGraph", "TGraphErrors", "TGraphAsymmErrors", "TGraphMultiErrors",
+		"TGraph2D", "TGraph2DErrors",
 		"TH1", "TH1C", "TH1D", "TH1F", "TH1I", "TH1K", "TH1S",
 		"TH2", "TH2C", "TH2D", "TH2F", "TH2I", "TH2Poly", "TH2PolyBin", "TH2S",
+		"TH3", "TH3D", "TH3F", "TH3I",
+		"THStack",
 		"TLimit", "TLimitDataSource",
 		"TMultiGraph",
 		"TProfile", "TProfile2D",
@@ -98,6 +103,7 @@ var (
 		"TBasket",
 		"TBranch", "TBranchElement", "TBranchObject", "TBranchRef",
 		"TChain",
+		"TEntryList", "TEntryListBlock",
 		"TLeaf", "TLeafElement", "TLeafObject",
 		"TLeafO",
 		"TLeafB", "TLeafS", "TLeafI", "TLeafL",
@@ -105,7 +111,17 @@ var (
 		"TLeafF16", "TLeafD32",
 		"TLeafC",
 		"TNtuple", "TNtupleD",
-		"TTree",
+		"TTree", "TTreeIndex", "TVirtualIndex",
+
+		// rpad
+		"TAttCanvas",
+		"TCanvas",
+		"TPad",
+		"TBox",
+		"TLatex",
+		"TLine",
+		"TPave", "TPaveText",
+		"TText",
 	}
 )
 
@@ -18,6 +18,7 @@ import (
 func main() {
 	genH1()
 	genH2()
+	genH3()
 }
 
 func genH1() {
@@ -136,6 +137,64 @@ func genH2() {
 	genroot.GoFmt(f)
 }
 
+func genH3() {
+	fname := "./rhist/h3_gen.go"
+	year := genroot.ExtractYear(fname)
+	f, err := os.Create(fname)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	genroot.GenImports(year, "rhist", f,
+		"fmt", "math", "reflect",
+		"",
+		"go-hep.org/x/hep/hbook",
+		"go-hep.org/x/hep/groot/root",
+		"go-hep.org/x/hep/groot/rcont",
+		"go-hep.org/x/hep/groot/rbytes",
+		"go-hep.org/x/hep/groot/rtypes",
+		"go-hep.org/x/hep/groot/rvers",
+	)
+
+	for i, typ := range []struct {
+		Name string
+		Type string
+		Elem string
+	}{
+		{
+			Name: "H3F",
+			Type: "rcont.ArrayF",
+			Elem: "float32",
+		},
+		{
+			Name: "H3D",
+			Type: "rcont.ArrayD",
+			Elem: "float64",
+		},
+		{
+			Name: "H3I",
+			Type: "rcont.ArrayI",
+			Elem: "int32",
+		},
+	} {
+		if i > 0 {
+			fmt.Fprintf(f, "\n")
+		}
+		tmpl := template.Must(template.New(typ.Name).Parse(h3Tmpl))
+		err = tmpl.Execute(f, typ)
+		if err != nil {
+			log.Fatalf("error executing template for %q: %v\n", typ.Name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	genroot.GoFmt(f)
+}
+
 const h1Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
 type {{.Name}} struct {
 	th1
@@ -148,6 +207,17 @@ func new{{.Name}}() *{{.Name}} {
 	}
 }
 
+// New{{.Name}}FromEdges creates a new, empty, 1-dim histogram with
+// bins defined by the provided edges.
+// New{{.Name}}FromEdges panics if fewer than two edges are provided,
+// or if the edges are not sorted in increasing order.
+func New{{.Name}}FromEdges(name, title string, edges []float64) *{{.Name}} {
+	h := hbook.NewH1DFromEdges(edges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return New{{.Name}}From(h)
+}
+
 // New{{.Name}}From creates a new 1-dim histogram from hbook.
 func New{{.Name}}From(h *hbook.H1D) *{{.Name}} {
 	var (
@@ -188,6 +258,8 @@ func New{{.Name}}From(h *hbook.H1D) *{{.Name}} {
 		hroot.th1.SetTitle(v.(string))
 	}
 	hroot.th1.xaxis.xbins.Data = edges
+	hroot.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th1.yaxis.setHbookAxis(h.YAxis())
 	return hroot
 }
 
@@ -359,6 +431,8 @@ func (h *{{.Name}}) AsH1D() *hbook.H1D {
 		bin.Range.Max = xmax
 		hh.Binning.Bins[i].Dist = h.dist1D(i + 1)
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
 
 	return hh
 }
@@ -396,6 +470,81 @@ func (h *{{.Name}}) ROOTMerge(src root.Object) error {
 	return nil
 }
 
+// Fill fills this histogram with x and weight w.
+func (h *{{.Name}}) Fill(x, w float64) {
+	histFill(h, x, 0, w)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Add(o H1, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *{{.Name}}) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Multiply(o H1) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Divide(o H1, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// Rebin merges groups of n consecutive bins into single bins.
+// If the number of bins is not a multiple of n, the remaining bins are
+// merged into the overflow bin.
+func (h *{{.Name}}) Rebin(n int) error {
+	return histRebin(h, n, 1)
+}
+
+func (h *{{.Name}}) base() *th1 {
+	return &h.th1
+}
+
+func (h *{{.Name}}) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *{{.Name}}) setCell(i int, v float64) {
+	h.arr.Data[i] = {{.Elem}}(v)
+}
+
+func (h *{{.Name}}) setCells(vs []float64) {
+	h.arr.Data = make([]{{.Elem}}, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = {{.Elem}}(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x and weights*x².
+func (h *{{.Name}}) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x and weights*x².
+func (h *{{.Name}}) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := new{{.Name}}()
@@ -409,6 +558,7 @@ var (
 	_ root.Merger        = (*{{.Name}})(nil)
 	_ root.Named         = (*{{.Name}})(nil)
 	_ H1                 = (*{{.Name}})(nil)
+	_ histCells          = (*{{.Name}})(nil)
 	_ rbytes.Marshaler   = (*{{.Name}})(nil)
 	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
 )
@@ -426,6 +576,17 @@ func new{{.Name}}() *{{.Name}} {
 	}
 }
 
+// New{{.Name}}FromEdges creates a new, empty, 2-dim histogram with
+// bins defined by the provided edges along X and Y.
+// New{{.Name}}FromEdges panics if fewer than two edges are provided
+// along an axis, or if the edges are not sorted in increasing order.
+func New{{.Name}}FromEdges(name, title string, xedges, yedges []float64) *{{.Name}} {
+	h := hbook.NewH2DFromEdges(xedges, yedges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return New{{.Name}}From(h)
+}
+
 // New{{.Name}}From creates a new {{.Name}} from hbook 2-dim histogram.
 func New{{.Name}}From(h *hbook.H2D) *{{.Name}} {
 	var (
@@ -499,6 +660,9 @@ func New{{.Name}}From(h *hbook.H2D) *{{.Name}} {
 	}
 	hroot.th2.th1.xaxis.xbins.Data = xedges
 	hroot.th2.th1.yaxis.xbins.Data = yedges
+	hroot.th2.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th2.th1.yaxis.setHbookAxis(h.YAxis())
+	hroot.th2.th1.zaxis.setHbookAxis(h.ZAxis())
 
 	return hroot
 }
@@ -730,6 +894,9 @@ func (h *{{.Name}}) AsH2D() *hbook.H2D {
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
+	hh.SetZAxis(h.th1.zaxis.hbookAxis())
 
 	return hh
 }
@@ -783,6 +950,129 @@ func (h *{{.Name}}) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Fill fills this histogram with (x,y) and weight w.
+func (h *{{.Name}}) Fill(x, y, w float64) {
+	histFill(h, x, y, w)
+}
+
+func (h *{{.Name}}) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*{{.Name}})
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.{{.Name}} (%T)", src.(root.Named).Name(), src)
+	}
+	return h.Add(hsrc, 1)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Add(o H2, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *{{.Name}}) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Multiply(o H2) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *{{.Name}}) Divide(o H2, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// RebinX merges groups of n consecutive bins along X into single bins.
+// If the number of bins along X is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *{{.Name}}) RebinX(n int) error {
+	return histRebin(h, n, 1)
+}
+
+// RebinY merges groups of n consecutive bins along Y into single bins.
+// If the number of bins along Y is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *{{.Name}}) RebinY(n int) error {
+	return histRebin(h, 1, n)
+}
+
+// ProjectionX returns the projection of this histogram onto the X axis,
+// summing the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_px" suffix.
+func (h *{{.Name}}) ProjectionX(name string, iymin, iymax int) *H1D {
+	return histProjection(h, name, 0, iymin, iymax)
+}
+
+// ProjectionY returns the projection of this histogram onto the Y axis,
+// summing the X bins from ixmin to ixmax (included.)
+// If ixmax < ixmin, all the X bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_py" suffix.
+func (h *{{.Name}}) ProjectionY(name string, ixmin, ixmax int) *H1D {
+	return histProjection(h, name, 1, ixmin, ixmax)
+}
+
+// ProfileX returns the profile of this histogram along the X axis, using
+// the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are used.
+// If name is empty, the name of the profile is the name of this histogram
+// with a "_pfx" suffix.
+func (h *{{.Name}}) ProfileX(name string, iymin, iymax int) *Profile1D {
+	return histProfileX(h, name, iymin, iymax)
+}
+
+func (h *{{.Name}}) base() *th1 {
+	return &h.th1
+}
+
+func (h *{{.Name}}) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *{{.Name}}) setCell(i int, v float64) {
+	h.arr.Data[i] = {{.Elem}}(v)
+}
+
+func (h *{{.Name}}) setCells(vs []float64) {
+	h.arr.Data = make([]{{.Elem}}, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = {{.Elem}}(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *{{.Name}}) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+		h.th2.tsumwy, h.th2.tsumwy2, h.th2.tsumwxy,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *{{.Name}}) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+	h.th2.tsumwy = s[4]
+	h.th2.tsumwy2 = s[5]
+	h.th2.tsumwxy = s[6]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := new{{.Name}}()
@@ -793,8 +1083,367 @@ func init() {
 
 var (
 	_ root.Object        = (*{{.Name}})(nil)
+	_ root.Merger        = (*{{.Name}})(nil)
 	_ root.Named         = (*{{.Name}})(nil)
 	_ H2                 = (*{{.Name}})(nil)
+	_ histCells          = (*{{.Name}})(nil)
+	_ rbytes.Marshaler   = (*{{.Name}})(nil)
+	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
+)
+`
+
+const h3Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
+type {{.Name}} struct {
+	th3
+	arr {{.Type}}
+}
+
+func new{{.Name}}() *{{.Name}} {
+	return &{{.Name}}{
+		th3: *newH3(),
+	}
+}
+
+// New{{.Name}}FromEdges creates a new, empty, 3-dim histogram with
+// bins defined by the provided edges along X, Y and Z.
+// New{{.Name}}FromEdges panics if fewer than two edges are provided
+// along an axis, or if the edges are not sorted in increasing order.
+func New{{.Name}}FromEdges(name, title string, xedges, yedges, zedges []float64) *{{.Name}} {
+	h := hbook.NewH3DFromEdges(xedges, yedges, zedges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return New{{.Name}}From(h)
+}
+
+// New{{.Name}}From creates a new {{.Name}} from hbook 3-dim histogram.
+func New{{.Name}}From(h *hbook.H3D) *{{.Name}} {
+	var (
+		hroot  = new{{.Name}}()
+		bng    = &h.Binning
+		nxbins = bng.Nx
+		nybins = bng.Ny
+		nzbins = bng.Nz
+	)
+
+	hroot.th3.th1.entries = float64(h.Entries())
+	hroot.th3.th1.tsumw = h.SumW()
+	hroot.th3.th1.tsumw2 = h.SumW2()
+	hroot.th3.th1.tsumwx = h.SumWX()
+	hroot.th3.th1.tsumwx2 = h.SumWX2()
+	hroot.th3.tsumwy = h.SumWY()
+	hroot.th3.tsumwy2 = h.SumWY2()
+	hroot.th3.tsumwxy = h.SumWXY()
+	hroot.th3.tsumwz = h.SumWZ()
+	hroot.th3.tsumwz2 = h.SumWZ2()
+	hroot.th3.tsumwxz = h.SumWXZ()
+	hroot.th3.tsumwyz = h.SumWYZ()
+
+	ncells := (nxbins + 2) * (nybins + 2) * (nzbins + 2)
+	hroot.th3.th1.ncells = ncells
+
+	for _, v := range []struct {
+		axis  *taxis
+		edges []hbook.Bin1D
+		rng   hbook.Range
+	}{
+		{&hroot.th3.th1.xaxis, bng.XEdges, bng.XRange},
+		{&hroot.th3.th1.yaxis, bng.YEdges, bng.YRange},
+		{&hroot.th3.th1.zaxis, bng.ZEdges, bng.ZRange},
+	} {
+		edges := make([]float64, 0, len(v.edges)+1)
+		for _, bin := range v.edges {
+			edges = append(edges, bin.Range.Min)
+		}
+		edges = append(edges, v.edges[len(v.edges)-1].Range.Max)
+
+		v.axis.nbins = len(v.edges)
+		v.axis.xmin = v.rng.Min
+		v.axis.xmax = v.rng.Max
+		v.axis.xbins.Data = edges
+	}
+
+	hroot.arr.Data = make([]{{.Elem}}, ncells)
+	hroot.th3.th1.sumw2.Data = make([]float64, ncells)
+
+	for iz := 0; iz < nzbins; iz++ {
+		for iy := 0; iy < nybins; iy++ {
+			for ix := 0; ix < nxbins; ix++ {
+				bin := &bng.Bins[(iz*nybins+iy)*nxbins+ix]
+				hroot.setDist3D(ix+1, iy+1, iz+1, bin.Dist.SumW(), bin.Dist.SumW2())
+			}
+		}
+	}
+
+	// outflows are stored in the first cell of their region.
+	cell := func(o, n int) int {
+		switch o {
+		case -1:
+			return 0
+		case +1:
+			return n + 1
+		}
+		return 1
+	}
+	for ox := -1; ox <= +1; ox++ {
+		for oy := -1; oy <= +1; oy++ {
+			for oz := -1; oz <= +1; oz++ {
+				if ox == 0 && oy == 0 && oz == 0 {
+					continue
+				}
+				d := bng.Outflow(ox, oy, oz)
+				hroot.setDist3D(
+					cell(ox, nxbins), cell(oy, nybins), cell(oz, nzbins),
+					d.SumW(), d.SumW2(),
+				)
+			}
+		}
+	}
+
+	hroot.th3.th1.SetName(h.Name())
+	if v, ok := h.Annotation()["title"]; ok && v != nil {
+		hroot.th3.th1.SetTitle(v.(string))
+	}
+	return hroot
+}
+
+func (*{{.Name}}) RVersion() int16 {
+	return rvers.{{.Name}}
+}
+
+func (*{{.Name}}) isH3() {}
+
+// Class returns the ROOT class name.
+func (*{{.Name}}) Class() string {
+	return "T{{.Name}}"
+}
+
+func (h *{{.Name}}) Array() {{.Type}} {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *{{.Name}}) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *{{.Name}}) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *{{.Name}}) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *{{.Name}}) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *{{.Name}}) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *{{.Name}}) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *{{.Name}}) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin 0 is the underflow bin and bin NbinsX+1 (resp. NbinsY+1, NbinsZ+1)
+// the overflow bin along X (resp. Y, Z.)
+func (h *{{.Name}}) BinContent(ix, iy, iz int) float64 {
+	return float64(h.arr.Data[h.th3.cell(ix, iy, iz)])
+}
+
+// BinError returns the error on the content of the (ix,iy,iz) bin.
+func (h *{{.Name}}) BinError(ix, iy, iz int) float64 {
+	i := h.th3.cell(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+func (h *{{.Name}}) dist3D(ix, iy, iz int) hbook.Dist3D {
+	var (
+		i     = h.th3.cell(ix, iy, iz)
+		sumw  = float64(h.arr.Data[i])
+		sumw2 = 0.0
+	)
+	if len(h.th1.sumw2.Data) > 0 {
+		sumw2 = h.th1.sumw2.Data[i]
+	}
+	d := hbook.Dist0D{
+		N:     h.entries(sumw, h.BinError(ix, iy, iz)),
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+	return hbook.Dist3D{
+		X: hbook.Dist1D{Dist: d},
+		Y: hbook.Dist1D{Dist: d},
+		Z: hbook.Dist1D{Dist: d},
+	}
+}
+
+func (h *{{.Name}}) setDist3D(ix, iy, iz int, sumw, sumw2 float64) {
+	i := h.th3.cell(ix, iy, iz)
+	h.arr.Data[i] = {{.Elem}}(sumw)
+	h.th1.sumw2.Data[i] = sumw2
+}
+
+func (h *{{.Name}}) entries(height, err float64) int64 {
+	if height <= 0 {
+		return 0
+	}
+	v := height / err
+	return int64(v*v + 0.5)
+}
+
+// AsH3D creates a new hbook.H3D from this ROOT histogram.
+// The contents of all the under/overflow bins of a given outflow region
+// are summed into the corresponding hbook outflow distribution.
+func (h *{{.Name}}) AsH3D() *hbook.H3D {
+	var (
+		nx = h.NbinsX()
+		ny = h.NbinsY()
+		nz = h.NbinsZ()
+		hh = hbook.NewH3DFromEdges(
+			axisEdges(&h.th1.xaxis),
+			axisEdges(&h.th1.yaxis),
+			axisEdges(&h.th1.zaxis),
+		)
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	oflow := func(i, n int) int {
+		switch {
+		case i == 0:
+			return -1
+		case i > n:
+			return +1
+		}
+		return 0
+	}
+
+	for iz := 0; iz < nz+2; iz++ {
+		for iy := 0; iy < ny+2; iy++ {
+			for ix := 0; ix < nx+2; ix++ {
+				var (
+					d  = h.dist3D(ix, iy, iz)
+					ox = oflow(ix, nx)
+					oy = oflow(iy, ny)
+					oz = oflow(iz, nz)
+				)
+				if ox == 0 && oy == 0 && oz == 0 {
+					hh.Binning.Bins[((iz-1)*ny+iy-1)*nx+ix-1].Dist = d
+					continue
+				}
+				addDist3D(hh.Binning.Outflow(ox, oy, oz), d)
+			}
+		}
+	}
+
+	d := hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	}
+	hh.Binning.Dist = hbook.Dist3D{
+		X: hbook.Dist1D{Dist: d},
+		Y: hbook.Dist1D{Dist: d},
+		Z: hbook.Dist1D{Dist: d},
+	}
+	hh.Binning.Dist.X.Stats.SumWX = h.SumWX()
+	hh.Binning.Dist.X.Stats.SumWX2 = h.SumWX2()
+	hh.Binning.Dist.Y.Stats.SumWX = h.SumWY()
+	hh.Binning.Dist.Y.Stats.SumWX2 = h.SumWY2()
+	hh.Binning.Dist.Z.Stats.SumWX = h.SumWZ()
+	hh.Binning.Dist.Z.Stats.SumWX2 = h.SumWZ2()
+	hh.Binning.Dist.Stats.SumWXY = h.SumWXY()
+	hh.Binning.Dist.Stats.SumWXZ = h.SumWXZ()
+	hh.Binning.Dist.Stats.SumWYZ = h.SumWYZ()
+
+	return hh
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+func (h *{{.Name}}) MarshalYODA() ([]byte, error) {
+	return h.AsH3D().MarshalYODA()
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (h *{{.Name}}) UnmarshalYODA(raw []byte) error {
+	var hh hbook.H3D
+	err := hh.UnmarshalYODA(raw)
+	if err != nil {
+		return err
+	}
+
+	*h = *New{{.Name}}From(&hh)
+	return nil
+}
+
+func (h *{{.Name}}) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+	w.WriteObject(&h.th3)
+	w.WriteObject(&h.arr)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *{{.Name}}) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class())
+	if hdr.Vers > rvers.{{.Name}} {
+		panic(fmt.Errorf("rhist: invalid {{.Name}} version=%d > %d", hdr.Vers, rvers.{{.Name}}))
+	}
+	if hdr.Vers < 1 {
+		return fmt.Errorf("rhist: T{{.Name}} version too old (%d<1)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th3)
+	r.ReadObject(&h.arr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// Fill fills this histogram with (x,y,z) and weight w.
+func (h *{{.Name}}) Fill(x, y, z, w float64) {
+	i := h.th3.fill(x, y, z, w)
+	h.arr.Data[i] += {{.Elem}}(w)
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := new{{.Name}}()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("T{{.Name}}", f)
+}
+
+var (
+	_ root.Object        = (*{{.Name}})(nil)
+	_ root.Named         = (*{{.Name}})(nil)
+	_ H3                 = (*{{.Name}})(nil)
 	_ rbytes.Marshaler   = (*{{.Name}})(nil)
 	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
 )
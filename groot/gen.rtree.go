@@ -508,6 +508,9 @@ func (leaf *{{.Name}}) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len*n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 {{- if .WithStreamerElement}}
 		{{.WFuncArray}}((*leaf.sli)[:end], leaf.elm)
 {{- else}}
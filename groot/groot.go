@@ -23,8 +23,8 @@ const (
 // Open opens the named ROOT file for reading. If successful, methods on the
 // returned file can be used for reading; the associated file descriptor
 // has mode os.O_RDONLY.
-func Open(path string) (*File, error) {
-	return riofs.Open(path)
+func Open(path string, opts ...FileOption) (*File, error) {
+	return riofs.Open(path, opts...)
 }
 
 // NewReader creates a new ROOT file reader.
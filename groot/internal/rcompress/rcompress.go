@@ -46,6 +46,10 @@ var (
 	// couldn't compress the input or when the compressed output is bigger
 	// than the input
 	errNoCompression = fmt.Errorf("rcompress: no compression")
+
+	// ErrChecksum is returned when the checksum of a compressed block
+	// does not match its content.
+	ErrChecksum = fmt.Errorf("rcompress: invalid checksum")
 )
 
 // Settings encodes the ROOT way of specifying a compression mechanism
@@ -78,14 +82,6 @@ func (set Settings) Compression() int32 {
 		default:
 			panic(fmt.Errorf("rcompress: unknown compression algorithm: %v", alg))
 		}
-	case lvl == flate.BestSpeed:
-		if alg == ZSTD {
-			lvl = int(zstd.SpeedFastest)
-		}
-	case lvl == flate.BestCompression:
-		if alg == ZSTD {
-			lvl = int(zstd.SpeedBestCompression)
-		}
 	case lvl > 99:
 		lvl = 99
 	}
@@ -223,6 +219,7 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 		hdr[1] = 'Z'
 		cfg := xz.WriterConfig{
 			CheckSum: xz.CRC32,
+			DictCap:  lzmaDictCap(lvl, len(src)),
 		}
 		if err := cfg.Verify(); err != nil {
 			return 0, fmt.Errorf("rcompress: could not create LZMA compressor config: %w", err)
@@ -292,7 +289,7 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 		hdr[1] = 'S'
 		hdr[2] = zstdVersion
 
-		w, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(zstd.EncoderLevel(lvl)))
+		w, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(lvl)))
 		if err != nil {
 			return 0, fmt.Errorf("rcompress: could not create ZSTD compressor: %w", err)
 		}
@@ -339,6 +336,41 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 	return n, nil
 }
 
+// lzmaDictCap returns the LZMA dictionary capacity for the provided
+// compression level, following the xz presets.
+// The capacity is bounded by the size of the data to compress.
+func lzmaDictCap(lvl, n int) int {
+	var (
+		min  = 1 << 12
+		dict = []int{
+			0: 1 << 18,
+			1: 1 << 20,
+			2: 1 << 21,
+			3: 1 << 22,
+			4: 1 << 22,
+			5: 1 << 23,
+			6: 1 << 23,
+			7: 1 << 24,
+			8: 1 << 25,
+			9: 1 << 26,
+		}
+	)
+	switch {
+	case lvl < 0:
+		lvl = 0
+	case lvl >= len(dict):
+		lvl = len(dict) - 1
+	}
+	sz := dict[lvl]
+	if sz > n {
+		sz = n
+	}
+	if sz < min {
+		sz = min
+	}
+	return sz
+}
+
 // Decompress decompresses src into dst.
 func Decompress(dst []byte, src io.Reader) error {
 	var (
@@ -379,7 +411,12 @@ func Decompress(dst []byte, src io.Reader) error {
 				return fmt.Errorf("rcompress: could not read LZ4 block: %w", err)
 			}
 			const chksum = 8
-			// FIXME: we skip the 32b checksum. use it!
+			if len(src) < chksum {
+				return fmt.Errorf("rcompress: invalid LZ4 block size %d", len(src))
+			}
+			if binary.BigEndian.Uint64(src[:chksum]) != xxHash64.Checksum(src[chksum:], 0) {
+				return fmt.Errorf("rcompress: could not decompress LZ4 block: %w", ErrChecksum)
+			}
 			_, err = lz4.UncompressBlock(src[chksum:], dst[beg:end])
 			if err != nil {
 				switch {
@@ -0,0 +1,259 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rarrow // import "go-hep.org/x/hep/groot/rarrow"
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// BatchReader is an ARROW RecordReader for ROOT Trees that aligns its records
+// with the basket boundaries of the tree.
+//
+// Each record holds entries stored in a single basket, for each branch of
+// the tree.
+// BatchReader keeps the baskets of each branch decoded into an Arrow array
+// until a record needs entries past them: records are zero-copy slices of
+// these arrays, so every basket is read and decompressed only once, even
+// when the baskets of a branch span several records.
+// Variable-length arrays and std::vector branches are converted to list
+// arrays.
+// String branches are converted to dictionary arrays (see Dictionary),
+// with one dictionary per set of decoded baskets.
+//
+// The minimal number of entries per record can be configured at creation
+// time with the WithChunk function: consecutive baskets are then merged
+// into a single record until it holds at least that many entries.
+// One can pass -1 to WithChunk to create a record with all entries of the
+// Tree or Chain.
+// The range of entries to read can be configured with the WithStart and
+// WithEnd functions.
+type BatchReader struct {
+	refs int64
+
+	mem    memory.Allocator
+	schema *arrow.Schema
+	tree   rtree.Tree
+
+	beg    int64
+	end    int64
+	bounds []int64 // first entry of each record, and end of the last record
+	cur    int     // index of the next record to load
+
+	cache []basketCache // decoded baskets, for each branch
+	rec   array.Record
+}
+
+// basketCache holds the entries of the last decoded baskets of a branch.
+type basketCache struct {
+	bounds []int64 // basket boundaries of the branch
+	beg    int64   // first entry of the decoded baskets
+	end    int64   // end of the decoded baskets
+	arr    array.Interface
+
+	nloads int // number of times baskets were decoded
+}
+
+// NewBatchReader creates a new ARROW RecordReader from the provided ROOT Tree,
+// with records aligned with the basket boundaries of the tree.
+func NewBatchReader(tree rtree.Tree, opts ...Option) *BatchReader {
+	cfg := newConfig(opts)
+
+	beg := cfg.beg
+	if beg <= 0 {
+		beg = 0
+	}
+
+	end := cfg.end
+	if end < 0 || end > tree.Entries() {
+		end = tree.Entries()
+	}
+
+	schema := SchemaFrom(tree)
+	fields := schema.Fields()
+	for i, f := range fields {
+		if f.Type.ID() == arrow.STRING {
+			fields[i].Type = dictStringType
+		}
+	}
+	schema = arrow.NewSchema(fields, nil)
+
+	cache := make([]basketCache, len(fields))
+	for i, b := range tree.Branches() {
+		cache[i].bounds = rtree.BasketBoundaries(tree, b.Name())
+	}
+
+	return &BatchReader{
+		refs:   1,
+		mem:    cfg.mem,
+		schema: schema,
+		tree:   tree,
+		beg:    beg,
+		end:    end,
+		bounds: batchBounds(rtree.BasketBoundaries(tree), beg, end, cfg.chunks),
+		cache:  cache,
+	}
+}
+
+// batchBounds returns the boundaries of the records holding the entries
+// in [beg, end), aligned with the provided basket boundaries, and holding
+// at least chunk entries (except for the last one.)
+func batchBounds(baskets []int64, beg, end, chunk int64) []int64 {
+	if beg >= end {
+		return nil
+	}
+
+	bounds := []int64{beg}
+	if chunk < 0 {
+		return append(bounds, end)
+	}
+
+	for _, entry := range baskets {
+		if entry <= beg || entry >= end {
+			continue
+		}
+		if entry-bounds[len(bounds)-1] < chunk {
+			continue
+		}
+		bounds = append(bounds, entry)
+	}
+	return append(bounds, end)
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *BatchReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *BatchReader) Release() {
+	if atomic.LoadInt64(&r.refs) <= 0 {
+		panic("groot/rarrow: too many releases")
+	}
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.rec != nil {
+			r.rec.Release()
+			r.rec = nil
+		}
+		for i := range r.cache {
+			c := &r.cache[i]
+			if c.arr != nil {
+				c.arr.Release()
+				c.arr = nil
+			}
+		}
+	}
+}
+
+func (r *BatchReader) Schema() *arrow.Schema { return r.schema }
+func (r *BatchReader) Record() array.Record  { return r.rec }
+
+func (r *BatchReader) Next() bool {
+	if r.cur+1 >= len(r.bounds) {
+		return false
+	}
+
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	var (
+		beg  = r.bounds[r.cur]
+		end  = r.bounds[r.cur+1]
+		cols = make([]array.Interface, len(r.cache))
+	)
+	for i := range r.cache {
+		c := &r.cache[i]
+		if c.arr == nil || beg < c.beg || c.end < end {
+			r.load(i, beg, end)
+		}
+		cols[i] = newSlice(c.arr, beg-c.beg, end-c.beg)
+		defer cols[i].Release()
+	}
+	r.rec = array.NewRecord(r.schema, cols, end-beg)
+	r.cur++
+	return true
+}
+
+// load decodes the baskets of the i-th branch holding the entries in
+// [beg, end).
+func (r *BatchReader) load(i int, beg, end int64) {
+	c := &r.cache[i]
+	if c.arr != nil {
+		c.arr.Release()
+		c.arr = nil
+	}
+
+	c.beg, c.end = r.beg, r.end
+	for _, entry := range c.bounds {
+		if entry <= beg && entry > c.beg {
+			c.beg = entry
+		}
+		if entry >= end && entry < c.end {
+			c.end = entry
+		}
+	}
+
+	var (
+		br    = r.tree.Branches()[i]
+		field = r.schema.Field(i)
+		rvars []rtree.ReadVar
+	)
+	for _, rv := range rtree.NewReadVars(r.tree) {
+		if rv.Name == br.Name() {
+			rvars = append(rvars, rv)
+		}
+	}
+
+	rr, err := rtree.NewReader(r.tree, rvars, rtree.WithRange(c.beg, c.end))
+	if err != nil {
+		panic(fmt.Errorf("could not create reader for branch %q: %+v", br.Name(), err))
+	}
+	defer rr.Close()
+
+	dt := field.Type
+	if dt == dictStringType {
+		dt = dictStringType.ValueType
+	}
+	bldr := builderFrom(r.mem, dt, c.end-c.beg)
+	defer bldr.Release()
+
+	err = rr.Read(func(ctx rtree.RCtx) error {
+		if bldr, ok := bldr.(*array.StructBuilder); ok && len(rvars) > 1 {
+			bldr.Append(true)
+			for j, rv := range rvars {
+				appendValue(bldr.FieldBuilder(j), rv.Deref())
+			}
+			return nil
+		}
+		appendData(bldr, rvars[0], dt)
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Errorf("could not read branch %q: %+v", br.Name(), err))
+	}
+
+	c.arr = bldr.NewArray()
+	if field.Type == dictStringType {
+		arr := c.arr.(*array.String)
+		defer arr.Release()
+		c.arr = newDictionary(r.mem, arr)
+	}
+	c.nloads++
+}
+
+var (
+	_ array.RecordReader = (*BatchReader)(nil)
+)
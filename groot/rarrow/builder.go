@@ -0,0 +1,126 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rarrow // import "go-hep.org/x/hep/groot/rarrow"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// RecordBuilder builds Arrow Records from the values of a set of read-vars,
+// one ROOT Tree entry at a time.
+//
+// RecordBuilder allows to stream the content of a ROOT Tree into a sequence
+// of Arrow Records, without loading the whole Tree in memory.
+type RecordBuilder struct {
+	mem    memory.Allocator
+	schema *arrow.Schema
+	rvars  []rtree.ReadVar
+	blds   []array.Builder
+	nrows  int64
+}
+
+// NewRecordBuilder creates a new Record builder for the provided read-vars,
+// bound to the leaves of the ROOT Tree t.
+//
+// Each read-var is mapped to a field of the Records' schema.
+// The field is named after the branch of the read-var, or after
+// "branch.leaf" when the branch holds more than one leaf.
+func NewRecordBuilder(t rtree.Tree, rvars []rtree.ReadVar, opts ...Option) (*RecordBuilder, error) {
+	cfg := newConfig(opts)
+
+	fields := make([]arrow.Field, len(rvars))
+	for i, rvar := range rvars {
+		leaf, err := leafFrom(t, rvar)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = arrow.Field{
+			Name: fieldName(rvar),
+			Type: dataTypeFromLeaf(leaf),
+		}
+	}
+
+	b := &RecordBuilder{
+		mem:    cfg.mem,
+		schema: arrow.NewSchema(fields, nil),
+		rvars:  rvars,
+		blds:   make([]array.Builder, len(fields)),
+	}
+	for i, field := range fields {
+		b.blds[i] = builderFrom(b.mem, field.Type, cfg.chunks)
+	}
+
+	return b, nil
+}
+
+// fieldName returns the name of the Arrow field associated with the
+// provided read-var.
+func fieldName(rvar rtree.ReadVar) string {
+	if rvar.Leaf == "" || rvar.Leaf == rvar.Name {
+		return rvar.Name
+	}
+	return rvar.Name + "." + rvar.Leaf
+}
+
+func leafFrom(t rtree.Tree, rvar rtree.ReadVar) (rtree.Leaf, error) {
+	b := t.Branch(rvar.Name)
+	if b == nil {
+		return nil, fmt.Errorf("rarrow: could not find branch %q", rvar.Name)
+	}
+	leaves := b.Leaves()
+	if rvar.Leaf == "" && len(leaves) == 1 {
+		return leaves[0], nil
+	}
+	for _, leaf := range leaves {
+		if leaf.Name() == rvar.Leaf {
+			return leaf, nil
+		}
+	}
+	return nil, fmt.Errorf("rarrow: could not find leaf %q in branch %q", rvar.Leaf, rvar.Name)
+}
+
+// Schema returns the schema of the Records built by this builder.
+func (b *RecordBuilder) Schema() *arrow.Schema { return b.schema }
+
+// Len returns the number of entries appended since the last Record
+// was built.
+func (b *RecordBuilder) Len() int64 { return b.nrows }
+
+// Append appends the current values of the read-vars to the Record
+// being built.
+func (b *RecordBuilder) Append() {
+	for i, field := range b.schema.Fields() {
+		appendData(b.blds[i], b.rvars[i], field.Type)
+	}
+	b.nrows++
+}
+
+// NewRecord creates a new Record from the appended entries and
+// resets the builder so it can be used to build a new Record.
+// The returned Record must be Release()'d after use.
+func (b *RecordBuilder) NewRecord() array.Record {
+	cols := make([]array.Interface, len(b.blds))
+	for i, bldr := range b.blds {
+		cols[i] = bldr.NewArray()
+		defer cols[i].Release()
+	}
+
+	rec := array.NewRecord(b.schema, cols, b.nrows)
+	b.nrows = 0
+	return rec
+}
+
+// Release releases the memory held by this builder.
+func (b *RecordBuilder) Release() {
+	for _, bldr := range b.blds {
+		bldr.Release()
+	}
+	b.blds = nil
+}
@@ -0,0 +1,94 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rarrow // import "go-hep.org/x/hep/groot/rarrow"
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestRecordBuilder(t *testing.T) {
+	f, err := groot.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := o.(rtree.Tree)
+
+	var rvars []rtree.ReadVar
+	for _, rvar := range rtree.NewReadVars(tree) {
+		switch rvar.Name {
+		case "Int64", "Str", "SliceFloat64":
+			rvars = append(rvars, rvar)
+		}
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bldr, err := NewRecordBuilder(tree, rvars, WithAllocator(mem), WithChunk(10))
+	if err != nil {
+		t.Fatalf("could not create record builder: %+v", err)
+	}
+	defer bldr.Release()
+
+	if got, want := bldr.Schema().String(), "schema:\n  fields: 3\n    - Int64: type=int64\n    - Str: type=utf8\n    - SliceFloat64: type=list<item: float64>"; got != want {
+		t.Fatalf("invalid schema:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	r, err := rtree.NewReader(tree, rvars, rtree.WithRange(0, 25))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	var recs []array.Record
+	err = r.Read(func(ctx rtree.RCtx) error {
+		bldr.Append()
+		if bldr.Len() == 10 {
+			recs = append(recs, bldr.NewRecord())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+	recs = append(recs, bldr.NewRecord())
+
+	if got, want := len(recs), 3; got != want {
+		t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+	}
+
+	var n int64
+	for _, rec := range recs {
+		i64 := rec.Column(0).(*array.Int64)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			if got, want := i64.Value(i), n; got != want {
+				t.Fatalf("invalid value: got=%d, want=%d", got, want)
+			}
+			n++
+		}
+		rec.Release()
+	}
+
+	if got, want := n, int64(25); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	_, err = NewRecordBuilder(tree, []rtree.ReadVar{{Name: "not-there"}})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rarrow // import "go-hep.org/x/hep/groot/rarrow"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// DictionaryType is the Arrow data type of dictionary-encoded arrays:
+// each value is stored as an index into a dictionary of distinct values.
+type DictionaryType struct {
+	IndexType arrow.DataType
+	ValueType arrow.DataType
+}
+
+// dictStringType is the data type of dictionary-encoded strings.
+var dictStringType = &DictionaryType{
+	IndexType: arrow.PrimitiveTypes.Int32,
+	ValueType: arrow.BinaryTypes.String,
+}
+
+func (*DictionaryType) ID() arrow.Type { return arrow.DICTIONARY }
+func (*DictionaryType) Name() string   { return "dictionary" }
+func (dt *DictionaryType) String() string {
+	return fmt.Sprintf("dictionary<values=%v, indices=%v>", dt.ValueType, dt.IndexType)
+}
+
+// Dictionary is a dictionary-encoded array of strings.
+//
+// Dictionary implements array.Interface: validity and Data are those of
+// the array of indices.
+type Dictionary struct {
+	dtype *DictionaryType
+	index *array.Int32  // indices of the values into the dictionary
+	dict  *array.String // distinct values
+}
+
+// newDictionary dictionary-encodes the provided array of strings.
+func newDictionary(mem memory.Allocator, arr *array.String) *Dictionary {
+	var (
+		ids  = make(map[string]int32)
+		ibld = array.NewInt32Builder(mem)
+		dbld = array.NewStringBuilder(mem)
+	)
+	defer ibld.Release()
+	defer dbld.Release()
+
+	ibld.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			ibld.AppendNull()
+			continue
+		}
+		v := arr.Value(i)
+		id, ok := ids[v]
+		if !ok {
+			id = int32(len(ids))
+			ids[v] = id
+			dbld.Append(v)
+		}
+		ibld.Append(id)
+	}
+
+	return &Dictionary{
+		dtype: dictStringType,
+		index: ibld.NewInt32Array(),
+		dict:  dbld.NewStringArray(),
+	}
+}
+
+func (a *Dictionary) DataType() arrow.DataType { return a.dtype }
+func (a *Dictionary) NullN() int               { return a.index.NullN() }
+func (a *Dictionary) NullBitmapBytes() []byte  { return a.index.NullBitmapBytes() }
+func (a *Dictionary) IsNull(i int) bool        { return a.index.IsNull(i) }
+func (a *Dictionary) IsValid(i int) bool       { return a.index.IsValid(i) }
+func (a *Dictionary) Data() *array.Data        { return a.index.Data() }
+func (a *Dictionary) Len() int                 { return a.index.Len() }
+
+// Indices returns the indices of the values into the dictionary.
+func (a *Dictionary) Indices() *array.Int32 { return a.index }
+
+// Dictionary returns the distinct values of the array.
+func (a *Dictionary) Dictionary() *array.String { return a.dict }
+
+// Value returns the i-th value of the array.
+func (a *Dictionary) Value(i int) string {
+	return a.dict.Value(int(a.index.Value(i)))
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (a *Dictionary) Retain() {
+	a.index.Retain()
+	a.dict.Retain()
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *Dictionary) Release() {
+	a.index.Release()
+	a.dict.Release()
+}
+
+func (a *Dictionary) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			fmt.Fprintf(o, "%q", a.Value(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// newSlice returns a zero-copy slice of the array, sharing its dictionary.
+func (a *Dictionary) newSlice(i, j int64) *Dictionary {
+	a.dict.Retain()
+	return &Dictionary{
+		dtype: a.dtype,
+		index: array.NewSlice(a.index, i, j).(*array.Int32),
+		dict:  a.dict,
+	}
+}
+
+// newSlice returns a zero-copy slice of the provided array.
+func newSlice(arr array.Interface, i, j int64) array.Interface {
+	if arr, ok := arr.(*Dictionary); ok {
+		return arr.newSlice(i, j)
+	}
+	return array.NewSlice(arr, i, j)
+}
+
+var (
+	_ arrow.DataType  = (*DictionaryType)(nil)
+	_ array.Interface = (*Dictionary)(nil)
+)
@@ -7,9 +7,12 @@ package rarrow // import "go-hep.org/x/hep/groot/rarrow"
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/memory"
 	"go-hep.org/x/hep/groot"
@@ -266,3 +269,237 @@ func TestRecordReader(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchReader(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "batch.root")
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var (
+			i32 int32
+			f64 float64
+			n   int32
+			arr = make([]float64, 0, 3)
+		)
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "I32", Value: &i32},
+			{Name: "F64", Value: &f64},
+			{Name: "N", Value: &n},
+			{Name: "ArrF64", Value: &arr, Count: "N"},
+		}, rtree.WithBasketSize(8*10))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 30; i++ {
+			i32 = int32(i)
+			f64 = float64(i)
+			n = int32(i % 3)
+			arr = arr[:n]
+			for j := range arr {
+				arr[j] = float64(i)
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](riofs.Dir(f), "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	// baskets of I32 and N hold 19 entries, baskets of F64 hold 9 entries.
+	if got, want := rtree.BasketBoundaries(tree), []int64{0, 9, 18, 19, 27, 30}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid basket boundaries:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		opts  []Option
+		beg   int64   // first entry
+		want  []int64 // number of entries of each record
+		loads []int   // number of basket decodings of I32 and F64
+	}{
+		{
+			name:  "baskets",
+			want:  []int64{9, 9, 1, 8, 3},
+			loads: []int{2, 4},
+		},
+		{
+			name: "chunk=10",
+			opts: []Option{WithChunk(10)},
+			want: []int64{18, 12},
+		},
+		{
+			name: "chunk=-1",
+			opts: []Option{WithChunk(-1)},
+			want: []int64{30},
+		},
+		{
+			name:  "range",
+			opts:  []Option{WithStart(5), WithEnd(25)},
+			beg:   5,
+			want:  []int64{4, 9, 1, 6},
+			loads: []int{2, 3},
+		},
+		{
+			name: "empty-range",
+			opts: []Option{WithStart(5), WithEnd(5)},
+			beg:  5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			rr := NewBatchReader(tree, append(tc.opts, WithAllocator(mem))...)
+			defer rr.Release()
+
+			if got, want := rr.Schema().Field(3).Type.ID(), arrow.LIST; got != want {
+				t.Fatalf("invalid type for jagged branch: got=%v, want=%v", got, want)
+			}
+
+			var (
+				rows []int64
+				next = tc.beg
+			)
+			for rr.Next() {
+				rec := rr.Record()
+				rows = append(rows, rec.NumRows())
+
+				i32s := rec.Column(0).(*array.Int32).Int32Values()
+				// records are slices of the decoded baskets: offsets of list
+				// arrays are relative to the first entry of these baskets.
+				lens := rec.Column(3).(*array.List).Offsets()[rec.Column(3).Data().Offset():]
+				for i, v := range i32s {
+					if int64(v) != next {
+						t.Fatalf("invalid entry: got=%d, want=%d", v, next)
+					}
+					if got, want := lens[i+1]-lens[i], int32(next%3); got != want {
+						t.Fatalf("invalid length for entry %d: got=%d, want=%d", next, got, want)
+					}
+					next++
+				}
+			}
+
+			if got, want := rows, tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid records:\ngot= %v\nwant=%v", got, want)
+			}
+
+			if tc.loads == nil {
+				return
+			}
+			if got, want := []int{rr.cache[0].nloads, rr.cache[1].nloads}, tc.loads; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid number of basket decodings:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestBatchReaderDictionary(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "dict.root")
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var str string
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "Str", Value: &str},
+		})
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 10; i++ {
+			str = fmt.Sprintf("str-%d", i%3)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](riofs.Dir(f), "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rr := NewBatchReader(tree, WithAllocator(mem), WithStart(2))
+	defer rr.Release()
+
+	if got, want := rr.Schema().Field(0).Type.ID(), arrow.DICTIONARY; got != want {
+		t.Fatalf("invalid type for string branch: got=%v, want=%v", got, want)
+	}
+
+	if !rr.Next() {
+		t.Fatalf("could not read record")
+	}
+
+	arr, ok := rr.Record().Column(0).(*Dictionary)
+	if !ok {
+		t.Fatalf("invalid array type: %T", rr.Record().Column(0))
+	}
+	if got, want := arr.Len(), 8; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	if got, want := arr.Dictionary().Len(), 3; got != want {
+		t.Fatalf("invalid dictionary size: got=%d, want=%d", got, want)
+	}
+	if got, want := arr.Indices().Int32Values(), []int32{0, 1, 2, 0, 1, 2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := arr.String(), `["str-2" "str-0" "str-1" "str-2" "str-0" "str-1" "str-2" "str-0"]`; got != want {
+		t.Fatalf("invalid values:\ngot= %s\nwant=%s", got, want)
+	}
+
+	if rr.Next() {
+		t.Fatalf("unexpected record")
+	}
+}
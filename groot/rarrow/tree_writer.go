@@ -30,8 +30,11 @@ func NewFlatTreeWriter(dir riofs.Directory, name string, schema *arrow.Schema, o
 		wvars = make([]rtree.WriteVar, 0, len(ctx.wvars)+len(ctx.count))
 	)
 
-	for _, wvar := range ctx.count {
-		wvars = append(wvars, wvar)
+	// count branches are written first, in the order of the schema fields.
+	for _, field := range schema.Fields() {
+		if wvar, ok := ctx.count[field.Name]; ok {
+			wvars = append(wvars, wvar)
+		}
 	}
 	wvars = append(wvars, ctx.wvars...)
 
@@ -84,6 +87,61 @@ func (fw *flatTreeWriter) Write(rec array.Record) error {
 	return nil
 }
 
+// WriteRecord writes the provided ARROW record as a ROOT flat-tree named
+// name under the provided dir directory.
+//
+// List columns are written as variable-length arrays, whose sizes are
+// stored in a dedicated count branch.
+func WriteRecord(dir riofs.Directory, name string, rec array.Record, opts ...rtree.WriteOption) error {
+	tw, err := NewFlatTreeWriter(dir, name, rec.Schema(), opts...)
+	if err != nil {
+		return err
+	}
+	defer tw.Close()
+
+	err = tw.Write(rec)
+	if err != nil {
+		return err
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("rarrow: could not close flat-tree writer %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// WriteTable writes the provided ARROW table as a ROOT flat-tree named
+// name under the provided dir directory.
+//
+// List columns are written as variable-length arrays, whose sizes are
+// stored in a dedicated count branch.
+func WriteTable(dir riofs.Directory, name string, tbl array.Table, opts ...rtree.WriteOption) error {
+	tw, err := NewFlatTreeWriter(dir, name, tbl.Schema(), opts...)
+	if err != nil {
+		return err
+	}
+	defer tw.Close()
+
+	r := array.NewTableReader(tbl, -1)
+	defer r.Release()
+
+	for r.Next() {
+		err = tw.Write(r.Record())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("rarrow: could not close flat-tree writer %q: %w", name, err)
+	}
+
+	return nil
+}
+
 type contextWriter struct {
 	wvars []rtree.WriteVar
 	count map[string]rtree.WriteVar
@@ -0,0 +1,172 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rarrow
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestWriteTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "str", Type: arrow.BinaryTypes.String},
+		{Name: "arr", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int64)},
+		{Name: "sli", Type: arrow.ListOf(arrow.PrimitiveTypes.Float32)},
+		{Name: "u16s", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint16)},
+	}, nil)
+
+	newRecord := func(beg, end int) array.Record {
+		bldr := array.NewRecordBuilder(mem, schema)
+		defer bldr.Release()
+
+		for i := beg; i < end; i++ {
+			bldr.Field(0).(*array.Int32Builder).Append(int32(i))
+			bldr.Field(1).(*array.Float64Builder).Append(float64(i))
+			bldr.Field(2).(*array.StringBuilder).Append(string(rune('a' + i)))
+
+			arr := bldr.Field(3).(*array.FixedSizeListBuilder)
+			arr.Append(true)
+			arr.ValueBuilder().(*array.Int64Builder).AppendValues([]int64{int64(i), int64(-i)}, nil)
+
+			sli := bldr.Field(4).(*array.ListBuilder)
+			sli.Append(true)
+			for j := 0; j < i%3; j++ {
+				sli.ValueBuilder().(*array.Float32Builder).Append(float32(i))
+			}
+
+			u16 := bldr.Field(5).(*array.ListBuilder)
+			u16.Append(true)
+			for j := 0; j < i%2; j++ {
+				u16.ValueBuilder().(*array.Uint16Builder).Append(uint16(i))
+			}
+		}
+		return bldr.NewRecord()
+	}
+
+	recs := []array.Record{newRecord(0, 5), newRecord(5, 12)}
+	defer recs[0].Release()
+	defer recs[1].Release()
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	fname := filepath.Join(t.TempDir(), "table.root")
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		err = WriteTable(f, "table", tbl, rtree.WithTitle("from table"))
+		if err != nil {
+			t.Fatalf("could not write table: %+v", err)
+		}
+
+		err = WriteRecord(f, "record", recs[1])
+		if err != nil {
+			t.Fatalf("could not write record: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		name string
+		beg  int
+		end  int
+	}{
+		{name: "table", beg: 0, end: 12},
+		{name: "record", beg: 5, end: 12},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := riofs.Get[rtree.Tree](riofs.Dir(f), tc.name)
+			if err != nil {
+				t.Fatalf("could not get tree: %+v", err)
+			}
+
+			if got, want := tree.Entries(), int64(tc.end-tc.beg); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var names []string
+			for _, b := range tree.Branches() {
+				names = append(names, b.Name())
+			}
+			want := []string{"rarrow_n_sli", "rarrow_n_u16s", "i32", "f64", "str", "arr", "sli", "u16s"}
+			if !reflect.DeepEqual(names, want) {
+				t.Fatalf("invalid branches:\ngot= %q\nwant=%q", names, want)
+			}
+
+			var (
+				i32  int32
+				f64  float64
+				str  string
+				arr  [2]int64
+				sli  []float32
+				u16s []uint16
+			)
+			r, err := rtree.NewReader(tree, []rtree.ReadVar{
+				{Name: "i32", Value: &i32},
+				{Name: "f64", Value: &f64},
+				{Name: "str", Value: &str},
+				{Name: "arr", Value: &arr},
+				{Name: "sli", Value: &sli},
+				{Name: "u16s", Value: &u16s},
+			})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				i := tc.beg + int(ctx.Entry)
+				if i32 != int32(i) || f64 != float64(i) || str != string(rune('a'+i)) {
+					t.Fatalf("invalid scalars for entry %d: i32=%d, f64=%v, str=%q", i, i32, f64, str)
+				}
+				if arr != [2]int64{int64(i), int64(-i)} {
+					t.Fatalf("invalid array for entry %d: %v", i, arr)
+				}
+				if got, want := len(sli), i%3; got != want {
+					t.Fatalf("invalid slice length for entry %d: got=%d, want=%d", i, got, want)
+				}
+				for _, v := range sli {
+					if v != float32(i) {
+						t.Fatalf("invalid slice for entry %d: %v", i, sli)
+					}
+				}
+				if got, want := len(u16s), i%2; got != want {
+					t.Fatalf("invalid slice length for entry %d: got=%d, want=%d", i, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Att3D is the ROOT 3D attributes class.
+// TAtt3D has no data member.
+type Att3D struct{}
+
+func NewAtt3D() *Att3D {
+	return &Att3D{}
+}
+
+func (*Att3D) Class() string {
+	return "TAtt3D"
+}
+
+func (*Att3D) RVersion() int16 {
+	return rvers.Att3D
+}
+
+func (a *Att3D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(a.Class(), a.RVersion())
+	return w.SetHeader(hdr)
+}
+
+func (a *Att3D) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(a.Class())
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAtt3D()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAtt3D", f)
+}
+
+var (
+	_ root.Object        = (*Att3D)(nil)
+	_ rbytes.Marshaler   = (*Att3D)(nil)
+	_ rbytes.Unmarshaler = (*Att3D)(nil)
+)
@@ -0,0 +1,94 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// AttPad holds the margins and frame attributes of a pad.
+type AttPad struct {
+	fLeftMargin      float32 // LeftMargin
+	fRightMargin     float32 // RightMargin
+	fBottomMargin    float32 // BottomMargin
+	fTopMargin       float32 // TopMargin
+	fXfile           float32 // X position where to draw the file name
+	fYfile           float32 // Y position where to draw the file name
+	fAfile           float32 // Alignment for the file name
+	fXstat           float32 // X position where to draw the statistics
+	fYstat           float32 // Y position where to draw the statistics
+	fAstat           float32 // Alignment for the statistics
+	fFrameFillColor  int16   // Pad frame fill color
+	fFrameLineColor  int16   // Pad frame line color
+	fFrameFillStyle  int16   // Pad frame fill style
+	fFrameLineStyle  int16   // Pad frame line style
+	fFrameLineWidth  int16   // Pad frame line width
+	fFrameBorderSize int16   // Pad frame border size
+	fFrameBorderMode int32   // Pad frame border mode
+}
+
+func (*AttPad) Class() string {
+	return "TAttPad"
+}
+
+func (*AttPad) RVersion() int16 {
+	return rvers.AttPad
+}
+
+// Margins returns the left, right, bottom and top margins of the pad,
+// as fractions of the pad size.
+func (a *AttPad) Margins() (left, right, bottom, top float64) {
+	return float64(a.fLeftMargin), float64(a.fRightMargin),
+		float64(a.fBottomMargin), float64(a.fTopMargin)
+}
+
+func (a *AttPad) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(a.Class())
+
+	a.fLeftMargin = r.ReadF32()
+	a.fRightMargin = r.ReadF32()
+	a.fBottomMargin = r.ReadF32()
+	a.fTopMargin = r.ReadF32()
+	a.fXfile = r.ReadF32()
+	a.fYfile = r.ReadF32()
+	a.fAfile = r.ReadF32()
+	a.fXstat = r.ReadF32()
+	a.fYstat = r.ReadF32()
+	a.fAstat = r.ReadF32()
+	if hdr.Vers > 1 {
+		a.fFrameFillColor = r.ReadI16()
+		a.fFrameLineColor = r.ReadI16()
+		a.fFrameFillStyle = r.ReadI16()
+		a.fFrameLineStyle = r.ReadI16()
+		a.fFrameLineWidth = r.ReadI16()
+		a.fFrameBorderSize = r.ReadI16()
+		a.fFrameBorderMode = r.ReadI32()
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		var v AttPad
+		return reflect.ValueOf(&v)
+	}
+	rtypes.Factory.Add("TAttPad", f)
+}
+
+var (
+	_ root.Object        = (*AttPad)(nil)
+	_ rbytes.Unmarshaler = (*AttPad)(nil)
+)
@@ -0,0 +1,89 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+type AttText struct {
+	Angle float32 // text angle, in degrees
+	Size  float32 // text size
+	Align int16   // text alignment, 10*horizontal+vertical
+	Color int16   // text color
+	Font  int16   // text font, 10*font+precision
+}
+
+func NewAttText() *AttText {
+	return &AttText{
+		Angle: 0,
+		Size:  0.05,
+		Align: 11,
+		Color: 1,
+		Font:  62,
+	}
+}
+
+func (*AttText) Class() string {
+	return "TAttText"
+}
+
+func (*AttText) RVersion() int16 {
+	return rvers.AttText
+}
+
+func (a *AttText) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(a.Class(), a.RVersion())
+	w.WriteF32(a.Angle)
+	w.WriteF32(a.Size)
+	w.WriteI16(a.Align)
+	w.WriteI16(a.Color)
+	w.WriteI16(a.Font)
+	return w.SetHeader(hdr)
+}
+
+func (a *AttText) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(a.Class())
+	if hdr.Vers > rvers.AttText {
+		panic(fmt.Errorf("rbase: invalid atttext version=%d > %d", hdr.Vers, rvers.AttText))
+	}
+
+	a.Angle = r.ReadF32()
+	a.Size = r.ReadF32()
+	a.Align = r.ReadI16()
+	a.Color = r.ReadI16()
+	a.Font = r.ReadI16()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAttText()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAttText", f)
+}
+
+var (
+	_ root.Object        = (*AttText)(nil)
+	_ rbytes.Marshaler   = (*AttText)(nil)
+	_ rbytes.Unmarshaler = (*AttText)(nil)
+)
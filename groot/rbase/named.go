@@ -50,6 +50,15 @@ func (n *Named) Title() string {
 func (n *Named) SetName(name string)   { n.name = name }
 func (n *Named) SetTitle(title string) { n.title = title }
 
+// TestBits returns whether any of the provided bits of the object is set.
+func (n *Named) TestBits(bits uint32) bool { return n.obj.TestBits(bits) }
+
+// SetBit sets the provided bit of the object.
+func (n *Named) SetBit(bit uint32) { n.obj.SetBit(bit) }
+
+// ResetBit resets the provided bit of the object.
+func (n *Named) ResetBit(bit uint32) { n.obj.ResetBit(bit) }
+
 func (*Named) Class() string {
 	return "TNamed"
 }
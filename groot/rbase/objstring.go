@@ -39,6 +39,11 @@ func (obj *ObjString) UID() uint32 {
 	return obj.obj.UID()
 }
 
+// SetUID sets the unique ID of the object.
+func (obj *ObjString) SetUID(id uint32) {
+	obj.obj.SetID(id)
+}
+
 func (obj *ObjString) Name() string {
 	return obj.str
 }
@@ -82,6 +82,10 @@ func TestWRBuffer(t *testing.T) {
 				obj: Object{ID: 0x0, Bits: 0x3000000},
 			},
 		},
+		{
+			name: "TAttText",
+			want: &AttText{Angle: 45, Size: 0.04, Align: 22, Color: 2, Font: 42},
+		},
 		{
 			name: "TDatime",
 			want: func() *Datime {
@@ -0,0 +1,72 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// VirtualPad is the abstract base class of pads and canvases.
+type VirtualPad struct {
+	obj     Object
+	attline AttLine
+	attfill AttFill
+	attpad  AttPad
+	qobj    QObject
+}
+
+func (*VirtualPad) Class() string {
+	return "TVirtualPad"
+}
+
+func (*VirtualPad) RVersion() int16 {
+	return rvers.VirtualPad
+}
+
+// AttFill returns the fill attributes of the pad.
+func (vpad *VirtualPad) AttFill() *AttFill { return &vpad.attfill }
+
+// AttLine returns the line attributes of the pad.
+func (vpad *VirtualPad) AttLine() *AttLine { return &vpad.attline }
+
+// AttPad returns the margins and frame attributes of the pad.
+func (vpad *VirtualPad) AttPad() *AttPad { return &vpad.attpad }
+
+func (vpad *VirtualPad) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(vpad.Class())
+
+	r.ReadObject(&vpad.obj)
+	r.ReadObject(&vpad.attline)
+	r.ReadObject(&vpad.attfill)
+	r.ReadObject(&vpad.attpad)
+	if hdr.Vers > 1 {
+		r.ReadObject(&vpad.qobj)
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		var v VirtualPad
+		return reflect.ValueOf(&v)
+	}
+	rtypes.Factory.Add("TVirtualPad", f)
+}
+
+var (
+	_ root.Object        = (*VirtualPad)(nil)
+	_ rbytes.Unmarshaler = (*VirtualPad)(nil)
+)
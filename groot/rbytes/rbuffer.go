@@ -237,6 +237,10 @@ func (r *RBuffer) ReadString() string {
 	if v == 0 {
 		return ""
 	}
+	if int64(n-1) > r.Len() {
+		r.err = fmt.Errorf("rbytes: invalid string length %d (remaining bytes: %d)", n, r.Len()+1)
+		return ""
+	}
 	buf := make([]byte, n)
 	buf[0] = v
 	if n != 0 {
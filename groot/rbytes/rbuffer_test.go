@@ -60,6 +60,21 @@ func TestRBuffer(t *testing.T) {
 	}
 }
 
+func TestRBufferReadStringTooLong(t *testing.T) {
+	for _, data := range [][]byte{
+		{3, 'a', 'b'},
+		{255, 255, 255, 255, 255, 'a', 'b', 'c'},
+	} {
+		r := NewRBuffer(data, nil, 0, nil)
+		if got := r.ReadString(); got != "" {
+			t.Fatalf("invalid string: got=%q, want=%q", got, "")
+		}
+		if r.Err() == nil {
+			t.Fatalf("expected an error reading a truncated string")
+		}
+	}
+}
+
 var f64sBenchSink = 0
 
 func BenchmarkReadF64s(b *testing.B) {
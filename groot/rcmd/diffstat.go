@@ -0,0 +1,481 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/rootcnv"
+)
+
+// DiffOption controls how DiffStat behaves.
+type DiffOption func(*diffStatCmd)
+
+// DiffKeys restricts the comparison to the provided keys, and to the
+// content of the directories they name.
+// By default, all the keys of both files are compared.
+func DiffKeys(keys ...string) DiffOption {
+	return func(cmd *diffStatCmd) {
+		for _, k := range keys {
+			k = strings.Trim(strings.TrimSpace(k), "/")
+			if k == "" {
+				continue
+			}
+			cmd.keys = append(cmd.keys, k)
+		}
+	}
+}
+
+// DiffTolerance sets the absolute and relative tolerances used to compare
+// the bins of histograms.
+// Two values a and b are considered equal when:
+//
+//	|a-b| <= abs + rel*max(|a|, |b|)
+//
+// By default, values must be exactly equal.
+func DiffTolerance(abs, rel float64) DiffOption {
+	return func(cmd *diffStatCmd) {
+		cmd.abs = abs
+		cmd.rel = rel
+	}
+}
+
+// DiffJSON enables the JSON output of the differences report.
+func DiffJSON(v bool) DiffOption {
+	return func(cmd *diffStatCmd) {
+		cmd.json = v
+	}
+}
+
+// DiffStat compares the structure and the statistical content of the two
+// provided ROOT files and writes a report of their differences to w.
+//
+// DiffStat reports the keys present in only one of the files, the
+// differences in the contents of the bins of histograms, in the number of
+// entries of trees and in the checksums of the values of each of their
+// branches.
+// Other objects are compared as a whole.
+//
+// Unlike Diff, DiffStat compares all the keys, even when some of them
+// differ, and does not report the differing values of each entry of trees.
+// The report is in a human-readable text format, unless the DiffJSON
+// option is enabled.
+//
+// DiffStat returns an error if the files differ.
+func DiffStat(w io.Writer, ref, chk *riofs.File, opts ...DiffOption) error {
+	cmd := diffStatCmd{
+		report: diffReport{
+			Ref:  ref.Name(),
+			Chk:  chk.Name(),
+			Keys: []*diffKey{},
+		},
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	err := cmd.diff(ref, chk)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.json:
+		o, err := json.MarshalIndent(cmd.report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode report to JSON: %w", err)
+		}
+		o = append(o, '\n')
+		_, err = w.Write(o)
+		if err != nil {
+			return fmt.Errorf("could not write report: %w", err)
+		}
+	default:
+		err = cmd.report.writeText(w)
+		if err != nil {
+			return fmt.Errorf("could not write report: %w", err)
+		}
+	}
+
+	if !cmd.report.Same {
+		return fmt.Errorf("files differ")
+	}
+	return nil
+}
+
+type diffStatCmd struct {
+	keys []string
+	abs  float64
+	rel  float64
+	json bool
+
+	report diffReport
+}
+
+// Status of a key in a differences report.
+const (
+	diffSame       = "same"
+	diffDiffer     = "differ"
+	diffMissingRef = "missing-ref"
+	diffMissingChk = "missing-chk"
+)
+
+type diffReport struct {
+	Ref  string     `json:"ref"`
+	Chk  string     `json:"chk"`
+	Same bool       `json:"same"`
+	Keys []*diffKey `json:"keys"`
+}
+
+type diffKey struct {
+	Name   string       `json:"name"`
+	Class  string       `json:"class"`
+	Status string       `json:"status"`
+	Diffs  []*diffValue `json:"diffs,omitempty"`
+}
+
+// diffValue describes a quantity that differs between the two files.
+type diffValue struct {
+	Name string      `json:"name"`
+	Ref  interface{} `json:"ref"`
+	Chk  interface{} `json:"chk"`
+}
+
+func (key *diffKey) add(name string, ref, chk interface{}) {
+	key.Status = diffDiffer
+	key.Diffs = append(key.Diffs, &diffValue{Name: name, Ref: ref, Chk: chk})
+}
+
+func (r *diffReport) writeText(w io.Writer) error {
+	var err error
+	pf := func(format string, args ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, format, args...)
+	}
+
+	for _, key := range r.Keys {
+		switch key.Status {
+		case diffSame:
+			continue
+		case diffMissingRef:
+			pf("key[%s] (%s) -- missing from ref-file\n", key.Name, key.Class)
+		case diffMissingChk:
+			pf("key[%s] (%s) -- missing from chk-file\n", key.Name, key.Class)
+		default:
+			pf("key[%s] (%s) -- (-ref +chk)\n", key.Name, key.Class)
+			for _, v := range key.Diffs {
+				pf("  %s: -%v +%v\n", v.Name, v.Ref, v.Chk)
+			}
+		}
+	}
+	if r.Same {
+		pf("files %q and %q are identical\n", r.Ref, r.Chk)
+	}
+	return err
+}
+
+// selected returns whether the named object should be compared.
+func (cmd *diffStatCmd) selected(name string) bool {
+	if len(cmd.keys) == 0 {
+		return true
+	}
+	for _, k := range cmd.keys {
+		if name == k || strings.HasPrefix(name, k+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// objects returns the objects contained in the provided file, indexed by
+// their path.
+func (cmd *diffStatCmd) objects(f *riofs.File) (map[string]root.Object, error) {
+	objs := make(map[string]root.Object)
+	err := riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path[len(f.Name()):], "/")
+		if name == "" || !cmd.selected(name) {
+			return nil
+		}
+		objs[name] = obj
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not inspect ROOT file %q: %w", f.Name(), err)
+	}
+	return objs, nil
+}
+
+func (cmd *diffStatCmd) diff(fref, fchk *riofs.File) error {
+	refs, err := cmd.objects(fref)
+	if err != nil {
+		return err
+	}
+	chks, err := cmd.objects(fchk)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(refs)+len(chks))
+	for name := range refs {
+		names = append(names, name)
+	}
+	for name := range chks {
+		if _, dup := refs[name]; !dup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, k := range cmd.keys {
+		if _, ok := refs[k]; ok {
+			continue
+		}
+		if _, ok := chks[k]; ok {
+			continue
+		}
+		return fmt.Errorf("could not find key %q", k)
+	}
+
+	cmd.report.Same = true
+	for _, name := range names {
+		var (
+			ref, okRef = refs[name]
+			chk, okChk = chks[name]
+			key        = &diffKey{Name: name, Status: diffSame}
+		)
+		switch {
+		case !okRef:
+			key.Class = chk.Class()
+			key.Status = diffMissingRef
+		case !okChk:
+			key.Class = ref.Class()
+			key.Status = diffMissingChk
+		default:
+			key.Class = ref.Class()
+			err := cmd.diffObject(key, ref, chk)
+			if err != nil {
+				return fmt.Errorf("could not compare %q: %w", name, err)
+			}
+		}
+		if key.Status != diffSame {
+			cmd.report.Same = false
+		}
+		cmd.report.Keys = append(cmd.report.Keys, key)
+	}
+
+	return nil
+}
+
+func (cmd *diffStatCmd) diffObject(key *diffKey, ref, chk root.Object) error {
+	if cref, cchk := ref.Class(), chk.Class(); cref != cchk {
+		key.add("class", cref, cchk)
+		return nil
+	}
+
+	switch ref := ref.(type) {
+	case riofs.Directory:
+		// directories are compared through their content.
+		return nil
+	case rtree.Tree:
+		return cmd.diffTree(key, ref, chk.(rtree.Tree))
+	case rhist.H2:
+		cmd.diffH2(key, rootcnv.H2D(ref), rootcnv.H2D(chk.(rhist.H2)))
+		return nil
+	case rhist.H1:
+		cmd.diffH1(key, rootcnv.H1D(ref), rootcnv.H1D(chk.(rhist.H1)))
+		return nil
+	default:
+		if !reflect.DeepEqual(ref, chk) {
+			key.add("value", fmt.Sprintf("%v", ref), fmt.Sprintf("%v", chk))
+		}
+		return nil
+	}
+}
+
+// equal returns whether a and b are equal, within the tolerances.
+func (cmd *diffStatCmd) equal(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= cmd.abs+cmd.rel*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func (cmd *diffStatCmd) diffDist(key *diffKey, name string, ref, chk interface {
+	SumW() float64
+	SumW2() float64
+}) {
+	if a, b := ref.SumW(), chk.SumW(); !cmd.equal(a, b) {
+		key.add(name+".sumw", a, b)
+	}
+	if a, b := ref.SumW2(), chk.SumW2(); !cmd.equal(a, b) {
+		key.add(name+".sumw2", a, b)
+	}
+}
+
+func (cmd *diffStatCmd) diffH1(key *diffKey, ref, chk *hbook.H1D) {
+	if a, b := ref.Entries(), chk.Entries(); a != b {
+		key.add("entries", a, b)
+	}
+
+	var (
+		bref = ref.Binning.Bins
+		bchk = chk.Binning.Bins
+	)
+	if len(bref) != len(bchk) {
+		key.add("nbins", len(bref), len(bchk))
+		return
+	}
+	for i := range bref {
+		if a, b := bref[i].XMin(), bchk[i].XMin(); a != b {
+			key.add(fmt.Sprintf("bin[%d].xmin", i), a, b)
+			return
+		}
+		if a, b := bref[i].XMax(), bchk[i].XMax(); a != b {
+			key.add(fmt.Sprintf("bin[%d].xmax", i), a, b)
+			return
+		}
+	}
+
+	cmd.diffDist(key, "underflow", &ref.Binning.Outflows[0], &chk.Binning.Outflows[0])
+	for i := range bref {
+		cmd.diffDist(key, fmt.Sprintf("bin[%d]", i), &bref[i], &bchk[i])
+	}
+	cmd.diffDist(key, "overflow", &ref.Binning.Outflows[1], &chk.Binning.Outflows[1])
+}
+
+func (cmd *diffStatCmd) diffH2(key *diffKey, ref, chk *hbook.H2D) {
+	if a, b := ref.Entries(), chk.Entries(); a != b {
+		key.add("entries", a, b)
+	}
+
+	var (
+		bref = &ref.Binning
+		bchk = &chk.Binning
+	)
+	if bref.Nx != bchk.Nx || bref.Ny != bchk.Ny {
+		key.add("nbins",
+			fmt.Sprintf("%dx%d", bref.Nx, bref.Ny),
+			fmt.Sprintf("%dx%d", bchk.Nx, bchk.Ny),
+		)
+		return
+	}
+	for i := range bref.Bins {
+		var (
+			a = bref.Bins[i].XRange
+			b = bchk.Bins[i].XRange
+			c = bref.Bins[i].YRange
+			d = bchk.Bins[i].YRange
+		)
+		if a != b || c != d {
+			ix, iy := i%bref.Nx, i/bref.Nx
+			key.add(fmt.Sprintf("bin[%d,%d].range", ix, iy),
+				fmt.Sprintf("x=[%v, %v] y=[%v, %v]", a.Min, a.Max, c.Min, c.Max),
+				fmt.Sprintf("x=[%v, %v] y=[%v, %v]", b.Min, b.Max, d.Min, d.Max),
+			)
+			return
+		}
+	}
+
+	for i := range bref.Outflows {
+		cmd.diffDist(key, fmt.Sprintf("outflow[%d]", i), &bref.Outflows[i], &bchk.Outflows[i])
+	}
+	for i := range bref.Bins {
+		ix, iy := i%bref.Nx, i/bref.Nx
+		cmd.diffDist(key, fmt.Sprintf("bin[%d,%d]", ix, iy), &bref.Bins[i], &bchk.Bins[i])
+	}
+}
+
+func (cmd *diffStatCmd) diffTree(key *diffKey, ref, chk rtree.Tree) error {
+	if a, b := ref.Entries(), chk.Entries(); a != b {
+		key.add("entries", a, b)
+	}
+
+	sref, err := treeChecksums(ref)
+	if err != nil {
+		return fmt.Errorf("could not compute checksums of ref-tree: %w", err)
+	}
+	schk, err := treeChecksums(chk)
+	if err != nil {
+		return fmt.Errorf("could not compute checksums of chk-tree: %w", err)
+	}
+
+	names := make([]string, 0, len(sref)+len(schk))
+	for name := range sref {
+		names = append(names, name)
+	}
+	for name := range schk {
+		if _, dup := sref[name]; !dup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	const missing = "<missing>"
+	for _, name := range names {
+		a, okRef := sref[name]
+		b, okChk := schk[name]
+		switch {
+		case !okRef:
+			a = missing
+		case !okChk:
+			b = missing
+		}
+		if a != b {
+			key.add("branch["+name+"]", a, b)
+		}
+	}
+
+	return nil
+}
+
+// treeChecksums returns the checksums of the values of each of the leaves
+// of the provided tree, indexed by the name of the leaves.
+func treeChecksums(t rtree.Tree) (map[string]string, error) {
+	vars := rtree.NewReadVars(t)
+	r, err := rtree.NewReader(t, vars)
+	if err != nil {
+		return nil, fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	hs := make([]hash.Hash64, len(vars))
+	for i := range hs {
+		hs[i] = fnv.New64a()
+	}
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		for i, v := range vars {
+			fmt.Fprintf(hs[i], "%v\n", reflect.Indirect(reflect.ValueOf(v.Value)).Interface())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read tree: %w", err)
+	}
+
+	sums := make(map[string]string, len(vars))
+	for i, v := range vars {
+		sums[v.Name] = fmt.Sprintf("%016x", hs[i].Sum64())
+	}
+	return sums, nil
+}
@@ -0,0 +1,208 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/rootcnv"
+)
+
+func TestDiffStat(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rcmd-diffstat-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type content struct {
+		w     float64 // weight of the histogram's last fill
+		nevts int     // number of entries of the tree
+		extra bool    // whether to add an extra histogram
+	}
+
+	create := func(fname string, c content) *riofs.File {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create %q: %+v", fname, err)
+		}
+
+		dir, err := riofs.Dir(f).Mkdir("dir")
+		if err != nil {
+			t.Fatalf("could not create directory: %+v", err)
+		}
+
+		h := hbook.NewH1D(4, 0, 4)
+		h.Fill(0.5, 1)
+		h.Fill(1.5, 2)
+		h.Fill(2.5, c.w)
+		err = dir.Put("h1", rootcnv.FromH1D(h))
+		if err != nil {
+			t.Fatalf("could not save histogram: %+v", err)
+		}
+
+		if c.extra {
+			err = f.Put("extra", rootcnv.FromH1D(h))
+			if err != nil {
+				t.Fatalf("could not save histogram: %+v", err)
+			}
+		}
+
+		var x float64
+		tree, err := rtree.NewWriter(dir, "tree", []rtree.WriteVar{{Name: "x", Value: &x}})
+		if err != nil {
+			t.Fatalf("could not create tree: %+v", err)
+		}
+		for i := 0; i < c.nevts; i++ {
+			x = float64(i)
+			_, err = tree.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+		err = tree.Close()
+		if err != nil {
+			t.Fatalf("could not close tree: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close %q: %+v", fname, err)
+		}
+
+		f, err = groot.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open %q: %+v", fname, err)
+		}
+		return f
+	}
+
+	ref := content{w: 3, nevts: 5}
+	for _, tc := range []struct {
+		name string
+		chk  content
+		opts []rcmd.DiffOption
+		err  string
+		want string
+	}{
+		{
+			name: "same",
+			chk:  ref,
+			want: "files \"REF\" and \"CHK\" are identical\n",
+		},
+		{
+			name: "differ",
+			chk:  content{w: 4, nevts: 6, extra: true},
+			err:  "files differ",
+			want: `key[dir/h1] (TH1D) -- (-ref +chk)
+  bin[2].sumw: -3 +4
+  bin[2].sumw2: -9 +16
+key[dir/tree] (TTree) -- (-ref +chk)
+  entries: -5 +6
+  branch[x]: -aa3b20e112c0acf3 +be8ae72e339b47e8
+key[extra] (TH1D) -- missing from ref-file
+`,
+		},
+		{
+			name: "tolerance",
+			chk:  content{w: 3.01, nevts: 5},
+			opts: []rcmd.DiffOption{rcmd.DiffTolerance(0, 0.01)},
+			want: "files \"REF\" and \"CHK\" are identical\n",
+		},
+		{
+			name: "keys",
+			chk:  content{w: 4, nevts: 5, extra: true},
+			opts: []rcmd.DiffOption{rcmd.DiffKeys("dir/h1")},
+			err:  "files differ",
+			want: `key[dir/h1] (TH1D) -- (-ref +chk)
+  bin[2].sumw: -3 +4
+  bin[2].sumw2: -9 +16
+`,
+		},
+		{
+			name: "json",
+			chk:  content{w: 3, nevts: 4},
+			opts: []rcmd.DiffOption{rcmd.DiffJSON(true)},
+			err:  "files differ",
+			want: `{
+  "ref": "REF",
+  "chk": "CHK",
+  "same": false,
+  "keys": [
+    {
+      "name": "dir",
+      "class": "TDirectoryFile",
+      "status": "same"
+    },
+    {
+      "name": "dir/h1",
+      "class": "TH1D",
+      "status": "same"
+    },
+    {
+      "name": "dir/tree",
+      "class": "TTree",
+      "status": "differ",
+      "diffs": [
+        {
+          "name": "entries",
+          "ref": 5,
+          "chk": 4
+        },
+        {
+          "name": "branch[x]",
+          "ref": "aa3b20e112c0acf3",
+          "chk": "e661c918b9a665bd"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name: "missing-key",
+			chk:  ref,
+			opts: []rcmd.DiffOption{rcmd.DiffKeys("not-there")},
+			err:  `could not find key "not-there"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			refname := filepath.Join(tmp, tc.name+"-ref.root")
+			fref := create(refname, ref)
+			defer fref.Close()
+
+			chkname := filepath.Join(tmp, tc.name+"-chk.root")
+			fchk := create(chkname, tc.chk)
+			defer fchk.Close()
+
+			out := new(strings.Builder)
+			err := rcmd.DiffStat(out, fref, fchk, tc.opts...)
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error.\ngot= %s\nwant=%s\n", got, want)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %+v", err)
+			case tc.err != "":
+				t.Fatalf("expected an error: %s", tc.err)
+			}
+
+			got := strings.NewReplacer(refname, "REF", chkname, "CHK").Replace(out.String())
+			if got, want := got, tc.want; got != want {
+				t.Fatalf("invalid report.\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -101,6 +101,14 @@ func (cmd *dumpCmd) dumpObj(obj root.Object) error {
 				return err
 			}
 		}
+	case rhist.HStack:
+		for _, h := range obj.Hists() {
+			fmt.Fprintf(cmd.w, "\n")
+			err = cmd.dumpObj(h)
+			if err != nil {
+				return err
+			}
+		}
 	case root.List:
 		fmt.Fprintf(cmd.w, "\n")
 		err = cmd.dumpList(obj)
@@ -0,0 +1,407 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rarrow"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// ExportFormat describes the output format of Export.
+type ExportFormat string
+
+const (
+	ExportCSV   ExportFormat = "csv"   // comma-separated values, with a header line
+	ExportJSONL ExportFormat = "jsonl" // JSON lines, one JSON object per entry
+	ExportArrow ExportFormat = "arrow" // Arrow IPC stream
+)
+
+// exportChunk is the number of entries of each Arrow record written
+// to an Arrow IPC stream.
+const exportChunk = 4096
+
+// ExportOption controls how Export behaves.
+type ExportOption func(*exportCmd)
+
+// ExportBranches selects the branches to export.
+// By default, all the branches of the tree are exported, except the
+// ones that can not be represented in the output format.
+func ExportBranches(names ...string) ExportOption {
+	return func(cmd *exportCmd) {
+		cmd.branches = append(cmd.branches, names...)
+	}
+}
+
+// ExportFilter sets the selection expression entries must pass to be
+// exported, as described by rtree.NewFormula.
+func ExportFilter(expr string) ExportOption {
+	return func(cmd *exportCmd) {
+		cmd.filter = expr
+	}
+}
+
+// ExportRange sets the half-open interval [beg, end) of entries to export.
+// An end of -1 exports all the entries up to the end of the tree.
+func ExportRange(beg, end int64) ExportOption {
+	return func(cmd *exportCmd) {
+		cmd.beg = beg
+		cmd.end = end
+	}
+}
+
+// Export writes the content of the tree tname from the ROOT file fname
+// into the provided io.Writer, using the requested output format.
+//
+// Entries are streamed from the tree to the output, one at a time, so
+// trees larger than the available memory can be exported.
+//
+// CSV can only hold scalar values: when no branch is explicitly selected,
+// branches holding arrays, slices or structs are ignored.
+func Export(w io.Writer, fname, tname string, format ExportFormat, opts ...ExportOption) error {
+	cmd := exportCmd{
+		format: format,
+		end:    -1,
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	switch format {
+	case ExportCSV, ExportJSONL, ExportArrow:
+		// ok.
+	default:
+		return fmt.Errorf("invalid export format %q", format)
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return fmt.Errorf("could not get tree %q: %w", tname, err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return fmt.Errorf("object %q in file %q is not a tree (%T)", tname, fname, obj)
+	}
+
+	return cmd.export(w, tree)
+}
+
+type exportCmd struct {
+	format   ExportFormat
+	branches []string
+	filter   string
+	beg      int64
+	end      int64
+}
+
+func (cmd *exportCmd) export(w io.Writer, tree rtree.Tree) error {
+	rvars, err := cmd.rvars(tree)
+	if err != nil {
+		return err
+	}
+
+	r, err := rtree.NewReader(tree, rvars, rtree.WithRange(cmd.beg, cmd.end))
+	if err != nil {
+		return fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	var filter func() bool
+	if cmd.filter != "" {
+		form, err := rtree.NewFormula(tree, cmd.filter)
+		if err != nil {
+			return fmt.Errorf("could not create filter %q: %w", cmd.filter, err)
+		}
+		form, err = r.Formula(form)
+		if err != nil {
+			return fmt.Errorf("could not bind filter %q: %w", cmd.filter, err)
+		}
+		fct, ok := form.Func().(func() bool)
+		if !ok {
+			return fmt.Errorf("filter %q is not a boolean expression", cmd.filter)
+		}
+		filter = fct
+	}
+
+	var enc exportEncoder
+	switch cmd.format {
+	case ExportCSV:
+		enc, err = newExportCSV(w, rvars)
+	case ExportJSONL:
+		enc, err = newExportJSONL(w, rvars)
+	case ExportArrow:
+		enc, err = newExportArrow(w, tree, rvars)
+	}
+	if err != nil {
+		return fmt.Errorf("could not create %s encoder: %w", cmd.format, err)
+	}
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		if filter != nil && !filter() {
+			return nil
+		}
+		err := enc.write()
+		if err != nil {
+			return fmt.Errorf("could not write entry %d: %w", ctx.Entry, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not export tree %q: %w", tree.Name(), err)
+	}
+
+	err = enc.close()
+	if err != nil {
+		return fmt.Errorf("could not close %s encoder: %w", cmd.format, err)
+	}
+
+	return nil
+}
+
+// rvars returns the read-vars of the branches to export.
+func (cmd *exportCmd) rvars(tree rtree.Tree) ([]rtree.ReadVar, error) {
+	all := rtree.NewReadVars(tree)
+	if len(cmd.branches) == 0 {
+		if cmd.format != ExportCSV {
+			return all, nil
+		}
+		rvars := all[:0]
+		for _, rvar := range all {
+			if isScalar(rvar) {
+				rvars = append(rvars, rvar)
+			}
+		}
+		return rvars, nil
+	}
+
+	var rvars []rtree.ReadVar
+	for _, name := range cmd.branches {
+		n := len(rvars)
+		for _, rvar := range all {
+			if rvar.Name != name {
+				continue
+			}
+			if cmd.format == ExportCSV && !isScalar(rvar) {
+				return nil, fmt.Errorf(
+					"branch %q (%T) can not be exported to CSV",
+					name, rvar.Deref(),
+				)
+			}
+			rvars = append(rvars, rvar)
+		}
+		if len(rvars) == n {
+			return nil, fmt.Errorf("could not find branch %q in tree %q", name, tree.Name())
+		}
+	}
+
+	return rvars, nil
+}
+
+// isScalar returns whether the read-var holds a scalar or a string value.
+func isScalar(rvar rtree.ReadVar) bool {
+	switch reflect.TypeOf(rvar.Value).Elem().Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	default:
+		return false
+	}
+}
+
+// colName returns the name of the column associated with a read-var.
+func colName(rvar rtree.ReadVar) string {
+	if rvar.Leaf == "" || rvar.Leaf == rvar.Name {
+		return rvar.Name
+	}
+	return rvar.Name + "." + rvar.Leaf
+}
+
+type exportEncoder interface {
+	write() error // write writes the current entry.
+	close() error // close flushes the encoder.
+}
+
+type exportCSV struct {
+	w     *csv.Writer
+	rvars []rtree.ReadVar
+	row   []string
+}
+
+func newExportCSV(w io.Writer, rvars []rtree.ReadVar) (*exportCSV, error) {
+	enc := &exportCSV{
+		w:     csv.NewWriter(w),
+		rvars: rvars,
+		row:   make([]string, len(rvars)),
+	}
+
+	for i, rvar := range rvars {
+		enc.row[i] = colName(rvar)
+	}
+
+	err := enc.w.Write(enc.row)
+	if err != nil {
+		return nil, fmt.Errorf("could not write CSV header: %w", err)
+	}
+
+	return enc, nil
+}
+
+func (enc *exportCSV) write() error {
+	for i, rvar := range enc.rvars {
+		rv := reflect.ValueOf(rvar.Value).Elem()
+		switch rv.Kind() {
+		case reflect.Bool:
+			enc.row[i] = strconv.FormatBool(rv.Bool())
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			enc.row[i] = strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			enc.row[i] = strconv.FormatUint(rv.Uint(), 10)
+		case reflect.Float32:
+			enc.row[i] = strconv.FormatFloat(rv.Float(), 'g', -1, 32)
+		case reflect.Float64:
+			enc.row[i] = strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+		case reflect.String:
+			enc.row[i] = rv.String()
+		default:
+			return fmt.Errorf("invalid CSV value type %T", rvar.Deref())
+		}
+	}
+	return enc.w.Write(enc.row)
+}
+
+func (enc *exportCSV) close() error {
+	enc.w.Flush()
+	return enc.w.Error()
+}
+
+type exportJSONL struct {
+	w     *bufio.Writer
+	rvars []rtree.ReadVar
+	keys  [][]byte
+	buf   bytes.Buffer
+}
+
+func newExportJSONL(w io.Writer, rvars []rtree.ReadVar) (*exportJSONL, error) {
+	enc := &exportJSONL{
+		w:     bufio.NewWriter(w),
+		rvars: rvars,
+		keys:  make([][]byte, len(rvars)),
+	}
+
+	for i, rvar := range rvars {
+		key, err := json.Marshal(colName(rvar))
+		if err != nil {
+			return nil, fmt.Errorf("could not encode column name: %w", err)
+		}
+		enc.keys[i] = key
+	}
+
+	return enc, nil
+}
+
+func (enc *exportJSONL) write() error {
+	enc.buf.Reset()
+	enc.buf.WriteByte('{')
+	for i, rvar := range enc.rvars {
+		if i > 0 {
+			enc.buf.WriteByte(',')
+		}
+		v, err := json.Marshal(rvar.Deref())
+		if err != nil {
+			return fmt.Errorf("could not encode %s: %w", enc.keys[i], err)
+		}
+		enc.buf.Write(enc.keys[i])
+		enc.buf.WriteByte(':')
+		enc.buf.Write(v)
+	}
+	enc.buf.WriteString("}\n")
+
+	_, err := enc.w.Write(enc.buf.Bytes())
+	return err
+}
+
+func (enc *exportJSONL) close() error {
+	return enc.w.Flush()
+}
+
+type exportArrow struct {
+	bldr *rarrow.RecordBuilder
+	w    *ipc.Writer
+}
+
+func newExportArrow(w io.Writer, tree rtree.Tree, rvars []rtree.ReadVar) (*exportArrow, error) {
+	mem := memory.NewGoAllocator()
+	bldr, err := rarrow.NewRecordBuilder(
+		tree, rvars,
+		rarrow.WithAllocator(mem),
+		rarrow.WithChunk(exportChunk),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Arrow record builder: %w", err)
+	}
+
+	return &exportArrow{
+		bldr: bldr,
+		w: ipc.NewWriter(
+			w,
+			ipc.WithSchema(bldr.Schema()),
+			ipc.WithAllocator(mem),
+		),
+	}, nil
+}
+
+func (enc *exportArrow) write() error {
+	enc.bldr.Append()
+	if enc.bldr.Len() < exportChunk {
+		return nil
+	}
+	return enc.flush()
+}
+
+func (enc *exportArrow) flush() error {
+	rec := enc.bldr.NewRecord()
+	defer rec.Release()
+
+	err := enc.w.Write(rec)
+	if err != nil {
+		return fmt.Errorf("could not write Arrow record: %w", err)
+	}
+	return nil
+}
+
+func (enc *exportArrow) close() error {
+	defer enc.bldr.Release()
+
+	if enc.bldr.Len() > 0 {
+		err := enc.flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.w.Close()
+}
@@ -0,0 +1,204 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestExport(t *testing.T) {
+	const fname = "../testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name   string
+		tree   string
+		format rcmd.ExportFormat
+		opts   []rcmd.ExportOption
+		want   string
+		err    error
+	}{
+		{
+			name:   "csv",
+			tree:   "tree",
+			format: rcmd.ExportCSV,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportRange(0, 3),
+			},
+			want: `Int32,Int64,UInt32,UInt64,Float32,Float64,Str,N
+0,0,0,0,0,0,evt-000,0
+1,1,1,1,1,1,evt-001,1
+2,2,2,2,2,2,evt-002,2
+`,
+		},
+		{
+			name:   "csv-branches-filter",
+			tree:   "tree",
+			format: rcmd.ExportCSV,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportBranches("Str", "Float64"),
+				rcmd.ExportFilter("Int32 % 25 == 0"),
+			},
+			want: `Str,Float64
+evt-000,0
+evt-025,25
+evt-050,50
+evt-075,75
+`,
+		},
+		{
+			name:   "jsonl",
+			tree:   "tree",
+			format: rcmd.ExportJSONL,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportBranches("Int64", "Str", "SliceInt32"),
+				rcmd.ExportRange(1, 4),
+			},
+			want: `{"Int64":1,"Str":"evt-001","SliceInt32":[1]}
+{"Int64":2,"Str":"evt-002","SliceInt32":[2,2]}
+{"Int64":3,"Str":"evt-003","SliceInt32":[3,3,3]}
+`,
+		},
+		{
+			name:   "jsonl-filter-range",
+			tree:   "tree",
+			format: rcmd.ExportJSONL,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportBranches("N"),
+				rcmd.ExportRange(10, 20),
+				rcmd.ExportFilter("N >= 8"),
+			},
+			want: `{"N":8}
+{"N":9}
+`,
+		},
+		{
+			name:   "csv-slice",
+			tree:   "tree",
+			format: rcmd.ExportCSV,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportBranches("SliceInt32"),
+			},
+			err: fmt.Errorf(`branch "SliceInt32" ([]int32) can not be exported to CSV`),
+		},
+		{
+			name:   "missing-branch",
+			tree:   "tree",
+			format: rcmd.ExportJSONL,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportBranches("not-there"),
+			},
+			err: fmt.Errorf(`could not find branch "not-there" in tree "tree"`),
+		},
+		{
+			name:   "invalid-filter",
+			tree:   "tree",
+			format: rcmd.ExportCSV,
+			opts: []rcmd.ExportOption{
+				rcmd.ExportFilter("Int32 +"),
+			},
+			err: fmt.Errorf(`could not create filter "Int32 +"`),
+		},
+		{
+			name:   "missing-tree",
+			tree:   "dir",
+			format: rcmd.ExportCSV,
+			err:    fmt.Errorf(`could not get tree "dir"`),
+		},
+		{
+			name:   "invalid-format",
+			tree:   "tree",
+			format: "xml",
+			err:    fmt.Errorf(`invalid export format "xml"`),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := rcmd.Export(out, fname, tc.tree, tc.format, tc.opts...)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); !strings.HasPrefix(got, want) {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil && tc.err == nil:
+				t.Fatalf("could not export tree: %+v", err)
+			case err == nil && tc.err != nil:
+				t.Fatalf("expected an error (%v)", tc.err)
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid export:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestExportArrow(t *testing.T) {
+	const fname = "../testdata/small-flat-tree.root"
+
+	out := new(bytes.Buffer)
+	err := rcmd.Export(
+		out, fname, "tree", rcmd.ExportArrow,
+		rcmd.ExportBranches("Int32", "Str", "ArrayFloat64", "SliceInt64"),
+		rcmd.ExportFilter("Int32 >= 40"),
+	)
+	if err != nil {
+		t.Fatalf("could not export tree: %+v", err)
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	r, err := ipc.NewReader(out, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatalf("could not create Arrow IPC reader: %+v", err)
+	}
+	defer r.Release()
+
+	var names []string
+	for _, field := range r.Schema().Fields() {
+		names = append(names, field.Name)
+	}
+	if got, want := strings.Join(names, ","), "Int32,Str,ArrayFloat64,SliceInt64"; got != want {
+		t.Fatalf("invalid schema: got=%q, want=%q", got, want)
+	}
+
+	var n int64
+	for r.Next() {
+		rec := r.Record()
+		i32 := rec.Column(0).(*array.Int32)
+		str := rec.Column(1).(*array.String)
+		sli := rec.Column(3).(*array.List)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			var (
+				evt = 40 + n
+				beg = sli.Offsets()[i]
+				end = sli.Offsets()[i+1]
+			)
+			if got, want := int64(i32.Value(i)), evt; got != want {
+				t.Fatalf("invalid Int32 value: got=%d, want=%d", got, want)
+			}
+			if got, want := str.Value(i), fmt.Sprintf("evt-%03d", evt); got != want {
+				t.Fatalf("invalid Str value: got=%q, want=%q", got, want)
+			}
+			if got, want := int64(end-beg), evt%10; got != want {
+				t.Fatalf("invalid SliceInt64 length: got=%d, want=%d", got, want)
+			}
+			n++
+		}
+	}
+
+	if got, want := n, int64(60); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+}
@@ -6,6 +6,7 @@ package rcmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"text/tabwriter"
@@ -23,6 +24,8 @@ type lsCmd struct {
 
 	streamers bool
 	trees     bool
+	sizes     bool
+	json      bool
 }
 
 // ListStreamers enables the display of streamer informations
@@ -41,6 +44,32 @@ func ListTrees(v bool) ListOption {
 	}
 }
 
+// ListSizes enables the display of the sizes of the keys, trees and
+// branches contained in the provided ROOT file.
+//
+// Keys are displayed with the size of their object before compression
+// (obj-len) and with their size on disk, including the key header
+// (nbytes.)
+// Trees and branches are displayed with the sizes of their baskets,
+// before (tot-bytes) and after (zip-bytes) compression.
+func ListSizes(v bool) ListOption {
+	return func(cmd *lsCmd) {
+		cmd.sizes = v
+	}
+}
+
+// ListJSON enables the JSON output of the summary content of the provided
+// ROOT file.
+// The JSON output describes the class, cycle and sizes of each key,
+// recursively.
+// Trees are described with their entries, sizes and branches, regardless
+// of the ListTrees option.
+func ListJSON(v bool) ListOption {
+	return func(cmd *lsCmd) {
+		cmd.json = v
+	}
+}
+
 // List displays the summary content of the named ROOT file into the
 // provided io Writer.
 //
@@ -56,6 +85,10 @@ func List(w io.Writer, fname string, opts ...ListOption) error {
 		opt(&cmd)
 	}
 
+	if cmd.json {
+		return cmd.lsJSON(fname)
+	}
+
 	return cmd.ls(fname)
 }
 
@@ -98,13 +131,22 @@ func (ls lsCmd) walk(w io.Writer, k riofs.Key) {
 		tree, ok := obj.(rtree.Tree)
 		if ok {
 			w := newWindent(2, w)
-			fmt.Fprintf(w, "%s\t%s\t%s\t(entries=%d)\n", k.ClassName(), k.Name(), k.Title(), tree.Entries())
-			displayBranches(w, tree, 2)
+			if ls.sizes {
+				tot, zip := sizesOf(tree)
+				fmt.Fprintf(w, "%s\t%s\t%s\t(entries=%d, tot-bytes=%d, zip-bytes=%d)\n", k.ClassName(), k.Name(), k.Title(), tree.Entries(), tot, zip)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\t%s\t(entries=%d)\n", k.ClassName(), k.Name(), k.Title(), tree.Entries())
+			}
+			displayBranches(w, tree, 2, ls.sizes)
 			w.Flush()
 			return
 		}
 	}
-	fmt.Fprintf(w, "%s\t%s\t%s\t(cycle=%d)\n", k.ClassName(), k.Name(), k.Title(), k.Cycle())
+	if ls.sizes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t(cycle=%d, obj-len=%d, nbytes=%d)\n", k.ClassName(), k.Name(), k.Title(), k.Cycle(), k.ObjLen(), k.Nbytes())
+	} else {
+		fmt.Fprintf(w, "%s\t%s\t%s\t(cycle=%d)\n", k.ClassName(), k.Name(), k.Title(), k.Cycle())
+	}
 	if isDirlike(k.ClassName()) {
 		obj := k.Value()
 		if dir, ok := obj.(riofs.Directory); ok {
@@ -161,7 +203,23 @@ type brancher interface {
 	Branches() []rtree.Branch
 }
 
-func displayBranches(w io.Writer, bres brancher, indent int) {
+// sizer is the interface implemented by trees and branches reporting the
+// sizes of their baskets.
+type sizer interface {
+	TotBytes() int64
+	ZipBytes() int64
+}
+
+// sizesOf returns the sizes of the baskets of a tree or a branch, before
+// and after compression.
+func sizesOf(v interface{}) (tot, zip int64) {
+	if v, ok := v.(sizer); ok {
+		return v.TotBytes(), v.ZipBytes()
+	}
+	return 0, 0
+}
+
+func displayBranches(w io.Writer, bres brancher, indent int, sizes bool) {
 	branches := bres.Branches()
 	if len(branches) <= 0 {
 		return
@@ -173,10 +231,164 @@ func displayBranches(w io.Writer, bres brancher, indent int) {
 			title = clip(b.Title(), 50)
 			class = clip(b.Class(), 20)
 		)
-		fmt.Fprintf(ww, "%s\t%q\t%v\n", name, title, class)
-		displayBranches(ww, b, 2)
+		if sizes {
+			tot, zip := sizesOf(b)
+			fmt.Fprintf(ww, "%s\t%q\t%v\t(tot-bytes=%d, zip-bytes=%d)\n", name, title, class, tot, zip)
+		} else {
+			fmt.Fprintf(ww, "%s\t%q\t%v\n", name, title, class)
+		}
+		displayBranches(ww, b, 2, sizes)
+	}
+}
+
+type lsFile struct {
+	File      string       `json:"file"`
+	Version   int          `json:"version"`
+	Streamers []lsStreamer `json:"streamer-infos,omitempty"`
+	Keys      []lsKey      `json:"keys"`
+}
+
+type lsStreamer struct {
+	Name    string `json:"name"`
+	Title   string `json:"title"`
+	Version int    `json:"version"`
+}
+
+type lsKey struct {
+	Name   string  `json:"name"`
+	Title  string  `json:"title"`
+	Class  string  `json:"class"`
+	Cycle  int     `json:"cycle"`
+	ObjLen int32   `json:"obj-len"`
+	Nbytes int32   `json:"nbytes"`
+	Tree   *lsTree `json:"tree,omitempty"`
+	Keys   []lsKey `json:"keys,omitempty"`
+}
+
+type lsTree struct {
+	Entries  int64      `json:"entries"`
+	TotBytes int64      `json:"tot-bytes"`
+	ZipBytes int64      `json:"zip-bytes"`
+	Branches []lsBranch `json:"branches"`
+}
+
+type lsBranch struct {
+	Name     string     `json:"name"`
+	Title    string     `json:"title"`
+	Class    string     `json:"class"`
+	TotBytes int64      `json:"tot-bytes"`
+	ZipBytes int64      `json:"zip-bytes"`
+	Branches []lsBranch `json:"branches,omitempty"`
+}
+
+func (ls lsCmd) lsJSON(fname string) error {
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	out := lsFile{
+		File:    fname,
+		Version: f.Version(),
+		Keys:    []lsKey{},
+	}
+
+	if ls.streamers {
+		for _, v := range f.StreamerInfos() {
+			out.Streamers = append(out.Streamers, lsStreamer{
+				Name:    v.Name(),
+				Title:   v.Title(),
+				Version: v.ClassVersion(),
+			})
+		}
+	}
+
+	for _, k := range f.Keys() {
+		key, err := ls.jsonKey(k)
+		if err != nil {
+			return err
+		}
+		out.Keys = append(out.Keys, key)
+	}
+
+	raw, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode JSON summary: %w", err)
+	}
+
+	_, err = ls.w.Write(append(raw, '\n'))
+	if err != nil {
+		return fmt.Errorf("could not write JSON summary: %w", err)
+	}
+
+	return nil
+}
+
+func (ls lsCmd) jsonKey(k riofs.Key) (lsKey, error) {
+	key := lsKey{
+		Name:   k.Name(),
+		Title:  k.Title(),
+		Class:  k.ClassName(),
+		Cycle:  k.Cycle(),
+		ObjLen: k.ObjLen(),
+		Nbytes: k.Nbytes(),
+	}
+
+	switch {
+	case isTreelike(k.ClassName()):
+		obj, err := k.Object()
+		if err != nil {
+			return key, fmt.Errorf("could not load tree %q: %w", k.Name(), err)
+		}
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return key, nil
+		}
+		tot, zip := sizesOf(tree)
+		key.Tree = &lsTree{
+			Entries:  tree.Entries(),
+			TotBytes: tot,
+			ZipBytes: zip,
+			Branches: jsonBranches(tree),
+		}
+
+	case isDirlike(k.ClassName()):
+		obj, err := k.Object()
+		if err != nil {
+			return key, fmt.Errorf("could not load directory %q: %w", k.Name(), err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return key, nil
+		}
+		key.Keys = []lsKey{}
+		for _, k := range dir.Keys() {
+			sub, err := ls.jsonKey(k)
+			if err != nil {
+				return key, err
+			}
+			key.Keys = append(key.Keys, sub)
+		}
+	}
+
+	return key, nil
+}
+
+func jsonBranches(bres brancher) []lsBranch {
+	var branches []lsBranch
+	for _, b := range bres.Branches() {
+		tot, zip := sizesOf(b)
+		branches = append(branches, lsBranch{
+			Name:     b.Name(),
+			Title:    b.Title(),
+			Class:    b.Class(),
+			TotBytes: tot,
+			ZipBytes: zip,
+			Branches: jsonBranches(b),
+		})
 	}
-	ww.Flush()
+	return branches
 }
 
 func clip(s string, n int) string {
@@ -78,6 +78,90 @@ version: 60600
 			opts: opts,
 			want: loadRef("./testdata/small-evnt-tree-nosplit.root-ls.txt"),
 		},
+		{
+			name: "../testdata/simple.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListTrees(true),
+				rcmd.ListSizes(true),
+			},
+			want: `=== [../testdata/simple.root] ===
+version: 60600
+  TTree   tree      fake data (entries=4, tot-bytes=288, zip-bytes=288)
+    one   "one/I"   TBranch   (tot-bytes=86, zip-bytes=86)
+    two   "two/F"   TBranch   (tot-bytes=86, zip-bytes=86)
+    three "three/C" TBranch   (tot-bytes=116, zip-bytes=116)
+`,
+		},
+		{
+			name: "../testdata/dirs-6.14.00.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListSizes(true),
+			},
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+version: 61400
+TDirectoryFile   dir1    dir1    (cycle=1, obj-len=60, nbytes=107)
+  TDirectoryFile dir11   dir11   (cycle=1, obj-len=60, nbytes=109)
+    TH1F         h1      h1      (cycle=1, obj-len=936, nbytes=345)
+TDirectoryFile dir2    dir2    (cycle=1, obj-len=60, nbytes=107)
+TDirectoryFile dir3    dir3    (cycle=1, obj-len=60, nbytes=107)
+`,
+		},
+		{
+			name: "../testdata/dirs-6.14.00.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListJSON(true),
+			},
+			want: loadRef("./testdata/dirs-6.14.00.root-ls.json"),
+		},
+		{
+			name: "../testdata/simple.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListJSON(true),
+			},
+			want: `{
+  "file": "../testdata/simple.root",
+  "version": 60600,
+  "keys": [
+    {
+      "name": "tree",
+      "title": "fake data",
+      "class": "TTree",
+      "cycle": 1,
+      "obj-len": 1743,
+      "nbytes": 515,
+      "tree": {
+        "entries": 4,
+        "tot-bytes": 288,
+        "zip-bytes": 288,
+        "branches": [
+          {
+            "name": "one",
+            "title": "one/I",
+            "class": "TBranch",
+            "tot-bytes": 86,
+            "zip-bytes": 86
+          },
+          {
+            "name": "two",
+            "title": "two/F",
+            "class": "TBranch",
+            "tot-bytes": 86,
+            "zip-bytes": 86
+          },
+          {
+            "name": "three",
+            "title": "three/C",
+            "class": "TBranch",
+            "tot-bytes": 116,
+            "zip-bytes": 116
+          }
+        ]
+      }
+    }
+  ]
+}
+`,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			out := new(strings.Builder)
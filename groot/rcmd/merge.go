@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	stdpath "path"
+	"sync"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
@@ -16,29 +17,88 @@ import (
 	"go-hep.org/x/hep/groot/rtree"
 )
 
+// MergeOption controls how Merge behaves.
+type MergeOption func(*mergeCmd)
+
+// MergeCompression sets the compression algorithm and level of the
+// merged output ROOT file, and of the trees it contains.
+// By default, the output file uses the default groot compression settings.
+func MergeCompression(alg riofs.CompressionAlgorithm, lvl int) MergeOption {
+	return func(cmd *mergeCmd) {
+		cmd.fopts = append(cmd.fopts, riofs.WithCompression(alg, lvl))
+	}
+}
+
+// MergeWorkers sets the number of input ROOT files that are opened and
+// read concurrently.
+// Objects are still merged in the order of the input files, so the
+// merged output does not depend on the number of workers.
+// By default, input files are read one at a time.
+func MergeWorkers(n int) MergeOption {
+	return func(cmd *mergeCmd) {
+		if n < 1 {
+			n = 1
+		}
+		cmd.workers = n
+	}
+}
+
+// MergeSkipErrors configures whether input files that can not be read and
+// objects that can not be merged are skipped, instead of aborting the
+// merge.
+// Skipped files and objects are reported on the standard logger.
+func MergeSkipErrors(v bool) MergeOption {
+	return func(cmd *mergeCmd) {
+		cmd.skip = v
+	}
+}
+
 // Merge merges all input fnames ROOT files into the output oname one.
-func Merge(oname string, fnames []string, verbose bool) error {
-	o, err := groot.Create(oname)
+//
+// Directories are merged recursively: objects are matched by their path
+// in the input files and objects appearing in only some of the input
+// files are merged from the files they appear in.
+// Trees, histograms and all the values implementing root.Merger are
+// merged; other objects are ignored.
+//
+// Merge's behaviour can be customized with a set of optional MergeOptions.
+func Merge(oname string, fnames []string, verbose bool, opts ...MergeOption) error {
+	if len(fnames) == 0 {
+		return fmt.Errorf("no input ROOT file to merge")
+	}
+
+	cmd := mergeCmd{
+		verbose: verbose,
+		workers: 1,
+		dirs:    make(map[string]struct{}),
+		tasks:   make(map[string]*task),
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	o, err := groot.Create(oname, cmd.fopts...)
 	if err != nil {
 		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
 	}
 	defer o.Close()
+	cmd.o = o
 
-	cmd := mergeCmd{verbose: verbose}
-	tsks, err := cmd.mergeTasksFrom(o, fnames[0])
-	if err != nil {
-		return fmt.Errorf("could not create merge tasks: %w", err)
-	}
+	r := cmd.newReader(fnames)
+	defer r.close()
 
-	for _, fname := range fnames[1:] {
-		err := cmd.process(tsks, fname)
+	for i := range fnames {
+		err := cmd.process(r.next(i))
+		r.release()
 		if err != nil {
-			return fmt.Errorf("could not process ROOT file %q: %w", fname, err)
+			if !cmd.skip {
+				return fmt.Errorf("could not process ROOT file %q: %w", fnames[i], err)
+			}
+			log.Printf("skipping ROOT file %q: %+v", fnames[i], err)
 		}
 	}
 
-	for i := range tsks {
-		tsk := &tsks[i]
+	for i, tsk := range cmd.tsks {
 		err := tsk.close(o)
 		if err != nil {
 			return fmt.Errorf("could not close task %d (%s): %w", i, tsk.path(), err)
@@ -55,10 +115,34 @@ func Merge(oname string, fnames []string, verbose bool) error {
 
 type mergeCmd struct {
 	verbose bool
+	skip    bool
+	workers int
+	fopts   []riofs.FileOption
+
+	o     *riofs.File
+	dirs  map[string]struct{} // directories created in the output file
+	tsks  []*task             // merge tasks, in creation order
+	tasks map[string]*task    // merge tasks, indexed by path
+}
+
+// mergeInput is an input ROOT file, together with the objects to merge
+// from it.
+type mergeInput struct {
+	fname string
+	f     *riofs.File
+	objs  []mergeObj
+	err   error
+}
+
+type mergeObj struct {
+	name string
+	obj  root.Object
 }
 
-func (mergeCmd) acceptObj(obj root.Object) bool {
+func (*mergeCmd) acceptObj(obj root.Object) bool {
 	switch obj.(type) {
+	case riofs.Directory:
+		return true
 	case rtree.Tree:
 		// need to specially handle rtree.Tree.
 		// rtree.Tree does not implement root.Merger: only rtree.Writer does.
@@ -72,144 +156,222 @@ func (mergeCmd) acceptObj(obj root.Object) bool {
 	}
 }
 
-func (cmd mergeCmd) process(tsks []task, fname string) error {
-	if cmd.verbose {
-		log.Printf("merging [%s]...", fname)
-	}
+// mergeReader opens and inspects the input ROOT files, using up to
+// a fixed number of goroutines.
+type mergeReader struct {
+	wg     sync.WaitGroup
+	quit   chan struct{}
+	sem    chan struct{}
+	inputs []chan mergeInput
+}
 
-	f, err := groot.Open(fname)
-	if err != nil {
-		return fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+func (cmd *mergeCmd) newReader(fnames []string) *mergeReader {
+	r := &mergeReader{
+		quit:   make(chan struct{}),
+		sem:    make(chan struct{}, cmd.workers),
+		inputs: make([]chan mergeInput, len(fnames)),
+	}
+	for i := range r.inputs {
+		r.inputs[i] = make(chan mergeInput, 1)
 	}
-	defer f.Close()
 
-	for i := range tsks {
-		tsk := &tsks[i]
-		err = tsk.merge(f)
-		if err != nil {
-			return fmt.Errorf("could not merge task %d (%s) for file %q: %w", i, tsk.path(), fname, err)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for i, fname := range fnames {
+			select {
+			case r.sem <- struct{}{}:
+			case <-r.quit:
+				return
+			}
+			r.wg.Add(1)
+			go func(i int, fname string) {
+				defer r.wg.Done()
+				r.inputs[i] <- cmd.open(fname)
+			}(i, fname)
 		}
-	}
+	}()
 
-	return nil
+	return r
 }
 
-type task struct {
-	dir string
-	key string
-	obj root.Object
+// next returns the content of the i-th input file.
+func (r *mergeReader) next(i int) mergeInput {
+	return <-r.inputs[i]
+}
 
-	verbose bool
+// release releases the worker slot of a processed input file.
+func (r *mergeReader) release() {
+	<-r.sem
+}
+
+// close stops the reader and closes the input files that were read but
+// not processed.
+func (r *mergeReader) close() {
+	close(r.quit)
+	r.wg.Wait()
+	for _, in := range r.inputs {
+		select {
+		case v := <-in:
+			if v.f != nil {
+				v.f.Close()
+			}
+		default:
+		}
+	}
 }
 
-func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error) {
+// open opens the named ROOT file and collects the objects to merge.
+func (cmd *mergeCmd) open(fname string) mergeInput {
+	in := mergeInput{fname: fname}
+
 	f, err := groot.Open(fname)
 	if err != nil {
-		return nil, fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+		in.err = fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+		return in
 	}
-	defer f.Close()
 
-	var tsks []task
 	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		name := path[len(f.Name()):]
 		if err != nil {
+			if cmd.skip {
+				log.Printf("skipping %q from ROOT file %q: %+v", name, fname, err)
+				return nil
+			}
 			return err
 		}
-		name := path[len(f.Name()):]
 		if name == "" {
 			return nil
 		}
-
-		if _, ok := obj.(riofs.Directory); ok {
-			_, err := riofs.Dir(o).Mkdir(name)
-			if err != nil {
-				return fmt.Errorf("could not create dir %q in output ROOT file: %w", name, err)
-			}
-			if cmd.verbose {
-				log.Printf("selecting %q", name)
-			}
-			return nil
-		}
-
 		if !cmd.acceptObj(obj) {
 			return nil
 		}
-		if cmd.verbose {
-			log.Printf("selecting %q", name)
-		}
-
-		var (
-			dirName = stdpath.Dir(name)
-			objName = stdpath.Base(name)
-			dir     = riofs.Directory(o)
-		)
+		in.objs = append(in.objs, mergeObj{name: name, obj: obj})
+		return nil
+	})
+	if err != nil {
+		f.Close()
+		in.err = fmt.Errorf("could not inspect input ROOT file %q: %w", fname, err)
+		return in
+	}
 
-		if dirName != "/" && dirName != "" {
-			obj, err := riofs.Dir(o).Get(dirName)
-			if err != nil {
-				return fmt.Errorf("could not get dir %q from output ROOT file: %w", dirName, err)
-			}
-			dir = obj.(riofs.Directory)
-		}
+	in.f = f
+	return in
+}
 
-		switch oo := obj.(type) {
-		case rtree.Tree:
-			w, err := rtree.NewWriter(dir, objName, rtree.WriteVarsFromTree(oo), rtree.WithTitle(oo.Title()))
-			if err != nil {
-				return fmt.Errorf("could not create output ROOT tree %q: %w", name, err)
-			}
+func (cmd *mergeCmd) process(in mergeInput) error {
+	if in.err != nil {
+		return in.err
+	}
+	defer in.f.Close()
 
-			r, err := rtree.NewReader(oo, nil)
-			if err != nil {
-				return fmt.Errorf(
-					"could not create input ROOT tree reader %q: %w",
-					name, err,
-				)
-			}
-			defer r.Close()
+	if cmd.verbose {
+		log.Printf("merging [%s]...", in.fname)
+	}
 
-			_, err = rtree.Copy(w, r)
-			if err != nil {
-				return fmt.Errorf("could not seed output ROOT tree %q: %w", name, err)
+	for _, v := range in.objs {
+		err := cmd.mergeObj(v.name, v.obj)
+		if err != nil {
+			if !cmd.skip {
+				return fmt.Errorf("could not merge %q: %w", v.name, err)
 			}
-			obj = w
+			log.Printf("skipping %q from ROOT file %q: %+v", v.name, in.fname, err)
 		}
+	}
+
+	return nil
+}
 
-		tsks = append(tsks, task{
-			dir:     dirName,
-			key:     objName,
-			obj:     obj,
-			verbose: cmd.verbose,
-		})
+func (cmd *mergeCmd) mergeObj(name string, obj root.Object) error {
+	if _, ok := obj.(riofs.Directory); ok {
+		if _, dup := cmd.dirs[name]; dup {
+			return nil
+		}
+		_, err := riofs.Dir(cmd.o).Mkdir(name)
+		if err != nil {
+			return fmt.Errorf("could not create dir %q in output ROOT file: %w", name, err)
+		}
+		cmd.dirs[name] = struct{}{}
+		if cmd.verbose {
+			log.Printf("selecting %q", name)
+		}
 		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("could not inspect input ROOT file: %w", err)
 	}
 
-	if cmd.verbose {
-		log.Printf("merging [%s]...", fname)
+	if tsk, ok := cmd.tasks[name]; ok {
+		return tsk.mergeObj(tsk.obj, obj)
 	}
 
-	return tsks, nil
+	tsk, err := cmd.newTask(name, obj)
+	if err != nil {
+		return err
+	}
+	cmd.tsks = append(cmd.tsks, tsk)
+	cmd.tasks[name] = tsk
+	return nil
 }
 
-func (tsk *task) path() string {
-	return stdpath.Join(tsk.dir, tsk.key)
+type task struct {
+	dir string
+	key string
+	obj root.Object
+
+	verbose bool
 }
 
-func (tsk *task) merge(f *riofs.File) error {
-	name := tsk.path()
-	obj, err := riofs.Dir(f).Get(name)
-	if err != nil {
-		return fmt.Errorf("could not get %q: %w", name, err)
+// newTask creates a new merge task for the named object, seeded with obj.
+func (cmd *mergeCmd) newTask(name string, obj root.Object) (*task, error) {
+	if cmd.verbose {
+		log.Printf("selecting %q", name)
 	}
 
-	err = tsk.mergeObj(tsk.obj, obj)
-	if err != nil {
-		return fmt.Errorf("could not merge %q: %w", name, err)
+	var (
+		dirName = stdpath.Dir(name)
+		objName = stdpath.Base(name)
+		dir     = riofs.Directory(cmd.o)
+	)
+
+	if dirName != "/" && dirName != "" {
+		obj, err := riofs.Dir(cmd.o).Get(dirName)
+		if err != nil {
+			return nil, fmt.Errorf("could not get dir %q from output ROOT file: %w", dirName, err)
+		}
+		dir = obj.(riofs.Directory)
 	}
 
-	return nil
+	switch oo := obj.(type) {
+	case rtree.Tree:
+		w, err := rtree.NewWriter(dir, objName, rtree.WriteVarsFromTree(oo), rtree.WithTitle(oo.Title()))
+		if err != nil {
+			return nil, fmt.Errorf("could not create output ROOT tree %q: %w", name, err)
+		}
+
+		r, err := rtree.NewReader(oo, nil)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not create input ROOT tree reader %q: %w",
+				name, err,
+			)
+		}
+		defer r.Close()
+
+		_, err = rtree.Copy(w, r)
+		if err != nil {
+			return nil, fmt.Errorf("could not seed output ROOT tree %q: %w", name, err)
+		}
+		obj = w
+	}
+
+	return &task{
+		dir:     dirName,
+		key:     objName,
+		obj:     obj,
+		verbose: cmd.verbose,
+	}, nil
+}
+
+func (tsk *task) path() string {
+	return stdpath.Join(tsk.dir, tsk.key)
 }
 
 func (tsk *task) close(f *riofs.File) error {
@@ -238,15 +400,9 @@ func (tsk *task) mergeObj(dst, src root.Object) error {
 	}
 
 	switch dst := dst.(type) {
-	case rhist.H2:
-		return tsk.mergeH2(dst, src.(rhist.H2))
 	case root.Merger:
 		return dst.ROOTMerge(src)
 	default:
 		return fmt.Errorf("could not find suitable merge-API for (dst=%T, src=%T)", dst, src)
 	}
 }
-
-func (tsk *task) mergeH2(dst, src rhist.H2) error {
-	panic("not implemented")
-}
@@ -15,6 +15,7 @@ import (
 	"go-hep.org/x/hep/groot/rcmd"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtree"
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
@@ -32,7 +33,7 @@ func TestMerge(t *testing.T) {
 		name   string
 		inputs []funcT
 		output funcT
-		panics string
+		opts   []rcmd.MergeOption
 	}{
 		{
 			name:   "flat-tree-1",
@@ -83,7 +84,6 @@ func TestMerge(t *testing.T) {
 			name:   "h2d-2",
 			inputs: []funcT{makeH2D(1), makeH2D(1)},
 			output: makeH2D(2),
-			panics: "not implemented", // FIXME(sbinet)
 		},
 		{
 			name:   "graph-1",
@@ -115,6 +115,38 @@ func TestMerge(t *testing.T) {
 			inputs: []funcT{makeGraphAsymmErr(0, 1), makeGraphAsymmErr(1, 2)},
 			output: makeGraphAsymmErr(0, 2),
 		},
+		{
+			name:   "h1d-4-workers",
+			inputs: []funcT{makeH1D(1), makeH1D(1), makeH1D(1), makeH1D(1)},
+			output: makeH1D(4),
+			opts:   []rcmd.MergeOption{rcmd.MergeWorkers(3)},
+		},
+		{
+			name:   "h1d-2-lz4",
+			inputs: []funcT{makeH1D(1), makeH1D(1)},
+			output: makeH1D(2),
+			opts:   []rcmd.MergeOption{rcmd.MergeCompression(riofs.LZ4, 1)},
+		},
+		{
+			name:   "flat-tree-2-zlib",
+			inputs: []funcT{makeFlatTree(1), makeFlatTree(1)},
+			output: makeFlatTree(2),
+			opts:   []rcmd.MergeOption{rcmd.MergeCompression(riofs.ZLIB, 9)},
+		},
+		{
+			name:   "h1f-h1d-union",
+			inputs: []funcT{makeH1F(1), makeH1D(1), makeH1F(1)},
+			output: makeH1FH1D(2, 1),
+		},
+		{
+			name:   "h1d-skip-errors",
+			inputs: []funcT{makeH1D(1), makeInvalid, makeH1D(1)},
+			output: makeH1D(2),
+			opts: []rcmd.MergeOption{
+				rcmd.MergeSkipErrors(true),
+				rcmd.MergeWorkers(2),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			var (
@@ -137,19 +169,7 @@ func TestMerge(t *testing.T) {
 				t.Fatalf("%+v", err)
 			}
 
-			if tc.panics != "" {
-				defer func() {
-					err := recover()
-					if err == nil {
-						t.Fatalf("expected a panic")
-					}
-					if got, want := err.(string), tc.panics; got != want {
-						t.Fatalf("invalid panic message. got=%q, want=%q", got, want)
-					}
-				}()
-			}
-
-			err = rcmd.Merge(oname, fnames, verbose)
+			err = rcmd.Merge(oname, fnames, verbose, tc.opts...)
 			if err != nil {
 				t.Fatalf("could not run root-merge: %+v", err)
 			}
@@ -311,6 +331,58 @@ func makeH1D(n int) func(t *testing.T, fname string) error {
 	}
 }
 
+func makeH1FH1D(nf, nd int) func(t *testing.T, fname string) error {
+	return func(t *testing.T, fname string) error {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer f.Close()
+
+		_, err = riofs.Dir(f).Mkdir("dir-1/dir-11")
+		if err != nil {
+			t.Fatalf("could not create directory: %+v", err)
+		}
+
+		dir21, err := riofs.Dir(f).Mkdir("dir-2/dir-11")
+		if err != nil {
+			t.Fatalf("could not create directory: %+v", err)
+		}
+
+		for _, v := range []struct {
+			name string
+			n    int
+			conv func(h *hbook.H1D) root.Object
+		}{
+			{"h1f", nf, func(h *hbook.H1D) root.Object { return rhist.NewH1FFrom(h) }},
+			{"h1d", nd, func(h *hbook.H1D) root.Object { return rootcnv.FromH1D(h) }},
+		} {
+			h := hbook.NewH1D(10, 0, 10)
+			h.Annotation()["title"] = v.name
+			for i := 0; i < v.n; i++ {
+				h.Fill(5, 1)
+				h.Fill(6, 2)
+			}
+
+			err = dir21.Put(v.name, v.conv(h))
+			if err != nil {
+				t.Fatalf("could not save %s: %+v", v.name, err)
+			}
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func makeInvalid(t *testing.T, fname string) error {
+	return os.WriteFile(fname, []byte("not a ROOT file"), 0644)
+}
+
 func makeH1I(n int) func(t *testing.T, fname string) error {
 	return func(t *testing.T, fname string) error {
 		f, err := groot.Create(fname)
@@ -14,7 +14,7 @@ import (
 func splitArg(cmd string) (fname, sel string, err error) {
 	fname = cmd
 	prefix := ""
-	for _, p := range []string{"https://", "http://", "root://", "file://"} {
+	for _, p := range []string{"https://", "http://", "root://", "roots://", "file://"} {
 		if strings.HasPrefix(cmd, p) {
 			prefix = p
 			break
@@ -0,0 +1,115 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	stdpath "path"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// SkimOption controls how Skim behaves.
+type SkimOption func(*skimCmd)
+
+// SkimWhere sets the selection expression entries must pass to be
+// copied to the output tree, as described by rtree.NewFormula.
+// By default, all the entries are copied.
+func SkimWhere(expr string) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.where = expr
+	}
+}
+
+// SkimBranches selects the top-level branches to copy to the output tree.
+// By default, all the branches are copied.
+func SkimBranches(names ...string) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.branches = append(cmd.branches, names...)
+	}
+}
+
+// SkimRange sets the half-open interval [beg, end) of entries to consider.
+// An end of -1 considers all the entries up to the end of the tree.
+func SkimRange(beg, end int64) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.beg = beg
+		cmd.end = end
+	}
+}
+
+// Skim copies the tree tname from the input ROOT file fname into the
+// output ROOT file oname, keeping only the selected branches and the
+// entries passing the selection expression.
+//
+// The output tree is created under the same path as the input one.
+// Skim returns the number of entries written to the output tree.
+func Skim(oname, fname, tname string, opts ...SkimOption) (int64, error) {
+	cmd := skimCmd{end: -1}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return 0, fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return 0, fmt.Errorf("could not get tree %q: %w", tname, err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return 0, fmt.Errorf("object %q in file %q is not a tree (%T)", tname, fname, obj)
+	}
+
+	o, err := groot.Create(oname)
+	if err != nil {
+		return 0, fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
+	}
+	defer o.Close()
+
+	var (
+		dirName = stdpath.Dir(tname)
+		objName = stdpath.Base(tname)
+		dir     = riofs.Directory(o)
+	)
+	if dirName != "/" && dirName != "" && dirName != "." {
+		dir, err = riofs.Dir(o).Mkdir(dirName)
+		if err != nil {
+			return 0, fmt.Errorf("could not create output directory %q: %w", dirName, err)
+		}
+	}
+
+	n, err := rtree.CopyTree(
+		dir, tree,
+		rtree.WithCopyName(objName),
+		rtree.WithCopyBranches(cmd.branches...),
+		rtree.WithCopyRange(cmd.beg, cmd.end),
+		rtree.WithCopyFilter(cmd.where),
+	)
+	if err != nil {
+		return n, fmt.Errorf("could not skim tree %q: %w", tname, err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return n, fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
+	}
+
+	return n, nil
+}
+
+type skimCmd struct {
+	where    string
+	branches []string
+	beg      int64
+	end      int64
+}
@@ -0,0 +1,143 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestSkim(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name     string
+		opts     []rcmd.SkimOption
+		branches []string
+		want     []int32
+		err      error
+	}{
+		{
+			name: "where-branches",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimWhere("Int32 % 25 == 0"),
+				rcmd.SkimBranches("Int32", "Str", "SliceInt32"),
+			},
+			branches: []string{"Int32", "Str", "N", "SliceInt32"},
+			want:     []int32{0, 25, 50, 75},
+		},
+		{
+			name: "where-abs",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimWhere("Float64 > 95 && abs(Float64 - 97) < 1.5"),
+				rcmd.SkimBranches("Int32"),
+			},
+			branches: []string{"Int32"},
+			want:     []int32{96, 97, 98},
+		},
+		{
+			name: "range",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimRange(10, 13),
+				rcmd.SkimBranches("Int32"),
+			},
+			branches: []string{"Int32"},
+			want:     []int32{10, 11, 12},
+		},
+		{
+			name: "invalid-where",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimWhere("Int32 +"),
+				rcmd.SkimBranches("Int32"),
+			},
+			err: fmt.Errorf(`could not skim tree "tree"`),
+		},
+		{
+			name: "missing-branch",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimBranches("not-there"),
+			},
+			err: fmt.Errorf(`could not skim tree "tree": rtree: tree "tree" has no branch named "not-there"`),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+
+			n, err := rcmd.Skim(oname, fname, "tree", tc.opts...)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); !strings.HasPrefix(got, want) {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil && tc.err == nil:
+				t.Fatalf("could not skim tree: %+v", err)
+			case err == nil && tc.err != nil:
+				t.Fatalf("expected an error (%v)", tc.err)
+			}
+
+			if got, want := n, int64(len(tc.want)); got != want {
+				t.Fatalf("invalid number of skimmed entries: got=%d, want=%d", got, want)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open skimmed file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not get skimmed tree: %+v", err)
+			}
+			tree := obj.(rtree.Tree)
+
+			var names []string
+			for _, b := range tree.Branches() {
+				names = append(names, b.Name())
+			}
+			if got, want := names, tc.branches; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid branches:\ngot= %q\nwant=%q", got, want)
+			}
+
+			var (
+				i32 int32
+				got []int32
+			)
+			r, err := rtree.NewReader(tree, []rtree.ReadVar{{Name: "Int32", Value: &i32}})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				got = append(got, i32)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read skimmed tree: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid skimmed entries:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	stdpath "path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// StatOption controls how Stat behaves.
+type StatOption func(*statCmd)
+
+// StatTree restricts the summary to the tree with the provided name.
+// By default, all the trees contained in the provided ROOT files are
+// considered.
+func StatTree(name string) StatOption {
+	return func(cmd *statCmd) {
+		cmd.tree = name
+	}
+}
+
+// StatWeight sets the name of the branch holding the per-entry weights.
+// If the trees have no such branch, the name is looked up in the ROOT files
+// and should refer to a metadata object holding the sum of weights of the
+// file: either a TObjString holding a number or a histogram.
+//
+// By default, all entries have a unit weight.
+func StatWeight(name string) StatOption {
+	return func(cmd *statCmd) {
+		cmd.weight = name
+	}
+}
+
+// Stat scans the provided ROOT files and writes a JSON summary of the
+// dataset they form into the provided io.Writer.
+//
+// Trees with the same name are considered to be parts of the same dataset.
+// For each tree, Stat reports the number of entries, the sum of weights and,
+// for each branch, the range of its values and the rate of null entries.
+// An entry is considered null when it does not hold any data (a NaN,
+// an empty string, an empty variable-length array, ...).
+func Stat(w io.Writer, fnames []string, opts ...StatOption) error {
+	cmd := statCmd{
+		ds: statDataset{
+			Files: fnames,
+			Trees: []*statTree{},
+		},
+		trees: make(map[string]*statTree),
+	}
+
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	for _, fname := range fnames {
+		err := cmd.scan(fname)
+		if err != nil {
+			return fmt.Errorf("could not scan file %q: %w", fname, err)
+		}
+	}
+
+	if cmd.tree != "" && len(cmd.ds.Trees) == 0 {
+		return fmt.Errorf("could not find tree %q", cmd.tree)
+	}
+
+	for _, tree := range cmd.ds.Trees {
+		for _, b := range tree.Branches {
+			if b.entries > 0 {
+				b.NullRate = float64(b.Nulls) / float64(b.entries)
+			}
+		}
+	}
+
+	o, err := json.MarshalIndent(cmd.ds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode summary to JSON: %w", err)
+	}
+	o = append(o, '\n')
+
+	_, err = w.Write(o)
+	if err != nil {
+		return fmt.Errorf("could not write summary: %w", err)
+	}
+
+	return nil
+}
+
+type statCmd struct {
+	tree   string
+	weight string
+
+	ds    statDataset
+	trees map[string]*statTree
+}
+
+type statDataset struct {
+	Files []string    `json:"files"`
+	Trees []*statTree `json:"trees"`
+}
+
+type statTree struct {
+	Name     string        `json:"name"`
+	Files    int           `json:"files"`
+	Entries  int64         `json:"entries"`
+	SumW     float64       `json:"sumw"`
+	Weight   string        `json:"weight,omitempty"` // origin of the weights
+	Branches []*statBranch `json:"branches"`
+
+	branches map[string]*statBranch
+}
+
+type statBranch struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Nulls    int64    `json:"nulls"`
+	NullRate float64  `json:"null-rate"`
+
+	entries int64 // number of entries holding this branch
+}
+
+func (cmd *statCmd) scan(fname string) error {
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	var trees []string
+	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := obj.(rtree.Tree); !ok {
+			return nil
+		}
+		name := strings.TrimPrefix(path, stdpath.Clean(f.Name())+"/")
+		if cmd.tree != "" && name != cmd.tree {
+			return nil
+		}
+		trees = append(trees, name)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not walk through file: %w", err)
+	}
+
+	for _, name := range trees {
+		err := cmd.scanTree(f, name)
+		if err != nil {
+			return fmt.Errorf("could not scan tree %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (cmd *statCmd) scanTree(f *riofs.File, name string) error {
+	t, err := riofs.Get[rtree.Tree](riofs.Dir(f), name)
+	if err != nil {
+		return err
+	}
+
+	tree, ok := cmd.trees[name]
+	if !ok {
+		tree = &statTree{
+			Name:     name,
+			Branches: []*statBranch{},
+			branches: make(map[string]*statBranch),
+		}
+		cmd.trees[name] = tree
+		cmd.ds.Trees = append(cmd.ds.Trees, tree)
+	}
+	tree.Files++
+	tree.Entries += t.Entries()
+
+	vars := rtree.NewReadVars(t)
+	stats := make([]*statBranch, len(vars))
+	for i, v := range vars {
+		name := v.Name
+		if v.Leaf != "" && v.Leaf != v.Name {
+			name = v.Name + "." + v.Leaf
+		}
+		b, ok := tree.branches[name]
+		if !ok {
+			b = &statBranch{
+				Name: name,
+				Type: reflect.TypeOf(v.Value).Elem().String(),
+			}
+			tree.branches[name] = b
+			tree.Branches = append(tree.Branches, b)
+		}
+		b.entries += t.Entries()
+		stats[i] = b
+	}
+
+	weight := -1
+	switch {
+	case cmd.weight == "":
+		tree.SumW += float64(t.Entries())
+	case t.Branch(cmd.weight) != nil:
+		for i, v := range vars {
+			if v.Name == cmd.weight {
+				weight = i
+				break
+			}
+		}
+		if weight < 0 {
+			return fmt.Errorf("could not find weight branch %q", cmd.weight)
+		}
+		tree.Weight = "branch:" + cmd.weight
+	default:
+		sumw, err := statMetaWeight(f, cmd.weight)
+		if err != nil {
+			return fmt.Errorf("could not retrieve sum of weights: %w", err)
+		}
+		tree.SumW += sumw
+		tree.Weight = "meta:" + cmd.weight
+	}
+
+	r, err := rtree.NewReader(t, vars)
+	if err != nil {
+		return fmt.Errorf("could not create reader: %w", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(rctx rtree.RCtx) error {
+		for i, v := range vars {
+			rv := reflect.ValueOf(v.Value).Elem()
+			if stats[i].fill(rv) {
+				stats[i].Nulls++
+			}
+		}
+		if weight >= 0 {
+			w, ok := statFloat(reflect.ValueOf(vars[weight].Value).Elem())
+			if !ok {
+				return fmt.Errorf("invalid weight type %T", vars[weight].Deref())
+			}
+			tree.SumW += w
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not read through tree: %w", err)
+	}
+
+	return nil
+}
+
+// statMetaWeight returns the sum of weights held by the named metadata
+// object of the provided file.
+func statMetaWeight(f *riofs.File, name string) (float64, error) {
+	obj, err := riofs.Dir(f).Get(name)
+	if err != nil {
+		return 0, err
+	}
+
+	switch obj := obj.(type) {
+	case rhist.H1:
+		return obj.SumW(), nil
+	case rhist.H2:
+		return obj.SumW(), nil
+	case root.ObjString:
+		v, err := strconv.ParseFloat(strings.TrimSpace(obj.String()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse sum of weights from %q: %w", name, err)
+		}
+		return v, nil
+	default:
+		return 0, fmt.Errorf("object %q (%T) does not hold a sum of weights", name, obj)
+	}
+}
+
+// fill updates the branch summary with the provided value.
+// fill reports whether the value was null.
+func (b *statBranch) fill(rv reflect.Value) bool {
+	if v, ok := statFloat(rv); ok {
+		if math.IsNaN(v) {
+			return true
+		}
+		b.update(v)
+		return false
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return b.fill(rv.Elem())
+	case reflect.Array, reflect.Slice:
+		null := true
+		for i := 0; i < rv.Len(); i++ {
+			if !b.fill(rv.Index(i)) {
+				null = false
+			}
+		}
+		return null
+	case reflect.Map:
+		null := true
+		iter := rv.MapRange()
+		for iter.Next() {
+			if !b.fill(iter.Value()) {
+				null = false
+			}
+		}
+		return null
+	case reflect.Struct:
+		var (
+			null   = true
+			fields = 0
+		)
+		for i := 0; i < rv.NumField(); i++ {
+			if !rv.Type().Field(i).IsExported() {
+				continue
+			}
+			fields++
+			if !b.fill(rv.Field(i)) {
+				null = false
+			}
+		}
+		return null && fields > 0
+	}
+	return false
+}
+
+func (b *statBranch) update(v float64) {
+	if b.Min == nil {
+		b.Min = new(float64)
+		b.Max = new(float64)
+		*b.Min = v
+		*b.Max = v
+		return
+	}
+	*b.Min = math.Min(*b.Min, v)
+	*b.Max = math.Max(*b.Max, v)
+}
+
+// statFloat converts a scalar numerical value into a float64.
+func statFloat(rv reflect.Value) (float64, bool) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, true
+		}
+		return 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,197 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestStat(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rcmd-stat-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fnames := []string{
+		filepath.Join(tmp, "f0.root"),
+		filepath.Join(tmp, "f1.root"),
+	}
+
+	for i, fname := range fnames {
+		err := func(ifile int, fname string) error {
+			f, err := groot.Create(fname)
+			if err != nil {
+				return fmt.Errorf("could not create file: %w", err)
+			}
+			defer f.Close()
+
+			err = f.Put("sumw", rbase.NewObjString(fmt.Sprintf("%d.5", 10*(ifile+1))))
+			if err != nil {
+				return fmt.Errorf("could not save metadata: %w", err)
+			}
+
+			var evt struct {
+				W   float64
+				X   float64
+				Str string
+				N   int32
+				Sli []int64 `groot:"Sli[N]"`
+			}
+			w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(&evt))
+			if err != nil {
+				return fmt.Errorf("could not create tree writer: %w", err)
+			}
+			defer w.Close()
+
+			for j := 0; j < 5; j++ {
+				i := 5*ifile + j
+				evt.W = 0.5
+				evt.X = float64(i)
+				if i%2 == 0 {
+					evt.X = math.NaN()
+				}
+				evt.Str = ""
+				if i%5 != 0 {
+					evt.Str = fmt.Sprintf("evt-%d", i)
+				}
+				evt.N = int32(j)
+				evt.Sli = evt.Sli[:0]
+				for k := 0; k < j; k++ {
+					evt.Sli = append(evt.Sli, int64(-i))
+				}
+				_, err = w.Write()
+				if err != nil {
+					return fmt.Errorf("could not write event %d: %w", i, err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				return fmt.Errorf("could not close tree writer: %w", err)
+			}
+
+			return f.Close()
+		}(i, fname)
+		if err != nil {
+			t.Fatalf("could not create file %d: %+v", i, err)
+		}
+	}
+
+	type branch struct {
+		Name     string   `json:"name"`
+		Type     string   `json:"type"`
+		Min      *float64 `json:"min"`
+		Max      *float64 `json:"max"`
+		Nulls    int64    `json:"nulls"`
+		NullRate float64  `json:"null-rate"`
+	}
+	type tree struct {
+		Name     string   `json:"name"`
+		Files    int      `json:"files"`
+		Entries  int64    `json:"entries"`
+		SumW     float64  `json:"sumw"`
+		Weight   string   `json:"weight"`
+		Branches []branch `json:"branches"`
+	}
+	type dataset struct {
+		Files []string `json:"files"`
+		Trees []tree   `json:"trees"`
+	}
+
+	ptr := func(v float64) *float64 { return &v }
+	branches := []branch{
+		{Name: "W", Type: "float64", Min: ptr(0.5), Max: ptr(0.5)},
+		{Name: "X", Type: "float64", Min: ptr(1), Max: ptr(9), Nulls: 5, NullRate: 0.5},
+		{Name: "Str", Type: "string", Nulls: 2, NullRate: 0.2},
+		{Name: "N", Type: "int32", Min: ptr(0), Max: ptr(4)},
+		{Name: "Sli", Type: "[]int64", Min: ptr(-9), Max: ptr(-1), Nulls: 2, NullRate: 0.2},
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.StatOption
+		want dataset
+		err  bool
+	}{
+		{
+			name: "default",
+			want: dataset{
+				Files: fnames,
+				Trees: []tree{{
+					Name: "tree", Files: 2, Entries: 10, SumW: 10,
+					Branches: branches,
+				}},
+			},
+		},
+		{
+			name: "weight-branch",
+			opts: []rcmd.StatOption{rcmd.StatTree("tree"), rcmd.StatWeight("W")},
+			want: dataset{
+				Files: fnames,
+				Trees: []tree{{
+					Name: "tree", Files: 2, Entries: 10, SumW: 5, Weight: "branch:W",
+					Branches: branches,
+				}},
+			},
+		},
+		{
+			name: "weight-meta",
+			opts: []rcmd.StatOption{rcmd.StatWeight("sumw")},
+			want: dataset{
+				Files: fnames,
+				Trees: []tree{{
+					Name: "tree", Files: 2, Entries: 10, SumW: 31, Weight: "meta:sumw",
+					Branches: branches,
+				}},
+			},
+		},
+		{
+			name: "no-weight",
+			opts: []rcmd.StatOption{rcmd.StatWeight("not-there")},
+			err:  true,
+		},
+		{
+			name: "no-tree",
+			opts: []rcmd.StatOption{rcmd.StatTree("not-there")},
+			err:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := rcmd.Stat(out, fnames, tc.opts...)
+			switch {
+			case err != nil && tc.err:
+				return
+			case err != nil:
+				t.Fatalf("could not compute stats: %+v", err)
+			case tc.err:
+				t.Fatalf("expected an error")
+			}
+
+			var got dataset
+			err = json.Unmarshal(out.Bytes(), &got)
+			if err != nil {
+				t.Fatalf("could not decode JSON summary: %+v\n%s", err, out.String())
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("invalid summary (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
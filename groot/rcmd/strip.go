@@ -0,0 +1,241 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	stdpath "path"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// StripOption controls how Strip behaves.
+type StripOption func(*stripCmd)
+
+// StripDrop drops the objects whose path matches one of the provided
+// patterns, as described by path.Match.
+// Paths are relative to the top-level directory of the input file,
+// e.g. "dir/h1" or "dir/h*".
+// Top-level branches of trees are dropped when their path, made of the
+// path of their tree and of their name, matches, e.g. "dir/tree/secret".
+// Dropping a directory drops all its content.
+func StripDrop(patterns ...string) StripOption {
+	return func(cmd *stripCmd) {
+		cmd.drop = append(cmd.drop, patterns...)
+	}
+}
+
+// StripRename renames the object at path old to new in the output file.
+// Parent directories of new are created as needed.
+// Renaming a directory renames the paths of all its content.
+func StripRename(old, new string) StripOption {
+	return func(cmd *stripCmd) {
+		cmd.rename[stripClean(old)] = stripClean(new)
+	}
+}
+
+// StripMetadata configures whether the user metadata of the copied
+// objects, i.e. the titles of keys, trees and named objects, is
+// cleared.
+func StripMetadata(v bool) StripOption {
+	return func(cmd *stripCmd) {
+		cmd.meta = v
+	}
+}
+
+// Strip copies the content of the input ROOT file fname into the output
+// ROOT file oname, dropping, renaming and anonymizing objects along the
+// way.
+// Only the last cycle of each key is copied.
+//
+// Strip is useful to produce shareable test files out of private data.
+func Strip(oname, fname string, opts ...StripOption) error {
+	cmd := stripCmd{
+		rename: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	for _, pattern := range cmd.drop {
+		_, err := stdpath.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("invalid drop pattern %q: %w", pattern, err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	o, err := groot.Create(oname)
+	if err != nil {
+		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
+	}
+	defer o.Close()
+
+	err = cmd.dir(o, f, "", "")
+	if err != nil {
+		return err
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
+	}
+
+	return nil
+}
+
+type stripCmd struct {
+	drop   []string
+	rename map[string]string
+	meta   bool
+}
+
+// dir copies the content of the input directory src, located at ipath,
+// into the output file o, under opath.
+func (cmd *stripCmd) dir(o *riofs.File, src riofs.Directory, ipath, opath string) error {
+	keys := src.Keys()
+	last := make(map[string]int, len(keys))
+	for _, k := range keys {
+		if cycle, ok := last[k.Name()]; !ok || k.Cycle() > cycle {
+			last[k.Name()] = k.Cycle()
+		}
+	}
+
+	for i := range keys {
+		k := &keys[i]
+		if k.Cycle() != last[k.Name()] {
+			continue
+		}
+
+		ipath := stdpath.Join(ipath, k.Name())
+		if cmd.dropped(ipath) {
+			continue
+		}
+
+		opath := stdpath.Join(opath, k.Name())
+		if v, ok := cmd.rename[ipath]; ok {
+			opath = v
+		}
+
+		obj, err := k.Object()
+		if err != nil {
+			return fmt.Errorf("could not load object %q: %w", ipath, err)
+		}
+
+		switch obj := obj.(type) {
+		case riofs.Directory:
+			_, err = stripMkdir(o, opath)
+			if err != nil {
+				return err
+			}
+			err = cmd.dir(o, obj, ipath, opath)
+		case rtree.Tree:
+			err = cmd.tree(o, obj, ipath, opath)
+		default:
+			err = cmd.obj(o, obj, opath)
+		}
+		if err != nil {
+			return fmt.Errorf("could not copy object %q: %w", ipath, err)
+		}
+	}
+
+	return nil
+}
+
+func (cmd *stripCmd) tree(o *riofs.File, tree rtree.Tree, ipath, opath string) error {
+	dir, err := stripMkdir(o, stdpath.Dir(opath))
+	if err != nil {
+		return err
+	}
+
+	var (
+		branches []string
+		dropped  = false
+	)
+	for _, b := range tree.Branches() {
+		if cmd.dropped(stdpath.Join(ipath, b.Name())) {
+			dropped = true
+			continue
+		}
+		branches = append(branches, b.Name())
+	}
+	if dropped && len(branches) == 0 {
+		return fmt.Errorf("all the branches of tree %q were dropped", ipath)
+	}
+
+	opts := []rtree.CopyOption{
+		rtree.WithCopyName(stdpath.Base(opath)),
+	}
+	if dropped {
+		opts = append(opts, rtree.WithCopyBranches(branches...))
+	}
+	if cmd.meta {
+		opts = append(opts, rtree.WithCopyWriteOptions(rtree.WithTitle("")))
+	}
+
+	_, err = rtree.CopyTree(dir, tree, opts...)
+	return err
+}
+
+func (cmd *stripCmd) obj(o *riofs.File, obj root.Object, opath string) error {
+	dir, err := stripMkdir(o, stdpath.Dir(opath))
+	if err != nil {
+		return err
+	}
+
+	if cmd.meta {
+		if v, ok := obj.(interface{ SetTitle(string) }); ok {
+			v.SetTitle("")
+		}
+	}
+
+	return dir.Put(stdpath.Base(opath), obj)
+}
+
+// dropped returns whether the object at path should be dropped.
+func (cmd *stripCmd) dropped(path string) bool {
+	for _, pattern := range cmd.drop {
+		if ok, _ := stdpath.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// stripMkdir returns the directory at path in the output file, creating
+// it and its parents as needed.
+func stripMkdir(o *riofs.File, path string) (riofs.Directory, error) {
+	if path == "" || path == "." || path == "/" {
+		return o, nil
+	}
+
+	obj, err := riofs.Dir(o).Get(path)
+	if err == nil {
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return nil, fmt.Errorf("output object %q is not a directory (%T)", path, obj)
+		}
+		return dir, nil
+	}
+
+	dir, err := riofs.Dir(o).Mkdir(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create output directory %q: %w", path, err)
+	}
+	return dir, nil
+}
+
+func stripClean(path string) string {
+	return strings.Trim(stdpath.Clean(path), "/")
+}
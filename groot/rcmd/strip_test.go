@@ -0,0 +1,230 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestStrip(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-root-strip-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "in.root")
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var (
+			run    int32
+			pt     float64
+			secret int64
+		)
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "run", Value: &run},
+			{Name: "pt", Value: &pt},
+			{Name: "secret", Value: &secret},
+		}, rtree.WithTitle("private tree"))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 10; i++ {
+			run = int32(i)
+			pt = float64(i) * 10
+			secret = int64(i) * 1000
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		h := hbook.NewH1D(10, 0, 10)
+		h.Fill(1, 1)
+		h.Annotation()["title"] = "private histo"
+		dir, err := riofs.Dir(f).Mkdir("dir/sub")
+		if err != nil {
+			t.Fatalf("could not create directory: %+v", err)
+		}
+		err = dir.Put("h1", rhist.NewH1DFrom(h))
+		if err != nil {
+			t.Fatalf("could not write histogram: %+v", err)
+		}
+
+		for _, v := range []string{"notes-v1", "notes-v2"} {
+			err = f.Put("notes", rbase.NewObjString(v))
+			if err != nil {
+				t.Fatalf("could not write notes: %+v", err)
+			}
+		}
+		err = riofs.Dir(f).Put("dir/cfg", rbase.NewObjString("cfg"))
+		if err != nil {
+			t.Fatalf("could not write config: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	type object struct {
+		class string
+		title string
+	}
+
+	walk := func(fname string) map[string]object {
+		t.Helper()
+		f, err := groot.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		objs := make(map[string]object)
+		err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+			if err != nil {
+				return err
+			}
+			path = path[len(f.Name()):]
+			if path == "" {
+				return nil
+			}
+			var title string
+			if v, ok := obj.(root.Named); ok {
+				title = v.Title()
+			}
+			objs[path[1:]] = object{class: obj.Class(), title: title}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not walk file: %+v", err)
+		}
+		return objs
+	}
+
+	for _, tc := range []struct {
+		name     string
+		opts     []rcmd.StripOption
+		want     map[string]object
+		branches []string
+	}{
+		{
+			name: "copy",
+			want: map[string]object{
+				"tree":       {"TTree", "private tree"},
+				"dir":        {"TDirectoryFile", "dir"},
+				"dir/sub":    {"TDirectoryFile", "sub"},
+				"dir/sub/h1": {"TH1D", "private histo"},
+				"dir/cfg":    {"TObjString", "Collectable string class"},
+				"notes":      {"TObjString", "Collectable string class"},
+			},
+			branches: []string{"run", "pt", "secret"},
+		},
+		{
+			name: "strip",
+			opts: []rcmd.StripOption{
+				rcmd.StripDrop("notes", "tree/secret", "dir/c*"),
+				rcmd.StripRename("dir/sub", "hists"),
+				rcmd.StripRename("tree", "data/events"),
+				rcmd.StripMetadata(true),
+			},
+			want: map[string]object{
+				"data":        {"TDirectoryFile", "data"},
+				"data/events": {"TTree", ""},
+				"dir":         {"TDirectoryFile", "dir"},
+				"hists":       {"TDirectoryFile", "hists"},
+				"hists/h1":    {"TH1D", ""},
+			},
+			branches: []string{"run", "pt"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Strip(oname, fname, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not strip file: %+v", err)
+			}
+
+			if got, want := walk(oname), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid output content:\ngot= %v\nwant=%v", got, want)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			tname := "tree"
+			if _, ok := tc.want["data/events"]; ok {
+				tname = "data/events"
+			}
+			tree, err := riofs.Get[rtree.Tree](riofs.Dir(f), tname)
+			if err != nil {
+				t.Fatalf("could not get output tree: %+v", err)
+			}
+			if got, want := tree.Entries(), int64(10); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var branches []string
+			for _, b := range tree.Branches() {
+				branches = append(branches, b.Name())
+			}
+			sort.Strings(branches)
+			sort.Strings(tc.branches)
+			if got, want := branches, tc.branches; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid branches:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.StripOption
+	}{
+		{
+			name: "invalid-pattern",
+			opts: []rcmd.StripOption{rcmd.StripDrop("[")},
+		},
+		{
+			name: "all-branches",
+			opts: []rcmd.StripOption{rcmd.StripDrop("tree/*")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Strip(oname, fname, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdpath "path"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// ErrCorrupted is returned by Verify when corrupted objects were found.
+var ErrCorrupted = errors.New("corrupted ROOT file")
+
+// VerifyOption controls how Verify behaves.
+type VerifyOption func(*verifyCmd)
+
+// VerifyJSON configures whether the verification report is written
+// as JSON.
+func VerifyJSON(v bool) VerifyOption {
+	return func(cmd *verifyCmd) {
+		cmd.json = v
+	}
+}
+
+// VerifyVerbose configures whether valid objects are also reported.
+func VerifyVerbose(v bool) VerifyOption {
+	return func(cmd *verifyCmd) {
+		cmd.verbose = v
+	}
+}
+
+// Verify checks the integrity of the ROOT file fname and writes a report
+// listing the corrupted objects, with their offsets in the file, to the
+// provided io.Writer.
+//
+// Verify reads and decompresses the payload of every key of the file,
+// recursively, and decodes the objects they hold.
+// Checksums of compressed payloads are verified while decompressing them.
+// For trees, every basket of every branch is read and decompressed, and
+// its byte count and number of entries are checked against the ones
+// recorded in the branch.
+//
+// Objects whose payload could be read but not decoded are reported
+// separately: they may be corrupted, or of a type groot does not support.
+//
+// Verify returns an error wrapping ErrCorrupted when corrupted objects
+// were found.
+func Verify(w io.Writer, fname string, opts ...VerifyOption) error {
+	cmd := verifyCmd{
+		w:   w,
+		rep: verifyReport{File: fname},
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	f, err := verifyOpen(fname)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	if !cmd.json {
+		fmt.Fprintf(cmd.w, "=== [%s] ===\n", fname)
+	}
+
+	cmd.dir(f, "")
+
+	n := len(cmd.rep.Corrupted)
+	switch {
+	case cmd.json:
+		if cmd.rep.Corrupted == nil {
+			cmd.rep.Corrupted = []verifyIssue{}
+		}
+		if cmd.rep.Undecodable == nil {
+			cmd.rep.Undecodable = []verifyIssue{}
+		}
+		out, err := json.MarshalIndent(cmd.rep, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode verification report: %w", err)
+		}
+		_, err = cmd.w.Write(append(out, '\n'))
+		if err != nil {
+			return fmt.Errorf("could not write verification report: %w", err)
+		}
+	default:
+		fmt.Fprintf(
+			cmd.w, "checked %d keys, %d trees and %d baskets: %d corrupted objects, %d undecodable objects\n",
+			cmd.rep.Keys, cmd.rep.Trees, cmd.rep.Baskets, n, len(cmd.rep.Undecodable),
+		)
+	}
+
+	if n > 0 {
+		return fmt.Errorf("found %d corrupted objects in %q: %w", n, fname, ErrCorrupted)
+	}
+
+	return nil
+}
+
+type verifyCmd struct {
+	w       io.Writer
+	json    bool
+	verbose bool
+
+	rep verifyReport
+}
+
+type verifyReport struct {
+	File        string        `json:"file"`
+	Keys        int           `json:"keys"`
+	Trees       int           `json:"trees"`
+	Baskets     int           `json:"baskets"`
+	Corrupted   []verifyIssue `json:"corrupted"`
+	Undecodable []verifyIssue `json:"undecodable"`
+}
+
+type verifyIssue struct {
+	Key    string        `json:"key"`   // path of the key, with its cycle
+	Class  string        `json:"class"` // class of the object held by the key
+	Seek   int64         `json:"seek"`  // offset of the corrupted key or basket
+	Basket *verifyBasket `json:"basket,omitempty"`
+	Err    string        `json:"error"`
+}
+
+type verifyBasket struct {
+	Branch  string   `json:"branch"`
+	Index   int      `json:"index"`
+	Entries [2]int64 `json:"entries"`
+}
+
+func (cmd *verifyCmd) dir(dir riofs.Directory, path string) {
+	keys := dir.Keys()
+	for i := range keys {
+		cmd.key(&keys[i], path)
+	}
+}
+
+func (cmd *verifyCmd) key(k *riofs.Key, path string) {
+	cmd.rep.Keys++
+
+	name := fmt.Sprintf("%s;%d", stdpath.Join(path, k.Name()), k.Cycle())
+	issue := verifyIssue{
+		Key:   name,
+		Class: k.ClassName(),
+		Seek:  k.SeekKey(),
+	}
+
+	err := verifyPayload(k)
+	if err != nil {
+		issue.Err = err.Error()
+		cmd.corrupted(issue)
+		return
+	}
+
+	obj, err := verifyObject(k)
+	if err != nil {
+		issue.Err = err.Error()
+		cmd.undecodable(issue)
+		return
+	}
+
+	switch obj := obj.(type) {
+	case riofs.Directory:
+		cmd.ok(issue)
+		cmd.dir(obj, stdpath.Join(path, k.Name()))
+		return
+
+	case rtree.Tree:
+		cmd.rep.Trees++
+		n, err := rtree.Verify(obj, func(c rtree.Corruption) {
+			cmd.corrupted(verifyIssue{
+				Key:   name,
+				Class: k.ClassName(),
+				Seek:  c.Seek,
+				Basket: &verifyBasket{
+					Branch:  c.Branch,
+					Index:   c.Basket,
+					Entries: [2]int64{c.Beg, c.End},
+				},
+				Err: c.Err.Error(),
+			})
+		})
+		cmd.rep.Baskets += n
+		if err != nil {
+			return
+		}
+	}
+
+	cmd.ok(issue)
+}
+
+func (cmd *verifyCmd) ok(issue verifyIssue) {
+	if !cmd.verbose || cmd.json {
+		return
+	}
+	fmt.Fprintf(cmd.w, "key %q (%s) at offset %d: ok\n", issue.Key, issue.Class, issue.Seek)
+}
+
+func (cmd *verifyCmd) undecodable(issue verifyIssue) {
+	cmd.rep.Undecodable = append(cmd.rep.Undecodable, issue)
+	if cmd.json {
+		return
+	}
+	fmt.Fprintf(
+		cmd.w, "key %q (%s) at offset %d: undecodable: %s\n",
+		issue.Key, issue.Class, issue.Seek, issue.Err,
+	)
+}
+
+func (cmd *verifyCmd) corrupted(issue verifyIssue) {
+	cmd.rep.Corrupted = append(cmd.rep.Corrupted, issue)
+	if cmd.json {
+		return
+	}
+
+	switch bkt := issue.Basket; bkt {
+	case nil:
+		fmt.Fprintf(
+			cmd.w, "key %q (%s) at offset %d: corrupted: %s\n",
+			issue.Key, issue.Class, issue.Seek, issue.Err,
+		)
+	default:
+		fmt.Fprintf(
+			cmd.w, "key %q (%s): branch %q, basket %d at offset %d, entries [%d, %d): corrupted: %s\n",
+			issue.Key, issue.Class, bkt.Branch, bkt.Index, issue.Seek,
+			bkt.Entries[0], bkt.Entries[1], issue.Err,
+		)
+	}
+}
+
+// verifyOpen opens the named ROOT file.
+func verifyOpen(fname string) (f *riofs.File, err error) {
+	// a corrupted file header may trigger out-of-bounds accesses.
+	defer func() {
+		if e := recover(); e != nil {
+			f = nil
+			err = fmt.Errorf("could not read file header: %v", e)
+		}
+	}()
+
+	return groot.Open(fname)
+}
+
+// verifyPayload reads and decompresses the payload of the provided key.
+func verifyPayload(k *riofs.Key) (err error) {
+	// corrupted compressed blocks may trigger out-of-bounds accesses.
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("could not load payload: %v", e)
+		}
+	}()
+
+	_, err = k.Load(nil)
+	return err
+}
+
+// verifyObject decodes the object held by the provided key.
+func verifyObject(k *riofs.Key) (obj root.Object, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			obj = nil
+			err = fmt.Errorf("could not decode object: %v", e)
+		}
+	}()
+
+	obj, err = k.Object()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode object: %w", err)
+	}
+
+	return obj, nil
+}
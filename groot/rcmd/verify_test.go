@@ -0,0 +1,242 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestVerify(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		want  string
+	}{
+		{
+			name:  "flat-tree",
+			fname: "../testdata/small-flat-tree.root",
+			want: `=== [../testdata/small-flat-tree.root] ===
+key "tree;1" (TTree) at offset 8802: ok
+checked 1 keys, 1 trees and 20 baskets: 0 corrupted objects, 0 undecodable objects
+`,
+		},
+		{
+			name:  "dirs",
+			fname: "../testdata/dirs-6.14.00.root",
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+key "dir1;1" (TDirectoryFile) at offset 230: ok
+key "dir1/dir11;1" (TDirectoryFile) at offset 551: ok
+key "dir1/dir11/h1;1" (TH1F) at offset 660: ok
+key "dir2;1" (TDirectoryFile) at offset 337: ok
+key "dir3;1" (TDirectoryFile) at offset 444: ok
+checked 5 keys, 0 trees and 0 baskets: 0 corrupted objects, 0 undecodable objects
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := rcmd.Verify(out, tc.fname, rcmd.VerifyVerbose(true))
+			if err != nil {
+				t.Fatalf("could not verify file: %+v", err)
+			}
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid report:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestVerifyCorrupted(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-root-verify-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "corrupted.root")
+	func() {
+		f, err := groot.Create(fname, riofs.WithCompression(riofs.LZ4, 1))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var f64 float64
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "F64", Value: &f64},
+		}, rtree.WithBasketSize(8*10))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 100; i++ {
+			f64 = float64(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		h := hbook.NewH1D(100, 0, 100)
+		for i := 0; i < 1000; i++ {
+			h.Fill(float64(i%100)+0.5, float64(i))
+		}
+		err = f.Put("h1", rhist.NewH1DFrom(h))
+		if err != nil {
+			t.Fatalf("could not write histogram: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	var hkey, hseek int64 // offsets of the histogram key and of its payload
+	func() {
+		f, err := groot.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		for _, k := range f.Keys() {
+			if k.Name() == "h1" {
+				hkey = k.SeekKey()
+				hseek = hkey + int64(k.KeyLen())
+			}
+		}
+	}()
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	// the key header of the first basket holds its class name and the name
+	// of its branch.
+	bseek := int64(bytes.Index(raw, []byte("\x07TBasket\x03F64")))
+	if bseek < 0 {
+		t.Fatalf("could not find first basket")
+	}
+
+	o, err := os.OpenFile(fname, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer o.Close()
+
+	garbage := bytes.Repeat([]byte{0xff}, 32)
+	// corrupt the key header of the first basket of the tree.
+	_, err = o.WriteAt(garbage, bseek)
+	if err != nil {
+		t.Fatalf("could not corrupt basket: %+v", err)
+	}
+	// corrupt the compressed payload of the histogram.
+	_, err = o.WriteAt(garbage, hseek+32)
+	if err != nil {
+		t.Fatalf("could not corrupt histogram: %+v", err)
+	}
+	err = o.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		err := rcmd.Verify(out, fname)
+		if !errors.Is(err, rcmd.ErrCorrupted) {
+			t.Fatalf("expected a corruption error, got: %+v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if got, want := len(lines), 4; got != want {
+			t.Fatalf("invalid report:\n%s", out.String())
+		}
+		if got, want := lines[1], `key "tree;1" (TTree): branch "F64", basket 0 at offset `; !strings.HasPrefix(got, want) {
+			t.Fatalf("invalid basket report:\ngot= %s\nwant=%s", got, want)
+		}
+		if got, want := lines[2], `key "h1;1" (TH1D) at offset `; !strings.HasPrefix(got, want) {
+			t.Fatalf("invalid key report:\ngot= %s\nwant=%s", got, want)
+		}
+		if got, want := lines[3], "checked 2 keys, 1 trees and 12 baskets: 2 corrupted objects, 0 undecodable objects"; got != want {
+			t.Fatalf("invalid summary:\ngot= %s\nwant=%s\n%s", got, want, out.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		err := rcmd.Verify(out, fname, rcmd.VerifyJSON(true))
+		if !errors.Is(err, rcmd.ErrCorrupted) {
+			t.Fatalf("expected a corruption error, got: %+v", err)
+		}
+
+		var rep struct {
+			File      string `json:"file"`
+			Baskets   int    `json:"baskets"`
+			Corrupted []struct {
+				Key    string `json:"key"`
+				Seek   int64  `json:"seek"`
+				Basket *struct {
+					Branch  string   `json:"branch"`
+					Index   int      `json:"index"`
+					Entries [2]int64 `json:"entries"`
+				} `json:"basket"`
+				Err string `json:"error"`
+			} `json:"corrupted"`
+		}
+		err = json.Unmarshal(out.Bytes(), &rep)
+		if err != nil {
+			t.Fatalf("could not decode JSON report: %+v\n%s", err, out.String())
+		}
+
+		if got, want := len(rep.Corrupted), 2; got != want {
+			t.Fatalf("invalid number of corrupted objects: got=%d, want=%d", got, want)
+		}
+
+		bkt := rep.Corrupted[0]
+		if bkt.Key != "tree;1" || bkt.Seek <= 0 || bkt.Seek > bseek || bkt.Basket == nil ||
+			bkt.Basket.Branch != "F64" || bkt.Basket.Index != 0 ||
+			bkt.Basket.Entries != [2]int64{0, 9} || bkt.Err == "" {
+			t.Fatalf("invalid basket report: %+v", bkt)
+		}
+
+		key := rep.Corrupted[1]
+		if key.Key != "h1;1" || key.Seek != hkey || key.Basket != nil || key.Err == "" {
+			t.Fatalf("invalid key report: %+v", key)
+		}
+	})
+}
+
+func TestVerifyUndecodable(t *testing.T) {
+	const fname = "../testdata/streamers.root"
+
+	out := new(bytes.Buffer)
+	err := rcmd.Verify(out, fname)
+	if err != nil {
+		t.Fatalf("undecodable objects should not be reported as corrupted: %+v", err)
+	}
+	if got, want := out.String(), "checked 1 keys, 0 trees and 0 baskets: 0 corrupted objects, 1 undecodable objects\n"; !strings.HasSuffix(got, want) {
+		t.Fatalf("invalid report:\n%s", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 )
 
 func init() {
+	StreamerInfos.Add(NewCxxStreamerInfo("TAtt3D", 1, 0x757a, []rbytes.StreamerElement{}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TAttAxis", 4, 0x5c6fff3e, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
 			Name:   *rbase.NewNamed("fNdivisions", "Number of divisions(10000*n3 + 100*n2 + n1)"),
@@ -158,6 +159,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttBBox2D", 0, 0x2549fc, []rbytes.StreamerElement{}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TAttFill", 2, 0xffd92a92, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
 			Name:   *rbase.NewNamed("fFillColor", "Fill area color"),
@@ -268,6 +270,229 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttPad", 4, 0xa715f011, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeftMargin", "LeftMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRightMargin", "RightMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBottomMargin", "BottomMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTopMargin", "TopMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXfile", "X position where to draw the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYfile", "Y position where to draw the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAfile", "Alignment for the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXstat", "X position where to draw the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYstat", "Y position where to draw the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAstat", "Alignment for the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameFillColor", "Pad frame fill color"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameLineColor", "Pad frame line color"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameFillStyle", "Pad frame fill style"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameLineStyle", "Pad frame line style"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameLineWidth", "Pad frame line width"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameBorderSize", "Pad frame border size"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFrameBorderMode", "Pad frame border mode"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TDatime", 1, 0xb44671ee, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
 			Name:   *rbase.NewNamed("fDatime", "Date (relative to 1995) + time"),
@@ -436,6 +661,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TQObject", 1, 0x42e9c, []rbytes.StreamerElement{}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TRef", 1, 0x91757901, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
@@ -532,70 +758,137 @@ func init() {
 		}.New()},
 	}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TString", 2, 0x17419, []rbytes.StreamerElement{}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArray", 1, 0x7021b2, []rbytes.StreamerElement{
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fN", "Number of array elements"),
-			Type:   rmeta.Int,
-			Size:   4,
+	StreamerInfos.Add(NewCxxStreamerInfo("TVirtualPad", 3, 0x28ece7b9, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayC", 1, 0xae879936, []rbytes.StreamerElement{
+		}.New(), 1),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN chars"),
-			Type:   41,
-			Size:   1,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
-			EName:  "char*",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayS", 1, 0x35c9314, []rbytes.StreamerElement{
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TAttPad", "Pad attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1491734511, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN shorts"),
-			Type:   42,
-			Size:   2,
+		}.New(), 4),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TQObject", "Base class for object communication mechanism"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 274076, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArray", 1, 0x7021b2, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "Number of array elements"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayC", 1, 0xae879936, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN chars"),
+			Type:   41,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "char*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, "fN", "TArray"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayS", 1, 0x35c9314, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN shorts"),
+			Type:   42,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
 			EName:  "short*",
 			XMin:   0.000000,
@@ -2746,6 +3039,73 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1, 61),
 	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TF2", 4, 0x4e653cbf, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TF1", "The Parametric 1-D function"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1914961880, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 12),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmin", "Lower bound for the range in y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmax", "Upper bound for the range in y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpy", "Number of points along y used for the graphical representation"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContour", "Array to display contour levels"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TFormula", 13, 0x3d29ef01, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
@@ -3224,7 +3584,7 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1, 61),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1", 8, 0x1c3740c4, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TGraph2D", 1, 0x3f8d192e, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
@@ -3278,8 +3638,8 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNcells", "Number of bins(1D), cells (2D) +U/Overflows"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fNpoints", "Number of points in the data set"),
+			Type:   rmeta.Counter,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
@@ -3290,86 +3650,86 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXaxis", "X axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpx", "Number of bins along X in fHistogram"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYaxis", "Y axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpy", "Number of bins along Y in fHistogram"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZaxis", "Z axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaxIter", "Maximum number of iterations to find Delaunay triangles"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBarOffset", "(1000*offset) for bar charts or legos"),
-			Type:   rmeta.Short,
-			Size:   2,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fX", "[fNpoints]"),
+			Type:   48,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBarWidth", "(1000*width) for bar charts or legos"),
-			Type:   rmeta.Short,
-			Size:   2,
+		}.New(), 1, "fNpoints", "TGraph2D"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fY", "[fNpoints] Data set to be plotted"),
+			Type:   48,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
-			Type:   rmeta.Double,
+		}.New(), 1, "fNpoints", "TGraph2D"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fZ", "[fNpoints]"),
+			Type:   48,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fNpoints", "TGraph2D"),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumw", "Total Sum of weights"),
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along z"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3382,7 +3742,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumw2", "Total Sum of squares of weights"),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along z"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3395,7 +3755,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwx", "Total Sum of weight*X"),
+			Name:   *rbase.NewNamed("fMargin", "Extra space (in %) around interpolated area for fHistogram"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3408,7 +3768,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwx2", "Total Sum of weight*X*X"),
+			Name:   *rbase.NewNamed("fZout", "fHistogram bin height for points lying outside the interpolated area"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3420,347 +3780,259 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting"),
-			Type:   rmeta.Double,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting"),
-			Type:   rmeta.Double,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TGraph2DErrors", 1, 0x4f13ae71, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TGraph2D", "Set of n x[i],y[i],z[i] points with 3-d graphics including Delaunay triangulation"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1066211630, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNormFactor", "Normalization factor"),
-			Type:   rmeta.Double,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEX", "[fNpoints] array of X errors"),
+			Type:   48,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fContour", "Array to display contour levels"),
-			Type:   rmeta.Any,
-			Size:   24,
+		}.New(), 1, "fNpoints", "TGraph2D"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEY", "[fNpoints] array of Y errors"),
+			Type:   48,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSumw2", "Array of sum of squares of weights"),
-			Type:   rmeta.Any,
-			Size:   24,
+		}.New(), 1, "fNpoints", "TGraph2D"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEZ", "[fNpoints] array of Z errors"),
+			Type:   48,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "double*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOption", "Histogram options"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 1, "fNpoints", "TGraph2D"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1", 8, 0x1c3740c4, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TString",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFunctions", "->Pointer to list of functions (fits and user)"),
-			Type:   rmeta.Objectp,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TList*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "fBuffer size"),
-			Type:   rmeta.Counter,
-			Size:   4,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "int",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBuffer", "[fBufferSize] entry buffer"),
-			Type:   48,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "double*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 8, "fBufferSize", "TH1"),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinStatErrOpt", "Option for bin statistical errors"),
-			Type:   rmeta.Int,
-			Size:   4,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TH1::EBinErrorOpt",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStatOverflows", "Per object flag to use under/overflows in statistics"),
-			Type:   rmeta.Int,
-			Size:   4,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TH1::EStatOverflows",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1C", 3, 0x36f6e4ad, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New(), 1),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1D", 3, 0xf9b1569f, []rbytes.StreamerElement{
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1F", 3, 0xe2939644, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNcells", "Number of bins(1D), cells (2D) +U/Overflows"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXaxis", "X axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TAxis",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1I", 3, 0x627564f6, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYaxis", "Y axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TAxis",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZaxis", "Z axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TAxis",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1K", 2, 0xfd37ced5, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBarOffset", "(1000*offset) for bar charts or legos"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBarWidth", "(1000*width) for bar charts or legos"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNIn", ""),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1S", 3, 0x8c4d9dcb, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw", "Total Sum of weights"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw2", "Total Sum of squares of weights"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2", 5, 0x182347f, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwx", "Total Sum of weight*X"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fScalefactor", "Scale factor"),
+			Name:   *rbase.NewNamed("fTsumwx2", "Total Sum of weight*X*X"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3773,7 +4045,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3786,7 +4058,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3799,7 +4071,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
+			Name:   *rbase.NewNamed("fNormFactor", "Normalization factor"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -3811,21 +4083,125 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2C", 4, 0xbd0010fe, []rbytes.StreamerElement{
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContour", "Array to display contour levels"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSumw2", "Array of sum of squares of weights"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOption", "Histogram options"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "->Pointer to list of functions (fits and user)"),
+			Type:   rmeta.Objectp,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferSize", "fBuffer size"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBuffer", "[fBufferSize] entry buffer"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8, "fBufferSize", "TH1"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinStatErrOpt", "Option for bin statistical errors"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1::EBinErrorOpt",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStatOverflows", "Per object flag to use under/overflows in statistics"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1::EStatOverflows",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1C", 3, 0x36f6e4ad, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New(), 8),
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
 			Type:   rmeta.Base,
@@ -3840,20 +4216,20 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2D", 4, 0x7fba82f0, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1D", 3, 0xf9b1569f, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New(), 8),
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
 			Type:   rmeta.Base,
@@ -3868,20 +4244,20 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2F", 4, 0x689cc295, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1F", 3, 0xe2939644, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New(), 8),
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
 			Type:   rmeta.Base,
@@ -3896,20 +4272,20 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2I", 4, 0xe87e9147, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1I", 3, 0x627564f6, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New(), 8),
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
 			Type:   rmeta.Base,
@@ -3924,27 +4300,122 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2Poly", 3, 0xbc566968, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1K", 2, 0xfd37ced5, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOverflow", "Overflow bins"),
-			Type:   rmeta.Enum(28),
-			Size:   72,
-			ArrLen: 9,
-			ArrDim: 1,
-			MaxIdx: [5]int32{9, 0, 0, 0, 0},
+			Name:   *rbase.NewNamed("fNIn", ""),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1S", 3, 0x8c4d9dcb, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2", 5, 0x182347f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fScalefactor", "Scale factor"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
 			EName:  "double",
 			XMin:   0.000000,
@@ -3952,7 +4423,1673 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCellX", "Number of partition cells in the x-direction of the histogram"),
+			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2C", 4, 0xbd0010fe, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2D", 4, 0x7fba82f0, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2F", 4, 0x689cc295, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2I", 4, 0xe87e9147, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2Poly", 3, 0xbc566968, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOverflow", "Overflow bins"),
+			Type:   rmeta.Enum(28),
+			Size:   72,
+			ArrLen: 9,
+			ArrDim: 1,
+			MaxIdx: [5]int32{9, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCellX", "Number of partition cells in the x-direction of the histogram"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCellY", "Number of partition cells in the y-direction of the histogram"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNCells", "Number of partition cells: fCellX*fCellY"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerLoop(Element{
+			Name:   *rbase.NewNamed("fCells", "[fNCells] The array of TLists that store the bins that intersect with each cell. List do not own the contained objects"),
+			Type:   rmeta.StreamLoop,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStepX", "Dimensions of a partition cell"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStepY", "Dimensions of a partition cell"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIsEmpty", "[fNCells] The array that returns true if the cell at the given coordinate is empty"),
+			Type:   58,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fCompletelyInside", "[fNCells] The array that returns true if the cell at the given coordinate is completely inside a bin"),
+			Type:   58,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFloat", "When set to kTRUE, allows the histogram to expand if a bin outside the limits is added."),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBins", "List of bins. The list owns the contained objects"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2PolyBin", 1, 0x8fb3de7c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fChanged", "For the 3D Painter"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNumber", "Bin number of the bin in TH2Poly"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPoly", "Object holding the polygon definition"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObject*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fArea", "Bin area"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContent", "Bin content"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmin", "X minimum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmin", "Y minimum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmax", "X maximum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmax", "Y maximum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2S", 4, 0x1256ca1c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3", 6, 0x8e8a4157, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAtt3D", "3D attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 30074, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz", "Total Sum of weight*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz2", "Total Sum of weight*Z*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwxz", "Total Sum of weight*X*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwyz", "Total Sum of weight*Y*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3D", 4, 0x7f83cc71, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3F", 4, 0xadb2efa5, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3I", 4, 0xf2f9a473, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THStack", 2, 0x725e8515, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHists", "Pointer to array of TH1"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLimit", 2, 0x785f, []rbytes.StreamerElement{}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLimitDataSource", 2, 0x20f07d45, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSignal", "Packed input signal"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBackground", "Packed input background"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCandidates", "Packed input candidates (data)"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fErrorOnSignal", "Packed error sources for signal"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fErrorOnBackground", "Packed error sources for background"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIds", "Packed IDs for the different error sources"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDummyTA", "Array of dummy object (used for bookeeping)"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDummyIds", "Array of dummy object (used for bookeeping)"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TMultiGraph", 2, 0xe0893cd5, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGraphs", "Pointer to list of TGraphs"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1F*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TProfile", 7, 0x4bedee54, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1D", "1-Dim histograms (one double per channel)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -105818465, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinEntries", "number of entries per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "EErrorType",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmin", "Lower limit in Y (if set)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmax", "Upper limit in Y (if set)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TProfile2D", 8, 0x36a142ac, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2D", "2-Dim histograms (one double per channel)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 2142929648, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinEntries", "Number of entries per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "EErrorType",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZmin", "Lower limit in Z (if set)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZmax", "Upper limit in Z (if set)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz", "Total Sum of weight*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz2", "Total Sum of weight*Z*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TDirectory", 5, 0x1e9b6f70, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMother", "pointer to mother of the directory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObject*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fList", "List of objects in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUUID", "Unique identifier"),
+			Type:   rmeta.Any,
+			Size:   32,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TUUID",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TDirectoryFile", 5, 0xbf9f443e, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TDirectory", ""),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 513503088, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fModified", "True if directory has been modified"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWritable", "True if directory is writable"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatimeC", "Date and time when directory is created"),
+			Type:   rmeta.Any,
+			Size:   16,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TDatime",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatimeM", "Date and time of last modification"),
+			Type:   rmeta.Any,
+			Size:   16,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TDatime",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesKeys", "Number of bytes for the keys"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesName", "Number of bytes in TNamed at creation time"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferSize", "Default buffer size to create new TKeys"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekDir", "Location of directory on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekParent", "Location of parent directory on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekKeys", "Location of Keys record on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFile", "Pointer to current file in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TFile*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fKeys", "Pointer to keys list in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TFile", 8, 0x9468e382, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TDirectoryFile", ""),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1080081346, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSumBuffer", "Sum of buffer sizes of objects written so far"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSum2Buffer", "Sum of squares of buffer sizes of objects written so far"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBytesWrite", "Number of bytes written to this file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBytesRead", "Number of bytes read from this file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBytesReadExtra", "Number of extra bytes (overhead) read by the readahead buffer"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBEGIN", "First used byte in file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEND", "Last used byte in file"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekFree", "Location on disk of free segments structure"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekInfo", "Location on disk of StreamerInfo record"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fD", "File descriptor"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVersion", "File format version"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesFree", "Number of bytes for free segments structure"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesInfo", "Number of bytes for StreamerInfo record"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWritten", "Number of objects written so far"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNProcessIDs", "Number of TProcessID written to this file"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fReadCalls", "Number of read calls ( not counting the cache calls )"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRealName", "Effective real file name (not original url)"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOption", "File options"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUnits", "Number of bytes for file pointers"),
+			Type:   rmeta.Char,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "char",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFree", "Free segments linked list table"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TKey", 4, 0x2d353cd7, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVersion", "Key version identifier"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytes", "Number of bytes for the object on file"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3965,7 +6102,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCellY", "Number of partition cells in the y-direction of the histogram"),
+			Name:   *rbase.NewNamed("fObjlen", "Length of uncompressed object in bytes"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3977,112 +6114,125 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatime", "Date/Time of insertion in file"),
+			Type:   rmeta.Any,
+			Size:   16,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TDatime",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNCells", "Number of partition cells: fCellX*fCellY"),
-			Type:   rmeta.Counter,
-			Size:   4,
+			Name:   *rbase.NewNamed("fKeylen", "Number of bytes for the key itself"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerLoop(Element{
-			Name:   *rbase.NewNamed("fCells", "[fNCells] The array of TLists that store the bins that intersect with each cell. List do not own the contained objects"),
-			Type:   rmeta.StreamLoop,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCycle", "Cycle number"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStepX", "Dimensions of a partition cell"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fSeekKey", "Location of object on file"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStepY", "Dimensions of a partition cell"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fSeekPdir", "Location of parent directory on file"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fIsEmpty", "[fNCells] The array that returns true if the cell at the given coordinate is empty"),
-			Type:   58,
-			Size:   1,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassName", "Object Class name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fCompletelyInside", "[fNCells] The array that returns true if the cell at the given coordinate is completely inside a bin"),
-			Type:   58,
-			Size:   1,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeft", "Number of bytes left in current segment"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFloat", "When set to kTRUE, allows the histogram to expand if a bin outside the limits is added."),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fBuffer", "Object buffer"),
+			Type:   rmeta.CharStar,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "char*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBins", "List of bins. The list owns the contained objects"),
+			Name:   *rbase.NewNamed("fBufferRef", "Pointer to the TBuffer object"),
 			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "TBuffer*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2PolyBin", 1, 0x8fb3de7c, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TFeldmanCousins", 1, 0xebbf41df, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
@@ -4097,46 +6247,46 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fChanged", "For the 3D Painter"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fCL", "confidence level as a fraction [e.g. 90% = 0.9]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNumber", "Bin number of the bin in TH2Poly"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fUpperLimit", "the calculated upper limit"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fPoly", "Object holding the polygon definition"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLowerLimit", "the calculated lower limit"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObject*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fArea", "Bin area"),
+			Name:   *rbase.NewNamed("fNobserved", "input number of observed events"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -4149,7 +6299,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fContent", "Bin content"),
+			Name:   *rbase.NewNamed("fNbackground", "input number of background events"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -4162,7 +6312,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmin", "X minimum value"),
+			Name:   *rbase.NewNamed("fMuMin", "minimum value of signal to use in calculating the tables"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -4175,7 +6325,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmin", "Y minimum value"),
+			Name:   *rbase.NewNamed("fMuMax", "maximum value of signal to use in calculating the tables"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -4188,7 +6338,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmax", "X maximum value"),
+			Name:   *rbase.NewNamed("fMuStep", "the step in signal to use when generating tables"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -4201,49 +6351,46 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmax", "Y maximum value"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNMuStep", "= (int)(fMuStep)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2S", 4, 0x1256ca1c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNMax", "= (int)(fMuMax)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fQUICK", "take a short cut to speed up the process of generating a"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLimit", 2, 0x785f, []rbytes.StreamerElement{}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLimitDataSource", 2, 0x20f07d45, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLorentzVector", 4, 0xe3dec1a1, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
@@ -4258,537 +6405,617 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSignal", "Packed input signal"),
+			Name:   *rbase.NewNamed("fP", "3 vector component"),
 			Type:   rmeta.Object,
-			Size:   64,
+			Size:   40,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "TVector3",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBackground", "Packed input background"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fE", "time or energy of (x,y,z,t) or (px,py,pz,e)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCandidates", "Packed input candidates (data)"),
-			Type:   rmeta.Object,
-			Size:   64,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TVector2", 3, 0x89b7f4, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fX", "components of the vector"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fErrorOnSignal", "Packed error sources for signal"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fY", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fErrorOnBackground", "Packed error sources for background"),
-			Type:   rmeta.Object,
-			Size:   64,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TVector3", 3, 0xabb6be1e, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fX", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIds", "Packed IDs for the different error sources"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fY", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDummyTA", "Array of dummy object (used for bookeeping)"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZ", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDummyIds", "Array of dummy object (used for bookeeping)"),
-			Type:   rmeta.Object,
-			Size:   64,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("ROOT::TIOFeatures", 1, 0x1aa12f10, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIOBits", ""),
+			Type:   rmeta.UChar,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "unsigned char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TMultiGraph", 2, 0xe0893cd5, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TBasket", 3, 0xbbf717e7, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Name:   *rbase.NewNamed("TKey", "Header description of a logical record on file."),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 758463703, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fGraphs", "Pointer to list of TGraphs"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 4),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferSize", "fBuffer length in bytes"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNevBufSize", "Length in Int_t of fEntryOffset OR fixed length of each entry if fEntryOffset is null!"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNevBuf", "Number of entries in basket"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TH1F*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLast", "Pointer to last used byte in basket"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fHeaderOnly", "True when only the basket header must be read/written"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEntryOffset", "[fNevBuf] Offset of entries in fBuffer(TKey); generated at runtime.  Special value"),
+			Type:   43,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNevBuf", "TBasket"),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranch", "Pointer to the basket support branch"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TBranch*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TProfile", 7, 0x4bedee54, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranch", 13, 0x10978aac, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1D", "1-Dim histograms (one double per channel)"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -105818465, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3),
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinEntries", "number of entries per bin"),
-			Type:   rmeta.Any,
-			Size:   24,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "EErrorType",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmin", "Lower limit in Y (if set)"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fBasketSize", "Initial Size of  Basket Buffer"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmax", "Upper limit in Y (if set)"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fWriteBasket", "Last basket number written"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fEntryNumber", "Current entry number (last one filled in this branch)"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
+			Name:   *rbase.NewNamed("fIOFeatures", "IO features for newly-created baskets."),
 			Type:   rmeta.Any,
-			Size:   24,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "ROOT::TIOFeatures",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TProfile2D", 8, 0x36a142ac, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2D", "2-Dim histograms (one double per channel)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOffset", "Offset of this branch"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 2142929648, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinEntries", "Number of entries per bin"),
-			Type:   rmeta.Any,
-			Size:   24,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaxBaskets", "Maximum number of Baskets so far"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Name:   *rbase.NewNamed("fSplitLevel", "Branch split level"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "EErrorType",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZmin", "Lower limit in Z (if set)"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZmax", "Upper limit in Z (if set)"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fFirstEntry", "Number of the first entry in this branch"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwz", "Total Sum of weight*Z"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all leaves before compression"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwz2", "Total Sum of weight*Z*Z"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all leaves after compression"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
-			Type:   rmeta.Any,
-			Size:   24,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranches", "-> List of Branches of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TDirectory", 5, 0x1e9b6f70, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeaves", "-> List of leaves of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBaskets", "-> List of baskets of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketBytes", "[fMaxBaskets] Length of baskets on file"),
+			Type:   43,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMother", "pointer to mother of the directory"),
-			Type:   rmeta.ObjectP,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketEntry", "[fMaxBaskets] Table of first entry in each basket"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObject*",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fList", "List of objects in memory"),
-			Type:   rmeta.ObjectP,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketSeek", "[fMaxBaskets] Addresses of baskets on file"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUUID", "Unique identifier"),
-			Type:   rmeta.Any,
-			Size:   32,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFileName", "Name of file where buffers are stored (\"\" if in same file as Tree header)"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TUUID",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TDirectoryFile", 5, 0xbf9f443e, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranchElement", 10, 0xe74f5e63, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TDirectory", ""),
+			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 513503088, 0, 0, 0},
+			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fModified", "True if directory has been modified"),
-			Type:   rmeta.Bool,
-			Size:   1,
+		}.New(), 13),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWritable", "True if directory is writable"),
-			Type:   rmeta.Bool,
-			Size:   1,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fParentName", "Name of parent class"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatimeC", "Date and time when directory is created"),
-			Type:   rmeta.Any,
-			Size:   16,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClonesName", "Name of class in TClonesArray (if any)"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TDatime",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatimeM", "Date and time of last modification"),
-			Type:   rmeta.Any,
-			Size:   16,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCheckSum", "CheckSum of class"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TDatime",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesKeys", "Number of bytes for the keys"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fClassVersion", "Version number of class"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesName", "Number of bytes in TNamed at creation time"),
+			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4801,7 +7028,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "Default buffer size to create new TKeys"),
+			Name:   *rbase.NewNamed("fType", "Branch type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4814,295 +7041,329 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekDir", "Location of directory on file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fStreamerType", "branch streamer type"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekParent", "Location of parent directory on file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum entries for a TClonesArray or variable array"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekKeys", "Location of Keys record on file"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranchCount", "pointer to primary branchcount branch"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TBranchElement*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFile", "Pointer to current file in memory"),
+			Name:   *rbase.NewNamed("fBranchCount2", "pointer to secondary branchcount branch"),
 			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TFile*",
+			EName:  "TBranchElement*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fKeys", "Pointer to keys list in memory"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranchObject", 1, 0xa4720f49, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 13),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TFile", 8, 0x9468e382, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranchRef", 1, 0x2360b3fd, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TDirectoryFile", ""),
+			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1080081346, 0, 0, 0},
+			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSumBuffer", "Sum of buffer sizes of objects written so far"),
-			Type:   rmeta.Double,
+		}.New(), 13),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRefTable", "pointer to the TRefTable"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TRefTable*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSum2Buffer", "Sum of squares of buffer sizes of objects written so far"),
-			Type:   rmeta.Double,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TChain", 5, 0x3a9ec568, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesWrite", "Number of bytes written to this file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fTreeOffsetLen", "Current size of fTreeOffset array"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesRead", "Number of bytes read from this file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNtrees", "Number of trees"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesReadExtra", "Number of extra bytes (overhead) read by the readahead buffer"),
-			Type:   rmeta.Long64,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fTreeOffset", "[fTreeOffsetLen] Array of variables"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBEGIN", "First used byte in file"),
-			Type:   rmeta.Long64,
+		}.New(), 5, "fTreeOffsetLen", "TChain"),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFiles", "-> List of file names containing the trees (TChainElement, owned)"),
+			Type:   rmeta.Objectp,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TObjArray*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEND", "Last used byte in file"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStatus", "-> List of active/inactive branches (TChainElement, owned)"),
+			Type:   rmeta.Objectp,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekFree", "Location on disk of free segments structure"),
-			Type:   rmeta.Long64,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TEntryList", 2, 0x2cd389ec, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekInfo", "Location on disk of StreamerInfo record"),
-			Type:   rmeta.Long64,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLists", "a list of underlying entry lists for each tree of a chain"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fD", "File descriptor"),
+			Name:   *rbase.NewNamed("fNBlocks", "number of TEntryListBlocks"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBlocks", "blocks with indices of passing events (TEntryListBlocks)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fVersion", "File format version"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fN", "number of entries in the list"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fEntriesToProcess", "used on proof to set the number of entries to process in a packet"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesFree", "Number of bytes for free segments structure"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTreeName", "name of the tree"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesInfo", "Number of bytes for StreamerInfo record"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFileName", "name of the file, where the tree is"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWritten", "Number of objects written so far"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fReapply", "If true, TTree::Draw will 'reapply' the original cut"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNProcessIDs", "Number of TProcessID written to this file"),
-			Type:   rmeta.Int,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TEntryListBlock", 1, 0xc1c130ee, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fReadCalls", "Number of read calls ( not counting the cache calls )"),
+			Name:   *rbase.NewNamed("fNPassed", "number of entries in the entry list (if fPassing=0 - number of entries not in the entry list"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5114,60 +7375,60 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fRealName", "Effective real file name (not original url)"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "size of fIndices for I/O  =fNPassed for list, fBlockSize for bits"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOption", "File options"),
-			Type:   rmeta.TString,
-			Size:   24,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndices", "[fN]"),
+			Type:   52,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "unsigned short*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TEntryListBlock"),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUnits", "Number of bytes for file pointers"),
-			Type:   rmeta.Char,
-			Size:   1,
+			Name:   *rbase.NewNamed("fType", "0 - bits, 1 - list"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "char",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFree", "Free segments linked list table"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPassing", "1 - stores entries that belong to the list"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TKey", 4, 0x2d353cd7, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeaf", 2, 0x6d1e8152, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
@@ -5182,7 +7443,7 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fVersion", "Key version identifier"),
+			Name:   *rbase.NewNamed("fLen", "Number of fixed length elements in the leaf's data."),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5195,7 +7456,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytes", "Number of bytes for the object on file"),
+			Name:   *rbase.NewNamed("fLenType", "Number of bytes for this data type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5208,7 +7469,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fObjlen", "Length of uncompressed object in bytes"),
+			Name:   *rbase.NewNamed("fOffset", "Offset in ClonesArray object (if one)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5220,86 +7481,75 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatime", "Date/Time of insertion in file"),
-			Type:   rmeta.Any,
-			Size:   16,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TDatime",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fKeylen", "Number of bytes for the key itself"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fIsRange", "(=kTRUE if leaf has a range, kFALSE otherwise).  This is equivalent to being a 'leafcount'.  For a TLeafElement the range information is actually store in the TBranchElement."),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCycle", "Cycle number"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fIsUnsigned", "(=kTRUE if unsigned, kFALSE otherwise)"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekKey", "Location of object on file"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeafCount", "Pointer to Leaf count if variable length (we do not own the counter)"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TLeaf*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekPdir", "Location of parent directory on file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafElement", 1, 0xa04f8893, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassName", "Object Class name"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeft", "Number of bytes left in current segment"),
+			Name:   *rbase.NewNamed("fType", "leaf type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5311,153 +7561,187 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBuffer", "Object buffer"),
-			Type:   rmeta.CharStar,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafObject", 4, 0x26ba7c4c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
-			EName:  "char*",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferRef", "Pointer to the TBuffer object"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVirtual", "Support for polymorphism, when set classname is written with object."),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBuffer*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TFeldmanCousins", 1, 0xebbf41df, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafO", 1, 0x2ae48d3, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCL", "confidence level as a fraction [e.g. 90% = 0.9]"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUpperLimit", "the calculated upper limit"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafB", 1, 0xf1e4b5e, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLowerLimit", "the calculated lower limit"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Char,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNobserved", "input number of observed events"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Char,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "char",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafS", 1, 0x150ceecf, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbackground", "input number of background events"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuMin", "minimum value of signal to use in calculating the tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuMax", "maximum value of signal to use in calculating the tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafI", 1, 0x7e6aae19, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuStep", "the step in signal to use when generating tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNMuStep", "= (int)(fMuStep)"),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5469,90 +7753,105 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafL", 1, 0xde320862, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNMax", "= (int)(fMuMax)"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fQUICK", "take a short cut to speed up the process of generating a"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLorentzVector", 4, 0xe3dec1a1, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafF", 1, 0x3add9d72, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fP", "3 vector component"),
-			Type:   rmeta.Object,
-			Size:   40,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TVector3",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fE", "time or energy of (x,y,z,t) or (px,py,pz,e)"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TVector2", 3, 0x89b7f4, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafD", 1, 0x118e8776, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fX", "components of the vector"),
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -5565,7 +7864,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fY", ""),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -5578,91 +7877,104 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TVector3", 3, 0xabb6be1e, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafF16", 1, 0xeb35f883, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fX", ""),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Float16,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Float16_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fY", ""),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Float16,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Float16_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafD32", 1, 0xe1dc6401, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZ", ""),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Type:   rmeta.Double32,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Double32_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("ROOT::TIOFeatures", 1, 0x1aa12f10, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIOBits", ""),
-			Type:   rmeta.UChar,
-			Size:   1,
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Type:   rmeta.Double32,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char",
+			EName:  "Double32_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBasket", 3, 0xbbf717e7, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TLeafC", 1, 0xfbe3b2f3, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TKey", "Header description of a logical record on file."),
+			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 758463703, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "fBuffer length in bytes"),
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5675,7 +7987,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNevBufSize", "Length in Int_t of fEntryOffset OR fixed length of each entry if fEntryOffset is null!"),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5687,21 +7999,23 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNevBuf", "Number of entries in basket"),
-			Type:   rmeta.Counter,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TNtuple", 2, 0xb8a51cab, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLast", "Pointer to last used byte in basket"),
+			Name:   *rbase.NewNamed("fNvar", "Number of columns"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5713,67 +8027,82 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TNtupleD", 1, 0x8de8d873, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fHeaderOnly", "True when only the basket header must be read/written"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fNvar", "Number of columns"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEntryOffset", "[fNevBuf] Offset of entries in fBuffer(TKey); generated at runtime.  Special value"),
-			Type:   43,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TTree", 20, 0x7264e07f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
-			EName:  "int*",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNevBuf", "TBasket"),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranch", "Pointer to the basket support branch"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranch*",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranch", 13, 0x10978aac, []rbytes.StreamerElement{
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
@@ -5781,59 +8110,59 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBasketSize", "Initial Size of  Basket Buffer"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all branches before compression"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all branches after compression"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWriteBasket", "Last basket number written"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fSavedBytes", "Number of autosaved bytes"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntryNumber", "Current entry number (last one filled in this branch)"),
+			Name:   *rbase.NewNamed("fFlushedBytes", "Number of auto-flushed bytes"),
 			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
@@ -5845,21 +8174,21 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIOFeatures", "IO features for newly-created baskets."),
-			Type:   rmeta.Any,
-			Size:   1,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWeight", "Tree weight (see TTree::SetWeight)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "ROOT::TIOFeatures",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOffset", "Offset of this branch"),
+			Name:   *rbase.NewNamed("fTimerInterval", "Timer interval in milliseconds"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5872,8 +8201,8 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxBaskets", "Maximum number of Baskets so far"),
-			Type:   rmeta.Counter,
+			Name:   *rbase.NewNamed("fScanField", "Number of runs before prompting in Scan"),
+			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
@@ -5885,7 +8214,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSplitLevel", "Branch split level"),
+			Name:   *rbase.NewNamed("fUpdate", "Update frequency for EntryLoop"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -5898,33 +8227,33 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fDefaultEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFirstEntry", "Number of the first entry in this branch"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNClusterRange", "Number of Cluster range in addition to the one defined by 'AutoFlush'"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all leaves before compression"),
+			Name:   *rbase.NewNamed("fMaxEntries", "Maximum number of entries in case of circular buffers"),
 			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
@@ -5937,7 +8266,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all leaves after compression"),
+			Name:   *rbase.NewNamed("fMaxEntryLoop", "Maximum number of entries to process"),
 			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
@@ -5949,60 +8278,60 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranches", "-> List of Branches of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaxVirtualSize", "Maximum total size of buffers kept in memory"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeaves", "-> List of leaves of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAutoSave", "Autosave tree when fAutoSave entries written or -fAutoSave (compressed) bytes produced"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBaskets", "-> List of baskets of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAutoFlush", "Auto-flush tree when fAutoFlush entries written or -fAutoFlush (compressed) bytes produced"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketBytes", "[fMaxBaskets] Length of baskets on file"),
-			Type:   43,
-			Size:   4,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEstimate", "Number of entries to estimate histogram limits"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int*",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
+		}.New()},
 		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketEntry", "[fMaxBaskets] Table of first entry in each basket"),
+			Name:   *rbase.NewNamed("fClusterRangeEnd", "[fNClusterRange] Last entry of a cluster range."),
 			Type:   56,
 			Size:   8,
 			ArrLen: 0,
@@ -6013,9 +8342,9 @@ func init() {
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
+		}.New(), 20, "fNClusterRange", "TTree"),
 		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketSeek", "[fMaxBaskets] Addresses of baskets on file"),
+			Name:   *rbase.NewNamed("fClusterSize", "[fNClusterRange] Number of entries in each cluster for a given range."),
 			Type:   56,
 			Size:   8,
 			ArrLen: 0,
@@ -6026,424 +8355,447 @@ func init() {
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFileName", "Name of file where buffers are stored (\"\" if in same file as Tree header)"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 20, "fNClusterRange", "TTree"),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIOFeatures", "IO features to define for newly-written baskets and branches."),
+			Type:   rmeta.Any,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "ROOT::TIOFeatures",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranchElement", 10, 0xe74f5e63, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranches", "List of Branches"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeaves", "Direct pointers to individual branch leaves"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fParentName", "Name of parent class"),
-			Type:   rmeta.TString,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAliases", "List of aliases for expressions based on the tree branches."),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIndexValues", "Sorted index values"),
+			Type:   rmeta.Any,
 			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TArrayD",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClonesName", "Name of class in TClonesArray (if any)"),
-			Type:   rmeta.TString,
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIndex", "Index of sorted values"),
+			Type:   rmeta.Any,
 			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TArrayI",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCheckSum", "CheckSum of class"),
-			Type:   rmeta.UInt,
-			Size:   4,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTreeIndex", "Pointer to the tree Index (if any)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "TVirtualIndex*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassVersion", "Version number of class"),
-			Type:   rmeta.Short,
-			Size:   2,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFriends", "pointer to list of friend elements"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUserInfo", "pointer to a list of user objects associated to this Tree"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fType", "Branch type"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranchRef", "Branch supporting the TRefTable (if any)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TBranchRef*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStreamerType", "branch streamer type"),
-			Type:   rmeta.Int,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TTreeIndex", 2, 0x3a3dd7c6, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TVirtualIndex", "Abstract interface for Tree Index"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1331234547, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMajorName", "Index major name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum entries for a TClonesArray or variable array"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinorName", "Index minor name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranchCount", "pointer to primary branchcount branch"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "Number of entries"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranchElement*",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranchCount2", "pointer to secondary branchcount branch"),
-			Type:   rmeta.ObjectP,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndexValues", "[fN] Sorted index values, higher 64bits"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranchElement*",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranchObject", 1, 0xa4720f49, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New(), 2, "fN", "TTreeIndex"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndexValuesMinor", "[fN] Sorted index values, lower 64bits"),
+			Type:   56,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 2, "fN", "TTreeIndex"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndex", "[fN] Index of sorted values"),
+			Type:   56,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 2, "fN", "TTreeIndex"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranchRef", 1, 0x2360b3fd, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TVirtualIndex", 1, 0xb0a6f90d, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fRefTable", "pointer to the TRefTable"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 1),
+	}))
+
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttCanvas", 1, 0xf676633f, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXBetween", "X distance between pads"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TRefTable*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TChain", 5, 0x3a9ec568, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTreeOffsetLen", "Current size of fTreeOffset array"),
-			Type:   rmeta.Counter,
+			Name:   *rbase.NewNamed("fYBetween", "Y distance between pads"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNtrees", "Number of trees"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fTitleFromTop", "Y distance of Global Title from top"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fTreeOffset", "[fTreeOffsetLen] Array of variables"),
-			Type:   56,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXdate", "X position where to draw the date"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5, "fTreeOffsetLen", "TChain"),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFiles", "-> List of file names containing the trees (TChainElement, owned)"),
-			Type:   rmeta.Objectp,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYdate", "X position where to draw the date"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStatus", "-> List of active/inactive branches (TChainElement, owned)"),
-			Type:   rmeta.Objectp,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAdate", "Alignment for the date"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeaf", 2, 0x6d1e8152, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TCanvas", 8, 0xddd3c85, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Name:   *rbase.NewNamed("TPad", "A Graphics pad"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 325755298, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLen", "Number of fixed length elements in the leaf's data."),
-			Type:   rmeta.Int,
-			Size:   4,
+		}.New(), 13),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCatt", "Canvas attributes"),
+			Type:   rmeta.Any,
+			Size:   32,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TAttCanvas",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDISPLAY", "Name of destination screen"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLenType", "Number of bytes for this data type"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fXsizeUser", "User specified size of canvas along X in CM"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOffset", "Offset in ClonesArray object (if one)"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fYsizeUser", "User specified size of canvas along Y in CM"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIsRange", "(=kTRUE if leaf has a range, kFALSE otherwise).  This is equivalent to being a 'leafcount'.  For a TLeafElement the range information is actually store in the TBranchElement."),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fXsizeReal", "Current size of canvas along X in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIsUnsigned", "(=kTRUE if unsigned, kFALSE otherwise)"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fYsizeReal", "Current size of canvas along Y in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeafCount", "Pointer to Leaf count if variable length (we do not own the counter)"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHighLightColor", "Highlight color of active pad"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TLeaf*",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafElement", 1, 0xa04f8893, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDoubleBuffer", "Double buffer flag (0=off, 1=on)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
+			Name:   *rbase.NewNamed("fWindowTopX", "Top X position of window (in pixels)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -6456,7 +8808,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fType", "leaf type"),
+			Name:   *rbase.NewNamed("fWindowTopY", "Top Y position of window (in pixels)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -6468,64 +8820,60 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafObject", 4, 0x26ba7c4c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWindowWidth", "Width of window (including borders, etc.)"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fVirtual", "Support for polymorphism, when set classname is written with object."),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fWindowHeight", "Height of window (including menubar, borders, etc.)"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafO", 1, 0x2ae48d3, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCw", "Width of the canvas along X (pixels)"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fCh", "Height of the canvas along Y (pixels)"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Name:   *rbase.NewNamed("fRetained", "Retain structure flag"),
 			Type:   rmeta.Bool,
 			Size:   1,
 			ArrLen: 0,
@@ -6538,227 +8886,217 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafB", 1, 0xf1e4b5e, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TPad", 13, 0x136aa1a2, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
+			Name:   *rbase.NewNamed("TVirtualPad", "Abstract base class for Pads and Canvases"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 686614457, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttBBox2D", "2D bounding box attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 2443772, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New(), 0),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Char,
-			Size:   1,
+			Name:   *rbase.NewNamed("fX1", "X of lower X coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "char",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Char,
-			Size:   1,
+			Name:   *rbase.NewNamed("fY1", "Y of lower Y coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "char",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafS", 1, 0x150ceecf, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fX2", "X of upper X coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fY2", "Y of upper Y coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafI", 1, 0x7e6aae19, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXtoAbsPixelk", "Conversion coefficient for X World to absolute pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fXtoPixelk", "Conversion coefficient for X World to pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fXtoPixel", "xpixel = fXtoPixelk + fXtoPixel*xworld"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafL", 1, 0xde320862, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYtoAbsPixelk", "Conversion coefficient for Y World to absolute pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fYtoPixelk", "Conversion coefficient for Y World to pixel"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fYtoPixel", "ypixel = fYtoPixelk + fYtoPixel*yworld"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafF", 1, 0x3add9d72, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUtoAbsPixelk", "Conversion coefficient for U NDC to absolute pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Float,
-			Size:   4,
+			Name:   *rbase.NewNamed("fUtoPixelk", "Conversion coefficient for U NDC to pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "float",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Float,
-			Size:   4,
+			Name:   *rbase.NewNamed("fUtoPixel", "xpixel = fUtoPixelk + fUtoPixel*undc"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "float",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafD", 1, 0x118e8776, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVtoAbsPixelk", "Conversion coefficient for V NDC to absolute pixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
+			Name:   *rbase.NewNamed("fVtoPixelk", "Conversion coefficient for V NDC to pixel"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -6771,7 +9109,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
+			Name:   *rbase.NewNamed("fVtoPixel", "ypixel = fVtoPixelk + fVtoPixel*vndc"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -6783,306 +9121,294 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafF16", 1, 0xeb35f883, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsPixeltoXk", "Conversion coefficient for absolute pixel to X World"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Float16,
-			Size:   4,
+			Name:   *rbase.NewNamed("fPixeltoXk", "Conversion coefficient for pixel to X World"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Float16_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Float16,
-			Size:   4,
+			Name:   *rbase.NewNamed("fPixeltoX", "xworld = fPixeltoXk + fPixeltoX*xpixel"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Float16_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafD32", 1, 0xe1dc6401, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsPixeltoYk", "Conversion coefficient for absolute pixel to Y World"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Double32,
+			Name:   *rbase.NewNamed("fPixeltoYk", "Conversion coefficient for pixel to Y World"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Double32_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Double32,
+			Name:   *rbase.NewNamed("fPixeltoY", "yworld = fPixeltoYk + fPixeltoY*ypixel"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Double32_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLeafC", 1, 0xfbe3b2f3, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TLeaf", "Leaf: description of a Branch data type"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXlowNDC", "X bottom left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1830715730, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value if leaf range is specified"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fYlowNDC", "Y bottom left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value if leaf range is specified"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fXUpNDC", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TNtuple", 2, 0xb8a51cab, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYUpNDC", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 20),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNvar", "Number of columns"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fWNDC", "Width of pad along X in Normalized Coordinates (NDC)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TNtupleD", 1, 0x8de8d873, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHNDC", "Height of pad along Y in Normalized Coordinates (NDC)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 20),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNvar", "Number of columns"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fAbsXlowNDC", "Absolute X top left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TTree", 20, 0x7264e07f, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsYlowNDC", "Absolute Y top left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsWNDC", "Absolute Width of pad along X in NDC"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsHNDC", "Absolute Height of pad along Y in NDC"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUxmin", "Minimum value on the X axis"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fUymin", "Minimum value on the Y axis"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all branches before compression"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fUxmax", "Maximum value on the X axis"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all branches after compression"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fUymax", "Maximum value on the Y axis"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSavedBytes", "Number of autosaved bytes"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fTheta", "theta angle to view as lego/surface"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFlushedBytes", "Number of auto-flushed bytes"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fPhi", "phi angle   to view as lego/surface"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWeight", "Tree weight (see TTree::SetWeight)"),
+			Name:   *rbase.NewNamed("fAspectRatio", "ratio of w/h in case of fixed ratio"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -7095,7 +9421,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimerInterval", "Timer interval in milliseconds"),
+			Name:   *rbase.NewNamed("fNumber", "pad number identifier"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -7108,7 +9434,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fScanField", "Number of runs before prompting in Scan"),
+			Name:   *rbase.NewNamed("fTickx", "Set to 1 if tick marks along X"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -7121,7 +9447,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUpdate", "Update frequency for EntryLoop"),
+			Name:   *rbase.NewNamed("fTicky", "Set to 1 if tick marks along Y"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -7134,7 +9460,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDefaultEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
+			Name:   *rbase.NewNamed("fLogx", "(=0 if X linear scale, =1 if log scale)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -7147,8 +9473,8 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNClusterRange", "Number of Cluster range in addition to the one defined by 'AutoFlush'"),
-			Type:   rmeta.Counter,
+			Name:   *rbase.NewNamed("fLogy", "(=0 if Y linear scale, =1 if log scale)"),
+			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
@@ -7160,239 +9486,238 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxEntries", "Maximum number of entries in case of circular buffers"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLogz", "(=0 if Z linear scale, =1 if log scale)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxEntryLoop", "Maximum number of entries to process"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fPadPaint", "Set to 1 while painting the pad"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxVirtualSize", "Maximum total size of buffers kept in memory"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fCrosshair", "Crosshair type (0 if no crosshair requested)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fAutoSave", "Autosave tree when fAutoSave entries written or -fAutoSave (compressed) bytes produced"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fCrosshairPos", "Position of crosshair"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fAutoFlush", "Auto-flush tree when fAutoFlush entries written or -fAutoFlush (compressed) bytes produced"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fBorderSize", "pad bordersize in pixels"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEstimate", "Number of entries to estimate histogram limits"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fBorderMode", "Bordermode (-1=down, 0 = no border, 1=up)"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fClusterRangeEnd", "[fNClusterRange] Last entry of a cluster range."),
-			Type:   56,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fModified", "Set to true when pad is modified"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 20, "fNClusterRange", "TTree"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fClusterSize", "[fNClusterRange] Number of entries in each cluster for a given range."),
-			Type:   56,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGridx", "Set to true if grid along X"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 20, "fNClusterRange", "TTree"),
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIOFeatures", "IO features to define for newly-written baskets and branches."),
-			Type:   rmeta.Any,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGridy", "Set to true if grid along Y"),
+			Type:   rmeta.Bool,
 			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "ROOT::TIOFeatures",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranches", "List of Branches"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsCoord", "Use absolute coordinates"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeaves", "Direct pointers to individual branch leaves"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEditable", "True if canvas is editable"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fAliases", "List of aliases for expressions based on the tree branches."),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFixedAspectRatio", "True if fixed aspect ratio"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIndexValues", "Sorted index values"),
-			Type:   rmeta.Any,
-			Size:   24,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPrimitives", "->List of primitives (subpads)"),
+			Type:   rmeta.Objectp,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIndex", "Index of sorted values"),
-			Type:   rmeta.Any,
-			Size:   24,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fExecs", "List of commands to be executed when a pad event occurs"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayI",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTreeIndex", "Pointer to the tree Index (if any)"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fName", "Pad name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TVirtualIndex*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFriends", "pointer to list of friend elements"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTitle", "Pad title"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUserInfo", "pointer to a list of user objects associated to this Tree"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNumPaletteColor", "Number of objects with an automatic color"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranchRef", "Branch supporting the TRefTable (if any)"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNextPaletteColor", "Next automatic color"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranchRef*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-
 }
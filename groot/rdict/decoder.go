@@ -36,10 +36,14 @@ func (dec *decoder) DecodeROOT(ptr interface{}) error {
 	)
 
 	if hdr.Vers != typevers {
-		dec.r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d)",
-			typename, hdr.Vers, typevers,
-		))
-		return dec.r.Err()
+		// schema evolution.
+		err := rstreamEvolved(dec.r, hdr, ptr)
+		if err != nil {
+			return fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d): %w",
+				typename, hdr.Vers, typevers, err,
+			)
+		}
+		return nil
 	}
 
 	for i, op := range dec.rops {
@@ -0,0 +1,399 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+)
+
+// Rule is a schema evolution rule.
+//
+// A Rule describes how a member of the on-file representation of a class,
+// written with a given class version, is converted into a member of the
+// in-memory representation (the Go type) of that class.
+//
+// Members of the on-file and in-memory representations are identified by
+// their ROOT names (i.e. the groot struct tag of the Go fields, if any).
+// Members with the same name are automatically converted, following Go
+// conversion rules for numbers, strings, slices, arrays and structs.
+// Rules are only needed for renamed members and for conversions that can
+// not be automatically performed.
+//
+// A Rule with no Source nor Target enables the automatic schema evolution
+// of a class for the given version, without any further conversion.
+type Rule struct {
+	Class   string // name of the class
+	Version int    // on-file class version the rule applies to, or -1 for all versions
+	Source  string // name of the on-file member, or "" for the whole on-file object
+	Target  string // name of the in-memory member (defaults to Source)
+
+	// Convert converts the on-file value src into the in-memory value dst.
+	// If nil, the value is converted following Go conversion rules.
+	Convert func(dst, src reflect.Value) error
+}
+
+// Rules stores all the schema evolution rules available at runtime.
+//
+// Schema evolution is applied when decoding an object whose on-file
+// StreamerInfo has a different version than the one of the Go type it is
+// decoded into:
+//   - types built from a StreamerInfo (see TypeFromSI) are always evolved,
+//   - types registered with groot/rtypes, which provide their own
+//     rbytes.Unmarshaler implementation, are only evolved when a rule has
+//     been registered for their class and on-file version.
+var Rules = &ruleDb{
+	db: make(map[string][]Rule),
+}
+
+type ruleDb struct {
+	sync.RWMutex
+	db map[string][]Rule // rules, indexed by class name
+}
+
+// Add registers a new schema evolution rule.
+func (db *ruleDb) Add(rule Rule) error {
+	switch {
+	case rule.Class == "":
+		return fmt.Errorf("rdict: invalid schema evolution rule with no class name")
+	case rule.Source == "" && rule.Target != "" && rule.Convert == nil:
+		return fmt.Errorf(
+			"rdict: invalid schema evolution rule for %q (version=%d): no conversion function for target %q",
+			rule.Class, rule.Version, rule.Target,
+		)
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	db.db[rule.Class] = append(db.db[rule.Class], rule)
+	return nil
+}
+
+// Get returns the schema evolution rules registered for the provided class
+// and on-file class version.
+func (db *ruleDb) Get(class string, vers int) []Rule {
+	db.RLock()
+	defer db.RUnlock()
+	var rules []Rule
+	for _, rule := range db.db[class] {
+		if rule.Version < 0 || rule.Version == vers {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func (db *ruleDb) has(class string) bool {
+	db.RLock()
+	defer db.RUnlock()
+	return len(db.db[class]) > 0
+}
+
+// Unmarshal decodes the object stored in r into obj.
+//
+// If schema evolution rules have been registered for the class of obj and
+// the version of its on-file representation (see Rules), the on-file
+// representation is decoded with its StreamerInfo and converted to obj.
+// Otherwise, Unmarshal calls obj.UnmarshalROOT.
+func Unmarshal(r *rbytes.RBuffer, obj rbytes.Unmarshaler) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	o, ok := obj.(root.Object)
+	if !ok || !Rules.has(o.Class()) {
+		return obj.UnmarshalROOT(r)
+	}
+	if _, ok := obj.(*Object); ok {
+		return obj.UnmarshalROOT(r)
+	}
+
+	var (
+		class = o.Class()
+		pos   = r.Pos()
+		hdr   = r.ReadHeader(class)
+	)
+	if r.Err() != nil || len(Rules.Get(class, int(hdr.Vers))) == 0 {
+		r.SetPos(pos)
+		return obj.UnmarshalROOT(r)
+	}
+
+	return rstreamEvolved(r, hdr, obj)
+}
+
+// rstreamEvolved reads into recv the members of the object described by
+// hdr, whose on-file representation differs from the one of recv.
+// rstreamEvolved expects the header of the object to have been read.
+func rstreamEvolved(r *rbytes.RBuffer, hdr rbytes.Header, recv interface{}) error {
+	si, err := onfileStreamerInfo(r, hdr.Name, int(hdr.Vers))
+	if err != nil {
+		r.SetErr(fmt.Errorf(
+			"rdict: could not find on-file streamer for %q (version=%d): %w",
+			hdr.Name, hdr.Vers, err,
+		))
+		return r.Err()
+	}
+
+	rt, err := typeFromSI(StreamerInfos, si)
+	if err != nil {
+		r.SetErr(fmt.Errorf("rdict: could not build on-file type for %q (version=%d): %w", hdr.Name, hdr.Vers, err))
+		return r.Err()
+	}
+
+	src := reflect.New(rt)
+	for i, rop := range si.roops {
+		err := rop.rstream(r, src.Interface())
+		if err != nil {
+			return fmt.Errorf(
+				"rdict: could not rstream element %d (%s) of %s (version=%d): %w",
+				i, rop.cfg.descr.elem.Name(), hdr.Name, hdr.Vers, err,
+			)
+		}
+	}
+	r.CheckHeader(hdr)
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	err = evolve(si, reflect.ValueOf(recv).Elem(), src.Elem())
+	if err != nil {
+		r.SetErr(err)
+	}
+	return r.Err()
+}
+
+// onfileStreamerInfo returns the StreamerInfo for the named class and version,
+// with its streamers built.
+func onfileStreamerInfo(r *rbytes.RBuffer, name string, vers int) (*StreamerInfo, error) {
+	si, ok := StreamerInfos.Get(name, vers)
+	if !ok {
+		v, err := r.StreamerInfo(name, vers)
+		if err != nil {
+			return nil, err
+		}
+		si = v
+	}
+	if si.ClassVersion() != vers {
+		return nil, fmt.Errorf("rdict: no streamer for %q (version=%d)", name, vers)
+	}
+
+	osi, ok := si.(*StreamerInfo)
+	if !ok {
+		return nil, fmt.Errorf("rdict: not a rdict.StreamerInfo (got=%T)", si)
+	}
+
+	err := osi.BuildStreamers()
+	if err != nil {
+		return nil, fmt.Errorf("rdict: could not build streamers: %w", err)
+	}
+	return osi, nil
+}
+
+// evolve converts src, the on-file representation of an object described
+// by si, into dst, applying the registered schema evolution rules.
+func evolve(si rbytes.StreamerInfo, dst, src reflect.Value) error {
+	if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+		return convert(dst, src)
+	}
+
+	var (
+		class = si.Name()
+		vers  = si.ClassVersion()
+		elems = si.Elements()
+		srcs  = make(map[string]reflect.Value, len(elems))
+		dsts  = membersOf(dst)
+		used  = make(map[string]bool)
+	)
+	for i, se := range elems {
+		srcs[se.Name()] = src.Field(i)
+	}
+
+	dst.Set(reflect.Zero(dst.Type()))
+	for _, rule := range Rules.Get(class, vers) {
+		if rule.Source == "" && rule.Target == "" {
+			continue
+		}
+		target := rule.Target
+		if target == "" {
+			target = rule.Source
+		}
+		fv, ok := dsts[target]
+		if !ok {
+			return fmt.Errorf("rdict: no member %q in %v (class=%q)", target, dst.Type(), class)
+		}
+		sv := src
+		if rule.Source != "" {
+			sv, ok = srcs[rule.Source]
+			if !ok {
+				return fmt.Errorf("rdict: no on-file member %q for %q (version=%d)", rule.Source, class, vers)
+			}
+			used[rule.Source] = true
+		}
+		conv := rule.Convert
+		if conv == nil {
+			conv = convert
+		}
+		err := conv(fv, sv)
+		if err != nil {
+			return fmt.Errorf("rdict: could not convert member %q of %q (version=%d): %w", target, class, vers, err)
+		}
+		used[target] = true
+	}
+
+	for _, se := range elems {
+		name := se.Name()
+		if used[name] {
+			continue
+		}
+		fv, ok := dsts[name]
+		if !ok {
+			// member removed from the in-memory representation.
+			continue
+		}
+		err := convert(fv, srcs[name])
+		if err != nil {
+			return fmt.Errorf("rdict: could not convert member %q of %q (version=%d): %w", name, class, vers, err)
+		}
+	}
+
+	return nil
+}
+
+// membersOf returns the settable fields of the provided struct value,
+// indexed by their ROOT name.
+func membersOf(rv reflect.Value) map[string]reflect.Value {
+	var (
+		rt = rv.Type()
+		vs = make(map[string]reflect.Value, rt.NumField())
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		fv := rv.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+		name := nameOf(rt.Field(i))
+		if j := strings.Index(name, ","); j >= 0 {
+			name = name[:j]
+		}
+		vs[name] = fv
+	}
+	return vs
+}
+
+// convert converts src into dst, following Go conversion rules.
+func convert(dst, src reflect.Value) error {
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Slice:
+		switch src.Kind() {
+		case reflect.Slice, reflect.Array:
+			if src.Kind() == reflect.Slice && src.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			n := src.Len()
+			dst.Set(reflect.MakeSlice(dst.Type(), n, n))
+			for i := 0; i < n; i++ {
+				err := convert(dst.Index(i), src.Index(i))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+	case reflect.Array:
+		switch src.Kind() {
+		case reflect.Slice, reflect.Array:
+			n := src.Len()
+			if n > dst.Len() {
+				n = dst.Len()
+			}
+			for i := 0; i < n; i++ {
+				err := convert(dst.Index(i), src.Index(i))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+	case reflect.Ptr:
+		if src.Kind() == reflect.Ptr {
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			src = src.Elem()
+		}
+		v := reflect.New(dst.Type().Elem())
+		err := convert(v.Elem(), src)
+		if err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+
+	case reflect.Struct:
+		if src.Kind() == reflect.Ptr {
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+			src = src.Elem()
+		}
+		if src.Kind() != reflect.Struct {
+			break
+		}
+		var (
+			srcs = membersOf(src)
+			dsts = membersOf(dst)
+		)
+		dst.Set(reflect.Zero(dst.Type()))
+		for name, fv := range dsts {
+			sv, ok := srcs[name]
+			if !ok {
+				continue
+			}
+			err := convert(fv, sv)
+			if err != nil {
+				return fmt.Errorf("rdict: could not convert field %q: %w", name, err)
+			}
+		}
+		return nil
+
+	default:
+		var (
+			sk = src.Kind()
+			dk = dst.Kind()
+		)
+		if isScalar(sk) && isScalar(dk) && (sk == reflect.String) == (dk == reflect.String) && src.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("rdict: can not convert %v to %v", src.Type(), dst.Type())
+}
+
+func isScalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
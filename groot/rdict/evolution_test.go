@@ -0,0 +1,194 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rmeta"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+// evolTest is the in-memory representation (version 2) of a class whose
+// version 1 had a float32 "px" member, renamed to a float64 "x" member,
+// and an int32 "n" member, changed to int64.
+type evolTest struct {
+	X   float64 `groot:"x"`
+	N   int64   `groot:"n"`
+	Sum float64 `groot:"sum"`
+}
+
+func (*evolTest) Class() string   { return "EvolTest" }
+func (*evolTest) RVersion() int16 { return 2 }
+
+func (o *evolTest) UnmarshalROOT(r *rbytes.RBuffer) error {
+	hdr := r.ReadHeader(o.Class())
+	o.X = r.ReadF64()
+	o.N = r.ReadI64()
+	o.Sum = r.ReadF64()
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func TestSchemaEvolution(t *testing.T) {
+	elem := func(name string, typ rmeta.Enum, size int32, ename string) *StreamerBasicType {
+		return &StreamerBasicType{
+			StreamerElement: Element{
+				Name:   *rbase.NewNamed(name, ""),
+				Type:   typ,
+				Size:   size,
+				MaxIdx: [5]int32{0, 0, 0, 0, 0},
+				EName:  ename,
+			}.New(),
+		}
+	}
+
+	StreamerInfos.Add(NewCxxStreamerInfo("EvolTest", 1, 0, []rbytes.StreamerElement{
+		elem("px", rmeta.Float32, 4, "float"),
+		elem("n", rmeta.Int, 4, "int"),
+		elem("e", rmeta.Float64, 8, "double"), // removed in version 2.
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("EvolHolder", 1, 0, []rbytes.StreamerElement{
+		elem("id", rmeta.Int, 4, "int"),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("evt", ""),
+			Type:   rmeta.Any,
+			Size:   24,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			EName:  "EvolTest",
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("EvolPlain", 1, 0, []rbytes.StreamerElement{
+		elem("a", rmeta.Int, 4, "int"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("EvolPlain", 2, 0, []rbytes.StreamerElement{
+		elem("a", rmeta.Long64, 8, "Long64_t"),
+		elem("b", rmeta.Float32, 4, "float"),
+	}))
+
+	rtypes.Factory.Add("EvolTest", func() reflect.Value {
+		return reflect.ValueOf(&evolTest{})
+	})
+
+	for _, rule := range []Rule{
+		{Class: "EvolTest", Version: 1, Source: "px", Target: "x"},
+		{
+			Class: "EvolTest", Version: 1, Target: "sum",
+			Convert: func(dst, src reflect.Value) error {
+				px := src.FieldByName("ROOT_px").Float()
+				n := src.FieldByName("ROOT_n").Int()
+				dst.SetFloat(px + float64(n))
+				return nil
+			},
+		},
+	} {
+		err := Rules.Add(rule)
+		if err != nil {
+			t.Fatalf("could not add rule: %+v", err)
+		}
+	}
+
+	err := Rules.Add(Rule{Class: "EvolTest", Version: 1, Target: "sum"})
+	if err == nil {
+		t.Fatalf("expected an error for a rule with no source nor conversion")
+	}
+
+	writeV1 := func(w *rbytes.WBuffer) {
+		hdr := w.WriteHeader("EvolTest", 1)
+		w.WriteF32(1.5)
+		w.WriteI32(42)
+		w.WriteF64(3)
+		_, _ = w.SetHeader(hdr)
+	}
+
+	t.Run("renamed", func(t *testing.T) {
+		w := rbytes.NewWBuffer(nil, nil, 0, nil)
+		writeV1(w)
+		if err := w.Err(); err != nil {
+			t.Fatalf("could not write v1: %+v", err)
+		}
+
+		var got evolTest
+		err := Unmarshal(rbytes.NewRBuffer(w.Bytes(), nil, 0, StreamerInfos), &got)
+		if err != nil {
+			t.Fatalf("could not unmarshal v1: %+v", err)
+		}
+		if want := (evolTest{X: 1.5, N: 42, Sum: 43.5}); got != want {
+			t.Fatalf("invalid value:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("current", func(t *testing.T) {
+		w := rbytes.NewWBuffer(nil, nil, 0, nil)
+		hdr := w.WriteHeader("EvolTest", 2)
+		w.WriteF64(1)
+		w.WriteI64(2)
+		w.WriteF64(3)
+		_, _ = w.SetHeader(hdr)
+
+		var got evolTest
+		err := Unmarshal(rbytes.NewRBuffer(w.Bytes(), nil, 0, StreamerInfos), &got)
+		if err != nil {
+			t.Fatalf("could not unmarshal v2: %+v", err)
+		}
+		if want := (evolTest{X: 1, N: 2, Sum: 3}); got != want {
+			t.Fatalf("invalid value:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		w := rbytes.NewWBuffer(nil, nil, 0, nil)
+		hdr := w.WriteHeader("EvolHolder", 1)
+		w.WriteI32(7)
+		writeV1(w)
+		_, _ = w.SetHeader(hdr)
+
+		si, ok := StreamerInfos.Get("EvolHolder", 1)
+		if !ok {
+			t.Fatalf("could not find streamer for EvolHolder")
+		}
+		obj := ObjectFrom(si, StreamerInfos)
+		err := obj.UnmarshalROOT(rbytes.NewRBuffer(w.Bytes(), nil, 0, StreamerInfos))
+		if err != nil {
+			t.Fatalf("could not unmarshal holder: %+v", err)
+		}
+
+		rv := reflect.ValueOf(obj.v).Elem()
+		if got, want := rv.Field(0).Interface(), int32(7); got != want {
+			t.Fatalf("invalid id: got=%v, want=%v", got, want)
+		}
+		if got, want := rv.Field(1).Interface(), (evolTest{X: 1.5, N: 42, Sum: 43.5}); got != want {
+			t.Fatalf("invalid evt:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("version-skew", func(t *testing.T) {
+		w := rbytes.NewWBuffer(nil, nil, 0, nil)
+		hdr := w.WriteHeader("EvolPlain", 1)
+		w.WriteI32(7)
+		_, _ = w.SetHeader(hdr)
+
+		si, ok := StreamerInfos.Get("EvolPlain", 2)
+		if !ok {
+			t.Fatalf("could not find streamer for EvolPlain")
+		}
+		obj := ObjectFrom(si, StreamerInfos)
+		err := obj.UnmarshalROOT(rbytes.NewRBuffer(w.Bytes(), nil, 0, StreamerInfos))
+		if err != nil {
+			t.Fatalf("could not unmarshal v1: %+v", err)
+		}
+
+		rv := reflect.ValueOf(obj.v).Elem()
+		if got, want := rv.Field(0).Interface(), int64(7); got != want {
+			t.Fatalf("invalid a: got=%v, want=%v", got, want)
+		}
+		if got, want := rv.Field(1).Interface(), float32(0); got != want {
+			t.Fatalf("invalid b: got=%v, want=%v", got, want)
+		}
+	})
+}
@@ -28,6 +28,50 @@ func ObjectFrom(si rbytes.StreamerInfo, sictx rbytes.StreamerInfoContext) *Objec
 	return newObjectFrom(si, sictx)
 }
 
+// ObjectOf returns an Object wrapping the provided pointer to a Go value.
+//
+// The StreamerInfo of the Object is generated from the type of the Go value
+// (see StreamerOf) and registered with StreamerInfos, together with the
+// StreamerInfos of the types it depends on.
+// ObjectOf allows to write values of any Go struct type as ROOT objects
+// that can be read back by ROOT/C++.
+func ObjectOf(ptr interface{}) (*Object, error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("rdict: invalid value (got=%T, want=non-nil pointer)", ptr)
+	}
+
+	var (
+		rt   = rv.Type().Elem()
+		si   rbytes.StreamerInfo
+		name string
+		err  error
+	)
+	func() {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("rdict: could not generate streamer for %v: %v", rt, e)
+			}
+		}()
+		si = StreamerOf(StreamerInfos, rt)
+		name = si.Name()
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := StreamerInfos.Get(name, si.ClassVersion()); !ok {
+		StreamerInfos.Add(si)
+	}
+
+	return &Object{
+		v:     ptr,
+		si:    si.(*StreamerInfo),
+		rvers: int16(si.ClassVersion()),
+		class: name,
+	}, nil
+}
+
 // Object wraps a type created from a Streamer and implements the
 // following interfaces:
 //  - root.Object
@@ -54,6 +98,11 @@ func (obj *Object) SetClass(name string) {
 	*obj = *newObjectFrom(si, StreamerInfos)
 }
 
+// Value returns the pointer to the Go value wrapped by the object.
+func (obj *Object) Value() interface{} {
+	return obj.v
+}
+
 func (obj *Object) String() string {
 	return fmt.Sprintf("%v", obj.v)
 }
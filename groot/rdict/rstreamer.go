@@ -53,9 +53,15 @@ type rstreamerElem struct {
 }
 
 func newRStreamerElem(i int, si *StreamerInfo, kind rbytes.StreamKind, rops []rstreamer) (*rstreamerElem, error) {
+	// the element streamer is bound and configured independently of
+	// the streamer info: do not share its configuration.
+	rop := rops[i]
+	cfg := *rop.cfg
+	rop.cfg = &cfg
+
 	return &rstreamerElem{
 		recv: nil,
-		rop:  &rops[i],
+		rop:  &rop,
 		i:    i,
 		kind: kind,
 		si:   si,
@@ -582,13 +588,22 @@ func rstreamSI(si *StreamerInfo) ropFunc {
 		return rstreamTNamed
 	case typename == "TString":
 		return rstreamTString
+	case rtypes.Factory.HasVersion(typename, si.ClassVersion()):
+		obj := rtypes.Factory.GetVersion(typename, si.ClassVersion())().Interface()
+		_, ok := obj.(rbytes.Unmarshaler)
+		if ok {
+			return func(r *rbytes.RBuffer, recv interface{}, cfg *streamerConfig) error {
+				obj := cfg.adjust(recv).(rbytes.Unmarshaler)
+				return Unmarshal(r, obj)
+			}
+		}
 	case rtypes.Factory.HasKey(typename):
 		obj := rtypes.Factory.Get(typename)().Interface()
 		_, ok := obj.(rbytes.Unmarshaler)
 		if ok {
 			return func(r *rbytes.RBuffer, recv interface{}, cfg *streamerConfig) error {
 				obj := cfg.adjust(recv).(rbytes.Unmarshaler)
-				return obj.UnmarshalROOT(r)
+				return Unmarshal(r, obj)
 			}
 		}
 	}
@@ -912,8 +927,15 @@ func rstreamStdMap(kname, vname string, krop, vrop ropFunc) ropFunc {
 			}
 		}
 
-		if rv.IsNil() {
+		switch {
+		case rv.IsNil():
 			rv.Set(reflect.MakeMapWithSize(rv.Type(), n))
+		default:
+			// drop the content of a previously read map.
+			iter := rv.MapRange()
+			for iter.Next() {
+				rv.SetMapIndex(iter.Key(), reflect.Value{})
+			}
 		}
 		for i := 0; i < n; i++ {
 			rv.SetMapIndex(keys.Index(i), vals.Index(i))
@@ -1094,11 +1116,15 @@ func rstreamCat(typename string, typevers int16, rops []rstreamer) ropFunc {
 	return func(r *rbytes.RBuffer, recv interface{}, cfg *streamerConfig) error {
 		hdr := r.ReadHeader(typename)
 		if hdr.Vers != typevers {
-			r.SetErr(fmt.Errorf(
-				"rdict: inconsistent ROOT version type=%q (got=%d, want=%d)",
-				hdr.Name, hdr.Vers, typevers,
-			))
-			return r.Err()
+			// schema evolution.
+			err := rstreamEvolved(r, hdr, cfg.adjust(recv))
+			if err != nil {
+				return fmt.Errorf(
+					"rdict: inconsistent ROOT version type=%q (got=%d, want=%d): %w",
+					hdr.Name, hdr.Vers, typevers, err,
+				)
+			}
+			return nil
 		}
 
 		recv = cfg.adjust(recv)
@@ -19,6 +19,13 @@ import (
 
 // StreamerOf generates a StreamerInfo from a reflect.Type.
 //
+// The StreamerInfos of the Go struct types the provided type depends on
+// (e.g. via its data members, slices, arrays, pointers or maps) are also
+// generated, when not already known, and registered with StreamerInfos and
+// with the provided context (if it stores streamers, as a ROOT file opened
+// for writing does), so values of the provided type can be written and then
+// read back by ROOT.
+//
 // StreamerOf panics if the provided type contains non-ROOT compatible types
 // such as chan, int, uint or func.
 func StreamerOf(ctx rbytes.StreamerInfoContext, typ reflect.Type) rbytes.StreamerInfo {
@@ -31,7 +38,9 @@ func StreamerOf(ctx rbytes.StreamerInfoContext, typ reflect.Type) rbytes.Streame
 	}
 
 	bldr := newStreamerBuilder(ctx, typ)
-	return bldr.genStreamer(typ)
+	si := bldr.genStreamer(typ)
+	bldr.genDeps(typ)
+	return si
 }
 
 type streamerStore interface {
@@ -40,12 +49,73 @@ type streamerStore interface {
 }
 
 type streamerBuilder struct {
-	ctx streamerStore
-	typ reflect.Type
+	ctx  streamerStore
+	typ  reflect.Type
+	deps map[reflect.Type]struct{} // types whose dependencies have been generated
 }
 
 func newStreamerBuilder(ctx rbytes.StreamerInfoContext, typ reflect.Type) *streamerBuilder {
-	return &streamerBuilder{ctx: newStreamerStore(ctx), typ: typ}
+	return &streamerBuilder{
+		ctx:  newStreamerStore(ctx),
+		typ:  typ,
+		deps: make(map[reflect.Type]struct{}),
+	}
+}
+
+// genDeps generates and registers the StreamerInfos of the Go struct types
+// the provided type depends on.
+func (bld *streamerBuilder) genDeps(typ reflect.Type) {
+	if _, dup := bld.deps[typ]; dup {
+		return
+	}
+	bld.deps[typ] = struct{}{}
+
+	switch typ.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		bld.genDeps(typ.Elem())
+	case reflect.Map:
+		bld.genDeps(typ.Key())
+		bld.genDeps(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			bld.genDep(typ.Field(i).Type)
+		}
+	}
+}
+
+// genDep generates and registers the StreamerInfos of the provided Go type,
+// if it is a Go struct type not already known, and of its dependencies.
+func (bld *streamerBuilder) genDep(typ reflect.Type) {
+	switch typ.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		bld.genDep(typ.Elem())
+		return
+	case reflect.Map:
+		bld.genDep(typ.Key())
+		bld.genDep(typ.Elem())
+		return
+	case reflect.Struct:
+		// ok.
+	default:
+		return
+	}
+
+	if isTObject(typ) || isTObject(reflect.PtrTo(typ)) {
+		return
+	}
+
+	if _, dup := bld.deps[typ]; dup {
+		return
+	}
+
+	name := typenameOf(typ)
+	si, ok := StreamerInfos.Get(name, -1)
+	if !ok {
+		si = bld.genStreamer(typ)
+		StreamerInfos.Add(si)
+	}
+	bld.ctx.addStreamer(si)
+	bld.genDeps(typ)
 }
 
 func (bld *streamerBuilder) genStreamer(typ reflect.Type) rbytes.StreamerInfo {
@@ -154,6 +224,18 @@ func (bld *streamerBuilder) genStdVectorOf(typ reflect.Type, name string, offset
 	)
 }
 
+func (bld *streamerBuilder) genStdMapOf(typ reflect.Type, name string, offset int32) rbytes.StreamerElement {
+	return NewCxxStreamerSTL(
+		StreamerElement{
+			named:  *rbase.NewNamed(name, ""),
+			etype:  rmeta.Streamer,
+			esize:  sizeOfStdMap,
+			offset: offset,
+			ename:  typenameOf(typ),
+		}, rmeta.STLmap, rmeta.Object,
+	)
+}
+
 func (bld *streamerBuilder) genPtr(typ reflect.Type, name string, offset int32) rbytes.StreamerElement {
 	// FIXME(sbinet): is typ always a struct?
 	//	switch typ.Kind() {
@@ -610,6 +692,9 @@ func (bld *streamerBuilder) genField(typ reflect.Type, field reflect.StructField
 		et := field.Type.Elem()
 		return bld.genPtr(et, nameOf(field), offsetOf(field))
 
+	case reflect.Map:
+		return bld.genStdMapOf(field.Type, nameOf(field), offsetOf(field))
+
 	default:
 		panic(fmt.Errorf(
 			"rdict: invalid struct field (name=%v, type=%v, kind=%v)",
@@ -808,6 +893,12 @@ func typenameOf(typ reflect.Type) string {
 		return "string"
 	case reflect.Ptr:
 		return typenameOf(typ.Elem()) + "*"
+	case reflect.Map:
+		vname := typenameOf(typ.Elem())
+		if strings.HasSuffix(vname, ">") {
+			vname += " "
+		}
+		return "map<" + typenameOf(typ.Key()) + "," + vname + ">"
 
 	default:
 		name := typ.Name()
@@ -823,6 +914,7 @@ const (
 	sizeOfTObjString = 40
 	sizeOfTString    = 3 * diskPtrSize
 	sizeOfStdString  = 4 * diskPtrSize
+	sizeOfStdMap     = 6 * diskPtrSize
 )
 
 var (
@@ -1119,13 +1119,28 @@ func TestStreamerOf(t *testing.T) {
 			},
 		},
 		{
-			// FIXME(sbinet): add support for maps.
-			typ: reflect.TypeOf(panicFIXMEStruct0{}),
+			typ: reflect.TypeOf(mapStruct{}),
 			want: &StreamerInfo{
-				named:  *rbase.NewNamed("panicFIXMEStruct0", "panicFIXMEStruct0"),
+				named:  *rbase.NewNamed("mapStruct", "mapStruct"),
 				clsver: 1,
+				objarr: rcont.NewObjArray(),
+				elems: []rbytes.StreamerElement{
+					NewCxxStreamerSTL(StreamerElement{
+						named:  *rbase.NewNamed("Map", ""),
+						etype:  rmeta.Streamer,
+						esize:  6 * int32(ptrSize),
+						offset: 0,
+						ename:  "map<int32_t,int32_t>",
+					}, rmeta.STLmap, rmeta.Object),
+					NewCxxStreamerSTL(StreamerElement{
+						named:  *rbase.NewNamed("Vecs", ""),
+						etype:  rmeta.Streamer,
+						esize:  6 * int32(ptrSize),
+						offset: 0,
+						ename:  "map<string,vector<float> >",
+					}, rmeta.STLmap, rmeta.Object),
+				},
 			},
-			panics: `rdict: invalid struct field (name=Map, type=map[int32]int32, kind=map)`,
 		},
 		{
 			// FIXME(sbinet): add support for interfaces?
@@ -1345,8 +1360,9 @@ type struct7 struct {
 	ArrUsr [1][2][3][4][5]struct1         `groot:"ArrUsr[1][2][3][4][5]"`
 }
 
-type panicFIXMEStruct0 struct {
-	Map map[int32]int32 `groot:"Map"`
+type mapStruct struct {
+	Map  map[int32]int32      `groot:"Map"`
+	Vecs map[string][]float32 `groot:"Vecs"`
 }
 
 type panicFIXMEStruct1 struct {
@@ -22,12 +22,23 @@ import (
 // then resorts to building a new type with reflect.
 func TypeFromSI(ctx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) (reflect.Type, error) {
 	name := si.Name()
+	if vers := si.ClassVersion(); rtypes.Factory.HasVersion(name, vers) {
+		fct := rtypes.Factory.GetVersion(name, vers)
+		v := fct()
+		return v.Type().Elem(), nil
+	}
 	if rtypes.Factory.HasKey(name) {
 		fct := rtypes.Factory.Get(name)
 		v := fct()
 		return v.Type().Elem(), nil
 	}
+	return typeFromSI(ctx, si)
+}
 
+// typeFromSI builds a new Go type corresponding to the provided StreamerInfo,
+// without considering the registered groot types for the StreamerInfo itself.
+func typeFromSI(ctx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) (reflect.Type, error) {
+	name := si.Name()
 	switch {
 	case name == "TString":
 		if len(si.Elements()) == 0 {
@@ -108,6 +119,46 @@ func TypeFromSI(ctx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) (reflect
 	return reflect.StructOf(fields), nil
 }
 
+// TypesFrom returns the Go types synthesized from the provided list of
+// StreamerInfos, indexed by class name.
+//
+// Only the user classes unknown to groot are considered: classes with a
+// registered Go type (see groot/rtypes), strings and STL containers are
+// skipped.
+// The returned types are built with reflect and can be used to read data
+// (e.g. the entries of a tree) without any hand-written Go type.
+// Go source code for these classes can be generated with the
+// root-gen-type command instead.
+func TypesFrom(ctx rbytes.StreamerInfoContext, sinfos []rbytes.StreamerInfo) (map[string]reflect.Type, error) {
+	types := make(map[string]reflect.Type)
+	for _, si := range sinfos {
+		name := si.Name()
+		switch {
+		case rtypes.Factory.HasKey(name),
+			rtypes.Factory.HasVersion(name, si.ClassVersion()):
+			continue
+		case name == "TString", name == "string", name == "std::string":
+			continue
+		case hasStdPrefix(name,
+			"vector", "list", "deque", "bitset",
+			"set", "multiset", "unordered_set", "unordered_multiset",
+			"map", "multimap", "unordered_map", "unordered_multimap",
+			"pair"):
+			continue
+		}
+		if _, dup := types[name]; dup {
+			continue
+		}
+
+		rt, err := TypeFromSI(ctx, si)
+		if err != nil {
+			return nil, fmt.Errorf("rdict: could not build type for %q: %w", name, err)
+		}
+		types[name] = rt
+	}
+	return types, nil
+}
+
 // TypeFromSE returns a Go type corresponding to the provided StreamerElement.
 // TypeFromSE first reaches out to the known groot types (via groot/rtypes) and
 // then resorts to building a new type with reflect.
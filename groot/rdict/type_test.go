@@ -6,12 +6,14 @@ package rdict_test
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rcont"
 	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
@@ -1411,3 +1413,51 @@ func TestTypeFromSI(t *testing.T) {
 		})
 	}
 }
+
+func TestTypesFrom(t *testing.T) {
+	f, err := riofs.Open("../testdata/streamers.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	types, err := rdict.TypesFrom(f, f.StreamerInfos())
+	if err != nil {
+		t.Fatalf("could not build types: %+v", err)
+	}
+
+	var names []string
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if got, want := names, []string{"Event", "P3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid types:\ngot= %q\nwant=%q", got, want)
+	}
+
+	p3 := types["P3"]
+	if got, want := p3.Kind(), reflect.Struct; got != want {
+		t.Fatalf("invalid P3 kind: got=%v, want=%v", got, want)
+	}
+
+	evt := types["Event"]
+	for _, tc := range []struct {
+		name string
+		want reflect.Type
+	}{
+		{"ROOT_I32", reflect.TypeOf(int32(0))},
+		{"ROOT_F64", reflect.TypeOf(float64(0))},
+		{"ROOT_Str", reflect.TypeOf("")},
+		{"ROOT_P3", p3},
+		{"ROOT_ArrayP3s", reflect.ArrayOf(10, p3)},
+		{"ROOT_StlVecI16", reflect.TypeOf([]int16(nil))},
+	} {
+		ft, ok := evt.FieldByName(tc.name)
+		if !ok {
+			t.Fatalf("could not find field %q in %v", tc.name, evt)
+		}
+		if got, want := ft.Type, tc.want; got != want {
+			t.Fatalf("invalid type for field %q: got=%v, want=%v", tc.name, got, want)
+		}
+	}
+}
@@ -121,9 +121,8 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 			rmeta.STLvector:
 
 			etn := se.ElemTypeName()
-			tname := strings.TrimRight(etn[0], "*")
-			if _, ok := rmeta.CxxBuiltins[tname]; ok {
-				// no-op: C++ builtin.
+			tname := strings.TrimSpace(strings.TrimRight(etn[0], "*"))
+			if !isVisitable(tname) {
 				return nil
 			}
 			si, err := v.ctx.StreamerInfo(tname, -1)
@@ -132,6 +131,23 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 			}
 			return v.run(depth+1, si)
 
+		case rmeta.STLmap, rmeta.STLmultimap, rmeta.STLunorderedmap, rmeta.STLunorderedmultimap:
+			for _, etn := range se.ElemTypeName() {
+				tname := strings.TrimSpace(strings.TrimRight(etn, "*"))
+				if !isVisitable(tname) {
+					continue
+				}
+				si, err := v.ctx.StreamerInfo(tname, -1)
+				if err != nil {
+					return fmt.Errorf("could not find std::map<K,V> element %q: %w", tname, err)
+				}
+				err = v.run(depth+1, si)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
 		default:
 			return fmt.Errorf("rdict: cant visit non-vector-like STL streamers %#v", se)
 		}
@@ -154,3 +170,24 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 
 	return nil
 }
+
+// isVisitable returns whether the streamer of the named element type of a STL
+// container should be visited.
+func isVisitable(tname string) bool {
+	switch {
+	case tname == "string", tname == "std::string", tname == "TString":
+		return false
+	case hasStdPrefix(tname,
+		"vector", "list", "deque", "set", "multiset", "unordered_set", "unordered_multiset",
+		"map", "multimap", "unordered_map", "unordered_multimap"):
+		// elements of nested STL containers are not visited.
+		return false
+	case rmeta.IsStdPairOfBuiltins(tname):
+		return false
+	}
+	if _, ok := rmeta.CxxBuiltins[tname]; ok {
+		// no-op: C++ builtin.
+		return false
+	}
+	return true
+}
@@ -51,9 +51,15 @@ type wstreamerElem struct {
 }
 
 func newWStreamer(i int, si *StreamerInfo, kind rbytes.StreamKind, wops []wstreamer) (*wstreamerElem, error) {
+	// the element streamer is bound and configured independently of
+	// the streamer info: do not share its configuration.
+	wop := wops[i]
+	cfg := *wop.cfg
+	wop.cfg = &cfg
+
 	return &wstreamerElem{
 		recv: nil,
-		wop:  &wops[i],
+		wop:  &wop,
 		i:    i,
 		kind: kind,
 		si:   si,
@@ -86,9 +92,15 @@ func (ww *wstreamerElem) WStreamROOT(w *rbytes.WBuffer) error {
 	return nil
 }
 
+func (ww *wstreamerElem) Count(f func() int) error {
+	ww.wop.cfg.count = f
+	return nil
+}
+
 var (
 	_ rbytes.WStreamer = (*wstreamerElem)(nil)
 	_ rbytes.Binder    = (*wstreamerElem)(nil)
+	_ rbytes.Counter   = (*wstreamerElem)(nil)
 )
 
 type wstreamOp interface {
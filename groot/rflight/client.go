@@ -0,0 +1,117 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rflight // import "go-hep.org/x/hep/groot/rflight"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	pb "go-hep.org/x/hep/groot/rflight/internal/flightpb"
+	"google.golang.org/grpc"
+)
+
+// Client is an Arrow Flight client for services serving ROOT trees.
+type Client struct {
+	cc  pb.FlightServiceClient
+	cfg *config
+}
+
+// NewClient creates a new Arrow Flight client using the provided gRPC
+// connection.
+func NewClient(cc grpc.ClientConnInterface, opts ...Option) *Client {
+	return &Client{cc: pb.NewFlightServiceClient(cc), cfg: newConfig(opts)}
+}
+
+// Schema returns the schema of the record batches served for the provided
+// query.
+func (c *Client) Schema(ctx context.Context, q Query) (*arrow.Schema, error) {
+	cmd, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not encode query: %w", err)
+	}
+
+	rep, err := c.cc.GetSchema(ctx, &pb.FlightDescriptor{Type: pb.FlightDescriptor_CMD, Cmd: cmd})
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not get schema: %w", err)
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(rep.Schema), ipc.WithAllocator(c.cfg.mem))
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not decode schema: %w", err)
+	}
+	defer r.Release()
+
+	return r.Schema(), nil
+}
+
+// Records returns a reader streaming the record batches served for the
+// provided query.
+// The returned reader must be released after use.
+func (c *Client) Records(ctx context.Context, q Query) (*ipc.Reader, error) {
+	tkt, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not encode query: %w", err)
+	}
+
+	stream, err := c.cc.DoGet(ctx, &pb.Ticket{Ticket: tkt})
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not open stream: %w", err)
+	}
+
+	r, err := ipc.NewReaderFromMessageReader(
+		&dataReader{stream: stream, refs: 1},
+		ipc.WithAllocator(c.cfg.mem),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rflight: could not create record reader: %w", err)
+	}
+	return r, nil
+}
+
+// dataReader reads Arrow IPC messages from Flight data messages.
+type dataReader struct {
+	stream pb.FlightService_DoGetClient
+	refs   int64
+	msg    *ipc.Message
+}
+
+func (r *dataReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+func (r *dataReader) Release() {
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.msg != nil {
+			r.msg.Release()
+			r.msg = nil
+		}
+	}
+}
+
+func (r *dataReader) Message() (*ipc.Message, error) {
+	data, err := r.stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("rflight: could not receive data: %w", err)
+	}
+
+	if r.msg != nil {
+		r.msg.Release()
+	}
+	r.msg = ipc.NewMessage(
+		memory.NewBufferBytes(data.DataHeader),
+		memory.NewBufferBytes(data.DataBody),
+	)
+	return r.msg, nil
+}
@@ -0,0 +1,13 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flightpb holds the protobuf messages and gRPC stubs of the
+// Apache Arrow Flight protocol.
+//
+// Flight.pb.go and Flight_grpc.pb.go are generated from the format/Flight.proto
+// file of Apache Arrow 12.0.1, with protoc-gen-go v1.28.1 and
+// protoc-gen-go-grpc v1.2.0. ARROW_FORMAT should point at that directory.
+package flightpb // import "go-hep.org/x/hep/groot/rflight/internal/flightpb"
+
+//go:generate protoc -I${ARROW_FORMAT} --go_out=. --go_opt=paths=source_relative,MFlight.proto=go-hep.org/x/hep/groot/rflight/internal/flightpb --go-grpc_out=. --go-grpc_opt=paths=source_relative,MFlight.proto=go-hep.org/x/hep/groot/rflight/internal/flightpb Flight.proto
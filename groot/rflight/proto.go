@@ -0,0 +1,35 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rflight // import "go-hep.org/x/hep/groot/rflight"
+
+import (
+	"context"
+
+	pb "go-hep.org/x/hep/groot/rflight/internal/flightpb"
+)
+
+// flightService exposes a Server through the Arrow Flight gRPC service
+// generated from Flight.proto.
+// Flight methods not implemented by Server report codes.Unimplemented.
+type flightService struct {
+	pb.UnimplementedFlightServiceServer
+	srv *Server
+}
+
+func (fs *flightService) ListFlights(req *pb.Criteria, stream pb.FlightService_ListFlightsServer) error {
+	return fs.srv.listFlights(req, stream)
+}
+
+func (fs *flightService) GetFlightInfo(ctx context.Context, req *pb.FlightDescriptor) (*pb.FlightInfo, error) {
+	return fs.srv.getFlightInfo(ctx, req)
+}
+
+func (fs *flightService) GetSchema(ctx context.Context, req *pb.FlightDescriptor) (*pb.SchemaResult, error) {
+	return fs.srv.getSchema(ctx, req)
+}
+
+func (fs *flightService) DoGet(req *pb.Ticket, stream pb.FlightService_DoGetServer) error {
+	return fs.srv.doGet(req, stream)
+}
@@ -0,0 +1,462 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rflight serves ROOT trees as Apache Arrow Flight services.
+//
+// A Server exposes the trees of the ROOT files located under a directory.
+// Arrow Flight clients (e.g. pyarrow.flight or Spark) retrieve the entries
+// of a tree as a stream of Arrow record batches with the DoGet method,
+// using a ticket holding a JSON-encoded Query:
+//
+//  {"file": "data/f.root", "tree": "dir/tree", "branches": ["pt", "eta"], "filter": "pt > 20"}
+//
+// The Server also implements the ListFlights, GetFlightInfo and GetSchema
+// methods; the other methods of the Flight service report codes.Unimplemented.
+// The gRPC service is generated from the Flight.proto file of Apache Arrow.
+// Flight descriptors are either CMD descriptors holding a JSON-encoded
+// Query, or PATH descriptors made of the path of the ROOT file followed by
+// the path of the tree in that file, e.g. ["data/f.root", "dir", "tree"].
+//
+// Example, with pyarrow:
+//
+//  import json
+//  import pyarrow.flight as flight
+//
+//  client = flight.connect("grpc://localhost:8815")
+//  query = {"file": "f.root", "tree": "tree", "filter": "pt > 20"}
+//  table = client.do_get(flight.Ticket(json.dumps(query))).read_all()
+package rflight // import "go-hep.org/x/hep/groot/rflight"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	stdpath "path"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rarrow"
+	pb "go-hep.org/x/hep/groot/rflight/internal/flightpb"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultChunk is the default number of entries of the record batches
+// streamed by a Server.
+const defaultChunk = 4096
+
+// Query describes the entries of a tree served by a Server.
+//
+// Queries are the JSON-encoded content of the tickets and CMD descriptors
+// exchanged with a Server.
+type Query struct {
+	File     string   `json:"file"`               // path of the ROOT file, relative to the directory of the server
+	Tree     string   `json:"tree"`               // path of the tree in the ROOT file
+	Branches []string `json:"branches,omitempty"` // branches to serve (default: all)
+	Filter   string   `json:"filter,omitempty"`   // selection expression, as described by rtree.NewFormula
+}
+
+// Option configures a Server or a Client.
+type Option func(cfg *config)
+
+type config struct {
+	mem   memory.Allocator
+	chunk int64
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		mem:   memory.NewGoAllocator(),
+		chunk: defaultChunk,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithAllocator configures the Arrow memory allocator used to build and
+// read record batches.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg *config) {
+		cfg.mem = mem
+	}
+}
+
+// WithChunk configures the maximum number of entries of the record batches
+// streamed by a Server.
+func WithChunk(nentries int64) Option {
+	return func(cfg *config) {
+		cfg.chunk = nentries
+	}
+}
+
+// Server is an Arrow Flight service serving the trees of the ROOT files
+// located under a directory.
+type Server struct {
+	dir string
+	cfg *config
+}
+
+// NewServer creates a new Arrow Flight service serving the trees of the
+// ROOT files located under dir.
+func NewServer(dir string, opts ...Option) *Server {
+	cfg := newConfig(opts)
+	if cfg.chunk <= 0 {
+		cfg.chunk = defaultChunk
+	}
+	return &Server{dir: dir, cfg: cfg}
+}
+
+// Register registers the Arrow Flight service with the provided gRPC server.
+func (srv *Server) Register(s *grpc.Server) {
+	pb.RegisterFlightServiceServer(s, &flightService{srv: srv})
+}
+
+func (srv *Server) listFlights(req *pb.Criteria, stream pb.FlightService_ListFlightsServer) error {
+	pattern := string(req.Expression)
+	if pattern != "" {
+		_, err := stdpath.Match(pattern, "")
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid criteria %q: %v", pattern, err)
+		}
+	}
+
+	return filepath.WalkDir(srv.dir, func(fname string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(fname) != ".root" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(srv.dir, fname)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		f, err := groot.Open(fname)
+		if err != nil {
+			return status.Errorf(codes.Internal, "could not open ROOT file %q: %v", rel, err)
+		}
+		defer f.Close()
+
+		return riofs.Walk(f, func(path string, obj root.Object, err error) error {
+			if err != nil {
+				return err
+			}
+			tree, ok := obj.(rtree.Tree)
+			if !ok {
+				return nil
+			}
+			tname := strings.TrimPrefix(strings.TrimPrefix(path, f.Name()), "/")
+			if pattern != "" {
+				if ok, _ := stdpath.Match(pattern, rel+"/"+tname); !ok {
+					return nil
+				}
+			}
+
+			info, err := srv.info(Query{File: rel, Tree: tname}, tree)
+			if err != nil {
+				return err
+			}
+			info.FlightDescriptor = &pb.FlightDescriptor{
+				Type: pb.FlightDescriptor_PATH,
+				Path: append([]string{rel}, strings.Split(tname, "/")...),
+			}
+			return stream.Send(info)
+		})
+	})
+}
+
+func (srv *Server) getFlightInfo(ctx context.Context, req *pb.FlightDescriptor) (*pb.FlightInfo, error) {
+	q, err := queryFrom(req)
+	if err != nil {
+		return nil, err
+	}
+
+	f, tree, err := srv.open(q)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := srv.info(q, tree)
+	if err != nil {
+		return nil, err
+	}
+	info.FlightDescriptor = req
+	return info, nil
+}
+
+func (srv *Server) getSchema(ctx context.Context, req *pb.FlightDescriptor) (*pb.SchemaResult, error) {
+	q, err := queryFrom(req)
+	if err != nil {
+		return nil, err
+	}
+
+	f, tree, err := srv.open(q)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	schema, err := srv.schema(q, tree)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SchemaResult{Schema: serializeSchema(schema, srv.cfg.mem)}, nil
+}
+
+func (srv *Server) doGet(req *pb.Ticket, stream pb.FlightService_DoGetServer) error {
+	var q Query
+	err := json.Unmarshal(req.Ticket, &q)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid ticket: %v", err)
+	}
+
+	f, tree, err := srv.open(q)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rvars, err := q.rvars(tree)
+	if err != nil {
+		return err
+	}
+
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not create tree reader: %v", err)
+	}
+	defer r.Close()
+
+	var filter func() bool
+	if q.Filter != "" {
+		form, err := rtree.NewFormula(tree, q.Filter)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "could not create filter %q: %v", q.Filter, err)
+		}
+		form, err = r.Formula(form)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "could not bind filter %q: %v", q.Filter, err)
+		}
+		fct, ok := form.Func().(func() bool)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "filter %q is not a boolean expression", q.Filter)
+		}
+		filter = fct
+	}
+
+	bldr, err := rarrow.NewRecordBuilder(
+		tree, rvars,
+		rarrow.WithAllocator(srv.cfg.mem),
+		rarrow.WithChunk(srv.cfg.chunk),
+	)
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not create Arrow record builder: %v", err)
+	}
+	defer bldr.Release()
+
+	w := ipc.NewWriterWithPayloadWriter(
+		&dataWriter{stream: stream},
+		ipc.WithSchema(bldr.Schema()),
+		ipc.WithAllocator(srv.cfg.mem),
+	)
+	defer w.Close()
+
+	flush := func() error {
+		rec := bldr.NewRecord()
+		defer rec.Release()
+		return w.Write(rec)
+	}
+
+	ctx := stream.Context()
+	err = r.Read(func(rctx rtree.RCtx) error {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+		if filter != nil && !filter() {
+			return nil
+		}
+		bldr.Append()
+		if bldr.Len() < srv.cfg.chunk {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return status.Errorf(status.Code(err), "could not stream tree %q: %v", q.Tree, err)
+	}
+
+	if bldr.Len() > 0 {
+		err = flush()
+		if err != nil {
+			return status.Errorf(status.Code(err), "could not stream tree %q: %v", q.Tree, err)
+		}
+	}
+
+	return w.Close()
+}
+
+// open returns the tree described by the provided query, and the ROOT file
+// holding it.
+func (srv *Server) open(q Query) (*riofs.File, rtree.Tree, error) {
+	if q.File == "" || q.Tree == "" {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "missing file or tree name in query")
+	}
+
+	// make sure the file is located under the directory of the server.
+	fname := filepath.Join(srv.dir, filepath.FromSlash(stdpath.Clean("/"+q.File)))
+	f, err := groot.Open(fname)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.NotFound, "could not open ROOT file %q: %v", q.File, err)
+	}
+
+	tree, err := riofs.Get[rtree.Tree](riofs.Dir(f), q.Tree)
+	if err != nil {
+		f.Close()
+		return nil, nil, status.Errorf(codes.NotFound, "could not get tree %q: %v", q.Tree, err)
+	}
+
+	return f, tree, nil
+}
+
+// info returns the Flight description of the provided query.
+func (srv *Server) info(q Query, tree rtree.Tree) (*pb.FlightInfo, error) {
+	schema, err := srv.schema(q, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	tkt, err := json.Marshal(q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not encode ticket: %v", err)
+	}
+
+	nrecs := tree.Entries()
+	if q.Filter != "" {
+		nrecs = -1
+	}
+
+	return &pb.FlightInfo{
+		Schema:       serializeSchema(schema, srv.cfg.mem),
+		Endpoint:     []*pb.FlightEndpoint{{Ticket: &pb.Ticket{Ticket: tkt}}},
+		TotalRecords: nrecs,
+		TotalBytes:   -1,
+	}, nil
+}
+
+// schema returns the schema of the record batches served for the provided
+// query.
+func (srv *Server) schema(q Query, tree rtree.Tree) (*arrow.Schema, error) {
+	rvars, err := q.rvars(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Filter != "" {
+		_, err = rtree.NewFormula(tree, q.Filter)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", q.Filter, err)
+		}
+	}
+
+	bldr, err := rarrow.NewRecordBuilder(tree, rvars, rarrow.WithAllocator(srv.cfg.mem))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not create Arrow record builder: %v", err)
+	}
+	defer bldr.Release()
+
+	return bldr.Schema(), nil
+}
+
+// rvars returns the read-vars of the branches selected by the query.
+func (q Query) rvars(tree rtree.Tree) ([]rtree.ReadVar, error) {
+	all := rtree.NewReadVars(tree)
+	if len(q.Branches) == 0 {
+		return all, nil
+	}
+
+	var rvars []rtree.ReadVar
+	for _, name := range q.Branches {
+		n := len(rvars)
+		for _, rvar := range all {
+			if rvar.Name == name {
+				rvars = append(rvars, rvar)
+			}
+		}
+		if len(rvars) == n {
+			return nil, status.Errorf(codes.InvalidArgument, "no branch %q in tree %q", name, q.Tree)
+		}
+	}
+	return rvars, nil
+}
+
+// queryFrom returns the query described by the provided Flight descriptor.
+func queryFrom(desc *pb.FlightDescriptor) (Query, error) {
+	var q Query
+	switch desc.Type {
+	case pb.FlightDescriptor_PATH:
+		if len(desc.Path) < 2 {
+			return q, status.Errorf(codes.InvalidArgument, "invalid descriptor path %q", desc.Path)
+		}
+		q.File = desc.Path[0]
+		q.Tree = strings.Join(desc.Path[1:], "/")
+	case pb.FlightDescriptor_CMD:
+		err := json.Unmarshal(desc.Cmd, &q)
+		if err != nil {
+			return q, status.Errorf(codes.InvalidArgument, "invalid descriptor command: %v", err)
+		}
+	default:
+		return q, status.Errorf(codes.InvalidArgument, "invalid descriptor type %d", desc.Type)
+	}
+	return q, nil
+}
+
+// serializeSchema encodes the provided schema as an Arrow IPC message.
+func serializeSchema(schema *arrow.Schema, mem memory.Allocator) []byte {
+	buf := new(bytes.Buffer)
+	w := ipc.NewWriter(buf, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	_ = w.Close() // writing to a bytes.Buffer can not fail.
+	return buf.Bytes()
+}
+
+// dataWriter sends Arrow IPC payloads as Flight data messages.
+type dataWriter struct {
+	stream pb.FlightService_DoGetServer
+	buf    bytes.Buffer
+}
+
+func (w *dataWriter) Start() error { return nil }
+func (w *dataWriter) Close() error { return nil }
+
+func (w *dataWriter) WritePayload(p ipc.Payload) error {
+	meta := p.Meta()
+	defer meta.Release()
+
+	w.buf.Reset()
+	err := p.SerializeBody(&w.buf)
+	if err != nil {
+		return fmt.Errorf("could not serialize payload body: %w", err)
+	}
+
+	return w.stream.Send(&pb.FlightData{
+		DataHeader: meta.Bytes(),
+		DataBody:   w.buf.Bytes(),
+	})
+}
@@ -0,0 +1,367 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rflight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot"
+	pb "go-hep.org/x/hep/groot/rflight/internal/flightpb"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestServer(t *testing.T, opts ...Option) *grpc.ClientConn {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "data"), 0755)
+	if err != nil {
+		t.Fatalf("could not create data directory: %+v", err)
+	}
+
+	raw, err := os.ReadFile("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not read ROOT file: %+v", err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "data", "small.root"), raw, 0644)
+	if err != nil {
+		t.Fatalf("could not write ROOT file: %+v", err)
+	}
+
+	func() {
+		f, err := groot.Create(filepath.Join(dir, "dirs.root"))
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		sub, err := riofs.Dir(f).Mkdir("dir")
+		if err != nil {
+			t.Fatalf("could not create directory: %+v", err)
+		}
+
+		var x float64
+		w, err := rtree.NewWriter(sub, "evts", []rtree.WriteVar{{Name: "x", Value: &x}})
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 5; i++ {
+			x = float64(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	NewServer(dir, opts...).Register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("could not dial server: %+v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	return cc
+}
+
+func TestSchema(t *testing.T) {
+	cc := newTestServer(t)
+	c := NewClient(cc)
+
+	schema, err := c.Schema(context.Background(), Query{
+		File:     "data/small.root",
+		Tree:     "tree",
+		Branches: []string{"Int32", "Str", "SliceInt64"},
+	})
+	if err != nil {
+		t.Fatalf("could not get schema: %+v", err)
+	}
+
+	var names []string
+	for _, field := range schema.Fields() {
+		names = append(names, field.Name)
+	}
+	if got, want := names, []string{"Int32", "Str", "SliceInt64"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid schema fields: got=%q, want=%q", got, want)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	cc := newTestServer(t, WithAllocator(mem), WithChunk(10))
+	c := NewClient(cc, WithAllocator(mem))
+
+	for _, tc := range []struct {
+		name  string
+		query Query
+		recs  []int64
+		want  []int32
+	}{
+		{
+			name:  "all",
+			query: Query{File: "data/small.root", Tree: "tree", Branches: []string{"Int32"}},
+			recs:  []int64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10},
+			want:  seq(0, 100),
+		},
+		{
+			name: "filter",
+			query: Query{
+				File:     "data/small.root",
+				Tree:     "tree",
+				Branches: []string{"Int32", "Str"},
+				Filter:   "Int32 >= 85 && Float64 < 97",
+			},
+			recs: []int64{10, 2},
+			want: seq(85, 97),
+		},
+		{
+			name: "empty",
+			query: Query{
+				File:     "data/small.root",
+				Tree:     "tree",
+				Branches: []string{"Int32"},
+				Filter:   "Int32 < 0",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := c.Records(context.Background(), tc.query)
+			if err != nil {
+				t.Fatalf("could not get records: %+v", err)
+			}
+			defer r.Release()
+
+			var (
+				recs []int64
+				got  []int32
+			)
+			for r.Next() {
+				rec := r.Record()
+				recs = append(recs, rec.NumRows())
+				got = append(got, rec.Column(0).(*array.Int32).Int32Values()...)
+			}
+			if err := r.Err(); err != nil {
+				t.Fatalf("could not read records: %+v", err)
+			}
+
+			if !reflect.DeepEqual(recs, tc.recs) {
+				t.Fatalf("invalid records: got=%v, want=%v", recs, tc.recs)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cc := newTestServer(t)
+	c := NewClient(cc)
+
+	for _, tc := range []struct {
+		name  string
+		query Query
+		code  codes.Code
+	}{
+		{
+			name:  "missing-tree",
+			query: Query{File: "data/small.root"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "no-such-file",
+			query: Query{File: "../../small-flat-tree.root", Tree: "tree"},
+			code:  codes.NotFound,
+		},
+		{
+			name:  "no-such-tree",
+			query: Query{File: "data/small.root", Tree: "not-there"},
+			code:  codes.NotFound,
+		},
+		{
+			name:  "no-such-branch",
+			query: Query{File: "data/small.root", Tree: "tree", Branches: []string{"not-there"}},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "invalid-filter",
+			query: Query{File: "data/small.root", Tree: "tree", Filter: "Int32 +"},
+			code:  codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := c.Schema(context.Background(), tc.query)
+			if got, want := status.Code(errors.Unwrap(err)), tc.code; got != want {
+				t.Fatalf("invalid schema error code: got=%v, want=%v (err=%v)", got, want, err)
+			}
+
+			r, err := c.Records(context.Background(), tc.query)
+			if err == nil {
+				r.Release()
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestListFlights(t *testing.T) {
+	fc := pb.NewFlightServiceClient(newTestServer(t))
+
+	for _, tc := range []struct {
+		pattern string
+		want    [][]string
+	}{
+		{
+			pattern: "",
+			want: [][]string{
+				{"data/small.root", "tree"},
+				{"dirs.root", "dir", "evts"},
+			},
+		},
+		{
+			pattern: "dirs.root/*/*",
+			want: [][]string{
+				{"dirs.root", "dir", "evts"},
+			},
+		},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			stream, err := fc.ListFlights(context.Background(), &pb.Criteria{Expression: []byte(tc.pattern)})
+			if err != nil {
+				t.Fatalf("could not list flights: %+v", err)
+			}
+
+			var got [][]string
+			for {
+				info, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not receive flight info: %+v", err)
+				}
+				desc := info.FlightDescriptor
+				if desc == nil || desc.Type != pb.FlightDescriptor_PATH {
+					t.Fatalf("invalid flight descriptor: %v", desc)
+				}
+				got = append(got, desc.Path)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid flights:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetFlightInfo(t *testing.T) {
+	cc := newTestServer(t)
+	c := NewClient(cc)
+
+	info, err := pb.NewFlightServiceClient(cc).GetFlightInfo(
+		context.Background(),
+		&pb.FlightDescriptor{Type: pb.FlightDescriptor_PATH, Path: []string{"dirs.root", "dir", "evts"}},
+	)
+	if err != nil {
+		t.Fatalf("could not get flight info: %+v", err)
+	}
+
+	if got, want := info.TotalRecords, int64(5); got != want {
+		t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+	}
+	if got, want := info.TotalBytes, int64(-1); got != want {
+		t.Fatalf("invalid number of bytes: got=%d, want=%d", got, want)
+	}
+	if len(info.Endpoint) != 1 || info.Endpoint[0].Ticket == nil {
+		t.Fatalf("invalid endpoints: %v", info.Endpoint)
+	}
+
+	var q Query
+	err = json.Unmarshal(info.Endpoint[0].Ticket.Ticket, &q)
+	if err != nil {
+		t.Fatalf("could not decode ticket: %+v", err)
+	}
+	if got, want := q, (Query{File: "dirs.root", Tree: "dir/evts"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid ticket: got=%#v, want=%#v", got, want)
+	}
+
+	r, err := c.Records(context.Background(), q)
+	if err != nil {
+		t.Fatalf("could not get records: %+v", err)
+	}
+	defer r.Release()
+
+	n := int64(0)
+	for r.Next() {
+		n += r.Record().NumRows()
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("could not read records: %+v", err)
+	}
+	if n != info.TotalRecords {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, info.TotalRecords)
+	}
+}
+
+func TestUnimplemented(t *testing.T) {
+	fc := pb.NewFlightServiceClient(newTestServer(t))
+
+	stream, err := fc.ListActions(context.Background(), &pb.Empty{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Fatalf("invalid error code: got=%v, want=%v (err=%v)", got, want, err)
+	}
+}
+
+func seq(beg, end int32) []int32 {
+	var vs []int32
+	for i := beg; i < end; i++ {
+		vs = append(vs, i)
+	}
+	return vs
+}
@@ -0,0 +1,307 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+)
+
+// histCells gives access to the cells (bins, including under/overflow bins)
+// and to the statistics of a ROOT histogram.
+type histCells interface {
+	Rank() int
+
+	base() *th1
+	cell(i int) float64
+	setCell(i int, v float64)
+	setCells(vs []float64)
+	stats() []float64
+	setStats(s []float64)
+}
+
+// histOperand returns the cells of the operand o of an arithmetic
+// operation on h, checking their binnings are compatible.
+func histOperand(h histCells, o interface{}) (histCells, error) {
+	src, ok := o.(histCells)
+	if !ok {
+		return nil, fmt.Errorf("rhist: invalid histogram operand type %T", o)
+	}
+	if h.Rank() != src.Rank() {
+		return nil, fmt.Errorf("rhist: incompatible histogram dimensions (%d != %d)", h.Rank(), src.Rank())
+	}
+
+	var (
+		dst = h.base()
+		ref = src.base()
+	)
+	if err := checkAxes(&dst.xaxis, &ref.xaxis); err != nil {
+		return nil, fmt.Errorf("rhist: incompatible x-axis: %w", err)
+	}
+	if h.Rank() > 1 {
+		if err := checkAxes(&dst.yaxis, &ref.yaxis); err != nil {
+			return nil, fmt.Errorf("rhist: incompatible y-axis: %w", err)
+		}
+	}
+	return src, nil
+}
+
+func checkAxes(a, b *taxis) error {
+	if a.nbins != b.nbins {
+		return fmt.Errorf("different number of bins (%d != %d)", a.nbins, b.nbins)
+	}
+	if a.xmin != b.xmin || a.xmax != b.xmax {
+		return fmt.Errorf(
+			"different limits ([%v, %v] != [%v, %v])",
+			a.xmin, a.xmax, b.xmin, b.xmax,
+		)
+	}
+	if len(a.xbins.Data) != len(b.xbins.Data) {
+		return fmt.Errorf("different bin edges")
+	}
+	for i, v := range a.xbins.Data {
+		if v != b.xbins.Data[i] {
+			return fmt.Errorf("different bin edges")
+		}
+	}
+	return nil
+}
+
+// histNcells returns the number of cells of the histogram.
+func histNcells(h histCells) int {
+	var (
+		hb = h.base()
+		n  = hb.xaxis.nbins + 2
+	)
+	if h.Rank() > 1 {
+		n *= hb.yaxis.nbins + 2
+	}
+	return n
+}
+
+// histSumw2 returns the sums of squares of weights of the histogram.
+// When the histogram has no such sums, the (absolute value of the) bin
+// contents are used.
+func histSumw2(h histCells) []float64 {
+	if hb := h.base(); len(hb.sumw2.Data) > 0 {
+		return hb.sumw2.Data
+	}
+	vs := make([]float64, histNcells(h))
+	for i := range vs {
+		vs[i] = math.Abs(h.cell(i))
+	}
+	return vs
+}
+
+// initSumw2 makes sure the sums of squares of weights of the histogram
+// are allocated.
+func initSumw2(h histCells) {
+	hb := h.base()
+	if len(hb.sumw2.Data) > 0 {
+		return
+	}
+	hb.sumw2.Data = histSumw2(h)
+}
+
+// resetStats recomputes the statistics of the histogram from its bin
+// contents, excluding under/overflow bins.
+func resetStats(h histCells) {
+	var (
+		hb = h.base()
+		nx = hb.xaxis.nbins
+		ny = 1
+		w2 = hb.sumw2.Data
+		s  = make([]float64, len(h.stats()))
+	)
+	if h.Rank() > 1 {
+		ny = hb.yaxis.nbins
+	}
+	for iy := 1; iy <= ny; iy++ {
+		y := 0.0
+		if h.Rank() > 1 {
+			y = hb.yaxis.BinCenter(iy)
+		}
+		for ix := 1; ix <= nx; ix++ {
+			var (
+				i = ix
+				x = hb.xaxis.BinCenter(ix)
+			)
+			if h.Rank() > 1 {
+				i += (nx + 2) * iy
+			}
+			w := h.cell(i)
+			s[0] += w
+			switch {
+			case len(w2) > 0:
+				s[1] += w2[i]
+			default:
+				s[1] += math.Abs(w)
+			}
+			s[2] += w * x
+			s[3] += w * x * x
+			if h.Rank() > 1 {
+				s[4] += w * y
+				s[5] += w * y * y
+				s[6] += w * x * y
+			}
+		}
+	}
+	h.setStats(s)
+}
+
+// histFill fills the histogram with (x,y) and weight w.
+// y is ignored for 1-dim histograms.
+func histFill(h histCells, x, y, w float64) {
+	var (
+		hb = h.base()
+		nx = hb.xaxis.nbins
+		ix = hb.xaxis.findBin(x)
+		i  = ix
+		in = ix >= 1 && ix <= nx
+	)
+	if h.Rank() > 1 {
+		iy := hb.yaxis.findBin(y)
+		i += (nx + 2) * iy
+		in = in && iy >= 1 && iy <= hb.yaxis.nbins
+	}
+
+	hb.entries++
+	h.setCell(i, h.cell(i)+w)
+	if len(hb.sumw2.Data) > 0 {
+		hb.sumw2.Data[i] += w * w
+	}
+	if !in {
+		return
+	}
+
+	s := h.stats()
+	s[0] += w
+	s[1] += w * w
+	s[2] += w * x
+	s[3] += w * x * x
+	if h.Rank() > 1 {
+		s[4] += w * y
+		s[5] += w * y * y
+		s[6] += w * x * y
+	}
+	h.setStats(s)
+}
+
+func histAdd(h histCells, o interface{}, c float64) error {
+	src, err := histOperand(h, o)
+	if err != nil {
+		return err
+	}
+
+	initSumw2(h)
+	var (
+		hb = h.base()
+		w2 = histSumw2(src)
+	)
+	for i := range hb.sumw2.Data {
+		h.setCell(i, h.cell(i)+c*src.cell(i))
+		hb.sumw2.Data[i] += c * c * w2[i]
+	}
+
+	var (
+		s1 = h.stats()
+		s2 = src.stats()
+	)
+	for i := range s1 {
+		switch i {
+		case 1:
+			s1[i] += c * c * s2[i]
+		default:
+			s1[i] += c * s2[i]
+		}
+	}
+	h.setStats(s1)
+	hb.entries = math.Abs(hb.entries + c*src.base().entries)
+
+	return nil
+}
+
+func histScale(h histCells, c float64) {
+	initSumw2(h)
+	hb := h.base()
+	for i := range hb.sumw2.Data {
+		h.setCell(i, c*h.cell(i))
+		hb.sumw2.Data[i] *= c * c
+	}
+
+	s := h.stats()
+	for i := range s {
+		switch i {
+		case 1:
+			s[i] *= c * c
+		default:
+			s[i] *= c
+		}
+	}
+	h.setStats(s)
+}
+
+func histMultiply(h histCells, o interface{}) error {
+	src, err := histOperand(h, o)
+	if err != nil {
+		return err
+	}
+
+	initSumw2(h)
+	var (
+		hb = h.base()
+		w2 = histSumw2(src)
+	)
+	for i := range hb.sumw2.Data {
+		var (
+			c0 = h.cell(i)
+			c1 = src.cell(i)
+		)
+		hb.sumw2.Data[i] = hb.sumw2.Data[i]*c1*c1 + w2[i]*c0*c0
+		h.setCell(i, c0*c1)
+	}
+	resetStats(h)
+
+	return nil
+}
+
+func histDivide(h histCells, o interface{}, binomial bool) error {
+	src, err := histOperand(h, o)
+	if err != nil {
+		return err
+	}
+
+	initSumw2(h)
+	var (
+		hb = h.base()
+		w2 = histSumw2(src)
+	)
+	for i := range hb.sumw2.Data {
+		var (
+			c0 = h.cell(i)
+			c1 = src.cell(i)
+		)
+		if c1 == 0 {
+			h.setCell(i, 0)
+			hb.sumw2.Data[i] = 0
+			continue
+		}
+
+		w := c0 / c1
+		h.setCell(i, w)
+		switch {
+		case binomial && c0 == c1:
+			hb.sumw2.Data[i] = 0
+		case binomial:
+			hb.sumw2.Data[i] = math.Abs(((1-2*w)*hb.sumw2.Data[i] + w*w*w2[i]) / (c1 * c1))
+		default:
+			c1sq := c1 * c1
+			hb.sumw2.Data[i] = (hb.sumw2.Data[i]*c1sq + w2[i]*c0*c0) / (c1sq * c1sq)
+		}
+	}
+	resetStats(h)
+
+	return nil
+}
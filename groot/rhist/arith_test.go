@@ -0,0 +1,183 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func newArithH1(xs, ws []float64) *hbook.H1D {
+	h := hbook.NewH1D(3, 0, 3)
+	for i, x := range xs {
+		h.Fill(x, ws[i])
+	}
+	return h
+}
+
+func checkArithBins(t *testing.T, h *rhist.H1D, contents, errs []float64) {
+	t.Helper()
+	for i := range contents {
+		if got, want := h.XBinContent(i+1), contents[i]; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid content for bin %d: got=%v, want=%v", i+1, got, want)
+		}
+		if got, want := h.XBinError(i+1), errs[i]; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid error for bin %d: got=%v, want=%v", i+1, got, want)
+		}
+	}
+}
+
+func TestHistArith(t *testing.T) {
+	var (
+		h1 = func() *rhist.H1D {
+			return rhist.NewH1DFrom(newArithH1(
+				[]float64{0.5, 0.5, 1.5, 2.5},
+				[]float64{1, 1, 2, 1},
+			))
+		}
+		h2 = func() *rhist.H1F {
+			return rhist.NewH1FFrom(newArithH1(
+				[]float64{0.5, 1.5, 1.5, 1.5, 1.5},
+				[]float64{1, 1, 1, 1, 1},
+			))
+		}
+	)
+
+	t.Run("add", func(t *testing.T) {
+		h := h1()
+		err := h.Add(h2(), 2)
+		if err != nil {
+			t.Fatalf("could not add histograms: %+v", err)
+		}
+		checkArithBins(t, h,
+			[]float64{4, 10, 1},
+			[]float64{math.Sqrt(6), math.Sqrt(20), 1},
+		)
+		if got, want := h.SumW(), 15.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW2(), 7.0+4*5; got != want {
+			t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+		}
+		if got, want := h.Entries(), 14.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("scale", func(t *testing.T) {
+		h := h1()
+		h.Scale(0.5)
+		checkArithBins(t, h,
+			[]float64{1, 1, 0.5},
+			[]float64{math.Sqrt(0.5), 1, 0.5},
+		)
+		if got, want := h.SumW(), 2.5; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW2(), 1.75; got != want {
+			t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+		}
+		if got, want := h.Entries(), 4.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("multiply", func(t *testing.T) {
+		h := h1()
+		err := h.Multiply(h2())
+		if err != nil {
+			t.Fatalf("could not multiply histograms: %+v", err)
+		}
+		checkArithBins(t, h,
+			[]float64{2, 8, 0},
+			[]float64{math.Sqrt(6), math.Sqrt(80), 0},
+		)
+		if got, want := h.SumW(), 10.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumWX(), 2*0.5+8*1.5; got != want {
+			t.Fatalf("invalid sumwx: got=%v, want=%v", got, want)
+		}
+		if got, want := h.Entries(), 4.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("divide", func(t *testing.T) {
+		h := h1()
+		err := h.Divide(h2(), false)
+		if err != nil {
+			t.Fatalf("could not divide histograms: %+v", err)
+		}
+		checkArithBins(t, h,
+			[]float64{2, 0.5, 0},
+			[]float64{math.Sqrt(6), math.Sqrt(80.0 / 256), 0},
+		)
+	})
+
+	t.Run("divide-binomial", func(t *testing.T) {
+		var (
+			pass = rhist.NewH1DFrom(newArithH1(
+				[]float64{0.5, 1.5, 1.5},
+				[]float64{1, 1, 1},
+			))
+			tot = rhist.NewH1DFrom(newArithH1(
+				[]float64{0.5, 0.5, 1.5, 1.5, 1.5, 1.5, 2.5},
+				[]float64{1, 1, 1, 1, 1, 1, 1},
+			))
+		)
+		err := pass.Divide(tot, true)
+		if err != nil {
+			t.Fatalf("could not divide histograms: %+v", err)
+		}
+		// binomial errors: sqrt(eff*(1-eff)/n)
+		checkArithBins(t, pass,
+			[]float64{0.5, 0.5, 0},
+			[]float64{math.Sqrt(0.25 / 2), math.Sqrt(0.25 / 4), 0},
+		)
+	})
+
+	t.Run("add-h2", func(t *testing.T) {
+		newH2 := func(w float64) *rhist.H2D {
+			h := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+			h.Fill(0.5, 0.5, w)
+			h.Fill(1.5, 0.5, 2*w)
+			return rhist.NewH2DFrom(h)
+		}
+		h := newH2(1)
+		err := h.Add(newH2(3), -1)
+		if err != nil {
+			t.Fatalf("could not add histograms: %+v", err)
+		}
+		// bin (ix=1, iy=1) is at index 1 + (nx+2)*1.
+		if got, want := h.XBinContent(5), -2.0; got != want {
+			t.Fatalf("invalid content: got=%v, want=%v", got, want)
+		}
+		if got, want := h.XBinError(5), math.Sqrt(10); got != want {
+			t.Fatalf("invalid error: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW(), -6.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumWY(), -3.0; got != want {
+			t.Fatalf("invalid sumwy: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		h := h1()
+		o := rhist.NewH1DFrom(hbook.NewH1D(4, 0, 3))
+		if err := h.Add(o, 1); err == nil {
+			t.Fatalf("expected an error for incompatible binnings")
+		}
+		o = rhist.NewH1DFrom(hbook.NewH1D(3, 0, 4))
+		if err := h.Divide(o, false); err == nil {
+			t.Fatalf("expected an error for incompatible limits")
+		}
+	})
+}
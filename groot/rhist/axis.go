@@ -7,6 +7,9 @@ package rhist
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
@@ -14,6 +17,14 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+)
+
+// status bits of the fBits2 word of TAxis.
+const (
+	axisAlphanumeric = 1 << 4 // axis with alphanumeric labels
+	axisCanExtend    = 1 << 5 // axis can be extended
+	axisNotAlpha     = 1 << 6 // axis cannot be alphanumeric
 )
 
 type taxis struct {
@@ -99,6 +110,159 @@ func (a *taxis) BinWidth(i int) float64 {
 	return a.xbins.Data[i] - a.xbins.Data[i-1]
 }
 
+// findBin returns the bin number containing x, 0 for the underflow bin
+// and nbins+1 for the overflow bin.
+func (a *taxis) findBin(x float64) int {
+	switch {
+	case x < a.xmin:
+		return 0
+	case !(x < a.xmax):
+		return a.nbins + 1
+	}
+	if edges := a.xbins.Data; len(edges) > 0 {
+		return sort.Search(len(edges), func(i int) bool { return edges[i] > x })
+	}
+	bin := 1 + int(float64(a.nbins)*(x-a.xmin)/(a.xmax-a.xmin))
+	if bin > a.nbins {
+		bin = a.nbins
+	}
+	return bin
+}
+
+// BinLabel returns the label of the i-th bin, or the empty string if that
+// bin has no label.
+func (a *taxis) BinLabel(i int) string {
+	if a.labels == nil {
+		return ""
+	}
+	for j := 0; j < a.labels.Len(); j++ {
+		lbl, ok := a.labels.At(j).(root.UIDer)
+		if !ok || int(lbl.UID()) != i {
+			continue
+		}
+		if lbl, ok := lbl.(root.Named); ok {
+			return lbl.Name()
+		}
+	}
+	return ""
+}
+
+// SetBinLabel sets the label of the i-th bin.
+// As in ROOT, the axis becomes alphanumeric and extendable once all its
+// bins are labeled.
+func (a *taxis) SetBinLabel(i int, label string) {
+	if a.labels == nil {
+		a.labels = &rcont.HashList{List: *rcont.NewList("", nil)}
+	}
+
+	objs := make([]root.Object, 0, a.labels.Len()+1)
+	for j := 0; j < a.labels.Len(); j++ {
+		obj := a.labels.At(j)
+		if lbl, ok := obj.(root.UIDer); ok && int(lbl.UID()) == i {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	lbl := rbase.NewObjString(label)
+	lbl.SetUID(uint32(i))
+	a.labels.List = *rcont.NewList("", append(objs, lbl))
+
+	if a.bits2&axisNotAlpha == 0 && a.labels.Len() == a.nbins {
+		a.bits2 |= axisAlphanumeric | axisCanExtend
+	}
+}
+
+// labelBin returns the bin holding the provided label, or 0 if no bin
+// has that label.
+func (a *taxis) labelBin(label string) int {
+	if a.labels == nil {
+		return 0
+	}
+	for j := 0; j < a.labels.Len(); j++ {
+		lbl, ok := a.labels.At(j).(interface {
+			root.UIDer
+			root.Named
+		})
+		if ok && lbl.Name() == label {
+			return int(lbl.UID())
+		}
+	}
+	return 0
+}
+
+// CanExtend returns whether the axis can be extended.
+func (a *taxis) CanExtend() bool {
+	return a.bits2&axisCanExtend != 0
+}
+
+// SetCanExtend sets whether the axis can be extended.
+func (a *taxis) SetCanExtend(v bool) {
+	switch v {
+	case true:
+		a.bits2 |= axisCanExtend
+	default:
+		a.bits2 &^= axisCanExtend
+	}
+}
+
+// TimeDisplay returns whether the axis displays time values instead of numerics.
+func (a *taxis) TimeDisplay() bool {
+	return a.time
+}
+
+// SetTimeDisplay sets whether the axis displays time values instead of numerics.
+func (a *taxis) SetTimeDisplay(v bool) {
+	a.time = v
+}
+
+// TimeFormat returns the date&time format of the axis.
+func (a *taxis) TimeFormat() string {
+	return a.tfmt
+}
+
+// SetTimeFormat sets the date&time format of the axis.
+//
+// The format follows the conventions of ROOT's TAxis::SetTimeFormat:
+// a strftime-like format (e.g. "%d/%m/%y %H:%M"), optionally followed by
+// the time offset of the axis values (e.g. "%F1995-01-01 00:00:00").
+func (a *taxis) SetTimeFormat(format string) {
+	a.tfmt = format
+}
+
+// hbookAxis returns the hbook metadata of this axis.
+// A title of the form "title [unit]" is split into its title and unit.
+func (a *taxis) hbookAxis() hbook.Axis {
+	ax := hbook.Axis{Title: a.Title()}
+	if i := strings.LastIndex(ax.Title, " ["); i > 0 && strings.HasSuffix(ax.Title, "]") {
+		ax.Unit = ax.Title[i+2 : len(ax.Title)-1]
+		ax.Title = ax.Title[:i]
+	}
+	if a.labels != nil && a.labels.Len() > 0 {
+		ax.Labels = make([]string, a.nbins)
+		for i := range ax.Labels {
+			ax.Labels[i] = a.BinLabel(i + 1)
+		}
+	}
+	return ax
+}
+
+// setHbookAxis sets the title and bin labels of this axis from the
+// provided hbook metadata.
+// The unit, if any, is appended to the title, as in "title [unit]".
+func (a *taxis) setHbookAxis(ax hbook.Axis) {
+	title := ax.Title
+	if ax.Unit != "" {
+		title += " [" + ax.Unit + "]"
+	}
+	a.SetTitle(title)
+	for i, lbl := range ax.Labels {
+		if lbl == "" {
+			continue
+		}
+		a.SetBinLabel(i+1, lbl)
+	}
+}
+
 func (a *taxis) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -176,6 +340,96 @@ func (a *taxis) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// defaultTimeOffset is the default time offset of ROOT axes
+// displaying time values (see TStyle::SetTimeOffset.)
+var defaultTimeOffset = time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// AxisTime returns the Go time layout and time offset corresponding to the
+// date&time format of the provided axis.
+// Axis values are seconds since that time offset.
+// AxisTime returns false if the axis does not display time values.
+func AxisTime(a Axis) (layout string, offset time.Time, ok bool) {
+	if !a.TimeDisplay() {
+		return "", time.Time{}, false
+	}
+	layout, offset = parseTimeFormat(a.TimeFormat())
+	return layout, offset, true
+}
+
+// parseTimeFormat converts a ROOT date&time format into a Go time layout
+// and a time offset.
+func parseTimeFormat(format string) (string, time.Time) {
+	offset := defaultTimeOffset
+	if i := strings.Index(format, "%F"); i >= 0 {
+		const layout = "2006-01-02 15:04:05"
+		v := strings.TrimSpace(format[i+2:])
+		if len(v) > len(layout) {
+			v = v[:len(layout)]
+		}
+		t, err := time.Parse(layout, v)
+		if err == nil {
+			offset = t
+		}
+		format = format[:i]
+	}
+	format = strings.TrimSpace(format)
+	if format == "" {
+		return "2006-01-02 15:04:05", offset
+	}
+
+	var o strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' || i+1 == len(format) {
+			o.WriteByte(c)
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'a':
+			o.WriteString("Mon")
+		case 'A':
+			o.WriteString("Monday")
+		case 'b', 'h':
+			o.WriteString("Jan")
+		case 'B':
+			o.WriteString("January")
+		case 'd':
+			o.WriteString("02")
+		case 'e':
+			o.WriteString("_2")
+		case 'H':
+			o.WriteString("15")
+		case 'I':
+			o.WriteString("03")
+		case 'j':
+			o.WriteString("002")
+		case 'm':
+			o.WriteString("01")
+		case 'M':
+			o.WriteString("04")
+		case 'p':
+			o.WriteString("PM")
+		case 'S':
+			o.WriteString("05")
+		case 'y':
+			o.WriteString("06")
+		case 'Y':
+			o.WriteString("2006")
+		case 'Z':
+			o.WriteString("MST")
+		case 'z':
+			o.WriteString("-0700")
+		case '%':
+			o.WriteByte('%')
+		default:
+			o.WriteByte('%')
+			o.WriteByte(format[i])
+		}
+	}
+	return o.String(), offset
+}
+
 func init() {
 	{
 		f := func() reflect.Value {
@@ -7,12 +7,14 @@ package rhist
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
 // ConfidenceLevel holds information about 95% confidence level limits.
@@ -34,6 +36,305 @@ type ConfidenceLevel struct {
 	fISB   []int32      `groot:"fISB,meta=[fNNMC]"`
 }
 
+const (
+	clMCLM2S  = 0.025       // quantile for the -2 sigma band
+	clMCLM1S  = 0.16        // quantile for the -1 sigma band
+	clMCLMED  = 0.5         // quantile for the median
+	clMCLP1S  = 0.84        // quantile for the +1 sigma band
+	clMCLP2S  = 0.975       // quantile for the +2 sigma band
+	clMCL3S1S = 2.6998e-3   // one-sided 1-CL for 3 sigma
+	clMCL5S1S = 5.7330e-7   // one-sided 1-CL for 5 sigma
+	clMCL3S2S = 1.349898e-3 // two-sided 1-CL for 3 sigma
+	clMCL5S2S = 2.866516e-7 // two-sided 1-CL for 5 sigma
+)
+
+func newConfidenceLevel(nmc int, onesided bool) *ConfidenceLevel {
+	cl := &ConfidenceLevel{
+		base:   *rbase.NewObject(),
+		fNNMC:  int32(nmc),
+		fNMC:   float64(nmc),
+		fMCL3S: clMCL3S2S,
+		fMCL5S: clMCL5S2S,
+		fTSB:   make([]float64, nmc),
+		fTSS:   make([]float64, nmc),
+		fLRS:   make([]float64, nmc),
+		fLRB:   make([]float64, nmc),
+		fISS:   make([]int32, nmc),
+		fISB:   make([]int32, nmc),
+	}
+	if onesided {
+		cl.fMCL3S = clMCL3S1S
+		cl.fMCL5S = clMCL5S1S
+	}
+	return cl
+}
+
+// sort computes the indices of the test statistics of the s+b and b
+// pseudo-experiments, sorted in increasing order.
+func (o *ConfidenceLevel) sort() {
+	idx := func(vs []float64, is []int32) {
+		for i := range is {
+			is[i] = int32(i)
+		}
+		sort.SliceStable(is, func(i, j int) bool {
+			return vs[is[i]] < vs[is[j]]
+		})
+	}
+	idx(o.fTSS, o.fISS)
+	idx(o.fTSB, o.fISB)
+}
+
+// Stot returns the total number of expected signal events.
+func (o *ConfidenceLevel) Stot() float64 { return o.fStot }
+
+// Btot returns the total number of expected background events.
+func (o *ConfidenceLevel) Btot() float64 { return o.fBtot }
+
+// Dtot returns the total number of observed candidates.
+func (o *ConfidenceLevel) Dtot() int { return int(o.fDtot) }
+
+// NMC returns the number of pseudo-experiments.
+func (o *ConfidenceLevel) NMC() int { return int(o.fNNMC) }
+
+// Statistic returns the observed value of the -2lnQ test statistic.
+func (o *ConfidenceLevel) Statistic() float64 {
+	return -2 * (o.fTSD - o.fStot)
+}
+
+// CLb returns the confidence level of the background-only hypothesis,
+// ie: the probability, under the background-only hypothesis, to obtain
+// a less signal-like outcome than the observed one.
+//
+// If sMC is true, the s+b pseudo-experiments are reweighted to compute
+// the value, otherwise the b pseudo-experiments are used.
+func (o *ConfidenceLevel) CLb(sMC bool) float64 {
+	var (
+		res float64
+		nmc = o.fNMC
+	)
+	switch sMC {
+	case true:
+		for _, i := range o.fISS {
+			if o.fTSS[i] < o.fTSD {
+				res += 1 / (o.fLRS[i] * nmc)
+			}
+		}
+	default:
+		for j, i := range o.fISB {
+			if o.fTSB[i] < o.fTSD {
+				res = float64(j+1) / nmc
+			}
+		}
+	}
+	return res
+}
+
+// CLsb returns the confidence level of the signal+background hypothesis.
+//
+// If sMC is true, the s+b pseudo-experiments are used to compute the value,
+// otherwise the b pseudo-experiments are reweighted.
+func (o *ConfidenceLevel) CLsb(sMC bool) float64 {
+	var (
+		res float64
+		nmc = o.fNMC
+	)
+	switch sMC {
+	case true:
+		for j, i := range o.fISS {
+			if o.fTSS[i] <= o.fTSD {
+				res = float64(j+1) / nmc
+			}
+		}
+	default:
+		for _, i := range o.fISB {
+			if o.fTSB[i] <= o.fTSD {
+				res += o.fLRB[i] / nmc
+			}
+		}
+	}
+	return res
+}
+
+// CLs returns the modified frequentist confidence level CLsb/CLb.
+// The signal hypothesis is excluded at the 95% confidence level when CLs
+// is below 0.05.
+//
+// CLs returns 0 if CLb is 0.
+func (o *ConfidenceLevel) CLs(sMC bool) float64 {
+	clb := o.CLb(false)
+	if clb == 0 {
+		return 0
+	}
+	return o.CLsb(sMC) / clb
+}
+
+// Significance returns the observed significance, in numbers of standard
+// deviations, derived from the probability 1-CLb for the background to
+// produce an outcome at least as signal-like as the observed one.
+func (o *ConfidenceLevel) Significance() float64 {
+	return distuv.UnitNormal.Quantile(o.CLb(false))
+}
+
+// quantile returns the index in the sorted pseudo-experiments arrays
+// corresponding to the expected band at sigma standard deviations.
+// quantile panics if sigma is not in [-2, 2].
+func (o *ConfidenceLevel) quantile(sigma int) int {
+	var q float64
+	switch sigma {
+	case -2:
+		q = clMCLP2S
+	case -1:
+		q = clMCLP1S
+	case 0:
+		q = clMCLMED
+	case +1:
+		q = clMCLM1S
+	case +2:
+		q = clMCLM2S
+	default:
+		panic(fmt.Errorf("rhist: invalid sigma band (sigma=%d)", sigma))
+	}
+	n := int(o.fNNMC)
+	i := int(o.fNMC * q)
+	if i < 1 {
+		i = 1
+	}
+	if i > n {
+		i = n
+	}
+	return i - 1
+}
+
+// ExpectedStatisticB returns the expected value of the -2lnQ test statistic
+// under the background-only hypothesis, for the band at sigma standard
+// deviations from the median.
+// sigma must be in [-2, 2].
+func (o *ConfidenceLevel) ExpectedStatisticB(sigma int) float64 {
+	return -2 * (o.fTSB[o.fISB[o.quantile(sigma)]] - o.fStot)
+}
+
+// ExpectedStatisticSB returns the expected value of the -2lnQ test statistic
+// under the signal+background hypothesis, for the band at sigma standard
+// deviations from the median.
+// sigma must be in [-2, 2].
+func (o *ConfidenceLevel) ExpectedStatisticSB(sigma int) float64 {
+	return -2 * (o.fTSS[o.fISS[o.quantile(sigma)]] - o.fStot)
+}
+
+// ExpectedCLsbB returns the expected CLsb under the background-only
+// hypothesis, for the band at sigma standard deviations from the median.
+// sigma must be in [-2, 2].
+func (o *ConfidenceLevel) ExpectedCLsbB(sigma int) float64 {
+	var (
+		res float64
+		ts  = o.fTSB[o.fISB[o.quantile(sigma)]]
+	)
+	for _, i := range o.fISB {
+		if o.fTSB[i] <= ts {
+			res += o.fLRB[i] / o.fNMC
+		}
+	}
+	return res
+}
+
+// ExpectedCLbSB returns the expected CLb under the signal+background
+// hypothesis, for the band at sigma standard deviations from the median.
+// sigma must be in [-2, 2].
+func (o *ConfidenceLevel) ExpectedCLbSB(sigma int) float64 {
+	var (
+		res float64
+		ts  = o.fTSS[o.fISS[o.quantile(sigma)]]
+	)
+	for _, i := range o.fISS {
+		if o.fTSS[i] <= ts {
+			res += 1 / (o.fLRS[i] * o.fNMC)
+		}
+	}
+	return res
+}
+
+// ExpectedCLsB returns the expected CLs under the background-only
+// hypothesis, for the band at sigma standard deviations from the median.
+// sigma must be in [-2, 2].
+func (o *ConfidenceLevel) ExpectedCLsB(sigma int) float64 {
+	var (
+		clb float64
+		ts  = o.fTSB[o.fISB[o.quantile(sigma)]]
+	)
+	for j, i := range o.fISB {
+		if o.fTSB[i] <= ts {
+			clb = float64(j+1) / o.fNMC
+		}
+	}
+	if clb == 0 {
+		return 0
+	}
+	return o.ExpectedCLsbB(sigma) / clb
+}
+
+// AverageCLsb returns the average CLsb under the background-only
+// hypothesis.
+func (o *ConfidenceLevel) AverageCLsb() float64 {
+	var (
+		res  float64
+		psum float64
+		nmc  = o.fNMC
+	)
+	for _, i := range o.fISB {
+		psum += o.fLRB[i] / nmc
+		res += psum / nmc
+	}
+	return res
+}
+
+// AverageCLs returns the average CLs under the background-only
+// hypothesis.
+func (o *ConfidenceLevel) AverageCLs() float64 {
+	var (
+		res  float64
+		psum float64
+		nmc  = o.fNMC
+	)
+	for j, i := range o.fISB {
+		psum += o.fLRB[i] / nmc
+		res += psum / (float64(j+1) / nmc) / nmc
+	}
+	return res
+}
+
+// Prob3S returns the probability, under the signal+background hypothesis,
+// of a 3 sigma discovery.
+func (o *ConfidenceLevel) Prob3S() float64 {
+	return o.discovery(o.fMCL3S)
+}
+
+// Prob5S returns the probability, under the signal+background hypothesis,
+// of a 5 sigma discovery.
+func (o *ConfidenceLevel) Prob5S() float64 {
+	return o.discovery(o.fMCL5S)
+}
+
+// discovery returns the fraction of s+b pseudo-experiments for which the
+// probability of the background to produce an outcome at least as
+// signal-like does not exceed p.
+func (o *ConfidenceLevel) discovery(p float64) float64 {
+	var (
+		res  float64
+		psum float64
+		n    = len(o.fISS)
+		nmc  = o.fNMC
+	)
+	for j := 0; j < n; j++ {
+		i := o.fISS[n-1-j]
+		psum += 1 / (o.fLRS[i] * nmc)
+		if psum > p {
+			break
+		}
+		res = float64(j+1) / nmc
+	}
+	return res
+}
+
 func (*ConfidenceLevel) Class() string {
 	return "TConfidenceLevel"
 }
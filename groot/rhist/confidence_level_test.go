@@ -0,0 +1,122 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestConfidenceLevel(t *testing.T) {
+	cl := newConfidenceLevel(4, true)
+	cl.fStot = 1
+	cl.fTSD = 2.5
+	copy(cl.fTSB, []float64{3, 1, 4, 2})
+	copy(cl.fLRB, []float64{1, 0.5, 2, 1})
+	copy(cl.fTSS, []float64{5, 2, 4, 3})
+	copy(cl.fLRS, []float64{4, 1, 2, 2})
+	cl.sort()
+
+	for _, tc := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"statistic", cl.Statistic(), -3},
+		{"clb", cl.CLb(false), 0.5},
+		{"clb-smc", cl.CLb(true), 0.25},
+		{"clsb", cl.CLsb(false), 0.375},
+		{"clsb-smc", cl.CLsb(true), 0.25},
+		{"cls", cl.CLs(false), 0.75},
+		{"cls-smc", cl.CLs(true), 0.5},
+		{"significance", cl.Significance(), 0},
+		{"exp-b-m2", cl.ExpectedStatisticB(-2), -4},
+		{"exp-b-med", cl.ExpectedStatisticB(0), -2},
+		{"exp-b-p2", cl.ExpectedStatisticB(+2), 0},
+		{"exp-sb-med", cl.ExpectedStatisticSB(0), -4},
+		{"exp-clsb-b-med", cl.ExpectedCLsbB(0), 0.375},
+		{"exp-clb-sb-med", cl.ExpectedCLbSB(0), 0.375},
+		{"exp-cls-b-med", cl.ExpectedCLsB(0), 0.75},
+		{"avg-clsb", cl.AverageCLsb(), (0.125 + 0.375 + 0.625 + 1.125) / 4},
+		{"avg-cls", cl.AverageCLs(), (0.5 + 0.75 + 0.625/0.75 + 1.125) / 4},
+		{"prob-3s", cl.Prob3S(), 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if math.Abs(tc.got-tc.want) > 1e-12 {
+				t.Fatalf("got=%v, want=%v", tc.got, tc.want)
+			}
+		})
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for an invalid sigma band")
+			}
+		}()
+		_ = cl.ExpectedStatisticB(3)
+	}()
+}
+
+func TestConfidenceLevelFromLimit(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sig     []float64
+		data    []float64
+		exclude bool
+	}{
+		{
+			name:    "excluded",
+			sig:     []float64{10, 20, 10},
+			data:    []float64{10, 10, 10},
+			exclude: true,
+		},
+		{
+			name:    "allowed",
+			sig:     []float64{0.1, 0.2, 0.1},
+			data:    []float64{10, 10, 10},
+			exclude: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := NewLimitDataSource()
+			err := src.AddChannel(
+				newLimitH1(tc.sig...),
+				newLimitH1(10, 10, 10),
+				newLimitH1(tc.data...),
+			)
+			if err != nil {
+				t.Fatalf("could not add channel: %+v", err)
+			}
+
+			cl, err := ComputeLimit(src, 10000, false, rand.NewSource(1234))
+			if err != nil {
+				t.Fatalf("could not compute limit: %+v", err)
+			}
+
+			if got, want := cl.NMC(), 10000; got != want {
+				t.Fatalf("invalid nmc: got=%d, want=%d", got, want)
+			}
+			if got, want := cl.Dtot(), 30; got != want {
+				t.Fatalf("invalid dtot: got=%d, want=%d", got, want)
+			}
+
+			cls := cl.CLs(false)
+			if got, want := cls < 0.05, tc.exclude; got != want {
+				t.Fatalf("invalid exclusion: cls=%v", cls)
+			}
+
+			for sigma := -2; sigma < 2; sigma++ {
+				lo := cl.ExpectedStatisticB(sigma)
+				hi := cl.ExpectedStatisticB(sigma + 1)
+				if lo > hi {
+					t.Fatalf("invalid expected bands ordering at sigma=%d: %v > %v", sigma, lo, hi)
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package rhist
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 
 	"go-hep.org/x/hep/groot/rbase"
@@ -14,6 +15,8 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
 // Efficiency handles efficiency histograms.
@@ -37,6 +40,125 @@ type Efficiency struct {
 	weight     float64 // weight for all events (default = 1)
 }
 
+// EffStatOption describes how the confidence intervals of an Efficiency
+// are computed.
+type EffStatOption int32
+
+const (
+	EffClopperPearson EffStatOption = iota // Clopper-Pearson interval (ROOT's kFCP)
+	EffNormal                              // normal approximation (ROOT's kFNormal)
+	EffWilson                              // Wilson interval (ROOT's kFWilson)
+	EffAgrestiCoull                        // Agresti-Coull interval (ROOT's kFAC)
+	EffFeldmanCousins                      // Feldman-Cousins interval (ROOT's kFFC), not supported
+	EffJeffrey                             // Bayesian interval with Jeffrey's prior (ROOT's kBJeffrey)
+	EffUniform                             // Bayesian interval with a uniform prior (ROOT's kBUniform)
+	EffBayesian                            // Bayesian interval with a user-defined Beta prior (ROOT's kBBayesian)
+	EffMidP                                // mid-P Lancaster interval (ROOT's kMidP), not supported
+)
+
+func (opt EffStatOption) isBayesian() bool {
+	switch opt {
+	case EffJeffrey, EffUniform, EffBayesian:
+		return true
+	}
+	return false
+}
+
+// NewEfficiency creates a new 1-dim efficiency with nbins equally spaced
+// bins between xmin and xmax.
+//
+// Confidence intervals are computed with the Clopper-Pearson method and a
+// confidence level of 0.683 (1 sigma), as in ROOT.
+func NewEfficiency(name, title string, nbins int, xmin, xmax float64) *Efficiency {
+	newH1 := func(kind string) *H1D {
+		h := hbook.NewH1D(nbins, xmin, xmax)
+		h.Annotation()["name"] = name + "_" + kind
+		h.Annotation()["title"] = title + " (" + kind + ")"
+		return NewH1DFrom(h)
+	}
+	return newEfficiency(name, title, newH1("passed"), newH1("total"))
+}
+
+// NewEfficiencyFrom creates a new 1-dim efficiency from the provided
+// histograms of passed and total events.
+// Both histograms must have the same binning and, for each bin, the
+// content of the passed histogram can not exceed the one of the total
+// histogram.
+func NewEfficiencyFrom(passed, total *hbook.H1D) (*Efficiency, error) {
+	var (
+		pbins = passed.Binning.Bins
+		tbins = total.Binning.Bins
+	)
+	if len(pbins) != len(tbins) {
+		return nil, fmt.Errorf(
+			"rhist: inconsistent number of bins (passed=%d, total=%d)",
+			len(pbins), len(tbins),
+		)
+	}
+	for i := range pbins {
+		var (
+			pb = pbins[i]
+			tb = tbins[i]
+		)
+		if pb.XMin() != tb.XMin() || pb.XMax() != tb.XMax() {
+			return nil, fmt.Errorf("rhist: inconsistent binning for bin %d", i+1)
+		}
+		if pb.SumW() > tb.SumW() {
+			return nil, fmt.Errorf(
+				"rhist: passed events exceed total events in bin %d (passed=%v, total=%v)",
+				i+1, pb.SumW(), tb.SumW(),
+			)
+		}
+	}
+	if passed.Binning.Underflow().SumW() > total.Binning.Underflow().SumW() ||
+		passed.Binning.Overflow().SumW() > total.Binning.Overflow().SumW() {
+		return nil, fmt.Errorf("rhist: passed events exceed total events in under/overflow bins")
+	}
+
+	var (
+		name  = total.Name() + "_clone"
+		title = ""
+	)
+	if v, ok := total.Annotation()["title"]; ok && v != nil {
+		title = v.(string)
+	}
+	return newEfficiency(name, title, NewH1DFrom(passed), NewH1DFrom(total)), nil
+}
+
+// NewEfficiencyFromEff1D creates a new 1-dim efficiency from the
+// histograms of passed and total events of the provided hbook efficiency.
+// The name and title of the efficiency are taken from its annotations.
+func NewEfficiencyFromEff1D(eff *hbook.Eff1D) (*Efficiency, error) {
+	o, err := NewEfficiencyFrom(eff.Passed(), eff.Total())
+	if err != nil {
+		return nil, err
+	}
+	o.named.SetName(eff.Name())
+	o.named.SetTitle("")
+	if v, ok := eff.Annotation()["title"]; ok && v != nil {
+		o.named.SetTitle(v.(string))
+	}
+	return o, nil
+}
+
+func newEfficiency(name, title string, passed, total H1) *Efficiency {
+	return &Efficiency{
+		named:     *rbase.NewNamed(name, title),
+		attline:   *rbase.NewAttLine(),
+		attfill:   *rbase.NewAttFill(),
+		attmark:   *rbase.NewAttMarker(),
+		betaAlpha: 1,
+		betaBeta:  1,
+		confLvl:   0.682689492137,
+		funcs:     *rcont.NewList("", nil),
+
+		passedHist: passed,
+		statOpt:    int32(EffClopperPearson),
+		totHist:    total,
+		weight:     1,
+	}
+}
+
 func (*Efficiency) Class() string {
 	return "TEfficiency"
 }
@@ -45,6 +167,315 @@ func (*Efficiency) RVersion() int16 {
 	return rvers.Efficiency
 }
 
+// Name returns the name of the instance
+func (o *Efficiency) Name() string {
+	return o.named.Name()
+}
+
+// Title returns the title of the instance
+func (o *Efficiency) Title() string {
+	return o.named.Title()
+}
+
+// Passed returns the histogram of passed events.
+func (o *Efficiency) Passed() H1 {
+	return o.passedHist
+}
+
+// Total returns the histogram of total events.
+func (o *Efficiency) Total() H1 {
+	return o.totHist
+}
+
+// ConfidenceLevel returns the confidence level used to compute the
+// confidence intervals.
+func (o *Efficiency) ConfidenceLevel() float64 {
+	return o.confLvl
+}
+
+// SetConfidenceLevel sets the confidence level used to compute the
+// confidence intervals.
+// SetConfidenceLevel panics if the level is not in ]0,1[.
+func (o *Efficiency) SetConfidenceLevel(lvl float64) {
+	if !(0 < lvl && lvl < 1) {
+		panic(fmt.Errorf("rhist: invalid confidence level %v", lvl))
+	}
+	o.confLvl = lvl
+}
+
+// StatOption returns how the confidence intervals are computed.
+func (o *Efficiency) StatOption() EffStatOption {
+	return EffStatOption(o.statOpt)
+}
+
+// SetStatOption sets how the confidence intervals are computed.
+// As in ROOT, EffJeffrey and EffUniform also set the parameters of the
+// Beta prior distribution, to (0.5, 0.5) and (1, 1) respectively.
+// SetStatOption panics for the unsupported EffFeldmanCousins and EffMidP
+// options.
+func (o *Efficiency) SetStatOption(opt EffStatOption) {
+	switch opt {
+	case EffClopperPearson, EffNormal, EffWilson, EffAgrestiCoull, EffBayesian:
+		// ok.
+	case EffJeffrey:
+		o.betaAlpha = 0.5
+		o.betaBeta = 0.5
+	case EffUniform:
+		o.betaAlpha = 1
+		o.betaBeta = 1
+	default:
+		panic(fmt.Errorf("rhist: unsupported efficiency statistic option %d", opt))
+	}
+	o.statOpt = int32(opt)
+}
+
+// BetaPrior returns the parameters of the Beta prior distribution used
+// by Bayesian confidence intervals.
+func (o *Efficiency) BetaPrior() (alpha, beta float64) {
+	return o.betaAlpha, o.betaBeta
+}
+
+// SetBetaPrior sets the parameters of the Beta prior distribution used
+// by Bayesian confidence intervals.
+func (o *Efficiency) SetBetaPrior(alpha, beta float64) {
+	o.betaAlpha = alpha
+	o.betaBeta = beta
+}
+
+// betaPrior returns the parameters of the Beta prior distribution for
+// the i-th bin.
+func (o *Efficiency) betaPrior(i int) (alpha, beta float64) {
+	if i < len(o.betaBinParams) {
+		return o.betaBinParams[i][0], o.betaBinParams[i][1]
+	}
+	return o.betaAlpha, o.betaBeta
+}
+
+// Fill fills the efficiency with an event at x, which passed the
+// selection criteria or not.
+func (o *Efficiency) Fill(x float64, passed bool) {
+	o.FillWeighted(x, 1, passed)
+}
+
+// FillWeighted fills the efficiency with an event at x, with weight w,
+// which passed the selection criteria or not.
+func (o *Efficiency) FillWeighted(x, w float64, passed bool) {
+	o.totHist = fillH1(o.totHist, x, w)
+	if passed {
+		o.passedHist = fillH1(o.passedHist, x, w)
+	}
+}
+
+// fillH1 fills h with x and weight w.
+// Histograms that are not a *H1D are converted to *H1D beforehand.
+func fillH1(h H1, x, w float64) *H1D {
+	hh, ok := h.(*H1D)
+	if !ok {
+		hh = NewH1DFrom(h.(interface{ AsH1D() *hbook.H1D }).AsH1D())
+	}
+
+	hh.Fill(x, w)
+	return hh
+}
+
+// counts returns the sum of weights (and of squared weights) of the
+// passed and total events for the i-th bin.
+func (o *Efficiency) counts(i int) (pw, pw2, tw, tw2 float64) {
+	type contenter interface {
+		XBinContent(i int) float64
+	}
+	var (
+		p = o.passedHist.(contenter)
+		t = o.totHist.(contenter)
+	)
+	pw = p.XBinContent(i)
+	tw = t.XBinContent(i)
+	pw2 = pw
+	tw2 = tw
+	if sumw2 := o.passedHist.SumW2s(); i < len(sumw2) {
+		pw2 = sumw2[i]
+	}
+	if sumw2 := o.totHist.SumW2s(); i < len(sumw2) {
+		tw2 = sumw2[i]
+	}
+	return pw, pw2, tw, tw2
+}
+
+// Efficiency returns the efficiency of the i-th bin.
+//
+// Bin 0 is the underflow bin and bin nbins+1 the overflow bin.
+// For Bayesian statistic options, the mean of the posterior distribution
+// is returned.
+func (o *Efficiency) Efficiency(i int) float64 {
+	pw, pw2, tw, tw2 := o.counts(i)
+	if o.StatOption().isBayesian() {
+		alpha, beta := o.betaPrior(i)
+		a, b := effBetaParams(pw, pw2, tw, tw2, alpha, beta)
+		return a / (a + b)
+	}
+	if tw == 0 {
+		return 0
+	}
+	return pw / tw
+}
+
+// ErrorLow returns the lower error on the efficiency of the i-th bin.
+func (o *Efficiency) ErrorLow(i int) float64 {
+	return o.Efficiency(i) - o.bound(i, false)
+}
+
+// ErrorUp returns the upper error on the efficiency of the i-th bin.
+func (o *Efficiency) ErrorUp(i int) float64 {
+	return o.bound(i, true) - o.Efficiency(i)
+}
+
+// bound returns the lower or upper bound of the confidence interval of
+// the efficiency of the i-th bin.
+func (o *Efficiency) bound(i int, upper bool) float64 {
+	var (
+		pw, pw2, tw, tw2 = o.counts(i)
+		opt              = o.StatOption()
+		lvl              = o.confLvl
+	)
+
+	switch {
+	case opt.isBayesian():
+		alpha, beta := o.betaPrior(i)
+		a, b := effBetaParams(pw, pw2, tw, tw2, alpha, beta)
+		return betaCentralInterval(lvl, a, b, upper)
+
+	case tw2 != tw || pw2 != pw:
+		// weighted events: use the normal approximation.
+		eff := o.Efficiency(i)
+		if tw == 0 {
+			return eff
+		}
+		var (
+			variance = (pw2*(1-2*eff) + tw2*eff*eff) / (tw * tw)
+			delta    = distuv.Normal{Mu: 0, Sigma: math.Sqrt(variance)}.Quantile(0.5 * (1 + lvl))
+		)
+		if upper {
+			return math.Min(eff+delta, 1)
+		}
+		return math.Max(eff-delta, 0)
+	}
+
+	switch opt {
+	case EffClopperPearson:
+		return ClopperPearson(tw, pw, lvl, upper)
+	case EffNormal:
+		return effNormal(tw, pw, lvl, upper)
+	case EffWilson:
+		return effWilson(tw, pw, lvl, upper)
+	case EffAgrestiCoull:
+		return effAgrestiCoull(tw, pw, lvl, upper)
+	default:
+		panic(fmt.Errorf("rhist: unsupported efficiency statistic option %d", opt))
+	}
+}
+
+// effBetaParams returns the parameters of the posterior Beta distribution
+// of the efficiency, given the passed and total sums of weights and the
+// prior parameters alpha and beta.
+// Weighted events are taken into account through their effective number.
+func effBetaParams(pw, pw2, tw, tw2, alpha, beta float64) (a, b float64) {
+	if tw2 != tw && tw2 > 0 {
+		norm := tw / tw2
+		return pw*norm + alpha, (tw-pw)*norm + beta
+	}
+	return pw + alpha, tw - pw + beta
+}
+
+// ClopperPearson returns the lower (or upper) bound of the Clopper-Pearson
+// confidence interval, with the given confidence level, of the efficiency
+// of passed events out of total events.
+func ClopperPearson(total, passed, level float64, upper bool) float64 {
+	alpha := 0.5 * (1 - level)
+	if upper {
+		if passed == total {
+			return 1
+		}
+		return distuv.Beta{Alpha: passed + 1, Beta: total - passed}.Quantile(1 - alpha)
+	}
+	if passed == 0 {
+		return 0
+	}
+	return distuv.Beta{Alpha: passed, Beta: total - passed + 1}.Quantile(alpha)
+}
+
+// Bayesian returns the lower (or upper) bound of the central Bayesian
+// confidence interval, with the given confidence level, of the efficiency
+// of passed events out of total events, assuming a Beta(alpha,beta) prior.
+func Bayesian(total, passed, level, alpha, beta float64, upper bool) float64 {
+	return betaCentralInterval(level, passed+alpha, total-passed+beta, upper)
+}
+
+func betaCentralInterval(level, a, b float64, upper bool) float64 {
+	switch {
+	case a <= 0 || b <= 0:
+		// invalid parameters.
+		if upper {
+			return 1
+		}
+		return 0
+	case upper:
+		return distuv.Beta{Alpha: a, Beta: b}.Quantile(0.5 * (1 + level))
+	default:
+		return distuv.Beta{Alpha: a, Beta: b}.Quantile(0.5 * (1 - level))
+	}
+}
+
+func effNormal(total, passed, level float64, upper bool) float64 {
+	if total == 0 {
+		if upper {
+			return 1
+		}
+		return 0
+	}
+	var (
+		eff   = passed / total
+		sigma = math.Sqrt(eff * (1 - eff) / total)
+		delta = distuv.UnitNormal.Quantile(0.5*(1+level)) * sigma
+	)
+	if upper {
+		return math.Min(eff+delta, 1)
+	}
+	return math.Max(eff-delta, 0)
+}
+
+func effWilson(total, passed, level float64, upper bool) float64 {
+	if total == 0 {
+		if upper {
+			return 1
+		}
+		return 0
+	}
+	var (
+		eff   = passed / total
+		kappa = distuv.UnitNormal.Quantile(0.5 * (1 + level))
+		k2    = kappa * kappa
+		mode  = (passed + 0.5*k2) / (total + k2)
+		delta = kappa / (total + k2) * math.Sqrt(total*eff*(1-eff)+0.25*k2)
+	)
+	if upper {
+		return math.Min(mode+delta, 1)
+	}
+	return math.Max(mode-delta, 0)
+}
+
+func effAgrestiCoull(total, passed, level float64, upper bool) float64 {
+	var (
+		kappa = distuv.UnitNormal.Quantile(0.5 * (1 + level))
+		k2    = kappa * kappa
+		mode  = (passed + 0.5*k2) / (total + k2)
+		delta = kappa * math.Sqrt(mode*(1-mode)/(total+k2))
+	)
+	if upper {
+		return math.Min(mode+delta, 1)
+	}
+	return math.Max(mode-delta, 0)
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (o *Efficiency) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -196,6 +627,7 @@ func init() {
 
 var (
 	_ root.Object        = (*Efficiency)(nil)
+	_ root.Named         = (*Efficiency)(nil)
 	_ rbytes.RVersioner  = (*Efficiency)(nil)
 	_ rbytes.Marshaler   = (*Efficiency)(nil)
 	_ rbytes.Unmarshaler = (*Efficiency)(nil)
@@ -0,0 +1,181 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestEfficiencyIntervals(t *testing.T) {
+	const (
+		lvl   = 0.682689492137
+		alpha = 0.5 * (1 - lvl)
+		n     = 10.0
+	)
+
+	for _, tc := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		// closed forms for passed=0 and passed=total.
+		{"cp-low-0", ClopperPearson(n, 0, lvl, false), 0},
+		{"cp-up-0", ClopperPearson(n, 0, lvl, true), 1 - math.Pow(alpha, 1/n)},
+		{"cp-low-n", ClopperPearson(n, n, lvl, false), math.Pow(alpha, 1/n)},
+		{"cp-up-n", ClopperPearson(n, n, lvl, true), 1},
+		{"bayes-up-0", Bayesian(n, 0, lvl, 1, 1, true), 1 - math.Pow(alpha, 1/(n+1))},
+		{"bayes-low-n", Bayesian(n, n, lvl, 1, 1, false), math.Pow(alpha, 1/(n+1))},
+		// symmetry.
+		{"cp-sym", ClopperPearson(n, 3, lvl, false), 1 - ClopperPearson(n, 7, lvl, true)},
+		{"bayes-sym", Bayesian(n, 3, lvl, 0.5, 0.5, false), 1 - Bayesian(n, 7, lvl, 0.5, 0.5, true)},
+		// P(k >= 5 | n=10, p=low) = P(k <= 5 | n=10, p=up) = alpha.
+		{"cp-low-5", ClopperPearson(n, 5, lvl, false), 0.3048178830},
+		{"cp-up-5", ClopperPearson(n, 5, lvl, true), 0.6951821170},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if math.Abs(tc.got-tc.want) > 1e-6 {
+				t.Fatalf("invalid value: got=%v, want=%v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEfficiencyFill(t *testing.T) {
+	eff := NewEfficiency("eff", "efficiency", 4, 0, 4)
+	for i, x := range []float64{-1, 0.5, 0.5, 1.5, 1.5, 1.5, 1.5, 3.5, 4} {
+		eff.Fill(x, i%2 == 0)
+	}
+
+	for _, tc := range []struct {
+		bin    int
+		passed float64
+		total  float64
+	}{
+		{0, 1, 1},
+		{1, 1, 2},
+		{2, 2, 4},
+		{3, 0, 0},
+		{4, 0, 1},
+		{5, 1, 1},
+	} {
+		pw, _, tw, _ := eff.counts(tc.bin)
+		if pw != tc.passed || tw != tc.total {
+			t.Fatalf("bin %d: invalid counts: got=(%v, %v), want=(%v, %v)", tc.bin, pw, tw, tc.passed, tc.total)
+		}
+	}
+
+	if got, want := eff.Efficiency(2), 0.5; got != want {
+		t.Fatalf("invalid efficiency: got=%v, want=%v", got, want)
+	}
+	if got, want := eff.ErrorUp(2), ClopperPearson(4, 2, eff.ConfidenceLevel(), true)-0.5; got != want {
+		t.Fatalf("invalid upper error: got=%v, want=%v", got, want)
+	}
+
+	eff.SetStatOption(EffUniform)
+	if got, want := eff.Efficiency(4), 1.0/3; got != want {
+		t.Fatalf("invalid bayesian efficiency: got=%v, want=%v", got, want)
+	}
+	if got, want := eff.ErrorLow(4), 1.0/3-Bayesian(1, 0, eff.ConfidenceLevel(), 1, 1, false); got != want {
+		t.Fatalf("invalid bayesian lower error: got=%v, want=%v", got, want)
+	}
+
+	fname := filepath.Join(t.TempDir(), "eff.root")
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	err = f.Put("eff", eff)
+	if err != nil {
+		t.Fatalf("could not write efficiency: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	obj, err := r.Get("eff")
+	if err != nil {
+		t.Fatalf("could not read efficiency: %+v", err)
+	}
+	got := obj.(*Efficiency)
+	if got.StatOption() != EffUniform {
+		t.Fatalf("invalid stat option: got=%d, want=%d", got.StatOption(), EffUniform)
+	}
+	for i := 0; i < 6; i++ {
+		if g, w := got.Efficiency(i), eff.Efficiency(i); g != w {
+			t.Fatalf("bin %d: invalid efficiency: got=%v, want=%v", i, g, w)
+		}
+		if g, w := got.ErrorUp(i), eff.ErrorUp(i); g != w {
+			t.Fatalf("bin %d: invalid upper error: got=%v, want=%v", i, g, w)
+		}
+	}
+}
+
+func TestEfficiencyFrom(t *testing.T) {
+	var (
+		passed = hbook.NewH1D(2, 0, 2)
+		total  = hbook.NewH1D(2, 0, 2)
+	)
+	passed.Fill(0.5, 1)
+	total.Fill(0.5, 1)
+	total.Fill(1.5, 1)
+
+	eff, err := NewEfficiencyFrom(passed, total)
+	if err != nil {
+		t.Fatalf("could not create efficiency: %+v", err)
+	}
+	if got, want := eff.Efficiency(1), 1.0; got != want {
+		t.Fatalf("invalid efficiency: got=%v, want=%v", got, want)
+	}
+
+	_, err = NewEfficiencyFrom(total, passed)
+	if err == nil {
+		t.Fatalf("expected an error for passed > total")
+	}
+
+	_, err = NewEfficiencyFrom(passed, hbook.NewH1D(3, 0, 2))
+	if err == nil {
+		t.Fatalf("expected an error for inconsistent binning")
+	}
+}
+
+func TestEfficiencyFromEff1D(t *testing.T) {
+	e := hbook.NewEff1D(2, 0, 2)
+	e.Annotation()["name"] = "eff"
+	e.Annotation()["title"] = "my efficiency"
+	e.Fill(0.5, true, 1)
+	e.Fill(0.5, false, 1)
+	e.Fill(1.5, true, 1)
+
+	eff, err := NewEfficiencyFromEff1D(e)
+	if err != nil {
+		t.Fatalf("could not create efficiency: %+v", err)
+	}
+	if got, want := eff.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := eff.Title(), "my efficiency"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+	for i, want := range []float64{0, 0.5, 1, 0} {
+		if got := eff.Efficiency(i); got != want {
+			t.Fatalf("bin %d: invalid efficiency: got=%v, want=%v", i, got, want)
+		}
+	}
+}
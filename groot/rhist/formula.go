@@ -7,6 +7,8 @@ package rhist
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
@@ -35,6 +37,103 @@ func newFormula() *Formula {
 	}
 }
 
+// NewFormula creates a new formula from the provided TFormula expression and
+// the initial values of its parameters.
+//
+// Parameters are written as [name], [pN] or [N] in the expression.
+// Like ROOT, the predefined functions gaus, expo and polN are expanded
+// into their explicit expressions.
+func NewFormula(name, expr string, params ...float64) (*Formula, error) {
+	expr, names := expandFormula(expr)
+	expr = strings.Join(strings.Fields(expr), "")
+
+	toks, err := ftokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pars = make(map[string]int32)
+		used = make(map[int32]bool)
+		rest []string
+	)
+	for i, tok := range toks {
+		if tok.kind != ftokParam {
+			continue
+		}
+		if i > 0 && toks[i-1].kind == ftokIdent && toks[i-1].str == "x" {
+			// x[i] is the i-th variable.
+			continue
+		}
+		switch i, err := strconv.Atoi(strings.TrimPrefix(tok.str, "p")); {
+		case err == nil && i >= 0:
+			pars["p"+strconv.Itoa(i)] = int32(i)
+			used[int32(i)] = true
+		default:
+			rest = append(rest, tok.str)
+		}
+	}
+	var next int32
+	for _, name := range append(names, rest...) {
+		if _, dup := pars[name]; dup {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		pars[name] = next
+		used[next] = true
+	}
+
+	// like ROOT, store numbered parameters as [pN].
+	o := new(strings.Builder)
+	for _, tok := range strings.SplitAfter(expr, "]") {
+		beg := strings.LastIndex(tok, "[")
+		if beg < 0 {
+			o.WriteString(tok)
+			continue
+		}
+		if strings.HasSuffix(tok[:beg], "x") {
+			o.WriteString(tok)
+			continue
+		}
+		if _, err := strconv.Atoi(tok[beg+1 : len(tok)-1]); err == nil {
+			tok = tok[:beg+1] + "p" + tok[beg+1:]
+		}
+		o.WriteString(tok)
+	}
+	expr = o.String()
+
+	ex, err := compileFormula(expr, pars)
+	if err != nil {
+		return nil, err
+	}
+	if ex.npar != len(pars) {
+		return nil, fmt.Errorf("rhist: invalid formula %q: non-contiguous parameter indices", expr)
+	}
+	if len(params) > len(pars) {
+		return nil, fmt.Errorf(
+			"rhist: too many parameter values for formula %q (got=%d, want=%d)",
+			expr, len(params), len(pars),
+		)
+	}
+
+	f := newFormula()
+	f.named.SetName(name)
+	f.named.SetTitle(expr)
+	f.clingParams = make([]float64, len(pars))
+	copy(f.clingParams, params)
+	f.allParamsSet = len(params) == len(pars)
+	f.params = pars
+	f.formula = expr
+	f.ndim = int32(ex.ndim)
+	f.linearParts = []root.Object{}
+	if f.ndim == 0 {
+		f.ndim = 1
+	}
+	return f, nil
+}
+
 func (*Formula) RVersion() int16 {
 	return rvers.Formula
 }
@@ -53,6 +152,91 @@ func (f *Formula) Title() string {
 	return f.named.Title()
 }
 
+// Expr returns the expression of the formula.
+func (f *Formula) Expr() string {
+	return f.formula
+}
+
+// NDim returns the number of variables of the formula.
+func (f *Formula) NDim() int {
+	return int(f.ndim)
+}
+
+// NPar returns the number of parameters of the formula.
+func (f *Formula) NPar() int {
+	return len(f.clingParams)
+}
+
+// Param returns the value of the i-th parameter.
+func (f *Formula) Param(i int) float64 {
+	return f.clingParams[i]
+}
+
+// SetParam sets the value of the i-th parameter.
+func (f *Formula) SetParam(i int, v float64) {
+	f.clingParams[i] = v
+}
+
+// ParamName returns the name of the i-th parameter.
+func (f *Formula) ParamName(i int) string {
+	for k, v := range f.params {
+		if int(v) == i {
+			return k
+		}
+	}
+	return "p" + strconv.Itoa(i)
+}
+
+// Func compiles the expression of the formula and returns a function
+// evaluating it at the provided values of the variables, with the current
+// values of the parameters.
+// The returned function panics if it is provided with less than NDim values.
+func (f *Formula) Func() (func(xs ...float64) float64, error) {
+	ex, err := f.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ps   = append([]float64(nil), f.clingParams...)
+		ndim = ex.ndim
+	)
+	return func(xs ...float64) float64 {
+		if len(xs) < ndim {
+			panic(fmt.Errorf("rhist: formula %q needs %d variables (got=%d)", f.formula, ndim, len(xs)))
+		}
+		return ex.eval(xs, ps)
+	}, nil
+}
+
+// Eval evaluates the formula at the provided values of the variables.
+// Eval compiles the expression at each call: Func should be used
+// to repeatedly evaluate the formula.
+func (f *Formula) Eval(xs ...float64) (float64, error) {
+	ex, err := f.compile()
+	if err != nil {
+		return 0, err
+	}
+	if len(xs) < ex.ndim {
+		return 0, fmt.Errorf("rhist: formula %q needs %d variables (got=%d)", f.formula, ex.ndim, len(xs))
+	}
+	return ex.eval(xs, f.clingParams), nil
+}
+
+func (f *Formula) compile() (*fexpr, error) {
+	ex, err := compileFormula(f.formula, f.params)
+	if err != nil {
+		return nil, err
+	}
+	if ex.npar > len(f.clingParams) {
+		return nil, fmt.Errorf(
+			"rhist: formula %q needs %d parameters (got=%d)",
+			f.formula, ex.npar, len(f.clingParams),
+		)
+	}
+	return ex, nil
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (f *Formula) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
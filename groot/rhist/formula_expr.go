@@ -0,0 +1,646 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// fnode is a compiled node of a TFormula expression.
+// xs holds the values of the variables, ps the values of the parameters.
+type fnode func(xs, ps []float64) float64
+
+// fexpr is a compiled TFormula expression.
+type fexpr struct {
+	eval fnode
+	ndim int // number of variables used by the expression
+	npar int // number of parameters used by the expression
+}
+
+// fparser is a recursive descent parser for the subset of C++ expressions
+// understood by TFormula.
+type fparser struct {
+	src  string
+	toks []ftoken
+	pos  int
+
+	params map[string]int32 // names of parameters
+	ndim   int
+	npar   int
+}
+
+type ftokenKind int
+
+const (
+	ftokEOF ftokenKind = iota
+	ftokNum
+	ftokIdent
+	ftokParam
+	ftokOp
+)
+
+type ftoken struct {
+	kind ftokenKind
+	str  string
+	num  float64
+}
+
+// compileFormula compiles the provided TFormula expression.
+// Parameters, written as [name], are resolved using the params map.
+func compileFormula(expr string, params map[string]int32) (*fexpr, error) {
+	toks, err := ftokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := fparser{
+		src:    expr,
+		toks:   toks,
+		params: params,
+	}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != ftokEOF {
+		return nil, p.errorf("unexpected token %q", tok.str)
+	}
+
+	return &fexpr{eval: node, ndim: p.ndim, npar: p.npar}, nil
+}
+
+func ftokenize(expr string) ([]ftoken, error) {
+	var (
+		toks []ftoken
+		rs   = []rune(expr)
+	)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			beg := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			if i < len(rs) && (rs[i] == 'e' || rs[i] == 'E') {
+				j := i + 1
+				if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+					j++
+				}
+				if j < len(rs) && unicode.IsDigit(rs[j]) {
+					i = j
+					for i < len(rs) && unicode.IsDigit(rs[i]) {
+						i++
+					}
+				}
+			}
+			str := string(rs[beg:i])
+			v, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, fmt.Errorf("rhist: invalid number %q in formula %q", str, expr)
+			}
+			toks = append(toks, ftoken{kind: ftokNum, str: str, num: v})
+
+		case unicode.IsLetter(r) || r == '_':
+			beg := i
+			for i < len(rs) {
+				if unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_' {
+					i++
+					continue
+				}
+				if rs[i] == ':' && i+2 < len(rs) && rs[i+1] == ':' {
+					i += 2
+					continue
+				}
+				break
+			}
+			toks = append(toks, ftoken{kind: ftokIdent, str: string(rs[beg:i])})
+
+		case r == '[':
+			end := i + 1
+			for end < len(rs) && rs[end] != ']' {
+				end++
+			}
+			if end == len(rs) {
+				return nil, fmt.Errorf("rhist: unbalanced '[' in formula %q", expr)
+			}
+			name := strings.TrimSpace(string(rs[i+1 : end]))
+			toks = append(toks, ftoken{kind: ftokParam, str: name})
+			i = end + 1
+
+		default:
+			op := string(r)
+			if i+1 < len(rs) {
+				switch two := string(rs[i : i+2]); two {
+				case "<=", ">=", "==", "!=", "&&", "||":
+					op = two
+				case "**":
+					op = "^"
+					i++
+				}
+			}
+			switch op {
+			case "+", "-", "*", "/", "%", "^", "(", ")", ",", "<", ">", "!",
+				"<=", ">=", "==", "!=", "&&", "||":
+			default:
+				return nil, fmt.Errorf("rhist: invalid character %q in formula %q", op, expr)
+			}
+			toks = append(toks, ftoken{kind: ftokOp, str: op})
+			i += len(op)
+		}
+	}
+	return append(toks, ftoken{kind: ftokEOF}), nil
+}
+
+func (p *fparser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("rhist: invalid formula %q: %s", p.src, fmt.Sprintf(format, args...))
+}
+
+func (p *fparser) peek() ftoken {
+	return p.toks[p.pos]
+}
+
+func (p *fparser) next() ftoken {
+	tok := p.toks[p.pos]
+	if tok.kind != ftokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *fparser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == ftokOp && tok.str == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *fparser) expect(op string) error {
+	if !p.accept(op) {
+		return p.errorf("expected %q, got %q", op, p.peek().str)
+	}
+	return nil
+}
+
+// fbinary parses a left-associative sequence of binary operations.
+func (p *fparser) fbinary(sub func() (fnode, error), ops map[string]func(a, b float64) float64) (fnode, error) {
+	lhs, err := sub()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		op, ok := ops[tok.str]
+		if tok.kind != ftokOp || !ok {
+			return lhs, nil
+		}
+		p.next()
+		rhs, err := sub()
+		if err != nil {
+			return nil, err
+		}
+		l := lhs
+		lhs = func(xs, ps []float64) float64 {
+			return op(l(xs, ps), rhs(xs, ps))
+		}
+	}
+}
+
+func fbool(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func (p *fparser) parseExpr() (fnode, error) {
+	return p.fbinary(p.parseAnd, map[string]func(a, b float64) float64{
+		"||": func(a, b float64) float64 { return fbool(a != 0 || b != 0) },
+	})
+}
+
+func (p *fparser) parseAnd() (fnode, error) {
+	return p.fbinary(p.parseEq, map[string]func(a, b float64) float64{
+		"&&": func(a, b float64) float64 { return fbool(a != 0 && b != 0) },
+	})
+}
+
+func (p *fparser) parseEq() (fnode, error) {
+	return p.fbinary(p.parseCmp, map[string]func(a, b float64) float64{
+		"==": func(a, b float64) float64 { return fbool(a == b) },
+		"!=": func(a, b float64) float64 { return fbool(a != b) },
+	})
+}
+
+func (p *fparser) parseCmp() (fnode, error) {
+	return p.fbinary(p.parseAdd, map[string]func(a, b float64) float64{
+		"<":  func(a, b float64) float64 { return fbool(a < b) },
+		"<=": func(a, b float64) float64 { return fbool(a <= b) },
+		">":  func(a, b float64) float64 { return fbool(a > b) },
+		">=": func(a, b float64) float64 { return fbool(a >= b) },
+	})
+}
+
+func (p *fparser) parseAdd() (fnode, error) {
+	return p.fbinary(p.parseMul, map[string]func(a, b float64) float64{
+		"+": func(a, b float64) float64 { return a + b },
+		"-": func(a, b float64) float64 { return a - b },
+	})
+}
+
+func (p *fparser) parseMul() (fnode, error) {
+	return p.fbinary(p.parseUnary, map[string]func(a, b float64) float64{
+		"*": func(a, b float64) float64 { return a * b },
+		"/": func(a, b float64) float64 { return a / b },
+		"%": math.Mod,
+	})
+}
+
+func (p *fparser) parseUnary() (fnode, error) {
+	switch {
+	case p.accept("-"):
+		v, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(xs, ps []float64) float64 { return -v(xs, ps) }, nil
+	case p.accept("+"):
+		return p.parseUnary()
+	case p.accept("!"):
+		v, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(xs, ps []float64) float64 { return fbool(v(xs, ps) == 0) }, nil
+	}
+	return p.parsePow()
+}
+
+// parsePow parses a right-associative exponentiation, which binds
+// tighter than unary operators: -x^2 == -(x^2).
+func (p *fparser) parsePow() (fnode, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("^") {
+		return base, nil
+	}
+	exp, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(xs, ps []float64) float64 {
+		return math.Pow(base(xs, ps), exp(xs, ps))
+	}, nil
+}
+
+func (p *fparser) parsePrimary() (fnode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case ftokNum:
+		v := tok.num
+		return func([]float64, []float64) float64 { return v }, nil
+
+	case ftokParam:
+		i, err := p.param(tok.str)
+		if err != nil {
+			return nil, err
+		}
+		return func(_, ps []float64) float64 { return ps[i] }, nil
+
+	case ftokIdent:
+		if p.accept("(") {
+			return p.parseCall(tok.str)
+		}
+		return p.ident(tok.str)
+
+	case ftokOp:
+		if tok.str == "(" {
+			v, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+		return nil, p.errorf("unexpected operator %q", tok.str)
+	}
+
+	return nil, p.errorf("unexpected end of expression")
+}
+
+// param returns the index of the named parameter.
+func (p *fparser) param(name string) (int, error) {
+	i := -1
+	switch idx, ok := p.params[name]; {
+	case ok:
+		i = int(idx)
+	default:
+		str := strings.TrimPrefix(name, "p")
+		v, err := strconv.Atoi(str)
+		if err != nil || v < 0 {
+			return 0, p.errorf("unknown parameter [%s]", name)
+		}
+		i = v
+	}
+	if i+1 > p.npar {
+		p.npar = i + 1
+	}
+	return i, nil
+}
+
+// variable returns a node evaluating the i-th variable.
+func (p *fparser) variable(i int) fnode {
+	if i+1 > p.ndim {
+		p.ndim = i + 1
+	}
+	return func(xs, _ []float64) float64 { return xs[i] }
+}
+
+func (p *fparser) ident(name string) (fnode, error) {
+	switch name {
+	case "x":
+		if p.peek().kind == ftokParam {
+			tok := p.next()
+			i, err := strconv.Atoi(tok.str)
+			if err != nil || i < 0 || i > 3 {
+				return nil, p.errorf("invalid variable x[%s]", tok.str)
+			}
+			return p.variable(i), nil
+		}
+		return p.variable(0), nil
+	case "y":
+		return p.variable(1), nil
+	case "z":
+		return p.variable(2), nil
+	case "t":
+		return p.variable(3), nil
+	}
+
+	if v, ok := fconsts[strings.TrimPrefix(name, "TMath::")]; ok {
+		return func([]float64, []float64) float64 { return v }, nil
+	}
+	return nil, p.errorf("unknown identifier %q", name)
+}
+
+func (p *fparser) parseCall(name string) (fnode, error) {
+	var args []fnode
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.accept(")") {
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if v, ok := fconsts[strings.TrimPrefix(name, "TMath::")]; ok && len(args) == 0 {
+		return func([]float64, []float64) float64 { return v }, nil
+	}
+
+	fct, ok := ffuncs[name]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+	if fct.nargs > 0 && len(args) != fct.nargs {
+		return nil, p.errorf("function %q expects %d argument(s), got %d", name, fct.nargs, len(args))
+	}
+	if fct.nargs < 0 && len(args) < -fct.nargs {
+		return nil, p.errorf("function %q expects at least %d argument(s), got %d", name, -fct.nargs, len(args))
+	}
+
+	switch len(args) {
+	case 1:
+		var (
+			f = fct.fct
+			a = args[0]
+		)
+		return func(xs, ps []float64) float64 {
+			return f(a(xs, ps))
+		}, nil
+	case 2:
+		var (
+			f    = fct.fct
+			a, b = args[0], args[1]
+		)
+		return func(xs, ps []float64) float64 {
+			return f(a(xs, ps), b(xs, ps))
+		}, nil
+	}
+
+	f := fct.fct
+	return func(xs, ps []float64) float64 {
+		vs := make([]float64, len(args))
+		for i, arg := range args {
+			vs[i] = arg(xs, ps)
+		}
+		return f(vs...)
+	}, nil
+}
+
+var fconsts = map[string]float64{
+	"pi":       math.Pi,
+	"Pi":       math.Pi,
+	"e":        math.E,
+	"E":        math.E,
+	"sqrt2":    math.Sqrt2,
+	"Sqrt2":    math.Sqrt2,
+	"Ln10":     math.Ln10,
+	"C":        299792458,
+	"Infinity": math.Inf(+1),
+}
+
+type ffunc struct {
+	nargs int // number of arguments (-n means at least n arguments.)
+	fct   func(vs ...float64) float64
+}
+
+func ffunc1(f func(float64) float64) ffunc {
+	return ffunc{nargs: 1, fct: func(vs ...float64) float64 { return f(vs[0]) }}
+}
+
+func ffunc2(f func(a, b float64) float64) ffunc {
+	return ffunc{nargs: 2, fct: func(vs ...float64) float64 { return f(vs[0], vs[1]) }}
+}
+
+var ffuncs = make(map[string]ffunc)
+
+func init() {
+	for _, v := range []struct {
+		names []string
+		fct   ffunc
+	}{
+		{[]string{"sin", "TMath::Sin"}, ffunc1(math.Sin)},
+		{[]string{"cos", "TMath::Cos"}, ffunc1(math.Cos)},
+		{[]string{"tan", "TMath::Tan"}, ffunc1(math.Tan)},
+		{[]string{"asin", "TMath::ASin"}, ffunc1(math.Asin)},
+		{[]string{"acos", "TMath::ACos"}, ffunc1(math.Acos)},
+		{[]string{"atan", "TMath::ATan"}, ffunc1(math.Atan)},
+		{[]string{"atan2", "TMath::ATan2"}, ffunc2(math.Atan2)},
+		{[]string{"sinh", "TMath::SinH"}, ffunc1(math.Sinh)},
+		{[]string{"cosh", "TMath::CosH"}, ffunc1(math.Cosh)},
+		{[]string{"tanh", "TMath::TanH"}, ffunc1(math.Tanh)},
+		{[]string{"asinh", "TMath::ASinH"}, ffunc1(math.Asinh)},
+		{[]string{"acosh", "TMath::ACosH"}, ffunc1(math.Acosh)},
+		{[]string{"atanh", "TMath::ATanH"}, ffunc1(math.Atanh)},
+		{[]string{"exp", "TMath::Exp"}, ffunc1(math.Exp)},
+		{[]string{"log", "TMath::Log"}, ffunc1(math.Log)},
+		{[]string{"log10", "TMath::Log10"}, ffunc1(math.Log10)},
+		{[]string{"log2", "TMath::Log2"}, ffunc1(math.Log2)},
+		{[]string{"sqrt", "TMath::Sqrt"}, ffunc1(math.Sqrt)},
+		{[]string{"sq", "TMath::Sq"}, ffunc1(func(x float64) float64 { return x * x })},
+		{[]string{"abs", "fabs", "TMath::Abs"}, ffunc1(math.Abs)},
+		{[]string{"floor", "TMath::Floor"}, ffunc1(math.Floor)},
+		{[]string{"ceil", "TMath::Ceil"}, ffunc1(math.Ceil)},
+		{[]string{"erf", "TMath::Erf"}, ffunc1(math.Erf)},
+		{[]string{"erfc", "TMath::Erfc"}, ffunc1(math.Erfc)},
+		{[]string{"sign", "TMath::Sign"}, ffunc2(math.Copysign)},
+		{[]string{"pow", "TMath::Power"}, ffunc2(math.Pow)},
+		{[]string{"fmod"}, ffunc2(math.Mod)},
+		{[]string{"min", "TMath::Min"}, ffunc2(math.Min)},
+		{[]string{"max", "TMath::Max"}, ffunc2(math.Max)},
+		{[]string{"TMath::Gaus"}, ffunc{nargs: -1, fct: tmathGaus}},
+	} {
+		for _, name := range v.names {
+			ffuncs[name] = v.fct
+		}
+	}
+}
+
+// tmathGaus implements TMath::Gaus(x, mean=0, sigma=1, norm=false).
+func tmathGaus(vs ...float64) float64 {
+	var (
+		x     = vs[0]
+		mean  = 0.0
+		sigma = 1.0
+		norm  = false
+	)
+	switch len(vs) {
+	case 4:
+		norm = vs[3] != 0
+		fallthrough
+	case 3:
+		sigma = vs[2]
+		fallthrough
+	case 2:
+		mean = vs[1]
+	}
+	if sigma == 0 {
+		return 1e30
+	}
+	arg := (x - mean) / sigma
+	if arg < -39 || arg > 39 {
+		return 0
+	}
+	v := math.Exp(-0.5 * arg * arg)
+	if norm {
+		v /= 2.50662827463100024 * sigma // sqrt(2*pi)*sigma
+	}
+	return v
+}
+
+var fshorthandRE = regexp.MustCompile(`\b(gaus|expo|pol([0-9]+))\b(\(([0-9]+)\))?`)
+
+// expandFormula expands the predefined functions (gaus, expo, polN) of a
+// TFormula expression, as ROOT does.
+// When the expression is made of a single predefined function, its parameters
+// are named after ROOT's conventions (Constant, Mean, Sigma, Slope, p0, ...).
+// Otherwise, parameters are numbered, starting from the offset given between
+// parentheses (e.g. "gaus(0)+pol1(3)") or after the parameters of the
+// previous predefined function.
+func expandFormula(expr string) (string, []string) {
+	expr = strings.TrimSpace(expr)
+	var (
+		next   = 0
+		named  = false
+		names  []string
+		loc    = fshorthandRE.FindAllStringSubmatchIndex(expr, -1)
+		single = len(loc) == 1 && loc[0][0] == 0 && loc[0][1] == len(expr) && loc[0][6] < 0
+	)
+	if len(loc) == 0 {
+		return expr, nil
+	}
+	if single {
+		named = true
+	}
+
+	var (
+		o   strings.Builder
+		beg = 0
+	)
+	for _, m := range loc {
+		o.WriteString(expr[beg:m[0]])
+		beg = m[1]
+
+		off := next
+		if m[8] >= 0 {
+			off, _ = strconv.Atoi(expr[m[8]:m[9]])
+		}
+		par := func(i int, name string) string {
+			if named {
+				names = append(names, name)
+				return "[" + name + "]"
+			}
+			return "[p" + strconv.Itoa(off+i) + "]"
+		}
+
+		var npar int
+		switch fct := expr[m[2]:m[3]]; {
+		case fct == "gaus":
+			var (
+				c = par(0, "Constant")
+				m = par(1, "Mean")
+				s = par(2, "Sigma")
+			)
+			fmt.Fprintf(&o, "%s*exp(-0.5*((x-%[2]s)/%[3]s)*((x-%[2]s)/%[3]s))", c, m, s)
+			npar = 3
+		case fct == "expo":
+			fmt.Fprintf(&o, "exp(%s+%s*x)", par(0, "Constant"), par(1, "Slope"))
+			npar = 2
+		default:
+			n, _ := strconv.Atoi(expr[m[4]:m[5]])
+			o.WriteString("(")
+			for i := 0; i <= n; i++ {
+				if i > 0 {
+					o.WriteString("+")
+				}
+				o.WriteString(par(i, "p"+strconv.Itoa(i)))
+				switch i {
+				case 0:
+				case 1:
+					o.WriteString("*x")
+				case 2:
+					o.WriteString("*TMath::Sq(x)")
+				default:
+					fmt.Fprintf(&o, "*TMath::Power(x,%d)", i)
+				}
+			}
+			o.WriteString(")")
+			npar = n + 1
+		}
+		next = off + npar
+	}
+	o.WriteString(expr[beg:])
+	return o.String(), names
+}
@@ -59,6 +59,42 @@ func newF1() *F1 {
 	}
 }
 
+// NewF1 creates a new 1-dim function, defined over [xmin, xmax], from the
+// provided TFormula expression and the initial values of its parameters.
+func NewF1(name, expr string, xmin, xmax float64, params ...float64) (*F1, error) {
+	formula, err := NewFormula(name, expr, params...)
+	if err != nil {
+		return nil, fmt.Errorf("rhist: could not create TF1 formula: %w", err)
+	}
+	if formula.NDim() != 1 {
+		return nil, fmt.Errorf("rhist: invalid TF1 formula %q (ndim=%d)", expr, formula.NDim())
+	}
+
+	f := newF1()
+	f.initFormula(name, expr, formula)
+	f.xmin = xmin
+	f.xmax = xmax
+	return f, nil
+}
+
+func (f *F1) initFormula(name, title string, formula *Formula) {
+	npar := formula.NPar()
+
+	f.named.SetName(name)
+	f.named.SetTitle(title)
+	f.attline = rbase.AttLine{Color: 2, Style: 1, Width: 2}
+	f.attfill = rbase.AttFill{Color: 19, Style: 0}
+	f.npar = int32(npar)
+	f.ndim = formula.ndim
+	f.npx = 100
+	f.fmin = -1111
+	f.fmax = -1111
+	f.parErrs = make([]float64, npar)
+	f.parMin = make([]float64, npar)
+	f.parMax = make([]float64, npar)
+	f.formula = formula
+}
+
 func (*F1) RVersion() int16 {
 	return rvers.F1
 }
@@ -77,6 +113,128 @@ func (f *F1) Title() string {
 	return f.named.Title()
 }
 
+// Formula returns the formula of the function, if any.
+// Functions defined from C++ code have no formula.
+func (f *F1) Formula() *Formula {
+	return f.formula
+}
+
+// AttLine returns the line attributes of the function.
+func (f *F1) AttLine() *rbase.AttLine { return &f.attline }
+
+// Range returns the range of the function.
+func (f *F1) Range() (xmin, xmax float64) {
+	return f.xmin, f.xmax
+}
+
+// NPar returns the number of parameters of the function.
+func (f *F1) NPar() int {
+	return int(f.npar)
+}
+
+// Param returns the value of the i-th parameter.
+func (f *F1) Param(i int) float64 {
+	switch {
+	case f.formula != nil:
+		return f.formula.Param(i)
+	case f.params != nil:
+		return f.params.params[i]
+	}
+	panic(fmt.Errorf("rhist: TF1 %q has no parameters", f.Name()))
+}
+
+// SetParam sets the value of the i-th parameter.
+func (f *F1) SetParam(i int, v float64) {
+	switch {
+	case f.formula != nil:
+		f.formula.SetParam(i, v)
+	case f.params != nil:
+		f.params.params[i] = v
+	default:
+		panic(fmt.Errorf("rhist: TF1 %q has no parameters", f.Name()))
+	}
+}
+
+// ParError returns the error on the i-th parameter.
+func (f *F1) ParError(i int) float64 {
+	return f.parErrs[i]
+}
+
+// Chi2 returns the chi-square of the fit of the function.
+func (f *F1) Chi2() float64 {
+	return f.chi2
+}
+
+// NDF returns the number of degrees of freedom of the fit of the function.
+func (f *F1) NDF() int {
+	return int(f.ndf)
+}
+
+// Func returns a function evaluating f.
+//
+// Functions with a formula are evaluated from their expression.
+// Functions defined from C++ code are evaluated by linear interpolation
+// of the values ROOT saved when the function was written, and are zero
+// outside of the range of these values.
+//
+// The returned function can be used with gonum/plot's plotter.NewFunction.
+func (f *F1) Func() (func(x float64) float64, error) {
+	if f.formula == nil {
+		if len(f.save) < 4 {
+			return nil, fmt.Errorf("rhist: TF1 %q has no formula nor saved values", f.Name())
+		}
+		return f.evalSave, nil
+	}
+
+	fct, err := f.formula.Func()
+	if err != nil {
+		return nil, err
+	}
+	norm := 1.0
+	if f.normalized && f.normIntegral != 0 {
+		norm = f.normIntegral
+	}
+	return func(x float64) float64 {
+		return fct(x) / norm
+	}, nil
+}
+
+// Eval evaluates the function at x.
+// Eval compiles the expression of the function at each call:
+// Func should be used to repeatedly evaluate the function.
+func (f *F1) Eval(x float64) (float64, error) {
+	fct, err := f.Func()
+	if err != nil {
+		return 0, err
+	}
+	return fct(x), nil
+}
+
+// evalSave linearly interpolates the saved values of the function.
+// ROOT saves npx+1 equidistant values followed by xmin and xmax.
+func (f *F1) evalSave(x float64) float64 {
+	var (
+		n    = len(f.save) - 3
+		xmin = f.save[n+1]
+		xmax = f.save[n+2]
+		dx   = (xmax - xmin) / float64(n)
+	)
+	if x < xmin || x > xmax || dx <= 0 {
+		return 0
+	}
+	bin := int((x - xmin) / dx)
+	if bin > n-1 {
+		bin = n - 1
+	}
+	var (
+		xlo = xmin + float64(bin)*dx
+		xhi = xlo + dx
+		ylo = f.save[bin]
+		yhi = f.save[bin+1]
+	)
+	return ((xhi*ylo - xlo*yhi) + x*(yhi-ylo)) / dx
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (f *F1) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -196,6 +354,19 @@ func (*F1Parameters) Class() string {
 	return "TF1Parameters"
 }
 
+// MarshalROOT implements rbytes.Marshaler
+func (f *F1Parameters) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(f.Class(), f.RVersion())
+	w.WriteStdVectorF64(f.params)
+	w.WriteStdVectorStrs(f.names)
+
+	return w.SetHeader(hdr)
+}
+
 func (f *F1Parameters) UnmarshalROOT(r *rbytes.RBuffer) error {
 	if r.Err() != nil {
 		return r.Err()
@@ -493,6 +664,7 @@ var (
 	_ rbytes.Unmarshaler = (*F1)(nil)
 
 	_ root.Object        = (*F1Parameters)(nil)
+	_ rbytes.Marshaler   = (*F1Parameters)(nil)
 	_ rbytes.Unmarshaler = (*F1Parameters)(nil)
 
 	_ root.Object        = (*f1Composition)(nil)
@@ -0,0 +1,273 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// F2 is a ROOT 2-dim function.
+type F2 struct {
+	f1 F1
+
+	ymin    float64      // Lower bound for the range in y
+	ymax    float64      // Upper bound for the range in y
+	npy     int32        // Number of points along y used for the graphical representation
+	contour rcont.ArrayD // Array to display contour levels
+}
+
+func newF2() *F2 {
+	return &F2{
+		f1: *newF1(),
+	}
+}
+
+// NewF2 creates a new 2-dim function, defined over [xmin, xmax]x[ymin, ymax],
+// from the provided TFormula expression and the initial values of its
+// parameters.
+func NewF2(name, expr string, xmin, xmax, ymin, ymax float64, params ...float64) (*F2, error) {
+	formula, err := NewFormula(name, expr, params...)
+	if err != nil {
+		return nil, fmt.Errorf("rhist: could not create TF2 formula: %w", err)
+	}
+	if formula.NDim() > 2 {
+		return nil, fmt.Errorf("rhist: invalid TF2 formula %q (ndim=%d)", expr, formula.NDim())
+	}
+	formula.ndim = 2
+
+	f := newF2()
+	f.f1.initFormula(name, expr, formula)
+	f.f1.npx = 30
+	f.f1.xmin = xmin
+	f.f1.xmax = xmax
+	f.ymin = ymin
+	f.ymax = ymax
+	f.npy = 30
+	return f, nil
+}
+
+func (*F2) RVersion() int16 {
+	return rvers.F2
+}
+
+func (*F2) Class() string {
+	return "TF2"
+}
+
+// Name returns the name of the instance
+func (f *F2) Name() string {
+	return f.f1.Name()
+}
+
+// Title returns the title of the instance
+func (f *F2) Title() string {
+	return f.f1.Title()
+}
+
+// Formula returns the formula of the function, if any.
+// Functions defined from C++ code have no formula.
+func (f *F2) Formula() *Formula {
+	return f.f1.formula
+}
+
+// XRange returns the range of the function along X.
+func (f *F2) XRange() (xmin, xmax float64) {
+	return f.f1.Range()
+}
+
+// YRange returns the range of the function along Y.
+func (f *F2) YRange() (ymin, ymax float64) {
+	return f.ymin, f.ymax
+}
+
+// NPar returns the number of parameters of the function.
+func (f *F2) NPar() int {
+	return f.f1.NPar()
+}
+
+// Param returns the value of the i-th parameter.
+func (f *F2) Param(i int) float64 {
+	return f.f1.Param(i)
+}
+
+// SetParam sets the value of the i-th parameter.
+func (f *F2) SetParam(i int, v float64) {
+	f.f1.SetParam(i, v)
+}
+
+// ParError returns the error on the i-th parameter.
+func (f *F2) ParError(i int) float64 {
+	return f.f1.ParError(i)
+}
+
+// Chi2 returns the chi-square of the fit of the function.
+func (f *F2) Chi2() float64 {
+	return f.f1.Chi2()
+}
+
+// NDF returns the number of degrees of freedom of the fit of the function.
+func (f *F2) NDF() int {
+	return f.f1.NDF()
+}
+
+// Func returns a function evaluating f.
+//
+// Functions with a formula are evaluated from their expression.
+// Functions defined from C++ code are evaluated by bilinear interpolation
+// of the values ROOT saved when the function was written, and are zero
+// outside of the range of these values.
+func (f *F2) Func() (func(x, y float64) float64, error) {
+	if f.f1.formula == nil {
+		if len(f.f1.save) < 10 {
+			return nil, fmt.Errorf("rhist: TF2 %q has no formula nor saved values", f.Name())
+		}
+		return f.evalSave, nil
+	}
+
+	fct, err := f.f1.formula.Func()
+	if err != nil {
+		return nil, err
+	}
+	norm := 1.0
+	if f.f1.normalized && f.f1.normIntegral != 0 {
+		norm = f.f1.normIntegral
+	}
+	return func(x, y float64) float64 {
+		return fct(x, y) / norm
+	}, nil
+}
+
+// Eval evaluates the function at (x,y).
+// Eval compiles the expression of the function at each call:
+// Func should be used to repeatedly evaluate the function.
+func (f *F2) Eval(x, y float64) (float64, error) {
+	fct, err := f.Func()
+	if err != nil {
+		return 0, err
+	}
+	return fct(x, y), nil
+}
+
+// evalSave interpolates the saved values of the function.
+// ROOT saves (npx+1)*(npy+1) values on a regular grid, followed by
+// xmin, xmax, ymin, ymax, npx and npy.
+func (f *F2) evalSave(x, y float64) float64 {
+	var (
+		save = f.f1.save
+		n    = len(save)
+		npx  = int(save[n-2])
+		npy  = int(save[n-1])
+		xmin = save[n-6]
+		xmax = save[n-5]
+		ymin = save[n-4]
+		ymax = save[n-3]
+	)
+	if npx <= 0 || npy <= 0 || (npx+1)*(npy+1)+6 != n {
+		return 0
+	}
+	if x < xmin || x > xmax || y < ymin || y > ymax {
+		return 0
+	}
+	var (
+		dx = (xmax - xmin) / float64(npx)
+		dy = (ymax - ymin) / float64(npy)
+	)
+	if dx <= 0 || dy <= 0 {
+		return 0
+	}
+
+	ix := int((x - xmin) / dx)
+	if ix > npx-1 {
+		ix = npx - 1
+	}
+	iy := int((y - ymin) / dy)
+	if iy > npy-1 {
+		iy = npy - 1
+	}
+
+	var (
+		t  = (x - xmin - float64(ix)*dx) / dx
+		u  = (y - ymin - float64(iy)*dy) / dy
+		k1 = ix + (npx+1)*iy
+		k2 = k1 + 1
+		k3 = k2 + npx + 1
+		k4 = k3 - 1
+	)
+	return (1-t)*(1-u)*save[k1] + t*(1-u)*save[k2] + t*u*save[k3] + (1-t)*u*save[k4]
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (f *F2) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(f.Class(), f.RVersion())
+	w.WriteObject(&f.f1)
+	w.WriteF64(f.ymin)
+	w.WriteF64(f.ymax)
+	w.WriteI32(f.npy)
+	w.WriteObject(&f.contour)
+
+	return w.SetHeader(hdr)
+}
+
+func (f *F2) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(f.Class())
+	if hdr.Vers > rvers.F2 {
+		panic(fmt.Errorf("rhist: invalid TF2 version=%d > %d", hdr.Vers, rvers.F2))
+	}
+
+	if hdr.Vers < 4 {
+		// tested with v4.
+		panic(fmt.Errorf("rhist: invalid TF2 version=%d < 4", hdr.Vers))
+	}
+
+	r.ReadObject(&f.f1)
+	f.ymin = r.ReadF64()
+	f.ymax = r.ReadF64()
+	f.npy = r.ReadI32()
+	r.ReadObject(&f.contour)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (f *F2) String() string {
+	switch {
+	case f.f1.formula != nil:
+		return fmt.Sprintf("TF2{Formula: %v}", f.f1.formula)
+	case f.f1.params != nil:
+		return fmt.Sprintf("TF2{Params: %v}", f.f1.params)
+	default:
+		return "TF2{...}"
+	}
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := newF2()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TF2", f)
+}
+
+var (
+	_ root.Object        = (*F2)(nil)
+	_ root.Named         = (*F2)(nil)
+	_ rbytes.Marshaler   = (*F2)(nil)
+	_ rbytes.Unmarshaler = (*F2)(nil)
+)
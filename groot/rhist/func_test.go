@@ -0,0 +1,249 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestFormulaEval(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		pars []float64
+		xs   []float64
+		want float64
+	}{
+		{expr: "1+2*3", want: 7},
+		{expr: "(1+2)*3", want: 9},
+		{expr: "2^3^2", want: 512},
+		{expr: "-x^2", xs: []float64{3}, want: -9},
+		{expr: "2**3", want: 8},
+		{expr: "1.5e2 + .5", want: 150.5},
+		{expr: "7 % 4", want: 3},
+		{expr: "[0] + [1]*x", pars: []float64{1, 2}, xs: []float64{3}, want: 7},
+		{expr: "[p0] + [p1]*x[0] + [p2]*x[1]", pars: []float64{1, 2, 3}, xs: []float64{1, 2}, want: 9},
+		{expr: "[a]*x*y + [b]", pars: []float64{2, 1}, xs: []float64{3, 4}, want: 25},
+		{expr: "x+y+z+t", xs: []float64{1, 2, 3, 4}, want: 10},
+		{expr: "sin(pi/2) + cos(0) + exp(0) + log(1)", want: 3},
+		{expr: "TMath::Exp(TMath::Log(2)) * TMath::Pi()", want: 2 * math.Pi},
+		{expr: "sqrt(16) + abs(-2) + pow(2, 10)", want: 1030},
+		{expr: "TMath::Sq(3) + TMath::Power(2, 3) + TMath::Max(1, 2)", want: 19},
+		{expr: "TMath::Gaus(1, 1, 2)", want: 1},
+		{expr: "atan2(1, 1)", want: math.Pi / 4},
+		{expr: "(x > 0)*x", xs: []float64{-2}, want: 0},
+		{expr: "(x > 0 && x <= 2) + (x == 1 || x != 1) + !x", xs: []float64{1}, want: 2},
+		{expr: "gaus", pars: []float64{2, 1, 0.5}, xs: []float64{1}, want: 2},
+		{expr: "expo", pars: []float64{1, 2}, xs: []float64{0.5}, want: math.Exp(2)},
+		{expr: "pol2", pars: []float64{1, 2, 3}, xs: []float64{2}, want: 17},
+		{expr: "pol1(0) + gaus(2)", pars: []float64{1, 2, 4, 0, 1}, xs: []float64{0}, want: 5},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			f, err := rhist.NewFormula("f", tc.expr, tc.pars...)
+			if err != nil {
+				t.Fatalf("could not create formula: %+v", err)
+			}
+			if got, want := f.NPar(), len(tc.pars); got != want {
+				t.Fatalf("invalid number of parameters: got=%d, want=%d", got, want)
+			}
+			got, err := f.Eval(tc.xs...)
+			if err != nil {
+				t.Fatalf("could not evaluate formula %q: %+v", f.Expr(), err)
+			}
+			if math.Abs(got-tc.want) > 1e-12 {
+				t.Fatalf("invalid value: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormulaParams(t *testing.T) {
+	f, err := rhist.NewFormula("f", "gaus", 1, 2, 3)
+	if err != nil {
+		t.Fatalf("could not create formula: %+v", err)
+	}
+	for i, want := range []string{"Constant", "Mean", "Sigma"} {
+		if got := f.ParamName(i); got != want {
+			t.Fatalf("invalid name for parameter %d: got=%q, want=%q", i, got, want)
+		}
+	}
+
+	f, err = rhist.NewFormula("f", "[0] + [1]*x")
+	if err != nil {
+		t.Fatalf("could not create formula: %+v", err)
+	}
+	if got, want := f.Expr(), "[p0]+[p1]*x"; got != want {
+		t.Fatalf("invalid expression: got=%q, want=%q", got, want)
+	}
+	if got, want := f.ParamName(1), "p1"; got != want {
+		t.Fatalf("invalid parameter name: got=%q, want=%q", got, want)
+	}
+
+	fct, err := f.Func()
+	if err != nil {
+		t.Fatalf("could not compile formula: %+v", err)
+	}
+	f.SetParam(1, 2)
+	if got, want := fct(3), 0.0; got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+	if got, err := f.Eval(3); err != nil || got != 6 {
+		t.Fatalf("invalid value: got=%v, want=%v (err=%+v)", got, 6, err)
+	}
+}
+
+func TestFormulaInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"1+",
+		"(1+2",
+		"x $ 2",
+		"[p0",
+		"foo(x)",
+		"bar",
+		"sin(1, 2)",
+		"TMath::Gaus()",
+		"[0] + [2]*x",
+		"x[7]",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := rhist.NewFormula("f", expr)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	_, err := rhist.NewFormula("f", "[0]*x", 1, 2)
+	if err == nil {
+		t.Fatalf("expected an error for too many parameters")
+	}
+
+	f, err := rhist.NewFormula("f", "x*y")
+	if err != nil {
+		t.Fatalf("could not create formula: %+v", err)
+	}
+	if _, err := f.Eval(1); err == nil {
+		t.Fatalf("expected an error for missing variables")
+	}
+}
+
+func TestF1(t *testing.T) {
+	f, err := rhist.NewF1("f1", "[c]*exp(-x/[tau])", 0, 10, 2, 4)
+	if err != nil {
+		t.Fatalf("could not create TF1: %+v", err)
+	}
+	if got, want := f.Name(), "f1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if xmin, xmax := f.Range(); xmin != 0 || xmax != 10 {
+		t.Fatalf("invalid range: got=[%v, %v]", xmin, xmax)
+	}
+	if got, want := f.NPar(), 2; got != want {
+		t.Fatalf("invalid number of parameters: got=%d, want=%d", got, want)
+	}
+	v, err := f.Eval(4)
+	if err != nil {
+		t.Fatalf("could not evaluate TF1: %+v", err)
+	}
+	if want := 2 * math.Exp(-1); math.Abs(v-want) > 1e-12 {
+		t.Fatalf("invalid value: got=%v, want=%v", v, want)
+	}
+
+	f.SetParam(0, 1)
+	if got, want := f.Param(0), 1.0; got != want {
+		t.Fatalf("invalid parameter: got=%v, want=%v", got, want)
+	}
+
+	if _, err := rhist.NewF1("f1", "x*y", 0, 1); err == nil {
+		t.Fatalf("expected an error for a 2-dim formula")
+	}
+}
+
+func TestF1FromFile(t *testing.T) {
+	f, err := riofs.Open("../testdata/tformula.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	get := func(name string) *rhist.F1 {
+		obj, err := f.Get(name)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", name, err)
+		}
+		return obj.(*rhist.F1)
+	}
+
+	t.Run("formula", func(t *testing.T) {
+		f1 := get("func1")
+		if f1.Formula() == nil {
+			t.Fatalf("expected a formula")
+		}
+		if got, want := f1.Param(1), 20.0; got != want {
+			t.Fatalf("invalid parameter: got=%v, want=%v", got, want)
+		}
+		// func1 is normalized to its integral over [0, 10].
+		v, err := f1.Eval(1)
+		if err != nil {
+			t.Fatalf("could not evaluate TF1: %+v", err)
+		}
+		if want := 30.0 / 1100; math.Abs(v-want) > 1e-12 {
+			t.Fatalf("invalid value: got=%v, want=%v", v, want)
+		}
+	})
+
+	t.Run("saved", func(t *testing.T) {
+		f2 := get("func2")
+		if f2.Formula() != nil {
+			t.Fatalf("expected no formula")
+		}
+		fct, err := f2.Func()
+		if err != nil {
+			t.Fatalf("could not create function: %+v", err)
+		}
+		for _, tc := range []struct {
+			x, want float64
+		}{
+			{0, 10},
+			{0.05, 11},
+			{5, 110},
+			{10, 210},
+			{-1, 0},
+			{11, 0},
+		} {
+			if got := fct(tc.x); math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("invalid value at x=%v: got=%v, want=%v", tc.x, got, tc.want)
+			}
+		}
+	})
+}
+
+func TestF2(t *testing.T) {
+	f, err := rhist.NewF2("f2", "[a]*x*y + [b]", -1, 1, -2, 2, 2, 3)
+	if err != nil {
+		t.Fatalf("could not create TF2: %+v", err)
+	}
+	if ymin, ymax := f.YRange(); ymin != -2 || ymax != 2 {
+		t.Fatalf("invalid y-range: got=[%v, %v]", ymin, ymax)
+	}
+	if got, want := f.Formula().NDim(), 2; got != want {
+		t.Fatalf("invalid ndim: got=%d, want=%d", got, want)
+	}
+	v, err := f.Eval(0.5, 2)
+	if err != nil {
+		t.Fatalf("could not evaluate TF2: %+v", err)
+	}
+	if got, want := v, 5.0; got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+
+	if _, err := rhist.NewF2("f2", "x*y*z", 0, 1, 0, 1); err == nil {
+		t.Fatalf("expected an error for a 3-dim formula")
+	}
+}
@@ -93,6 +93,70 @@ func (g *tgraph) XY(i int) (float64, float64) {
 	return g.x[i], g.y[i]
 }
 
+// AttLine returns the line attributes of the graph.
+func (g *tgraph) AttLine() *rbase.AttLine { return &g.attline }
+
+// AttFill returns the fill attributes of the graph.
+func (g *tgraph) AttFill() *rbase.AttFill { return &g.attfill }
+
+// AttMarker returns the marker attributes of the graph.
+func (g *tgraph) AttMarker() *rbase.AttMarker { return &g.attmarker }
+
+// Functions returns the functions (fits and user) attached to the graph.
+func (g *tgraph) Functions() []root.Object {
+	if g.funcs == nil {
+		return nil
+	}
+	objs := make([]root.Object, g.funcs.Len())
+	for i := range objs {
+		objs[i] = g.funcs.At(i)
+	}
+	return objs
+}
+
+// XAxis returns the axis along X.
+func (g *tgraph) XAxis() Axis {
+	return &g.hist().th1.xaxis
+}
+
+// YAxis returns the axis along Y.
+func (g *tgraph) YAxis() Axis {
+	return &g.hist().th1.yaxis
+}
+
+// hist returns the histogram holding the axes of the graph, creating it
+// if needed.
+func (g *tgraph) hist() *H1F {
+	if g.histo != nil {
+		return g.histo
+	}
+
+	xmin, xmax := 0.0, 1.0
+	if len(g.x) > 0 {
+		xmin, xmax = g.x[0], g.x[0]
+		for _, x := range g.x {
+			xmin = math.Min(xmin, x)
+			xmax = math.Max(xmax, x)
+		}
+		if xmin == xmax {
+			xmin--
+			xmax++
+		}
+	}
+
+	const nbins = 100
+	h := newH1F()
+	h.th1.SetName(g.Name())
+	h.th1.SetTitle(g.Title())
+	h.th1.ncells = nbins + 2
+	h.th1.xaxis.nbins = nbins
+	h.th1.xaxis.xmin = xmin
+	h.th1.xaxis.xmax = xmax
+	h.arr.Data = make([]float32, nbins+2)
+	g.histo = h
+	return g.histo
+}
+
 func (g *tgraph) ROOTMerge(src root.Object) error {
 	switch src := src.(type) {
 	case *tgraph:
@@ -650,16 +714,62 @@ func newGraphMultiErrs(n, ny int) *tgraphmultierrs {
 		xerrhi:   make([]float64, n),
 		yerrlo:   make([]rcont.ArrayD, ny),
 		yerrhi:   make([]rcont.ArrayD, ny),
-		attfills: make([]rbase.AttFill, n),
-		attlines: make([]rbase.AttLine, n),
+		attfills: make([]rbase.AttFill, ny),
+		attlines: make([]rbase.AttLine, ny),
 	}
 	for i := 0; i < ny; i++ {
 		g.yerrlo[i].Data = make([]float64, n)
 		g.yerrhi[i].Data = make([]float64, n)
+		g.attfills[i] = *rbase.NewAttFill()
+		g.attlines[i] = *rbase.NewAttLine()
 	}
 	return g
 }
 
+// NewGraphMultiErrors creates a new graph with asymmetric X errors and
+// multiple sources of asymmetric Y errors.
+// ylo[e] and yhi[e] hold the low and high Y errors of the e-th source.
+func NewGraphMultiErrors(name, title string, xs, ys, xlo, xhi []float64, ylo, yhi [][]float64) (GraphMultiErrors, error) {
+	n := len(xs)
+	if len(ys) != n || len(xlo) != n || len(xhi) != n {
+		return nil, fmt.Errorf("rhist: inconsistent number of points or x-errors")
+	}
+	if len(ylo) != len(yhi) {
+		return nil, fmt.Errorf(
+			"rhist: inconsistent number of y-errors sources (lo=%d, hi=%d)",
+			len(ylo), len(yhi),
+		)
+	}
+	for e := range ylo {
+		if len(ylo[e]) != n || len(yhi[e]) != n {
+			return nil, fmt.Errorf("rhist: inconsistent number of y-errors for source %d", e)
+		}
+	}
+
+	g := newGraphMultiErrs(n, len(ylo))
+	copy(g.x, xs)
+	copy(g.y, ys)
+	copy(g.xerrlo, xlo)
+	copy(g.xerrhi, xhi)
+	for e := range ylo {
+		copy(g.yerrlo[e].Data, ylo[e])
+		copy(g.yerrhi[e].Data, yhi[e])
+	}
+	g.tgraph.Named.SetName(name)
+	g.tgraph.Named.SetTitle(title)
+
+	if n > 0 {
+		g.min = +math.MaxFloat64
+		g.max = -math.MaxFloat64
+		for _, y := range ys {
+			g.min = math.Min(g.min, y)
+			g.max = math.Max(g.max, y)
+		}
+	}
+
+	return g, nil
+}
+
 func newGraphMultiErrorsFrom(s2 *hbook.S2D) GraphErrors {
 	var (
 		n     = s2.Len()
@@ -702,8 +812,50 @@ func (g *tgraphmultierrs) XError(i int) (float64, float64) {
 	return g.xerrlo[i], g.xerrhi[i]
 }
 
+// YError returns two error values for Y data, combining the different
+// sources of Y errors according to the errors summation mode of the graph.
 func (g *tgraphmultierrs) YError(i int) (float64, float64) {
-	return g.yerrlo[0].At(i), g.yerrhi[0].At(i)
+	if g.nyerr == 0 {
+		return 0, 0
+	}
+
+	const (
+		kOnlyFirst = 0
+		kSquareSum = 1
+		kAbsSum    = 2
+	)
+
+	switch g.sumErrMode {
+	case kSquareSum:
+		var lo, hi float64
+		for e := range g.yerrlo {
+			vlo, vhi := g.YErrorAt(i, e)
+			lo += vlo * vlo
+			hi += vhi * vhi
+		}
+		return math.Sqrt(lo), math.Sqrt(hi)
+	case kAbsSum:
+		var lo, hi float64
+		for e := range g.yerrlo {
+			vlo, vhi := g.YErrorAt(i, e)
+			lo += vlo
+			hi += vhi
+		}
+		return lo, hi
+	default:
+		return g.YErrorAt(i, 0)
+	}
+}
+
+// NYErrors returns the number of sources of Y errors.
+func (g *tgraphmultierrs) NYErrors() int {
+	return int(g.nyerr)
+}
+
+// YErrorAt returns two error values for Y data, from the e-th
+// source of Y errors.
+func (g *tgraphmultierrs) YErrorAt(i, e int) (float64, float64) {
+	return g.yerrlo[e].At(i), g.yerrhi[e].At(i)
 }
 
 // MarshalROOT implements rbytes.Marshaler
@@ -859,6 +1011,7 @@ var (
 	_ root.Merger         = (*tgraphmultierrs)(nil)
 	_ Graph               = (*tgraphmultierrs)(nil)
 	_ GraphErrors         = (*tgraphmultierrs)(nil)
+	_ GraphMultiErrors    = (*tgraphmultierrs)(nil)
 	_ rbytes.Marshaler    = (*tgraphmultierrs)(nil)
 	_ rbytes.Unmarshaler  = (*tgraphmultierrs)(nil)
 	_ yodacnv.Marshaler   = (*tgraphmultierrs)(nil)
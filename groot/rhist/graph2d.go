@@ -0,0 +1,339 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+)
+
+type tgraph2d struct {
+	rbase.Named
+	attline   rbase.AttLine
+	attfill   rbase.AttFill
+	attmarker rbase.AttMarker
+
+	npoints int32     // number of points in the data set
+	npx     int32     // number of bins along X in the interpolation histogram
+	npy     int32     // number of bins along Y in the interpolation histogram
+	maxiter int32     // maximum number of iterations to find Delaunay triangles
+	x       []float64 // [npoints]
+	y       []float64 // [npoints]
+	z       []float64 // [npoints]
+	min     float64   // minimum value for plotting along z
+	max     float64   // maximum value for plotting along z
+	margin  float64   // extra space (in %) around interpolated area for the interpolation histogram
+	zout    float64   // histogram bin height for points lying outside the interpolated area
+	funcs   root.List // pointer to list of functions (fits and user)
+}
+
+func newGraph2D(n int) *tgraph2d {
+	return &tgraph2d{
+		Named:     *rbase.NewNamed("", ""),
+		attline:   *rbase.NewAttLine(),
+		attfill:   *rbase.NewAttFill(),
+		attmarker: *rbase.NewAttMarker(),
+		npoints:   int32(n),
+		npx:       40,
+		npy:       40,
+		maxiter:   100000,
+		x:         make([]float64, n),
+		y:         make([]float64, n),
+		z:         make([]float64, n),
+		min:       -1111,
+		max:       -1111,
+		funcs:     rcont.NewList("", nil),
+	}
+}
+
+// NewGraph2DFrom creates a new Graph2D from the non-empty bins of a 2-dim
+// hbook efficiency map.
+// The (x,y) coordinates of the graph points are the centers of the bins,
+// and their z coordinates are the efficiencies.
+func NewGraph2DFrom(eff *hbook.Eff2D) Graph2D {
+	var (
+		pts   = eff.Points()
+		groot = newGraph2D(len(pts))
+	)
+
+	for i, pt := range pts {
+		groot.x[i] = pt.X
+		groot.y[i] = pt.Y
+		groot.z[i] = pt.Eff
+	}
+
+	groot.Named.SetName(eff.Name())
+	if v, ok := eff.Annotation()["title"]; ok {
+		groot.Named.SetTitle(v.(string))
+	}
+
+	return groot
+}
+
+// NewGraph2D creates a new Graph2D from the provided (x,y,z) coordinates.
+func NewGraph2D(name, title string, xs, ys, zs []float64) (Graph2D, error) {
+	if len(xs) != len(ys) || len(xs) != len(zs) {
+		return nil, fmt.Errorf(
+			"rhist: inconsistent number of coordinates (x=%d, y=%d, z=%d)",
+			len(xs), len(ys), len(zs),
+		)
+	}
+
+	g := newGraph2D(len(xs))
+	copy(g.x, xs)
+	copy(g.y, ys)
+	copy(g.z, zs)
+	g.Named.SetName(name)
+	g.Named.SetTitle(title)
+
+	return g, nil
+}
+
+func (*tgraph2d) RVersion() int16 {
+	return rvers.Graph2D
+}
+
+func (*tgraph2d) Class() string {
+	return "TGraph2D"
+}
+
+func (g *tgraph2d) Len() int {
+	return len(g.x)
+}
+
+func (g *tgraph2d) XY(i int) (float64, float64) {
+	return g.x[i], g.y[i]
+}
+
+func (g *tgraph2d) XYZ(i int) (float64, float64, float64) {
+	return g.x[i], g.y[i], g.z[i]
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (g *tgraph2d) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(g.Class(), g.RVersion())
+
+	w.WriteObject(&g.Named)
+	w.WriteObject(&g.attline)
+	w.WriteObject(&g.attfill)
+	w.WriteObject(&g.attmarker)
+
+	w.WriteI32(g.npoints)
+	w.WriteI32(g.npx)
+	w.WriteI32(g.npy)
+	w.WriteI32(g.maxiter)
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.x[:g.npoints])
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.y[:g.npoints])
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.z[:g.npoints])
+	w.WriteF64(g.min)
+	w.WriteF64(g.max)
+	w.WriteF64(g.margin)
+	w.WriteF64(g.zout)
+	w.WriteObjectAny(g.funcs) // obj-ptr
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (g *tgraph2d) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(g.Class())
+	if hdr.Vers > rvers.Graph2D {
+		panic(fmt.Errorf("rhist: invalid TGraph2D version=%d > %d", hdr.Vers, rvers.Graph2D))
+	}
+
+	r.ReadObject(&g.Named)
+	r.ReadObject(&g.attline)
+	r.ReadObject(&g.attfill)
+	r.ReadObject(&g.attmarker)
+
+	g.npoints = r.ReadI32()
+	g.npx = r.ReadI32()
+	g.npy = r.ReadI32()
+	g.maxiter = r.ReadI32()
+	_ = r.ReadI8() // is-array
+	g.x = rbytes.ResizeF64(nil, int(g.npoints))
+	r.ReadArrayF64(g.x)
+	_ = r.ReadI8() // is-array
+	g.y = rbytes.ResizeF64(nil, int(g.npoints))
+	r.ReadArrayF64(g.y)
+	_ = r.ReadI8() // is-array
+	g.z = rbytes.ResizeF64(nil, int(g.npoints))
+	r.ReadArrayF64(g.z)
+	g.min = r.ReadF64()
+	g.max = r.ReadF64()
+	g.margin = r.ReadF64()
+	g.zout = r.ReadF64()
+
+	g.funcs = nil
+	if funcs := r.ReadObjectAny(); funcs != nil {
+		g.funcs = funcs.(root.List)
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+type tgraph2derrs struct {
+	tgraph2d
+
+	xerr []float64 // [npoints] array of X errors
+	yerr []float64 // [npoints] array of Y errors
+	zerr []float64 // [npoints] array of Z errors
+}
+
+func newGraph2DErrs(n int) *tgraph2derrs {
+	return &tgraph2derrs{
+		tgraph2d: *newGraph2D(n),
+		xerr:     make([]float64, n),
+		yerr:     make([]float64, n),
+		zerr:     make([]float64, n),
+	}
+}
+
+// NewGraph2DErrorsFrom creates a new Graph2DErrors from the non-empty bins
+// of a 2-dim hbook efficiency map.
+// The (x,y) coordinates of the graph points are the centers of the bins,
+// with the half-widths of the bins as errors, and their z coordinates are
+// the efficiencies, with their uncertainties as errors.
+func NewGraph2DErrorsFrom(eff *hbook.Eff2D) Graph2DErrors {
+	var (
+		pts   = eff.Points()
+		groot = newGraph2DErrs(len(pts))
+	)
+
+	for i, pt := range pts {
+		groot.x[i] = pt.X
+		groot.y[i] = pt.Y
+		groot.z[i] = pt.Eff
+		groot.xerr[i] = pt.ErrX
+		groot.yerr[i] = pt.ErrY
+		groot.zerr[i] = pt.Err
+	}
+
+	groot.Named.SetName(eff.Name())
+	if v, ok := eff.Annotation()["title"]; ok {
+		groot.Named.SetTitle(v.(string))
+	}
+
+	return groot
+}
+
+func (*tgraph2derrs) RVersion() int16 {
+	return rvers.Graph2DErrors
+}
+
+func (*tgraph2derrs) Class() string {
+	return "TGraph2DErrors"
+}
+
+func (g *tgraph2derrs) XError(i int) (float64, float64) {
+	return g.xerr[i], g.xerr[i]
+}
+
+func (g *tgraph2derrs) YError(i int) (float64, float64) {
+	return g.yerr[i], g.yerr[i]
+}
+
+func (g *tgraph2derrs) ZError(i int) (float64, float64) {
+	return g.zerr[i], g.zerr[i]
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (g *tgraph2derrs) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(g.Class(), g.RVersion())
+
+	w.WriteObject(&g.tgraph2d)
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.xerr[:g.tgraph2d.npoints])
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.yerr[:g.tgraph2d.npoints])
+	w.WriteI8(1) // is-array
+	w.WriteArrayF64(g.zerr[:g.tgraph2d.npoints])
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (g *tgraph2derrs) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(g.Class())
+	if hdr.Vers > rvers.Graph2DErrors {
+		panic(fmt.Errorf("rhist: invalid TGraph2DErrors version=%d > %d", hdr.Vers, rvers.Graph2DErrors))
+	}
+
+	r.ReadObject(&g.tgraph2d)
+
+	n := int(g.tgraph2d.npoints)
+	_ = r.ReadI8() // is-array
+	g.xerr = rbytes.ResizeF64(nil, n)
+	r.ReadArrayF64(g.xerr)
+	_ = r.ReadI8() // is-array
+	g.yerr = rbytes.ResizeF64(nil, n)
+	r.ReadArrayF64(g.yerr)
+	_ = r.ReadI8() // is-array
+	g.zerr = rbytes.ResizeF64(nil, n)
+	r.ReadArrayF64(g.zerr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newGraph2D(0)
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TGraph2D", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := newGraph2DErrs(0)
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TGraph2DErrors", f)
+	}
+}
+
+var (
+	_ root.Object        = (*tgraph2d)(nil)
+	_ root.Named         = (*tgraph2d)(nil)
+	_ Graph2D            = (*tgraph2d)(nil)
+	_ rbytes.Marshaler   = (*tgraph2d)(nil)
+	_ rbytes.Unmarshaler = (*tgraph2d)(nil)
+
+	_ root.Object        = (*tgraph2derrs)(nil)
+	_ root.Named         = (*tgraph2derrs)(nil)
+	_ Graph2D            = (*tgraph2derrs)(nil)
+	_ Graph2DErrors      = (*tgraph2derrs)(nil)
+	_ rbytes.Marshaler   = (*tgraph2derrs)(nil)
+	_ rbytes.Unmarshaler = (*tgraph2derrs)(nil)
+)
@@ -5,13 +5,17 @@
 package rhist_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/plotter"
 )
 
 func TestGraph(t *testing.T) {
@@ -186,6 +190,146 @@ func TestGraphMultiErrors(t *testing.T) {
 			t.Errorf("yerr[%d].high=%v want=%v", i, yhi, want)
 		}
 	}
+
+	gme, ok := obj.(rhist.GraphMultiErrors)
+	if !ok {
+		t.Fatalf("'gme' not a rhist.GraphMultiErrors: %T", obj)
+	}
+	if got, want := gme.NYErrors(), 2; got != want {
+		t.Fatalf("invalid number of y-errors sources: got=%d, want=%d", got, want)
+	}
+	var (
+		ysyslos = []float64{0.5, 0.4, 0.8, 0.3, 1.2}
+		ysyshis = []float64{0.6, 0.7, 0.6, 0.4, 0.8}
+	)
+	for i := 0; i < gme.Len(); i++ {
+		ylo, yhi := gme.YErrorAt(i, 1)
+		if want := ysyslos[i]; want != ylo {
+			t.Errorf("yerr[%d][1].low=%v want=%v", i, ylo, want)
+		}
+		if want := ysyshis[i]; want != yhi {
+			t.Errorf("yerr[%d][1].high=%v want=%v", i, yhi, want)
+		}
+	}
+}
+
+func TestNewGraphMultiErrors(t *testing.T) {
+	var (
+		xs  = []float64{1, 2, 3}
+		ys  = []float64{2, 4, 6}
+		xlo = []float64{0.1, 0.1, 0.1}
+		xhi = []float64{0.2, 0.2, 0.2}
+		ylo = [][]float64{{1, 2, 3}, {0.5, 0.5, 0.5}}
+		yhi = [][]float64{{2, 3, 4}, {0.6, 0.6, 0.6}}
+	)
+
+	_, err := rhist.NewGraphMultiErrors("gme", "", xs, ys[:2], xlo, xhi, ylo, yhi)
+	if err == nil {
+		t.Fatalf("expected an error for inconsistent points")
+	}
+	_, err = rhist.NewGraphMultiErrors("gme", "", xs, ys, xlo, xhi, ylo, yhi[:1])
+	if err == nil {
+		t.Fatalf("expected an error for inconsistent y-errors sources")
+	}
+
+	g, err := rhist.NewGraphMultiErrors("gme", "title", xs, ys, xlo, xhi, ylo, yhi)
+	if err != nil {
+		t.Fatalf("could not create graph: %+v", err)
+	}
+
+	dir, err := os.MkdirTemp("", "groot-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "gme.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	err = f.Put("gme", g)
+	if err != nil {
+		t.Fatalf("could not write graph: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := groot.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	obj, err := r.Get("gme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gme := obj.(rhist.GraphMultiErrors)
+
+	if got, want := gme.Title(), "title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+	if got, want := gme.NYErrors(), 2; got != want {
+		t.Fatalf("invalid number of y-errors sources: got=%d, want=%d", got, want)
+	}
+	for i := 0; i < gme.Len(); i++ {
+		x, y := gme.XY(i)
+		if x != xs[i] || y != ys[i] {
+			t.Fatalf("invalid point %d: got=(%v, %v), want=(%v, %v)", i, x, y, xs[i], ys[i])
+		}
+		for e := range ylo {
+			lo, hi := gme.YErrorAt(i, e)
+			if lo != ylo[e][i] || hi != yhi[e][i] {
+				t.Fatalf(
+					"invalid y-errors %d for point %d: got=(%v, %v), want=(%v, %v)",
+					e, i, lo, hi, ylo[e][i], yhi[e][i],
+				)
+			}
+		}
+		// only the first source of errors is used by default.
+		lo, hi := gme.YError(i)
+		if lo != ylo[0][i] || hi != yhi[0][i] {
+			t.Fatalf("invalid y-errors for point %d: got=(%v, %v)", i, lo, hi)
+		}
+	}
+}
+
+func TestNewGraph2D(t *testing.T) {
+	_, err := rhist.NewGraph2D("g2", "", []float64{1}, []float64{1, 2}, []float64{1})
+	if err == nil {
+		t.Fatalf("expected an error for inconsistent coordinates")
+	}
+
+	var (
+		xs = []float64{1, 2, 3}
+		ys = []float64{4, 5, 6}
+		zs = []float64{7, 8, 9}
+	)
+	g, err := rhist.NewGraph2D("g2", "title", xs, ys, zs)
+	if err != nil {
+		t.Fatalf("could not create graph: %+v", err)
+	}
+
+	var _ plotter.XYZer = g
+
+	if got, want := g.Len(), 3; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	for i := range xs {
+		x, y, z := g.XYZ(i)
+		if x != xs[i] || y != ys[i] || z != zs[i] {
+			t.Fatalf("invalid point %d: got=(%v, %v, %v)", i, x, y, z)
+		}
+		x, y = g.XY(i)
+		if x != xs[i] || y != ys[i] {
+			t.Fatalf("invalid point %d: got=(%v, %v)", i, x, y)
+		}
+	}
 }
 
 func TestInvalidGraphMerger(t *testing.T) {
@@ -229,3 +373,117 @@ func TestInvalidGraphMerger(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphTimeAxis(t *testing.T) {
+	dir, err := os.MkdirTemp("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "graph-time.root")
+	{
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		g := rhist.NewGraphFrom(hbook.NewS2D([]hbook.Point2D{
+			{X: 0, Y: 1},
+			{X: 3600, Y: 2},
+			{X: 7200, Y: 3},
+		}...))
+		g.XAxis().SetTimeDisplay(true)
+		g.XAxis().SetTimeFormat("%d/%m/%y %H:%M%F2022-03-04 05:00:00")
+
+		err = f.Put("gr", g)
+		if err != nil {
+			t.Fatalf("could not write graph: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("gr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := obj.(rhist.Graph)
+
+	if _, _, ok := rhist.AxisTime(g.YAxis()); ok {
+		t.Fatalf("y-axis should not display time values")
+	}
+
+	layout, offset, ok := rhist.AxisTime(g.XAxis())
+	if !ok {
+		t.Fatalf("x-axis should display time values")
+	}
+	if got, want := layout, "02/01/06 15:04"; got != want {
+		t.Fatalf("invalid layout: got=%q, want=%q", got, want)
+	}
+	if got, want := offset, time.Date(2022, time.March, 4, 5, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("invalid offset: got=%v, want=%v", got, want)
+	}
+
+	x, _ := g.XY(1)
+	date := offset.Add(time.Duration(x) * time.Second).Format(layout)
+	if got, want := date, "04/03/22 06:00"; got != want {
+		t.Fatalf("invalid date: got=%q, want=%q", got, want)
+	}
+}
+
+func TestAxisTime(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		layout string
+		offset time.Time
+	}{
+		{
+			format: "",
+			layout: "2006-01-02 15:04:05",
+			offset: time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			format: "%Y-%m-%d %H:%M:%S",
+			layout: "2006-01-02 15:04:05",
+			offset: time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			format: "#splitline{%b %d}{%H:%M}%F1970-01-01 00:00:00s0",
+			layout: "#splitline{Jan 02}{15:04}",
+			offset: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			format: "%a %I%p %%",
+			layout: "Mon 03PM %",
+			offset: time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			axis := rhist.NewAxis("xaxis")
+			axis.SetTimeDisplay(true)
+			axis.SetTimeFormat(tc.format)
+
+			layout, offset, ok := rhist.AxisTime(axis)
+			if !ok {
+				t.Fatalf("axis should display time values")
+			}
+			if layout != tc.layout {
+				t.Fatalf("invalid layout: got=%q, want=%q", layout, tc.layout)
+			}
+			if !offset.Equal(tc.offset) {
+				t.Fatalf("invalid offset: got=%v, want=%v", offset, tc.offset)
+			}
+		})
+	}
+}
@@ -31,6 +31,17 @@ func newH1F() *H1F {
 	}
 }
 
+// NewH1FFromEdges creates a new, empty, 1-dim histogram with
+// bins defined by the provided edges.
+// NewH1FFromEdges panics if fewer than two edges are provided,
+// or if the edges are not sorted in increasing order.
+func NewH1FFromEdges(name, title string, edges []float64) *H1F {
+	h := hbook.NewH1DFromEdges(edges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH1FFrom(h)
+}
+
 // NewH1FFrom creates a new 1-dim histogram from hbook.
 func NewH1FFrom(h *hbook.H1D) *H1F {
 	var (
@@ -71,6 +82,8 @@ func NewH1FFrom(h *hbook.H1D) *H1F {
 		hroot.th1.SetTitle(v.(string))
 	}
 	hroot.th1.xaxis.xbins.Data = edges
+	hroot.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th1.yaxis.setHbookAxis(h.YAxis())
 	return hroot
 }
 
@@ -242,6 +255,8 @@ func (h *H1F) AsH1D() *hbook.H1D {
 		bin.Range.Max = xmax
 		hh.Binning.Bins[i].Dist = h.dist1D(i + 1)
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
 
 	return hh
 }
@@ -279,6 +294,81 @@ func (h *H1F) ROOTMerge(src root.Object) error {
 	return nil
 }
 
+// Fill fills this histogram with x and weight w.
+func (h *H1F) Fill(x, w float64) {
+	histFill(h, x, 0, w)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H1F) Add(o H1, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H1F) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H1F) Multiply(o H1) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H1F) Divide(o H1, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// Rebin merges groups of n consecutive bins into single bins.
+// If the number of bins is not a multiple of n, the remaining bins are
+// merged into the overflow bin.
+func (h *H1F) Rebin(n int) error {
+	return histRebin(h, n, 1)
+}
+
+func (h *H1F) base() *th1 {
+	return &h.th1
+}
+
+func (h *H1F) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H1F) setCell(i int, v float64) {
+	h.arr.Data[i] = float32(v)
+}
+
+func (h *H1F) setCells(vs []float64) {
+	h.arr.Data = make([]float32, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = float32(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x and weights*x².
+func (h *H1F) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x and weights*x².
+func (h *H1F) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH1F()
@@ -292,6 +382,7 @@ var (
 	_ root.Merger        = (*H1F)(nil)
 	_ root.Named         = (*H1F)(nil)
 	_ H1                 = (*H1F)(nil)
+	_ histCells          = (*H1F)(nil)
 	_ rbytes.Marshaler   = (*H1F)(nil)
 	_ rbytes.Unmarshaler = (*H1F)(nil)
 )
@@ -308,6 +399,17 @@ func newH1D() *H1D {
 	}
 }
 
+// NewH1DFromEdges creates a new, empty, 1-dim histogram with
+// bins defined by the provided edges.
+// NewH1DFromEdges panics if fewer than two edges are provided,
+// or if the edges are not sorted in increasing order.
+func NewH1DFromEdges(name, title string, edges []float64) *H1D {
+	h := hbook.NewH1DFromEdges(edges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH1DFrom(h)
+}
+
 // NewH1DFrom creates a new 1-dim histogram from hbook.
 func NewH1DFrom(h *hbook.H1D) *H1D {
 	var (
@@ -348,6 +450,8 @@ func NewH1DFrom(h *hbook.H1D) *H1D {
 		hroot.th1.SetTitle(v.(string))
 	}
 	hroot.th1.xaxis.xbins.Data = edges
+	hroot.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th1.yaxis.setHbookAxis(h.YAxis())
 	return hroot
 }
 
@@ -519,6 +623,8 @@ func (h *H1D) AsH1D() *hbook.H1D {
 		bin.Range.Max = xmax
 		hh.Binning.Bins[i].Dist = h.dist1D(i + 1)
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
 
 	return hh
 }
@@ -556,6 +662,81 @@ func (h *H1D) ROOTMerge(src root.Object) error {
 	return nil
 }
 
+// Fill fills this histogram with x and weight w.
+func (h *H1D) Fill(x, w float64) {
+	histFill(h, x, 0, w)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H1D) Add(o H1, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H1D) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H1D) Multiply(o H1) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H1D) Divide(o H1, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// Rebin merges groups of n consecutive bins into single bins.
+// If the number of bins is not a multiple of n, the remaining bins are
+// merged into the overflow bin.
+func (h *H1D) Rebin(n int) error {
+	return histRebin(h, n, 1)
+}
+
+func (h *H1D) base() *th1 {
+	return &h.th1
+}
+
+func (h *H1D) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H1D) setCell(i int, v float64) {
+	h.arr.Data[i] = float64(v)
+}
+
+func (h *H1D) setCells(vs []float64) {
+	h.arr.Data = make([]float64, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = float64(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x and weights*x².
+func (h *H1D) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x and weights*x².
+func (h *H1D) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH1D()
@@ -569,6 +750,7 @@ var (
 	_ root.Merger        = (*H1D)(nil)
 	_ root.Named         = (*H1D)(nil)
 	_ H1                 = (*H1D)(nil)
+	_ histCells          = (*H1D)(nil)
 	_ rbytes.Marshaler   = (*H1D)(nil)
 	_ rbytes.Unmarshaler = (*H1D)(nil)
 )
@@ -585,6 +767,17 @@ func newH1I() *H1I {
 	}
 }
 
+// NewH1IFromEdges creates a new, empty, 1-dim histogram with
+// bins defined by the provided edges.
+// NewH1IFromEdges panics if fewer than two edges are provided,
+// or if the edges are not sorted in increasing order.
+func NewH1IFromEdges(name, title string, edges []float64) *H1I {
+	h := hbook.NewH1DFromEdges(edges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH1IFrom(h)
+}
+
 // NewH1IFrom creates a new 1-dim histogram from hbook.
 func NewH1IFrom(h *hbook.H1D) *H1I {
 	var (
@@ -625,6 +818,8 @@ func NewH1IFrom(h *hbook.H1D) *H1I {
 		hroot.th1.SetTitle(v.(string))
 	}
 	hroot.th1.xaxis.xbins.Data = edges
+	hroot.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th1.yaxis.setHbookAxis(h.YAxis())
 	return hroot
 }
 
@@ -796,6 +991,8 @@ func (h *H1I) AsH1D() *hbook.H1D {
 		bin.Range.Max = xmax
 		hh.Binning.Bins[i].Dist = h.dist1D(i + 1)
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
 
 	return hh
 }
@@ -833,6 +1030,81 @@ func (h *H1I) ROOTMerge(src root.Object) error {
 	return nil
 }
 
+// Fill fills this histogram with x and weight w.
+func (h *H1I) Fill(x, w float64) {
+	histFill(h, x, 0, w)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H1I) Add(o H1, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H1I) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H1I) Multiply(o H1) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H1I) Divide(o H1, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// Rebin merges groups of n consecutive bins into single bins.
+// If the number of bins is not a multiple of n, the remaining bins are
+// merged into the overflow bin.
+func (h *H1I) Rebin(n int) error {
+	return histRebin(h, n, 1)
+}
+
+func (h *H1I) base() *th1 {
+	return &h.th1
+}
+
+func (h *H1I) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H1I) setCell(i int, v float64) {
+	h.arr.Data[i] = int32(v)
+}
+
+func (h *H1I) setCells(vs []float64) {
+	h.arr.Data = make([]int32, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = int32(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x and weights*x².
+func (h *H1I) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x and weights*x².
+func (h *H1I) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH1I()
@@ -846,6 +1118,7 @@ var (
 	_ root.Merger        = (*H1I)(nil)
 	_ root.Named         = (*H1I)(nil)
 	_ H1                 = (*H1I)(nil)
+	_ histCells          = (*H1I)(nil)
 	_ rbytes.Marshaler   = (*H1I)(nil)
 	_ rbytes.Unmarshaler = (*H1I)(nil)
 )
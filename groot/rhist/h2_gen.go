@@ -31,6 +31,17 @@ func newH2F() *H2F {
 	}
 }
 
+// NewH2FFromEdges creates a new, empty, 2-dim histogram with
+// bins defined by the provided edges along X and Y.
+// NewH2FFromEdges panics if fewer than two edges are provided
+// along an axis, or if the edges are not sorted in increasing order.
+func NewH2FFromEdges(name, title string, xedges, yedges []float64) *H2F {
+	h := hbook.NewH2DFromEdges(xedges, yedges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH2FFrom(h)
+}
+
 // NewH2FFrom creates a new H2F from hbook 2-dim histogram.
 func NewH2FFrom(h *hbook.H2D) *H2F {
 	var (
@@ -104,6 +115,9 @@ func NewH2FFrom(h *hbook.H2D) *H2F {
 	}
 	hroot.th2.th1.xaxis.xbins.Data = xedges
 	hroot.th2.th1.yaxis.xbins.Data = yedges
+	hroot.th2.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th2.th1.yaxis.setHbookAxis(h.YAxis())
+	hroot.th2.th1.zaxis.setHbookAxis(h.ZAxis())
 
 	return hroot
 }
@@ -335,6 +349,9 @@ func (h *H2F) AsH2D() *hbook.H2D {
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
+	hh.SetZAxis(h.th1.zaxis.hbookAxis())
 
 	return hh
 }
@@ -388,6 +405,129 @@ func (h *H2F) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Fill fills this histogram with (x,y) and weight w.
+func (h *H2F) Fill(x, y, w float64) {
+	histFill(h, x, y, w)
+}
+
+func (h *H2F) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2F)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2F (%T)", src.(root.Named).Name(), src)
+	}
+	return h.Add(hsrc, 1)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H2F) Add(o H2, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H2F) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H2F) Multiply(o H2) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H2F) Divide(o H2, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// RebinX merges groups of n consecutive bins along X into single bins.
+// If the number of bins along X is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2F) RebinX(n int) error {
+	return histRebin(h, n, 1)
+}
+
+// RebinY merges groups of n consecutive bins along Y into single bins.
+// If the number of bins along Y is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2F) RebinY(n int) error {
+	return histRebin(h, 1, n)
+}
+
+// ProjectionX returns the projection of this histogram onto the X axis,
+// summing the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_px" suffix.
+func (h *H2F) ProjectionX(name string, iymin, iymax int) *H1D {
+	return histProjection(h, name, 0, iymin, iymax)
+}
+
+// ProjectionY returns the projection of this histogram onto the Y axis,
+// summing the X bins from ixmin to ixmax (included.)
+// If ixmax < ixmin, all the X bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_py" suffix.
+func (h *H2F) ProjectionY(name string, ixmin, ixmax int) *H1D {
+	return histProjection(h, name, 1, ixmin, ixmax)
+}
+
+// ProfileX returns the profile of this histogram along the X axis, using
+// the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are used.
+// If name is empty, the name of the profile is the name of this histogram
+// with a "_pfx" suffix.
+func (h *H2F) ProfileX(name string, iymin, iymax int) *Profile1D {
+	return histProfileX(h, name, iymin, iymax)
+}
+
+func (h *H2F) base() *th1 {
+	return &h.th1
+}
+
+func (h *H2F) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H2F) setCell(i int, v float64) {
+	h.arr.Data[i] = float32(v)
+}
+
+func (h *H2F) setCells(vs []float64) {
+	h.arr.Data = make([]float32, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = float32(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2F) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+		h.th2.tsumwy, h.th2.tsumwy2, h.th2.tsumwxy,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2F) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+	h.th2.tsumwy = s[4]
+	h.th2.tsumwy2 = s[5]
+	h.th2.tsumwxy = s[6]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH2F()
@@ -398,8 +538,10 @@ func init() {
 
 var (
 	_ root.Object        = (*H2F)(nil)
+	_ root.Merger        = (*H2F)(nil)
 	_ root.Named         = (*H2F)(nil)
 	_ H2                 = (*H2F)(nil)
+	_ histCells          = (*H2F)(nil)
 	_ rbytes.Marshaler   = (*H2F)(nil)
 	_ rbytes.Unmarshaler = (*H2F)(nil)
 )
@@ -416,6 +558,17 @@ func newH2D() *H2D {
 	}
 }
 
+// NewH2DFromEdges creates a new, empty, 2-dim histogram with
+// bins defined by the provided edges along X and Y.
+// NewH2DFromEdges panics if fewer than two edges are provided
+// along an axis, or if the edges are not sorted in increasing order.
+func NewH2DFromEdges(name, title string, xedges, yedges []float64) *H2D {
+	h := hbook.NewH2DFromEdges(xedges, yedges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH2DFrom(h)
+}
+
 // NewH2DFrom creates a new H2D from hbook 2-dim histogram.
 func NewH2DFrom(h *hbook.H2D) *H2D {
 	var (
@@ -489,6 +642,9 @@ func NewH2DFrom(h *hbook.H2D) *H2D {
 	}
 	hroot.th2.th1.xaxis.xbins.Data = xedges
 	hroot.th2.th1.yaxis.xbins.Data = yedges
+	hroot.th2.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th2.th1.yaxis.setHbookAxis(h.YAxis())
+	hroot.th2.th1.zaxis.setHbookAxis(h.ZAxis())
 
 	return hroot
 }
@@ -720,6 +876,9 @@ func (h *H2D) AsH2D() *hbook.H2D {
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
+	hh.SetZAxis(h.th1.zaxis.hbookAxis())
 
 	return hh
 }
@@ -773,6 +932,129 @@ func (h *H2D) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Fill fills this histogram with (x,y) and weight w.
+func (h *H2D) Fill(x, y, w float64) {
+	histFill(h, x, y, w)
+}
+
+func (h *H2D) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2D)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2D (%T)", src.(root.Named).Name(), src)
+	}
+	return h.Add(hsrc, 1)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H2D) Add(o H2, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H2D) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H2D) Multiply(o H2) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H2D) Divide(o H2, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// RebinX merges groups of n consecutive bins along X into single bins.
+// If the number of bins along X is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2D) RebinX(n int) error {
+	return histRebin(h, n, 1)
+}
+
+// RebinY merges groups of n consecutive bins along Y into single bins.
+// If the number of bins along Y is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2D) RebinY(n int) error {
+	return histRebin(h, 1, n)
+}
+
+// ProjectionX returns the projection of this histogram onto the X axis,
+// summing the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_px" suffix.
+func (h *H2D) ProjectionX(name string, iymin, iymax int) *H1D {
+	return histProjection(h, name, 0, iymin, iymax)
+}
+
+// ProjectionY returns the projection of this histogram onto the Y axis,
+// summing the X bins from ixmin to ixmax (included.)
+// If ixmax < ixmin, all the X bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_py" suffix.
+func (h *H2D) ProjectionY(name string, ixmin, ixmax int) *H1D {
+	return histProjection(h, name, 1, ixmin, ixmax)
+}
+
+// ProfileX returns the profile of this histogram along the X axis, using
+// the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are used.
+// If name is empty, the name of the profile is the name of this histogram
+// with a "_pfx" suffix.
+func (h *H2D) ProfileX(name string, iymin, iymax int) *Profile1D {
+	return histProfileX(h, name, iymin, iymax)
+}
+
+func (h *H2D) base() *th1 {
+	return &h.th1
+}
+
+func (h *H2D) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H2D) setCell(i int, v float64) {
+	h.arr.Data[i] = float64(v)
+}
+
+func (h *H2D) setCells(vs []float64) {
+	h.arr.Data = make([]float64, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = float64(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2D) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+		h.th2.tsumwy, h.th2.tsumwy2, h.th2.tsumwxy,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2D) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+	h.th2.tsumwy = s[4]
+	h.th2.tsumwy2 = s[5]
+	h.th2.tsumwxy = s[6]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH2D()
@@ -783,8 +1065,10 @@ func init() {
 
 var (
 	_ root.Object        = (*H2D)(nil)
+	_ root.Merger        = (*H2D)(nil)
 	_ root.Named         = (*H2D)(nil)
 	_ H2                 = (*H2D)(nil)
+	_ histCells          = (*H2D)(nil)
 	_ rbytes.Marshaler   = (*H2D)(nil)
 	_ rbytes.Unmarshaler = (*H2D)(nil)
 )
@@ -801,6 +1085,17 @@ func newH2I() *H2I {
 	}
 }
 
+// NewH2IFromEdges creates a new, empty, 2-dim histogram with
+// bins defined by the provided edges along X and Y.
+// NewH2IFromEdges panics if fewer than two edges are provided
+// along an axis, or if the edges are not sorted in increasing order.
+func NewH2IFromEdges(name, title string, xedges, yedges []float64) *H2I {
+	h := hbook.NewH2DFromEdges(xedges, yedges)
+	h.Annotation()["name"] = name
+	h.Annotation()["title"] = title
+	return NewH2IFrom(h)
+}
+
 // NewH2IFrom creates a new H2I from hbook 2-dim histogram.
 func NewH2IFrom(h *hbook.H2D) *H2I {
 	var (
@@ -874,6 +1169,9 @@ func NewH2IFrom(h *hbook.H2D) *H2I {
 	}
 	hroot.th2.th1.xaxis.xbins.Data = xedges
 	hroot.th2.th1.yaxis.xbins.Data = yedges
+	hroot.th2.th1.xaxis.setHbookAxis(h.XAxis())
+	hroot.th2.th1.yaxis.setHbookAxis(h.YAxis())
+	hroot.th2.th1.zaxis.setHbookAxis(h.ZAxis())
 
 	return hroot
 }
@@ -1105,6 +1403,9 @@ func (h *H2I) AsH2D() *hbook.H2D {
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
+	hh.SetXAxis(h.th1.xaxis.hbookAxis())
+	hh.SetYAxis(h.th1.yaxis.hbookAxis())
+	hh.SetZAxis(h.th1.zaxis.hbookAxis())
 
 	return hh
 }
@@ -1158,6 +1459,129 @@ func (h *H2I) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Fill fills this histogram with (x,y) and weight w.
+func (h *H2I) Fill(x, y, w float64) {
+	histFill(h, x, y, w)
+}
+
+func (h *H2I) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2I)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2I (%T)", src.(root.Named).Name(), src)
+	}
+	return h.Add(hsrc, 1)
+}
+
+// Add adds the bin contents of o, scaled by c, to this histogram.
+// Sums of squares of weights are propagated accordingly.
+// o must have the same binning as this histogram.
+func (h *H2I) Add(o H2, c float64) error {
+	return histAdd(h, o, c)
+}
+
+// Scale multiplies the bin contents of this histogram by c.
+// Sums of squares of weights are multiplied by c².
+func (h *H2I) Scale(c float64) {
+	histScale(h, c)
+}
+
+// Multiply multiplies the bin contents of this histogram by the ones of o.
+// o must have the same binning as this histogram.
+func (h *H2I) Multiply(o H2) error {
+	return histMultiply(h, o)
+}
+
+// Divide divides the bin contents of this histogram by the ones of o.
+// Bins where o is empty are set to zero.
+// If binomial is true, errors are computed assuming the bin contents of
+// this histogram are a subset of the ones of o (as for an efficiency.)
+// o must have the same binning as this histogram.
+func (h *H2I) Divide(o H2, binomial bool) error {
+	return histDivide(h, o, binomial)
+}
+
+// RebinX merges groups of n consecutive bins along X into single bins.
+// If the number of bins along X is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2I) RebinX(n int) error {
+	return histRebin(h, n, 1)
+}
+
+// RebinY merges groups of n consecutive bins along Y into single bins.
+// If the number of bins along Y is not a multiple of n, the remaining bins
+// are merged into the overflow bins.
+func (h *H2I) RebinY(n int) error {
+	return histRebin(h, 1, n)
+}
+
+// ProjectionX returns the projection of this histogram onto the X axis,
+// summing the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_px" suffix.
+func (h *H2I) ProjectionX(name string, iymin, iymax int) *H1D {
+	return histProjection(h, name, 0, iymin, iymax)
+}
+
+// ProjectionY returns the projection of this histogram onto the Y axis,
+// summing the X bins from ixmin to ixmax (included.)
+// If ixmax < ixmin, all the X bins, including under/overflow bins, are summed.
+// If name is empty, the name of the projection is the name of this histogram
+// with a "_py" suffix.
+func (h *H2I) ProjectionY(name string, ixmin, ixmax int) *H1D {
+	return histProjection(h, name, 1, ixmin, ixmax)
+}
+
+// ProfileX returns the profile of this histogram along the X axis, using
+// the Y bins from iymin to iymax (included.)
+// If iymax < iymin, all the Y bins, including under/overflow bins, are used.
+// If name is empty, the name of the profile is the name of this histogram
+// with a "_pfx" suffix.
+func (h *H2I) ProfileX(name string, iymin, iymax int) *Profile1D {
+	return histProfileX(h, name, iymin, iymax)
+}
+
+func (h *H2I) base() *th1 {
+	return &h.th1
+}
+
+func (h *H2I) cell(i int) float64 {
+	return float64(h.arr.Data[i])
+}
+
+func (h *H2I) setCell(i int, v float64) {
+	h.arr.Data[i] = int32(v)
+}
+
+func (h *H2I) setCells(vs []float64) {
+	h.arr.Data = make([]int32, len(vs))
+	for i, v := range vs {
+		h.arr.Data[i] = int32(v)
+	}
+}
+
+// stats returns the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2I) stats() []float64 {
+	return []float64{
+		h.th1.tsumw, h.th1.tsumw2,
+		h.th1.tsumwx, h.th1.tsumwx2,
+		h.th2.tsumwy, h.th2.tsumwy2, h.th2.tsumwxy,
+	}
+}
+
+// setStats sets the sums of weights, weights², weights*x, weights*x²,
+// weights*y, weights*y² and weights*x*y.
+func (h *H2I) setStats(s []float64) {
+	h.th1.tsumw = s[0]
+	h.th1.tsumw2 = s[1]
+	h.th1.tsumwx = s[2]
+	h.th1.tsumwx2 = s[3]
+	h.th2.tsumwy = s[4]
+	h.th2.tsumwy2 = s[5]
+	h.th2.tsumwxy = s[6]
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := newH2I()
@@ -1168,8 +1592,10 @@ func init() {
 
 var (
 	_ root.Object        = (*H2I)(nil)
+	_ root.Merger        = (*H2I)(nil)
 	_ root.Named         = (*H2I)(nil)
 	_ H2                 = (*H2I)(nil)
+	_ histCells          = (*H2I)(nil)
 	_ rbytes.Marshaler   = (*H2I)(nil)
 	_ rbytes.Unmarshaler = (*H2I)(nil)
 )
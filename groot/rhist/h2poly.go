@@ -0,0 +1,601 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+const (
+	h2polyNOverflow = 9  // number of overflow bins of a TH2Poly
+	h2polyNCells    = 25 // default number of partition cells along x and y
+)
+
+// H2Poly is a 2-dim histogram with bins of arbitrary polygonal shapes.
+//
+// Bins are numbered from 1 to Len(), in the order they were added.
+// The 9 overflow bins, numbered from -1 to -9, hold the contents of the
+// regions around (and inside, for the -5 bin) the histogram range that
+// are not covered by any bin:
+//
+//	-1 | -2 | -3
+//	-------------
+//	-4 | -5 | -6
+//	-------------
+//	-7 | -8 | -9
+type H2Poly struct {
+	th2
+
+	overflow [h2polyNOverflow]float64 // overflow bins
+	cellX    int32                    // number of partition cells in the x-direction of the histogram
+	cellY    int32                    // number of partition cells in the y-direction of the histogram
+	ncells   int32                    // number of partition cells: cellX*cellY
+	cells    []rcont.List             // bins that intersect with each partition cell
+	stepX    float64                  // dimensions of a partition cell
+	stepY    float64                  // dimensions of a partition cell
+	isEmpty  []bool                   // whether the partition cell at a given coordinate is empty
+	inside   []bool                   // whether the partition cell at a given coordinate is completely inside a bin
+	float    bool                     // whether the histogram can expand if a bin outside the limits is added
+	bins     *rcont.List              // list of bins
+}
+
+func newH2Poly() *H2Poly {
+	return &H2Poly{
+		th2: *newH2(),
+	}
+}
+
+// NewH2Poly creates a new, empty, 2-dim histogram with polygonal bins,
+// for the provided range.
+func NewH2Poly(name, title string, xmin, xmax, ymin, ymax float64) *H2Poly {
+	h := newH2Poly()
+	h.th1.SetName(name)
+	h.th1.SetTitle(title)
+	h.th1.ncells = h2polyNOverflow
+	h.th1.xaxis.nbins = 1
+	h.th1.xaxis.xmin = xmin
+	h.th1.xaxis.xmax = xmax
+	h.th1.yaxis.nbins = 1
+	h.th1.yaxis.xmin = ymin
+	h.th1.yaxis.xmax = ymax
+
+	h.cellX = h2polyNCells
+	h.cellY = h2polyNCells
+	h.ncells = h.cellX * h.cellY
+	h.cells = make([]rcont.List, h.ncells)
+	for i := range h.cells {
+		h.cells[i] = *rcont.NewList("", nil)
+	}
+	h.stepX = (xmax - xmin) / float64(h.cellX)
+	h.stepY = (ymax - ymin) / float64(h.cellY)
+	h.isEmpty = make([]bool, h.ncells)
+	for i := range h.isEmpty {
+		h.isEmpty[i] = true
+	}
+	h.inside = make([]bool, h.ncells)
+	h.bins = rcont.NewList("", nil)
+	return h
+}
+
+func (*H2Poly) Class() string {
+	return "TH2Poly"
+}
+
+func (*H2Poly) RVersion() int16 {
+	return rvers.H2Poly
+}
+
+// Len returns the number of (non-overflow) bins.
+func (h *H2Poly) Len() int {
+	if h.bins == nil {
+		return 0
+	}
+	return h.bins.Len()
+}
+
+// Bin returns the bin with the provided bin number, in [1, Len()].
+func (h *H2Poly) Bin(bin int) *H2PolyBin {
+	return h.bins.At(bin - 1).(*H2PolyBin)
+}
+
+// AddBin adds a new bin with the polygonal shape described by the
+// provided vertices, and returns its bin number.
+// The polygon is implicitly closed.
+func (h *H2Poly) AddBin(xs, ys []float64) int {
+	if len(xs) != len(ys) {
+		panic(fmt.Errorf("rhist: length mismatch (len(xs)=%d, len(ys)=%d)", len(xs), len(ys)))
+	}
+
+	g := newGraph(len(xs))
+	g.Named.SetName("Graph")
+	g.Named.SetTitle("Graph")
+	g.min = -1111
+	g.max = -1111
+	copy(g.x, xs)
+	copy(g.y, ys)
+
+	bin := newH2PolyBin(g, h.Len()+1)
+	h.bins.Append(bin)
+	h.th1.ncells++
+	if len(h.th1.sumw2.Data) > 0 {
+		h.th1.sumw2.Data = append(h.th1.sumw2.Data, 0)
+	}
+	h.addToPartition(bin)
+	return bin.Number()
+}
+
+// AddBinRect adds a new rectangular bin, from (x1,y1) to (x2,y2), and
+// returns its bin number.
+func (h *H2Poly) AddBinRect(x1, y1, x2, y2 float64) int {
+	return h.AddBin(
+		[]float64{x1, x2, x2, x1, x1},
+		[]float64{y1, y1, y2, y2, y1},
+	)
+}
+
+// addToPartition adds the provided bin to all the partition cells its
+// bounding box overlaps with.
+func (h *H2Poly) addToPartition(bin *H2PolyBin) {
+	var (
+		xmin = h.th1.xaxis.xmin
+		ymin = h.th1.yaxis.xmin
+		nl   = h.cellIndex(bin.xmin, xmin, h.stepX, h.cellX)
+		nr   = h.cellIndex(bin.xmax, xmin, h.stepX, h.cellX)
+		mb   = h.cellIndex(bin.ymin, ymin, h.stepY, h.cellY)
+		mt   = h.cellIndex(bin.ymax, ymin, h.stepY, h.cellY)
+	)
+	for i := nl; i <= nr; i++ {
+		for j := mb; j <= mt; j++ {
+			cell := i + j*int(h.cellX)
+			h.cells[cell].Append(bin)
+			h.isEmpty[cell] = false
+		}
+	}
+}
+
+func (h *H2Poly) cellIndex(v, min, step float64, n int32) int {
+	i := int(math.Floor((v - min) / step))
+	switch {
+	case i >= int(n):
+		i = int(n) - 1
+	case i < 0:
+		i = 0
+	}
+	return i
+}
+
+// Fill fills the histogram with the provided (x,y) point and weight.
+// Fill returns the number of the filled bin.
+func (h *H2Poly) Fill(x, y, w float64) int {
+	if len(h.th1.sumw2.Data) == 0 && w != 1 {
+		h.sumw2()
+	}
+
+	var (
+		xaxis    = &h.th1.xaxis
+		yaxis    = &h.th1.yaxis
+		overflow int
+	)
+	switch {
+	case y > yaxis.xmax:
+		overflow -= 1
+	case y > yaxis.xmin:
+		overflow -= 4
+	default:
+		overflow -= 7
+	}
+	switch {
+	case x > xaxis.xmax:
+		overflow -= 2
+	case x > xaxis.xmin:
+		overflow -= 1
+	}
+	if overflow != -5 {
+		h.fillOverflow(-overflow-1, w)
+		return overflow
+	}
+
+	var (
+		n    = h.cellIndex(x, xaxis.xmin, h.stepX, h.cellX)
+		m    = h.cellIndex(y, yaxis.xmin, h.stepY, h.cellY)
+		cell = n + int(h.cellX)*m
+	)
+	if !h.isEmpty[cell] {
+		cell := &h.cells[cell]
+		for i := 0; i < cell.Len(); i++ {
+			bin := cell.At(i).(*H2PolyBin)
+			if !bin.IsInside(x, y) {
+				continue
+			}
+			bin.content += w
+			bin.changed = true
+
+			h.th1.entries++
+			h.th1.tsumw += w
+			h.th1.tsumw2 += w * w
+			h.th1.tsumwx += w * x
+			h.th1.tsumwx2 += w * x * x
+			h.th2.tsumwy += w * y
+			h.th2.tsumwy2 += w * y * y
+			if len(h.th1.sumw2.Data) > 0 {
+				h.th1.sumw2.Data[bin.Number()-1+h2polyNOverflow] += w * w
+			}
+			return bin.Number()
+		}
+	}
+
+	h.fillOverflow(4, w)
+	return -5
+}
+
+func (h *H2Poly) fillOverflow(i int, w float64) {
+	h.overflow[i] += w
+	if len(h.th1.sumw2.Data) > 0 {
+		h.th1.sumw2.Data[i] += w * w
+	}
+}
+
+// sumw2 creates the sum of squares of weights array, assuming all
+// previous entries were filled with a unit weight.
+func (h *H2Poly) sumw2() {
+	sumw2 := make([]float64, h2polyNOverflow+h.Len())
+	for i, v := range h.overflow {
+		sumw2[i] = math.Abs(v)
+	}
+	for i := 0; i < h.Len(); i++ {
+		sumw2[i+h2polyNOverflow] = math.Abs(h.Bin(i + 1).content)
+	}
+	h.th1.sumw2.Data = sumw2
+}
+
+// BinContent returns the content of the provided bin.
+// Bin numbers are in [1, Len()] for regular bins, and in [-9, -1] for
+// overflow bins.
+func (h *H2Poly) BinContent(bin int) float64 {
+	switch {
+	case bin > h.Len() || bin == 0 || bin < -h2polyNOverflow:
+		return 0
+	case bin < 0:
+		return h.overflow[-bin-1]
+	default:
+		return h.Bin(bin).content
+	}
+}
+
+// BinError returns the error on the content of the provided bin.
+func (h *H2Poly) BinError(bin int) float64 {
+	if bin > h.Len() || bin == 0 || bin < -h2polyNOverflow {
+		return 0
+	}
+	if sumw2 := h.th1.sumw2.Data; len(sumw2) > 0 {
+		i := -(bin + 1)
+		if bin > 0 {
+			i = bin + h2polyNOverflow - 1
+		}
+		if i >= len(sumw2) {
+			return 0
+		}
+		return math.Sqrt(sumw2[i])
+	}
+	return math.Sqrt(math.Abs(h.BinContent(bin)))
+}
+
+// Polygons returns the vertices of the polygons making up the i-th bin,
+// with i in [0, Len()).
+func (h *H2Poly) Polygons(i int) (xs, ys [][]float64) {
+	return h.Bin(i + 1).Polygons()
+}
+
+// Value returns the content of the i-th bin, with i in [0, Len()).
+func (h *H2Poly) Value(i int) float64 {
+	return h.Bin(i + 1).content
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (h *H2Poly) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+
+	w.WriteObject(&h.th2)
+	w.WriteArrayF64(h.overflow[:])
+	w.WriteI32(h.cellX)
+	w.WriteI32(h.cellY)
+	w.WriteI32(h.ncells)
+	{
+		hdr := w.WriteHeader("TList", rvers.StreamerInfo)
+		for i := range h.cells {
+			w.WriteObject(&h.cells[i])
+		}
+		_, _ = w.SetHeader(hdr)
+	}
+	w.WriteF64(h.stepX)
+	w.WriteF64(h.stepY)
+	w.WriteI8(1) // is-array
+	w.WriteArrayBool(h.isEmpty)
+	w.WriteI8(1) // is-array
+	w.WriteArrayBool(h.inside)
+	w.WriteBool(h.float)
+	w.WriteObjectAny(h.bins) // obj-ptr
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (h *H2Poly) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class())
+	if hdr.Vers > rvers.H2Poly {
+		panic(fmt.Errorf("rhist: invalid TH2Poly version=%d > %d", hdr.Vers, rvers.H2Poly))
+	}
+
+	r.ReadObject(&h.th2)
+	r.ReadArrayF64(h.overflow[:])
+	h.cellX = r.ReadI32()
+	h.cellY = r.ReadI32()
+	h.ncells = r.ReadI32()
+	{
+		hdr := r.ReadHeader("TList")
+		h.cells = make([]rcont.List, h.ncells)
+		for i := range h.cells {
+			r.ReadObject(&h.cells[i])
+		}
+		r.CheckHeader(hdr)
+	}
+	h.stepX = r.ReadF64()
+	h.stepY = r.ReadF64()
+	h.isEmpty = h.readBools(r)
+	h.inside = h.readBools(r)
+	h.float = r.ReadBool()
+	{
+		h.bins = nil
+		if oo := r.ReadObjectAny(); oo != nil { // obj-ptr
+			h.bins = oo.(*rcont.List)
+		}
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (h *H2Poly) readBools(r *rbytes.RBuffer) []bool {
+	if r.ReadI8() == 0 { // is-array
+		return nil
+	}
+	vs := make([]bool, h.ncells)
+	r.ReadArrayBool(vs)
+	return vs
+}
+
+// H2PolyBin is a bin of a H2Poly histogram.
+type H2PolyBin struct {
+	obj     rbase.Object
+	changed bool        // for the 3D painter
+	number  int32       // bin number of the bin in H2Poly
+	poly    root.Object // object holding the polygon definition (a Graph or a MultiGraph)
+	area    float64     // bin area
+	content float64     // bin content
+	xmin    float64     // x minimum value
+	ymin    float64     // y minimum value
+	xmax    float64     // x maximum value
+	ymax    float64     // y maximum value
+}
+
+func newH2PolyBin(poly Graph, number int) *H2PolyBin {
+	bin := &H2PolyBin{
+		obj:     *rbase.NewObject(),
+		changed: true,
+		number:  int32(number),
+		poly:    poly,
+		xmin:    +math.MaxFloat64,
+		ymin:    +math.MaxFloat64,
+		xmax:    -math.MaxFloat64,
+		ymax:    -math.MaxFloat64,
+	}
+	for i := 0; i < poly.Len(); i++ {
+		x, y := poly.XY(i)
+		bin.xmin = math.Min(bin.xmin, x)
+		bin.xmax = math.Max(bin.xmax, x)
+		bin.ymin = math.Min(bin.ymin, y)
+		bin.ymax = math.Max(bin.ymax, y)
+	}
+	bin.area = polygonArea(poly)
+	return bin
+}
+
+func (*H2PolyBin) Class() string {
+	return "TH2PolyBin"
+}
+
+func (*H2PolyBin) RVersion() int16 {
+	return rvers.H2PolyBin
+}
+
+// Number returns the bin number of the bin in its H2Poly.
+func (b *H2PolyBin) Number() int {
+	return int(b.number)
+}
+
+// Content returns the content of the bin.
+func (b *H2PolyBin) Content() float64 {
+	return b.content
+}
+
+// Area returns the area of the bin.
+func (b *H2PolyBin) Area() float64 {
+	return b.area
+}
+
+// XMin returns the minimum x value of the bin.
+func (b *H2PolyBin) XMin() float64 { return b.xmin }
+
+// XMax returns the maximum x value of the bin.
+func (b *H2PolyBin) XMax() float64 { return b.xmax }
+
+// YMin returns the minimum y value of the bin.
+func (b *H2PolyBin) YMin() float64 { return b.ymin }
+
+// YMax returns the maximum y value of the bin.
+func (b *H2PolyBin) YMax() float64 { return b.ymax }
+
+// graphs returns the graphs describing the polygons of the bin.
+func (b *H2PolyBin) graphs() []Graph {
+	switch poly := b.poly.(type) {
+	case Graph:
+		return []Graph{poly}
+	case MultiGraph:
+		return poly.Graphs()
+	default:
+		return nil
+	}
+}
+
+// Polygons returns the vertices of the polygons making up the bin.
+func (b *H2PolyBin) Polygons() (xs, ys [][]float64) {
+	gs := b.graphs()
+	xs = make([][]float64, len(gs))
+	ys = make([][]float64, len(gs))
+	for i, g := range gs {
+		n := g.Len()
+		xs[i] = make([]float64, n)
+		ys[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			xs[i][j], ys[i][j] = g.XY(j)
+		}
+	}
+	return xs, ys
+}
+
+// IsInside returns whether the (x,y) point is inside the bin.
+func (b *H2PolyBin) IsInside(x, y float64) bool {
+	for _, g := range b.graphs() {
+		if isInsidePolygon(x, y, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (b *H2PolyBin) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(b.Class(), b.RVersion())
+
+	w.WriteObject(&b.obj)
+	w.WriteBool(b.changed)
+	w.WriteI32(b.number)
+	w.WriteObjectAny(b.poly) // obj-ptr
+	w.WriteF64(b.area)
+	w.WriteF64(b.content)
+	w.WriteF64(b.xmin)
+	w.WriteF64(b.ymin)
+	w.WriteF64(b.xmax)
+	w.WriteF64(b.ymax)
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (b *H2PolyBin) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(b.Class())
+	if hdr.Vers > rvers.H2PolyBin {
+		panic(fmt.Errorf("rhist: invalid TH2PolyBin version=%d > %d", hdr.Vers, rvers.H2PolyBin))
+	}
+
+	r.ReadObject(&b.obj)
+	b.changed = r.ReadBool()
+	b.number = r.ReadI32()
+	b.poly = r.ReadObjectAny()
+	b.area = r.ReadF64()
+	b.content = r.ReadF64()
+	b.xmin = r.ReadF64()
+	b.ymin = r.ReadF64()
+	b.xmax = r.ReadF64()
+	b.ymax = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// polygonArea returns the area of the polygon described by the graph.
+func polygonArea(g Graph) float64 {
+	var (
+		n   = g.Len()
+		sum = 0.0
+	)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		xi, yi := g.XY(i)
+		xj, yj := g.XY(j)
+		sum += (xi + xj) * (yj - yi)
+	}
+	return 0.5 * math.Abs(sum)
+}
+
+// isInsidePolygon returns whether the (x,y) point is inside the polygon
+// described by the graph, using the even-odd rule.
+func isInsidePolygon(x, y float64, g Graph) bool {
+	var (
+		n      = g.Len()
+		inside = false
+	)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		xi, yi := g.XY(i)
+		xj, yj := g.XY(j)
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newH2Poly()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TH2Poly", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &H2PolyBin{obj: *rbase.NewObject()}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TH2PolyBin", f)
+	}
+}
+
+var (
+	_ root.Object        = (*H2Poly)(nil)
+	_ root.Named         = (*H2Poly)(nil)
+	_ rbytes.Marshaler   = (*H2Poly)(nil)
+	_ rbytes.Unmarshaler = (*H2Poly)(nil)
+
+	_ root.Object        = (*H2PolyBin)(nil)
+	_ rbytes.Marshaler   = (*H2PolyBin)(nil)
+	_ rbytes.Unmarshaler = (*H2PolyBin)(nil)
+)
@@ -0,0 +1,125 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestH2Poly(t *testing.T) {
+	h := rhist.NewH2Poly("h2poly", "my title", 0, 4, 0, 4)
+	if got, want := h.AddBinRect(0, 0, 2, 2), 1; got != want {
+		t.Fatalf("invalid bin number: got=%d, want=%d", got, want)
+	}
+	if got, want := h.AddBin([]float64{2, 4, 4}, []float64{0, 0, 4}), 2; got != want {
+		t.Fatalf("invalid bin number: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		x, y, w float64
+		bin     int
+	}{
+		{1, 1, 1, 1},
+		{1.5, 0.5, 1, 1},
+		{3.5, 1, 2, 2},
+		{2.5, 3, 1, -5}, // in range, but in no bin.
+		{-1, 1, 1, -4},
+		{5, 5, 1, -3},
+		{1, -1, 1, -8},
+	} {
+		if got, want := h.Fill(tc.x, tc.y, tc.w), tc.bin; got != want {
+			t.Fatalf("invalid filled bin for (%v,%v): got=%d, want=%d", tc.x, tc.y, got, want)
+		}
+	}
+
+	check := func(t *testing.T, h *rhist.H2Poly) {
+		t.Helper()
+		if got, want := h.Len(), 2; got != want {
+			t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+		}
+		for _, tc := range []struct {
+			bin  int
+			want float64
+			err  float64
+		}{
+			{1, 2, 1.4142135623730951},
+			{2, 2, 2},
+			{-5, 1, 1},
+			{-4, 1, 1},
+			{-3, 1, 1},
+			{-8, 1, 1},
+			{-1, 0, 0},
+			{0, 0, 0},
+			{3, 0, 0},
+		} {
+			if got, want := h.BinContent(tc.bin), tc.want; got != want {
+				t.Fatalf("invalid content for bin %d: got=%v, want=%v", tc.bin, got, want)
+			}
+			if got, want := h.BinError(tc.bin), tc.err; got != want {
+				t.Fatalf("invalid error for bin %d: got=%v, want=%v", tc.bin, got, want)
+			}
+		}
+		if got, want := h.Entries(), 3.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := h.Bin(2).Area(), 4.0; got != want {
+			t.Fatalf("invalid area: got=%v, want=%v", got, want)
+		}
+		xs, ys := h.Polygons(1)
+		if got, want := xs, [][]float64{{2, 4, 4}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid polygon xs: got=%v, want=%v", got, want)
+		}
+		if got, want := ys, [][]float64{{0, 0, 4}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid polygon ys: got=%v, want=%v", got, want)
+		}
+	}
+	check(t, h)
+
+	fname := filepath.Join(t.TempDir(), "h2poly.root")
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	err = f.Put("h2poly", h)
+	if err != nil {
+		t.Fatalf("could not write histogram: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	obj, err := r.Get("h2poly")
+	if err != nil {
+		t.Fatalf("could not read histogram: %+v", err)
+	}
+	got := obj.(*rhist.H2Poly)
+	if got, want := got.Name(), "h2poly"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	check(t, got)
+
+	// bins of the partition cells and of the histogram should be shared.
+	if got, want := got.Fill(1, 1, 1), 1; got != want {
+		t.Fatalf("invalid filled bin: got=%d, want=%d", got, want)
+	}
+	if got, want := got.Bin(1).Content(), 3.0; got != want {
+		t.Fatalf("invalid content after fill: got=%v, want=%v", got, want)
+	}
+}
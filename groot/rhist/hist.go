@@ -14,6 +14,7 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 type th1 struct {
@@ -74,6 +75,28 @@ func (h *th1) Entries() float64 {
 	return h.entries
 }
 
+// YAxis returns the axis along Y.
+// For 1-dim histograms, the Y axis is the axis of the bin contents.
+func (h *th1) YAxis() Axis { return &h.yaxis }
+
+// AttLine returns the line attributes of the histogram.
+func (h *th1) AttLine() *rbase.AttLine { return &h.attline }
+
+// AttFill returns the fill attributes of the histogram.
+func (h *th1) AttFill() *rbase.AttFill { return &h.attfill }
+
+// AttMarker returns the marker attributes of the histogram.
+func (h *th1) AttMarker() *rbase.AttMarker { return &h.attmarker }
+
+// Functions returns the functions (fits and user) attached to the histogram.
+func (h *th1) Functions() []root.Object {
+	objs := make([]root.Object, h.funcs.Len())
+	for i := range objs {
+		objs[i] = h.funcs.At(i)
+	}
+	return objs
+}
+
 // SumW returns the total sum of weights
 func (h *th1) SumW() float64 {
 	return h.tsumw
@@ -280,6 +303,184 @@ func (h *th2) SumWXY() float64 {
 	return h.tsumwxy
 }
 
+type th3 struct {
+	th1
+	att3d   rbase.Att3D
+	tsumwy  float64 // total sum of weight*y
+	tsumwy2 float64 // total sum of weight*y*y
+	tsumwxy float64 // total sum of weight*x*y
+	tsumwz  float64 // total sum of weight*z
+	tsumwz2 float64 // total sum of weight*z*z
+	tsumwxz float64 // total sum of weight*x*z
+	tsumwyz float64 // total sum of weight*y*z
+}
+
+func newH3() *th3 {
+	return &th3{
+		th1: *newH1(),
+	}
+}
+
+func (*th3) RVersion() int16 {
+	return rvers.H3
+}
+
+func (*th3) Class() string {
+	return "TH3"
+}
+
+func (h *th3) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+
+	w.WriteObject(&h.th1)
+	w.WriteObject(&h.att3d)
+	w.WriteF64(h.tsumwy)
+	w.WriteF64(h.tsumwy2)
+	w.WriteF64(h.tsumwxy)
+	w.WriteF64(h.tsumwz)
+	w.WriteF64(h.tsumwz2)
+	w.WriteF64(h.tsumwxz)
+	w.WriteF64(h.tsumwyz)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *th3) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class())
+	if hdr.Vers > rvers.H3 {
+		panic(fmt.Errorf("rhist: invalid TH3 version=%d > %d", hdr.Vers, rvers.H3))
+	}
+	if hdr.Vers < 3 {
+		return fmt.Errorf("rhist: TH3 version too old (%d<3)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th1)
+	r.ReadObject(&h.att3d)
+	h.tsumwy = r.ReadF64()
+	h.tsumwy2 = r.ReadF64()
+	h.tsumwxy = r.ReadF64()
+	h.tsumwz = r.ReadF64()
+	h.tsumwz2 = r.ReadF64()
+	h.tsumwxz = r.ReadF64()
+	h.tsumwyz = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// SumWY returns the total sum of weights*y
+func (h *th3) SumWY() float64 {
+	return h.tsumwy
+}
+
+// SumWY2 returns the total sum of weights*y*y
+func (h *th3) SumWY2() float64 {
+	return h.tsumwy2
+}
+
+// SumWXY returns the total sum of weights*x*y
+func (h *th3) SumWXY() float64 {
+	return h.tsumwxy
+}
+
+// SumWZ returns the total sum of weights*z
+func (h *th3) SumWZ() float64 {
+	return h.tsumwz
+}
+
+// SumWZ2 returns the total sum of weights*z*z
+func (h *th3) SumWZ2() float64 {
+	return h.tsumwz2
+}
+
+// SumWXZ returns the total sum of weights*x*z
+func (h *th3) SumWXZ() float64 {
+	return h.tsumwxz
+}
+
+// SumWYZ returns the total sum of weights*y*z
+func (h *th3) SumWYZ() float64 {
+	return h.tsumwyz
+}
+
+// cell returns the index of the cell of the (ix,iy,iz) bin.
+func (h *th3) cell(ix, iy, iz int) int {
+	var (
+		nx = h.th1.xaxis.nbins + 2
+		ny = h.th1.yaxis.nbins + 2
+	)
+	return ix + nx*(iy+ny*iz)
+}
+
+// fill updates the statistics of the histogram for an entry (x,y,z)
+// with weight w, and returns the index of the cell containing that entry.
+func (h *th3) fill(x, y, z, w float64) int {
+	var (
+		ix = h.th1.xaxis.findBin(x)
+		iy = h.th1.yaxis.findBin(y)
+		iz = h.th1.zaxis.findBin(z)
+		i  = h.cell(ix, iy, iz)
+	)
+
+	h.th1.entries++
+	if len(h.th1.sumw2.Data) > 0 {
+		h.th1.sumw2.Data[i] += w * w
+	}
+
+	switch {
+	case ix < 1 || ix > h.th1.xaxis.nbins,
+		iy < 1 || iy > h.th1.yaxis.nbins,
+		iz < 1 || iz > h.th1.zaxis.nbins:
+		return i
+	}
+
+	h.th1.tsumw += w
+	h.th1.tsumw2 += w * w
+	h.th1.tsumwx += w * x
+	h.th1.tsumwx2 += w * x * x
+	h.tsumwy += w * y
+	h.tsumwy2 += w * y * y
+	h.tsumwxy += w * x * y
+	h.tsumwz += w * z
+	h.tsumwz2 += w * z * z
+	h.tsumwxz += w * x * z
+	h.tsumwyz += w * y * z
+	return i
+}
+
+// axisEdges returns the edges of the bins of the provided axis.
+func axisEdges(a *taxis) []float64 {
+	edges := make([]float64, a.nbins+1)
+	for i := range edges {
+		edges[i] = a.BinLowEdge(i + 1)
+	}
+	return edges
+}
+
+// addDist3D adds the weights moments of o to d.
+func addDist3D(d *hbook.Dist3D, o hbook.Dist3D) {
+	for _, v := range []struct {
+		d *hbook.Dist0D
+		o hbook.Dist0D
+	}{
+		{&d.X.Dist, o.X.Dist},
+		{&d.Y.Dist, o.Y.Dist},
+		{&d.Z.Dist, o.Z.Dist},
+	} {
+		v.d.N += v.o.N
+		v.d.SumW += v.o.SumW
+		v.d.SumW2 += v.o.SumW2
+	}
+}
+
 func init() {
 	{
 		f := func() reflect.Value {
@@ -295,6 +496,13 @@ func init() {
 		}
 		rtypes.Factory.Add("TH2", f)
 	}
+	{
+		f := func() reflect.Value {
+			o := newH3()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TH3", f)
+	}
 }
 
 var (
@@ -307,4 +515,9 @@ var (
 	_ root.Named         = (*th2)(nil)
 	_ rbytes.Marshaler   = (*th2)(nil)
 	_ rbytes.Unmarshaler = (*th2)(nil)
+
+	_ root.Object        = (*th3)(nil)
+	_ root.Named         = (*th3)(nil)
+	_ rbytes.Marshaler   = (*th3)(nil)
+	_ rbytes.Unmarshaler = (*th3)(nil)
 )
@@ -6,6 +6,7 @@ package rhist_test
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -16,6 +17,8 @@ import (
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/hbook"
 )
 
 func TestRWHist(t *testing.T) {
@@ -123,3 +126,407 @@ func TestROOT4Hist(t *testing.T) {
 		t.Fatalf("invalid H1D name: got=%q, want=%q", got, want)
 	}
 }
+
+func TestHStackInvalid(t *testing.T) {
+	var (
+		h1 = rhist.NewH1DFrom(hbook.NewH1D(10, 0, 10))
+		h2 = rhist.NewH2DFrom(hbook.NewH2D(10, 0, 10, 10, 0, 10))
+	)
+
+	_, err := rhist.NewHStack("hs", "", h1, h2)
+	if err == nil {
+		t.Fatalf("expected an error when stacking 1-dim and 2-dim histograms")
+	}
+
+	_, err = rhist.NewHStack("hs", "", h1, rhist.NewAxis("xaxis"))
+	if err == nil {
+		t.Fatalf("expected an error when stacking a non-histogram")
+	}
+}
+
+func TestHistFromEdges(t *testing.T) {
+	dir, err := os.MkdirTemp("", "groot-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		xedges = []float64{0, 1, 3, 7}
+		yedges = []float64{-1, 0, 10}
+	)
+
+	h1 := rhist.NewH1DFromEdges("h1", "my title", xedges)
+	h1.Fill(0.5, 1)
+	h1.Fill(2, 2)
+	h1.Fill(6, 3)
+	h1.Fill(8, 4)
+
+	h2 := rhist.NewH2FFromEdges("h2", "my title", xedges, yedges)
+	h2.Fill(2, 5, 2)
+	h2.Fill(2, 5, 1)
+	h2.Fill(-1, 5, 1)
+
+	fname := filepath.Join(dir, "edges.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, h := range []root.Object{h1, h2} {
+		err = f.Put(h.(root.Named).Name(), h)
+		if err != nil {
+			t.Fatalf("could not write histogram: %+v", err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := groot.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	o, err := r.Get("h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g1 := o.(*rhist.H1D)
+	if got, want := g1.XAxis().XBins(), xedges; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid edges: got=%v, want=%v", got, want)
+	}
+	if got, want := g1.Title(), "my title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+	for i, want := range []float64{0, 1, 2, 3, 4} {
+		if got := g1.XBinContent(i); got != want {
+			t.Fatalf("invalid content for bin %d: got=%v, want=%v", i, got, want)
+		}
+	}
+	if got, want := g1.XBinWidth(3), 4.0; got != want {
+		t.Fatalf("invalid bin width: got=%v, want=%v", got, want)
+	}
+	if got, want := g1.Entries(), 4.0; got != want {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+	if got, want := g1.SumW(), 6.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+
+	o, err = r.Get("h2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2 := o.(*rhist.H2F)
+	if got, want := g2.YAxis().XBins(), yedges; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid y-edges: got=%v, want=%v", got, want)
+	}
+	// bin (ix=2, iy=2) is at index 2 + (nx+2)*2.
+	if got, want := g2.XBinContent(2+5*2), 3.0; got != want {
+		t.Fatalf("invalid content: got=%v, want=%v", got, want)
+	}
+	if got, want := g2.XBinError(2+5*2), math.Sqrt(5); got != want {
+		t.Fatalf("invalid error: got=%v, want=%v", got, want)
+	}
+	if got, want := g2.SumWY(), 15.0; got != want {
+		t.Fatalf("invalid sumwy: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH3(t *testing.T) {
+	href := hbook.NewH3DFromEdges(
+		[]float64{0, 1, 3},
+		[]float64{-1, 0, 1},
+		[]float64{0, 10, 20, 30},
+	)
+	href.Ann["name"] = "h3"
+	href.Ann["title"] = "my title"
+	href.Fill(0.5, -0.5, 5, 1)
+	href.Fill(2, 0.5, 25, 2)
+	href.Fill(2, 0.5, 25, 3)
+	href.Fill(-1, 0.5, 15, 4)
+	href.Fill(4, 2, 35, 5)
+
+	h := rhist.NewH3FFrom(href)
+	if got, want := h.Name(), "h3"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if nx, ny, nz := h.NbinsX(), h.NbinsY(), h.NbinsZ(); nx != 2 || ny != 2 || nz != 3 {
+		t.Fatalf("invalid number of bins: got=(%d,%d,%d)", nx, ny, nz)
+	}
+	if got, want := h.ZAxis().XBins(), []float64{0, 10, 20, 30}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid z-edges: got=%v, want=%v", got, want)
+	}
+	for _, tc := range []struct {
+		ix, iy, iz int
+		want       float64
+	}{
+		{1, 1, 1, 1},
+		{2, 2, 3, 5},
+		{0, 1, 1, 4},
+		{3, 3, 4, 5},
+		{1, 1, 2, 0},
+	} {
+		if got := h.BinContent(tc.ix, tc.iy, tc.iz); got != tc.want {
+			t.Fatalf("invalid content for bin (%d,%d,%d): got=%v, want=%v", tc.ix, tc.iy, tc.iz, got, tc.want)
+		}
+	}
+	if got, want := h.BinError(2, 2, 3), math.Sqrt(13); got != want {
+		t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumWZ(), href.SumWZ(); got != want {
+		t.Fatalf("invalid sumwz: got=%v, want=%v", got, want)
+	}
+
+	hh := h.AsH3D()
+	if got, want := hh.Binning.Bins, href.Binning.Bins; len(got) != len(want) {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", len(got), len(want))
+	}
+	for i := range hh.Binning.Bins {
+		got := &hh.Binning.Bins[i]
+		want := &href.Binning.Bins[i]
+		if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() || got.Entries() != want.Entries() {
+			t.Fatalf("invalid bin %d: got=%v, want=%v", i, got.Dist, want.Dist)
+		}
+		if got.XRange != want.XRange || got.YRange != want.YRange || got.ZRange != want.ZRange {
+			t.Fatalf("invalid bin %d ranges", i)
+		}
+	}
+	if got, want := hh.Binning.Outflow(-1, 0, 0).SumW(), 4.0; got != want {
+		t.Fatalf("invalid outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := hh.SumWYZ(), href.SumWYZ(); got != want {
+		t.Fatalf("invalid sumwyz: got=%v, want=%v", got, want)
+	}
+
+	h.Fill(0.5, -0.5, 15, 2)
+	if got, want := h.BinContent(1, 1, 2), 2.0; got != want {
+		t.Fatalf("invalid content after fill: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Entries(), 6.0; got != want {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+
+	raw, err := h.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to YODA: %+v", err)
+	}
+	var got rhist.H3F
+	err = got.UnmarshalYODA(raw)
+	if err != nil {
+		t.Fatalf("could not unmarshal from YODA: %+v", err)
+	}
+	if v := got.BinContent(2, 2, 3); v != 5 {
+		t.Fatalf("invalid content after YODA round-trip: got=%v, want=%v", v, 5)
+	}
+}
+
+func TestProfile2D(t *testing.T) {
+	pref := hbook.NewP2DFromEdges(
+		[]float64{0, 1, 3},
+		[]float64{-1, 0, 1},
+	)
+	pref.Ann["name"] = "p2"
+	pref.Ann["title"] = "my title"
+	pref.Fill(0.5, -0.5, 1, 1)
+	pref.Fill(0.5, -0.5, 3, 1)
+	pref.Fill(2, 0.5, 4, 2)
+	pref.Fill(2, 0.5, 4, 2)
+	pref.Fill(-1, 0.5, 10, 1)
+	pref.Fill(4, 2, 20, 3)
+
+	p := rhist.NewProfile2DFrom(pref)
+	if got, want := p.Name(), "p2"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := p.Title(), "my title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+	if nx, ny := p.NbinsX(), p.NbinsY(); nx != 2 || ny != 2 {
+		t.Fatalf("invalid number of bins: got=(%d,%d)", nx, ny)
+	}
+	for _, tc := range []struct {
+		ix, iy  int
+		content float64
+		entries float64
+		err     float64
+	}{
+		{1, 1, 2, 2, 1 / math.Sqrt(2)},
+		{2, 2, 4, 4, 0},
+		{0, 1, 10, 1, 0}, // outflows are stored in the first bin of an edge.
+		{3, 3, 20, 3, 0},
+		{1, 2, 0, 0, 0},
+	} {
+		if got := p.BinContent(tc.ix, tc.iy); got != tc.content {
+			t.Fatalf("invalid content for bin (%d,%d): got=%v, want=%v", tc.ix, tc.iy, got, tc.content)
+		}
+		if got := p.BinEntries(tc.ix, tc.iy); got != tc.entries {
+			t.Fatalf("invalid entries for bin (%d,%d): got=%v, want=%v", tc.ix, tc.iy, got, tc.entries)
+		}
+		if got := p.BinError(tc.ix, tc.iy); math.Abs(got-tc.err) > 1e-12 {
+			t.Fatalf("invalid error for bin (%d,%d): got=%v, want=%v", tc.ix, tc.iy, got, tc.err)
+		}
+	}
+
+	pp := p.AsP2D()
+	if got, want := len(pp.Binning.Bins), len(pref.Binning.Bins); got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	for i := range pp.Binning.Bins {
+		got := &pp.Binning.Bins[i]
+		want := &pref.Binning.Bins[i]
+		if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() || got.Entries() != want.Entries() {
+			t.Fatalf("invalid bin %d: got=%v, want=%v", i, got.Dist, want.Dist)
+		}
+		if got.Dist.Z != want.Dist.Z {
+			t.Fatalf("invalid bin %d z-moments: got=%v, want=%v", i, got.Dist.Z, want.Dist.Z)
+		}
+		if got.XRange != want.XRange || got.YRange != want.YRange {
+			t.Fatalf("invalid bin %d ranges", i)
+		}
+	}
+	if got, want := pp.Binning.Outflows[hbook.BngW-1].Z, pref.Binning.Outflows[hbook.BngW-1].Z; got != want {
+		t.Fatalf("invalid W outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := pp.Binning.Dist, pref.Binning.Dist; got.Z != want.Z || got.X != want.X || got.Stats.SumWXY != want.Stats.SumWXY {
+		t.Fatalf("invalid distribution: got=%v, want=%v", got, want)
+	}
+
+	raw, err := p.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to YODA: %+v", err)
+	}
+	var got rhist.Profile2D
+	err = got.UnmarshalYODA(raw)
+	if err != nil {
+		t.Fatalf("could not unmarshal from YODA: %+v", err)
+	}
+	if v := got.BinContent(2, 2); v != 4 {
+		t.Fatalf("invalid content after YODA round-trip: got=%v, want=%v", v, 4)
+	}
+
+	t.Run("tprofile.root", func(t *testing.T) {
+		f, err := groot.Open("../testdata/tprofile.root")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		o, err := f.Get("p2d")
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := o.(*rhist.Profile2D)
+		if got, want := p.ErrMode(), hbook.ProfileErrMean; got != want {
+			t.Fatalf("invalid error mode: got=%v, want=%v", got, want)
+		}
+		if got, want := p.BinEntries(20, 20), 159.0; got != want {
+			t.Fatalf("invalid bin entries: got=%v, want=%v", got, want)
+		}
+
+		pp := p.AsP2D()
+		if got, want := pp.Name(), "p2d"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		bin := pp.Bin(-0.1, -0.1)
+		if got, want := bin.ZMean(), p.BinContent(20, 20); got != want {
+			t.Fatalf("invalid bin mean: got=%v, want=%v", got, want)
+		}
+		if got, want := bin.ZErr(p.ErrMode()), p.BinError(20, 20); got != want {
+			t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+		}
+		if got, want := bin.Entries(), int64(159); got != want {
+			t.Fatalf("invalid bin entries: got=%v, want=%v", got, want)
+		}
+	})
+}
+
+func TestHistAxisMetadata(t *testing.T) {
+	h1 := hbook.NewH1D(3, 0, 3)
+	h1.Ann["name"] = "h1"
+	h1.SetXAxis(hbook.Axis{Title: "channel", Labels: []string{"ee", "", "emu"}})
+	h1.SetYAxis(hbook.Axis{Title: "events", Unit: "1/fb"})
+	h1.Fill(0.5, 1)
+
+	h2 := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+	h2.Ann["name"] = "h2"
+	h2.SetXAxis(hbook.Axis{Title: "process", Labels: []string{"sig", "bkg"}})
+	h2.SetYAxis(hbook.Axis{Title: "p_{T}", Unit: "GeV"})
+	h2.SetZAxis(hbook.Axis{Title: "efficiency"})
+	h2.Fill(1.5, 0.5, 1)
+
+	r1 := rhist.NewH1DFrom(h1)
+	if got, want := r1.XAxis().Title(), "channel"; got != want {
+		t.Fatalf("invalid x-axis title: got=%q, want=%q", got, want)
+	}
+	for i, want := range []string{"ee", "", "emu"} {
+		if got := r1.XAxis().BinLabel(i + 1); got != want {
+			t.Fatalf("invalid label for bin %d: got=%q, want=%q", i+1, got, want)
+		}
+	}
+
+	r2 := rhist.NewH2DFrom(h2)
+	if got, want := r2.XAxis().BinLabel(2), "bkg"; got != want {
+		t.Fatalf("invalid bin label: got=%q, want=%q", got, want)
+	}
+	if !r2.XAxis().CanExtend() {
+		t.Fatalf("fully labeled axis should be extendable")
+	}
+
+	fname := filepath.Join(t.TempDir(), "axis.root")
+	w, err := groot.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]root.Object{"h1": r1, "h2": r2} {
+		err = w.Put(k, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g1 := o.(*rhist.H1D).AsH1D()
+	if got, want := g1.XAxis(), h1.XAxis(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid x-axis: got=%#v, want=%#v", got, want)
+	}
+	if got, want := g1.YAxis(), h1.YAxis(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid y-axis: got=%#v, want=%#v", got, want)
+	}
+
+	o, err = f.Get("h2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2 := o.(*rhist.H2D).AsH2D()
+	for _, v := range []struct {
+		name      string
+		got, want hbook.Axis
+	}{
+		{"x", g2.XAxis(), h2.XAxis()},
+		{"y", g2.YAxis(), h2.YAxis()},
+		{"z", g2.ZAxis(), h2.ZAxis()},
+	} {
+		if !reflect.DeepEqual(v.got, v.want) {
+			t.Fatalf("invalid %s-axis: got=%#v, want=%#v", v.name, v.got, v.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+type thstack struct {
+	rbase.Named
+
+	hists *rcont.List // Pointer to array of TH1
+	histo H1          // Pointer to histogram used for drawing axis
+	ymax  float64     // Maximum value for plotting along y
+	ymin  float64     // Minimum value for plotting along y
+}
+
+func newHStack() *thstack {
+	return &thstack{
+		Named: *rbase.NewNamed("", ""),
+		hists: rcont.NewList("", nil),
+		ymax:  -1111,
+		ymin:  -1111,
+	}
+}
+
+// NewHStack creates a new stack of histograms.
+// All the histograms must be either 1-dim (H1) or 2-dim (H2) histograms.
+func NewHStack(name, title string, hists ...root.Object) (HStack, error) {
+	hs := newHStack()
+	hs.SetName(name)
+	hs.SetTitle(title)
+
+	var h2 int
+	for i, h := range hists {
+		switch h.(type) {
+		case H2:
+			h2++
+		case H1:
+		default:
+			return nil, fmt.Errorf("rhist: invalid histogram #%d (type=%T) for stack %q", i, h, name)
+		}
+		hs.hists.Append(h)
+	}
+	if h2 != 0 && h2 != len(hists) {
+		return nil, fmt.Errorf("rhist: can not stack 1-dim and 2-dim histograms in stack %q", name)
+	}
+
+	return hs, nil
+}
+
+func (*thstack) Class() string {
+	return "THStack"
+}
+
+func (*thstack) RVersion() int16 {
+	return rvers.HStack
+}
+
+func (hs *thstack) Len() int {
+	if hs.hists == nil {
+		return 0
+	}
+	return hs.hists.Len()
+}
+
+func (hs *thstack) Hists() []root.Object {
+	o := make([]root.Object, hs.Len())
+	for i := range o {
+		o[i] = hs.hists.At(i)
+	}
+	return o
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (o *thstack) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(o.Class(), o.RVersion())
+
+	w.WriteObject(&o.Named)
+	w.WriteObjectAny(o.hists) // obj-ptr
+	w.WriteObjectAny(o.histo) // obj-ptr
+	w.WriteF64(o.ymax)
+	w.WriteF64(o.ymin)
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (o *thstack) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(o.Class())
+	if hdr.Vers > o.RVersion() {
+		panic(fmt.Errorf(
+			"rbytes: invalid %s version=%d > %d",
+			o.Class(), hdr.Vers, o.RVersion(),
+		))
+	}
+
+	r.ReadObject(&o.Named)
+	{
+		o.hists = nil
+		if oo := r.ReadObjectAny(); oo != nil { // obj-ptr
+			o.hists = oo.(*rcont.List)
+		}
+	}
+	{
+		o.histo = nil
+		if oo := r.ReadObjectAny(); oo != nil { // obj-ptr
+			o.histo = oo.(H1)
+		}
+	}
+	o.ymax = r.ReadF64()
+	o.ymin = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := newHStack()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("THStack", f)
+}
+
+var (
+	_ root.Object        = (*thstack)(nil)
+	_ root.Named         = (*thstack)(nil)
+	_ HStack             = (*thstack)(nil)
+	_ rbytes.Marshaler   = (*thstack)(nil)
+	_ rbytes.Unmarshaler = (*thstack)(nil)
+)
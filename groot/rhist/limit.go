@@ -6,6 +6,7 @@ package rhist
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 
 	"go-hep.org/x/hep/groot/rbase"
@@ -14,8 +15,11 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
+// Limit computes 95% confidence level limits using the CLs method.
 type Limit struct{}
 
 func (*Limit) Class() string {
@@ -51,6 +55,192 @@ func (o *Limit) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// ComputeLimit computes the confidence levels of the signal+background and
+// background-only hypotheses for the provided data source, with nmc
+// pseudo-experiments.
+//
+// The test statistic is the likelihood ratio -2lnQ, summed over all the
+// bins (including under/overflow bins) of all the channels of the data
+// source.
+// When stat is true, the signal and background histograms are fluctuated
+// within their statistical uncertainties for each pseudo-experiment.
+//
+// If src is nil, the global x/exp/rand source will be used.
+func ComputeLimit(data *LimitDataSource, nmc int, stat bool, src rand.Source) (*ConfidenceLevel, error) {
+	if nmc <= 0 {
+		return nil, fmt.Errorf("rhist: invalid number of pseudo-experiments (nmc=%d)", nmc)
+	}
+
+	chans, err := data.channels()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res = newConfidenceLevel(nmc, true)
+		tsd float64
+	)
+
+	// tables holds the precomputed log(1+s/b) weights of each bin.
+	// background-free bins are set to have a maximum test statistic value
+	// (corresponding to s/b of about 5e8.)
+	tables := make([][]float64, len(chans))
+	for i, ch := range chans {
+		res.fStot += ch.integral(ch.sig)
+		res.fBtot += ch.integral(ch.bkg)
+		res.fDtot += int32(ch.integral(ch.data))
+
+		tables[i] = make([]float64, len(ch.sig))
+		for j := range ch.sig {
+			var (
+				s = ch.sig[j]
+				b = ch.bkg[j]
+				d = ch.data[j]
+			)
+			switch {
+			case s > 0 && b > 0:
+				tsd += limitLogLikelihood(s, b, b, d)
+				tables[i][j] = limitLogLikelihood(s, b, b, 1)
+			case s > 0 && b == 0:
+				tables[i][j] = 20
+			}
+		}
+	}
+	res.fTSD = tsd
+
+	var (
+		tss = res.fTSS
+		tsb = res.fTSB
+		lrs = res.fLRS
+		lrb = res.fLRB
+
+		gauss = distuv.Normal{Mu: 0, Sigma: 1, Src: src}
+		pois  = func(rate float64) float64 {
+			if rate <= 0 {
+				return 0
+			}
+			return distuv.Poisson{Lambda: rate, Src: src}.Rand()
+		}
+
+		// fluctuated signal and background sets.
+		// an independent set of fluctuations is used for reweighting
+		// pseudo-experiments, as using the same fluctuations for
+		// numerator and denominator is biased.
+		fluct1 = make([]limitChannel, len(chans))
+		fluct2 = make([]limitChannel, len(chans))
+	)
+
+	for i, ch := range chans {
+		fluct1[i] = ch.clone()
+		fluct2[i] = ch.clone()
+	}
+
+	for i := 0; i < nmc; i++ {
+		if stat {
+			for j, ch := range chans {
+				ch.fluctuate(fluct1[j], &gauss)
+				ch.fluctuate(fluct2[j], &gauss)
+			}
+		}
+
+		for ich := range chans {
+			var (
+				f1 = fluct1[ich]
+				f2 = fluct2[ich]
+			)
+			for bin := range f1.sig {
+				s := f1.sig[bin]
+				if s == 0 {
+					continue
+				}
+				var (
+					s2 = f2.sig[bin]
+					b  = f1.bkg[bin]
+					b2 = f2.bkg[bin]
+					v  = tables[ich][bin]
+				)
+
+				// s+b hypothesis.
+				n := pois(s + b)
+				tss[i] += n * v
+				switch {
+				case s > 0 && b2 > 0:
+					lrs[i] += limitLogLikelihood(s, b, b2, n) - s - b + b2
+				case s > 0 && b2 == 0:
+					lrs[i] += 20*n - s
+				}
+
+				// b hypothesis.
+				n = pois(b)
+				tsb[i] += n * v
+				switch {
+				case s2 > 0 && b > 0:
+					lrb[i] += limitLogLikelihood(s2, b2, b, n) - s2 - b2 + b
+				case s > 0 && b == 0:
+					lrb[i] += 20*n - s
+				}
+			}
+		}
+		lrs[i] = math.Exp(math.Min(lrs[i], 710))
+		lrb[i] = math.Exp(math.Min(lrb[i], 710))
+	}
+
+	// lrs and lrb are the likelihood ratios prob(s+b)/prob(b) of each
+	// pseudo-experiment, used as weights to relate the PDFs of the s+b and
+	// b hypotheses.
+	res.sort()
+
+	return res, nil
+}
+
+func limitLogLikelihood(s, b, b2, d float64) float64 {
+	return d * math.Log((s+b)/b2)
+}
+
+// limitChannel holds the bin contents of the signal, background and data
+// histograms of a channel, as well as the statistical uncertainties on the
+// signal and background.
+// Bins are stored from the underflow bin to the overflow bin.
+type limitChannel struct {
+	sig, esig []float64
+	bkg, ebkg []float64
+	data      []float64
+}
+
+func (ch limitChannel) clone() limitChannel {
+	return limitChannel{
+		sig:  append([]float64(nil), ch.sig...),
+		esig: ch.esig,
+		bkg:  append([]float64(nil), ch.bkg...),
+		ebkg: ch.ebkg,
+		data: ch.data,
+	}
+}
+
+// integral returns the sum of the contents of the provided bins,
+// excluding the under/overflow bins.
+func (limitChannel) integral(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs[1 : len(vs)-1] {
+		sum += v
+	}
+	return sum
+}
+
+// fluctuate fluctuates the signal and background contents within their
+// statistical uncertainties, and stores the result into out.
+// Under/overflow bins are left untouched.
+func (ch limitChannel) fluctuate(out limitChannel, gauss *distuv.Normal) {
+	for i := 1; i < len(ch.sig)-1; i++ {
+		out.sig[i] = ch.sig[i] + ch.esig[i]*gauss.Rand()
+	}
+	for i := 1; i < len(ch.bkg)-1; i++ {
+		out.bkg[i] = ch.bkg[i] + ch.ebkg[i]*gauss.Rand()
+	}
+}
+
+// LimitDataSource holds the signal, background and candidates (data)
+// histograms of the channels used to compute a limit.
 type LimitDataSource struct {
 	base     rbase.Object   `groot:"BASE-TObject"`       // base class
 	sig      rcont.ObjArray `groot:"fSignal"`            // packed input signal
@@ -63,6 +253,116 @@ type LimitDataSource struct {
 	dummyIDs rcont.ObjArray `groot:"fDummyIds"`          // array of dummy object (used for bookeeping)
 }
 
+// NewLimitDataSource creates a new, empty, data source.
+func NewLimitDataSource() *LimitDataSource {
+	return &LimitDataSource{
+		base:     *rbase.NewObject(),
+		sig:      *rcont.NewObjArray(),
+		bkg:      *rcont.NewObjArray(),
+		data:     *rcont.NewObjArray(),
+		sigErr:   *rcont.NewObjArray(),
+		bkgErr:   *rcont.NewObjArray(),
+		ids:      *rcont.NewObjArray(),
+		dummyTA:  *rcont.NewObjArray(),
+		dummyIDs: *rcont.NewObjArray(),
+	}
+}
+
+// AddChannel adds a channel, described by its signal, background and
+// candidates (data) histograms, to the data source.
+// The three histograms must have the same number of bins.
+func (o *LimitDataSource) AddChannel(sig, bkg, data H1) error {
+	if _, err := newLimitChannel(sig, bkg, data); err != nil {
+		return err
+	}
+	add := func(arr *rcont.ObjArray, v root.Object) {
+		n := arr.Len()
+		objs := make([]root.Object, n, n+1)
+		for i := range objs {
+			objs[i] = arr.At(i)
+		}
+		arr.SetElems(append(objs, v))
+	}
+	add(&o.sig, sig)
+	add(&o.bkg, bkg)
+	add(&o.data, data)
+	return nil
+}
+
+// Len returns the number of channels of the data source.
+func (o *LimitDataSource) Len() int {
+	return o.sig.Len()
+}
+
+// channels returns the bin contents of all the channels.
+func (o *LimitDataSource) channels() ([]limitChannel, error) {
+	if o == nil || o.sig.Len() == 0 {
+		return nil, fmt.Errorf("rhist: empty limit data source")
+	}
+	if o.bkg.Len() != o.sig.Len() || o.data.Len() != o.sig.Len() {
+		return nil, fmt.Errorf(
+			"rhist: inconsistent number of channels (sig=%d, bkg=%d, data=%d)",
+			o.sig.Len(), o.bkg.Len(), o.data.Len(),
+		)
+	}
+	if o.sigErr.Len() != 0 || o.bkgErr.Len() != 0 {
+		// TODO: handle systematic uncertainties (TObjArray of TVectorD).
+		return nil, fmt.Errorf("rhist: systematic uncertainties not supported")
+	}
+
+	chans := make([]limitChannel, o.sig.Len())
+	for i := range chans {
+		ch, err := newLimitChannel(o.sig.At(i), o.bkg.At(i), o.data.At(i))
+		if err != nil {
+			return nil, fmt.Errorf("rhist: invalid channel %d: %w", i, err)
+		}
+		chans[i] = ch
+	}
+	return chans, nil
+}
+
+func newLimitChannel(sig, bkg, data root.Object) (limitChannel, error) {
+	type binner interface {
+		NbinsX() int
+		XBinContent(i int) float64
+		XBinError(i int) float64
+	}
+
+	var ch limitChannel
+	hs := make([]binner, 3)
+	for i, o := range []root.Object{sig, bkg, data} {
+		h, ok := o.(binner)
+		if !ok {
+			return ch, fmt.Errorf("rhist: invalid histogram type %T", o)
+		}
+		hs[i] = h
+	}
+
+	nbins := hs[0].NbinsX()
+	if hs[1].NbinsX() != nbins || hs[2].NbinsX() != nbins {
+		return ch, fmt.Errorf(
+			"rhist: inconsistent number of bins (sig=%d, bkg=%d, data=%d)",
+			nbins, hs[1].NbinsX(), hs[2].NbinsX(),
+		)
+	}
+
+	ch = limitChannel{
+		sig:  make([]float64, nbins+2),
+		esig: make([]float64, nbins+2),
+		bkg:  make([]float64, nbins+2),
+		ebkg: make([]float64, nbins+2),
+		data: make([]float64, nbins+2),
+	}
+	for i := range ch.sig {
+		ch.sig[i] = hs[0].XBinContent(i)
+		ch.esig[i] = hs[0].XBinError(i)
+		ch.bkg[i] = hs[1].XBinContent(i)
+		ch.ebkg[i] = hs[1].XBinError(i)
+		ch.data[i] = hs[2].XBinContent(i)
+	}
+	return ch, nil
+}
+
 func (*LimitDataSource) Class() string {
 	return "TLimitDataSource"
 }
@@ -0,0 +1,128 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"golang.org/x/exp/rand"
+)
+
+func newLimitH1(vs ...float64) *H1D {
+	h := hbook.NewH1D(len(vs), 0, float64(len(vs)))
+	for i, v := range vs {
+		h.Fill(float64(i)+0.5, v)
+	}
+	return NewH1DFrom(h)
+}
+
+func TestComputeLimit(t *testing.T) {
+	var (
+		sig  = []float64{1, 2, 1}
+		bkg  = []float64{10, 10, 10}
+		data = []float64{12, 11, 10}
+	)
+
+	src := NewLimitDataSource()
+	err := src.AddChannel(newLimitH1(sig...), newLimitH1(bkg...), newLimitH1(data...))
+	if err != nil {
+		t.Fatalf("could not add channel: %+v", err)
+	}
+	if got, want := src.Len(), 1; got != want {
+		t.Fatalf("invalid number of channels: got=%d, want=%d", got, want)
+	}
+
+	const nmc = 50000
+	cl, err := ComputeLimit(src, nmc, false, rand.NewSource(1234))
+	if err != nil {
+		t.Fatalf("could not compute limit: %+v", err)
+	}
+
+	var tsd float64
+	for i := range sig {
+		tsd += data[i] * math.Log(1+sig[i]/bkg[i])
+	}
+
+	if got, want := cl.fStot, 4.0; got != want {
+		t.Fatalf("invalid stot: got=%v, want=%v", got, want)
+	}
+	if got, want := cl.fBtot, 30.0; got != want {
+		t.Fatalf("invalid btot: got=%v, want=%v", got, want)
+	}
+	if got, want := cl.fDtot, int32(33); got != want {
+		t.Fatalf("invalid dtot: got=%v, want=%v", got, want)
+	}
+	if got, want := cl.fTSD, tsd; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid tsd: got=%v, want=%v", got, want)
+	}
+	if got, want := cl.fNMC, float64(nmc); got != want {
+		t.Fatalf("invalid nmc: got=%v, want=%v", got, want)
+	}
+
+	for i := 1; i < nmc; i++ {
+		if cl.fTSS[cl.fISS[i-1]] > cl.fTSS[cl.fISS[i]] {
+			t.Fatalf("s+b test statistics not sorted at %d", i)
+		}
+		if cl.fTSB[cl.fISB[i-1]] > cl.fTSB[cl.fISB[i]] {
+			t.Fatalf("b test statistics not sorted at %d", i)
+		}
+	}
+
+	var tss, tsb, lrb float64
+	for i := 0; i < nmc; i++ {
+		tss += cl.fTSS[i]
+		tsb += cl.fTSB[i]
+		lrb += cl.fLRB[i]
+	}
+	tss /= nmc
+	tsb /= nmc
+	lrb /= nmc
+
+	// expected test statistics: sum (s+b) log(1+s/b) and sum b log(1+s/b).
+	var wtss, wtsb float64
+	for i := range sig {
+		v := math.Log(1 + sig[i]/bkg[i])
+		wtss += (sig[i] + bkg[i]) * v
+		wtsb += bkg[i] * v
+	}
+	if math.Abs(tss-wtss) > 0.01*wtss {
+		t.Fatalf("invalid mean s+b test statistic: got=%v, want=%v", tss, wtss)
+	}
+	if math.Abs(tsb-wtsb) > 0.01*wtsb {
+		t.Fatalf("invalid mean b test statistic: got=%v, want=%v", tsb, wtsb)
+	}
+
+	// the likelihood ratios of the b pseudo-experiments average to 1.
+	if math.Abs(lrb-1) > 0.05 {
+		t.Fatalf("invalid mean b likelihood ratio: got=%v, want=1", lrb)
+	}
+
+	_, err = ComputeLimit(src, 100, true, rand.NewSource(1234))
+	if err != nil {
+		t.Fatalf("could not compute limit with stat. fluctuations: %+v", err)
+	}
+}
+
+func TestComputeLimitInvalid(t *testing.T) {
+	src := NewLimitDataSource()
+	if _, err := ComputeLimit(src, 10, false, nil); err == nil {
+		t.Fatalf("expected an error for an empty data source")
+	}
+
+	err := src.AddChannel(newLimitH1(1, 2), newLimitH1(1, 2, 3), newLimitH1(1, 2))
+	if err == nil {
+		t.Fatalf("expected an error for inconsistent binning")
+	}
+
+	err = src.AddChannel(newLimitH1(1, 2), newLimitH1(1, 2), newLimitH1(1, 2))
+	if err != nil {
+		t.Fatalf("could not add channel: %+v", err)
+	}
+	if _, err := ComputeLimit(src, 0, false, nil); err == nil {
+		t.Fatalf("expected an error for nmc=0")
+	}
+}
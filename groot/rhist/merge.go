@@ -0,0 +1,304 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+// Merge merges the provided 1-dim histograms into a new histogram, of the
+// same type as the first one, following the semantics of ROOT's TH1::Merge:
+//
+//   - histograms with the same binning are added bin by bin,
+//   - histograms with labeled bins are merged label by label: bins with the
+//     same label are added, and new labels extend the axis,
+//   - histograms with different but compatible binnings (bins of the same
+//     width, with aligned edges) are merged into a histogram whose axis
+//     spans the ranges of all the histograms.
+//     The underflow (resp. overflow) bins can then only be merged when the
+//     lower (resp. upper) edge of the axis of the histogram is preserved.
+func Merge(hs ...H1) (H1, error) {
+	if len(hs) == 0 {
+		return nil, fmt.Errorf("rhist: no histogram to merge")
+	}
+
+	srcs := make([]histCells, len(hs))
+	for i, h := range hs {
+		src, ok := h.(histCells)
+		if !ok {
+			return nil, fmt.Errorf("rhist: invalid histogram type %T", h)
+		}
+		srcs[i] = src
+	}
+
+	var (
+		ref   = srcs[0].base()
+		xaxis taxis
+		index func(h histCells, i int) (int, error)
+		err   error
+	)
+	switch {
+	case mergeLabeled(srcs):
+		xaxis, index, err = mergeLabelAxis(srcs)
+	default:
+		xaxis, index, err = mergeAxis(srcs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nx    = xaxis.nbins
+		vs    = make([]float64, nx+2)
+		w2    []float64
+		stats = make([]float64, 4)
+	)
+	for _, src := range srcs {
+		if len(src.base().sumw2.Data) > 0 {
+			w2 = make([]float64, nx+2)
+			break
+		}
+	}
+
+	var entries float64
+	for _, src := range srcs {
+		var (
+			hb  = src.base()
+			sw2 = histSumw2(src)
+		)
+		for i := 0; i < hb.xaxis.nbins+2; i++ {
+			v := src.cell(i)
+			if v == 0 && sw2[i] == 0 {
+				continue
+			}
+			j, err := index(src, i)
+			if err != nil {
+				return nil, err
+			}
+			vs[j] += v
+			if w2 != nil {
+				w2[j] += sw2[i]
+			}
+		}
+		for i, v := range src.stats()[:4] {
+			stats[i] += v
+		}
+		entries += hb.entries
+	}
+
+	obj := rtypes.Factory.Get(hs[0].Class())().Interface()
+	dst, ok := obj.(histCells)
+	if !ok {
+		return nil, fmt.Errorf("rhist: invalid histogram type %T", obj)
+	}
+
+	hb := dst.base()
+	*hb = *ref
+	hb.xaxis = xaxis
+	hb.ncells = nx + 2
+	hb.contour.Data = append([]float64(nil), ref.contour.Data...)
+	hb.sumw2.Data = w2
+	hb.funcs = *rcont.NewList("", nil)
+	hb.buffer = nil
+	dst.setCells(vs)
+	dst.setStats(stats)
+	if xaxis.labels != nil {
+		// bin centers are meaningless for labeled bins.
+		resetStats(dst)
+	}
+	hb.entries = entries
+
+	return obj.(H1), nil
+}
+
+// mergeLabeled returns whether the histograms should be merged
+// label by label.
+func mergeLabeled(hs []histCells) bool {
+	for _, h := range hs {
+		if h.base().xaxis.labels != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeLabelAxis returns the axis resulting from the merge of the
+// labeled bins of the provided histograms.
+// New labels are assigned to the first free bins, extending the axis
+// when needed.
+func mergeLabelAxis(hs []histCells) (taxis, func(h histCells, i int) (int, error), error) {
+	var (
+		ref   = hs[0].base().xaxis
+		xaxis = copyAxis(&ref)
+	)
+
+	for _, h := range hs {
+		hb := h.base()
+		for i := 1; i <= hb.xaxis.nbins; i++ {
+			lbl := hb.xaxis.BinLabel(i)
+			if lbl == "" {
+				if h.cell(i) != 0 {
+					return taxis{}, nil, fmt.Errorf(
+						"rhist: cannot merge histogram %q: bin %d has no label",
+						hb.Name(), i,
+					)
+				}
+				continue
+			}
+			if xaxis.labelBin(lbl) != 0 {
+				continue
+			}
+			// find the first free bin for the new label.
+			bin := 1
+			for xaxis.BinLabel(bin) != "" {
+				bin++
+			}
+			if bin > xaxis.nbins {
+				width := xaxis.BinWidth(1)
+				xaxis.xbins.Data = nil
+				xaxis.nbins = bin
+				xaxis.xmax = xaxis.xmin + float64(bin)*width
+			}
+			xaxis.SetBinLabel(bin, lbl)
+		}
+	}
+
+	index := func(h histCells, i int) (int, error) {
+		hb := h.base()
+		switch i {
+		case 0:
+			return 0, nil
+		case hb.xaxis.nbins + 1:
+			return xaxis.nbins + 1, nil
+		}
+		return xaxis.labelBin(hb.xaxis.BinLabel(i)), nil
+	}
+	return xaxis, index, nil
+}
+
+// mergeAxis returns the axis resulting from the merge of the provided
+// histograms with numerical bins.
+func mergeAxis(hs []histCells) (taxis, func(h histCells, i int) (int, error), error) {
+	var (
+		ref  = hs[0].base().xaxis
+		same = true
+	)
+	for _, h := range hs[1:] {
+		if checkAxes(&ref, &h.base().xaxis) != nil {
+			same = false
+			break
+		}
+	}
+	if same {
+		index := func(h histCells, i int) (int, error) { return i, nil }
+		return copyAxis(&ref), index, nil
+	}
+
+	var (
+		width = ref.BinWidth(1)
+		xmin  = ref.xmin
+		xmax  = ref.xmax
+	)
+	for _, h := range hs {
+		var (
+			hb = h.base()
+			ax = &hb.xaxis
+		)
+		w, ok := fixedWidth(ax)
+		if !ok {
+			return taxis{}, nil, fmt.Errorf(
+				"rhist: cannot merge histogram %q: incompatible variable bins",
+				hb.Name(),
+			)
+		}
+		if !mergeClose(w, width, width) {
+			return taxis{}, nil, fmt.Errorf(
+				"rhist: cannot merge histogram %q: incompatible bin widths (%v != %v)",
+				hb.Name(), w, width,
+			)
+		}
+		if n := (ax.xmin - ref.xmin) / width; !mergeClose(n, math.Round(n), 1) {
+			return taxis{}, nil, fmt.Errorf(
+				"rhist: cannot merge histogram %q: bin edges are not aligned",
+				hb.Name(),
+			)
+		}
+		xmin = math.Min(xmin, ax.xmin)
+		xmax = math.Max(xmax, ax.xmax)
+	}
+
+	xaxis := copyAxis(&ref)
+	xaxis.xbins.Data = nil
+	xaxis.nbins = int(math.Round((xmax - xmin) / width))
+	xaxis.xmin = xmin
+	xaxis.xmax = xmin + float64(xaxis.nbins)*width
+	xaxis.first = 0
+	xaxis.last = 0
+
+	index := func(h histCells, i int) (int, error) {
+		var (
+			hb = h.base()
+			ax = &hb.xaxis
+		)
+		switch i {
+		case 0:
+			if !mergeClose(ax.xmin, xaxis.xmin, width) {
+				return 0, fmt.Errorf(
+					"rhist: cannot merge histogram %q: underflow bin is not empty and lower edge differs",
+					hb.Name(),
+				)
+			}
+			return 0, nil
+		case ax.nbins + 1:
+			if !mergeClose(ax.xmax, xaxis.xmax, width) {
+				return 0, fmt.Errorf(
+					"rhist: cannot merge histogram %q: overflow bin is not empty and upper edge differs",
+					hb.Name(),
+				)
+			}
+			return xaxis.nbins + 1, nil
+		}
+		return xaxis.findBin(ax.BinCenter(i)), nil
+	}
+	return xaxis, index, nil
+}
+
+// fixedWidth returns the width of the bins of the axis, and whether all its
+// bins have the same width.
+func fixedWidth(a *taxis) (float64, bool) {
+	width := a.BinWidth(1)
+	for i := 2; i <= a.nbins && len(a.xbins.Data) > 0; i++ {
+		if !mergeClose(a.BinWidth(i), width, width) {
+			return width, false
+		}
+	}
+	return width, true
+}
+
+// mergeClose returns whether a and b are equal, up to a tolerance
+// relative to the provided scale.
+func mergeClose(a, b, scale float64) bool {
+	return math.Abs(a-b) <= 1e-6*math.Abs(scale)
+}
+
+// copyAxis returns a deep copy of the provided axis.
+func copyAxis(a *taxis) taxis {
+	o := *a
+	o.xbins.Data = append([]float64(nil), a.xbins.Data...)
+	if a.labels != nil {
+		objs := make([]root.Object, a.labels.Len())
+		for i := range objs {
+			objs[i] = a.labels.At(i)
+		}
+		o.labels = &rcont.HashList{List: *rcont.NewList("", objs)}
+	}
+	o.modlabs = nil
+	return o
+}
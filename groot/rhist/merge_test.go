@@ -0,0 +1,163 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func newMergeH1(n int, xmin, xmax float64, xs ...float64) *rhist.H1D {
+	h := hbook.NewH1D(n, xmin, xmax)
+	for _, x := range xs {
+		h.Fill(x, 1)
+	}
+	return rhist.NewH1DFrom(h)
+}
+
+func checkMergeBins(t *testing.T, h rhist.H1, want []float64) {
+	t.Helper()
+	hh := h.(interface{ XBinContent(int) float64 })
+	for i, v := range want {
+		if got := hh.XBinContent(i); got != v {
+			t.Fatalf("invalid content for bin %d: got=%v, want=%v", i, got, v)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("same-axes", func(t *testing.T) {
+		var (
+			h1 = newMergeH1(3, 0, 3, -1, 0.5, 1.5)
+			h2 = newMergeH1(3, 0, 3, 1.5, 2.5, 4)
+		)
+		h, err := rhist.Merge(h1, h2)
+		if err != nil {
+			t.Fatalf("could not merge histograms: %+v", err)
+		}
+		if _, ok := h.(*rhist.H1D); !ok {
+			t.Fatalf("invalid histogram type %T", h)
+		}
+		checkMergeBins(t, h, []float64{1, 1, 2, 1, 1})
+		if got, want := h.Entries(), 6.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW(), h1.SumW()+h2.SumW(); got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumWX(), h1.SumWX()+h2.SumWX(); got != want {
+			t.Fatalf("invalid sumwx: got=%v, want=%v", got, want)
+		}
+		if got, want := h1.XBinContent(2), 1.0; got != want {
+			t.Fatalf("input histogram was modified: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("extend", func(t *testing.T) {
+		var (
+			h1 = newMergeH1(4, 0, 4, -1, 0.5, 3.5)
+			h2 = newMergeH1(4, 2, 6, 2.5, 3.5, 5.5, 7)
+		)
+		h, err := rhist.Merge(h1, h2)
+		if err != nil {
+			t.Fatalf("could not merge histograms: %+v", err)
+		}
+		xaxis := h.XAxis()
+		if got, want := xaxis.NBins(), 6; got != want {
+			t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+		}
+		if xmin, xmax := xaxis.XMin(), xaxis.XMax(); xmin != 0 || xmax != 6 {
+			t.Fatalf("invalid axis range: got=[%v, %v]", xmin, xmax)
+		}
+		checkMergeBins(t, h, []float64{1, 1, 0, 1, 2, 0, 1, 1})
+		if got, want := h.SumW(), h1.SumW()+h2.SumW(); got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+
+		// h2 has a non-empty underflow bin, and a different lower edge.
+		h2 = newMergeH1(4, 2, 6, 1, 2.5)
+		if _, err := rhist.Merge(h1, h2); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		newH := func(lbls ...string) *rhist.H1D {
+			h := newMergeH1(len(lbls), 0, float64(len(lbls)))
+			for i, lbl := range lbls {
+				h.XAxis().SetBinLabel(i+1, lbl)
+				h.Fill(float64(i)+0.5, float64(i+1))
+			}
+			return h
+		}
+		h, err := rhist.Merge(newH("a", "b"), newH("b", "c"))
+		if err != nil {
+			t.Fatalf("could not merge histograms: %+v", err)
+		}
+		xaxis := h.XAxis()
+		if got, want := xaxis.NBins(), 3; got != want {
+			t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+		}
+		for i, want := range []string{"a", "b", "c"} {
+			if got := xaxis.BinLabel(i + 1); got != want {
+				t.Fatalf("invalid label for bin %d: got=%q, want=%q", i+1, got, want)
+			}
+		}
+		if !xaxis.CanExtend() {
+			t.Fatalf("labeled axis should be extendable")
+		}
+		checkMergeBins(t, h, []float64{0, 1, 3, 2, 0})
+		if got, want := h.Entries(), 4.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW(), 6.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+
+		// unlabeled bins with content can not be merged.
+		if _, err := rhist.Merge(newH("a"), newMergeH1(1, 0, 1, 0.5)); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		h1 := rhist.NewH1FFrom(hbook.NewH1D(2, 0, 2))
+		h1.Fill(0.5, 1)
+		h, err := rhist.Merge(h1, newMergeH1(2, 0, 2, 1.5))
+		if err != nil {
+			t.Fatalf("could not merge histograms: %+v", err)
+		}
+		if _, ok := h.(*rhist.H1F); !ok {
+			t.Fatalf("invalid histogram type %T", h)
+		}
+		checkMergeBins(t, h, []float64{0, 1, 1, 0})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			hs   []rhist.H1
+		}{
+			{"empty", nil},
+			{"widths", []rhist.H1{newMergeH1(2, 0, 2), newMergeH1(4, 0, 2)}},
+			{"edges", []rhist.H1{newMergeH1(2, 0, 2), newMergeH1(2, 0.5, 2.5)}},
+			{
+				"variable-bins",
+				[]rhist.H1{
+					rhist.NewH1DFromEdges("h1", "", []float64{0, 1, 3}),
+					rhist.NewH1DFromEdges("h2", "", []float64{0, 2, 3}),
+				},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				if _, err := rhist.Merge(tc.hs...); err == nil {
+					t.Fatalf("expected an error")
+				}
+			})
+		}
+	})
+}
@@ -37,6 +37,19 @@ func newMultiGraph() *tmultigraph {
 	}
 }
 
+// NewMultiGraph creates a new multi-graph from the provided graphs.
+func NewMultiGraph(name, title string, graphs ...Graph) MultiGraph {
+	mg := newMultiGraph()
+	mg.SetName(name)
+	mg.SetTitle(title)
+	mg.ymax = -1111
+	mg.ymin = -1111
+	for _, g := range graphs {
+		mg.graphs.Append(g)
+	}
+	return mg
+}
+
 func (*tmultigraph) Class() string {
 	return "TMultiGraph"
 }
@@ -41,6 +41,35 @@ func (*Profile1D) RVersion() int16 {
 	return rvers.Profile
 }
 
+// Name returns the name of the profile.
+func (p *Profile1D) Name() string {
+	return p.h1d.Name()
+}
+
+// Title returns the title of the profile.
+func (p *Profile1D) Title() string {
+	return p.h1d.Title()
+}
+
+// NbinsX returns the number of bins in X.
+func (p *Profile1D) NbinsX() int {
+	return p.h1d.NbinsX()
+}
+
+// XBinContent returns the mean value of the i-th bin.
+func (p *Profile1D) XBinContent(i int) float64 {
+	n := p.binEntries.Data[i]
+	if n == 0 {
+		return 0
+	}
+	return p.h1d.arr.Data[i] / n
+}
+
+// XBinEntries returns the sum of weights of the i-th bin.
+func (p *Profile1D) XBinEntries(i int) float64 {
+	return p.binEntries.Data[i]
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (p *Profile1D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -99,6 +128,7 @@ func init() {
 
 var (
 	_ root.Object        = (*Profile1D)(nil)
+	_ root.Named         = (*Profile1D)(nil)
 	_ rbytes.RVersioner  = (*Profile1D)(nil)
 	_ rbytes.Marshaler   = (*Profile1D)(nil)
 	_ rbytes.Unmarshaler = (*Profile1D)(nil)
@@ -13,6 +13,7 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 // Profile2D is a 2-dim profile histogram.
@@ -33,6 +34,64 @@ func newProfile2D() *Profile2D {
 	}
 }
 
+// NewProfile2DFrom creates a new Profile2D from a hbook 2-dim profile histogram.
+func NewProfile2DFrom(p *hbook.P2D) *Profile2D {
+	var (
+		bng    = &p.Binning
+		nx     = bng.Nx
+		ny     = bng.Ny
+		xedges = make([]float64, 0, nx+1)
+		yedges = make([]float64, 0, ny+1)
+	)
+	for _, bin := range bng.XEdges {
+		xedges = append(xedges, bin.Range.Min)
+	}
+	xedges = append(xedges, bng.XEdges[nx-1].Range.Max)
+	for _, bin := range bng.YEdges {
+		yedges = append(yedges, bin.Range.Min)
+	}
+	yedges = append(yedges, bng.YEdges[ny-1].Range.Max)
+
+	h := hbook.NewH2DFromEdges(xedges, yedges)
+	h.Ann = p.Ann
+	h.Binning.Dist = dist2DFrom3D(bng.Dist)
+	for i, bin := range bng.Bins {
+		h.Binning.Bins[i].Dist = dist2DFrom3D(bin.Dist)
+	}
+	for i, oflow := range bng.Outflows {
+		h.Binning.Outflows[i] = dist2DFrom3D(oflow)
+	}
+
+	var (
+		proot  = newProfile2D()
+		ncells = (nx + 2) * (ny + 2)
+	)
+	proot.h2d = *NewH2DFrom(h)
+	proot.binEntries.Data = make([]float64, ncells)
+	proot.binSumw2.Data = make([]float64, ncells)
+	proot.sumwz = bng.Dist.Z.SumWX()
+	proot.sumwz2 = bng.Dist.Z.SumWX2()
+
+	set := func(ix, iy int, d hbook.Dist3D) {
+		i := proot.h2d.bin(ix, iy)
+		proot.h2d.arr.Data[i] = d.Z.SumWX()
+		proot.h2d.th1.sumw2.Data[i] = d.Z.SumWX2()
+		proot.binEntries.Data[i] = d.SumW()
+		proot.binSumw2.Data[i] = d.SumW2()
+	}
+
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			set(ix+1, iy+1, bng.Bins[iy*nx+ix].Dist)
+		}
+	}
+	for i, v := range profile2DOutflows(nx, ny) {
+		set(v[0], v[1], bng.Outflows[i])
+	}
+
+	return proot
+}
+
 func (*Profile2D) Class() string {
 	return "TProfile2D"
 }
@@ -41,6 +100,152 @@ func (*Profile2D) RVersion() int16 {
 	return rvers.Profile2D
 }
 
+// Name returns the name of the profile.
+func (p2d *Profile2D) Name() string {
+	return p2d.h2d.Name()
+}
+
+// Title returns the title of the profile.
+func (p2d *Profile2D) Title() string {
+	return p2d.h2d.Title()
+}
+
+// NbinsX returns the number of bins in X.
+func (p2d *Profile2D) NbinsX() int {
+	return p2d.h2d.NbinsX()
+}
+
+// NbinsY returns the number of bins in Y.
+func (p2d *Profile2D) NbinsY() int {
+	return p2d.h2d.NbinsY()
+}
+
+// ErrMode returns the option used to compute the errors of the profile.
+func (p2d *Profile2D) ErrMode() hbook.ProfileErr {
+	return hbook.ProfileErr(p2d.errMode)
+}
+
+// BinContent returns the mean value of the (ix,iy) bin.
+// Indices of the in-range bins start at 1.
+func (p2d *Profile2D) BinContent(ix, iy int) float64 {
+	i := p2d.h2d.bin(ix, iy)
+	n := p2d.binEntries.Data[i]
+	if n == 0 {
+		return 0
+	}
+	return p2d.h2d.arr.Data[i] / n
+}
+
+// BinEntries returns the sum of weights of the (ix,iy) bin.
+// Indices of the in-range bins start at 1.
+func (p2d *Profile2D) BinEntries(ix, iy int) float64 {
+	return p2d.binEntries.Data[p2d.h2d.bin(ix, iy)]
+}
+
+// BinError returns the error on the mean value of the (ix,iy) bin,
+// computed according to the error mode of the profile.
+// Indices of the in-range bins start at 1.
+func (p2d *Profile2D) BinError(ix, iy int) float64 {
+	bin := hbook.BinP2D{Dist: p2d.dist3D(ix, iy)}
+	return bin.ZErr(p2d.ErrMode())
+}
+
+func (p2d *Profile2D) dist3D(ix, iy int) hbook.Dist3D {
+	var (
+		i     = p2d.h2d.bin(ix, iy)
+		sumw  = p2d.binEntries.Data[i]
+		sumw2 = sumw // ROOT assumes unit weights when binSumw2 is empty.
+		n     int64
+	)
+	if len(p2d.binSumw2.Data) > 0 {
+		sumw2 = p2d.binSumw2.Data[i]
+	}
+	if sumw2 > 0 {
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+
+	var (
+		dist = hbook.Dist0D{N: n, SumW: sumw, SumW2: sumw2}
+		d    = hbook.Dist3D{
+			X: hbook.Dist1D{Dist: dist},
+			Y: hbook.Dist1D{Dist: dist},
+			Z: hbook.Dist1D{Dist: dist},
+		}
+	)
+	d.Z.Stats.SumWX = p2d.h2d.arr.Data[i]
+	if len(p2d.h2d.th1.sumw2.Data) > 0 {
+		d.Z.Stats.SumWX2 = p2d.h2d.th1.sumw2.Data[i]
+	}
+	return d
+}
+
+// AsP2D creates a new hbook.P2D from this ROOT profile.
+//
+// ROOT only stores the sum of weights (and of squared weights) of the
+// profiled quantity for each bin: the per-bin (x,y) moments are thus
+// not available, and the number of entries in each bin is estimated
+// from its number of effective entries.
+func (p2d *Profile2D) AsP2D() *hbook.P2D {
+	var (
+		h  = &p2d.h2d
+		nx = p2d.NbinsX()
+		ny = p2d.NbinsY()
+		p  = hbook.NewP2DFromEdges(
+			axisEdges(&h.th1.xaxis),
+			axisEdges(&h.th1.yaxis),
+		)
+	)
+	p.Ann = hbook.Annotation{
+		"name":  p2d.Name(),
+		"title": p2d.Title(),
+	}
+
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			p.Binning.Bins[iy*nx+ix].Dist = p2d.dist3D(ix+1, iy+1)
+		}
+	}
+	for i, v := range profile2DOutflows(nx, ny) {
+		p.Binning.Outflows[i] = p2d.dist3D(v[0], v[1])
+	}
+
+	dist := hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	}
+	d := &p.Binning.Dist
+	d.X.Dist = dist
+	d.Y.Dist = dist
+	d.Z.Dist = dist
+	d.X.Stats.SumWX = h.SumWX()
+	d.X.Stats.SumWX2 = h.SumWX2()
+	d.Y.Stats.SumWX = h.SumWY()
+	d.Y.Stats.SumWX2 = h.SumWY2()
+	d.Z.Stats.SumWX = p2d.sumwz
+	d.Z.Stats.SumWX2 = p2d.sumwz2
+	d.Stats.SumWXY = h.SumWXY()
+
+	return p
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+func (p2d *Profile2D) MarshalYODA() ([]byte, error) {
+	return p2d.AsP2D().MarshalYODA()
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (p2d *Profile2D) UnmarshalYODA(raw []byte) error {
+	var p hbook.P2D
+	err := p.UnmarshalYODA(raw)
+	if err != nil {
+		return err
+	}
+
+	*p2d = *NewProfile2DFrom(&p)
+	return nil
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (p2d *Profile2D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -89,6 +294,30 @@ func (p2d *Profile2D) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// profile2DOutflows returns the (ix,iy) ROOT bin indices of the outflow
+// regions of a 2-dim profile, in the order of hbook.BinningP2D.Outflows.
+// hbook outflows along an edge are stored in the first bin of that edge.
+func profile2DOutflows(nx, ny int) [8][2]int {
+	return [8][2]int{
+		{0, ny + 1},      // NW
+		{1, ny + 1},      // N
+		{nx + 1, ny + 1}, // NE
+		{nx + 1, 1},      // E
+		{nx + 1, 0},      // SE
+		{1, 0},           // S
+		{0, 0},           // SW
+		{0, 1},           // W
+	}
+}
+
+func dist2DFrom3D(d hbook.Dist3D) hbook.Dist2D {
+	var o hbook.Dist2D
+	o.X = d.X
+	o.Y = d.Y
+	o.Stats.SumWXY = d.Stats.SumWXY
+	return o
+}
+
 func init() {
 	f := func() reflect.Value {
 		p2d := newProfile2D()
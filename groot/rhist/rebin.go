@@ -0,0 +1,265 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+)
+
+// rebinAxis merges groups of n consecutive bins of the axis.
+func rebinAxis(a *taxis, n int) {
+	nbins := a.nbins / n
+	switch edges := a.xbins.Data; len(edges) {
+	case 0:
+		width := (a.xmax - a.xmin) / float64(a.nbins)
+		a.xmax = a.xmin + float64(nbins*n)*width
+	default:
+		vs := make([]float64, nbins+1)
+		for i := range vs {
+			vs[i] = edges[i*n]
+		}
+		a.xbins.Data = vs
+		a.xmax = vs[nbins]
+	}
+	a.nbins = nbins
+	a.first = 0
+	a.last = 0
+}
+
+// rebinIndex returns the index of the bin, after merging groups of n
+// consecutive bins into nbins bins, holding the content of the i-th bin.
+func rebinIndex(i, n, nbins int) int {
+	if i == 0 {
+		return 0
+	}
+	j := (i-1)/n + 1
+	if j > nbins {
+		j = nbins + 1
+	}
+	return j
+}
+
+// histRebin merges groups of nx (resp. ny) consecutive bins along X
+// (resp. Y) into single bins.
+func histRebin(h histCells, nx, ny int) error {
+	var (
+		hb   = h.base()
+		rank = h.Rank()
+	)
+
+	check := func(a *taxis, n int) error {
+		if n < 1 || n > a.nbins {
+			return fmt.Errorf(
+				"rhist: invalid rebin factor %d along %s (nbins=%d)",
+				n, a.Name(), a.nbins,
+			)
+		}
+		return nil
+	}
+	if err := check(&hb.xaxis, nx); err != nil {
+		return err
+	}
+	if rank > 1 {
+		if err := check(&hb.yaxis, ny); err != nil {
+			return err
+		}
+	}
+
+	var (
+		onx   = hb.xaxis.nbins
+		nnx   = onx / nx
+		orows = 1
+		nrows = 1
+		nny   = 0
+	)
+	if rank > 1 {
+		nny = hb.yaxis.nbins / ny
+		orows = hb.yaxis.nbins + 2
+		nrows = nny + 2
+	}
+
+	var (
+		vs = make([]float64, (nnx+2)*nrows)
+		w2 []float64
+	)
+	if len(hb.sumw2.Data) > 0 {
+		w2 = make([]float64, len(vs))
+	}
+	for iy := 0; iy < orows; iy++ {
+		jy := 0
+		if rank > 1 {
+			jy = rebinIndex(iy, ny, nny)
+		}
+		for ix := 0; ix < onx+2; ix++ {
+			var (
+				jx  = rebinIndex(ix, nx, nnx)
+				src = ix + (onx+2)*iy
+				dst = jx + (nnx+2)*jy
+			)
+			vs[dst] += h.cell(src)
+			if w2 != nil {
+				w2[dst] += hb.sumw2.Data[src]
+			}
+		}
+	}
+
+	h.setCells(vs)
+	if w2 != nil {
+		hb.sumw2.Data = w2
+	}
+	hb.ncells = len(vs)
+	rebinAxis(&hb.xaxis, nx)
+	if rank > 1 {
+		rebinAxis(&hb.yaxis, ny)
+	}
+
+	return nil
+}
+
+// projectedAxis returns a copy of the axis, suitable for a 1-dim histogram.
+func projectedAxis(a *taxis) taxis {
+	ax := *a
+	ax.SetName("xaxis")
+	ax.xbins.Data = append([]float64(nil), a.xbins.Data...)
+	return ax
+}
+
+// projRange returns the range of bins of the axis to sum over, and whether
+// that range is the full range of the axis (including under/overflow bins.)
+func projRange(a *taxis, first, last int) (int, int, bool) {
+	if last < first {
+		return 0, a.nbins + 1, true
+	}
+	if first < 0 {
+		first = 0
+	}
+	if last > a.nbins+1 {
+		last = a.nbins + 1
+	}
+	return first, last, first == 0 && last == a.nbins+1
+}
+
+// histProjection returns the projection of the 2-dim histogram h onto its
+// X (axis=0) or Y (axis=1) axis.
+func histProjection(h histCells, name string, axis, first, last int) *H1D {
+	var (
+		hb  = h.base()
+		nx  = hb.xaxis.nbins
+		pax = &hb.xaxis
+		oax = &hb.yaxis
+		sfx = "_px"
+	)
+	if axis == 1 {
+		pax, oax = oax, pax
+		sfx = "_py"
+	}
+	if name == "" {
+		name = hb.Name() + sfx
+	}
+	first, last, full := projRange(oax, first, last)
+
+	var (
+		np  = pax.nbins
+		out = newH1D()
+		src = histSumw2(h)
+		vs  = make([]float64, np+2)
+		w2  = make([]float64, np+2)
+	)
+	for i := range vs {
+		for j := first; j <= last; j++ {
+			c := i + (nx+2)*j
+			if axis == 1 {
+				c = j + (nx+2)*i
+			}
+			vs[i] += h.cell(c)
+			w2[i] += src[c]
+		}
+	}
+
+	out.th1.SetName(name)
+	out.th1.SetTitle(hb.Title())
+	out.th1.xaxis = projectedAxis(pax)
+	out.th1.ncells = np + 2
+	out.arr.Data = vs
+	out.th1.sumw2.Data = w2
+
+	switch {
+	case full:
+		s := h.stats()
+		if axis == 1 {
+			s = []float64{s[0], s[1], s[4], s[5]}
+		}
+		out.setStats(s[:4])
+		out.th1.entries = hb.entries
+	default:
+		resetStats(out)
+		for _, v := range vs {
+			out.th1.entries += v
+		}
+	}
+
+	return out
+}
+
+// histProfileX returns the profile of the 2-dim histogram h along its X axis.
+func histProfileX(h histCells, name string, first, last int) *Profile1D {
+	var (
+		hb = h.base()
+		nx = hb.xaxis.nbins
+	)
+	if name == "" {
+		name = hb.Name() + "_pfx"
+	}
+	first, last, full := projRange(&hb.yaxis, first, last)
+
+	var (
+		p   = newProfile1D()
+		src = histSumw2(h)
+		sum = make([]float64, nx+2) // sum of w*y
+		sq  = make([]float64, nx+2) // sum of w*y*y
+		ent = make([]float64, nx+2) // sum of w
+		w2  = make([]float64, nx+2) // sum of w*w
+	)
+	for ix := range sum {
+		x := hb.xaxis.BinCenter(ix)
+		for iy := first; iy <= last; iy++ {
+			var (
+				c = ix + (nx+2)*iy
+				w = h.cell(c)
+				y = hb.yaxis.BinCenter(iy)
+			)
+			sum[ix] += w * y
+			sq[ix] += w * y * y
+			ent[ix] += w
+			w2[ix] += src[c]
+			if !full {
+				p.h1d.th1.entries += w
+			}
+			if ix < 1 || ix > nx {
+				continue
+			}
+			p.h1d.th1.tsumw += w
+			p.h1d.th1.tsumw2 += src[c]
+			p.h1d.th1.tsumwx += w * x
+			p.h1d.th1.tsumwx2 += w * x * x
+			p.sumwy += w * y
+			p.sumwy2 += w * y * y
+		}
+	}
+
+	p.h1d.th1.SetName(name)
+	p.h1d.th1.SetTitle(hb.Title())
+	p.h1d.th1.xaxis = projectedAxis(&hb.xaxis)
+	p.h1d.th1.ncells = nx + 2
+	p.h1d.arr.Data = sum
+	p.h1d.th1.sumw2.Data = sq
+	p.binEntries.Data = ent
+	p.binSumw2.Data = w2
+	if full {
+		p.h1d.th1.entries = hb.entries
+	}
+
+	return p
+}
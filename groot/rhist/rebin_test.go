@@ -0,0 +1,157 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestRebin(t *testing.T) {
+	newH1 := func() *rhist.H1D {
+		h := hbook.NewH1D(5, 0, 5)
+		for i := 0; i < 5; i++ {
+			h.Fill(float64(i)+0.5, float64(i+1))
+		}
+		h.Fill(-1, 1)
+		h.Fill(+6, 1)
+		return rhist.NewH1DFrom(h)
+	}
+
+	h := newH1()
+	err := h.Rebin(2)
+	if err != nil {
+		t.Fatalf("could not rebin histogram: %+v", err)
+	}
+
+	if got, want := h.NbinsX(), 2; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	if got, want := h.XAxis().XMax(), 4.0; got != want {
+		t.Fatalf("invalid xmax: got=%v, want=%v", got, want)
+	}
+	if got, want := h.XBinWidth(1), 2.0; got != want {
+		t.Fatalf("invalid bin width: got=%v, want=%v", got, want)
+	}
+	for i, want := range []struct{ v, err float64 }{
+		{1, 1},
+		{3, math.Sqrt(5)},
+		{7, 5},
+		{6, math.Sqrt(26)},
+	} {
+		if got := h.XBinContent(i); got != want.v {
+			t.Fatalf("invalid bin content for bin %d: got=%v, want=%v", i, got, want.v)
+		}
+		if got := h.XBinError(i); got != want.err {
+			t.Fatalf("invalid bin error for bin %d: got=%v, want=%v", i, got, want.err)
+		}
+	}
+	if got, want := h.SumW(), newH1().SumW(); got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+
+	for _, n := range []int{0, 6} {
+		if err := newH1().Rebin(n); err == nil {
+			t.Fatalf("expected an error for rebin factor %d", n)
+		}
+	}
+}
+
+func TestProjection(t *testing.T) {
+	newH2 := func() *rhist.H2D {
+		h := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+		h.Fill(0.5, 0.5, 1)
+		h.Fill(1.5, 0.5, 2)
+		h.Fill(0.5, 1.5, 3)
+		h.Fill(1.5, 1.5, 4)
+		h.Annotation()["name"] = "h2"
+		return rhist.NewH2DFrom(h)
+	}
+
+	t.Run("projx", func(t *testing.T) {
+		h := newH2().ProjectionX("", 0, -1)
+		if got, want := h.Name(), "h2_px"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		for i, want := range []float64{0, 4, 6, 0} {
+			if got := h.XBinContent(i); got != want {
+				t.Fatalf("invalid bin content for bin %d: got=%v, want=%v", i, got, want)
+			}
+		}
+		if got, want := h.XBinError(2), math.Sqrt(4+16); got != want {
+			t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumW(), 10.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.Entries(), 4.0; got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("projy", func(t *testing.T) {
+		h := newH2().ProjectionY("py", 1, 1)
+		if got, want := h.Name(), "py"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		for i, want := range []float64{0, 1, 3, 0} {
+			if got := h.XBinContent(i); got != want {
+				t.Fatalf("invalid bin content for bin %d: got=%v, want=%v", i, got, want)
+			}
+		}
+		if got, want := h.SumW(), 4.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := h.SumWX(), 0.5+3*1.5; got != want {
+			t.Fatalf("invalid sumwx: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("profx", func(t *testing.T) {
+		p := newH2().ProfileX("", 0, -1)
+		if got, want := p.Name(), "h2_pfx"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		if got, want := p.NbinsX(), 2; got != want {
+			t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+		}
+		for i, want := range []float64{0, 1.25, 7.0 / 6} {
+			if got := p.XBinContent(i); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("invalid bin content for bin %d: got=%v, want=%v", i, got, want)
+			}
+		}
+		if got, want := p.XBinEntries(2), 6.0; got != want {
+			t.Fatalf("invalid bin entries: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("rebinx", func(t *testing.T) {
+		h := newH2()
+		err := h.RebinX(2)
+		if err != nil {
+			t.Fatalf("could not rebin histogram: %+v", err)
+		}
+		if got, want := h.NbinsX(), 1; got != want {
+			t.Fatalf("invalid number of x-bins: got=%d, want=%d", got, want)
+		}
+		if got, want := h.NbinsY(), 2; got != want {
+			t.Fatalf("invalid number of y-bins: got=%d, want=%d", got, want)
+		}
+		// bin (ix, iy) is at index ix + (nx+2)*iy.
+		if got, want := h.XBinContent(1+3*1), 3.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+		if got, want := h.XBinContent(1+3*2), 7.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+
+		if err := h.RebinY(3); err == nil {
+			t.Fatalf("expected an error for an invalid rebin factor")
+		}
+	})
+}
@@ -21,6 +21,22 @@ type Axis interface {
 	BinCenter(int) float64
 	BinLowEdge(int) float64
 	BinWidth(int) float64
+
+	// BinLabel returns the label of the i-th bin, if any.
+	BinLabel(i int) string
+	// SetBinLabel sets the label of the i-th bin.
+	SetBinLabel(i int, label string)
+	// CanExtend returns whether the axis can be extended.
+	CanExtend() bool
+
+	// TimeDisplay returns whether the axis displays time values.
+	TimeDisplay() bool
+	// SetTimeDisplay sets whether the axis displays time values.
+	SetTimeDisplay(v bool)
+	// TimeFormat returns the date&time format of the axis.
+	TimeFormat() string
+	// SetTimeFormat sets the date&time format of the axis.
+	SetTimeFormat(format string)
 }
 
 // H1 is a 1-dim ROOT histogram
@@ -41,6 +57,9 @@ type H1 interface {
 	SumWX2() float64
 	// SumW2s returns the array of sum of squares of weights
 	SumW2s() []float64
+
+	// XAxis returns the axis along X.
+	XAxis() Axis
 }
 
 // H2 is a 2-dim ROOT histogram
@@ -67,6 +86,52 @@ type H2 interface {
 	SumWY2() float64
 	// SumWXY returns the total sum of weights*x*y
 	SumWXY() float64
+
+	// XAxis returns the axis along X.
+	XAxis() Axis
+	// YAxis returns the axis along Y.
+	YAxis() Axis
+}
+
+// H3 is a 3-dim ROOT histogram
+type H3 interface {
+	root.Named
+
+	isH3()
+
+	// Entries returns the number of entries for this histogram.
+	Entries() float64
+	// SumW returns the total sum of weights
+	SumW() float64
+	// SumW2 returns the total sum of squares of weights
+	SumW2() float64
+	// SumWX returns the total sum of weights*x
+	SumWX() float64
+	// SumWX2 returns the total sum of weights*x*x
+	SumWX2() float64
+	// SumW2s returns the array of sum of squares of weights
+	SumW2s() []float64
+	// SumWY returns the total sum of weights*y
+	SumWY() float64
+	// SumWY2 returns the total sum of weights*y*y
+	SumWY2() float64
+	// SumWXY returns the total sum of weights*x*y
+	SumWXY() float64
+	// SumWZ returns the total sum of weights*z
+	SumWZ() float64
+	// SumWZ2 returns the total sum of weights*z*z
+	SumWZ2() float64
+	// SumWXZ returns the total sum of weights*x*z
+	SumWXZ() float64
+	// SumWYZ returns the total sum of weights*y*z
+	SumWYZ() float64
+
+	// XAxis returns the axis along X.
+	XAxis() Axis
+	// YAxis returns the axis along Y.
+	YAxis() Axis
+	// ZAxis returns the axis along Z.
+	ZAxis() Axis
 }
 
 // Graph describes a ROOT TGraph
@@ -75,6 +140,11 @@ type Graph interface {
 
 	Len() int
 	XY(i int) (float64, float64)
+
+	// XAxis returns the axis along X.
+	XAxis() Axis
+	// YAxis returns the axis along Y.
+	YAxis() Axis
 }
 
 // GraphErrors describes a ROOT TGraphErrors
@@ -86,6 +156,37 @@ type GraphErrors interface {
 	YError(i int) (float64, float64)
 }
 
+// GraphMultiErrors describes a ROOT TGraphMultiErrors
+type GraphMultiErrors interface {
+	GraphErrors
+	// NYErrors returns the number of sources of Y errors.
+	NYErrors() int
+	// YErrorAt returns two error values for Y data, from the e-th
+	// source of Y errors.
+	YErrorAt(i, e int) (float64, float64)
+}
+
+// Graph2D describes a ROOT TGraph2D.
+// Graph2D values implement the gonum/plot/plotter.XYZer interface.
+type Graph2D interface {
+	root.Named
+
+	Len() int
+	XY(i int) (float64, float64)
+	XYZ(i int) (float64, float64, float64)
+}
+
+// Graph2DErrors describes a ROOT TGraph2DErrors
+type Graph2DErrors interface {
+	Graph2D
+	// XError returns two error values for X data.
+	XError(i int) (float64, float64)
+	// YError returns two error values for Y data.
+	YError(i int) (float64, float64)
+	// ZError returns two error values for Z data.
+	ZError(i int) (float64, float64)
+}
+
 // F1Composition describes a 1-dim functions composition.
 type F1Composition interface {
 	root.Object
@@ -100,3 +201,11 @@ type MultiGraph interface {
 
 	Graphs() []Graph
 }
+
+// HStack describes a ROOT THStack
+type HStack interface {
+	root.Named
+
+	// Hists returns the stacked histograms, either H1 or H2 values.
+	Hists() []root.Object
+}
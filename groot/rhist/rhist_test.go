@@ -202,4 +202,16 @@ var HistoTestCases = []struct {
 			},
 		},
 	},
+	{
+		Name: "TH3D",
+		Want: func() rtests.ROOTer {
+			h := NewH3DFromEdges("h3d", "my title", []float64{0, 1, 2}, []float64{0, 2, 5}, []float64{-1, 0, 1})
+			h.Fill(0.5, 1, 0.5, 1)
+			h.Fill(1.5, 3, -0.5, 2)
+			h.Fill(1.5, 6, -0.5, 3)
+			// funcs are read back as an empty, non-nil, list.
+			h.th3.th1.funcs = *rcont.NewList("", []root.Object{})
+			return h
+		}(),
+	},
 }
@@ -190,6 +190,75 @@ func TestCreate(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			Name: "TGraph2D",
+			ROOT: "retrieved: [tg2d]\n",
+			Want: []rtests.ROOTer{
+				func() rtests.ROOTer {
+					eff := newEff2D()
+					eff.Annotation()["name"] = "tg2d"
+					return rhist.NewGraph2DFrom(eff).(rtests.ROOTer)
+				}(),
+			},
+		},
+		{
+			Name: "TGraph2DErrors",
+			ROOT: "retrieved: [tg2de]\n",
+			Want: []rtests.ROOTer{
+				func() rtests.ROOTer {
+					eff := newEff2D()
+					eff.Annotation()["name"] = "tg2de"
+					return rhist.NewGraph2DErrorsFrom(eff).(rtests.ROOTer)
+				}(),
+			},
+		},
+		{
+			Name: "TH2D-Eff2D",
+			ROOT: "retrieved: [eff]\n",
+			Want: []rtests.ROOTer{
+				func() rtests.ROOTer {
+					eff := newEff2D().Smooth(1, 1)
+					eff.Annotation()["name"] = "eff"
+					return rhist.NewH2DFrom(eff.Map())
+				}(),
+			},
+		},
+		{
+			Name: "TMultiGraph",
+			ROOT: "retrieved: [mg]\n",
+			Want: []rtests.ROOTer{
+				func() rtests.ROOTer {
+					g1 := hbook.NewS2D(hbook.Point2D{X: 1, Y: 1}, hbook.Point2D{X: 2, Y: 1.5})
+					g1.Annotation()["name"] = "g1"
+					g2 := hbook.NewS2D(hbook.Point2D{X: 1, Y: 2}, hbook.Point2D{X: 2, Y: 2.5})
+					g2.Annotation()["name"] = "g2"
+					return rhist.NewMultiGraph(
+						"mg", "my title",
+						rhist.NewGraphFrom(g1), rhist.NewGraphFrom(g2),
+					).(rtests.ROOTer)
+				}(),
+			},
+		},
+		{
+			Name: "THStack",
+			ROOT: "retrieved: [hs]\n",
+			Want: []rtests.ROOTer{
+				func() rtests.ROOTer {
+					newH1 := func(name string, w float64) rhist.H1 {
+						h := hbook.NewH1D(10, 0, 10)
+						h.Annotation()["name"] = name
+						h.Fill(1, w)
+						h.Fill(2, 2*w)
+						return rhist.NewH1DFrom(h)
+					}
+					hs, err := rhist.NewHStack("hs", "my title", newH1("h1", 1), newH1("h2", 2))
+					if err != nil {
+						t.Fatalf("could not create stack: %+v", err)
+					}
+					return hs.(rtests.ROOTer)
+				}(),
+			},
+		},
 	} {
 		fname := filepath.Join(dir, fmt.Sprintf("out-%d.root", i))
 		t.Run(tc.Name, func(t *testing.T) {
@@ -258,6 +327,40 @@ func TestCreate(t *testing.T) {
 						t.Fatalf("error reading back value[%d].\ngot:\n%s\nwant:\n%s", i, got, want)
 					}
 
+				case rhist.HStack:
+					want := want.(rhist.HStack)
+					if got, want := rgot.Name(), want.Name(); got != want {
+						t.Fatalf("invalid name: got=%q, want=%q", got, want)
+					}
+					var (
+						ghs = rgot.Hists()
+						whs = want.Hists()
+					)
+					if got, want := len(ghs), len(whs); got != want {
+						t.Fatalf("invalid number of histograms: got=%d, want=%d", got, want)
+					}
+					for j := range ghs {
+						got, err := ghs[j].(yodacnv.Marshaler).MarshalYODA()
+						if err != nil {
+							t.Fatalf("could not marshal 'rgot' to YODA: %+v", err)
+						}
+						want, err := whs[j].(yodacnv.Marshaler).MarshalYODA()
+						if err != nil {
+							t.Fatalf("could not marshal 'want' to YODA: %+v", err)
+						}
+						if !bytes.Equal(got, want) {
+							t.Fatalf("error reading back value[%d][%d].\ngot:\n%s\nwant:\n%s", i, j, got, want)
+						}
+					}
+
+				case rhist.Graph2D:
+					want := want.(rhist.Graph2D)
+					if got, want := rgot.Name(), want.Name(); got != want {
+						t.Fatalf("invalid name: got=%q, want=%q", got, want)
+					}
+					if got, want := graph2DPoints(rgot), graph2DPoints(want); !reflect.DeepEqual(got, want) {
+						t.Fatalf("error reading back value[%d].\ngot = %v\nwant= %v", i, got, want)
+					}
 				default:
 					if got := rgot.(rtests.ROOTer); !reflect.DeepEqual(got, want) {
 						t.Fatalf("error reading back value[%d].\ngot = %#v\nwant= %#v", i, got, want)
@@ -304,6 +407,31 @@ void rootls(const char *fname, const char *kname) {
 	}
 }
 
+func newEff2D() *hbook.Eff2D {
+	eff := hbook.NewEff2DFromEdges([]float64{0, 1, 2, 4}, []float64{-2, 0, 2})
+	eff.Annotation()["title"] = "my title"
+	eff.Fill(0.5, -1, true, 1)
+	eff.Fill(0.5, -1, false, 1)
+	eff.Fill(1.5, -1, true, 2)
+	eff.Fill(3.0, +1, true, 0.5)
+	eff.Fill(3.0, +1, false, 1.5)
+	return eff
+}
+
+func graph2DPoints(g rhist.Graph2D) [][6]float64 {
+	pts := make([][6]float64, g.Len())
+	for i := range pts {
+		pt := &pts[i]
+		pt[0], pt[1], pt[2] = g.XYZ(i)
+		if g, ok := g.(rhist.Graph2DErrors); ok {
+			pt[3], _ = g.XError(i)
+			pt[4], _ = g.YError(i)
+			pt[5], _ = g.ZError(i)
+		}
+	}
+	return pts
+}
+
 func stripLine(t *testing.T, raw []byte) string {
 	r := bytes.NewReader(bytes.TrimSpace(raw))
 	scan := bufio.NewScanner(r)
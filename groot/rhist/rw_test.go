@@ -276,10 +276,84 @@ func TestWRBuffer(t *testing.T) {
 			name: "TGraphMultiErrors",
 			want: loadFrom("../testdata/tgme.root", "gme"),
 		},
+		{
+			name: "TGraph2D",
+			want: &tgraph2d{
+				Named:     *rbase.NewNamed("g2d", "my title"),
+				attline:   rbase.AttLine{Color: 602, Style: 1, Width: 1},
+				attfill:   rbase.AttFill{Color: 0, Style: 1001},
+				attmarker: rbase.AttMarker{Color: 1, Style: 1, Width: 1},
+				npoints:   3,
+				npx:       40,
+				npy:       40,
+				maxiter:   100000,
+				x:         []float64{1, 2, 3},
+				y:         []float64{4, 5, 6},
+				z:         []float64{7, 8, 9},
+				min:       -1111,
+				max:       -1111,
+				margin:    0.1,
+				zout:      2,
+				funcs:     rcont.NewList("", []root.Object{}),
+			},
+		},
+		{
+			name: "TGraph2DErrors",
+			want: &tgraph2derrs{
+				tgraph2d: tgraph2d{
+					Named:     *rbase.NewNamed("g2de", "my title"),
+					attline:   rbase.AttLine{Color: 602, Style: 1, Width: 1},
+					attfill:   rbase.AttFill{Color: 0, Style: 1001},
+					attmarker: rbase.AttMarker{Color: 1, Style: 1, Width: 1},
+					npoints:   2,
+					npx:       40,
+					npy:       40,
+					maxiter:   100000,
+					x:         []float64{1, 2},
+					y:         []float64{4, 5},
+					z:         []float64{7, 8},
+					min:       -1111,
+					max:       -1111,
+					funcs:     rcont.NewList("", []root.Object{}),
+				},
+				xerr: []float64{0.1, 0.2},
+				yerr: []float64{0.3, 0.4},
+				zerr: []float64{0.5, 0.6},
+			},
+		},
 		{
 			name: "TMultiGraph",
 			want: loadFrom("../testdata/tgme.root", "mg"),
 		},
+		{
+			name: "TF1",
+			want: loadFrom("../testdata/tformula.root", "func1"),
+		},
+		{
+			name: "TF1Parameters",
+			want: loadFrom("../testdata/tformula.root", "func2"),
+		},
+		{
+			name: "TF2",
+			want: func() rtests.ROOTer {
+				f, err := NewF2("f2", "[a]*x*y+[b]", -1, 1, -2, 2, 2, 3)
+				if err != nil {
+					t.Fatalf("could not create TF2: %+v", err)
+				}
+				return f
+			}(),
+		},
+		{
+			name: "TH3F",
+			want: func() rtests.ROOTer {
+				h := NewH3FFromEdges("h3", "my title", []float64{0, 1, 2}, []float64{0, 2}, []float64{-1, 0, 1, 3})
+				h.Fill(0.5, 1, 2, 1)
+				h.Fill(1.5, 1, -2, 2)
+				// funcs are read back as an empty, non-nil, list.
+				h.th3.th1.funcs = *rcont.NewList("", []root.Object{})
+				return h
+			}(),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			{
@@ -0,0 +1,71 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+)
+
+// Advice describes the expected access pattern to the content of a ROOT
+// file.
+// Advices are used to tune the read-ahead of local files, which are
+// memory-mapped.
+// Advices are ignored by the other kinds of files and on platforms where
+// memory-mapped files can not be advised.
+type Advice int
+
+const (
+	NormalAccess     Advice = iota // no specific access pattern
+	SequentialAccess               // sequential access, e.g. when scanning trees
+	RandomAccess                   // random access, e.g. when browsing keys
+)
+
+func (a Advice) String() string {
+	switch a {
+	case NormalAccess:
+		return "normal"
+	case SequentialAccess:
+		return "sequential"
+	case RandomAccess:
+		return "random"
+	default:
+		return fmt.Sprintf("Advice(%d)", int(a))
+	}
+}
+
+// WithAdvice configures a ROOT file opened for reading with the expected
+// access pattern to its content.
+func WithAdvice(a Advice) FileOption {
+	return func(f *File) error {
+		return f.Advise(a)
+	}
+}
+
+type adviser interface {
+	advise(a Advice) error
+}
+
+// Advise informs the file of the expected access pattern to its content,
+// e.g. sequential access before scanning a large tree, or random access
+// before browsing its keys.
+func (f *File) Advise(a Advice) error {
+	switch a {
+	case NormalAccess, SequentialAccess, RandomAccess:
+		// ok.
+	default:
+		return fmt.Errorf("riofs: invalid access advice %v", a)
+	}
+
+	r, ok := f.r.(adviser)
+	if !ok {
+		return nil
+	}
+
+	err := r.advise(a)
+	if err != nil {
+		return fmt.Errorf("riofs: could not advise %s access to %q: %w", a, f.id, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestOpenWithAdvice(t *testing.T) {
+	const fname = "../testdata/dirs-6.14.00.root"
+	for _, advice := range []Advice{NormalAccess, SequentialAccess, RandomAccess} {
+		t.Run(advice.String(), func(t *testing.T) {
+			f, err := Open(fname, WithAdvice(advice))
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			if got, want := len(f.Keys()), 3; got != want {
+				t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+			}
+
+			// change access pattern while the file is opened.
+			err = f.Advise(RandomAccess)
+			if err != nil {
+				t.Fatalf("could not advise file: %+v", err)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+		})
+	}
+
+	_, err := Open(fname, WithAdvice(Advice(42)))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestLocalFileRead(t *testing.T) {
+	const fname = "../testdata/dirs-6.14.00.root"
+	want, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	r, err := openLocalFile("file://" + fname)
+	if err != nil {
+		t.Fatalf("could not open local file: %+v", err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("could not read local file: %+v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("invalid content")
+	}
+
+	buf := make([]byte, 4)
+	_, err = r.ReadAt(buf, 0)
+	if err != nil {
+		t.Fatalf("could not read-at: %+v", err)
+	}
+	if got, want := string(buf), "root"; got != want {
+		t.Fatalf("invalid magic: got=%q, want=%q", got, want)
+	}
+
+	n, err := r.ReadAt(buf, int64(len(want)-2))
+	if n != 2 || err != io.EOF {
+		t.Fatalf("invalid read-at past EOF: n=%d, err=%v", n, err)
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Fatalf("could not close local file: %+v", err)
+	}
+}
+
+func TestNewReaderAdvice(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	f, err := NewReader(RMemFile(raw))
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	// advices are ignored for non-local files.
+	err = f.Advise(SequentialAccess)
+	if err != nil {
+		t.Fatalf("could not advise file: %+v", err)
+	}
+}
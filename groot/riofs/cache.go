@@ -0,0 +1,215 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// cacheGap is the maximum number of unneeded bytes between two prefetched
+// byte ranges that are coalesced into a single read.
+const cacheGap = 32 * 1024
+
+// Span is a range of bytes of a ROOT file.
+type Span struct {
+	Off int64 // offset of the first byte
+	Len int64 // number of bytes
+}
+
+// WithReadCache configures a ROOT file opened for reading with a read cache
+// holding up to size bytes.
+//
+// Byte ranges that will be read soon, e.g. the baskets needed by an upcoming
+// scan of a tree, can be registered with File.Prefetch.
+// When one of these ranges is read, the cache is filled with as many of the
+// following ranges as it can hold, coalescing neighbouring ranges into
+// large reads. This dramatically reduces the number of small reads needed
+// to scan trees stored in remote files.
+// Reads of ranges that were not registered go directly to the file.
+func WithReadCache(size int64) FileOption {
+	return func(f *File) error {
+		if size <= 0 {
+			return fmt.Errorf("riofs: invalid read cache size %d", size)
+		}
+		if f.w != nil {
+			return fmt.Errorf("riofs: read cache not supported for files opened for writing")
+		}
+		f.r = newRCache(f.r, size)
+		return nil
+	}
+}
+
+// Prefetch registers byte ranges of the file that will be read soon.
+// Prefetch is a no-op for files without a read cache (see WithReadCache.)
+func (f *File) Prefetch(spans ...Span) {
+	c, ok := f.r.(*rcache)
+	if !ok {
+		return
+	}
+	c.prefetch(spans)
+}
+
+// rcache is a read cache filled with the registered byte ranges of a file.
+type rcache struct {
+	r   Reader
+	max int64 // maximum number of cached bytes
+
+	mu    sync.Mutex
+	spans []Span   // registered byte ranges not yet fetched, sorted and coalesced
+	blks  []cblock // cached blocks, sorted by offset
+}
+
+// cblock is a cached block of bytes.
+type cblock struct {
+	off int64
+	buf []byte
+}
+
+func newRCache(r Reader, size int64) *rcache {
+	return &rcache{r: r, max: size}
+}
+
+func (c *rcache) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func (c *rcache) Stat() (os.FileInfo, error) {
+	st, ok := c.r.(stater)
+	if !ok {
+		return nil, fmt.Errorf("riofs: underlying file w/o os.FileInfo")
+	}
+	return st.Stat()
+}
+
+func (c *rcache) advise(a Advice) error {
+	r, ok := c.r.(adviser)
+	if !ok {
+		return nil
+	}
+	return r.advise(a)
+}
+
+func (c *rcache) Close() error {
+	c.mu.Lock()
+	c.spans = nil
+	c.blks = nil
+	c.mu.Unlock()
+	return c.r.Close()
+}
+
+func (c *rcache) ReadAt(p []byte, off int64) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hit(p, off) {
+		return len(p), nil
+	}
+
+	i := sort.Search(len(c.spans), func(i int) bool {
+		return c.spans[i].Off+c.spans[i].Len > off
+	})
+	if i == len(c.spans) || c.spans[i].Off > off {
+		// not a registered byte range.
+		return c.r.ReadAt(p, off)
+	}
+
+	err := c.fill(i)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.hit(p, off) {
+		return len(p), nil
+	}
+	return c.r.ReadAt(p, off)
+}
+
+// hit copies the cached bytes at off into p, if available.
+func (c *rcache) hit(p []byte, off int64) bool {
+	i := sort.Search(len(c.blks), func(i int) bool {
+		return c.blks[i].off+int64(len(c.blks[i].buf)) > off
+	})
+	if i == len(c.blks) {
+		return false
+	}
+	blk := c.blks[i]
+	if off < blk.off || off+int64(len(p)) > blk.off+int64(len(blk.buf)) {
+		return false
+	}
+	copy(p, blk.buf[off-blk.off:])
+	return true
+}
+
+// fill replaces the content of the cache with the registered byte ranges,
+// starting with the i-th one.
+func (c *rcache) fill(i int) error {
+	c.blks = c.blks[:0]
+
+	var (
+		n int64
+		j = i
+	)
+	for ; j < len(c.spans); j++ {
+		spn := c.spans[j]
+		if n > 0 && n+spn.Len > c.max {
+			break
+		}
+		n += spn.Len
+	}
+
+	for _, spn := range c.spans[i:j] {
+		buf := make([]byte, spn.Len)
+		n, err := c.r.ReadAt(buf, spn.Off)
+		if err != nil && err != io.EOF {
+			c.blks = c.blks[:0]
+			return fmt.Errorf("riofs: could not fill read cache: %w", err)
+		}
+		c.blks = append(c.blks, cblock{off: spn.Off, buf: buf[:n]})
+	}
+
+	// ranges before the i-th one were not needed anymore.
+	c.spans = append(c.spans[:0], c.spans[j:]...)
+	return nil
+}
+
+func (c *rcache) prefetch(spans []Span) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all := make([]Span, 0, len(c.spans)+len(spans))
+	all = append(all, c.spans...)
+	for _, spn := range spans {
+		if spn.Len <= 0 {
+			continue
+		}
+		all = append(all, spn)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Off < all[j].Off })
+
+	c.spans = c.spans[:0]
+	for _, spn := range all {
+		if n := len(c.spans); n > 0 {
+			cur := &c.spans[n-1]
+			end := cur.Off + cur.Len
+			if spn.Off <= end+cacheGap && spn.Off+spn.Len-cur.Off <= c.max {
+				if v := spn.Off + spn.Len; v > end {
+					cur.Len = v - cur.Off
+				}
+				continue
+			}
+		}
+		c.spans = append(c.spans, spn)
+	}
+}
+
+var (
+	_ Reader  = (*rcache)(nil)
+	_ stater  = (*rcache)(nil)
+	_ adviser = (*rcache)(nil)
+)
@@ -0,0 +1,84 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type countReader struct {
+	*bytes.Reader
+	reads []Span
+}
+
+func (r *countReader) ReadAt(p []byte, off int64) (int, error) {
+	r.reads = append(r.reads, Span{Off: off, Len: int64(len(p))})
+	return r.Reader.ReadAt(p, off)
+}
+
+func (r *countReader) Close() error { return nil }
+
+func TestReadCache(t *testing.T) {
+	data := make([]byte, 1<<20)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	r := &countReader{Reader: bytes.NewReader(data)}
+	c := newRCache(r, 64*1024)
+	c.prefetch([]Span{
+		{Off: 40000, Len: 1000},
+		{Off: 0, Len: 1000},
+		{Off: 1000, Len: 2000},
+		{Off: 10000, Len: 500}, // coalesced with the first ranges.
+		{Off: 500000, Len: 100},
+		{Off: 1<<20 - 100, Len: 200}, // past the end of the data.
+	})
+
+	want := []Span{
+		{Off: 0, Len: 41000},
+		{Off: 500000, Len: 100},
+		{Off: 1<<20 - 100, Len: 200},
+	}
+	if got := c.spans; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid spans:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	read := func(off, n int64) {
+		t.Helper()
+		p := make([]byte, n)
+		_, err := c.ReadAt(p, off)
+		if err != nil && err != io.EOF {
+			t.Fatalf("could not read [%d, %d): %+v", off, off+n, err)
+		}
+		if !bytes.Equal(p, data[off:off+n]) {
+			t.Fatalf("invalid data for [%d, %d)", off, off+n)
+		}
+	}
+
+	read(1000, 2000)
+	read(0, 1000)
+	read(10000, 500)
+	read(40000, 1000)
+	read(500000, 100)
+	read(200000, 100) // not registered.
+	read(1<<20-100, 100)
+
+	want = []Span{
+		{Off: 0, Len: 41000},
+		{Off: 500000, Len: 100},
+		{Off: 1<<20 - 100, Len: 200}, // fetched together with the previous range.
+		{Off: 200000, Len: 100},
+	}
+	if got := r.reads; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid reads:\ngot= %+v\nwant=%+v", got, want)
+	}
+	if len(c.spans) != 0 {
+		t.Fatalf("invalid remaining spans: %+v", c.spans)
+	}
+}
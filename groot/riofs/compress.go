@@ -5,13 +5,67 @@
 package riofs
 
 import (
+	"fmt"
+
 	"go-hep.org/x/hep/groot/internal/rcompress"
 )
 
+// CompressionAlgorithm is an algorithm used to compress the payloads of
+// the keys and baskets of a ROOT file.
+type CompressionAlgorithm int
+
+// Compression algorithms supported by ROOT files.
+const (
+	NoCompression CompressionAlgorithm = 0
+	ZLIB          CompressionAlgorithm = CompressionAlgorithm(rcompress.ZLIB)
+	LZMA          CompressionAlgorithm = CompressionAlgorithm(rcompress.LZMA)
+	LZ4           CompressionAlgorithm = CompressionAlgorithm(rcompress.LZ4)
+	ZSTD          CompressionAlgorithm = CompressionAlgorithm(rcompress.ZSTD)
+)
+
+func (alg CompressionAlgorithm) String() string {
+	switch alg {
+	case NoCompression:
+		return "none"
+	case ZLIB:
+		return "zlib"
+	case LZMA:
+		return "lzma"
+	case LZ4:
+		return "lz4"
+	case ZSTD:
+		return "zstd"
+	}
+	return fmt.Sprintf("CompressionAlgorithm(%d)", int(alg))
+}
+
 func (f *File) setCompression(alg rcompress.Kind, lvl int) {
 	f.compression = rcompress.Settings{Alg: alg, Lvl: lvl}.Compression()
 }
 
+// WithCompression configures a ROOT file to use the provided compression
+// algorithm and compression level.
+// As for ROOT, the compression level ranges from 1 (fastest) to 9 (best
+// compression), a level of 0 disabling the compression.
+// The compress/flate constants (e.g. flate.DefaultCompression) may also
+// be used.
+//
+// Trees created in that file use the same compression settings for their
+// baskets, unless configured otherwise.
+func WithCompression(alg CompressionAlgorithm, level int) FileOption {
+	return func(f *File) error {
+		switch alg {
+		case NoCompression:
+			f.setCompression(0, 0)
+		case ZLIB, LZMA, LZ4, ZSTD:
+			f.setCompression(rcompress.Kind(alg), level)
+		default:
+			return fmt.Errorf("riofs: invalid compression algorithm %v", alg)
+		}
+		return nil
+	}
+}
+
 // WithLZ4 configures a ROOT file to use LZ4 as a compression mechanism.
 func WithLZ4(level int) FileOption {
 	return func(f *File) error {
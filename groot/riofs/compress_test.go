@@ -0,0 +1,87 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs_test
+
+import (
+	"compress/flate"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+)
+
+func TestWithCompression(t *testing.T) {
+	dir, err := os.MkdirTemp("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	want := strings.Repeat("0123456789-", 10000)
+
+	for _, alg := range []riofs.CompressionAlgorithm{
+		riofs.NoCompression,
+		riofs.ZLIB,
+		riofs.LZMA,
+		riofs.LZ4,
+		riofs.ZSTD,
+	} {
+		for _, lvl := range []int{flate.DefaultCompression, 1, 5, 9} {
+			name := fmt.Sprintf("%v-%d", alg, lvl)
+			t.Run(name, func(t *testing.T) {
+				fname := filepath.Join(dir, name+".root")
+				w, err := riofs.Create(fname, riofs.WithCompression(alg, lvl))
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer w.Close()
+
+				err = w.Put("str", rbase.NewObjString(want))
+				if err != nil {
+					t.Fatalf("could not put object: %+v", err)
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+
+				r, err := riofs.Open(fname)
+				if err != nil {
+					t.Fatalf("could not open file: %+v", err)
+				}
+				defer r.Close()
+
+				if got, want := riofs.CompressionAlgorithm(r.Compression()/100), alg; got != want {
+					t.Fatalf("invalid compression algorithm: got=%v, want=%v", got, want)
+				}
+
+				key := r.Keys()[0]
+				compressed := key.Nbytes() < key.KeyLen()+key.ObjLen()
+				if got, want := compressed, alg != riofs.NoCompression; got != want {
+					t.Fatalf("invalid compression state: got=%v, want=%v", got, want)
+				}
+
+				obj, err := r.Get("str")
+				if err != nil {
+					t.Fatalf("could not get object: %+v", err)
+				}
+				if got := obj.(root.ObjString).String(); got != want {
+					t.Fatalf("invalid value")
+				}
+			})
+		}
+	}
+
+	_, err = riofs.Create(filepath.Join(dir, "invalid.root"), riofs.WithCompression(42, 1))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestConcurrentReads(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []riofs.FileOption
+	}{
+		{name: "default"},
+		{name: "lazy", opts: []riofs.FileOption{riofs.WithLazyKeys()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := riofs.Open("../testdata/dirs-6.14.00.root", tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			ft, err := riofs.Open("../testdata/small-flat-tree.root", tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ft.Close()
+
+			const n = 16
+			var (
+				wg   sync.WaitGroup
+				errc = make(chan error, 2*n)
+			)
+			for i := 0; i < n; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					for _, name := range []string{"dir1/dir11/h1", "dir2", "dir3"} {
+						_, err := riofs.Dir(f).Get(name)
+						if err != nil {
+							errc <- fmt.Errorf("could not get %q: %w", name, err)
+							return
+						}
+					}
+				}()
+				go func() {
+					defer wg.Done()
+					tree, err := riofs.Get[rtree.Tree](ft, "tree")
+					if err != nil {
+						errc <- err
+						return
+					}
+					var v int32
+					r, err := rtree.NewReader(tree, []rtree.ReadVar{{Name: "Int32", Value: &v}})
+					if err != nil {
+						errc <- err
+						return
+					}
+					defer r.Close()
+
+					var sum int64
+					err = r.Read(func(ctx rtree.RCtx) error {
+						sum += int64(v)
+						return nil
+					})
+					if err != nil {
+						errc <- err
+						return
+					}
+					if sum != 4950 {
+						errc <- fmt.Errorf("invalid sum: got=%d, want=4950", sum)
+					}
+				}()
+			}
+			wg.Wait()
+			close(errc)
+			for err := range errc {
+				t.Fatal(err)
+			}
+		})
+	}
+}
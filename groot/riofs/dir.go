@@ -7,6 +7,7 @@ package riofs
 import (
 	"fmt"
 	"io"
+	stdpath "path"
 	"reflect"
 	"sort"
 	"strings"
@@ -92,6 +93,7 @@ type tdirectoryFile struct {
 
 	file *File // pointer to current file in memory
 	keys []Key
+	lazy *lazyKeys // on-demand loading of keys (nil if keys are loaded)
 	dirs []*tdirectoryFile
 }
 
@@ -227,7 +229,18 @@ func (dir *tdirectoryFile) readDirInfo() error {
 	return r.Err()
 }
 
+// readKeys reads the list of keys of the directory from the file.
 func (dir *tdirectoryFile) readKeys() error {
+	dir.keys = nil
+	return dir.decodeKeys(func(k *Key) bool {
+		dir.keys = append(dir.keys, *k)
+		return true
+	})
+}
+
+// decodeKeys decodes the keys of the directory from the file, and calls f
+// with each of them, until f returns false.
+func (dir *tdirectoryFile) decodeKeys(f func(k *Key) bool) error {
 	var err error
 	if dir.seekkeys <= 0 {
 		return nil
@@ -256,11 +269,8 @@ func (dir *tdirectoryFile) readKeys() error {
 	if r.Err() != nil {
 		return r.Err()
 	}
-	dir.keys = make([]Key, int(nkeys))
-	for i := range dir.keys {
-		k := &dir.keys[i]
-		k.f = dir.file
-		k.parent = dir
+	for i := 0; i < int(nkeys); i++ {
+		k := Key{f: dir.file, parent: dir}
 		err := k.UnmarshalROOT(r)
 		if err != nil {
 			return err
@@ -269,6 +279,9 @@ func (dir *tdirectoryFile) readKeys() error {
 		if k.class == "TDirectory" {
 			k.class = "TDirectoryFile"
 		}
+		if !f(&k) {
+			break
+		}
 	}
 	return nil
 }
@@ -359,6 +372,11 @@ func (dir *tdirectoryFile) writeHeader() error {
 //             if object is not in memory, try with highest cycle from file
 //     foo;1 : get cycle 1 of foo on file
 func (dir *tdirectoryFile) Get(namecycle string) (root.Object, error) {
+	err := dir.loadKeys()
+	if err != nil {
+		return nil, err
+	}
+
 	var keys []*Key
 	name, cycle := decodeNameCycle(namecycle)
 	for i := range dir.keys {
@@ -386,24 +404,8 @@ func (dir *tdirectoryFile) Get(namecycle string) (root.Object, error) {
 		key = keys[len(keys)-1]
 	}
 
-	obj, err := key.Object()
-	if err != nil {
-		return nil, err
-	}
-
-	if obj != nil {
-		switch obj := obj.(type) {
-		case *tdirectoryFile:
-			obj.dir.parent = dir
-			if obj.dir.Name() == "" {
-				obj.dir.named.SetName(name)
-			}
-			if obj.Title() == "" {
-				obj.dir.named.SetTitle(name)
-			}
-		}
-	}
-	return obj, nil
+	// sub-directories are attached to their parent by Key.Object.
+	return key.Object()
 }
 
 func (dir *tdirectoryFile) Put(name string, obj root.Object) error {
@@ -479,12 +481,25 @@ func (dir *tdirectoryFile) Put(name string, obj root.Object) error {
 }
 
 // Keys returns the list of keys being held by this directory.
+// Keys of directories of files opened with WithLazyKeys are loaded on
+// demand: errors encountered while loading them are reported by Get.
 func (dir *tdirectoryFile) Keys() []Key {
+	_ = dir.loadKeys()
 	return dir.keys
 }
 
-// Mkdir creates a new subdirectory
+// Mkdir creates a new subdirectory.
+// Mkdir creates the intermediate directories of nested paths (e.g. "a/b/c")
+// as needed.
 func (dir *tdirectoryFile) Mkdir(name string) (Directory, error) {
+	if pname, base := stdpath.Split(name); pname != "" && base != "" {
+		p, err := Dir(dir).Mkdir(strings.TrimRight(pname, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("riofs: could not create parent directory of %q: %w", name, err)
+		}
+		return p.Mkdir(base)
+	}
+
 	if _, err := dir.Get(name); err == nil {
 		return nil, fmt.Errorf("riofs: %q already exists", name)
 	}
@@ -605,22 +620,12 @@ func (dir *tdirectoryFile) addStreamer(streamer rbytes.StreamerInfo) {
 // writeKeys writes the list of keys to the file.
 // The list of keys is written out as a single data record.
 func (dir *tdirectoryFile) writeKeys() error {
-	var (
-		err    error
-		nbytes = int32(4) // space for n-keys
-	)
-
-	if dir.file.IsBigFile() {
-		nbytes += 8
-	}
-	for i := range dir.Keys() {
-		key := &dir.keys[i]
-		nbytes += key.keylen
-	}
-
-	hdr := newKey(dir, dir.Name(), dir.Title(), "TDirectory", nbytes, dir.file)
+	var err error
 
-	buf := rbytes.NewWBuffer(make([]byte, nbytes), nil, 0, nil)
+	// the list of keys is sized from the encoded keys rather than from
+	// their on-file key length: keys read from old files may have been
+	// renamed (e.g. TDirectory -> TDirectoryFile) since.
+	buf := rbytes.NewWBuffer(nil, nil, 0, nil)
 	buf.WriteI32(int32(len(dir.Keys())))
 	for _, k := range dir.Keys() {
 		_, err = k.MarshalROOT(buf)
@@ -628,8 +633,15 @@ func (dir *tdirectoryFile) writeKeys() error {
 			return fmt.Errorf("riofs: could not write key: %w", err)
 		}
 	}
+
+	hdr := newKey(dir, dir.Name(), dir.Title(), "TDirectory", int32(len(buf.Bytes())), dir.file)
 	hdr.buf = buf.Bytes()
 
+	var (
+		seekkeys   = dir.seekkeys
+		nbyteskeys = dir.nbyteskeys
+	)
+
 	dir.seekkeys = hdr.seekkey
 	dir.nbyteskeys = hdr.nbytes
 
@@ -637,6 +649,160 @@ func (dir *tdirectoryFile) writeKeys() error {
 	if err != nil {
 		return fmt.Errorf("riofs: could not write header key: %w", err)
 	}
+
+	if seekkeys != 0 {
+		// release the previous list of keys, if any.
+		dir.file.markFree(seekkeys, seekkeys+int64(nbyteskeys)-1)
+	}
+	return nil
+}
+
+// del removes the key name;cycle from the directory and releases its
+// record on file.
+// All the cycles of the named key are removed if cycle is 9999.
+// Sub-directories are removed together with their content.
+func (dir *tdirectoryFile) del(name string, cycle int16) error {
+	if dir.file.w == nil {
+		return fmt.Errorf("could not delete %q from directory %q: %w", name, dir.dir.Name(), ErrReadOnly)
+	}
+
+	var (
+		keys = dir.keys[:0]
+		ndel = 0
+	)
+	for i := range dir.keys {
+		k := dir.keys[i]
+		if k.name != name || (cycle != 9999 && k.cycle != cycle) {
+			keys = append(keys, k)
+			continue
+		}
+		err := dir.free(&k)
+		if err != nil {
+			return fmt.Errorf("riofs: could not delete %s;%d: %w", k.name, k.cycle, err)
+		}
+		ndel++
+	}
+	dir.keys = keys
+
+	if ndel == 0 {
+		return noKeyError{key: name, obj: dir}
+	}
+	return nil
+}
+
+// free releases the record of the provided key on file, as well as the
+// records of its content when the key holds a sub-directory.
+func (dir *tdirectoryFile) free(k *Key) error {
+	switch k.class {
+	case "TDirectory", "TDirectoryFile":
+		var sub *tdirectoryFile
+		dirs := dir.dirs[:0]
+		for _, d := range dir.dirs {
+			if d.seekdir != k.seekkey {
+				dirs = append(dirs, d)
+				continue
+			}
+			if sub == nil {
+				sub = d
+			}
+		}
+		dir.dirs = dirs
+
+		if sub == nil {
+			obj, err := k.Object()
+			if err != nil {
+				return fmt.Errorf("could not load directory %q: %w", k.name, err)
+			}
+			sub = obj.(*tdirectoryFile)
+			// loading a sub-directory of a file opened for update
+			// registers it for saving: undo that.
+			dir.dirs = dir.dirs[:len(dirs)]
+		}
+
+		for i := range sub.keys {
+			err := sub.free(&sub.keys[i])
+			if err != nil {
+				return err
+			}
+		}
+		sub.keys = nil
+		if sub.seekkeys != 0 {
+			dir.file.markFree(sub.seekkeys, sub.seekkeys+int64(sub.nbyteskeys)-1)
+		}
+	}
+
+	dir.file.markFree(k.seekkey, k.seekkey+int64(k.nbytes)-1)
+	return nil
+}
+
+// purge removes all the cycles of the keys of the directory, but the keep
+// most recent ones, and releases their records on file.
+func (dir *tdirectoryFile) purge(keep int) error {
+	if dir.file.w == nil {
+		return fmt.Errorf("could not purge directory %q: %w", dir.dir.Name(), ErrReadOnly)
+	}
+	if keep < 1 {
+		keep = 1
+	}
+
+	cycles := make(map[string][]int16)
+	for _, k := range dir.keys {
+		cycles[k.name] = append(cycles[k.name], k.cycle)
+	}
+
+	var (
+		keys = dir.keys[:0]
+		drop = make(map[string]int16, len(cycles)) // most recent cycle to remove
+	)
+	for name, cs := range cycles {
+		if len(cs) <= keep {
+			continue
+		}
+		sort.Slice(cs, func(i, j int) bool { return cs[i] > cs[j] })
+		drop[name] = cs[keep]
+	}
+
+	for i := range dir.keys {
+		k := dir.keys[i]
+		if last, ok := drop[k.name]; !ok || k.cycle > last {
+			keys = append(keys, k)
+			continue
+		}
+		err := dir.free(&k)
+		if err != nil {
+			return fmt.Errorf("riofs: could not delete %s;%d: %w", k.name, k.cycle, err)
+		}
+	}
+	dir.keys = keys
+
+	return nil
+}
+
+// replace puts the provided object under the given name, and removes all
+// the previous cycles of that key, releasing their records on file.
+func (dir *tdirectoryFile) replace(name string, obj root.Object) error {
+	if v, ok := obj.(root.Named); ok && name == "" {
+		name = v.Name()
+	}
+
+	var cycles []int16
+	for _, k := range dir.keys {
+		if k.name == name {
+			cycles = append(cycles, k.cycle)
+		}
+	}
+
+	err := dir.Put(name, obj)
+	if err != nil {
+		return err
+	}
+
+	for _, cycle := range cycles {
+		err = dir.del(name, cycle)
+		if err != nil {
+			return fmt.Errorf("riofs: could not remove %s;%d: %w", name, cycle, err)
+		}
+	}
 	return nil
 }
 
@@ -682,6 +848,11 @@ func (dir *tdirectoryFile) writeKeys() error {
 // }
 
 func (dir *tdirectoryFile) records(w io.Writer, indent int) error {
+	err := dir.loadKeys()
+	if err != nil {
+		return err
+	}
+
 	hdr := strings.Repeat("  ", indent)
 	fmt.Fprintf(w, "%s=== dir %q @%d ===\n", hdr, dir.Name(), dir.seekdir)
 	parent := "<nil>"
@@ -90,6 +90,13 @@ type FileOption func(f *File) error
 //    38->41 [46->53] fSeekInfo   = Pointer to TStreamerInfo record
 //    42->45 [54->57] fNbytesInfo = Number of bytes in TStreamerInfo record
 //    46->63 [58->75] fUUID       = Universal Unique ID
+//
+// A File opened for reading can be shared by multiple goroutines, e.g. to
+// retrieve different objects or to read the baskets of different trees,
+// without reopening the file: records are read with positional reads
+// (io.ReaderAt) that do not share a file offset.
+// Readers passed to NewReader must thus support parallel ReadAt calls, as
+// documented by io.ReaderAt.
 type File struct {
 	r      Reader
 	w      Writer
@@ -116,14 +123,27 @@ type File struct {
 	siKey  Key
 	sinfos []rbytes.StreamerInfo
 	simap  map[rbytes.StreamerInfo]struct{} // local set of streamers, when writing
+	sidx   map[string]rbytes.StreamerInfo   // streamers of the file, indexed by class name
+
+	// kmu protects the payloads and types cached by the keys of the file,
+	// which may be loaded concurrently by goroutines sharing the file.
+	kmu sync.RWMutex
 
 	spans freeList // list of free spans on file
+
+	lazy bool // whether the keys of directories are loaded on demand
 }
 
 // Open opens the named ROOT file for reading. If successful, methods on the
 // returned file can be used for reading; the associated file descriptor
 // has mode os.O_RDONLY.
-func Open(path string) (*File, error) {
+//
+// The "-" path reads the ROOT file from the standard input (see NewStreamReader.)
+//
+// Local files are memory-mapped, when the platform and the file system
+// support it, and concurrent readers of the same local file share the same
+// mapping. Otherwise, local files are read with regular reads.
+func Open(path string, opts ...FileOption) (*File, error) {
 	fd, err := openFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("riofs: unable to open %q: %w", path, err)
@@ -136,6 +156,17 @@ func Open(path string) (*File, error) {
 	}
 	f.dir.file = f
 
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		err := opt(f)
+		if err != nil {
+			_ = fd.Close()
+			return nil, fmt.Errorf("riofs: could not apply option to ROOT file: %w", err)
+		}
+	}
+
 	err = f.readHeader()
 	if err != nil {
 		return nil, fmt.Errorf("riofs: failed to read header %q: %w", path, err)
@@ -168,9 +199,22 @@ func Create(name string, opts ...FileOption) (*File, error) {
 		return nil, fmt.Errorf("riofs: unable to create %q: %w", name, err)
 	}
 
+	f, err := newWriter(name, fd, opts)
+	if err != nil {
+		_ = fd.Close()
+		_ = os.RemoveAll(name)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// newWriter creates a new named ROOT file for writing, backed by the
+// provided writer.
+func newWriter(name string, w Writer, opts []FileOption) (*File, error) {
 	f := &File{
-		w:           fd,
-		closer:      fd,
+		w:           w,
+		closer:      w,
 		id:          name,
 		version:     root.Version,
 		begin:       kBEGIN,
@@ -206,10 +250,8 @@ func Create(name string, opts ...FileOption) (*File, error) {
 	f.seekfree = 0
 	f.nbytesfree = 0
 
-	err = f.writeHeader()
+	err := f.writeHeader()
 	if err != nil {
-		_ = fd.Close()
-		_ = os.RemoveAll(name)
 		return nil, fmt.Errorf("riofs: failed to write header %q: %w", name, err)
 	}
 
@@ -231,22 +273,101 @@ func Create(name string, opts ...FileOption) (*File, error) {
 	return f, nil
 }
 
-func (f *File) setEnd(pos int64) error {
-	f.end = pos
-	if f.spans.Len() == 0 {
-		return fmt.Errorf("riofs: empty free segment list")
+// Update opens the named ROOT file for reading and writing.
+// New objects can be added to the file, existing ones replaced (see Replace)
+// or deleted (see Delete).
+// The records released on file are tracked in the list of free segments
+// and are reused for new records, whenever possible.
+// The list of keys and the header of the modified directories, the
+// streamer infos and the list of free segments are rewritten when the
+// file is flushed or closed.
+func Update(name string, opts ...FileOption) (*File, error) {
+	fd, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("riofs: unable to open %q for update: %w", name, err)
 	}
-	blk := f.spans.last()
+
+	f := &File{
+		r:      fd,
+		w:      fd,
+		closer: fd,
+		id:     name,
+		simap:  make(map[rbytes.StreamerInfo]struct{}),
+	}
+	f.dir.file = f
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		err := opt(f)
+		if err != nil {
+			_ = fd.Close()
+			return nil, fmt.Errorf("riofs: could not apply option to ROOT file: %w", err)
+		}
+	}
+
+	err = f.readHeader()
+	if err != nil {
+		_ = fd.Close()
+		return nil, fmt.Errorf("riofs: failed to read header %q: %w", name, err)
+	}
+
+	for _, si := range f.sinfos {
+		f.simap[si] = struct{}{}
+	}
+
+	if len(f.spans) == 0 {
+		f.spans.add(f.end, kStartBigFile)
+	}
+
+	return f, nil
+}
+
+// alloc reserves nbytes on file and returns the position of the reserved
+// record.
+// The record is put in the first free segment large enough to hold it,
+// or at the end of the file.
+// When the record does not fill its free segment, the remaining gap is
+// marked as free on file.
+func (f *File) alloc(nbytes int32) (int64, error) {
+	blk := f.spans.best(int64(nbytes))
 	if blk == nil {
-		return fmt.Errorf("riofs: last free segment is nil")
+		return 0, fmt.Errorf("riofs: empty free segment list")
 	}
 
-	if blk.last != kStartBigFile {
-		return fmt.Errorf("riofs: last free segment is not the file ending")
+	pos := blk.first
+	if pos >= f.end {
+		// append at the end of the file.
+		f.end = pos + int64(nbytes)
+		blk.first = f.end
+		if f.end > blk.last {
+			blk.last += 1000000000
+		}
+		return pos, nil
 	}
 
-	blk.first = pos
-	return nil
+	left := blk.last - pos - int64(nbytes) + 1
+	if left == 0 {
+		// the record fills exactly a deleted gap.
+		for i := range f.spans {
+			if &f.spans[i] == blk {
+				f.spans.remove(i)
+				break
+			}
+		}
+		return pos, nil
+	}
+
+	blk.first = pos + int64(nbytes)
+	buf := rbytes.NewWBuffer(make([]byte, 4), nil, 0, f)
+	buf.WriteI32(-int32(left))
+	_, err := f.w.WriteAt(buf.Bytes(), blk.first)
+	if err != nil {
+		return 0, fmt.Errorf("riofs: could not mark free segment: %w", err)
+	}
+
+	return pos, nil
 }
 
 // Stat returns the os.FileInfo structure describing this file.
@@ -285,7 +406,41 @@ func (f *File) Version() int {
 }
 
 func (f *File) readHeader() error {
+	err := f.readFileHeader()
+	if err != nil {
+		return err
+	}
 
+	err = f.dir.readDirInfo()
+	if err != nil {
+		return fmt.Errorf("riofs: failed to read ROOT directory infos: %w", err)
+	}
+
+	if f.seekfree > 0 {
+		err = f.readFreeSegments()
+		if err != nil {
+			return fmt.Errorf("riofs: failed to read ROOT file free segments: %w", err)
+		}
+	}
+
+	if f.seekinfo > 0 {
+		err = f.readStreamerInfo()
+		if err != nil {
+			return fmt.Errorf("riofs: failed to read ROOT streamer infos: %w", err)
+		}
+	}
+
+	err = f.dir.initKeys()
+	if err != nil {
+		return fmt.Errorf("riofs: failed to read ROOT file keys: %w", err)
+	}
+
+	return nil
+}
+
+// readFileHeader reads the header record of the file, located at the
+// beginning of the file.
+func (f *File) readFileHeader() error {
 	buf := make([]byte, 64+12) // 64: small file + extra space for big file
 	if _, err := f.ReadAt(buf, 0); err != nil {
 		return err
@@ -335,32 +490,6 @@ func (f *File) readHeader() error {
 		return r.Err()
 	}
 
-	var err error
-
-	err = f.dir.readDirInfo()
-	if err != nil {
-		return fmt.Errorf("riofs: failed to read ROOT directory infos: %w", err)
-	}
-
-	if f.seekfree > 0 {
-		err = f.readFreeSegments()
-		if err != nil {
-			return fmt.Errorf("riofs: failed to read ROOT file free segments: %w", err)
-		}
-	}
-
-	if f.seekinfo > 0 {
-		err = f.readStreamerInfo()
-		if err != nil {
-			return fmt.Errorf("riofs: failed to read ROOT streamer infos: %w", err)
-		}
-	}
-
-	err = f.dir.readKeys()
-	if err != nil {
-		return fmt.Errorf("riofs: failed to read ROOT file keys: %w", err)
-	}
-
 	return nil
 }
 
@@ -428,6 +557,40 @@ func (f *File) writeHeader() error {
 	return err
 }
 
+// Flush writes the list of keys and the header of all the directories,
+// the streamer infos, the list of free segments and the header of the
+// File to the underlying storage, without closing it.
+// After a Flush, the File can be opened and read by another process
+// while it is still being written to.
+// Flush is a no-op for read-only files.
+func (f *File) Flush() error {
+	if f.closer == nil || f.w == nil {
+		return nil
+	}
+
+	err := f.dir.close()
+	if err != nil {
+		return fmt.Errorf("riofs: could not save directories: %w", err)
+	}
+
+	err = f.writeStreamerInfo()
+	if err != nil {
+		return fmt.Errorf("riofs: could not write streamer infos: %w", err)
+	}
+
+	err = f.writeFreeSegments()
+	if err != nil {
+		return fmt.Errorf("riofs: could not write free segments: %w", err)
+	}
+
+	err = f.writeHeader()
+	if err != nil {
+		return fmt.Errorf("riofs: could not write file header: %w", err)
+	}
+
+	return nil
+}
+
 // Close closes the File, rendering it unusable for I/O.
 // It returns an error, if any.
 func (f *File) Close() error {
@@ -517,17 +680,31 @@ func (f *File) readStreamerInfo() error {
 
 	objs := f.siKey.Value().(root.List)
 	f.sinfos = make([]rbytes.StreamerInfo, 0, objs.Len())
+	f.sidx = make(map[string]rbytes.StreamerInfo, objs.Len())
 	for i := 0; i < objs.Len(); i++ {
 		obj, ok := objs.At(i).(rbytes.StreamerInfo)
 		if !ok {
 			continue
 		}
 		f.sinfos = append(f.sinfos, obj)
+		f.indexStreamer(obj)
 		rdict.StreamerInfos.Add(obj)
 	}
 	return nil
 }
 
+// indexStreamer indexes the provided streamer by class name, unless a
+// streamer for that class was already indexed.
+func (f *File) indexStreamer(si rbytes.StreamerInfo) {
+	if f.sidx == nil {
+		f.sidx = make(map[string]rbytes.StreamerInfo)
+	}
+	if _, dup := f.sidx[si.Name()]; dup {
+		return
+	}
+	f.sidx[si.Name()] = si
+}
+
 // muWriteStreamerInfo makes sure we serialize calls to File.writeStreamerInfo,
 // as StreamerInfos are shared through the global rdict.StreamerInfos registry.
 var muWriteStreamerInfo sync.Mutex
@@ -572,7 +749,7 @@ func (f *File) writeStreamerInfo() error {
 		return fmt.Errorf("riofs: could not write StreamerInfo list: %w", err)
 	}
 
-	key, err = newKeyFromBuf(&f.dir, "StreamerInfo", sinfos.Title(), sinfos.Class(), 1, buf.Bytes(), f)
+	key, err = newKeyFromBuf(&f.dir, "StreamerInfo", sinfos.Title(), sinfos.Class(), 1, buf.Bytes(), f, f.compression)
 	if err != nil {
 		return fmt.Errorf("riofs: could not create StreamerInfo key: %w", err)
 	}
@@ -624,7 +801,7 @@ func (f *File) findDepStreamers() error {
 	}
 
 	for _, dep := range deps {
-		if isCoreType(dep.name) || isCxxBuiltin(dep.name) {
+		if isCoreType(dep.name) || isCxxBuiltin(dep.name) || rmeta.IsStdPairOfBuiltins(dep.name) {
 			continue
 		}
 		sub, err := rdict.StreamerInfos.StreamerInfo(dep.name, dep.vers)
@@ -804,6 +981,7 @@ func (f *File) StreamerInfo(name string, version int) (rbytes.StreamerInfo, erro
 			si := stdvecSIFrom(name, cxx.Args[0], f)
 			if si != nil {
 				f.sinfos = append(f.sinfos, si)
+				f.indexStreamer(si)
 				rdict.StreamerInfos.Add(si)
 				return si, nil
 			}
@@ -847,6 +1025,7 @@ func (f *File) addStreamer(streamer rbytes.StreamerInfo) {
 
 	f.simap[streamer] = struct{}{}
 	f.sinfos = append(f.sinfos, streamer)
+	f.indexStreamer(streamer)
 }
 
 // Get returns the object identified by namecycle
@@ -862,6 +1041,27 @@ func (f *File) Get(namecycle string) (root.Object, error) {
 	return f.dir.Get(namecycle)
 }
 
+// Delete removes the object identified by namecycle from the file and
+// releases its record on file.
+// namecycle has the format [path/to/]name[;cycle].
+// All the cycles of the object are removed if no cycle is given.
+func (f *File) Delete(namecycle string) error {
+	return Delete(f, namecycle)
+}
+
+// Move moves the object identified by src to dst, within the file.
+// See the package-level Move function for details.
+func (f *File) Move(src, dst string) error {
+	return Move(f, src, dst)
+}
+
+// Purge removes all the cycles of the objects held by the top-level
+// directory of the file, but the keep most recent ones, and releases
+// their records on file.
+func (f *File) Purge(keep int) error {
+	return Purge(f, keep)
+}
+
 // Put puts the object v under the key with the given name.
 func (f *File) Put(name string, v root.Object) error {
 	if f.w == nil {
@@ -6,6 +6,7 @@ package riofs_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 	"go-hep.org/x/hep/groot/internal/rtests"
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtree"
@@ -547,3 +549,594 @@ func TestReadOnlyFile(t *testing.T) {
 		t.Fatalf("expected an error. got nil")
 	}
 }
+
+func TestFileFlush(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "flush.root")
+
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer w.Close()
+
+	dir, err := riofs.Dir(w).Mkdir("dir")
+	if err != nil {
+		t.Fatalf("could not create dir: %+v", err)
+	}
+
+	check := func(want ...string) {
+		t.Helper()
+		r, err := riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer r.Close()
+
+		for _, name := range want {
+			o, err := riofs.Dir(r).Get(name)
+			if err != nil {
+				t.Fatalf("could not get %q: %+v", name, err)
+			}
+			if got, want := o.(*rbase.ObjString).String(), name; got != want {
+				t.Fatalf("invalid value for %q: got=%q, want=%q", name, got, want)
+			}
+		}
+	}
+
+	for i, name := range []string{"obj1", "dir/obj2", "obj3"} {
+		err = riofs.Dir(w).Put(name, rbase.NewObjString(name))
+		if err != nil {
+			t.Fatalf("could not put %q: %+v", name, err)
+		}
+		err = w.Flush()
+		if err != nil {
+			t.Fatalf("could not flush file (iter=%d): %+v", i, err)
+		}
+		check([]string{"obj1", "dir/obj2", "obj3"}[:i+1]...)
+	}
+
+	err = riofs.Delete(w, "obj1")
+	if err != nil {
+		t.Fatalf("could not delete obj1: %+v", err)
+	}
+	err = riofs.Delete(dir, "obj2;1")
+	if err != nil {
+		t.Fatalf("could not delete dir/obj2: %+v", err)
+	}
+	err = riofs.Delete(w, "obj1")
+	if err == nil {
+		t.Fatalf("expected an error deleting a missing key")
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	check("obj3")
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	for _, name := range []string{"obj1", "dir/obj2"} {
+		_, err = riofs.Dir(r).Get(name)
+		if err == nil {
+			t.Fatalf("expected %q to be deleted", name)
+		}
+	}
+
+	err = riofs.Delete(r, "obj3")
+	if !errors.Is(err, riofs.ErrReadOnly) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, riofs.ErrReadOnly)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "update.root")
+
+	w, err := riofs.Create(fname, riofs.WithoutCompression())
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	for _, name := range []string{"obj1", "dir/obj2", "big"} {
+		err = riofs.Dir(w).Put(name, rbase.NewObjString(name))
+		if err != nil {
+			t.Fatalf("could not put %q: %+v", name, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	big := strings.Repeat("0123456789", 100)
+	update := func(f func(f *riofs.File) error) int64 {
+		t.Helper()
+		u, err := riofs.Update(fname)
+		if err != nil {
+			t.Fatalf("could not open file for update: %+v", err)
+		}
+		defer u.Close()
+
+		err = f(u)
+		if err != nil {
+			t.Fatalf("could not update file: %+v", err)
+		}
+
+		err = u.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		fi, err := os.Stat(fname)
+		if err != nil {
+			t.Fatalf("could not stat file: %+v", err)
+		}
+		return fi.Size()
+	}
+
+	check := func(want map[string]string) {
+		t.Helper()
+		r, err := riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer r.Close()
+
+		for name, v := range want {
+			o, err := riofs.Dir(r).Get(name)
+			switch {
+			case v == "":
+				if err == nil {
+					t.Fatalf("expected %q to be deleted", name)
+				}
+				continue
+			case err != nil:
+				t.Fatalf("could not get %q: %+v", name, err)
+			}
+			if got, want := o.(*rbase.ObjString).String(), v; got != want {
+				t.Fatalf("invalid value for %q: got=%q, want=%q", name, got, want)
+			}
+		}
+
+		var n int
+		for _, k := range r.Keys() {
+			if k.Name() == "obj1" {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Fatalf("invalid number of cycles for obj1: got=%d, want=1", n)
+		}
+	}
+
+	update(func(f *riofs.File) error {
+		err := riofs.Replace(f, "obj1", rbase.NewObjString("obj1-v2"))
+		if err != nil {
+			return err
+		}
+		err = riofs.Dir(f).Put("dir/obj3", rbase.NewObjString("obj3"))
+		if err != nil {
+			return err
+		}
+		err = riofs.Delete(f, "dir/obj2")
+		if err != nil {
+			return err
+		}
+		_, err = riofs.Dir(f).Mkdir("dir/sub")
+		if err != nil {
+			return err
+		}
+		return riofs.Dir(f).Put("dir/sub/obj4", rbase.NewObjString("obj4"))
+	})
+
+	check(map[string]string{
+		"obj1":         "obj1-v2",
+		"dir/obj2":     "",
+		"dir/obj3":     "obj3",
+		"dir/sub/obj4": "obj4",
+		"big":          "big",
+	})
+
+	replace := func(f *riofs.File) error {
+		return riofs.Replace(f, "big", rbase.NewObjString(big))
+	}
+	size := update(replace)
+	if sz := update(replace); sz > size {
+		size = sz
+	}
+	for i := 0; i < 5; i++ {
+		// the records released by the previous updates should be reused.
+		if got := update(replace); got > size {
+			t.Fatalf("invalid file size (iter=%d): got=%d, want<=%d", i, got, size)
+		}
+	}
+
+	check(map[string]string{
+		"obj1":         "obj1-v2",
+		"dir/obj3":     "obj3",
+		"dir/sub/obj4": "obj4",
+		"big":          big,
+	})
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	err = riofs.Replace(r, "obj1", rbase.NewObjString("obj1-v3"))
+	if !errors.Is(err, riofs.ErrReadOnly) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, riofs.ErrReadOnly)
+	}
+
+	_, err = riofs.Update(filepath.Join(tmp, "missing.root"))
+	if err == nil {
+		t.Fatalf("expected an error updating a missing file")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "purge.root")
+
+	w, err := riofs.Create(fname, riofs.WithoutCompression())
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	big := func(i int) root.Object {
+		return rbase.NewObjString(strings.Repeat(fmt.Sprintf("%d", i%10), 10000))
+	}
+	for i := 1; i <= 4; i++ {
+		err = w.Put("obj", big(i))
+		if err != nil {
+			t.Fatalf("could not put obj;%d: %+v", i, err)
+		}
+	}
+	for i, name := range []string{"dir/obj", "dir/sub/obj", "other"} {
+		err = riofs.Dir(w).Put(name, big(i))
+		if err != nil {
+			t.Fatalf("could not put %q: %+v", name, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	fi, err := os.Stat(fname)
+	if err != nil {
+		t.Fatalf("could not stat file: %+v", err)
+	}
+	size := fi.Size()
+
+	u, err := riofs.Update(fname)
+	if err != nil {
+		t.Fatalf("could not open file for update: %+v", err)
+	}
+	defer u.Close()
+
+	err = u.Purge(2)
+	if err != nil {
+		t.Fatalf("could not purge file: %+v", err)
+	}
+	err = u.Delete("dir")
+	if err != nil {
+		t.Fatalf("could not delete dir: %+v", err)
+	}
+	err = u.Delete("dir")
+	if err == nil {
+		t.Fatalf("expected an error deleting a missing directory")
+	}
+
+	err = u.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	var keys []string
+	for _, k := range r.Keys() {
+		keys = append(keys, fmt.Sprintf("%s;%d", k.Name(), k.Cycle()))
+	}
+	if got, want := keys, []string{"obj;3", "obj;4", "other;1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys:\ngot= %q\nwant=%q", got, want)
+	}
+
+	err = r.Purge(1)
+	if !errors.Is(err, riofs.ErrReadOnly) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, riofs.ErrReadOnly)
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	// the records released by Purge and Delete should be reused.
+	u, err = riofs.Update(fname)
+	if err != nil {
+		t.Fatalf("could not open file for update: %+v", err)
+	}
+	defer u.Close()
+
+	for i := 0; i < 3; i++ {
+		err = u.Put(fmt.Sprintf("new-%d", i), big(i))
+		if err != nil {
+			t.Fatalf("could not put new-%d: %+v", i, err)
+		}
+	}
+
+	err = u.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	fi, err = os.Stat(fname)
+	if err != nil {
+		t.Fatalf("could not stat file: %+v", err)
+	}
+	if got := fi.Size(); got > size {
+		t.Fatalf("invalid file size: got=%d, want<=%d", got, size)
+	}
+}
+
+func TestMkdirNested(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "mkdir-nested.root")
+
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer w.Close()
+
+	_, err = w.Mkdir("a/b/c")
+	if err != nil {
+		t.Fatalf("could not create a/b/c: %+v", err)
+	}
+	_, err = w.Mkdir("a/b/d")
+	if err != nil {
+		t.Fatalf("could not create a/b/d: %+v", err)
+	}
+	_, err = w.Mkdir("a/b/c")
+	if err == nil {
+		t.Fatalf("expected an error creating an existing directory")
+	}
+
+	err = riofs.Dir(w).Put("a/b/c/obj", rbase.NewObjString("hello"))
+	if err != nil {
+		t.Fatalf("could not put a/b/c/obj: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	for _, name := range []string{"a", "a/b", "a/b/c", "a/b/d"} {
+		o, err := riofs.Dir(r).Get(name)
+		if err != nil {
+			t.Fatalf("could not get %q: %+v", name, err)
+		}
+		if _, ok := o.(riofs.Directory); !ok {
+			t.Fatalf("%q is not a directory (%T)", name, o)
+		}
+	}
+
+	o, err := riofs.Dir(r).Get("a/b/c/obj")
+	if err != nil {
+		t.Fatalf("could not get a/b/c/obj: %+v", err)
+	}
+	if got, want := o.(*rbase.ObjString).String(), "hello"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+}
+
+func TestMove(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "move.root")
+
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer w.Close()
+
+	for _, name := range []string{"obj1", "obj2", "obj2", "obj3", "dir/obj4"} {
+		err = riofs.Dir(w).Put(name, rbase.NewObjString(name))
+		if err != nil {
+			t.Fatalf("could not put %q: %+v", name, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		src, dst string
+	}{
+		{"obj1", "renamed"},
+		{"obj2", "dir"},
+		{"obj3", "x/y/obj"},
+		{"dir/obj4", "/"},
+	} {
+		err = w.Move(tc.src, tc.dst)
+		if err != nil {
+			t.Fatalf("could not move %q to %q: %+v", tc.src, tc.dst, err)
+		}
+	}
+
+	err = w.Move("dir", "other")
+	if err == nil {
+		t.Fatalf("expected an error moving a directory")
+	}
+	err = w.Move("missing", "other")
+	if err == nil {
+		t.Fatalf("expected an error moving a missing object")
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{"renamed", "obj1"},
+		{"dir/obj2", "obj2"},
+		{"x/y/obj", "obj3"},
+		{"obj4", "dir/obj4"},
+	} {
+		o, err := riofs.Dir(r).Get(tc.name)
+		if err != nil {
+			t.Fatalf("could not get %q: %+v", tc.name, err)
+		}
+		if got := o.(*rbase.ObjString).String(); got != tc.want {
+			t.Fatalf("invalid value for %q: got=%q, want=%q", tc.name, got, tc.want)
+		}
+	}
+
+	for _, name := range []string{"obj1", "obj2", "obj3", "dir/obj4"} {
+		_, err := riofs.Dir(r).Get(name)
+		if err == nil {
+			t.Fatalf("expected %q to be moved", name)
+		}
+	}
+
+	err = r.Move("renamed", "other")
+	if !errors.Is(err, riofs.ErrReadOnly) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, riofs.ErrReadOnly)
+	}
+}
+
+type goPos struct {
+	X float64 `groot:"x"`
+	Y float64 `groot:"y"`
+}
+
+type goEvent struct {
+	ID   int32            `groot:"id"`
+	Name string           `groot:"name"`
+	Pos  goPos            `groot:"pos"`
+	Hits []goPos          `groot:"hits"`
+	Tags map[string]int32 `groot:"tags"`
+}
+
+func TestPutGoStruct(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "go-struct.root")
+
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer w.Close()
+
+	want := goEvent{
+		ID:   42,
+		Name: "evt-42",
+		Pos:  goPos{1, 2},
+		Hits: []goPos{{3, 4}, {5, 6}},
+		Tags: map[string]int32{"a": 1, "b": 2},
+	}
+	obj, err := rdict.ObjectOf(&want)
+	if err != nil {
+		t.Fatalf("could not create object: %+v", err)
+	}
+
+	err = w.Put("evt", obj)
+	if err != nil {
+		t.Fatalf("could not put object: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	var names []string
+	for _, si := range r.StreamerInfos() {
+		names = append(names, si.Name())
+	}
+	for _, name := range []string{"goEvent", "goPos"} {
+		found := false
+		for _, v := range names {
+			if v == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("could not find streamer for %q in %q", name, names)
+		}
+	}
+
+	v, err := r.Get("evt")
+	if err != nil {
+		t.Fatalf("could not get object: %+v", err)
+	}
+	if got, want := v.Class(), "goEvent"; got != want {
+		t.Fatalf("invalid class: got=%q, want=%q", got, want)
+	}
+
+	rv := reflect.ValueOf(v.(*rdict.Object).Value()).Elem()
+	got := goEvent{
+		ID:   int32(rv.FieldByName("ROOT_id").Int()),
+		Name: rv.FieldByName("ROOT_name").String(),
+		Tags: make(map[string]int32),
+	}
+	pos := func(v reflect.Value) goPos {
+		return goPos{v.FieldByName("ROOT_x").Float(), v.FieldByName("ROOT_y").Float()}
+	}
+	got.Pos = pos(rv.FieldByName("ROOT_pos"))
+	hits := rv.FieldByName("ROOT_hits")
+	for i := 0; i < hits.Len(); i++ {
+		got.Hits = append(got.Hits, pos(hits.Index(i)))
+	}
+	tags := rv.FieldByName("ROOT_tags")
+	for _, k := range tags.MapKeys() {
+		got.Tags[k.String()] = int32(tags.MapIndex(k).Int())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid round-trip:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
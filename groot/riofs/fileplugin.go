@@ -7,11 +7,9 @@ package riofs
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"sort"
-	"strings"
 	"sync"
-
-	"github.com/go-mmap/mmap"
 )
 
 var drivers = struct {
@@ -53,6 +51,10 @@ func openFile(path string) (Reader, error) {
 	drivers.RLock()
 	defer drivers.RUnlock()
 
+	if path == "-" {
+		return NewStreamReader(os.Stdin), nil
+	}
+
 	if f, err := openLocalFile(path); err == nil {
 		return f, nil
 	}
@@ -68,11 +70,6 @@ func openFile(path string) (Reader, error) {
 	return nil, fmt.Errorf("riofs: no ROOT plugin to open [%s] (scheme=%s)", path, scheme)
 }
 
-func openLocalFile(path string) (Reader, error) {
-	path = strings.TrimPrefix(path, "file://")
-	return mmap.Open(path)
-}
-
 func init() {
 	Register("file", openLocalFile)
 }
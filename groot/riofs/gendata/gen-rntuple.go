@@ -0,0 +1,92 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"go-hep.org/x/hep/groot/internal/rtests"
+)
+
+var (
+	root = flag.String("f", "rntuple-v1.root", "output ROOT file")
+)
+
+func main() {
+	flag.Parse()
+
+	out, err := rtests.RunCxxROOT("genrntuple", []byte(script), *root)
+	if err != nil {
+		log.Fatalf("could not run ROOT macro:\noutput:\n%v\nerror: %+v", string(out), err)
+	}
+}
+
+// script needs ROOT >= 6.34, the first release with the RNTuple 1.0 format.
+const script = `
+#include <ROOT/RNTupleModel.hxx>
+#include <ROOT/RNTupleWriteOptions.hxx>
+#include <ROOT/RNTupleWriter.hxx>
+
+#include <array>
+#include <cstdint>
+#include <string>
+#include <vector>
+
+using ROOT::Experimental::RNTupleModel;
+using ROOT::Experimental::RNTupleWriteOptions;
+using ROOT::Experimental::RNTupleWriter;
+
+void genrntuple(const char* fname) {
+	auto model = RNTupleModel::Create();
+
+	auto b   = model->MakeField<bool>("b");
+	auto i8  = model->MakeField<std::int8_t>("i8");
+	auto i16 = model->MakeField<std::int16_t>("i16");
+	auto i32 = model->MakeField<std::int32_t>("i32");
+	auto i64 = model->MakeField<std::int64_t>("i64");
+	auto u8  = model->MakeField<std::uint8_t>("u8");
+	auto u16 = model->MakeField<std::uint16_t>("u16");
+	auto u32 = model->MakeField<std::uint32_t>("u32");
+	auto u64 = model->MakeField<std::uint64_t>("u64");
+	auto f32 = model->MakeField<float>("f32");
+	auto f64 = model->MakeField<double>("f64");
+	auto str = model->MakeField<std::string>("str");
+	auto arr = model->MakeField<std::array<double, 3>>("arr");
+	auto vec = model->MakeField<std::vector<float>>("vec");
+
+	RNTupleWriteOptions opts;
+	opts.SetCompression(505); // zstd, level 5
+
+	auto w = RNTupleWriter::Recreate(std::move(model), "ntpl", fname, opts);
+
+	int evtmax = 10;
+	for (int i = 0; i != evtmax; i++) {
+		*b   = i%2 == 0;
+		*i8  = -i;
+		*i16 = -i;
+		*i32 = -i;
+		*i64 = -i;
+		*u8  = i;
+		*u16 = i;
+		*u32 = i;
+		*u64 = i;
+		*f32 = i;
+		*f64 = i;
+		*str = "str-" + std::to_string(i);
+		*arr = {double(i), double(i+1), double(i+2)};
+		*vec = std::vector<float>(i, float(i));
+		w->Fill();
+		if (i == 4) {
+			w->CommitCluster();
+		}
+	}
+	w.reset();
+
+	exit(0);
+}
+`
@@ -9,15 +9,21 @@ import (
 	"io"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"go-hep.org/x/hep/groot/internal/rcompress"
 	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
 )
 
+// kmuDetached protects the payloads and types cached by keys that are not
+// attached to any file.
+var kmuDetached sync.RWMutex
+
 // noKeyError is the error returned when a riofs.Key could not be found.
 type noKeyError struct {
 	key string
@@ -97,12 +103,12 @@ func newKey(dir *tdirectoryFile, name, title, class string, objlen int32, f *Fil
 	k.keylen = k.sizeof()
 	// FIXME(sbinet): this assumes the key-payload isn't compressed.
 	// if the key's payload is actually compressed, we introduce a hole
-	// with the f.setEnd call below.
+	// with the f.alloc call below.
 	k.nbytes = k.objlen + k.keylen
 	eof := f.end
 	if objlen > 0 {
-		k.seekkey = eof
-		err := f.setEnd(k.seekkey + int64(k.nbytes))
+		var err error
+		k.seekkey, err = f.alloc(k.nbytes)
 		if err != nil {
 			panic(err)
 		}
@@ -127,7 +133,19 @@ func NewKey(dir Directory, name, title, class string, cycle int16, obj []byte, f
 	if dir != nil {
 		d = dir.(*tdirectoryFile)
 	}
-	return newKeyFromBuf(d, name, title, class, cycle, obj, f)
+	return newKeyFromBuf(d, name, title, class, cycle, obj, f, f.compression)
+}
+
+// NewKeyWithCompression creates a new key from the provided serialized object buffer.
+// NewKeyWithCompression puts the key and its payload at the end of the provided file f.
+// Unlike NewKey, the object buffer is compressed with the provided compression
+// settings (algorithm*100 + level) instead of the ones from the file.
+func NewKeyWithCompression(dir Directory, name, title, class string, cycle int16, obj []byte, f *File, compress int32) (Key, error) {
+	var d *tdirectoryFile
+	if dir != nil {
+		d = dir.(*tdirectoryFile)
+	}
+	return newKeyFromBuf(d, name, title, class, cycle, obj, f, compress)
 }
 
 func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object, f *File) (Key, error) {
@@ -161,7 +179,6 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 		class:    class,
 		name:     name,
 		title:    title,
-		seekkey:  f.end,
 		seekpdir: dir.seekdir,
 		obj:      obj,
 		otyp:     reflect.TypeOf(obj),
@@ -177,15 +194,15 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 	}
 	k.nbytes = k.keylen + int32(len(k.buf))
 
-	err = f.setEnd(k.seekkey + int64(k.nbytes))
+	k.seekkey, err = f.alloc(k.nbytes)
 	if err != nil {
-		return k, fmt.Errorf("riofs: could not update ROOT file end: %w", err)
+		return k, fmt.Errorf("riofs: could not reserve space for key %q: %w", name, err)
 	}
 
 	return k, nil
 }
 
-func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16, buf []byte, f *File) (Key, error) {
+func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16, buf []byte, f *File, compress int32) (Key, error) {
 	var err error
 	if dir == nil {
 		dir = &f.dir
@@ -204,7 +221,6 @@ func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16,
 		class:    class,
 		name:     name,
 		title:    title,
-		seekkey:  f.end,
 		seekpdir: dir.seekdir,
 		parent:   dir,
 	}
@@ -212,15 +228,15 @@ func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16,
 		k.rvers += 1000
 	}
 
-	k.buf, err = rcompress.Compress(nil, buf, k.f.compression)
+	k.buf, err = rcompress.Compress(nil, buf, compress)
 	if err != nil {
 		return k, fmt.Errorf("riofs: could not compress object %s for key %q: %w", class, name, err)
 	}
 	k.nbytes = k.keylen + int32(len(k.buf))
 
-	err = f.setEnd(k.seekkey + int64(k.nbytes))
+	k.seekkey, err = f.alloc(k.nbytes)
 	if err != nil {
-		return k, fmt.Errorf("riofs: could not update ROOT file end: %w", err)
+		return k, fmt.Errorf("riofs: could not reserve space for key %q: %w", name, err)
 	}
 
 	return k, nil
@@ -268,6 +284,56 @@ func NewKeyForBasketInternal(dir Directory, name, title, class string, cycle int
 	return k
 }
 
+// NewKeyFromBasketInternal creates a new key holding the provided, already
+// compressed, payload of the src basket key.
+// The new key has the name, title, class, cycle and uncompressed payload
+// length of the src key, and is put at the end of the provided file f.
+// NewKeyFromBasketInternal returns an error if the header of the new key
+// would not have the same length than the src one.
+//
+// This is needed for Tree/Branch/Basket fast merging.
+//
+// DO NOT USE.
+func NewKeyFromBasketInternal(src *Key, buf []byte, f *File) (Key, error) {
+	var (
+		dir    = &f.dir
+		keylen = keylenFor(src.name, src.title, src.class, dir, f.end)
+	)
+	if keylen != src.keylen {
+		return Key{}, fmt.Errorf(
+			"riofs: could not copy key %q: invalid key length (got=%d, want=%d)",
+			src.name, keylen, src.keylen,
+		)
+	}
+
+	k := Key{
+		f:        f,
+		nbytes:   keylen + int32(len(buf)),
+		rvers:    rvers.Key,
+		keylen:   keylen,
+		objlen:   src.objlen,
+		datetime: src.datetime,
+		cycle:    src.cycle,
+		class:    src.class,
+		name:     src.name,
+		title:    src.title,
+		seekpdir: dir.seekdir,
+		buf:      buf,
+		parent:   dir,
+	}
+	if f.IsBigFile() {
+		k.rvers += 1000
+	}
+
+	var err error
+	k.seekkey, err = f.alloc(k.nbytes)
+	if err != nil {
+		return k, fmt.Errorf("riofs: could not reserve space for key %q: %w", src.name, err)
+	}
+
+	return k, nil
+}
+
 // KeyFromDir creates a new empty key (with no associated payload object)
 // with provided name and title, and the expected object type name.
 // The key will be held by the provided directory.
@@ -321,14 +387,52 @@ func (k *Key) SetBuffer(buf []byte) { k.buf = buf; k.objlen = int32(len(buf)) }
 // ObjectType returns nil if the Key's payload type is not known
 // to the registry of groot.
 func (k *Key) ObjectType() reflect.Type {
-	if k.otyp != nil {
-		return k.otyp
-	}
-	if !rtypes.Factory.HasKey(k.class) {
+	mu := k.mu()
+	mu.RLock()
+	otyp := k.otyp
+	mu.RUnlock()
+	if otyp != nil {
+		return otyp
+	}
+
+	switch fct, ok := k.versionedFactory(); {
+	case ok:
+		otyp = fct().Type()
+	case rtypes.Factory.HasKey(k.class):
+		otyp = rtypes.Factory.Get(k.class)().Type()
+	default:
 		return nil
 	}
-	k.otyp = rtypes.Factory.Get(k.class)().Type()
-	return k.otyp
+
+	mu.Lock()
+	defer mu.Unlock()
+	k.otyp = otyp
+	return otyp
+}
+
+// mu returns the lock protecting the payload and type cached by the Key.
+func (k *Key) mu() *sync.RWMutex {
+	if k.f == nil {
+		return &kmuDetached
+	}
+	return &k.f.kmu
+}
+
+// versionedFactory returns the factory function registered for the
+// Key's class and the class version recorded in the file, if any.
+func (k *Key) versionedFactory() (rtypes.FactoryFct, bool) {
+	if k.f == nil {
+		return nil, false
+	}
+	si, ok := k.f.sidx[k.class]
+	if !ok {
+		return nil, false
+	}
+	vers := si.ClassVersion()
+	if !rtypes.Factory.HasVersion(k.class, vers) {
+		return nil, false
+	}
+	return rtypes.Factory.GetVersion(k.class, vers), true
 }
 
 // Value returns the data corresponding to the Key's value
@@ -342,8 +446,12 @@ func (k *Key) Value() interface{} {
 
 // Object returns the (ROOT) object corresponding to the Key's value.
 func (k *Key) Object() (root.Object, error) {
-	if k.obj != nil {
-		return k.obj, nil
+	mu := k.mu()
+	mu.RLock()
+	obj := k.obj
+	mu.RUnlock()
+	if obj != nil {
+		return obj, nil
 	}
 
 	buf, err := k.Bytes()
@@ -351,13 +459,16 @@ func (k *Key) Object() (root.Object, error) {
 		return nil, fmt.Errorf("riofs: could not load key payload: %w", err)
 	}
 
-	fct := rtypes.Factory.Get(k.class)
+	fct, ok := k.versionedFactory()
+	if !ok {
+		fct = rtypes.Factory.Get(k.class)
+	}
 	if fct == nil {
 		return nil, fmt.Errorf("riofs: no registered factory for class %q (key=%q)", k.class, k.Name())
 	}
 
 	v := fct()
-	obj, ok := v.Interface().(root.Object)
+	obj, ok = v.Interface().(root.Object)
 	if !ok {
 		return nil, fmt.Errorf("riofs: class %q does not implement root.Object (key=%q)", k.class, k.Name())
 	}
@@ -367,7 +478,7 @@ func (k *Key) Object() (root.Object, error) {
 		return nil, fmt.Errorf("riofs: class %q does not implement rbytes.Unmarshaler (key=%q)", k.class, k.Name())
 	}
 
-	err = vv.UnmarshalROOT(rbytes.NewRBuffer(buf, nil, uint32(k.keylen), k.f))
+	err = rdict.Unmarshal(rbytes.NewRBuffer(buf, nil, uint32(k.keylen), k.f), vv)
 	if err != nil {
 		return nil, fmt.Errorf("riofs: could not unmarshal key payload: %w", err)
 	}
@@ -381,14 +492,24 @@ func (k *Key) Object() (root.Object, error) {
 		dir.dir.named.SetName(k.Name())
 		dir.dir.named.SetTitle(k.Name())
 		dir.classname = k.class
-		err = dir.readKeys()
+		err = dir.initKeys()
 		if err != nil {
 			return nil, err
 		}
+		if p, ok := k.parent.(*tdirectoryFile); ok && k.f != nil && k.f.w != nil {
+			// make sure the sub-directory is saved when the file is
+			// opened for update.
+			p.dirs = append(p.dirs, dir)
+		}
 	}
 
-	k.obj = obj
-	return obj, nil
+	mu.Lock()
+	defer mu.Unlock()
+	if k.obj == nil {
+		// another goroutine may have loaded the payload concurrently.
+		k.obj = obj
+	}
+	return k.obj, nil
 }
 
 // Bytes returns the buffer of bytes corresponding to the Key's value
@@ -6,6 +6,8 @@ package riofs
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -14,6 +16,7 @@ import (
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
 )
 
@@ -184,3 +187,57 @@ func newTestKeyFrom(dir Directory, obj root.Object, wbuf *rbytes.WBuffer) (Key,
 	}
 	return k, nil
 }
+
+type versionedObjString struct {
+	rbase.ObjString
+}
+
+func TestKeyVersionedFactory(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "versioned.root")
+	w, err := Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	err = w.Put("str", rbase.NewObjString("hello"))
+	if err != nil {
+		t.Fatalf("could not write object: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	vers := int(rvers.ObjString)
+	rtypes.Factory.AddVersion("TObjString", vers, func() reflect.Value {
+		return reflect.ValueOf(&versionedObjString{})
+	})
+	t.Cleanup(func() { rtypes.Factory.DelVersion("TObjString", vers) })
+
+	f, err := Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("str")
+	if err != nil {
+		t.Fatalf("could not read object: %+v", err)
+	}
+
+	str, ok := obj.(*versionedObjString)
+	if !ok {
+		t.Fatalf("invalid object type: got=%T, want=%T", obj, str)
+	}
+
+	if got, want := str.String(), "hello"; got != want {
+		t.Fatalf("invalid object value: got=%q, want=%q", got, want)
+	}
+}
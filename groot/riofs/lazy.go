@@ -0,0 +1,120 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithLazyKeys configures a ROOT file opened for reading to load the list
+// of keys of its directories on demand, the first time a directory is
+// queried for its content, instead of when the directory is opened.
+//
+// Opening files holding hundreds of thousands of keys is then fast, and
+// RangeKeys can be used to iterate over such keys without loading them
+// all in memory.
+// Errors encountered while loading the list of keys of a directory are
+// reported by the Get method of that directory.
+//
+// WithLazyKeys is ignored by files opened for update.
+func WithLazyKeys() FileOption {
+	return func(f *File) error {
+		f.lazy = true
+		return nil
+	}
+}
+
+// lazyKeys handles the on-demand loading of the keys of a directory.
+type lazyKeys struct {
+	mu     sync.Mutex
+	loaded bool  // whether the list of keys has been loaded
+	err    error // error encountered while loading the list of keys
+}
+
+// isLazy returns whether the keys of the sub-directories of the file
+// should be loaded on demand.
+func (f *File) isLazy() bool {
+	return f != nil && f.lazy && f.w == nil
+}
+
+// initKeys loads the list of keys of the directory, unless the file has
+// been configured to load them on demand.
+func (dir *tdirectoryFile) initKeys() error {
+	if dir.file.isLazy() {
+		dir.lazy = new(lazyKeys)
+		return nil
+	}
+	return dir.readKeys()
+}
+
+// loadKeys loads the list of keys of the directory, if they have not been
+// loaded yet.
+func (dir *tdirectoryFile) loadKeys() error {
+	if dir.lazy == nil {
+		return nil
+	}
+
+	dir.lazy.mu.Lock()
+	defer dir.lazy.mu.Unlock()
+	if !dir.lazy.loaded {
+		dir.lazy.loaded = true
+		err := dir.readKeys()
+		if err != nil {
+			dir.keys = nil
+			dir.lazy.err = fmt.Errorf("riofs: could not read keys of directory %q: %w", dir.Name(), err)
+		}
+	}
+	return dir.lazy.err
+}
+
+// loaded returns whether the list of keys of the directory is available
+// in memory.
+func (dir *tdirectoryFile) loaded() bool {
+	if dir.lazy == nil {
+		return true
+	}
+	dir.lazy.mu.Lock()
+	defer dir.lazy.mu.Unlock()
+	return dir.lazy.loaded
+}
+
+// RangeKeys calls f sequentially for each key held by the provided
+// directory. If f returns false, RangeKeys stops the iteration.
+//
+// For directories of files opened with WithLazyKeys whose keys have not
+// been loaded yet, keys are decoded one at a time from the file, without
+// retaining the whole list of keys in memory.
+func RangeKeys(dir Directory, f func(k Key) bool) error {
+	switch d := dir.(type) {
+	case *File:
+		dir = &d.dir
+	case *recDir:
+		return RangeKeys(d.dir, f)
+	}
+
+	if d, ok := dir.(*tdirectoryFile); ok && !d.loaded() {
+		return d.scanKeys(f)
+	}
+
+	for _, k := range dir.Keys() {
+		if !f(k) {
+			break
+		}
+	}
+	return nil
+}
+
+// scanKeys decodes the keys of the directory one at a time from the file,
+// and calls f with each of them, until f returns false.
+func (dir *tdirectoryFile) scanKeys(f func(k Key) bool) error {
+	err := dir.decodeKeys(func(k *Key) bool {
+		return f(*k)
+	})
+	if err != nil {
+		return fmt.Errorf("riofs: could not read keys of directory %q: %w", dir.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+)
+
+func TestLazyKeys(t *testing.T) {
+	const nkeys = 1000
+
+	fname := filepath.Join(t.TempDir(), "lazy.root")
+	w, err := Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := w.Mkdir("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < nkeys; i++ {
+		name := fmt.Sprintf("key-%03d", i)
+		err = w.Put(name, rbase.NewObjString(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = sub.Put(name, rbase.NewObjString("sub-"+name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err := Open(fname, WithLazyKeys())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if f.dir.loaded() {
+		t.Fatalf("keys loaded at open")
+	}
+
+	var names []string
+	err = RangeKeys(f, func(k Key) bool {
+		names = append(names, k.Name())
+		return len(names) < 10
+	})
+	if err != nil {
+		t.Fatalf("could not range over keys: %+v", err)
+	}
+	if got, want := len(names), 10; got != want {
+		t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+	}
+	if got, want := names[1], "key-000"; got != want {
+		t.Fatalf("invalid key name: got=%q, want=%q", got, want)
+	}
+
+	n := 0
+	err = RangeKeys(f, func(k Key) bool {
+		n++
+		return true
+	})
+	if err != nil {
+		t.Fatalf("could not range over keys: %+v", err)
+	}
+	if got, want := n, nkeys+1; got != want {
+		t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+	}
+
+	if f.dir.loaded() {
+		t.Fatalf("keys loaded by RangeKeys")
+	}
+
+	obj, err := f.Get("key-042")
+	if err != nil {
+		t.Fatalf("could not get key: %+v", err)
+	}
+	if got, want := obj.(*rbase.ObjString).String(), "key-042"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+
+	if !f.dir.loaded() {
+		t.Fatalf("keys not loaded by Get")
+	}
+	if got, want := len(f.Keys()), nkeys+1; got != want {
+		t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+	}
+
+	obj, err = f.Get("dir")
+	if err != nil {
+		t.Fatalf("could not get sub-directory: %+v", err)
+	}
+	dir := obj.(*tdirectoryFile)
+	if dir.loaded() {
+		t.Fatalf("keys of sub-directory loaded at open")
+	}
+
+	n = 0
+	err = RangeKeys(dir, func(k Key) bool {
+		n++
+		return true
+	})
+	if err != nil {
+		t.Fatalf("could not range over keys: %+v", err)
+	}
+	if got, want := n, nkeys; got != want {
+		t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+	}
+
+	obj, err = Dir(f).Get("dir/key-999")
+	if err != nil {
+		t.Fatalf("could not get key: %+v", err)
+	}
+	if got, want := obj.(*rbase.ObjString).String(), "sub-key-999"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+}
@@ -4,7 +4,11 @@
 
 package riofs
 
-import "bytes"
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
 
 // RMemFile creates a simple in-memory read-only ROOT file
 // from the provided slice of bytes.
@@ -22,6 +26,76 @@ func (r *memFile) Read(p []byte) (int, error)                   { return r.r.Rea
 func (r *memFile) ReadAt(p []byte, off int64) (int, error)      { return r.r.ReadAt(p, off) }
 func (r *memFile) Seek(offset int64, whence int) (int64, error) { return r.r.Seek(offset, whence) }
 
+// MemFile is a ROOT file created entirely in memory, the equivalent of
+// ROOT's TMemFile.
+//
+// Objects are written to a MemFile like they would to a regular ROOT file.
+// Once the MemFile is closed, its content is a complete ROOT file, that can
+// be retrieved with Bytes or WriteTo, e.g. to send it over the network or
+// to read it back with RMemFile.
+type MemFile struct {
+	*File
+	buf *wmemFile
+}
+
+// NewMemFile creates the named ROOT file in memory, for writing.
+func NewMemFile(name string, opts ...FileOption) (*MemFile, error) {
+	buf := new(wmemFile)
+	f, err := newWriter(name, buf, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &MemFile{File: f, buf: buf}, nil
+}
+
+// Bytes returns the content of the in-memory ROOT file.
+// The content is only a complete ROOT file once the file has been closed.
+// The returned slice is valid until the next write to the file.
+func (f *MemFile) Bytes() []byte {
+	return f.buf.p
+}
+
+// WriteTo writes the content of the in-memory ROOT file to w.
+// The content is only a complete ROOT file once the file has been closed.
+func (f *MemFile) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(f.buf.p)
+	if err != nil {
+		return int64(n), fmt.Errorf("riofs: could not write in-memory file %q: %w", f.id, err)
+	}
+	return int64(n), nil
+}
+
+// wmemFile is a simple in-memory write-only ROOT file.
+type wmemFile struct {
+	p   []byte
+	pos int64
+}
+
+func (w *wmemFile) Close() error { return nil }
+
+func (w *wmemFile) Write(p []byte) (int, error) {
+	n, err := w.WriteAt(p, w.pos)
+	w.pos += int64(n)
+	return n, err
+}
+
+func (w *wmemFile) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("riofs: invalid in-memory write offset %d", off)
+	}
+	if end := off + int64(len(p)); end > int64(len(w.p)) {
+		if end > int64(cap(w.p)) {
+			buf := make([]byte, end, 2*end)
+			copy(buf, w.p)
+			w.p = buf
+		}
+		w.p = w.p[:end]
+	}
+	return copy(w.p[off:], p), nil
+}
+
 var (
-	_ Reader = (*memFile)(nil)
+	_ Reader      = (*memFile)(nil)
+	_ Writer      = (*wmemFile)(nil)
+	_ io.WriterTo = (*MemFile)(nil)
 )
@@ -5,6 +5,7 @@
 package riofs
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -72,3 +73,104 @@ func TestRMemFile(t *testing.T) {
 		t.Fatalf("error closing file: %v", err)
 	}
 }
+
+func TestMemFile(t *testing.T) {
+	w, err := NewMemFile("objstring.root", WithoutCompression())
+	if err != nil {
+		t.Fatalf("could not create in-memory file: %+v", err)
+	}
+
+	var (
+		kname = "my-key"
+		want  = rbase.NewObjString("Hello World from Go-HEP!")
+	)
+
+	dir, err := Dir(w).Mkdir("dir")
+	if err != nil {
+		t.Fatalf("could not create directory: %+v", err)
+	}
+
+	err = dir.Put(kname, want)
+	if err != nil {
+		t.Fatalf("could not put object: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close in-memory file: %+v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := w.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("could not write in-memory file: %+v", err)
+	}
+	if got, want := n, int64(len(w.Bytes())); got != want {
+		t.Fatalf("invalid number of bytes written: got=%d, want=%d", got, want)
+	}
+	if !bytes.Equal(buf.Bytes(), w.Bytes()) {
+		t.Fatalf("invalid in-memory file content")
+	}
+
+	fname := filepath.Join(t.TempDir(), "objstring.root")
+	err = os.WriteFile(fname, w.Bytes(), 0644)
+	if err != nil {
+		t.Fatalf("could not write file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		open func() (*File, error)
+	}{
+		{
+			name: "rmem",
+			open: func() (*File, error) { return NewReader(RMemFile(w.Bytes())) },
+		},
+		{
+			name: "file",
+			open: func() (*File, error) { return Open(fname) },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := tc.open()
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer r.Close()
+
+			if got, want := r.Name(), "objstring.root"; got != want {
+				t.Fatalf("invalid file name: got=%q, want=%q", got, want)
+			}
+
+			obj, err := Dir(r).Get("dir/" + kname)
+			if err != nil {
+				t.Fatalf("could not get object: %+v", err)
+			}
+
+			if got := obj.(root.ObjString); !reflect.DeepEqual(got, want) {
+				t.Fatalf("error reading back objstring.\ngot = %#v\nwant = %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestWMemFile(t *testing.T) {
+	var w wmemFile
+
+	_, err := w.WriteAt([]byte("world"), 6)
+	if err != nil {
+		t.Fatalf("could not write-at: %+v", err)
+	}
+	_, err = w.Write([]byte("hello "))
+	if err != nil {
+		t.Fatalf("could not write: %+v", err)
+	}
+	if got, want := string(w.p), "hello world"; got != want {
+		t.Fatalf("invalid content: got=%q, want=%q", got, want)
+	}
+
+	_, err = w.WriteAt([]byte("x"), -1)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
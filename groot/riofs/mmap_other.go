@@ -0,0 +1,35 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package riofs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-mmap/mmap"
+)
+
+// openLocalFile opens a memory-mapped local file.
+// Access advices are not supported on this platform.
+// Files are read with regular reads on platforms without mmap.
+func openLocalFile(path string) (Reader, error) {
+	path = strings.TrimPrefix(path, "file://")
+	f, err := mmap.Open(path)
+	if err == nil {
+		return f, nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, fmt.Errorf("riofs: %q is a directory", path)
+	}
+	return os.Open(path)
+}
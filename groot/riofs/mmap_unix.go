@@ -0,0 +1,241 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package riofs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+var errMmapClosed = errors.New("riofs: mmap: closed")
+
+// mmapFile is a read-only memory-mapped local file.
+// Concurrent readers of the same file share the same mapping.
+type mmapFile struct {
+	f    *os.File
+	m    *mapping
+	data []byte
+	pos  int64
+}
+
+// mapping is a memory mapping of a local file, shared among its readers.
+type mapping struct {
+	name string
+	fi   os.FileInfo
+	data []byte
+	refs int
+}
+
+// mappings holds the memory mappings of the currently opened local files.
+var mappings = struct {
+	sync.Mutex
+	db map[string]*mapping
+}{
+	db: make(map[string]*mapping),
+}
+
+// acquire returns a memory mapping of the provided file, creating it if
+// no reader of that (unmodified) file already holds one.
+func acquire(f *os.File, fi os.FileInfo) (*mapping, error) {
+	name, err := filepath.Abs(f.Name())
+	if err != nil {
+		name = f.Name()
+	}
+
+	mappings.Lock()
+	defer mappings.Unlock()
+
+	if m, ok := mappings.db[name]; ok && sameFile(m.fi, fi) {
+		m.refs++
+		return m, nil
+	}
+
+	data, err := unix.Mmap(int(f.Fd()), 0, int(fi.Size()), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mapping{name: name, fi: fi, data: data, refs: 1}
+	// a modified file replaces the mapping of its previous version, which
+	// is released by its last reader.
+	mappings.db[name] = m
+	return m, nil
+}
+
+// release releases a reference to the provided mapping, unmapping it
+// once it is not used anymore.
+func (m *mapping) release() error {
+	mappings.Lock()
+	defer mappings.Unlock()
+
+	m.refs--
+	if m.refs > 0 {
+		return nil
+	}
+	if mappings.db[m.name] == m {
+		delete(mappings.db, m.name)
+	}
+	return unix.Munmap(m.data)
+}
+
+func sameFile(a, b os.FileInfo) bool {
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+func (m *mmapFile) Len() int {
+	return len(m.data)
+}
+
+func (m *mmapFile) Stat() (os.FileInfo, error) {
+	if m.f == nil {
+		return nil, errMmapClosed
+	}
+	return m.f.Stat()
+}
+
+func (m *mmapFile) Read(p []byte) (int, error) {
+	n, err := m.ReadAt(p, m.pos)
+	m.pos += int64(n)
+	return n, err
+}
+
+func (m *mmapFile) ReadAt(p []byte, off int64) (int, error) {
+	if m.f == nil {
+		return 0, errMmapClosed
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("riofs: mmap: invalid ReadAt offset %d", off)
+	}
+	if off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (m *mmapFile) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = m.pos + offset
+	case io.SeekEnd:
+		pos = int64(len(m.data)) + offset
+	default:
+		return 0, fmt.Errorf("riofs: mmap: invalid whence %d", whence)
+	}
+	if pos < 0 {
+		return 0, fmt.Errorf("riofs: mmap: negative position %d", pos)
+	}
+	m.pos = pos
+	return pos, nil
+}
+
+var (
+	_ Reader    = (*mmapFile)(nil)
+	_ io.Seeker = (*mmapFile)(nil)
+	_ stater    = (*mmapFile)(nil)
+	_ adviser   = (*mmapFile)(nil)
+)
+
+func openLocalFile(path string) (Reader, error) {
+	path = strings.TrimPrefix(path, "file://")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	size := fi.Size()
+	if size < 0 {
+		_ = f.Close()
+		return nil, fmt.Errorf("riofs: mmap: invalid file size %d", size)
+	}
+
+	if fi.IsDir() {
+		_ = f.Close()
+		return nil, fmt.Errorf("riofs: mmap: %q is a directory", path)
+	}
+
+	if size != int64(int(size)) {
+		// file too large to be mapped in the address space.
+		return f, nil
+	}
+
+	r := &mmapFile{f: f}
+	if size == 0 {
+		return r, nil
+	}
+
+	r.m, err = acquire(f, fi)
+	if err != nil {
+		// the file can not be memory-mapped (e.g. some network
+		// file systems): fall back to regular reads.
+		return f, nil
+	}
+	r.data = r.m.data
+
+	return r, nil
+}
+
+// advise applies the provided access advice to the mapping of the file,
+// which is shared by all its readers.
+func (m *mmapFile) advise(a Advice) error {
+	if m.f == nil {
+		return errMmapClosed
+	}
+	if len(m.data) == 0 {
+		return nil
+	}
+
+	var advice int
+	switch a {
+	case SequentialAccess:
+		advice = unix.MADV_SEQUENTIAL
+	case RandomAccess:
+		advice = unix.MADV_RANDOM
+	default:
+		advice = unix.MADV_NORMAL
+	}
+	return unix.Madvise(m.data, advice)
+}
+
+func (m *mmapFile) Close() error {
+	if m.f == nil {
+		return errMmapClosed
+	}
+
+	var err error
+	if m.m != nil {
+		err = m.m.release()
+		m.m = nil
+		m.data = nil
+	}
+
+	if e := m.f.Close(); e != nil && err == nil {
+		err = e
+	}
+	m.f = nil
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package riofs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestMmapSharedMapping(t *testing.T) {
+	const fname = "../testdata/dirs-6.14.00.root"
+
+	open := func() *mmapFile {
+		t.Helper()
+		r, err := openLocalFile(fname)
+		if err != nil {
+			t.Fatalf("could not open local file: %+v", err)
+		}
+		m, ok := r.(*mmapFile)
+		if !ok {
+			t.Fatalf("invalid local file reader type %T", r)
+		}
+		return m
+	}
+
+	r1 := open()
+	r2 := open()
+	if r1.m != r2.m {
+		t.Fatalf("readers do not share the same mapping")
+	}
+	if got, want := r1.m.refs, 2; got != want {
+		t.Fatalf("invalid number of references: got=%d, want=%d", got, want)
+	}
+
+	err := r1.Close()
+	if err != nil {
+		t.Fatalf("could not close first reader: %+v", err)
+	}
+
+	buf := make([]byte, 4)
+	_, err = r2.ReadAt(buf, 0)
+	if err != nil {
+		t.Fatalf("could not read from second reader: %+v", err)
+	}
+	if got, want := string(buf), "root"; got != want {
+		t.Fatalf("invalid magic: got=%q, want=%q", got, want)
+	}
+
+	err = r2.Close()
+	if err != nil {
+		t.Fatalf("could not close second reader: %+v", err)
+	}
+
+	mappings.Lock()
+	n := len(mappings.db)
+	mappings.Unlock()
+	if n != 0 {
+		t.Fatalf("mappings not released: %d", n)
+	}
+}
+
+func TestMmapModifiedFile(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "data.bin")
+	err := os.WriteFile(fname, []byte("hello"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r1, err := openLocalFile(fname)
+	if err != nil {
+		t.Fatalf("could not open local file: %+v", err)
+	}
+	defer r1.Close()
+
+	err = os.WriteFile(fname, []byte("hello, world"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r2, err := openLocalFile(fname)
+	if err != nil {
+		t.Fatalf("could not open local file: %+v", err)
+	}
+	defer r2.Close()
+
+	if r1.(*mmapFile).m == r2.(*mmapFile).m {
+		t.Fatalf("modified file should not share the mapping of its previous version")
+	}
+	if got, want := r2.(*mmapFile).Len(), 12; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+}
+
+func TestMmapConcurrentOpen(t *testing.T) {
+	const fname = "../testdata/dirs-6.14.00.root"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f, err := Open(fname)
+			if err != nil {
+				t.Errorf("could not open ROOT file: %+v", err)
+				return
+			}
+			defer f.Close()
+
+			if got, want := len(f.Keys()), 3; got != want {
+				t.Errorf("invalid number of keys: got=%d, want=%d", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,209 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	cacheBlkSize = 256 * 1024 // size of a cached block
+	cacheSize    = 256        // maximum number of cached blocks
+)
+
+// bcache is a reader that keeps the most recently read blocks of a remote
+// resource in memory.
+//
+// Reads are rounded to whole blocks. Missing consecutive blocks are fetched
+// together with a single read of the underlying reader.
+// Once the cache is full, the least recently used blocks are evicted.
+type bcache struct {
+	r   reader
+	len int64 // size of the remote resource
+	blk int64 // size of a block
+	max int   // maximum number of cached blocks
+
+	mu   sync.Mutex
+	lru  *list.List              // cached blocks, most recently used first
+	blks map[int64]*list.Element // cached blocks, indexed by block number
+	pend map[int64]*bfetch       // blocks being fetched, indexed by block number
+}
+
+type block struct {
+	id  int64
+	buf []byte
+}
+
+// bfetch tracks the fetching of a block.
+type bfetch struct {
+	done chan struct{}
+	buf  []byte
+	err  error
+}
+
+func newBCache(r reader, size, blk int64, max int) *bcache {
+	return &bcache{
+		r:    r,
+		len:  size,
+		blk:  blk,
+		max:  max,
+		lru:  list.New(),
+		blks: make(map[int64]*list.Element),
+		pend: make(map[int64]*bfetch),
+	}
+}
+
+func (c *bcache) Close() error {
+	c.mu.Lock()
+	c.lru.Init()
+	c.blks = make(map[int64]*list.Element)
+	c.mu.Unlock()
+
+	return c.r.Close()
+}
+
+func (c *bcache) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func (c *bcache) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("riofs/http: negative offset")
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off >= c.len {
+		return 0, io.EOF
+	}
+
+	end := off + int64(len(p))
+	if end > c.len {
+		end = c.len
+	}
+
+	var (
+		beg  = off / c.blk
+		last = (end - 1) / c.blk
+		bufs = make([][]byte, last-beg+1)
+		wait = make([]*bfetch, last-beg+1)
+		runs []span // runs of consecutive blocks to fetch
+	)
+
+	c.mu.Lock()
+	for id := beg; id <= last; id++ {
+		if elmt, ok := c.blks[id]; ok {
+			c.lru.MoveToFront(elmt)
+			bufs[id-beg] = elmt.Value.(*block).buf
+			continue
+		}
+		if f, ok := c.pend[id]; ok {
+			wait[id-beg] = f
+			continue
+		}
+		f := &bfetch{done: make(chan struct{})}
+		c.pend[id] = f
+		wait[id-beg] = f
+		if n := len(runs); n > 0 && runs[n-1].off+runs[n-1].len == id {
+			runs[n-1].len++
+			continue
+		}
+		runs = append(runs, span{off: id, len: 1})
+	}
+	c.mu.Unlock()
+
+	var grp errgroup.Group
+	for i := range runs {
+		run := runs[i]
+		grp.Go(func() error {
+			return c.fetch(run)
+		})
+	}
+	err := grp.Wait()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, f := range wait {
+		if f == nil {
+			continue
+		}
+		<-f.done
+		if f.err != nil {
+			return 0, f.err
+		}
+		bufs[i] = f.buf
+	}
+
+	n := 0
+	for i, buf := range bufs {
+		pos := (beg + int64(i)) * c.blk
+		if pos < off {
+			buf = buf[off-pos:]
+		}
+		n += copy(p[n:], buf)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fetch reads the provided run of blocks from the underlying reader and
+// stores them into the cache.
+func (c *bcache) fetch(run span) error {
+	beg := run.off * c.blk
+	end := (run.off + run.len) * c.blk
+	if end > c.len {
+		end = c.len
+	}
+
+	buf := make([]byte, end-beg)
+	_, err := c.r.ReadAt(buf, beg)
+	if err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("riofs/http: could not read blocks [%d, %d): %w", run.off, run.off+run.len, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := int64(0); i < run.len; i++ {
+		id := run.off + i
+		f := c.pend[id]
+		delete(c.pend, id)
+		if err != nil {
+			f.err = err
+			close(f.done)
+			continue
+		}
+
+		lo := i * c.blk
+		hi := lo + c.blk
+		if hi > int64(len(buf)) {
+			hi = int64(len(buf))
+		}
+		f.buf = make([]byte, hi-lo)
+		copy(f.buf, buf[lo:hi])
+		close(f.done)
+
+		c.blks[id] = c.lru.PushFront(&block{id: id, buf: f.buf})
+		for c.lru.Len() > c.max {
+			elmt := c.lru.Back()
+			c.lru.Remove(elmt)
+			delete(c.blks, elmt.Value.(*block).id)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,157 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+type countReader struct {
+	*bytes.Reader
+
+	mu sync.Mutex
+	n  int // number of ReadAt calls
+}
+
+func (r *countReader) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	r.n++
+	r.mu.Unlock()
+	return r.Reader.ReadAt(p, off)
+}
+
+func (r *countReader) Close() error { return nil }
+
+func (r *countReader) reads() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.n
+}
+
+func TestBCache(t *testing.T) {
+	const (
+		size = 1000
+		blk  = 64
+	)
+
+	want := make([]byte, size)
+	rnd := rand.New(rand.NewSource(1234))
+	_, _ = rnd.Read(want)
+
+	src := &countReader{Reader: bytes.NewReader(want)}
+	c := newBCache(src, size, blk, 4)
+	defer c.Close()
+
+	read := func(beg, end int64) {
+		t.Helper()
+		p := make([]byte, end-beg)
+		n, err := c.ReadAt(p, beg)
+		if err != nil {
+			t.Fatalf("could not read [%d, %d): %+v", beg, end, err)
+		}
+		if n != len(p) {
+			t.Fatalf("invalid number of bytes read: got=%d, want=%d", n, len(p))
+		}
+		if !bytes.Equal(p, want[beg:end]) {
+			t.Fatalf("invalid content for [%d, %d)", beg, end)
+		}
+	}
+
+	for _, tc := range []struct {
+		beg, end int64
+		reads    int // total number of reads of the underlying reader
+	}{
+		{10, 20, 1},
+		{0, 64, 1},    // hit block 0
+		{60, 130, 2},  // hit block 0, fetch blocks 1-2
+		{100, 110, 2}, // hit block 1
+		{0, 256, 3},   // hit blocks 0-2, fetch block 3
+		{512, 600, 4}, // fetch blocks 8-9, evict blocks 0-1
+		{0, 10, 5},    // fetch block 0, evict block 2
+		{200, 300, 6}, // hit block 3, fetch block 4, evict block 8
+		{size - 5, size, 7},
+	} {
+		read(tc.beg, tc.end)
+		if got, want := src.reads(), tc.reads; got != want {
+			t.Fatalf("invalid number of reads after [%d, %d): got=%d, want=%d", tc.beg, tc.end, got, want)
+		}
+	}
+
+	if got, want := c.lru.Len(), 4; got != want {
+		t.Fatalf("invalid number of cached blocks: got=%d, want=%d", got, want)
+	}
+
+	p := make([]byte, 10)
+	n, err := c.ReadAt(p, size-4)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF, got: %+v", err)
+	}
+	if n != 4 || !bytes.Equal(p[:n], want[size-4:]) {
+		t.Fatalf("invalid short read: n=%d", n)
+	}
+
+	_, err = c.ReadAt(p, size)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF, got: %+v", err)
+	}
+
+	_, err = c.ReadAt(p, -1)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestBCacheConcurrent(t *testing.T) {
+	const (
+		size = 64 * 1024
+		blk  = 1024
+	)
+
+	want := make([]byte, size)
+	rnd := rand.New(rand.NewSource(1234))
+	_, _ = rnd.Read(want)
+
+	src := &countReader{Reader: bytes.NewReader(want)}
+	c := newBCache(src, size, blk, 16)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for j := 0; j < 100; j++ {
+				beg := rnd.Int63n(size)
+				end := beg + rnd.Int63n(4*blk)
+				if end > size {
+					end = size
+				}
+				p := make([]byte, end-beg)
+				_, err := c.ReadAt(p, beg)
+				if err != nil && err != io.EOF {
+					t.Errorf("could not read [%d, %d): %+v", beg, end, err)
+					return
+				}
+				if !bytes.Equal(p, want[beg:end]) {
+					t.Errorf("invalid content for [%d, %d)", beg, end)
+					return
+				}
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	if got, max := c.lru.Len(), 16; got > max {
+		t.Fatalf("too many cached blocks: got=%d, max=%d", got, max)
+	}
+	if len(c.pend) != 0 {
+		t.Fatalf("pending fetches: %d", len(c.pend))
+	}
+}
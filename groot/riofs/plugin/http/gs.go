@@ -0,0 +1,226 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+const (
+	gsScope    = "https://www.googleapis.com/auth/devstorage.read_only"
+	gsTokenURI = "https://oauth2.googleapis.com/token"
+)
+
+// openGS opens a ROOT file located at gs://bucket/object.
+//
+// If STORAGE_EMULATOR_HOST is set, objects are retrieved from that
+// Google Cloud Storage emulator.
+// Requests are authorized with the OAuth2 access token taken from the
+// GOOGLE_OAUTH_ACCESS_TOKEN environment variable or, otherwise, with the
+// service account credentials file pointed at by GOOGLE_APPLICATION_CREDENTIALS.
+// Requests are anonymous if none of these are set.
+func openGS(path string) (riofs.Reader, error) {
+	bucket, obj, err := splitBucket("gs", path)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := "https://storage.googleapis.com/" + bucket + "/" + obj
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		uri = strings.TrimRight(host, "/") + "/" + bucket + "/" + obj
+	}
+
+	tr := &gsTransport{
+		rt:    http.DefaultTransport,
+		token: os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"),
+	}
+	if fname := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); tr.token == "" && fname != "" {
+		tr.sa, err = loadServiceAccount(fname)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return openURL(&http.Client{Transport: tr}, uri)
+}
+
+// gsTransport authorizes HTTP requests with an OAuth2 bearer token.
+type gsTransport struct {
+	rt http.RoundTripper
+	sa *serviceAccount // service account used to retrieve tokens, if any
+
+	mu    sync.Mutex
+	token string
+	exp   time.Time // expiration time of the token (zero if it never expires)
+}
+
+func (tr *gsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := tr.bearer()
+	if err != nil {
+		return nil, err
+	}
+	if tok == "" {
+		return tr.rt.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tok)
+	return tr.rt.RoundTrip(req)
+}
+
+// bearer returns the current access token, retrieving a new one from the
+// service account if needed.
+func (tr *gsTransport) bearer() (string, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.sa == nil {
+		return tr.token, nil
+	}
+	if tr.token != "" && time.Now().Add(time.Minute).Before(tr.exp) {
+		return tr.token, nil
+	}
+
+	tok, exp, err := tr.sa.fetch(tr.rt, time.Now())
+	if err != nil {
+		return "", err
+	}
+	tr.token = tok
+	tr.exp = exp
+	return tr.token, nil
+}
+
+// serviceAccount holds the credentials of a Google service account.
+type serviceAccount struct {
+	email string
+	uri   string // token endpoint
+	key   *rsa.PrivateKey
+}
+
+func loadServiceAccount(fname string) (*serviceAccount, error) {
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, fmt.Errorf("riofs/http: could not read GCS credentials: %w", err)
+	}
+
+	var cfg struct {
+		Type     string `json:"type"`
+		Email    string `json:"client_email"`
+		Key      string `json:"private_key"`
+		TokenURI string `json:"token_uri"`
+	}
+	err = json.Unmarshal(raw, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("riofs/http: could not decode GCS credentials %q: %w", fname, err)
+	}
+	if cfg.Type != "service_account" {
+		return nil, fmt.Errorf("riofs/http: unsupported GCS credentials type %q", cfg.Type)
+	}
+
+	blk, _ := pem.Decode([]byte(cfg.Key))
+	if blk == nil {
+		return nil, fmt.Errorf("riofs/http: could not decode GCS private key")
+	}
+	v, err := x509.ParsePKCS8PrivateKey(blk.Bytes)
+	if err != nil {
+		v, err = x509.ParsePKCS1PrivateKey(blk.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("riofs/http: could not parse GCS private key: %w", err)
+		}
+	}
+	key, ok := v.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("riofs/http: invalid GCS private key type %T", v)
+	}
+
+	sa := &serviceAccount{
+		email: cfg.Email,
+		uri:   cfg.TokenURI,
+		key:   key,
+	}
+	if sa.uri == "" {
+		sa.uri = gsTokenURI
+	}
+	return sa, nil
+}
+
+// fetch exchanges a signed JWT for an access token, and returns that token
+// with its expiration time.
+func (sa *serviceAccount) fetch(rt http.RoundTripper, now time.Time) (string, time.Time, error) {
+	jwt, err := sa.jwt(now)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	cli := &http.Client{Transport: rt}
+	resp, err := cli.PostForm(sa.uri, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {jwt},
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("riofs/http: could not retrieve GCS access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("riofs/http: could not retrieve GCS access token: %s", resp.Status)
+	}
+
+	var tok struct {
+		Token string `json:"access_token"`
+		Exp   int64  `json:"expires_in"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&tok)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("riofs/http: could not decode GCS access token: %w", err)
+	}
+	return tok.Token, now.Add(time.Duration(tok.Exp) * time.Second), nil
+}
+
+// jwt returns a JWT assertion signed with RS256.
+func (sa *serviceAccount) jwt(now time.Time) (string, error) {
+	hdr, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   sa.email,
+		"scope": gsScope,
+		"aud":   sa.uri,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	msg := enc.EncodeToString(hdr) + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(msg))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, sa.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", fmt.Errorf("riofs/http: could not sign GCS JWT: %w", err)
+	}
+	return msg + "." + enc.EncodeToString(sig), nil
+}
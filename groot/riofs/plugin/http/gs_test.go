@@ -0,0 +1,107 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestOpenGS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ntoks int32
+	fsrv := http.StripPrefix("/bucket", http.FileServer(http.Dir("../../../testdata")))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" ||
+			r.FormValue("assertion") == "" {
+			http.Error(w, "invalid grant", http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&ntoks, 1)
+		fmt.Fprintf(w, `{"access_token":"sa-token","expires_in":3600,"token_type":"Bearer"}`)
+	})
+	var want atomic.Value
+	mux.HandleFunc("/bucket/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+want.Load().(string) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fsrv.ServeHTTP(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	creds := filepath.Join(t.TempDir(), "creds.json")
+	raw, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "sa@example.iam.gserviceaccount.com",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"token_uri": srv.URL + "/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(creds, raw, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("STORAGE_EMULATOR_HOST", srv.Listener.Addr().String())
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		creds string
+		want  string
+	}{
+		{name: "token", token: "user-token", want: "user-token"},
+		{name: "service-account", creds: creds, want: "sa-token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", tc.token)
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", tc.creds)
+			want.Store(tc.want)
+
+			f, err := riofs.Open("gs://bucket/small-flat-tree.root")
+			if err != nil {
+				t.Fatalf("could not open GCS file: %+v", err)
+			}
+			defer f.Close()
+
+			tree, err := riofs.Get[rtree.Tree](f, "tree")
+			if err != nil {
+				t.Fatalf("could not get tree: %+v", err)
+			}
+			if got, want := tree.Entries(), int64(100); got != want {
+				t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	if got, want := atomic.LoadInt32(&ntoks), int32(1); got != want {
+		t.Fatalf("invalid number of token requests: got=%d, want=%d", got, want)
+	}
+}
@@ -2,10 +2,17 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package http is a plugin for riofs.Open to support opening ROOT files over http(s).
+// Package http is a plugin for riofs.Open to support opening ROOT files over http(s),
+// and from S3 (s3://) and Google Cloud Storage (gs://) object stores.
+//
+// ROOT files are read with HTTP range requests, without downloading them
+// fully, and the most recently read parts of the files are kept in memory.
+// If the HTTP server does not support range requests, ROOT files are
+// downloaded into a temporary file.
 package http
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -18,29 +25,46 @@ import (
 func init() {
 	riofs.Register("http", openFile)
 	riofs.Register("https", openFile)
+	riofs.Register("s3", openS3)
+	riofs.Register("gs", openGS)
 }
 
 func openFile(path string) (riofs.Reader, error) {
-	r, err := httpio.Open(path)
-	if err != nil {
-		// HTTP server may not support accept-range.
-		return tmpFileFrom(path)
+	return openURL(nil, path)
+}
+
+// openURL opens the provided URL with the provided HTTP client.
+// If cli is nil, default HTTP clients are used.
+func openURL(cli *http.Client, uri string) (riofs.Reader, error) {
+	var opts []httpio.Option
+	if cli != nil {
+		opts = append(opts, httpio.WithClient(cli))
 	}
-	rc, err := rcacheOf(&preader{r: r, n: runtime.NumCPU()})
+	r, err := httpio.Open(uri, opts...)
 	if err != nil {
-		_ = r.Close()
-		return tmpFileFrom(path)
+		// HTTP server may not support accept-range.
+		return tmpFileFrom(cli, uri)
 	}
-	return rc, nil
+	return newBCache(
+		&preader{r: r, n: runtime.NumCPU()},
+		r.Size(), cacheBlkSize, cacheSize,
+	), nil
 }
 
-func tmpFileFrom(path string) (riofs.Reader, error) {
-	resp, err := http.Get(path)
+func tmpFileFrom(cli *http.Client, path string) (riofs.Reader, error) {
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	resp, err := cli.Get(path)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("riofs/http: could not download %q: %s", path, resp.Status)
+	}
+
 	f, err := os.CreateTemp("", "riofs-remote-")
 	if err != nil {
 		return nil, err
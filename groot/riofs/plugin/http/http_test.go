@@ -5,8 +5,13 @@
 package http
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
 )
 
 func TestTmpFile(t *testing.T) {
@@ -47,3 +52,54 @@ func TestTmpFile(t *testing.T) {
 		t.Fatalf("file %q should have been removed", tmp.Name())
 	}
 }
+
+func TestOpenFile(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("../../../testdata")))
+	defer srv.Close()
+
+	rd, err := openFile(srv.URL + "/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	if _, ok := rd.(*bcache); !ok {
+		t.Fatalf("invalid remote file reader: got=%T, want=%T", rd, (*bcache)(nil))
+	}
+	err = rd.Close()
+	if err != nil {
+		t.Fatalf("could not close remote file: %+v", err)
+	}
+
+	f, err := riofs.Open(srv.URL + "/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	var (
+		sum  float64
+		v    float64
+		vars = []rtree.ReadVar{{Name: "Float64", Value: &v}}
+	)
+	r, err := rtree.NewReader(tree, vars)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(rtree.RCtx) error {
+		sum += v
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	if got, want := sum, 4950.0; got != want {
+		t.Fatalf("invalid sum: got=%v, want=%v", got, want)
+	}
+}
@@ -5,11 +5,19 @@
 package http
 
 import (
+	"io"
 	"sync"
 
 	"go-hep.org/x/hep/groot/riofs"
 )
 
+type reader interface {
+	io.Reader
+	io.ReaderAt
+	io.Closer
+}
+
+// preader splits large reads into blocks read in parallel.
 type preader struct {
 	r reader
 	n int // number of workers
@@ -92,6 +100,11 @@ func (r *preader) pread(p []byte, off int64) (int, error) {
 	return n, err
 }
 
+type span struct {
+	off int64
+	len int64
+}
+
 type pread struct {
 	n   int
 	err error
@@ -0,0 +1,225 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// emptySHA256 is the hex-encoded SHA-256 hash of an empty payload.
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// openS3 opens a ROOT file located at s3://bucket/key.
+//
+// The region of the bucket is taken from the AWS_REGION or AWS_DEFAULT_REGION
+// environment variables (default: us-east-1).
+// If AWS_ENDPOINT_URL is set, objects are retrieved from that S3-compatible
+// endpoint, using path-style requests.
+// Requests are signed with the credentials taken from the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables, if any.
+func openS3(path string) (riofs.Reader, error) {
+	bucket, key, err := splitBucket("s3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	uri := "https://" + bucket + ".s3." + region + ".amazonaws.com/" + key
+	if endpoint := os.Getenv("AWS_ENDPOINT_URL"); endpoint != "" {
+		uri = strings.TrimRight(endpoint, "/") + "/" + bucket + "/" + key
+	}
+
+	cli := &http.Client{
+		Transport: &s3Transport{
+			rt:     http.DefaultTransport,
+			key:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			secret: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			token:  os.Getenv("AWS_SESSION_TOKEN"),
+			region: region,
+		},
+	}
+
+	return openURL(cli, uri)
+}
+
+// splitBucket splits a scheme://bucket/key URL into its bucket and key.
+func splitBucket(scheme, path string) (bucket, key string, err error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", "", fmt.Errorf("riofs/http: could not parse %q: %w", path, err)
+	}
+	if u.Scheme != scheme || u.Host == "" {
+		return "", "", fmt.Errorf("riofs/http: invalid %s URL %q", scheme, path)
+	}
+	key = strings.TrimPrefix(u.Path, "/")
+	if key == "" {
+		return "", "", fmt.Errorf("riofs/http: invalid %s URL %q: missing object name", scheme, path)
+	}
+	return u.Host, key, nil
+}
+
+// s3Transport signs HTTP requests with the AWS signature version 4.
+// Requests are sent unsigned if no credentials were provided.
+type s3Transport struct {
+	rt     http.RoundTripper
+	key    string
+	secret string
+	token  string
+	region string
+}
+
+func (tr *s3Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if tr.key == "" || tr.secret == "" {
+		return tr.rt.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	if tr.token != "" {
+		req.Header.Set("X-Amz-Security-Token", tr.token)
+	}
+	signV4(req, tr.key, tr.secret, tr.region, "s3", time.Now())
+	return tr.rt.RoundTrip(req)
+}
+
+// signV4 signs the provided body-less request with the AWS signature version 4.
+func signV4(req *http.Request, key, secret, region, service string, t time.Time) {
+	var (
+		now  = t.UTC()
+		date = now.Format("20060102T150405Z")
+		day  = now.Format("20060102")
+		host = req.Host
+	)
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	req.Header.Set("X-Amz-Date", date)
+	if service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", emptySHA256)
+	}
+
+	hdrs := map[string]string{"host": host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, "x-amz-") {
+			hdrs[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(hdrs))
+	for k := range hdrs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canon strings.Builder
+	for _, k := range names {
+		canon.WriteString(k + ":" + hdrs[k] + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	creq := strings.Join([]string{
+		req.Method,
+		awsEscapePath(req.URL.EscapedPath()),
+		awsQuery(req.URL.Query()),
+		canon.String(),
+		signed,
+		emptySHA256,
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	sum := sha256.Sum256([]byte(creq))
+	str := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	sig := hmacSHA256([]byte("AWS4"+secret), day)
+	sig = hmacSHA256(sig, region)
+	sig = hmacSHA256(sig, service)
+	sig = hmacSHA256(sig, "aws4_request")
+	sig = hmacSHA256(sig, str)
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		key, scope, signed, hex.EncodeToString(sig),
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsEscapePath returns the canonical form of an already escaped URL path,
+// where each segment is URI-encoded with the AWS rules.
+func awsEscapePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		v, err := url.PathUnescape(seg)
+		if err != nil {
+			v = seg
+		}
+		segs[i] = awsEscape(v)
+	}
+	return strings.Join(segs, "/")
+}
+
+// awsQuery returns the canonical form of a query string.
+func awsQuery(vs url.Values) string {
+	keys := make([]string, 0, len(vs))
+	for k := range vs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var o []string
+	for _, k := range keys {
+		vals := append([]string(nil), vs[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			o = append(o, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(o, "&")
+}
+
+// awsEscape URI-encodes s, leaving only the unreserved characters
+// A-Z, a-z, 0-9, '-', '.', '_' and '~' as is.
+func awsEscape(s string) string {
+	const hexdigits = "0123456789ABCDEF"
+	var o strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			o.WriteByte(c)
+		default:
+			o.WriteByte('%')
+			o.WriteByte(hexdigits[c>>4])
+			o.WriteByte(hexdigits[c&0xf])
+		}
+	}
+	return o.String()
+}
@@ -0,0 +1,102 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestSignV4(t *testing.T) {
+	// get-vanilla test vector from the AWS signature version 4 test suite.
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signV4(req, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", now)
+
+	const want = "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("invalid signature:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestSplitBucket(t *testing.T) {
+	for _, tc := range []struct {
+		path   string
+		bucket string
+		key    string
+		err    bool
+	}{
+		{path: "s3://bkt/dir/f.root", bucket: "bkt", key: "dir/f.root"},
+		{path: "s3://bkt/f.root", bucket: "bkt", key: "f.root"},
+		{path: "s3://bkt", err: true},
+		{path: "s3:///f.root", err: true},
+		{path: "gs://bkt/f.root", err: true},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			bucket, key, err := splitBucket("s3", tc.path)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not split %q: %+v", tc.path, err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			case err != nil:
+				return
+			}
+			if bucket != tc.bucket || key != tc.key {
+				t.Fatalf("invalid split: got=(%q, %q), want=(%q, %q)", bucket, key, tc.bucket, tc.key)
+			}
+		})
+	}
+}
+
+func TestOpenS3(t *testing.T) {
+	fsrv := http.StripPrefix("/bucket", http.FileServer(http.Dir("../../../testdata")))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			r.Header.Get("X-Amz-Security-Token") != "token" {
+			http.Error(w, "unauthorized", http.StatusForbidden)
+			return
+		}
+		fsrv.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+	t.Setenv("AWS_REGION", "eu-west-3")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+
+	f, err := riofs.Open("s3://bucket/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open S3 file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+	if got, want := tree.Entries(), int64(100); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+
+	_, err = riofs.Open("s3://bucket/not-there.root")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
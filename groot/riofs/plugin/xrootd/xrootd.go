@@ -6,6 +6,9 @@
 package xrootd
 
 import (
+	"fmt"
+	"io"
+
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/xrootd/xrdio"
 )
@@ -13,12 +16,41 @@ import (
 func init() {
 	riofs.Register("root", openFile)
 	riofs.Register("xroot", openFile)
+	riofs.Register("roots", openFile)
+	riofs.Register("xroots", openFile)
 }
 
 func openFile(path string) (riofs.Reader, error) {
 	return xrdio.Open(path)
 }
 
+// VerifyChecksum checks that the content read from r, e.g. a downloaded copy,
+// matches the checksum of the remote file name, as computed by the XRootD server
+// with the typ algorithm (e.g. adler32, md5).
+// The default algorithm of the server is used if typ is empty.
+//
+// Example:
+//
+//	err := xrootd.VerifyChecksum(f, "root://server.example.com//some/file.root", "adler32")
+func VerifyChecksum(r io.Reader, name, typ string) error {
+	f, err := xrdio.Open(name)
+	if err != nil {
+		return fmt.Errorf("riofs/xrootd: could not open %q: %w", name, err)
+	}
+	defer f.Close()
+
+	cs, err := f.Checksum(typ)
+	if err != nil {
+		return err
+	}
+
+	err = xrdio.VerifyChecksum(r, cs)
+	if err != nil {
+		return fmt.Errorf("riofs/xrootd: could not verify %q: %w", name, err)
+	}
+	return nil
+}
+
 var (
 	_ riofs.Reader = (*xrdio.File)(nil)
 	_ riofs.Writer = (*xrdio.File)(nil)
@@ -0,0 +1,374 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Report is the integrity report of a ROOT file, as produced by OpenRecover.
+type Report struct {
+	Errs      []error  // errors encountered while reading the metadata of the file
+	Records   []Record // records found while scanning the file
+	Recovered int      // number of top-level keys recovered from the scan of the file
+}
+
+// Record describes a record (a key and its payload) found while scanning
+// a ROOT file.
+type Record struct {
+	Pos    int64  // position of the record on file
+	Nbytes int32  // number of bytes of the record on file
+	Objlen int32  // number of bytes of the uncompressed payload
+	Class  string // class name of the payload
+	Name   string // name of the key
+	Cycle  int16  // cycle of the key
+	Err    error  // integrity error of the record, nil if the record is valid
+
+	key *Key
+}
+
+func (rec Record) String() string {
+	o := fmt.Sprintf("At:%d N=%d %s %s;%d", rec.Pos, rec.Nbytes, rec.Class, rec.Name, rec.Cycle)
+	if rec.Err != nil {
+		o += ": " + rec.Err.Error()
+	}
+	return o
+}
+
+// OK returns whether no integrity problem was found.
+func (rep *Report) OK() bool {
+	return len(rep.Errs) == 0 && len(rep.Bad()) == 0
+}
+
+// Bad returns the records that failed the integrity checks.
+func (rep *Report) Bad() []Record {
+	var bad []Record
+	for _, rec := range rep.Records {
+		if rec.Err != nil {
+			bad = append(bad, rec)
+		}
+	}
+	return bad
+}
+
+func (rep *Report) String() string {
+	o := new(strings.Builder)
+	fmt.Fprintf(o, "records: %d, bad records: %d, recovered keys: %d", len(rep.Records), len(rep.Bad()), rep.Recovered)
+	for _, err := range rep.Errs {
+		fmt.Fprintf(o, "\nerror: %v", err)
+	}
+	for _, rec := range rep.Bad() {
+		fmt.Fprintf(o, "\nbad record: %v", rec)
+	}
+	return o.String()
+}
+
+// OpenRecover opens the named ROOT file for reading, even if the records
+// describing its content (the top-level directory, its list of keys or the
+// streamer infos) are damaged, e.g. when the file was not properly closed.
+//
+// OpenRecover scans all the records of the file, checks their byte counts
+// and the checksums of their compressed payloads, and returns a report of
+// the problems it found.
+// When the list of keys of the top-level directory can not be read, it is
+// rebuilt from the valid records found during the scan, as done by ROOT's
+// TFile::Recover.
+func OpenRecover(path string, opts ...FileOption) (*File, *Report, error) {
+	fd, err := openFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("riofs: unable to open %q: %w", path, err)
+	}
+
+	f := &File{
+		r:      fd,
+		closer: fd,
+		id:     path,
+	}
+	f.dir.file = f
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		err := opt(f)
+		if err != nil {
+			_ = fd.Close()
+			return nil, nil, fmt.Errorf("riofs: could not apply option to ROOT file: %w", err)
+		}
+	}
+	f.lazy = false
+
+	rep, err := f.recover()
+	if err != nil {
+		_ = fd.Close()
+		return nil, nil, fmt.Errorf("riofs: could not recover %q: %w", path, err)
+	}
+
+	return f, rep, nil
+}
+
+// recover reads the metadata of the file, rebuilding the ones that are
+// damaged from a scan of the records of the file.
+func (f *File) recover() (*Report, error) {
+	var magic [4]byte
+	_, err := f.ReadAt(magic[:], 0)
+	if err != nil {
+		return nil, fmt.Errorf("riofs: could not read ROOT file magic header: %w", err)
+	}
+	if string(magic[:]) != string(rootMagic) {
+		return nil, fmt.Errorf("riofs: %q is not a root file", f.id)
+	}
+
+	rep := new(Report)
+	err = f.readFileHeader()
+	if err != nil {
+		rep.Errs = append(rep.Errs, fmt.Errorf("riofs: could not read file header: %w", err))
+	}
+
+	size := f.end
+	if fi, err := f.Stat(); err == nil {
+		size = fi.Size()
+	}
+	if f.begin <= 0 || f.begin >= size {
+		f.begin = kBEGIN
+	}
+	if f.end <= f.begin || f.end > size {
+		f.end = size
+	}
+
+	rep.Records, f.end = f.scan(f.end)
+
+	err = f.dir.readDirInfo()
+	if err != nil {
+		rep.Errs = append(rep.Errs, fmt.Errorf("riofs: could not read top-level directory: %w", err))
+		f.recoverDir(rep.Records)
+	}
+	f.dir.lazy = nil
+
+	err = f.safely(f.readStreamerInfo)
+	if err != nil {
+		rep.Errs = append(rep.Errs, fmt.Errorf("riofs: could not read streamer infos: %w", err))
+		f.recoverStreamerInfo(rep.Records)
+	}
+
+	err = f.dir.readKeys()
+	if err != nil {
+		rep.Errs = append(rep.Errs, fmt.Errorf("riofs: could not read top-level keys: %w", err))
+		rep.Recovered = f.recoverKeys(rep.Records)
+	}
+
+	return rep, nil
+}
+
+// scan scans the records of the file located before end.
+// scan returns the records found and the position of the end of the
+// last record that could be read.
+func (f *File) scan(end int64) ([]Record, int64) {
+	var (
+		recs []Record
+		pos  = f.begin
+	)
+	for pos < end {
+		var (
+			buf = make([]byte, 4)
+			rec = Record{Pos: pos}
+		)
+		if end-pos < int64(len(buf)) {
+			break
+		}
+		_, err := f.ReadAt(buf, pos)
+		if err != nil {
+			break
+		}
+		rec.Nbytes = rbytes.NewRBuffer(buf, nil, 0, nil).ReadI32()
+		switch {
+		case rec.Nbytes < 0:
+			// gap of free bytes.
+			if int64(-rec.Nbytes) > end-pos {
+				return recs, pos
+			}
+			pos += int64(-rec.Nbytes)
+			continue
+		case rec.Nbytes == 0:
+			return recs, pos
+		}
+
+		n := int64(rec.Nbytes)
+		if n > end-pos {
+			n = end - pos
+		}
+		if n > 64+3*256 {
+			n = 64 + 3*256 // large enough for the header of any key.
+		}
+		buf = make([]byte, n)
+		_, err = f.ReadAt(buf, pos)
+		if err != nil && err != io.EOF {
+			rec.Err = fmt.Errorf("could not read record header: %w", err)
+			recs = append(recs, rec)
+			return recs, pos
+		}
+
+		k := &Key{f: f, parent: &f.dir}
+		err = k.UnmarshalROOT(rbytes.NewRBuffer(buf, nil, 0, nil))
+		if err != nil || k.keylen <= 0 || k.keylen > k.nbytes || k.objlen < 0 {
+			rec.Err = fmt.Errorf("invalid record header (keylen=%d, objlen=%d): %v", k.keylen, k.objlen, err)
+			recs = append(recs, rec)
+			return recs, pos
+		}
+		if k.class == "TDirectory" {
+			k.class = "TDirectoryFile"
+		}
+		rec.Objlen = k.objlen
+		rec.Class = k.class
+		rec.Name = k.name
+		rec.Cycle = k.cycle
+		rec.key = k
+
+		if pos+int64(k.nbytes) > end {
+			rec.Err = fmt.Errorf("truncated record (missing %d bytes)", pos+int64(k.nbytes)-end)
+			recs = append(recs, rec)
+			return recs, pos
+		}
+		pos += int64(k.nbytes)
+
+		if k.seekkey != rec.Pos {
+			rec.Err = fmt.Errorf("inconsistent record position (seek-key=%d)", k.seekkey)
+			recs = append(recs, rec)
+			continue
+		}
+
+		rec.Err = f.safely(func() error {
+			_, err := k.Bytes()
+			return err
+		})
+		recs = append(recs, rec)
+	}
+	return recs, pos
+}
+
+// safely runs fct, converting panics into errors.
+// Damaged records may trigger out-of-bounds accesses when decoded.
+func (f *File) safely(fct func() error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+	return fct()
+}
+
+// recoverDir rebuilds the top-level directory from the record of the
+// file, located at the beginning of the file.
+func (f *File) recoverDir(recs []Record) {
+	f.dir.dir.rvers = rvers.DirectoryFile
+	f.dir.dir.named = *rbase.NewNamed(f.id, "")
+	f.dir.seekdir = f.begin
+	f.dir.seekkeys = 0
+	f.dir.nbyteskeys = 0
+
+	if len(recs) == 0 || recs[0].Pos != f.begin || recs[0].Err != nil {
+		return
+	}
+
+	k := recs[0].key
+	_ = f.safely(func() error {
+		buf, err := k.Bytes()
+		if err != nil {
+			return err
+		}
+		var (
+			dir tdirectoryFile
+			r   = rbytes.NewRBuffer(buf, nil, 0, nil)
+		)
+		_ = r.ReadString() // name of the file
+		_ = r.ReadString() // title of the file
+		nbytes := r.Pos()
+		err = dir.UnmarshalROOT(r)
+		if err != nil {
+			return err
+		}
+		f.nbytesname = k.keylen + int32(nbytes)
+		f.dir.dir = dir.dir
+		f.dir.ctime = dir.ctime
+		f.dir.mtime = dir.mtime
+		f.dir.nbyteskeys = dir.nbyteskeys
+		f.dir.nbytesname = dir.nbytesname
+		f.dir.seekkeys = dir.seekkeys
+		f.dir.classname = k.class
+		f.dir.dir.named = *rbase.NewNamed(k.name, k.title)
+		return nil
+	})
+}
+
+// recoverStreamerInfo reads the streamer infos from the last valid
+// StreamerInfo record found in the file.
+func (f *File) recoverStreamerInfo(recs []Record) {
+	for i := len(recs) - 1; i >= 0; i-- {
+		rec := recs[i]
+		if rec.Err != nil || rec.Class != "TList" || rec.Name != "StreamerInfo" {
+			continue
+		}
+		f.seekinfo = rec.Pos
+		f.nbytesinfo = rec.Nbytes
+		err := f.safely(f.readStreamerInfo)
+		if err == nil {
+			return
+		}
+	}
+	f.seekinfo = 0
+	f.nbytesinfo = 0
+	f.sinfos = nil
+	f.sidx = nil
+}
+
+// recoverKeys rebuilds the list of keys of the top-level directory from
+// the valid records found in the file.
+// recoverKeys returns the number of recovered keys.
+func (f *File) recoverKeys(recs []Record) int {
+	f.dir.keys = nil
+	for _, rec := range recs {
+		if rec.Err != nil || rec.key.seekpdir != f.begin || rec.Pos == f.begin {
+			continue
+		}
+		switch {
+		case rec.Class == "TFile", rec.Class == "TBasket":
+			// file header, free segments or list of keys, and baskets of trees.
+			continue
+		case rec.Class == "TList" && rec.Name == "StreamerInfo":
+			continue
+		case rec.Class == "TDirectoryFile" && !f.isDirRecord(rec):
+			// list of keys of the top-level directory.
+			continue
+		}
+		f.dir.keys = append(f.dir.keys, *rec.key)
+	}
+	return len(f.dir.keys)
+}
+
+// isDirRecord returns whether the provided record holds a directory.
+func (f *File) isDirRecord(rec Record) bool {
+	err := f.safely(func() error {
+		buf, err := rec.key.Bytes()
+		if err != nil {
+			return err
+		}
+		var dir tdirectoryFile
+		err = dir.UnmarshalROOT(rbytes.NewRBuffer(buf, nil, 0, nil))
+		if err != nil {
+			return err
+		}
+		if dir.seekdir != rec.Pos {
+			return fmt.Errorf("not a directory")
+		}
+		return nil
+	})
+	return err == nil
+}
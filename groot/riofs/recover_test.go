@@ -0,0 +1,177 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/rbase"
+)
+
+func TestOpenRecover(t *testing.T) {
+	const nobjs = 5
+
+	tmp := t.TempDir()
+	value := func(i int) string {
+		return strings.Repeat(fmt.Sprintf("obj-%d ", i), 200)
+	}
+
+	// create creates a new file and returns its name, its keys and the
+	// location of its list of keys.
+	create := func(t *testing.T) (string, []Key, int64) {
+		t.Helper()
+		fname := filepath.Join(tmp, strings.Replace(t.Name(), "/", "-", -1)+".root")
+		w, err := Create(fname, WithLZ4(1))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		for i := 0; i < nobjs; i++ {
+			err = w.Put(fmt.Sprintf("obj-%d", i), rbase.NewObjString(value(i)))
+			if err != nil {
+				t.Fatalf("could not put obj-%d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		f, err := Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+		return fname, append([]Key(nil), f.Keys()...), f.dir.seekkeys
+	}
+
+	check := func(t *testing.T, f *File, names ...string) {
+		t.Helper()
+		for _, name := range names {
+			obj, err := f.Get(name)
+			if err != nil {
+				t.Fatalf("could not get %q: %+v", name, err)
+			}
+			var i int
+			_, _ = fmt.Sscanf(name, "obj-%d", &i)
+			if got, want := obj.(*rbase.ObjString).String(), value(i); got != want {
+				t.Fatalf("invalid value for %q", name)
+			}
+		}
+	}
+
+	all := make([]string, nobjs)
+	for i := range all {
+		all[i] = fmt.Sprintf("obj-%d", i)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		fname, _, _ := create(t)
+		f, rep, err := OpenRecover(fname)
+		if err != nil {
+			t.Fatalf("could not recover file: %+v", err)
+		}
+		defer f.Close()
+
+		if !rep.OK() {
+			t.Fatalf("invalid report:\n%v", rep)
+		}
+		if got, want := rep.Recovered, 0; got != want {
+			t.Fatalf("invalid number of recovered keys: got=%d, want=%d", got, want)
+		}
+		if got, want := len(f.Keys()), nobjs; got != want {
+			t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+		}
+		check(t, f, all...)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		fname, _, seekkeys := create(t)
+		// simulate a file that was not properly closed: drop the list of
+		// keys, the streamer infos and the free segments.
+		err := os.Truncate(fname, seekkeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Open(fname)
+		if err == nil {
+			t.Fatalf("expected an error opening a truncated file")
+		}
+
+		f, rep, err := OpenRecover(fname)
+		if err != nil {
+			t.Fatalf("could not recover file: %+v", err)
+		}
+		defer f.Close()
+
+		if rep.OK() {
+			t.Fatalf("expected a damaged file")
+		}
+		if got, want := rep.Recovered, nobjs; got != want {
+			t.Fatalf("invalid number of recovered keys: got=%d, want=%d\n%v", got, want, rep)
+		}
+		if got, want := len(f.Keys()), nobjs; got != want {
+			t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+		}
+		check(t, f, all...)
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		fname, keys, _ := create(t)
+		k := keys[2]
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw[k.seekkey+int64(k.nbytes)-10] ^= 0xff
+		err = os.WriteFile(fname, raw, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, rep, err := OpenRecover(fname)
+		if err != nil {
+			t.Fatalf("could not recover file: %+v", err)
+		}
+		defer f.Close()
+
+		bad := rep.Bad()
+		if len(bad) != 1 {
+			t.Fatalf("invalid number of bad records: got=%d, want=1\n%v", len(bad), rep)
+		}
+		if got, want := bad[0].Name, k.Name(); got != want {
+			t.Fatalf("invalid bad record: got=%q, want=%q", got, want)
+		}
+		if !errors.Is(bad[0].Err, rcompress.ErrChecksum) {
+			t.Fatalf("invalid error: got=%+v, want=%v", bad[0].Err, rcompress.ErrChecksum)
+		}
+		if got, want := len(f.Keys()), nobjs; got != want {
+			t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+		}
+		_, err = f.Get(k.Name())
+		if !errors.Is(err, rcompress.ErrChecksum) {
+			t.Fatalf("invalid error: got=%+v, want=%v", err, rcompress.ErrChecksum)
+		}
+		check(t, f, append(all[:2:2], all[3:]...)...)
+	})
+
+	t.Run("not-root", func(t *testing.T) {
+		fname := filepath.Join(tmp, "not-root.root")
+		err := os.WriteFile(fname, []byte(strings.Repeat("not a ROOT file", 10)), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = OpenRecover(fname)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
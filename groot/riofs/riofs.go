@@ -35,6 +35,7 @@ import (
 //go:generate go run ./gendata/gen-tgme.go -f ../testdata/tgme.root
 //go:generate go run ./gendata/gen-tdatime.go -f ../testdata/tdatime.root
 //go:generate go run ./gendata/gen-base.go -f ../testdata/tbase.root
+//go:generate go run ./gendata/gen-rntuple.go -f ../testdata/rntuple-v1.root
 
 // Directory describes a ROOT directory structure in memory.
 type Directory interface {
@@ -0,0 +1,128 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// NewStreamReader returns a Reader reading a ROOT file from a non-seekable
+// stream, such as a pipe, the standard input or a network connection.
+//
+// As ROOT files are not laid out to be read sequentially, the content of
+// the stream is spooled into a temporary file, as far as needed by the
+// requested reads. The temporary file is removed when the reader is closed.
+// Closing the reader also closes r, if it implements io.Closer.
+func NewStreamReader(r io.Reader) Reader {
+	return &stream{src: r}
+}
+
+// stream is a Reader that spools a non-seekable stream into a temporary
+// file.
+type stream struct {
+	mu  sync.Mutex
+	src io.Reader
+	f   *os.File // spooled content of the stream
+	n   int64    // number of spooled bytes
+	eof bool     // whether the stream has been fully spooled
+	pos int64    // position for Read
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n, err := s.ReadAt(p, s.pos)
+	s.pos += int64(n)
+	return n, err
+}
+
+func (s *stream) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("riofs: invalid stream offset %d", off)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.src == nil {
+		return 0, os.ErrClosed
+	}
+
+	err := s.fill(off + int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+
+	if off >= s.n {
+		return 0, io.EOF
+	}
+	if left := s.n - off; int64(len(p)) > left {
+		n, err := s.f.ReadAt(p[:left], off)
+		if err == nil {
+			err = io.EOF
+		}
+		return n, err
+	}
+	return s.f.ReadAt(p, off)
+}
+
+// fill spools the stream until at least end bytes have been spooled,
+// or until the end of the stream.
+func (s *stream) fill(end int64) error {
+	if s.eof || end <= s.n {
+		return nil
+	}
+
+	if s.f == nil {
+		f, err := os.CreateTemp("", "riofs-stream-")
+		if err != nil {
+			return fmt.Errorf("riofs: could not create spool file: %w", err)
+		}
+		s.f = f
+	}
+
+	n, err := io.CopyN(s.f, s.src, end-s.n)
+	s.n += n
+	switch err {
+	case nil:
+		return nil
+	case io.EOF:
+		s.eof = true
+		return nil
+	default:
+		return fmt.Errorf("riofs: could not spool stream: %w", err)
+	}
+}
+
+func (s *stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.src == nil {
+		return nil
+	}
+
+	var err error
+	if c, ok := s.src.(io.Closer); ok {
+		err = c.Close()
+	}
+	s.src = nil
+
+	if s.f != nil {
+		if e := s.f.Close(); e != nil && err == nil {
+			err = e
+		}
+		if e := os.Remove(s.f.Name()); e != nil && err == nil {
+			err = e
+		}
+		s.f = nil
+	}
+	return err
+}
+
+var (
+	_ Reader = (*stream)(nil)
+)
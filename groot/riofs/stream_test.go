@@ -0,0 +1,110 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestStreamReader(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	// hide the io.ReaderAt and io.Seeker interfaces of bytes.Reader.
+	src := struct{ io.Reader }{bytes.NewReader(raw)}
+	r := NewStreamReader(src).(*stream)
+
+	buf := make([]byte, 4)
+	_, err = r.ReadAt(buf, 0)
+	if err != nil {
+		t.Fatalf("could not read-at: %+v", err)
+	}
+	if got, want := string(buf), "root"; got != want {
+		t.Fatalf("invalid magic: got=%q, want=%q", got, want)
+	}
+	if got, want := r.n, int64(4); got != want {
+		t.Fatalf("invalid number of spooled bytes: got=%d, want=%d", got, want)
+	}
+
+	n, err := r.ReadAt(buf, int64(len(raw)-2))
+	if n != 2 || err != io.EOF {
+		t.Fatalf("invalid read-at past EOF: n=%d, err=%v", n, err)
+	}
+	if !r.eof || r.n != int64(len(raw)) {
+		t.Fatalf("stream not fully spooled: n=%d, eof=%v", r.n, r.eof)
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("could not read stream: %+v", err)
+	}
+	if !bytes.Equal(got, raw) {
+		t.Fatalf("invalid content")
+	}
+
+	spool := r.f.Name()
+	err = r.Close()
+	if err != nil {
+		t.Fatalf("could not close stream: %+v", err)
+	}
+	if _, err := os.Stat(spool); !os.IsNotExist(err) {
+		t.Fatalf("spool file %q should have been removed", spool)
+	}
+
+	_, err = r.ReadAt(buf, 0)
+	if err == nil {
+		t.Fatalf("expected an error reading a closed stream")
+	}
+}
+
+func TestOpenStdin(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("could not create pipe: %+v", err)
+	}
+	defer pr.Close()
+
+	go func() {
+		defer pw.Close()
+		_, _ = pw.Write(raw)
+	}()
+
+	stdin := os.Stdin
+	os.Stdin = pr
+	defer func() { os.Stdin = stdin }()
+
+	f, err := Open("-")
+	if err != nil {
+		t.Fatalf("could not open ROOT file from stdin: %+v", err)
+	}
+	defer f.Close()
+
+	if got, want := len(f.Keys()), 3; got != want {
+		t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+	}
+
+	obj, err := Dir(f).Get("dir1/dir11/h1")
+	if err != nil {
+		t.Fatalf("could not get object: %+v", err)
+	}
+	if got, want := obj.Class(), "TH1F"; got != want {
+		t.Fatalf("invalid class: got=%q, want=%q", got, want)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+}
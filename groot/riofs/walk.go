@@ -139,7 +139,7 @@ func (dir *recDir) mkdir(path string) (Directory, error) {
 		if ok {
 			return d, nil
 		}
-		return nil, keyTypeError{key: path, class: d.(root.Object).Class()}
+		return nil, keyTypeError{key: path, class: o.Class()}
 	}
 
 	ps := strings.Split(path, "/")
@@ -245,6 +245,130 @@ func Get[T any](dir Directory, key string) (T, error) {
 	return v, nil
 }
 
+// Delete removes the object identified by namecycle from the provided
+// directory and releases its record on file.
+// namecycle has the format [path/to/]name[;cycle].
+// All the cycles of the object are removed if no cycle is given.
+func Delete(dir Directory, namecycle string) error {
+	name, cycle := decodeNameCycle(namecycle)
+	d, name, err := dirFileOf(dir, name)
+	if err != nil {
+		return err
+	}
+	return d.del(name, cycle)
+}
+
+// Purge removes all the cycles of the objects held by the provided
+// directory, but the keep most recent ones, and releases their records
+// on file.
+// At least the most recent cycle of each object is kept.
+// Sub-directories are not purged.
+func Purge(dir Directory, keep int) error {
+	d, _, err := dirFileOf(dir, "")
+	if err != nil {
+		return err
+	}
+	return d.purge(keep)
+}
+
+// Move moves the object identified by src to dst, within the file holding
+// the provided directory, creating the parent directories of dst as needed.
+// src has the format [path/to/]name[;cycle]. The most recent cycle is moved
+// if no cycle is given, and all the cycles of src are then removed.
+// dst has the format [path/to/]name. If dst is an existing directory, the
+// object is moved into that directory, under its current name. If dst is an
+// existing object, the moved object becomes the most recent cycle of dst.
+// Directories can not be moved.
+func Move(dir Directory, src, dst string) error {
+	sname, cycle := decodeNameCycle(src)
+	sdir, sname, err := dirFileOf(dir, sname)
+	if err != nil {
+		return err
+	}
+	if sdir.file.w == nil {
+		return fmt.Errorf("riofs: could not move %q: %w", src, ErrReadOnly)
+	}
+
+	obj, err := sdir.Get(fmt.Sprintf("%s;%d", sname, cycle))
+	if err != nil {
+		return err
+	}
+	if _, ok := obj.(Directory); ok {
+		return fmt.Errorf("riofs: could not move %q: moving directories is not supported", src)
+	}
+
+	dst = strings.Trim(dst, "/")
+	if o, err := Dir(dir).Get(dst); err == nil {
+		if _, ok := o.(Directory); ok {
+			dst = stdpath.Join(dst, sname)
+		}
+	}
+	if pdir := stdpath.Dir(dst); pdir != "." {
+		_, err = Dir(dir).Mkdir(pdir)
+		if err != nil {
+			return fmt.Errorf("riofs: could not create parent directory %q for %q: %w", pdir, dst, err)
+		}
+	}
+
+	ddir, dname, err := dirFileOf(dir, dst)
+	if err != nil {
+		return err
+	}
+	if ddir == sdir && dname == sname {
+		return nil
+	}
+
+	err = ddir.Put(dname, obj)
+	if err != nil {
+		return fmt.Errorf("riofs: could not move %q to %q: %w", src, dst, err)
+	}
+
+	return sdir.del(sname, cycle)
+}
+
+// Replace puts the provided object under the given name in the provided
+// directory, and removes all the previous cycles of that object, releasing
+// their records on file.
+// name has the format [path/to/]name.
+func Replace(dir Directory, name string, obj root.Object) error {
+	d, name, err := dirFileOf(dir, name)
+	if err != nil {
+		return err
+	}
+	return d.replace(name, obj)
+}
+
+// dirFileOf returns the directory holding the provided [path/to/]name,
+// relative to dir, and the base name.
+func dirFileOf(dir Directory, name string) (*tdirectoryFile, string, error) {
+	pdir, name := stdpath.Split(strings.TrimPrefix(name, "/"))
+	pdir = strings.TrimRight(pdir, "/")
+	if pdir != "" {
+		o, err := Dir(dir).Get(pdir)
+		if err != nil {
+			return nil, name, err
+		}
+		d, ok := o.(Directory)
+		if !ok {
+			return nil, name, fmt.Errorf("riofs: not a directory %q", pdir)
+		}
+		dir = d
+	}
+
+	for {
+		switch d := dir.(type) {
+		case *File:
+			return &d.dir, name, nil
+		case *tdirectoryFile:
+			return d, name, nil
+		case *recDir:
+			dir = d.dir
+		default:
+			return nil, name, fmt.Errorf("riofs: unknown Directory type %T", d)
+		}
+	}
+}
+
 var (
 	_ Directory = (*recDir)(nil)
 )
@@ -204,14 +204,20 @@ func TestRecDirMkdir(t *testing.T) {
 		})
 	}
 
-	// test recursive mkdir does not work on f.
+	// test nested mkdir works on f.
+	_, err = f.Mkdir("xdir/xsubdir")
+	if err != nil {
+		t.Fatalf("could not create nested directory: %+v\ncontent:\n%v", err, display())
+	}
+	if _, err := rd.Get("xdir/xsubdir"); err != nil {
+		t.Fatalf("could not get nested directory: %+v\ncontent:\n%v", err, display())
+	}
+
+	// test nested mkdir fails when the leaf directory already exists.
 	_, err = f.Mkdir("xdir/xsubdir")
 	if err == nil {
 		t.Fatalf("expected an error, got=%v\ncontent:\n%v", err, display())
 	}
-	if got, want := err.Error(), fmt.Errorf("riofs: invalid directory name %q (contains a '/')", "xdir/xsubdir").Error(); got != want {
-		t.Fatalf("invalid error. got=%q, want=%q", got, want)
-	}
 
 	// test regular mkdir fails when directory already exists
 	_, err = f.Mkdir("dir1")
@@ -83,6 +83,26 @@ func CxxTemplateFrom(typename string) CxxTemplate {
 	return cxx
 }
 
+// IsStdPairOfBuiltins returns whether the provided typename is a
+// std::pair<T1,T2> of C++ builtins, such as 'pair<double,double>'.
+// ROOT does not store streamers for such types in files.
+func IsStdPairOfBuiltins(typename string) bool {
+	name := strings.TrimSpace(typename)
+	if !(strings.HasPrefix(name, "pair<") || strings.HasPrefix(name, "std::pair<")) || !strings.HasSuffix(name, ">") {
+		return false
+	}
+	cxx := CxxTemplateFrom(name)
+	if len(cxx.Args) != 2 {
+		return false
+	}
+	for _, arg := range cxx.Args {
+		if _, ok := CxxBuiltins[arg]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // TypeName2Enum returns the Enum corresponding to the provided C++ (or Go) typename.
 func TypeName2Enum(typename string) (Enum, bool) {
 	switch typename {
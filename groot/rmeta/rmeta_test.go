@@ -324,3 +324,23 @@ func TestCxxTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestIsStdPairOfBuiltins(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want bool
+	}{
+		{"pair<double,double>", true},
+		{"std::pair<int, unsigned int>", true},
+		{"pair<int,TObject>", false},
+		{"pair<int,vector<int> >", false},
+		{"vector<double>", false},
+		{"double", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := rmeta.IsStdPairOfBuiltins(tc.name), tc.want; got != want {
+				t.Fatalf("invalid value: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
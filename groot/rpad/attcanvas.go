@@ -0,0 +1,67 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// AttCanvas holds the attributes of a canvas.
+type AttCanvas struct {
+	fXBetween     float32 // X distance between pads
+	fYBetween     float32 // Y distance between pads
+	fTitleFromTop float32 // Y distance of Global Title from top
+	fXdate        float32 // X position where to draw the date
+	fYdate        float32 // X position where to draw the date
+	fAdate        float32 // Alignment for the date
+}
+
+func (*AttCanvas) Class() string {
+	return "TAttCanvas"
+}
+
+func (*AttCanvas) RVersion() int16 {
+	return rvers.AttCanvas
+}
+
+func (att *AttCanvas) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(att.Class())
+	if hdr.Vers > rvers.AttCanvas {
+		panic(fmt.Errorf("rpad: invalid attcanvas version=%d > %d", hdr.Vers, rvers.AttCanvas))
+	}
+
+	att.fXBetween = r.ReadF32()
+	att.fYBetween = r.ReadF32()
+	att.fTitleFromTop = r.ReadF32()
+	att.fXdate = r.ReadF32()
+	att.fYdate = r.ReadF32()
+	att.fAdate = r.ReadF32()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		var v AttCanvas
+		return reflect.ValueOf(&v)
+	}
+	rtypes.Factory.Add("TAttCanvas", f)
+}
+
+var (
+	_ root.Object        = (*AttCanvas)(nil)
+	_ rbytes.Unmarshaler = (*AttCanvas)(nil)
+)
@@ -0,0 +1,127 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Box is a ROOT TBox: a rectangle in user coordinates.
+type Box struct {
+	obj     rbase.Object
+	attline rbase.AttLine
+	attfill rbase.AttFill
+
+	x1 float64 // X of 1st point
+	y1 float64 // Y of 1st point
+	x2 float64 // X of 2nd point
+	y2 float64 // Y of 2nd point
+}
+
+// NewBox creates a new box with the provided corners.
+func NewBox(x1, y1, x2, y2 float64) *Box {
+	return &Box{
+		obj:     *rbase.NewObject(),
+		attline: *rbase.NewAttLine(),
+		attfill: *rbase.NewAttFill(),
+		x1:      x1,
+		y1:      y1,
+		x2:      x2,
+		y2:      y2,
+	}
+}
+
+func (*Box) Class() string {
+	return "TBox"
+}
+
+func (*Box) RVersion() int16 {
+	return rvers.Box
+}
+
+// Corners returns the lower-left and upper-right corners of the box.
+func (b *Box) Corners() (x1, y1, x2, y2 float64) {
+	return b.x1, b.y1, b.x2, b.y2
+}
+
+// AttLine returns the line attributes of the box.
+func (b *Box) AttLine() *rbase.AttLine { return &b.attline }
+
+// AttFill returns the fill attributes of the box.
+func (b *Box) AttFill() *rbase.AttFill { return &b.attfill }
+
+func (b *Box) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(b.Class(), b.RVersion())
+	w.WriteObject(&b.obj)
+	w.WriteObject(&b.attline)
+	w.WriteObject(&b.attfill)
+	writeBBox2D(w)
+	w.WriteF64(b.x1)
+	w.WriteF64(b.y1)
+	w.WriteF64(b.x2)
+	w.WriteF64(b.y2)
+
+	return w.SetHeader(hdr)
+}
+
+func (b *Box) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(b.Class())
+	if hdr.Vers > rvers.Box {
+		panic(fmt.Errorf("rpad: invalid box version=%d > %d", hdr.Vers, rvers.Box))
+	}
+
+	r.ReadObject(&b.obj)
+	r.ReadObject(&b.attline)
+	r.ReadObject(&b.attfill)
+	readBBox2D(r)
+	b.x1 = r.ReadF64()
+	b.y1 = r.ReadF64()
+	b.x2 = r.ReadF64()
+	b.y2 = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// writeBBox2D writes the TAttBBox2D base class, which holds no data.
+func writeBBox2D(w *rbytes.WBuffer) {
+	hdr := w.WriteHeader("TAttBBox2D", rvers.AttBBox2D)
+	_, _ = w.SetHeader(hdr)
+}
+
+// readBBox2D reads the TAttBBox2D base class, which holds no data.
+func readBBox2D(r *rbytes.RBuffer) {
+	hdr := r.ReadHeader("TAttBBox2D")
+	r.CheckHeader(hdr)
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewBox(0, 0, 0, 0)
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TBox", f)
+}
+
+var (
+	_ root.Object        = (*Box)(nil)
+	_ rbytes.Marshaler   = (*Box)(nil)
+	_ rbytes.Unmarshaler = (*Box)(nil)
+)
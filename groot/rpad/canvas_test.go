@@ -0,0 +1,84 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/rpad"
+)
+
+func TestCanvasRead(t *testing.T) {
+	f, err := groot.Open("../testdata/tcanvas.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := o.(*rpad.Canvas)
+
+	if got, want := c.Name(), "c1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	if got, want := c.Title(), "c1-title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+
+	if got, want := c.Class(), "TCanvas"; got != want {
+		t.Fatalf("invalid class: got=%q, want=%q", got, want)
+	}
+
+	if w, h := c.Size(); w != 296 || h != 372 {
+		t.Fatalf("invalid size: got=(%d,%d), want=(296,372)", w, h)
+	}
+
+	if xlow, ylow, xup, yup := c.NDC(); xlow != 0 || ylow != 0 || xup != 1 || yup != 1 {
+		t.Fatalf("invalid NDC: got=(%v,%v,%v,%v)", xlow, ylow, xup, yup)
+	}
+
+	if c.Logx() || c.Logy() || c.Gridx() || c.Gridy() {
+		t.Fatalf("invalid log/grid flags")
+	}
+
+	if got, want := c.Keys(), []string{"Graph"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys: got=%q, want=%q", got, want)
+	}
+
+	prims := c.Primitives()
+	if got, want := len(prims), 1; got != want {
+		t.Fatalf("invalid number of primitives: got=%d, want=%d", got, want)
+	}
+
+	o, err = c.Get("Graph")
+	if err != nil {
+		t.Fatalf("could not retrieve graph: %+v", err)
+	}
+	gr, ok := o.(rhist.Graph)
+	if !ok {
+		t.Fatalf("invalid primitive type: got=%T", o)
+	}
+	if got, want := gr.Len(), 5; got != want {
+		t.Fatalf("invalid graph length: got=%d, want=%d", got, want)
+	}
+	for i, want := range [][2]float64{{0, 0}, {1, 2}, {2, 4}, {3, 1}, {4, 3}} {
+		x, y := gr.XY(i)
+		if x != want[0] || y != want[1] {
+			t.Fatalf("invalid point %d: got=(%v,%v), want=%v", i, x, y, want)
+		}
+	}
+
+	_, err = c.Get("not-there")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
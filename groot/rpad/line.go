@@ -0,0 +1,122 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Line is a ROOT TLine: a segment between two points.
+type Line struct {
+	obj     rbase.Object
+	attline rbase.AttLine
+
+	x1 float64 // X of 1st point
+	y1 float64 // Y of 1st point
+	x2 float64 // X of 2nd point
+	y2 float64 // Y of 2nd point
+}
+
+// NewLine creates a new line between (x1,y1) and (x2,y2), in user coordinates.
+func NewLine(x1, y1, x2, y2 float64) *Line {
+	return &Line{
+		obj:     *rbase.NewObject(),
+		attline: *rbase.NewAttLine(),
+		x1:      x1,
+		y1:      y1,
+		x2:      x2,
+		y2:      y2,
+	}
+}
+
+func (*Line) Class() string {
+	return "TLine"
+}
+
+func (*Line) RVersion() int16 {
+	return rvers.Line
+}
+
+// Points returns the end points of the line.
+func (l *Line) Points() (x1, y1, x2, y2 float64) {
+	return l.x1, l.y1, l.x2, l.y2
+}
+
+// NDC returns whether the end points are in normalized coordinates
+// of the pad, instead of user coordinates.
+func (l *Line) NDC() bool { return l.obj.TestBits(kNDC) }
+
+// SetNDC sets whether the end points are in normalized coordinates.
+func (l *Line) SetNDC(v bool) {
+	switch v {
+	case true:
+		l.obj.SetBit(kNDC)
+	default:
+		l.obj.ResetBit(kNDC)
+	}
+}
+
+// AttLine returns the line attributes of the line.
+func (l *Line) AttLine() *rbase.AttLine { return &l.attline }
+
+func (l *Line) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(l.Class(), l.RVersion())
+	w.WriteObject(&l.obj)
+	w.WriteObject(&l.attline)
+	writeBBox2D(w)
+	w.WriteF64(l.x1)
+	w.WriteF64(l.y1)
+	w.WriteF64(l.x2)
+	w.WriteF64(l.y2)
+
+	return w.SetHeader(hdr)
+}
+
+func (l *Line) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(l.Class())
+	if hdr.Vers > rvers.Line {
+		panic(fmt.Errorf("rpad: invalid line version=%d > %d", hdr.Vers, rvers.Line))
+	}
+
+	r.ReadObject(&l.obj)
+	r.ReadObject(&l.attline)
+	readBBox2D(r)
+	l.x1 = r.ReadF64()
+	l.y1 = r.ReadF64()
+	l.x2 = r.ReadF64()
+	l.y2 = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewLine(0, 0, 0, 0)
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TLine", f)
+}
+
+var (
+	_ root.Object        = (*Line)(nil)
+	_ rbytes.Marshaler   = (*Line)(nil)
+	_ rbytes.Unmarshaler = (*Line)(nil)
+)
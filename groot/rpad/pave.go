@@ -0,0 +1,249 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Pave is a ROOT TPave: a box with a border and a shadow, positioned
+// in normalized coordinates of the pad.
+type Pave struct {
+	Box
+
+	x1NDC        float64 // X1 point in NDC coordinates
+	y1NDC        float64 // Y1 point in NDC coordinates
+	x2NDC        float64 // X2 point in NDC coordinates
+	y2NDC        float64 // Y2 point in NDC coordinates
+	borderSize   int32   // window box bordersize in pixels
+	ndcInit      int32   // (=0 if transformation to NDC not yet done)
+	shadowColor  int32   // Color of the pave's shadow
+	cornerRadius float64 // Corner radius in case of option arc
+	option       string  // Pave style
+	name         string  // Pave name
+}
+
+// NewPave creates a new pave with the provided corners, in normalized
+// coordinates of the pad.
+func NewPave(x1, y1, x2, y2 float64, opt string) *Pave {
+	return &Pave{
+		Box:         *NewBox(x1, y1, x2, y2),
+		x1NDC:       x1,
+		y1NDC:       y1,
+		x2NDC:       x2,
+		y2NDC:       y2,
+		borderSize:  4,
+		shadowColor: 1,
+		option:      opt,
+		name:        "TPave",
+	}
+}
+
+func (*Pave) Class() string {
+	return "TPave"
+}
+
+func (*Pave) RVersion() int16 {
+	return rvers.Pave
+}
+
+func (p *Pave) Name() string  { return p.name }
+func (p *Pave) Title() string { return "" }
+
+// SetName sets the name of the pave.
+// ROOT names "title" the pave text displaying the title of a plot.
+func (p *Pave) SetName(name string) { p.name = name }
+
+// Option returns the drawing option of the pave.
+func (p *Pave) Option() string { return p.option }
+
+// CornersNDC returns the lower-left and upper-right corners of the pave,
+// in normalized coordinates of the pad.
+func (p *Pave) CornersNDC() (x1, y1, x2, y2 float64) {
+	return p.x1NDC, p.y1NDC, p.x2NDC, p.y2NDC
+}
+
+func (p *Pave) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(p.Class(), p.RVersion())
+	w.WriteObject(&p.Box)
+	w.WriteF64(p.x1NDC)
+	w.WriteF64(p.y1NDC)
+	w.WriteF64(p.x2NDC)
+	w.WriteF64(p.y2NDC)
+	w.WriteI32(p.borderSize)
+	w.WriteI32(p.ndcInit)
+	w.WriteI32(p.shadowColor)
+	w.WriteF64(p.cornerRadius)
+	w.WriteString(p.option)
+	w.WriteString(p.name)
+
+	return w.SetHeader(hdr)
+}
+
+func (p *Pave) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(p.Class())
+	if hdr.Vers > rvers.Pave {
+		panic(fmt.Errorf("rpad: invalid pave version=%d > %d", hdr.Vers, rvers.Pave))
+	}
+
+	r.ReadObject(&p.Box)
+	p.x1NDC = r.ReadF64()
+	p.y1NDC = r.ReadF64()
+	p.x2NDC = r.ReadF64()
+	p.y2NDC = r.ReadF64()
+	p.borderSize = r.ReadI32()
+	p.ndcInit = r.ReadI32()
+	p.shadowColor = r.ReadI32()
+	p.cornerRadius = r.ReadF64()
+	p.option = r.ReadString()
+	p.name = r.ReadString()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// PaveText is a ROOT TPaveText: a pave holding lines of text.
+type PaveText struct {
+	Pave
+	atttext rbase.AttText
+
+	label   string      // Label written at the top of the pavetext
+	longest int32       // Length of the longest line
+	margin  float32     // Text margin
+	lines   *rcont.List // List of labels
+}
+
+// NewPaveText creates a new, empty, pave text with the provided corners,
+// in normalized coordinates of the pad.
+func NewPaveText(x1, y1, x2, y2 float64, opt string) *PaveText {
+	return &PaveText{
+		Pave:    *NewPave(x1, y1, x2, y2, opt),
+		atttext: *rbase.NewAttText(),
+		margin:  0.05,
+		lines:   rcont.NewList("", nil),
+	}
+}
+
+func (*PaveText) Class() string {
+	return "TPaveText"
+}
+
+func (*PaveText) RVersion() int16 {
+	return rvers.PaveText
+}
+
+// AttText returns the text attributes of the pave text.
+func (p *PaveText) AttText() *rbase.AttText { return &p.atttext }
+
+// Label returns the label written at the top of the pave text.
+func (p *PaveText) Label() string { return p.label }
+
+// Lines returns the lines of the pave text.
+// Lines are usually TText, TLatex or TLine values.
+func (p *PaveText) Lines() []root.Object {
+	if p.lines == nil {
+		return nil
+	}
+	objs := make([]root.Object, p.lines.Len())
+	for i := range objs {
+		objs[i] = p.lines.At(i)
+	}
+	return objs
+}
+
+// AddText appends a new line of text to the pave text.
+func (p *PaveText) AddText(txt string) *Latex {
+	ltx := NewLatex(0, 0, txt)
+	if p.lines == nil {
+		p.lines = rcont.NewList("", nil)
+	}
+	p.lines.Append(ltx)
+	return ltx
+}
+
+func (p *PaveText) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(p.Class(), p.RVersion())
+	w.WriteObject(&p.Pave)
+	w.WriteObject(&p.atttext)
+	w.WriteString(p.label)
+	w.WriteI32(p.longest)
+	w.WriteF32(p.margin)
+	w.WriteObjectAny(p.lines)
+
+	return w.SetHeader(hdr)
+}
+
+func (p *PaveText) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(p.Class())
+	if hdr.Vers > rvers.PaveText {
+		panic(fmt.Errorf("rpad: invalid pavetext version=%d > %d", hdr.Vers, rvers.PaveText))
+	}
+
+	r.ReadObject(&p.Pave)
+	r.ReadObject(&p.atttext)
+	p.label = r.ReadString()
+	p.longest = r.ReadI32()
+	p.margin = r.ReadF32()
+	p.lines = nil
+	if lines := r.ReadObjectAny(); lines != nil {
+		p.lines = lines.(*rcont.List)
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := NewPave(0, 0, 0, 0, "")
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TPave", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := NewPaveText(0, 0, 0, 0, "")
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TPaveText", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Pave)(nil)
+	_ root.Named         = (*Pave)(nil)
+	_ rbytes.Marshaler   = (*Pave)(nil)
+	_ rbytes.Unmarshaler = (*Pave)(nil)
+
+	_ root.Object        = (*PaveText)(nil)
+	_ root.Named         = (*PaveText)(nil)
+	_ rbytes.Marshaler   = (*PaveText)(nil)
+	_ rbytes.Unmarshaler = (*PaveText)(nil)
+)
@@ -0,0 +1,12 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rpad contains the definitions of ROOT graphics classes:
+// canvases, pads and the graphics primitives (boxes, lines, texts and
+// pave texts) drawn in them.
+package rpad // import "go-hep.org/x/hep/groot/rpad"
+
+const (
+	kNDC = 1 << 14 // kLineNDC, kTextNDC: coordinates are NDC.
+)
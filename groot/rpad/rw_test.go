@@ -0,0 +1,104 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+func TestWRBuffer(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want rtests.ROOTer
+	}{
+		{
+			name: "TBox",
+			want: NewBox(1, 2, 3, 4),
+		},
+		{
+			name: "TLine",
+			want: func() *Line {
+				l := NewLine(0.1, 0.2, 0.3, 0.4)
+				l.SetNDC(true)
+				l.AttLine().Color = 2
+				return l
+			}(),
+		},
+		{
+			name: "TText",
+			want: NewText(1, 2, "text"),
+		},
+		{
+			name: "TLatex",
+			want: func() *Latex {
+				ltx := NewLatex(0.5, 0.6, "#sqrt{s} = 13 TeV")
+				ltx.SetNDC(true)
+				ltx.AttText().Size = 0.03
+				return ltx
+			}(),
+		},
+		{
+			name: "TPave",
+			want: NewPave(0.1, 0.2, 0.3, 0.4, "brNDC"),
+		},
+		{
+			name: "TPaveText",
+			want: func() *PaveText {
+				pt := NewPaveText(0.1, 0.8, 0.5, 0.9, "NDC")
+				pt.AddText("line-1")
+				pt.AddText("line-2")
+				return pt
+			}(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			{
+				wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+				wbuf.SetErr(io.EOF)
+				_, err := tc.want.MarshalROOT(wbuf)
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if err != io.EOF {
+					t.Fatalf("got=%v, want=%v", err, io.EOF)
+				}
+			}
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+			_, err := tc.want.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal ROOT: %v", err)
+			}
+
+			rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil)
+			class := tc.want.Class()
+			obj := rtypes.Factory.Get(class)().Interface().(rbytes.Unmarshaler)
+			{
+				rbuf.SetErr(io.EOF)
+				err = obj.UnmarshalROOT(rbuf)
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if err != io.EOF {
+					t.Fatalf("got=%v, want=%v", err, io.EOF)
+				}
+				rbuf.SetErr(nil)
+			}
+			err = obj.UnmarshalROOT(rbuf)
+			if err != nil {
+				t.Fatalf("could not unmarshal ROOT: %v", err)
+			}
+
+			if !reflect.DeepEqual(obj, tc.want) {
+				t.Fatalf("error\ngot= %+v\nwant=%+v\n", obj, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Text is a ROOT TText: a text string at a given position.
+type Text struct {
+	named   rbase.Named
+	atttext rbase.AttText
+
+	x float64 // X position of text (left,center,etc..)
+	y float64 // Y position of text (left,center,etc..)
+}
+
+// NewText creates a new text at position (x,y), in user coordinates.
+func NewText(x, y float64, txt string) *Text {
+	return &Text{
+		named:   *rbase.NewNamed("", txt),
+		atttext: *rbase.NewAttText(),
+		x:       x,
+		y:       y,
+	}
+}
+
+func (*Text) Class() string {
+	return "TText"
+}
+
+func (*Text) RVersion() int16 {
+	return rvers.Text
+}
+
+func (txt *Text) Name() string  { return txt.named.Name() }
+func (txt *Text) Title() string { return txt.named.Title() }
+
+// Text returns the text string.
+func (txt *Text) Text() string { return txt.named.Title() }
+
+// Pos returns the position of the text.
+func (txt *Text) Pos() (x, y float64) { return txt.x, txt.y }
+
+// NDC returns whether the position is in normalized coordinates
+// of the pad, instead of user coordinates.
+func (txt *Text) NDC() bool { return txt.named.TestBits(kNDC) }
+
+// SetNDC sets whether the position is in normalized coordinates.
+func (txt *Text) SetNDC(v bool) {
+	switch v {
+	case true:
+		txt.named.SetBit(kNDC)
+	default:
+		txt.named.ResetBit(kNDC)
+	}
+}
+
+// AttText returns the text attributes of the text.
+func (txt *Text) AttText() *rbase.AttText { return &txt.atttext }
+
+func (txt *Text) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(txt.Class(), txt.RVersion())
+	w.WriteObject(&txt.named)
+	w.WriteObject(&txt.atttext)
+	writeBBox2D(w)
+	w.WriteF64(txt.x)
+	w.WriteF64(txt.y)
+
+	return w.SetHeader(hdr)
+}
+
+func (txt *Text) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(txt.Class())
+	if hdr.Vers > rvers.Text {
+		panic(fmt.Errorf("rpad: invalid text version=%d > %d", hdr.Vers, rvers.Text))
+	}
+
+	r.ReadObject(&txt.named)
+	r.ReadObject(&txt.atttext)
+	readBBox2D(r)
+	txt.x = r.ReadF64()
+	txt.y = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// Latex is a ROOT TLatex: a text string using the ROOT LaTeX-like syntax.
+type Latex struct {
+	Text
+	attline rbase.AttLine
+
+	factorSize      float64 // relative size of subscripts and superscripts
+	factorPos       float64 // relative position of subscripts and superscripts
+	limitFactorSize int32   // lower bound for subscripts/superscripts size
+	originSize      float64 // font size of the starting font
+}
+
+// NewLatex creates a new LaTeX text at position (x,y), in user coordinates.
+func NewLatex(x, y float64, txt string) *Latex {
+	return &Latex{
+		Text:            *NewText(x, y, txt),
+		attline:         *rbase.NewAttLine(),
+		factorSize:      1.5,
+		factorPos:       0.6,
+		limitFactorSize: 3,
+		originSize:      0.04,
+	}
+}
+
+func (*Latex) Class() string {
+	return "TLatex"
+}
+
+func (*Latex) RVersion() int16 {
+	return rvers.Latex
+}
+
+func (ltx *Latex) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(ltx.Class(), ltx.RVersion())
+	w.WriteObject(&ltx.Text)
+	w.WriteObject(&ltx.attline)
+	w.WriteF64(ltx.factorSize)
+	w.WriteF64(ltx.factorPos)
+	w.WriteI32(ltx.limitFactorSize)
+	w.WriteF64(ltx.originSize)
+
+	return w.SetHeader(hdr)
+}
+
+func (ltx *Latex) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(ltx.Class())
+	if hdr.Vers > rvers.Latex {
+		panic(fmt.Errorf("rpad: invalid latex version=%d > %d", hdr.Vers, rvers.Latex))
+	}
+
+	r.ReadObject(&ltx.Text)
+	r.ReadObject(&ltx.attline)
+	ltx.factorSize = r.ReadF64()
+	ltx.factorPos = r.ReadF64()
+	ltx.limitFactorSize = r.ReadI32()
+	ltx.originSize = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := NewText(0, 0, "")
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TText", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := NewLatex(0, 0, "")
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TLatex", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Text)(nil)
+	_ root.Named         = (*Text)(nil)
+	_ rbytes.Marshaler   = (*Text)(nil)
+	_ rbytes.Unmarshaler = (*Text)(nil)
+
+	_ root.Object        = (*Latex)(nil)
+	_ root.Named         = (*Latex)(nil)
+	_ rbytes.Marshaler   = (*Latex)(nil)
+	_ rbytes.Unmarshaler = (*Latex)(nil)
+)
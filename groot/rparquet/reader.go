@@ -0,0 +1,214 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rparquet // import "go-hep.org/x/hep/groot/rparquet"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+// ReadTree converts the content of the Parquet file fname into a new tree
+// named name, created under the provided directory, and returns the number
+// of written entries.
+func ReadTree(dir riofs.Directory, name, fname string, opts ...Option) (int64, error) {
+	cfg := newConfig(opts)
+
+	pr, err := ntparquet.Open(fname)
+	if err != nil {
+		return 0, fmt.Errorf("rparquet: could not open Parquet file %q: %w", fname, err)
+	}
+	defer pr.Close()
+
+	sch := schemaOf(pr)
+	cols, err := sch.selected(cfg.branches)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pred := range cfg.preds {
+		col, ok := sch.cols[pred.name]
+		if !ok {
+			return 0, fmt.Errorf("rparquet: no column %q for predicate", pred.name)
+		}
+		switch col.rtype.Kind() {
+		case reflect.Bool, reflect.String, reflect.Slice:
+			return 0, fmt.Errorf("rparquet: invalid predicate column %q of type %v", pred.name, col.rtype)
+		}
+	}
+
+	var (
+		wvars = make([]rtree.WriteVar, 0, len(cols))
+		vals  = make([]reflect.Value, len(cols))
+		cnts  = make(map[int]reflect.Value) // count values of LIST columns
+		sel   = make(map[string]int, len(cols))
+	)
+	for i, col := range cols {
+		sel[col.name] = i
+	}
+	for i, col := range cols {
+		vals[i] = reflect.New(col.rtype)
+		if col.rtype.Kind() != reflect.Slice {
+			continue
+		}
+		cname, _ := pr.Metadata(countKey + col.name)
+		if j, ok := sel[cname]; !ok || cols[j].rtype.Kind() != reflect.Int32 {
+			cname = "rparquet_n_" + col.name
+			cnt := reflect.New(reflect.TypeOf(int32(0)))
+			cnts[i] = cnt.Elem()
+			wvars = append(wvars, rtree.WriteVar{Name: cname, Value: cnt.Interface()})
+		}
+		cols[i].count = cname
+	}
+	for i, col := range cols {
+		wvars = append(wvars, rtree.WriteVar{
+			Name:  col.name,
+			Value: vals[i].Interface(),
+			Count: col.count,
+		})
+	}
+
+	w, err := rtree.NewWriter(dir, name, wvars, cfg.wopts...)
+	if err != nil {
+		return 0, fmt.Errorf("rparquet: could not create tree writer: %w", err)
+	}
+	defer w.Close()
+
+	var n int64
+	for i := 0; i < pr.NumRowGroups(); i++ {
+		rg := pr.RowGroup(i)
+		if !match(rg, cfg.preds) {
+			continue
+		}
+
+		rows, err := filter(rg, cfg.preds)
+		if err != nil {
+			return n, fmt.Errorf("rparquet: could not evaluate predicates on row-group %d: %w", i, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		data := make([]reflect.Value, len(cols))
+		for j, col := range cols {
+			data[j] = reflect.New(reflect.SliceOf(col.rtype))
+			err = rg.Column(col.name, data[j].Interface())
+			if err != nil {
+				return n, fmt.Errorf("rparquet: could not read row-group %d: %w", i, err)
+			}
+			data[j] = data[j].Elem()
+		}
+
+		for _, row := range rows {
+			for k := range vals {
+				v := data[k].Index(row)
+				vals[k].Elem().Set(v)
+				if cnt, ok := cnts[k]; ok {
+					cnt.SetInt(int64(v.Len()))
+				}
+			}
+			_, err = w.Write()
+			if err != nil {
+				return n, fmt.Errorf("rparquet: could not write entry %d: %w", n, err)
+			}
+			n++
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return n, fmt.Errorf("rparquet: could not close tree writer: %w", err)
+	}
+
+	return n, nil
+}
+
+// schema describes the top-level columns of a Parquet file.
+type schema struct {
+	cols  map[string]column
+	order []string
+}
+
+// schemaOf returns the schema of the Parquet file read by the provided
+// reader, mapped to ROOT types.
+func schemaOf(pr *ntparquet.Reader) *schema {
+	sch := &schema{cols: make(map[string]column)}
+	for _, desc := range pr.Cols() {
+		col := column{
+			name:  desc.Name(),
+			rtype: desc.Type(),
+			ptype: desc.Type(),
+		}
+		sch.cols[col.name] = col
+		sch.order = append(sch.order, col.name)
+	}
+	return sch
+}
+
+// selected returns the columns of the schema with the provided names,
+// or all the columns if no name is provided.
+func (sch *schema) selected(names []string) ([]column, error) {
+	if len(names) == 0 {
+		names = sch.order
+	}
+	cols := make([]column, 0, len(names))
+	for _, name := range names {
+		col, ok := sch.cols[name]
+		if !ok {
+			return nil, fmt.Errorf("rparquet: no column %q in Parquet file", name)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// predicate selects rows whose value for a column is within [min, max].
+type predicate struct {
+	name string
+	min  float64
+	max  float64
+}
+
+// match returns whether the provided row-group may hold rows satisfying
+// all the predicates, according to the statistics of its columns.
+func match(rg *ntparquet.RowGroup, preds []predicate) bool {
+	for _, pred := range preds {
+		min, max, ok := rg.Stats(pred.name)
+		if ok && (max < pred.min || pred.max < min) {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the indices of the rows of the provided row-group
+// satisfying all the predicates.
+// Rows holding a null value for a predicate column are not selected.
+func filter(rg *ntparquet.RowGroup, preds []predicate) ([]int, error) {
+	rows := make([]int, rg.NumRows())
+	for i := range rows {
+		rows[i] = i
+	}
+	for _, pred := range preds {
+		var xs []*float64
+		err := rg.Column(pred.name, &xs)
+		if err != nil {
+			return nil, err
+		}
+		sel := rows[:0]
+		for _, i := range rows {
+			x := xs[i]
+			if x == nil || *x < pred.min || pred.max < *x {
+				continue
+			}
+			sel = append(sel, i)
+		}
+		rows = sel
+	}
+	return rows, nil
+}
@@ -0,0 +1,186 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rparquet handles conversions between ROOT trees and Parquet files.
+//
+// Parquet files are read and written with the hbook/ntup/ntparquet package.
+// ROOT types are mapped to Parquet types as follows:
+//
+//   - bool: BOOLEAN
+//   - int8, int16, int32: INT32 (with the INT_8, INT_16 and INT_32 converted types)
+//   - uint8, uint16, uint32: INT32 (with the UINT_8, UINT_16 and UINT_32 converted types)
+//   - int64: INT64 (with the INT_64 converted type)
+//   - uint64: INT64 (with the UINT_64 converted type)
+//   - float32 (including Float16_t): FLOAT
+//   - float64 (including Double32_t): DOUBLE
+//   - string: BYTE_ARRAY (with the UTF8 converted type)
+//   - fixed-size arrays, variable-length arrays and std::vector of the above: LIST
+//
+// Parquet files are mapped back to ROOT trees with the reverse rules.
+// LIST columns are converted to variable-length arrays, whose sizes are
+// stored in a dedicated count branch: the count branch of the original tree
+// when the Parquet file was created by WriteTree, a branch named
+// "rparquet_n_<column>" otherwise.
+// INT32 and INT64 columns with other converted types (e.g. DATE or
+// TIMESTAMP_MILLIS) are stored as int32 and int64 values.
+// OPTIONAL values are supported: null values are stored as zero values,
+// and null lists as empty arrays.
+// Other Parquet types and nested groups are not supported.
+package rparquet // import "go-hep.org/x/hep/groot/rparquet"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+// countKey is the prefix of the key-value metadata entries associating
+// a LIST column to the count branch of its variable-length array.
+const countKey = "rparquet.count."
+
+// Option configures how trees and Parquet files are converted.
+type Option func(cfg *config)
+
+type config struct {
+	branches []string
+	popts    []ntparquet.Option
+	preds    []predicate
+	wopts    []rtree.WriteOption
+}
+
+func newConfig(opts []Option) *config {
+	cfg := new(config)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithBranches selects the branches (or Parquet columns) to convert.
+// All of them are converted by default.
+func WithBranches(names ...string) Option {
+	return func(cfg *config) {
+		cfg.branches = append(cfg.branches, names...)
+	}
+}
+
+// WithParquetOptions configures the Parquet files created by WriteTree,
+// e.g. their compression codec or the number of rows of their row groups.
+// By default, files are compressed with Snappy.
+func WithParquetOptions(opts ...ntparquet.Option) Option {
+	return func(cfg *config) {
+		cfg.popts = append(cfg.popts, opts...)
+	}
+}
+
+// WithPredicate selects the rows of the Parquet file read by ReadTree
+// whose value for the provided scalar numerical column is in [min, max].
+// Multiple predicates are combined with a logical AND.
+//
+// Predicates are pushed down to the Parquet reader: row-groups whose
+// statistics show they hold no matching rows are skipped altogether.
+// Columns used in predicates do not need to be converted.
+func WithPredicate(column string, min, max float64) Option {
+	return func(cfg *config) {
+		cfg.preds = append(cfg.preds, predicate{name: column, min: min, max: max})
+	}
+}
+
+// WithWriteOptions configures the tree created by ReadTree.
+func WithWriteOptions(opts ...rtree.WriteOption) Option {
+	return func(cfg *config) {
+		cfg.wopts = append(cfg.wopts, opts...)
+	}
+}
+
+// column describes how a ROOT value is mapped to a Parquet column.
+type column struct {
+	name  string       // name of the Parquet column
+	rtype reflect.Type // type of the ROOT value
+	ptype reflect.Type // type of the Go field holding the Parquet value
+	count string       // name of the count branch, for variable-length arrays
+}
+
+// scalarType returns the Go type holding Parquet values of the provided
+// ROOT scalar type.
+func scalarType(rt reflect.Type) (reflect.Type, error) {
+	switch rt.Kind() {
+	case reflect.Bool:
+		return reflect.TypeOf(false), nil
+	case reflect.Int8:
+		return reflect.TypeOf(int8(0)), nil
+	case reflect.Int16:
+		return reflect.TypeOf(int16(0)), nil
+	case reflect.Int32:
+		return reflect.TypeOf(int32(0)), nil
+	case reflect.Int64:
+		return reflect.TypeOf(int64(0)), nil
+	case reflect.Uint8:
+		return reflect.TypeOf(uint8(0)), nil
+	case reflect.Uint16:
+		return reflect.TypeOf(uint16(0)), nil
+	case reflect.Uint32:
+		return reflect.TypeOf(uint32(0)), nil
+	case reflect.Uint64:
+		return reflect.TypeOf(uint64(0)), nil
+	case reflect.Float32:
+		return reflect.TypeOf(float32(0)), nil
+	case reflect.Float64:
+		return reflect.TypeOf(float64(0)), nil
+	case reflect.String:
+		return reflect.TypeOf(""), nil
+	}
+	return nil, fmt.Errorf("rparquet: unsupported type %v", rt)
+}
+
+// columnFrom returns the Parquet column holding values of the provided
+// ROOT type.
+func columnFrom(name string, rt reflect.Type) (column, error) {
+	col := column{name: name, rtype: rt}
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice:
+		et, err := scalarType(rt.Elem())
+		if err != nil {
+			return col, fmt.Errorf("rparquet: unsupported type %v for column %q", rt, name)
+		}
+		col.ptype = reflect.SliceOf(et)
+	default:
+		pt, err := scalarType(rt)
+		if err != nil {
+			return col, fmt.Errorf("rparquet: unsupported type %v for column %q", rt, name)
+		}
+		col.ptype = pt
+	}
+	return col, nil
+}
+
+// structOf returns the type of the Go struct holding a row of the provided
+// columns.
+func structOf(cols []column) reflect.Type {
+	fields := make([]reflect.StructField, len(cols))
+	for i, col := range cols {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: col.ptype,
+			Tag:  reflect.StructTag(fmt.Sprintf("hbook:%q", col.name)),
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+// toParquet stores the ROOT value src into the Parquet value dst.
+func toParquet(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Array, reflect.Slice:
+		n := src.Len()
+		dst.Set(reflect.MakeSlice(dst.Type(), n, n))
+		for i := 0; i < n; i++ {
+			toParquet(dst.Index(i), src.Index(i))
+		}
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rparquet
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tmp := t.TempDir()
+
+	f, err := groot.Open("../testdata/x-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	src, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+	want := load(t, src)
+
+	pname := filepath.Join(tmp, "tree.parquet")
+	n, err := WriteTree(pname, src, WithParquetOptions(ntparquet.WithRowGroupSize(3), ntparquet.WithGzip(6)))
+	if err != nil {
+		t.Fatalf("could not write Parquet file: %+v", err)
+	}
+	if n != src.Entries() {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", n, src.Entries())
+	}
+
+	func() {
+		pr, err := ntparquet.Open(pname)
+		if err != nil {
+			t.Fatalf("could not open Parquet file: %+v", err)
+		}
+		defer pr.Close()
+
+		var rows []int64
+		for i := 0; i < pr.NumRowGroups(); i++ {
+			rows = append(rows, pr.RowGroup(i).NumRows())
+		}
+		if got, want := rows, []int64{3, 3, 3, 1}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid row-groups: got=%v, want=%v", got, want)
+		}
+	}()
+
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		entries  []int
+		branches []string
+	}{
+		{
+			name:    "all",
+			entries: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "branches",
+			opts:     []Option{WithBranches("I32", "N", "SliF64", "ArrU16")},
+			entries:  []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			branches: []string{"rparquet_n_ArrU16", "I32", "N", "SliF64", "ArrU16"},
+		},
+		{
+			name:     "count",
+			opts:     []Option{WithBranches("SliI64")},
+			entries:  []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			branches: []string{"rparquet_n_SliI64", "SliI64"},
+		},
+		{
+			name:    "predicate",
+			opts:    []Option{WithPredicate("I32", -7, -2), WithPredicate("F64", 4, 100)},
+			entries: []int{4, 5, 6, 7},
+		},
+		{
+			name:     "predicate-hidden",
+			opts:     []Option{WithBranches("Str"), WithPredicate("I64", -1, 0)},
+			entries:  []int{0, 1},
+			branches: []string{"Str"},
+		},
+		{
+			name:    "predicate-none",
+			opts:    []Option{WithPredicate("F32", 100, 200)},
+			entries: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			o, err := groot.Create(oname)
+			if err != nil {
+				t.Fatalf("could not create ROOT file: %+v", err)
+			}
+			defer o.Close()
+
+			n, err := ReadTree(o, "tree", pname, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not convert Parquet file: %+v", err)
+			}
+			if got, want := n, int64(len(tc.entries)); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			err = o.Close()
+			if err != nil {
+				t.Fatalf("could not close ROOT file: %+v", err)
+			}
+
+			o, err = groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer o.Close()
+
+			tree, err := riofs.Get[rtree.Tree](o, "tree")
+			if err != nil {
+				t.Fatalf("could not get tree: %+v", err)
+			}
+
+			if tc.branches != nil {
+				var names []string
+				for _, b := range tree.Branches() {
+					names = append(names, b.Name())
+				}
+				if !reflect.DeepEqual(names, tc.branches) {
+					t.Fatalf("invalid branches:\ngot= %q\nwant=%q", names, tc.branches)
+				}
+			}
+
+			got := load(t, tree)
+			for i, entry := range got {
+				ref := want[tc.entries[i]]
+				for name, v := range entry {
+					if _, ok := ref[name]; !ok {
+						continue // generated count branch.
+					}
+					if !reflect.DeepEqual(v, ref[name]) {
+						t.Fatalf("entry %d: invalid value for branch %q:\ngot= %v\nwant=%v", i, name, v, ref[name])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReadTreeErrors(t *testing.T) {
+	tmp := t.TempDir()
+
+	f, err := groot.Open("../testdata/x-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	src, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	_, err = WriteTree(filepath.Join(tmp, "x.parquet"), src, WithBranches("NotThere"))
+	if err == nil {
+		t.Fatalf("expected an error for an unknown branch")
+	}
+
+	pname := filepath.Join(tmp, "tree.parquet")
+	_, err = WriteTree(pname, src, WithBranches("I32", "Str", "SliF64"))
+	if err != nil {
+		t.Fatalf("could not write Parquet file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "column", opts: []Option{WithBranches("F64")}},
+		{name: "predicate", opts: []Option{WithPredicate("F64", 0, 1)}},
+		{name: "predicate-string", opts: []Option{WithPredicate("Str", 0, 1)}},
+		{name: "predicate-list", opts: []Option{WithPredicate("SliF64", 0, 1)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := groot.Create(filepath.Join(tmp, tc.name+".root"))
+			if err != nil {
+				t.Fatalf("could not create ROOT file: %+v", err)
+			}
+			defer o.Close()
+
+			_, err = ReadTree(o, "tree", pname, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestReadTreeArrow(t *testing.T) {
+	oname := filepath.Join(t.TempDir(), "arrow.root")
+	o, err := groot.Create(oname)
+	if err != nil {
+		t.Fatalf("could not create ROOT file: %+v", err)
+	}
+	defer o.Close()
+
+	n, err := ReadTree(
+		o, "tree", "../../hbook/ntup/ntparquet/testdata/arrow-gzip.parquet",
+		WithBranches("opt_f64", "list_i32"),
+		WithPredicate("i32", -50000, 0),
+	)
+	if err != nil {
+		t.Fatalf("could not convert Parquet file: %+v", err)
+	}
+	if got, want := n, int64(6); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	err = o.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT file: %+v", err)
+	}
+
+	o, err = groot.Open(oname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer o.Close()
+
+	tree, err := riofs.Get[rtree.Tree](o, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	got := load(t, tree)
+	want := []map[string]interface{}{
+		{"rparquet_n_list_i32": int32(0), "opt_f64": 0.0, "list_i32": []int32{}},
+		{"rparquet_n_list_i32": int32(1), "opt_f64": 0.0, "list_i32": []int32{10}},
+		{"rparquet_n_list_i32": int32(2), "opt_f64": 3.0, "list_i32": []int32{20, 21}},
+		{"rparquet_n_list_i32": int32(0), "opt_f64": 4.5, "list_i32": []int32{}},
+		{"rparquet_n_list_i32": int32(0), "opt_f64": 0.0, "list_i32": []int32{}},
+		{"rparquet_n_list_i32": int32(1), "opt_f64": 7.5, "list_i32": []int32{50}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+// load returns the content of the provided tree, with arrays converted
+// to slices and ROOT types (e.g. Float16_t) converted to their Go
+// equivalents.
+func load(t *testing.T, tree rtree.Tree) []map[string]interface{} {
+	t.Helper()
+
+	rvars := rtree.NewReadVars(tree)
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	var entries []map[string]interface{}
+	err = r.Read(func(ctx rtree.RCtx) error {
+		entry := make(map[string]interface{}, len(rvars))
+		for _, rv := range rvars {
+			v := reflect.ValueOf(rv.Value).Elem()
+			switch v.Kind() {
+			case reflect.Array, reflect.Slice:
+				sli := reflect.MakeSlice(reflect.SliceOf(goTypeOf(v.Type().Elem())), v.Len(), v.Len())
+				for i := 0; i < v.Len(); i++ {
+					sli.Index(i).Set(v.Index(i).Convert(sli.Type().Elem()))
+				}
+				entry[rv.Name] = sli.Interface()
+			default:
+				entry[rv.Name] = v.Convert(goTypeOf(v.Type())).Interface()
+			}
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+	return entries
+}
+
+func goTypeOf(rt reflect.Type) reflect.Type {
+	switch rt.Kind() {
+	case reflect.Float32:
+		return reflect.TypeOf(float32(0))
+	case reflect.Float64:
+		return reflect.TypeOf(float64(0))
+	}
+	return rt
+}
@@ -0,0 +1,137 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rparquet // import "go-hep.org/x/hep/groot/rparquet"
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+// WriteTree writes the content of the provided tree to the Parquet file
+// oname, and returns the number of written rows.
+//
+// Each leaf of the tree is converted to a column of the Parquet file.
+// Columns are named after their branch, or after their branch and leaf
+// (e.g. "branch.leaf") for branches with multiple leaves.
+func WriteTree(oname string, t rtree.Tree, opts ...Option) (int64, error) {
+	cfg := newConfig(opts)
+
+	rvars, cols, err := columnsFrom(t, cfg.branches)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := rtree.NewReader(t, rvars)
+	if err != nil {
+		return 0, fmt.Errorf("rparquet: could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	f, err := os.Create(oname)
+	if err != nil {
+		return 0, fmt.Errorf("rparquet: could not create output Parquet file %q: %w", oname, err)
+	}
+	defer f.Close()
+
+	popts := append([]ntparquet.Option(nil), cfg.popts...)
+	for _, col := range cols {
+		if col.count == "" {
+			continue
+		}
+		popts = append(popts, ntparquet.WithMetadata(countKey+col.name, col.count))
+	}
+
+	row := reflect.New(structOf(cols)).Elem()
+	pw, err := ntparquet.Create(f, row.Interface(), popts...)
+	if err != nil {
+		return 0, fmt.Errorf("rparquet: could not create Parquet writer: %w", err)
+	}
+	defer pw.Close()
+
+	var n int64
+	err = r.Read(func(ctx rtree.RCtx) error {
+		for i, rv := range rvars {
+			toParquet(row.Field(i), reflect.ValueOf(rv.Value).Elem())
+		}
+		err := pw.Append(row.Interface())
+		if err != nil {
+			return fmt.Errorf("could not write row %d: %w", ctx.Entry, err)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, fmt.Errorf("rparquet: could not convert tree %q: %w", t.Name(), err)
+	}
+
+	err = pw.Close()
+	if err != nil {
+		return n, fmt.Errorf("rparquet: could not write Parquet footer: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return n, fmt.Errorf("rparquet: could not close output Parquet file %q: %w", oname, err)
+	}
+
+	return n, nil
+}
+
+// columnsFrom returns the read-vars of the selected branches of the
+// provided tree, and the Parquet columns they are mapped to.
+func columnsFrom(t rtree.Tree, branches []string) ([]rtree.ReadVar, []column, error) {
+	var (
+		rvars []rtree.ReadVar
+		cols  []column
+		names = make(map[string]string) // leaf name to column name
+		sel   = make(map[string]bool, len(branches))
+	)
+	for _, name := range branches {
+		if t.Branch(name) == nil {
+			return nil, nil, fmt.Errorf("rparquet: no branch %q in tree %q", name, t.Name())
+		}
+		sel[name] = true
+	}
+
+	for _, rv := range rtree.NewReadVars(t) {
+		if len(sel) > 0 && !sel[rv.Name] {
+			continue
+		}
+		b := t.Branch(rv.Name)
+		name := rv.Name
+		if len(b.Leaves()) > 1 {
+			name += "." + rv.Leaf
+		}
+		col, err := columnFrom(name, reflect.TypeOf(rv.Value).Elem())
+		if err != nil {
+			return nil, nil, err
+		}
+		if leaf := b.Leaf(rv.Leaf); leaf != nil && leaf.LeafCount() != nil {
+			col.count = leaf.LeafCount().Name()
+		}
+		names[rv.Leaf] = name
+		rvars = append(rvars, rv)
+		cols = append(cols, col)
+	}
+
+	if len(cols) == 0 {
+		return nil, nil, fmt.Errorf("rparquet: no branch to convert in tree %q", t.Name())
+	}
+
+	// count leaves are referred to by the name of their column.
+	// drop the references to count leaves that are not converted.
+	for i, col := range cols {
+		if col.count == "" {
+			continue
+		}
+		cols[i].count = names[col.count]
+	}
+
+	return rvars, cols, nil
+}
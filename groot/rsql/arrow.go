@@ -0,0 +1,207 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// ScanArrow executes a query against the tree and returns the results of
+// the query as an Arrow record, with one column per selected expression.
+// Columns that are not branches of the tree are named "col<i>", with i the
+// index of the column.
+// Only scalar columns (booleans, numbers and strings) are supported.
+//
+// If mem is nil, the default Go allocator is used.
+// Users are responsible for releasing the returned record.
+func ScanArrow(tree rtree.Tree, query string, mem memory.Allocator) (array.Record, error) {
+	if mem == nil {
+		mem = memory.NewGoAllocator()
+	}
+
+	q, err := Prepare(tree, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var bldr *array.RecordBuilder
+	defer func() {
+		if bldr != nil {
+			bldr.Release()
+		}
+	}()
+
+	err = q.Exec(func(row []interface{}) error {
+		if bldr == nil {
+			schema, err := schemaFrom(q.cols, row)
+			if err != nil {
+				return err
+			}
+			bldr = array.NewRecordBuilder(mem, schema)
+		}
+		for i, v := range row {
+			err := appendArrow(bldr.Field(i), v)
+			if err != nil {
+				return fmt.Errorf("groot/rsql: could not append value to column %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bldr == nil {
+		// no row passed the selection.
+		schema, err := schemaFrom(q.cols, nil)
+		if err != nil {
+			return nil, err
+		}
+		bldr = array.NewRecordBuilder(mem, schema)
+	}
+
+	return bldr.NewRecord(), nil
+}
+
+// schemaFrom returns the Arrow schema of the results of a query.
+// The types of the columns not known before evaluation are inferred from
+// the provided row, if any.
+func schemaFrom(cols []Column, row []interface{}) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		fields[i].Name = col.Name
+		if fields[i].Name == "" {
+			fields[i].Name = fmt.Sprintf("col%d", i)
+		}
+
+		typ := col.Type
+		if typ == nil && i < len(row) && row[i] != nil {
+			typ = reflect.TypeOf(row[i])
+		}
+		if typ == nil {
+			fields[i].Type = arrow.Null
+			continue
+		}
+
+		dt, err := dataTypeFrom(typ)
+		if err != nil {
+			return nil, fmt.Errorf("groot/rsql: invalid column %q: %w", fields[i].Name, err)
+		}
+		fields[i].Type = dt
+	}
+
+	return arrow.NewSchema(fields, nil), nil
+}
+
+func dataTypeFrom(typ reflect.Type) (arrow.DataType, error) {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.String:
+		return arrow.BinaryTypes.String, nil
+	}
+	return nil, fmt.Errorf("unsupported column type %v", typ)
+}
+
+func appendArrow(bldr array.Builder, v interface{}) error {
+	var ok bool
+	switch bldr := bldr.(type) {
+	case *array.NullBuilder:
+		ok = v == nil
+		bldr.AppendNull()
+	case *array.BooleanBuilder:
+		var x bool
+		if x, ok = v.(bool); ok {
+			bldr.Append(x)
+		}
+	case *array.Int8Builder:
+		var x int8
+		if x, ok = v.(int8); ok {
+			bldr.Append(x)
+		}
+	case *array.Int16Builder:
+		var x int16
+		if x, ok = v.(int16); ok {
+			bldr.Append(x)
+		}
+	case *array.Int32Builder:
+		var x int32
+		if x, ok = v.(int32); ok {
+			bldr.Append(x)
+		}
+	case *array.Int64Builder:
+		var x int64
+		if x, ok = v.(int64); ok {
+			bldr.Append(x)
+		}
+	case *array.Uint8Builder:
+		var x uint8
+		if x, ok = v.(uint8); ok {
+			bldr.Append(x)
+		}
+	case *array.Uint16Builder:
+		var x uint16
+		if x, ok = v.(uint16); ok {
+			bldr.Append(x)
+		}
+	case *array.Uint32Builder:
+		var x uint32
+		if x, ok = v.(uint32); ok {
+			bldr.Append(x)
+		}
+	case *array.Uint64Builder:
+		var x uint64
+		if x, ok = v.(uint64); ok {
+			bldr.Append(x)
+		}
+	case *array.Float32Builder:
+		var x float32
+		if x, ok = v.(float32); ok {
+			bldr.Append(x)
+		}
+	case *array.Float64Builder:
+		var x float64
+		if x, ok = v.(float64); ok {
+			bldr.Append(x)
+		}
+	case *array.StringBuilder:
+		var x string
+		if x, ok = v.(string); ok {
+			bldr.Append(x)
+		}
+	default:
+		return fmt.Errorf("unsupported arrow builder %T", bldr)
+	}
+	if !ok {
+		return fmt.Errorf("invalid value type %T for builder %T", v, bldr)
+	}
+	return nil
+}
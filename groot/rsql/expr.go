@@ -2,10 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
+package rsql // import "go-hep.org/x/hep/groot/rsql"
 
 import (
-	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -21,13 +20,11 @@ type expression interface {
 	isStatic() bool
 }
 
-type execCtx struct {
-	db *driverConn
-}
+// execCtx is the context of the evaluation of an expression.
+type execCtx struct{}
 
-func newExecCtx(db *driverConn, args []driver.NamedValue) *execCtx {
-	ectx := execCtx{db: db}
-	return &ectx
+func newExecCtx() *execCtx {
+	return &execCtx{}
 }
 
 type binExpr struct {
@@ -1310,7 +1307,7 @@ type valueExpr struct {
 	v    interface{}
 }
 
-func newValueExpr(expr *sqlparser.SQLVal, args []driver.NamedValue) (expression, error) {
+func newValueExpr(expr *sqlparser.SQLVal, args []interface{}) (expression, error) {
 	s := string(expr.Val)
 	switch expr.Type {
 	//	case sqlparser.HexVal: // FIXME(sbinet): difference with HexNum?
@@ -1346,16 +1343,19 @@ func newValueExpr(expr *sqlparser.SQLVal, args []driver.NamedValue) (expression,
 
 	case sqlparser.ValArg:
 		if !strings.HasPrefix(s, ":v") {
-			return nil, fmt.Errorf("rsqldrv: invalid ValArg name %q", s)
+			return nil, fmt.Errorf("groot/rsql: invalid ValArg name %q", s)
 		}
 		i, err := strconv.ParseInt(s[len(":v"):], 10, 64)
 		if err != nil {
 			return nil, err
 		}
 		i-- // :v1 --> index-0
+		if i < 0 || int(i) >= len(args) {
+			return nil, fmt.Errorf("groot/rsql: missing value for ValArg %q", s)
+		}
 		return &valueExpr{
 			expr: expr,
-			v:    idealValArgFrom(args[i].Value), // FIXME(sbinet): unwrap driver.Value?
+			v:    idealValArgFrom(args[i]),
 		}, nil
 
 	default:
@@ -1377,7 +1377,7 @@ func idealValArgFrom(v interface{}) interface{} {
 	case reflect.String:
 		return rv.String()
 	}
-	panic(fmt.Errorf("rsqldrv: invalid ValArg type %#v", v))
+	panic(fmt.Errorf("groot/rsql: invalid ValArg type %#v", v))
 }
 
 func (expr *valueExpr) sql() sqlparser.Expr { return expr.expr }
@@ -0,0 +1,440 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xwb1989/sqlparser"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// Query is a SQL SELECT statement compiled against a ROOT tree.
+//
+// A Query evaluates its SELECT and WHERE expressions directly on the
+// values read from the tree, without going through database/sql.
+type Query struct {
+	tree rtree.Tree
+	cols []Column
+	vars []rtree.ReadVar // branches read by the query
+	deps []string        // names of the branches read by the query
+
+	eval   expression // row expression
+	filter expression // selection expression, if any
+}
+
+// Column describes a column of the results of a query.
+type Column struct {
+	Name string       // name of the column, empty if the column is not a branch
+	Type reflect.Type // type of the column values, nil if not known before evaluation
+}
+
+// Prepare compiles the provided SQL SELECT query against the tree.
+// The FROM clause of the query must name the tree.
+// args are the values of the placeholders of the query (?, :v1, :v2, ...)
+func Prepare(tree rtree.Tree, query string, args ...interface{}) (*Query, error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("groot/rsql: could not parse query: %w", err)
+	}
+
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("groot/rsql: invalid query type %T (want a SELECT statement)", stmt)
+	}
+
+	switch len(sel.From) {
+	case 1:
+		from, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return nil, fmt.Errorf("groot/rsql: invalid table expression %q", sqlparser.String(sel.From[0]))
+		}
+		expr, ok := from.Expr.(sqlparser.TableName)
+		if !ok {
+			return nil, fmt.Errorf("groot/rsql: invalid FROM expression %q", sqlparser.String(from.Expr))
+		}
+		if name := expr.Name.CompliantName(); name != tree.Name() {
+			return nil, fmt.Errorf("groot/rsql: invalid table name %q (want=%q)", name, tree.Name())
+		}
+	default:
+		return nil, fmt.Errorf("groot/rsql: invalid number of tables (got=%d, want=1)", len(sel.From))
+	}
+
+	q := &Query{tree: tree}
+
+	names, err := colsFromSelect(tree, sel)
+	if err != nil {
+		return nil, fmt.Errorf("groot/rsql: could not extract columns: %w", err)
+	}
+	q.cols = make([]Column, len(names))
+	for i, name := range names {
+		q.cols[i].Name = name
+	}
+
+	q.vars, err = depsFromSelect(tree, sel)
+	if err != nil {
+		return nil, fmt.Errorf("groot/rsql: could not extract read-vars: %w", err)
+	}
+	for i, v := range q.vars {
+		q.deps = append(q.deps, v.Name)
+		for j := range q.cols {
+			if q.cols[j].Name == v.Name {
+				q.cols[j].Type = reflect.TypeOf(q.vars[i].Value).Elem()
+			}
+		}
+	}
+
+	switch expr := sel.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
+	case *sqlparser.AliasedExpr:
+		q.eval, err = newExprFrom(expr.Expr, args)
+		if err != nil {
+			return nil, fmt.Errorf("groot/rsql: could not generate row expression: %w", err)
+		}
+	case *sqlparser.StarExpr:
+		tuple := make(sqlparser.ValTuple, len(q.cols))
+		for i, col := range q.cols {
+			tuple[i] = &sqlparser.ColName{Name: sqlparser.NewColIdent(col.Name)}
+		}
+		q.eval, err = newExprFrom(tuple, args)
+		if err != nil {
+			return nil, fmt.Errorf("groot/rsql: could not generate row expression from 'select *': %w", err)
+		}
+	}
+
+	if sel.Where != nil {
+		switch sel.Where.Type {
+		case sqlparser.WhereStr:
+			q.filter, err = newExprFrom(sel.Where.Expr, args)
+			if err != nil {
+				return nil, fmt.Errorf("groot/rsql: could not generate filter expression: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("groot/rsql: invalid 'where' type %q", sel.Where.Type)
+		}
+	}
+
+	return q, nil
+}
+
+// Tree returns the tree the query is executed against.
+func (q *Query) Tree() rtree.Tree {
+	return q.tree
+}
+
+// Columns returns the description of the columns of the results
+// of the query.
+func (q *Query) Columns() []Column {
+	cols := make([]Column, len(q.cols))
+	copy(cols, q.cols)
+	return cols
+}
+
+// Exec executes the query and calls f with the values of the columns of
+// each row passing the WHERE clause of the query, if any.
+// The values are typed Go values (int32, float64, string, []float32, ...)
+// The row slice is re-used between calls to f.
+func (q *Query) Exec(f func(row []interface{}) error) error {
+	vars := make([]rtree.ReadVar, len(q.vars))
+	for i, v := range q.vars {
+		vars[i] = v
+		vars[i].Value = reflect.New(reflect.TypeOf(v.Value).Elem()).Interface()
+	}
+
+	r, err := rtree.NewReader(q.tree, vars)
+	if err != nil {
+		return fmt.Errorf("groot/rsql: could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	var (
+		ectx = newExecCtx()
+		vctx = make(map[interface{}]interface{}, len(vars))
+		row  = make([]interface{}, len(q.cols))
+	)
+	err = r.Read(func(ctx rtree.RCtx) error {
+		for i, v := range vars {
+			vctx[q.deps[i]] = reflect.Indirect(reflect.ValueOf(v.Value)).Interface()
+		}
+
+		if q.filter != nil {
+			ok, err := q.filter.eval(ectx, vctx)
+			if err != nil {
+				return fmt.Errorf("could not evaluate filter: %w", err)
+			}
+			if !ok.(bool) {
+				return nil
+			}
+		}
+
+		vs, err := q.eval.eval(ectx, vctx)
+		if err != nil {
+			return fmt.Errorf("could not evaluate row values: %w", err)
+		}
+
+		switch vs := vs.(type) {
+		case []interface{}:
+			row = row[:0]
+			for _, v := range vs {
+				row = append(row, valueOf(v))
+			}
+		default:
+			row = append(row[:0], valueOf(vs))
+		}
+
+		return f(row)
+	})
+	if err != nil {
+		return fmt.Errorf("groot/rsql: could not execute query: %w", err)
+	}
+
+	return nil
+}
+
+// valueOf converts ideal constants into their default Go types.
+func valueOf(v interface{}) interface{} {
+	switch v := v.(type) {
+	case idealFloat:
+		return float64(v)
+	case idealInt:
+		return int64(v)
+	case idealUint:
+		return uint64(v)
+	}
+	return v
+}
+
+// depsFromSelect analyses the query and extracts the branches that need to be read
+// for the query to be properly executed.
+func depsFromSelect(tree rtree.Tree, stmt *sqlparser.Select) ([]rtree.ReadVar, error) {
+	var (
+		vars []rtree.ReadVar
+
+		set  = make(map[string]struct{})
+		cols []string
+	)
+
+	markBranch := func(name string) {
+		if name != "" {
+			if _, dup := set[name]; !dup {
+				set[name] = struct{}{}
+				cols = append(cols, name)
+			}
+		}
+	}
+
+	collectCols := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.StarExpr:
+			other := node.TableName.Name.CompliantName()
+			switch other {
+			case "", tree.Name():
+				for _, b := range tree.Branches() {
+					markBranch(b.Name())
+				}
+			default:
+				return false, fmt.Errorf("star-expression with other table name not supported")
+			}
+			return false, nil
+
+		case sqlparser.ColIdent:
+			name := node.CompliantName()
+			markBranch(name)
+			return false, nil
+
+		default:
+			return true, nil
+		}
+	}
+
+	nodes := make([]sqlparser.SQLNode, len(stmt.SelectExprs))
+	for i, expr := range stmt.SelectExprs {
+		nodes[i] = expr
+	}
+
+	if stmt.Where != nil {
+		nodes = append(nodes, stmt.Where.Expr)
+	}
+
+	err := sqlparser.Walk(collectCols, nodes...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range cols {
+		branch := tree.Branch(name)
+		if branch == nil {
+			return nil, fmt.Errorf("could not find branch/leaf %q in tree %q", name, tree.Name())
+		}
+		leaf := branch.Leaves()[0] // FIXME(sbinet): handle sub-leaves
+		etyp := leaf.Type()
+		switch etyp.Kind() {
+		case reflect.Int8:
+			if leaf.IsUnsigned() {
+				etyp = reflect.TypeOf(uint8(0))
+			}
+		case reflect.Int16:
+			if leaf.IsUnsigned() {
+				etyp = reflect.TypeOf(uint16(0))
+			}
+		case reflect.Int32:
+			if leaf.IsUnsigned() {
+				etyp = reflect.TypeOf(uint32(0))
+			}
+		case reflect.Int64:
+			if leaf.IsUnsigned() {
+				etyp = reflect.TypeOf(uint64(0))
+			}
+		}
+		switch {
+		case leaf.LeafCount() != nil:
+			etyp = reflect.SliceOf(etyp)
+		case leaf.Len() > 1 && leaf.Kind() != reflect.String:
+			etyp = reflect.ArrayOf(leaf.Len(), etyp)
+		}
+		vars = append(vars, rtree.ReadVar{
+			Name:  branch.Name(),
+			Leaf:  leaf.Name(),
+			Value: reflect.New(etyp).Interface(),
+		})
+	}
+
+	return vars, nil
+}
+
+// colsFromSelect returns the names of the columns of the query.
+// Columns that are not plain branches of the tree are given an empty name.
+func colsFromSelect(tree rtree.Tree, stmt *sqlparser.Select) ([]string, error) {
+	var cols []string
+
+	collect := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			return true, nil
+		case sqlparser.ColIdent:
+			cols = append(cols, node.CompliantName())
+			return false, nil
+		case *sqlparser.ParenExpr:
+			return true, nil
+		case sqlparser.ValTuple:
+			return true, nil
+		case sqlparser.Exprs:
+			return true, nil
+		case *sqlparser.BinaryExpr:
+			// not a simple select query.
+			// add a dummy column name and stop recursion
+			cols = append(cols, "")
+			return false, nil
+		case *sqlparser.UnaryExpr:
+			return true, nil
+		case *sqlparser.SQLVal:
+			// not a simple select query.
+			// add a dummy column name and stop recursion
+			cols = append(cols, "")
+			return false, nil
+		}
+		return false, nil
+	}
+
+	switch expr := stmt.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
+	case *sqlparser.AliasedExpr:
+		err := sqlparser.Walk(collect, expr.Expr)
+		return cols, err
+
+	case *sqlparser.StarExpr:
+		branches := make([]string, len(tree.Branches()))
+		for i, b := range tree.Branches() {
+			branches[i] = b.Name()
+		}
+		return branches, nil
+
+	default:
+		return nil, fmt.Errorf("invalid select-expr type %#v", expr)
+	}
+}
+
+func newExprFrom(expr sqlparser.Expr, args []interface{}) (expression, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.ComparisonExpr:
+		op := operatorFrom(expr.Operator)
+		if op == opInvalid {
+			return nil, fmt.Errorf("groot/rsql: invalid comparison operator %q", expr.Operator)
+		}
+
+		l, err := newExprFrom(expr.Left, args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newExprFrom(expr.Right, args)
+		if err != nil {
+			return nil, err
+		}
+		return newBinExpr(expr, op, l, r)
+
+	case *sqlparser.ParenExpr:
+		return newExprFrom(expr.Expr, args)
+
+	case *sqlparser.AndExpr:
+		l, err := newExprFrom(expr.Left, args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newExprFrom(expr.Right, args)
+		if err != nil {
+			return nil, err
+		}
+		return newBinExpr(expr, opAndAnd, l, r)
+
+	case *sqlparser.OrExpr:
+		l, err := newExprFrom(expr.Left, args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newExprFrom(expr.Right, args)
+		if err != nil {
+			return nil, err
+		}
+		return newBinExpr(expr, opOrOr, l, r)
+
+	case *sqlparser.ColName:
+		return &identExpr{
+			expr: expr,
+			name: expr.Name.CompliantName(),
+		}, nil
+
+	case *sqlparser.SQLVal:
+		return newValueExpr(expr, args)
+
+	case sqlparser.BoolVal:
+		return &valueExpr{expr: expr, v: bool(expr)}, nil
+
+	case *sqlparser.BinaryExpr:
+		l, err := newExprFrom(expr.Left, args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newExprFrom(expr.Right, args)
+		if err != nil {
+			return nil, err
+		}
+		op := operatorFrom(expr.Operator)
+		if op == opInvalid {
+			return nil, fmt.Errorf("groot/rsql: invalid binary-expression operator %q", expr.Operator)
+		}
+		return newBinExpr(expr, op, l, r)
+
+	case sqlparser.ValTuple:
+		vs := make([]expression, len(expr))
+		for i, e := range expr {
+			v, err := newExprFrom(e, args)
+			if err != nil {
+				return nil, err
+			}
+			vs[i] = v
+		}
+		return &tupleExpr{expr: expr, exprs: vs}, nil
+	}
+	return nil, fmt.Errorf("groot/rsql: invalid filter expression %#v %T", expr, expr)
+}
@@ -0,0 +1,183 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rsql"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestQuery(t *testing.T) {
+	f, err := riofs.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		query string
+		args  []interface{}
+		cols  []rsql.Column
+		rows  [][]interface{}
+	}{
+		{
+			query: `select * from tree where one > 2`,
+			cols: []rsql.Column{
+				{Name: "one", Type: reflect.TypeOf(int32(0))},
+				{Name: "two", Type: reflect.TypeOf(float32(0))},
+				{Name: "three", Type: reflect.TypeOf("")},
+			},
+			rows: [][]interface{}{
+				{int32(3), float32(3.3), "tres"},
+				{int32(4), float32(4.4), "quatro"},
+			},
+		},
+		{
+			query: `select (one, two+?, :v2) from tree where (three="dos")`,
+			args:  []interface{}{10, "x"},
+			cols: []rsql.Column{
+				{Name: "one", Type: reflect.TypeOf(int32(0))},
+				{Name: ""},
+				{Name: ""},
+			},
+			rows: [][]interface{}{
+				{int32(2), float32(12.2), "x"},
+			},
+		},
+		{
+			query: `select 2*one from tree`,
+			cols:  []rsql.Column{{Name: ""}},
+			rows: [][]interface{}{
+				{int32(2)}, {int32(4)}, {int32(6)}, {int32(8)},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := rsql.Prepare(tree, tc.query, tc.args...)
+			if err != nil {
+				t.Fatalf("could not prepare query: %+v", err)
+			}
+
+			if got, want := q.Columns(), tc.cols; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid columns:\ngot= %v\nwant=%v", got, want)
+			}
+
+			var rows [][]interface{}
+			err = q.Exec(func(row []interface{}) error {
+				rows = append(rows, append([]interface{}(nil), row...))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not execute query: %+v", err)
+			}
+
+			if got, want := rows, tc.rows; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		query string
+		args  []interface{}
+	}{
+		{query: `select one from other`},
+		{query: `select one from tree, other`},
+		{query: `select four from tree`},
+		{query: `select (one, ?) from tree`},
+		{query: `delete from tree`},
+	} {
+		t.Run("invalid:"+tc.query, func(t *testing.T) {
+			_, err := rsql.Prepare(tree, tc.query, tc.args...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestScanArrow(t *testing.T) {
+	f, err := riofs.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		query  string
+		schema *arrow.Schema
+		nrows  int64
+	}{
+		{
+			query: `select (one, two, three, one+10) from tree where one >= 2`,
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "one", Type: arrow.PrimitiveTypes.Int32},
+				{Name: "two", Type: arrow.PrimitiveTypes.Float32},
+				{Name: "three", Type: arrow.BinaryTypes.String},
+				{Name: "col3", Type: arrow.PrimitiveTypes.Int32},
+			}, nil),
+			nrows: 3,
+		},
+		{
+			query: `select (one, one+10) from tree where one > 10`,
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "one", Type: arrow.PrimitiveTypes.Int32},
+				{Name: "col1", Type: arrow.Null},
+			}, nil),
+			nrows: 0,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			rec, err := rsql.ScanArrow(tree, tc.query, mem)
+			if err != nil {
+				t.Fatalf("could not scan tree: %+v", err)
+			}
+			defer rec.Release()
+
+			if got, want := rec.Schema(), tc.schema; !got.Equal(want) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, want)
+			}
+			if got, want := rec.NumRows(), tc.nrows; got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			if tc.nrows == 0 {
+				return
+			}
+
+			if got, want := rec.Column(0).(*array.Int32).Int32Values(), []int32{2, 3, 4}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid column: got=%v, want=%v", got, want)
+			}
+			if got, want := rec.Column(1).(*array.Float32).Float32Values(), []float32{2.2, 3.3, 4.4}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid column: got=%v, want=%v", got, want)
+			}
+			if got, want := rec.Column(2).(*array.String).Value(2), "quatro"; got != want {
+				t.Fatalf("invalid column: got=%v, want=%v", got, want)
+			}
+			if got, want := rec.Column(3).(*array.Int32).Int32Values(), []int32{12, 13, 14}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid column: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
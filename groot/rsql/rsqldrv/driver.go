@@ -17,6 +17,7 @@ import (
 
 	"github.com/xwb1989/sqlparser"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rsql"
 	"go-hep.org/x/hep/groot/rtree"
 )
 
@@ -233,18 +234,12 @@ func (res *driverResult) RowsAffected() (int64, error) { return res.rows, nil }
 // driverRows is an iterator over an executed query's results.
 type driverRows struct {
 	conn  *driverConn
-	args  []driver.NamedValue
 	cols  []string
-	types []colDescr    // types of the columns
-	deps  []string      // names of the columns to be read
-	vars  []interface{} // values of the columns that were read
+	types []colDescr // types of the columns
 
-	reader *rtree.Reader
-	row    rowCtx
-	rows   chan rowCtx
-
-	eval   expression
-	filter expression
+	query *rsql.Query
+	row   rowCtx
+	rows  chan rowCtx
 }
 
 type colDescr struct {
@@ -289,20 +284,28 @@ func newDriverRows(ctx context.Context, conn *driverConn, stmt *sqlparser.Select
 		return nil, fmt.Errorf("rsqldrv: object %q is not a Tree", name)
 	}
 
-	rows := &driverRows{conn: conn, args: args}
+	vals := make([]interface{}, len(args))
+	for i, arg := range args {
+		vals[i] = arg.Value
+	}
 
-	rows.cols, err = rows.extractColsFromSelect(tree, stmt, args)
+	query, err := rsql.Prepare(tree, sqlparser.String(stmt), vals...)
 	if err != nil {
-		return nil, fmt.Errorf("could not extract columns: %w", err)
+		return nil, fmt.Errorf("rsqldrv: could not prepare query: %w", err)
 	}
-	rows.types = make([]colDescr, len(rows.cols))
-	for i, name := range rows.cols {
-		if name == "" {
+
+	rows := &driverRows{conn: conn, query: query}
+	cols := query.Columns()
+	rows.cols = make([]string, len(cols))
+	rows.types = make([]colDescr, len(cols))
+	for i, col := range cols {
+		rows.cols[i] = col.Name
+		if col.Name == "" {
 			rows.types[i].Type = reflect.TypeOf(new(interface{})).Elem()
 			continue
 		}
-		rows.types[i].Name = name
-		branch := tree.Branch(name)
+		rows.types[i].Name = col.Name
+		branch := tree.Branch(col.Name)
 		if branch == nil {
 			rows.types[i].Type = reflect.TypeOf(new(interface{})).Elem()
 			continue
@@ -310,208 +313,10 @@ func newDriverRows(ctx context.Context, conn *driverConn, stmt *sqlparser.Select
 		rows.types[i] = colDescrFromLeaf(branch.Leaves()[0]) // FIXME(sbinet): multi-leaves' branches
 	}
 
-	vars, err := rows.extractDepsFromSelect(tree, stmt, args)
-	if err != nil {
-		return nil, fmt.Errorf("could not extract read-vars: %w", err)
-	}
-	rows.vars = varsFrom(vars)
-	for _, v := range vars {
-		rows.deps = append(rows.deps, v.Name)
-	}
-
-	rows.reader, err = rtree.NewReader(tree, vars)
-	if err != nil {
-		return nil, err
-	}
-
-	switch expr := stmt.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
-	case *sqlparser.AliasedExpr:
-		rows.eval, err = newExprFrom(expr.Expr, args)
-		if err != nil {
-			return nil, fmt.Errorf("could not generate row expression: %w", err)
-		}
-	case *sqlparser.StarExpr:
-		tuple := make(sqlparser.ValTuple, len(rows.cols))
-		for i, name := range rows.cols {
-			tuple[i] = &sqlparser.ColName{Name: sqlparser.NewColIdent(name)}
-		}
-		rows.eval, err = newExprFrom(tuple, args)
-		if err != nil {
-			return nil, fmt.Errorf("could not generate row expression from 'select *': %w", err)
-		}
-	}
-
-	if stmt.Where != nil {
-		switch stmt.Where.Type {
-		case sqlparser.WhereStr:
-			rows.filter, err = newExprFrom(stmt.Where.Expr, args)
-			if err != nil {
-				return nil, err
-			}
-		default:
-			panic(fmt.Errorf("unknown 'where' type: %q", stmt.Where.Type))
-		}
-	}
-
 	rows.start()
 	return rows, nil
 }
 
-func varsFrom(vars []rtree.ReadVar) []interface{} {
-	vs := make([]interface{}, len(vars))
-	for i, v := range vars {
-		vs[i] = v.Value
-	}
-	return vs
-}
-
-// extractDepsFromSelect analyses the query and extracts the branches that need to be read
-// for the query to be properly executed.
-func (rows *driverRows) extractDepsFromSelect(tree rtree.Tree, stmt *sqlparser.Select, args []driver.NamedValue) ([]rtree.ReadVar, error) {
-	var (
-		vars []rtree.ReadVar
-
-		set  = make(map[string]struct{})
-		cols []string
-	)
-
-	markBranch := func(name string) {
-		if name != "" {
-			if _, dup := set[name]; !dup {
-				set[name] = struct{}{}
-				cols = append(cols, name)
-			}
-		}
-	}
-
-	collectCols := func(node sqlparser.SQLNode) (bool, error) {
-		switch node := node.(type) {
-		case *sqlparser.StarExpr:
-			other := node.TableName.Name.CompliantName()
-			switch other {
-			case "", tree.Name():
-				for _, b := range tree.Branches() {
-					markBranch(b.Name())
-				}
-			default:
-				panic(fmt.Errorf("rsqldrv: star-expression with other table name not supported"))
-			}
-			return false, nil
-
-		case sqlparser.ColIdent:
-			name := node.CompliantName()
-			markBranch(name)
-			return false, nil
-
-		default:
-			return true, nil
-		}
-	}
-
-	nodes := make([]sqlparser.SQLNode, len(stmt.SelectExprs))
-	for i, expr := range stmt.SelectExprs {
-		nodes[i] = expr
-	}
-
-	if stmt.Where != nil {
-		nodes = append(nodes, stmt.Where.Expr)
-	}
-
-	err := sqlparser.Walk(collectCols, nodes...)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, name := range cols {
-		branch := tree.Branch(name)
-		if branch == nil {
-			return nil, fmt.Errorf("rsqldrv: could not find branch/leaf %q in tree %q", name, tree.Name())
-		}
-		leaf := branch.Leaves()[0] // FIXME(sbinet): handle sub-leaves
-		etyp := leaf.Type()
-		switch etyp.Kind() {
-		case reflect.Int8:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint8(0))
-			}
-		case reflect.Int16:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint16(0))
-			}
-		case reflect.Int32:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint32(0))
-			}
-		case reflect.Int64:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint64(0))
-			}
-		}
-		switch {
-		case leaf.LeafCount() != nil:
-			etyp = reflect.SliceOf(etyp)
-		case leaf.Len() > 1 && leaf.Kind() != reflect.String:
-			etyp = reflect.ArrayOf(leaf.Len(), etyp)
-		}
-		vars = append(vars, rtree.ReadVar{
-			Name:  branch.Name(),
-			Leaf:  leaf.Name(),
-			Value: reflect.New(etyp).Interface(),
-		})
-	}
-
-	return vars, nil
-}
-
-func (rows *driverRows) extractColsFromSelect(tree rtree.Tree, stmt *sqlparser.Select, args []driver.NamedValue) ([]string, error) {
-	var cols []string
-
-	collect := func(node sqlparser.SQLNode) (bool, error) {
-		switch node := node.(type) {
-		case *sqlparser.ColName:
-			return true, nil
-		case sqlparser.ColIdent:
-			cols = append(cols, node.CompliantName())
-			return false, nil
-		case *sqlparser.ParenExpr:
-			return true, nil
-		case sqlparser.ValTuple:
-			return true, nil
-		case sqlparser.Exprs:
-			return true, nil
-		case *sqlparser.BinaryExpr:
-			// not a simple select query.
-			// add a dummy column name and stop recursion
-			cols = append(cols, "")
-			return false, nil
-		case *sqlparser.UnaryExpr:
-			return true, nil
-		case *sqlparser.SQLVal:
-			// not a simple select query.
-			// add a dummy column name and stop recursion
-			cols = append(cols, "")
-			return false, nil
-		}
-		return false, nil
-	}
-
-	switch expr := stmt.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
-	case *sqlparser.AliasedExpr:
-		err := sqlparser.Walk(collect, expr.Expr)
-		return cols, err
-
-	case *sqlparser.StarExpr:
-		branches := make([]string, len(tree.Branches()))
-		for i, b := range tree.Branches() {
-			branches[i] = b.Name()
-		}
-		return branches, nil
-
-	default:
-		panic(fmt.Errorf("rsqldrv: invalid select-expr type %#v", expr))
-	}
-}
-
 // Columns returns the names of the columns. The number of columns of the
 // result is inferred from the length of the slice.  If a particular column
 // name isn't known, an empty string should be returned for that entry.
@@ -549,52 +354,22 @@ func (r *driverRows) ColumnTypeNullable(i int) (nullable, ok bool) {
 
 // Close closes the rows iterator.
 func (r *driverRows) Close() error {
-	return r.reader.Close()
+	return nil
 }
 
 type rowCtx struct {
-	ctx  rtree.RCtx
-	vs   interface{}
+	vs   []interface{}
 	done chan int
 	err  error
 }
 
 func (r *driverRows) start() {
 	r.rows = make(chan rowCtx)
-	r.row.ctx.Entry = -1
 	go func() {
 		defer close(r.rows)
-		err := r.reader.Read(func(ctx rtree.RCtx) error {
-			ectx := newExecCtx(r.conn, r.args)
-			vctx := make(map[interface{}]interface{})
-			for i, v := range r.vars {
-				vctx[r.deps[i]] = reflect.Indirect(reflect.ValueOf(v)).Interface()
-			}
-
-			switch r.filter {
-			case nil:
-				// no filter
-			default:
-				ok, err := r.filter.eval(ectx, vctx)
-				if err != nil {
-					//log.Printf("filter.eval: ok=%#v err=%v", ok, err)
-					return err
-				}
-				if !ok.(bool) {
-					return nil
-				}
-			}
-
-			vs, err := r.eval.eval(ectx, vctx)
-			// log.Printf("row.eval: v=%#v, err=%v n=%d", vs, err, len(dest))
-			if err != nil {
-				return fmt.Errorf("could not evaluate row values: %w", err)
-			}
-
+		err := r.query.Exec(func(row []interface{}) error {
 			evt := rowCtx{
-				ctx:  ctx,
-				vs:   vs,
-				err:  nil,
+				vs:   append([]interface{}(nil), row...),
 				done: make(chan int),
 			}
 
@@ -620,7 +395,7 @@ func (r *driverRows) start() {
 // should be taken when closing Rows not to modify
 // a buffer held in dest.
 func (r *driverRows) Next(dest []driver.Value) error {
-	if r.row.ctx.Entry >= 0 {
+	if r.row.done != nil {
 		close(r.row.done)
 	}
 
@@ -638,20 +413,13 @@ func (r *driverRows) Next(dest []driver.Value) error {
 		}
 	}
 
-	switch vs := row.vs.(type) {
-	case []interface{}:
-		for i, v := range vs {
-			switch v := v.(type) {
-			case string:
-				dest[i] = []byte(v)
-			default:
-				dest[i] = v
-			}
+	for i, v := range row.vs {
+		switch v := v.(type) {
+		case string:
+			dest[i] = []byte(v)
+		default:
+			dest[i] = v
 		}
-	case string:
-		dest[0] = []byte(vs)
-	default:
-		dest[0] = vs
 	}
 
 	return nil
@@ -678,90 +446,6 @@ func (stmt *driverStmt) Query(args []driver.Value) (driver.Rows, error) {
 	panic("not implemented")
 }
 
-func newExprFrom(expr sqlparser.Expr, args []driver.NamedValue) (expression, error) {
-	switch expr := expr.(type) {
-	case *sqlparser.ComparisonExpr:
-		op := operatorFrom(expr.Operator)
-		if op == opInvalid {
-			return nil, fmt.Errorf("rsqldrv: invalid comparison operator %q", expr.Operator)
-		}
-
-		l, err := newExprFrom(expr.Left, args)
-		if err != nil {
-			return nil, err
-		}
-		r, err := newExprFrom(expr.Right, args)
-		if err != nil {
-			return nil, err
-		}
-		return newBinExpr(expr, op, l, r)
-
-	case *sqlparser.ParenExpr:
-		return newExprFrom(expr.Expr, args)
-
-	case *sqlparser.AndExpr:
-		l, err := newExprFrom(expr.Left, args)
-		if err != nil {
-			return nil, err
-		}
-		r, err := newExprFrom(expr.Right, args)
-		if err != nil {
-			return nil, err
-		}
-		return newBinExpr(expr, opAndAnd, l, r)
-
-	case *sqlparser.OrExpr:
-		l, err := newExprFrom(expr.Left, args)
-		if err != nil {
-			return nil, err
-		}
-		r, err := newExprFrom(expr.Right, args)
-		if err != nil {
-			return nil, err
-		}
-		return newBinExpr(expr, opOrOr, l, r)
-
-	case *sqlparser.ColName:
-		return &identExpr{
-			expr: expr,
-			name: expr.Name.CompliantName(),
-		}, nil
-
-	case *sqlparser.SQLVal:
-		return newValueExpr(expr, args)
-
-	case sqlparser.BoolVal:
-		return &valueExpr{expr: expr, v: bool(expr)}, nil
-
-	case *sqlparser.BinaryExpr:
-		l, err := newExprFrom(expr.Left, args)
-		if err != nil {
-			return nil, err
-		}
-		r, err := newExprFrom(expr.Right, args)
-		if err != nil {
-			return nil, err
-		}
-		op := operatorFrom(expr.Operator)
-		if op == opInvalid {
-			return nil, fmt.Errorf("rsqldrv: invalid binary-expression operator %q", expr.Operator)
-		}
-		return newBinExpr(expr, op, l, r)
-
-	case sqlparser.ValTuple:
-		vs := make([]expression, len(expr))
-		for i, e := range expr {
-			v, err := newExprFrom(e, args)
-			if err != nil {
-				return nil, err
-			}
-			vs[i] = v
-		}
-		return &tupleExpr{expr: expr, exprs: vs}, nil
-	}
-	return nil, fmt.Errorf("rsqldrv: invalid filter expression %#v %T", expr, expr)
-}
-
 var (
 	_ driver.Driver         = (*rootDriver)(nil)
 	_ driver.Conn           = (*driverConn)(nil)
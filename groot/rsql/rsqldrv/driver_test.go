@@ -607,3 +607,230 @@ func TestFlatTree(t *testing.T) {
 		i++
 	}
 }
+
+func TestSelectColumns(t *testing.T) {
+	db, err := sql.Open("root", "../../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type eface = interface{}
+
+	for _, tc := range []struct {
+		query string
+		cols  []string
+		types []interface{}
+		args  []interface{}
+		vals  [][]eface
+	}{
+		{
+			query: `select one from tree`,
+			cols:  []string{"one"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(1)},
+				{int32(2)},
+				{int32(3)},
+				{int32(4)},
+			},
+		},
+		{
+			query: `select (one) from tree`,
+			cols:  []string{"one"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(1)},
+				{int32(2)},
+				{int32(3)},
+				{int32(4)},
+			},
+		},
+		{
+			query: `select (one, two) from tree`,
+			cols:  []string{"one", "two"},
+			types: []interface{}{int32(0), 0.0},
+			vals: [][]eface{
+				{int32(1), 1.1},
+				{int32(2), 2.2},
+				{int32(3), 3.3},
+				{int32(4), 4.4},
+			},
+		},
+		{
+			query: `select (one, (two)) from tree`,
+			cols:  []string{"one", "two"},
+			types: []interface{}{int32(0), 0.0},
+			vals: [][]eface{
+				{int32(1), 1.1},
+				{int32(2), 2.2},
+				{int32(3), 3.3},
+				{int32(4), 4.4},
+			},
+		},
+		{
+			query: `select (one, ((two))) from tree`,
+			cols:  []string{"one", "two"},
+			types: []interface{}{int32(0), 0.0},
+			vals: [][]eface{
+				{int32(1), 1.1},
+				{int32(2), 2.2},
+				{int32(3), 3.3},
+				{int32(4), 4.4},
+			},
+		},
+		{
+			query: `select (((one), ((two)))) from tree`,
+			cols:  []string{"one", "two"},
+			types: []interface{}{int32(0), 0.0},
+			vals: [][]eface{
+				{int32(1), 1.1},
+				{int32(2), 2.2},
+				{int32(3), 3.3},
+				{int32(4), 4.4},
+			},
+		},
+		{
+			query: `select three from tree`,
+			cols:  []string{"three"},
+			types: []interface{}{""},
+			vals: [][]eface{
+				{"uno"},
+				{"dos"},
+				{"tres"},
+				{"quatro"},
+			},
+		},
+		{
+			query: `select (one, two, three) from tree`,
+			cols:  []string{"one", "two", "three"},
+			types: []interface{}{int32(0), 0.0, ""},
+			vals: [][]eface{
+				{int32(1), 1.1, "uno"},
+				{int32(2), 2.2, "dos"},
+				{int32(3), 3.3, "tres"},
+				{int32(4), 4.4, "quatro"},
+			},
+		},
+		{
+			query: `select (?, two, ?) from tree`,
+			cols:  []string{"", "two", ""},
+			types: []interface{}{"", 0.0, ""},
+			args:  []interface{}{"one", "three"},
+			vals: [][]eface{
+				{"one", 1.1, "three"},
+				{"one", 2.2, "three"},
+				{"one", 3.3, "three"},
+				{"one", 4.4, "three"},
+			},
+		},
+		{
+			query: `select (:v1, two, :v2) from tree`,
+			cols:  []string{"", "two", ""},
+			types: []interface{}{"", 0.0, ""},
+			args:  []interface{}{"one", "three"},
+			vals: [][]eface{
+				{"one", 1.1, "three"},
+				{"one", 2.2, "three"},
+				{"one", 3.3, "three"},
+				{"one", 4.4, "three"},
+			},
+		},
+		{
+			query: `select (:v2, two, :v1) from tree`,
+			cols:  []string{"", "two", ""},
+			types: []interface{}{"", 0.0, ""},
+			args:  []interface{}{"three", "one"},
+			vals: [][]eface{
+				{"one", 1.1, "three"},
+				{"one", 2.2, "three"},
+				{"one", 3.3, "three"},
+				{"one", 4.4, "three"},
+			},
+		},
+		{
+			query: `select (:v2, two+:v3, :v1) from tree`,
+			cols:  []string{"", "", ""},
+			types: []interface{}{"", 0.0, ""},
+			args:  []interface{}{"three", "one", 10},
+			vals: [][]eface{
+				{"one", 11.1, "three"},
+				{"one", 12.2, "three"},
+				{"one", 13.3, "three"},
+				{"one", 14.4, "three"},
+			},
+		},
+		{
+			query: `select (one) from tree where (two > 3)`,
+			cols:  []string{"one"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(3)},
+				{int32(4)},
+			},
+		},
+		{
+			query: `select (one) from tree where (3 <= two && two < 4)`,
+			cols:  []string{"one"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(3)},
+			},
+		},
+		{
+			query: `select (one, two) from tree where (three="quatro")`,
+			cols:  []string{"one", "two"},
+			types: []interface{}{int32(0), 0.0},
+			vals: [][]eface{
+				{int32(4), 4.4},
+			},
+		},
+		{
+			query: `select (one, two, ?+:v2) from tree where (three="quatro")`,
+			cols:  []string{"one", "two", ""},
+			types: []interface{}{int32(0), 0.0, uint64(0)},
+			args:  []interface{}{uint64(5), uint64(10)},
+			vals: [][]eface{
+				{int32(4), 4.4, uint64(15)},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			rows, err := db.Query(tc.query, tc.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			cols, err := rows.Columns()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := cols, tc.cols; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid columns.\ngot= %q\nwant=%q", got, want)
+			}
+
+			var got [][]eface
+			for rows.Next() {
+				vars := make([]interface{}, len(tc.types))
+				for i, v := range tc.types {
+					vars[i] = reflect.New(reflect.TypeOf(v)).Interface()
+				}
+				err = rows.Scan(vars...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				row := make([]eface, len(vars))
+				for i, v := range vars {
+					row[i] = reflect.Indirect(reflect.ValueOf(v)).Interface()
+				}
+				got = append(got, row)
+			}
+
+			if got, want := got, tc.vals; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid values.\ngot= %v\nwant=%v\n", got, want)
+			}
+		})
+	}
+}
@@ -3,15 +3,19 @@
 // license that can be found in the LICENSE file.
 
 // Package rsql provides a convenient access to ROOT files/trees as a database.
+//
+// Package rsql evaluates SQL SELECT queries directly against ROOT trees
+// and returns typed results (Go values, hbook histograms or Arrow records),
+// without the database/sql machinery.
+// The rsqldrv package exposes the same query engine as a database/sql driver.
 package rsql // import "go-hep.org/x/hep/groot/rsql"
 
 import (
 	"fmt"
-	"io"
 	"math"
 	"reflect"
+	"strconv"
 
-	"go-hep.org/x/hep/groot/rsql/rsqldrv"
 	"go-hep.org/x/hep/groot/rtree"
 	"go-hep.org/x/hep/hbook"
 )
@@ -31,39 +35,32 @@ func Scan(tree rtree.Tree, query string, f interface{}) error {
 		return fmt.Errorf("groot/rsql: expected a func returning an error. got %T", f)
 	}
 	vargs := make([]reflect.Value, rt.NumIn())
-	args := make([]interface{}, rt.NumIn())
-	for i := range args {
-		ptr := reflect.New(rt.In(i))
-		args[i] = ptr.Interface()
-		vargs[i] = ptr.Elem()
+	for i := range vargs {
+		vargs[i] = reflect.New(rt.In(i)).Elem()
 	}
 
-	db := rsqldrv.OpenDB(rtree.FileOf(tree))
-	defer db.Close()
-
-	rows, err := db.Query(query)
+	q, err := Prepare(tree, query)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		err = rows.Scan(args...)
-		if err != nil {
-			return err
+	return q.Exec(func(row []interface{}) error {
+		if got, want := len(vargs), len(row); got != want {
+			return fmt.Errorf("groot/rsql: invalid number of func arguments (got=%d, want=%d)", got, want)
+		}
+		for i, v := range row {
+			err := assign(vargs[i], v)
+			if err != nil {
+				return fmt.Errorf("groot/rsql: could not assign column %d: %w", i, err)
+			}
 		}
 
 		out := rv.Call(vargs)[0].Interface()
 		if out != nil {
 			return out.(error)
 		}
-	}
-
-	err = rows.Err()
-	if err == io.EOF {
-		err = nil
-	}
-	return err
+		return nil
+	})
 }
 
 // ScanH1D executes a query against the tree and fills the histogram with
@@ -139,3 +136,98 @@ func ScanH2D(tree rtree.Tree, query string, h *hbook.H2D) (*hbook.H2D, error) {
 func nextULP(v float64) float64 {
 	return math.Nextafter(v, v+1)
 }
+
+// assign assigns the column value v to the provided destination, converting
+// between numeric types if needed.
+// Conversions that would lose information are rejected: floating point
+// values can only be assigned to integers if they are integral, and
+// numbers must fit within the range of their destination.
+func assign(dst reflect.Value, v interface{}) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Type().AssignableTo(dst.Type()):
+		dst.Set(rv)
+		return nil
+	case rv.Kind() == reflect.Float32 && dst.Kind() == reflect.Float64:
+		// go through the shortest decimal representation of the float32
+		// value, as database/sql does, so 1.1f is scanned as 1.1.
+		f, err := strconv.ParseFloat(strconv.FormatFloat(rv.Float(), 'g', -1, 32), 64)
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+		return nil
+	case isNumber(rv.Kind()) && isNumber(dst.Kind()):
+		if !convertible(dst, rv) {
+			return fmt.Errorf("can not convert %v (type %T) to %v without loss", v, v, dst.Type())
+		}
+		dst.Set(rv.Convert(dst.Type()))
+		return nil
+	case rv.Kind() == reflect.String && dst.Kind() == reflect.String,
+		rv.Kind() == reflect.Bool && dst.Kind() == reflect.Bool:
+		dst.Set(rv.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("can not convert %T to %v", v, dst.Type())
+}
+
+// convertible returns whether the number v can be converted to the type
+// of the number dst without loss.
+func convertible(dst, v reflect.Value) bool {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			if f != math.Trunc(f) || math.IsInf(f, 0) {
+				return false
+			}
+			lim := math.Ldexp(1, dst.Type().Bits()-1)
+			return -lim <= f && f < lim
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := v.Uint()
+			return u <= math.MaxInt64 && !dst.OverflowInt(int64(u))
+		default:
+			return !dst.OverflowInt(v.Int())
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			if f != math.Trunc(f) || math.IsInf(f, 0) {
+				return false
+			}
+			return 0 <= f && f < math.Ldexp(1, dst.Type().Bits())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return !dst.OverflowUint(v.Uint())
+		default:
+			i := v.Int()
+			return i >= 0 && !dst.OverflowUint(uint64(i))
+		}
+
+	default:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			// infinities and NaNs are representable by all floats.
+			return math.IsInf(f, 0) || math.IsNaN(f) || !dst.OverflowFloat(f)
+		}
+		return true
+	}
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -7,6 +7,9 @@ package rsql_test
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
+	"testing"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rsql"
@@ -227,3 +230,105 @@ func ExampleScanH2D_withH2D() {
 	// y-std-dev: 1.4200938936093859
 	// y-std-err: 0.7100469468046929
 }
+
+func TestScanConversions(t *testing.T) {
+	f, err := groot.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(rtree.Tree)
+
+	for _, tc := range []struct {
+		query string
+		fct   interface{}
+		want  interface{}
+		err   string
+	}{
+		{
+			query: `select one from tree`,
+			fct:   func(x int8) int8 { return x },
+			want:  []int8{1, 2, 3, 4},
+		},
+		{
+			query: `select one from tree`,
+			fct:   func(x uint64) uint64 { return x },
+			want:  []uint64{1, 2, 3, 4},
+		},
+		{
+			query: `select one from tree`,
+			fct:   func(x float32) float32 { return x },
+			want:  []float32{1, 2, 3, 4},
+		},
+		{
+			query: `select two from tree`,
+			fct:   func(x float64) float64 { return x },
+			want:  []float64{1.1, 2.2, 3.3, 4.4},
+		},
+		{
+			query: `select 100*one from tree where one < 2`,
+			fct:   func(x int8) int8 { return x },
+			want:  []int8{100},
+		},
+		{
+			query: `select two-two from tree`,
+			fct:   func(x int16) int16 { return x },
+			want:  []int16{0, 0, 0, 0},
+		},
+		{
+			query: `select two from tree`,
+			fct:   func(x int32) int32 { return x },
+			err:   "groot/rsql: could not assign column 0: can not convert 1.1 (type float32) to int32 without loss",
+		},
+		{
+			query: `select two from tree`,
+			fct:   func(x uint32) uint32 { return x },
+			err:   "groot/rsql: could not assign column 0: can not convert 1.1 (type float32) to uint32 without loss",
+		},
+		{
+			query: `select 100*one from tree`,
+			fct:   func(x int8) int8 { return x },
+			err:   "to int8 without loss",
+		},
+		{
+			query: `select one-3 from tree`,
+			fct:   func(x uint8) uint8 { return x },
+			err:   "to uint8 without loss",
+		},
+	} {
+		fct := reflect.ValueOf(tc.fct)
+		t.Run(fmt.Sprintf("%s-%v", tc.query, fct.Type().In(0)), func(t *testing.T) {
+			got := reflect.MakeSlice(reflect.SliceOf(fct.Type().Out(0)), 0, 4)
+			scan := reflect.MakeFunc(
+				reflect.FuncOf([]reflect.Type{fct.Type().In(0)}, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()}, false),
+				func(args []reflect.Value) []reflect.Value {
+					got = reflect.Append(got, fct.Call(args)[0])
+					return []reflect.Value{reflect.Zero(reflect.TypeOf((*error)(nil)).Elem())}
+				},
+			)
+
+			err := rsql.Scan(tree, tc.query, scan.Interface())
+			switch {
+			case tc.err != "":
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if got, want := err.Error(), tc.err; !strings.Contains(got, want) {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not scan tree: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got.Interface(), tc.want) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", got.Interface(), tc.want)
+			}
+		})
+	}
+}
@@ -87,6 +87,38 @@ type TreeResponse struct {
 	Tree Tree   `json:"tree"`
 }
 
+// HistRequest describes a request to fetch the content of a 1-dim or
+// 2-dim histogram.
+type HistRequest struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type HistResponse struct {
+	URI  string `json:"uri"`
+	Dir  string `json:"dir"`
+	Obj  string `json:"obj"`
+	Hist Hist   `json:"hist"`
+}
+
+// Hist is the JSON representation of the content of a 1-dim or 2-dim
+// histogram.
+//
+// The bins of 2-dim histograms are stored row by row: the bin (ix, iy) is
+// located at index iy*nx + ix, where nx is the number of bins along X.
+type Hist struct {
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Title   string    `json:"title"`
+	Dim     int       `json:"dim"`
+	Entries int64     `json:"entries"`
+	XEdges  []float64 `json:"xedges"`
+	YEdges  []float64 `json:"yedges,omitempty"`
+	SumW    []float64 `json:"sumw"`  // sum of weights, per bin.
+	SumW2   []float64 `json:"sumw2"` // sum of squared weights, per bin.
+}
+
 type PlotH1Request struct {
 	URI string `json:"uri"`
 	Dir string `json:"dir"`
@@ -255,3 +287,67 @@ func (sty LineStyle) toJSON() jsonLineStyle {
 var (
 	defaultLineColor = color.RGBA{R: 255, A: 255}
 )
+
+// StreamTreeRequest describes a request to stream the rows of a tree
+// over a WebSocket connection.
+type StreamTreeRequest struct {
+	URI  string   `json:"uri"`
+	Dir  string   `json:"dir"`
+	Obj  string   `json:"obj"`
+	Vars []string `json:"vars,omitempty"` // names of the branches to stream. (default: all)
+	Page int64    `json:"page,omitempty"` // number of rows per page. (default: 100)
+
+	// Filter is a selection expression rows must pass to be streamed,
+	// as described by rtree.NewFormula. (default: all rows)
+	Filter string `json:"filter,omitempty"`
+}
+
+// StreamTreeCmd is a command sent by the client to drive the streaming of
+// the rows of a tree.
+type StreamTreeCmd struct {
+	Cmd   string `json:"cmd"`             // "next", "seek" or "close"
+	Entry int64  `json:"entry,omitempty"` // entry to seek to.
+	Pages int    `json:"pages,omitempty"` // number of pages to send. (default: 1)
+}
+
+// StreamTreeResponse is a page of rows of a tree streamed over a WebSocket
+// connection.
+type StreamTreeResponse struct {
+	URI     string          `json:"uri"`
+	Dir     string          `json:"dir"`
+	Obj     string          `json:"obj"`
+	Vars    []string        `json:"vars"`
+	Entries int64           `json:"entries"`        // total number of entries in the tree.
+	Beg     int64           `json:"beg"`            // first entry of the page.
+	Rows    [][]interface{} `json:"rows,omitempty"` // values of the page, row by row.
+	EOF     bool            `json:"eof,omitempty"`  // whether the page is the last one.
+	Err     string          `json:"error,omitempty"`
+}
+
+// WatchPlotRequest describes a request to plot an object of a ROOT file
+// and to push a new plot over a WebSocket connection each time the ROOT
+// file is modified.
+type WatchPlotRequest struct {
+	Kind string   `json:"kind"` // kind of plot: "h1", "h2", "s2" or "tree".
+	URI  string   `json:"uri"`
+	Dir  string   `json:"dir"`
+	Obj  string   `json:"obj"`
+	Vars []string `json:"vars,omitempty"` // names of the branches to plot, for "tree" plots.
+
+	Options PlotOptions `json:"options"`
+
+	// Period is the interval, in milliseconds, between two checks for
+	// modifications of the ROOT file. (default: 1000)
+	Period int `json:"period,omitempty"`
+}
+
+// WatchPlotCmd is a command sent by the client to drive the watch of a plot.
+type WatchPlotCmd struct {
+	Cmd string `json:"cmd"` // "refresh" or "close"
+}
+
+// WatchPlotResponse is a plot pushed over a WebSocket connection.
+type WatchPlotResponse struct {
+	PlotResponse
+	Err string `json:"error,omitempty"`
+}
@@ -76,6 +76,17 @@ func (db *DB) set(uri string, f *riofs.File) {
 	db.files[uri] = f
 }
 
+// reopen re-opens the ROOT file associated with the provided URI, to take
+// into account the modifications of that file.
+func (db *DB) reopen(uri string) error {
+	f, err := riofs.Open(uri)
+	if err != nil {
+		return fmt.Errorf("could not re-open ROOT file %q: %w", uri, err)
+	}
+	db.set(uri, f)
+	return nil
+}
+
 func (db *DB) del(uri string) {
 	db.Lock()
 	defer db.Unlock()
@@ -436,6 +436,124 @@ func (srv *Server) handleTree(w http.ResponseWriter, r *http.Request) error {
 	return json.NewEncoder(w).Encode(resp)
 }
 
+// Hist returns the content of the 1-dim or 2-dim histogram specified by
+// the HistRequest:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1"}
+// Hist replies with a HistResponse:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1",
+//    "hist": {
+//      "type": "TH1D", "name": "h1", "title": "my title", "dim": 1,
+//      "entries": 42,
+//      "xedges": [0, 1, 2, 3],
+//      "sumw": [10, 20, 12],
+//      "sumw2": [10, 20, 12]
+//    }
+//  }
+func (srv *Server) Hist(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleHist)(w, r)
+}
+
+func (srv *Server) handleHist(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req HistRequest
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode hist request: %w", err)
+	}
+
+	resp := HistResponse{
+		URI: req.URI,
+		Dir: req.Dir,
+		Obj: req.Obj,
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		obj, err := riofs.Dir(f).Get(req.Dir)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+		}
+
+		obj, err = dir.Get(req.Obj)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+		}
+
+		resp.Hist.Type = obj.Class()
+		switch robj := obj.(type) {
+		case rhist.H1:
+			h := rootcnv.H1D(robj)
+			bins := h.Binning.Bins
+			resp.Hist.Name = robj.Name()
+			resp.Hist.Title = robj.Title()
+			resp.Hist.Dim = 1
+			resp.Hist.Entries = h.Entries()
+			resp.Hist.XEdges = make([]float64, 0, len(bins)+1)
+			resp.Hist.SumW = make([]float64, len(bins))
+			resp.Hist.SumW2 = make([]float64, len(bins))
+			for i, bin := range bins {
+				resp.Hist.XEdges = append(resp.Hist.XEdges, bin.XMin())
+				resp.Hist.SumW[i] = bin.SumW()
+				resp.Hist.SumW2[i] = bin.SumW2()
+			}
+			if n := len(bins); n > 0 {
+				resp.Hist.XEdges = append(resp.Hist.XEdges, bins[n-1].XMax())
+			}
+
+		case rhist.H2:
+			h := rootcnv.H2D(robj)
+			bng := h.Binning
+			resp.Hist.Name = robj.Name()
+			resp.Hist.Title = robj.Title()
+			resp.Hist.Dim = 2
+			resp.Hist.Entries = h.Entries()
+			resp.Hist.XEdges = edgesOf(bng.XEdges)
+			resp.Hist.YEdges = edgesOf(bng.YEdges)
+			resp.Hist.SumW = make([]float64, len(bng.Bins))
+			resp.Hist.SumW2 = make([]float64, len(bng.Bins))
+			for i, bin := range bng.Bins {
+				resp.Hist.SumW[i] = bin.SumW()
+				resp.Hist.SumW2[i] = bin.SumW2()
+			}
+
+		default:
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 1-dim nor a 2-dim histogram (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// edgesOf returns the edges of the provided contiguous bins.
+func edgesOf(bins []hbook.Bin1D) []float64 {
+	if len(bins) == 0 {
+		return nil
+	}
+	edges := make([]float64, len(bins)+1)
+	for i, bin := range bins {
+		edges[i] = bin.XMin()
+	}
+	edges[len(bins)] = bins[len(bins)-1].XMax()
+	return edges
+}
+
 // PlotH1 plots the 1-dim histogram specified by the PlotH1Request:
 //  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1", "type": "png"}
 //  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1", "type": "svg",
@@ -454,10 +572,7 @@ func (srv *Server) handlePlotH1(w http.ResponseWriter, r *http.Request) error {
 	dec := json.NewDecoder(r.Body)
 	defer r.Body.Close()
 
-	var (
-		req  PlotH1Request
-		resp PlotResponse
-	)
+	var req PlotH1Request
 
 	err := dec.Decode(&req)
 	if err != nil {
@@ -469,7 +584,20 @@ func (srv *Server) handlePlotH1(w http.ResponseWriter, r *http.Request) error {
 		return fmt.Errorf("could not open ROOT file database: %w", err)
 	}
 
-	err = db.Tx(req.URI, func(f *riofs.File) error {
+	resp, err := srv.plotH1(db, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// plotH1 renders the 1-dim histogram specified by the request.
+func (srv *Server) plotH1(db *DB, req PlotH1Request) (PlotResponse, error) {
+	var resp PlotResponse
+	err := db.Tx(req.URI, func(f *riofs.File) error {
 		if f == nil {
 			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
 		}
@@ -523,13 +651,7 @@ func (srv *Server) handlePlotH1(w http.ResponseWriter, r *http.Request) error {
 		resp.Data = base64.StdEncoding.EncodeToString(out)
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	return json.NewEncoder(w).Encode(resp)
+	return resp, err
 }
 
 // PlotH2 plots the 2-dim histogram specified by the PlotH2Request:
@@ -548,10 +670,7 @@ func (srv *Server) handlePlotH2(w http.ResponseWriter, r *http.Request) error {
 	dec := json.NewDecoder(r.Body)
 	defer r.Body.Close()
 
-	var (
-		req  PlotH2Request
-		resp PlotResponse
-	)
+	var req PlotH2Request
 
 	err := dec.Decode(&req)
 	if err != nil {
@@ -563,7 +682,20 @@ func (srv *Server) handlePlotH2(w http.ResponseWriter, r *http.Request) error {
 		return fmt.Errorf("could not open ROOT file database: %w", err)
 	}
 
-	err = db.Tx(req.URI, func(f *riofs.File) error {
+	resp, err := srv.plotH2(db, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// plotH2 renders the 2-dim histogram specified by the request.
+func (srv *Server) plotH2(db *DB, req PlotH2Request) (PlotResponse, error) {
+	var resp PlotResponse
+	err := db.Tx(req.URI, func(f *riofs.File) error {
 		if f == nil {
 			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
 		}
@@ -615,13 +747,7 @@ func (srv *Server) handlePlotH2(w http.ResponseWriter, r *http.Request) error {
 		resp.Data = base64.StdEncoding.EncodeToString(out)
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	return json.NewEncoder(w).Encode(resp)
+	return resp, err
 }
 
 // PlotS2 plots the 2-dim scatter specified by the PlotS2Request:
@@ -641,10 +767,7 @@ func (srv *Server) handlePlotS2(w http.ResponseWriter, r *http.Request) error {
 	dec := json.NewDecoder(r.Body)
 	defer r.Body.Close()
 
-	var (
-		req  PlotS2Request
-		resp PlotResponse
-	)
+	var req PlotS2Request
 
 	err := dec.Decode(&req)
 	if err != nil {
@@ -656,7 +779,20 @@ func (srv *Server) handlePlotS2(w http.ResponseWriter, r *http.Request) error {
 		return fmt.Errorf("could not open ROOT file database: %w", err)
 	}
 
-	err = db.Tx(req.URI, func(f *riofs.File) error {
+	resp, err := srv.plotS2(db, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// plotS2 renders the 2-dim scatter specified by the request.
+func (srv *Server) plotS2(db *DB, req PlotS2Request) (PlotResponse, error) {
+	var resp PlotResponse
+	err := db.Tx(req.URI, func(f *riofs.File) error {
 		if f == nil {
 			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
 		}
@@ -715,13 +851,7 @@ func (srv *Server) handlePlotS2(w http.ResponseWriter, r *http.Request) error {
 		resp.Data = base64.StdEncoding.EncodeToString(out)
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	return json.NewEncoder(w).Encode(resp)
+	return resp, err
 }
 
 // PlotTree plots the Tree branch(es) specified by the PlotBranchRequest:
@@ -741,10 +871,7 @@ func (srv *Server) handlePlotTree(w http.ResponseWriter, r *http.Request) error
 	dec := json.NewDecoder(r.Body)
 	defer r.Body.Close()
 
-	var (
-		req  PlotTreeRequest
-		resp PlotResponse
-	)
+	var req PlotTreeRequest
 
 	err := dec.Decode(&req)
 	if err != nil {
@@ -756,7 +883,20 @@ func (srv *Server) handlePlotTree(w http.ResponseWriter, r *http.Request) error
 		return fmt.Errorf("could not open ROOT file database: %w", err)
 	}
 
-	err = db.Tx(req.URI, func(f *riofs.File) error {
+	resp, err := srv.plotTree(db, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// plotTree renders the tree branch specified by the request.
+func (srv *Server) plotTree(db *DB, req PlotTreeRequest) (PlotResponse, error) {
+	var resp PlotResponse
+	err := db.Tx(req.URI, func(f *riofs.File) error {
 		if f == nil {
 			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
 		}
@@ -872,11 +1012,5 @@ func (srv *Server) handlePlotTree(w http.ResponseWriter, r *http.Request) error
 		resp.Data = base64.StdEncoding.EncodeToString(out)
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	return json.NewEncoder(w).Encode(resp)
+	return resp, err
 }
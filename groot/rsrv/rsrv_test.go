@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"image/color"
 	"io"
 	"log"
@@ -19,12 +20,17 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	uuid "github.com/hashicorp/go-uuid"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/hbook"
+	"golang.org/x/net/websocket"
 	"gonum.org/v1/plot/cmpimg"
 )
 
@@ -53,10 +59,13 @@ func newTestServer() *httptest.Server {
 	mux.HandleFunc("/list-files", srv.ListFiles)
 	mux.HandleFunc("/list-dirs", srv.Dirent)
 	mux.HandleFunc("/list-tree", srv.Tree)
+	mux.HandleFunc("/hist", srv.Hist)
 	mux.HandleFunc("/plot-h1", srv.PlotH1)
 	mux.HandleFunc("/plot-h2", srv.PlotH2)
 	mux.HandleFunc("/plot-s2", srv.PlotS2)
 	mux.HandleFunc("/plot-tree", srv.PlotTree)
+	mux.HandleFunc("/stream-tree", srv.StreamTree)
+	mux.HandleFunc("/watch-plot", srv.WatchPlot)
 
 	return httptest.NewServer(mux)
 }
@@ -500,6 +509,135 @@ func testTree(t *testing.T, ts *httptest.Server, req TreeRequest, resp *TreeResp
 	}
 }
 
+func TestHist(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var uris []string
+	for _, fname := range []string{
+		"../testdata/dirs-6.14.00.root",
+		"../../hbook/rootcnv/testdata/gauss-h2.root",
+	} {
+		local, err := filepath.Abs(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		uri := "file://" + local
+		testOpenFile(t, ts, uri, http.StatusOK)
+		defer testCloseFile(t, ts, uri)
+		uris = append(uris, uri)
+	}
+
+	for _, tc := range []struct {
+		req    HistRequest
+		typ    string
+		dim    int
+		nx, ny int
+		edges  []float64
+	}{
+		{
+			req: HistRequest{URI: uris[0], Dir: "/dir1/dir11", Obj: "h1"},
+			typ: "TH1F",
+			dim: 1,
+			nx:  100,
+		},
+		{
+			req: HistRequest{URI: uris[1], Obj: "h2d"},
+			typ: "TH2D",
+			dim: 2,
+			nx:  3,
+			ny:  3,
+		},
+		{
+			req:   HistRequest{URI: uris[1], Obj: "h2f-var"},
+			typ:   "TH2F",
+			dim:   2,
+			nx:    3,
+			ny:    3,
+			edges: []float64{0, 1, 2, 3},
+		},
+	} {
+		t.Run(tc.req.Obj, func(t *testing.T) {
+			var resp HistResponse
+			testHist(t, ts, tc.req, &resp)
+
+			h := resp.Hist
+			if got, want := h.Type, tc.typ; got != want {
+				t.Fatalf("invalid type: got=%q, want=%q", got, want)
+			}
+			if got, want := h.Name, tc.req.Obj; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := h.Dim, tc.dim; got != want {
+				t.Fatalf("invalid dim: got=%d, want=%d", got, want)
+			}
+			if got, want := len(h.XEdges), tc.nx+1; got != want {
+				t.Fatalf("invalid x-edges: got=%d, want=%d", got, want)
+			}
+			nbins := tc.nx
+			switch tc.dim {
+			case 1:
+				if h.YEdges != nil {
+					t.Fatalf("invalid y-edges: got=%v, want=nil", h.YEdges)
+				}
+			case 2:
+				if got, want := len(h.YEdges), tc.ny+1; got != want {
+					t.Fatalf("invalid y-edges: got=%d, want=%d", got, want)
+				}
+				nbins *= tc.ny
+			}
+			if tc.edges != nil {
+				if got, want := h.XEdges, tc.edges; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid x-edges: got=%v, want=%v", got, want)
+				}
+				if got, want := h.YEdges, tc.edges; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid y-edges: got=%v, want=%v", got, want)
+				}
+			}
+			if got, want := len(h.SumW), nbins; got != want {
+				t.Fatalf("invalid sumw: got=%d, want=%d", got, want)
+			}
+			if got, want := len(h.SumW2), nbins; got != want {
+				t.Fatalf("invalid sumw2: got=%d, want=%d", got, want)
+			}
+			if h.Entries <= 0 {
+				t.Fatalf("invalid entries: got=%d", h.Entries)
+			}
+		})
+	}
+}
+
+func testHist(t *testing.T, ts *httptest.Server, req HistRequest, resp *HistResponse) {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(req)
+	if err != nil {
+		t.Fatalf("could not encode request: %v", err)
+	}
+
+	hreq, err := http.NewRequest(http.MethodPost, ts.URL+"/hist", body)
+	if err != nil {
+		t.Fatalf("could not create http request: %v", err)
+	}
+	srv.addCookies(hreq)
+
+	hresp, err := ts.Client().Do(hreq)
+	if err != nil {
+		t.Fatalf("could not post http request: %v", err)
+	}
+	defer hresp.Body.Close()
+
+	if hresp.StatusCode != http.StatusOK {
+		t.Fatalf("could not fetch histogram: %v", hresp.StatusCode)
+	}
+
+	err = json.NewDecoder(hresp.Body).Decode(resp)
+	if err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+}
+
 func TestPlotH1(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -999,6 +1137,425 @@ func testPlotTree(t *testing.T, ts *httptest.Server, req PlotTreeRequest, resp *
 	}
 }
 
+func TestStreamTree(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	const uri = "stream.root"
+	testUploadFile(t, ts, uri, local, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	cfg, err := websocket.NewConfig(strings.Replace(ts.URL, "http://", "ws://", 1)+"/stream-tree", ts.URL)
+	if err != nil {
+		t.Fatalf("could not create websocket config: %+v", err)
+	}
+	for _, cookie := range srv.cookies {
+		cfg.Header.Add("Cookie", cookie.String())
+	}
+
+	ws, err := websocket.DialConfig(cfg)
+	if err != nil {
+		t.Fatalf("could not dial websocket: %+v", err)
+	}
+	defer ws.Close()
+
+	recv := func() StreamTreeResponse {
+		t.Helper()
+		var resp StreamTreeResponse
+		err := websocket.JSON.Receive(ws, &resp)
+		if err != nil {
+			t.Fatalf("could not receive page: %+v", err)
+		}
+		if resp.Err != "" {
+			t.Fatalf("stream error: %s", resp.Err)
+		}
+		return resp
+	}
+
+	send := func(cmd StreamTreeCmd) {
+		t.Helper()
+		err := websocket.JSON.Send(ws, cmd)
+		if err != nil {
+			t.Fatalf("could not send command: %+v", err)
+		}
+	}
+
+	err = websocket.JSON.Send(ws, StreamTreeRequest{
+		URI:  uri,
+		Obj:  "tree",
+		Vars: []string{"one", "three"},
+		Page: 3,
+	})
+	if err != nil {
+		t.Fatalf("could not send request: %+v", err)
+	}
+
+	resp := recv()
+	if got, want := resp.Vars, []string{"one", "three"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid vars: got=%q, want=%q", got, want)
+	}
+	if got, want := resp.Entries, int64(4); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := resp.Rows, [][]interface{}{{1.0, "uno"}, {2.0, "dos"}, {3.0, "tres"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid first page:\ngot= %v\nwant=%v", got, want)
+	}
+	if resp.EOF {
+		t.Fatalf("unexpected EOF")
+	}
+
+	send(StreamTreeCmd{Cmd: "next"})
+	resp = recv()
+	if got, want := resp.Beg, int64(3); got != want {
+		t.Fatalf("invalid page beginning: got=%d, want=%d", got, want)
+	}
+	if got, want := resp.Rows, [][]interface{}{{4.0, "quatro"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid last page:\ngot= %v\nwant=%v", got, want)
+	}
+	if !resp.EOF {
+		t.Fatalf("expected EOF")
+	}
+
+	send(StreamTreeCmd{Cmd: "seek", Entry: 1})
+	resp = recv()
+	if got, want := resp.Rows, [][]interface{}{{2.0, "dos"}, {3.0, "tres"}, {4.0, "quatro"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid page after seek:\ngot= %v\nwant=%v", got, want)
+	}
+
+	send(StreamTreeCmd{Cmd: "close"})
+}
+
+func TestStreamTreeCloseFile(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	const uri = "stream-close.root"
+	testUploadFile(t, ts, uri, local, http.StatusOK)
+
+	ws := dialWebSocket(t, ts, "/stream-tree")
+	defer ws.Close()
+
+	recv := func() StreamTreeResponse {
+		t.Helper()
+		var resp StreamTreeResponse
+		err := websocket.JSON.Receive(ws, &resp)
+		if err != nil {
+			t.Fatalf("could not receive page: %+v", err)
+		}
+		return resp
+	}
+
+	send := func(cmd StreamTreeCmd) {
+		t.Helper()
+		err := websocket.JSON.Send(ws, cmd)
+		if err != nil {
+			t.Fatalf("could not send command: %+v", err)
+		}
+	}
+
+	err = websocket.JSON.Send(ws, StreamTreeRequest{
+		URI:  uri,
+		Obj:  "tree",
+		Vars: []string{"one"},
+		Page: 1,
+	})
+	if err != nil {
+		t.Fatalf("could not send request: %+v", err)
+	}
+
+	resp := recv()
+	if resp.Err != "" {
+		t.Fatalf("stream error: %s", resp.Err)
+	}
+
+	// close the file while other connections stream the tree,
+	// with and without a filter.
+	var (
+		wg   sync.WaitGroup
+		errc = make(chan error, 4)
+	)
+	for i := 0; i < cap(errc); i++ {
+		ws := dialWebSocket(t, ts, "/stream-tree")
+		defer ws.Close()
+		req := StreamTreeRequest{URI: uri, Obj: "tree", Page: 1}
+		if i%2 == 1 {
+			req.Filter = "one != 2"
+		}
+		err := websocket.JSON.Send(ws, req)
+		if err != nil {
+			t.Fatalf("could not send request: %+v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var resp StreamTreeResponse
+				err := websocket.JSON.Receive(ws, &resp)
+				if err != nil {
+					errc <- fmt.Errorf("could not receive page: %w", err)
+					return
+				}
+				switch {
+				case resp.Err != "":
+					if !strings.Contains(resp.Err, "no such file") {
+						errc <- fmt.Errorf("invalid stream error: %q", resp.Err)
+					}
+					return
+				case resp.EOF:
+					resp.Beg = -1
+				}
+				cmd := StreamTreeCmd{Cmd: "next"}
+				if resp.Beg < 0 {
+					cmd = StreamTreeCmd{Cmd: "seek", Entry: 0}
+				}
+				err = websocket.JSON.Send(ws, cmd)
+				if err != nil {
+					errc <- fmt.Errorf("could not send command: %w", err)
+					return
+				}
+			}
+		}()
+	}
+
+	testCloseFile(t, ts, uri)
+	wg.Wait()
+	close(errc)
+	for err := range errc {
+		t.Fatalf("%+v", err)
+	}
+
+	send(StreamTreeCmd{Cmd: "next"})
+	resp = recv()
+	if resp.Rows != nil {
+		t.Fatalf("unexpected rows from closed file: %v", resp.Rows)
+	}
+	if got, want := resp.Err, fmt.Sprintf("rsrv: no such file %q", uri); got != want {
+		t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestStreamTreeFilter(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	const uri = "stream-filter.root"
+	testUploadFile(t, ts, uri, local, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	ws := dialWebSocket(t, ts, "/stream-tree")
+	defer ws.Close()
+
+	recv := func() StreamTreeResponse {
+		t.Helper()
+		var resp StreamTreeResponse
+		err := websocket.JSON.Receive(ws, &resp)
+		if err != nil {
+			t.Fatalf("could not receive page: %+v", err)
+		}
+		return resp
+	}
+
+	err = websocket.JSON.Send(ws, StreamTreeRequest{
+		URI:    uri,
+		Obj:    "tree",
+		Vars:   []string{"one", "three"},
+		Page:   2,
+		Filter: "one != 2",
+	})
+	if err != nil {
+		t.Fatalf("could not send request: %+v", err)
+	}
+
+	resp := recv()
+	if resp.Err != "" {
+		t.Fatalf("stream error: %s", resp.Err)
+	}
+	if got, want := resp.Rows, [][]interface{}{{1.0, "uno"}, {3.0, "tres"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid first page:\ngot= %v\nwant=%v", got, want)
+	}
+	if resp.EOF {
+		t.Fatalf("unexpected EOF")
+	}
+
+	err = websocket.JSON.Send(ws, StreamTreeCmd{Cmd: "next"})
+	if err != nil {
+		t.Fatalf("could not send command: %+v", err)
+	}
+	resp = recv()
+	if got, want := resp.Beg, int64(3); got != want {
+		t.Fatalf("invalid page beginning: got=%d, want=%d", got, want)
+	}
+	if got, want := resp.Rows, [][]interface{}{{4.0, "quatro"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid last page:\ngot= %v\nwant=%v", got, want)
+	}
+	if !resp.EOF {
+		t.Fatalf("expected EOF")
+	}
+
+	// invalid filter.
+	ws2 := dialWebSocket(t, ts, "/stream-tree")
+	defer ws2.Close()
+
+	err = websocket.JSON.Send(ws2, StreamTreeRequest{
+		URI:    uri,
+		Obj:    "tree",
+		Filter: "not-there > 2",
+	})
+	if err != nil {
+		t.Fatalf("could not send request: %+v", err)
+	}
+	var bad StreamTreeResponse
+	err = websocket.JSON.Receive(ws2, &bad)
+	if err != nil {
+		t.Fatalf("could not receive response: %+v", err)
+	}
+	if !strings.HasPrefix(bad.Err, `could not create filter "not-there > 2"`) {
+		t.Fatalf("invalid error: %q", bad.Err)
+	}
+}
+
+func TestWatchPlot(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	tmp, err := os.MkdirTemp("", "groot-rsrv-watch-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "live.root")
+	create := func(n int) {
+		t.Helper()
+		// create a new file and move it over the watched one, so the
+		// currently opened (and mmap'ed) version is left untouched.
+		tmpname := filepath.Join(tmp, "tmp.root")
+		f, err := groot.Create(tmpname)
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		h := hbook.NewH1D(10, 0, 10)
+		for i := 0; i < n; i++ {
+			h.Fill(float64(i%10)+0.5, 1)
+		}
+		err = f.Put("h1", rhist.NewH1DFrom(h))
+		if err != nil {
+			t.Fatalf("could not save histogram: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+		err = os.Rename(tmpname, fname)
+		if err != nil {
+			t.Fatalf("could not rename ROOT file: %+v", err)
+		}
+	}
+	create(5)
+
+	uri := "file://" + fname
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	ws := dialWebSocket(t, ts, "/watch-plot")
+	defer ws.Close()
+
+	recv := func() WatchPlotResponse {
+		t.Helper()
+		var resp WatchPlotResponse
+		err := websocket.JSON.Receive(ws, &resp)
+		if err != nil {
+			t.Fatalf("could not receive plot: %+v", err)
+		}
+		return resp
+	}
+
+	err = websocket.JSON.Send(ws, WatchPlotRequest{
+		Kind:   "h1",
+		URI:    uri,
+		Obj:    "h1",
+		Period: 10,
+	})
+	if err != nil {
+		t.Fatalf("could not send request: %+v", err)
+	}
+
+	first := recv()
+	if first.Err != "" {
+		t.Fatalf("watch error: %s", first.Err)
+	}
+	if first.Data == "" {
+		t.Fatalf("empty plot")
+	}
+
+	create(50)
+	live := recv()
+	if live.Err != "" {
+		t.Fatalf("watch error: %s", live.Err)
+	}
+	if live.Data == first.Data {
+		t.Fatalf("plot was not updated")
+	}
+
+	for _, tc := range []struct {
+		cmd string
+		err string
+	}{
+		{cmd: "refresh"},
+		{cmd: "not-there", err: `rsrv: invalid watch-plot command "not-there"`},
+	} {
+		err = websocket.JSON.Send(ws, WatchPlotCmd{Cmd: tc.cmd})
+		if err != nil {
+			t.Fatalf("could not send command: %+v", err)
+		}
+		resp := recv()
+		if got, want := resp.Err, tc.err; got != want {
+			t.Fatalf("invalid error for %q: got=%q, want=%q", tc.cmd, got, want)
+		}
+		if tc.err == "" && resp.Data != live.Data {
+			t.Fatalf("invalid refreshed plot")
+		}
+	}
+
+	err = websocket.JSON.Send(ws, WatchPlotCmd{Cmd: "close"})
+	if err != nil {
+		t.Fatalf("could not send command: %+v", err)
+	}
+}
+
+func dialWebSocket(t *testing.T, ts *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+
+	cfg, err := websocket.NewConfig(strings.Replace(ts.URL, "http://", "ws://", 1)+path, ts.URL)
+	if err != nil {
+		t.Fatalf("could not create websocket config: %+v", err)
+	}
+	for _, cookie := range srv.cookies {
+		cfg.Header.Add("Cookie", cookie.String())
+	}
+
+	ws, err := websocket.DialConfig(cfg)
+	if err != nil {
+		t.Fatalf("could not dial websocket: %+v", err)
+	}
+	return ws
+}
+
 func (srv *Server) addCookies(req *http.Request) {
 	for _, cookie := range srv.cookies {
 		req.AddCookie(cookie)
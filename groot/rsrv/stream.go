@@ -0,0 +1,376 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"golang.org/x/net/websocket"
+)
+
+const defaultStreamPage = 100
+
+// StreamTree streams the rows of the tree branch(es) specified by a
+// StreamTreeRequest over a WebSocket connection.
+//
+// Once the connection is established, the client sends a StreamTreeRequest:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "vars": ["pt", "eta"], "page": 50}
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "vars": ["pt"], "filter": "pt > 20"}
+// StreamTree replies with a first StreamTreeResponse page:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "vars": ["pt", "eta"],
+//   "entries": 1000, "beg": 0, "rows": [[10.2, 0.5], [22.3, -1.2], ...]}
+//
+// Subsequent pages are only sent when requested by the client, with a
+// StreamTreeCmd:
+//  {"cmd": "next", "pages": 2}
+//  {"cmd": "seek", "entry": 500}
+//  {"cmd": "close"}
+// so slow clients are never flooded with rows they can not display.
+// The last page of the tree is flagged with "eof".
+// When a filter is provided, only the rows passing the filter are streamed
+// and the pages are filled with the next passing rows.
+func (srv *Server) StreamTree(w http.ResponseWriter, r *http.Request) {
+	err := srv.setCookie(w, r)
+	if err != nil {
+		log.Printf("error retrieving cookie: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		log.Printf("error %q: %v\n", r.URL.Path, err.Error())
+		http.Error(w, fmt.Errorf("could not open ROOT file database: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	websocket.Handler(func(ws *websocket.Conn) {
+		defer ws.Close()
+		err := srv.handleStreamTree(db, ws)
+		if err != nil {
+			log.Printf("error %q: %v\n", r.URL.Path, err.Error())
+		}
+	}).ServeHTTP(w, r)
+}
+
+func (srv *Server) handleStreamTree(db *DB, ws *websocket.Conn) error {
+	var req StreamTreeRequest
+	err := websocket.JSON.Receive(ws, &req)
+	if err != nil {
+		return fmt.Errorf("could not decode stream-tree request: %w", err)
+	}
+
+	stream, err := newTreeStream(db, req)
+	if err != nil {
+		_ = websocket.JSON.Send(ws, StreamTreeResponse{
+			URI: req.URI,
+			Dir: req.Dir,
+			Obj: req.Obj,
+			Err: err.Error(),
+		})
+		return err
+	}
+
+	err = stream.send(ws, 1)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var cmd StreamTreeCmd
+		err := websocket.JSON.Receive(ws, &cmd)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("could not decode stream-tree command: %w", err)
+		}
+
+		switch cmd.Cmd {
+		case "next":
+			// ok.
+		case "seek":
+			stream.seek(cmd.Entry)
+		case "close":
+			return nil
+		default:
+			resp := stream.resp()
+			resp.Err = fmt.Sprintf("rsrv: invalid stream-tree command %q", cmd.Cmd)
+			err = websocket.JSON.Send(ws, resp)
+			if err != nil {
+				return fmt.Errorf("could not send stream-tree error: %w", err)
+			}
+			continue
+		}
+
+		err = stream.send(ws, cmd.Pages)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// treeStream streams pages of rows of a tree.
+//
+// The ROOT file holding the tree is only accessed through DB.Tx, for each
+// page of rows, so the file can be safely closed or re-opened while the
+// tree is being streamed.
+type treeStream struct {
+	db    *DB
+	req   StreamTreeRequest
+	names []string
+	nevts int64 // number of entries of the tree
+	cur   int64 // current entry
+}
+
+func newTreeStream(db *DB, req StreamTreeRequest) (*treeStream, error) {
+	if req.Page <= 0 {
+		req.Page = defaultStreamPage
+	}
+
+	s := &treeStream{
+		db:  db,
+		req: req,
+	}
+
+	err := db.Tx(req.URI, func(f *riofs.File) error {
+		tree, err := s.tree(f)
+		if err != nil {
+			return err
+		}
+
+		if req.Filter != "" {
+			_, err := rtree.NewFormula(tree, req.Filter)
+			if err != nil {
+				return fmt.Errorf("could not create filter %q: %w", req.Filter, err)
+			}
+		}
+
+		rvars, err := s.rvars(tree)
+		if err != nil {
+			return err
+		}
+
+		s.names = make([]string, len(rvars))
+		for i, rv := range rvars {
+			s.names[i] = rv.Name
+			if rv.Leaf != rv.Name {
+				s.names[i] += "." + rv.Leaf
+			}
+		}
+		s.nevts = tree.Entries()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// tree retrieves the streamed tree from the provided ROOT file.
+func (s *treeStream) tree(f *riofs.File) (rtree.Tree, error) {
+	req := s.req
+	obj, err := riofs.Dir(f).Get(req.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+	}
+	dir, ok := obj.(riofs.Directory)
+	if !ok {
+		return nil, fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+	}
+
+	obj, err = dir.Get(req.Obj)
+	if err != nil {
+		return nil, fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return nil, fmt.Errorf("rsrv: object %v:%s/%q is not a tree (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+	}
+	return tree, nil
+}
+
+// rvars returns the read-vars of the streamed branches of the tree.
+func (s *treeStream) rvars(tree rtree.Tree) ([]rtree.ReadVar, error) {
+	req := s.req
+	all := rtree.NewReadVars(tree)
+	if len(req.Vars) == 0 {
+		return all, nil
+	}
+
+	var rvars []rtree.ReadVar
+	for _, name := range req.Vars {
+		n := len(rvars)
+		for _, rv := range all {
+			if rv.Name == name {
+				rvars = append(rvars, rv)
+			}
+		}
+		if n == len(rvars) {
+			return nil, fmt.Errorf("rsrv: tree %v:%s/%s has no branch %q", req.URI, req.Dir, req.Obj, name)
+		}
+	}
+	return rvars, nil
+}
+
+func (s *treeStream) resp() StreamTreeResponse {
+	return StreamTreeResponse{
+		URI:     s.req.URI,
+		Dir:     s.req.Dir,
+		Obj:     s.req.Obj,
+		Vars:    s.names,
+		Entries: s.nevts,
+		Beg:     s.cur,
+	}
+}
+
+func (s *treeStream) seek(entry int64) {
+	switch {
+	case entry < 0:
+		entry = 0
+	case entry > s.nevts:
+		entry = s.nevts
+	}
+	s.cur = entry
+}
+
+// send sends the n next pages of rows to the client.
+func (s *treeStream) send(ws *websocket.Conn, n int) error {
+	if n <= 0 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		resp, err := s.page()
+		if err != nil {
+			resp.Rows = nil
+			resp.Err = err.Error()
+		}
+		err = websocket.JSON.Send(ws, resp)
+		if err != nil {
+			return fmt.Errorf("could not send stream-tree page: %w", err)
+		}
+		if resp.EOF || resp.Err != "" {
+			break
+		}
+	}
+	return nil
+}
+
+// errPageFull stops the reading of a tree once a page of rows is full.
+var errPageFull = errors.New("rsrv: page full")
+
+// page reads the next page of rows.
+func (s *treeStream) page() (StreamTreeResponse, error) {
+	var resp StreamTreeResponse
+	err := s.db.Tx(s.req.URI, func(f *riofs.File) error {
+		tree, err := s.tree(f)
+		if err != nil {
+			return err
+		}
+		// the file may have been re-opened since the last page.
+		s.nevts = tree.Entries()
+		resp, err = s.read(tree)
+		return err
+	})
+	if err != nil && resp.URI == "" {
+		resp = s.resp()
+	}
+	return resp, err
+}
+
+// read reads the next page of rows from the provided tree.
+func (s *treeStream) read(tree rtree.Tree) (StreamTreeResponse, error) {
+	rvars, err := s.rvars(tree)
+	if err != nil {
+		return s.resp(), err
+	}
+
+	var (
+		resp = s.resp()
+		nevt = s.nevts
+		beg  = s.cur
+		end  = beg + s.req.Page
+	)
+	if s.req.Filter != "" {
+		// scan the tree until the page is full.
+		end = nevt
+	}
+	if end > nevt {
+		end = nevt
+	}
+	resp.EOF = end >= nevt
+
+	if beg >= end {
+		return resp, nil
+	}
+
+	r, err := rtree.NewReader(tree, rvars, rtree.WithRange(beg, end))
+	if err != nil {
+		return resp, fmt.Errorf("could not create reader for tree %q of file %q: %w", tree.Name(), s.req.URI, err)
+	}
+	defer r.Close()
+
+	var filter func() bool
+	if s.req.Filter != "" {
+		form, err := rtree.NewFormula(tree, s.req.Filter)
+		if err != nil {
+			return resp, fmt.Errorf("could not create filter %q: %w", s.req.Filter, err)
+		}
+		form, err = r.Formula(form)
+		if err != nil {
+			return resp, fmt.Errorf("could not bind filter %q: %w", s.req.Filter, err)
+		}
+		fct, ok := form.Func().(func() bool)
+		if !ok {
+			return resp, fmt.Errorf("rsrv: filter %q is not a boolean expression", s.req.Filter)
+		}
+		filter = fct
+	}
+
+	next := end
+	resp.Rows = make([][]interface{}, 0, s.req.Page)
+	err = r.Read(func(ctx rtree.RCtx) error {
+		if int64(len(resp.Rows)) == s.req.Page {
+			next = ctx.Entry
+			return errPageFull
+		}
+		if filter != nil && !filter() {
+			return nil
+		}
+		row := make([]interface{}, len(rvars))
+		for i, rv := range rvars {
+			// encode values right away, as the reader may reuse
+			// the memory of slices from one entry to the next.
+			raw, err := json.Marshal(rv.Deref())
+			if err != nil {
+				return fmt.Errorf("could not encode %q: %w", s.names[i], err)
+			}
+			row[i] = json.RawMessage(raw)
+		}
+		resp.Rows = append(resp.Rows, row)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return resp, fmt.Errorf("could not read entries [%d, %d): %w", beg, end, err)
+	}
+
+	err = r.Close()
+	if err != nil {
+		return resp, fmt.Errorf("could not close reader: %w", err)
+	}
+
+	s.cur = next
+	resp.EOF = next >= nevt
+	return resp, nil
+}
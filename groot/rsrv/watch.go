@@ -0,0 +1,210 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsrv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const defaultWatchPeriod = 1000 // milliseconds
+
+// WatchPlot plots the object specified by a WatchPlotRequest and pushes a
+// new plot over a WebSocket connection each time the ROOT file holding that
+// object is modified, so live-updated plots can be displayed.
+//
+// Once the connection is established, the client sends a WatchPlotRequest:
+//  {"kind": "h1", "uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1",
+//   "period": 500, "options": {"type": "png"}}
+//  {"kind": "tree", "uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "vars": ["pt"]}
+// WatchPlot replies with a first WatchPlotResponse:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1", "data": "..."}
+// and with a new WatchPlotResponse each time the ROOT file is modified.
+//
+// Modifications are only detected for files located on the local file system.
+// The client can also request the ROOT file to be re-opened and the object
+// to be plotted again, with a WatchPlotCmd:
+//  {"cmd": "refresh"}
+//  {"cmd": "close"}
+func (srv *Server) WatchPlot(w http.ResponseWriter, r *http.Request) {
+	err := srv.setCookie(w, r)
+	if err != nil {
+		log.Printf("error retrieving cookie: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		log.Printf("error %q: %v\n", r.URL.Path, err.Error())
+		http.Error(w, fmt.Errorf("could not open ROOT file database: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	websocket.Handler(func(ws *websocket.Conn) {
+		defer ws.Close()
+		err := srv.handleWatchPlot(db, ws)
+		if err != nil {
+			log.Printf("error %q: %v\n", r.URL.Path, err.Error())
+		}
+	}).ServeHTTP(w, r)
+}
+
+func (srv *Server) handleWatchPlot(db *DB, ws *websocket.Conn) error {
+	var req WatchPlotRequest
+	err := websocket.JSON.Receive(ws, &req)
+	if err != nil {
+		return fmt.Errorf("could not decode watch-plot request: %w", err)
+	}
+
+	if req.Period <= 0 {
+		req.Period = defaultWatchPeriod
+	}
+
+	stamp := stampOf(req.URI)
+	err = srv.sendPlot(db, ws, req, nil)
+	if err != nil {
+		return err
+	}
+
+	var (
+		done = make(chan struct{})
+		cmds = make(chan WatchPlotCmd)
+		errc = make(chan error, 1)
+	)
+	defer close(done)
+
+	go func() {
+		for {
+			var cmd WatchPlotCmd
+			err := websocket.JSON.Receive(ws, &cmd)
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case cmds <- cmd:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	tick := time.NewTicker(time.Duration(req.Period) * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		select {
+		case err := <-errc:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("could not decode watch-plot command: %w", err)
+
+		case cmd := <-cmds:
+			switch cmd.Cmd {
+			case "refresh":
+				stamp = stampOf(req.URI)
+				err = srv.sendPlot(db, ws, req, db.reopen(req.URI))
+			case "close":
+				return nil
+			default:
+				err = srv.sendPlot(db, ws, req, fmt.Errorf("rsrv: invalid watch-plot command %q", cmd.Cmd))
+			}
+			if err != nil {
+				return err
+			}
+
+		case <-tick.C:
+			cur := stampOf(req.URI)
+			if !cur.changed(stamp) {
+				continue
+			}
+			stamp = cur
+			err = srv.sendPlot(db, ws, req, db.reopen(req.URI))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendPlot plots the object specified by the request and sends the plot
+// to the client.
+// If a non-nil error is provided, that error is sent instead.
+func (srv *Server) sendPlot(db *DB, ws *websocket.Conn, req WatchPlotRequest, err error) error {
+	var resp WatchPlotResponse
+	if err == nil {
+		resp.PlotResponse, err = srv.plot(db, req)
+	}
+	if err != nil {
+		resp.URI = req.URI
+		resp.Dir = req.Dir
+		resp.Obj = req.Obj
+		resp.Err = err.Error()
+	}
+
+	err = websocket.JSON.Send(ws, resp)
+	if err != nil {
+		return fmt.Errorf("could not send watch-plot response: %w", err)
+	}
+	return nil
+}
+
+// plot plots the object specified by the request.
+func (srv *Server) plot(db *DB, req WatchPlotRequest) (PlotResponse, error) {
+	switch req.Kind {
+	case "h1":
+		return srv.plotH1(db, PlotH1Request{
+			URI: req.URI, Dir: req.Dir, Obj: req.Obj, Options: req.Options,
+		})
+	case "h2":
+		return srv.plotH2(db, PlotH2Request{
+			URI: req.URI, Dir: req.Dir, Obj: req.Obj, Options: req.Options,
+		})
+	case "s2":
+		return srv.plotS2(db, PlotS2Request{
+			URI: req.URI, Dir: req.Dir, Obj: req.Obj, Options: req.Options,
+		})
+	case "tree":
+		return srv.plotTree(db, PlotTreeRequest{
+			URI: req.URI, Dir: req.Dir, Obj: req.Obj, Vars: req.Vars, Options: req.Options,
+		})
+	default:
+		return PlotResponse{}, fmt.Errorf("rsrv: invalid plot kind %q", req.Kind)
+	}
+}
+
+// fileStamp identifies a version of a file on the local file system.
+type fileStamp struct {
+	ok   bool
+	size int64
+	mod  time.Time
+}
+
+func stampOf(uri string) fileStamp {
+	fi, err := os.Stat(strings.TrimPrefix(uri, "file://"))
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{ok: true, size: fi.Size(), mod: fi.ModTime()}
+}
+
+// changed returns whether the file has been modified since the old stamp.
+func (cur fileStamp) changed(old fileStamp) bool {
+	if !cur.ok {
+		return false
+	}
+	return !old.ok || cur.size != old.size || !cur.mod.Equal(old.mod)
+}
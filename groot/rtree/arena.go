@@ -0,0 +1,155 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Arena holds the values of a leaf for a range of entries, flattened
+// into a single caller-provided buffer.
+//
+// The values of the i-th entry are Data[Offsets[i]:Offsets[i+1]].
+// An Arena can be reused across calls to ReadInto, after a call to Reset,
+// to amortize memory allocations.
+type Arena[T any] struct {
+	Data    []T   // values of all the entries
+	Offsets []int // offsets of the values of each entry into Data
+}
+
+// Len returns the number of entries held by the arena.
+func (a *Arena[T]) Len() int {
+	if len(a.Offsets) == 0 {
+		return 0
+	}
+	return len(a.Offsets) - 1
+}
+
+// At returns the values of the i-th entry held by the arena.
+// The returned slice aliases the arena's data.
+func (a *Arena[T]) At(i int) []T {
+	return a.Data[a.Offsets[i]:a.Offsets[i+1]:a.Offsets[i+1]]
+}
+
+// Reset empties the arena, keeping its allocated storage.
+func (a *Arena[T]) Reset() {
+	a.Data = a.Data[:0]
+	a.Offsets = a.Offsets[:0]
+}
+
+// ReadInto reads the values of the named branch for all the entries
+// selected by the provided options, and appends them to the arena.
+//
+// The branch must hold a single leaf, whose elements are of type T:
+// scalars, fixed-size arrays and variable-length arrays of T are supported.
+// Variable-length arrays are decoded directly into the spare capacity of
+// the arena, without any intermediate per-entry buffer.
+// Values are only copied (and the arena grown) when that spare capacity
+// is too small.
+//
+// Example:
+//
+//  var arena rtree.Arena[float32]
+//  err := rtree.ReadInto(tree, "ArrayFloat32", &arena)
+//  for i := 0; i < arena.Len(); i++ {
+//      vs := arena.At(i)
+//  }
+func ReadInto[T any](t Tree, name string, arena *Arena[T], opts ...ReadOption) error {
+	br := t.Branch(name)
+	if br == nil {
+		return fmt.Errorf("rtree: tree %q has no branch named %q", t.Name(), name)
+	}
+	leaves := br.Leaves()
+	if len(leaves) != 1 || len(br.Branches()) != 0 {
+		return fmt.Errorf("rtree: branch %q does not hold exactly one leaf", name)
+	}
+
+	var (
+		leaf  = leaves[0]
+		etype = reflect.TypeOf((*T)(nil)).Elem()
+		ptype = reflect.TypeOf(newValue(leaf)).Elem()
+		rvar  = ReadVar{Name: name, Leaf: leaf.Name()}
+		fill  func()
+	)
+
+	if len(arena.Offsets) == 0 {
+		arena.Offsets = append(arena.Offsets, len(arena.Data))
+	}
+
+	switch {
+	case ptype == etype:
+		v := new(T)
+		rvar.Value = v
+		fill = func() {
+			arena.Data = append(arena.Data, *v)
+		}
+
+	case ptype.Kind() == reflect.Array && flatElem(ptype) == etype:
+		var (
+			ptr = reflect.New(ptype)
+			// alias the (possibly nested) array as a flat slice.
+			sli = unsafe.Slice((*T)(unsafe.Pointer(ptr.Pointer())), ptype.Size()/etype.Size())
+		)
+		rvar.Value = ptr.Interface()
+		fill = func() {
+			arena.Data = append(arena.Data, sli...)
+		}
+
+	case ptype.Kind() == reflect.Slice && ptype.Elem() == etype:
+		v := new([]T)
+		tail := func() []T {
+			n := len(arena.Data)
+			return arena.Data[n:n]
+		}
+		*v = tail()
+		rvar.Value = v
+		fill = func() {
+			var (
+				vs = *v
+				n  = len(arena.Data)
+			)
+			switch {
+			case len(vs) == 0:
+				// nothing to do.
+			case cap(arena.Data) > n && &vs[0] == &arena.Data[:n+1][n]:
+				// values were decoded in place.
+				arena.Data = arena.Data[:n+len(vs)]
+			default:
+				arena.Data = append(arena.Data, vs...)
+			}
+			*v = tail()
+		}
+
+	default:
+		return fmt.Errorf(
+			"rtree: could not read leaf %q (type=%v) into arena of %v",
+			leaf.Name(), ptype, etype,
+		)
+	}
+
+	r, err := NewReader(t, []ReadVar{rvar}, opts...)
+	if err != nil {
+		return fmt.Errorf("rtree: could not create reader for branch %q: %w", name, err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(RCtx) error {
+		fill()
+		arena.Offsets = append(arena.Offsets, len(arena.Data))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("rtree: could not read branch %q: %w", name, err)
+	}
+
+	return r.Close()
+}
+
+func flatElem(rt reflect.Type) reflect.Type {
+	rt, _ = flattenArrayType(rt)
+	return rt
+}
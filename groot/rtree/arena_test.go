@@ -0,0 +1,172 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestReadInto(t *testing.T) {
+	f, err := riofs.Open("../testdata/x-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	var (
+		i64 int64
+		arr [10]float64
+		sli []float64
+	)
+	r, err := NewReader(tree, []ReadVar{
+		{Name: "I64", Value: &i64},
+		{Name: "ArrF64", Value: &arr},
+		{Name: "SliF64", Value: &sli},
+	})
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	var (
+		wantI64 [][]int64
+		wantArr [][]float64
+		wantSli [][]float64
+	)
+	err = r.Read(func(RCtx) error {
+		wantI64 = append(wantI64, []int64{i64})
+		wantArr = append(wantArr, append([]float64{}, arr[:]...))
+		wantSli = append(wantSli, append([]float64{}, sli...))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	t.Run("I64", func(t *testing.T) {
+		var arena Arena[int64]
+		err := ReadInto(tree, "I64", &arena)
+		if err != nil {
+			t.Fatalf("could not read into arena: %+v", err)
+		}
+		checkArena(t, &arena, wantI64)
+	})
+
+	t.Run("ArrF64", func(t *testing.T) {
+		var arena Arena[float64]
+		err := ReadInto(tree, "ArrF64", &arena)
+		if err != nil {
+			t.Fatalf("could not read into arena: %+v", err)
+		}
+		checkArena(t, &arena, wantArr)
+	})
+
+	t.Run("SliF64", func(t *testing.T) {
+		arena := Arena[float64]{
+			Data: make([]float64, 0, 1024),
+		}
+		data := arena.Data[:1]
+		err := ReadInto(tree, "SliF64", &arena)
+		if err != nil {
+			t.Fatalf("could not read into arena: %+v", err)
+		}
+		checkArena(t, &arena, wantSli)
+		if &arena.Data[:1][0] != &data[0] {
+			t.Fatalf("arena storage was reallocated")
+		}
+
+		// reuse the arena with a smaller capacity than needed.
+		arena = Arena[float64]{Data: make([]float64, 0, 2)}
+		err = ReadInto(tree, "SliF64", &arena)
+		if err != nil {
+			t.Fatalf("could not read into arena: %+v", err)
+		}
+		checkArena(t, &arena, wantSli)
+
+		// append a range of entries to a non-empty arena.
+		err = ReadInto(tree, "SliF64", &arena, WithRange(2, 5))
+		if err != nil {
+			t.Fatalf("could not read into arena: %+v", err)
+		}
+		checkArena(t, &arena, append(wantSli, wantSli[2:5]...))
+
+		arena.Reset()
+		if got := arena.Len(); got != 0 {
+			t.Fatalf("invalid arena length after reset: got=%d", got)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var arena Arena[float32]
+		for _, name := range []string{"SliF64", "I64", "NotThere"} {
+			err := ReadInto(tree, name, &arena)
+			if err == nil {
+				t.Fatalf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func TestReadIntoStdVector(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-evnt-tree-fullsplit.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	const name = "StlVecF64"
+	var sli []float64
+	r, err := NewReader(tree, []ReadVar{{Name: name, Value: &sli}})
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	var want [][]float64
+	err = r.Read(func(RCtx) error {
+		want = append(want, append([]float64{}, sli...))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	var arena Arena[float64]
+	err = ReadInto(tree, name, &arena)
+	if err != nil {
+		t.Fatalf("could not read into arena: %+v", err)
+	}
+	checkArena(t, &arena, want)
+}
+
+func checkArena[T any](t *testing.T, arena *Arena[T], want [][]T) {
+	t.Helper()
+
+	if got, want := arena.Len(), len(want); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	for i := range want {
+		got := arena.At(i)
+		if len(got) == 0 && len(want[i]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("invalid entry %d:\ngot= %v\nwant=%v", i, got, want[i])
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"sync"
+	"time"
 
 	"go-hep.org/x/hep/groot/riofs"
 )
@@ -26,15 +28,29 @@ type bkreader struct {
 	cur    *rbasket      // current buffer being served
 	closed chan struct{} // channel is closed when the async reader shuts down
 
+	rab  rahead
+	held int  // number of baskets reserved from the memory budget
+	stop bool // whether the reader is shutting down
+
 	name string
 }
 
+// rahead describes how baskets are read ahead.
+type rahead struct {
+	n   int       // number of read-ahead baskets, per branch
+	mem *bkbudget // memory budget shared by all branches (nil for no limit)
+	st  *rstats   // statistics of the reader (nil for none)
+
+	cr *corruptions // corrupted baskets (nil to abort on corrupted baskets)
+}
+
 type bkReq struct {
 	bkt *rbasket
 	err error
 }
 
-func newBkReader(b Branch, n int, beg, end int64) *bkreader {
+func newBkReader(b Branch, rab rahead, beg, end int64) *bkreader {
+	n := rab.n
 	if n < 0 {
 		n = runtime.NumCPU() + 1
 	}
@@ -55,8 +71,10 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 		exit:   make(chan struct{}),
 		n:      n,
 		closed: make(chan struct{}),
+		rab:    rab,
 		name:   b.Name(),
 	}
+	bkr.rab.n = n
 
 	if len(base.basketEntry) == len(base.basketSeek) {
 		// prepare for recover basket mode.
@@ -153,7 +171,15 @@ func (bkr *bkreader) run(eoff, beg, end int) {
 	for i, span := range bkr.spans[beg:end] {
 		select {
 		case tok := <-bkr.reuse:
-			tok.err = tok.bkt.inflate(bkr.name, beg+i, span, eoff, bkr.f)
+			mem := int64(span.sz)
+			if !bkr.rab.mem.acquire(bkr, mem) {
+				return
+			}
+			tok.bkt.mem = mem
+			start := time.Now()
+			tok.err = bkr.inflate(tok.bkt, beg+i, span, eoff)
+			tok.bkt.dt = time.Since(start)
+			bkr.rab.mem.adjust(tok.bkt, int64(len(tok.bkt.buf)))
 			bkr.ready <- tok
 		case <-bkr.exit:
 			return
@@ -161,8 +187,23 @@ func (bkr *bkreader) run(eoff, beg, end int) {
 	}
 }
 
+// inflate reads and decompresses the basket described by span into bkt.
+func (bkr *bkreader) inflate(bkt *rbasket, id int, span rspan, eoff int) (err error) {
+	// corrupted baskets may trigger out-of-bounds accesses, which would
+	// otherwise crash the program from the read-ahead goroutine.
+	defer func() {
+		if e := recover(); e != nil {
+			bkt.id = id
+			bkt.span = span
+			err = fmt.Errorf("rtree: could not inflate basket %d: %v", id, e)
+		}
+	}()
+	return bkt.inflate(bkr.name, id, span, eoff, bkr.f)
+}
+
 func (bkr *bkreader) read() (*rbasket, error) {
 	if bkr.cur != nil {
+		bkr.rab.mem.release(bkr, bkr.cur)
 		bkr.cur.reset()
 		bkr.reuse <- bkReq{bkt: bkr.cur, err: nil}
 		bkr.cur = nil
@@ -172,16 +213,107 @@ func (bkr *bkreader) read() (*rbasket, error) {
 		return nil, io.EOF
 	}
 	bkr.cur = tok.bkt
+	bkr.rab.st.basket(int64(tok.bkt.span.sz), tok.bkt.dt)
 
 	return bkr.cur, tok.err
 }
 
 func (bkr *bkreader) close() {
+	bkr.rab.mem.stop(bkr)
 	select {
 	case <-bkr.closed:
 	case bkr.exit <- struct{}{}:
 		<-bkr.closed
 	}
+
+	// give back the memory of the baskets still held.
+	if bkr.cur != nil {
+		bkr.rab.mem.release(bkr, bkr.cur)
+		bkr.cur = nil
+	}
+	for tok := range bkr.ready {
+		bkr.rab.mem.release(bkr, tok.bkt)
+	}
+}
+
+// bkbudget is a memory budget shared by the read-ahead basket readers
+// of a tree reader.
+//
+// A basket reader waits for memory to be released before reading
+// a new basket, unless it does not hold any basket: this guarantees
+// each branch can always make progress.
+type bkbudget struct {
+	mu   sync.Mutex
+	cond sync.Cond
+	max  int64 // maximum number of bytes held by baskets
+	used int64 // number of bytes held by baskets
+}
+
+func newBkBudget(max int64) *bkbudget {
+	if max <= 0 {
+		return nil
+	}
+	b := &bkbudget{max: max}
+	b.cond.L = &b.mu
+	return b
+}
+
+// acquire reserves n bytes for a new basket of bkr.
+// acquire returns false if bkr is shutting down.
+func (b *bkbudget) acquire(bkr *bkreader, n int64) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for !bkr.stop && bkr.held > 0 && b.used+n > b.max {
+		b.cond.Wait()
+	}
+	if bkr.stop {
+		return false
+	}
+	b.used += n
+	bkr.held++
+	return true
+}
+
+// adjust adjusts the memory reserved for bkt to its actual size n.
+func (b *bkbudget) adjust(bkt *rbasket, n int64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used += n - bkt.mem
+	if n < bkt.mem {
+		b.cond.Broadcast()
+	}
+	bkt.mem = n
+}
+
+// release gives back the memory reserved for bkt by bkr.
+func (b *bkbudget) release(bkr *bkreader, bkt *rbasket) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= bkt.mem
+	bkt.mem = 0
+	bkr.held--
+	b.cond.Broadcast()
+}
+
+// stop wakes up bkr if it is waiting for memory and prevents it from
+// reserving more.
+func (b *bkbudget) stop(bkr *bkreader) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bkr.stop = true
+	b.cond.Broadcast()
 }
 
 type rspan struct {
@@ -4,7 +4,14 @@
 
 package rtree
 
-import "testing"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
 
 func TestBkReaderFindBaskets(t *testing.T) {
 	for _, tc := range []struct {
@@ -65,3 +72,157 @@ func TestBkReaderFindBaskets(t *testing.T) {
 		})
 	}
 }
+
+func TestReaderWithPrefetchMemory(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 5000
+	fname := filepath.Join(tmp, "prefetch.root")
+	{
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt struct {
+			I64 int64
+			F64 float64
+			N   int32
+			Sli []float64 `groot:"Sli[N]"`
+		}
+		w, err := NewWriter(f, "tree", WriteVarsFromStruct(&evt), WithBasketSize(512))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < nevts; i++ {
+			evt.I64 = int64(i)
+			evt.F64 = float64(i)
+			evt.N = int32(i % 10)
+			evt.Sli = evt.Sli[:0]
+			for j := 0; j < int(evt.N); j++ {
+				evt.Sli = append(evt.Sli, float64(i))
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	for _, tc := range []struct {
+		mem   int64
+		nrab  int
+		fail  int64 // entry at which to stop reading (-1 to read all)
+		chain bool
+	}{
+		{mem: 1, nrab: 4, fail: -1},
+		{mem: 1024, nrab: 4, fail: -1},
+		{mem: 1 << 20, nrab: 8, fail: -1},
+		{mem: 1024, nrab: -1, fail: 1234},
+		{mem: 1024, nrab: 4, fail: -1, chain: true},
+	} {
+		t.Run(fmt.Sprintf("mem=%d-nrab=%d-chain=%v", tc.mem, tc.nrab, tc.chain), func(t *testing.T) {
+			var (
+				tree = tree
+				want = int64(nevts)
+				evt  struct {
+					I64 int64
+					F64 float64
+					N   int32
+					Sli []float64 `groot:"Sli[N]"`
+				}
+			)
+			if tc.chain {
+				tree = Chain(tree, tree)
+				want *= 2
+			}
+			if tc.fail >= 0 {
+				want = tc.fail
+			}
+
+			r, err := NewReader(
+				tree, ReadVarsFromStruct(&evt),
+				WithPrefetchBaskets(tc.nrab),
+				WithPrefetchMemory(tc.mem),
+			)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			errStop := fmt.Errorf("stop")
+			var n int64
+			err = r.Read(func(ctx RCtx) error {
+				if ctx.Entry == tc.fail {
+					return errStop
+				}
+				n++
+				i := ctx.Entry % nevts
+				if evt.I64 != i || evt.F64 != float64(i) || evt.N != int32(i%10) {
+					return fmt.Errorf("entry %d: invalid values: %+v", ctx.Entry, evt)
+				}
+				for _, v := range evt.Sli {
+					if v != float64(i) {
+						return fmt.Errorf("entry %d: invalid slice: %v", ctx.Entry, evt.Sli)
+					}
+				}
+				return nil
+			})
+			switch {
+			case tc.fail >= 0:
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("could not read tree: %+v", err)
+				}
+			}
+
+			if n != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", n, want)
+			}
+
+			if rt, ok := r.r.(*rtree); ok {
+				mem := rt.brs[0].rb.rab.mem
+				if mem.used != 0 {
+					t.Fatalf("memory budget not released: %d", mem.used)
+				}
+			}
+		})
+	}
+
+	_, err = NewReader(tree, nil, WithPrefetchMemory(-1))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
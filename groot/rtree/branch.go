@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unsafe"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rcont"
 	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
@@ -60,23 +62,7 @@ type tbranch struct {
 }
 
 func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl int, cfg wopt) (Branch, error) {
-	base := &tbranch{
-		named:    *rbase.NewNamed(name, ""),
-		attfill:  *rbase.NewAttFill(),
-		compress: int(cfg.compress),
-
-		iobits:      w.ttree.iobits,
-		basketSize:  int(cfg.bufsize),
-		maxBaskets:  defaultMaxBaskets,
-		basketBytes: make([]int32, 0, defaultMaxBaskets),
-		basketEntry: make([]int64, 1, defaultMaxBaskets),
-		basketSeek:  make([]int64, 0, defaultMaxBaskets),
-
-		tree: &w.ttree,
-		btop: btopOf(parent),
-		bup:  parent,
-		dir:  w.dir,
-	}
+	base := newWBranch(w, name, parent, cfg)
 
 	var (
 		b Branch = base
@@ -101,7 +87,11 @@ func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl
 			return newBranchElementFromWVar(w, base, wvar, parent, lvl, cfg)
 		default:
 			fmt.Fprintf(title, "[%s]", wvar.Count)
-			rt = rt.Elem()
+			et, shape := flattenArrayType(rt.Elem())
+			for _, dim := range shape {
+				fmt.Fprintf(title, "[%d]", dim)
+			}
+			rt = et
 		}
 		base.entryOffsetLen = 1000 // slice, so we need an offset array
 
@@ -142,6 +132,27 @@ func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl
 	return b, nil
 }
 
+// newWBranch creates a new branch, ready to be written to.
+func newWBranch(w *wtree, name string, parent Branch, cfg wopt) *tbranch {
+	return &tbranch{
+		named:    *rbase.NewNamed(name, ""),
+		attfill:  *rbase.NewAttFill(),
+		compress: int(cfg.compress),
+
+		iobits:      w.ttree.iobits,
+		basketSize:  int(cfg.bufsize),
+		maxBaskets:  defaultMaxBaskets,
+		basketBytes: make([]int32, 0, defaultMaxBaskets),
+		basketEntry: make([]int64, 1, defaultMaxBaskets),
+		basketSeek:  make([]int64, 0, defaultMaxBaskets),
+
+		tree: &w.ttree,
+		btop: btopOf(parent),
+		bup:  parent,
+		dir:  w.dir,
+	}
+}
+
 func (b *tbranch) RVersion() int16 {
 	return rvers.Branch
 }
@@ -158,6 +169,18 @@ func (b *tbranch) Class() string {
 	return "TBranch"
 }
 
+// TotBytes returns the total number of bytes of the baskets of this
+// branch, before compression.
+func (b *tbranch) TotBytes() int64 {
+	return b.totBytes
+}
+
+// ZipBytes returns the total number of bytes of the baskets of this
+// branch, after compression.
+func (b *tbranch) ZipBytes() int64 {
+	return b.zipBytes
+}
+
 func (b *tbranch) getTree() *ttree {
 	return b.tree
 }
@@ -751,8 +774,40 @@ func (b *tbranch) flush() error {
 		}
 	}
 
+	if b.ctx.bk == nil {
+		// no basket to write (e.g. split object.)
+		return nil
+	}
+	return b.writeCurrentBasket()
+}
+
+// flushBaskets writes the current baskets of the branch and its
+// sub-branches, if they hold any entry, and creates new ones so the
+// branch can still be written to.
+func (b *tbranch) flushBaskets() error {
+	for i, sub := range b.branches {
+		err := sub.flushBaskets()
+		if err != nil {
+			return fmt.Errorf("could not flush baskets of subbranch[%d]=%q of branch %q: %w", i, sub.Name(), b.Name(), err)
+		}
+	}
+
+	if b.ctx.bk == nil || b.ctx.bk.nevbuf == 0 {
+		return nil
+	}
+
+	err := b.writeCurrentBasket()
+	if err != nil {
+		return err
+	}
+	b.createNewBasket()
+	return nil
+}
+
+// writeCurrentBasket writes the current basket of the branch to file.
+func (b *tbranch) writeCurrentBasket() error {
 	f := b.tree.getFile()
-	totBytes, zipBytes, err := b.ctx.bk.writeFile(f)
+	totBytes, zipBytes, err := b.ctx.bk.writeFile(f, int32(b.compress))
 	if err != nil {
 		return fmt.Errorf("could not marshal basket[%d] (branch=%q): %w", b.writeBasket, b.Name(), err)
 	}
@@ -872,9 +927,12 @@ func newBranchElementFromWVar(w *wtree, base *tbranch, wvar WriteVar, parent Bra
 		stype:    -1,
 		streamer: streamer,
 	}
-	switch reflect.TypeOf(wvar.Value).Elem().Kind() {
+	switch rt := reflect.TypeOf(wvar.Value).Elem(); rt.Kind() {
 	case reflect.Struct:
 		b.tbranch.entryOffsetLen = 20
+		if isSplittable(rt) && lvl < int(cfg.splitlvl) {
+			return newSplitBranchElementFromWVar(w, b, wvar, lvl, cfg)
+		}
 	case reflect.Slice:
 		b.tbranch.entryOffsetLen = 400
 	}
@@ -890,6 +948,147 @@ func newBranchElementFromWVar(w *wtree, base *tbranch, wvar WriteVar, parent Bra
 	return b, nil
 }
 
+// newSplitBranchElementFromWVar creates a branch for the struct value of
+// wvar, split into one sub-branch per data member, as ROOT does for split
+// objects.
+// The top-level branch holds no data: each entry is written by the
+// sub-branches.
+func newSplitBranchElementFromWVar(w *wtree, b *tbranchElement, wvar WriteVar, lvl int, cfg wopt) (Branch, error) {
+	rv := reflect.ValueOf(wvar.Value).Elem()
+
+	b.id = -2 // top-level branch of a split object.
+	b.tbranch.entryOffsetLen = 1000
+	b.tbranch.splitLevel = int(cfg.splitlvl)
+	w.ttree.f.RegisterStreamer(b.streamer)
+
+	leaf := &tleafElement{
+		rvers: rvers.LeafElement,
+		tleaf: newLeaf(wvar.Name, nil, int(rv.Type().Size()), 0, false, false, nil, b),
+		id:    b.id,
+		ltype: -1,
+		ptr:   wvar.Value,
+		src:   rv,
+	}
+	b.leaves = append(b.leaves, leaf)
+	w.ttree.leaves = append(w.ttree.leaves, leaf)
+
+	prefix := ""
+	if strings.HasSuffix(wvar.Name, ".") {
+		prefix = wvar.Name
+	}
+
+	err := newMemberBranches(w, b, b.streamer, rv, prefix, lvl+1, cfg)
+	if err != nil {
+		return nil, err
+	}
+	b.named.SetTitle(wvar.Name)
+	return b, nil
+}
+
+// newMemberBranches creates the sub-branches of the split branch parent,
+// one per data member of the struct value rv, as described by si.
+// Struct members are themselves split while the split level allows it.
+func newMemberBranches(w *wtree, parent *tbranchElement, si rbytes.StreamerInfo, rv reflect.Value, prefix string, lvl int, cfg wopt) error {
+	var (
+		class  = si.Name()
+		counts = make(map[string]leafCount)
+	)
+
+	for i, se := range si.Elements() {
+		var (
+			ft   = rv.Type().Field(i)
+			ptr  = reflect.NewAt(ft.Type, unsafe.Pointer(rv.Field(i).UnsafeAddr()))
+			name = prefix + se.Name()
+			base = newWBranch(w, name, parent, cfg)
+			sub  = &tbranchElement{
+				tbranch:  *base,
+				class:    class,
+				parent:   class,
+				chksum:   uint32(si.CheckSum()),
+				clsver:   uint16(si.ClassVersion()),
+				id:       int32(i),
+				btype:    0,
+				stype:    int32(se.Type()),
+				streamer: si,
+			}
+		)
+
+		if ft.Type.Kind() == reflect.Struct && se.Type() == rmeta.Any &&
+			isSplittable(ft.Type) && lvl < int(cfg.splitlvl) {
+			msi := rdict.StreamerOf(w.ttree.f, ft.Type)
+
+			sub.btype = 2 // data member object, split.
+			sub.tbranch.entryOffsetLen = 1000
+			sub.tbranch.splitLevel = int(cfg.splitlvl) - lvl
+			sub.named.SetTitle(name)
+			err := newMemberBranches(w, sub, msi, ptr.Elem(), name+".", lvl+1, cfg)
+			if err != nil {
+				return fmt.Errorf("could not create sub-branches of member %q: %w", name, err)
+			}
+			parent.branches = append(parent.branches, sub)
+			continue
+		}
+
+		var (
+			title = name
+			shape []int
+			count leafCount
+			et, _ = flattenArrayType(ft.Type)
+		)
+		if st := se.Type(); st <= 0 || st >= rmeta.OffsetL {
+			sub.tbranch.entryOffsetLen = 400
+		}
+		if se, ok := se.(interface{ CountName() string }); ok && se.CountName() != "" {
+			count = counts[prefix+se.CountName()]
+			if count == nil {
+				return fmt.Errorf("could not find leaf count %q for member %q", se.CountName(), name)
+			}
+			title += "[" + count.Name() + "]"
+			et, _ = flattenArrayType(ft.Type.Elem())
+		}
+		for _, dim := range se.ArrayDims() {
+			shape = append(shape, int(dim))
+			name += fmt.Sprintf("[%d]", dim)
+			title += fmt.Sprintf("[%d]", dim)
+		}
+		sub.named.SetName(name)
+		sub.named.SetTitle(title)
+
+		wstreamer, err := rdict.WStreamerOf(si, i, rbytes.ObjectWise)
+		if err != nil {
+			return fmt.Errorf("could not create w-streamer for member %q: %w", name, err)
+		}
+
+		leaf := &tleafElement{
+			rvers:     rvers.LeafElement,
+			tleaf:     newLeaf(prefix+se.Name(), shape, int(et.Size()), 0, false, false, count, sub),
+			id:        int32(i),
+			ltype:     int32(se.Type()),
+			wstreamer: wstreamer,
+		}
+		err = leaf.setAddress(ptr.Interface())
+		if err != nil {
+			return fmt.Errorf("could not set leaf address for member %q: %w", name, err)
+		}
+		counts[leaf.Name()] = leaf
+
+		sub.leaves = append(sub.leaves, leaf)
+		w.ttree.leaves = append(w.ttree.leaves, leaf)
+		sub.createNewBasket()
+
+		parent.branches = append(parent.branches, sub)
+	}
+	return nil
+}
+
+// isSplittable returns whether values of the provided struct type can be
+// split into one branch per data member.
+// Types with a custom ROOT streamer are never split.
+func isSplittable(rt reflect.Type) bool {
+	ptr := reflect.PtrTo(rt)
+	return !ptr.Implements(reflect.TypeOf((*rbytes.Marshaler)(nil)).Elem())
+}
+
 func (b *tbranchElement) RVersion() int16 {
 	return rvers.BranchElement
 }
@@ -1085,6 +1284,19 @@ func (b *tbranchElement) write() (int, error) {
 	b.entries++
 	b.entryNumber++
 
+	if len(b.branches) > 0 {
+		// split object: data is held by the sub-branches.
+		var tot int
+		for i, sub := range b.branches {
+			n, err := sub.write()
+			tot += n
+			if err != nil {
+				return tot, fmt.Errorf("could not write subbranch[%d]=%q of branch %q: %w", i, sub.Name(), b.Name(), err)
+			}
+		}
+		return tot, nil
+	}
+
 	szOld := b.ctx.bk.wbuf.Len()
 	b.ctx.bk.update(szOld)
 	_, err := b.writeToBuffer(b.ctx.bk.wbuf)
@@ -1094,8 +1306,15 @@ func (b *tbranchElement) write() (int, error) {
 		return n, fmt.Errorf("could not write to buffer (branch=%q): %w", b.Name(), err)
 	}
 	if n > b.ctx.bk.nevsize {
-		b.ctx.bk.grow(n)
+		switch b.entryOffsetLen {
+		case 0:
+			// fixed-size entries (e.g. member of a split object.)
+			b.ctx.bk.nevsize = n
+		default:
+			b.ctx.bk.grow(n)
+		}
 	}
+	b.updateCountMax()
 
 	// FIXME(sbinet): harmonize or drive via "auto-flush" ?
 	if szNew+int64(n) >= int64(b.basketSize) {
@@ -1109,6 +1328,25 @@ func (b *tbranchElement) write() (int, error) {
 	return n, nil
 }
 
+// updateCountMax updates the maximum value of the branches holding the
+// leaf-counts of the leaves of this branch.
+// ROOT uses that maximum to size the arrays it counts.
+func (b *tbranchElement) updateCountMax() {
+	for _, leaf := range b.leaves {
+		lc, ok := leaf.LeafCount().(*tleafElement)
+		if !ok {
+			continue
+		}
+		bc, ok := lc.branch.(*tbranchElement)
+		if !ok {
+			continue
+		}
+		if n := int32(lc.ivalue()); n > bc.max {
+			bc.max = n
+		}
+	}
+}
+
 func (b *tbranchElement) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 	var tot int
 	for i, leaf := range b.leaves {
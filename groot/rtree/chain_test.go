@@ -6,6 +6,8 @@ package rtree_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -444,3 +446,77 @@ func TestChainReaderStruct(t *testing.T) {
 		t.Fatalf("entries differ: got=%d want=%d", total.got, total.want)
 	}
 }
+
+func TestChainWeights(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var (
+		weights = []float64{1, 0.5, 2}
+		fnames  = make([]string, len(weights))
+	)
+	const nevts = 5
+
+	for i, w := range weights {
+		fnames[i] = filepath.Join(tmp, fmt.Sprintf("chain-weight-%d.root", i))
+		f, err := riofs.Create(fnames[i])
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+
+		var v int64
+		tw, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{{Name: "v", Value: &v}}, rtree.WithWeight(w))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		for j := 0; j < nevts; j++ {
+			v = int64(j)
+			_, err = tw.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", j, err)
+			}
+		}
+		err = tw.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	chain, closer, err := rtree.ChainOf("tree", fnames...)
+	if err != nil {
+		t.Fatalf("could not create chain: %+v", err)
+	}
+	defer func() {
+		_ = closer()
+	}()
+
+	var v int64
+	r, err := rtree.NewReader(chain, []rtree.ReadVar{{Name: "v", Value: &v}}, rtree.WithRange(3, 13))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	var sumw float64
+	err = r.Read(func(ctx rtree.RCtx) error {
+		if got, want := ctx.Weight, weights[ctx.Entry/nevts]; got != want {
+			return fmt.Errorf("entry %d: invalid weight: got=%v, want=%v", ctx.Entry, got, want)
+		}
+		sumw += ctx.Weight
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read chain: %+v", err)
+	}
+
+	if got, want := sumw, 2*1+5*0.5+3*2.0; got != want {
+		t.Fatalf("invalid sum of weights: got=%v, want=%v", got, want)
+	}
+}
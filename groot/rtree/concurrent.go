@@ -0,0 +1,381 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// maxChunkSize is the maximum number of entries handed to a worker
+// of a ConcurrentReader in one go.
+const maxChunkSize = 10000
+
+// ConcurrentReader reads data from a Tree, decompressing and decoding
+// entries with multiple worker goroutines.
+type ConcurrentReader struct {
+	tree  Tree
+	rvars []ReadVar
+	beg   int64
+	end   int64
+	nrab  int
+	nmem  int64
+	elist *EntryList
+	n     int          // number of workers
+	stats *rstats      // statistics shared by all the workers
+	cr    *corruptions // corrupted baskets skipped by all the workers, if any
+}
+
+// NewConcurrentReader creates a new concurrent Tree Reader from the provided
+// ROOT Tree and the set of read-variables into which data will be read.
+// NewConcurrentReader uses n worker goroutines.
+// If n <= 0, the number of logical CPUs is used.
+func NewConcurrentReader(t Tree, rvars []ReadVar, n int, opts ...ReadOption) (*ConcurrentReader, error) {
+	r, err := NewReader(t, rvars, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+
+	return &ConcurrentReader{
+		tree:  t,
+		rvars: rvars,
+		beg:   r.beg,
+		end:   r.end,
+		nrab:  r.nrab,
+		nmem:  r.nmem,
+		elist: r.elist,
+		n:     n,
+		stats: r.stats,
+		cr:    r.cr,
+	}, nil
+}
+
+// Read reads data from the underlying tree over the whole specified range.
+//
+// Read calls the provided user function f for each entry successfully read,
+// concurrently from the worker goroutines, in no particular order.
+// Data of the current entry is loaded into rvars, a worker-local copy of
+// the read-variables the reader was created with (and in the same order).
+// rvars and its values are only valid during the call to f.
+func (r *ConcurrentReader) Read(f func(ctx RCtx, rvars []ReadVar) error) error {
+	r.stats.reset(nentries(r.beg, r.end, r.elist))
+	r.cr.reset()
+	defer r.stats.report()
+
+	err := r.run(func(ctx context.Context, rr *Reader, rvars []ReadVar) error {
+		return rr.Read(func(rctx RCtx) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return f(rctx, rvars)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return r.cr.err()
+}
+
+// ReadOrdered reads data from the underlying tree over the whole specified range.
+//
+// Entries are decoded concurrently by the worker goroutines but
+// ReadOrdered calls the provided user function f sequentially,
+// in entry order, for each entry successfully read.
+// Data of the current entry is loaded into the read-variables the reader
+// was created with.
+//
+// To hand entries over from the workers to f, ReadOrdered deep-copies
+// the values of each entry with reflect: this costs a few allocations
+// per entry, proportional to the size of its slices, strings and nested
+// values, and holds up to 2*n chunks of decoded entries in memory.
+// Read should be preferred when entries need not be processed in order.
+func (r *ConcurrentReader) ReadOrdered(f func(ctx RCtx) error) error {
+	type entry struct {
+		ctx  RCtx
+		vals []reflect.Value
+	}
+
+	var (
+		chunks = r.chunks()
+		slots  = make([]chan []entry, len(chunks))
+		sema   = make(chan struct{}, 2*r.n) // limit number of in-flight chunks
+		ids    = make(map[[2]int64]int, len(chunks))
+	)
+	for i, c := range chunks {
+		slots[i] = make(chan []entry, 1)
+		ids[c] = i
+	}
+
+	r.stats.reset(nentries(r.beg, r.end, r.elist))
+	r.cr.reset()
+	defer r.stats.report()
+
+	grp, ctx := errgroup.WithContext(context.Background())
+	grp.Go(func() error {
+		return r.runChunks(ctx, chunks, sema, func(ctx context.Context, rr *Reader, rvars []ReadVar) error {
+			entries := make([]entry, 0, rr.end-rr.beg)
+			err := rr.Read(func(rctx RCtx) error {
+				vals := make([]reflect.Value, len(rvars))
+				for i, rv := range rvars {
+					vals[i] = deepCopy(reflect.ValueOf(rv.Value).Elem())
+				}
+				if rctx.Unmatched != nil {
+					rctx.Unmatched = append([]string(nil), rctx.Unmatched...)
+				}
+				entries = append(entries, entry{ctx: rctx, vals: vals})
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			slots[ids[[2]int64{rr.beg, rr.end}]] <- entries
+			return nil
+		})
+	})
+
+	grp.Go(func() error {
+		for _, slot := range slots {
+			var entries []entry
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case entries = <-slot:
+			}
+			for _, e := range entries {
+				for i, rv := range r.rvars {
+					reflect.ValueOf(rv.Value).Elem().Set(e.vals[i])
+				}
+				err := f(e.ctx)
+				if err != nil {
+					return err
+				}
+			}
+			<-sema
+		}
+		return nil
+	})
+
+	err := grp.Wait()
+	if err != nil {
+		return err
+	}
+	return r.cr.err()
+}
+
+// opts returns the options of a reader over the [beg, end) range of entries.
+func (r *ConcurrentReader) opts(beg, end int64) []ReadOption {
+	opts := []ReadOption{
+		WithRange(beg, end),
+		WithPrefetchBaskets(r.nrab),
+		WithPrefetchMemory(r.nmem),
+		withStats(r.stats),
+	}
+	if r.cr != nil {
+		opts = append(opts, withCorruptions(r.cr))
+	}
+	if r.elist != nil {
+		opts = append(opts, WithEntryList(r.elist))
+	}
+	return opts
+}
+
+// chunks returns the list of [beg, end) entry ranges handed to workers.
+//
+// Chunks start and end on basket boundaries so that a basket is only
+// decompressed by a single worker, unless a basket holds more than
+// maxChunkSize entries.
+func (r *ConcurrentReader) chunks() [][2]int64 {
+	n := r.end - r.beg
+	if n <= 0 {
+		return nil
+	}
+	size := n / int64(4*r.n)
+	switch {
+	case size < 1:
+		size = 1
+	case size > maxChunkSize:
+		size = maxChunkSize
+	}
+
+	var (
+		chunks = make([][2]int64, 0, n/size+1)
+		beg    = r.beg // first entry of the current chunk
+		last   = r.beg // last basket boundary within the current chunk
+	)
+	for _, bound := range append(chunkBoundaries(r.tree), r.end) {
+		if bound <= beg {
+			continue
+		}
+		if bound > r.end {
+			bound = r.end
+		}
+		if bound-beg > maxChunkSize && last > beg {
+			// do not grow the current chunk with an overly large basket.
+			chunks = append(chunks, [2]int64{beg, last})
+			beg = last
+		}
+		for bound-beg > maxChunkSize {
+			chunks = append(chunks, [2]int64{beg, beg + maxChunkSize})
+			beg += maxChunkSize
+		}
+		if bound-beg >= size {
+			chunks = append(chunks, [2]int64{beg, bound})
+			beg = bound
+		}
+		last = bound
+	}
+	if beg < r.end {
+		chunks = append(chunks, [2]int64{beg, r.end})
+	}
+	return chunks
+}
+
+// chunkBoundaries returns the basket boundaries chunks are aligned with.
+// Joined trees are aligned with the baskets of their main tree.
+func chunkBoundaries(t Tree) []int64 {
+	if t, ok := t.(*join); ok && len(t.trees) > 0 {
+		return BasketBoundaries(t.trees[0])
+	}
+	return BasketBoundaries(t)
+}
+
+func (r *ConcurrentReader) run(f func(ctx context.Context, rr *Reader, rvars []ReadVar) error) error {
+	grp, ctx := errgroup.WithContext(context.Background())
+	grp.Go(func() error {
+		return r.runChunks(ctx, r.chunks(), nil, f)
+	})
+	return grp.Wait()
+}
+
+// runChunks dispatches the provided chunks to the worker goroutines.
+// If sema is not nil, a token is sent to sema before each chunk is dispatched.
+//
+// Each worker creates a single Reader, bound to its own copy of the
+// read-variables, and sets it up to read each chunk it is handed over
+// before calling f.
+func (r *ConcurrentReader) runChunks(ctx context.Context, chunks [][2]int64, sema chan struct{}, f func(ctx context.Context, rr *Reader, rvars []ReadVar) error) error {
+	var (
+		grp, gctx = errgroup.WithContext(ctx)
+		queue     = make(chan [2]int64)
+	)
+
+	for i := 0; i < r.n; i++ {
+		rvars := cloneRVars(r.rvars)
+		grp.Go(func() error {
+			rr, err := NewReader(r.tree, rvars, r.opts(r.beg, r.end)...)
+			if err != nil {
+				return err
+			}
+			defer rr.Close()
+
+			for c := range queue {
+				rr.setRange(c[0], c[1])
+				err := f(gctx, rr, rvars)
+				if err != nil {
+					return fmt.Errorf("rtree: could not process entries [%d, %d): %w", c[0], c[1], err)
+				}
+			}
+			return nil
+		})
+	}
+
+	grp.Go(func() error {
+		defer close(queue)
+		for _, c := range chunks {
+			if sema != nil {
+				select {
+				case sema <- struct{}{}:
+				case <-gctx.Done():
+					return gctx.Err()
+				}
+			}
+			select {
+			case queue <- c:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	return grp.Wait()
+}
+
+// cloneRVars returns a copy of the provided read-vars, bound to newly
+// allocated values.
+func cloneRVars(rvars []ReadVar) []ReadVar {
+	o := make([]ReadVar, len(rvars))
+	for i, rv := range rvars {
+		o[i] = ReadVar{
+			Name:  rv.Name,
+			Leaf:  rv.Leaf,
+			Value: reflect.New(reflect.TypeOf(rv.Value).Elem()).Interface(),
+			count: rv.count,
+		}
+	}
+	return o
+}
+
+// deepCopy returns a copy of the provided value that does not share
+// memory with it.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		o := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			o.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return o
+	case reflect.Array:
+		o := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			o.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return o
+	case reflect.Struct:
+		o := reflect.New(v.Type()).Elem()
+		o.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if !o.Field(i).CanSet() {
+				continue
+			}
+			o.Field(i).Set(deepCopy(v.Field(i)))
+		}
+		return o
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		o := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			o.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+		return o
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		o := reflect.New(v.Type().Elem())
+		o.Elem().Set(deepCopy(v.Elem()))
+		return o
+	default:
+		o := reflect.New(v.Type()).Elem()
+		o.Set(v)
+		return o
+	}
+}
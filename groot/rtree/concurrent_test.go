@@ -0,0 +1,248 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestConcurrentReader(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 12345
+	fname := filepath.Join(tmp, "concurrent.root")
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt struct {
+			I64 int64
+			N   int32
+			Sli []float64 `groot:"Sli[N]"`
+			Str string
+		}
+		w, err := NewWriter(f, "tree", WriteVarsFromStruct(&evt), WithBasketSize(1024))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < nevts; i++ {
+			evt.I64 = int64(i)
+			evt.N = int32(i % 5)
+			evt.Sli = evt.Sli[:0]
+			for j := 0; j < int(evt.N); j++ {
+				evt.Sli = append(evt.Sli, float64(i))
+			}
+			evt.Str = fmt.Sprintf("evt-%d", i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	t.Run("chunks", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			tree     Tree
+			n        int
+			beg, end int64
+		}{
+			{name: "tree", tree: tree, n: 4, beg: 0, end: nevts},
+			{name: "tree", tree: tree, n: 4, beg: 42, end: 1042},
+			{name: "tree", tree: tree, n: 1, beg: 0, end: nevts},
+			{name: "chain", tree: Chain(tree, tree), n: 4, beg: 0, end: 2 * nevts},
+			{name: "chain", tree: Chain(tree, tree), n: 4, beg: nevts - 100, end: nevts + 100},
+		} {
+			t.Run(fmt.Sprintf("%s-n=%d-[%d,%d)", tc.name, tc.n, tc.beg, tc.end), func(t *testing.T) {
+				var i64 int64
+				r, err := NewConcurrentReader(tc.tree, []ReadVar{{Name: "I64", Value: &i64}}, tc.n, WithRange(tc.beg, tc.end))
+				if err != nil {
+					t.Fatalf("could not create reader: %+v", err)
+				}
+
+				bounds := make(map[int64]bool)
+				for _, v := range BasketBoundaries(tc.tree) {
+					bounds[v] = true
+				}
+
+				chunks := r.chunks()
+				if len(chunks) < 2 {
+					t.Fatalf("invalid number of chunks: got=%d", len(chunks))
+				}
+				next := tc.beg
+				for _, c := range chunks {
+					if c[0] != next {
+						t.Fatalf("invalid chunk %v: want beg=%d", c, next)
+					}
+					if c[1] <= c[0] || c[1]-c[0] > maxChunkSize {
+						t.Fatalf("invalid chunk %v", c)
+					}
+					if c[1] != tc.end && !bounds[c[1]] {
+						t.Fatalf("chunk %v does not end on a basket boundary", c)
+					}
+					next = c[1]
+				}
+				if next != tc.end {
+					t.Fatalf("invalid last chunk: got=%d, want=%d", next, tc.end)
+				}
+			})
+		}
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		var (
+			i64  int64
+			rvar = []ReadVar{{Name: "I64", Value: &i64}}
+		)
+		r, err := NewReader(tree, rvar)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		for _, rng := range [][2]int64{{0, 10}, {5000, 5100}, {42, 1042}, {nevts - 3, nevts}} {
+			r.setRange(rng[0], rng[1])
+			next := rng[0]
+			err = r.Read(func(ctx RCtx) error {
+				if ctx.Entry != next || i64 != next {
+					return fmt.Errorf("invalid entry: got=(%d, %d), want=%d", ctx.Entry, i64, next)
+				}
+				next++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read range %v: %+v", rng, err)
+			}
+			if next != rng[1] {
+				t.Fatalf("invalid last entry for range %v: got=%d", rng, next)
+			}
+		}
+	})
+
+	check := func(i int64, i64 int64, sli []float64, str string) error {
+		if i64 != i {
+			return fmt.Errorf("entry %d: invalid I64: got=%d", i, i64)
+		}
+		if got, want := len(sli), int(i%5); got != want {
+			return fmt.Errorf("entry %d: invalid Sli length: got=%d, want=%d", i, got, want)
+		}
+		for _, v := range sli {
+			if v != float64(i) {
+				return fmt.Errorf("entry %d: invalid Sli: got=%v", i, sli)
+			}
+		}
+		if got, want := str, fmt.Sprintf("evt-%d", i); got != want {
+			return fmt.Errorf("entry %d: invalid Str: got=%q, want=%q", i, got, want)
+		}
+		return nil
+	}
+
+	for _, tc := range []struct {
+		n        int
+		beg, end int64
+	}{
+		{n: 1, beg: 0, end: nevts},
+		{n: 4, beg: 0, end: nevts},
+		{n: 4, beg: 42, end: 1042},
+		{n: 0, beg: 10, end: 12},
+		{n: 3, beg: 10, end: 10},
+	} {
+		t.Run(fmt.Sprintf("n=%d-[%d,%d)", tc.n, tc.beg, tc.end), func(t *testing.T) {
+			var (
+				i64  int64
+				sli  []float64
+				str  string
+				rvar = []ReadVar{
+					{Name: "I64", Value: &i64},
+					{Name: "Sli", Value: &sli},
+					{Name: "Str", Value: &str},
+				}
+			)
+
+			r, err := NewConcurrentReader(tree, rvar, tc.n, WithRange(tc.beg, tc.end))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+
+			var (
+				mu   sync.Mutex
+				seen = make(map[int64]struct{})
+			)
+			err = r.Read(func(ctx RCtx, rvars []ReadVar) error {
+				var (
+					i64 = *rvars[0].Value.(*int64)
+					sli = *rvars[1].Value.(*[]float64)
+					str = *rvars[2].Value.(*string)
+				)
+				err := check(ctx.Entry, i64, sli, str)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				seen[ctx.Entry] = struct{}{}
+				mu.Unlock()
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+			if got, want := len(seen), int(tc.end-tc.beg); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			next := tc.beg
+			err = r.ReadOrdered(func(ctx RCtx) error {
+				if ctx.Entry != next {
+					return fmt.Errorf("invalid entry order: got=%d, want=%d", ctx.Entry, next)
+				}
+				next++
+				return check(ctx.Entry, i64, sli, str)
+			})
+			if err != nil {
+				t.Fatalf("could not read tree in order: %+v", err)
+			}
+			if next != tc.end {
+				t.Fatalf("invalid last entry: got=%d, want=%d", next, tc.end)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package rtree
 
 import (
 	"fmt"
+
+	"go-hep.org/x/hep/groot/riofs"
 )
 
 // Copy copies from src to dst until either the reader is depleted or
@@ -53,3 +55,192 @@ func Copy(dst Writer, src *Reader) (int64, error) {
 
 	return tot, err
 }
+
+// CopyOption configures how CopyTree copies a tree.
+type CopyOption func(opt *copyOpt) error
+
+type copyOpt struct {
+	name     string        // name of the output tree
+	branches []string      // names of the branches to copy
+	beg, end int64         // range of entries to copy
+	filter   string        // selection expression
+	wopts    []WriteOption // options for the output tree
+}
+
+// WithCopyName sets the name of the output tree.
+// The name of the input tree is used by default.
+func WithCopyName(name string) CopyOption {
+	return func(opt *copyOpt) error {
+		opt.name = name
+		return nil
+	}
+}
+
+// WithCopyBranches selects the top-level branches to copy.
+// All branches are copied by default.
+// Branches holding the size of a selected variable-length array branch are
+// automatically selected.
+func WithCopyBranches(names ...string) CopyOption {
+	return func(opt *copyOpt) error {
+		opt.branches = append(opt.branches, names...)
+		return nil
+	}
+}
+
+// WithCopyRange selects the half-open interval [beg, end) of entries to
+// copy.
+// As for WithRange, an end value of -1 selects all the entries up to the
+// last one.
+func WithCopyRange(beg, end int64) CopyOption {
+	return func(opt *copyOpt) error {
+		opt.beg = beg
+		opt.end = end
+		return nil
+	}
+}
+
+// WithCopyFilter selects the entries to copy with a boolean expression,
+// as described by NewFormula.
+// Branches used in the expression do not need to be copied.
+func WithCopyFilter(expr string) CopyOption {
+	return func(opt *copyOpt) error {
+		opt.filter = expr
+		return nil
+	}
+}
+
+// WithCopyWriteOptions configures the output tree, e.g. its compression.
+func WithCopyWriteOptions(opts ...WriteOption) CopyOption {
+	return func(opt *copyOpt) error {
+		opt.wopts = append(opt.wopts, opts...)
+		return nil
+	}
+}
+
+// CopyTree copies the src tree under the dst directory, possibly
+// selecting a subset of its branches (slimming) and of its entries
+// (skimming.)
+// CopyTree returns the number of copied entries.
+//
+// Example:
+//
+//  n, err := rtree.CopyTree(dir, tree,
+//      rtree.WithCopyBranches("run", "evt", "pt"),
+//      rtree.WithCopyFilter("pt > 20 && abs(eta) < 2.5"),
+//  )
+func CopyTree(dst riofs.Directory, src Tree, opts ...CopyOption) (int64, error) {
+	cfg := copyOpt{
+		name: src.Name(),
+		end:  -1,
+	}
+	for _, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return 0, fmt.Errorf("rtree: could not configure tree copy: %w", err)
+		}
+	}
+
+	wvars, err := copyWVars(src, cfg.branches)
+	if err != nil {
+		return 0, err
+	}
+
+	rvars := make([]ReadVar, len(wvars))
+	for i, wvar := range wvars {
+		rvars[i] = ReadVar{
+			Name:  wvar.Name,
+			Value: wvar.Value,
+		}
+	}
+
+	r, err := NewReader(src, rvars, WithRange(cfg.beg, cfg.end))
+	if err != nil {
+		return 0, fmt.Errorf("rtree: could not create reader: %w", err)
+	}
+	defer r.Close()
+
+	var filter func() bool
+	if cfg.filter != "" {
+		form, err := NewFormula(src, cfg.filter)
+		if err != nil {
+			return 0, err
+		}
+		form, err = r.Formula(form)
+		if err != nil {
+			return 0, fmt.Errorf("rtree: could not bind filter %q: %w", cfg.filter, err)
+		}
+		fct, ok := form.Func().(func() bool)
+		if !ok {
+			return 0, fmt.Errorf("rtree: filter %q is not a boolean expression", cfg.filter)
+		}
+		filter = fct
+	}
+
+	wopts := append([]WriteOption{WithTitle(src.Title())}, cfg.wopts...)
+	w, err := NewWriter(dst, cfg.name, wvars, wopts...)
+	if err != nil {
+		return 0, fmt.Errorf("rtree: could not create writer: %w", err)
+	}
+	defer w.Close()
+
+	var n int64
+	err = r.Read(func(ctx RCtx) error {
+		if filter != nil && !filter() {
+			return nil
+		}
+		_, err := w.Write()
+		if err != nil {
+			return fmt.Errorf("rtree: could not write entry %d to tree: %w", ctx.Entry, err)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, fmt.Errorf("rtree: could not copy tree %q: %w", src.Name(), err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return n, fmt.Errorf("rtree: could not close tree %q: %w", cfg.name, err)
+	}
+
+	return n, nil
+}
+
+// copyWVars returns the write-vars of the selected branches of the
+// provided tree, with the branches holding the size of selected
+// variable-length arrays.
+func copyWVars(t Tree, branches []string) ([]WriteVar, error) {
+	all := WriteVarsFromTree(t)
+	if len(branches) == 0 {
+		return all, nil
+	}
+
+	sel := make(map[string]bool, len(branches))
+	for _, name := range branches {
+		found := false
+		for _, wvar := range all {
+			if wvar.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("rtree: tree %q has no branch named %q", t.Name(), name)
+		}
+		sel[name] = true
+	}
+	for _, wvar := range all {
+		if sel[wvar.Name] && wvar.Count != "" {
+			sel[wvar.Count] = true
+		}
+	}
+
+	wvars := make([]WriteVar, 0, len(sel))
+	for _, wvar := range all {
+		if sel[wvar.Name] {
+			wvars = append(wvars, wvar)
+		}
+	}
+	return wvars, nil
+}
@@ -372,3 +372,144 @@ func TestCopyTree(t *testing.T) {
 		})
 	}
 }
+
+func TestCopyTreeWithSelection(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-copy-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Event struct {
+		N   int32
+		Sli []float64 `groot:"Sli[N]"`
+		Run int32
+		Val float64
+	}
+
+	fname := filepath.Join(tmp, "src.root")
+	{
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt Event
+		w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(&evt), rtree.WithTitle("my tree"))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 10; i++ {
+			evt.N = int32(i % 3)
+			evt.Sli = evt.Sli[:0]
+			for j := 0; j < int(evt.N); j++ {
+				evt.Sli = append(evt.Sli, float64(i))
+			}
+			evt.Run = 42
+			evt.Val = float64(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not get input tree: %+v", err)
+	}
+	src := obj.(rtree.Tree)
+
+	oname := filepath.Join(tmp, "dst.root")
+	{
+		o, err := groot.Create(oname)
+		if err != nil {
+			t.Fatalf("could not create output file: %+v", err)
+		}
+		defer o.Close()
+
+		n, err := rtree.CopyTree(o, src,
+			rtree.WithCopyName("skim"),
+			rtree.WithCopyBranches("Sli", "Run"),
+			rtree.WithCopyRange(1, 9),
+			rtree.WithCopyFilter("Val > 2 && N > 0"),
+			rtree.WithCopyWriteOptions(rtree.WithLZ4(1)),
+		)
+		if err != nil {
+			t.Fatalf("could not copy tree: %+v", err)
+		}
+		if got, want := n, int64(4); got != want {
+			t.Fatalf("invalid number of copied entries: got=%d, want=%d", got, want)
+		}
+
+		err = o.Close()
+		if err != nil {
+			t.Fatalf("could not close output file: %+v", err)
+		}
+	}
+
+	got := new(bytes.Buffer)
+	err = rcmd.Dump(got, oname, true, nil)
+	if err != nil {
+		t.Fatalf("could not dump output file: %+v", err)
+	}
+
+	want := `key[000]: skim;1 "my tree" (TTree)
+[000][N]: 1
+[000][Sli]: [4]
+[000][Run]: 42
+[001][N]: 2
+[001][Sli]: [5 5]
+[001][Run]: 42
+[002][N]: 1
+[002][Sli]: [7]
+[002][Run]: 42
+[003][N]: 2
+[003][Sli]: [8 8]
+[003][Run]: 42
+`
+	if got := got.String(); got != want {
+		t.Fatalf("invalid root-dump output:\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []rtree.CopyOption
+	}{
+		{"branch", []rtree.CopyOption{rtree.WithCopyBranches("NotThere")}},
+		{"filter", []rtree.CopyOption{rtree.WithCopyFilter("Val +")}},
+		{"not-bool", []rtree.CopyOption{rtree.WithCopyFilter("Val + 1")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := groot.Create(filepath.Join(tmp, tc.name+".root"))
+			if err != nil {
+				t.Fatalf("could not create output file: %+v", err)
+			}
+			defer o.Close()
+
+			_, err = rtree.CopyTree(o, src, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// errCorrupted is returned when reading an entry held by a corrupted basket.
+var errCorrupted = errors.New("rtree: corrupted basket")
+
+// Corruption describes a range of entries of a branch that could not be
+// read because of a truncated or corrupted basket.
+type Corruption struct {
+	File   string // name of the file holding the tree
+	Tree   string // name of the tree
+	Branch string // name of the branch
+	Basket int    // index of the basket
+	Seek   int64  // offset of the basket in the file (0 for baskets stored with the tree)
+	Beg    int64  // first skipped entry of the tree
+	End    int64  // one past the last skipped entry of the tree
+	Err    error  // reason of the failure
+}
+
+func (c Corruption) String() string {
+	return fmt.Sprintf(
+		"file=%q tree=%q branch=%q basket=%d seek=%d entries=[%d, %d): %v",
+		c.File, c.Tree, c.Branch, c.Basket, c.Seek, c.Beg, c.End, c.Err,
+	)
+}
+
+// CorruptionError is returned by readers configured with WithSkipCorrupted,
+// once all the entries have been read, when corrupted baskets were found.
+type CorruptionError struct {
+	Corruptions []Corruption // list of corrupted baskets
+	Skipped     int64        // number of skipped entries
+}
+
+func (e *CorruptionError) Error() string {
+	o := new(strings.Builder)
+	fmt.Fprintf(o, "rtree: skipped %d entries from %d corrupted baskets", e.Skipped, len(e.Corruptions))
+	for _, c := range e.Corruptions {
+		fmt.Fprintf(o, "\n\t%v", c)
+	}
+	return o.String()
+}
+
+// WithSkipCorrupted configures a reader to skip the entries held by
+// baskets that can not be read, decompressed or decoded, instead of
+// aborting the read.
+//
+// f, if not nil, is called for each corrupted basket as soon as it is
+// detected, e.g. to log the affected range of entries.
+// f is never called concurrently.
+//
+// Once all the entries have been read, Read returns a *CorruptionError
+// listing the corrupted baskets, if any.
+func WithSkipCorrupted(f func(c Corruption)) ReadOption {
+	return func(r *Reader) error {
+		r.cr = &corruptions{fct: f}
+		return nil
+	}
+}
+
+// withCorruptions configures a reader to report the corrupted baskets it
+// skips into the provided (shared) list.
+func withCorruptions(cr *corruptions) ReadOption {
+	return func(r *Reader) error {
+		r.cr = cr
+		return nil
+	}
+}
+
+// corruptions collects the corrupted baskets skipped by a reader.
+type corruptions struct {
+	mu      sync.Mutex
+	fct     func(c Corruption)
+	cs      []Corruption
+	skipped int64
+}
+
+func (cr *corruptions) reset() {
+	if cr == nil {
+		return
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.cs = nil
+	cr.skipped = 0
+}
+
+func (cr *corruptions) add(c Corruption) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.cs = append(cr.cs, c)
+	if cr.fct != nil {
+		cr.fct(c)
+	}
+}
+
+func (cr *corruptions) skip() {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.skipped++
+}
+
+func (cr *corruptions) err() error {
+	if cr == nil {
+		return nil
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if len(cr.cs) == 0 {
+		return nil
+	}
+	return &CorruptionError{
+		Corruptions: append([]Corruption(nil), cr.cs...),
+		Skipped:     cr.skipped,
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// createCorrupted creates a file with a tree of nevts entries, and
+// corrupts the third basket of its F64 branch.
+// createCorrupted returns the name of the file and the entry range of the
+// corrupted basket.
+func createCorrupted(t *testing.T, nevts int) (string, int64, int64) {
+	t.Helper()
+
+	fname := filepath.Join(t.TempDir(), "corrupted.root")
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var (
+			i64 int64
+			f64 float64
+		)
+		w, err := NewWriter(f, "tree", []WriteVar{
+			{Name: "I64", Value: &i64},
+			{Name: "F64", Value: &f64},
+		}, WithBasketSize(8*10))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < nevts; i++ {
+			i64 = int64(i)
+			f64 = float64(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	var (
+		pos      int64
+		nbytes   int32
+		beg, end int64
+	)
+	func() {
+		f, err := riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		tree, err := riofs.Get[Tree](f, "tree")
+		if err != nil {
+			t.Fatalf("could not retrieve tree: %+v", err)
+		}
+		br := asBranch(tree.Branch("F64"))
+		if len(br.basketSeek) < 4 {
+			t.Fatalf("not enough baskets: %d", len(br.basketSeek))
+		}
+		pos = br.basketSeek[2]
+		nbytes = br.basketBytes[2]
+		beg = br.basketEntry[2]
+		end = br.basketEntry[3]
+	}()
+
+	o, err := os.OpenFile(fname, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer o.Close()
+
+	garbage := make([]byte, nbytes)
+	for i := range garbage {
+		garbage[i] = 0xff
+	}
+	_, err = o.WriteAt(garbage, pos)
+	if err != nil {
+		t.Fatalf("could not corrupt basket: %+v", err)
+	}
+	err = o.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	return fname, beg, end
+}
+
+func TestReaderSkipCorrupted(t *testing.T) {
+	const nevts = 100
+	fname, beg, end := createCorrupted(t, nevts)
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	var (
+		i64 int64
+		f64 float64
+	)
+	rvars := []ReadVar{
+		{Name: "I64", Value: &i64},
+		{Name: "F64", Value: &f64},
+	}
+
+	t.Run("abort", func(t *testing.T) {
+		r, err := NewReader(tree, rvars)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		err = r.Read(func(ctx RCtx) error { return nil })
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		var cerr *CorruptionError
+		if errors.As(err, &cerr) {
+			t.Fatalf("unexpected corruption report: %+v", err)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		var logged []Corruption
+		r, err := NewReader(tree, rvars, WithSkipCorrupted(func(c Corruption) {
+			logged = append(logged, c)
+		}))
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		for iter := 0; iter < 2; iter++ {
+			logged = logged[:0]
+			var n int64
+			err = r.Read(func(ctx RCtx) error {
+				if beg <= ctx.Entry && ctx.Entry < end {
+					t.Fatalf("entry %d should have been skipped", ctx.Entry)
+				}
+				if i64 != ctx.Entry || f64 != float64(ctx.Entry) {
+					t.Fatalf("invalid entry %d: i64=%d, f64=%v", ctx.Entry, i64, f64)
+				}
+				n++
+				return nil
+			})
+
+			var cerr *CorruptionError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("expected a corruption report, got: %+v", err)
+			}
+
+			if got, want := n, nevts-(end-beg); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+			if got, want := cerr.Skipped, end-beg; got != want {
+				t.Fatalf("invalid number of skipped entries: got=%d, want=%d", got, want)
+			}
+			if got, want := len(cerr.Corruptions), 1; got != want {
+				t.Fatalf("invalid number of corruptions: got=%d, want=%d", got, want)
+			}
+			if got, want := len(logged), 1; got != want {
+				t.Fatalf("invalid number of logged corruptions: got=%d, want=%d", got, want)
+			}
+
+			c := cerr.Corruptions[0]
+			if c.File != fname || c.Tree != "tree" || c.Branch != "F64" ||
+				c.Basket != 2 || c.Beg != beg || c.End != end || c.Err == nil {
+				t.Fatalf("invalid corruption: %v", c)
+			}
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		r, err := NewConcurrentReader(tree, rvars, 4, WithSkipCorrupted(nil))
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+
+		var n int64
+		err = r.ReadOrdered(func(ctx RCtx) error {
+			n++
+			return nil
+		})
+		var cerr *CorruptionError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("expected a corruption report, got: %+v", err)
+		}
+		if got, want := n, nevts-(end-beg); got != want {
+			t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+		}
+		if got, want := cerr.Skipped, end-beg; got != want {
+			t.Fatalf("invalid number of skipped entries: got=%d, want=%d", got, want)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	const nevts = 100
+	fname, beg, end := createCorrupted(t, nevts)
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	var logged []Corruption
+	n, err := Verify(tree, func(c Corruption) {
+		logged = append(logged, c)
+	})
+
+	var nbkts int
+	for _, b := range tree.Branches() {
+		nbkts += len(asBranch(b).basketSeek)
+	}
+	if got, want := n, nbkts; got != want {
+		t.Fatalf("invalid number of verified baskets: got=%d, want=%d", got, want)
+	}
+
+	var cerr *CorruptionError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a corruption report, got: %+v", err)
+	}
+	if got, want := len(cerr.Corruptions), 1; got != want {
+		t.Fatalf("invalid number of corruptions: got=%d, want=%d", got, want)
+	}
+	if got, want := len(logged), 1; got != want {
+		t.Fatalf("invalid number of logged corruptions: got=%d, want=%d", got, want)
+	}
+	if got, want := cerr.Skipped, end-beg; got != want {
+		t.Fatalf("invalid number of affected entries: got=%d, want=%d", got, want)
+	}
+
+	c := cerr.Corruptions[0]
+	if c.File != fname || c.Tree != "tree" || c.Branch != "F64" ||
+		c.Basket != 2 || c.Seek != asBranch(tree.Branch("F64")).basketSeek[2] ||
+		c.Beg != beg || c.End != end || c.Err == nil {
+		t.Fatalf("invalid corruption: %v", c)
+	}
+}
+
+func TestVerifyValid(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	n, err := Verify(tree, nil)
+	if err != nil {
+		t.Fatalf("could not verify tree: %+v", err)
+	}
+	if n == 0 {
+		t.Fatalf("no basket verified")
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// entryListBlockSize is the number of entries handled by a TEntryListBlock.
+const entryListBlockSize = 64000
+
+// EntryList holds a sorted list of entry numbers of a tree, e.g. the
+// entries passing a selection.
+//
+// EntryList mimicks ROOT's TEntryList.
+// Entry lists of chains, as created by ROOT, hold a sub-list for each
+// tree of the chain.
+type EntryList struct {
+	named   rbase.Named
+	lists   []*EntryList // sub-lists, one for each tree of a chain.
+	tree    string       // name of the tree
+	file    string       // name of the file holding the tree
+	reapply bool
+
+	entries []int64 // sorted entries
+}
+
+// NewEntryList creates a new empty entry list.
+func NewEntryList(name, title string) *EntryList {
+	return &EntryList{
+		named: *rbase.NewNamed(name, title),
+	}
+}
+
+func (*EntryList) RVersion() int16 { return rvers.EntryList }
+func (*EntryList) Class() string   { return "TEntryList" }
+
+func (el *EntryList) Name() string  { return el.named.Name() }
+func (el *EntryList) Title() string { return el.named.Title() }
+
+// TreeName returns the name of the tree this entry list applies to.
+func (el *EntryList) TreeName() string { return el.tree }
+
+// FileName returns the name of the file holding the tree this entry
+// list applies to.
+func (el *EntryList) FileName() string { return el.file }
+
+// SetTree sets the names of the tree, and of the file holding that tree,
+// this entry list applies to.
+func (el *EntryList) SetTree(tree, file string) {
+	el.tree = tree
+	el.file = file
+}
+
+// Lists returns the sub-lists of this entry list.
+// Entry lists of chains hold one sub-list for each tree of the chain.
+func (el *EntryList) Lists() []*EntryList { return el.lists }
+
+// Len returns the number of entries in the list, including the ones
+// of its sub-lists.
+func (el *EntryList) Len() int64 {
+	n := int64(len(el.entries))
+	for _, sub := range el.lists {
+		n += sub.Len()
+	}
+	return n
+}
+
+// Entries returns the sorted entries of the list.
+// Entries of the sub-lists are not included.
+func (el *EntryList) Entries() []int64 { return el.entries }
+
+// Enter adds the provided entry to the list.
+// Enter returns false if the entry was already in the list.
+func (el *EntryList) Enter(entry int64) bool {
+	n := len(el.entries)
+	if n == 0 || el.entries[n-1] < entry {
+		el.entries = append(el.entries, entry)
+		return true
+	}
+
+	i := sort.Search(n, func(i int) bool { return el.entries[i] >= entry })
+	if el.entries[i] == entry {
+		return false
+	}
+	el.entries = append(el.entries, 0)
+	copy(el.entries[i+1:], el.entries[i:])
+	el.entries[i] = entry
+	return true
+}
+
+// Contains returns whether the provided entry is in the list.
+// Entries of the sub-lists are not considered.
+func (el *EntryList) Contains(entry int64) bool {
+	i := sort.Search(len(el.entries), func(i int) bool { return el.entries[i] >= entry })
+	return i < len(el.entries) && el.entries[i] == entry
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (el *EntryList) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(el.Class(), el.RVersion())
+	w.WriteObject(&el.named)
+
+	switch len(el.lists) {
+	case 0:
+		w.WriteObjectAny(nil)
+	default:
+		objs := make([]root.Object, len(el.lists))
+		for i, sub := range el.lists {
+			objs[i] = sub
+		}
+		w.WriteObjectAny(rcont.NewList("", objs))
+	}
+
+	blocks := el.blocks()
+	w.WriteI32(int32(len(blocks)))
+	switch len(blocks) {
+	case 0:
+		w.WriteObjectAny(nil)
+	default:
+		objs := make([]root.Object, len(blocks))
+		for i, blk := range blocks {
+			objs[i] = blk
+		}
+		arr := rcont.NewObjArray()
+		arr.SetElems(objs)
+		w.WriteObjectAny(arr)
+	}
+
+	w.WriteI64(el.Len())
+	w.WriteI64(el.Len()) // entries to process
+	w.WriteString(el.tree)
+	w.WriteString(el.file)
+	w.WriteBool(el.reapply)
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (el *EntryList) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(el.Class())
+	if hdr.Vers > rvers.EntryList {
+		panic(fmt.Errorf("rtree: invalid TEntryList version=%d > %d", hdr.Vers, rvers.EntryList))
+	}
+
+	r.ReadObject(&el.named)
+
+	el.lists = nil
+	if v := r.ReadObjectAny(); v != nil {
+		lists := v.(*rcont.List)
+		el.lists = make([]*EntryList, lists.Len())
+		for i := range el.lists {
+			el.lists[i] = lists.At(i).(*EntryList)
+		}
+	}
+
+	_ = r.ReadI32() // number of blocks
+	el.entries = nil
+	if v := r.ReadObjectAny(); v != nil {
+		blocks := v.(*rcont.ObjArray)
+		for i := 0; i < blocks.Len(); i++ {
+			blk, ok := blocks.At(i).(*entryListBlock)
+			if !ok || blk == nil {
+				continue
+			}
+			el.entries = blk.appendEntries(el.entries, int64(i)*entryListBlockSize)
+		}
+	}
+
+	_ = r.ReadI64() // number of entries
+	_ = r.ReadI64() // entries to process
+	el.tree = r.ReadString()
+	el.file = r.ReadString()
+	if hdr.Vers > 1 {
+		el.reapply = r.ReadBool()
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// blocks splits the entries of the list into TEntryListBlocks.
+func (el *EntryList) blocks() []*entryListBlock {
+	if len(el.entries) == 0 {
+		return nil
+	}
+
+	n := el.entries[len(el.entries)-1]/entryListBlockSize + 1
+	blocks := make([]*entryListBlock, n)
+	beg := 0
+	for i := range blocks {
+		end := sort.Search(len(el.entries), func(j int) bool {
+			return el.entries[j] >= int64(i+1)*entryListBlockSize
+		})
+		blocks[i] = newEntryListBlock(el.entries[beg:end], int64(i)*entryListBlockSize)
+		beg = end
+	}
+	return blocks
+}
+
+// entryListBlock holds the entries of a TEntryList, in a range of
+// entryListBlockSize entries.
+type entryListBlock struct {
+	base    rbase.Object
+	npassed int32
+	indices []uint16
+	typ     int32 // 0: bits, 1: list
+	passing bool  // whether indices are the ones of passing entries
+}
+
+func newEntryListBlock(entries []int64, offset int64) *entryListBlock {
+	const nwords = entryListBlockSize / 16
+
+	blk := &entryListBlock{
+		base:    *rbase.NewObject(),
+		npassed: int32(len(entries)),
+		passing: true,
+	}
+
+	switch {
+	case len(entries) < nwords:
+		blk.typ = 1
+		blk.indices = make([]uint16, len(entries))
+		for i, entry := range entries {
+			blk.indices[i] = uint16(entry - offset)
+		}
+	default:
+		blk.typ = 0
+		blk.indices = make([]uint16, nwords)
+		for _, entry := range entries {
+			i := entry - offset
+			blk.indices[i/16] |= 1 << (i % 16)
+		}
+	}
+
+	return blk
+}
+
+func (*entryListBlock) RVersion() int16 { return rvers.EntryListBlock }
+func (*entryListBlock) Class() string   { return "TEntryListBlock" }
+
+// appendEntries appends the entries held by this block to the provided
+// slice, shifted by the provided offset.
+func (blk *entryListBlock) appendEntries(entries []int64, offset int64) []int64 {
+	switch blk.typ {
+	case 0:
+		for i, word := range blk.indices {
+			for j := 0; j < 16; j++ {
+				if word&(1<<j) != 0 {
+					entries = append(entries, offset+int64(16*i+j))
+				}
+			}
+		}
+	default:
+		if blk.passing {
+			for _, idx := range blk.indices {
+				entries = append(entries, offset+int64(idx))
+			}
+			return entries
+		}
+		// indices of the entries not in the list.
+		var skip int
+		for i := 0; i < entryListBlockSize; i++ {
+			if skip < len(blk.indices) && int(blk.indices[skip]) == i {
+				skip++
+				continue
+			}
+			entries = append(entries, offset+int64(i))
+		}
+	}
+	return entries
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (blk *entryListBlock) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(blk.Class(), blk.RVersion())
+	w.WriteObject(&blk.base)
+	w.WriteI32(blk.npassed)
+	w.WriteI32(int32(len(blk.indices)))
+	w.WriteI8(1) // is-array
+	w.WriteArrayU16(blk.indices)
+	w.WriteI32(blk.typ)
+	w.WriteBool(blk.passing)
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (blk *entryListBlock) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(blk.Class())
+	if hdr.Vers > rvers.EntryListBlock {
+		panic(fmt.Errorf("rtree: invalid TEntryListBlock version=%d > %d", hdr.Vers, rvers.EntryListBlock))
+	}
+
+	r.ReadObject(&blk.base)
+	blk.npassed = r.ReadI32()
+	n := int(r.ReadI32())
+	_ = r.ReadI8() // is-array
+	blk.indices = rbytes.ResizeU16(blk.indices, n)
+	r.ReadArrayU16(blk.indices)
+	blk.typ = r.ReadI32()
+	blk.passing = r.ReadBool()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &EntryList{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEntryList", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &entryListBlock{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEntryListBlock", f)
+	}
+}
+
+var (
+	_ root.Object        = (*EntryList)(nil)
+	_ root.Named         = (*EntryList)(nil)
+	_ rbytes.Marshaler   = (*EntryList)(nil)
+	_ rbytes.Unmarshaler = (*EntryList)(nil)
+
+	_ root.Object        = (*entryListBlock)(nil)
+	_ rbytes.Marshaler   = (*entryListBlock)(nil)
+	_ rbytes.Unmarshaler = (*entryListBlock)(nil)
+)
@@ -0,0 +1,291 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestEntryList(t *testing.T) {
+	el := NewEntryList("elist", "my entries")
+	for _, entry := range []int64{5, 1, 3, 5, 10, 0} {
+		el.Enter(entry)
+	}
+
+	if got, want := el.Entries(), []int64{0, 1, 3, 5, 10}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+	if got, want := el.Len(), int64(5); got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	if !el.Contains(3) || el.Contains(4) {
+		t.Fatalf("invalid contains")
+	}
+}
+
+func TestEntryListRW(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "elist.root")
+
+	want := NewEntryList("elist", "my entries")
+	want.SetTree("tree", "file.root")
+	// first block in bits mode, second one in list mode.
+	for i := int64(0); i < 70000; i += 3 {
+		want.Enter(i)
+	}
+
+	{
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		err = f.Put("elist", want)
+		if err != nil {
+			t.Fatalf("could not write entry list: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("elist")
+	if err != nil {
+		t.Fatalf("could not read entry list: %+v", err)
+	}
+	got := obj.(*EntryList)
+
+	if got, want := got.Name(), want.Name(); got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := got.TreeName(), want.TreeName(); got != want {
+		t.Fatalf("invalid tree name: got=%q, want=%q", got, want)
+	}
+	if got, want := got.FileName(), want.FileName(); got != want {
+		t.Fatalf("invalid file name: got=%q, want=%q", got, want)
+	}
+	if !reflect.DeepEqual(got.Entries(), want.Entries()) {
+		t.Fatalf("invalid round-trip entries")
+	}
+}
+
+func TestReaderWithEntryList(t *testing.T) {
+	f, err := riofs.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	read := func(t *testing.T, tree Tree, opts ...ReadOption) ([]int64, []int32) {
+		t.Helper()
+
+		var v int32
+		r, err := NewReader(tree, []ReadVar{{Name: "one", Value: &v}}, opts...)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		var (
+			entries []int64
+			vals    []int32
+		)
+		err = r.Read(func(ctx RCtx) error {
+			entries = append(entries, ctx.Entry)
+			vals = append(vals, v)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		return entries, vals
+	}
+
+	// selection pass.
+	el := NewEntryList("elist", "")
+	{
+		var v int32
+		r, err := NewReader(tree, []ReadVar{{Name: "one", Value: &v}})
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		err = r.Read(func(ctx RCtx) error {
+			if v%2 == 0 {
+				el.Enter(ctx.Entry)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		r.Close()
+	}
+
+	t.Run("tree", func(t *testing.T) {
+		entries, vals := read(t, tree, WithEntryList(el))
+		if got, want := entries, []int64{1, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := vals, []int32{2, 4}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("tree-range", func(t *testing.T) {
+		entries, vals := read(t, tree, WithEntryList(el), WithRange(2, 4))
+		if got, want := entries, []int64{3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := vals, []int32{4}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := Chain(tree, tree)
+		el := NewEntryList("elist", "")
+		for _, entry := range []int64{2, 5, 7} {
+			el.Enter(entry)
+		}
+		entries, vals := read(t, chain, WithEntryList(el))
+		if got, want := entries, []int64{2, 5, 7}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := vals, []int32{3, 2, 4}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("sub-lists", func(t *testing.T) {
+		el := NewEntryList("elist", "")
+		el.lists = []*EntryList{NewEntryList("sub", "")}
+		_, err := NewReader(tree, nil, WithEntryList(el))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestReaderWithEntryListBaskets(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "baskets.root")
+	{
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt struct {
+			N   int64
+			Sli []float64 `groot:"Sli[N]"`
+		}
+		w, err := NewWriter(f, "tree", WriteVarsFromStruct(&evt), WithBasketSize(512))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < 1000; i++ {
+			evt.N = int64(i % 5)
+			evt.Sli = evt.Sli[:0]
+			for j := 0; j < int(evt.N); j++ {
+				evt.Sli = append(evt.Sli, float64(i))
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	el := NewEntryList("elist", "")
+	for _, entry := range []int64{3, 4, 501, 998, 999} {
+		el.Enter(entry)
+	}
+
+	var evt struct {
+		N   int64
+		Sli []float64 `groot:"Sli[N]"`
+	}
+	r, err := NewReader(tree, ReadVarsFromStruct(&evt), WithEntryList(el))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	var n int
+	err = r.Read(func(ctx RCtx) error {
+		n++
+		if got, want := evt.N, ctx.Entry%5; got != want {
+			t.Fatalf("entry %d: invalid N: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		for _, v := range evt.Sli {
+			if got, want := v, float64(ctx.Entry); got != want {
+				t.Fatalf("entry %d: invalid value: got=%v, want=%v", ctx.Entry, got, want)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	if got, want := n, len(el.Entries()); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"reflect"
+	"strconv"
+
+	"go-hep.org/x/hep/groot/rtree/rfunc"
+)
+
+// NewFormula creates a new formula from the provided string expression,
+// mimicking ROOT's TTreeFormula.
+//
+// The expression is compiled against the names of the branches of the
+// provided tree, e.g.:
+//
+//  sqrt(px*px + py*py) > 10 && abs(eta) < 2.5
+//
+// Expressions follow the Go syntax and may use:
+//  - numeric literals, true and false,
+//  - names of branches holding scalar numbers or booleans (branches of
+//    friend trees are accessed as alias.name),
+//  - the arithmetic operators +, -, *, / and % (as math.Mod),
+//  - the comparison operators ==, !=, <, <=, > and >=,
+//  - the logical operators &&, || and !,
+//  - the functions abs, sqrt, cbrt, exp, log, log10, log2, sin, cos, tan,
+//    asin, acos, atan, sinh, cosh, tanh, floor, ceil, trunc, round,
+//    atan2, pow, hypot, min and max.
+//
+// Numbers are evaluated as float64 values.
+// Numbers used as booleans are true when non-zero, booleans used as numbers
+// are 1 when true and 0 otherwise.
+//
+// The returned formula should be bound to a tree Reader with Reader.Formula.
+// Its Func method returns a func() bool for boolean expressions (e.g. to be
+// used as a filter), and a func() float64 otherwise.
+func NewFormula(t Tree, expr string) (rfunc.Formula, error) {
+	node, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not parse formula %q: %w", expr, err)
+	}
+
+	rvars := make(map[string]ReadVar)
+	for _, rvar := range NewReadVars(t) {
+		if _, dup := rvars[rvar.Name]; dup {
+			continue
+		}
+		rvars[rvar.Name] = rvar
+	}
+
+	f := &exprFormula{
+		expr: expr,
+		node: node,
+	}
+
+	ids := make(map[string]int)
+	cmp := exprCompiler{
+		lookup: func(name string) (exprValue, bool, error) {
+			rvar, ok := rvars[name]
+			if !ok {
+				return exprValue{}, false, nil
+			}
+			if _, dup := ids[name]; !dup {
+				ids[name] = len(f.names)
+				f.names = append(f.names, name)
+			}
+			v, err := exprVar(rvar.Value)
+			if err != nil {
+				return v, false, fmt.Errorf("invalid variable %q: %w", name, err)
+			}
+			return v, true, nil
+		},
+	}
+
+	v, err := cmp.compile(node)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not compile formula %q: %w", expr, err)
+	}
+	f.bool = v.bool != nil
+
+	return f, nil
+}
+
+// exprFormula is a formula compiled from a string expression.
+type exprFormula struct {
+	expr  string
+	node  ast.Expr
+	names []string
+	bool  bool // whether the formula evaluates to a boolean
+
+	fct interface{}
+}
+
+var (
+	_ rfunc.Formula = (*exprFormula)(nil)
+)
+
+func (f *exprFormula) RVars() []string { return f.names }
+
+func (f *exprFormula) Bind(args []interface{}) error {
+	if got, want := len(args), len(f.names); got != want {
+		return fmt.Errorf(
+			"rtree: invalid number of bind arguments (got=%d, want=%d)",
+			got, want,
+		)
+	}
+
+	vars := make(map[string]exprValue, len(args))
+	for i, arg := range args {
+		v, err := exprVar(arg)
+		if err != nil {
+			return fmt.Errorf("rtree: could not bind variable %q: %w", f.names[i], err)
+		}
+		vars[f.names[i]] = v
+	}
+
+	cmp := exprCompiler{
+		lookup: func(name string) (exprValue, bool, error) {
+			v, ok := vars[name]
+			return v, ok, nil
+		},
+	}
+
+	v, err := cmp.compile(f.node)
+	if err != nil {
+		return fmt.Errorf("rtree: could not compile formula %q: %w", f.expr, err)
+	}
+
+	switch {
+	case f.bool:
+		f.fct = v.asBool()
+	default:
+		f.fct = v.asNum()
+	}
+
+	return nil
+}
+
+func (f *exprFormula) Func() interface{} { return f.fct }
+
+// exprValue is a compiled expression, evaluating either to a number
+// or to a boolean.
+type exprValue struct {
+	num  func() float64
+	bool func() bool
+}
+
+func (v exprValue) asNum() func() float64 {
+	if v.num != nil {
+		return v.num
+	}
+	fct := v.bool
+	return func() float64 {
+		if fct() {
+			return 1
+		}
+		return 0
+	}
+}
+
+func (v exprValue) asBool() func() bool {
+	if v.bool != nil {
+		return v.bool
+	}
+	fct := v.num
+	return func() bool { return fct() != 0 }
+}
+
+// exprVar returns the expression value loading the provided pointer
+// to a read-var value.
+func exprVar(ptr interface{}) (exprValue, error) {
+	switch ptr := ptr.(type) {
+	case *bool:
+		return exprValue{bool: func() bool { return *ptr }}, nil
+	case *int8:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *int16:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *int32:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *int64:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *uint8:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *uint16:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *uint32:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *uint64:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *float32:
+		return exprValue{num: func() float64 { return float64(*ptr) }}, nil
+	case *float64:
+		return exprValue{num: func() float64 { return *ptr }}, nil
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr {
+		return exprValue{}, fmt.Errorf("invalid value type %T (expected a pointer)", ptr)
+	}
+	rv = rv.Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		return exprValue{bool: func() bool { return rv.Bool() }}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return exprValue{num: func() float64 { return float64(rv.Int()) }}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return exprValue{num: func() float64 { return float64(rv.Uint()) }}, nil
+	case reflect.Float32, reflect.Float64:
+		return exprValue{num: rv.Float}, nil
+	}
+
+	return exprValue{}, fmt.Errorf("unsupported value type %T (expected a scalar number or boolean)", ptr)
+}
+
+var (
+	exprFuncs1 = map[string]func(float64) float64{
+		"abs":   math.Abs,
+		"sqrt":  math.Sqrt,
+		"cbrt":  math.Cbrt,
+		"exp":   math.Exp,
+		"log":   math.Log,
+		"log10": math.Log10,
+		"log2":  math.Log2,
+		"sin":   math.Sin,
+		"cos":   math.Cos,
+		"tan":   math.Tan,
+		"asin":  math.Asin,
+		"acos":  math.Acos,
+		"atan":  math.Atan,
+		"sinh":  math.Sinh,
+		"cosh":  math.Cosh,
+		"tanh":  math.Tanh,
+		"floor": math.Floor,
+		"ceil":  math.Ceil,
+		"trunc": math.Trunc,
+		"round": math.Round,
+	}
+
+	exprFuncs2 = map[string]func(x, y float64) float64{
+		"atan2": math.Atan2,
+		"pow":   math.Pow,
+		"hypot": math.Hypot,
+		"min":   math.Min,
+		"max":   math.Max,
+	}
+)
+
+// exprCompiler compiles an expression AST into closures.
+type exprCompiler struct {
+	// lookup returns the expression value associated with the named variable.
+	lookup func(name string) (exprValue, bool, error)
+}
+
+func (cmp *exprCompiler) compile(node ast.Expr) (exprValue, error) {
+	switch node := node.(type) {
+	case *ast.ParenExpr:
+		return cmp.compile(node.X)
+
+	case *ast.BasicLit:
+		switch node.Kind {
+		case token.INT, token.FLOAT:
+			v, err := strconv.ParseFloat(node.Value, 64)
+			if err != nil {
+				return exprValue{}, fmt.Errorf("invalid number literal %q: %w", node.Value, err)
+			}
+			return exprValue{num: func() float64 { return v }}, nil
+		}
+		return exprValue{}, fmt.Errorf("unsupported literal %s", node.Value)
+
+	case *ast.Ident:
+		return cmp.ident(node.Name)
+
+	case *ast.SelectorExpr:
+		name, ok := exprName(node)
+		if !ok {
+			return exprValue{}, fmt.Errorf("unsupported selector expression")
+		}
+		return cmp.ident(name)
+
+	case *ast.UnaryExpr:
+		x, err := cmp.compile(node.X)
+		if err != nil {
+			return x, err
+		}
+		switch node.Op {
+		case token.ADD:
+			return exprValue{num: x.asNum()}, nil
+		case token.SUB:
+			fx := x.asNum()
+			return exprValue{num: func() float64 { return -fx() }}, nil
+		case token.NOT:
+			fx := x.asBool()
+			return exprValue{bool: func() bool { return !fx() }}, nil
+		}
+		return exprValue{}, fmt.Errorf("unsupported unary operator %s", node.Op)
+
+	case *ast.BinaryExpr:
+		return cmp.binary(node)
+
+	case *ast.CallExpr:
+		return cmp.call(node)
+	}
+
+	return exprValue{}, fmt.Errorf("unsupported expression of type %T", node)
+}
+
+func (cmp *exprCompiler) ident(name string) (exprValue, error) {
+	v, ok, err := cmp.lookup(name)
+	if err != nil {
+		return v, err
+	}
+	if ok {
+		return v, nil
+	}
+	switch name {
+	case "true":
+		return exprValue{bool: func() bool { return true }}, nil
+	case "false":
+		return exprValue{bool: func() bool { return false }}, nil
+	}
+	return v, fmt.Errorf("unknown variable %q", name)
+}
+
+func (cmp *exprCompiler) binary(node *ast.BinaryExpr) (exprValue, error) {
+	x, err := cmp.compile(node.X)
+	if err != nil {
+		return x, err
+	}
+	y, err := cmp.compile(node.Y)
+	if err != nil {
+		return y, err
+	}
+
+	switch node.Op {
+	case token.LAND:
+		fx, fy := x.asBool(), y.asBool()
+		return exprValue{bool: func() bool { return fx() && fy() }}, nil
+	case token.LOR:
+		fx, fy := x.asBool(), y.asBool()
+		return exprValue{bool: func() bool { return fx() || fy() }}, nil
+	}
+
+	fx, fy := x.asNum(), y.asNum()
+	switch node.Op {
+	case token.ADD:
+		return exprValue{num: func() float64 { return fx() + fy() }}, nil
+	case token.SUB:
+		return exprValue{num: func() float64 { return fx() - fy() }}, nil
+	case token.MUL:
+		return exprValue{num: func() float64 { return fx() * fy() }}, nil
+	case token.QUO:
+		return exprValue{num: func() float64 { return fx() / fy() }}, nil
+	case token.REM:
+		return exprValue{num: func() float64 { return math.Mod(fx(), fy()) }}, nil
+	case token.EQL:
+		return exprValue{bool: func() bool { return fx() == fy() }}, nil
+	case token.NEQ:
+		return exprValue{bool: func() bool { return fx() != fy() }}, nil
+	case token.LSS:
+		return exprValue{bool: func() bool { return fx() < fy() }}, nil
+	case token.LEQ:
+		return exprValue{bool: func() bool { return fx() <= fy() }}, nil
+	case token.GTR:
+		return exprValue{bool: func() bool { return fx() > fy() }}, nil
+	case token.GEQ:
+		return exprValue{bool: func() bool { return fx() >= fy() }}, nil
+	}
+
+	return exprValue{}, fmt.Errorf("unsupported binary operator %s", node.Op)
+}
+
+func (cmp *exprCompiler) call(node *ast.CallExpr) (exprValue, error) {
+	id, ok := node.Fun.(*ast.Ident)
+	if !ok {
+		return exprValue{}, fmt.Errorf("unsupported function call")
+	}
+
+	args := make([]func() float64, len(node.Args))
+	for i, arg := range node.Args {
+		v, err := cmp.compile(arg)
+		if err != nil {
+			return v, err
+		}
+		args[i] = v.asNum()
+	}
+
+	if fct, ok := exprFuncs1[id.Name]; ok {
+		if len(args) != 1 {
+			return exprValue{}, fmt.Errorf("invalid number of arguments to %s (got=%d, want=1)", id.Name, len(args))
+		}
+		x := args[0]
+		return exprValue{num: func() float64 { return fct(x()) }}, nil
+	}
+
+	if fct, ok := exprFuncs2[id.Name]; ok {
+		if len(args) != 2 {
+			return exprValue{}, fmt.Errorf("invalid number of arguments to %s (got=%d, want=2)", id.Name, len(args))
+		}
+		x, y := args[0], args[1]
+		return exprValue{num: func() float64 { return fct(x(), y()) }}, nil
+	}
+
+	return exprValue{}, fmt.Errorf("unknown function %q", id.Name)
+}
+
+// exprName returns the dotted name of a selector expression (e.g. "alias.name").
+func exprName(node ast.Expr) (string, bool) {
+	switch node := node.(type) {
+	case *ast.Ident:
+		return node.Name, true
+	case *ast.SelectorExpr:
+		x, ok := exprName(node.X)
+		if !ok {
+			return "", false
+		}
+		return x + "." + node.Sel.Name, true
+	}
+	return "", false
+}
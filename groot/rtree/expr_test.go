@@ -0,0 +1,143 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestNewFormula(t *testing.T) {
+	f, err := riofs.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	for _, tc := range []struct {
+		expr string
+		want interface{}
+		err  string
+	}{
+		{
+			expr: "one",
+			want: []float64{1, 2, 3, 4},
+		},
+		{
+			expr: "sqrt(one*one) + 2*two - 1",
+			want: []float64{2.2, 5.4, 8.6, 11.8},
+		},
+		{
+			expr: "max(one, 2) + -one % 3",
+			want: []float64{1, 0, 3, 3},
+		},
+		{
+			expr: "one > 1 && two < 4",
+			want: []bool{false, true, true, false},
+		},
+		{
+			expr: "!(one == 2) || false",
+			want: []bool{true, false, true, true},
+		},
+		{
+			expr: "(one >= 3) + 1",
+			want: []float64{1, 1, 2, 2},
+		},
+		{
+			expr: "one +",
+			err:  `rtree: could not parse formula "one +": 1:6: expected operand, found 'EOF'`,
+		},
+		{
+			expr: "zero > 1",
+			err:  `rtree: could not compile formula "zero > 1": unknown variable "zero"`,
+		},
+		{
+			expr: "three == 1",
+			err:  `rtree: could not compile formula "three == 1": invalid variable "three": unsupported value type *string (expected a scalar number or boolean)`,
+		},
+		{
+			expr: "sqrt(one, two)",
+			err:  `rtree: could not compile formula "sqrt(one, two)": invalid number of arguments to sqrt (got=2, want=1)`,
+		},
+		{
+			expr: "foo(one)",
+			err:  `rtree: could not compile formula "foo(one)": unknown function "foo"`,
+		},
+		{
+			expr: `one == "1"`,
+			err:  `rtree: could not compile formula "one == \"1\"": unsupported literal "1"`,
+		},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			form, err := NewFormula(tree, tc.expr)
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not create formula: %+v", err)
+			case tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+
+			r, err := NewReader(tree, nil)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			form, err = r.Formula(form)
+			if err != nil {
+				t.Fatalf("could not bind formula: %+v", err)
+			}
+
+			var (
+				nums  []float64
+				bools []bool
+			)
+			err = r.Read(func(RCtx) error {
+				switch fct := form.Func().(type) {
+				case func() float64:
+					nums = append(nums, fct())
+				case func() bool:
+					bools = append(bools, fct())
+				default:
+					t.Fatalf("invalid formula func type %T", fct)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+
+			var got interface{} = nums
+			if bools != nil {
+				got = bools
+			}
+			if nums, ok := got.([]float64); ok {
+				want := tc.want.([]float64)
+				for i := range nums {
+					if diff := nums[i] - want[i]; diff > 1e-6 || diff < -1e-6 {
+						t.Fatalf("invalid formula values:\ngot= %v\nwant=%v", got, tc.want)
+					}
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid formula values:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddFriend returns a new Tree made of the provided main tree t and of its
+// friend tree, mimicking ROOT's TTree::AddFriend.
+//
+// Branches and leaves of the friend tree are accessible through the returned
+// tree as "alias.name".
+// When not shadowed by a branch (or leaf) of the main tree (or of a previously
+// added friend), they are also accessible by their bare name.
+// If alias is empty, the name of the friend tree is used.
+//
+// If the friend tree has an index (see BuildIndex and WithIndex), entries of
+// the friend tree are aligned with the ones of the main tree by index: the
+// major and minor expressions of the friend index are evaluated on each
+// entry of the main tree and the friend entry holding the same values is
+// read. Matching friend entries are looked up lazily, for the range of
+// entries being read.
+// As for ROOT, entries of the main tree without a matching friend entry are
+// still read: values of the friend tree are then zeroed and the alias of the
+// friend is reported in RCtx.Unmatched.
+// Otherwise, entries of the friend tree are aligned with the ones of the
+// main tree by entry number, and AddFriend errors out if the friend tree
+// has less entries than the main tree.
+//
+// AddFriend may be called multiple times to attach multiple friends to a
+// main tree.
+func AddFriend(t Tree, friend Tree, alias string) (Tree, error) {
+	if alias == "" {
+		alias = friend.Name()
+	}
+	if strings.Contains(alias, ".") {
+		return nil, fmt.Errorf("rtree: invalid friend alias %q", alias)
+	}
+
+	var (
+		trees   []Tree
+		aliases []string
+		indices []*TreeIndex
+	)
+	switch t := t.(type) {
+	case *join:
+		if t.aliases == nil {
+			// t is a plain join of trees: use it as a whole.
+			return nil, fmt.Errorf("rtree: can not add a friend to a joined tree")
+		}
+		trees = append(trees, t.trees...)
+		aliases = append(aliases, t.aliases...)
+		indices = append(indices, t.indices...)
+	default:
+		trees = []Tree{t}
+		aliases = []string{""}
+		indices = []*TreeIndex{nil}
+	}
+
+	for _, tree := range append([]Tree{friend}, trees...) {
+		if _, ok := tree.(*ttree); !ok {
+			return nil, fmt.Errorf("rtree: friend trees of type %T are not supported", tree)
+		}
+	}
+
+	for _, a := range aliases {
+		if a == alias {
+			return nil, fmt.Errorf("rtree: tree %q already has a friend with alias %q", trees[0].Name(), alias)
+		}
+	}
+
+	idx := IndexOf(friend)
+	switch idx {
+	case nil:
+		if n, nevts := friend.Entries(), trees[0].Entries(); n < nevts {
+			return nil, fmt.Errorf(
+				"rtree: friend tree %q has not enough entries (got=%d, want>=%d)",
+				friend.Name(), n, nevts,
+			)
+		}
+	default:
+		// make sure the index expressions can be evaluated on the main tree.
+		_, err := friendEntries(trees[0], idx, 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not align friend tree %q by index: %w", friend.Name(), err)
+		}
+	}
+
+	trees = append(trees, friend)
+	aliases = append(aliases, alias)
+	indices = append(indices, idx)
+
+	tree := &join{
+		name:    trees[0].Name(),
+		title:   trees[0].Title(),
+		trees:   trees,
+		aliases: aliases,
+		indices: indices,
+		bmap:    make(map[string]Branch),
+		lmap:    make(map[string]Leaf),
+	}
+
+	for i, t := range trees {
+		alias := aliases[i]
+		for _, b := range t.Branches() {
+			if alias != "" {
+				b = &friendBranch{embeddedBranch: b, alias: alias}
+			}
+			tree.branches = append(tree.branches, b)
+			tree.bmap[b.Name()] = b
+		}
+		for _, l := range t.Leaves() {
+			tree.leaves = append(tree.leaves, l)
+			if alias != "" {
+				tree.lmap[alias+"."+l.Name()] = l
+			}
+		}
+	}
+
+	// make friend branches and leaves available under their bare name,
+	// if not shadowed.
+	for i, t := range trees[1:] {
+		alias := aliases[i+1]
+		for _, b := range t.Branches() {
+			if _, dup := tree.bmap[b.Name()]; dup {
+				continue
+			}
+			tree.bmap[b.Name()] = tree.bmap[alias+"."+b.Name()]
+		}
+	}
+	for _, t := range trees {
+		for _, l := range t.Leaves() {
+			if _, dup := tree.lmap[l.Name()]; dup {
+				continue
+			}
+			tree.lmap[l.Name()] = l
+		}
+	}
+
+	return tree, nil
+}
+
+// friendBranch is a branch of a friend tree, accessed through an alias.
+type friendBranch struct {
+	embeddedBranch
+	alias string
+}
+
+// embeddedBranch allows to embed a Branch, despite its Branch method.
+type embeddedBranch = Branch
+
+func (b *friendBranch) Name() string {
+	return b.alias + "." + b.embeddedBranch.Name()
+}
+
+func (b *friendBranch) Leaf(name string) Leaf {
+	name = strings.TrimPrefix(name, b.alias+".")
+	return b.embeddedBranch.Leaf(name)
+}
+
+// unalias returns the read-var stripped from the provided friend alias.
+func unalias(rvar ReadVar, alias string) ReadVar {
+	if alias == "" {
+		return rvar
+	}
+	prefix := alias + "."
+	rvar.Name = strings.TrimPrefix(rvar.Name, prefix)
+	rvar.Leaf = strings.TrimPrefix(rvar.Leaf, prefix)
+	return rvar
+}
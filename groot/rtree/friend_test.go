@@ -0,0 +1,395 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestAddFriend(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 10
+	create := func(name string, n int, scale float64) {
+		f, err := riofs.Create(filepath.Join(tmp, name+".root"))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt struct {
+			X   float64
+			Y   float64
+			N   int32
+			Sli []float64 `groot:"Sli[N]"`
+		}
+		w, err := NewWriter(f, name, WriteVarsFromStruct(&evt))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < n; i++ {
+			evt.X = scale * float64(i)
+			evt.Y = -scale * float64(i)
+			evt.N = int32(i % 3)
+			evt.Sli = evt.Sli[:0]
+			for j := 0; j < int(evt.N); j++ {
+				evt.Sli = append(evt.Sli, scale*float64(j))
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+	create("main", nevts, 1)
+	create("friend", nevts+2, 10)
+
+	open := func(name string) (*riofs.File, Tree) {
+		f, err := riofs.Open(filepath.Join(tmp, name+".root"))
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		o, err := riofs.Dir(f).Get(name)
+		if err != nil {
+			t.Fatalf("could not retrieve tree: %+v", err)
+		}
+		return f, o.(Tree)
+	}
+
+	fmain, tmain := open("main")
+	defer fmain.Close()
+
+	ffriend, tfriend := open("friend")
+	defer ffriend.Close()
+
+	tree, err := AddFriend(tmain, tfriend, "f")
+	if err != nil {
+		t.Fatalf("could not add friend: %+v", err)
+	}
+
+	if got, want := tree.Entries(), int64(nevts); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	for _, name := range []string{"X", "f.X", "f.Sli"} {
+		if tree.Branch(name) == nil {
+			t.Fatalf("could not find branch %q", name)
+		}
+	}
+
+	_, err = AddFriend(tree, tfriend, "f")
+	if err == nil {
+		t.Fatalf("expected an error for duplicate alias")
+	}
+
+	var (
+		x    float64
+		fx   float64
+		fsli []float64
+		rvs  = []ReadVar{
+			{Name: "X", Value: &x},
+			{Name: "f.X", Value: &fx},
+			{Name: "f.Sli", Value: &fsli},
+		}
+	)
+
+	r, err := NewReader(tree, rvs)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	n := 0
+	err = r.Read(func(ctx RCtx) error {
+		i := ctx.Entry
+		if got, want := x, float64(i); got != want {
+			return fmt.Errorf("entry %d: invalid X: got=%v, want=%v", i, got, want)
+		}
+		if got, want := fx, 10*float64(i); got != want {
+			return fmt.Errorf("entry %d: invalid f.X: got=%v, want=%v", i, got, want)
+		}
+		if got, want := len(fsli), int(i%3); got != want {
+			return fmt.Errorf("entry %d: invalid f.Sli: got=%v, want=%v", i, got, want)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	if n != nevts {
+		t.Fatalf("invalid number of entries read: got=%d, want=%d", n, nevts)
+	}
+
+	all, err := NewReader(tree, NewReadVars(tree))
+	if err != nil {
+		t.Fatalf("could not create reader for all read-vars: %+v", err)
+	}
+	defer all.Close()
+
+	err = all.Read(func(ctx RCtx) error { return nil })
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	_, err = AddFriend(tfriend, tmain, "m")
+	if err == nil {
+		t.Fatalf("expected an error for short friend tree")
+	}
+}
+
+func TestAddFriendWithIndex(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Event struct {
+		Run int32
+		Evt int64
+		X   float64
+	}
+
+	create := func(name string, evts []Event, opts ...WriteOption) {
+		f, err := riofs.Create(filepath.Join(tmp, name+".root"))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt Event
+		w, err := NewWriter(f, name, WriteVarsFromStruct(&evt), opts...)
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := range evts {
+			evt = evts[i]
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	const nevts = 20
+	var (
+		evts    = make([]Event, nevts)
+		friends = make([]Event, 0, nevts+5)
+	)
+	for i := range evts {
+		evts[i] = Event{Run: int32(i / 5), Evt: int64(i % 5), X: float64(i)}
+	}
+	// friend events are stored in reverse order, with extra events not
+	// present in the main tree.
+	for i := nevts - 1; i >= 0; i-- {
+		evt := evts[i]
+		evt.X *= 10
+		friends = append(friends, evt)
+		if i%4 == 0 {
+			friends = append(friends, Event{Run: 42, Evt: int64(i), X: -1})
+		}
+	}
+
+	create("main", evts)
+	create("friend", friends, WithIndex("Run", "Evt"), WithBasketSize(32))
+	create("short", friends[:nevts/2], WithIndex("Run", "Evt"))
+
+	open := func(name string) (*riofs.File, Tree) {
+		f, err := riofs.Open(filepath.Join(tmp, name+".root"))
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		o, err := riofs.Dir(f).Get(name)
+		if err != nil {
+			t.Fatalf("could not retrieve tree: %+v", err)
+		}
+		return f, o.(Tree)
+	}
+
+	fmain, tmain := open("main")
+	defer fmain.Close()
+
+	ffriend, tfriend := open("friend")
+	defer ffriend.Close()
+
+	if IndexOf(tfriend) == nil {
+		t.Fatalf("expected an index for friend tree")
+	}
+
+	tree, err := AddFriend(tmain, tfriend, "f")
+	if err != nil {
+		t.Fatalf("could not add friend: %+v", err)
+	}
+
+	var (
+		run  int32
+		evt  int64
+		x    float64
+		frun int32
+		fevt int64
+		fx   float64
+		rvs  = []ReadVar{
+			{Name: "Run", Value: &run},
+			{Name: "Evt", Value: &evt},
+			{Name: "X", Value: &x},
+			{Name: "f.Run", Value: &frun},
+			{Name: "f.Evt", Value: &fevt},
+			{Name: "f.X", Value: &fx},
+		}
+	)
+
+	for _, tc := range []struct {
+		name     string
+		beg, end int64
+	}{
+		{name: "all", beg: 0, end: -1},
+		{name: "range", beg: 3, end: 17},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewReader(tree, rvs, WithRange(tc.beg, tc.end))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				i := ctx.Entry
+				if run != frun || evt != fevt {
+					return fmt.Errorf(
+						"entry %d: invalid friend index values: got=(%d, %d), want=(%d, %d)",
+						i, frun, fevt, run, evt,
+					)
+				}
+				if got, want := fx, 10*float64(i); got != want {
+					return fmt.Errorf("entry %d: invalid f.X: got=%v, want=%v", i, got, want)
+				}
+				if got, want := x, float64(i); got != want {
+					return fmt.Errorf("entry %d: invalid X: got=%v, want=%v", i, got, want)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+
+			end := tc.end
+			if end < 0 {
+				end = nevts
+			}
+			if got, want := int64(n), end-tc.beg; got != want {
+				t.Fatalf("invalid number of entries read: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	fshort, tshort := open("short")
+	defer fshort.Close()
+
+	tree, err = AddFriend(tmain, tshort, "s")
+	if err != nil {
+		t.Fatalf("could not add indexed friend with less entries: %+v", err)
+	}
+
+	// only the last entries of the main tree have a matching friend entry.
+	const nmatch = 8
+	check := func(ctx RCtx) error {
+		i := ctx.Entry
+		switch {
+		case i < nevts-nmatch:
+			if !reflect.DeepEqual(ctx.Unmatched, []string{"s"}) {
+				return fmt.Errorf("entry %d: invalid unmatched friends: got=%q", i, ctx.Unmatched)
+			}
+			if fx != 0 {
+				return fmt.Errorf("entry %d: invalid s.X: got=%v, want=0", i, fx)
+			}
+		default:
+			if ctx.Unmatched != nil {
+				return fmt.Errorf("entry %d: invalid unmatched friends: got=%q", i, ctx.Unmatched)
+			}
+			if got, want := fx, 10*float64(i); got != want {
+				return fmt.Errorf("entry %d: invalid s.X: got=%v, want=%v", i, got, want)
+			}
+		}
+		if got, want := x, float64(i); got != want {
+			return fmt.Errorf("entry %d: invalid X: got=%v, want=%v", i, got, want)
+		}
+		return nil
+	}
+
+	rvs = []ReadVar{{Name: "X", Value: &x}, {Name: "s.X", Value: &fx}}
+	r, err := NewReader(tree, rvs)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	fx = -1
+	n := 0
+	err = r.Read(func(ctx RCtx) error {
+		n++
+		return check(ctx)
+	})
+	if err != nil {
+		t.Fatalf("could not read tree with partial friend matches: %+v", err)
+	}
+	if n != nevts {
+		t.Fatalf("invalid number of entries read: got=%d, want=%d", n, nevts)
+	}
+
+	cr, err := NewConcurrentReader(tree, rvs, 3)
+	if err != nil {
+		t.Fatalf("could not create concurrent reader: %+v", err)
+	}
+
+	n = 0
+	err = cr.ReadOrdered(func(ctx RCtx) error {
+		n++
+		return check(ctx)
+	})
+	if err != nil {
+		t.Fatalf("could not read tree with partial friend matches concurrently: %+v", err)
+	}
+	if n != nevts {
+		t.Fatalf("invalid number of entries read concurrently: got=%d, want=%d", n, nevts)
+	}
+}
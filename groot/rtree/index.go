@@ -0,0 +1,427 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// TreeIndex is an index of the entries of a tree, sorted by the values of
+// a major and a minor expression (e.g. run and event numbers.)
+//
+// TreeIndex mimicks ROOT's TTreeIndex.
+type TreeIndex struct {
+	named rbase.Named
+
+	major  string  // name of the major expression
+	minor  string  // name of the minor expression
+	majors []int64 // sorted major values
+	minors []int64 // sorted minor values
+	index  []int64 // entries of the sorted values
+}
+
+// BuildIndex builds an index of the entries of the provided tree, sorted
+// by the values of the major and minor expressions.
+// Expressions are compiled with NewFormula and their values are truncated
+// to int64.
+// An empty minor expression is equivalent to "0".
+//
+// The index is attached to the tree, if the tree is backed by a ROOT file,
+// so it can be later retrieved with IndexOf.
+// Entries of chains are indexed with their global entry number.
+//
+// Example:
+//
+//  idx, err := rtree.BuildIndex(tree, "run", "event")
+//  entry := idx.EntryWithIndex(run, event)
+func BuildIndex(t Tree, major, minor string) (*TreeIndex, error) {
+	if minor == "" {
+		minor = "0"
+	}
+
+	r, err := NewReader(t, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create reader: %w", err)
+	}
+	defer r.Close()
+
+	fmajor, fminor, err := indexFuncs(r, t, major, minor)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		n      = t.Entries()
+		majors = make([]int64, 0, n)
+		minors = make([]int64, 0, n)
+	)
+	err = r.Read(func(RCtx) error {
+		majors = append(majors, fmajor())
+		minors = append(minors, fminor())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not build index of tree %q: %w", t.Name(), err)
+	}
+
+	err = r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not close reader: %w", err)
+	}
+
+	idx := newTreeIndex(major, minor, majors, minors)
+	if tree := ttreeOf(t); tree != nil {
+		tree.treeIndex = idx
+	}
+	return idx, nil
+}
+
+// indexFuncs returns the functions evaluating the major and minor index
+// expressions of the tree t, bound to the reader r.
+func indexFuncs(r *Reader, t Tree, major, minor string) (fmajor, fminor func() int64, err error) {
+	fct := func(expr string) (func() int64, error) {
+		form, err := NewFormula(t, expr)
+		if err != nil {
+			return nil, err
+		}
+		form, err = r.Formula(form)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not bind index expression %q: %w", expr, err)
+		}
+		switch fct := form.Func().(type) {
+		case func() float64:
+			return func() int64 { return int64(fct()) }, nil
+		case func() bool:
+			return func() int64 {
+				if fct() {
+					return 1
+				}
+				return 0
+			}, nil
+		default:
+			return nil, fmt.Errorf("rtree: invalid index expression %q type %T", expr, fct)
+		}
+	}
+
+	fmajor, err = fct(major)
+	if err != nil {
+		return nil, nil, err
+	}
+	fminor, err = fct(minor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fmajor, fminor, nil
+}
+
+// friendEntries returns, for each entry of the tree t in [beg, end), the
+// entry of the indexed friend tree holding the same major and minor values,
+// or -1.
+// The major and minor values are computed by evaluating the expressions
+// of the friend index on the tree t, as ROOT does for friend trees.
+func friendEntries(t Tree, idx *TreeIndex, beg, end int64) ([]int64, error) {
+	r, err := NewReader(t, nil, WithRange(beg, end))
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create reader: %w", err)
+	}
+	defer r.Close()
+
+	fmajor, fminor, err := indexFuncs(r, t, idx.major, idx.minor)
+	if err != nil {
+		return nil, err
+	}
+
+	ents := make([]int64, 0, end-beg)
+	err = r.Read(func(RCtx) error {
+		ents = append(ents, idx.EntryWithIndex(fmajor(), fminor()))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not evaluate friend index on tree %q: %w", t.Name(), err)
+	}
+
+	err = r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not close reader: %w", err)
+	}
+
+	return ents, nil
+}
+
+// IndexOf returns the index attached to the provided tree, either read
+// from the ROOT file or built with BuildIndex.
+// IndexOf returns nil if the tree has no index.
+func IndexOf(t Tree) *TreeIndex {
+	tree := ttreeOf(t)
+	if tree == nil {
+		return nil
+	}
+	idx, _ := tree.treeIndex.(*TreeIndex)
+	return idx
+}
+
+// newTreeIndex creates a new index from the major and minor values of
+// each entry.
+func newTreeIndex(major, minor string, majors, minors []int64) *TreeIndex {
+	idx := &TreeIndex{
+		named:  *rbase.NewNamed("", ""),
+		major:  major,
+		minor:  minor,
+		majors: majors,
+		minors: minors,
+		index:  make([]int64, len(majors)),
+	}
+	for i := range idx.index {
+		idx.index[i] = int64(i)
+	}
+	sort.Stable(indexSorter{idx})
+	return idx
+}
+
+func (*TreeIndex) RVersion() int16 { return rvers.TreeIndex }
+func (*TreeIndex) Class() string   { return "TTreeIndex" }
+
+func (idx *TreeIndex) Name() string  { return idx.named.Name() }
+func (idx *TreeIndex) Title() string { return idx.named.Title() }
+
+// MajorName returns the expression of the major values of the index.
+func (idx *TreeIndex) MajorName() string { return idx.major }
+
+// MinorName returns the expression of the minor values of the index.
+func (idx *TreeIndex) MinorName() string { return idx.minor }
+
+// Len returns the number of indexed entries.
+func (idx *TreeIndex) Len() int { return len(idx.index) }
+
+// EntryWithIndex returns the entry number holding the provided major and
+// minor values.
+// If several entries hold these values, the first one is returned.
+// EntryWithIndex returns -1 if no entry holds these values.
+func (idx *TreeIndex) EntryWithIndex(major, minor int64) int64 {
+	i := sort.Search(len(idx.index), func(i int) bool {
+		if idx.majors[i] != major {
+			return idx.majors[i] > major
+		}
+		return idx.minors[i] >= minor
+	})
+	if i < len(idx.index) && idx.majors[i] == major && idx.minors[i] == minor {
+		return idx.index[i]
+	}
+	return -1
+}
+
+// MarshalROOT implements rbytes.Marshaler
+func (idx *TreeIndex) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(idx.Class(), idx.RVersion())
+	{
+		hdr := w.WriteHeader("TVirtualIndex", rvers.VirtualIndex)
+		w.WriteObject(&idx.named)
+		_, _ = w.SetHeader(hdr)
+	}
+	w.WriteString(idx.major)
+	w.WriteString(idx.minor)
+	w.WriteI64(int64(len(idx.index)))
+	w.WriteI8(1) // is-array
+	w.WriteArrayI64(idx.majors)
+	w.WriteI8(1) // is-array
+	w.WriteArrayI64(idx.minors)
+	w.WriteI8(1) // is-array
+	w.WriteArrayI64(idx.index)
+
+	return w.SetHeader(hdr)
+}
+
+// UnmarshalROOT implements rbytes.Unmarshaler
+func (idx *TreeIndex) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(idx.Class())
+	if hdr.Vers > rvers.TreeIndex {
+		panic(fmt.Errorf("rtree: invalid TTreeIndex version=%d > %d", hdr.Vers, rvers.TreeIndex))
+	}
+
+	{
+		hdr := r.ReadHeader("TVirtualIndex")
+		if hdr.Vers > rvers.VirtualIndex {
+			panic(fmt.Errorf("rtree: invalid TVirtualIndex version=%d > %d", hdr.Vers, rvers.VirtualIndex))
+		}
+		r.ReadObject(&idx.named)
+		r.CheckHeader(hdr)
+	}
+
+	idx.major = r.ReadString()
+	idx.minor = r.ReadString()
+	n := int(r.ReadI64())
+
+	idx.majors = rbytes.ResizeI64(idx.majors, n)
+	idx.minors = rbytes.ResizeI64(idx.minors, n)
+	idx.index = rbytes.ResizeI64(idx.index, n)
+
+	switch {
+	case hdr.Vers > 1:
+		_ = r.ReadI8() // is-array
+		r.ReadArrayI64(idx.majors)
+		_ = r.ReadI8() // is-array
+		r.ReadArrayI64(idx.minors)
+		_ = r.ReadI8() // is-array
+		r.ReadArrayI64(idx.index)
+	default:
+		// v1 combines major and minor values as major<<31 + minor.
+		const mask = 1<<31 - 1
+		r.ReadArrayI64(idx.majors)
+		r.ReadArrayI64(idx.index)
+		for i, v := range idx.majors {
+			idx.majors[i] = v >> 31
+			idx.minors[i] = v & mask
+		}
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+// windex collects the major and minor values of the entries written
+// to a tree.
+type windex struct {
+	major  string
+	minor  string
+	fmajor func() int64
+	fminor func() int64
+	majors []int64
+	minors []int64
+}
+
+func newWIndex(wvars []WriteVar, major, minor string) (*windex, error) {
+	value := func(name string) (func() int64, error) {
+		if name == "" {
+			return func() int64 { return 0 }, nil
+		}
+		for _, wvar := range wvars {
+			if wvar.Name != name {
+				continue
+			}
+			rv := reflect.ValueOf(wvar.Value)
+			if rv.Kind() != reflect.Ptr {
+				return nil, fmt.Errorf("rtree: index branch %q is not a pointer (type=%T)", name, wvar.Value)
+			}
+			rv = rv.Elem()
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return rv.Int, nil
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				return func() int64 { return int64(rv.Uint()) }, nil
+			case reflect.Float32, reflect.Float64:
+				return func() int64 { return int64(rv.Float()) }, nil
+			default:
+				return nil, fmt.Errorf("rtree: index branch %q does not hold a scalar number (type=%T)", name, wvar.Value)
+			}
+		}
+		return nil, fmt.Errorf("rtree: no index branch %q", name)
+	}
+
+	fmajor, err := value(major)
+	if err != nil {
+		return nil, err
+	}
+	fminor, err := value(minor)
+	if err != nil {
+		return nil, err
+	}
+
+	if minor == "" {
+		minor = "0"
+	}
+
+	return &windex{
+		major:  major,
+		minor:  minor,
+		fmajor: fmajor,
+		fminor: fminor,
+	}, nil
+}
+
+func (idx *windex) write() {
+	idx.majors = append(idx.majors, idx.fmajor())
+	idx.minors = append(idx.minors, idx.fminor())
+}
+
+func (idx *windex) build() *TreeIndex {
+	// newTreeIndex sorts its inputs in place: work on copies so the tree
+	// can still be written to (and indexed) after an autosave.
+	var (
+		majors = append([]int64(nil), idx.majors...)
+		minors = append([]int64(nil), idx.minors...)
+	)
+	return newTreeIndex(idx.major, idx.minor, majors, minors)
+}
+
+// indexSorter sorts the values of an index by major and minor values.
+type indexSorter struct {
+	idx *TreeIndex
+}
+
+func (s indexSorter) Len() int { return len(s.idx.index) }
+
+func (s indexSorter) Less(i, j int) bool {
+	idx := s.idx
+	if idx.majors[i] != idx.majors[j] {
+		return idx.majors[i] < idx.majors[j]
+	}
+	return idx.minors[i] < idx.minors[j]
+}
+
+func (s indexSorter) Swap(i, j int) {
+	idx := s.idx
+	idx.majors[i], idx.majors[j] = idx.majors[j], idx.majors[i]
+	idx.minors[i], idx.minors[j] = idx.minors[j], idx.minors[i]
+	idx.index[i], idx.index[j] = idx.index[j], idx.index[i]
+}
+
+// ttreeOf returns the ROOT file backed tree underlying the provided tree,
+// or nil.
+func ttreeOf(t Tree) *ttree {
+	switch t := t.(type) {
+	case *ttree:
+		return t
+	case *tntuple:
+		return &t.ttree
+	case *tntupleD:
+		return &t.ttree
+	case *wtree:
+		return &t.ttree
+	}
+	return nil
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &TreeIndex{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TTreeIndex", f)
+	}
+}
+
+var (
+	_ root.Object        = (*TreeIndex)(nil)
+	_ root.Named         = (*TreeIndex)(nil)
+	_ rbytes.Marshaler   = (*TreeIndex)(nil)
+	_ rbytes.Unmarshaler = (*TreeIndex)(nil)
+)
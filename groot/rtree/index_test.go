@@ -0,0 +1,212 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestTreeIndex(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 100
+	var (
+		runOf = func(i int) int32 { return int32(10 - i%10) }
+		evtOf = func(i int) int64 { return int64(nevts - i) }
+	)
+
+	for _, tc := range []struct {
+		name  string
+		wopts []WriteOption
+	}{
+		{name: "build"},
+		{name: "write", wopts: []WriteOption{WithIndex("run", "evt")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, tc.name+".root")
+			{
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				var (
+					run int32
+					evt int64
+					val float64
+				)
+				w, err := NewWriter(f, "tree", []WriteVar{
+					{Name: "run", Value: &run},
+					{Name: "evt", Value: &evt},
+					{Name: "val", Value: &val},
+				}, tc.wopts...)
+				if err != nil {
+					t.Fatalf("could not create writer: %+v", err)
+				}
+				defer w.Close()
+
+				for i := 0; i < nevts; i++ {
+					run = runOf(i)
+					evt = evtOf(i)
+					val = float64(i)
+					_, err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write entry %d: %+v", i, err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			tree := o.(Tree)
+
+			idx := IndexOf(tree)
+			switch {
+			case tc.wopts == nil:
+				if idx != nil {
+					t.Fatalf("unexpected tree index")
+				}
+				idx, err = BuildIndex(tree, "run", "evt")
+				if err != nil {
+					t.Fatalf("could not build index: %+v", err)
+				}
+				if IndexOf(tree) != idx {
+					t.Fatalf("index not attached to tree")
+				}
+			default:
+				if idx == nil {
+					t.Fatalf("missing tree index")
+				}
+			}
+
+			if got, want := idx.MajorName(), "run"; got != want {
+				t.Fatalf("invalid major name: got=%q, want=%q", got, want)
+			}
+			if got, want := idx.MinorName(), "evt"; got != want {
+				t.Fatalf("invalid minor name: got=%q, want=%q", got, want)
+			}
+			if got, want := idx.Len(), nevts; got != want {
+				t.Fatalf("invalid index length: got=%d, want=%d", got, want)
+			}
+
+			for i := 0; i < nevts; i++ {
+				got := idx.EntryWithIndex(int64(runOf(i)), evtOf(i))
+				if got != int64(i) {
+					t.Fatalf("invalid entry for (%d, %d): got=%d, want=%d", runOf(i), evtOf(i), got, i)
+				}
+			}
+
+			for _, v := range [][2]int64{{0, 0}, {1, 2}, {11, 90}, {10, 99}} {
+				if got := idx.EntryWithIndex(v[0], v[1]); got != -1 {
+					t.Fatalf("invalid entry for %v: got=%d, want=-1", v, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildIndexExpr(t *testing.T) {
+	f, err := riofs.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	idx, err := BuildIndex(tree, "one % 2", "")
+	if err != nil {
+		t.Fatalf("could not build index: %+v", err)
+	}
+
+	if got, want := idx.MinorName(), "0"; got != want {
+		t.Fatalf("invalid minor name: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		major int64
+		want  int64
+	}{
+		{0, 1},
+		{1, 0},
+		{2, -1},
+	} {
+		if got := idx.EntryWithIndex(tc.major, 0); got != tc.want {
+			t.Fatalf("invalid entry for major=%d: got=%d, want=%d", tc.major, got, tc.want)
+		}
+	}
+
+	_, err = BuildIndex(tree, "zero", "")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestWriterWithInvalidIndex(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	f, err := riofs.Create(filepath.Join(tmp, "invalid.root"))
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	var (
+		run int32
+		str string
+	)
+	wvars := []WriteVar{
+		{Name: "run", Value: &run},
+		{Name: "str", Value: &str},
+	}
+
+	for _, tc := range []struct {
+		major, minor string
+	}{
+		{"", "run"},
+		{"evt", ""},
+		{"run", "str"},
+	} {
+		_, err := NewWriter(f, "tree", wvars, WithIndex(tc.major, tc.minor))
+		if err == nil {
+			t.Fatalf("(%q, %q): expected an error", tc.major, tc.minor)
+		}
+	}
+}
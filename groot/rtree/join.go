@@ -15,6 +15,8 @@ type join struct {
 	name     string
 	title    string
 	trees    []Tree
+	aliases  []string     // aliases of friend trees, if any
+	indices  []*TreeIndex // indices of friend trees aligned by index, nil for the ones aligned by entry number
 	branches []Branch
 	leaves   []Leaf
 	bmap     map[string]Branch
@@ -94,6 +96,23 @@ func Join(trees ...Tree) (Tree, error) {
 	return tree, nil
 }
 
+// alias returns the friend alias of the i-th tree.
+func (t *join) alias(i int) string {
+	if t.aliases == nil {
+		return ""
+	}
+	return t.aliases[i]
+}
+
+// friendIndex returns the index aligning the i-th tree with the main tree,
+// or nil if the i-th tree is aligned by entry number.
+func (t *join) friendIndex(i int) *TreeIndex {
+	if t.indices == nil {
+		return nil
+	}
+	return t.indices[i]
+}
+
 // Class returns the ROOT class of the argument.
 func (*join) Class() string {
 	return "TJoin"
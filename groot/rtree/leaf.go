@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unsafe"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
@@ -39,14 +40,12 @@ func newLeaf(name string, shape []int, etype, offset int, hasrange, unsigned boo
 	)
 
 	title.WriteString(name)
-	switch {
-	case count != nil:
+	if count != nil {
 		fmt.Fprintf(title, "[%s]", count.Name())
-	default:
-		for _, dim := range shape {
-			nelems *= dim
-			fmt.Fprintf(title, "[%d]", dim)
-		}
+	}
+	for _, dim := range shape {
+		nelems *= dim
+		fmt.Fprintf(title, "[%d]", dim)
 	}
 	return tleaf{
 		named:    *rbase.NewNamed(name, title.String()),
@@ -641,7 +640,16 @@ func newLeafFromWVar(w *wtree, b Branch, v WriteVar, lvl int, cfg wopt) (Leaf, e
 
 	switch kind {
 	case reflect.Slice:
-		lc := b.Leaf(v.Count)
+		var lc Leaf
+		if v.Count != "" {
+			lc = b.Leaf(v.Count)
+			if lc == nil {
+				return nil, fmt.Errorf(
+					"could not find leaf count %q for slice (name=%q, type=%T): count branch must be declared before the slice",
+					v.Count, v.Name, v.Value,
+				)
+			}
+		}
 		switch lc {
 		case nil:
 			// write as vector<T>.
@@ -684,7 +692,27 @@ func newLeafFromWVar(w *wtree, b Branch, v WriteVar, lvl int, cfg wopt) (Leaf, e
 				)
 			}
 			count = lcc
-			kind = rt.Elem().Kind()
+			rt, shape = flattenArrayType(rt.Elem())
+			kind = rt.Kind()
+			if len(shape) > 0 {
+				// slice of arrays: alias the user slice as a flat slice of
+				// its elements before each write.
+				var (
+					src = rv
+					dst = reflect.New(reflect.SliceOf(rt))
+					sz  = int(src.Type().Elem().Size() / rt.Size())
+				)
+				v.Value = dst.Interface()
+				w.decays = append(w.decays, func() {
+					var (
+						raw = (*reflect.SliceHeader)(unsafe.Pointer(src.UnsafeAddr()))
+						hdr = (*reflect.SliceHeader)(unsafe.Pointer(dst.Pointer()))
+					)
+					hdr.Data = raw.Data
+					hdr.Len = raw.Len * sz
+					hdr.Cap = raw.Cap * sz
+				})
+			}
 		}
 
 	case reflect.Struct:
@@ -190,6 +190,9 @@ func (leaf *LeafO) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayBool((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -417,6 +420,9 @@ func (leaf *LeafB) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayI8((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -644,6 +650,9 @@ func (leaf *LeafS) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayI16((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -871,6 +880,9 @@ func (leaf *LeafI) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayI32((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -1098,6 +1110,9 @@ func (leaf *LeafL) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayI64((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -1296,6 +1311,9 @@ func (leaf *LeafF) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayF32((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -1494,6 +1512,9 @@ func (leaf *LeafD) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayF64((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -1703,6 +1724,9 @@ func (leaf *LeafF16) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayF16((*leaf.sli)[:end], leaf.elm)
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -1912,6 +1936,9 @@ func (leaf *LeafD32) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayD32((*leaf.sli)[:end], leaf.elm)
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -2114,6 +2141,9 @@ func (leaf *LeafC) writeToBuffer(w *rbytes.WBuffer) (int, error) {
 			n = max
 		}
 		end := leaf.tleaf.len * n
+		if sz := len(*leaf.sli); sz < end {
+			return 0, fmt.Errorf("rtree: leaf %q has %d elements, want %d from leaf count %q", leaf.Name(), sz, end, leaf.count.Name())
+		}
 		w.WriteArrayString((*leaf.sli)[:end])
 		nbytes += leaf.tleaf.etype * end
 	default:
@@ -0,0 +1,135 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"sort"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// DefaultLumiTreeName is the default name of the companion tree holding
+// luminosity blocks bookkeeping information.
+const DefaultLumiTreeName = "lumi"
+
+// LumiBlock describes the bookkeeping information of a luminosity block:
+// the number of events and the sum of weights (before any selection)
+// that were processed for a given (run, luminosity block) pair.
+type LumiBlock struct {
+	Run   int32   `groot:"run"`   // run number
+	Lumi  int32   `groot:"lumi"`  // luminosity block number
+	Nevts int64   `groot:"nevts"` // number of processed events
+	SumW  float64 `groot:"sumw"`  // sum of weights of processed events
+	SumW2 float64 `groot:"sumw2"` // sum of squared weights of processed events
+}
+
+// Lumi holds the aggregated bookkeeping information of a set of
+// luminosity blocks.
+type Lumi struct {
+	Nevts  int64       // total number of processed events
+	SumW   float64     // total sum of weights
+	SumW2  float64     // total sum of squared weights
+	Blocks []LumiBlock // luminosity blocks, sorted by (run, lumi)
+}
+
+// Runs returns the sorted list of runs contributing to the luminosity blocks.
+func (lumi Lumi) Runs() []int32 {
+	var runs []int32
+	for i, blk := range lumi.Blocks {
+		if i > 0 && lumi.Blocks[i-1].Run == blk.Run {
+			continue
+		}
+		runs = append(runs, blk.Run)
+	}
+	return runs
+}
+
+// Add adds the provided luminosity block to the aggregate.
+// Luminosity blocks with the same (run, lumi) pair are merged together.
+func (lumi *Lumi) Add(blk LumiBlock) {
+	lumi.Nevts += blk.Nevts
+	lumi.SumW += blk.SumW
+	lumi.SumW2 += blk.SumW2
+
+	i := sort.Search(len(lumi.Blocks), func(i int) bool {
+		b := lumi.Blocks[i]
+		return b.Run > blk.Run || (b.Run == blk.Run && b.Lumi >= blk.Lumi)
+	})
+	if i < len(lumi.Blocks) && lumi.Blocks[i].Run == blk.Run && lumi.Blocks[i].Lumi == blk.Lumi {
+		b := &lumi.Blocks[i]
+		b.Nevts += blk.Nevts
+		b.SumW += blk.SumW
+		b.SumW2 += blk.SumW2
+		return
+	}
+	lumi.Blocks = append(lumi.Blocks, LumiBlock{})
+	copy(lumi.Blocks[i+1:], lumi.Blocks[i:])
+	lumi.Blocks[i] = blk
+}
+
+// WriteLumi writes the provided luminosity blocks as a companion tree
+// named name under the provided directory.
+func WriteLumi(dir riofs.Directory, name string, blocks []LumiBlock, opts ...WriteOption) error {
+	var blk LumiBlock
+	w, err := NewWriter(dir, name, WriteVarsFromStruct(&blk), opts...)
+	if err != nil {
+		return fmt.Errorf("rtree: could not create lumi tree writer: %w", err)
+	}
+	defer w.Close()
+
+	for i := range blocks {
+		blk = blocks[i]
+		_, err = w.Write()
+		if err != nil {
+			return fmt.Errorf("rtree: could not write lumi block %d: %w", i, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("rtree: could not close lumi tree writer: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLumi reads and aggregates the luminosity blocks stored in the
+// provided companion tree.
+// The tree may be a chain of companion trees spanning multiple files.
+func ReadLumi(t Tree) (Lumi, error) {
+	var (
+		lumi Lumi
+		blk  LumiBlock
+	)
+
+	r, err := NewReader(t, ReadVarsFromStruct(&blk))
+	if err != nil {
+		return lumi, fmt.Errorf("rtree: could not create lumi tree reader: %w", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(RCtx) error {
+		lumi.Add(blk)
+		return nil
+	})
+	if err != nil {
+		return lumi, fmt.Errorf("rtree: could not read lumi blocks: %w", err)
+	}
+
+	return lumi, nil
+}
+
+// LumiOf reads and aggregates the luminosity blocks stored in the name
+// companion trees of the provided files.
+func LumiOf(name string, files ...string) (Lumi, error) {
+	t, closer, err := ChainOf(name, files...)
+	if err != nil {
+		return Lumi{}, fmt.Errorf("rtree: could not open lumi trees: %w", err)
+	}
+	defer closer()
+
+	return ReadLumi(t)
+}
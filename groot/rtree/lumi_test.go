@@ -0,0 +1,82 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestLumi(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files := []struct {
+		name   string
+		blocks []rtree.LumiBlock
+	}{
+		{
+			name: filepath.Join(tmp, "lumi-1.root"),
+			blocks: []rtree.LumiBlock{
+				{Run: 2, Lumi: 1, Nevts: 10, SumW: 5, SumW2: 3},
+				{Run: 1, Lumi: 2, Nevts: 20, SumW: 10, SumW2: 6},
+			},
+		},
+		{
+			name: filepath.Join(tmp, "lumi-2.root"),
+			blocks: []rtree.LumiBlock{
+				{Run: 1, Lumi: 1, Nevts: 1, SumW: 1, SumW2: 1},
+				{Run: 2, Lumi: 1, Nevts: 10, SumW: 5, SumW2: 3},
+			},
+		},
+	}
+
+	for _, file := range files {
+		f, err := riofs.Create(file.name)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		err = rtree.WriteLumi(f, rtree.DefaultLumiTreeName, file.blocks)
+		if err != nil {
+			t.Fatalf("could not write lumi blocks: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	lumi, err := rtree.LumiOf(rtree.DefaultLumiTreeName, files[0].name, files[1].name)
+	if err != nil {
+		t.Fatalf("could not read lumi blocks: %+v", err)
+	}
+
+	want := rtree.Lumi{
+		Nevts: 41,
+		SumW:  21,
+		SumW2: 13,
+		Blocks: []rtree.LumiBlock{
+			{Run: 1, Lumi: 1, Nevts: 1, SumW: 1, SumW2: 1},
+			{Run: 1, Lumi: 2, Nevts: 20, SumW: 10, SumW2: 6},
+			{Run: 2, Lumi: 1, Nevts: 20, SumW: 10, SumW2: 6},
+		},
+	}
+
+	if !reflect.DeepEqual(lumi, want) {
+		t.Fatalf("invalid lumi:\ngot= %+v\nwant=%+v", lumi, want)
+	}
+
+	if got, want := lumi.Runs(), []int32{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid runs: got=%v, want=%v", got, want)
+	}
+}
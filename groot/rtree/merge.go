@@ -0,0 +1,302 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// Merge appends the entries of the provided src trees to the dst tree.
+// It returns the number of entries appended and the first error
+// encountered while merging, if any.
+//
+// When a src tree has the same branches than dst, with the same leaves
+// and the same compression settings, its baskets are copied as-is,
+// without being decompressed and re-compressed.
+// Otherwise, the entries of the src tree are read and re-encoded into
+// dst: the src tree must then provide all the branches of dst, with
+// compatible types.
+func Merge(dst Writer, srcs ...Tree) (int64, error) {
+	w, ok := dst.(*wtree)
+	if !ok {
+		return 0, fmt.Errorf("rtree: invalid dst tree type %T", dst)
+	}
+
+	var tot int64
+	for i, src := range srcs {
+		n, err := w.merge(src)
+		tot += n
+		if err != nil {
+			return tot, fmt.Errorf("rtree: could not merge tree #%d (%q): %w", i, src.Name(), err)
+		}
+	}
+	return tot, nil
+}
+
+// merge appends the entries of src to the tree, copying the baskets of
+// src when possible.
+func (w *wtree) merge(src Tree) (int64, error) {
+	if t := w.fastMergeable(src); t != nil {
+		return w.fastMerge(t)
+	}
+
+	for _, wvar := range w.wvars {
+		if src.Branch(wvar.Name) == nil {
+			return 0, fmt.Errorf("rtree: incompatible schemas: tree %q has no branch %q", src.Name(), wvar.Name)
+		}
+	}
+
+	r, err := NewReader(src, nil)
+	if err != nil {
+		return 0, fmt.Errorf("rtree: could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	beg := w.Entries()
+	_, err = Copy(w, r)
+	return w.Entries() - beg, err
+}
+
+// fastMergeable returns the underlying tree of src if its baskets can be
+// copied as-is into the tree, nil otherwise.
+func (w *wtree) fastMergeable(src Tree) *ttree {
+	if w.closed || w.index != nil {
+		return nil
+	}
+
+	t := ttreeOf(src)
+	if t == nil || t.f == nil {
+		return nil
+	}
+
+	// basket headers switch to 64b offsets in big files, which would
+	// shift the entry offsets held in the compressed payloads.
+	if t.f.IsBigFile() || w.ttree.f.IsBigFile() {
+		return nil
+	}
+
+	if len(t.branches) != len(w.ttree.branches) {
+		return nil
+	}
+	for i, b := range w.ttree.branches {
+		if !fastMergeableBranch(b, t.branches[i], t.entries) {
+			return nil
+		}
+	}
+	return t
+}
+
+// fastMergeableBranch returns whether the baskets of the src branch
+// can be copied as-is into the dst branch.
+func fastMergeableBranch(dst, src Branch, entries int64) bool {
+	var (
+		bdst = tbranchOf(dst)
+		bsrc = tbranchOf(src)
+	)
+	if bdst == nil || bsrc == nil {
+		return false
+	}
+
+	if dst.Name() != src.Name() || dst.Class() != src.Class() {
+		return false
+	}
+	if bdst.compress != bsrc.compress || bsrc.fname != "" {
+		return false
+	}
+
+	// all the entries of src must be held by baskets on file.
+	if bsrc.entries != entries || len(bsrc.basketEntry) != bsrc.writeBasket+1 ||
+		bsrc.basketEntry[bsrc.writeBasket] != entries {
+		return false
+	}
+
+	if edst, ok := dst.(*tbranchElement); ok {
+		esrc := src.(*tbranchElement)
+		if edst.class != esrc.class || edst.parent != esrc.parent ||
+			edst.clones != esrc.clones || edst.chksum != esrc.chksum ||
+			edst.clsver != esrc.clsver || edst.id != esrc.id ||
+			edst.btype != esrc.btype || edst.stype != esrc.stype ||
+			edst.stltyp != esrc.stltyp {
+			return false
+		}
+	}
+
+	if len(bdst.leaves) != len(bsrc.leaves) {
+		return false
+	}
+	for i, ldst := range bdst.leaves {
+		lsrc := bsrc.leaves[i]
+		if ldst.Name() != lsrc.Name() || ldst.Title() != lsrc.Title() ||
+			ldst.Class() != lsrc.Class() || ldst.IsUnsigned() != lsrc.IsUnsigned() {
+			return false
+		}
+	}
+
+	if len(bdst.branches) != len(bsrc.branches) {
+		return false
+	}
+	for i, sub := range bdst.branches {
+		if !fastMergeableBranch(sub, bsrc.branches[i], entries) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fastMerge copies the baskets of src at the end of the tree.
+func (w *wtree) fastMerge(src *ttree) (int64, error) {
+	// commit the entries already written, so the copied baskets
+	// start on a basket boundary.
+	for _, b := range w.ttree.branches {
+		err := b.flushBaskets()
+		if err != nil {
+			return 0, fmt.Errorf("rtree: could not flush baskets of branch %q: %w", b.Name(), err)
+		}
+	}
+
+	for i, b := range w.ttree.branches {
+		err := w.copyBaskets(b, src.branches[i])
+		if err != nil {
+			return 0, fmt.Errorf("rtree: could not copy baskets of branch %q: %w", b.Name(), err)
+		}
+	}
+
+	w.ttree.entries += src.entries
+	return src.entries, nil
+}
+
+// copyBaskets copies the baskets of the src branch and of its
+// sub-branches at the end of the dst branch.
+func (w *wtree) copyBaskets(bdst, bsrc Branch) error {
+	var (
+		dst = tbranchOf(bdst)
+		src = tbranchOf(bsrc)
+	)
+	for i, sub := range dst.branches {
+		err := w.copyBaskets(sub, src.branches[i])
+		if err != nil {
+			return fmt.Errorf("could not copy baskets of subbranch[%d]=%q: %w", i, sub.Name(), err)
+		}
+	}
+
+	var (
+		f   = w.ttree.f
+		beg = dst.entryNumber
+	)
+	for i := 0; i < src.writeBasket; i++ {
+		key, err := copyBasket(f, src.tree.f, src.basketSeek[i], src.basketBytes[i])
+		if err != nil {
+			return fmt.Errorf("could not copy basket[%d]: %w", i, err)
+		}
+		dst.totBytes += int64(key.KeyLen() + key.ObjLen())
+		dst.zipBytes += int64(key.Nbytes())
+		w.ttree.totBytes += int64(key.KeyLen() + key.ObjLen())
+		w.ttree.zipBytes += int64(key.Nbytes())
+
+		dst.basketBytes = append(dst.basketBytes, key.Nbytes())
+		dst.basketEntry = append(dst.basketEntry, beg+src.basketEntry[i+1])
+		dst.basketSeek = append(dst.basketSeek, key.SeekKey())
+		dst.writeBasket++
+	}
+	dst.entries += src.entries
+	dst.entryNumber += src.entries
+
+	for i, leaf := range dst.leaves {
+		mergeLeafMax(leaf, src.leaves[i])
+	}
+	if edst, ok := bdst.(*tbranchElement); ok {
+		if esrc := bsrc.(*tbranchElement); esrc.max > edst.max {
+			edst.max = esrc.max
+		}
+	}
+
+	// the current (empty) basket should follow the copied ones.
+	if dst.ctx.bk != nil && dst.ctx.bk.nevbuf == 0 {
+		dst.createNewBasket()
+	}
+
+	return nil
+}
+
+// copyBasket copies the basket located at seek in the src file at the
+// end of the dst file, and returns the key of the new basket.
+func copyBasket(dst, src *riofs.File, seek int64, nbytes int32) (riofs.Key, error) {
+	raw := make([]byte, nbytes)
+	_, err := src.ReadAt(raw, seek)
+	if err != nil {
+		return riofs.Key{}, fmt.Errorf("could not read basket: %w", err)
+	}
+
+	var bkt Basket
+	err = bkt.UnmarshalROOT(rbytes.NewRBuffer(raw, nil, 0, nil))
+	if err != nil {
+		return riofs.Key{}, fmt.Errorf("could not unmarshal basket header: %w", err)
+	}
+
+	keylen := bkt.key.KeyLen()
+	bkt.key, err = riofs.NewKeyFromBasketInternal(&bkt.key, raw[keylen:], dst)
+	if err != nil {
+		return riofs.Key{}, fmt.Errorf("could not create basket key: %w", err)
+	}
+	bkt.header = true
+
+	wbuf := rbytes.NewWBuffer(make([]byte, keylen), nil, 0, dst)
+	_, err = bkt.MarshalROOT(wbuf)
+	if err != nil {
+		return bkt.key, fmt.Errorf("could not marshal basket header: %w", err)
+	}
+	if n := int32(len(wbuf.Bytes())); n != keylen {
+		return bkt.key, fmt.Errorf("invalid basket header length (got=%d, want=%d)", n, keylen)
+	}
+
+	_, err = dst.WriteAt(wbuf.Bytes(), bkt.key.SeekKey())
+	if err != nil {
+		return bkt.key, fmt.Errorf("could not write basket header: %w", err)
+	}
+	_, err = dst.WriteAt(raw[keylen:], bkt.key.SeekKey()+int64(keylen))
+	if err != nil {
+		return bkt.key, fmt.Errorf("could not write basket payload: %w", err)
+	}
+
+	return bkt.key, nil
+}
+
+// mergeLeafMax updates the maximum value of the dst leaf with the one
+// of the src leaf.
+// The maximum value of a leaf-count is used to size the arrays it counts.
+func mergeLeafMax(dst, src Leaf) {
+	switch dst := dst.(type) {
+	case *LeafB:
+		if v := src.(*LeafB).max; v > dst.max {
+			dst.max = v
+		}
+	case *LeafS:
+		if v := src.(*LeafS).max; v > dst.max {
+			dst.max = v
+		}
+	case *LeafI:
+		if v := src.(*LeafI).max; v > dst.max {
+			dst.max = v
+		}
+	case *LeafL:
+		if v := src.(*LeafL).max; v > dst.max {
+			dst.max = v
+		}
+	}
+}
+
+func tbranchOf(b Branch) *tbranch {
+	switch b := b.(type) {
+	case *tbranch:
+		return b
+	case *tbranchElement:
+		return &b.tbranch
+	}
+	return nil
+}
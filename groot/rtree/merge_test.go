@@ -0,0 +1,234 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestMerge(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type event struct {
+		I32 int32
+		F64 float64
+		N   int32
+		Sli []float64
+		Arr [3]int16
+		Str string
+	}
+
+	gen := func(i int) event {
+		evt := event{
+			I32: int32(i),
+			F64: float64(i),
+			N:   int32(i % 5),
+			Arr: [3]int16{int16(i), int16(-i), int16(2 * i)},
+			Str: fmt.Sprintf("evt-%03d", i),
+		}
+		evt.Sli = make([]float64, evt.N)
+		for j := range evt.Sli {
+			evt.Sli[j] = float64(i*10 + j)
+		}
+		return evt
+	}
+
+	wvarsOf := func(evt *event) []WriteVar {
+		return []WriteVar{
+			{Name: "i32", Value: &evt.I32},
+			{Name: "f64", Value: &evt.F64},
+			{Name: "n", Value: &evt.N},
+			{Name: "sli", Value: &evt.Sli, Count: "n"},
+			{Name: "arr", Value: &evt.Arr},
+			{Name: "str", Value: &evt.Str},
+		}
+	}
+
+	write := func(w Writer, evt *event, beg, end int) {
+		t.Helper()
+		for i := beg; i < end; i++ {
+			*evt = gen(i)
+			_, err := w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+	}
+
+	// create the input files, holding entries [5,15) and [15,40).
+	var srcs []string
+	for i, rng := range [][2]int{{5, 15}, {15, 40}} {
+		fname := filepath.Join(tmp, fmt.Sprintf("src-%d.root", i))
+		srcs = append(srcs, fname)
+
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt event
+		w, err := NewWriter(f, "tree", wvarsOf(&evt), WithBasketSize(256))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		write(w, &evt, rng[0], rng[1])
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		wopts []WriteOption
+		fast  bool
+	}{
+		{name: "fast", fast: true},
+		{name: "reencode", wopts: []WriteOption{WithLZ4(1)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, tc.name+".root")
+			{
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				var evt event
+				w, err := NewWriter(f, "tree", wvarsOf(&evt), tc.wopts...)
+				if err != nil {
+					t.Fatalf("could not create writer: %+v", err)
+				}
+				defer w.Close()
+
+				write(w, &evt, 0, 5)
+
+				var trees []Tree
+				for _, src := range srcs {
+					f, err := riofs.Open(src)
+					if err != nil {
+						t.Fatalf("could not open input file: %+v", err)
+					}
+					defer f.Close()
+
+					tree, err := riofs.Get[Tree](f, "tree")
+					if err != nil {
+						t.Fatalf("could not retrieve input tree: %+v", err)
+					}
+
+					if got, want := w.(*wtree).fastMergeable(tree) != nil, tc.fast; got != want {
+						t.Fatalf("invalid fast-merge: got=%v, want=%v", got, want)
+					}
+					trees = append(trees, tree)
+				}
+
+				n, err := Merge(w, trees...)
+				if err != nil {
+					t.Fatalf("could not merge trees: %+v", err)
+				}
+				if got, want := n, int64(35); got != want {
+					t.Fatalf("invalid number of merged entries: got=%d, want=%d", got, want)
+				}
+
+				write(w, &evt, 40, 43)
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close writer: %+v", err)
+				}
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			tree, err := riofs.Get[Tree](f, "tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			if got, want := tree.Entries(), int64(43); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var evt event
+			rvars := make([]ReadVar, 0, 6)
+			for _, wvar := range wvarsOf(&evt) {
+				rvars = append(rvars, ReadVar{Name: wvar.Name, Value: wvar.Value})
+			}
+			r, err := NewReader(tree, rvars)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx RCtx) error {
+				if got, want := evt, gen(int(ctx.Entry)); !reflect.DeepEqual(got, want) {
+					return fmt.Errorf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+
+	t.Run("incompatible", func(t *testing.T) {
+		f, err := riofs.Create(filepath.Join(tmp, "incompatible.root"))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var (
+			evt   event
+			extra float32
+		)
+		w, err := NewWriter(f, "tree", append(wvarsOf(&evt), WriteVar{Name: "extra", Value: &extra}))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		src, err := riofs.Open(srcs[0])
+		if err != nil {
+			t.Fatalf("could not open input file: %+v", err)
+		}
+		defer src.Close()
+
+		tree, err := riofs.Get[Tree](src, "tree")
+		if err != nil {
+			t.Fatalf("could not retrieve input tree: %+v", err)
+		}
+
+		_, err = Merge(w, tree)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
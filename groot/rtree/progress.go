@@ -0,0 +1,145 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Progress describes the progress of a tree reader.
+type Progress struct {
+	Entries    int64         // number of entries processed
+	Total      int64         // number of entries to process
+	Baskets    int64         // number of baskets read
+	Bytes      int64         // number of (compressed) bytes of baskets read
+	Decompress time.Duration // time spent reading and decompressing baskets
+	Elapsed    time.Duration // time elapsed since the beginning of the read
+}
+
+// WithProgress configures a reader to call f with the progress of the
+// read every n processed entries, and once at the end of the read.
+// If n <= 0, f is only called at the end of the read.
+//
+// f is never called concurrently, but may be called from a different
+// goroutine than the one that started the read.
+func WithProgress(n int64, f func(p Progress)) ReadOption {
+	return func(r *Reader) error {
+		r.stats.every = n
+		r.stats.fct = f
+		return nil
+	}
+}
+
+// withStats configures a reader to report its progress into the
+// provided (shared) statistics.
+func withStats(st *rstats) ReadOption {
+	return func(r *Reader) error {
+		r.stats = st
+		r.shared = true
+		return nil
+	}
+}
+
+// Progress returns the current progress of the reader.
+//
+// Progress can be called concurrently with Read, e.g. to publish the
+// progress of a reader as a metric:
+//
+//  expvar.Publish("rtree-reader", expvar.Func(func() interface{} {
+//      return r.Progress()
+//  }))
+func (r *Reader) Progress() Progress {
+	return r.stats.progress()
+}
+
+// nentries returns the number of entries a reader will process.
+func nentries(beg, end int64, elist *EntryList) int64 {
+	if elist == nil {
+		return end - beg
+	}
+	var (
+		entries = elist.Entries()
+		ibeg    = sort.Search(len(entries), func(i int) bool { return entries[i] >= beg })
+		iend    = sort.Search(len(entries), func(i int) bool { return entries[i] >= end })
+	)
+	return int64(iend - ibeg)
+}
+
+// rstats collects the statistics of a tree reader.
+type rstats struct {
+	entries int64 // number of processed entries
+	baskets int64 // number of read baskets
+	nbytes  int64 // number of read bytes
+	unzip   int64 // time spent reading and decompressing baskets, in ns
+	total   int64 // number of entries to process
+	start   int64 // start time of the read, in ns since Unix epoch
+
+	every int64          // number of entries between two reports
+	fct   func(Progress) // progress report function
+	mu    sync.Mutex     // serializes calls to fct
+}
+
+func (st *rstats) reset(total int64) {
+	atomic.StoreInt64(&st.entries, 0)
+	atomic.StoreInt64(&st.baskets, 0)
+	atomic.StoreInt64(&st.nbytes, 0)
+	atomic.StoreInt64(&st.unzip, 0)
+	atomic.StoreInt64(&st.total, total)
+	atomic.StoreInt64(&st.start, time.Now().UnixNano())
+}
+
+func (st *rstats) progress() Progress {
+	if st == nil {
+		return Progress{}
+	}
+	var elapsed time.Duration
+	if start := atomic.LoadInt64(&st.start); start != 0 {
+		elapsed = time.Since(time.Unix(0, start))
+	}
+	return Progress{
+		Entries:    atomic.LoadInt64(&st.entries),
+		Total:      atomic.LoadInt64(&st.total),
+		Baskets:    atomic.LoadInt64(&st.baskets),
+		Bytes:      atomic.LoadInt64(&st.nbytes),
+		Decompress: time.Duration(atomic.LoadInt64(&st.unzip)),
+		Elapsed:    elapsed,
+	}
+}
+
+// entry records the processing of an entry.
+func (st *rstats) entry() {
+	n := atomic.AddInt64(&st.entries, 1)
+	if st.fct != nil && st.every > 0 && n%st.every == 0 {
+		st.report()
+	}
+}
+
+// basket records the reading of a basket of nbytes bytes.
+func (st *rstats) basket(nbytes int64, dt time.Duration) {
+	if st == nil {
+		return
+	}
+	atomic.AddInt64(&st.baskets, 1)
+	atomic.AddInt64(&st.nbytes, nbytes)
+	atomic.AddInt64(&st.unzip, int64(dt))
+}
+
+func (st *rstats) report() {
+	if st.fct == nil {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.fct(st.progress())
+}
+
+// Progress returns the current progress of the reader.
+// Progress can be called concurrently with Read and ReadOrdered.
+func (r *ConcurrentReader) Progress() Progress {
+	return r.stats.progress()
+}
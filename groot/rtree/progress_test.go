@@ -0,0 +1,154 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestReaderWithProgress(t *testing.T) {
+	f, err := riofs.Open("../testdata/x-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	elist := NewEntryList("elist", "")
+	for _, i := range []int64{1, 3, 4, 8, 9} {
+		elist.Enter(i)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		opts  []ReadOption
+		total int64
+	}{
+		{name: "all", total: 10},
+		{name: "range", opts: []ReadOption{WithRange(2, 8)}, total: 6},
+		{name: "elist", opts: []ReadOption{WithRange(2, 9), WithEntryList(elist)}, total: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var reports []Progress
+			opts := append([]ReadOption{
+				WithProgress(2, func(p Progress) {
+					reports = append(reports, p)
+				}),
+			}, tc.opts...)
+
+			var (
+				i32 int32
+				sli []float64
+			)
+			r, err := NewReader(tree, []ReadVar{
+				{Name: "I32", Value: &i32},
+				{Name: "SliF64", Value: &sli},
+			}, opts...)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			if got, want := r.Progress(), (Progress{}); got != want {
+				t.Fatalf("invalid initial progress: got=%+v, want=%+v", got, want)
+			}
+
+			for iter := 0; iter < 2; iter++ {
+				reports = reports[:0]
+				err = r.Read(func(ctx RCtx) error { return nil })
+				if err != nil {
+					t.Fatalf("could not read tree: %+v", err)
+				}
+
+				if got, want := len(reports), int(tc.total/2)+1; got != want {
+					t.Fatalf("invalid number of reports: got=%d, want=%d", got, want)
+				}
+				for i, p := range reports[:len(reports)-1] {
+					if got, want := p.Entries, int64(2*(i+1)); got != want {
+						t.Fatalf("invalid number of entries in report %d: got=%d, want=%d", i, got, want)
+					}
+				}
+
+				p := r.Progress()
+				if got, want := p, reports[len(reports)-1]; got.Entries != want.Entries || got.Baskets != want.Baskets {
+					t.Fatalf("invalid final progress: got=%+v, want=%+v", got, want)
+				}
+				if got, want := p.Entries, tc.total; got != want {
+					t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+				}
+				if got, want := p.Total, tc.total; got != want {
+					t.Fatalf("invalid total number of entries: got=%d, want=%d", got, want)
+				}
+				// one basket for each of I32, SliF64 and its count N.
+				if got, want := p.Baskets, int64(3); got != want {
+					t.Fatalf("invalid number of baskets: got=%d, want=%d", got, want)
+				}
+				if p.Bytes <= 0 {
+					t.Fatalf("invalid number of bytes: got=%d", p.Bytes)
+				}
+				if p.Decompress <= 0 || p.Elapsed <= 0 {
+					t.Fatalf("invalid durations: %+v", p)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrentReaderWithProgress(t *testing.T) {
+	f, err := riofs.Open("../testdata/x-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	var (
+		nreports int64
+		last     Progress
+	)
+	r, err := NewConcurrentReader(tree, []ReadVar{{Name: "I64", Value: new(int64)}}, 4,
+		WithProgress(1, func(p Progress) {
+			atomic.AddInt64(&nreports, 1)
+			last = p
+		}),
+	)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	err = r.Read(func(ctx RCtx, rvars []ReadVar) error { return nil })
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	if got, want := nreports, int64(11); got != want {
+		t.Fatalf("invalid number of reports: got=%d, want=%d", got, want)
+	}
+	if got, want := last.Entries, int64(10); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	if got, want := r.Progress().Total, int64(10); got != want {
+		t.Fatalf("invalid total number of entries: got=%d, want=%d", got, want)
+	}
+
+	err = r.ReadOrdered(func(ctx RCtx) error { return nil })
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+	if got, want := r.Progress().Entries, int64(10); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+}
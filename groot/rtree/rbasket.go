@@ -6,6 +6,7 @@ package rtree
 
 import (
 	"fmt"
+	"time"
 
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/riofs"
@@ -16,6 +17,8 @@ type rbasket struct {
 	span rspan  // basket entry span
 	bk   Basket // current basket
 	buf  []byte
+	mem  int64         // memory reserved from the read-ahead budget
+	dt   time.Duration // time spent reading and decompressing the basket
 }
 
 func (rbk *rbasket) reset() {
@@ -88,7 +88,7 @@ func TestReadAheadBasket(t *testing.T) {
 				end  = tree.Entries()
 			)
 
-			ra := newBkReader(b, tc.conc, beg, end)
+			ra := newBkReader(b, rahead{n: tc.conc}, beg, end)
 			defer ra.close()
 
 			var got []rspan
@@ -4,28 +4,71 @@
 
 package rtree
 
+import (
+	"fmt"
+	"io"
+)
+
 type rbranch struct {
 	b      Branch
 	rb     *bkreader
 	cur    *rbasket
 	leaves []rleaf
+
+	cr  *corruptions // corrupted baskets (nil to abort on corrupted baskets)
+	bad bool         // whether the current basket is corrupted
 }
 
-func newRBranch(b Branch, n int, beg, end int64, leaves []rleaf, rctx rleafCtx) rbranch {
+func newRBranch(b Branch, rab rahead, beg, end int64, leaves []rleaf, rctx rleafCtx) rbranch {
 	rb := rbranch{
 		b:      b,
-		rb:     newBkReader(b, n, beg, end),
+		rb:     newBkReader(b, rab, beg, end),
 		leaves: leaves,
+		cr:     rab.cr,
 	}
 	return rb
 }
 
 func (rb *rbranch) start() error {
+	return rb.next()
+}
+
+// next loads the next basket of the branch.
+func (rb *rbranch) next() error {
 	var err error
 	rb.cur, err = rb.rb.read()
+	rb.bad = false
+	if err != nil && err != io.EOF && rb.cr != nil && rb.cur != nil {
+		rb.corrupted(rb.cur.span.beg, err)
+		return nil
+	}
 	return err
 }
 
+// corrupted marks the entries of the current basket, starting at beg,
+// as corrupted.
+func (rb *rbranch) corrupted(beg int64, err error) {
+	rb.bad = true
+
+	var (
+		tree = rb.b.getTree()
+		file string
+	)
+	if tree.f != nil {
+		file = tree.f.Name()
+	}
+	rb.cr.add(Corruption{
+		File:   file,
+		Tree:   tree.Name(),
+		Branch: rb.b.Name(),
+		Basket: rb.cur.id,
+		Seek:   rb.cur.span.pos,
+		Beg:    beg,
+		End:    rb.cur.span.end,
+		Err:    err,
+	})
+}
+
 func (rb *rbranch) stop() error {
 	if rb.cur == nil {
 		return nil
@@ -36,19 +79,55 @@ func (rb *rbranch) stop() error {
 
 func (rb *rbranch) reset() {
 	rb.rb.close()
-	rb.rb = newBkReader(rb.b, rb.rb.n, rb.rb.beg, rb.rb.end)
+	rb.rb = newBkReader(rb.b, rb.rb.rab, rb.rb.beg, rb.rb.end)
+}
+
+// seek repositions the branch reader on the basket holding the i-th entry.
+// seek is used when entries are not read in increasing order.
+func (rb *rbranch) seek(i int64) error {
+	rb.rb.close()
+	rb.rb = newBkReader(rb.b, rb.rb.rab, i, rb.rb.end)
+	return rb.next()
 }
 
 func (rb *rbranch) read(i int64) error {
-	var err error
-	if i >= rb.cur.span.end {
-		rb.cur, err = rb.rb.read()
+	if i < rb.cur.span.beg {
+		err := rb.seek(i)
 		if err != nil {
 			return err
 		}
 	}
 
-	j := i - rb.cur.span.beg
+	for i >= rb.cur.span.end {
+		err := rb.next()
+		if err != nil {
+			return err
+		}
+	}
+
+	if rb.bad {
+		return errCorrupted
+	}
+
+	err := rb.load(i - rb.cur.span.beg)
+	if err != nil && rb.cr != nil {
+		rb.corrupted(i, err)
+		return errCorrupted
+	}
+	return err
+}
+
+// load loads the j-th entry of the current basket into the leaves.
+func (rb *rbranch) load(j int64) (err error) {
+	if rb.cr != nil {
+		// corrupted data may trigger out-of-bounds accesses.
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("rtree: could not decode entry %d of basket %d: %v", j, rb.cur.id, e)
+			}
+		}()
+	}
+
 	switch len(rb.leaves) {
 	case 1:
 		err = rb.cur.loadRLeaf(j, rb.leaves[0])
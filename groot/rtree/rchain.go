@@ -11,26 +11,28 @@ import (
 type rchain struct {
 	ch *chain
 
-	rvs  []ReadVar
-	nrab int
-	beg  int64
-	end  int64
+	rvs []ReadVar
+	rab rahead
+	beg int64
+	end int64
 
 	ibeg int // first tree to process
 	iend int // last-1 tree to process
+
+	ents []int64 // entries to read (nil to read all entries)
 }
 
 var (
 	_ reader = (*rchain)(nil)
 )
 
-func newRChain(ch *chain, rvars []ReadVar, n int, beg, end int64) *rchain {
+func newRChain(ch *chain, rvars []ReadVar, rab rahead, beg, end int64) *rchain {
 	r := &rchain{
-		ch:   ch,
-		rvs:  rvars,
-		nrab: n,
-		beg:  beg,
-		end:  end,
+		ch:  ch,
+		rvs: rvars,
+		rab: rab,
+		beg: beg,
+		end: end,
 	}
 
 	tbeg, tend := r.findTrees(beg, end)
@@ -54,12 +56,28 @@ func (r *rchain) Close() error {
 
 func (r *rchain) rvars() []ReadVar { return r.rvs }
 
+func (r *rchain) selectEntries(entries []int64) { r.ents = entries }
+
+func (r *rchain) setRange(beg, end int64) {
+	tbeg, tend := r.findTrees(beg, end)
+	if tbeg < 0 || tend < 0 {
+		panic(fmt.Errorf(
+			"rtree: could not find matching trees in chain for [%d, %d) within [%d, %d)",
+			beg, end, 0, r.ch.Entries(),
+		))
+	}
+	r.beg = beg
+	r.end = end
+	r.ibeg = tbeg
+	r.iend = tend
+}
+
 func (r *rchain) loadRVars() {
 	if len(r.ch.trees) == 0 {
 		return
 	}
 
-	rr := newReader(r.ch.trees[0], r.rvs, r.nrab, 0, 1)
+	rr := newReader(r.ch.trees[0], r.rvs, r.rab, 0, 1)
 	defer rr.Close()
 	r.rvs = rr.rvars()
 }
@@ -119,7 +137,21 @@ func (r *rchain) findTrees(beg, end int64) (int, int) {
 }
 
 func (r *rchain) runTree(itree int, off, beg, end int64, f func(RCtx) error) error {
-	rr := newReader(r.ch.trees[itree], r.rvs, r.nrab, beg, end)
+	rr := newReader(r.ch.trees[itree], r.rvs, r.rab, beg, end)
+	if r.ents != nil {
+		// convert chain entries into tree entries.
+		var (
+			eoff = r.ch.offs[itree]
+			ents = make([]int64, 0, len(r.ents))
+		)
+		for _, entry := range r.ents {
+			if entry < eoff+beg || eoff+end <= entry {
+				continue
+			}
+			ents = append(ents, entry-eoff)
+		}
+		rr.selectEntries(ents)
+	}
 	return rr.run(off, beg, end, f)
 }
 
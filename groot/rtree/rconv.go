@@ -0,0 +1,94 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rphys"
+)
+
+// rleafConv reads data from a leaf into a value of the leaf's native
+// type and converts it into the user provided value.
+type rleafConv struct {
+	rleaf
+	conv func()
+}
+
+var (
+	_ rleaf = (*rleafConv)(nil)
+)
+
+func (leaf *rleafConv) readFromBuffer(r *rbytes.RBuffer) error {
+	err := leaf.rleaf.readFromBuffer(r)
+	if err != nil {
+		return err
+	}
+	leaf.conv()
+	return nil
+}
+
+var (
+	typeLorentzVector    = reflect.TypeOf(rphys.LorentzVector{})
+	typeLorentzVectorSli = reflect.TypeOf([]rphys.LorentzVector(nil))
+)
+
+// newRLeafConv returns a converting rleaf when the user provided value
+// is a Go type that can be filled from the leaf's native type:
+//  - TLorentzVector into fmom.PxPyPzE,
+//  - std::vector<TLorentzVector> into []fmom.PxPyPzE.
+// newRLeafConv returns nil otherwise.
+func newRLeafConv(leaf Leaf, rvar ReadVar, rctx rleafCtx) rleaf {
+	switch v := rvar.Value.(type) {
+	case *fmom.PxPyPzE:
+		if !isLeafElemOrObj(leaf) || leaf.Type() != typeLorentzVector {
+			return nil
+		}
+		src := new(rphys.LorentzVector)
+		return &rleafConv{
+			rleaf: rleafFrom(leaf, rvarWithValue(rvar, src), rctx),
+			conv:  func() { *v = p4From(src) },
+		}
+
+	case *[]fmom.PxPyPzE:
+		if !isLeafElemOrObj(leaf) || leaf.Type() != typeLorentzVectorSli {
+			return nil
+		}
+		src := new([]rphys.LorentzVector)
+		return &rleafConv{
+			rleaf: rleafFrom(leaf, rvarWithValue(rvar, src), rctx),
+			conv: func() {
+				n := len(*src)
+				if cap(*v) < n {
+					*v = make([]fmom.PxPyPzE, n)
+				}
+				*v = (*v)[:n]
+				for i := range *src {
+					(*v)[i] = p4From(&(*src)[i])
+				}
+			},
+		}
+	}
+	return nil
+}
+
+func isLeafElemOrObj(leaf Leaf) bool {
+	switch leaf.(type) {
+	case *tleafElement, *tleafObject:
+		return true
+	}
+	return false
+}
+
+func rvarWithValue(rvar ReadVar, ptr interface{}) ReadVar {
+	rvar.Value = ptr
+	return rvar
+}
+
+func p4From(p4 *rphys.LorentzVector) fmom.PxPyPzE {
+	return fmom.NewPxPyPzE(p4.Px(), p4.Py(), p4.Pz(), p4.E())
+}
@@ -7,7 +7,9 @@ package rtree
 import (
 	"fmt"
 	"io"
+	"sort"
 
+	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/rtree/rfunc"
 )
 
@@ -16,13 +18,19 @@ type Reader struct {
 	r    reader
 	beg  int64
 	end  int64
-	nrab int // number of read-ahead baskets
+	nrab int   // number of read-ahead baskets
+	nmem int64 // memory budget of read-ahead baskets
 
 	tree  Tree
 	rvars []ReadVar
+	elist *EntryList // list of entries to read, if any
 
 	evals []rfunc.Formula
 	dirty bool // whether we need to re-create scanner (if formula needed new branches)
+
+	stats  *rstats      // statistics of the reader
+	cr     *corruptions // corrupted baskets skipped by the reader (nil to abort on corrupted baskets)
+	shared bool         // whether stats and corruptions are shared with (and reported by) another reader
 }
 
 // ReadOption configures how a ROOT tree should be traversed.
@@ -48,6 +56,36 @@ func WithPrefetchBaskets(n int) ReadOption {
 	}
 }
 
+// WithPrefetchMemory specifies the maximum amount of memory, in bytes,
+// held by the baskets read ahead by all the branches of a reader.
+// Each branch is always allowed to hold one basket, so reading proceeds
+// even when the budget is smaller than the baskets.
+// The default is 0, for no limit.
+func WithPrefetchMemory(n int64) ReadOption {
+	return func(r *Reader) error {
+		if n < 0 {
+			return fmt.Errorf("rtree: invalid negative prefetch memory budget (%d)", n)
+		}
+		r.nmem = n
+		return nil
+	}
+}
+
+// WithEntryList specifies the list of entries a Tree reader will read
+// through.
+// Entries of the list outside of the range of the reader are ignored.
+// Entry lists holding sub-lists (e.g. entry lists of chains) are not
+// supported.
+func WithEntryList(elist *EntryList) ReadOption {
+	return func(r *Reader) error {
+		if len(elist.Lists()) != 0 {
+			return fmt.Errorf("rtree: entry lists with sub-lists are not supported")
+		}
+		r.elist = elist
+		return nil
+	}
+}
+
 // NewReader creates a new Tree Reader from the provided ROOT Tree and
 // the set of read-variables into which data will be read.
 func NewReader(t Tree, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
@@ -63,7 +101,7 @@ func NewReader(t Tree, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
 		return nil, fmt.Errorf("rtree: could not create reader: %w", err)
 	}
 
-	r.r = newReader(t, rvars, r.nrab, r.beg, r.end)
+	r.r = r.newReader(rvars)
 	r.rvars = r.r.rvars()
 
 	return &r, nil
@@ -73,6 +111,11 @@ func (r *Reader) setup(t Tree, opts []ReadOption) error {
 	r.beg = 0
 	r.end = -1
 	r.nrab = 2
+	r.nmem = 0
+	r.elist = nil
+	r.stats = new(rstats)
+	r.cr = nil
+	r.shared = false
 
 	for i, opt := range opts {
 		err := opt(r)
@@ -128,7 +171,14 @@ func (r *Reader) Close() error {
 
 // RCtx provides an entry-wise local context to the tree Reader.
 type RCtx struct {
-	Entry int64 // Current tree entry.
+	Entry  int64   // Current tree entry.
+	Weight float64 // Weight of the tree holding the current entry.
+
+	// Unmatched holds the aliases of the friend trees, aligned by index,
+	// without an entry matching the current entry.
+	// Values of these friend trees are zeroed.
+	// Unmatched is only valid during the call to the user function.
+	Unmatched []string
 }
 
 // Read will read data from the underlying tree over the whole specified range.
@@ -137,12 +187,41 @@ func (r *Reader) Read(f func(ctx RCtx) error) error {
 	if r.dirty {
 		r.dirty = false
 		_ = r.r.Close()
-		r.r = newReader(r.tree, r.rvars, r.nrab, r.beg, r.end)
+		r.r = r.newReader(r.rvars)
 	}
 	r.r.reset()
 
+	if !r.shared {
+		r.stats.reset(nentries(r.beg, r.end, r.elist))
+		r.cr.reset()
+		defer r.stats.report()
+	}
+
 	const eoff = 0 // entry offset
-	return r.r.run(eoff, r.beg, r.end, f)
+	err := r.r.run(eoff, r.beg, r.end, func(ctx RCtx) error {
+		err := f(ctx)
+		r.stats.entry()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !r.shared {
+		return r.cr.err()
+	}
+	return nil
+}
+
+// setRange sets the half-open interval [beg, end) of entries the next
+// call to Read will read through, reusing the branches and baskets
+// buffers already set up by the Reader.
+func (r *Reader) setRange(beg, end int64) {
+	r.beg = beg
+	r.end = end
+	if r.r != nil {
+		r.r.setRange(beg, end)
+	}
 }
 
 // Reset resets the current Reader with the provided options.
@@ -159,12 +238,26 @@ func (r *Reader) Reset(opts ...ReadOption) error {
 		return fmt.Errorf("rtree: could not reset reader options: %w", err)
 	}
 
-	r.r = newReader(r.tree, r.rvars, r.nrab, r.beg, r.end)
+	r.r = r.newReader(r.rvars)
 	r.rvars = r.r.rvars()
 
 	return nil
 }
 
+func (r *Reader) newReader(rvars []ReadVar) reader {
+	rab := rahead{
+		n:   r.nrab,
+		mem: newBkBudget(r.nmem),
+		st:  r.stats,
+		cr:  r.cr,
+	}
+	rr := newReader(r.tree, rvars, rab, r.beg, r.end)
+	if r.elist != nil {
+		rr.selectEntries(r.elist.Entries())
+	}
+	return rr
+}
+
 // FormulaFunc creates a new formula based on the provided function and
 // the list of branches as inputs.
 func (r *Reader) FormulaFunc(branches []string, fct interface{}) (rfunc.Formula, error) {
@@ -225,6 +318,8 @@ type reader interface {
 	rvars() []ReadVar
 
 	run(off, beg, end int64, f func(RCtx) error) error
+	selectEntries(entries []int64)
+	setRange(beg, end int64)
 	start() error
 	stop()
 	reset()
@@ -236,6 +331,8 @@ type rtree struct {
 	rvs  []ReadVar
 	brs  []rbranch
 	lvs  []rleaf
+	ents []int64      // entries to read (nil to read all entries)
+	cr   *corruptions // corrupted baskets (nil to abort on corrupted baskets)
 }
 
 var (
@@ -244,7 +341,19 @@ var (
 
 func (r *rtree) rvars() []ReadVar { return r.rvs }
 
-func newReader(t Tree, rvars []ReadVar, n int, beg, end int64) reader {
+func (r *rtree) selectEntries(entries []int64) { r.ents = entries }
+
+// setRange sets the range of entries the baskets are read for.
+// The new range is taken into account at the next reset.
+func (r *rtree) setRange(beg, end int64) {
+	for i := range r.brs {
+		rb := r.brs[i].rb
+		rb.beg = beg
+		rb.end = end
+	}
+}
+
+func newReader(t Tree, rvars []ReadVar, rab rahead, beg, end int64) reader {
 	rvars, err := sanitizeRVars(t, rvars)
 	if err != nil {
 		panic(err)
@@ -252,24 +361,25 @@ func newReader(t Tree, rvars []ReadVar, n int, beg, end int64) reader {
 
 	switch t := t.(type) {
 	case *ttree:
-		return newRTree(t, rvars, n, beg, end)
+		return newRTree(t, rvars, rab, beg, end)
 	case *tntuple:
-		return newRTree(&t.ttree, rvars, n, beg, end)
+		return newRTree(&t.ttree, rvars, rab, beg, end)
 	case *tntupleD:
-		return newRTree(&t.ttree, rvars, n, beg, end)
+		return newRTree(&t.ttree, rvars, rab, beg, end)
 	case *chain:
-		return newRChain(t, rvars, n, beg, end)
+		return newRChain(t, rvars, rab, beg, end)
 	case *join:
-		return newRJoin(t, rvars, n, beg, end)
+		return newRJoin(t, rvars, rab, beg, end)
 	default:
 		panic(fmt.Errorf("rtree: unknown Tree implementation %T", t))
 	}
 }
 
-func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
+func newRTree(t *ttree, rvars []ReadVar, rab rahead, beg, end int64) *rtree {
 	r := &rtree{
 		tree: t,
 		rvs:  rvars,
+		cr:   rab.cr,
 	}
 	usr := make(map[string]struct{}, len(rvars))
 	for _, rvar := range rvars {
@@ -327,14 +437,42 @@ func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
 		brs[id] = append(brs[id], leaf)
 	}
 
+	branches := make([]Branch, len(brs))
+	for i, leaves := range brs {
+		branches[i] = leaves[0].Leaf().Branch()
+	}
+	prefetch(t.f, branches, beg, end)
+
 	r.brs = make([]rbranch, len(brs))
 	for i, leaves := range brs {
-		branch := leaves[0].Leaf().Branch()
-		r.brs[i] = newRBranch(branch, n, beg, end, leaves, r)
+		r.brs[i] = newRBranch(branches[i], rab, beg, end, leaves, r)
 	}
 
 	return r
 }
+
+// prefetch registers the baskets of the provided branches holding the
+// entries in [beg, end) with the read cache of the file, if any.
+func prefetch(f *riofs.File, branches []Branch, beg, end int64) {
+	if f == nil {
+		return
+	}
+
+	var spans []riofs.Span
+	for _, b := range branches {
+		base := asBranch(b)
+		for i, seek := range base.basketSeek {
+			if seek <= 0 || i+1 >= len(base.basketEntry) {
+				continue
+			}
+			if base.basketEntry[i+1] <= beg || base.basketEntry[i] >= end {
+				continue
+			}
+			spans = append(spans, riofs.Span{Off: seek, Len: int64(base.basketBytes[i])})
+		}
+	}
+	f.Prefetch(spans...)
+}
 func (r *rtree) Close() error {
 	for i := range r.brs {
 		rb := &r.brs[i]
@@ -366,6 +504,10 @@ func (r *rtree) stop() {
 }
 
 func (r *rtree) reset() {
+	for i := range r.brs {
+		rb := &r.brs[i]
+		prefetch(r.tree.f, []Branch{rb.b}, rb.rb.beg, rb.rb.end)
+	}
 	for i := range r.brs {
 		rb := &r.brs[i]
 		rb.reset()
@@ -424,7 +566,7 @@ func (r *rtree) rcountLeaf(name string) leafCount {
 func (r *rtree) run(off, beg, end int64, f func(RCtx) error) error {
 	var (
 		err  error
-		rctx RCtx
+		rctx = RCtx{Weight: r.tree.weight}
 	)
 
 	defer r.Close()
@@ -435,9 +577,13 @@ func (r *rtree) run(off, beg, end int64, f func(RCtx) error) error {
 	}
 	defer r.stop()
 
-	for i := beg; i < end; i++ {
-		err = r.read(i)
+	return loopEntries(r.ents, beg, end, func(i int64) error {
+		err := r.read(i)
 		if err != nil {
+			if err == errCorrupted {
+				r.cr.skip()
+				return nil
+			}
 			return fmt.Errorf("rtree: could not read entry %d: %w", i, err)
 		}
 		rctx.Entry = i + off
@@ -445,9 +591,35 @@ func (r *rtree) run(off, beg, end int64, f func(RCtx) error) error {
 		if err != nil {
 			return fmt.Errorf("rtree: could not process entry %d: %w", i, err)
 		}
+		return nil
+	})
+}
+
+// loopEntries calls f for each entry in [beg, end).
+// If entries is not nil, f is only called for the entries of that sorted
+// list that are in [beg, end).
+func loopEntries(entries []int64, beg, end int64, f func(i int64) error) error {
+	if entries == nil {
+		for i := beg; i < end; i++ {
+			err := f(i)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
-	return err
+	i := sort.Search(len(entries), func(i int) bool { return entries[i] >= beg })
+	for _, entry := range entries[i:] {
+		if entry >= end {
+			break
+		}
+		err := f(entry)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *rtree) read(ievt int64) error {
@@ -7,14 +7,18 @@ package rtree
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
 
+	"go-hep.org/x/hep/fmom"
 	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rphys"
 )
 
 func TestReader(t *testing.T) {
@@ -899,3 +903,211 @@ func TestMultiLeafBranchWithTreeReadVars(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestReaderLorentzVector(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/tlv-split00.root",
+		"../testdata/tlv-split01.root",
+		"../testdata/tlv-split99.root",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := f.Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve ROOT tree: %+v", err)
+			}
+			tree := o.(Tree)
+
+			var p4 fmom.PxPyPzE
+			r, err := NewReader(tree, []ReadVar{{Name: "p4", Value: &p4}})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx RCtx) error {
+				i := float64(ctx.Entry)
+				if got, want := p4, fmom.NewPxPyPzE(i, i+1, i+2, i+3); got != want {
+					return fmt.Errorf("entry %d: invalid p4: got=%v, want=%v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
+
+func TestReaderStdVectors(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, v := range []interface{}{
+		[]int32{},
+		[][]int32{},
+		rphys.LorentzVector{},
+		[]rphys.LorentzVector{},
+	} {
+		rdict.StreamerInfos.Add(rdict.StreamerOf(
+			rdict.StreamerInfos, reflect.TypeOf(v),
+		))
+	}
+
+	const nevts = 5
+	fname := filepath.Join(tmp, "std-vectors.root")
+	{
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt struct {
+			F32s []float32
+			I32s [][]int32
+			P4s  []rphys.LorentzVector
+		}
+		w, err := NewWriter(f, "tree", WriteVarsFromStruct(&evt))
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < nevts; i++ {
+			evt.F32s = evt.F32s[:0]
+			evt.I32s = evt.I32s[:0]
+			evt.P4s = evt.P4s[:0]
+			for j := 0; j < i; j++ {
+				evt.F32s = append(evt.F32s, float32(i+j))
+				evt.I32s = append(evt.I32s, make([]int32, j))
+				evt.P4s = append(evt.P4s, *rphys.NewLorentzVector(
+					float64(i), float64(j), 0, float64(i+j),
+				))
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	var evt struct {
+		F32s []float32
+		I32s [][]int32
+		P4s  []fmom.PxPyPzE
+	}
+	r, err := NewReader(tree, ReadVarsFromStruct(&evt))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx RCtx) error {
+		i := int(ctx.Entry)
+		if got, want := len(evt.F32s), i; got != want {
+			return fmt.Errorf("entry %d: invalid f32s length: got=%d, want=%d", i, got, want)
+		}
+		if got, want := len(evt.I32s), i; got != want {
+			return fmt.Errorf("entry %d: invalid i32s length: got=%d, want=%d", i, got, want)
+		}
+		if got, want := len(evt.P4s), i; got != want {
+			return fmt.Errorf("entry %d: invalid p4s length: got=%d, want=%d", i, got, want)
+		}
+		for j := 0; j < i; j++ {
+			if got, want := evt.F32s[j], float32(i+j); got != want {
+				return fmt.Errorf("entry %d: invalid f32s[%d]: got=%v, want=%v", i, j, got, want)
+			}
+			if got, want := len(evt.I32s[j]), j; got != want {
+				return fmt.Errorf("entry %d: invalid i32s[%d] length: got=%d, want=%d", i, j, got, want)
+			}
+			want := fmom.NewPxPyPzE(float64(i), float64(j), 0, float64(i+j))
+			if got := evt.P4s[j]; got != want {
+				return fmt.Errorf("entry %d: invalid p4s[%d]: got=%v, want=%v", i, j, got, want)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+}
+
+func TestReaderWithReadCache(t *testing.T) {
+	const fname = "../testdata/x-flat-tree.root"
+
+	load := func(opts ...riofs.FileOption) []string {
+		t.Helper()
+		f, err := riofs.Open(fname, opts...)
+		if err != nil {
+			t.Fatalf("could not open ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		tree, err := riofs.Get[Tree](f, "tree")
+		if err != nil {
+			t.Fatalf("could not retrieve tree: %+v", err)
+		}
+
+		rvars := NewReadVars(tree)
+		r, err := NewReader(tree, rvars)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		var vs []string
+		for i := 0; i < 2; i++ {
+			err = r.Read(func(ctx RCtx) error {
+				for _, rv := range rvars {
+					vs = append(vs, fmt.Sprint(reflect.ValueOf(rv.Value).Elem().Interface()))
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		}
+		return vs
+	}
+
+	want := load()
+	for _, size := range []int64{1, 4 * 1024, 1 << 20} {
+		t.Run(fmt.Sprintf("size=%d", size), func(t *testing.T) {
+			got := load(riofs.WithReadCache(size))
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid values read with read cache")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package rtree
 
 import (
 	"fmt"
+	"reflect"
 )
 
 type rjoin struct {
@@ -14,43 +15,60 @@ type rjoin struct {
 	rs []*rtree // FIXME(sbinet): handle join of chains?
 
 	rvs  []ReadVar
-	nrab int
+	rab  rahead
 	beg  int64
 	end  int64
+	ents []int64 // entries to read (nil to read all entries)
+
+	fbeg    int64     // first entry of the main tree with matched friend entries
+	fents   [][]int64 // entries of friend trees aligned by index, for each entry from fbeg
+	nomatch []string  // aliases of friend trees without an entry matching the current entry
 }
 
-func newRJoin(t *join, rvars []ReadVar, n int, beg, end int64) *rjoin {
+func newRJoin(t *join, rvars []ReadVar, rab rahead, beg, end int64) *rjoin {
 	rvars = bindRVarsTo(t, rvars)
 	r := &rjoin{
-		j:    t,
-		rs:   make([]*rtree, len(t.trees)),
-		rvs:  rvars,
-		nrab: n,
-		beg:  beg,
-		end:  end,
+		j:   t,
+		rs:  make([]*rtree, len(t.trees)),
+		rvs: rvars,
+		rab: rab,
+		beg: beg,
+		end: end,
 	}
 	rps := make([][]ReadVar, len(r.rs))
 	for i, t := range r.j.trees {
-		rps[i] = r.loadRVars(t.(*ttree), rvars)
+		rps[i] = r.loadRVars(t.(*ttree), rvars, r.j.alias(i))
 	}
 
 	r.rvs = r.rvs[:0]
 	for i, tree := range t.trees {
-		r.rs[i] = newRTree(tree.(*ttree), rps[i], r.nrab, beg, end)
-		r.rvs = append(r.rvs, r.rs[i].rvars()...)
+		switch r.j.friendIndex(i) {
+		case nil:
+			r.rs[i] = newRTree(tree.(*ttree), rps[i], r.rab, beg, end)
+		default:
+			// friend entries aligned by index may be anywhere in the friend tree.
+			r.rs[i] = newRTree(tree.(*ttree), rps[i], r.rab, 0, tree.Entries())
+		}
+		alias := r.j.alias(i)
+		for _, rv := range r.rs[i].rvars() {
+			if alias != "" {
+				rv.Name = alias + "." + rv.Name
+			}
+			r.rvs = append(r.rvs, rv)
+		}
 	}
 
 	return r
 }
 
-func (r *rjoin) loadRVars(t *ttree, rvars []ReadVar) []ReadVar {
+func (r *rjoin) loadRVars(t *ttree, rvars []ReadVar, alias string) []ReadVar {
 	rps := make([]ReadVar, 0, len(rvars))
 	for _, rv := range rvars {
 		br := asBranch(rv.leaf.Branch())
 		if br.tree != t {
 			continue
 		}
-		rps = append(rps, rv)
+		rps = append(rps, unalias(rv, alias))
 	}
 	return rps
 }
@@ -68,6 +86,20 @@ func (r *rjoin) Close() error {
 
 func (r *rjoin) rvars() []ReadVar { return r.rvs }
 
+func (r *rjoin) selectEntries(entries []int64) { r.ents = entries }
+
+func (r *rjoin) setRange(beg, end int64) {
+	r.beg = beg
+	r.end = end
+	for i, rr := range r.rs {
+		if r.j.friendIndex(i) != nil {
+			// friend entries aligned by index may be anywhere in the friend tree.
+			continue
+		}
+		rr.setRange(beg, end)
+	}
+}
+
 func (r *rjoin) reset() {
 	for _, rr := range r.rs {
 		rr.reset()
@@ -77,34 +109,89 @@ func (r *rjoin) reset() {
 func (r *rjoin) run(off, beg, end int64, f func(RCtx) error) error {
 	var (
 		err  error
-		rctx RCtx
+		rctx = RCtx{Weight: 1}
 	)
 	defer r.Close()
 
+	if len(r.rs) > 0 {
+		// like for ROOT friend trees, the weight is the one of the main tree.
+		rctx.Weight = r.rs[0].tree.weight
+	}
+
+	err = r.match(beg, end)
+	if err != nil {
+		return err
+	}
+
 	err = r.start()
 	if err != nil {
 		return err
 	}
 	defer r.stop()
 
-	for i := beg; i < end; i++ {
-		err = r.read(i)
+	return loopEntries(r.ents, beg, end, func(i int64) error {
+		err := r.read(i)
 		if err != nil {
+			if err == errCorrupted {
+				r.rab.cr.skip()
+				return nil
+			}
 			return fmt.Errorf("rtree: could not read entry %d: %w", i, err)
 		}
 		rctx.Entry = i + off
+		rctx.Unmatched = nil
+		if len(r.nomatch) > 0 {
+			rctx.Unmatched = r.nomatch
+		}
 		err = f(rctx)
 		if err != nil {
 			return fmt.Errorf("rtree: could not process entry %d: %w", i, err)
 		}
-	}
+		return nil
+	})
+}
 
-	return err
+// match looks up the entries of the friend trees aligned by index, for
+// each entry of the main tree in [beg, end).
+func (r *rjoin) match(beg, end int64) error {
+	if r.fents == nil {
+		r.fents = make([][]int64, len(r.rs))
+	}
+	r.fbeg = beg
+	for i := range r.rs {
+		idx := r.j.friendIndex(i)
+		if idx == nil {
+			continue
+		}
+		ents, err := friendEntries(r.j.trees[0], idx, beg, end)
+		if err != nil {
+			return fmt.Errorf(
+				"rtree: could not match entries of friend tree %q: %w",
+				r.j.trees[i].Name(), err,
+			)
+		}
+		r.fents[i] = ents
+	}
+	return nil
 }
 
 func (r *rjoin) read(ievt int64) error {
-	for _, rr := range r.rs {
-		err := rr.read(ievt)
+	r.nomatch = r.nomatch[:0]
+	for j, rr := range r.rs {
+		entry := ievt
+		if r.j.friendIndex(j) != nil {
+			entry = r.fents[j][ievt-r.fbeg]
+			if entry < 0 {
+				// like ROOT, zero the values of the friend tree.
+				for _, rv := range rr.rvars() {
+					v := reflect.ValueOf(rv.Value).Elem()
+					v.Set(reflect.Zero(v.Type()))
+				}
+				r.nomatch = append(r.nomatch, r.j.alias(j))
+				continue
+			}
+		}
+		err := rr.read(entry)
 		if err != nil {
 			return err
 		}
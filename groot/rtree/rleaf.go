@@ -32,6 +32,10 @@ type rleaf interface {
 const rleafDefaultSliceCap = 8
 
 func rleafFrom(leaf Leaf, rvar ReadVar, rctx rleafCtx) rleaf {
+	if rleaf := newRLeafConv(leaf, rvar, rctx); rleaf != nil {
+		return rleaf
+	}
+
 	switch leaf := leaf.(type) {
 	case *LeafO:
 		return newRLeafBool(leaf, rvar, rctx)
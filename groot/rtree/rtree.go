@@ -8,6 +8,7 @@ package rtree // import "go-hep.org/x/hep/groot/rtree"
 
 import (
 	"reflect" // Tree is a collection of branches of data.
+	"sort"
 
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/riofs"
@@ -18,6 +19,73 @@ import (
 // If the tree is not connected to any ROOT file, nil is returned.
 func FileOf(tree Tree) *riofs.File { return tree.(*ttree).f }
 
+// BasketBoundaries returns the sorted list of entries at which at least one
+// branch of the provided tree starts a new basket, terminated by the number
+// of entries of the tree.
+// Entries between two consecutive boundaries are thus held by a single
+// basket, for each branch.
+//
+// If branch names are provided, only the baskets of these top-level branches
+// (and of their sub-branches) are considered.
+//
+// Trees that are neither ROOT trees nor chains of ROOT trees are described
+// by a single range of entries.
+func BasketBoundaries(t Tree, branches ...string) []int64 {
+	set := make(map[int64]struct{})
+	basketBoundaries(t, 0, branches, set)
+
+	bounds := make([]int64, 0, len(set))
+	for entry := range set {
+		bounds = append(bounds, entry)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	return bounds
+}
+
+func basketBoundaries(t Tree, offset int64, names []string, set map[int64]struct{}) {
+	set[offset] = struct{}{}
+	set[offset+t.Entries()] = struct{}{}
+
+	switch t := t.(type) {
+	case *chain:
+		for i, tree := range t.trees {
+			basketBoundaries(tree, offset+t.offs[i], names, set)
+		}
+		return
+	case *ttree, *tntuple, *tntupleD:
+		// ok.
+	default:
+		return
+	}
+
+	var walk func(bs []Branch)
+	walk = func(bs []Branch) {
+		for _, b := range bs {
+			base := asBranch(b)
+			for i := range base.basketSeek {
+				if i >= len(base.basketEntry) {
+					break
+				}
+				if entry := base.basketEntry[i]; entry < t.Entries() {
+					set[offset+entry] = struct{}{}
+				}
+			}
+			walk(b.Branches())
+		}
+	}
+
+	branches := t.Branches()
+	if len(names) > 0 {
+		branches = make([]Branch, 0, len(names))
+		for _, name := range names {
+			if b := t.Branch(name); b != nil {
+				branches = append(branches, b)
+			}
+		}
+	}
+	walk(branches)
+}
+
 type Tree interface {
 	root.Named
 
@@ -50,6 +118,7 @@ type Branch interface {
 	writeToBuffer(w *rbytes.WBuffer) (int, error)
 	write() (int, error)
 	flush() error
+	flushBaskets() error
 }
 
 // Leaf describes branches data types
@@ -20,6 +20,9 @@ func toTitle(s string) string {
 }
 
 // ReadVar describes a variable to be read out of a tree.
+//
+// TLorentzVector and std::vector<TLorentzVector> leaves may also be read
+// into fmom.PxPyPzE and []fmom.PxPyPzE values.
 type ReadVar struct {
 	Name  string      // name of the branch to read
 	Leaf  string      // name of the leaf to read
@@ -1339,6 +1339,44 @@ func TestTreeRW(t *testing.T) {
 				return d
 			},
 		},
+		{
+			name:  "slices-arrays",
+			nevts: 5,
+			wvars: []WriteVar{
+				{Name: "N", Value: new(int16)},
+				{Name: "SliArrF32", Value: new([][3]float32), Count: "N"},
+				{Name: "SliArrI64", Value: new([][2][2]int64), Count: "N"},
+			},
+			btitles: []string{
+				"N/S",
+				"SliArrF32[N][3]/F",
+				"SliArrI64[N][2][2]/L",
+			},
+			ltitles: []string{
+				"N",
+				"SliArrF32[N][3]",
+				"SliArrI64[N][2][2]",
+			},
+			total: 5*2 + 10*(3*4+4*8),
+			want: func(i int) interface{} {
+				type Data struct {
+					N         int16
+					SliArrF32 [][3]float32
+					SliArrI64 [][2][2]int64
+				}
+				d := Data{
+					N:         int16(i),
+					SliArrF32: make([][3]float32, i),
+					SliArrI64: make([][2][2]int64, i),
+				}
+				for j := 0; j < i; j++ {
+					v := float32(10*i + j)
+					d.SliArrF32[j] = [3]float32{v, v + 1, v + 2}
+					d.SliArrI64[j] = [2][2]int64{{int64(v), -1}, {-2, int64(-v)}}
+				}
+				return d
+			},
+		},
 		{
 			name:  "compr-no-compression",
 			wopts: []WriteOption{WithoutCompression()},
@@ -1610,3 +1610,99 @@ func TestUprootTrees(t *testing.T) {
 		})
 	}
 }
+
+func TestBasketBoundaries(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "bounds.root")
+
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	var (
+		i32 int32
+		f64 float64
+	)
+	w, err := NewWriter(f, "tree", []WriteVar{
+		{Name: "I32", Value: &i32},
+		{Name: "F64", Value: &f64},
+	}, WithBasketSize(8*10))
+	if err != nil {
+		t.Fatalf("could not create tree writer: %+v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 30; i++ {
+		i32 = int32(i)
+		f64 = float64(i)
+		_, err = w.Write()
+		if err != nil {
+			t.Fatalf("could not write entry %d: %+v", i, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close tree writer: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err = riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		tree     Tree
+		branches []string
+		want     []int64
+	}{
+		{
+			name: "tree",
+			tree: tree,
+			want: []int64{0, 9, 18, 19, 27, 30},
+		},
+		{
+			name: "chain",
+			tree: Chain(tree, tree),
+			want: []int64{0, 9, 18, 19, 27, 30, 39, 48, 49, 57, 60},
+		},
+		{
+			name:     "tree-i32",
+			tree:     tree,
+			branches: []string{"I32"},
+			want:     []int64{0, 19, 30},
+		},
+		{
+			name:     "tree-f64",
+			tree:     tree,
+			branches: []string{"F64"},
+			want:     []int64{0, 9, 18, 27, 30},
+		},
+		{
+			name:     "chain-i32",
+			tree:     Chain(tree, tree),
+			branches: []string{"I32"},
+			want:     []int64{0, 19, 30, 49, 60},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := BasketBoundaries(tc.tree, tc.branches...)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid boundaries:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
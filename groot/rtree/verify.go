@@ -0,0 +1,121 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// Verify reads and decompresses all the baskets of all the branches of the
+// provided tree, and checks their byte count and number of entries against
+// the ones recorded in the branches.
+// Checksums of compressed baskets are verified while decompressing them.
+//
+// f, if not nil, is called for each corrupted basket as soon as it is
+// detected.
+//
+// Verify returns the number of verified baskets and, when corrupted baskets
+// were found, a *CorruptionError listing them.
+func Verify(t Tree, f func(c Corruption)) (int, error) {
+	var (
+		cr = &corruptions{fct: f}
+		n  int
+	)
+
+	var verify func(bs []Branch)
+	verify = func(bs []Branch) {
+		for _, b := range bs {
+			n += verifyBranch(b, cr)
+			verify(b.Branches())
+		}
+	}
+	verify(t.Branches())
+
+	return n, cr.err()
+}
+
+// verifyBranch verifies the baskets of the provided branch stored in
+// their own key, and reports the corrupted ones to cr.
+// verifyBranch returns the number of verified baskets.
+func verifyBranch(b Branch, cr *corruptions) int {
+	var (
+		base = asBranch(b)
+		tree = b.getTree()
+		file string
+	)
+	if tree.f != nil {
+		file = tree.f.Name()
+	}
+
+	for i, seek := range base.basketSeek {
+		span := rspan{
+			pos: seek,
+			sz:  base.basketBytes[i],
+			beg: base.basketEntry[i],
+			end: -1,
+		}
+		if i+1 < len(base.basketEntry) {
+			span.end = base.basketEntry[i+1]
+		}
+
+		var bkt rbasket
+		err := verifyBasket(&bkt, b.Name(), i, span, base.entryOffsetLen, tree.f)
+		if err == nil {
+			continue
+		}
+
+		cr.add(Corruption{
+			File:   file,
+			Tree:   tree.Name(),
+			Branch: b.Name(),
+			Basket: i,
+			Seek:   seek,
+			Beg:    span.beg,
+			End:    span.end,
+			Err:    err,
+		})
+		if span.end > span.beg {
+			cr.mu.Lock()
+			cr.skipped += span.end - span.beg
+			cr.mu.Unlock()
+		}
+	}
+
+	return len(base.basketSeek)
+}
+
+// verifyBasket reads, decompresses and checks the basket described by span.
+func verifyBasket(bkt *rbasket, name string, id int, span rspan, eoff int, f *riofs.File) (err error) {
+	// corrupted baskets may trigger out-of-bounds accesses.
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("rtree: could not inflate basket %d: %v", id, e)
+		}
+	}()
+
+	if f == nil {
+		return fmt.Errorf("rtree: no file attached to basket %d", id)
+	}
+
+	err = bkt.inflate(name, id, span, eoff, f)
+	if err != nil {
+		return err
+	}
+
+	if got, want := bkt.bk.key.Nbytes(), span.sz; got != want {
+		return fmt.Errorf("rtree: invalid byte count for basket %d: got=%d, want=%d", id, got, want)
+	}
+
+	if span.end < 0 {
+		return nil
+	}
+	if got, want := int64(bkt.bk.nevbuf), span.end-span.beg; got != want {
+		return fmt.Errorf("rtree: invalid number of entries for basket %d: got=%d, want=%d", id, got, want)
+	}
+
+	return nil
+}
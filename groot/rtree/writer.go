@@ -7,9 +7,11 @@ package rtree
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"go-hep.org/x/hep/groot/internal/rcompress"
 	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rvers"
@@ -26,6 +28,11 @@ type Writer interface {
 	// Flush commits the current contents of the tree to stable storage.
 	Flush() error
 
+	// AutoSave writes a snapshot of the tree with its current contents
+	// under a new key cycle, so the tree can be read by another process
+	// while it is still being written to.
+	AutoSave() error
+
 	// Close writes metadata and closes the tree.
 	Close() error
 }
@@ -34,10 +41,28 @@ type Writer interface {
 type WriteOption func(opt *wopt) error
 
 type wopt struct {
-	title    string // title of the writer tree
-	bufsize  int32  // buffer size for branches
-	splitlvl int32  // maximum split-level for branches
-	compress int32  // compression algorithm name and compression level
+	title    string   // title of the writer tree
+	weight   float64  // weight of the writer tree
+	bufsize  int32    // buffer size for branches
+	splitlvl int32    // maximum split-level for branches
+	compress int32    // compression algorithm name and compression level
+	index    []string // names of the major and minor index branches
+	autoSave int64    // number of entries between two autosaves
+
+	branches map[string][]WriteOption // per-branch configuration options
+}
+
+// forBranch returns the configuration for the named top-level branch.
+func (opt wopt) forBranch(name string) (wopt, error) {
+	cfg := opt
+	cfg.branches = nil
+	for _, o := range opt.branches[name] {
+		err := o(&cfg)
+		if err != nil {
+			return cfg, fmt.Errorf("rtree: could not configure branch %q: %w", name, err)
+		}
+	}
+	return cfg, nil
 }
 
 // WithLZ4 configures a ROOT tree to use LZ4 as a compression mechanism.
@@ -72,6 +97,14 @@ func WithZlib(level int) WriteOption {
 	}
 }
 
+// WithZstd configures a ROOT tree to use zstd as a compression mechanism.
+func WithZstd(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZSTD, Lvl: level}.Compression()
+		return nil
+	}
+}
+
 // WithBasketSize configures a ROOT tree to use 'size' (in bytes) as a basket buffer size.
 // if size is <= 0, the default buffer size is used (DefaultBasketSize).
 func WithBasketSize(size int) WriteOption {
@@ -92,7 +125,99 @@ func WithTitle(title string) WriteOption {
 	}
 }
 
-// WithSplitLevel sets the maximum branch depth split level
+// WithBranchOptions configures the top-level branch named name with
+// the provided options (e.g. compression or basket size), overriding
+// the tree-level ones.
+// Options applied to a branch are inherited by its sub-branches.
+// NewWriter fails if name does not match any top-level branch.
+func WithBranchOptions(name string, opts ...WriteOption) WriteOption {
+	return func(opt *wopt) error {
+		if opt.branches == nil {
+			opt.branches = make(map[string][]WriteOption)
+		}
+		opt.branches[name] = append(opt.branches[name], opts...)
+		return nil
+	}
+}
+
+// checkBranchOptions checks that each set of per-branch options applies
+// to a top-level branch described by the provided write-vars.
+func checkBranchOptions(vars []WriteVar, opts map[string][]WriteOption) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	names := make(map[string]struct{}, len(vars))
+	for _, v := range vars {
+		names[v.Name] = struct{}{}
+	}
+	var missing []string
+	for name := range opts {
+		if _, ok := names[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("no branch named %q for branch options", missing[0])
+}
+
+// WithWeight sets the weight of the tree writer.
+// The weight is applied to all the entries of the tree and is reported
+// by tree readers through RCtx.Weight.
+// The default weight is 1.
+func WithWeight(w float64) WriteOption {
+	return func(opt *wopt) error {
+		opt.weight = w
+		return nil
+	}
+}
+
+// WithIndex configures a ROOT tree to be saved with an index of its
+// entries, sorted by the values of the named major and minor branches.
+// Index branches must hold scalar numbers.
+// An empty minor name indexes entries with the major values only.
+//
+// The index of a tree can be retrieved with IndexOf.
+func WithIndex(major, minor string) WriteOption {
+	return func(opt *wopt) error {
+		if major == "" {
+			return fmt.Errorf("rtree: invalid empty major index name")
+		}
+		opt.index = []string{major, minor}
+		return nil
+	}
+}
+
+// WithAutoSave configures a ROOT tree to be automatically saved every n
+// written entries, as with Writer.AutoSave.
+// Autosaves are disabled if n <= 0 (the default.)
+//
+// Autosaves allow to monitor the output of a long running job: the file
+// holding the tree can be opened and read by another process while the
+// tree is still being filled.
+func WithAutoSave(n int64) WriteOption {
+	return func(opt *wopt) error {
+		opt.autoSave = n
+		return nil
+	}
+}
+
+// WithSplitLevel sets the maximum branch depth split level.
+//
+// As for ROOT, struct values are written as a single unsplit branch with
+// a split level of 0.
+// Otherwise, struct values are split into one sub-branch per data member,
+// and struct data members are themselves split while the nesting depth is
+// lower than the split level.
+// The default split level is 99.
+//
+// Note that struct values are thus split by default: users relying on the
+// previous behavior, where struct values were always written as a single
+// unsplit branch whatever the split level, should use WithSplitLevel(0).
+//
+// WithSplitLevel can be applied to a single branch with WithBranchOptions.
 func WithSplitLevel(lvl int) WriteOption {
 	return func(opt *wopt) error {
 		opt.splitlvl = int32(lvl)
@@ -103,6 +228,10 @@ func WithSplitLevel(lvl int) WriteOption {
 type wtree struct {
 	ttree
 	wvars []WriteVar
+	index *windex // index of the tree entries, if any
+	cycle int16   // key cycle of the last autosave snapshot, if any
+
+	decays []func() // re-alias user slices of arrays before each write
 
 	closed bool
 }
@@ -137,6 +266,7 @@ func NewWriter(dir riofs.Directory, name string, vars []WriteVar, opts ...WriteO
 	}
 
 	cfg := wopt{
+		weight:   1,
 		bufsize:  defaultBasketSize,
 		splitlvl: defaultSplitLevel,
 		compress: w.ttree.f.Compression(),
@@ -149,16 +279,37 @@ func NewWriter(dir riofs.Directory, name string, vars []WriteVar, opts ...WriteO
 		}
 	}
 
+	err := checkBranchOptions(vars, cfg.branches)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not configure tree writer: %w", err)
+	}
+
 	w.ttree.named.SetTitle(cfg.title)
+	w.ttree.weight = cfg.weight
+	if cfg.autoSave > 0 {
+		w.ttree.autoSave = cfg.autoSave
+	}
 
 	for _, v := range vars {
-		b, err := newBranchFromWVar(w, v.Name, v, nil, 0, cfg)
+		bcfg, err := cfg.forBranch(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := newBranchFromWVar(w, v.Name, v, nil, 0, bcfg)
 		if err != nil {
 			return nil, fmt.Errorf("rtree: could not create branch for write-var %#v: %w", v, err)
 		}
 		w.ttree.branches = append(w.ttree.branches, b)
 	}
 
+	if cfg.index != nil {
+		idx, err := newWIndex(vars, cfg.index[0], cfg.index[1])
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create tree index: %w", err)
+		}
+		w.index = idx
+	}
+
 	return w, nil
 }
 
@@ -167,13 +318,7 @@ func (w *wtree) SetTitle(title string) { w.ttree.named.SetTitle(title) }
 func (w *wtree) ROOTMerge(src root.Object) error {
 	switch src := src.(type) {
 	case Tree:
-		r, err := NewReader(src, nil)
-		if err != nil {
-			return fmt.Errorf("rtree: could not create tree reader: %w", err)
-		}
-		defer r.Close()
-
-		_, err = Copy(w, r)
+		_, err := w.merge(src)
 		if err != nil {
 			return fmt.Errorf("rtree: could not merge tree: %w", err)
 		}
@@ -190,6 +335,9 @@ func (w *wtree) Write() (int, error) {
 		tot int
 		zip int
 	)
+	for _, decay := range w.decays {
+		decay()
+	}
 	for _, b := range w.ttree.branches {
 		nbytes, err := b.write()
 		if err != nil {
@@ -197,11 +345,21 @@ func (w *wtree) Write() (int, error) {
 		}
 		tot += nbytes
 	}
+	if w.index != nil {
+		w.index.write()
+	}
 	w.ttree.entries++
 	w.ttree.totBytes += int64(tot)
 	w.ttree.zipBytes += int64(zip)
 	// FIXME(sbinet): autoflush
 
+	if n := w.ttree.autoSave; n > 0 && w.ttree.entries%n == 0 {
+		err := w.AutoSave()
+		if err != nil {
+			return tot, fmt.Errorf("rtree: could not autosave tree %q: %w", w.Name(), err)
+		}
+	}
+
 	return tot, nil
 }
 
@@ -229,6 +387,76 @@ func (w *wtree) Close() error {
 		return fmt.Errorf("rtree: could not flush tree %q: %w", w.Name(), err)
 	}
 
+	if err := w.save(); err != nil {
+		return err
+	}
+
+	if w.cycle > 0 {
+		// the final tree supersedes the last autosave snapshot.
+		err := riofs.Delete(w.ttree.dir, fmt.Sprintf("%s;%d", w.Name(), w.cycle))
+		if err != nil {
+			return fmt.Errorf("rtree: could not delete autosave snapshot of tree %q: %w", w.Name(), err)
+		}
+		w.cycle = 0
+	}
+
+	return nil
+}
+
+// AutoSave writes a snapshot of the tree with its current contents under
+// a new key cycle, and commits the metadata of the file holding the tree
+// to stable storage.
+// The snapshot replaces the one of the previous call to AutoSave, if any,
+// and is itself replaced by the final tree when the writer is closed.
+//
+// The file can thus be opened and the snapshot read by another process
+// while the tree is still being written to.
+func (w *wtree) AutoSave() error {
+	if w.closed {
+		return fmt.Errorf("rtree: could not autosave closed tree %q", w.Name())
+	}
+
+	for _, b := range w.ttree.branches {
+		err := b.flushBaskets()
+		if err != nil {
+			return fmt.Errorf("rtree: could not flush baskets of branch %q: %w", b.Name(), err)
+		}
+	}
+
+	if err := w.save(); err != nil {
+		return err
+	}
+	w.ttree.savedBytes = w.ttree.totBytes
+
+	if err := w.ttree.f.Flush(); err != nil {
+		return fmt.Errorf("rtree: could not flush file of tree %q: %w", w.Name(), err)
+	}
+
+	// only release the previous snapshot once the new one has been
+	// committed, so readers always find a valid tree on file.
+	cycle := w.cycle
+	w.cycle = w.keyCycle()
+	if cycle > 0 {
+		err := riofs.Delete(w.ttree.dir, fmt.Sprintf("%s;%d", w.Name(), cycle))
+		if err != nil {
+			return fmt.Errorf("rtree: could not delete autosave snapshot of tree %q: %w", w.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// save writes the tree metadata under a new key cycle.
+func (w *wtree) save() error {
+	if w.index != nil {
+		si, ok := rdict.StreamerInfos.Get("TTreeIndex", rvers.TreeIndex)
+		if !ok {
+			return fmt.Errorf("rtree: could not find streamer for TTreeIndex")
+		}
+		w.ttree.f.RegisterStreamer(si)
+		w.ttree.treeIndex = w.index.build()
+	}
+
 	if err := w.ttree.dir.Put(w.Name(), w); err != nil {
 		return fmt.Errorf("rtree: could not save tree %q: %w", w.Name(), err)
 	}
@@ -236,6 +464,17 @@ func (w *wtree) Close() error {
 	return nil
 }
 
+// keyCycle returns the highest key cycle of the tree in its directory.
+func (w *wtree) keyCycle() int16 {
+	var cycle int
+	for _, k := range w.ttree.dir.Keys() {
+		if k.Name() == w.Name() && k.Cycle() > cycle {
+			cycle = k.Cycle()
+		}
+	}
+	return int16(cycle)
+}
+
 func fileOf(d riofs.Directory) *riofs.File {
 	const max = 1<<31 - 1
 	for i := 0; i < max; i++ {
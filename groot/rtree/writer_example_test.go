@@ -9,6 +9,7 @@ import (
 	"compress/flate"
 	"fmt"
 	"log"
+	"os"
 	"reflect"
 
 	"go-hep.org/x/hep/groot"
@@ -30,6 +31,7 @@ func Example_createFlatNtuple() {
 		fname = "../testdata/groot-flat-ntuple.root"
 		nevts = 5
 	)
+	defer os.Remove(fname)
 	func() {
 		f, err := groot.Create(fname)
 		if err != nil {
@@ -145,6 +147,7 @@ func Example_createFlatNtupleWithLZMA() {
 		fname = "../testdata/groot-flat-ntuple-with-lzma.root"
 		nevts = 5
 	)
+	defer os.Remove(fname)
 	func() {
 		f, err := groot.Create(fname)
 		if err != nil {
@@ -260,6 +263,7 @@ func Example_createFlatNtupleFromStruct() {
 		fname = "../testdata/groot-flat-ntuple-with-struct.root"
 		nevts = 5
 	)
+	defer os.Remove(fname)
 	func() {
 		f, err := groot.Create(fname)
 		if err != nil {
@@ -394,6 +398,7 @@ func Example_createEventNtupleNoSplit() {
 		fname = "../testdata/groot-event-ntuple-nosplit.root"
 		nevts = 5
 	)
+	defer os.Remove(fname)
 
 	func() {
 		f, err := groot.Create(fname)
@@ -409,7 +414,7 @@ func Example_createEventNtupleNoSplit() {
 			}
 		)
 
-		tree, err := rtree.NewWriter(f, "mytree", wvars)
+		tree, err := rtree.NewWriter(f, "mytree", wvars, rtree.WithSplitLevel(0))
 		if err != nil {
 			log.Fatalf("could not create tree writer: %+v", err)
 		}
@@ -535,6 +540,7 @@ func Example_createEventNtupleFullSplit() {
 		fname = "../testdata/groot-event-ntuple-fullsplit.root"
 		nevts = 5
 	)
+	defer os.Remove(fname)
 
 	func() {
 		f, err := groot.Create(fname)
@@ -628,14 +634,18 @@ func Example_createEventNtupleFullSplit() {
 	// -- read back ROOT file
 	// === [../testdata/groot-event-ntuple-fullsplit.root] ===
 	// version: 62600
-	//   TTree mytree             (entries=5)
-	//     i32 "i32/I"    TBranch
-	//     f64 "f64/D"    TBranch
-	//     str "str/C"    TBranch
-	//     arr "arr[5]/D" TBranch
-	//     sli "sli"      TBranchElement
-	//     p4  "p4"       TBranchElement
-	//     mc  "mc"       TBranchElement
+	//   TTree   mytree             (entries=5)
+	//     i32   "i32/I"    TBranch
+	//     f64   "f64/D"    TBranch
+	//     str   "str/C"    TBranch
+	//     arr   "arr[5]/D" TBranch
+	//     sli   "sli"      TBranchElement
+	//     p4    "p4"       TBranchElement
+	//       px  "px"       TBranchElement
+	//       py  "py"       TBranchElement
+	//       pz  "pz"       TBranchElement
+	//       ene "ene"      TBranchElement
+	//     mc    "mc"       TBranchElement
 	//
 	// key[000]: mytree;1 "" (TTree)
 	// [000][i32]: 0
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/riofs"
 	"golang.org/x/exp/rand"
 )
@@ -170,3 +171,575 @@ func TestConcurrentWrite(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestWriterWithBranchOptions(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	fname := filepath.Join(tmp, "branch-opts.root")
+	f, err := riofs.Create(fname, riofs.WithZlib(1))
+	if err != nil {
+		t.Fatalf("could not create root file: %+v", err)
+	}
+	defer f.Close()
+
+	var evt struct {
+		I64 int64
+		F64 float64
+		Arr [10]float64
+	}
+	w, err := NewWriter(f, "tree", WriteVarsFromStruct(&evt),
+		WithLZ4(1),
+		WithBranchOptions("F64", WithZstd(1), WithBasketSize(16*1024)),
+		WithBranchOptions("Arr", WithoutCompression()),
+	)
+	if err != nil {
+		t.Fatalf("could not create tree writer: %+v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 1000; i++ {
+		evt.I64 = int64(i)
+		evt.F64 = float64(i)
+		for j := range evt.Arr {
+			evt.Arr[j] = float64(i)
+		}
+		_, err = w.Write()
+		if err != nil {
+			t.Fatalf("could not write event %d: %+v", i, err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close tree writer: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close root file: %+v", err)
+	}
+
+	f, err = riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open root file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	for _, tc := range []struct {
+		name  string
+		magic string
+		size  int
+	}{
+		{"I64", "L4", defaultBasketSize},
+		{"F64", "ZS", 16 * 1024},
+		{"Arr", "", defaultBasketSize},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tree.Branch(tc.name).(*tbranch)
+			if got, want := b.basketSize, tc.size; got != want {
+				t.Fatalf("invalid basket size: got=%d, want=%d", got, want)
+			}
+
+			var key riofs.Key
+			hdr := make([]byte, 128)
+			_, err := f.ReadAt(hdr, b.basketSeek[0])
+			if err != nil {
+				t.Fatalf("could not read basket key: %+v", err)
+			}
+			err = key.UnmarshalROOT(rbytes.NewRBuffer(hdr, nil, 0, nil))
+			if err != nil {
+				t.Fatalf("could not unmarshal basket key: %+v", err)
+			}
+
+			compressed := key.Nbytes()-key.KeyLen() != key.ObjLen()
+			if got, want := compressed, tc.magic != ""; got != want {
+				t.Fatalf("invalid compression: got=%v, want=%v", got, want)
+			}
+			if !compressed {
+				return
+			}
+
+			magic := make([]byte, 2)
+			_, err = f.ReadAt(magic, key.SeekKey()+int64(key.KeyLen()))
+			if err != nil {
+				t.Fatalf("could not read basket payload: %+v", err)
+			}
+			if got, want := string(magic), tc.magic; got != want {
+				t.Fatalf("invalid compression algorithm: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestWriterWithInvalidBranchOptions(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	f, err := riofs.Create(filepath.Join(tmp, "branch-opts.root"))
+	if err != nil {
+		t.Fatalf("could not create root file: %+v", err)
+	}
+	defer f.Close()
+
+	var evt struct {
+		I64 int64
+		F64 float64
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []WriteOption
+		err  string
+	}{
+		{
+			name: "unknown",
+			opts: []WriteOption{WithBranchOptions("F32", WithZstd(1))},
+			err:  `rtree: could not configure tree writer: no branch named "F32" for branch options`,
+		},
+		{
+			name: "many-unknowns",
+			opts: []WriteOption{
+				WithBranchOptions("F64", WithZstd(1)),
+				WithBranchOptions("Z", WithoutCompression()),
+				WithBranchOptions("A", WithoutCompression()),
+			},
+			err: `rtree: could not configure tree writer: no branch named "A" for branch options`,
+		},
+		{
+			name: "case",
+			opts: []WriteOption{WithBranchOptions("i64", WithoutCompression())},
+			err:  `rtree: could not configure tree writer: no branch named "i64" for branch options`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWriter(f, "tree-"+tc.name, WriteVarsFromStruct(&evt), tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestWriterSplitLevel(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	for _, tc := range []struct {
+		name  string
+		wopts []WriteOption
+		nsubs int // number of sub-branches of evt
+		nvec  int // number of sub-branches of evt.P3
+	}{
+		{
+			name:  "split-00",
+			wopts: []WriteOption{WithSplitLevel(0)},
+		},
+		{
+			name:  "split-01",
+			wopts: []WriteOption{WithSplitLevel(1)},
+			nsubs: 39,
+		},
+		{
+			name:  "split-99",
+			nsubs: 39,
+			nvec:  3,
+		},
+		{
+			name:  "branch-split-00",
+			wopts: []WriteOption{WithBranchOptions("evt", WithSplitLevel(0))},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, tc.name+".root")
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create root file: %+v", err)
+			}
+			defer f.Close()
+
+			var (
+				evt   EventType
+				want  = evt.want
+				wvars = []WriteVar{{Name: "evt", Value: &evt.Evt}}
+				wopts = append([]WriteOption{WithTitle("my tree title")}, tc.wopts...)
+			)
+			w, err := NewWriter(f, "tree", wvars, wopts...)
+			if err != nil {
+				t.Fatalf("could not create tree writer: %+v", err)
+			}
+			defer w.Close()
+
+			for i := 0; i < 100; i++ {
+				evt = want(int64(i))
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write event %d: %+v", i, err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close tree writer: %+v", err)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close root file: %+v", err)
+			}
+
+			f, err = riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open root file: %+v", err)
+			}
+			defer f.Close()
+
+			tree, err := riofs.Get[Tree](f, "tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+
+			b := tree.Branch("evt")
+			if got, want := len(b.Branches()), tc.nsubs; got != want {
+				t.Fatalf("invalid number of sub-branches: got=%d, want=%d", got, want)
+			}
+			if tc.nsubs > 0 {
+				if got, want := len(b.Branch("P3").Branches()), tc.nvec; got != want {
+					t.Fatalf("invalid number of sub-branches for P3: got=%d, want=%d", got, want)
+				}
+				if got, want := b.Branch("N").(*tbranchElement).max, int32(9); got != want {
+					t.Fatalf("invalid maximum for leaf-count: got=%d, want=%d", got, want)
+				}
+			}
+
+			testEventTree(t, tc.name, fname)
+		})
+	}
+}
+
+func TestWriterAutoSave(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	for _, nevts := range []int{35, 40} {
+		t.Run(fmt.Sprintf("nevts=%d", nevts), func(t *testing.T) {
+			const nsave = 10
+			fname := filepath.Join(tmp, fmt.Sprintf("autosave-%d.root", nevts))
+
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create root file: %+v", err)
+			}
+			defer f.Close()
+
+			dir, err := riofs.Dir(f).Mkdir("dir")
+			if err != nil {
+				t.Fatalf("could not create directory: %+v", err)
+			}
+
+			var (
+				i64 int64
+				n   int32
+				sli []float64
+			)
+			w, err := NewWriter(dir, "tree", []WriteVar{
+				{Name: "i64", Value: &i64},
+				{Name: "n", Value: &n},
+				{Name: "sli", Value: &sli, Count: "n"},
+			}, WithAutoSave(nsave), WithIndex("i64", ""))
+			if err != nil {
+				t.Fatalf("could not create tree writer: %+v", err)
+			}
+			defer w.Close()
+
+			check := func(fname string, want int) {
+				t.Helper()
+
+				f, err := riofs.Open(fname)
+				if err != nil {
+					t.Fatalf("could not open root file: %+v", err)
+				}
+				defer f.Close()
+
+				var cycles int
+				d, err := riofs.Dir(f).Get("dir")
+				if err != nil {
+					t.Fatalf("could not retrieve directory: %+v", err)
+				}
+				for _, k := range d.(riofs.Directory).Keys() {
+					if k.Name() == "tree" {
+						cycles++
+					}
+				}
+				if cycles > 2 {
+					t.Fatalf("too many tree cycles: %d", cycles)
+				}
+
+				tree, err := riofs.Get[Tree](f, "dir/tree")
+				if err != nil {
+					t.Fatalf("could not retrieve tree: %+v", err)
+				}
+				if got := tree.Entries(); got != int64(want) {
+					t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+				}
+				if got := IndexOf(tree).Len(); got != want {
+					t.Fatalf("invalid index length: got=%d, want=%d", got, want)
+				}
+
+				var (
+					i64 int64
+					sli []float64
+				)
+				r, err := NewReader(tree, []ReadVar{
+					{Name: "i64", Value: &i64},
+					{Name: "sli", Value: &sli},
+				})
+				if err != nil {
+					t.Fatalf("could not create tree reader: %+v", err)
+				}
+				defer r.Close()
+
+				n := 0
+				err = r.Read(func(ctx RCtx) error {
+					if i64 != ctx.Entry {
+						return fmt.Errorf("invalid i64 value: got=%d, want=%d", i64, ctx.Entry)
+					}
+					if len(sli) != int(i64%5) {
+						return fmt.Errorf("invalid slice length: got=%d, want=%d", len(sli), i64%5)
+					}
+					n++
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("could not read tree: %+v", err)
+				}
+				if n != want {
+					t.Fatalf("invalid number of read entries: got=%d, want=%d", n, want)
+				}
+			}
+
+			for i := 0; i < nevts; i++ {
+				i64 = int64(i)
+				sli = sli[:0]
+				for j := 0; j < i%5; j++ {
+					sli = append(sli, float64(i))
+				}
+				n = int32(len(sli))
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write event %d: %+v", i, err)
+				}
+				if (i+1)%nsave == 0 {
+					check(fname, i+1)
+				}
+			}
+
+			err = w.AutoSave()
+			if err != nil {
+				t.Fatalf("could not autosave tree: %+v", err)
+			}
+			check(fname, nevts)
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close tree writer: %+v", err)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close root file: %+v", err)
+			}
+
+			check(fname, nevts)
+
+			f, err = riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open root file: %+v", err)
+			}
+			defer f.Close()
+
+			d, err := riofs.Dir(f).Get("dir")
+			if err != nil {
+				t.Fatalf("could not retrieve directory: %+v", err)
+			}
+			if got, want := len(d.(riofs.Directory).Keys()), 1; got != want {
+				t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+			}
+
+			err = w.AutoSave()
+			if err == nil {
+				t.Fatalf("expected an error autosaving a closed tree")
+			}
+		})
+	}
+}
+
+func TestWriterCountLeaf(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	f, err := riofs.Create(filepath.Join(tmp, "count.root"))
+	if err != nil {
+		t.Fatalf("could not create root file: %+v", err)
+	}
+	defer f.Close()
+
+	var (
+		n   int32
+		sli []float32
+	)
+
+	_, err = NewWriter(f, "undeclared", []WriteVar{
+		{Name: "sli", Value: &sli, Count: "n"},
+		{Name: "n", Value: &n},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for a count leaf declared after its slice")
+	}
+
+	w, err := NewWriter(f, "tree", []WriteVar{
+		{Name: "n", Value: &n},
+		{Name: "sli", Value: &sli, Count: "n"},
+	})
+	if err != nil {
+		t.Fatalf("could not create tree writer: %+v", err)
+	}
+	defer w.Close()
+
+	n = 2
+	sli = []float32{1, 2}
+	_, err = w.Write()
+	if err != nil {
+		t.Fatalf("could not write entry: %+v", err)
+	}
+
+	n = 3
+	_, err = w.Write()
+	if err == nil {
+		t.Fatalf("expected an error for a slice shorter than its count")
+	}
+}
+
+func TestWriterGoStructWithMap(t *testing.T) {
+	type Hit struct {
+		X float64 `groot:"x"`
+		E float32 `groot:"e"`
+	}
+	type Evt struct {
+		ID   int64            `groot:"id"`
+		Hits []Hit            `groot:"hits"`
+		Tags map[int32]string `groot:"tags"`
+	}
+
+	want := func(i int) Evt {
+		evt := Evt{
+			ID:   int64(i),
+			Tags: map[int32]string{int32(i): fmt.Sprintf("tag-%d", i)},
+		}
+		for j := 0; j < i%3; j++ {
+			evt.Hits = append(evt.Hits, Hit{X: float64(i + j), E: float32(j)})
+		}
+		return evt
+	}
+
+	for _, split := range []int{0, 99} {
+		t.Run(fmt.Sprintf("split-%02d", split), func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "go-struct-map.root")
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create root file: %+v", err)
+			}
+			defer f.Close()
+
+			var evt Evt
+			w, err := NewWriter(f, "tree", []WriteVar{{Name: "evt", Value: &evt}}, WithSplitLevel(split))
+			if err != nil {
+				t.Fatalf("could not create tree writer: %+v", err)
+			}
+			defer w.Close()
+
+			const n = 10
+			for i := 0; i < n; i++ {
+				evt = want(i)
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write event %d: %+v", i, err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close tree writer: %+v", err)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close root file: %+v", err)
+			}
+
+			f, err = riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open root file: %+v", err)
+			}
+			defer f.Close()
+
+			tree, err := riofs.Get[Tree](f, "tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+
+			var got Evt
+			r, err := NewReader(tree, []ReadVar{{Name: "evt", Value: &got}})
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx RCtx) error {
+				want := want(int(ctx.Entry))
+				if len(want.Hits) == 0 && len(got.Hits) == 0 {
+					want.Hits = got.Hits
+				}
+				if !reflect.DeepEqual(got, want) {
+					return fmt.Errorf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
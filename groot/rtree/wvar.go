@@ -12,6 +12,11 @@ import (
 )
 
 // WriteVar describes a variable to be written out to a tree.
+//
+// Slices with a Count are written as variable-length arrays, e.g. pt[njets]/F.
+// Slices of fixed-size arrays are also supported, e.g. p4[njets][4]/D.
+// The count branch must be declared before the slices it governs, and its
+// value must not exceed the length of these slices when an entry is written.
 type WriteVar struct {
 	Name  string      // name of the variable
 	Value interface{} // pointer to the value to write
@@ -96,7 +101,7 @@ func WriteVarsFromStruct(ptr interface{}, opts ...WriteOption) []WriteVar {
 			switch ft.Type.Kind() {
 			case reflect.Slice:
 				sli, dims := split(wvar.Name)
-				if len(dims) > 1 {
+				if len(dims) > 1 && ft.Type.Elem().Kind() != reflect.Array {
 					panic(fmt.Errorf("rtree: invalid number of slice-dimensions for field %q: %q", ft.Name, wvar.Name))
 				}
 				wvar.Name = sli
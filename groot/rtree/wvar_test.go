@@ -98,6 +98,17 @@ func TestWriteVarsFromStruct(t *testing.T) {
 				{Name: "F4"},
 			},
 		},
+		{
+			name: "slices-of-arrays",
+			ptr: &struct {
+				N   int32
+				Sli [][3]float32 `groot:"pt[N][3]"`
+			}{},
+			want: []WriteVar{
+				{Name: "N"},
+				{Name: "pt", Count: "N"},
+			},
+		},
 		{
 			name: "arrays",
 			ptr: &struct {
@@ -14,7 +14,8 @@ type FactoryFct func() reflect.Value
 
 type factory struct {
 	mu sync.RWMutex
-	db map[string]FactoryFct // a registry of all factory functions by type name
+	db map[string]FactoryFct         // a registry of all factory functions by type name
+	vs map[string]map[int]FactoryFct // a registry of factory functions by type name and class version
 }
 
 func (f *factory) Len() int {
@@ -72,6 +73,72 @@ func (f *factory) Add(n string, fct FactoryFct) {
 	f.mu.Unlock()
 }
 
+// Del removes the factory function registered for the type named n, if any.
+// Factory functions registered with AddVersion are left untouched.
+func (f *factory) Del(n string) {
+	f.mu.Lock()
+	delete(f.db, n)
+	f.mu.Unlock()
+}
+
+// AddVersion registers the factory function fct for the type named n,
+// when decoding data written with the class version vers.
+//
+// Versioned factories take precedence over the ones registered with Add
+// (and over the generic rdict-based decoding path) when the on-file
+// version of a class matches vers.
+// This allows applications to plug optimized or customized decoders for
+// specific classes without modifying groot.
+func (f *factory) AddVersion(n string, vers int, fct FactoryFct) {
+	f.mu.Lock()
+	if f.vs == nil {
+		f.vs = make(map[string]map[int]FactoryFct)
+	}
+	db, ok := f.vs[n]
+	if !ok {
+		db = make(map[int]FactoryFct)
+		f.vs[n] = db
+	}
+	db[vers] = fct
+	f.mu.Unlock()
+}
+
+// DelVersion removes the factory function registered for the type
+// named n and the class version vers, if any.
+func (f *factory) DelVersion(n string, vers int) {
+	f.mu.Lock()
+	db, ok := f.vs[n]
+	if ok {
+		delete(db, vers)
+		if len(db) == 0 {
+			delete(f.vs, n)
+		}
+	}
+	f.mu.Unlock()
+}
+
+// HasVersion returns whether a factory function has been registered
+// for the type named n and the class version vers.
+func (f *factory) HasVersion(n string, vers int) bool {
+	f.mu.RLock()
+	_, ok := f.vs[n][vers]
+	f.mu.RUnlock()
+	return ok
+}
+
+// GetVersion returns the factory function registered for the type
+// named n and the class version vers.
+// GetVersion falls back to Get if no such versioned factory function exists.
+func (f *factory) GetVersion(n string, vers int) FactoryFct {
+	f.mu.RLock()
+	fct, ok := f.vs[n][vers]
+	f.mu.RUnlock()
+	if ok {
+		return fct
+	}
+	return f.Get(n)
+}
+
 type setClasser interface {
 	SetClass(name string)
 }
@@ -5,6 +5,7 @@
 package rtypes_test
 
 import (
+	"reflect"
 	"testing"
 
 	_ "go-hep.org/x/hep/groot/rbase" // import factories for rbase types
@@ -14,7 +15,7 @@ import (
 
 func TestFactory(t *testing.T) {
 	n := rtypes.Factory.Len()
-	if got, want := n, 11; got != want {
+	if got, want := n, 16; got != want {
 		t.Fatalf("got=%d, want=%d", got, want)
 	}
 
@@ -30,3 +31,50 @@ func TestFactory(t *testing.T) {
 		}
 	}
 }
+
+func TestFactoryVersion(t *testing.T) {
+	const name = "groot::test::Versioned"
+
+	type v1 struct{ root.Object }
+	type v2 struct{ root.Object }
+
+	rtypes.Factory.Add(name, func() reflect.Value { return reflect.ValueOf(&v1{}) })
+	t.Cleanup(func() { rtypes.Factory.Del(name) })
+
+	rtypes.Factory.AddVersion(name, 2, func() reflect.Value { return reflect.ValueOf(&v2{}) })
+	t.Cleanup(func() { rtypes.Factory.DelVersion(name, 2) })
+
+	if rtypes.Factory.HasVersion(name, 1) {
+		t.Fatalf("unexpected factory for version 1")
+	}
+	if !rtypes.Factory.HasVersion(name, 2) {
+		t.Fatalf("expected a factory for version 2")
+	}
+
+	for _, tc := range []struct {
+		vers int
+		want reflect.Type
+	}{
+		{1, reflect.TypeOf(&v1{})},
+		{2, reflect.TypeOf(&v2{})},
+		{3, reflect.TypeOf(&v1{})},
+	} {
+		got := rtypes.Factory.GetVersion(name, tc.vers)().Type()
+		if got != tc.want {
+			t.Fatalf("invalid type for version %d: got=%v, want=%v", tc.vers, got, tc.want)
+		}
+	}
+
+	rtypes.Factory.DelVersion(name, 2)
+	if rtypes.Factory.HasVersion(name, 2) {
+		t.Fatalf("unexpected factory for version 2 after removal")
+	}
+	if got, want := rtypes.Factory.GetVersion(name, 2)().Type(), reflect.TypeOf(&v1{}); got != want {
+		t.Fatalf("invalid type after removal: got=%v, want=%v", got, want)
+	}
+
+	rtypes.Factory.Del(name)
+	if rtypes.Factory.HasKey(name) {
+		t.Fatalf("unexpected factory after removal")
+	}
+}
@@ -11,19 +11,25 @@ const ROOT = 62600
 
 // ROOT classes versions
 const (
+	Att3D                    = 1  // ROOT version for TAtt3D
 	AttAxis                  = 4  // ROOT version for TAttAxis
+	AttBBox2D                = 0  // ROOT version for TAttBBox2D
 	AttFill                  = 2  // ROOT version for TAttFill
 	AttLine                  = 2  // ROOT version for TAttLine
 	AttMarker                = 2  // ROOT version for TAttMarker
+	AttPad                   = 4  // ROOT version for TAttPad
+	AttText                  = 2  // ROOT version for TAttText
 	Datime                   = 1  // ROOT version for TDatime
 	Named                    = 1  // ROOT version for TNamed
 	Object                   = 1  // ROOT version for TObject
 	ObjString                = 1  // ROOT version for TObjString
 	ProcessID                = 1  // ROOT version for TProcessID
 	ProcessUUID              = 1  // ROOT version for TProcessUUID
+	QObject                  = 1  // ROOT version for TQObject
 	Ref                      = 1  // ROOT version for TRef
 	UUID                     = 1  // ROOT version for TUUID
 	String                   = 2  // ROOT version for TString
+	VirtualPad               = 3  // ROOT version for TVirtualPad
 	Array                    = 1  // ROOT version for TArray
 	ArrayC                   = 1  // ROOT version for TArrayC
 	ArrayS                   = 1  // ROOT version for TArrayS
@@ -65,11 +71,14 @@ const (
 	F1Convolution            = 1  // ROOT version for TF1Convolution
 	F1NormSum                = 1  // ROOT version for TF1NormSum
 	F1Parameters             = 1  // ROOT version for TF1Parameters
+	F2                       = 4  // ROOT version for TF2
 	Formula                  = 13 // ROOT version for TFormula
 	Graph                    = 4  // ROOT version for TGraph
 	GraphErrors              = 3  // ROOT version for TGraphErrors
 	GraphAsymmErrors         = 3  // ROOT version for TGraphAsymmErrors
 	GraphMultiErrors         = 1  // ROOT version for TGraphMultiErrors
+	Graph2D                  = 1  // ROOT version for TGraph2D
+	Graph2DErrors            = 1  // ROOT version for TGraph2DErrors
 	H1                       = 8  // ROOT version for TH1
 	H1C                      = 3  // ROOT version for TH1C
 	H1D                      = 3  // ROOT version for TH1D
@@ -85,6 +94,11 @@ const (
 	H2Poly                   = 3  // ROOT version for TH2Poly
 	H2PolyBin                = 1  // ROOT version for TH2PolyBin
 	H2S                      = 4  // ROOT version for TH2S
+	H3                       = 6  // ROOT version for TH3
+	H3D                      = 4  // ROOT version for TH3D
+	H3F                      = 4  // ROOT version for TH3F
+	H3I                      = 4  // ROOT version for TH3I
+	HStack                   = 2  // ROOT version for THStack
 	Limit                    = 2  // ROOT version for TLimit
 	LimitDataSource          = 2  // ROOT version for TLimitDataSource
 	MultiGraph               = 2  // ROOT version for TMultiGraph
@@ -105,6 +119,8 @@ const (
 	BranchObject             = 1  // ROOT version for TBranchObject
 	BranchRef                = 1  // ROOT version for TBranchRef
 	Chain                    = 5  // ROOT version for TChain
+	EntryList                = 2  // ROOT version for TEntryList
+	EntryListBlock           = 1  // ROOT version for TEntryListBlock
 	Leaf                     = 2  // ROOT version for TLeaf
 	LeafElement              = 1  // ROOT version for TLeafElement
 	LeafObject               = 4  // ROOT version for TLeafObject
@@ -121,4 +137,15 @@ const (
 	Ntuple                   = 2  // ROOT version for TNtuple
 	NtupleD                  = 1  // ROOT version for TNtupleD
 	Tree                     = 20 // ROOT version for TTree
+	TreeIndex                = 2  // ROOT version for TTreeIndex
+	VirtualIndex             = 1  // ROOT version for TVirtualIndex
+	AttCanvas                = 1  // ROOT version for TAttCanvas
+	Canvas                   = 8  // ROOT version for TCanvas
+	Pad                      = 13 // ROOT version for TPad
+	Box                      = 3  // ROOT version for TBox
+	Latex                    = 2  // ROOT version for TLatex
+	Line                     = 3  // ROOT version for TLine
+	Pave                     = 3  // ROOT version for TPave
+	PaveText                 = 2  // ROOT version for TPaveText
+	Text                     = 2  // ROOT version for TText
 )
@@ -85,7 +85,34 @@ func (ann *Annotation) unmarshalYODAv1(data []byte) error {
 
 // unmarshalYODAv2 unmarshal YODA v2.
 func (ann *Annotation) unmarshalYODAv2(data []byte) error {
-	return yaml.Unmarshal(data, ann)
+	err := yaml.Unmarshal(data, ann)
+	if err != nil {
+		return err
+	}
+	// lists of strings (e.g. bin labels) are decoded as []interface{},
+	// which can not be gob-encoded.
+	for k, v := range *ann {
+		if v, ok := v.([]interface{}); ok {
+			if strs, ok := yamlStrings(v); ok {
+				(*ann)[k] = strs
+			}
+		}
+	}
+	return nil
+}
+
+// yamlStrings returns the provided values as strings, if they all are
+// strings.
+func yamlStrings(vs []interface{}) ([]string, bool) {
+	o := make([]string, len(vs))
+	for i, v := range vs {
+		str, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		o[i] = str
+	}
+	return o, true
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler
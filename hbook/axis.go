@@ -0,0 +1,95 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+)
+
+// Axis describes the metadata attached to an axis of a histogram.
+//
+// The metadata of an axis is stored in the annotations of its histogram,
+// so it is persisted and converted together with them.
+// The axis title is stored under the "<A>Label" key, where <A> is the
+// name of the axis (X, Y or Z), as done by Rivet for YODA files.
+// The unit and bin labels are stored under the "<A>Unit" and
+// "<A>BinLabels" keys.
+type Axis struct {
+	Title string // title of the axis
+	Unit  string // unit of the values along the axis
+
+	// Labels holds the alphanumeric labels of the bins along the axis,
+	// for categorical axes.
+	// Labels is either empty or has one element per bin of the axis.
+	Labels []string
+}
+
+// BinLabel returns the label of the i-th bin of the axis, or the empty
+// string if the axis has no bin labels.
+func (ax Axis) BinLabel(i int) string {
+	if i < 0 || i >= len(ax.Labels) {
+		return ""
+	}
+	return ax.Labels[i]
+}
+
+// BinIndex returns the index of the bin with the provided label, or -1
+// if no bin has that label.
+func (ax Axis) BinIndex(label string) int {
+	for i, v := range ax.Labels {
+		if v == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// axis returns the metadata of the named axis stored in the annotation.
+func (ann Annotation) axis(name string) Axis {
+	var ax Axis
+	if v, ok := ann[name+"Label"].(string); ok {
+		ax.Title = v
+	}
+	if v, ok := ann[name+"Unit"].(string); ok {
+		ax.Unit = v
+	}
+	switch v := ann[name+"BinLabels"].(type) {
+	case []string:
+		ax.Labels = append([]string(nil), v...)
+	case []interface{}:
+		ax.Labels = make([]string, len(v))
+		for i, lbl := range v {
+			ax.Labels[i] = fmt.Sprint(lbl)
+		}
+	}
+	return ax
+}
+
+// setAxis stores the metadata of the named axis into the annotation.
+// setAxis panics if the axis has bin labels and their number is not nbins.
+func (ann Annotation) setAxis(name string, nbins int, ax Axis) {
+	if len(ax.Labels) != 0 && len(ax.Labels) != nbins {
+		panic(fmt.Errorf(
+			"hbook: invalid number of bin labels for %s-axis (got=%d, want=%d)",
+			name, len(ax.Labels), nbins,
+		))
+	}
+	for _, v := range []struct {
+		key string
+		val interface{}
+		ok  bool
+	}{
+		{name + "Label", ax.Title, ax.Title != ""},
+		{name + "Unit", ax.Unit, ax.Unit != ""},
+		{name + "BinLabels", append([]string(nil), ax.Labels...), len(ax.Labels) != 0},
+	} {
+		switch {
+		case v.ok:
+			ann[v.key] = v.val
+		default:
+			delete(ann, v.key)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+func TestAxis(t *testing.T) {
+	h := NewH1D(3, 0, 3)
+	h.Ann["name"] = "h1"
+	if got, want := h.XAxis(), (Axis{}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid default axis: got=%#v, want=%#v", got, want)
+	}
+
+	xaxis := Axis{Title: "channel", Labels: []string{"ee", "mumu", "emu"}}
+	yaxis := Axis{Title: "events", Unit: "1/fb"}
+	h.SetXAxis(xaxis)
+	h.SetYAxis(yaxis)
+	if got, want := h.XAxis(), xaxis; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid x-axis: got=%#v, want=%#v", got, want)
+	}
+	if got, want := h.YAxis(), yaxis; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid y-axis: got=%#v, want=%#v", got, want)
+	}
+	if got, want := h.XAxis().BinLabel(1), "mumu"; got != want {
+		t.Fatalf("invalid bin label: got=%q, want=%q", got, want)
+	}
+	if got, want := h.XAxis().BinLabel(3), ""; got != want {
+		t.Fatalf("invalid bin label: got=%q, want=%q", got, want)
+	}
+	if got, want := h.XAxis().BinIndex("emu"), 2; got != want {
+		t.Fatalf("invalid bin index: got=%d, want=%d", got, want)
+	}
+	if got, want := h.XAxis().BinIndex("tautau"), -1; got != want {
+		t.Fatalf("invalid bin index: got=%d, want=%d", got, want)
+	}
+
+	xaxis.Labels[0] = "xx"
+	if got, want := h.XAxis().BinLabel(0), "ee"; got != want {
+		t.Fatalf("axis metadata not copied: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		fct  func()
+		want string
+	}{
+		{
+			name: "h1-x",
+			fct:  func() { h.SetXAxis(Axis{Labels: []string{"a"}}) },
+			want: "hbook: invalid number of bin labels for X-axis (got=1, want=3)",
+		},
+		{
+			name: "h1-y",
+			fct:  func() { h.SetYAxis(Axis{Labels: []string{"a"}}) },
+			want: "hbook: invalid number of bin labels for Y-axis (got=1, want=0)",
+		},
+		{
+			name: "h2-y",
+			fct:  func() { NewH2D(2, 0, 2, 3, 0, 3).SetYAxis(Axis{Labels: []string{"a", "b"}}) },
+			want: "hbook: invalid number of bin labels for Y-axis (got=2, want=3)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, msg := panics(tc.fct)
+			if !ok || msg != tc.want {
+				t.Fatalf("invalid panic: got=%v (%q), want=%q", ok, msg, tc.want)
+			}
+		})
+	}
+
+	h.SetYAxis(Axis{})
+	if _, ok := h.Ann["YLabel"]; ok {
+		t.Fatalf("y-axis title not removed")
+	}
+	if _, ok := h.Ann["YUnit"]; ok {
+		t.Fatalf("y-axis unit not removed")
+	}
+}
+
+func TestAxisRoundTrip(t *testing.T) {
+	h1 := NewH1D(2, 0, 2)
+	h1.Ann["name"] = "h1"
+	h1.SetXAxis(Axis{Title: "channel", Labels: []string{"ee", "mumu"}})
+	h1.SetYAxis(Axis{Title: "events"})
+	h1.Fill(0.5, 1)
+
+	h2 := NewH2D(2, 0, 2, 1, 0, 1)
+	h2.Ann["name"] = "h2"
+	h2.SetXAxis(Axis{Labels: []string{"a", "b"}})
+	h2.SetYAxis(Axis{Title: "p_T", Unit: "GeV"})
+	h2.SetZAxis(Axis{Title: "efficiency"})
+
+	type axer interface {
+		XAxis() Axis
+		YAxis() Axis
+	}
+
+	for _, tc := range []struct {
+		name string
+		h    interface {
+			axer
+			marshalYODAv2() ([]byte, error)
+			marshalYODAv3() ([]byte, error)
+		}
+		v interface {
+			axer
+			UnmarshalYODA([]byte) error
+		}
+	}{
+		{"h1d", h1, new(H1D)},
+		{"h2d", h2, new(H2D)},
+	} {
+		for _, v := range []struct {
+			name string
+			f    func() ([]byte, error)
+		}{
+			{"yoda-v2", tc.h.marshalYODAv2},
+			{"yoda-v3", tc.h.marshalYODAv3},
+		} {
+			t.Run(tc.name+"-"+v.name, func(t *testing.T) {
+				raw, err := v.f()
+				if err != nil {
+					t.Fatalf("could not marshal to YODA: %+v", err)
+				}
+				err = tc.v.UnmarshalYODA(raw)
+				if err != nil {
+					t.Fatalf("could not unmarshal from YODA: %+v", err)
+				}
+				if got, want := tc.v.XAxis(), tc.h.XAxis(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid x-axis: got=%#v, want=%#v", got, want)
+				}
+				if got, want := tc.v.YAxis(), tc.h.YAxis(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid y-axis: got=%#v, want=%#v", got, want)
+				}
+
+				// check annotations read back from YODA can be gob-encoded.
+				err = gob.NewEncoder(new(bytes.Buffer)).Encode(tc.v)
+				if err != nil {
+					t.Fatalf("could not gob-encode: %+v", err)
+				}
+			})
+		}
+	}
+}
@@ -4,7 +4,10 @@
 
 package hbook
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
 
 // indices for the 2D-binning overflows
 const (
@@ -161,6 +164,30 @@ func (bng *Binning2D) yMax() float64 {
 	return bng.YRange.Max
 }
 
+// outflowIndex2D returns the index into Binning2D.Outflows of the outflow
+// region at position (ox, oy), where each position is -1, 0 or +1.
+func outflowIndex2D(ox, oy int) int {
+	switch {
+	case ox < 0 && oy > 0:
+		return BngNW - 1
+	case ox == 0 && oy > 0:
+		return BngN - 1
+	case ox > 0 && oy > 0:
+		return BngNE - 1
+	case ox > 0 && oy == 0:
+		return BngE - 1
+	case ox > 0 && oy < 0:
+		return BngSE - 1
+	case ox == 0 && oy < 0:
+		return BngS - 1
+	case ox < 0 && oy < 0:
+		return BngSW - 1
+	case ox < 0 && oy == 0:
+		return BngW - 1
+	}
+	panic(fmt.Errorf("hbook: invalid outflow region (%d,%d)", ox, oy))
+}
+
 func (bng *Binning2D) fill(x, y, w float64) {
 	idx := bng.coordToIndex(x, y)
 	bng.Dist.fill(x, y, w)
@@ -176,12 +203,33 @@ func (bng *Binning2D) fill(x, y, w float64) {
 }
 
 func (bng *Binning2D) coordToIndex(x, y float64) int {
-	ix := Bin1Ds(bng.XEdges).IndexOf(x)
-	iy := Bin1Ds(bng.YEdges).IndexOf(y)
+	return coordToIndex2D(bng.XEdges, bng.YEdges, x, y)
+}
+
+func (bng *Binning2D) scaleW(f float64) {
+	bng.Dist.scaleW(f)
+	for i := range bng.Outflows {
+		bng.Outflows[i].scaleW(f)
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].scaleW(f)
+	}
+}
+
+// coordToIndex2D returns the index of the bin at (x,y) of the 2-dim
+// binning with the provided edges.
+// Outflows are returned as negative indices (-BngXXX), gaps as nx*ny.
+func coordToIndex2D(xedges, yedges []Bin1D, x, y float64) int {
+	var (
+		nx = len(xedges)
+		ny = len(yedges)
+		ix = Bin1Ds(xedges).IndexOf(x)
+		iy = Bin1Ds(yedges).IndexOf(y)
+	)
 
 	switch {
-	case ix == bng.Nx && iy == bng.Ny: // GAP
-		return len(bng.Bins)
+	case ix == nx && iy == ny: // GAP
+		return nx * ny
 	case ix == OverflowBin1D && iy == OverflowBin1D:
 		return -BngNE
 	case ix == OverflowBin1D && iy == UnderflowBin1D:
@@ -199,5 +247,5 @@ func (bng *Binning2D) coordToIndex(x, y float64) int {
 	case iy == UnderflowBin1D:
 		return -BngS
 	}
-	return iy*bng.Nx + ix
+	return iy*nx + ix
 }
@@ -0,0 +1,250 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Binning3D is a 3-dim binning of the (x,y,z) space.
+//
+// The bin with indices (ix,iy,iz) along the X, Y and Z axes is stored
+// at index (iz*Ny+iy)*Nx+ix of Bins.
+// The 26 outflow regions around the binned volume are stored in Outflows
+// and can be retrieved with the Outflow method.
+type Binning3D struct {
+	Bins     []Bin3D
+	Dist     Dist3D
+	Outflows [26]Dist3D
+	XRange   Range
+	YRange   Range
+	ZRange   Range
+	Nx       int
+	Ny       int
+	Nz       int
+	XEdges   []Bin1D
+	YEdges   []Bin1D
+	ZEdges   []Bin1D
+}
+
+func newBinning3D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64, nz int, zlow, zhigh float64) Binning3D {
+	if xlow >= xhigh {
+		panic(errInvalidXAxis)
+	}
+	if ylow >= yhigh {
+		panic(errInvalidYAxis)
+	}
+	if zlow >= zhigh {
+		panic(errInvalidZAxis)
+	}
+	if nx <= 0 {
+		panic(errEmptyXAxis)
+	}
+	if ny <= 0 {
+		panic(errEmptyYAxis)
+	}
+	if nz <= 0 {
+		panic(errEmptyZAxis)
+	}
+
+	edges := func(n int, low, high float64) []float64 {
+		var (
+			o     = make([]float64, n+1)
+			width = (high - low) / float64(n)
+		)
+		for i := range o {
+			o[i] = low + float64(i)*width
+		}
+		o[n] = high
+		return o
+	}
+
+	return newBinning3DFromEdges(
+		edges(nx, xlow, xhigh),
+		edges(ny, ylow, yhigh),
+		edges(nz, zlow, zhigh),
+	)
+}
+
+func newBinning3DFromEdges(xedges, yedges, zedges []float64) Binning3D {
+	for _, v := range []struct {
+		edges    []float64
+		short    error
+		notSort  error
+		dupEdges error
+	}{
+		{xedges, errShortXAxis, errNotSortedXAxis, errDupEdgesXAxis},
+		{yedges, errShortYAxis, errNotSortedYAxis, errDupEdgesYAxis},
+		{zedges, errShortZAxis, errNotSortedZAxis, errDupEdgesZAxis},
+	} {
+		if len(v.edges) <= 1 {
+			panic(v.short)
+		}
+		if !sort.IsSorted(sort.Float64Slice(v.edges)) {
+			panic(v.notSort)
+		}
+		for i := range v.edges[1:] {
+			if v.edges[i] == v.edges[i+1] {
+				panic(v.dupEdges)
+			}
+		}
+	}
+
+	var (
+		nx = len(xedges) - 1
+		ny = len(yedges) - 1
+		nz = len(zedges) - 1
+	)
+	bng := Binning3D{
+		Bins:   make([]Bin3D, nx*ny*nz),
+		XRange: Range{Min: xedges[0], Max: xedges[nx]},
+		YRange: Range{Min: yedges[0], Max: yedges[ny]},
+		ZRange: Range{Min: zedges[0], Max: zedges[nz]},
+		Nx:     nx,
+		Ny:     ny,
+		Nz:     nz,
+		XEdges: newBin1Ds(xedges),
+		YEdges: newBin1Ds(yedges),
+		ZEdges: newBin1Ds(zedges),
+	}
+	for iz, zbin := range bng.ZEdges {
+		for iy, ybin := range bng.YEdges {
+			for ix, xbin := range bng.XEdges {
+				bin := &bng.Bins[bng.index(ix, iy, iz)]
+				bin.XRange = xbin.Range
+				bin.YRange = ybin.Range
+				bin.ZRange = zbin.Range
+			}
+		}
+	}
+	return bng
+}
+
+// newBin1Ds returns the 1-dim bins defined by the provided edges.
+func newBin1Ds(edges []float64) []Bin1D {
+	bins := make([]Bin1D, len(edges)-1)
+	for i := range bins {
+		bins[i].Range.Min = edges[i]
+		bins[i].Range.Max = edges[i+1]
+	}
+	return bins
+}
+
+func (bng *Binning3D) entries() int64 {
+	return bng.Dist.Entries()
+}
+
+func (bng *Binning3D) effEntries() float64 {
+	return bng.Dist.EffEntries()
+}
+
+// xMin returns the low edge of the X-axis
+func (bng *Binning3D) xMin() float64 {
+	return bng.XRange.Min
+}
+
+// xMax returns the high edge of the X-axis
+func (bng *Binning3D) xMax() float64 {
+	return bng.XRange.Max
+}
+
+// yMin returns the low edge of the Y-axis
+func (bng *Binning3D) yMin() float64 {
+	return bng.YRange.Min
+}
+
+// yMax returns the high edge of the Y-axis
+func (bng *Binning3D) yMax() float64 {
+	return bng.YRange.Max
+}
+
+// zMin returns the low edge of the Z-axis
+func (bng *Binning3D) zMin() float64 {
+	return bng.ZRange.Min
+}
+
+// zMax returns the high edge of the Z-axis
+func (bng *Binning3D) zMax() float64 {
+	return bng.ZRange.Max
+}
+
+// index returns the index into Bins of the bin (ix,iy,iz).
+func (bng *Binning3D) index(ix, iy, iz int) int {
+	return (iz*bng.Ny+iy)*bng.Nx + ix
+}
+
+// Outflow returns the distribution of the outflow region (ox,oy,oz).
+// Each of ox, oy and oz is -1 (resp. +1) for the region below (resp. above)
+// the range of the corresponding axis, and 0 for the region within that range.
+// Outflow panics if the provided indices do not describe an outflow region.
+func (bng *Binning3D) Outflow(ox, oy, oz int) *Dist3D {
+	return &bng.Outflows[outflowIndex3D(ox, oy, oz)]
+}
+
+// outflowIndex3D returns the index into Outflows of the region (ox,oy,oz).
+func outflowIndex3D(ox, oy, oz int) int {
+	for _, v := range []int{ox, oy, oz} {
+		if v < -1 || v > +1 {
+			panic(fmt.Errorf("hbook: invalid outflow region (%d,%d,%d)", ox, oy, oz))
+		}
+	}
+	i := 9*(ox+1) + 3*(oy+1) + (oz + 1)
+	switch {
+	case i == 13:
+		panic(fmt.Errorf("hbook: invalid outflow region (%d,%d,%d)", ox, oy, oz))
+	case i > 13:
+		i--
+	}
+	return i
+}
+
+func (bng *Binning3D) fill(x, y, z, w float64) {
+	idx := bng.coordToIndex(x, y, z)
+	bng.Dist.fill(x, y, z, w)
+	if idx == len(bng.Bins) {
+		// GAP bin
+		return
+	}
+	if idx < 0 {
+		bng.Outflows[-idx-1].fill(x, y, z, w)
+		return
+	}
+	bng.Bins[idx].fill(x, y, z, w)
+}
+
+// coordToIndex returns the index into Bins of the bin containing (x,y,z).
+// coordToIndex returns -i-1 for the i-th outflow region, and len(Bins)
+// for gaps.
+func (bng *Binning3D) coordToIndex(x, y, z float64) int {
+	var (
+		ix = Bin1Ds(bng.XEdges).IndexOf(x)
+		iy = Bin1Ds(bng.YEdges).IndexOf(y)
+		iz = Bin1Ds(bng.ZEdges).IndexOf(z)
+	)
+	if ix == bng.Nx || iy == bng.Ny || iz == bng.Nz {
+		return len(bng.Bins)
+	}
+
+	oflow := func(i int) int {
+		switch i {
+		case UnderflowBin1D:
+			return -1
+		case OverflowBin1D:
+			return +1
+		}
+		return 0
+	}
+
+	var (
+		ox = oflow(ix)
+		oy = oflow(iy)
+		oz = oflow(iz)
+	)
+	if ox != 0 || oy != 0 || oz != 0 {
+		return -outflowIndex3D(ox, oy, oz) - 1
+	}
+	return bng.index(ix, iy, iz)
+}
@@ -0,0 +1,289 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+)
+
+// BinningP2D is a 2-dim binning for 2-dim profile histograms.
+type BinningP2D struct {
+	Bins     []BinP2D
+	Dist     Dist3D
+	Outflows [8]Dist3D
+	XRange   Range
+	YRange   Range
+	Nx       int
+	Ny       int
+	XEdges   []Bin1D
+	YEdges   []Bin1D
+}
+
+func newBinningP2D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64) BinningP2D {
+	return newBinningP2DFrom(newBinning2D(nx, xlow, xhigh, ny, ylow, yhigh))
+}
+
+func newBinningP2DFromEdges(xedges, yedges []float64) BinningP2D {
+	return newBinningP2DFrom(newBinning2DFromEdges(xedges, yedges))
+}
+
+// newBinningP2DFrom returns a profile binning with the same geometry as
+// the provided 2-dim binning.
+func newBinningP2DFrom(b Binning2D) BinningP2D {
+	bng := BinningP2D{
+		Bins:   make([]BinP2D, len(b.Bins)),
+		XRange: b.XRange,
+		YRange: b.YRange,
+		Nx:     b.Nx,
+		Ny:     b.Ny,
+		XEdges: b.XEdges,
+		YEdges: b.YEdges,
+	}
+	for i, bin := range b.Bins {
+		bng.Bins[i].XRange = bin.XRange
+		bng.Bins[i].YRange = bin.YRange
+	}
+	return bng
+}
+
+func (bng *BinningP2D) entries() int64 {
+	return bng.Dist.Entries()
+}
+
+func (bng *BinningP2D) effEntries() float64 {
+	return bng.Dist.EffEntries()
+}
+
+// xMin returns the low edge of the X-axis
+func (bng *BinningP2D) xMin() float64 {
+	return bng.XRange.Min
+}
+
+// xMax returns the high edge of the X-axis
+func (bng *BinningP2D) xMax() float64 {
+	return bng.XRange.Max
+}
+
+// yMin returns the low edge of the Y-axis
+func (bng *BinningP2D) yMin() float64 {
+	return bng.YRange.Min
+}
+
+// yMax returns the high edge of the Y-axis
+func (bng *BinningP2D) yMax() float64 {
+	return bng.YRange.Max
+}
+
+func (bng *BinningP2D) fill(x, y, z, w float64) {
+	idx := bng.coordToIndex(x, y)
+	bng.Dist.fill(x, y, z, w)
+	if idx == len(bng.Bins) {
+		// GAP bin
+		return
+	}
+	if idx < 0 {
+		bng.Outflows[-idx-1].fill(x, y, z, w)
+		return
+	}
+	bng.Bins[idx].fill(x, y, z, w)
+}
+
+func (bng *BinningP2D) coordToIndex(x, y float64) int {
+	return coordToIndex2D(bng.XEdges, bng.YEdges, x, y)
+}
+
+func (bng *BinningP2D) scaleW(f float64) {
+	bng.Dist.scaleW(f)
+	for i := range bng.Outflows {
+		bng.Outflows[i].scaleW(f)
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].Dist.scaleW(f)
+	}
+}
+
+// BinP2D models a bin of a 2-dim profile histogram.
+// The profiled quantity is stored along the Z axis of the bin distribution.
+type BinP2D struct {
+	XRange Range
+	YRange Range
+	Dist   Dist3D
+}
+
+// Rank returns the number of dimensions for this bin.
+func (BinP2D) Rank() int { return 2 }
+
+func (b *BinP2D) fill(x, y, z, w float64) {
+	b.Dist.fill(x, y, z, w)
+}
+
+// Entries returns the number of entries in this bin.
+func (b *BinP2D) Entries() int64 {
+	return b.Dist.Entries()
+}
+
+// EffEntries returns the effective number of entries \f$ = (\sum w)^2 / \sum w^2 \f$
+func (b *BinP2D) EffEntries() float64 {
+	return b.Dist.EffEntries()
+}
+
+// SumW returns the sum of weights in this bin.
+func (b *BinP2D) SumW() float64 {
+	return b.Dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this bin.
+func (b *BinP2D) SumW2() float64 {
+	return b.Dist.SumW2()
+}
+
+// XEdges returns the [low,high] edges of this bin.
+func (b *BinP2D) XEdges() Range {
+	return b.XRange
+}
+
+// YEdges returns the [low,high] edges of this bin.
+func (b *BinP2D) YEdges() Range {
+	return b.YRange
+}
+
+// XMin returns the lower limit of the bin (inclusive).
+func (b *BinP2D) XMin() float64 {
+	return b.XRange.Min
+}
+
+// YMin returns the lower limit of the bin (inclusive).
+func (b *BinP2D) YMin() float64 {
+	return b.YRange.Min
+}
+
+// XMax returns the upper limit of the bin (exclusive).
+func (b *BinP2D) XMax() float64 {
+	return b.XRange.Max
+}
+
+// YMax returns the upper limit of the bin (exclusive).
+func (b *BinP2D) YMax() float64 {
+	return b.YRange.Max
+}
+
+// XMid returns the geometric center of the bin.
+// i.e.: 0.5*(high+low)
+func (b *BinP2D) XMid() float64 {
+	return 0.5 * (b.XRange.Min + b.XRange.Max)
+}
+
+// YMid returns the geometric center of the bin.
+// i.e.: 0.5*(high+low)
+func (b *BinP2D) YMid() float64 {
+	return 0.5 * (b.YRange.Min + b.YRange.Max)
+}
+
+// XWidth returns the (signed) width of the bin
+func (b *BinP2D) XWidth() float64 {
+	return b.XRange.Max - b.XRange.Min
+}
+
+// YWidth returns the (signed) width of the bin
+func (b *BinP2D) YWidth() float64 {
+	return b.YRange.Max - b.YRange.Min
+}
+
+// XMean returns the mean X.
+func (b *BinP2D) XMean() float64 {
+	return b.Dist.xMean()
+}
+
+// YMean returns the mean Y.
+func (b *BinP2D) YMean() float64 {
+	return b.Dist.yMean()
+}
+
+// ZMean returns the mean Z, the profiled value of this bin.
+// ZMean returns NaN if the bin is empty.
+func (b *BinP2D) ZMean() float64 {
+	return b.Dist.zMean()
+}
+
+// ZVariance returns the variance in Z.
+func (b *BinP2D) ZVariance() float64 {
+	return b.Dist.zVariance()
+}
+
+// ZStdDev returns the standard deviation in Z.
+func (b *BinP2D) ZStdDev() float64 {
+	return b.Dist.zStdDev()
+}
+
+// ZStdErr returns the standard error in Z.
+func (b *BinP2D) ZStdErr() float64 {
+	return b.Dist.zStdErr()
+}
+
+// ZRMS returns the RMS in Z.
+func (b *BinP2D) ZRMS() float64 {
+	return b.Dist.zRMS()
+}
+
+// ZErr returns the uncertainty on the profiled value of this bin,
+// computed according to the provided mode.
+// ZErr returns 0 if the bin is empty.
+func (b *BinP2D) ZErr(mode ProfileErr) float64 {
+	return mode.err(b.Dist.Z)
+}
+
+// ProfileErr describes how the uncertainty on the profiled value of a
+// bin is computed.
+//
+// The values of ProfileErr match the ones of ROOT's TProfile error
+// options.
+type ProfileErr int
+
+const (
+	// ProfileErrMean is the standard error on the mean of the values in
+	// a bin: spread/sqrt(N_eff). This is the default.
+	ProfileErrMean ProfileErr = iota
+
+	// ProfileErrSpread is the spread (standard deviation) of the values
+	// in a bin. (ROOT's "s" option.)
+	ProfileErrSpread
+
+	// ProfileErrInteger is the standard error on the mean of the values
+	// in a bin, for integer values: bins with a null spread are given
+	// an error of 1/sqrt(12*N_eff). (ROOT's "i" option.)
+	ProfileErrInteger
+
+	// ProfileErrGaussian is 1/sqrt(sum(w)), the error on the mean of
+	// values with unit Gaussian errors. (ROOT's "g" option.)
+	ProfileErrGaussian
+)
+
+// err returns the uncertainty on the mean of the provided distribution.
+func (mode ProfileErr) err(d Dist1D) float64 {
+	sumw := d.SumW()
+	if sumw == 0 {
+		return 0
+	}
+	if mode == ProfileErrGaussian {
+		return 1 / math.Sqrt(sumw)
+	}
+
+	var (
+		mean   = d.SumWX() / sumw
+		spread = math.Sqrt(math.Abs(d.SumWX2()/sumw - mean*mean))
+		neff   = d.EffEntries()
+	)
+	switch mode {
+	case ProfileErrSpread:
+		return spread
+	case ProfileErrInteger:
+		if spread == 0 {
+			return 1 / math.Sqrt(12*neff)
+		}
+		return spread / math.Sqrt(neff)
+	default:
+		return spread / math.Sqrt(neff)
+	}
+}
@@ -0,0 +1,534 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const compactVersion = 1
+
+// Compact1D is a compact, lossy, representation of a 1-dim histogram,
+// suitable for the long-term storage of large amounts of histograms.
+//
+// The sum of weights and the sum of squared weights of each bin are stored
+// with a reduced floating point precision, so that their relative error is
+// bounded by the tolerance provided to NewCompact1D.
+// The number of entries of each bin, the global distribution and the
+// under/over-flows are stored exactly.
+// The per-bin x moments are not stored: they are recomputed from the
+// bin centers when the histogram is restored.
+type Compact1D struct {
+	prec     uint8 // number of stored mantissa bits
+	bins     []Range
+	dist     Dist1D
+	outflows [2]Dist1D
+	entries  []int64
+	sumw     []float64
+	sumw2    []float64
+	ann      Annotation
+}
+
+// NewCompact1D returns a compact representation of the provided histogram,
+// with the relative error on the bin contents bounded by tol.
+// NewCompact1D panics if tol is not in the ]0, 1[ range.
+func NewCompact1D(h *H1D, tol float64) *Compact1D {
+	prec := compactPrec(tol)
+	c := &Compact1D{
+		prec:     prec,
+		bins:     make([]Range, len(h.Binning.Bins)),
+		dist:     h.Binning.Dist,
+		outflows: h.Binning.Outflows,
+		entries:  make([]int64, len(h.Binning.Bins)),
+		sumw:     make([]float64, len(h.Binning.Bins)),
+		sumw2:    make([]float64, len(h.Binning.Bins)),
+		ann:      h.Ann.clone(),
+	}
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		c.bins[i] = bin.Range
+		c.entries[i] = bin.Entries()
+		c.sumw[i] = compactRound(bin.SumW(), prec)
+		c.sumw2[i] = compactRound(bin.SumW2(), prec)
+	}
+	return c
+}
+
+// Tolerance returns the upper bound on the relative error of the bin
+// contents of the compacted histogram.
+func (c *Compact1D) Tolerance() float64 {
+	return compactTol(c.prec)
+}
+
+// H1D returns the 1-dim histogram restored from its compact representation.
+func (c *Compact1D) H1D() *H1D {
+	h := NewH1DFromBins(c.bins...)
+	h.Binning.Dist = c.dist
+	h.Binning.Outflows = c.outflows
+	h.Ann = c.ann.clone()
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		xmid := bin.XMid()
+		bin.Dist.Dist = Dist0D{
+			N:     c.entries[i],
+			SumW:  c.sumw[i],
+			SumW2: c.sumw2[i],
+		}
+		bin.Dist.Stats.SumWX = c.sumw[i] * xmid
+		bin.Dist.Stats.SumWX2 = c.sumw[i] * xmid * xmid
+	}
+	return h
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c *Compact1D) MarshalBinary() ([]byte, error) {
+	var enc compactEncoder
+	enc.u8(compactVersion)
+	enc.u8(c.prec)
+	enc.ranges(c.bins)
+	err := enc.binary(&c.dist)
+	if err != nil {
+		return nil, err
+	}
+	for i := range c.outflows {
+		err = enc.binary(&c.outflows[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range c.bins {
+		enc.uvarint(uint64(c.entries[i]))
+		enc.qf64(c.sumw[i], c.prec)
+		enc.qf64(c.sumw2[i], c.prec)
+	}
+	err = enc.binary(&c.ann)
+	if err != nil {
+		return nil, err
+	}
+	return enc.buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *Compact1D) UnmarshalBinary(data []byte) error {
+	dec := compactDecoder{buf: data}
+	dec.version()
+	c.prec = dec.prec()
+	c.bins = dec.ranges()
+	dec.binary(&c.dist)
+	for i := range c.outflows {
+		dec.binary(&c.outflows[i])
+	}
+	n := len(c.bins)
+	c.entries = make([]int64, n)
+	c.sumw = make([]float64, n)
+	c.sumw2 = make([]float64, n)
+	for i := 0; i < n; i++ {
+		c.entries[i] = int64(dec.uvarint())
+		c.sumw[i] = dec.qf64(c.prec)
+		c.sumw2[i] = dec.qf64(c.prec)
+	}
+	c.ann = make(Annotation)
+	dec.binary(&c.ann)
+	return dec.err
+}
+
+// Compact2D is a compact, lossy, representation of a 2-dim histogram,
+// suitable for the long-term storage of large amounts of histograms.
+//
+// See Compact1D for a description of what is stored exactly and what is not.
+// The per-bin x, y and xy moments are recomputed from the bin centers when
+// the histogram is restored.
+type Compact2D struct {
+	prec     uint8 // number of stored mantissa bits
+	xedges   []Range
+	yedges   []Range
+	dist     Dist2D
+	outflows [8]Dist2D
+	entries  []int64
+	sumw     []float64
+	sumw2    []float64
+	ann      Annotation
+}
+
+// NewCompact2D returns a compact representation of the provided histogram,
+// with the relative error on the bin contents bounded by tol.
+// NewCompact2D panics if tol is not in the ]0, 1[ range.
+func NewCompact2D(h *H2D, tol float64) *Compact2D {
+	prec := compactPrec(tol)
+	c := &Compact2D{
+		prec:     prec,
+		xedges:   make([]Range, len(h.Binning.XEdges)),
+		yedges:   make([]Range, len(h.Binning.YEdges)),
+		dist:     h.Binning.Dist,
+		outflows: h.Binning.Outflows,
+		entries:  make([]int64, len(h.Binning.Bins)),
+		sumw:     make([]float64, len(h.Binning.Bins)),
+		sumw2:    make([]float64, len(h.Binning.Bins)),
+		ann:      h.Ann.clone(),
+	}
+	for i, bin := range h.Binning.XEdges {
+		c.xedges[i] = bin.Range
+	}
+	for i, bin := range h.Binning.YEdges {
+		c.yedges[i] = bin.Range
+	}
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		c.entries[i] = bin.Entries()
+		c.sumw[i] = compactRound(bin.SumW(), prec)
+		c.sumw2[i] = compactRound(bin.SumW2(), prec)
+	}
+	return c
+}
+
+// Tolerance returns the upper bound on the relative error of the bin
+// contents of the compacted histogram.
+func (c *Compact2D) Tolerance() float64 {
+	return compactTol(c.prec)
+}
+
+// H2D returns the 2-dim histogram restored from its compact representation.
+func (c *Compact2D) H2D() *H2D {
+	h := NewH2DFromEdges(compactEdges(c.xedges), compactEdges(c.yedges))
+	h.Binning.Dist = c.dist
+	h.Binning.Outflows = c.outflows
+	h.Ann = c.ann.clone()
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		xmid, ymid := bin.XYMid()
+		d0 := Dist0D{
+			N:     c.entries[i],
+			SumW:  c.sumw[i],
+			SumW2: c.sumw2[i],
+		}
+		bin.Dist.X.Dist = d0
+		bin.Dist.X.Stats.SumWX = c.sumw[i] * xmid
+		bin.Dist.X.Stats.SumWX2 = c.sumw[i] * xmid * xmid
+		bin.Dist.Y.Dist = d0
+		bin.Dist.Y.Stats.SumWX = c.sumw[i] * ymid
+		bin.Dist.Y.Stats.SumWX2 = c.sumw[i] * ymid * ymid
+		bin.Dist.Stats.SumWXY = c.sumw[i] * xmid * ymid
+	}
+	return h
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c *Compact2D) MarshalBinary() ([]byte, error) {
+	var enc compactEncoder
+	enc.u8(compactVersion)
+	enc.u8(c.prec)
+	enc.ranges(c.xedges)
+	enc.ranges(c.yedges)
+	err := enc.binary(&c.dist)
+	if err != nil {
+		return nil, err
+	}
+	for i := range c.outflows {
+		err = enc.binary(&c.outflows[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range c.entries {
+		enc.uvarint(uint64(c.entries[i]))
+		enc.qf64(c.sumw[i], c.prec)
+		enc.qf64(c.sumw2[i], c.prec)
+	}
+	err = enc.binary(&c.ann)
+	if err != nil {
+		return nil, err
+	}
+	return enc.buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *Compact2D) UnmarshalBinary(data []byte) error {
+	dec := compactDecoder{buf: data}
+	dec.version()
+	c.prec = dec.prec()
+	c.xedges = dec.ranges()
+	c.yedges = dec.ranges()
+	dec.binary(&c.dist)
+	for i := range c.outflows {
+		dec.binary(&c.outflows[i])
+	}
+	n := len(c.xedges) * len(c.yedges)
+	c.entries = make([]int64, n)
+	c.sumw = make([]float64, n)
+	c.sumw2 = make([]float64, n)
+	for i := 0; i < n; i++ {
+		c.entries[i] = int64(dec.uvarint())
+		c.sumw[i] = dec.qf64(c.prec)
+		c.sumw2[i] = dec.qf64(c.prec)
+	}
+	c.ann = make(Annotation)
+	dec.binary(&c.ann)
+	return dec.err
+}
+
+// compactPrec returns the number of mantissa bits needed to represent
+// floating point values with a relative error bounded by tol.
+func compactPrec(tol float64) uint8 {
+	if !(0 < tol && tol < 1) {
+		panic(fmt.Errorf("hbook: invalid compaction tolerance %v", tol))
+	}
+	// rounding to nearest with m mantissa bits yields a relative error
+	// of at most 2^-(m+1).
+	m := math.Ceil(-math.Log2(tol)) - 1
+	switch {
+	case m < 0:
+		m = 0
+	case m > 52:
+		m = 52
+	}
+	return uint8(m)
+}
+
+// compactTol returns the upper bound on the relative error of values
+// stored with prec mantissa bits.
+func compactTol(prec uint8) float64 {
+	return math.Ldexp(1, -int(prec)-1)
+}
+
+// compactBits returns the bits of v, rounded to nearest and truncated to
+// prec mantissa bits.
+func compactBits(v float64, prec uint8) uint64 {
+	shift := 52 - uint(prec)
+	bits := math.Float64bits(v)
+	if shift == 0 {
+		return bits
+	}
+	sign := bits & (1 << 63)
+	bits &^= 1 << 63
+	bits += 1 << (shift - 1) // round to nearest; may carry into the exponent.
+	bits >>= shift
+	return sign>>shift | bits
+}
+
+func compactRound(v float64, prec uint8) float64 {
+	return math.Float64frombits(compactBits(v, prec) << (52 - uint(prec)))
+}
+
+// compactEdges returns the edges of the provided contiguous ranges.
+func compactEdges(rs []Range) []float64 {
+	edges := make([]float64, len(rs)+1)
+	for i, r := range rs {
+		edges[i] = r.Min
+	}
+	edges[len(rs)] = rs[len(rs)-1].Max
+	return edges
+}
+
+// Kinds of binning encodings.
+const (
+	compactBinsUniform = iota // n equal-width bins: n, min, max
+	compactBinsEdges          // n contiguous bins: n, n+1 edges
+	compactBinsRanges         // n bins: n, n ranges
+)
+
+type compactEncoder struct {
+	buf []byte
+}
+
+func (enc *compactEncoder) u8(v uint8) {
+	enc.buf = append(enc.buf, v)
+}
+
+func (enc *compactEncoder) uvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	enc.buf = append(enc.buf, buf[:n]...)
+}
+
+func (enc *compactEncoder) f64(v float64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+	enc.buf = append(enc.buf, buf[:]...)
+}
+
+// qf64 encodes a value rounded to prec mantissa bits.
+// Zero values are encoded with a single byte.
+func (enc *compactEncoder) qf64(v float64, prec uint8) {
+	enc.uvarint(compactBits(v, prec))
+}
+
+func (enc *compactEncoder) ranges(rs []Range) {
+	n := len(rs)
+	enc.uvarint(uint64(n))
+	if n == 0 {
+		enc.u8(compactBinsRanges)
+		return
+	}
+
+	var (
+		uniform    = true
+		contiguous = true
+		xmin       = rs[0].Min
+		xmax       = rs[n-1].Max
+		width      = (xmax - xmin) / float64(n)
+	)
+	for i, r := range rs {
+		if i > 0 && r.Min != rs[i-1].Max {
+			contiguous = false
+			uniform = false
+			break
+		}
+		if r.Min != xmin+float64(i)*width || r.Max != xmin+float64(i+1)*width {
+			uniform = false
+		}
+	}
+
+	switch {
+	case uniform:
+		enc.u8(compactBinsUniform)
+		enc.f64(xmin)
+		enc.f64(xmax)
+	case contiguous:
+		enc.u8(compactBinsEdges)
+		for _, r := range rs {
+			enc.f64(r.Min)
+		}
+		enc.f64(xmax)
+	default:
+		enc.u8(compactBinsRanges)
+		for _, r := range rs {
+			enc.f64(r.Min)
+			enc.f64(r.Max)
+		}
+	}
+}
+
+func (enc *compactEncoder) binary(v interface{ MarshalBinary() ([]byte, error) }) error {
+	sub, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	enc.uvarint(uint64(len(sub)))
+	enc.buf = append(enc.buf, sub...)
+	return nil
+}
+
+var errCompactShort = errors.New("hbook: short compact histogram buffer")
+
+type compactDecoder struct {
+	buf []byte
+	err error
+}
+
+func (dec *compactDecoder) version() {
+	vers := dec.u8()
+	if dec.err == nil && vers != compactVersion {
+		dec.err = fmt.Errorf("hbook: invalid compact histogram version %d", vers)
+	}
+}
+
+func (dec *compactDecoder) prec() uint8 {
+	prec := dec.u8()
+	if dec.err == nil && prec > 52 {
+		dec.err = fmt.Errorf("hbook: invalid compact histogram precision %d", prec)
+	}
+	return prec
+}
+
+func (dec *compactDecoder) u8() uint8 {
+	if dec.err != nil {
+		return 0
+	}
+	if len(dec.buf) < 1 {
+		dec.err = errCompactShort
+		return 0
+	}
+	v := dec.buf[0]
+	dec.buf = dec.buf[1:]
+	return v
+}
+
+func (dec *compactDecoder) uvarint() uint64 {
+	if dec.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(dec.buf)
+	if n <= 0 {
+		dec.err = errCompactShort
+		return 0
+	}
+	dec.buf = dec.buf[n:]
+	return v
+}
+
+func (dec *compactDecoder) f64() float64 {
+	if dec.err != nil {
+		return 0
+	}
+	if len(dec.buf) < 8 {
+		dec.err = errCompactShort
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(dec.buf[:8]))
+	dec.buf = dec.buf[8:]
+	return v
+}
+
+func (dec *compactDecoder) qf64(prec uint8) float64 {
+	bits := dec.uvarint()
+	return math.Float64frombits(bits << (52 - uint(prec)))
+}
+
+func (dec *compactDecoder) ranges() []Range {
+	n := int(dec.uvarint())
+	kind := dec.u8()
+	if dec.err != nil {
+		return nil
+	}
+	if n > len(dec.buf) {
+		dec.err = errCompactShort
+		return nil
+	}
+
+	rs := make([]Range, n)
+	switch kind {
+	case compactBinsUniform:
+		var (
+			xmin  = dec.f64()
+			xmax  = dec.f64()
+			width = (xmax - xmin) / float64(n)
+		)
+		for i := range rs {
+			rs[i] = Range{Min: xmin + float64(i)*width, Max: xmin + float64(i+1)*width}
+		}
+	case compactBinsEdges:
+		edge := dec.f64()
+		for i := range rs {
+			rs[i].Min = edge
+			edge = dec.f64()
+			rs[i].Max = edge
+		}
+	case compactBinsRanges:
+		for i := range rs {
+			rs[i].Min = dec.f64()
+			rs[i].Max = dec.f64()
+		}
+	default:
+		dec.err = fmt.Errorf("hbook: invalid compact binning kind %d", kind)
+		return nil
+	}
+	return rs
+}
+
+func (dec *compactDecoder) binary(v interface{ UnmarshalBinary([]byte) error }) {
+	n := dec.uvarint()
+	if dec.err != nil {
+		return
+	}
+	if uint64(len(dec.buf)) < n {
+		dec.err = errCompactShort
+		return
+	}
+	dec.err = v.UnmarshalBinary(dec.buf[:n])
+	dec.buf = dec.buf[n:]
+}
@@ -0,0 +1,195 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCompact1D(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+
+	for _, tc := range []struct {
+		name string
+		h    *H1D
+	}{
+		{
+			name: "uniform",
+			h:    NewH1D(100, -4, 4),
+		},
+		{
+			name: "edges",
+			h:    NewH1DFromEdges([]float64{-4, -2, -1, -0.5, 0, 0.5, 1, 2, 4}),
+		},
+		{
+			name: "bins",
+			h:    NewH1DFromBins(Range{-4, -1}, Range{0, 1}, Range{2, 4}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.h
+			h.Annotation()["name"] = "h1"
+			for i := 0; i < 100000; i++ {
+				h.Fill(rnd.NormFloat64(), rnd.Float64())
+			}
+
+			for _, tol := range []float64{0.1, 1e-2, 1e-3, 1e-6} {
+				c := NewCompact1D(h, tol)
+				if got := c.Tolerance(); got > tol {
+					t.Fatalf("tol=%v: invalid tolerance: %v", tol, got)
+				}
+
+				raw, err := c.MarshalBinary()
+				if err != nil {
+					t.Fatalf("tol=%v: could not marshal compact histogram: %+v", tol, err)
+				}
+
+				var cc Compact1D
+				err = cc.UnmarshalBinary(raw)
+				if err != nil {
+					t.Fatalf("tol=%v: could not unmarshal compact histogram: %+v", tol, err)
+				}
+
+				got := cc.H1D()
+				if got, want := got.Name(), h.Name(); got != want {
+					t.Fatalf("tol=%v: invalid name: got=%q, want=%q", tol, got, want)
+				}
+				if got, want := got.Entries(), h.Entries(); got != want {
+					t.Fatalf("tol=%v: invalid entries: got=%d, want=%d", tol, got, want)
+				}
+				if got, want := got.XMean(), h.XMean(); got != want {
+					t.Fatalf("tol=%v: invalid mean: got=%v, want=%v", tol, got, want)
+				}
+				if len(got.Binning.Bins) != len(h.Binning.Bins) {
+					t.Fatalf("tol=%v: invalid number of bins", tol)
+				}
+				for i := range h.Binning.Bins {
+					var (
+						gbin = &got.Binning.Bins[i]
+						wbin = &h.Binning.Bins[i]
+					)
+					if gbin.Range != wbin.Range {
+						t.Fatalf("tol=%v: bin %d: invalid range: got=%v, want=%v", tol, i, gbin.Range, wbin.Range)
+					}
+					if got, want := gbin.Entries(), wbin.Entries(); got != want {
+						t.Fatalf("tol=%v: bin %d: invalid entries: got=%d, want=%d", tol, i, got, want)
+					}
+					for _, v := range []struct {
+						name      string
+						got, want float64
+					}{
+						{"sumw", gbin.SumW(), wbin.SumW()},
+						{"sumw2", gbin.SumW2(), wbin.SumW2()},
+					} {
+						if diff := math.Abs(v.got - v.want); diff > tol*math.Abs(v.want) {
+							t.Fatalf("tol=%v: bin %d: invalid %s: got=%v, want=%v", tol, i, v.name, v.got, v.want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCompact1DSize(t *testing.T) {
+	h := NewH1D(1000, 0, 1000)
+	for i := 0; i < 200; i++ {
+		h.Fill(float64(i), 1)
+	}
+
+	full, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal histogram: %+v", err)
+	}
+
+	raw, err := NewCompact1D(h, 1e-2).MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal compact histogram: %+v", err)
+	}
+
+	if got, max := len(raw), len(full)/10; got > max {
+		t.Fatalf("compact histogram too large: got=%d, full=%d", got, len(full))
+	}
+}
+
+func TestCompact2D(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+
+	h := NewH2D(20, -4, 4, 30, -5, 5)
+	h.Annotation()["name"] = "h2"
+	for i := 0; i < 100000; i++ {
+		h.Fill(rnd.NormFloat64(), 2*rnd.NormFloat64(), rnd.Float64())
+	}
+
+	const tol = 1e-3
+	raw, err := NewCompact2D(h, tol).MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal compact histogram: %+v", err)
+	}
+
+	var c Compact2D
+	err = c.UnmarshalBinary(raw)
+	if err != nil {
+		t.Fatalf("could not unmarshal compact histogram: %+v", err)
+	}
+
+	got := c.H2D()
+	if got, want := got.Name(), h.Name(); got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := got.Entries(), h.Entries(); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := got.XMean(), h.XMean(); got != want {
+		t.Fatalf("invalid x-mean: got=%v, want=%v", got, want)
+	}
+	if got, want := got.YMean(), h.YMean(); got != want {
+		t.Fatalf("invalid y-mean: got=%v, want=%v", got, want)
+	}
+	if got, want := got.Binning.Outflows, h.Binning.Outflows; got != want {
+		t.Fatalf("invalid outflows:\ngot= %v\nwant=%v", got, want)
+	}
+	for i := range h.Binning.Bins {
+		var (
+			gbin = &got.Binning.Bins[i]
+			wbin = &h.Binning.Bins[i]
+		)
+		if gbin.XRange != wbin.XRange || gbin.YRange != wbin.YRange {
+			t.Fatalf("bin %d: invalid ranges", i)
+		}
+		if got, want := gbin.Entries(), wbin.Entries(); got != want {
+			t.Fatalf("bin %d: invalid entries: got=%d, want=%d", i, got, want)
+		}
+		if diff := math.Abs(gbin.SumW() - wbin.SumW()); diff > tol*math.Abs(wbin.SumW()) {
+			t.Fatalf("bin %d: invalid sumw: got=%v, want=%v", i, gbin.SumW(), wbin.SumW())
+		}
+		if diff := math.Abs(gbin.SumW2() - wbin.SumW2()); diff > tol*math.Abs(wbin.SumW2()) {
+			t.Fatalf("bin %d: invalid sumw2: got=%v, want=%v", i, gbin.SumW2(), wbin.SumW2())
+		}
+	}
+}
+
+func TestCompactInvalid(t *testing.T) {
+	for _, tol := range []float64{0, -1, 1, 2, math.NaN()} {
+		panicked, _ := panics(func() { NewCompact1D(NewH1D(10, 0, 1), tol) })
+		if !panicked {
+			t.Fatalf("tol=%v: expected a panic", tol)
+		}
+	}
+
+	raw, err := NewCompact1D(NewH1D(10, 0, 1), 1e-2).MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal compact histogram: %+v", err)
+	}
+	for _, n := range []int{0, 1, 5, len(raw) / 2, len(raw) - 1} {
+		var c Compact1D
+		err := c.UnmarshalBinary(raw[:n])
+		if err == nil {
+			t.Fatalf("n=%d: expected an error", n)
+		}
+	}
+}
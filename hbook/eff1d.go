@@ -0,0 +1,143 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"math"
+)
+
+// Eff1D is a 1-dim efficiency, computed from the weighted numbers of
+// events passing a selection and the weighted total numbers of events,
+// in bins of x.
+//
+// Eff1D can be converted to a ROOT TEfficiency with the groot/rhist
+// package.
+type Eff1D struct {
+	pass *H1D // weighted numbers of events passing the selection
+	tot  *H1D // weighted total numbers of events
+	ann  Annotation
+}
+
+// NewEff1D creates a new 1-dim efficiency with n bins between xmin and xmax.
+func NewEff1D(n int, xmin, xmax float64) *Eff1D {
+	return &Eff1D{
+		pass: NewH1D(n, xmin, xmax),
+		tot:  NewH1D(n, xmin, xmax),
+		ann:  make(Annotation),
+	}
+}
+
+// NewEff1DFromEdges creates a new 1-dim efficiency from a slice of edges.
+// NewEff1DFromEdges panics under the same conditions than NewH1DFromEdges.
+func NewEff1DFromEdges(edges []float64) *Eff1D {
+	return &Eff1D{
+		pass: NewH1DFromEdges(edges),
+		tot:  NewH1DFromEdges(edges),
+		ann:  make(Annotation),
+	}
+}
+
+// NewEff1DFromH1D creates a new 1-dim efficiency from the histograms
+// of passing and total events.
+// NewEff1DFromH1D returns an error if the binnings of the histograms are
+// not compatible.
+// The histograms are not copied.
+func NewEff1DFromH1D(pass, tot *H1D) (*Eff1D, error) {
+	if !sameBinning1D(&pass.Binning, &tot.Binning) {
+		return nil, fmt.Errorf("hbook: incompatible binnings for passed and total histograms")
+	}
+	return &Eff1D{
+		pass: pass,
+		tot:  tot,
+		ann:  make(Annotation),
+	}, nil
+}
+
+// Name returns the name of this efficiency, if any.
+func (e *Eff1D) Name() string {
+	v, ok := e.ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this efficiency.
+func (e *Eff1D) Annotation() Annotation {
+	return e.ann
+}
+
+// Passed returns the histogram of weighted events passing the selection.
+func (e *Eff1D) Passed() *H1D {
+	return e.pass
+}
+
+// Total returns the histogram of weighted total events.
+func (e *Eff1D) Total() *H1D {
+	return e.tot
+}
+
+// Fill fills this efficiency with an event at x, weighted by w,
+// and passing (or not) the selection.
+func (e *Eff1D) Fill(x float64, pass bool, w float64) {
+	e.tot.Fill(x, w)
+	if pass {
+		e.pass.Fill(x, w)
+	}
+}
+
+// Eff returns the efficiency and its binomial uncertainty for the bin
+// containing x.
+// Eff returns NaN values if x is outside the binning or if the bin
+// is empty.
+func (e *Eff1D) Eff(x float64) (eff, err float64) {
+	idx := e.tot.Binning.coordToIndex(x)
+	if idx < 0 {
+		return math.NaN(), math.NaN()
+	}
+	var (
+		pass = &e.pass.Binning.Bins[idx].Dist.Dist
+		tot  = &e.tot.Binning.Bins[idx].Dist.Dist
+	)
+	if tot.SumW == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return effBinomial(pass.SumW, pass.SumW2, tot.SumW, tot.SumW2)
+}
+
+// S2D returns the efficiencies of the non-empty bins as a 2-dim scatter.
+// The uncertainties are binomial ones, unless another error treatment is
+// requested with the DivBayesian option.
+func (e *Eff1D) S2D(opts ...DivOptions) *S2D {
+	opts = append([]DivOptions{DivBinomial(), DivIgnoreNaNs()}, opts...)
+	s, err := DivideH1D(e.pass, e.tot, opts...)
+	if err != nil {
+		// binnings have been checked at creation time.
+		panic(err)
+	}
+	s.ann = make(Annotation, len(e.ann))
+	for k, v := range e.ann {
+		s.ann[k] = v
+	}
+	return s
+}
+
+// sameBinning1D returns whether the two binnings have the same bins.
+func sameBinning1D(a, b *Binning1D) bool {
+	if len(a.Bins) != len(b.Bins) {
+		return false
+	}
+	for i := range a.Bins {
+		if a.Bins[i].Range != b.Bins[i].Range {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestEff1D(t *testing.T) {
+	const eps = 1e-12
+
+	e := NewEff1D(3, 0, 3)
+	e.Annotation()["name"] = "eff"
+	if got, want := e.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	// bin 0: 4 events, 3 passing.
+	for i := 0; i < 4; i++ {
+		e.Fill(0.5, i < 3, 1)
+	}
+	// bin 1: 4 events, none passing.
+	for i := 0; i < 4; i++ {
+		e.Fill(1.5, false, 1)
+	}
+	// outside of the binning.
+	e.Fill(-1, true, 1)
+
+	for _, tc := range []struct {
+		x   float64
+		eff float64
+		err float64
+	}{
+		{0.5, 0.75, math.Sqrt(0.75 * 0.25 / 4)},
+		{1.5, 0, 0},
+		{2.5, math.NaN(), math.NaN()},
+		{-1, math.NaN(), math.NaN()},
+	} {
+		eff, err := e.Eff(tc.x)
+		if !cmpEff(eff, tc.eff, eps) || !cmpEff(err, tc.err, eps) {
+			t.Fatalf("invalid efficiency at x=%v: got=(%v, %v), want=(%v, %v)", tc.x, eff, err, tc.eff, tc.err)
+		}
+	}
+
+	s := e.S2D()
+	if got, want := s.Name(), "eff"; got != want {
+		t.Fatalf("invalid scatter name: got=%q, want=%q", got, want)
+	}
+	if got, want := s.Len(), 2; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+	if got, want := s.Point(0), (Point2D{
+		X: 0.5, Y: 0.75,
+		ErrX: Range{Min: 0.5, Max: 0.5},
+		ErrY: Range{Min: math.Sqrt(0.75 * 0.25 / 4), Max: math.Sqrt(0.75 * 0.25 / 4)},
+	}); got != want {
+		t.Fatalf("invalid point: got=%+v, want=%+v", got, want)
+	}
+
+	const cl = 0.682689492137
+	s = e.S2D(DivBayesian(cl, 1, 1))
+	for i, tc := range []struct {
+		eff    float64
+		lo, hi float64
+	}{
+		{
+			eff: 0.75,
+			lo:  distuv.Beta{Alpha: 4, Beta: 2}.Quantile(0.5 * (1 - cl)),
+			hi:  distuv.Beta{Alpha: 4, Beta: 2}.Quantile(0.5 * (1 + cl)),
+		},
+		{
+			eff: 0,
+			lo:  0,
+			hi:  distuv.Beta{Alpha: 1, Beta: 5}.Quantile(0.5 * (1 + cl)),
+		},
+	} {
+		pt := s.Point(i)
+		if !cmpEff(pt.Y, tc.eff, eps) ||
+			!cmpEff(pt.Y-pt.ErrY.Min, tc.lo, eps) ||
+			!cmpEff(pt.Y+pt.ErrY.Max, tc.hi, eps) {
+			t.Fatalf("invalid bayesian point %d: got=%+v, want=(%v, [%v, %v])", i, pt, tc.eff, tc.lo, tc.hi)
+		}
+	}
+
+	_, err := NewEff1DFromH1D(NewH1D(2, 0, 2), NewH1D(3, 0, 2))
+	if err == nil {
+		t.Fatalf("expected an error for incompatible binnings")
+	}
+
+	o, err := NewEff1DFromH1D(e.Passed(), e.Total())
+	if err != nil {
+		t.Fatalf("could not create efficiency: %+v", err)
+	}
+	if got, _ := o.Eff(0.5); got != 0.75 {
+		t.Fatalf("invalid efficiency: got=%v", got)
+	}
+}
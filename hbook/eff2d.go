@@ -0,0 +1,340 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"math"
+)
+
+// Eff2D is a 2-dim efficiency map, computed from the weighted numbers
+// of events passing a selection and the weighted total numbers of events,
+// in bins of (x,y).
+//
+// Eff2D is typically used to compute trigger or identification
+// efficiency maps and scale-factors.
+type Eff2D struct {
+	pass *H2D // weighted numbers of events passing the selection
+	tot  *H2D // weighted total numbers of events
+	ann  Annotation
+}
+
+// NewEff2D creates a new 2-dim efficiency map.
+func NewEff2D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64) *Eff2D {
+	return &Eff2D{
+		pass: NewH2D(nx, xlow, xhigh, ny, ylow, yhigh),
+		tot:  NewH2D(nx, xlow, xhigh, ny, ylow, yhigh),
+		ann:  make(Annotation),
+	}
+}
+
+// NewEff2DFromEdges creates a new 2-dim efficiency map from slices
+// of edges in x and y.
+// NewEff2DFromEdges panics under the same conditions than NewH2DFromEdges.
+func NewEff2DFromEdges(xedges, yedges []float64) *Eff2D {
+	return &Eff2D{
+		pass: NewH2DFromEdges(xedges, yedges),
+		tot:  NewH2DFromEdges(xedges, yedges),
+		ann:  make(Annotation),
+	}
+}
+
+// NewEff2DFromH2D creates a new 2-dim efficiency map from the histograms
+// of passing and total events.
+// NewEff2DFromH2D returns an error if the binnings of the histograms are
+// not compatible.
+// The histograms are not copied.
+func NewEff2DFromH2D(pass, tot *H2D) (*Eff2D, error) {
+	if !sameBinning2D(&pass.Binning, &tot.Binning) {
+		return nil, fmt.Errorf("hbook: incompatible binnings for passed and total histograms")
+	}
+	return &Eff2D{
+		pass: pass,
+		tot:  tot,
+		ann:  make(Annotation),
+	}, nil
+}
+
+// Name returns the name of this efficiency map, if any.
+func (e *Eff2D) Name() string {
+	v, ok := e.ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this efficiency map.
+func (e *Eff2D) Annotation() Annotation {
+	return e.ann
+}
+
+// Passed returns the histogram of weighted events passing the selection.
+func (e *Eff2D) Passed() *H2D {
+	return e.pass
+}
+
+// Total returns the histogram of weighted total events.
+func (e *Eff2D) Total() *H2D {
+	return e.tot
+}
+
+// Fill fills this efficiency map with an event at (x,y), weighted by w,
+// and passing (or not) the selection.
+func (e *Eff2D) Fill(x, y float64, pass bool, w float64) {
+	e.tot.Fill(x, y, w)
+	if pass {
+		e.pass.Fill(x, y, w)
+	}
+}
+
+// Eff returns the efficiency and its uncertainty for the bin
+// containing (x,y).
+// Eff returns NaN values if (x,y) is outside the map or if the bin
+// is empty.
+func (e *Eff2D) Eff(x, y float64) (eff, err float64) {
+	idx := e.tot.Binning.coordToIndex(x, y)
+	if idx < 0 || idx >= len(e.tot.Binning.Bins) {
+		return math.NaN(), math.NaN()
+	}
+	return e.eff(idx)
+}
+
+// eff returns the efficiency and its uncertainty for the i-th bin.
+//
+// The uncertainty is the binomial uncertainty, generalized to weighted
+// events:
+//  var = ((1-2 eff) \sum w^2_pass + eff^2 \sum w^2_tot) / (\sum w_tot)^2
+func (e *Eff2D) eff(i int) (float64, float64) {
+	var (
+		pass = &e.pass.Binning.Bins[i].Dist.X.Dist
+		tot  = &e.tot.Binning.Bins[i].Dist.X.Dist
+	)
+	if tot.SumW == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return effBinomial(pass.SumW, pass.SumW2, tot.SumW, tot.SumW2)
+}
+
+// EffPoint2D is the efficiency of a bin of an efficiency map.
+type EffPoint2D struct {
+	X, Y       float64 // center of the bin
+	ErrX, ErrY float64 // half-widths of the bin
+	Eff        float64 // efficiency
+	Err        float64 // uncertainty on the efficiency
+}
+
+// Points returns the efficiencies of the non-empty bins of this
+// efficiency map.
+func (e *Eff2D) Points() []EffPoint2D {
+	pts := make([]EffPoint2D, 0, len(e.tot.Binning.Bins))
+	for i := range e.tot.Binning.Bins {
+		bin := &e.tot.Binning.Bins[i]
+		if bin.SumW() == 0 {
+			continue
+		}
+		eff, err := e.eff(i)
+		pts = append(pts, EffPoint2D{
+			X:    bin.XMid(),
+			Y:    bin.YMid(),
+			ErrX: 0.5 * bin.XWidth(),
+			ErrY: 0.5 * bin.YWidth(),
+			Eff:  eff,
+			Err:  err,
+		})
+	}
+	return pts
+}
+
+// Map returns the efficiency map as a 2-dim histogram, where the sum of
+// weights of each bin holds the efficiency and the sum of squared weights
+// holds the squared uncertainty.
+// Empty bins have a zero efficiency.
+func (e *Eff2D) Map() *H2D {
+	var (
+		bng = &e.tot.Binning
+		h   = &H2D{
+			Binning: Binning2D{
+				Bins:   make([]Bin2D, len(bng.Bins)),
+				XRange: bng.XRange,
+				YRange: bng.YRange,
+				Nx:     bng.Nx,
+				Ny:     bng.Ny,
+				XEdges: make([]Bin1D, len(bng.XEdges)),
+				YEdges: make([]Bin1D, len(bng.YEdges)),
+			},
+			Ann: make(Annotation),
+		}
+	)
+	copy(h.Binning.XEdges, bng.XEdges)
+	copy(h.Binning.YEdges, bng.YEdges)
+	for k, v := range e.ann {
+		h.Ann[k] = v
+	}
+
+	for i := range bng.Bins {
+		bin := &h.Binning.Bins[i]
+		bin.XRange = bng.Bins[i].XRange
+		bin.YRange = bng.Bins[i].YRange
+
+		eff, err := e.eff(i)
+		if math.IsNaN(eff) {
+			continue
+		}
+		x, y := bin.XYMid()
+		bin.Dist.fill(x, y, eff)
+		bin.Dist.X.Dist.N = bng.Bins[i].Entries()
+		bin.Dist.X.Dist.SumW2 = err * err
+		bin.Dist.Y.Dist = bin.Dist.X.Dist
+		h.Binning.Dist.addScaled(1, 1, bin.Dist)
+	}
+
+	return h
+}
+
+// Smooth returns a new efficiency map, where the passed and total
+// numbers of events of each bin are replaced with their average over
+// the neighbouring bins, weighted with a gaussian kernel of widths sx and
+// sy (in units of bins.)
+// The kernel is truncated at 3 standard deviations.
+// A width of zero disables the smoothing along the corresponding axis.
+//
+// Smoothing the numbers of events rather than the efficiencies preserves
+// the statistical weight of each bin.
+func (e *Eff2D) Smooth(sx, sy float64) *Eff2D {
+	if sx < 0 || sy < 0 {
+		panic(fmt.Errorf("hbook: invalid negative smoothing widths (sx=%v, sy=%v)", sx, sy))
+	}
+	var (
+		nx = int(math.Ceil(3 * sx))
+		ny = int(math.Ceil(3 * sy))
+		kx = gaussKernel(nx, sx)
+		ky = gaussKernel(ny, sy)
+	)
+
+	o := &Eff2D{
+		pass: smoothH2D(e.pass, kx, ky),
+		tot:  smoothH2D(e.tot, kx, ky),
+		ann:  make(Annotation),
+	}
+	for k, v := range e.ann {
+		o.ann[k] = v
+	}
+	return o
+}
+
+// gaussKernel returns the 2n+1 values of a gaussian kernel with width sigma.
+func gaussKernel(n int, sigma float64) []float64 {
+	k := make([]float64, 2*n+1)
+	if sigma == 0 {
+		k[n] = 1
+		return k
+	}
+	for i := range k {
+		x := float64(i-n) / sigma
+		k[i] = math.Exp(-0.5 * x * x)
+	}
+	return k
+}
+
+// smoothH2D returns a copy of h, where each bin is replaced with the
+// kernel-weighted average of its neighbours.
+func smoothH2D(h *H2D, kx, ky []float64) *H2D {
+	var (
+		bng = &h.Binning
+		o   = &H2D{
+			Binning: Binning2D{
+				Bins:     make([]Bin2D, len(bng.Bins)),
+				Outflows: bng.Outflows,
+				XRange:   bng.XRange,
+				YRange:   bng.YRange,
+				Nx:       bng.Nx,
+				Ny:       bng.Ny,
+				XEdges:   make([]Bin1D, len(bng.XEdges)),
+				YEdges:   make([]Bin1D, len(bng.YEdges)),
+			},
+			Ann: make(Annotation),
+		}
+		nx = len(kx) / 2
+		ny = len(ky) / 2
+	)
+	copy(o.Binning.XEdges, bng.XEdges)
+	copy(o.Binning.YEdges, bng.YEdges)
+	for k, v := range h.Ann {
+		o.Ann[k] = v
+	}
+
+	for iy := 0; iy < bng.Ny; iy++ {
+		for ix := 0; ix < bng.Nx; ix++ {
+			var (
+				i    = iy*bng.Nx + ix
+				bin  = &o.Binning.Bins[i]
+				sumw = 0.0 // sum of kernel weights
+				sumn = 0.0 // kernel-weighted number of entries
+			)
+			bin.XRange = bng.Bins[i].XRange
+			bin.YRange = bng.Bins[i].YRange
+			for jy := iy - ny; jy <= iy+ny; jy++ {
+				if jy < 0 || jy >= bng.Ny {
+					continue
+				}
+				for jx := ix - nx; jx <= ix+nx; jx++ {
+					if jx < 0 || jx >= bng.Nx {
+						continue
+					}
+					k := kx[jx-ix+nx] * ky[jy-iy+ny]
+					sumw += k
+				}
+			}
+			for jy := iy - ny; jy <= iy+ny; jy++ {
+				if jy < 0 || jy >= bng.Ny {
+					continue
+				}
+				for jx := ix - nx; jx <= ix+nx; jx++ {
+					if jx < 0 || jx >= bng.Nx {
+						continue
+					}
+					var (
+						k   = kx[jx-ix+nx] * ky[jy-iy+ny] / sumw
+						src = bng.Bins[jy*bng.Nx+jx].Dist
+					)
+					bin.Dist.addScaled(k, k*k, src)
+					sumn += k * float64(src.Entries())
+				}
+			}
+			n := int64(math.Round(sumn))
+			bin.Dist.X.Dist.N = n
+			bin.Dist.Y.Dist.N = n
+			o.Binning.Dist.addScaled(1, 1, bin.Dist)
+		}
+	}
+	for _, d := range bng.Outflows {
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+
+	return o
+}
+
+// sameBinning2D returns whether the two binnings have the same bins.
+func sameBinning2D(a, b *Binning2D) bool {
+	if a.Nx != b.Nx || a.Ny != b.Ny {
+		return false
+	}
+	for i := range a.XEdges {
+		if a.XEdges[i].Range != b.XEdges[i].Range {
+			return false
+		}
+	}
+	for i := range a.YEdges {
+		if a.YEdges[i].Range != b.YEdges[i].Range {
+			return false
+		}
+	}
+	return true
+}
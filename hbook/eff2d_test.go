@@ -0,0 +1,155 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEff2D(t *testing.T) {
+	const eps = 1e-12
+
+	e := NewEff2D(2, 0, 2, 2, 0, 2)
+	e.Annotation()["name"] = "eff"
+	if got, want := e.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	// bin (0,0): 3 events, 2 passing.
+	e.Fill(0.5, 0.5, true, 1)
+	e.Fill(0.5, 0.5, true, 1)
+	e.Fill(0.5, 0.5, false, 1)
+	// bin (1,0): weighted events.
+	e.Fill(1.5, 0.5, true, 2)
+	e.Fill(1.5, 0.5, false, 1)
+	// bin (1,1): all events passing.
+	e.Fill(1.5, 1.5, true, 1)
+	// outside of the map.
+	e.Fill(-1, -1, true, 1)
+
+	for _, tc := range []struct {
+		x, y float64
+		eff  float64
+		err  float64
+	}{
+		{0.5, 0.5, 2. / 3., math.Sqrt(2. / 3. * 1. / 3. / 3.)},
+		{1.5, 0.5, 2. / 3., math.Sqrt(((1-4./3.)*4 + 4./9.*5) / 9)},
+		{1.5, 1.5, 1, 0},
+		{0.5, 1.5, math.NaN(), math.NaN()},
+		{-1, -1, math.NaN(), math.NaN()},
+	} {
+		eff, err := e.Eff(tc.x, tc.y)
+		if !cmpEff(eff, tc.eff, eps) || !cmpEff(err, tc.err, eps) {
+			t.Fatalf("invalid efficiency at (%v,%v): got=(%v, %v), want=(%v, %v)", tc.x, tc.y, eff, err, tc.eff, tc.err)
+		}
+	}
+
+	pts := e.Points()
+	if got, want := len(pts), 3; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+	if got, want := pts[1], (EffPoint2D{X: 1.5, Y: 0.5, ErrX: 0.5, ErrY: 0.5, Eff: 2. / 3., Err: pts[1].Err}); got != want {
+		t.Fatalf("invalid point: got=%+v, want=%+v", got, want)
+	}
+
+	m := e.Map()
+	if got, want := m.Name(), "eff"; got != want {
+		t.Fatalf("invalid map name: got=%q, want=%q", got, want)
+	}
+	for i, pt := range pts {
+		bin := m.Bin(pt.X, pt.Y)
+		if !cmpEff(bin.SumW(), pt.Eff, eps) || !cmpEff(math.Sqrt(bin.SumW2()), pt.Err, eps) {
+			t.Fatalf("invalid map bin %d: got=(%v, %v), want=(%v, %v)", i, bin.SumW(), math.Sqrt(bin.SumW2()), pt.Eff, pt.Err)
+		}
+	}
+	if got, want := m.Bin(0.5, 0.5).Entries(), int64(3); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	if got, want := m.Bin(0.5, 1.5).SumW(), 0.0; got != want {
+		t.Fatalf("invalid empty bin: got=%v, want=%v", got, want)
+	}
+
+	_, err := NewEff2DFromH2D(NewH2D(2, 0, 2, 2, 0, 2), NewH2D(2, 0, 2, 3, 0, 2))
+	if err == nil {
+		t.Fatalf("expected an error for incompatible binnings")
+	}
+
+	o, err := NewEff2DFromH2D(e.Passed(), e.Total())
+	if err != nil {
+		t.Fatalf("could not create efficiency map: %+v", err)
+	}
+	if got, _ := o.Eff(0.5, 0.5); got != 2./3. {
+		t.Fatalf("invalid efficiency: got=%v", got)
+	}
+}
+
+func TestEff2DSmooth(t *testing.T) {
+	const eps = 1e-12
+
+	e := NewEff2D(5, 0, 5, 5, 0, 5)
+	for ix := 0; ix < 5; ix++ {
+		for iy := 0; iy < 5; iy++ {
+			x := float64(ix) + 0.5
+			y := float64(iy) + 0.5
+			for i := 0; i < 10; i++ {
+				e.Fill(x, y, i < 2*ix, 1)
+			}
+		}
+	}
+
+	// no smoothing.
+	s := e.Smooth(0, 0)
+	for _, pt := range e.Points() {
+		got, _ := s.Eff(pt.X, pt.Y)
+		if !cmpEff(got, pt.Eff, eps) {
+			t.Fatalf("invalid efficiency at (%v,%v): got=%v, want=%v", pt.X, pt.Y, got, pt.Eff)
+		}
+	}
+
+	// smoothing along y only: efficiencies do not depend on y.
+	s = e.Smooth(0, 1)
+	for _, pt := range e.Points() {
+		got, _ := s.Eff(pt.X, pt.Y)
+		if !cmpEff(got, pt.Eff, eps) {
+			t.Fatalf("invalid efficiency at (%v,%v): got=%v, want=%v", pt.X, pt.Y, got, pt.Eff)
+		}
+	}
+
+	// smoothing along x: efficiencies are linear in x, and thus
+	// unchanged away from the edges, with a smaller uncertainty.
+	s = e.Smooth(0.5, 0)
+	{
+		var (
+			got, gerr  = s.Eff(2.5, 2.5)
+			want, werr = e.Eff(2.5, 2.5)
+		)
+		if !cmpEff(got, want, 1e-6) {
+			t.Fatalf("invalid efficiency: got=%v, want=%v", got, want)
+		}
+		if gerr >= werr {
+			t.Fatalf("invalid uncertainty: got=%v, want<%v", gerr, werr)
+		}
+	}
+	if got, want := s.Total().SumW(), e.Total().SumW(); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("invalid total sum of weights: got=%v, want=%v", got, want)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		_ = e.Smooth(-1, 0)
+	}()
+}
+
+func cmpEff(a, b, eps float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) <= eps
+}
@@ -79,6 +79,35 @@ func (h *H1D) Annotation() Annotation {
 	return h.Ann
 }
 
+// XAxis returns the metadata of the X-axis of this histogram.
+func (h *H1D) XAxis() Axis {
+	return h.Ann.axis("X")
+}
+
+// SetXAxis sets the metadata of the X-axis of this histogram.
+// SetXAxis panics if the axis has bin labels and their number is not the
+// number of bins of this histogram.
+func (h *H1D) SetXAxis(ax Axis) {
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	h.Ann.setAxis("X", len(h.Binning.Bins), ax)
+}
+
+// YAxis returns the metadata of the Y-axis of this histogram.
+func (h *H1D) YAxis() Axis {
+	return h.Ann.axis("Y")
+}
+
+// SetYAxis sets the metadata of the Y-axis of this histogram.
+// SetYAxis panics if the axis has bin labels.
+func (h *H1D) SetYAxis(ax Axis) {
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	h.Ann.setAxis("Y", 0, ax)
+}
+
 // Rank returns the number of dimensions for this histogram
 func (h *H1D) Rank() int {
 	return 1
@@ -473,6 +502,55 @@ func (h *H1D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalYODA2 marshals the histogram into the text format of YODA2
+// (YODA_HISTO1D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (h *H1D) MarshalYODA2() ([]byte, error) {
+	return h.marshalYODAv3()
+}
+
+func (h *H1D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO1D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	rngs := make([]Range, len(h.Binning.Bins))
+	for i, bin := range h.Binning.Bins {
+		rngs[i] = bin.Range
+	}
+	edges, gaps := yodaEdges(rngs)
+	writeYODAEdges(buf, 1, edges)
+	writeYODAMasked(buf, gaps)
+
+	fmt.Fprintf(buf, "# Mean: %e\n", h.XMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", h.Integral())
+
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t numEntries\n")
+	row := func(d Dist1D) {
+		writeYODARow(buf, d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), float64(d.Entries()))
+	}
+	row(h.Binning.Outflows[0])
+	bins := h.Binning.Bins
+	for i := 1; i < len(edges); i++ {
+		if len(gaps) > 0 && gaps[0] == i {
+			gaps = gaps[1:]
+			row(Dist1D{})
+			continue
+		}
+		row(bins[0].Dist)
+		bins = bins[1:]
+	}
+	row(h.Binning.Outflows[1])
+	fmt.Fprintf(buf, "END YODA_HISTO1D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
 func (h *H1D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
@@ -485,6 +563,8 @@ func (h *H1D) UnmarshalYODA(data []byte) error {
 		return h.unmarshalYODAv1(r)
 	case 2:
 		return h.unmarshalYODAv2(r)
+	case 3:
+		return h.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -728,6 +808,49 @@ scanLoop:
 	return err
 }
 
+func (h *H1D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	h.annFromYODA(ann)
+
+	err = blk.check(1, 5)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid H1D-YODA data: %w", err)
+	}
+
+	var (
+		edges = blk.edges[0]
+		n     = len(edges) - 1
+		dist  Dist1D
+		bins  = make([]Bin1D, 0, n)
+	)
+	for i, row := range blk.rows {
+		d := yodaDist1D(row)
+		dist.addScaled(1, 1, d)
+		if i == 0 || i == n+1 || blk.masked[i] {
+			continue
+		}
+		bins = append(bins, Bin1D{
+			Range: Range{Min: edges[i-1], Max: edges[i]},
+			Dist:  d,
+		})
+	}
+
+	h.Binning = Binning1D{
+		Bins: bins,
+		Dist: dist,
+		Outflows: [2]Dist1D{
+			yodaDist1D(blk.rows[0]),
+			yodaDist1D(blk.rows[n+1]),
+		},
+		XRange: Range{Min: edges[0], Max: edges[n]},
+	}
+	return nil
+}
+
 // Counts return a slice of Count, ignoring outerflow.
 // The low and high error is equal to 0.5 * sqrt(sum(w^2)).
 func (h *H1D) Counts() []Count {
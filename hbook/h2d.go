@@ -57,6 +57,50 @@ func (h *H2D) Annotation() Annotation {
 	return h.Ann
 }
 
+// XAxis returns the metadata of the X-axis of this histogram.
+func (h *H2D) XAxis() Axis {
+	return h.Ann.axis("X")
+}
+
+// SetXAxis sets the metadata of the X-axis of this histogram.
+// SetXAxis panics if the axis has bin labels and their number is not the
+// number of bins along X.
+func (h *H2D) SetXAxis(ax Axis) {
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	h.Ann.setAxis("X", h.Binning.Nx, ax)
+}
+
+// YAxis returns the metadata of the Y-axis of this histogram.
+func (h *H2D) YAxis() Axis {
+	return h.Ann.axis("Y")
+}
+
+// SetYAxis sets the metadata of the Y-axis of this histogram.
+// SetYAxis panics if the axis has bin labels and their number is not the
+// number of bins along Y.
+func (h *H2D) SetYAxis(ax Axis) {
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	h.Ann.setAxis("Y", h.Binning.Ny, ax)
+}
+
+// ZAxis returns the metadata of the Z-axis of this histogram.
+func (h *H2D) ZAxis() Axis {
+	return h.Ann.axis("Z")
+}
+
+// SetZAxis sets the metadata of the Z-axis of this histogram.
+// SetZAxis panics if the axis has bin labels.
+func (h *H2D) SetZAxis(ax Axis) {
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	h.Ann.setAxis("Z", 0, ax)
+}
+
 // Rank returns the number of dimensions for this histogram
 func (h *H2D) Rank() int {
 	return 2
@@ -239,6 +283,11 @@ func (h *H2D) YMax() float64 {
 	return h.Binning.yMax()
 }
 
+// Scale scales the content of each bin by the given factor.
+func (h *H2D) Scale(factor float64) {
+	h.Binning.scaleW(factor)
+}
+
 // Integral computes the integral of the histogram.
 //
 // Overflows are included in the computation.
@@ -408,6 +457,57 @@ func (h *H2D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalYODA2 marshals the histogram into the text format of YODA2
+// (YODA_HISTO2D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (h *H2D) MarshalYODA2() ([]byte, error) {
+	return h.marshalYODAv3()
+}
+
+func (h *H2D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO2D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	var (
+		bng = &h.Binning
+		nx  = bng.Nx
+		ny  = bng.Ny
+	)
+	writeYODAEdges(buf, 1, bin1DEdges(bng.XEdges))
+	writeYODAEdges(buf, 2, bin1DEdges(bng.YEdges))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e)\n", h.XMean(), h.YMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", h.Integral())
+
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t sumW(A2)\t sumW2(A2)\t sumW(A1,A2)\t numEntries\n")
+	for iy := 0; iy < ny+2; iy++ {
+		for ix := 0; ix < nx+2; ix++ {
+			var d Dist2D
+			ox, oy := yodaOutflow(ix, nx), yodaOutflow(iy, ny)
+			switch {
+			case ox == 0 && oy == 0:
+				d = bng.Bins[(iy-1)*nx+ix-1].Dist
+			case ix == yodaOutflowBin(ox, nx) && iy == yodaOutflowBin(oy, ny):
+				d = bng.Outflows[outflowIndex2D(ox, oy)]
+			}
+			writeYODARow(
+				buf,
+				d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(),
+				d.SumWXY(), float64(d.Entries()),
+			)
+		}
+	}
+	fmt.Fprintf(buf, "END YODA_HISTO2D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
 func (h *H2D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
@@ -420,6 +520,8 @@ func (h *H2D) UnmarshalYODA(data []byte) error {
 		return h.unmarshalYODAv1(r)
 	case 2:
 		return h.unmarshalYODAv2(r)
+	case 3:
+		return h.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -624,3 +726,39 @@ scanLoop:
 	}
 	return err
 }
+
+func (h *H2D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	h.annFromYODA(ann)
+
+	err = blk.check(2, 8)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid H2D-YODA data: %w", err)
+	}
+
+	var (
+		bng = newBinning2DFromEdges(blk.edges[0], blk.edges[1])
+		nx  = bng.Nx
+		ny  = bng.Ny
+	)
+	for i, row := range blk.rows {
+		var (
+			d      = yodaDist2D(row)
+			ix, iy = i % (nx + 2), i / (nx + 2)
+			ox, oy = yodaOutflow(ix, nx), yodaOutflow(iy, ny)
+		)
+		bng.Dist.addScaled(1, 1, d)
+		switch {
+		case ox == 0 && oy == 0:
+			bng.Bins[(iy-1)*nx+ix-1].Dist = d
+		default:
+			bng.Outflows[outflowIndex2D(ox, oy)].addScaled(1, 1, d)
+		}
+	}
+	h.Binning = bng
+	return nil
+}
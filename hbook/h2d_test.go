@@ -487,3 +487,28 @@ func TestH2DFillN(t *testing.T) {
 		h2.FillN(xs, ys, []float64{1})
 	}()
 }
+
+func TestH2DScale(t *testing.T) {
+	h := NewH2D(2, 0, 2, 2, 0, 2)
+	h.Fill(0.5, 0.5, 1)
+	h.Fill(1.5, 0.5, 2)
+	h.Fill(1.5, 1.5, 3)
+	h.Fill(5.0, 0.5, 4) // overflow
+
+	h.Scale(0.5)
+	if got, want := h.SumW(), 5.0; got != want {
+		t.Fatalf("invalid scaled sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Integral(), 5.0; got != want {
+		t.Fatalf("invalid scaled integral: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Bin(1.5, 0.5).SumW(), 1.0; got != want {
+		t.Fatalf("invalid scaled bin sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Bin(1.5, 0.5).SumW2(), 1.0; got != want {
+		t.Fatalf("invalid scaled bin sumw2: got=%v, want=%v", got, want)
+	}
+	if got, want := h.XMean(), 2.8; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid scaled x-mean: got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,816 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// H3D is a 3-dim histogram with weighted entries.
+type H3D struct {
+	Binning Binning3D
+	Ann     Annotation
+}
+
+// NewH3D creates a new 3-dim histogram.
+func NewH3D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64, nz int, zlow, zhigh float64) *H3D {
+	return &H3D{
+		Binning: newBinning3D(nx, xlow, xhigh, ny, ylow, yhigh, nz, zlow, zhigh),
+		Ann:     make(Annotation),
+	}
+}
+
+// NewH3DFromEdges creates a new 3-dim histogram from slices
+// of edges in x, y and z.
+// The number of bins in x, y and z is thus len(edges)-1.
+// It panics if the length of edges is <=1 (in any dimension.)
+// It panics if the edges are not sorted (in any dimension.)
+// It panics if there are duplicate edge values (in any dimension.)
+func NewH3DFromEdges(xedges, yedges, zedges []float64) *H3D {
+	return &H3D{
+		Binning: newBinning3DFromEdges(xedges, yedges, zedges),
+		Ann:     make(Annotation),
+	}
+}
+
+// Name returns the name of this histogram, if any
+func (h *H3D) Name() string {
+	v, ok := h.Ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this histogram
+func (h *H3D) Annotation() Annotation {
+	return h.Ann
+}
+
+// Rank returns the number of dimensions for this histogram
+func (h *H3D) Rank() int {
+	return 3
+}
+
+// Entries returns the number of entries in this histogram
+func (h *H3D) Entries() int64 {
+	return h.Binning.entries()
+}
+
+// EffEntries returns the number of effective entries in this histogram
+func (h *H3D) EffEntries() float64 {
+	return h.Binning.effEntries()
+}
+
+// SumW returns the sum of weights in this histogram.
+// Overflows are included in the computation.
+func (h *H3D) SumW() float64 {
+	return h.Binning.Dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this histogram.
+// Overflows are included in the computation.
+func (h *H3D) SumW2() float64 {
+	return h.Binning.Dist.SumW2()
+}
+
+// SumWX returns the 1st order weighted x moment
+// Overflows are included in the computation.
+func (h *H3D) SumWX() float64 {
+	return h.Binning.Dist.SumWX()
+}
+
+// SumWX2 returns the 2nd order weighted x moment
+// Overflows are included in the computation.
+func (h *H3D) SumWX2() float64 {
+	return h.Binning.Dist.SumWX2()
+}
+
+// SumWY returns the 1st order weighted y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWY() float64 {
+	return h.Binning.Dist.SumWY()
+}
+
+// SumWY2 returns the 2nd order weighted y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWY2() float64 {
+	return h.Binning.Dist.SumWY2()
+}
+
+// SumWZ returns the 1st order weighted z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWZ() float64 {
+	return h.Binning.Dist.SumWZ()
+}
+
+// SumWZ2 returns the 2nd order weighted z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWZ2() float64 {
+	return h.Binning.Dist.SumWZ2()
+}
+
+// SumWXY returns the 1st order weighted x*y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWXY() float64 {
+	return h.Binning.Dist.SumWXY()
+}
+
+// SumWXZ returns the 1st order weighted x*z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWXZ() float64 {
+	return h.Binning.Dist.SumWXZ()
+}
+
+// SumWYZ returns the 1st order weighted y*z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWYZ() float64 {
+	return h.Binning.Dist.SumWYZ()
+}
+
+// XMean returns the mean X.
+// Overflows are included in the computation.
+func (h *H3D) XMean() float64 {
+	return h.Binning.Dist.xMean()
+}
+
+// YMean returns the mean Y.
+// Overflows are included in the computation.
+func (h *H3D) YMean() float64 {
+	return h.Binning.Dist.yMean()
+}
+
+// ZMean returns the mean Z.
+// Overflows are included in the computation.
+func (h *H3D) ZMean() float64 {
+	return h.Binning.Dist.zMean()
+}
+
+// XVariance returns the variance in X.
+// Overflows are included in the computation.
+func (h *H3D) XVariance() float64 {
+	return h.Binning.Dist.xVariance()
+}
+
+// YVariance returns the variance in Y.
+// Overflows are included in the computation.
+func (h *H3D) YVariance() float64 {
+	return h.Binning.Dist.yVariance()
+}
+
+// ZVariance returns the variance in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZVariance() float64 {
+	return h.Binning.Dist.zVariance()
+}
+
+// XStdDev returns the standard deviation in X.
+// Overflows are included in the computation.
+func (h *H3D) XStdDev() float64 {
+	return h.Binning.Dist.xStdDev()
+}
+
+// YStdDev returns the standard deviation in Y.
+// Overflows are included in the computation.
+func (h *H3D) YStdDev() float64 {
+	return h.Binning.Dist.yStdDev()
+}
+
+// ZStdDev returns the standard deviation in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZStdDev() float64 {
+	return h.Binning.Dist.zStdDev()
+}
+
+// XStdErr returns the standard error in X.
+// Overflows are included in the computation.
+func (h *H3D) XStdErr() float64 {
+	return h.Binning.Dist.xStdErr()
+}
+
+// YStdErr returns the standard error in Y.
+// Overflows are included in the computation.
+func (h *H3D) YStdErr() float64 {
+	return h.Binning.Dist.yStdErr()
+}
+
+// ZStdErr returns the standard error in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZStdErr() float64 {
+	return h.Binning.Dist.zStdErr()
+}
+
+// XRMS returns the RMS in X.
+// Overflows are included in the computation.
+func (h *H3D) XRMS() float64 {
+	return h.Binning.Dist.xRMS()
+}
+
+// YRMS returns the RMS in Y.
+// Overflows are included in the computation.
+func (h *H3D) YRMS() float64 {
+	return h.Binning.Dist.yRMS()
+}
+
+// ZRMS returns the RMS in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZRMS() float64 {
+	return h.Binning.Dist.zRMS()
+}
+
+// Fill fills this histogram with (x,y,z) and weight w.
+func (h *H3D) Fill(x, y, z, w float64) {
+	h.Binning.fill(x, y, z, w)
+}
+
+// FillN fills this histogram with the provided slices (xs,ys,zs) and weights ws.
+// if ws is nil, the histogram will be filled with entries of weight 1.
+// Otherwise, FillN panics if the slices lengths differ.
+func (h *H3D) FillN(xs, ys, zs, ws []float64) {
+	if len(xs) != len(ys) || len(xs) != len(zs) {
+		panic(fmt.Errorf("hbook: lengths mismatch"))
+	}
+	switch ws {
+	case nil:
+		for i := range xs {
+			h.Binning.fill(xs[i], ys[i], zs[i], 1)
+		}
+	default:
+		if len(xs) != len(ws) {
+			panic(fmt.Errorf("hbook: lengths mismatch"))
+		}
+		for i := range xs {
+			h.Binning.fill(xs[i], ys[i], zs[i], ws[i])
+		}
+	}
+}
+
+// Bin returns the bin at coordinates (x,y,z) for this 3-dim histogram.
+// Bin returns nil for under/over flow bins.
+func (h *H3D) Bin(x, y, z float64) *Bin3D {
+	idx := h.Binning.coordToIndex(x, y, z)
+	if idx < 0 || idx == len(h.Binning.Bins) {
+		return nil
+	}
+	return &h.Binning.Bins[idx]
+}
+
+// XMin returns the low edge of the X-axis of this histogram.
+func (h *H3D) XMin() float64 {
+	return h.Binning.xMin()
+}
+
+// XMax returns the high edge of the X-axis of this histogram.
+func (h *H3D) XMax() float64 {
+	return h.Binning.xMax()
+}
+
+// YMin returns the low edge of the Y-axis of this histogram.
+func (h *H3D) YMin() float64 {
+	return h.Binning.yMin()
+}
+
+// YMax returns the high edge of the Y-axis of this histogram.
+func (h *H3D) YMax() float64 {
+	return h.Binning.yMax()
+}
+
+// ZMin returns the low edge of the Z-axis of this histogram.
+func (h *H3D) ZMin() float64 {
+	return h.Binning.zMin()
+}
+
+// ZMax returns the high edge of the Z-axis of this histogram.
+func (h *H3D) ZMax() float64 {
+	return h.Binning.zMax()
+}
+
+// Integral computes the integral of the histogram.
+//
+// Overflows are included in the computation.
+func (h *H3D) Integral() float64 {
+	return h.SumW()
+}
+
+// ProjectionX returns the 1-dim histogram of the projection of h
+// along the X-axis.
+// Entries outside of the Y- and Z-ranges of h are not projected.
+func (h *H3D) ProjectionX() *H1D {
+	return h.project1D("_px", h.Binning.XEdges, func(ix, iy, iz int) int { return ix }, func(d *Dist3D) Dist1D { return d.X })
+}
+
+// ProjectionY returns the 1-dim histogram of the projection of h
+// along the Y-axis.
+// Entries outside of the X- and Z-ranges of h are not projected.
+func (h *H3D) ProjectionY() *H1D {
+	return h.project1D("_py", h.Binning.YEdges, func(ix, iy, iz int) int { return iy }, func(d *Dist3D) Dist1D { return d.Y })
+}
+
+// ProjectionZ returns the 1-dim histogram of the projection of h
+// along the Z-axis.
+// Entries outside of the X- and Y-ranges of h are not projected.
+func (h *H3D) ProjectionZ() *H1D {
+	return h.project1D("_pz", h.Binning.ZEdges, func(ix, iy, iz int) int { return iz }, func(d *Dist3D) Dist1D { return d.Z })
+}
+
+// ProjectionXY returns the 2-dim histogram of the projection of h
+// on the (X,Y) plane.
+// Entries outside of the Z-range of h are not projected.
+func (h *H3D) ProjectionXY() *H2D {
+	return h.project2D(
+		"_xy", h.Binning.XEdges, h.Binning.YEdges,
+		func(ix, iy, iz int) (int, int) { return ix, iy },
+		(*Dist3D).xy,
+	)
+}
+
+// ProjectionXZ returns the 2-dim histogram of the projection of h
+// on the (X,Z) plane, with X along the X-axis and Z along the Y-axis.
+// Entries outside of the Y-range of h are not projected.
+func (h *H3D) ProjectionXZ() *H2D {
+	return h.project2D(
+		"_xz", h.Binning.XEdges, h.Binning.ZEdges,
+		func(ix, iy, iz int) (int, int) { return ix, iz },
+		(*Dist3D).xz,
+	)
+}
+
+// ProjectionYZ returns the 2-dim histogram of the projection of h
+// on the (Y,Z) plane, with Y along the X-axis and Z along the Y-axis.
+// Entries outside of the X-range of h are not projected.
+func (h *H3D) ProjectionYZ() *H2D {
+	return h.project2D(
+		"_yz", h.Binning.YEdges, h.Binning.ZEdges,
+		func(ix, iy, iz int) (int, int) { return iy, iz },
+		(*Dist3D).yz,
+	)
+}
+
+// projAnn returns the annotations of a projection of h.
+func (h *H3D) projAnn(suffix string) Annotation {
+	ann := h.Ann.clone()
+	if name := h.Name(); name != "" {
+		ann["name"] = name + suffix
+	}
+	return ann
+}
+
+func (h *H3D) project1D(suffix string, edges []Bin1D, axis func(ix, iy, iz int) int, dist func(d *Dist3D) Dist1D) *H1D {
+	var (
+		bng = &h.Binning
+		o   = &H1D{
+			Binning: Binning1D{
+				Bins:   append([]Bin1D(nil), edges...),
+				XRange: Range{Min: edges[0].Range.Min, Max: edges[len(edges)-1].Range.Max},
+			},
+			Ann: h.projAnn(suffix),
+		}
+	)
+	for i := range o.Binning.Bins {
+		o.Binning.Bins[i].Dist = Dist1D{}
+	}
+
+	for iz := 0; iz < bng.Nz; iz++ {
+		for iy := 0; iy < bng.Ny; iy++ {
+			for ix := 0; ix < bng.Nx; ix++ {
+				d := dist(&bng.Bins[bng.index(ix, iy, iz)].Dist)
+				o.Binning.Bins[axis(ix, iy, iz)].Dist.addScaled(1, 1, d)
+				o.Binning.Dist.addScaled(1, 1, d)
+			}
+		}
+	}
+
+	for i, v := range []int{-1, +1} {
+		// only the outflow region along the projection axis is kept.
+		d := dist(bng.Outflow(axis(v, 0, 0), axis(0, v, 0), axis(0, 0, v)))
+		o.Binning.Outflows[i].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+func (h *H3D) project2D(suffix string, xedges, yedges []Bin1D, axes func(ix, iy, iz int) (int, int), dist func(d *Dist3D) Dist2D) *H2D {
+	var (
+		bng = &h.Binning
+		o   = &H2D{
+			Binning: newBinning2DFromEdges(bin1DEdges(xedges), bin1DEdges(yedges)),
+			Ann:     h.projAnn(suffix),
+		}
+		nx = o.Binning.Nx
+	)
+
+	for iz := 0; iz < bng.Nz; iz++ {
+		for iy := 0; iy < bng.Ny; iy++ {
+			for ix := 0; ix < bng.Nx; ix++ {
+				var (
+					d      = dist(&bng.Bins[bng.index(ix, iy, iz)].Dist)
+					jx, jy = axes(ix, iy, iz)
+				)
+				o.Binning.Bins[jy*nx+jx].Dist.addScaled(1, 1, d)
+				o.Binning.Dist.addScaled(1, 1, d)
+			}
+		}
+	}
+
+	for _, v := range []struct {
+		ox, oy int
+		idx    int
+	}{
+		{-1, +1, BngNW},
+		{0, +1, BngN},
+		{+1, +1, BngNE},
+		{+1, 0, BngE},
+		{+1, -1, BngSE},
+		{0, -1, BngS},
+		{-1, -1, BngSW},
+		{-1, 0, BngW},
+	} {
+		// find the 3-dim outflow region, with the projected-out axis
+		// within range, that maps to the 2-dim (ox,oy) outflow region.
+		var region [3]int
+		for i := range region {
+			var (
+				c      [3]int
+				jx, jy int
+			)
+			c[i] = 1
+			jx, jy = axes(c[0], c[1], c[2])
+			switch {
+			case jx == 1 && jy == 0:
+				region[i] = v.ox
+			case jx == 0 && jy == 1:
+				region[i] = v.oy
+			}
+		}
+		d := dist(bng.Outflow(region[0], region[1], region[2]))
+		o.Binning.Outflows[v.idx-1].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+// bin1DEdges returns the edges of the provided contiguous bins.
+func bin1DEdges(bins []Bin1D) []float64 {
+	edges := make([]float64, 0, len(bins)+1)
+	for _, bin := range bins {
+		edges = append(edges, bin.Range.Min)
+	}
+	return append(edges, bins[len(bins)-1].Range.Max)
+}
+
+// check various interfaces
+var _ Object = (*H3D)(nil)
+var _ Histogram = (*H3D)(nil)
+
+// annToYODA creates a new Annotation with fields compatible with YODA
+func (h *H3D) annToYODA() Annotation {
+	ann := make(Annotation, len(h.Ann))
+	ann["Type"] = "Histo3D"
+	ann["Path"] = "/" + h.Name()
+	ann["Title"] = ""
+	for k, v := range h.Ann {
+		if k == "name" {
+			continue
+		}
+		if k == "title" {
+			ann["Title"] = v
+			continue
+		}
+		ann[k] = v
+	}
+	return ann
+}
+
+// annFromYODA creates a new Annotation from YODA compatible fields
+func (h *H3D) annFromYODA(ann Annotation) {
+	if len(h.Ann) == 0 {
+		h.Ann = make(Annotation, len(ann))
+	}
+	for k, v := range ann {
+		switch k {
+		case "Type":
+			// noop
+		case "Path":
+			name := v.(string)
+			name = strings.TrimPrefix(name, "/")
+			h.Ann["name"] = name
+		case "Title":
+			h.Ann["title"] = v
+		default:
+			h.Ann[k] = v
+		}
+	}
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+//
+// YODA1 has no 3-dim histogram: H3D is serialized as a YODA_HISTO3D_V2
+// block, modeled after the YODA_HISTO2D_V2 one.
+func (h *H3D) MarshalYODA() ([]byte, error) {
+	return h.marshalYODAv2()
+}
+
+func (h *H3D) marshalYODAv2() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO3D_V2 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e, %e)\n", h.XMean(), h.YMean(), h.ZMean())
+	fmt.Fprintf(buf, "# Volume: %e\n", h.Integral())
+
+	fmt.Fprintf(buf, "# ID\t ID\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t sumwxz\t sumwyz\t numEntries\n")
+	d := h.Binning.Dist
+	fmt.Fprintf(
+		buf,
+		"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+		d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+		d.SumWXY(), d.SumWXZ(), d.SumWYZ(), float64(d.Entries()),
+	)
+
+	// outflows
+	fmt.Fprintf(buf, "# 3D outflow persistency not currently supported until API is stable\n")
+
+	// bins
+	fmt.Fprintf(buf, "# xlow\t xhigh\t ylow\t yhigh\t zlow\t zhigh\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t sumwxz\t sumwyz\t numEntries\n")
+	for ix := 0; ix < h.Binning.Nx; ix++ {
+		for iy := 0; iy < h.Binning.Ny; iy++ {
+			for iz := 0; iz < h.Binning.Nz; iz++ {
+				bin := h.Binning.Bins[h.Binning.index(ix, iy, iz)]
+				d := bin.Dist
+				fmt.Fprintf(
+					buf,
+					"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+					bin.XRange.Min, bin.XRange.Max, bin.YRange.Min, bin.YRange.Max, bin.ZRange.Min, bin.ZRange.Max,
+					d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+					d.SumWXY(), d.SumWXZ(), d.SumWYZ(), float64(d.Entries()),
+				)
+			}
+		}
+	}
+	fmt.Fprintf(buf, "END YODA_HISTO3D_V2\n\n")
+	return buf.Bytes(), err
+}
+
+// MarshalYODA2 marshals the histogram into the text format of YODA2
+// (YODA_HISTO3D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (h *H3D) MarshalYODA2() ([]byte, error) {
+	return h.marshalYODAv3()
+}
+
+func (h *H3D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO3D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	var (
+		bng = &h.Binning
+		nx  = bng.Nx
+		ny  = bng.Ny
+		nz  = bng.Nz
+	)
+	writeYODAEdges(buf, 1, bin1DEdges(bng.XEdges))
+	writeYODAEdges(buf, 2, bin1DEdges(bng.YEdges))
+	writeYODAEdges(buf, 3, bin1DEdges(bng.ZEdges))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e, %e)\n", h.XMean(), h.YMean(), h.ZMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", h.Integral())
+
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t sumW(A2)\t sumW2(A2)\t sumW(A3)\t sumW2(A3)\t sumW(A1,A2)\t sumW(A1,A3)\t sumW(A2,A3)\t numEntries\n")
+	for iz := 0; iz < nz+2; iz++ {
+		for iy := 0; iy < ny+2; iy++ {
+			for ix := 0; ix < nx+2; ix++ {
+				var (
+					d          Dist3D
+					ox, oy, oz = yodaOutflow(ix, nx), yodaOutflow(iy, ny), yodaOutflow(iz, nz)
+				)
+				switch {
+				case ox == 0 && oy == 0 && oz == 0:
+					d = bng.Bins[bng.index(ix-1, iy-1, iz-1)].Dist
+				case ix == yodaOutflowBin(ox, nx) && iy == yodaOutflowBin(oy, ny) && iz == yodaOutflowBin(oz, nz):
+					d = *bng.Outflow(ox, oy, oz)
+				}
+				writeYODARow(
+					buf,
+					d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+					d.SumWXY(), d.SumWXZ(), d.SumWYZ(), float64(d.Entries()),
+				)
+			}
+		}
+	}
+	fmt.Fprintf(buf, "END YODA_HISTO3D_V3\n\n")
+	return buf.Bytes(), err
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (h *H3D) UnmarshalYODA(data []byte) error {
+	r := newRBuffer(data)
+	_, vers, err := readYODAHeader(r, "BEGIN YODA_HISTO3D")
+	if err != nil {
+		return err
+	}
+	switch vers {
+	case 2:
+		return h.unmarshalYODAv2(r)
+	case 3:
+		return h.unmarshalYODAv3(r)
+	default:
+		return fmt.Errorf("hbook: invalid YODA version %v", vers)
+	}
+}
+
+func (h *H3D) unmarshalYODAv2(r *rbuffer) error {
+	ann := make(Annotation)
+
+	// pos of end of annotations
+	pos := bytes.Index(r.Bytes(), []byte("\n# Mean:"))
+	if pos < 0 {
+		return fmt.Errorf("hbook: invalid H3D-YODA data")
+	}
+	err := ann.unmarshalYODAv2(r.Bytes()[:pos+1])
+	if err != nil {
+		return fmt.Errorf("hbook: %q\nhbook: %w", string(r.Bytes()[:pos+1]), err)
+	}
+	h.annFromYODA(ann)
+	r.next(pos)
+
+	var ctx struct {
+		dist bool
+		bins bool
+	}
+
+	// sets of bin edges, to infer the binning in X, Y and Z.
+	var (
+		xset = make(map[float64]struct{})
+		yset = make(map[float64]struct{})
+		zset = make(map[float64]struct{})
+	)
+
+	var (
+		dist Dist3D
+		bins []Bin3D
+	)
+	s := bufio.NewScanner(r)
+scanLoop:
+	for s.Scan() {
+		buf := s.Bytes()
+		if len(buf) == 0 || buf[0] == '#' {
+			continue
+		}
+		rbuf := bytes.NewReader(buf)
+		switch {
+		case bytes.HasPrefix(buf, []byte("END YODA_HISTO3D_V2")):
+			break scanLoop
+		case !ctx.dist && bytes.HasPrefix(buf, []byte("Total   \t")):
+			ctx.dist = true
+			d := &dist
+			var n float64
+			_, err = fmt.Fscanf(
+				rbuf,
+				"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&d.X.Dist.SumW, &d.X.Dist.SumW2,
+				&d.X.Stats.SumWX, &d.X.Stats.SumWX2,
+				&d.Y.Stats.SumWX, &d.Y.Stats.SumWX2,
+				&d.Z.Stats.SumWX, &d.Z.Stats.SumWX2,
+				&d.Stats.SumWXY, &d.Stats.SumWXZ, &d.Stats.SumWYZ, &n,
+			)
+			if err != nil {
+				return fmt.Errorf("hbook: %q\nhbook: %w", string(buf), err)
+			}
+			d.X.Dist.N = int64(n)
+			d.Y.Dist = d.X.Dist
+			d.Z.Dist = d.X.Dist
+			ctx.bins = true
+		case ctx.bins:
+			var bin Bin3D
+			d := &bin.Dist
+			var n float64
+			_, err = fmt.Fscanf(
+				rbuf,
+				"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&bin.XRange.Min, &bin.XRange.Max,
+				&bin.YRange.Min, &bin.YRange.Max,
+				&bin.ZRange.Min, &bin.ZRange.Max,
+				&d.X.Dist.SumW, &d.X.Dist.SumW2,
+				&d.X.Stats.SumWX, &d.X.Stats.SumWX2,
+				&d.Y.Stats.SumWX, &d.Y.Stats.SumWX2,
+				&d.Z.Stats.SumWX, &d.Z.Stats.SumWX2,
+				&d.Stats.SumWXY, &d.Stats.SumWXZ, &d.Stats.SumWYZ, &n,
+			)
+			if err != nil {
+				return fmt.Errorf("hbook: %q\nhbook: %w", string(buf), err)
+			}
+			d.X.Dist.N = int64(n)
+			d.Y.Dist = d.X.Dist
+			d.Z.Dist = d.X.Dist
+			for _, v := range []struct {
+				set map[float64]struct{}
+				rng Range
+			}{
+				{xset, bin.XRange},
+				{yset, bin.YRange},
+				{zset, bin.ZRange},
+			} {
+				v.set[v.rng.Min] = struct{}{}
+				v.set[v.rng.Max] = struct{}{}
+			}
+			bins = append(bins, bin)
+
+		default:
+			return fmt.Errorf("hbook: invalid H3D-YODA data: %q", string(buf))
+		}
+	}
+
+	edges := func(set map[float64]struct{}) []float64 {
+		o := make([]float64, 0, len(set))
+		for v := range set {
+			o = append(o, v)
+		}
+		sort.Float64s(o)
+		return o
+	}
+
+	var (
+		xedges = edges(xset)
+		yedges = edges(yset)
+		zedges = edges(zset)
+		nx     = len(xedges) - 1
+		ny     = len(yedges) - 1
+		nz     = len(zedges) - 1
+	)
+	if nx <= 0 || ny <= 0 || nz <= 0 || nx*ny*nz != len(bins) {
+		return fmt.Errorf("hbook: invalid H3D-YODA binning")
+	}
+	h.Binning = newBinning3DFromEdges(xedges, yedges, zedges)
+	h.Binning.Dist = dist
+	// YODA bins are transposed wrt ours
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			for iz := 0; iz < nz; iz++ {
+				h.Binning.Bins[h.Binning.index(ix, iy, iz)] = bins[(ix*ny+iy)*nz+iz]
+			}
+		}
+	}
+	return err
+}
+
+func (h *H3D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	h.annFromYODA(ann)
+
+	err = blk.check(3, 12)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid H3D-YODA data: %w", err)
+	}
+
+	var (
+		bng = newBinning3DFromEdges(blk.edges[0], blk.edges[1], blk.edges[2])
+		nx  = bng.Nx
+		ny  = bng.Ny
+		nz  = bng.Nz
+	)
+	for i, row := range blk.rows {
+		var (
+			d          = yodaDist3D(row)
+			ix         = i % (nx + 2)
+			iy         = (i / (nx + 2)) % (ny + 2)
+			iz         = i / ((nx + 2) * (ny + 2))
+			ox, oy, oz = yodaOutflow(ix, nx), yodaOutflow(iy, ny), yodaOutflow(iz, nz)
+		)
+		bng.Dist.addScaled(1, 1, d)
+		switch {
+		case ox == 0 && oy == 0 && oz == 0:
+			bng.Bins[bng.index(ix-1, iy-1, iz-1)].Dist = d
+		default:
+			bng.Outflow(ox, oy, oz).addScaled(1, 1, d)
+		}
+	}
+	h.Binning = bng
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestH3D(t *testing.T) {
+	h := NewH3D(2, 0, 2, 3, 0, 3, 4, 0, 4)
+	if got, want := h.Rank(), 3; got != want {
+		t.Fatalf("invalid rank: got=%d, want=%d", got, want)
+	}
+	if got, want := len(h.Binning.Bins), 2*3*4; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+
+	h.Fill(0.5, 0.5, 0.5, 1)
+	h.Fill(1.5, 2.5, 3.5, 2)
+	h.Fill(1.5, 2.5, 3.5, 1)
+	h.Fill(-1, 1.5, 1.5, 1)
+	h.Fill(1.5, 4, 5, 3)
+
+	if got, want := h.Entries(), int64(5); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h.SumW(), 8.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumW2(), 16.0; got != want {
+		t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumWZ(), 0.5+3*3.5+1.5+15; got != want {
+		t.Fatalf("invalid sumwz: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumWXZ(), 0.25+3*1.5*3.5-1.5+3*1.5*5; got != want {
+		t.Fatalf("invalid sumwxz: got=%v, want=%v", got, want)
+	}
+	if got, want := h.ZMean(), h.SumWZ()/h.SumW(); got != want {
+		t.Fatalf("invalid z-mean: got=%v, want=%v", got, want)
+	}
+
+	bin := h.Bin(1.2, 2.2, 3.2)
+	if bin == nil {
+		t.Fatalf("expected a bin")
+	}
+	if got, want := bin.Entries(), int64(2); got != want {
+		t.Fatalf("invalid bin entries: got=%d, want=%d", got, want)
+	}
+	if got, want := bin.SumW(), 3.0; got != want {
+		t.Fatalf("invalid bin sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.Volume(), 1.0; got != want {
+		t.Fatalf("invalid bin volume: got=%v, want=%v", got, want)
+	}
+	if h.Bin(-1, 0, 0) != nil {
+		t.Fatalf("expected no bin for outflows")
+	}
+
+	if got, want := h.Binning.Outflow(-1, 0, 0).SumW(), 1.0; got != want {
+		t.Fatalf("invalid (-1,0,0) outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Binning.Outflow(0, +1, +1).SumW(), 3.0; got != want {
+		t.Fatalf("invalid (0,+1,+1) outflow: got=%v, want=%v", got, want)
+	}
+
+	for _, v := range [][3]int{{0, 0, 0}, {2, 0, 0}, {0, -2, 1}} {
+		if ok, _ := panics(func() { h.Binning.Outflow(v[0], v[1], v[2]) }); !ok {
+			t.Fatalf("expected a panic for outflow region %v", v)
+		}
+	}
+}
+
+func TestH3DEdges(t *testing.T) {
+	h := NewH3DFromEdges(
+		[]float64{0, 1, 3},
+		[]float64{-1, 1},
+		[]float64{0, 0.5, 1, 10},
+	)
+	if nx, ny, nz := h.Binning.Nx, h.Binning.Ny, h.Binning.Nz; nx != 2 || ny != 1 || nz != 3 {
+		t.Fatalf("invalid binning: got=(%d,%d,%d)", nx, ny, nz)
+	}
+	h.Fill(2, 0, 5, 1)
+	bin := h.Binning.Bins[h.Binning.index(1, 0, 2)]
+	if got, want := bin.SumW(), 1.0; got != want {
+		t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.ZRange, (Range{Min: 1, Max: 10}); got != want {
+		t.Fatalf("invalid bin z-range: got=%v, want=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		fct    func()
+		panics error
+	}{
+		{"short-z", func() { NewH3DFromEdges([]float64{0, 1}, []float64{0, 1}, []float64{0}) }, errShortZAxis},
+		{"sort-y", func() { NewH3DFromEdges([]float64{0, 1}, []float64{1, 0}, []float64{0, 1}) }, errNotSortedYAxis},
+		{"dup-x", func() { NewH3DFromEdges([]float64{0, 1, 1}, []float64{0, 1}, []float64{0, 1}) }, errDupEdgesXAxis},
+		{"invalid-z", func() { NewH3D(1, 0, 1, 1, 0, 1, 1, 1, 0) }, errInvalidZAxis},
+		{"empty-z", func() { NewH3D(1, 0, 1, 1, 0, 1, 0, 0, 1) }, errEmptyZAxis},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, msg := panics(tc.fct)
+			if !ok || msg != tc.panics.Error() {
+				t.Fatalf("invalid panic: got=%v (%q), want=%q", ok, msg, tc.panics)
+			}
+		})
+	}
+}
+
+func TestH3DFillN(t *testing.T) {
+	h1 := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	h2 := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	var (
+		xs = []float64{0.5, 1.5, -1, 0.5}
+		ys = []float64{0.5, 0.5, 1.5, 3}
+		zs = []float64{1.5, 0.5, 0.5, 0.5}
+		ws = []float64{1, 2, 3, 4}
+	)
+	for i := range xs {
+		h1.Fill(xs[i], ys[i], zs[i], ws[i])
+	}
+	h2.FillN(xs, ys, zs, ws)
+	if !reflect.DeepEqual(h1, h2) {
+		t.Fatalf("histograms differ")
+	}
+
+	if ok, _ := panics(func() { h2.FillN(xs, ys, zs[:1], nil) }); !ok {
+		t.Fatalf("expected a panic")
+	}
+	if ok, _ := panics(func() { h2.FillN(xs, ys, zs, ws[:1]) }); !ok {
+		t.Fatalf("expected a panic")
+	}
+}
+
+func TestH3DProjections(t *testing.T) {
+	h := NewH3D(2, 0, 2, 3, 0, 3, 4, 0, 4)
+	h.Ann["name"] = "h3"
+	h.Ann["title"] = "my title"
+	h.Fill(0.5, 0.5, 0.5, 1)
+	h.Fill(1.5, 2.5, 3.5, 2)
+	h.Fill(1.5, 1.5, 3.5, 1)
+	h.Fill(-1, 1.5, 1.5, 4)
+	h.Fill(1.5, 4, 0.5, 8)
+	h.Fill(3, 4, 2.5, 16)
+
+	t.Run("x", func(t *testing.T) {
+		p := h.ProjectionX()
+		if got, want := p.Name(), "h3_px"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		if got, want := p.Annotation()["title"], "my title"; got != want {
+			t.Fatalf("invalid title: got=%q, want=%q", got, want)
+		}
+		if got, want := []float64{p.Binning.Bins[0].SumW(), p.Binning.Bins[1].SumW()}, []float64{1, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid bins: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Binning.Underflow().SumW(), 4.0; got != want {
+			t.Fatalf("invalid underflow: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumW(), 8.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Entries(), int64(4); got != want {
+			t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumWX(), 0.5+3*1.5-4; got != want {
+			t.Fatalf("invalid sumwx: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("z", func(t *testing.T) {
+		p := h.ProjectionZ()
+		var got []float64
+		for _, bin := range p.Binning.Bins {
+			got = append(got, bin.SumW())
+		}
+		if want := []float64{1, 0, 0, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid bins: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumW(), 4.0; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("xy", func(t *testing.T) {
+		p := h.ProjectionXY()
+		if got, want := p.Name(), "h3_xy"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		if nx, ny := p.Binning.Nx, p.Binning.Ny; nx != 2 || ny != 3 {
+			t.Fatalf("invalid binning: got=(%d,%d)", nx, ny)
+		}
+		if got, want := p.Bin(1.5, 2.5).SumW(), 2.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Binning.Outflows[BngW-1].SumW(), 4.0; got != want {
+			t.Fatalf("invalid W outflow: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Binning.Outflows[BngN-1].SumW(), 8.0; got != want {
+			t.Fatalf("invalid N outflow: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Binning.Outflows[BngNE-1].SumW(), 16.0; got != want {
+			t.Fatalf("invalid NE outflow: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumW(), h.SumW(); got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumWXY(), h.SumWXY(); got != want {
+			t.Fatalf("invalid sumwxy: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("yz", func(t *testing.T) {
+		p := h.ProjectionYZ()
+		if nx, ny := p.Binning.Nx, p.Binning.Ny; nx != 3 || ny != 4 {
+			t.Fatalf("invalid binning: got=(%d,%d)", nx, ny)
+		}
+		if got, want := p.Bin(1.5, 3.5).SumW(), 1.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+		if got, want := p.Binning.Outflows[BngE-1].SumW(), 8.0; got != want {
+			t.Fatalf("invalid E outflow: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumW(), 1.0+2+1+8; got != want {
+			t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("xz", func(t *testing.T) {
+		p := h.ProjectionXZ()
+		if got, want := p.Bin(1.5, 3.5).SumW(), 3.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+		if got, want := p.SumWXY(), 0.25+1.5*3.5*3-1*1.5*4; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid sumwxz: got=%v, want=%v", got, want)
+		}
+	})
+}
+
+func TestH3DYODA(t *testing.T) {
+	h := NewH3DFromEdges([]float64{-1, 0, 1}, []float64{-2, 0, 2}, []float64{0, 1, 3})
+	h.Ann["name"] = "h3d"
+	h.Ann["title"] = "my title"
+	h.Fill(+0.5, +1, 0.5, 1)
+	h.Fill(-0.5, +1, 2.5, 1)
+	h.Fill(+0.0, -1, 1.5, 2)
+	h.Fill(+0.5, +1, 4.0, 1)
+
+	chk, err := h.MarshalYODA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/h3d_v2_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(chk, ref) {
+		t.Fatalf("h3d file differ:\n%s\n",
+			cmp.Diff(
+				string(ref),
+				string(chk),
+			),
+		)
+	}
+
+	var got H3D
+	err = got.UnmarshalYODA(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// outflows are not persisted.
+	want := *h
+	want.Binning.Outflows = [26]Dist3D{}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid round-trip:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestH3DSerialization(t *testing.T) {
+	href := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	href.Fill(0.5, 1.5, 0.5, 1)
+	href.Fill(-1, 1.5, 0.5, 2)
+	href.Annotation()["title"] = "histo title"
+	href.Annotation()["name"] = "histo name"
+
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(href)
+	if err != nil {
+		t.Fatalf("could not serialize histogram: %v", err)
+	}
+
+	var hnew H3D
+	err = gob.NewDecoder(buf).Decode(&hnew)
+	if err != nil {
+		t.Fatalf("could not deserialize histogram: %v", err)
+	}
+
+	if !reflect.DeepEqual(href, &hnew) {
+		t.Fatalf("ref=%v\nnew=%v\n", href, &hnew)
+	}
+}
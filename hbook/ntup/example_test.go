@@ -10,6 +10,7 @@ import (
 	"log"
 	"math"
 
+	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/ntup"
 	"go-hep.org/x/hep/hbook/ntup/ntcsv"
 )
@@ -151,3 +152,51 @@ func ExampleNtuple_scanH1D() {
 	// V1StdDev:    3.027650
 	// V1StdErr:    0.957427
 }
+
+func ExampleCreateFrom() {
+	db, err := sql.Open("ql", "memory://example-create-from.db")
+	if err != nil {
+		log.Fatalf("could not create db: %+v", err)
+	}
+	defer db.Close()
+
+	type Event struct {
+		X float64 `hbook:"x"`
+		W float64 `hbook:"w,weight"`
+	}
+
+	nt, err := ntup.CreateFrom(db, "evts", []Event{
+		{X: 0.5, W: 1},
+		{X: 1.5, W: 2},
+		{X: 2.5, W: 0.5},
+	})
+	if err != nil {
+		log.Fatalf("could not create ntuple: %+v", err)
+	}
+
+	err = nt.Append(Event{X: 3.5, W: 4})
+	if err != nil {
+		log.Fatalf("could not append event: %+v", err)
+	}
+
+	var xs []float64
+	err = nt.Column("x where w >= 1", &xs)
+	if err != nil {
+		log.Fatalf("could not read column: %+v", err)
+	}
+	fmt.Printf("xs:      %v\n", xs)
+
+	h, err := nt.ScanH1D("x", hbook.NewH1D(4, 0, 4))
+	if err != nil {
+		log.Fatalf("could not fill histogram: %+v", err)
+	}
+	fmt.Printf("weight:  %q\n", nt.Weight())
+	fmt.Printf("entries: %d\n", h.Entries())
+	fmt.Printf("sumw:    %v\n", h.SumW())
+
+	// Output:
+	// xs:      [0.5 1.5 3.5]
+	// weight:  "w"
+	// entries: 4
+	// sumw:    7.5
+}
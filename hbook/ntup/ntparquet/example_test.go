@@ -0,0 +1,89 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntparquet_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+func Example() {
+	tmp, err := os.MkdirTemp("", "ntparquet-")
+	if err != nil {
+		log.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Event struct {
+		N int32   `hbook:"n"`
+		X float64 `hbook:"x"`
+		W float64 `hbook:"w,weight"`
+	}
+
+	fname := filepath.Join(tmp, "data.parquet")
+	f, err := os.Create(fname)
+	if err != nil {
+		log.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	w, err := ntparquet.Create(f, Event{}, ntparquet.WithZstd(3))
+	if err != nil {
+		log.Fatalf("could not create n-tuple: %+v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		err = w.Append(Event{N: int32(i), X: float64(i) + 0.5, W: 2})
+		if err != nil {
+			log.Fatalf("could not append row: %+v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		log.Fatalf("could not close n-tuple: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		log.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := ntparquet.Open(fname)
+	if err != nil {
+		log.Fatalf("could not open n-tuple: %+v", err)
+	}
+	defer r.Close()
+
+	for _, col := range r.Cols() {
+		fmt.Printf("column %q: %v\n", col.Name(), col.Type())
+	}
+	fmt.Printf("weight: %q\n", r.Weight())
+
+	var xs []float64
+	err = r.Column("x", &xs)
+	if err != nil {
+		log.Fatalf("could not read column: %+v", err)
+	}
+	fmt.Printf("xs: %v\n", xs)
+
+	h, err := r.ScanH1D("x", nil)
+	if err != nil {
+		log.Fatalf("could not fill histogram: %+v", err)
+	}
+	fmt.Printf("entries: %d, sumw: %v, mean: %v\n", h.Entries(), h.SumW(), h.XMean())
+
+	// Output:
+	// column "n": int32
+	// column "x": float64
+	// column "w": float64
+	// weight: "w"
+	// xs: [0.5 1.5 2.5 3.5]
+	// entries: 4, sumw: 8, mean: 2
+}
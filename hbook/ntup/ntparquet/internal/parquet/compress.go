@@ -0,0 +1,105 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compression codecs.
+const (
+	CodecUncompressed = 0
+	CodecSnappy       = 1
+	CodecGzip         = 2
+	CodecZstd         = 6
+)
+
+// Compress compresses src with the provided codec and compression level.
+func Compress(codec int32, lvl int, src []byte) ([]byte, error) {
+	switch codec {
+	case CodecUncompressed:
+		return src, nil
+
+	case CodecSnappy:
+		return snappy.Encode(nil, src), nil
+
+	case CodecGzip:
+		var buf bytes.Buffer
+		w, err := gzip.NewWriterLevel(&buf, lvl)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not create gzip writer: %w", err)
+		}
+		_, err = w.Write(src)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not compress data: %w", err)
+		}
+		err = w.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not compress data: %w", err)
+		}
+		return buf.Bytes(), nil
+
+	case CodecZstd:
+		w, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(lvl)))
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not create zstd writer: %w", err)
+		}
+		defer w.Close()
+		return w.EncodeAll(src, nil), nil
+
+	default:
+		return nil, fmt.Errorf("parquet: unsupported compression codec %d", codec)
+	}
+}
+
+// Decompress decompresses src, compressed with the provided codec, into
+// a buffer of the provided size.
+func Decompress(codec int32, src []byte, size int) ([]byte, error) {
+	switch codec {
+	case CodecUncompressed:
+		return src, nil
+
+	case CodecSnappy:
+		dst, err := snappy.Decode(make([]byte, size), src)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not decompress snappy data: %w", err)
+		}
+		return dst, nil
+
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not create gzip reader: %w", err)
+		}
+		defer r.Close()
+		dst := make([]byte, size)
+		_, err = io.ReadFull(r, dst)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not decompress gzip data: %w", err)
+		}
+		return dst, nil
+
+	case CodecZstd:
+		r, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not create zstd reader: %w", err)
+		}
+		defer r.Close()
+		dst, err := r.DecodeAll(src, make([]byte, 0, size))
+		if err != nil {
+			return nil, fmt.Errorf("parquet: could not decompress zstd data: %w", err)
+		}
+		return dst, nil
+
+	default:
+		return nil, fmt.Errorf("parquet: unsupported compression codec %d", codec)
+	}
+}
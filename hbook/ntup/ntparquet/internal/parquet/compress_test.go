@@ -0,0 +1,63 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	src := bytes.Repeat([]byte("go-hep parquet "), 100)
+
+	for _, tc := range []struct {
+		codec int32
+		lvl   int
+	}{
+		{CodecUncompressed, 0},
+		{CodecSnappy, 0},
+		{CodecGzip, 1},
+		{CodecGzip, 9},
+		{CodecZstd, 1},
+		{CodecZstd, 19},
+	} {
+		t.Run(fmt.Sprintf("codec=%d-lvl=%d", tc.codec, tc.lvl), func(t *testing.T) {
+			buf, err := Compress(tc.codec, tc.lvl, src)
+			if err != nil {
+				t.Fatalf("could not compress data: %+v", err)
+			}
+			if tc.codec != CodecUncompressed && len(buf) >= len(src) {
+				t.Fatalf("data was not compressed: got=%d bytes, src=%d bytes", len(buf), len(src))
+			}
+
+			got, err := Decompress(tc.codec, buf, len(src))
+			if err != nil {
+				t.Fatalf("could not decompress data: %+v", err)
+			}
+			if !bytes.Equal(got, src) {
+				t.Fatalf("invalid round trip")
+			}
+
+			if tc.codec == CodecUncompressed {
+				return
+			}
+			_, err = Decompress(tc.codec, buf[:len(buf)/2], len(src))
+			if err == nil {
+				t.Fatalf("expected an error decompressing truncated data")
+			}
+		})
+	}
+
+	const lzo = 3
+	_, err := Compress(lzo, 0, src)
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported codec")
+	}
+	_, err = Decompress(lzo, src, len(src))
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported codec")
+	}
+}
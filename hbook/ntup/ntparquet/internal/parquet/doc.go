@@ -0,0 +1,9 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parquet implements the low-level layers of the Apache Parquet
+// file format needed by ntparquet: the Thrift compact protocol, the file
+// and page metadata, the PLAIN and RLE/bit-packing hybrid encodings and
+// the compression codecs.
+package parquet // import "go-hep.org/x/hep/hbook/ntup/ntparquet/internal/parquet"
@@ -0,0 +1,297 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Values of a column are held in slices of the Go type matching their
+// physical type:
+//  - BOOLEAN:    []bool
+//  - INT32:      []int32
+//  - INT64:      []int64
+//  - FLOAT:      []float32
+//  - DOUBLE:     []float64
+//  - BYTE_ARRAY: []string
+
+// PlainEncode encodes the provided values with the PLAIN encoding.
+func PlainEncode(vs interface{}) []byte {
+	switch vs := vs.(type) {
+	case []bool:
+		buf := make([]byte, (len(vs)+7)/8)
+		for i, v := range vs {
+			if v {
+				buf[i/8] |= 1 << (i % 8)
+			}
+		}
+		return buf
+	case []int32:
+		buf := make([]byte, 4*len(vs))
+		for i, v := range vs {
+			binary.LittleEndian.PutUint32(buf[4*i:], uint32(v))
+		}
+		return buf
+	case []int64:
+		buf := make([]byte, 8*len(vs))
+		for i, v := range vs {
+			binary.LittleEndian.PutUint64(buf[8*i:], uint64(v))
+		}
+		return buf
+	case []float32:
+		buf := make([]byte, 4*len(vs))
+		for i, v := range vs {
+			binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+		}
+		return buf
+	case []float64:
+		buf := make([]byte, 8*len(vs))
+		for i, v := range vs {
+			binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(v))
+		}
+		return buf
+	case []string:
+		n := 0
+		for _, v := range vs {
+			n += 4 + len(v)
+		}
+		var (
+			buf = make([]byte, n)
+			pos = 0
+		)
+		for _, v := range vs {
+			binary.LittleEndian.PutUint32(buf[pos:], uint32(len(v)))
+			pos += 4
+			pos += copy(buf[pos:], v)
+		}
+		return buf
+	default:
+		panic(fmt.Errorf("parquet: invalid values type %T", vs))
+	}
+}
+
+// PlainDecode decodes n values of the provided physical type, encoded
+// with the PLAIN encoding.
+func PlainDecode(typ int32, buf []byte, n int) (interface{}, error) {
+	size := 0
+	switch typ {
+	case TypeBoolean:
+		size = (n + 7) / 8
+	case TypeInt32, TypeFloat:
+		size = 4 * n
+	case TypeInt64, TypeDouble:
+		size = 8 * n
+	}
+	if len(buf) < size {
+		return nil, fmt.Errorf("parquet: truncated page (got=%d bytes, want=%d)", len(buf), size)
+	}
+
+	switch typ {
+	case TypeBoolean:
+		vs := make([]bool, n)
+		for i := range vs {
+			vs[i] = buf[i/8]&(1<<(i%8)) != 0
+		}
+		return vs, nil
+	case TypeInt32:
+		vs := make([]int32, n)
+		for i := range vs {
+			vs[i] = int32(binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+		return vs, nil
+	case TypeInt64:
+		vs := make([]int64, n)
+		for i := range vs {
+			vs[i] = int64(binary.LittleEndian.Uint64(buf[8*i:]))
+		}
+		return vs, nil
+	case TypeFloat:
+		vs := make([]float32, n)
+		for i := range vs {
+			vs[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+		return vs, nil
+	case TypeDouble:
+		vs := make([]float64, n)
+		for i := range vs {
+			vs[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+		}
+		return vs, nil
+	case TypeByteArray:
+		vs := make([]string, n)
+		for i := range vs {
+			if len(buf) < 4 {
+				return nil, fmt.Errorf("parquet: truncated page")
+			}
+			sz := int(binary.LittleEndian.Uint32(buf))
+			buf = buf[4:]
+			if len(buf) < sz {
+				return nil, fmt.Errorf("parquet: truncated page")
+			}
+			vs[i] = string(buf[:sz])
+			buf = buf[sz:]
+		}
+		return vs, nil
+	default:
+		return nil, fmt.Errorf("parquet: unsupported physical type %d", typ)
+	}
+}
+
+// RLEDecode decodes n values, encoded with the RLE/bit-packing hybrid
+// encoding with the provided bit width.
+func RLEDecode(buf []byte, width, n int) ([]int32, error) {
+	var (
+		vs    = make([]int32, 0, n)
+		nbyte = (width + 7) / 8
+	)
+	for len(vs) < n {
+		h, sz := binary.Uvarint(buf)
+		if sz <= 0 {
+			return nil, fmt.Errorf("parquet: invalid RLE run header")
+		}
+		buf = buf[sz:]
+
+		switch h & 1 {
+		case 0:
+			// RLE run.
+			if len(buf) < nbyte {
+				return nil, fmt.Errorf("parquet: truncated RLE run")
+			}
+			var v uint32
+			for i := 0; i < nbyte; i++ {
+				v |= uint32(buf[i]) << (8 * i)
+			}
+			buf = buf[nbyte:]
+			for i := 0; i < int(h>>1) && len(vs) < n; i++ {
+				vs = append(vs, int32(v))
+			}
+		default:
+			// bit-packed run.
+			var (
+				nvals = int(h>>1) * 8
+				size  = int(h>>1) * width
+			)
+			if len(buf) < size {
+				return nil, fmt.Errorf("parquet: truncated bit-packed run")
+			}
+			var (
+				acc  uint64
+				bits int
+				mask = uint64(1)<<width - 1
+			)
+			for i, j := 0, 0; i < nvals; i++ {
+				for bits < width {
+					acc |= uint64(buf[j]) << bits
+					bits += 8
+					j++
+				}
+				if len(vs) < n {
+					vs = append(vs, int32(acc&mask))
+				}
+				acc >>= width
+				bits -= width
+			}
+			buf = buf[size:]
+		}
+	}
+	return vs, nil
+}
+
+// RLEEncode encodes the provided values with the RLE/bit-packing hybrid
+// encoding with the provided bit width, as a sequence of RLE runs.
+func RLEEncode(vs []int32, width int) []byte {
+	var (
+		w     tWriter
+		nbyte = (width + 7) / 8
+	)
+	for i := 0; i < len(vs); {
+		j := i + 1
+		for j < len(vs) && vs[j] == vs[i] {
+			j++
+		}
+		w.uvarint(uint64(j-i) << 1)
+		for k := 0; k < nbyte; k++ {
+			w.buf = append(w.buf, byte(uint32(vs[i])>>(8*k)))
+		}
+		i = j
+	}
+	return w.buf
+}
+
+// BitWidth returns the number of bits needed to encode v.
+func BitWidth(v int) int {
+	n := 0
+	for ; v > 0; v >>= 1 {
+		n++
+	}
+	return n
+}
+
+// DictGather returns the values of the dictionary at the provided indices.
+func DictGather(dict interface{}, idx []int32) (interface{}, error) {
+	var n int
+	switch dict := dict.(type) {
+	case []bool:
+		n = len(dict)
+	case []int32:
+		n = len(dict)
+	case []int64:
+		n = len(dict)
+	case []float32:
+		n = len(dict)
+	case []float64:
+		n = len(dict)
+	case []string:
+		n = len(dict)
+	}
+	for _, i := range idx {
+		if i < 0 || int(i) >= n {
+			return nil, fmt.Errorf("parquet: invalid dictionary index %d (size=%d)", i, n)
+		}
+	}
+
+	switch dict := dict.(type) {
+	case []bool:
+		vs := make([]bool, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	case []int32:
+		vs := make([]int32, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	case []int64:
+		vs := make([]int64, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	case []float32:
+		vs := make([]float32, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	case []float64:
+		vs := make([]float64, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	case []string:
+		vs := make([]string, len(idx))
+		for i, j := range idx {
+			vs[i] = dict[j]
+		}
+		return vs, nil
+	default:
+		return nil, fmt.Errorf("parquet: missing dictionary page")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	for _, tc := range []struct {
+		typ  int32
+		vals interface{}
+		size int
+	}{
+		{TypeBoolean, []bool{true, false, false, true, true, true, false, true, true}, 2},
+		{TypeInt32, []int32{0, -1, 42, 1 << 30}, 16},
+		{TypeInt64, []int64{0, -1, 42, 1 << 60}, 32},
+		{TypeFloat, []float32{0, -1.5, 42}, 12},
+		{TypeDouble, []float64{0, -1.5, 42, 1e300}, 32},
+		{TypeByteArray, []string{"", "hello", "world!"}, 3*4 + 11},
+	} {
+		t.Run(reflect.TypeOf(tc.vals).String(), func(t *testing.T) {
+			buf := PlainEncode(tc.vals)
+			if got, want := len(buf), tc.size; got != want {
+				t.Fatalf("invalid encoded size: got=%d, want=%d", got, want)
+			}
+
+			n := reflect.ValueOf(tc.vals).Len()
+			got, err := PlainDecode(tc.typ, buf, n)
+			if err != nil {
+				t.Fatalf("could not decode values: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.vals) {
+				t.Fatalf("invalid round trip:\ngot= %v\nwant=%v", got, tc.vals)
+			}
+
+			if tc.size == 0 {
+				return
+			}
+			_, err = PlainDecode(tc.typ, buf[:len(buf)-1], n)
+			if err == nil {
+				t.Fatalf("expected an error decoding truncated values")
+			}
+		})
+	}
+
+	_, err := PlainDecode(TypeInt96, make([]byte, 12), 1)
+	if err == nil {
+		t.Fatalf("expected an error decoding INT96 values")
+	}
+}
+
+// bitPacked encodes vs as a bit-packed run of the provided bit width.
+func bitPacked(vs []uint32, width int) []byte {
+	var (
+		ngrp = (len(vs) + 7) / 8
+		w    tWriter
+		out  = make([]byte, ngrp*width)
+		ibit = 0
+	)
+	w.uvarint(uint64(ngrp)<<1 | 1)
+	for _, v := range vs {
+		for j := 0; j < width; j++ {
+			if v&(1<<j) != 0 {
+				out[ibit/8] |= 1 << (ibit % 8)
+			}
+			ibit++
+		}
+	}
+	return append(w.buf, out...)
+}
+
+func TestRLE(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		vals  []int32
+		width int
+	}{
+		{"empty", nil, 1},
+		{"levels", []int32{0, 0, 1, 1, 1, 0, 1}, 1},
+		{"runs", []int32{3, 3, 3, 3, 7, 7, 0, 5}, 3},
+		{"wide", []int32{1000, 1000, 65535, 2}, 16},
+		{"long", make([]int32, 1000), 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := RLEEncode(tc.vals, tc.width)
+			got, err := RLEDecode(buf, tc.width, len(tc.vals))
+			if err != nil {
+				t.Fatalf("could not decode values: %+v", err)
+			}
+			if len(got) == 0 && len(tc.vals) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tc.vals) {
+				t.Fatalf("invalid round trip:\ngot= %v\nwant=%v", got, tc.vals)
+			}
+		})
+	}
+
+	t.Run("rle-encoding", func(t *testing.T) {
+		got := RLEEncode([]int32{1, 1, 1, 2}, 2)
+		want := []byte{3 << 1, 1, 1 << 1, 2}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("invalid encoding: got=%x, want=%x", got, want)
+		}
+	})
+
+	t.Run("bit-packed", func(t *testing.T) {
+		vals := []uint32{0, 1, 2, 3, 4, 5, 6, 7, 7, 6}
+		buf := bitPacked(vals, 3)
+		// mix bit-packed and RLE runs.
+		var w tWriter
+		w.uvarint(4 << 1)
+		w.buf = append(w.buf, 5)
+		buf = append(buf, w.buf...)
+
+		got, err := RLEDecode(buf, 3, 16+4)
+		if err != nil {
+			t.Fatalf("could not decode values: %+v", err)
+		}
+		want := []int32{0, 1, 2, 3, 4, 5, 6, 7, 7, 6, 0, 0, 0, 0, 0, 0, 5, 5, 5, 5}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, want)
+		}
+
+		// values beyond n are padding.
+		got, err = RLEDecode(buf, 3, 10)
+		if err != nil {
+			t.Fatalf("could not decode values: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want[:10]) {
+			t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, want[:10])
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		buf  []byte
+	}{
+		{"header", nil},
+		{"rle", []byte{2 << 1}},
+		{"bit-packed", []byte{1<<1 | 1, 0xff}},
+	} {
+		t.Run("invalid-"+tc.name, func(t *testing.T) {
+			_, err := RLEDecode(tc.buf, 8, 2)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestBitWidth(t *testing.T) {
+	for _, tc := range []struct {
+		v    int
+		want int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{3, 2},
+		{4, 3},
+		{255, 8},
+		{256, 9},
+	} {
+		if got := BitWidth(tc.v); got != tc.want {
+			t.Fatalf("invalid bit width for %d: got=%d, want=%d", tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestDictGather(t *testing.T) {
+	idx := []int32{2, 0, 0, 1}
+	for _, tc := range []struct {
+		dict interface{}
+		want interface{}
+	}{
+		{[]bool{true, false, true}, []bool{true, true, true, false}},
+		{[]int32{10, 11, 12}, []int32{12, 10, 10, 11}},
+		{[]int64{10, 11, 12}, []int64{12, 10, 10, 11}},
+		{[]float32{10, 11, 12}, []float32{12, 10, 10, 11}},
+		{[]float64{10, 11, 12}, []float64{12, 10, 10, 11}},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "a", "b"}},
+	} {
+		t.Run(reflect.TypeOf(tc.dict).String(), func(t *testing.T) {
+			got, err := DictGather(tc.dict, idx)
+			if err != nil {
+				t.Fatalf("could not gather values: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, tc.want)
+			}
+
+			_, err = DictGather(tc.dict, []int32{3})
+			if err == nil {
+				t.Fatalf("expected an error for an out of range index")
+			}
+		})
+	}
+
+	_, err := DictGather(nil, idx)
+	if err == nil {
+		t.Fatalf("expected an error for a missing dictionary")
+	}
+}
@@ -0,0 +1,565 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+// This file holds the subset of the Parquet file metadata, as described in
+// https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift,
+// needed to read and write n-tuples of flat and LIST columns.
+
+// physical types.
+const (
+	TypeBoolean   = 0
+	TypeInt32     = 1
+	TypeInt64     = 2
+	TypeInt96     = 3
+	TypeFloat     = 4
+	TypeDouble    = 5
+	TypeByteArray = 6
+	TypeFixedLen  = 7
+)
+
+// repetition types.
+const (
+	RepRequired = 0
+	RepOptional = 1
+	RepRepeated = 2
+)
+
+// converted types.
+const (
+	ConvUTF8   = 0
+	ConvList   = 3
+	ConvUint8  = 11
+	ConvUint16 = 12
+	ConvUint32 = 13
+	ConvUint64 = 14
+	ConvInt8   = 15
+	ConvInt16  = 16
+	ConvInt32  = 17
+	ConvInt64  = 18
+)
+
+// encodings.
+const (
+	EncPlain         = 0
+	EncPlainDict     = 2
+	EncRLE           = 3
+	EncBitPacked     = 4
+	EncRLEDictionary = 8
+)
+
+// page types.
+const (
+	PageData       = 0
+	PageIndex      = 1
+	PageDictionary = 2
+	PageDataV2     = 3
+)
+
+// FileMetaData describes the content of a Parquet file.
+type FileMetaData struct {
+	Version   int32
+	Schema    []SchemaElement
+	NumRows   int64
+	RowGroups []RowGroup
+	KeyValues []KeyValue
+	CreatedBy string
+}
+
+// SchemaElement describes a node of the schema tree.
+type SchemaElement struct {
+	Type        int32 // physical type, -1 if none
+	Repetition  int32 // repetition type, -1 if none
+	Name        string
+	NumChildren int32
+	Converted   int32 // converted type, -1 if none
+
+	// logical type.
+	LogicalString bool // STRING logical type
+	LogicalList   bool // LIST logical type
+	IntBits       int8 // bit width of the INTEGER logical type, 0 if none
+	IntSigned     bool // signedness of the INTEGER logical type
+}
+
+type RowGroup struct {
+	Columns        []ColumnChunk
+	TotalSize      int64 // total uncompressed size of the column chunks
+	NumRows        int64
+	FileOffset     int64
+	CompressedSize int64 // total compressed size of the column chunks
+}
+
+type ColumnChunk struct {
+	FileOffset int64
+	MetaData   ColumnMetaData
+}
+
+type ColumnMetaData struct {
+	Type             int32
+	Encodings        []int32
+	Path             []string
+	Codec            int32
+	NumValues        int64
+	UncompressedSize int64
+	CompressedSize   int64
+	DataPageOffset   int64
+	DictPageOffset   int64 // -1 if none
+	Statistics       Statistics
+}
+
+// Statistics holds the PLAIN encoded minimum and maximum values of a
+// column chunk.
+type Statistics struct {
+	Min, Max           []byte // deprecated, signed, statistics
+	MinValue, MaxValue []byte // statistics following the column sort order
+	NullCount          int64  // number of null values, -1 if unknown
+}
+
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+type PageHeader struct {
+	Type             int32
+	UncompressedSize int32
+	CompressedSize   int32
+
+	// data page (v1 and v2).
+	NumValues int32
+	Encoding  int32
+
+	// data page v2.
+	NumNulls     int32
+	DefLevelsLen int32
+	RepLevelsLen int32
+	Compressed   bool
+}
+
+// Marshal returns the file metadata, encoded with the Thrift compact
+// protocol.
+func (md *FileMetaData) Marshal() []byte {
+	var w tWriter
+	md.marshal(&w)
+	return w.buf
+}
+
+// Unmarshal decodes the file metadata from buf, encoded with the Thrift
+// compact protocol.
+func (md *FileMetaData) Unmarshal(buf []byte) error {
+	r := tReader{buf: buf}
+	md.unmarshal(&r)
+	return r.err
+}
+
+// Marshal returns the page header, encoded with the Thrift compact protocol.
+func (ph *PageHeader) Marshal() []byte {
+	var w tWriter
+	ph.marshal(&w)
+	return w.buf
+}
+
+// Unmarshal decodes the page header from the start of buf, encoded with
+// the Thrift compact protocol, and returns the number of bytes read.
+func (ph *PageHeader) Unmarshal(buf []byte) (int, error) {
+	r := tReader{buf: buf}
+	ph.unmarshal(&r)
+	return r.pos, r.err
+}
+
+func (md *FileMetaData) marshal(w *tWriter) {
+	w.beginStruct()
+	w.fieldI32(1, md.Version)
+	w.field(2, tList)
+	w.listHeader(tStruct, len(md.Schema))
+	for i := range md.Schema {
+		md.Schema[i].marshal(w)
+	}
+	w.fieldI64(3, md.NumRows)
+	w.field(4, tList)
+	w.listHeader(tStruct, len(md.RowGroups))
+	for i := range md.RowGroups {
+		md.RowGroups[i].marshal(w)
+	}
+	if len(md.KeyValues) > 0 {
+		w.field(5, tList)
+		w.listHeader(tStruct, len(md.KeyValues))
+		for _, kv := range md.KeyValues {
+			w.beginStruct()
+			w.fieldString(1, kv.Key)
+			w.fieldString(2, kv.Value)
+			w.endStruct()
+		}
+	}
+	if md.CreatedBy != "" {
+		w.fieldString(6, md.CreatedBy)
+	}
+	w.endStruct()
+}
+
+func (md *FileMetaData) unmarshal(r *tReader) {
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			md.Version = r.i32()
+		case id == 2 && typ == tList:
+			_, n := r.listHeader()
+			md.Schema = make([]SchemaElement, n)
+			for i := range md.Schema {
+				md.Schema[i].unmarshal(r)
+			}
+		case id == 3 && typ == tI64:
+			md.NumRows = r.i64()
+		case id == 4 && typ == tList:
+			_, n := r.listHeader()
+			md.RowGroups = make([]RowGroup, n)
+			for i := range md.RowGroups {
+				md.RowGroups[i].unmarshal(r)
+			}
+		case id == 5 && typ == tList:
+			_, n := r.listHeader()
+			md.KeyValues = make([]KeyValue, n)
+			for i := range md.KeyValues {
+				kv := &md.KeyValues[i]
+				r.readStruct(func(id int16, typ byte) {
+					switch {
+					case id == 1 && typ == tBinary:
+						kv.Key = r.string()
+					case id == 2 && typ == tBinary:
+						kv.Value = r.string()
+					default:
+						r.skip(typ)
+					}
+				})
+			}
+		case id == 6 && typ == tBinary:
+			md.CreatedBy = r.string()
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (se *SchemaElement) marshal(w *tWriter) {
+	w.beginStruct()
+	if se.Type >= 0 {
+		w.fieldI32(1, se.Type)
+	}
+	if se.Repetition >= 0 {
+		w.fieldI32(3, se.Repetition)
+	}
+	w.fieldString(4, se.Name)
+	if se.NumChildren > 0 {
+		w.fieldI32(5, se.NumChildren)
+	}
+	if se.Converted >= 0 {
+		w.fieldI32(6, se.Converted)
+	}
+	switch {
+	case se.LogicalString:
+		w.field(10, tStruct)
+		w.beginStruct()
+		w.field(1, tStruct)
+		w.beginStruct()
+		w.endStruct()
+		w.endStruct()
+	case se.LogicalList:
+		w.field(10, tStruct)
+		w.beginStruct()
+		w.field(3, tStruct)
+		w.beginStruct()
+		w.endStruct()
+		w.endStruct()
+	case se.IntBits > 0:
+		w.field(10, tStruct)
+		w.beginStruct()
+		w.field(10, tStruct)
+		w.beginStruct()
+		w.fieldByte(1, se.IntBits)
+		w.fieldBool(2, se.IntSigned)
+		w.endStruct()
+		w.endStruct()
+	}
+	w.endStruct()
+}
+
+func (se *SchemaElement) unmarshal(r *tReader) {
+	se.Type = -1
+	se.Repetition = -1
+	se.Converted = -1
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			se.Type = r.i32()
+		case id == 3 && typ == tI32:
+			se.Repetition = r.i32()
+		case id == 4 && typ == tBinary:
+			se.Name = r.string()
+		case id == 5 && typ == tI32:
+			se.NumChildren = r.i32()
+		case id == 6 && typ == tI32:
+			se.Converted = r.i32()
+		case id == 10 && typ == tStruct:
+			r.readStruct(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tStruct:
+					se.LogicalString = true
+					r.skip(typ)
+				case id == 3 && typ == tStruct:
+					se.LogicalList = true
+					r.skip(typ)
+				case id == 10 && typ == tStruct:
+					r.readStruct(func(id int16, typ byte) {
+						switch {
+						case id == 1 && typ == tByte:
+							se.IntBits = int8(r.byte())
+						case id == 2 && (typ == tTrue || typ == tFalse):
+							se.IntSigned = r.bool(typ)
+						default:
+							r.skip(typ)
+						}
+					})
+				default:
+					r.skip(typ)
+				}
+			})
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (rg *RowGroup) marshal(w *tWriter) {
+	w.beginStruct()
+	w.field(1, tList)
+	w.listHeader(tStruct, len(rg.Columns))
+	for i := range rg.Columns {
+		rg.Columns[i].marshal(w)
+	}
+	w.fieldI64(2, rg.TotalSize)
+	w.fieldI64(3, rg.NumRows)
+	w.fieldI64(5, rg.FileOffset)
+	w.fieldI64(6, rg.CompressedSize)
+	w.endStruct()
+}
+
+func (rg *RowGroup) unmarshal(r *tReader) {
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tList:
+			_, n := r.listHeader()
+			rg.Columns = make([]ColumnChunk, n)
+			for i := range rg.Columns {
+				rg.Columns[i].unmarshal(r)
+			}
+		case id == 2 && typ == tI64:
+			rg.TotalSize = r.i64()
+		case id == 3 && typ == tI64:
+			rg.NumRows = r.i64()
+		case id == 5 && typ == tI64:
+			rg.FileOffset = r.i64()
+		case id == 6 && typ == tI64:
+			rg.CompressedSize = r.i64()
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (cc *ColumnChunk) marshal(w *tWriter) {
+	w.beginStruct()
+	w.fieldI64(2, cc.FileOffset)
+	w.field(3, tStruct)
+	cc.MetaData.marshal(w)
+	w.endStruct()
+}
+
+func (cc *ColumnChunk) unmarshal(r *tReader) {
+	cc.MetaData.DictPageOffset = -1
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 2 && typ == tI64:
+			cc.FileOffset = r.i64()
+		case id == 3 && typ == tStruct:
+			cc.MetaData.unmarshal(r)
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (md *ColumnMetaData) marshal(w *tWriter) {
+	w.beginStruct()
+	w.fieldI32(1, md.Type)
+	w.field(2, tList)
+	w.listHeader(tI32, len(md.Encodings))
+	for _, enc := range md.Encodings {
+		w.varint(int64(enc))
+	}
+	w.field(3, tList)
+	w.listHeader(tBinary, len(md.Path))
+	for _, p := range md.Path {
+		w.binary([]byte(p))
+	}
+	w.fieldI32(4, md.Codec)
+	w.fieldI64(5, md.NumValues)
+	w.fieldI64(6, md.UncompressedSize)
+	w.fieldI64(7, md.CompressedSize)
+	w.fieldI64(9, md.DataPageOffset)
+	if md.DictPageOffset >= 0 {
+		w.fieldI64(11, md.DictPageOffset)
+	}
+	if md.Statistics.MinValue != nil {
+		w.field(12, tStruct)
+		w.beginStruct()
+		if md.Statistics.NullCount >= 0 {
+			w.fieldI64(3, md.Statistics.NullCount)
+		}
+		w.fieldBinary(5, md.Statistics.MaxValue)
+		w.fieldBinary(6, md.Statistics.MinValue)
+		w.endStruct()
+	}
+	w.endStruct()
+}
+
+func (md *ColumnMetaData) unmarshal(r *tReader) {
+	md.DictPageOffset = -1
+	md.Statistics.NullCount = -1
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			md.Type = r.i32()
+		case id == 2 && typ == tList:
+			_, n := r.listHeader()
+			md.Encodings = make([]int32, n)
+			for i := range md.Encodings {
+				md.Encodings[i] = r.i32()
+			}
+		case id == 3 && typ == tList:
+			_, n := r.listHeader()
+			md.Path = make([]string, n)
+			for i := range md.Path {
+				md.Path[i] = r.string()
+			}
+		case id == 4 && typ == tI32:
+			md.Codec = r.i32()
+		case id == 5 && typ == tI64:
+			md.NumValues = r.i64()
+		case id == 6 && typ == tI64:
+			md.UncompressedSize = r.i64()
+		case id == 7 && typ == tI64:
+			md.CompressedSize = r.i64()
+		case id == 9 && typ == tI64:
+			md.DataPageOffset = r.i64()
+		case id == 11 && typ == tI64:
+			md.DictPageOffset = r.i64()
+		case id == 12 && typ == tStruct:
+			md.Statistics.unmarshal(r)
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (st *Statistics) unmarshal(r *tReader) {
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tBinary:
+			st.Max = r.binary()
+		case id == 2 && typ == tBinary:
+			st.Min = r.binary()
+		case id == 3 && typ == tI64:
+			st.NullCount = r.i64()
+		case id == 5 && typ == tBinary:
+			st.MaxValue = r.binary()
+		case id == 6 && typ == tBinary:
+			st.MinValue = r.binary()
+		default:
+			r.skip(typ)
+		}
+	})
+}
+
+func (ph *PageHeader) marshal(w *tWriter) {
+	w.beginStruct()
+	w.fieldI32(1, ph.Type)
+	w.fieldI32(2, ph.UncompressedSize)
+	w.fieldI32(3, ph.CompressedSize)
+	switch ph.Type {
+	case PageData:
+		w.field(5, tStruct)
+		w.beginStruct()
+		w.fieldI32(1, ph.NumValues)
+		w.fieldI32(2, ph.Encoding)
+		w.fieldI32(3, EncRLE)
+		w.fieldI32(4, EncRLE)
+		w.endStruct()
+	case PageDictionary:
+		w.field(7, tStruct)
+		w.beginStruct()
+		w.fieldI32(1, ph.NumValues)
+		w.fieldI32(2, ph.Encoding)
+		w.endStruct()
+	case PageDataV2:
+		w.field(8, tStruct)
+		w.beginStruct()
+		w.fieldI32(1, ph.NumValues)
+		w.fieldI32(2, ph.NumNulls)
+		w.fieldI32(3, ph.NumValues)
+		w.fieldI32(4, ph.Encoding)
+		w.fieldI32(5, ph.DefLevelsLen)
+		w.fieldI32(6, ph.RepLevelsLen)
+		w.fieldBool(7, ph.Compressed)
+		w.endStruct()
+	}
+	w.endStruct()
+}
+
+func (ph *PageHeader) unmarshal(r *tReader) {
+	ph.Compressed = true
+	r.readStruct(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			ph.Type = r.i32()
+		case id == 2 && typ == tI32:
+			ph.UncompressedSize = r.i32()
+		case id == 3 && typ == tI32:
+			ph.CompressedSize = r.i32()
+		case (id == 5 || id == 7) && typ == tStruct:
+			// data page and dictionary page headers.
+			r.readStruct(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					ph.NumValues = r.i32()
+				case id == 2 && typ == tI32:
+					ph.Encoding = r.i32()
+				default:
+					r.skip(typ)
+				}
+			})
+		case id == 8 && typ == tStruct:
+			r.readStruct(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					ph.NumValues = r.i32()
+				case id == 2 && typ == tI32:
+					ph.NumNulls = r.i32()
+				case id == 4 && typ == tI32:
+					ph.Encoding = r.i32()
+				case id == 5 && typ == tI32:
+					ph.DefLevelsLen = r.i32()
+				case id == 6 && typ == tI32:
+					ph.RepLevelsLen = r.i32()
+				case id == 7 && (typ == tTrue || typ == tFalse):
+					ph.Compressed = r.bool(typ)
+				default:
+					r.skip(typ)
+				}
+			})
+		default:
+			r.skip(typ)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileMetaData(t *testing.T) {
+	want := FileMetaData{
+		Version: 1,
+		Schema: []SchemaElement{
+			{Type: -1, Repetition: -1, Converted: -1, Name: "schema", NumChildren: 4},
+			{Type: TypeInt32, Repetition: RepRequired, Converted: ConvInt16, Name: "i16", IntBits: 16, IntSigned: true},
+			{Type: TypeInt64, Repetition: RepRequired, Converted: ConvUint64, Name: "u64", IntBits: 64},
+			{Type: TypeByteArray, Repetition: RepOptional, Converted: ConvUTF8, Name: "str", LogicalString: true},
+			{Type: -1, Repetition: RepRequired, Converted: ConvList, Name: "list", NumChildren: 1, LogicalList: true},
+		},
+		NumRows: 42,
+		RowGroups: []RowGroup{{
+			Columns: []ColumnChunk{{
+				FileOffset: 4,
+				MetaData: ColumnMetaData{
+					Type:             TypeDouble,
+					Encodings:        []int32{EncPlain, EncRLE},
+					Path:             []string{"list", "list", "element"},
+					Codec:            CodecSnappy,
+					NumValues:        42,
+					UncompressedSize: 400,
+					CompressedSize:   300,
+					DataPageOffset:   4,
+					DictPageOffset:   -1,
+					Statistics: Statistics{
+						MinValue:  PlainEncode([]float64{-1}),
+						MaxValue:  PlainEncode([]float64{+1}),
+						NullCount: 2,
+					},
+				},
+			}},
+			TotalSize:      400,
+			NumRows:        42,
+			FileOffset:     4,
+			CompressedSize: 300,
+		}},
+		KeyValues: []KeyValue{{Key: "k1", Value: "v1"}, {Key: "k2", Value: ""}},
+		CreatedBy: "go-hep",
+	}
+
+	var got FileMetaData
+	err := got.Unmarshal(want.Marshal())
+	if err != nil {
+		t.Fatalf("could not unmarshal file metadata: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid round trip:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestFileMetaDataInvalid(t *testing.T) {
+	md := FileMetaData{
+		Version:   1,
+		Schema:    []SchemaElement{{Type: -1, Repetition: -1, Converted: -1, Name: "schema"}},
+		CreatedBy: "go-hep",
+	}
+	buf := md.Marshal()
+
+	for _, n := range []int{0, 1, len(buf) / 2, len(buf) - 1} {
+		var got FileMetaData
+		err := got.Unmarshal(buf[:n])
+		if err == nil {
+			t.Fatalf("expected an error decoding %d bytes out of %d", n, len(buf))
+		}
+	}
+}
+
+func TestPageHeader(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		hdr  PageHeader
+	}{
+		{
+			name: "data",
+			hdr: PageHeader{
+				Type:             PageData,
+				UncompressedSize: 100,
+				CompressedSize:   80,
+				NumValues:        10,
+				Encoding:         EncPlain,
+				Compressed:       true,
+			},
+		},
+		{
+			name: "dict",
+			hdr: PageHeader{
+				Type:             PageDictionary,
+				UncompressedSize: 50,
+				CompressedSize:   50,
+				NumValues:        5,
+				Encoding:         EncPlainDict,
+				Compressed:       true,
+			},
+		},
+		{
+			name: "data-v2",
+			hdr: PageHeader{
+				Type:             PageDataV2,
+				UncompressedSize: 100,
+				CompressedSize:   90,
+				NumValues:        10,
+				Encoding:         EncRLEDictionary,
+				NumNulls:         3,
+				DefLevelsLen:     4,
+				RepLevelsLen:     2,
+				Compressed:       false,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := tc.hdr.Marshal()
+			// page headers are followed by the page payload.
+			buf := append(raw, 0xde, 0xad, 0xbe, 0xef)
+
+			var got PageHeader
+			n, err := got.Unmarshal(buf)
+			if err != nil {
+				t.Fatalf("could not unmarshal page header: %+v", err)
+			}
+			if n != len(raw) {
+				t.Fatalf("invalid number of bytes read: got=%d, want=%d", n, len(raw))
+			}
+			if !reflect.DeepEqual(got, tc.hdr) {
+				t.Fatalf("invalid round trip:\ngot= %+v\nwant=%+v", got, tc.hdr)
+			}
+
+			_, err = got.Unmarshal(raw[:len(raw)-1])
+			if err == nil {
+				t.Fatalf("expected an error decoding a truncated page header")
+			}
+		})
+	}
+}
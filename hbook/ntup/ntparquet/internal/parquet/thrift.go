@@ -0,0 +1,275 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// types of the Thrift compact protocol.
+const (
+	tStop   = 0
+	tTrue   = 1
+	tFalse  = 2
+	tByte   = 3
+	tI16    = 4
+	tI32    = 5
+	tI64    = 6
+	tDouble = 7
+	tBinary = 8
+	tList   = 9
+	tSet    = 10
+	tMap    = 11
+	tStruct = 12
+)
+
+// tWriter encodes values with the Thrift compact protocol.
+type tWriter struct {
+	buf  []byte
+	last []int16 // ids of the last written fields, one per nested struct
+}
+
+func (w *tWriter) beginStruct() {
+	w.last = append(w.last, 0)
+}
+
+func (w *tWriter) endStruct() {
+	w.buf = append(w.buf, tStop)
+	w.last = w.last[:len(w.last)-1]
+}
+
+func (w *tWriter) field(id int16, typ byte) {
+	var (
+		i     = len(w.last) - 1
+		delta = id - w.last[i]
+	)
+	switch {
+	case 0 < delta && delta <= 15:
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	default:
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	w.last[i] = id
+}
+
+func (w *tWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (w *tWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	w.buf = append(w.buf, tmp[:n]...)
+}
+
+func (w *tWriter) binary(v []byte) {
+	w.uvarint(uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *tWriter) listHeader(typ byte, n int) {
+	switch {
+	case n < 15:
+		w.buf = append(w.buf, byte(n)<<4|typ)
+	default:
+		w.buf = append(w.buf, 0xf0|typ)
+		w.uvarint(uint64(n))
+	}
+}
+
+func (w *tWriter) fieldBool(id int16, v bool) {
+	switch v {
+	case true:
+		w.field(id, tTrue)
+	default:
+		w.field(id, tFalse)
+	}
+}
+
+func (w *tWriter) fieldByte(id int16, v int8) {
+	w.field(id, tByte)
+	w.buf = append(w.buf, byte(v))
+}
+
+func (w *tWriter) fieldI32(id int16, v int32) {
+	w.field(id, tI32)
+	w.varint(int64(v))
+}
+
+func (w *tWriter) fieldI64(id int16, v int64) {
+	w.field(id, tI64)
+	w.varint(v)
+}
+
+func (w *tWriter) fieldBinary(id int16, v []byte) {
+	w.field(id, tBinary)
+	w.binary(v)
+}
+
+func (w *tWriter) fieldString(id int16, v string) {
+	w.fieldBinary(id, []byte(v))
+}
+
+// tReader decodes values encoded with the Thrift compact protocol.
+type tReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *tReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos >= len(r.buf) {
+		r.err = fmt.Errorf("parquet: truncated thrift data")
+		return 0
+	}
+	v := r.buf[r.pos]
+	r.pos++
+	return v
+}
+
+func (r *tReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		r.err = fmt.Errorf("parquet: invalid thrift varint")
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+func (r *tReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *tReader) i32() int32 {
+	return int32(r.varint())
+}
+
+func (r *tReader) i64() int64 {
+	return r.varint()
+}
+
+func (r *tReader) double() float64 {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos+8 > len(r.buf) {
+		r.err = fmt.Errorf("parquet: truncated thrift data")
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(r.buf[r.pos:]))
+	r.pos += 8
+	return v
+}
+
+func (r *tReader) binary() []byte {
+	n := int(r.uvarint())
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.buf) {
+		r.err = fmt.Errorf("parquet: truncated thrift data")
+		return nil
+	}
+	v := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return v
+}
+
+func (r *tReader) string() string {
+	return string(r.binary())
+}
+
+func (r *tReader) listHeader() (typ byte, n int) {
+	v := r.byte()
+	typ = v & 0x0f
+	n = int(v >> 4)
+	if n == 15 {
+		n = int(r.uvarint())
+	}
+	return typ, n
+}
+
+// readStruct reads the fields of a struct, calling f for each of them.
+// f must consume the value of the field, or skip it.
+func (r *tReader) readStruct(f func(id int16, typ byte)) {
+	var last int16
+	for r.err == nil {
+		v := r.byte()
+		typ := v & 0x0f
+		if typ == tStop {
+			return
+		}
+		id := last + int16(v>>4)
+		if v>>4 == 0 {
+			id = int16(r.varint())
+		}
+		last = id
+		f(id, typ)
+	}
+}
+
+// bool returns the value of a boolean field of the given type.
+func (r *tReader) bool(typ byte) bool {
+	return typ == tTrue
+}
+
+// skip skips a value of the given type.
+func (r *tReader) skip(typ byte) {
+	switch typ {
+	case tTrue, tFalse:
+		// value is stored in the type.
+	case tByte:
+		r.byte()
+	case tI16, tI32, tI64:
+		r.uvarint()
+	case tDouble:
+		r.double()
+	case tBinary:
+		r.binary()
+	case tList, tSet:
+		etyp, n := r.listHeader()
+		for i := 0; i < n && r.err == nil; i++ {
+			r.skipElem(etyp)
+		}
+	case tMap:
+		n := int(r.uvarint())
+		if n == 0 {
+			return
+		}
+		kv := r.byte()
+		for i := 0; i < n && r.err == nil; i++ {
+			r.skipElem(kv >> 4)
+			r.skipElem(kv & 0x0f)
+		}
+	case tStruct:
+		r.readStruct(func(id int16, typ byte) { r.skip(typ) })
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("parquet: invalid thrift type %d", typ)
+		}
+	}
+}
+
+// skipElem skips an element of a container.
+func (r *tReader) skipElem(typ byte) {
+	switch typ {
+	case tTrue, tFalse:
+		// booleans are stored as a byte in containers.
+		r.byte()
+	default:
+		r.skip(typ)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parquet
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestThriftVarint(t *testing.T) {
+	for _, v := range []int64{
+		0, 1, -1, 2, -2, 63, -64, 64, 1 << 20, -1 << 20,
+		math.MaxInt32, math.MinInt32, math.MaxInt64, math.MinInt64,
+	} {
+		var w tWriter
+		w.varint(v)
+
+		r := tReader{buf: w.buf}
+		got := r.varint()
+		if r.err != nil {
+			t.Fatalf("could not decode %d: %+v", v, r.err)
+		}
+		if got != v {
+			t.Fatalf("invalid round trip: got=%d, want=%d", got, v)
+		}
+		if r.pos != len(w.buf) {
+			t.Fatalf("invalid number of bytes read for %d: got=%d, want=%d", v, r.pos, len(w.buf))
+		}
+	}
+
+	// zigzag encoding.
+	for _, tc := range []struct {
+		v    int64
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{-1, []byte{0x01}},
+		{1, []byte{0x02}},
+		{-2, []byte{0x03}},
+		{64, []byte{0x80, 0x01}},
+	} {
+		var w tWriter
+		w.varint(tc.v)
+		if !bytes.Equal(w.buf, tc.want) {
+			t.Fatalf("invalid encoding of %d: got=%x, want=%x", tc.v, w.buf, tc.want)
+		}
+	}
+}
+
+func TestThriftStruct(t *testing.T) {
+	var w tWriter
+	w.beginStruct()
+	w.fieldI32(1, -42)
+	w.fieldBool(2, true)
+	w.fieldBool(3, false)
+	w.fieldString(4, "hello")
+	w.fieldI64(40, 1<<40) // field id delta larger than 15.
+	w.fieldByte(41, -3)
+	w.field(42, tList)
+	w.listHeader(tI32, 20) // long list header.
+	for i := 0; i < 20; i++ {
+		w.varint(int64(i))
+	}
+	w.field(43, tStruct)
+	w.beginStruct()
+	w.fieldI32(1, 7)
+	w.endStruct()
+	w.endStruct()
+
+	var (
+		r    = tReader{buf: w.buf}
+		ids  []int16
+		i32  int32
+		b2   bool
+		b3   bool
+		str  string
+		i64  int64
+		i8   byte
+		list []int32
+		sub  int32
+	)
+	r.readStruct(func(id int16, typ byte) {
+		ids = append(ids, id)
+		switch id {
+		case 1:
+			i32 = r.i32()
+		case 2:
+			b2 = r.bool(typ)
+		case 3:
+			b3 = r.bool(typ)
+		case 4:
+			str = r.string()
+		case 40:
+			i64 = r.i64()
+		case 41:
+			i8 = r.byte()
+		case 42:
+			_, n := r.listHeader()
+			for i := 0; i < n; i++ {
+				list = append(list, r.i32())
+			}
+		case 43:
+			r.readStruct(func(id int16, typ byte) {
+				switch id {
+				case 1:
+					sub = r.i32()
+				default:
+					r.skip(typ)
+				}
+			})
+		default:
+			r.skip(typ)
+		}
+	})
+	if r.err != nil {
+		t.Fatalf("could not decode struct: %+v", r.err)
+	}
+	if r.pos != len(w.buf) {
+		t.Fatalf("invalid number of bytes read: got=%d, want=%d", r.pos, len(w.buf))
+	}
+
+	if got, want := len(ids), 8; got != want {
+		t.Fatalf("invalid number of fields: got=%d, want=%d (ids=%v)", got, want, ids)
+	}
+	if i32 != -42 || !b2 || b3 || str != "hello" || i64 != 1<<40 || int8(i8) != -3 || sub != 7 {
+		t.Fatalf("invalid fields: i32=%d, b2=%v, b3=%v, str=%q, i64=%d, i8=%d, sub=%d", i32, b2, b3, str, i64, int8(i8), sub)
+	}
+	if len(list) != 20 || list[19] != 19 {
+		t.Fatalf("invalid list: %v", list)
+	}
+}
+
+func TestThriftSkip(t *testing.T) {
+	var w tWriter
+	w.beginStruct()
+	w.fieldI32(1, 1)
+	// unknown fields of all kinds.
+	w.fieldBool(2, true)
+	w.fieldByte(3, 4)
+	w.field(4, tI16)
+	w.varint(-5)
+	w.fieldI64(5, 6)
+	w.field(6, tDouble)
+	w.buf = append(w.buf, make([]byte, 8)...)
+	w.fieldBinary(7, []byte("binary"))
+	w.field(8, tList)
+	w.listHeader(tTrue, 2)
+	w.buf = append(w.buf, 1, 0)
+	w.field(9, tSet)
+	w.listHeader(tBinary, 1)
+	w.binary([]byte("set"))
+	w.field(10, tMap)
+	w.uvarint(1)
+	w.buf = append(w.buf, tBinary<<4|tI32)
+	w.binary([]byte("key"))
+	w.varint(42)
+	w.field(11, tStruct)
+	w.beginStruct()
+	w.fieldString(1, "nested")
+	w.endStruct()
+	w.field(12, tMap)
+	w.uvarint(0) // empty map.
+	w.fieldI32(13, 13)
+	w.endStruct()
+
+	var (
+		r     = tReader{buf: w.buf}
+		first int32
+		last  int32
+	)
+	r.readStruct(func(id int16, typ byte) {
+		switch id {
+		case 1:
+			first = r.i32()
+		case 13:
+			last = r.i32()
+		default:
+			r.skip(typ)
+		}
+	})
+	if r.err != nil {
+		t.Fatalf("could not skip fields: %+v", r.err)
+	}
+	if first != 1 || last != 13 {
+		t.Fatalf("invalid fields: got=(%d, %d), want=(1, 13)", first, last)
+	}
+	if r.pos != len(w.buf) {
+		t.Fatalf("invalid number of bytes read: got=%d, want=%d", r.pos, len(w.buf))
+	}
+}
+
+func TestThriftInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		buf  []byte
+		f    func(r *tReader)
+		err  string
+	}{
+		{
+			name: "empty",
+			buf:  nil,
+			f:    func(r *tReader) { r.byte() },
+			err:  "parquet: truncated thrift data",
+		},
+		{
+			name: "varint",
+			buf:  []byte{0x80, 0x80},
+			f:    func(r *tReader) { r.varint() },
+			err:  "parquet: invalid thrift varint",
+		},
+		{
+			name: "double",
+			buf:  []byte{1, 2, 3},
+			f:    func(r *tReader) { r.double() },
+			err:  "parquet: truncated thrift data",
+		},
+		{
+			name: "binary",
+			buf:  []byte{10, 'a', 'b'},
+			f:    func(r *tReader) { r.binary() },
+			err:  "parquet: truncated thrift data",
+		},
+		{
+			name: "type",
+			buf:  []byte{0x1d, 0},
+			f: func(r *tReader) {
+				r.readStruct(func(id int16, typ byte) { r.skip(typ) })
+			},
+			err: "parquet: invalid thrift type 13",
+		},
+		{
+			name: "struct",
+			buf:  []byte{0x15, 0x02},
+			f: func(r *tReader) {
+				r.readStruct(func(id int16, typ byte) { r.skip(typ) })
+			},
+			err: "parquet: truncated thrift data",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tReader{buf: tc.buf}
+			tc.f(&r)
+			if r.err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := r.err.Error(), tc.err; !strings.Contains(got, want) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ntparquet provides read/write access to n-tuple data stored in
+// Apache Parquet files, without going through a database/sql layer.
+//
+// Each exported field of a Go struct is mapped to a Parquet column,
+// named after the "hbook", "rio" or "db" struct tag of the field (or its
+// name), as for ntup.Create.
+// Fields of type T are stored as required columns, fields of type *T as
+// optional columns (nil pointers being stored as null values) and fields
+// of type []T as LIST columns.
+// A field can be declared as the per-row weight column of the n-tuple
+// with the "weight" option of its hbook struct tag.
+//
+// Example:
+//
+//	type Event struct {
+//	    X float64 `hbook:"x"`
+//	    W float64 `hbook:"w,weight"`
+//	}
+//
+//	w, err := ntparquet.Create(f, Event{}, ntparquet.WithZstd(3))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = w.Append([]Event{{X: 1, W: 0.5}, {X: 2, W: 2}})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = w.Close()
+//
+// Files written by this package can be read back by the Python/Arrow
+// ecosystem (pyarrow, pandas, polars, ...), and this package can read
+// Parquet files written by those tools, provided their columns are
+// primitive columns or lists of primitive values.
+//
+// Supported value types are bool, (u)int8, (u)int16, (u)int32, (u)int64,
+// int, uint, float32, float64 and string.
+package ntparquet // import "go-hep.org/x/hep/hbook/ntup/ntparquet"
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet/internal/parquet"
+)
+
+const (
+	magic = "PAR1"
+
+	// DefaultRowGroupSize is the default number of rows of a row group.
+	DefaultRowGroupSize = 64 * 1024
+
+	// kvWeight is the key of the file metadata holding the name of the
+	// weight column.
+	kvWeight = "hbook.weight"
+)
+
+// Option configures a Parquet n-tuple writer.
+type Option func(cfg *config) error
+
+type config struct {
+	codec int32 // compression codec
+	lvl   int   // compression level
+	nrows int   // number of rows per row group
+
+	kvs []parquet.KeyValue // key-value metadata
+}
+
+func newConfig(opts []Option) (config, error) {
+	cfg := config{
+		codec: parquet.CodecSnappy,
+		nrows: DefaultRowGroupSize,
+	}
+	for _, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithoutCompression configures a Parquet n-tuple to not use any
+// compression mechanism.
+func WithoutCompression() Option {
+	return func(cfg *config) error {
+		cfg.codec = parquet.CodecUncompressed
+		return nil
+	}
+}
+
+// WithSnappy configures a Parquet n-tuple to use Snappy as a compression
+// mechanism.
+// Snappy is the default compression mechanism.
+func WithSnappy() Option {
+	return func(cfg *config) error {
+		cfg.codec = parquet.CodecSnappy
+		return nil
+	}
+}
+
+// WithGzip configures a Parquet n-tuple to use gzip as a compression
+// mechanism, with the provided compression level.
+func WithGzip(level int) Option {
+	return func(cfg *config) error {
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			return fmt.Errorf("ntparquet: invalid gzip compression level %d", level)
+		}
+		cfg.codec = parquet.CodecGzip
+		cfg.lvl = level
+		return nil
+	}
+}
+
+// WithZstd configures a Parquet n-tuple to use zstd as a compression
+// mechanism, with the provided compression level.
+// Levels are mapped onto the closest zstd encoder speed, as for
+// zstd.EncoderLevelFromZstd.
+func WithZstd(level int) Option {
+	return func(cfg *config) error {
+		cfg.codec = parquet.CodecZstd
+		cfg.lvl = level
+		return nil
+	}
+}
+
+// WithRowGroupSize configures the number of rows of the row groups of a
+// Parquet n-tuple.
+// If n is <= 0, DefaultRowGroupSize is used.
+func WithRowGroupSize(n int) Option {
+	return func(cfg *config) error {
+		if n <= 0 {
+			n = DefaultRowGroupSize
+		}
+		cfg.nrows = n
+		return nil
+	}
+}
+
+// WithMetadata adds a key-value pair to the metadata of a Parquet n-tuple.
+func WithMetadata(key, value string) Option {
+	return func(cfg *config) error {
+		if key == kvWeight {
+			return fmt.Errorf("ntparquet: reserved metadata key %q", key)
+		}
+		cfg.kvs = append(cfg.kvs, parquet.KeyValue{Key: key, Value: value})
+		return nil
+	}
+}
+
+// column describes a column of a Parquet n-tuple.
+// column implements the ntup.Descriptor interface.
+type column struct {
+	name  string
+	typ   reflect.Type // Go type of the column
+	field int          // index of the struct field holding the column
+
+	groups []parquet.SchemaElement // Parquet description of the groups of a LIST column
+	elem   parquet.SchemaElement   // Parquet description of the values of the column
+	path   []string                // path of the values of the column in the schema
+
+	// definition and repetition levels.
+	list   bool  // whether the column holds lists of values
+	maxDef int32 // definition level of defined values
+	maxRep int32 // repetition level of the values of lists
+	defNil int32 // definition level under which a list is null
+	defLen int32 // definition level under which a list is empty
+}
+
+// Name returns the name of the column.
+func (col *column) Name() string { return col.name }
+
+// Type returns the Go type of the column.
+func (col *column) Type() reflect.Type { return col.typ }
+
+// columnsFrom returns the columns and the name of the weight column
+// described by the provided struct type.
+func columnsFrom(rt reflect.Type) ([]*column, string, error) {
+	if rt.Kind() != reflect.Struct {
+		return nil, "", fmt.Errorf("ntparquet: expected a struct, got %v", rt)
+	}
+
+	var (
+		cols   []*column
+		weight string
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !ast.IsExported(f.Name) {
+			continue
+		}
+		name, isWeight, err := fieldName(f)
+		if err != nil {
+			return nil, "", err
+		}
+		if isWeight {
+			if weight != "" {
+				return nil, "", fmt.Errorf("ntparquet: multiple weight columns (%q, %q)", weight, name)
+			}
+			weight = name
+		}
+		col, err := newColumn(name, f.Type)
+		if err != nil {
+			return nil, "", err
+		}
+		col.field = i
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return nil, "", fmt.Errorf("ntparquet: no column in %v", rt)
+	}
+	return cols, weight, nil
+}
+
+// fieldName returns the name of the column associated with the provided
+// struct field, and whether it is a weight column.
+func fieldName(f reflect.StructField) (string, bool, error) {
+	var (
+		name   = ""
+		weight = false
+	)
+	for _, k := range []string{"hbook", "rio", "db"} {
+		v := f.Tag.Get(k)
+		if v != "" && v != "-" {
+			name = v
+			break
+		}
+	}
+	if i := strings.Index(name, ","); i >= 0 {
+		opt := name[i+1:]
+		name = name[:i]
+		switch opt {
+		case "weight":
+			weight = true
+		default:
+			return "", false, fmt.Errorf("ntparquet: invalid struct tag option %q for field %q", opt, f.Name)
+		}
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, weight, nil
+}
+
+// newColumn returns the description of a column of the provided Go type.
+//
+// Pointers to scalar values are mapped to OPTIONAL columns, where nil
+// pointers are stored as null values.
+// Slices of scalar values are mapped to LIST columns.
+func newColumn(name string, rt reflect.Type) (*column, error) {
+	col := &column{name: name, typ: rt, field: -1}
+	et := rt
+	switch rt.Kind() {
+	case reflect.Ptr:
+		et = rt.Elem()
+		col.maxDef = 1
+	case reflect.Slice:
+		et = rt.Elem()
+		col.list = true
+		col.maxDef = 1
+		col.maxRep = 1
+		col.defLen = 1
+		col.groups = []parquet.SchemaElement{
+			{Type: -1, Repetition: parquet.RepRequired, Name: name, NumChildren: 1, Converted: parquet.ConvList, LogicalList: true},
+			{Type: -1, Repetition: parquet.RepRepeated, Name: "list", NumChildren: 1, Converted: -1},
+		}
+	}
+
+	elem, err := scalarElement(name, et)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: unsupported type %v for column %q", rt, name)
+	}
+	switch {
+	case col.list:
+		elem.Name = "element"
+		col.path = []string{name, "list", "element"}
+	case col.maxDef > 0:
+		elem.Repetition = parquet.RepOptional
+		col.path = []string{name}
+	default:
+		col.path = []string{name}
+	}
+	col.elem = elem
+
+	return col, nil
+}
+
+// scalarElement returns the Parquet description of the required values
+// of the provided scalar Go type.
+func scalarElement(name string, rt reflect.Type) (parquet.SchemaElement, error) {
+	elem := parquet.SchemaElement{
+		Repetition: parquet.RepRequired,
+		Name:       name,
+		Converted:  -1,
+	}
+	integer := func(ptyp, conv int32, bits int8, signed bool) {
+		elem.Type = ptyp
+		elem.Converted = conv
+		elem.IntBits = bits
+		elem.IntSigned = signed
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		elem.Type = parquet.TypeBoolean
+	case reflect.Int8:
+		integer(parquet.TypeInt32, parquet.ConvInt8, 8, true)
+	case reflect.Int16:
+		integer(parquet.TypeInt32, parquet.ConvInt16, 16, true)
+	case reflect.Int32:
+		integer(parquet.TypeInt32, parquet.ConvInt32, 32, true)
+	case reflect.Int64, reflect.Int:
+		integer(parquet.TypeInt64, parquet.ConvInt64, 64, true)
+	case reflect.Uint8:
+		integer(parquet.TypeInt32, parquet.ConvUint8, 8, false)
+	case reflect.Uint16:
+		integer(parquet.TypeInt32, parquet.ConvUint16, 16, false)
+	case reflect.Uint32:
+		integer(parquet.TypeInt32, parquet.ConvUint32, 32, false)
+	case reflect.Uint64, reflect.Uint:
+		integer(parquet.TypeInt64, parquet.ConvUint64, 64, false)
+	case reflect.Float32:
+		elem.Type = parquet.TypeFloat
+	case reflect.Float64:
+		elem.Type = parquet.TypeDouble
+	case reflect.String:
+		elem.Type = parquet.TypeByteArray
+		elem.Converted = parquet.ConvUTF8
+		elem.LogicalString = true
+	default:
+		return elem, fmt.Errorf("ntparquet: unsupported type %v for column %q", rt, name)
+	}
+
+	return elem, nil
+}
+
+// columnsFromSchema returns the description of the columns of the
+// provided Parquet schema.
+//
+// Top-level primitive columns are supported, as well as LIST columns of
+// primitive values, following the 3-level structure of the Parquet
+// specification, its 2-level legacy variant, and repeated primitive
+// columns.
+func columnsFromSchema(schema []parquet.SchemaElement) ([]*column, error) {
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("ntparquet: empty Parquet schema")
+	}
+
+	var (
+		cols []*column
+		i    = 1
+	)
+	for n := 0; n < int(schema[0].NumChildren); n++ {
+		if i >= len(schema) {
+			return nil, fmt.Errorf("ntparquet: truncated Parquet schema")
+		}
+		var (
+			top   = schema[i]
+			col   = &column{name: top.Name, field: -1}
+			outer = int32(0) // definition level of the outer group of a list
+		)
+		if top.Repetition == parquet.RepOptional {
+			outer = 1
+		}
+
+		switch {
+		case top.NumChildren == 0 && top.Repetition != parquet.RepRepeated:
+			col.elem = top
+			col.path = []string{top.Name}
+			col.maxDef = outer
+			i++
+
+		case top.NumChildren == 0:
+			// legacy repeated primitive column.
+			col.elem = top
+			col.path = []string{top.Name}
+			col.list = true
+			col.maxRep = 1
+			col.maxDef = 1
+			col.defLen = 1
+			i++
+
+		case (top.Converted == parquet.ConvList || top.LogicalList) && top.NumChildren == 1 &&
+			i+1 < len(schema) && schema[i+1].Repetition == parquet.RepRepeated:
+			rep := schema[i+1]
+			col.list = true
+			col.maxRep = 1
+			col.defNil = outer
+			col.defLen = outer + 1
+			col.maxDef = outer + 1
+			switch {
+			case rep.NumChildren == 0:
+				// legacy 2-level list.
+				col.groups = []parquet.SchemaElement{top}
+				col.elem = rep
+				col.path = []string{top.Name, rep.Name}
+				i += 2
+			case rep.NumChildren == 1 && i+2 < len(schema) && schema[i+2].NumChildren == 0 &&
+				schema[i+2].Repetition != parquet.RepRepeated:
+				elem := schema[i+2]
+				col.groups = []parquet.SchemaElement{top, rep}
+				col.elem = elem
+				col.path = []string{top.Name, rep.Name, elem.Name}
+				if elem.Repetition == parquet.RepOptional {
+					col.maxDef++
+				}
+				i += 3
+			default:
+				return nil, fmt.Errorf("ntparquet: nested column %q not supported", top.Name)
+			}
+
+		default:
+			return nil, fmt.Errorf("ntparquet: nested column %q not supported", top.Name)
+		}
+
+		rt, err := scalarType(col.elem)
+		if err != nil {
+			return nil, err
+		}
+		col.typ = rt
+		if col.list {
+			col.typ = reflect.SliceOf(rt)
+		}
+		cols = append(cols, col)
+	}
+	if i != len(schema) {
+		return nil, fmt.Errorf("ntparquet: invalid Parquet schema")
+	}
+
+	return cols, nil
+}
+
+// scalarType returns the Go type of the values of a primitive Parquet
+// schema element.
+func scalarType(elem parquet.SchemaElement) (reflect.Type, error) {
+	var (
+		rt     reflect.Type
+		bits   = elem.IntBits
+		signed = elem.IntSigned
+	)
+	switch elem.Converted {
+	case parquet.ConvInt8:
+		bits, signed = 8, true
+	case parquet.ConvInt16:
+		bits, signed = 16, true
+	case parquet.ConvInt32:
+		bits, signed = 32, true
+	case parquet.ConvInt64:
+		bits, signed = 64, true
+	case parquet.ConvUint8:
+		bits, signed = 8, false
+	case parquet.ConvUint16:
+		bits, signed = 16, false
+	case parquet.ConvUint32:
+		bits, signed = 32, false
+	case parquet.ConvUint64:
+		bits, signed = 64, false
+	}
+
+	switch elem.Type {
+	case parquet.TypeBoolean:
+		rt = reflect.TypeOf(false)
+	case parquet.TypeInt32:
+		switch {
+		case bits == 8 && signed:
+			rt = reflect.TypeOf(int8(0))
+		case bits == 16 && signed:
+			rt = reflect.TypeOf(int16(0))
+		case bits == 8:
+			rt = reflect.TypeOf(uint8(0))
+		case bits == 16:
+			rt = reflect.TypeOf(uint16(0))
+		case bits == 32 && !signed:
+			rt = reflect.TypeOf(uint32(0))
+		default:
+			rt = reflect.TypeOf(int32(0))
+		}
+	case parquet.TypeInt64:
+		switch {
+		case bits == 64 && !signed:
+			rt = reflect.TypeOf(uint64(0))
+		default:
+			rt = reflect.TypeOf(int64(0))
+		}
+	case parquet.TypeFloat:
+		rt = reflect.TypeOf(float32(0))
+	case parquet.TypeDouble:
+		rt = reflect.TypeOf(float64(0))
+	case parquet.TypeByteArray:
+		rt = reflect.TypeOf("")
+	default:
+		return nil, fmt.Errorf("ntparquet: unsupported physical type %d for column %q", elem.Type, elem.Name)
+	}
+
+	return rt, nil
+}
@@ -0,0 +1,520 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntparquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/hbook/ntup/ntparquet"
+)
+
+type event struct {
+	B   bool    `hbook:"b"`
+	I8  int8    `hbook:"i8"`
+	I16 int16   `hbook:"i16"`
+	I32 int32   `hbook:"i32"`
+	I64 int64   `hbook:"i64"`
+	I   int     `hbook:"i"`
+	U8  uint8   `hbook:"u8"`
+	U16 uint16  `hbook:"u16"`
+	U32 uint32  `hbook:"u32"`
+	U64 uint64  `hbook:"u64"`
+	F32 float32 `hbook:"f32"`
+	F64 float64 `hbook:"f64"`
+	Str string  `hbook:"str"`
+	W   float64 `hbook:"w,weight"`
+
+	unexported int
+}
+
+func newEvents(n int) []event {
+	evts := make([]event, n)
+	for i := range evts {
+		evts[i] = event{
+			B:   i%2 == 0,
+			I8:  int8(-i),
+			I16: int16(-i * 100),
+			I32: int32(-i * 10000),
+			I64: int64(-i) << 40,
+			I:   -i,
+			U8:  uint8(200 + i),
+			U16: uint16(60000 + i),
+			U32: uint32(4000000000 + i),
+			U64: uint64(1)<<63 + uint64(i),
+			F32: float32(i) + 0.5,
+			F64: float64(i) + 0.25,
+			Str: fmt.Sprintf("evt-%d", i),
+			W:   float64(i%3) + 1,
+		}
+	}
+	return evts
+}
+
+func TestRoundTrip(t *testing.T) {
+	const nevts = 10
+	want := newEvents(nevts)
+
+	for _, tc := range []struct {
+		name string
+		opts []ntparquet.Option
+	}{
+		{"default", nil},
+		{"uncompressed", []ntparquet.Option{ntparquet.WithoutCompression()}},
+		{"snappy", []ntparquet.Option{ntparquet.WithSnappy(), ntparquet.WithRowGroupSize(3)}},
+		{"gzip", []ntparquet.Option{ntparquet.WithGzip(9), ntparquet.WithRowGroupSize(4)}},
+		{"zstd", []ntparquet.Option{ntparquet.WithZstd(3), ntparquet.WithRowGroupSize(nevts)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := ntparquet.Create(&buf, event{}, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			if got, want := w.Weight(), "w"; got != want {
+				t.Fatalf("invalid weight column: got=%q, want=%q", got, want)
+			}
+
+			err = w.Append(want[:1])
+			if err != nil {
+				t.Fatalf("could not append slice: %+v", err)
+			}
+			err = w.Append(want[1])
+			if err != nil {
+				t.Fatalf("could not append value: %+v", err)
+			}
+			for i := range want[2:] {
+				err = w.Append(&want[2+i])
+				if err != nil {
+					t.Fatalf("could not append pointer: %+v", err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+
+			err = w.Append(want[0])
+			if err == nil {
+				t.Fatalf("expected an error appending to a closed writer")
+			}
+
+			r, err := ntparquet.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			if got, want := r.NumRows(), int64(nevts); got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			if got, want := r.Weight(), "w"; got != want {
+				t.Fatalf("invalid weight column: got=%q, want=%q", got, want)
+			}
+
+			rcols := r.Cols()
+			wcols := w.Cols()
+			if len(rcols) != len(wcols) {
+				t.Fatalf("invalid number of columns: got=%d, want=%d", len(rcols), len(wcols))
+			}
+			for i := range rcols {
+				var (
+					rc = rcols[i]
+					wc = wcols[i]
+				)
+				if rc.Name() != wc.Name() || rc.Type() != wc.Type() && wc.Type().Kind() != reflect.Int {
+					t.Fatalf("invalid column %d: got=(%q, %v), want=(%q, %v)",
+						i, rc.Name(), rc.Type(), wc.Name(), wc.Type(),
+					)
+				}
+			}
+
+			var got []event
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read n-tuple: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid round trip:\ngot= %+v\nwant=%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestColumn(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "col.parquet")
+	f, err := os.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	w, err := ntparquet.Create(f, event{}, ntparquet.WithRowGroupSize(2))
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	err = w.Append(newEvents(5))
+	if err != nil {
+		t.Fatalf("could not append rows: %+v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := ntparquet.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	var i8 []float64
+	err = r.Column("i8", &i8)
+	if err != nil {
+		t.Fatalf("could not read column: %+v", err)
+	}
+	if got, want := i8, []float64{0, -1, -2, -3, -4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column: got=%v, want=%v", got, want)
+	}
+
+	var str []string
+	err = r.Column("str", &str)
+	if err != nil {
+		t.Fatalf("could not read column: %+v", err)
+	}
+	if got, want := str, []string{"evt-0", "evt-1", "evt-2", "evt-3", "evt-4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid column: got=%v, want=%v", got, want)
+	}
+
+	h, err := r.ScanH1D("f64", nil)
+	if err != nil {
+		t.Fatalf("could not scan column: %+v", err)
+	}
+	if got, want := h.Entries(), int64(5); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h.SumW(), 1.0+2+3+1+2; got != want {
+		t.Fatalf("invalid sum of weights: got=%v, want=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		dst  interface{}
+		err  error
+	}{
+		{
+			name: "f64",
+			dst:  []float64{},
+			err:  fmt.Errorf("ntparquet: expected a pointer to a slice, got []float64"),
+		},
+		{
+			name: "not-there",
+			dst:  new([]float64),
+			err:  fmt.Errorf(`ntparquet: unknown column "not-there"`),
+		},
+		{
+			name: "str",
+			dst:  new([]float64),
+			err:  fmt.Errorf(`ntparquet: column "str" of type string can not be stored into float64`),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.Column(tc.name, tc.dst)
+			if err == nil || err.Error() != tc.err.Error() {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCreateInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		row  interface{}
+		opts []ntparquet.Option
+		err  error
+	}{
+		{
+			name: "nil",
+			row:  nil,
+			err:  fmt.Errorf("ntparquet: invalid nil row"),
+		},
+		{
+			name: "not-a-struct",
+			row:  42.0,
+			err:  fmt.Errorf("ntparquet: expected a struct, got float64"),
+		},
+		{
+			name: "no-column",
+			row:  struct{ x float64 }{},
+			err:  fmt.Errorf("ntparquet: no column in struct { x float64 }"),
+		},
+		{
+			name: "unsupported-type",
+			row: struct {
+				X [][]float64 `hbook:"x"`
+			}{},
+			err: fmt.Errorf(`ntparquet: unsupported type [][]float64 for column "x"`),
+		},
+		{
+			name: "invalid-tag",
+			row: struct {
+				X float64 `hbook:"x,foo"`
+			}{},
+			err: fmt.Errorf(`ntparquet: invalid struct tag option "foo" for field "X"`),
+		},
+		{
+			name: "multiple-weights",
+			row: struct {
+				W1 float64 `hbook:"w1,weight"`
+				W2 float64 `hbook:"w2,weight"`
+			}{},
+			err: fmt.Errorf(`ntparquet: multiple weight columns ("w1", "w2")`),
+		},
+		{
+			name: "invalid-gzip-level",
+			row:  event{},
+			opts: []ntparquet.Option{ntparquet.WithGzip(42)},
+			err:  fmt.Errorf("ntparquet: invalid gzip compression level 42"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ntparquet.Create(new(bytes.Buffer), tc.row, tc.opts...)
+			if err == nil || err.Error() != tc.err.Error() {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{
+			name: "too-small",
+			raw:  []byte("PAR1PAR1"),
+			err:  fmt.Errorf("ntparquet: file too small to be a Parquet file"),
+		},
+		{
+			name: "header",
+			raw:  []byte("XXXX\x00\x00\x00\x00PAR1"),
+			err:  fmt.Errorf(`ntparquet: invalid Parquet file header magic "XXXX"`),
+		},
+		{
+			name: "footer",
+			raw:  []byte("PAR1\x00\x00\x00\x00XXXX"),
+			err:  fmt.Errorf(`ntparquet: invalid Parquet file footer magic "XXXX"`),
+		},
+		{
+			name: "metadata-size",
+			raw:  []byte("PAR1\xff\x00\x00\x00PAR1"),
+			err:  fmt.Errorf("ntparquet: invalid Parquet metadata size 255"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ntparquet.NewReader(bytes.NewReader(tc.raw), int64(len(tc.raw)))
+			if err == nil || err.Error() != tc.err.Error() {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRoundTripNullsLists(t *testing.T) {
+	type row struct {
+		X *float64  `hbook:"x"`
+		N *int32    `hbook:"n"`
+		S *string   `hbook:"s"`
+		L []float64 `hbook:"l"`
+		U []uint16  `hbook:"u"`
+		T []string  `hbook:"t"`
+	}
+
+	ptr := func(i int) row {
+		var (
+			x = float64(i) + 0.5
+			n = int32(-i)
+			s = fmt.Sprintf("s-%d", i)
+		)
+		return row{X: &x, N: &n, S: &s}
+	}
+
+	want := make([]row, 10)
+	for i := range want {
+		if i%3 != 1 {
+			want[i] = ptr(i)
+		}
+		want[i].L = []float64{}
+		want[i].U = []uint16{}
+		want[i].T = []string{}
+		for j := 0; j < i%4; j++ {
+			want[i].L = append(want[i].L, float64(10*i+j))
+			want[i].U = append(want[i].U, uint16(65535-j))
+			want[i].T = append(want[i].T, fmt.Sprintf("t-%d-%d", i, j))
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []ntparquet.Option
+	}{
+		{"uncompressed", []ntparquet.Option{ntparquet.WithoutCompression(), ntparquet.WithRowGroupSize(4)}},
+		{"snappy", []ntparquet.Option{ntparquet.WithSnappy(), ntparquet.WithRowGroupSize(3)}},
+		{"zstd", []ntparquet.Option{ntparquet.WithZstd(3)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]ntparquet.Option{ntparquet.WithMetadata("origin", "test")}, tc.opts...)
+			w, err := ntparquet.Create(&buf, row{}, opts...)
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			err = w.Append(want)
+			if err != nil {
+				t.Fatalf("could not append rows: %+v", err)
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+
+			r, err := ntparquet.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+
+			if v, ok := r.Metadata("origin"); !ok || v != "test" {
+				t.Fatalf("invalid metadata: got=(%q, %v)", v, ok)
+			}
+			if _, ok := r.Metadata("not-there"); ok {
+				t.Fatalf("unexpected metadata")
+			}
+
+			var got []row
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read rows: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid round trip:\ngot= %+v\nwant=%+v", got, want)
+			}
+
+			var xs []float64
+			err = r.Column("x", &xs)
+			if err != nil {
+				t.Fatalf("could not read column: %+v", err)
+			}
+			if got, want := xs[:3], []float64{0.5, 0, 2.5}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid null values: got=%v, want=%v", got, want)
+			}
+
+			var ls [][]float32
+			err = r.Column("l", &ls)
+			if err != nil {
+				t.Fatalf("could not read column: %+v", err)
+			}
+			if got, want := ls[:4], [][]float32{{}, {10}, {20, 21}, {30, 31, 32}}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid list values: got=%v, want=%v", got, want)
+			}
+
+			h, err := r.ScanH1D("x", nil)
+			if err != nil {
+				t.Fatalf("could not scan column: %+v", err)
+			}
+			if got, want := h.Entries(), int64(7); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestRowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ntparquet.Create(&buf, event{}, ntparquet.WithRowGroupSize(4))
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	evts := newEvents(10)
+	err = w.Append(evts)
+	if err != nil {
+		t.Fatalf("could not append rows: %+v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+
+	r, err := ntparquet.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	if got, want := r.NumRowGroups(), 3; got != want {
+		t.Fatalf("invalid number of row groups: got=%d, want=%d", got, want)
+	}
+
+	for i, tc := range []struct {
+		n   int64
+		beg int
+		i8  [2]float64
+		u64 [2]float64
+		f64 [2]float64
+	}{
+		{4, 0, [2]float64{-3, 0}, [2]float64{1 << 63, 1<<63 + 3}, [2]float64{0.25, 3.25}},
+		{4, 4, [2]float64{-7, -4}, [2]float64{1<<63 + 4, 1<<63 + 7}, [2]float64{4.25, 7.25}},
+		{2, 8, [2]float64{-9, -8}, [2]float64{1<<63 + 8, 1<<63 + 9}, [2]float64{8.25, 9.25}},
+	} {
+		rg := r.RowGroup(i)
+		if got, want := rg.NumRows(), tc.n; got != want {
+			t.Fatalf("row group %d: invalid number of rows: got=%d, want=%d", i, got, want)
+		}
+
+		for _, st := range []struct {
+			name string
+			want [2]float64
+		}{
+			{"i8", tc.i8},
+			{"u64", tc.u64},
+			{"f64", tc.f64},
+		} {
+			min, max, ok := rg.Stats(st.name)
+			if !ok || [2]float64{min, max} != st.want {
+				t.Fatalf("row group %d: invalid stats for %q: got=(%v, %v, %v), want=%v",
+					i, st.name, min, max, ok, st.want,
+				)
+			}
+		}
+		if _, _, ok := rg.Stats("str"); ok {
+			t.Fatalf("row group %d: unexpected stats for string column", i)
+		}
+
+		var strs []string
+		err = rg.Column("str", &strs)
+		if err != nil {
+			t.Fatalf("row group %d: could not read column: %+v", i, err)
+		}
+		for j, s := range strs {
+			if want := evts[tc.beg+j].Str; s != want {
+				t.Fatalf("row group %d: invalid value %d: got=%q, want=%q", i, j, s, want)
+			}
+		}
+		if len(strs) != int(tc.n) {
+			t.Fatalf("row group %d: invalid number of values: got=%d, want=%d", i, len(strs), tc.n)
+		}
+	}
+}
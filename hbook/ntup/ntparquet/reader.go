@@ -0,0 +1,825 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntparquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/ntup"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet/internal/parquet"
+)
+
+// Reader reads n-tuple data from a Parquet file.
+//
+// Reader handles schemas of primitive columns and of LIST columns of
+// primitive values, stored in PLAIN or dictionary encoded data pages
+// (v1 or v2), compressed with Snappy, gzip or zstd.
+//
+// Null values are read as zero values, or as nil pointers when they are
+// read into slices of pointers.
+// Null lists are read as nil slices and null elements of lists as zero
+// values.
+type Reader struct {
+	r io.ReaderAt
+	c io.Closer
+
+	md     parquet.FileMetaData
+	cols   []*column
+	weight string
+}
+
+// Open opens the named Parquet file and returns a n-tuple reader
+// connected to that file.
+func Open(name string) (*Reader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: could not open file: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("ntparquet: could not stat file: %w", err)
+	}
+
+	r, err := NewReader(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r.c = f
+
+	return r, nil
+}
+
+// NewReader returns a n-tuple reader for the Parquet data of the provided
+// size, accessed through r.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, fmt.Errorf("ntparquet: file too small to be a Parquet file")
+	}
+
+	var buf [8]byte
+	_, err := r.ReadAt(buf[:4], 0)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: could not read file header: %w", err)
+	}
+	if string(buf[:4]) != magic {
+		return nil, fmt.Errorf("ntparquet: invalid Parquet file header magic %q", buf[:4])
+	}
+
+	_, err = r.ReadAt(buf[:], size-8)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: could not read file footer: %w", err)
+	}
+	if string(buf[4:]) != magic {
+		return nil, fmt.Errorf("ntparquet: invalid Parquet file footer magic %q", buf[4:])
+	}
+
+	n := int64(binary.LittleEndian.Uint32(buf[:4]))
+	if n > size-8-int64(len(magic)) {
+		return nil, fmt.Errorf("ntparquet: invalid Parquet metadata size %d", n)
+	}
+	raw := make([]byte, n)
+	_, err = r.ReadAt(raw, size-8-n)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: could not read file metadata: %w", err)
+	}
+
+	rd := &Reader{r: r}
+	err = rd.md.Unmarshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("ntparquet: could not decode file metadata: %w", err)
+	}
+
+	rd.cols, err = columnsFromSchema(rd.md.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rg := range rd.md.RowGroups {
+		if len(rg.Columns) != len(rd.cols) {
+			return nil, fmt.Errorf(
+				"ntparquet: invalid number of column chunks in row group %d (got=%d, want=%d)",
+				i, len(rg.Columns), len(rd.cols),
+			)
+		}
+	}
+
+	for _, kv := range rd.md.KeyValues {
+		if kv.Key != kvWeight {
+			continue
+		}
+		if rd.index(kv.Value) < 0 {
+			return nil, fmt.Errorf("ntparquet: unknown weight column %q", kv.Value)
+		}
+		rd.weight = kv.Value
+	}
+
+	return rd, nil
+}
+
+// Close closes the underlying file, if the reader was created with Open.
+func (r *Reader) Close() error {
+	if r.c == nil {
+		return nil
+	}
+	err := r.c.Close()
+	r.c = nil
+	return err
+}
+
+// NumRows returns the number of rows of this n-tuple.
+func (r *Reader) NumRows() int64 {
+	return r.md.NumRows
+}
+
+// Cols returns the columns of this n-tuple.
+func (r *Reader) Cols() []ntup.Descriptor {
+	cols := make([]ntup.Descriptor, len(r.cols))
+	for i, col := range r.cols {
+		cols[i] = col
+	}
+	return cols
+}
+
+// Weight returns the name of the weight column of this n-tuple, or the
+// empty string if this n-tuple is not weighted.
+func (r *Reader) Weight() string {
+	return r.weight
+}
+
+// Metadata returns the value associated with the provided key in the
+// key-value metadata of the file, and whether the key was found.
+func (r *Reader) Metadata(key string) (string, bool) {
+	for _, kv := range r.md.KeyValues {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// NumRowGroups returns the number of row groups of this n-tuple.
+func (r *Reader) NumRowGroups() int {
+	return len(r.md.RowGroups)
+}
+
+// RowGroup returns the i-th row group of this n-tuple.
+func (r *Reader) RowGroup(i int) *RowGroup {
+	return &RowGroup{r: r, rg: &r.md.RowGroups[i]}
+}
+
+// Column reads all the values of the named column into dst, a pointer to
+// a slice.
+// The values are converted to the element type of the slice if it differs
+// from the type of the column, as long as both are numeric types (or
+// both are bool, or both are string), or slices of such types.
+// Values of a column of type T can also be read into a slice of *T, where
+// null values are stored as nil pointers.
+func (r *Reader) Column(name string, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ntparquet: expected a pointer to a slice, got %T", dst)
+	}
+
+	i := r.index(name)
+	if i < 0 {
+		return fmt.Errorf("ntparquet: unknown column %q", name)
+	}
+
+	vs, nulls, err := r.column(i)
+	if err != nil {
+		return err
+	}
+
+	return assign(rv.Elem(), vs, nulls, name)
+}
+
+// Read reads all the rows of the n-tuple into dst, a pointer to a slice
+// of structs.
+// Struct fields are associated with columns of the same name, following
+// the same struct tag conventions as Create.
+// Fields without a matching column are left untouched.
+func (r *Reader) Read(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice ||
+		rv.Elem().Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ntparquet: expected a pointer to a slice of structs, got %T", dst)
+	}
+
+	var (
+		rt   = rv.Elem().Type().Elem()
+		rows = reflect.MakeSlice(rv.Elem().Type(), int(r.md.NumRows), int(r.md.NumRows))
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _, err := fieldName(f)
+		if err != nil {
+			return err
+		}
+		j := r.index(name)
+		if j < 0 {
+			continue
+		}
+
+		vs, nulls, err := r.column(j)
+		if err != nil {
+			return err
+		}
+
+		fv := reflect.New(reflect.SliceOf(f.Type)).Elem()
+		err = assign(fv, vs, nulls, name)
+		if err != nil {
+			return err
+		}
+		for k := 0; k < rows.Len(); k++ {
+			rows.Index(k).Field(i).Set(fv.Index(k))
+		}
+	}
+
+	rv.Elem().Set(rows)
+	return nil
+}
+
+// ScanH1D fills the histogram with the values of the named column,
+// weighted by the weight column of the n-tuple, if any.
+// Rows with a null value or a null weight are skipped.
+// If h is nil, a (100-bins, xmin, xmax+ULP) histogram is created,
+// where xmin and xmax are inferred from the content of the column.
+func (r *Reader) ScanH1D(name string, h *hbook.H1D) (*hbook.H1D, error) {
+	var xs []*float64
+	err := r.Column(name, &xs)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := make([]*float64, len(xs))
+	switch r.weight {
+	case "":
+		one := 1.0
+		for i := range ws {
+			ws[i] = &one
+		}
+	default:
+		err = r.Column(r.weight, &ws)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if h == nil {
+		var (
+			xmin = +math.MaxFloat64
+			xmax = -math.MaxFloat64
+		)
+		for i, x := range xs {
+			if x == nil || ws[i] == nil {
+				continue
+			}
+			xmin = math.Min(xmin, *x)
+			xmax = math.Max(xmax, *x)
+		}
+		h = hbook.NewH1D(100, xmin, math.Nextafter(xmax, math.Inf(+1)))
+	}
+
+	for i, x := range xs {
+		if x == nil || ws[i] == nil {
+			continue
+		}
+		h.Fill(*x, *ws[i])
+	}
+
+	return h, nil
+}
+
+func (r *Reader) index(name string) int {
+	for i, col := range r.cols {
+		if col.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// column reads all the values of the i-th column, as a slice of the
+// column Go type, and returns the rows holding null values, if any.
+func (r *Reader) column(i int) (reflect.Value, []bool, error) {
+	var (
+		col   = r.cols[i]
+		out   = reflect.MakeSlice(reflect.SliceOf(col.typ), 0, int(r.md.NumRows))
+		nulls []bool
+	)
+	for j := range r.md.RowGroups {
+		vs, ns, err := r.rowGroupColumn(&r.md.RowGroups[j], i)
+		if err != nil {
+			return out, nil, fmt.Errorf("ntparquet: could not read column %q of row group %d: %w", col.name, j, err)
+		}
+		if ns != nil && nulls == nil {
+			nulls = make([]bool, out.Len(), r.md.NumRows)
+		}
+		if nulls != nil {
+			if ns == nil {
+				ns = make([]bool, vs.Len())
+			}
+			nulls = append(nulls, ns...)
+		}
+		out = reflect.AppendSlice(out, vs)
+	}
+	return out, nulls, nil
+}
+
+// rowGroupColumn reads the values of the i-th column of the provided row
+// group, as a slice of the column Go type, and returns the rows holding
+// null values, if any.
+func (r *Reader) rowGroupColumn(rg *parquet.RowGroup, i int) (reflect.Value, []bool, error) {
+	col := r.cols[i]
+	defs, reps, vs, err := r.chunk(col, &rg.Columns[i])
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return assemble(col, defs, reps, convert(vs, col.elemType()))
+}
+
+// elemType returns the Go type of the values of the column.
+func (col *column) elemType() reflect.Type {
+	if col.list {
+		return col.typ.Elem()
+	}
+	return col.typ
+}
+
+// chunk reads a column chunk, and returns its definition and repetition
+// levels and its defined values, as a slice of the Go type matching the
+// physical type of the column.
+func (r *Reader) chunk(col *column, cc *parquet.ColumnChunk) (defs, reps []int32, vals interface{}, err error) {
+	md := &cc.MetaData
+	if md.Type != col.elem.Type {
+		return nil, nil, nil, fmt.Errorf("invalid physical type (got=%d, want=%d)", md.Type, col.elem.Type)
+	}
+
+	beg := md.DataPageOffset
+	if 0 < md.DictPageOffset && md.DictPageOffset < beg {
+		beg = md.DictPageOffset
+	}
+	if md.CompressedSize < 0 || md.CompressedSize > math.MaxInt32 {
+		return nil, nil, nil, fmt.Errorf("invalid column chunk size %d", md.CompressedSize)
+	}
+	buf := make([]byte, md.CompressedSize)
+	_, err = r.r.ReadAt(buf, beg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not read column chunk: %w", err)
+	}
+
+	var (
+		vs   []interface{}
+		dict interface{}
+		nval int64
+		pos  int
+	)
+	for nval < md.NumValues && pos < len(buf) {
+		var hdr parquet.PageHeader
+		nhdr, err := hdr.Unmarshal(buf[pos:])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not decode page header: %w", err)
+		}
+		pos += nhdr
+		if hdr.CompressedSize < 0 || int(hdr.CompressedSize) > len(buf)-pos {
+			return nil, nil, nil, fmt.Errorf("invalid page size %d", hdr.CompressedSize)
+		}
+		page := buf[pos : pos+int(hdr.CompressedSize)]
+		pos += int(hdr.CompressedSize)
+
+		var (
+			n    = int(hdr.NumValues)
+			data []byte
+			rs   []int32
+			ds   []int32
+		)
+		switch hdr.Type {
+		case parquet.PageDictionary:
+			data, err = parquet.Decompress(md.Codec, page, int(hdr.UncompressedSize))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			dict, err = parquet.PlainDecode(col.elem.Type, data, n)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			continue
+
+		case parquet.PageData:
+			data, err = parquet.Decompress(md.Codec, page, int(hdr.UncompressedSize))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if col.maxRep > 0 {
+				rs, data, err = levelsV1(data, col.maxRep, n)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("could not decode repetition levels: %w", err)
+				}
+			}
+			if col.maxDef > 0 {
+				ds, data, err = levelsV1(data, col.maxDef, n)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("could not decode definition levels: %w", err)
+				}
+			}
+
+		case parquet.PageDataV2:
+			lvls := int(hdr.RepLevelsLen) + int(hdr.DefLevelsLen)
+			if hdr.RepLevelsLen < 0 || hdr.DefLevelsLen < 0 || lvls > len(page) {
+				return nil, nil, nil, fmt.Errorf("invalid levels size %d", lvls)
+			}
+			if col.maxRep > 0 {
+				rs, err = parquet.RLEDecode(page[:hdr.RepLevelsLen], parquet.BitWidth(int(col.maxRep)), n)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("could not decode repetition levels: %w", err)
+				}
+			}
+			if col.maxDef > 0 {
+				ds, err = parquet.RLEDecode(page[hdr.RepLevelsLen:lvls], parquet.BitWidth(int(col.maxDef)), n)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("could not decode definition levels: %w", err)
+				}
+			}
+			data = page[lvls:]
+			if hdr.Compressed {
+				data, err = parquet.Decompress(md.Codec, data, int(hdr.UncompressedSize)-lvls)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+			}
+
+		default:
+			// index pages, ... hold no values.
+			continue
+		}
+
+		// only defined values are stored in the page.
+		nv := n
+		if col.maxDef > 0 {
+			nv = 0
+			for _, d := range ds {
+				if d == col.maxDef {
+					nv++
+				}
+			}
+		}
+
+		v, err := decode(hdr.Encoding, col.elem.Type, data, nv, dict)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		vs = append(vs, v)
+		defs = append(defs, ds...)
+		reps = append(reps, rs...)
+		nval += int64(n)
+	}
+
+	if nval != md.NumValues {
+		return nil, nil, nil, fmt.Errorf("invalid number of values (got=%d, want=%d)", nval, md.NumValues)
+	}
+
+	switch len(vs) {
+	case 0:
+		vals, err = parquet.PlainDecode(col.elem.Type, nil, 0)
+		return defs, reps, vals, err
+	case 1:
+		return defs, reps, vs[0], nil
+	default:
+		out := reflect.ValueOf(vs[0])
+		for _, v := range vs[1:] {
+			out = reflect.AppendSlice(out, reflect.ValueOf(v))
+		}
+		return defs, reps, out.Interface(), nil
+	}
+}
+
+// levelsV1 decodes the n RLE encoded levels of a v1 data page, prefixed
+// with their size, and returns the rest of the page.
+func levelsV1(data []byte, max int32, n int) ([]int32, []byte, error) {
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("truncated levels")
+	}
+	size := int(binary.LittleEndian.Uint32(data))
+	if size < 0 || size > len(data)-4 {
+		return nil, nil, fmt.Errorf("invalid levels size %d", size)
+	}
+	lvls, err := parquet.RLEDecode(data[4:4+size], parquet.BitWidth(int(max)), n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lvls, data[4+size:], nil
+}
+
+// assemble assembles the defined values of a column chunk, according to
+// their definition and repetition levels, into a slice of the Go type of
+// the column, and returns the rows holding null values, if any.
+func assemble(col *column, defs, reps []int32, vals reflect.Value) (reflect.Value, []bool, error) {
+	var (
+		j     = 0 // index of the next defined value
+		out   reflect.Value
+		nulls []bool
+	)
+	switch {
+	case col.list:
+		out = reflect.MakeSlice(reflect.SliceOf(col.typ), 0, 0)
+		for i, d := range defs {
+			if reps[i] == 0 {
+				out = reflect.Append(out, reflect.Zero(col.typ))
+				nulls = append(nulls, false)
+				row := out.Len() - 1
+				switch {
+				case d < col.defNil:
+					nulls[row] = true
+					continue
+				case d < col.defLen:
+					out.Index(row).Set(reflect.MakeSlice(col.typ, 0, 0))
+					continue
+				}
+			}
+			if out.Len() == 0 {
+				return out, nil, fmt.Errorf("invalid repetition level %d for first value", reps[i])
+			}
+			elem := reflect.Zero(col.typ.Elem())
+			if d == col.maxDef {
+				if j >= vals.Len() {
+					return out, nil, fmt.Errorf("invalid number of values")
+				}
+				elem = vals.Index(j)
+				j++
+			}
+			row := out.Index(out.Len() - 1)
+			row.Set(reflect.Append(row, elem))
+		}
+
+	case col.maxDef > 0:
+		out = reflect.MakeSlice(reflect.SliceOf(col.typ), len(defs), len(defs))
+		nulls = make([]bool, len(defs))
+		for i, d := range defs {
+			if d < col.maxDef {
+				nulls[i] = true
+				continue
+			}
+			if j >= vals.Len() {
+				return out, nil, fmt.Errorf("invalid number of values")
+			}
+			out.Index(i).Set(vals.Index(j))
+			j++
+		}
+
+	default:
+		return vals, nil, nil
+	}
+
+	if j != vals.Len() {
+		return out, nil, fmt.Errorf("invalid number of values (got=%d, want=%d)", j, vals.Len())
+	}
+	return out, nulls, nil
+}
+
+// decode decodes n values of the provided physical type and encoding.
+func decode(enc, typ int32, data []byte, n int, dict interface{}) (interface{}, error) {
+	switch enc {
+	case parquet.EncPlain:
+		return parquet.PlainDecode(typ, data, n)
+	case parquet.EncPlainDict, parquet.EncRLEDictionary:
+		if n == 0 {
+			return parquet.PlainDecode(typ, nil, 0)
+		}
+		if len(data) < 1 {
+			return nil, fmt.Errorf("truncated dictionary indices")
+		}
+		width := int(data[0])
+		if width > 32 {
+			return nil, fmt.Errorf("invalid dictionary indices bit width %d", width)
+		}
+		idx, err := parquet.RLEDecode(data[1:], width, n)
+		if err != nil {
+			return nil, err
+		}
+		return parquet.DictGather(dict, idx)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %d", enc)
+	}
+}
+
+// convert converts the physical values vs to a slice of the provided
+// Go type.
+func convert(vs interface{}, rt reflect.Type) reflect.Value {
+	rv := reflect.ValueOf(vs)
+	if rv.Type().Elem() == rt {
+		return rv
+	}
+	out := reflect.MakeSlice(reflect.SliceOf(rt), rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		out.Index(i).Set(rv.Index(i).Convert(rt))
+	}
+	return out
+}
+
+// assign stores the values of the slice src into the slice dst,
+// converting them to the element type of dst if needed.
+// Null values are stored as nil pointers into slices of pointers, and as
+// zero values otherwise.
+func assign(dst, src reflect.Value, nulls []bool, name string) error {
+	var (
+		dt = dst.Type().Elem()
+		st = src.Type().Elem()
+		et = dt
+	)
+	if dt == st {
+		dst.Set(src)
+		return nil
+	}
+
+	if dt.Kind() == reflect.Ptr {
+		et = dt.Elem()
+	}
+	if !convertible(et, st) {
+		return fmt.Errorf("ntparquet: column %q of type %v can not be stored into %v", name, st, dt)
+	}
+
+	out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		if nulls != nil && nulls[i] {
+			continue
+		}
+		v := convertValue(src.Index(i), et)
+		if dt.Kind() == reflect.Ptr {
+			p := reflect.New(et)
+			p.Elem().Set(v)
+			v = p
+		}
+		out.Index(i).Set(v)
+	}
+	dst.Set(out)
+	return nil
+}
+
+// convertible returns whether values of type src can be converted to
+// values of type dst.
+func convertible(dst, src reflect.Type) bool {
+	if dst == src {
+		return true
+	}
+	if dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice {
+		return convertible(dst.Elem(), src.Elem())
+	}
+	return kindOf(dst) != 0 && kindOf(dst) == kindOf(src)
+}
+
+// convertValue converts the value v to the provided type.
+func convertValue(v reflect.Value, rt reflect.Type) reflect.Value {
+	switch {
+	case v.Type() == rt:
+		return v
+	case v.Kind() == reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(rt)
+		}
+		out := reflect.MakeSlice(rt, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(convertValue(v.Index(i), rt.Elem()))
+		}
+		return out
+	default:
+		return v.Convert(rt)
+	}
+}
+
+// kindOf returns a class of Go kinds that can be converted to each other.
+func kindOf(rt reflect.Type) int {
+	switch rt.Kind() {
+	case reflect.Bool:
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 2
+	case reflect.String:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// RowGroup gives access to the values of a row group of a Parquet n-tuple.
+type RowGroup struct {
+	r  *Reader
+	rg *parquet.RowGroup
+}
+
+// NumRows returns the number of rows of this row group.
+func (rg *RowGroup) NumRows() int64 {
+	return rg.rg.NumRows
+}
+
+// Column reads the values of the named column of this row group into dst,
+// a pointer to a slice, with the same conversion rules as Reader.Column.
+func (rg *RowGroup) Column(name string, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ntparquet: expected a pointer to a slice, got %T", dst)
+	}
+
+	i := rg.r.index(name)
+	if i < 0 {
+		return fmt.Errorf("ntparquet: unknown column %q", name)
+	}
+
+	vs, nulls, err := rg.r.rowGroupColumn(rg.rg, i)
+	if err != nil {
+		return fmt.Errorf("ntparquet: could not read column %q: %w", name, err)
+	}
+
+	return assign(rv.Elem(), vs, nulls, name)
+}
+
+// Stats returns the minimum and maximum values of the named numerical
+// column in this row group, as recorded in the file metadata.
+// Stats returns false if the column has no usable statistics.
+func (rg *RowGroup) Stats(name string) (min, max float64, ok bool) {
+	i := rg.r.index(name)
+	if i < 0 {
+		return 0, 0, false
+	}
+	col := rg.r.cols[i]
+	if col.list {
+		return 0, 0, false
+	}
+
+	var (
+		stats    = rg.rg.Columns[i].MetaData.Statistics
+		unsigned = false
+		bmin     = stats.MinValue
+		bmax     = stats.MaxValue
+	)
+	switch col.typ.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		unsigned = true
+	}
+	if bmin == nil || bmax == nil {
+		// deprecated statistics follow a signed sort order.
+		if unsigned {
+			return 0, 0, false
+		}
+		bmin, bmax = stats.Min, stats.Max
+	}
+	if bmin == nil || bmax == nil {
+		return 0, 0, false
+	}
+
+	decode := func(b []byte) (float64, bool) {
+		switch col.elem.Type {
+		case parquet.TypeInt32:
+			if len(b) != 4 {
+				return 0, false
+			}
+			v := binary.LittleEndian.Uint32(b)
+			if unsigned {
+				return float64(v), true
+			}
+			return float64(int32(v)), true
+		case parquet.TypeInt64:
+			if len(b) != 8 {
+				return 0, false
+			}
+			v := binary.LittleEndian.Uint64(b)
+			if unsigned {
+				return float64(v), true
+			}
+			return float64(int64(v)), true
+		case parquet.TypeFloat:
+			if len(b) != 4 {
+				return 0, false
+			}
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), true
+		case parquet.TypeDouble:
+			if len(b) != 8 {
+				return 0, false
+			}
+			return math.Float64frombits(binary.LittleEndian.Uint64(b)), true
+		}
+		return 0, false
+	}
+
+	min, ok = decode(bmin)
+	if !ok {
+		return 0, 0, false
+	}
+	max, ok = decode(bmax)
+	return min, max, ok
+}
@@ -0,0 +1,463 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntparquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/hbook/ntup/ntparquet/internal/parquet"
+)
+
+// rleRun encodes a RLE run of n values v of the provided bit width.
+func rleRun(n int, v uint32, width int) []byte {
+	buf := appendUvarint(nil, uint64(n)<<1)
+	for i := 0; i < (width+7)/8; i++ {
+		buf = append(buf, byte(v>>(8*i)))
+	}
+	return buf
+}
+
+// bitPacked encodes vs as a bit-packed run of the provided bit width.
+func bitPacked(vs []uint32, width int) []byte {
+	ngrp := (len(vs) + 7) / 8
+	var (
+		buf  = appendUvarint(nil, uint64(ngrp)<<1|1)
+		out  = make([]byte, ngrp*width)
+		ibit = 0
+	)
+	for _, v := range vs {
+		for j := 0; j < width; j++ {
+			if v&(1<<j) != 0 {
+				out[ibit/8] |= 1 << (ibit % 8)
+			}
+			ibit++
+		}
+	}
+	return append(buf, out...)
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func appendU32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+// pqFile builds a Parquet file made of a single row group.
+type pqFile struct {
+	buf bytes.Buffer
+	md  parquet.FileMetaData
+}
+
+func newPQFile(cols ...parquet.SchemaElement) *pqFile {
+	f := &pqFile{
+		md: parquet.FileMetaData{
+			Version: 1,
+			Schema: append([]parquet.SchemaElement{{
+				Type: -1, Repetition: -1, Converted: -1,
+				Name:        "schema",
+				NumChildren: int32(len(cols)),
+			}}, cols...),
+			RowGroups: []parquet.RowGroup{{}},
+		},
+	}
+	f.buf.WriteString(magic)
+	return f
+}
+
+// page writes a page with the provided header and (uncompressed) payload.
+func (f *pqFile) page(hdr parquet.PageHeader, payload []byte) {
+	hdr.UncompressedSize = int32(len(payload))
+	hdr.CompressedSize = int32(len(payload))
+	f.buf.Write(hdr.Marshal())
+	f.buf.Write(payload)
+}
+
+// chunk writes a column chunk made of the pages written by fct.
+func (f *pqFile) chunk(icol int, dict bool, fct func()) {
+	var (
+		beg = int64(f.buf.Len())
+		col = f.md.Schema[1+icol]
+	)
+	fct()
+	md := parquet.ColumnMetaData{
+		Type:           col.Type,
+		Encodings:      []int32{parquet.EncPlain, parquet.EncRLE, parquet.EncRLEDictionary},
+		Path:           []string{col.Name},
+		Codec:          parquet.CodecUncompressed,
+		NumValues:      f.md.NumRows,
+		CompressedSize: int64(f.buf.Len()) - beg,
+		DataPageOffset: beg,
+		DictPageOffset: -1,
+	}
+	md.UncompressedSize = md.CompressedSize
+	if dict {
+		md.DictPageOffset = beg
+		md.DataPageOffset = beg + 1 // pages start with the dictionary one.
+	}
+	rg := &f.md.RowGroups[0]
+	rg.Columns = append(rg.Columns, parquet.ColumnChunk{FileOffset: beg, MetaData: md})
+	rg.NumRows = f.md.NumRows
+}
+
+func (f *pqFile) bytes() []byte {
+	md := f.md.Marshal()
+	f.buf.Write(md)
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(md)))
+	f.buf.Write(n[:])
+	f.buf.WriteString(magic)
+	return f.buf.Bytes()
+}
+
+func TestReadDictPages(t *testing.T) {
+	f := newPQFile(
+		parquet.SchemaElement{Type: parquet.TypeInt64, Repetition: parquet.RepOptional, Name: "x", Converted: -1},
+		parquet.SchemaElement{Type: parquet.TypeByteArray, Repetition: parquet.RepRequired, Name: "s", Converted: parquet.ConvUTF8, LogicalString: true},
+	)
+	f.md.NumRows = 7
+
+	f.chunk(0, true, func() {
+		f.page(
+			parquet.PageHeader{Type: parquet.PageDictionary, NumValues: 3, Encoding: parquet.EncPlainDict},
+			parquet.PlainEncode([]int64{10, 20, 30}),
+		)
+
+		// v1 data page, with definition levels.
+		var payload []byte
+		defs := rleRun(4, 1, 1)
+		payload = appendU32(payload, uint32(len(defs)))
+		payload = append(payload, defs...)
+		payload = append(payload, 2)
+		payload = append(payload, bitPacked([]uint32{2, 0, 1, 2}, 2)...)
+		f.page(parquet.PageHeader{Type: parquet.PageData, NumValues: 4, Encoding: parquet.EncPlainDict}, payload)
+
+		// v2 data page, with definition levels.
+		defs = rleRun(3, 1, 1)
+		payload = append([]byte{}, defs...)
+		payload = append(payload, 2)
+		payload = append(payload, rleRun(3, 1, 2)...)
+		f.page(parquet.PageHeader{
+			Type: parquet.PageDataV2, NumValues: 3, Encoding: parquet.EncRLEDictionary,
+			DefLevelsLen: int32(len(defs)), Compressed: false,
+		}, payload)
+	})
+
+	f.chunk(1, true, func() {
+		f.page(
+			parquet.PageHeader{Type: parquet.PageDictionary, NumValues: 2, Encoding: parquet.EncPlain},
+			parquet.PlainEncode([]string{"a", "bb"}),
+		)
+		payload := []byte{1}
+		payload = append(payload, bitPacked([]uint32{0, 1, 1, 0, 0, 1, 0}, 1)...)
+		f.page(parquet.PageHeader{
+			Type: parquet.PageDataV2, NumValues: 7, Encoding: parquet.EncRLEDictionary,
+			Compressed: true,
+		}, payload)
+	})
+
+	raw := f.bytes()
+	r, err := NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	type row struct {
+		X int64  `hbook:"x"`
+		S string `hbook:"s"`
+	}
+	var got []row
+	err = r.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read rows: %+v", err)
+	}
+
+	want := []row{
+		{30, "a"}, {10, "bb"}, {20, "bb"}, {30, "a"},
+		{20, "a"}, {20, "bb"}, {20, "a"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestReadNulls(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		page func(f *pqFile)
+	}{
+		{
+			name: "v1",
+			page: func(f *pqFile) {
+				var payload []byte
+				defs := bitPacked([]uint32{1, 0}, 1)
+				payload = appendU32(payload, uint32(len(defs)))
+				payload = append(payload, defs...)
+				payload = append(payload, parquet.PlainEncode([]float64{1})...)
+				f.page(parquet.PageHeader{Type: parquet.PageData, NumValues: 2, Encoding: parquet.EncPlain}, payload)
+			},
+		},
+		{
+			name: "v2",
+			page: func(f *pqFile) {
+				defs := bitPacked([]uint32{1, 0}, 1)
+				payload := append(defs, parquet.PlainEncode([]float64{1})...)
+				f.page(parquet.PageHeader{
+					Type: parquet.PageDataV2, NumValues: 2, NumNulls: 1, Encoding: parquet.EncPlain,
+					DefLevelsLen: int32(len(defs)),
+				}, payload)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newPQFile(parquet.SchemaElement{Type: parquet.TypeDouble, Repetition: parquet.RepOptional, Name: "x", Converted: -1})
+			f.md.NumRows = 2
+			f.chunk(0, false, func() { tc.page(f) })
+
+			raw := f.bytes()
+			r, err := NewReader(bytes.NewReader(raw), int64(len(raw)))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+
+			var xs []float64
+			err = r.Column("x", &xs)
+			if err != nil {
+				t.Fatalf("could not read column: %+v", err)
+			}
+			if got, want := xs, []float64{1, 0}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid values: got=%v, want=%v", got, want)
+			}
+
+			var ps []*float32
+			err = r.Column("x", &ps)
+			if err != nil {
+				t.Fatalf("could not read column: %+v", err)
+			}
+			if len(ps) != 2 || ps[0] == nil || *ps[0] != 1 || ps[1] != nil {
+				t.Fatalf("invalid values: got=%v", ps)
+			}
+		})
+	}
+}
+
+func TestReadNested(t *testing.T) {
+	f := newPQFile(
+		parquet.SchemaElement{Type: -1, Repetition: parquet.RepOptional, Name: "p4", NumChildren: 2, Converted: -1},
+		parquet.SchemaElement{Type: parquet.TypeDouble, Repetition: parquet.RepRequired, Name: "px", Converted: -1},
+		parquet.SchemaElement{Type: parquet.TypeDouble, Repetition: parquet.RepRequired, Name: "py", Converted: -1},
+	)
+	f.md.Schema[0].NumChildren = 1
+
+	raw := f.bytes()
+	_, err := NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err == nil || err.Error() != `ntparquet: nested column "p4" not supported` {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+// The testdata/arrow-*.parquet files have been generated by the Go
+// implementation of Apache Arrow, with testdata/gen-golden.go.
+func TestReadArrow(t *testing.T) {
+	type row struct {
+		I8      int8      `hbook:"i8"`
+		U16     uint16    `hbook:"u16"`
+		I32     int32     `hbook:"i32"`
+		U32     uint32    `hbook:"u32"`
+		I64     int64     `hbook:"i64"`
+		U64     uint64    `hbook:"u64"`
+		F32     float32   `hbook:"f32"`
+		F64     float64   `hbook:"f64"`
+		B       bool      `hbook:"b"`
+		Str     string    `hbook:"str"`
+		OptF64  *float64  `hbook:"opt_f64"`
+		OptStr  *string   `hbook:"opt_str"`
+		ListI32 []int32   `hbook:"list_i32"`
+		ListF64 []float64 `hbook:"list_f64"`
+	}
+
+	want := make([]row, 10)
+	for i := range want {
+		want[i] = row{
+			I8:      int8(-i),
+			U16:     uint16(65535 - i),
+			I32:     int32(-i * 10000),
+			U32:     uint32(4000000000 + i),
+			I64:     int64(-i) << 40,
+			U64:     uint64(1)<<63 + uint64(i),
+			F32:     float32(i) + 0.5,
+			F64:     float64(i) + 0.25,
+			B:       i%2 == 0,
+			Str:     fmt.Sprintf("evt-%d", i),
+			ListI32: []int32{},
+			ListF64: []float64{float64(i)},
+		}
+		if i%3 != 1 {
+			v := float64(i) * 1.5
+			want[i].OptF64 = &v
+		}
+		if i%4 != 2 {
+			v := fmt.Sprintf("opt-%d", i)
+			want[i].OptStr = &v
+		}
+		for j := 0; j < i%4; j++ {
+			want[i].ListI32 = append(want[i].ListI32, int32(10*i+j))
+		}
+		if i%2 == 0 {
+			want[i].ListF64 = []float64{float64(i), 0, float64(i) + 0.5}
+		}
+	}
+	want[3].ListI32 = nil
+
+	for _, name := range []string{"plain", "snappy", "gzip"} {
+		t.Run(name, func(t *testing.T) {
+			r, err := Open("testdata/arrow-" + name + ".parquet")
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer r.Close()
+
+			if got, want := r.NumRows(), int64(10); got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			if got, want := r.NumRowGroups(), 3; got != want {
+				t.Fatalf("invalid number of row groups: got=%d, want=%d", got, want)
+			}
+
+			types := make(map[string]reflect.Type)
+			for _, col := range r.Cols() {
+				types[col.Name()] = col.Type()
+			}
+			for name, want := range map[string]reflect.Type{
+				"u64":      reflect.TypeOf(uint64(0)),
+				"opt_f64":  reflect.TypeOf(float64(0)),
+				"list_i32": reflect.TypeOf([]int32(nil)),
+				"list_f64": reflect.TypeOf([]float64(nil)),
+			} {
+				if got := types[name]; got != want {
+					t.Fatalf("invalid type for column %q: got=%v, want=%v", name, got, want)
+				}
+			}
+
+			var got []row
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read rows: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", got, want)
+			}
+
+			for _, tc := range []struct {
+				name     string
+				min, max float64
+			}{
+				{"i8", -3, 0},
+				{"u64", 1 << 63, 1<<63 + 3},
+				{"opt_f64", 0, 4.5},
+			} {
+				min, max, ok := r.RowGroup(0).Stats(tc.name)
+				if !ok || min != tc.min || max != tc.max {
+					t.Fatalf("invalid stats for %q: got=(%v, %v, %v), want=(%v, %v)",
+						tc.name, min, max, ok, tc.min, tc.max,
+					)
+				}
+			}
+		})
+	}
+}
+
+// The testdata/pyarrow-v0.7.1.parquet file, written by pandas/pyarrow
+// (parquet-cpp 1.3.2) with Snappy compression, dictionary encoding and
+// optional columns, comes from the test data of Apache Arrow.
+func TestReadPyArrow(t *testing.T) {
+	r, err := Open("testdata/pyarrow-v0.7.1.parquet")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	for _, tc := range []struct {
+		name string
+		dst  interface{}
+		want interface{}
+	}{
+		{
+			name: "carat",
+			dst:  new([]float64),
+			want: []float64{0.23, 0.21, 0.23, 0.29, 0.31, 0.24, 0.24, 0.26, 0.22, 0.23},
+		},
+		{
+			name: "cut",
+			dst:  new([]string),
+			want: []string{"Ideal", "Premium", "Good", "Premium", "Good", "Very Good", "Very Good", "Very Good", "Fair", "Very Good"},
+		},
+		{
+			name: "color",
+			dst:  new([]string),
+			want: []string{"E", "E", "E", "I", "J", "J", "I", "H", "E", "H"},
+		},
+		{
+			name: "clarity",
+			dst:  new([]string),
+			want: []string{"SI2", "SI1", "VS1", "VS2", "SI2", "VVS2", "VVS1", "SI1", "VS2", "VS1"},
+		},
+		{
+			name: "depth",
+			dst:  new([]float64),
+			want: []float64{61.5, 59.8, 56.9, 62.4, 63.3, 62.8, 62.3, 61.9, 65.1, 59.4},
+		},
+		{
+			name: "table",
+			dst:  new([]float64),
+			want: []float64{55, 61, 65, 58, 58, 57, 57, 55, 61, 61},
+		},
+		{
+			name: "price",
+			dst:  new([]int64),
+			want: []int64{326, 326, 327, 334, 335, 336, 336, 337, 337, 338},
+		},
+		{
+			name: "x",
+			dst:  new([]float64),
+			want: []float64{3.95, 3.89, 4.05, 4.2, 4.34, 3.94, 3.95, 4.07, 3.87, 4},
+		},
+		{
+			name: "y",
+			dst:  new([]float64),
+			want: []float64{3.98, 3.84, 4.07, 4.23, 4.35, 3.96, 3.98, 4.11, 3.78, 4.05},
+		},
+		{
+			name: "z",
+			dst:  new([]float64),
+			want: []float64{2.43, 2.31, 2.31, 2.63, 2.75, 2.48, 2.47, 2.53, 2.49, 2.39},
+		},
+		{
+			name: "__index_level_0__",
+			dst:  new([]int),
+			want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.Column(tc.name, tc.dst)
+			if err != nil {
+				t.Fatalf("could not read column: %+v", err)
+			}
+			got := reflect.ValueOf(tc.dst).Elem().Interface()
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// gen-golden generates the golden Parquet files of the ntparquet tests
+// with the Go implementation of Apache Arrow.
+//
+// It is not part of the go-hep module: it needs to be run from a scratch
+// module requiring github.com/apache/arrow/go/v12.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+const nrows = 10
+
+func main() {
+	tbl := table()
+	defer tbl.Release()
+
+	for _, tc := range []struct {
+		name  string
+		codec compress.Compression
+		dict  bool
+		vers  parquet.DataPageVersion
+	}{
+		{"arrow-plain.parquet", compress.Codecs.Uncompressed, false, parquet.DataPageV1},
+		{"arrow-snappy.parquet", compress.Codecs.Snappy, true, parquet.DataPageV1},
+		{"arrow-gzip.parquet", compress.Codecs.Gzip, true, parquet.DataPageV2},
+	} {
+		f, err := os.Create(tc.name)
+		if err != nil {
+			log.Fatalf("could not create %q: %+v", tc.name, err)
+		}
+		props := parquet.NewWriterProperties(
+			parquet.WithCompression(tc.codec),
+			parquet.WithDictionaryDefault(tc.dict),
+			parquet.WithDataPageVersion(tc.vers),
+		)
+		err = pqarrow.WriteTable(tbl, f, 4, props, pqarrow.DefaultWriterProps())
+		if err != nil {
+			log.Fatalf("could not write %q: %+v", tc.name, err)
+		}
+		// WriteTable closes f.
+	}
+}
+
+func table() arrow.Table {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+		{Name: "u16", Type: arrow.PrimitiveTypes.Uint16},
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "u32", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "f32", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "b", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "str", Type: arrow.BinaryTypes.String},
+		{Name: "opt_f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "opt_str", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "list_i32", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32), Nullable: true},
+		{Name: "list_f64", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer bldr.Release()
+
+	for i := 0; i < nrows; i++ {
+		bldr.Field(0).(*array.Int8Builder).Append(int8(-i))
+		bldr.Field(1).(*array.Uint16Builder).Append(uint16(65535 - i))
+		bldr.Field(2).(*array.Int32Builder).Append(int32(-i * 10000))
+		bldr.Field(3).(*array.Uint32Builder).Append(uint32(4000000000 + i))
+		bldr.Field(4).(*array.Int64Builder).Append(int64(-i) << 40)
+		bldr.Field(5).(*array.Uint64Builder).Append(uint64(1)<<63 + uint64(i))
+		bldr.Field(6).(*array.Float32Builder).Append(float32(i) + 0.5)
+		bldr.Field(7).(*array.Float64Builder).Append(float64(i) + 0.25)
+		bldr.Field(8).(*array.BooleanBuilder).Append(i%2 == 0)
+		bldr.Field(9).(*array.StringBuilder).Append(fmt.Sprintf("evt-%d", i))
+
+		// opt_f64: null every third row.
+		switch b := bldr.Field(10).(*array.Float64Builder); {
+		case i%3 == 1:
+			b.AppendNull()
+		default:
+			b.Append(float64(i) * 1.5)
+		}
+
+		// opt_str: null every fourth row.
+		switch b := bldr.Field(11).(*array.StringBuilder); {
+		case i%4 == 2:
+			b.AppendNull()
+		default:
+			b.Append(fmt.Sprintf("opt-%d", i))
+		}
+
+		// list_i32: a null list, empty lists and lists of i%4 elements.
+		lb := bldr.Field(12).(*array.ListBuilder)
+		switch {
+		case i == 3:
+			lb.AppendNull()
+		default:
+			lb.Append(true)
+			vb := lb.ValueBuilder().(*array.Int32Builder)
+			for j := 0; j < i%4; j++ {
+				vb.Append(int32(10*i + j))
+			}
+		}
+
+		// list_f64: lists with null elements.
+		lb = bldr.Field(13).(*array.ListBuilder)
+		lb.Append(true)
+		vb := lb.ValueBuilder().(*array.Float64Builder)
+		switch {
+		case i%2 == 0:
+			vb.Append(float64(i))
+			vb.AppendNull()
+			vb.Append(float64(i) + 0.5)
+		default:
+			vb.Append(float64(i))
+		}
+	}
+
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	return array.NewTableFromRecords(schema, []arrow.Record{rec})
+}
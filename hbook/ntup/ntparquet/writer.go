@@ -0,0 +1,443 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntparquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/hbook/ntup"
+	"go-hep.org/x/hep/hbook/ntup/ntparquet/internal/parquet"
+)
+
+const createdBy = "go-hep.org/x/hep/hbook/ntup/ntparquet"
+
+// Writer writes n-tuple data to a Parquet file.
+//
+// Rows are buffered in memory and written out, one row group at a time,
+// once the configured number of rows per row group has been reached.
+// Each column of a row group is stored as a single, PLAIN encoded, page,
+// with the minimum and maximum values of the column chunk for numerical
+// columns.
+type Writer struct {
+	w   io.Writer
+	n   int64 // number of bytes written so far
+	cfg config
+
+	rt     reflect.Type
+	cols   []*column
+	weight string
+
+	rows reflect.Value // buffered rows
+	md   parquet.FileMetaData
+	err  error
+}
+
+// Create creates a new Parquet n-tuple writer, writing to the provided
+// io.Writer.
+// The schema of the n-tuple is described by the row value, a struct or
+// a pointer to a struct.
+//
+// Close must be called to write the Parquet footer.
+// Close does not close the underlying io.Writer.
+func Create(w io.Writer, row interface{}, opts ...Option) (*Writer, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rt := reflect.TypeOf(row)
+	if rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt == nil {
+		return nil, fmt.Errorf("ntparquet: invalid nil row")
+	}
+
+	cols, weight, err := columnsFrom(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	wrt := &Writer{
+		w:      w,
+		cfg:    cfg,
+		rt:     rt,
+		cols:   cols,
+		weight: weight,
+		rows:   reflect.MakeSlice(reflect.SliceOf(rt), 0, cfg.nrows),
+		md: parquet.FileMetaData{
+			Version:   1,
+			Schema:    make([]parquet.SchemaElement, 0, 1+len(cols)),
+			CreatedBy: createdBy,
+		},
+	}
+
+	wrt.md.Schema = append(wrt.md.Schema, parquet.SchemaElement{
+		Type:        -1,
+		Repetition:  -1,
+		Name:        "schema",
+		NumChildren: int32(len(cols)),
+		Converted:   -1,
+	})
+	for _, col := range cols {
+		wrt.md.Schema = append(wrt.md.Schema, col.groups...)
+		wrt.md.Schema = append(wrt.md.Schema, col.elem)
+	}
+	if weight != "" {
+		wrt.md.KeyValues = append(wrt.md.KeyValues, parquet.KeyValue{Key: kvWeight, Value: weight})
+	}
+	wrt.md.KeyValues = append(wrt.md.KeyValues, cfg.kvs...)
+
+	err = wrt.write([]byte(magic))
+	if err != nil {
+		return nil, err
+	}
+
+	return wrt, nil
+}
+
+// Cols returns the columns of this n-tuple.
+func (w *Writer) Cols() []ntup.Descriptor {
+	cols := make([]ntup.Descriptor, len(w.cols))
+	for i, col := range w.cols {
+		cols[i] = col
+	}
+	return cols
+}
+
+// Weight returns the name of the weight column of this n-tuple, or the
+// empty string if this n-tuple is not weighted.
+func (w *Writer) Weight() string {
+	return w.weight
+}
+
+// Append appends the provided rows to the n-tuple.
+// rows can be a value or a pointer to a value of the struct type the
+// writer was created with, or a slice of such values.
+func (w *Writer) Append(rows interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	switch {
+	case rv.Kind() == reflect.Struct && rv.Type() == w.rt:
+		return w.append(rv)
+	case rv.Kind() == reflect.Slice && rv.Type().Elem() == w.rt:
+		for i := 0; i < rv.Len(); i++ {
+			err := w.append(rv.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("ntparquet: invalid row type %T (want %v)", rows, w.rt)
+	}
+}
+
+func (w *Writer) append(row reflect.Value) error {
+	w.rows = reflect.Append(w.rows, row)
+	if w.rows.Len() < w.cfg.nrows {
+		return nil
+	}
+	return w.flush()
+}
+
+// Close writes the buffered rows and the Parquet footer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	err := w.flush()
+	if err != nil {
+		return err
+	}
+
+	md := w.md.Marshal()
+
+	var footer [8]byte
+	binary.LittleEndian.PutUint32(footer[:4], uint32(len(md)))
+	copy(footer[4:], magic)
+
+	err = w.write(md)
+	if err != nil {
+		return err
+	}
+
+	err = w.write(footer[:])
+	if err != nil {
+		return err
+	}
+
+	w.err = fmt.Errorf("ntparquet: writer closed")
+	return nil
+}
+
+// flush writes the buffered rows as a new row group.
+func (w *Writer) flush() error {
+	n := w.rows.Len()
+	if n == 0 {
+		return nil
+	}
+
+	rg := parquet.RowGroup{
+		Columns:    make([]parquet.ColumnChunk, len(w.cols)),
+		NumRows:    int64(n),
+		FileOffset: w.n,
+	}
+
+	for i, col := range w.cols {
+		var (
+			defs, reps, vals = w.values(col)
+			nvals            = n
+			raw              []byte
+		)
+		if col.maxRep > 0 {
+			nvals = len(reps)
+			raw = appendLevels(raw, reps, col.maxRep)
+		}
+		if col.maxDef > 0 {
+			nvals = len(defs)
+			raw = appendLevels(raw, defs, col.maxDef)
+		}
+		raw = append(raw, parquet.PlainEncode(vals)...)
+		if len(raw) > math.MaxInt32 {
+			w.err = fmt.Errorf("ntparquet: page of column %q too big (%d bytes)", col.name, len(raw))
+			return w.err
+		}
+
+		data, err := parquet.Compress(w.cfg.codec, w.cfg.lvl, raw)
+		if err != nil {
+			w.err = fmt.Errorf("ntparquet: could not compress column %q: %w", col.name, err)
+			return w.err
+		}
+
+		hdr := parquet.PageHeader{
+			Type:             parquet.PageData,
+			UncompressedSize: int32(len(raw)),
+			CompressedSize:   int32(len(data)),
+			NumValues:        int32(nvals),
+			Encoding:         parquet.EncPlain,
+		}
+		phdr := hdr.Marshal()
+
+		off := w.n
+		err = w.write(phdr)
+		if err != nil {
+			return err
+		}
+		err = w.write(data)
+		if err != nil {
+			return err
+		}
+
+		rg.Columns[i] = parquet.ColumnChunk{
+			FileOffset: off,
+			MetaData: parquet.ColumnMetaData{
+				Type:             col.elem.Type,
+				Encodings:        []int32{parquet.EncPlain, parquet.EncRLE},
+				Path:             col.path,
+				Codec:            w.cfg.codec,
+				NumValues:        int64(nvals),
+				UncompressedSize: int64(len(phdr) + len(raw)),
+				CompressedSize:   int64(len(phdr) + len(data)),
+				DataPageOffset:   off,
+				DictPageOffset:   -1,
+				Statistics:       statsOf(col, vals, int64(nvals-reflect.ValueOf(vals).Len())),
+			},
+		}
+		rg.TotalSize += rg.Columns[i].MetaData.UncompressedSize
+		rg.CompressedSize += rg.Columns[i].MetaData.CompressedSize
+	}
+
+	w.md.NumRows += rg.NumRows
+	w.md.RowGroups = append(w.md.RowGroups, rg)
+	w.rows = w.rows.Slice(0, 0)
+
+	return nil
+}
+
+// values returns the definition and repetition levels and the values of
+// the buffered rows for the provided column.
+// Values are returned as a slice of the Go type matching the column
+// physical type.
+func (w *Writer) values(col *column) (defs, reps []int32, vals interface{}) {
+	var (
+		n  = w.rows.Len()
+		vs = make([]reflect.Value, 0, n)
+	)
+	for i := 0; i < n; i++ {
+		v := w.rows.Index(i).Field(col.field)
+		switch {
+		case col.list:
+			if v.Len() == 0 {
+				defs = append(defs, col.defLen-1)
+				reps = append(reps, 0)
+				continue
+			}
+			for j := 0; j < v.Len(); j++ {
+				defs = append(defs, col.maxDef)
+				if j == 0 {
+					reps = append(reps, 0)
+				} else {
+					reps = append(reps, col.maxRep)
+				}
+				vs = append(vs, v.Index(j))
+			}
+		case col.maxDef > 0:
+			if v.IsNil() {
+				defs = append(defs, 0)
+				continue
+			}
+			defs = append(defs, col.maxDef)
+			vs = append(vs, v.Elem())
+		default:
+			vs = append(vs, v)
+		}
+	}
+	return defs, reps, physical(col.elem.Type, vs)
+}
+
+// physical returns the provided values as a slice of the Go type matching
+// the provided physical type.
+func physical(typ int32, rvs []reflect.Value) interface{} {
+	var (
+		n = len(rvs)
+		f = func(i int) reflect.Value { return rvs[i] }
+	)
+
+	switch typ {
+	case parquet.TypeBoolean:
+		vs := make([]bool, n)
+		for i := range vs {
+			vs[i] = f(i).Bool()
+		}
+		return vs
+	case parquet.TypeInt32:
+		vs := make([]int32, n)
+		for i := range vs {
+			switch v := f(i); v.Kind() {
+			case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+				vs[i] = int32(uint32(v.Uint()))
+			default:
+				vs[i] = int32(v.Int())
+			}
+		}
+		return vs
+	case parquet.TypeInt64:
+		vs := make([]int64, n)
+		for i := range vs {
+			switch v := f(i); v.Kind() {
+			case reflect.Uint64, reflect.Uint:
+				vs[i] = int64(v.Uint())
+			default:
+				vs[i] = v.Int()
+			}
+		}
+		return vs
+	case parquet.TypeFloat:
+		vs := make([]float32, n)
+		for i := range vs {
+			vs[i] = float32(f(i).Float())
+		}
+		return vs
+	case parquet.TypeDouble:
+		vs := make([]float64, n)
+		for i := range vs {
+			vs[i] = f(i).Float()
+		}
+		return vs
+	case parquet.TypeByteArray:
+		vs := make([]string, n)
+		for i := range vs {
+			vs[i] = f(i).String()
+		}
+		return vs
+	default:
+		panic(fmt.Errorf("ntparquet: invalid physical type %d", typ))
+	}
+}
+
+// appendLevels appends the provided definition or repetition levels,
+// with their size, to the buffer of a v1 data page.
+func appendLevels(buf []byte, lvls []int32, max int32) []byte {
+	raw := parquet.RLEEncode(lvls, parquet.BitWidth(int(max)))
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(raw)))
+	buf = append(buf, size[:]...)
+	return append(buf, raw...)
+}
+
+// statsOf returns the statistics of the provided values of a numerical
+// column, following the sort order of the column.
+// NaN values are ignored.
+func statsOf(col *column, vals interface{}, nulls int64) parquet.Statistics {
+	var (
+		stats = parquet.Statistics{NullCount: nulls}
+		rv    = reflect.ValueOf(vals)
+		imin  = -1
+		imax  = -1
+	)
+
+	var less func(i, j int) bool
+	switch vs := vals.(type) {
+	case []int32:
+		less = func(i, j int) bool { return vs[i] < vs[j] }
+		if !col.elem.IntSigned && col.elem.IntBits > 0 {
+			less = func(i, j int) bool { return uint32(vs[i]) < uint32(vs[j]) }
+		}
+	case []int64:
+		less = func(i, j int) bool { return vs[i] < vs[j] }
+		if !col.elem.IntSigned && col.elem.IntBits > 0 {
+			less = func(i, j int) bool { return uint64(vs[i]) < uint64(vs[j]) }
+		}
+	case []float32:
+		less = func(i, j int) bool { return vs[i] < vs[j] }
+	case []float64:
+		less = func(i, j int) bool { return vs[i] < vs[j] }
+	default:
+		return stats
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if v := rv.Index(i); v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			if math.IsNaN(v.Float()) {
+				continue
+			}
+		}
+		if imin < 0 || less(i, imin) {
+			imin = i
+		}
+		if imax < 0 || less(imax, i) {
+			imax = i
+		}
+	}
+	if imin < 0 {
+		return stats
+	}
+
+	stats.MinValue = parquet.PlainEncode(rv.Slice(imin, imin+1).Interface())
+	stats.MaxValue = parquet.PlainEncode(rv.Slice(imax, imax+1).Interface())
+	return stats
+}
+
+func (w *Writer) write(p []byte) error {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	if err != nil {
+		w.err = fmt.Errorf("ntparquet: could not write data: %w", err)
+	}
+	return w.err
+}
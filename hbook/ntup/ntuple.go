@@ -34,10 +34,16 @@ var (
 )
 
 // Ntuple provides read/write access to row-wise n-tuple data.
+//
+// An Ntuple may hold a per-row weight column, used when filling
+// histograms from the n-tuple data.
 type Ntuple struct {
 	db     *sql.DB
 	name   string
 	schema []Descriptor
+	weight string       // name of the weight column, if any
+	rtype  reflect.Type // type of the rows, for n-tuples created from a struct
+	table  bool         // whether the table has been created in the db
 }
 
 // Open inspects the given database handle and tries to return
@@ -64,8 +70,12 @@ func Open(db *sql.DB, name string) (*Ntuple, error) {
 //  - a list of builtin values (the columns names are varX where X=[1-len(cols)])
 //  - a list of ntup.Descriptors
 //
+// A field of a struct value can be declared as the weight column of the
+// n-tuple with the "weight" option of its hbook struct tag.
+//
 // e.g.:
 //  nt, err := ntup.Create(db, "nt", struct{X float64 `hbook:"x"`}{})
+//  nt, err := ntup.Create(db, "nt", struct{X, W float64 `hbook:"w,weight"`}{})
 //  nt, err := ntup.Create(db, "nt", int64(0), float64(0))
 func Create(db *sql.DB, name string, cols ...interface{}) (*Ntuple, error) {
 	var err error
@@ -82,7 +92,8 @@ func Create(db *sql.DB, name string, cols ...interface{}) (*Ntuple, error) {
 		rt := rv.Type()
 		switch rt.Kind() {
 		case reflect.Struct:
-			schema, err = schemaFromStruct(rt)
+			schema, nt.weight, err = schemaFromStruct(rt)
+			nt.rtype = rt
 		default:
 			schema, err = schemaFrom(cols...)
 		}
@@ -96,6 +107,35 @@ func Create(db *sql.DB, name string, cols ...interface{}) (*Ntuple, error) {
 	return nt, err
 }
 
+// CreateFrom creates a new ntuple with the given name inside the given
+// database handle, and fills it with the provided rows.
+// rows must be a slice of structs: the n-tuple schema is inferred from
+// the struct type, as for Create.
+//
+// e.g.:
+//  type Event struct {
+//      X float64 `hbook:"x"`
+//      W float64 `hbook:"w,weight"`
+//  }
+//  nt, err := ntup.CreateFrom(db, "nt", []Event{{X: 1, W: 0.5}, {X: 2, W: 2}})
+func CreateFrom(db *sql.DB, name string, rows interface{}) (*Ntuple, error) {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("hbook/ntup: expected a slice of structs, got %T", rows)
+	}
+
+	nt, err := Create(db, name, reflect.Zero(rv.Type().Elem()).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	err = nt.Append(rows)
+	if err != nil {
+		return nil, err
+	}
+	return nt, nil
+}
+
 // DB returns the underlying db this n-tuple is connected to.
 func (nt *Ntuple) DB() *sql.DB {
 	return nt.db
@@ -112,6 +152,112 @@ func (nt *Ntuple) Cols() []Descriptor {
 	return nt.schema
 }
 
+// Weight returns the name of the weight column of this n-tuple,
+// or "" if the n-tuple is not weighted.
+func (nt *Ntuple) Weight() string {
+	return nt.weight
+}
+
+// SetWeight sets the name of the weight column of this n-tuple.
+// An empty name removes the weight column.
+// SetWeight returns an error if the column is not part of the known
+// schema of this n-tuple.
+func (nt *Ntuple) SetWeight(name string) error {
+	if name != "" && nt.schema != nil && nt.col(name) == nil {
+		return fmt.Errorf("hbook/ntup: no column %q in ntuple %q", name, nt.name)
+	}
+	nt.weight = name
+	return nil
+}
+
+func (nt *Ntuple) col(name string) *columnDescr {
+	for _, col := range nt.schema {
+		if col.Name() == name {
+			if col, ok := col.(*columnDescr); ok {
+				return col
+			}
+		}
+	}
+	return nil
+}
+
+// Append appends the provided rows to the n-tuple.
+// rows can be a struct value, a pointer to a struct value or a slice of
+// struct values, of the struct type the n-tuple was created from.
+// The underlying table is created in the database if needed.
+func (nt *Ntuple) Append(rows interface{}) error {
+	if nt.rtype == nil {
+		return fmt.Errorf("hbook/ntup: ntuple %q was not created from a struct", nt.name)
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(rows))
+	switch {
+	case rv.Kind() == reflect.Slice && rv.Type().Elem() == nt.rtype:
+		// ok.
+	case rv.Type() == nt.rtype:
+		slice := reflect.MakeSlice(reflect.SliceOf(nt.rtype), 1, 1)
+		slice.Index(0).Set(rv)
+		rv = slice
+	default:
+		return fmt.Errorf("hbook/ntup: invalid row type %T (want %v)", rows, nt.rtype)
+	}
+
+	tx, err := nt.db.Begin()
+	if err != nil {
+		return fmt.Errorf("hbook/ntup: could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if !nt.table {
+		_, err = tx.Exec(nt.createStmt())
+		if err != nil {
+			return fmt.Errorf("hbook/ntup: could not create table %q: %w", nt.name, err)
+		}
+	}
+
+	var (
+		stmt = nt.insertStmt()
+		args = make([]interface{}, len(nt.schema))
+	)
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		for j, col := range nt.schema {
+			args[j] = row.Field(col.(*columnDescr).field).Interface()
+		}
+		_, err = tx.Exec(stmt, args...)
+		if err != nil {
+			return fmt.Errorf("hbook/ntup: could not insert row %d: %w", i, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("hbook/ntup: could not commit transaction: %w", err)
+	}
+	nt.table = true
+	return nil
+}
+
+func (nt *Ntuple) createStmt() string {
+	cols := make([]string, len(nt.schema))
+	for i, col := range nt.schema {
+		cols[i] = col.Name() + " " + col.Type().Kind().String()
+	}
+	return "create table if not exists " + nt.name + " (" + strings.Join(cols, ", ") + ");"
+}
+
+func (nt *Ntuple) insertStmt() string {
+	var (
+		names = make([]string, len(nt.schema))
+		vals  = make([]string, len(nt.schema))
+	)
+	for i, col := range nt.schema {
+		names[i] = col.Name()
+		vals[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return "insert into " + nt.name + " (" + strings.Join(names, ", ") + ") values(" + strings.Join(vals, ", ") + ");"
+}
+
 // Descriptor describes a column
 type Descriptor interface {
 	Name() string       // the column name
@@ -119,8 +265,9 @@ type Descriptor interface {
 }
 
 type columnDescr struct {
-	name string
-	typ  reflect.Type
+	name  string
+	typ   reflect.Type
+	field int // index of the struct field holding the column
 }
 
 func (col *columnDescr) Name() string {
@@ -131,9 +278,11 @@ func (col *columnDescr) Type() reflect.Type {
 	return col.typ
 }
 
-func schemaFromStruct(rt reflect.Type) ([]Descriptor, error) {
-	var schema []Descriptor
-	var err error
+func schemaFromStruct(rt reflect.Type) ([]Descriptor, string, error) {
+	var (
+		schema []Descriptor
+		weight string
+	)
 	for i := 0; i < rt.NumField(); i++ {
 		f := rt.Field(i)
 		if !ast.IsExported(f.Name) {
@@ -142,23 +291,39 @@ func schemaFromStruct(rt reflect.Type) ([]Descriptor, error) {
 		ft := f.Type
 		switch ft.Kind() {
 		case reflect.Chan:
-			return nil, errChanType
+			return nil, "", errChanType
 		case reflect.Interface:
-			return nil, errIfaceType
+			return nil, "", errIfaceType
 		case reflect.Map:
-			return nil, errMapType
+			return nil, "", errMapType
 		case reflect.Slice:
-			return nil, errSliceType
+			return nil, "", errSliceType
 		case reflect.Struct:
-			return nil, errStructType
+			return nil, "", errStructType
 		}
 		fname := getTag(f.Tag, "hbook", "rio", "db")
+		if i := strings.Index(fname, ","); i >= 0 {
+			opt := fname[i+1:]
+			fname = fname[:i]
+			if fname == "" {
+				fname = f.Name
+			}
+			switch opt {
+			case "weight":
+				if weight != "" {
+					return nil, "", fmt.Errorf("hbook/ntup: multiple weight columns (%q, %q)", weight, fname)
+				}
+				weight = fname
+			default:
+				return nil, "", fmt.Errorf("hbook/ntup: invalid struct tag option %q for field %q", opt, f.Name)
+			}
+		}
 		if fname == "" {
 			fname = f.Name
 		}
-		schema = append(schema, &columnDescr{fname, ft})
+		schema = append(schema, &columnDescr{fname, ft, i})
 	}
-	return schema, err
+	return schema, weight, nil
 }
 
 func schemaFrom(src ...interface{}) ([]Descriptor, error) {
@@ -178,7 +343,7 @@ func schemaFrom(src ...interface{}) ([]Descriptor, error) {
 		case reflect.Struct:
 			return nil, errStructType
 		}
-		schema = append(schema, &columnDescr{fmt.Sprintf("var%d", i+1), rt})
+		schema = append(schema, &columnDescr{fmt.Sprintf("var%d", i+1), rt, -1})
 	}
 	return schema, err
 }
@@ -251,10 +416,57 @@ func (nt *Ntuple) Scan(query string, f interface{}) error {
 	return err
 }
 
+// Column executes a query against the ntuple and stores the values of
+// the selected column in the slice pointed at by dst.
+// The previous content of the slice is discarded.
+//
+// e.g.
+//  var xs []float64
+//  err = nt.Column("x where z>10", &xs)
+func (nt *Ntuple) Column(query string, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("hbook/ntup: expected a pointer to a slice, got %T", dst)
+	}
+	var (
+		slice = rv.Elem()
+		et    = slice.Type().Elem()
+	)
+
+	query, err := nt.massageQuery(query)
+	if err != nil {
+		return err
+	}
+
+	rows, err := nt.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	slice.Set(slice.Slice(0, 0))
+	for rows.Next() {
+		v := reflect.New(et)
+		err = rows.Scan(v.Interface())
+		if err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, v.Elem()))
+	}
+
+	err = rows.Err()
+	if err == io.EOF {
+		err = nil
+	}
+	return err
+}
+
 // ScanH1D executes a query against the ntuple and fills the histogram with
 // the results of the query.
 // If h is nil, a (100-bins, xmin, xmax+ULP) histogram is created,
 // where xmin and xmax are inferred from the content of the underlying database.
+// If the n-tuple has a weight column, the histogram is filled with the
+// weight of each row.
 func (nt *Ntuple) ScanH1D(query string, h *hbook.H1D) (*hbook.H1D, error) {
 	if h == nil {
 		var (
@@ -275,6 +487,14 @@ func (nt *Ntuple) ScanH1D(query string, h *hbook.H1D) (*hbook.H1D, error) {
 		h = hbook.NewH1D(100, xmin, nextULP(xmax))
 	}
 
+	if nt.weight != "" {
+		err := nt.Scan(nt.withWeight(query), func(x, w float64) error {
+			h.Fill(x, w)
+			return nil
+		})
+		return h, err
+	}
+
 	err := nt.Scan(query, func(x float64) error {
 		h.Fill(x, 1)
 		return nil
@@ -289,6 +509,8 @@ func (nt *Ntuple) ScanH1D(query string, h *hbook.H1D) (*hbook.H1D, error) {
 // is created,
 // where xmin, xmax and ymin,ymax are inferred from the content of the
 // underlying database.
+// If the n-tuple has a weight column, the histogram is filled with the
+// weight of each row.
 func (nt *Ntuple) ScanH2D(query string, h *hbook.H2D) (*hbook.H2D, error) {
 	if h == nil {
 		var (
@@ -313,6 +535,14 @@ func (nt *Ntuple) ScanH2D(query string, h *hbook.H2D) (*hbook.H2D, error) {
 		h = hbook.NewH2D(100, xmin, nextULP(xmax), 100, ymin, nextULP(ymax))
 	}
 
+	if nt.weight != "" {
+		err := nt.Scan(nt.withWeight(query), func(x, y, w float64) error {
+			h.Fill(x, y, w)
+			return nil
+		})
+		return h, err
+	}
+
 	err := nt.Scan(query, func(x, y float64) error {
 		h.Fill(x, y, 1)
 		return nil
@@ -321,6 +551,18 @@ func (nt *Ntuple) ScanH2D(query string, h *hbook.H2D) (*hbook.H2D, error) {
 	return h, err
 }
 
+// withWeight returns the query, with the weight column appended to the
+// selected columns.
+func (nt *Ntuple) withWeight(q string) string {
+	n := len(q)
+	for _, tok := range []string{" WHERE ", " where ", " ORDER ", " order "} {
+		if i := strings.Index(q, tok); i >= 0 && i < n {
+			n = i
+		}
+	}
+	return q[:n] + ", " + nt.weight + q[n:]
+}
+
 func (nt *Ntuple) massageQuery(q string) (string, error) {
 	const (
 		tokWHERE = " WHERE "
@@ -615,6 +615,164 @@ func TestCreateFromStruct(t *testing.T) {
 	}
 }
 
+func TestCreateFrom(t *testing.T) {
+	db, err := sql.Open("ql", "memory://ntuple-from.db")
+	if err != nil {
+		t.Fatalf("error creating db: %v\n", err)
+	}
+	defer db.Close()
+
+	type Event struct {
+		I int64   `hbook:"i"`
+		X float64 `hbook:"x"`
+		Y float64 `hbook:"y"`
+		W float64 `hbook:"w,weight"`
+		S string
+	}
+
+	rows := []Event{
+		{I: 0, X: 0.5, Y: 1.5, W: 1, S: "a"},
+		{I: 1, X: 1.5, Y: 0.5, W: 2, S: "b"},
+		{I: 2, X: 1.5, Y: 1.5, W: 0.5, S: "c"},
+	}
+
+	nt, err := CreateFrom(db, "evts", rows)
+	if err != nil {
+		t.Fatalf("error creating ntuple: %+v", err)
+	}
+
+	if got, want := nt.Weight(), "w"; got != want {
+		t.Fatalf("invalid weight column: got=%q, want=%q", got, want)
+	}
+	var names []string
+	for _, col := range nt.Cols() {
+		names = append(names, col.Name())
+	}
+	if got, want := names, []string{"i", "x", "y", "w", "S"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid columns: got=%q, want=%q", got, want)
+	}
+
+	err = nt.Append(Event{I: 3, X: 0.5, Y: 0.5, W: 4, S: "d"})
+	if err != nil {
+		t.Fatalf("could not append row: %+v", err)
+	}
+	err = nt.Append(&Event{I: 4, X: 5, Y: 0.5, W: 1, S: "e"})
+	if err != nil {
+		t.Fatalf("could not append row: %+v", err)
+	}
+
+	var (
+		is []int64
+		xs []float64
+		ss []string
+	)
+	for _, tc := range []struct {
+		query string
+		dst   interface{}
+		want  interface{}
+	}{
+		{"i", &is, []int64{0, 1, 2, 3, 4}},
+		{"x where w > 1", &xs, []float64{1.5, 0.5}},
+		{"S", &ss, []string{"a", "b", "c", "d", "e"}},
+	} {
+		err = nt.Column(tc.query, tc.dst)
+		if err != nil {
+			t.Fatalf("could not read column %q: %+v", tc.query, err)
+		}
+		if got := reflect.ValueOf(tc.dst).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid column %q: got=%v, want=%v", tc.query, got, tc.want)
+		}
+	}
+
+	h1, err := nt.ScanH1D("x where i < 4", hbook.NewH1D(2, 0, 2))
+	if err != nil {
+		t.Fatalf("could not scan h1d: %+v", err)
+	}
+	if got, want := h1.SumW(), 7.5; got != want {
+		t.Fatalf("invalid h1d sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h1.Value(0), 5.0; got != want {
+		t.Fatalf("invalid h1d bin content: got=%v, want=%v", got, want)
+	}
+
+	h2, err := nt.ScanH2D("x, y", hbook.NewH2D(2, 0, 2, 2, 0, 2))
+	if err != nil {
+		t.Fatalf("could not scan h2d: %+v", err)
+	}
+	if got, want := h2.SumW(), 8.5; got != want {
+		t.Fatalf("invalid h2d sumw: got=%v, want=%v", got, want)
+	}
+
+	err = nt.SetWeight("")
+	if err != nil {
+		t.Fatalf("could not reset weight column: %+v", err)
+	}
+	h1, err = nt.ScanH1D("x", hbook.NewH1D(2, 0, 2))
+	if err != nil {
+		t.Fatalf("could not scan h1d: %+v", err)
+	}
+	if got, want := h1.SumW(), 5.0; got != want {
+		t.Fatalf("invalid unweighted h1d sumw: got=%v, want=%v", got, want)
+	}
+
+	err = nt.SetWeight("not-there")
+	if err == nil {
+		t.Fatalf("expected an error for an invalid weight column")
+	}
+	err = nt.Append(struct{ X float64 }{1})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid row type")
+	}
+	err = nt.Column("x", xs)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid destination")
+	}
+}
+
+func TestCreateFromInvalid(t *testing.T) {
+	db, err := sql.Open("ql", "memory://ntuple-from-invalid.db")
+	if err != nil {
+		t.Fatalf("error creating db: %v\n", err)
+	}
+	defer db.Close()
+
+	for _, tc := range []struct {
+		name string
+		rows interface{}
+	}{
+		{"not-a-slice", struct{ X float64 }{}},
+		{"not-a-struct", []float64{1, 2}},
+		{
+			"multiple-weights",
+			[]struct {
+				W1 float64 `hbook:"w1,weight"`
+				W2 float64 `hbook:"w2,weight"`
+			}{},
+		},
+		{
+			"invalid-option",
+			[]struct {
+				X float64 `hbook:"x,foo"`
+			}{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CreateFrom(db, "ntup", tc.rows)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	nt, err := Create(db, "ntup", int64(0))
+	if err != nil {
+		t.Fatalf("could not create ntuple: %+v", err)
+	}
+	if err := nt.Append(struct{ X int64 }{1}); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
 func TestCreateInvalid(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -7,11 +7,16 @@ package hbook
 import (
 	"fmt"
 	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
 // DivideH1D divides 2 1D-histograms and returns a 2D scatter.
 // DivideH1D returns an error if the binning of the 1D histograms are not compatible.
-// If no DivOptions is passed, NaN raised during division are kept.
+// If no DivOptions is passed, NaN raised during division are kept and the
+// uncertainties of the numerator and denominator are treated as uncorrelated.
+// DivBinomial and DivBayesian can be used when the numerator is a subset
+// of the denominator, e.g. to compute efficiencies.
 func DivideH1D(num, den *H1D, opts ...DivOptions) (*S2D, error) {
 
 	cfg := newDivConfig()
@@ -54,6 +59,15 @@ func DivideH1D(num, den *H1D, opts ...DivOptions) (*S2D, error) {
 				ey = 0.0 // TODO(rmadar): I guess this is the most sensitive case
 				// but another field could be added to divConfig
 			}
+		case cfg.errs == divBinomial:
+			y, ey = effBinomial(b1.SumW(), b1.SumW2(), b2.SumW(), b2.SumW2())
+		case cfg.errs == divBayesian:
+			y = b1.SumW() / b2.SumW()
+			lo, hi := effBayesian(b1.SumW(), b1.SumW2(), b2.SumW(), b2.SumW2(), cfg.cl, cfg.alpha, cfg.beta)
+			lo = math.Min(lo, y)
+			hi = math.Max(hi, y)
+			s2d.Fill(Point2D{X: x, Y: y, ErrX: Range{Min: exm, Max: exp}, ErrY: Range{Min: y - lo, Max: hi - y}})
+			continue
 		default:
 			y = b1h / b2h
 			// TODO(sbinet): is this the exact error treatment for all (uncorrelated) cases?
@@ -85,14 +99,60 @@ type DivOptions func(c *divConfig)
 type divConfig struct {
 	ignoreNaN  bool
 	replaceNaN float64
+
+	errs  divErrors
+	cl    float64 // confidence level of Bayesian intervals
+	alpha float64 // parameters of the Beta prior of Bayesian intervals
+	beta  float64
 }
 
+// divErrors describes how the uncertainties of a division are computed.
+type divErrors int
+
+const (
+	divUncorrelated divErrors = iota
+	divBinomial
+	divBayesian
+)
+
 // newDivConfig function builds the default configuration
 // for DivideH1D() option.
 func newDivConfig() *divConfig {
 	return &divConfig{replaceNaN: math.NaN()}
 }
 
+// DivBinomial function configures DivideH1D to compute binomial
+// uncertainties, generalized to weighted events:
+//
+//	var = ((1-2 eff) \sum w^2_num + eff^2 \sum w^2_den) / (\sum w_den)^2
+//
+// with eff = \sum w_num / \sum w_den.
+//
+// The numerator is expected to be a subset of the denominator.
+func DivBinomial() DivOptions {
+	return func(c *divConfig) {
+		c.errs = divBinomial
+	}
+}
+
+// DivBayesian function configures DivideH1D to compute asymmetric
+// uncertainties from the central interval, with the cl confidence level,
+// of the posterior distribution of the efficiency, assuming a
+// Beta(alpha,beta) prior.
+// A uniform prior is given by alpha=beta=1 and Jeffrey's prior by
+// alpha=beta=0.5.
+// Weighted events are taken into account through their effective number.
+//
+// The numerator is expected to be a subset of the denominator.
+func DivBayesian(cl, alpha, beta float64) DivOptions {
+	return func(c *divConfig) {
+		c.errs = divBayesian
+		c.cl = cl
+		c.alpha = alpha
+		c.beta = beta
+	}
+}
+
 // DivIgnoreNaNs function configures DivideH1D to
 // ignore data points with NaNs.
 func DivIgnoreNaNs() DivOptions {
@@ -109,6 +169,39 @@ func DivReplaceNaNs(v float64) DivOptions {
 	}
 }
 
+// effBinomial returns the efficiency and its binomial uncertainty,
+// given the sums of weights and squared weights of passed and total events.
+func effBinomial(pw, pw2, tw, tw2 float64) (eff, err float64) {
+	eff = pw / tw
+	v := ((1-2*eff)*pw2 + eff*eff*tw2) / (tw * tw)
+	if v < 0 {
+		v = 0
+	}
+	return eff, math.Sqrt(v)
+}
+
+// effBayesian returns the bounds of the central interval, with the cl
+// confidence level, of the posterior distribution of the efficiency,
+// given the sums of weights and squared weights of passed and total events
+// and a Beta(alpha,beta) prior.
+func effBayesian(pw, pw2, tw, tw2, cl, alpha, beta float64) (lo, hi float64) {
+	if tw2 != tw && tw2 > 0 {
+		// weighted events: use the effective numbers of events.
+		norm := tw / tw2
+		pw *= norm
+		tw *= norm
+	}
+	var (
+		a = pw + alpha
+		b = tw - pw + beta
+	)
+	if a <= 0 || b <= 0 {
+		return 0, 1
+	}
+	dist := distuv.Beta{Alpha: a, Beta: b}
+	return dist.Quantile(0.5 * (1 - cl)), dist.Quantile(0.5 * (1 + cl))
+}
+
 // fuzzyEq returns true if a and b are equal with a degree of fuzziness
 func fuzzyEq(a, b float64) bool {
 	const tol = 1e-5
@@ -157,3 +250,61 @@ func AddH1D(h1, h2 *H1D) *H1D {
 func SubH1D(h1, h2 *H1D) *H1D {
 	return AddScaledH1D(h1, -1, h2)
 }
+
+// AddH2D returns the bin-by-bin summed histogram of h1 and h2
+// assuming their statistical uncertainties are uncorrelated.
+func AddH2D(h1, h2 *H2D) *H2D {
+	if h1.Binning.Nx != h2.Binning.Nx || h1.Binning.Ny != h2.Binning.Ny {
+		panic(fmt.Errorf("hbook: h1 and h2 have different number of bins"))
+	}
+
+	if h1.XMin() != h2.XMin() || h1.XMax() != h2.XMax() ||
+		h1.YMin() != h2.YMin() || h1.YMax() != h2.YMax() {
+		panic(fmt.Errorf("hbook: h1 and h2 have different range"))
+	}
+
+	o := &H2D{
+		Binning: h1.Binning,
+		Ann:     h1.Ann.clone(),
+	}
+	o.Binning.Bins = append([]Bin2D(nil), h1.Binning.Bins...)
+	o.Binning.XEdges = append([]Bin1D(nil), h1.Binning.XEdges...)
+	o.Binning.YEdges = append([]Bin1D(nil), h1.Binning.YEdges...)
+
+	for i := range o.Binning.Bins {
+		o.Binning.Bins[i].Dist.addScaled(1, 1, h2.Binning.Bins[i].Dist)
+	}
+
+	o.Binning.Dist.addScaled(1, 1, h2.Binning.Dist)
+	for i := range o.Binning.Outflows {
+		o.Binning.Outflows[i].addScaled(1, 1, h2.Binning.Outflows[i])
+	}
+	return o
+}
+
+// AddP1D returns the bin-by-bin summed profile histogram of p1 and p2.
+func AddP1D(p1, p2 *P1D) *P1D {
+	if len(p1.bng.bins) != len(p2.bng.bins) {
+		panic(fmt.Errorf("hbook: p1 and p2 have different number of bins"))
+	}
+
+	if p1.XMin() != p2.XMin() || p1.XMax() != p2.XMax() {
+		panic(fmt.Errorf("hbook: p1 and p2 have different range"))
+	}
+
+	o := &P1D{
+		bng: p1.bng,
+		ann: p1.ann.clone(),
+	}
+	o.bng.bins = append([]BinP1D(nil), p1.bng.bins...)
+
+	for i := range o.bng.bins {
+		o.bng.bins[i].dist.addScaled(1, 1, p2.bng.bins[i].dist)
+	}
+
+	o.bng.dist.addScaled(1, 1, p2.bng.dist)
+	for i := range o.bng.outflows {
+		o.bng.outflows[i].addScaled(1, 1, p2.bng.outflows[i])
+	}
+	return o
+}
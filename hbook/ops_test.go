@@ -327,3 +327,139 @@ END YODA_HISTO1D_V2
 		)
 	}
 }
+
+func TestAddH2D(t *testing.T) {
+	type xyw struct{ x, y, w float64 }
+	var (
+		xyw1 = []xyw{{-1, 0.5, 1}, {0.5, 0.5, 2}, {1.5, 2.5, 1}, {2.5, 4.5, 0.5}, {3.5, 1.5, 1}}
+		xyw2 = []xyw{{0.5, 0.5, 1}, {1.5, -1, 1}, {2.5, 2.5, 2}, {3.5, 3.5, 1}}
+	)
+
+	var (
+		h1 = NewH2D(4, 0, 4, 4, 0, 4)
+		h2 = NewH2D(4, 0, 4, 4, 0, 4)
+		hh = NewH2D(4, 0, 4, 4, 0, 4)
+	)
+	for _, v := range xyw1 {
+		h1.Fill(v.x, v.y, v.w)
+		hh.Fill(v.x, v.y, v.w)
+	}
+	for _, v := range xyw2 {
+		h2.Fill(v.x, v.y, v.w)
+		hh.Fill(v.x, v.y, v.w)
+	}
+
+	h3 := AddH2D(h1, h2)
+
+	got, err := h3.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to yoda: %+v", err)
+	}
+	want, err := hh.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to yoda: %+v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("add differ:\n%s\n", cmp.Diff(string(got), string(want)))
+	}
+
+	if got, want := h1.Entries(), int64(len(xyw1)); got != want {
+		t.Fatalf("h1 was modified: entries=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		h2     *H2D
+		panics error
+	}{
+		{
+			h2:     NewH2D(4, 0, 4, 2, 0, 4),
+			panics: fmt.Errorf("hbook: h1 and h2 have different number of bins"),
+		},
+		{
+			h2:     NewH2D(4, 0, 4, 4, 1, 4),
+			panics: fmt.Errorf("hbook: h1 and h2 have different range"),
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			defer func() {
+				err := recover()
+				if err == nil {
+					t.Fatalf("expected a panic")
+				}
+				if got, want := err.(error).Error(), tc.panics.Error(); got != want {
+					t.Fatalf("invalid panic message.\ngot= %v\nwant=%v", got, want)
+				}
+			}()
+			_ = AddH2D(h1, tc.h2)
+		})
+	}
+}
+
+func TestAddP1D(t *testing.T) {
+	type xyw struct{ x, y, w float64 }
+	var (
+		xyw1 = []xyw{{-1, 0.5, 1}, {0.5, 0.5, 2}, {1.5, 2.5, 1}, {2.5, 4.5, 0.5}, {4.5, 1.5, 1}}
+		xyw2 = []xyw{{0.5, 1.5, 1}, {1.5, -1, 1}, {2.5, 2.5, 2}, {3.5, 3.5, 1}}
+	)
+
+	var (
+		p1 = NewP1D(4, 0, 4)
+		p2 = NewP1D(4, 0, 4)
+		pp = NewP1D(4, 0, 4)
+	)
+	for _, v := range xyw1 {
+		p1.Fill(v.x, v.y, v.w)
+		pp.Fill(v.x, v.y, v.w)
+	}
+	for _, v := range xyw2 {
+		p2.Fill(v.x, v.y, v.w)
+		pp.Fill(v.x, v.y, v.w)
+	}
+
+	p3 := AddP1D(p1, p2)
+
+	got, err := p3.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to yoda: %+v", err)
+	}
+	want, err := pp.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal to yoda: %+v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("add differ:\n%s\n", cmp.Diff(string(got), string(want)))
+	}
+
+	if got, want := p1.Entries(), int64(len(xyw1)); got != want {
+		t.Fatalf("p1 was modified: entries=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		p2     *P1D
+		panics error
+	}{
+		{
+			p2:     NewP1D(2, 0, 4),
+			panics: fmt.Errorf("hbook: p1 and p2 have different number of bins"),
+		},
+		{
+			p2:     NewP1D(4, 1, 5),
+			panics: fmt.Errorf("hbook: p1 and p2 have different range"),
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			defer func() {
+				err := recover()
+				if err == nil {
+					t.Fatalf("expected a panic")
+				}
+				if got, want := err.(error).Error(), tc.panics.Error(); got != want {
+					t.Fatalf("invalid panic message.\ngot= %v\nwant=%v", got, want)
+				}
+			}()
+			_ = AddP1D(p1, tc.p2)
+		})
+	}
+}
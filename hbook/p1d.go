@@ -290,6 +290,50 @@ func (p *P1D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalYODA2 marshals the profile into the text format of YODA2
+// (YODA_PROFILE1D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (p *P1D) MarshalYODA2() ([]byte, error) {
+	return p.marshalYODAv3()
+}
+
+func (p *P1D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := p.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_PROFILE1D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	edges := make([]float64, 0, len(p.bng.bins)+1)
+	for i, bin := range p.bng.bins {
+		if i == 0 {
+			edges = append(edges, bin.xrange.Min)
+		}
+		edges = append(edges, bin.xrange.Max)
+	}
+	writeYODAEdges(buf, 1, edges)
+
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t sumW(A2)\t sumW2(A2)\t sumW(A1,A2)\t numEntries\n")
+	row := func(d Dist2D) {
+		writeYODARow(
+			buf,
+			d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(),
+			d.SumWXY(), float64(d.Entries()),
+		)
+	}
+	row(p.bng.outflows[0])
+	for _, bin := range p.bng.bins {
+		row(bin.dist)
+	}
+	row(p.bng.outflows[1])
+	fmt.Fprintf(buf, "END YODA_PROFILE1D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
 func (p *P1D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
@@ -302,6 +346,8 @@ func (p *P1D) UnmarshalYODA(data []byte) error {
 		return p.unmarshalYODAv1(r)
 	case 2:
 		return p.unmarshalYODAv2(r)
+	case 3:
+		return p.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -557,6 +603,47 @@ scanLoop:
 	return err
 }
 
+func (p *P1D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	p.annFromYODA(ann)
+
+	err = blk.check(1, 8)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid P1D-YODA data: %w", err)
+	}
+
+	var (
+		edges = blk.edges[0]
+		n     = len(edges) - 1
+		dist  Dist2D
+		bins  = make([]BinP1D, n)
+	)
+	for i, row := range blk.rows {
+		d := yodaDist2D(row)
+		dist.addScaled(1, 1, d)
+		if i == 0 || i == n+1 {
+			continue
+		}
+		bins[i-1] = BinP1D{
+			xrange: Range{Min: edges[i-1], Max: edges[i]},
+			dist:   d,
+		}
+	}
+
+	p.bng = newBinningP1D(n, edges[0], edges[n])
+	p.bng.dist = dist
+	p.bng.bins = bins
+	p.bng.outflows = [2]Dist2D{
+		yodaDist2D(blk.rows[0]),
+		yodaDist2D(blk.rows[n+1]),
+	}
+	return nil
+}
+
 // binningP1D is a 1-dim binning for 1-dim profile histograms.
 type binningP1D struct {
 	bins     []BinP1D
@@ -0,0 +1,543 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// P2D is a 2-dim profile histogram: it records the mean (and spread) of
+// a quantity z, in bins of (x,y).
+type P2D struct {
+	Binning BinningP2D
+	Ann     Annotation
+}
+
+// NewP2D returns a 2-dim profile histogram with nx bins between xlow and
+// xhigh, and ny bins between ylow and yhigh.
+func NewP2D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64) *P2D {
+	return &P2D{
+		Binning: newBinningP2D(nx, xlow, xhigh, ny, ylow, yhigh),
+		Ann:     make(Annotation),
+	}
+}
+
+// NewP2DFromEdges returns a 2-dim profile histogram from slices of edges
+// in x and y.
+// The number of bins in x and y is thus len(edges)-1.
+// It panics if the length of edges is <=1 (in any dimension.)
+// It panics if the edges are not sorted (in any dimension.)
+// It panics if there are duplicate edge values (in any dimension.)
+func NewP2DFromEdges(xedges, yedges []float64) *P2D {
+	return &P2D{
+		Binning: newBinningP2DFromEdges(xedges, yedges),
+		Ann:     make(Annotation),
+	}
+}
+
+// NewP2DFromH2D creates a 2-dim profile histogram from a 2-dim histogram's binning.
+func NewP2DFromH2D(h *H2D) *P2D {
+	return &P2D{
+		Binning: newBinningP2DFrom(newBinning2DFromEdges(
+			bin1DEdges(h.Binning.XEdges),
+			bin1DEdges(h.Binning.YEdges),
+		)),
+		Ann: make(Annotation),
+	}
+}
+
+// Name returns the name of this profile histogram, if any
+func (p *P2D) Name() string {
+	v, ok := p.Ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this profile histogram
+func (p *P2D) Annotation() Annotation {
+	return p.Ann
+}
+
+// Rank returns the number of dimensions for this profile histogram
+func (p *P2D) Rank() int {
+	return 2
+}
+
+// Entries returns the number of entries in this profile histogram
+func (p *P2D) Entries() int64 {
+	return p.Binning.entries()
+}
+
+// EffEntries returns the number of effective entries in this profile histogram
+func (p *P2D) EffEntries() float64 {
+	return p.Binning.effEntries()
+}
+
+// SumW returns the sum of weights in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumW() float64 {
+	return p.Binning.Dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumW2() float64 {
+	return p.Binning.Dist.SumW2()
+}
+
+// XMean returns the mean X.
+// Overflows are included in the computation.
+func (p *P2D) XMean() float64 {
+	return p.Binning.Dist.xMean()
+}
+
+// YMean returns the mean Y.
+// Overflows are included in the computation.
+func (p *P2D) YMean() float64 {
+	return p.Binning.Dist.yMean()
+}
+
+// ZMean returns the mean Z.
+// Overflows are included in the computation.
+func (p *P2D) ZMean() float64 {
+	return p.Binning.Dist.zMean()
+}
+
+// XStdDev returns the standard deviation in X.
+// Overflows are included in the computation.
+func (p *P2D) XStdDev() float64 {
+	return p.Binning.Dist.xStdDev()
+}
+
+// YStdDev returns the standard deviation in Y.
+// Overflows are included in the computation.
+func (p *P2D) YStdDev() float64 {
+	return p.Binning.Dist.yStdDev()
+}
+
+// ZStdDev returns the standard deviation in Z.
+// Overflows are included in the computation.
+func (p *P2D) ZStdDev() float64 {
+	return p.Binning.Dist.zStdDev()
+}
+
+// XStdErr returns the standard error in X.
+// Overflows are included in the computation.
+func (p *P2D) XStdErr() float64 {
+	return p.Binning.Dist.xStdErr()
+}
+
+// YStdErr returns the standard error in Y.
+// Overflows are included in the computation.
+func (p *P2D) YStdErr() float64 {
+	return p.Binning.Dist.yStdErr()
+}
+
+// ZStdErr returns the standard error in Z.
+// Overflows are included in the computation.
+func (p *P2D) ZStdErr() float64 {
+	return p.Binning.Dist.zStdErr()
+}
+
+// Fill fills this profile histogram with the value z at (x,y), with
+// weight w.
+func (p *P2D) Fill(x, y, z, w float64) {
+	p.Binning.fill(x, y, z, w)
+}
+
+// Bin returns the bin at coordinates (x,y) for this profile histogram.
+// Bin returns nil for under/over flow bins.
+func (p *P2D) Bin(x, y float64) *BinP2D {
+	idx := p.Binning.coordToIndex(x, y)
+	if idx < 0 || idx == len(p.Binning.Bins) {
+		return nil
+	}
+	return &p.Binning.Bins[idx]
+}
+
+// XMin returns the low edge of the X-axis of this profile histogram.
+func (p *P2D) XMin() float64 {
+	return p.Binning.xMin()
+}
+
+// XMax returns the high edge of the X-axis of this profile histogram.
+func (p *P2D) XMax() float64 {
+	return p.Binning.xMax()
+}
+
+// YMin returns the low edge of the Y-axis of this profile histogram.
+func (p *P2D) YMin() float64 {
+	return p.Binning.yMin()
+}
+
+// YMax returns the high edge of the Y-axis of this profile histogram.
+func (p *P2D) YMax() float64 {
+	return p.Binning.yMax()
+}
+
+// Scale scales the content of each bin by the given factor.
+func (p *P2D) Scale(factor float64) {
+	p.Binning.scaleW(factor)
+}
+
+// GridXYZ returns an anonymous struct value that implements
+// gonum/plot/plotter.GridXYZ and is ready to plot.
+// The Z value of a cell is the profiled value (the mean Z) of the
+// corresponding bin, or NaN if the bin is empty.
+func (p *P2D) GridXYZ() p2dGridXYZ {
+	return p2dGridXYZ{p}
+}
+
+type p2dGridXYZ struct {
+	p *P2D
+}
+
+func (g p2dGridXYZ) Dims() (c, r int) {
+	return g.p.Binning.Nx, g.p.Binning.Ny
+}
+
+func (g p2dGridXYZ) Z(c, r int) float64 {
+	idx := r*g.p.Binning.Nx + c
+	return g.p.Binning.Bins[idx].ZMean()
+}
+
+func (g p2dGridXYZ) X(c int) float64 {
+	return g.p.Binning.Bins[c].XMid()
+}
+
+func (g p2dGridXYZ) Y(r int) float64 {
+	idx := r * g.p.Binning.Nx
+	return g.p.Binning.Bins[idx].YMid()
+}
+
+// check various interfaces
+var _ Object = (*P2D)(nil)
+var _ Histogram = (*P2D)(nil)
+
+// annToYODA creates a new Annotation with fields compatible with YODA
+func (p *P2D) annToYODA() Annotation {
+	ann := make(Annotation, len(p.Ann))
+	ann["Type"] = "Profile2D"
+	ann["Path"] = "/" + p.Name()
+	ann["Title"] = ""
+	for k, v := range p.Ann {
+		if k == "name" {
+			continue
+		}
+		if k == "title" {
+			ann["Title"] = v
+			continue
+		}
+		ann[k] = v
+	}
+	return ann
+}
+
+// annFromYODA creates a new Annotation from YODA compatible fields
+func (p *P2D) annFromYODA(ann Annotation) {
+	if len(p.Ann) == 0 {
+		p.Ann = make(Annotation, len(ann))
+	}
+	for k, v := range ann {
+		switch k {
+		case "Type":
+			// noop
+		case "Path":
+			name := v.(string)
+			name = strings.TrimPrefix(name, "/")
+			p.Ann["name"] = name
+		case "Title":
+			p.Ann["title"] = v
+		default:
+			p.Ann[k] = v
+		}
+	}
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+func (p *P2D) MarshalYODA() ([]byte, error) {
+	return p.marshalYODAv2()
+}
+
+func (p *P2D) marshalYODAv2() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := p.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_PROFILE2D_V2 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e)\n", p.XMean(), p.YMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", p.SumW())
+
+	fmt.Fprintf(buf, "# ID\t ID\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t numEntries\n")
+	d := p.Binning.Dist
+	fmt.Fprintf(
+		buf,
+		"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+		d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+		d.SumWXY(), float64(d.Entries()),
+	)
+
+	// outflows
+	fmt.Fprintf(buf, "# 2D outflow persistency not currently supported until API is stable\n")
+
+	// bins
+	fmt.Fprintf(buf, "# xlow\t xhigh\t ylow\t yhigh\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t numEntries\n")
+	for ix := 0; ix < p.Binning.Nx; ix++ {
+		for iy := 0; iy < p.Binning.Ny; iy++ {
+			bin := p.Binning.Bins[iy*p.Binning.Nx+ix]
+			d := bin.Dist
+			fmt.Fprintf(
+				buf,
+				"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				bin.XRange.Min, bin.XRange.Max, bin.YRange.Min, bin.YRange.Max,
+				d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+				d.SumWXY(), float64(d.Entries()),
+			)
+		}
+	}
+	fmt.Fprintf(buf, "END YODA_PROFILE2D_V2\n\n")
+	return buf.Bytes(), err
+}
+
+// MarshalYODA2 marshals the profile into the text format of YODA2
+// (YODA_PROFILE2D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (p *P2D) MarshalYODA2() ([]byte, error) {
+	return p.marshalYODAv3()
+}
+
+func (p *P2D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := p.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_PROFILE2D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	var (
+		bng = &p.Binning
+		nx  = bng.Nx
+		ny  = bng.Ny
+	)
+	writeYODAEdges(buf, 1, bin1DEdges(bng.XEdges))
+	writeYODAEdges(buf, 2, bin1DEdges(bng.YEdges))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e)\n", p.XMean(), p.YMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", p.SumW())
+
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t sumW(A2)\t sumW2(A2)\t sumW(A3)\t sumW2(A3)\t sumW(A1,A2)\t sumW(A1,A3)\t sumW(A2,A3)\t numEntries\n")
+	for iy := 0; iy < ny+2; iy++ {
+		for ix := 0; ix < nx+2; ix++ {
+			var d Dist3D
+			ox, oy := yodaOutflow(ix, nx), yodaOutflow(iy, ny)
+			switch {
+			case ox == 0 && oy == 0:
+				d = bng.Bins[(iy-1)*nx+ix-1].Dist
+			case ix == yodaOutflowBin(ox, nx) && iy == yodaOutflowBin(oy, ny):
+				d = bng.Outflows[outflowIndex2D(ox, oy)]
+			}
+			writeYODARow(
+				buf,
+				d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWZ(), d.SumWZ2(),
+				d.SumWXY(), d.SumWXZ(), d.SumWYZ(), float64(d.Entries()),
+			)
+		}
+	}
+	fmt.Fprintf(buf, "END YODA_PROFILE2D_V3\n\n")
+	return buf.Bytes(), err
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (p *P2D) UnmarshalYODA(data []byte) error {
+	r := newRBuffer(data)
+	_, vers, err := readYODAHeader(r, "BEGIN YODA_PROFILE2D")
+	if err != nil {
+		return err
+	}
+	switch vers {
+	case 2:
+		return p.unmarshalYODAv2(r)
+	case 3:
+		return p.unmarshalYODAv3(r)
+	default:
+		return fmt.Errorf("hbook: invalid YODA version %v", vers)
+	}
+}
+
+func (p *P2D) unmarshalYODAv2(r *rbuffer) error {
+	ann := make(Annotation)
+
+	// pos of end of annotations
+	pos := bytes.Index(r.Bytes(), []byte("\n# Mean:"))
+	if pos < 0 {
+		return fmt.Errorf("hbook: invalid P2D-YODA data")
+	}
+	err := ann.unmarshalYODAv2(r.Bytes()[:pos+1])
+	if err != nil {
+		return fmt.Errorf("hbook: %q\nhbook: %w", string(r.Bytes()[:pos+1]), err)
+	}
+	p.annFromYODA(ann)
+	r.next(pos)
+
+	var ctx struct {
+		dist bool
+		bins bool
+	}
+
+	// sets of bin edges, to infer the binning in X and Y.
+	var (
+		xset = make(map[float64]struct{})
+		yset = make(map[float64]struct{})
+	)
+
+	var (
+		dist Dist3D
+		bins []BinP2D
+	)
+	s := bufio.NewScanner(r)
+scanLoop:
+	for s.Scan() {
+		buf := s.Bytes()
+		if len(buf) == 0 || buf[0] == '#' {
+			continue
+		}
+		rbuf := bytes.NewReader(buf)
+		switch {
+		case bytes.HasPrefix(buf, []byte("END YODA_PROFILE2D_V2")):
+			break scanLoop
+		case !ctx.dist && bytes.HasPrefix(buf, []byte("Total   \t")):
+			ctx.dist = true
+			d := &dist
+			var n float64
+			_, err = fmt.Fscanf(
+				rbuf,
+				"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&d.X.Dist.SumW, &d.X.Dist.SumW2,
+				&d.X.Stats.SumWX, &d.X.Stats.SumWX2,
+				&d.Y.Stats.SumWX, &d.Y.Stats.SumWX2,
+				&d.Z.Stats.SumWX, &d.Z.Stats.SumWX2,
+				&d.Stats.SumWXY, &n,
+			)
+			if err != nil {
+				return fmt.Errorf("hbook: %q\nhbook: %w", string(buf), err)
+			}
+			d.X.Dist.N = int64(n)
+			d.Y.Dist = d.X.Dist
+			d.Z.Dist = d.X.Dist
+			ctx.bins = true
+		case ctx.bins:
+			var bin BinP2D
+			d := &bin.Dist
+			var n float64
+			_, err = fmt.Fscanf(
+				rbuf,
+				"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&bin.XRange.Min, &bin.XRange.Max,
+				&bin.YRange.Min, &bin.YRange.Max,
+				&d.X.Dist.SumW, &d.X.Dist.SumW2,
+				&d.X.Stats.SumWX, &d.X.Stats.SumWX2,
+				&d.Y.Stats.SumWX, &d.Y.Stats.SumWX2,
+				&d.Z.Stats.SumWX, &d.Z.Stats.SumWX2,
+				&d.Stats.SumWXY, &n,
+			)
+			if err != nil {
+				return fmt.Errorf("hbook: %q\nhbook: %w", string(buf), err)
+			}
+			d.X.Dist.N = int64(n)
+			d.Y.Dist = d.X.Dist
+			d.Z.Dist = d.X.Dist
+			xset[bin.XRange.Min] = struct{}{}
+			xset[bin.XRange.Max] = struct{}{}
+			yset[bin.YRange.Min] = struct{}{}
+			yset[bin.YRange.Max] = struct{}{}
+			bins = append(bins, bin)
+
+		default:
+			return fmt.Errorf("hbook: invalid P2D-YODA data: %q", string(buf))
+		}
+	}
+
+	edges := func(set map[float64]struct{}) []float64 {
+		o := make([]float64, 0, len(set))
+		for v := range set {
+			o = append(o, v)
+		}
+		sort.Float64s(o)
+		return o
+	}
+
+	var (
+		xedges = edges(xset)
+		yedges = edges(yset)
+		nx     = len(xedges) - 1
+		ny     = len(yedges) - 1
+	)
+	if nx <= 0 || ny <= 0 || nx*ny != len(bins) {
+		return fmt.Errorf("hbook: invalid P2D-YODA binning")
+	}
+	p.Binning = newBinningP2DFromEdges(xedges, yedges)
+	p.Binning.Dist = dist
+	// YODA bins are transposed wrt ours
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			p.Binning.Bins[iy*nx+ix] = bins[ix*ny+iy]
+		}
+	}
+	return err
+}
+
+func (p *P2D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	p.annFromYODA(ann)
+
+	err = blk.check(2, 12)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid P2D-YODA data: %w", err)
+	}
+
+	var (
+		bng = newBinningP2DFromEdges(blk.edges[0], blk.edges[1])
+		nx  = bng.Nx
+		ny  = bng.Ny
+	)
+	for i, row := range blk.rows {
+		var (
+			d      = yodaDist3D(row)
+			ix, iy = i % (nx + 2), i / (nx + 2)
+			ox, oy = yodaOutflow(ix, nx), yodaOutflow(iy, ny)
+		)
+		bng.Dist.addScaled(1, 1, d)
+		switch {
+		case ox == 0 && oy == 0:
+			bng.Bins[(iy-1)*nx+ix-1].Dist = d
+		default:
+			bng.Outflows[outflowIndex2D(ox, oy)].addScaled(1, 1, d)
+		}
+	}
+	p.Binning = bng
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestP2D(t *testing.T) {
+	p := NewP2D(2, 0, 2, 3, 0, 3)
+	if got, want := p.Rank(), 2; got != want {
+		t.Fatalf("invalid rank: got=%d, want=%d", got, want)
+	}
+	if got, want := len(p.Binning.Bins), 2*3; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+
+	p.Fill(0.5, 0.5, 1, 1)
+	p.Fill(0.5, 0.5, 3, 1)
+	p.Fill(1.5, 2.5, 2, 1)
+	p.Fill(1.5, 2.5, 2, 3)
+	p.Fill(-1, 1.5, 10, 1)
+	p.Fill(1.5, 4, 20, 2)
+
+	if got, want := p.Entries(), int64(6); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := p.SumW(), 9.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := p.ZMean(), (1+3+2+6+10+40)/9.0; got != want {
+		t.Fatalf("invalid z-mean: got=%v, want=%v", got, want)
+	}
+
+	bin := p.Bin(0.2, 0.8)
+	if bin == nil {
+		t.Fatalf("expected a bin")
+	}
+	if got, want := bin.ZMean(), 2.0; got != want {
+		t.Fatalf("invalid bin z-mean: got=%v, want=%v", got, want)
+	}
+	for _, tc := range []struct {
+		mode ProfileErr
+		want float64
+	}{
+		{ProfileErrMean, 1 / math.Sqrt(2)},
+		{ProfileErrSpread, 1},
+		{ProfileErrInteger, 1 / math.Sqrt(2)},
+		{ProfileErrGaussian, 1 / math.Sqrt(2)},
+	} {
+		if got := bin.ZErr(tc.mode); math.Abs(got-tc.want) > 1e-12 {
+			t.Fatalf("invalid error (mode=%d): got=%v, want=%v", tc.mode, got, tc.want)
+		}
+	}
+
+	bin = p.Bin(1.5, 2.5)
+	if got, want := bin.ZMean(), 2.0; got != want {
+		t.Fatalf("invalid bin z-mean: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.EffEntries(), 16.0/10.0; got != want {
+		t.Fatalf("invalid bin eff-entries: got=%v, want=%v", got, want)
+	}
+	for _, tc := range []struct {
+		mode ProfileErr
+		want float64
+	}{
+		{ProfileErrMean, 0},
+		{ProfileErrSpread, 0},
+		{ProfileErrInteger, 1 / math.Sqrt(12*1.6)},
+		{ProfileErrGaussian, 0.5},
+	} {
+		if got := bin.ZErr(tc.mode); math.Abs(got-tc.want) > 1e-12 {
+			t.Fatalf("invalid error (mode=%d): got=%v, want=%v", tc.mode, got, tc.want)
+		}
+	}
+
+	bin = p.Bin(0.5, 2.5)
+	if got := bin.ZMean(); !math.IsNaN(got) {
+		t.Fatalf("invalid empty bin z-mean: got=%v, want=NaN", got)
+	}
+	if got := bin.ZErr(ProfileErrMean); got != 0 {
+		t.Fatalf("invalid empty bin error: got=%v, want=0", got)
+	}
+
+	if p.Bin(-1, 1.5) != nil {
+		t.Fatalf("expected no bin for outflows")
+	}
+	if got, want := p.Binning.Outflows[BngW-1].SumW(), 1.0; got != want {
+		t.Fatalf("invalid W outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := p.Binning.Outflows[BngN-1].SumW(), 2.0; got != want {
+		t.Fatalf("invalid N outflow: got=%v, want=%v", got, want)
+	}
+
+	grid := p.GridXYZ()
+	if c, r := grid.Dims(); c != 2 || r != 3 {
+		t.Fatalf("invalid grid dims: got=(%d,%d)", c, r)
+	}
+	if got, want := grid.Z(1, 2), 2.0; got != want {
+		t.Fatalf("invalid grid value: got=%v, want=%v", got, want)
+	}
+	if got, want := grid.X(1), 1.5; got != want {
+		t.Fatalf("invalid grid x: got=%v, want=%v", got, want)
+	}
+	if got, want := grid.Y(2), 2.5; got != want {
+		t.Fatalf("invalid grid y: got=%v, want=%v", got, want)
+	}
+
+	p.Scale(2)
+	if got, want := p.SumW(), 18.0; got != want {
+		t.Fatalf("invalid scaled sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := p.Bin(0.5, 0.5).ZMean(), 2.0; got != want {
+		t.Fatalf("invalid scaled bin z-mean: got=%v, want=%v", got, want)
+	}
+}
+
+func TestP2DYODA(t *testing.T) {
+	p := NewP2DFromEdges([]float64{-1, 0, 1}, []float64{-2, 0, 2})
+	p.Ann["name"] = "p2d"
+	p.Ann["title"] = "my title"
+	p.Fill(+0.5, +1, 0.5, 1)
+	p.Fill(-0.5, +1, 2.5, 1)
+	p.Fill(+0.0, -1, 1.5, 2)
+	p.Fill(+0.5, +1, 4.0, 1)
+
+	chk, err := p.MarshalYODA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/p2d_v2_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(chk, ref) {
+		t.Fatalf("p2d file differ:\n%s\n",
+			cmp.Diff(
+				string(ref),
+				string(chk),
+			),
+		)
+	}
+
+	var got P2D
+	err = got.UnmarshalYODA(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// outflows and (x,z), (y,z) correlations are not persisted.
+	want := *p
+	want.Binning.Outflows = [8]Dist3D{}
+	want.Binning.Dist.Stats.SumWXZ = 0
+	want.Binning.Dist.Stats.SumWYZ = 0
+	for i := range want.Binning.Bins {
+		want.Binning.Bins[i].Dist.Stats.SumWXZ = 0
+		want.Binning.Bins[i].Dist.Stats.SumWYZ = 0
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid round-trip:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestP2DSerialization(t *testing.T) {
+	pref := NewP2D(2, 0, 2, 2, 0, 2)
+	pref.Fill(0.5, 1.5, 0.5, 1)
+	pref.Fill(-1, 1.5, 0.5, 2)
+	pref.Annotation()["title"] = "profile title"
+	pref.Annotation()["name"] = "profile name"
+
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(pref)
+	if err != nil {
+		t.Fatalf("could not serialize profile: %v", err)
+	}
+
+	var pnew P2D
+	err = gob.NewDecoder(buf).Decode(&pnew)
+	if err != nil {
+		t.Fatalf("could not deserialize profile: %v", err)
+	}
+
+	if !reflect.DeepEqual(pref, &pnew) {
+		t.Fatalf("ref=%v\nnew=%v\n", pref, &pnew)
+	}
+}
@@ -28,6 +28,31 @@ func H2D(h2 rhist.H2) *hbook.H2D {
 	return h2.(h2der).AsH2D()
 }
 
+type h3der interface {
+	AsH3D() *hbook.H3D
+}
+
+// H3D creates a new H3D from a TH3x.
+func H3D(h3 rhist.H3) *hbook.H3D {
+	return h3.(h3der).AsH3D()
+}
+
+// P2D creates a new P2D from a TProfile2D.
+func P2D(p2 *rhist.Profile2D) *hbook.P2D {
+	return p2.AsP2D()
+}
+
+// Eff1D creates a new Eff1D from a TEfficiency.
+func Eff1D(eff *rhist.Efficiency) (*hbook.Eff1D, error) {
+	o, err := hbook.NewEff1DFromH1D(H1D(eff.Passed()), H1D(eff.Total()))
+	if err != nil {
+		return nil, err
+	}
+	o.Annotation()["name"] = eff.Name()
+	o.Annotation()["title"] = eff.Title()
+	return o, nil
+}
+
 // S2D creates a new S2D from a TGraph, TGraphErrors or TGraphAsymmErrors.
 func S2D(g rhist.Graph) *hbook.S2D {
 	pts := make([]hbook.Point2D, g.Len())
@@ -52,6 +77,33 @@ func S2D(g rhist.Graph) *hbook.S2D {
 	return s2d
 }
 
+// S2Ds creates one S2D per source of Y errors of a TGraphMultiErrors.
+// The i-th S2D holds the points of the graph with the i-th source of Y errors.
+func S2Ds(g rhist.GraphMultiErrors) []*hbook.S2D {
+	o := make([]*hbook.S2D, g.NYErrors())
+	for e := range o {
+		pts := make([]hbook.Point2D, g.Len())
+		for i := range pts {
+			var (
+				x, y     = g.XY(i)
+				xlo, xhi = g.XError(i)
+				ylo, yhi = g.YErrorAt(i, e)
+			)
+			pts[i] = hbook.Point2D{
+				X:    x,
+				Y:    y,
+				ErrX: hbook.Range{Min: xlo, Max: xhi},
+				ErrY: hbook.Range{Min: ylo, Max: yhi},
+			}
+		}
+		s2d := hbook.NewS2D(pts...)
+		s2d.Annotation()["name"] = g.Name()
+		s2d.Annotation()["title"] = g.Title()
+		o[e] = s2d
+	}
+	return o
+}
+
 // FromH1D creates a new ROOT TH1D from a 1-dim hbook histogram.
 func FromH1D(h1 *hbook.H1D) *rhist.H1D {
 	return rhist.NewH1DFrom(h1)
@@ -62,6 +114,21 @@ func FromH2D(h2 *hbook.H2D) *rhist.H2D {
 	return rhist.NewH2DFrom(h2)
 }
 
+// FromH3D creates a new ROOT TH3D from a 3-dim hbook histogram.
+func FromH3D(h3 *hbook.H3D) *rhist.H3D {
+	return rhist.NewH3DFrom(h3)
+}
+
+// FromP2D creates a new ROOT TProfile2D from a 2-dim hbook profile histogram.
+func FromP2D(p2 *hbook.P2D) *rhist.Profile2D {
+	return rhist.NewProfile2DFrom(p2)
+}
+
+// FromEff1D creates a new ROOT TEfficiency from a 1-dim hbook efficiency.
+func FromEff1D(eff *hbook.Eff1D) (*rhist.Efficiency, error) {
+	return rhist.NewEfficiencyFromEff1D(eff)
+}
+
 // FromS2D creates a new ROOT TGraphAsymmErrors from 2-dim hbook data points.
 func FromS2D(s2 *hbook.S2D) rhist.GraphErrors {
 	return rhist.NewGraphAsymmErrorsFrom(s2)
@@ -556,6 +556,125 @@ func TestFromH2D(t *testing.T) {
 	}
 }
 
+func TestFromH3D(t *testing.T) {
+	const npoints = 1000
+
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	h := hbook.NewH3D(4, -4, +4, 3, -4, +4, 5, -4, +4)
+	for i := 0; i < npoints; i++ {
+		h.Fill(dist.Rand(), dist.Rand(), dist.Rand(), 1)
+	}
+	h.Fill(-5, +0, +0, 2)
+	h.Fill(+5, +5, +0, 3)
+	h.Fill(+0, +0, -5, 4)
+	h.Fill(+5, -5, +5, 5)
+
+	h.Annotation()["name"] = "my-name"
+	h.Annotation()["title"] = "my-title"
+
+	h3 := rootcnv.FromH3D(h)
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"entries", h3.Entries(), float64(h.Entries())},
+		{"sumw", h3.SumW(), h.SumW()},
+		{"sumw2", h3.SumW2(), h.SumW2()},
+		{"sumwx", h3.SumWX(), h.SumWX()},
+		{"sumwy", h3.SumWY(), h.SumWY()},
+		{"sumwz", h3.SumWZ(), h.SumWZ()},
+		{"sumwyz", h3.SumWYZ(), h.SumWYZ()},
+	} {
+		if tc.got != tc.want {
+			t.Fatalf("%s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	hh := rootcnv.H3D(h3)
+	if got, want := hh.Name(), "my-name"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	for i := range h.Binning.Bins {
+		got := &hh.Binning.Bins[i]
+		want := &h.Binning.Bins[i]
+		if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() {
+			t.Fatalf("invalid bin %d: got=%v, want=%v", i, got.Dist, want.Dist)
+		}
+	}
+	for _, o := range [][3]int{{-1, 0, 0}, {+1, +1, 0}, {0, 0, -1}, {+1, -1, +1}} {
+		got := hh.Binning.Outflow(o[0], o[1], o[2]).SumW()
+		want := h.Binning.Outflow(o[0], o[1], o[2]).SumW()
+		if got != want {
+			t.Fatalf("invalid outflow %v: got=%v, want=%v", o, got, want)
+		}
+	}
+}
+
+func TestFromP2D(t *testing.T) {
+	const npoints = 1000
+
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	p := hbook.NewP2D(4, -4, +4, 3, -4, +4)
+	for i := 0; i < npoints; i++ {
+		x := dist.Rand()
+		y := dist.Rand()
+		p.Fill(x, y, x*x+y*y, 1)
+	}
+	p.Fill(-5, +0, 1, 2)
+	p.Fill(+5, +5, 2, 3)
+
+	p.Annotation()["name"] = "my-name"
+	p.Annotation()["title"] = "my-title"
+
+	p2 := rootcnv.FromP2D(p)
+	if got, want := p2.BinContent(2, 2), p.Binning.Bins[1*4+1].ZMean(); got != want {
+		t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+	}
+
+	pp := rootcnv.P2D(p2)
+	if got, want := pp.Name(), "my-name"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"entries", float64(pp.Entries()), float64(p.Entries())},
+		{"sumw", pp.SumW(), p.SumW()},
+		{"sumw2", pp.SumW2(), p.SumW2()},
+		{"xmean", pp.XMean(), p.XMean()},
+		{"zmean", pp.ZMean(), p.ZMean()},
+	} {
+		if tc.got != tc.want {
+			t.Fatalf("%s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+	for i := range p.Binning.Bins {
+		got := &pp.Binning.Bins[i]
+		want := &p.Binning.Bins[i]
+		if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() || got.Dist.Z != want.Dist.Z {
+			t.Fatalf("invalid bin %d: got=%v, want=%v", i, got.Dist, want.Dist)
+		}
+	}
+	for _, i := range []int{hbook.BngW, hbook.BngNE} {
+		got := pp.Binning.Outflows[i-1].SumW()
+		want := p.Binning.Outflows[i-1].SumW()
+		if got != want {
+			t.Fatalf("invalid outflow %d: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
 func TestFromS2D(t *testing.T) {
 	hg := hbook.NewS2D(
 		hbook.Point2D{X: 1, Y: 1, ErrX: hbook.Range{Min: 1, Max: 2}, ErrY: hbook.Range{Min: 3, Max: 4}},
@@ -586,3 +705,76 @@ func TestFromS2D(t *testing.T) {
 		)
 	}
 }
+
+func TestS2Ds(t *testing.T) {
+	f, err := groot.Open("../../groot/testdata/tgme.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("gme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2ds := rootcnv.S2Ds(obj.(rhist.GraphMultiErrors))
+	if got, want := len(s2ds), 2; got != want {
+		t.Fatalf("invalid number of S2Ds: got=%d, want=%d", got, want)
+	}
+
+	for i, tc := range []struct {
+		ylo, yhi []float64
+	}{
+		{
+			ylo: []float64{1, 0.5, 1, 0.5, 1},
+			yhi: []float64{0.5, 1, 0.5, 1, 2},
+		},
+		{
+			ylo: []float64{0.5, 0.4, 0.8, 0.3, 1.2},
+			yhi: []float64{0.6, 0.7, 0.6, 0.4, 0.8},
+		},
+	} {
+		s2d := s2ds[i]
+		if got, want := s2d.Name(), "gme"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		for j, pt := range s2d.Points() {
+			if got, want := pt.ErrY, (hbook.Range{Min: tc.ylo[j], Max: tc.yhi[j]}); got != want {
+				t.Fatalf("invalid y-errors for source %d, point %d: got=%v, want=%v", i, j, got, want)
+			}
+			if got, want := pt.ErrX, (hbook.Range{Min: 0.3, Max: 0.3}); got != want {
+				t.Fatalf("invalid x-errors for source %d, point %d: got=%v, want=%v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestEff1D(t *testing.T) {
+	e := hbook.NewEff1DFromEdges([]float64{0, 1, 3})
+	e.Annotation()["name"] = "eff"
+	e.Annotation()["title"] = "efficiency"
+	e.Fill(0.5, true, 1)
+	e.Fill(0.5, false, 2)
+	e.Fill(2.5, true, 1)
+
+	reff, err := rootcnv.FromEff1D(e)
+	if err != nil {
+		t.Fatalf("could not convert to TEfficiency: %+v", err)
+	}
+
+	got, err := rootcnv.Eff1D(reff)
+	if err != nil {
+		t.Fatalf("could not convert from TEfficiency: %+v", err)
+	}
+	if got, want := got.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	for _, x := range []float64{0.5, 2.5} {
+		geff, gerr := got.Eff(x)
+		weff, werr := e.Eff(x)
+		if geff != weff || gerr != werr {
+			t.Fatalf("invalid efficiency at x=%v: got=(%v, %v), want=(%v, %v)", x, geff, gerr, weff, werr)
+		}
+	}
+}
@@ -372,9 +372,46 @@ func (s *S2D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+// MarshalYODA2 marshals the scatter into the text format of YODA2
+// (YODA_SCATTER2D_V3 blocks).
+// Unlike MarshalYODA, the YODA2 format can not be read back by YODA1.
+func (s *S2D) MarshalYODA2() ([]byte, error) {
+	return s.marshalYODAv3()
+}
+
+func (s *S2D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := s.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_SCATTER2D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# val1\t err1-\t err1+\t val2\t err2-\t err2+\n")
+	s.Sort()
+	for _, pt := range s.pts {
+		writeYODARow(buf, pt.X, pt.ErrX.Min, pt.ErrX.Max, pt.Y, pt.ErrY.Min, pt.ErrY.Max)
+	}
+	fmt.Fprintf(buf, "END YODA_SCATTER2D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
+//
+// UnmarshalYODA also reads the YODA2 1-dim estimates (YODA_ESTIMATE1D_V3
+// blocks), as a scatter with one point per bin.
 func (s *S2D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
+	if bytes.HasPrefix(data, []byte("BEGIN YODA_ESTIMATE1D_V3 ")) {
+		_, _, err := readYODAHeader(r, "BEGIN YODA_ESTIMATE1D")
+		if err != nil {
+			return err
+		}
+		return s.unmarshalYODAEstimate(r)
+	}
 	_, vers, err := readYODAHeader(r, "BEGIN YODA_SCATTER2D")
 	if err != nil {
 		return err
@@ -384,6 +421,8 @@ func (s *S2D) UnmarshalYODA(data []byte) error {
 		return s.unmarshalYODAv1(r)
 	case 2:
 		return s.unmarshalYODAv2(r)
+	case 3:
+		return s.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -482,3 +521,77 @@ scanLoop:
 	s.Sort()
 	return err
 }
+
+func (s *S2D) unmarshalYODAv3(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	s.annFromYODA(ann)
+
+	for _, row := range blk.rows {
+		if len(row) != 6 {
+			return fmt.Errorf("hbook: invalid Scatter2D-YODA data: got %d columns, want 6", len(row))
+		}
+		s.Fill(Point2D{
+			X:    row[0],
+			Y:    row[3],
+			ErrX: Range{Min: row[1], Max: row[2]},
+			ErrY: Range{Min: row[4], Max: row[5]},
+		})
+	}
+	s.Sort()
+	return nil
+}
+
+// unmarshalYODAEstimate reads a YODA2 1-dim estimate.
+// Each in-range bin is converted to a point at the center of the bin,
+// with the half-width of the bin as x-error, and the quadratic sum of
+// all the error sources as y-errors.
+func (s *S2D) unmarshalYODAEstimate(r *rbuffer) error {
+	ann := make(Annotation)
+	blk, err := readYODAv3(r, ann)
+	if err != nil {
+		return err
+	}
+	s.annFromYODA(ann)
+
+	err = blk.check(1, 1+2*blk.nerrs)
+	if err != nil {
+		return fmt.Errorf("hbook: invalid Estimate1D-YODA data: %w", err)
+	}
+
+	var (
+		edges = blk.edges[0]
+		n     = len(edges) - 1
+	)
+	for i, row := range blk.rows[1 : n+1] {
+		if blk.masked[i+1] || math.IsNaN(row[0]) {
+			continue
+		}
+		var (
+			xmin = edges[i]
+			xmax = edges[i+1]
+			dx   = 0.5 * (xmax - xmin)
+			dn   float64
+			up   float64
+		)
+		for j := 1; j < len(row); j += 2 {
+			if !math.IsNaN(row[j]) {
+				dn += row[j] * row[j]
+			}
+			if !math.IsNaN(row[j+1]) {
+				up += row[j+1] * row[j+1]
+			}
+		}
+		s.Fill(Point2D{
+			X:    xmin + dx,
+			Y:    row[0],
+			ErrX: Range{Min: dx, Max: dx},
+			ErrY: Range{Min: math.Sqrt(dn), Max: math.Sqrt(up)},
+		})
+	}
+	s.Sort()
+	return nil
+}
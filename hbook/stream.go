@@ -0,0 +1,331 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Moments accumulates the first four moments of a weighted distribution,
+// without storing the filled values.
+//
+// Moments uses numerically stable one-pass update formulae:
+//
+//	P. Pébay, "Formulas for Robust, One-Pass Parallel Computation of
+//	Covariances and Arbitrary-Order Statistical Moments", SAND2008-6212.
+//
+// Moments values filled independently (e.g. from different goroutines)
+// can be combined with Merge.
+type Moments struct {
+	n     int64   // number of entries
+	sumw  float64 // sum of weights
+	sumw2 float64 // sum of squared weights
+	mean  float64 // weighted mean
+	m2    float64 // sum of w*(x-mean)^2
+	m3    float64 // sum of w*(x-mean)^3
+	m4    float64 // sum of w*(x-mean)^4
+	min   float64
+	max   float64
+}
+
+// Fill fills the accumulator with the value x and weight w.
+func (m *Moments) Fill(x, w float64) {
+	m.merge(1, w, w*w, x, 0, 0, 0, x, x)
+}
+
+// FillN fills the accumulator with the values xs and weights ws.
+// If ws is nil, all values are filled with a weight of 1.
+// FillN panics if the lengths of xs and ws differ.
+func (m *Moments) FillN(xs, ws []float64) {
+	switch ws {
+	case nil:
+		for _, x := range xs {
+			m.Fill(x, 1)
+		}
+	default:
+		if len(xs) != len(ws) {
+			panic(fmt.Errorf("hbook: lengths mismatch"))
+		}
+		for i := range xs {
+			m.Fill(xs[i], ws[i])
+		}
+	}
+}
+
+// Merge adds the content of o to m.
+func (m *Moments) Merge(o *Moments) {
+	if o.n == 0 {
+		return
+	}
+	m.merge(o.n, o.sumw, o.sumw2, o.mean, o.m2, o.m3, o.m4, o.min, o.max)
+}
+
+func (m *Moments) merge(n int64, sumw, sumw2, mean, m2, m3, m4, min, max float64) {
+	if m.n == 0 {
+		m.min, m.max = min, max
+	}
+	m.min = math.Min(m.min, min)
+	m.max = math.Max(m.max, max)
+	m.n += n
+	m.sumw2 += sumw2
+
+	var (
+		wa = m.sumw
+		wb = sumw
+		w  = wa + wb
+	)
+	m.sumw = w
+	if w == 0 {
+		return
+	}
+
+	var (
+		d  = mean - m.mean
+		d2 = d * d
+		ww = wa * wb
+	)
+	m.mean += d * wb / w
+	m4 = m.m4 + m4 +
+		d2*d2*ww*(wa*wa-ww+wb*wb)/(w*w*w) +
+		6*d2*(wa*wa*m2+wb*wb*m.m2)/(w*w) +
+		4*d*(wa*m3-wb*m.m3)/w
+	m3 = m.m3 + m3 +
+		d2*d*ww*(wa-wb)/(w*w) +
+		3*d*(wa*m2-wb*m.m2)/w
+	m.m2 += m2 + d2*ww/w
+	m.m3 = m3
+	m.m4 = m4
+}
+
+// Entries returns the number of entries.
+func (m *Moments) Entries() int64 {
+	return m.n
+}
+
+// EffEntries returns the effective number of entries.
+func (m *Moments) EffEntries() float64 {
+	if m.sumw2 == 0 {
+		return 0
+	}
+	return m.sumw * m.sumw / m.sumw2
+}
+
+// SumW returns the sum of weights.
+func (m *Moments) SumW() float64 {
+	return m.sumw
+}
+
+// SumW2 returns the sum of squared weights.
+func (m *Moments) SumW2() float64 {
+	return m.sumw2
+}
+
+// Min returns the smallest filled value.
+func (m *Moments) Min() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.min
+}
+
+// Max returns the largest filled value.
+func (m *Moments) Max() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.max
+}
+
+// Mean returns the weighted mean of the distribution.
+func (m *Moments) Mean() float64 {
+	if m.sumw == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance returns the weighted variance of the distribution,
+// with the same definition than Dist1D:
+//
+//	sig2 = \sum w(x-mean)^2 * \sum(w) / ( \sum(w)^2 - \sum(w^2) )
+func (m *Moments) Variance() float64 {
+	denom := m.sumw*m.sumw - m.sumw2
+	if denom == 0 {
+		return math.NaN()
+	}
+	return m.m2 * m.sumw / denom
+}
+
+// StdDev returns the weighted standard deviation of the distribution.
+func (m *Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// StdErr returns the weighted standard error of the distribution.
+func (m *Moments) StdErr() float64 {
+	return math.Sqrt(m.Variance() / m.EffEntries())
+}
+
+// Skewness returns the weighted skewness of the distribution, defined as:
+//
+//	g1 = m3 / m2^(3/2)
+//
+// where mk is the k-th weighted central moment of the distribution.
+func (m *Moments) Skewness() float64 {
+	if m.m2 == 0 {
+		return math.NaN()
+	}
+	return math.Sqrt(m.sumw) * m.m3 / math.Pow(m.m2, 1.5)
+}
+
+// Kurtosis returns the weighted excess kurtosis of the distribution,
+// defined as:
+//
+//	g2 = m4 / m2^2 - 3
+//
+// where mk is the k-th weighted central moment of the distribution.
+func (m *Moments) Kurtosis() float64 {
+	if m.m2 == 0 {
+		return math.NaN()
+	}
+	return m.sumw*m.m4/(m.m2*m.m2) - 3
+}
+
+// Quantile estimates a quantile of a distribution, without storing the
+// filled values, using the P² algorithm:
+//
+//	R. Jain and I. Chlamtac, "The P² algorithm for dynamic calculation of
+//	quantiles and histograms without storing observations",
+//	Communications of the ACM, 28(10), 1985.
+//
+// Quantile uses a constant amount of memory, whatever the number of filled
+// values.
+// The estimate is exact up to five filled values.
+type Quantile struct {
+	p   float64
+	n   int64
+	q   [5]float64 // heights of the markers
+	pos [5]float64 // actual positions of the markers
+	des [5]float64 // desired positions of the markers
+	inc [5]float64 // increments of the desired positions
+}
+
+// NewQuantile returns a new estimator of the p-quantile of a distribution.
+// NewQuantile panics if p is not in the [0, 1] range.
+func NewQuantile(p float64) *Quantile {
+	if !(0 <= p && p <= 1) {
+		panic(fmt.Errorf("hbook: invalid quantile p=%v", p))
+	}
+	return &Quantile{
+		p:   p,
+		pos: [5]float64{0, 1, 2, 3, 4},
+		des: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		inc: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+// P returns the probability of the estimated quantile.
+func (q *Quantile) P() float64 {
+	return q.p
+}
+
+// Entries returns the number of entries.
+func (q *Quantile) Entries() int64 {
+	return q.n
+}
+
+// Fill fills the estimator with the value x.
+func (q *Quantile) Fill(x float64) {
+	if q.n < 5 {
+		q.q[q.n] = x
+		q.n++
+		if q.n == 5 {
+			sort.Float64s(q.q[:])
+		}
+		return
+	}
+	q.n++
+
+	var k int
+	switch {
+	case x < q.q[0]:
+		q.q[0] = x
+		k = 0
+	case x >= q.q[4]:
+		q.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < q.q[k+1] {
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		q.pos[i]++
+	}
+	for i := range q.des {
+		q.des[i] += q.inc[i]
+	}
+
+	for i := 1; i < 4; i++ {
+		d := q.des[i] - q.pos[i]
+		if (d >= 1 && q.pos[i+1]-q.pos[i] > 1) || (d <= -1 && q.pos[i-1]-q.pos[i] < -1) {
+			d = math.Copysign(1, d)
+			v := q.parabolic(i, d)
+			if !(q.q[i-1] < v && v < q.q[i+1]) {
+				v = q.linear(i, d)
+			}
+			q.q[i] = v
+			q.pos[i] += d
+		}
+	}
+}
+
+// parabolic returns the piecewise-parabolic prediction of the height
+// of the i-th marker, moved by d.
+func (q *Quantile) parabolic(i int, d float64) float64 {
+	var (
+		n0, n1, n2 = q.pos[i-1], q.pos[i], q.pos[i+1]
+		q0, q1, q2 = q.q[i-1], q.q[i], q.q[i+1]
+	)
+	return q1 + d/(n2-n0)*((n1-n0+d)*(q2-q1)/(n2-n1)+(n2-n1-d)*(q1-q0)/(n1-n0))
+}
+
+// linear returns the linear prediction of the height of the i-th marker,
+// moved by d.
+func (q *Quantile) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return q.q[i] + d*(q.q[j]-q.q[i])/(q.pos[j]-q.pos[i])
+}
+
+// Value returns the current estimate of the quantile.
+// Value returns NaN if no value was filled.
+func (q *Quantile) Value() float64 {
+	switch {
+	case q.n == 0:
+		return math.NaN()
+	case q.n <= 5:
+		// exact quantile, interpolated between the sorted values.
+		vs := append([]float64(nil), q.q[:q.n]...)
+		sort.Float64s(vs)
+		var (
+			r = q.p * float64(len(vs)-1)
+			i = int(r)
+		)
+		if i == len(vs)-1 {
+			return vs[i]
+		}
+		return vs[i] + (r-float64(i))*(vs[i+1]-vs[i])
+	case q.p == 0:
+		return q.q[0]
+	case q.p == 1:
+		return q.q[4]
+	}
+	return q.q[2]
+}
@@ -0,0 +1,156 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestMoments(t *testing.T) {
+	var (
+		src = rand.New(rand.NewSource(1234))
+		xs  = make([]float64, 10000)
+		ws  = make([]float64, len(xs))
+	)
+	dist := distuv.Gamma{Alpha: 2, Beta: 1, Src: src}
+	for i := range xs {
+		xs[i] = dist.Rand()
+		ws[i] = 0.5 + src.Float64()
+	}
+
+	// two-pass reference values.
+	var sumw, sumw2, sumwx float64
+	for i, x := range xs {
+		sumw += ws[i]
+		sumw2 += ws[i] * ws[i]
+		sumwx += ws[i] * x
+	}
+	mean := sumwx / sumw
+	var m2, m3, m4 float64
+	for i, x := range xs {
+		d := x - mean
+		m2 += ws[i] * d * d
+		m3 += ws[i] * d * d * d
+		m4 += ws[i] * d * d * d * d
+	}
+	m2 /= sumw
+	m3 /= sumw
+	m4 /= sumw
+
+	var m Moments
+	m.FillN(xs, ws)
+
+	var (
+		m1 Moments
+		mm Moments
+	)
+	m1.FillN(xs[:3000], ws[:3000])
+	mm.FillN(xs[3000:], ws[3000:])
+	mm.Merge(&m1)
+
+	for _, tc := range []struct {
+		name string
+		m    *Moments
+	}{
+		{"fill", &m},
+		{"merge", &mm},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.m
+			if got, want := m.Entries(), int64(len(xs)); got != want {
+				t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+			}
+			for _, v := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"sumw", m.SumW(), sumw},
+				{"sumw2", m.SumW2(), sumw2},
+				{"eff-entries", m.EffEntries(), sumw * sumw / sumw2},
+				{"mean", m.Mean(), mean},
+				{"variance", m.Variance(), m2 * sumw * sumw / (sumw*sumw - sumw2)},
+				{"skewness", m.Skewness(), m3 / math.Pow(m2, 1.5)},
+				{"kurtosis", m.Kurtosis(), m4/(m2*m2) - 3},
+				{"min", m.Min(), floats.Min(xs)},
+				{"max", m.Max(), floats.Max(xs)},
+			} {
+				if !scalar.EqualWithinRel(v.got, v.want, 1e-10) {
+					t.Fatalf("invalid %s: got=%v, want=%v", v.name, v.got, v.want)
+				}
+			}
+		})
+	}
+
+	var empty Moments
+	for _, v := range []float64{empty.Mean(), empty.Variance(), empty.Skewness(), empty.Kurtosis(), empty.Min()} {
+		if !math.IsNaN(v) {
+			t.Fatalf("expected NaN for an empty accumulator, got=%v", v)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	t.Run("exact", func(t *testing.T) {
+		q := NewQuantile(0.5)
+		if v := q.Value(); !math.IsNaN(v) {
+			t.Fatalf("expected NaN, got=%v", v)
+		}
+		for _, tc := range []struct {
+			x, want float64
+		}{
+			{3, 3},
+			{1, 2},
+			{2, 2},
+			{5, 2.5},
+			{4, 3},
+		} {
+			q.Fill(tc.x)
+			if got := q.Value(); got != tc.want {
+				t.Fatalf("invalid median after %d entries: got=%v, want=%v", q.Entries(), got, tc.want)
+			}
+		}
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		var (
+			dist = distuv.Normal{Mu: 0, Sigma: 1, Src: rand.New(rand.NewSource(1234))}
+			ps   = []float64{0, 0.05, 0.25, 0.5, 0.75, 0.95, 1}
+			qs   = make([]*Quantile, len(ps))
+			xs   = make([]float64, 100000)
+		)
+		for i, p := range ps {
+			qs[i] = NewQuantile(p)
+		}
+		for i := range xs {
+			xs[i] = dist.Rand()
+			for _, q := range qs {
+				q.Fill(xs[i])
+			}
+		}
+		sort.Float64s(xs)
+		for _, q := range qs {
+			want := xs[int(q.P()*float64(len(xs)-1))]
+			if got := q.Value(); math.Abs(got-want) > 0.01 {
+				t.Fatalf("invalid %v-quantile: got=%v, want=%v", q.P(), got, want)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			if e := recover(); e == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		_ = NewQuantile(1.5)
+	})
+}
@@ -5,8 +5,12 @@
 package hbook // import "go-hep.org/x/hep/hbook"
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -23,6 +27,9 @@ func readYODAHeader(r *rbuffer, hdr string) (string, int, error) {
 		vers int
 	)
 	switch {
+	case strings.HasPrefix(path, hdr+"_V3 "):
+		hdr += "_V3"
+		vers = 3
 	case strings.HasPrefix(path, hdr+"_V2 "):
 		hdr += "_V2"
 		vers = 2
@@ -34,3 +41,258 @@ func readYODAHeader(r *rbuffer, hdr string) (string, int, error) {
 
 	return path[len(hdr)+1 : len(path)-1], vers, nil
 }
+
+// yodaBlock holds the data of a YODA2 block, written with the V3 version
+// of the YODA text format.
+type yodaBlock struct {
+	edges  [][]float64  // bin edges along each axis (Edges(A1), Edges(A2), ...)
+	masked map[int]bool // global indices of the masked bins
+	nerrs  int          // number of error sources (ErrorLabels)
+	rows   [][]float64  // data rows
+}
+
+// readYODAv3 reads the annotations and the data of a V3 YODA block,
+// whose header line has already been consumed.
+func readYODAv3(r *rbuffer, ann Annotation) (yodaBlock, error) {
+	blk := yodaBlock{masked: make(map[int]bool)}
+
+	// pos of end of annotations
+	pos := bytes.Index(r.Bytes(), []byte("\n---\n"))
+	if pos < 0 {
+		return blk, fmt.Errorf("hbook: could not find end of YODA annotations")
+	}
+	err := ann.unmarshalYODAv2(r.Bytes()[:pos+1])
+	if err != nil {
+		return blk, fmt.Errorf("hbook: %q\nhbook: %w", string(r.Bytes()[:pos+1]), err)
+	}
+	r.next(pos + len("\n---\n"))
+
+	s := bufio.NewScanner(r)
+scanLoop:
+	for s.Scan() {
+		txt := strings.TrimSpace(s.Text())
+		switch {
+		case txt == "" || strings.HasPrefix(txt, "#"):
+			continue
+		case strings.HasPrefix(txt, "END YODA_"):
+			break scanLoop
+		case strings.HasPrefix(txt, "Edges(A"):
+			var iax int
+			_, err = fmt.Sscanf(txt, "Edges(A%d):", &iax)
+			if err != nil || iax != len(blk.edges)+1 {
+				return blk, fmt.Errorf("hbook: invalid YODA edges %q", txt)
+			}
+			edges, err := yodaFloats(yodaList(txt))
+			if err != nil {
+				return blk, fmt.Errorf("hbook: invalid YODA edges %q: %w", txt, err)
+			}
+			blk.edges = append(blk.edges, edges)
+		case strings.HasPrefix(txt, "MaskedBins:"):
+			for _, v := range yodaList(txt) {
+				i, err := strconv.Atoi(v)
+				if err != nil {
+					return blk, fmt.Errorf("hbook: invalid YODA masked bins %q: %w", txt, err)
+				}
+				blk.masked[i] = true
+			}
+		case strings.HasPrefix(txt, "ErrorLabels:"):
+			blk.nerrs = len(yodaList(txt))
+		default:
+			row, err := yodaFloats(strings.Fields(txt))
+			if err != nil {
+				return blk, fmt.Errorf("hbook: invalid YODA data %q: %w", txt, err)
+			}
+			blk.rows = append(blk.rows, row)
+		}
+	}
+	return blk, s.Err()
+}
+
+// check checks the block has the expected number of axes and rows,
+// and the expected number of columns per row.
+func (blk *yodaBlock) check(naxes, ncols int) error {
+	if len(blk.edges) != naxes {
+		return fmt.Errorf("hbook: invalid YODA data: got %d axes, want %d", len(blk.edges), naxes)
+	}
+	nrows := 1
+	for _, edges := range blk.edges {
+		if len(edges) < 2 {
+			return fmt.Errorf("hbook: invalid YODA data: axis with no bin")
+		}
+		nrows *= len(edges) + 1
+	}
+	if len(blk.rows) != nrows {
+		return fmt.Errorf("hbook: invalid YODA data: got %d bins, want %d", len(blk.rows), nrows)
+	}
+	for _, row := range blk.rows {
+		if len(row) != ncols {
+			return fmt.Errorf("hbook: invalid YODA data: got %d columns, want %d", len(row), ncols)
+		}
+	}
+	return nil
+}
+
+// yodaList returns the elements of the list at the end of the provided
+// line, as in "Edges(A1): [0, 1, 2]".
+func yodaList(txt string) []string {
+	beg := strings.Index(txt, "[")
+	end := strings.LastIndex(txt, "]")
+	if beg < 0 || end < beg {
+		return nil
+	}
+	txt = strings.TrimSpace(txt[beg+1 : end])
+	if txt == "" {
+		return nil
+	}
+	vs := strings.Split(txt, ",")
+	for i, v := range vs {
+		vs[i] = strings.TrimSpace(v)
+	}
+	return vs
+}
+
+// yodaFloats parses the provided values.
+// Missing values ("---") are returned as NaN.
+func yodaFloats(vs []string) ([]float64, error) {
+	o := make([]float64, len(vs))
+	for i, v := range vs {
+		if v == "---" {
+			o[i] = math.NaN()
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		o[i] = f
+	}
+	return o, nil
+}
+
+// writeYODAEdges writes the edges of the iax-th axis of a V3 YODA block.
+func writeYODAEdges(w io.Writer, iax int, edges []float64) {
+	fmt.Fprintf(w, "Edges(A%d): [", iax)
+	for i, v := range edges {
+		if i > 0 {
+			fmt.Fprintf(w, ", ")
+		}
+		fmt.Fprintf(w, "%e", v)
+	}
+	fmt.Fprintf(w, "]\n")
+}
+
+// writeYODAMasked writes the global indices of the masked bins of a V3
+// YODA block, if any.
+func writeYODAMasked(w io.Writer, masked []int) {
+	if len(masked) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "MaskedBins: [")
+	for i, v := range masked {
+		if i > 0 {
+			fmt.Fprintf(w, ", ")
+		}
+		fmt.Fprintf(w, "%d", v)
+	}
+	fmt.Fprintf(w, "]\n")
+}
+
+// writeYODARow writes a row of values of a V3 YODA block.
+func writeYODARow(w io.Writer, vs ...float64) {
+	for i, v := range vs {
+		if i > 0 {
+			fmt.Fprintf(w, "\t")
+		}
+		fmt.Fprintf(w, "%e", v)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+// yodaEdges returns the edges of the provided contiguous ranges,
+// with the indices of the ranges inserted to fill the gaps between them.
+func yodaEdges(rngs []Range) (edges []float64, gaps []int) {
+	edges = make([]float64, 0, len(rngs)+1)
+	for i, rng := range rngs {
+		if i > 0 && rngs[i-1].Max != rng.Min {
+			gaps = append(gaps, len(edges))
+			edges = append(edges, rng.Min)
+		}
+		if i == 0 {
+			edges = append(edges, rng.Min)
+		}
+		edges = append(edges, rng.Max)
+	}
+	return edges, gaps
+}
+
+// yodaOutflow returns the position of the i-th bin of an axis with n
+// in-range bins, with respect to the range of that axis: -1 for the
+// underflow bin (i=0), +1 for the overflow bin (i=n+1) and 0 otherwise.
+func yodaOutflow(i, n int) int {
+	switch i {
+	case 0:
+		return -1
+	case n + 1:
+		return +1
+	}
+	return 0
+}
+
+// yodaOutflowBin returns the index of the bin of an axis with n in-range
+// bins, where the content of an outflow region at position o is stored.
+//
+// YODA2 stores outflows bin by bin, whereas hbook only keeps one
+// distribution per outflow region: the content of a region is stored in
+// the first bin of that region.
+func yodaOutflowBin(o, n int) int {
+	switch o {
+	case -1:
+		return 0
+	case +1:
+		return n + 1
+	}
+	return 1
+}
+
+func yodaDist1D(row []float64) Dist1D {
+	var d Dist1D
+	d.Dist.SumW = row[0]
+	d.Dist.SumW2 = row[1]
+	d.Stats.SumWX = row[2]
+	d.Stats.SumWX2 = row[3]
+	d.Dist.N = int64(row[4])
+	return d
+}
+
+func yodaDist2D(row []float64) Dist2D {
+	var d Dist2D
+	d.X.Dist.SumW = row[0]
+	d.X.Dist.SumW2 = row[1]
+	d.X.Stats.SumWX = row[2]
+	d.X.Stats.SumWX2 = row[3]
+	d.Y.Stats.SumWX = row[4]
+	d.Y.Stats.SumWX2 = row[5]
+	d.Stats.SumWXY = row[6]
+	d.X.Dist.N = int64(row[7])
+	d.Y.Dist = d.X.Dist
+	return d
+}
+
+func yodaDist3D(row []float64) Dist3D {
+	var d Dist3D
+	d.X.Dist.SumW = row[0]
+	d.X.Dist.SumW2 = row[1]
+	d.X.Stats.SumWX = row[2]
+	d.X.Stats.SumWX2 = row[3]
+	d.Y.Stats.SumWX = row[4]
+	d.Y.Stats.SumWX2 = row[5]
+	d.Z.Stats.SumWX = row[6]
+	d.Z.Stats.SumWX2 = row[7]
+	d.Stats.SumWXY = row[8]
+	d.Stats.SumWXZ = row[9]
+	d.Stats.SumWYZ = row[10]
+	d.X.Dist.N = int64(row[11])
+	d.Y.Dist = d.X.Dist
+	d.Z.Dist = d.X.Dist
+	return d
+}
@@ -5,8 +5,12 @@
 package hbook
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestReadYODAHeader(t *testing.T) {
@@ -37,6 +41,11 @@ func TestReadYODAHeader(t *testing.T) {
 			want: "/name with whitespace",
 			vers: 2,
 		},
+		{
+			str:  "BEGIN YODA_HISTO1D_V3 /name\n",
+			want: "/name",
+			vers: 3,
+		},
 		{
 			str:  "BEGIN YODA /name",
 			want: "",
@@ -85,3 +94,106 @@ func TestReadYODAHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestYODAv3(t *testing.T) {
+	h1 := NewH1DFromBins([]Range{
+		{Min: -10, Max: -5}, {Min: -5, Max: 0}, {Min: 0, Max: 4} /*GAP*/, {Min: 5, Max: 10},
+	}...)
+	h1.Ann["name"] = "h1"
+	h1.Ann["title"] = "my title"
+	for _, x := range []float64{-12, -7, -1, -1, 2, 6, 11} {
+		h1.Fill(x, 2)
+	}
+
+	h2 := NewH2DFromEdges([]float64{0, 1, 3}, []float64{-1, 0, 1})
+	h2.Ann["name"] = "h2"
+	for _, v := range [][2]float64{{0.5, -0.5}, {2, 0.5}, {-1, 0.5}, {4, 4}, {0.5, -2}} {
+		h2.Fill(v[0], v[1], 1)
+	}
+
+	h3 := NewH3D(2, 0, 2, 1, 0, 1, 2, 0, 2)
+	h3.Ann["name"] = "h3"
+	for _, v := range [][3]float64{{0.5, 0.5, 0.5}, {1.5, 0.5, 1.5}, {-1, 0.5, 0.5}, {3, 3, -1}} {
+		h3.Fill(v[0], v[1], v[2], 1)
+	}
+
+	p1 := NewP1D(4, 0, 4)
+	p1.ann["name"] = "p1"
+	for _, v := range [][2]float64{{-1, 2}, {0.5, 1}, {1.5, 2}, {3.5, 4}, {5, 1}} {
+		p1.Fill(v[0], v[1], 1)
+	}
+
+	p2 := NewP2D(2, 0, 2, 1, 0, 1)
+	p2.Ann["name"] = "p2"
+	for _, v := range [][3]float64{{0.5, 0.5, 2}, {1.5, 0.5, 1}, {-1, 0.5, 3}, {3, 3, 4}} {
+		p2.Fill(v[0], v[1], v[2], 1)
+	}
+
+	s2 := NewS2D(Point2D{X: 1, Y: 2, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 1, Max: 2}})
+	s2.ann["name"] = "s2"
+
+	for _, tc := range []struct {
+		name string
+		h    interface {
+			marshalYODAv2() ([]byte, error)
+			marshalYODAv3() ([]byte, error)
+		}
+		v    interface{ UnmarshalYODA([]byte) error }
+		want string
+	}{
+		{"h1d", h1, new(H1D), "testdata/h1d_v3_golden.yoda"},
+		{"h2d", h2, new(H2D), ""},
+		{"h3d", h3, new(H3D), ""},
+		{"p1d", p1, new(P1D), ""},
+		{"p2d", p2, new(P2D), ""},
+		{"s2d", s2, new(S2D), ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := tc.h.marshalYODAv3()
+			if err != nil {
+				t.Fatalf("could not marshal to YODA2: %+v", err)
+			}
+
+			if tc.want != "" {
+				want, err := os.ReadFile(tc.want)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(raw, want) {
+					t.Fatalf("invalid YODA2 output:\n%s", cmp.Diff(string(want), string(raw)))
+				}
+			}
+
+			err = tc.v.UnmarshalYODA(raw)
+			if err != nil {
+				t.Fatalf("could not unmarshal from YODA2: %+v", err)
+			}
+
+			for _, v := range []struct {
+				name string
+				f    func() ([]byte, error)
+			}{
+				{"v2", tc.v.(interface{ marshalYODAv2() ([]byte, error) }).marshalYODAv2},
+				{"v3", tc.v.(interface{ marshalYODAv3() ([]byte, error) }).marshalYODAv3},
+			} {
+				got, err := v.f()
+				if err != nil {
+					t.Fatalf("could not marshal round-tripped value: %+v", err)
+				}
+				var want []byte
+				switch v.name {
+				case "v2":
+					want, err = tc.h.marshalYODAv2()
+				case "v3":
+					want = raw
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Fatalf("round-trip error (%s):\n%s", v.name, cmp.Diff(string(want), string(got)))
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package yodacnv_test
 
 import (
 	"bytes"
+	"math"
 	"reflect"
 	"testing"
 
@@ -41,6 +42,162 @@ func TestReadWrite(t *testing.T) {
 	}
 }
 
+func TestReadWrite2(t *testing.T) {
+	objs, err := yodacnv.Read(bytes.NewReader(rdata))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := new(bytes.Buffer)
+	for _, v := range objs {
+		err = yodacnv.Write2(w, v.(yodacnv.Marshaler2))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Contains(w.Bytes(), []byte("BEGIN YODA_HISTO1D_V3 /histo-1d\n")) {
+		t.Fatalf("missing YODA2 header:\n%s", w.String())
+	}
+
+	objs, err = yodacnv.Read(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w = new(bytes.Buffer)
+	for _, v := range objs {
+		err = yodacnv.Write(w, v.(yodacnv.Marshaler))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !reflect.DeepEqual(w.Bytes(), rdata) {
+		t.Fatalf("got:\n%s\nwant:\n%s\n", w.String(), string(rdata))
+	}
+}
+
+func TestReadYODA2(t *testing.T) {
+	r := bytes.NewReader([]byte(`BEGIN YODA_COUNTER_V3 /_EVTCOUNT
+Path: /_EVTCOUNT
+Title: ~
+Type: Counter
+---
+# sumW       	sumW2        	numEntries
+3.000000e+00 	3.000000e+00 	3.000000e+00
+END YODA_COUNTER_V3
+
+BEGIN YODA_HISTO1D_V3 /ANALYSIS/h1
+Path: /ANALYSIS/h1
+Title: my title
+Type: Histo1D
+---
+# Mean: 1.000000e+00
+# Integral: 4.000000e+00
+Edges(A1): [0.000000e+00, 1.000000e+00, 2.000000e+00, 3.000000e+00]
+MaskedBins: [2]
+# sumW       	sumW2        	sumW(A1)     	sumW2(A1)    	numEntries
+1.000000e+00 	1.000000e+00 	-1.000000e+00	1.000000e+00 	1.000000e+00
+1.000000e+00 	1.000000e+00 	5.000000e-01 	2.500000e-01 	1.000000e+00
+0.000000e+00 	0.000000e+00 	0.000000e+00 	0.000000e+00 	0.000000e+00
+2.000000e+00 	4.000000e+00 	5.000000e+00 	1.250000e+01 	1.000000e+00
+0.000000e+00 	0.000000e+00 	0.000000e+00 	0.000000e+00 	0.000000e+00
+END YODA_HISTO1D_V3
+
+BEGIN YODA_ESTIMATE1D_V3 /REF/ANALYSIS/d01-x01-y01
+Path: /REF/ANALYSIS/d01-x01-y01
+Title: ~
+Type: Estimate1D
+---
+Edges(A1): [0.000000e+00, 2.000000e+00, 4.000000e+00]
+ErrorLabels: ["stat", "syst"]
+# value      	errDn(1)     	errUp(1)     	errDn(2)     	errUp(2)
+nan          	---          	---          	---          	---
+1.000000e+01 	-3.000000e+00	3.000000e+00 	-4.000000e+00	1.000000e+00
+2.000000e+01 	-1.000000e+00	2.000000e+00 	---          	---
+nan          	---          	---          	---          	---
+END YODA_ESTIMATE1D_V3
+`))
+
+	objs, err := yodacnv.Read(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(objs), 2; got != want {
+		t.Fatalf("got %d values. want %d", got, want)
+	}
+
+	h := objs[0].(*hbook.H1D)
+	if got, want := h.Name(), "ANALYSIS/h1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := len(h.Binning.Bins), 2; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	if got, want := h.Binning.Bins[1].XMin(), 2.0; got != want {
+		t.Fatalf("invalid bin low edge: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumW(), 4.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Binning.Underflow().SumW(), 1.0; got != want {
+		t.Fatalf("invalid underflow: got=%v, want=%v", got, want)
+	}
+
+	s := objs[1].(*hbook.S2D)
+	if got, want := s.Len(), 2; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+	for i, want := range []hbook.Point2D{
+		{X: 1, Y: 10, ErrX: hbook.Range{Min: 1, Max: 1}, ErrY: hbook.Range{Min: 5, Max: math.Sqrt(10)}},
+		{X: 3, Y: 20, ErrX: hbook.Range{Min: 1, Max: 1}, ErrY: hbook.Range{Min: 1, Max: 2}},
+	} {
+		if got := s.Point(i); got != want {
+			t.Fatalf("invalid point %d: got=%+v, want=%+v", i, got, want)
+		}
+	}
+}
+
+func TestReadProfile2D(t *testing.T) {
+	p := hbook.NewP2D(2, 0, 2, 2, 0, 2)
+	p.Annotation()["name"] = "p2d"
+	p.Fill(0.5, 0.5, 1, 1)
+	p.Fill(1.5, 0.5, 2, 1)
+	p.Fill(1.5, 0.5, 4, 1)
+
+	for _, tc := range []struct {
+		name  string
+		write func(w *bytes.Buffer) error
+	}{
+		{"yoda", func(w *bytes.Buffer) error { return yodacnv.Write(w, p) }},
+		{"yoda2", func(w *bytes.Buffer) error { return yodacnv.Write2(w, p) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := tc.write(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			objs, err := yodacnv.Read(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(objs), 1; got != want {
+				t.Fatalf("got %d values. want %d", got, want)
+			}
+
+			got := objs[0].(*hbook.P2D)
+			if got, want := got.Name(), "p2d"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := got.Bin(1.5, 0.5).ZMean(), 3.0; got != want {
+				t.Fatalf("invalid bin mean: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
 func TestReadCounter(t *testing.T) {
 	r := bytes.NewReader([]byte(`BEGIN YODA_COUNTER /_EVTCOUNT
 Path=/_EVTCOUNT
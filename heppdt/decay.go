@@ -0,0 +1,158 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heppdt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Decay describes a decay channel of a particle.
+type Decay struct {
+	BR       float64 // branching ratio
+	Products []PID   // decay products
+}
+
+// LoadDecays loads the decay channels described in r into the table.
+//
+// The decay channels of a particle are described in a block of lines
+// starting with the particle, followed by one line per decay channel with
+// its branching ratio and its decay products, and ending with 'Enddecay'.
+// Particles are given by name or by PDG code:
+//
+//  # D0 decays
+//  Decay D^0
+//    0.0389  K^- pi^+
+//    0.139   -321 211 111
+//  Enddecay
+//
+// Lines starting with '#' or '//' are ignored.
+// Decay channels of a particle replace the ones previously loaded.
+func (t *Table) LoadDecays(r io.Reader) error {
+	if t.decays == nil {
+		t.decays = make(map[PID][]Decay)
+	}
+
+	var (
+		s      = bufio.NewScanner(r)
+		lineno = 0
+		parent PID
+		decays []Decay
+		inside = false
+	)
+	for s.Scan() {
+		lineno++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		toks := strings.Fields(line)
+		switch {
+		case toks[0] == "Decay":
+			if inside {
+				return fmt.Errorf("heppdt: line:%d: missing 'Enddecay' for particle %d", lineno, parent)
+			}
+			if len(toks) != 2 {
+				return fmt.Errorf("heppdt: malformed line:%d: %v", lineno, line)
+			}
+			pid, err := t.pidOf(toks[1])
+			if err != nil {
+				return fmt.Errorf("heppdt: line:%d: %w", lineno, err)
+			}
+			parent = pid
+			decays = nil
+			inside = true
+
+		case toks[0] == "Enddecay":
+			if !inside {
+				return fmt.Errorf("heppdt: line:%d: unexpected 'Enddecay'", lineno)
+			}
+			t.decays[parent] = decays
+			inside = false
+
+		default:
+			if !inside {
+				return fmt.Errorf("heppdt: line:%d: decay channel outside of a 'Decay' block", lineno)
+			}
+			if len(toks) < 2 {
+				return fmt.Errorf("heppdt: malformed line:%d: %v", lineno, line)
+			}
+			br, err := strconv.ParseFloat(toks[0], 64)
+			if err != nil {
+				return fmt.Errorf("heppdt: line:%d: %w", lineno, err)
+			}
+			if br < 0 {
+				return fmt.Errorf("heppdt: line:%d: negative branching ratio %v", lineno, br)
+			}
+			dcy := Decay{BR: br, Products: make([]PID, 0, len(toks)-1)}
+			for _, tok := range toks[1:] {
+				pid, err := t.pidOf(tok)
+				if err != nil {
+					return fmt.Errorf("heppdt: line:%d: %w", lineno, err)
+				}
+				dcy.Products = append(dcy.Products, pid)
+			}
+			decays = append(decays, dcy)
+		}
+	}
+
+	err := s.Err()
+	if err != nil {
+		return fmt.Errorf("heppdt: could not read decays: %w", err)
+	}
+
+	if inside {
+		return fmt.Errorf("heppdt: missing 'Enddecay' for particle %d", parent)
+	}
+
+	return nil
+}
+
+// Decays returns the decay channels of the particle with the provided ID.
+// If no decay channel was loaded for that particle, the charge-conjugated
+// decay channels of its antiparticle are returned, if any.
+func (t *Table) Decays(pid PID) []Decay {
+	if decays, ok := t.decays[pid]; ok {
+		return decays
+	}
+
+	decays, ok := t.decays[-pid]
+	if !ok || t.ParticleByID(-pid) == nil {
+		return nil
+	}
+
+	ccs := make([]Decay, len(decays))
+	for i, dcy := range decays {
+		ccs[i] = Decay{BR: dcy.BR, Products: make([]PID, len(dcy.Products))}
+		for j, p := range dcy.Products {
+			if t.ParticleByID(-p) != nil {
+				p = -p
+			}
+			ccs[i].Products[j] = p
+		}
+	}
+	return ccs
+}
+
+// pidOf returns the particle ID corresponding to the provided PDG code or
+// particle name.
+func (t *Table) pidOf(s string) (PID, error) {
+	if id, err := strconv.Atoi(s); err == nil {
+		pid := PID(id)
+		if t.ParticleByID(pid) == nil {
+			return 0, fmt.Errorf("unknown particle ID %d", id)
+		}
+		return pid, nil
+	}
+
+	pid, ok := t.pid[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown particle %q", s)
+	}
+	return pid, nil
+}
@@ -0,0 +1,207 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heppdt
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"golang.org/x/exp/rand"
+)
+
+const decays = `
+# D0 decays
+Decay D^0
+  0.0389  K^- pi^+
+  0.139   -321 211 111
+Enddecay
+
+// pi0 decays
+Decay pi^0
+  0.988 gamma gamma
+Enddecay
+`
+
+func newDecayTable(t *testing.T) *Table {
+	t.Helper()
+	tbl, err := New(bytes.NewBufferString(tabledata), "particle.tbl")
+	if err != nil {
+		t.Fatalf("could not load particle table: %+v", err)
+	}
+	err = tbl.LoadDecays(strings.NewReader(decays))
+	if err != nil {
+		t.Fatalf("could not load decays: %+v", err)
+	}
+	return &tbl
+}
+
+func TestLoadDecays(t *testing.T) {
+	tbl := newDecayTable(t)
+
+	for _, tc := range []struct {
+		pid  PID
+		want []Decay
+	}{
+		{
+			pid: 421,
+			want: []Decay{
+				{BR: 0.0389, Products: []PID{-321, 211}},
+				{BR: 0.139, Products: []PID{-321, 211, 111}},
+			},
+		},
+		{
+			pid: -421,
+			want: []Decay{
+				{BR: 0.0389, Products: []PID{321, -211}},
+				{BR: 0.139, Products: []PID{321, -211, 111}},
+			},
+		},
+		{
+			pid:  111,
+			want: []Decay{{BR: 0.988, Products: []PID{22, 22}}},
+		},
+		{
+			pid:  211,
+			want: nil,
+		},
+	} {
+		got := tbl.Decays(tc.pid)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid decays for pid=%d:\ngot= %v\nwant=%v", tc.pid, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		txt  string
+	}{
+		{"missing-enddecay", "Decay D^0\n 1 K^- pi^+\n"},
+		{"nested-decay", "Decay D^0\nDecay pi^0\nEnddecay\n"},
+		{"unexpected-enddecay", "Enddecay\n"},
+		{"outside-block", "1 K^- pi^+\n"},
+		{"unknown-parent", "Decay not-a-particle\nEnddecay\n"},
+		{"unknown-product", "Decay D^0\n 1 K^- 999999999\nEnddecay\n"},
+		{"invalid-br", "Decay D^0\n xx K^- pi^+\nEnddecay\n"},
+		{"negative-br", "Decay D^0\n -1 K^- pi^+\nEnddecay\n"},
+		{"no-product", "Decay D^0\n 1\nEnddecay\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tbl.LoadDecays(strings.NewReader(tc.txt))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestDecayGen(t *testing.T) {
+	tbl := newDecayTable(t)
+	gen := NewDecayGen(tbl, rand.NewSource(1234))
+
+	const (
+		nevts = 10000
+		eps   = 1e-9
+	)
+
+	var (
+		mD0    = tbl.ParticleByID(421).Mass
+		parent = fmom.NewPxPyPzE(1, -2, 30, math.Sqrt(1+4+900+mD0*mD0))
+		counts = make([]int, 2)
+	)
+
+	for i := 0; i < nevts; i++ {
+		p4 := parent
+		if i%2 == 0 {
+			p4 = fmom.NewPxPyPzE(0, 0, 0, mD0)
+		}
+		dcy, err := gen.Decay(421, p4)
+		if err != nil {
+			t.Fatalf("could not generate decay %d: %+v", i, err)
+		}
+		counts[dcy.Channel]++
+
+		var sum fmom.PxPyPzE
+		for _, p := range dcy.FinalState() {
+			if got, want := p.P4.M(), tbl.ParticleByID(p.ID).Mass; math.Abs(got-want) > 1e-6 {
+				t.Fatalf("invalid mass for pid=%d: got=%v, want=%v", p.ID, got, want)
+			}
+			sum = *fmom.IAdd(&sum, &p.P4).(*fmom.PxPyPzE)
+		}
+		for _, v := range []struct {
+			got, want float64
+		}{
+			{sum.Px(), p4.Px()},
+			{sum.Py(), p4.Py()},
+			{sum.Pz(), p4.Pz()},
+			{sum.E(), p4.E()},
+		} {
+			if math.Abs(v.got-v.want) > eps {
+				t.Fatalf("four-momentum not conserved (evt=%d): got=%v, want=%v", i, sum, p4)
+			}
+		}
+
+		switch dcy.Channel {
+		case 0:
+			if got, want := len(dcy.FinalState()), 2; got != want {
+				t.Fatalf("invalid number of final state particles: got=%d, want=%d", got, want)
+			}
+		case 1:
+			pi0 := dcy.Daughters[2]
+			if got, want := pi0.ID, PID(111); got != want {
+				t.Fatalf("invalid pid: got=%d, want=%d", got, want)
+			}
+			if got, want := len(pi0.Daughters), 2; got != want {
+				t.Fatalf("pi0 did not decay")
+			}
+			if got, want := len(dcy.FinalState()), 4; got != want {
+				t.Fatalf("invalid number of final state particles: got=%d, want=%d", got, want)
+			}
+		}
+	}
+
+	var (
+		got  = float64(counts[0]) / nevts
+		want = 0.0389 / (0.0389 + 0.139)
+	)
+	if math.Abs(got-want) > 0.01 {
+		t.Fatalf("invalid channel fraction: got=%v, want=%v", got, want)
+	}
+
+	// D0 -> K- pi+ at rest: back-to-back with fixed momentum.
+	for i := 0; i < 10; i++ {
+		dcy, err := gen.DecayAtRest(421)
+		if err != nil {
+			t.Fatalf("could not generate decay: %+v", err)
+		}
+		if dcy.Channel != 0 {
+			continue
+		}
+		var (
+			k  = dcy.Daughters[0].P4
+			pi = dcy.Daughters[1].P4
+			pk = pdk(mD0, tbl.ParticleByID(-321).Mass, tbl.ParticleByID(211).Mass)
+		)
+		if math.Abs(k.P()-pk) > eps || math.Abs(pi.P()-pk) > eps {
+			t.Fatalf("invalid two-body momenta: got=(%v, %v), want=%v", k.P(), pi.P(), pk)
+		}
+	}
+
+	_, err := gen.Decay(421, fmom.NewPxPyPzE(0, 0, 0, 0.5))
+	if err == nil {
+		t.Fatalf("expected an error for a kinematically forbidden decay")
+	}
+
+	dcy, err := gen.DecayAtRest(211)
+	if err != nil {
+		t.Fatalf("could not generate decay: %+v", err)
+	}
+	if dcy.Channel != -1 || len(dcy.Daughters) != 0 {
+		t.Fatalf("unexpected decay of pi+: %+v", dcy)
+	}
+}
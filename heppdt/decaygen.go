@@ -0,0 +1,277 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heppdt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/fmom"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// DecayProduct is a particle generated by a DecayGen, with its
+// four-momentum and the products of its decay, if any.
+type DecayProduct struct {
+	ID        PID            // particle ID
+	P4        fmom.PxPyPzE   // four-momentum in GeV
+	Channel   int            // index of the decay channel, -1 if the particle did not decay
+	Daughters []DecayProduct // decay products
+}
+
+// FinalState returns the particles of the decay chain that did not decay.
+func (p *DecayProduct) FinalState() []DecayProduct {
+	if len(p.Daughters) == 0 {
+		return []DecayProduct{*p}
+	}
+	var o []DecayProduct
+	for i := range p.Daughters {
+		o = append(o, p.Daughters[i].FinalState()...)
+	}
+	return o
+}
+
+// DecayGen generates decay chains from the decay channels of a particle
+// data table.
+//
+// Decay channels are sampled from their branching ratios, among the
+// channels that are kinematically allowed.
+// The momenta of the decay products are sampled uniformly in the n-body
+// phase space, with the GENBOD (Raubold-Lynch) algorithm.
+// Matrix elements and spin correlations are not taken into account.
+//
+// Decay products are decayed recursively, unless they have no decay
+// channel in the table.
+type DecayGen struct {
+	tbl *Table
+	rnd func() float64
+}
+
+// NewDecayGen creates a new decay generator from the decay channels of
+// the provided table.
+// If tbl is nil, the default particle data table is used.
+// If src is nil, the global x/exp/rand source will be used.
+func NewDecayGen(tbl *Table, src rand.Source) *DecayGen {
+	if tbl == nil {
+		tbl = &defaultTable
+	}
+	gen := &DecayGen{
+		tbl: tbl,
+		rnd: rand.Float64,
+	}
+	if src != nil {
+		gen.rnd = rand.New(src).Float64
+	}
+	return gen
+}
+
+// maxDecayDepth is the maximum depth of generated decay chains.
+const maxDecayDepth = 64
+
+// Decay generates the decay chain of the particle with the provided ID
+// and four-momentum.
+// The mass of the decaying particle is taken from its four-momentum.
+func (gen *DecayGen) Decay(pid PID, p4 fmom.PxPyPzE) (DecayProduct, error) {
+	return gen.decay(pid, p4, 0)
+}
+
+// DecayAtRest generates the decay chain of the particle with the provided
+// ID, at rest and with its nominal mass.
+func (gen *DecayGen) DecayAtRest(pid PID) (DecayProduct, error) {
+	p := gen.tbl.ParticleByID(pid)
+	if p == nil {
+		return DecayProduct{}, fmt.Errorf("heppdt: unknown particle ID %d", pid)
+	}
+	return gen.Decay(pid, fmom.NewPxPyPzE(0, 0, 0, p.Mass))
+}
+
+func (gen *DecayGen) decay(pid PID, p4 fmom.PxPyPzE, depth int) (DecayProduct, error) {
+	out := DecayProduct{ID: pid, P4: p4, Channel: -1}
+	if depth > maxDecayDepth {
+		return out, fmt.Errorf("heppdt: decay chain too deep (pid=%d)", pid)
+	}
+
+	p := gen.tbl.ParticleByID(pid)
+	if p == nil {
+		return out, fmt.Errorf("heppdt: unknown particle ID %d", pid)
+	}
+	decays := gen.tbl.Decays(pid)
+	if len(decays) == 0 {
+		return out, nil
+	}
+
+	mass := p4.M()
+	ichan, masses, err := gen.channel(pid, mass, decays)
+	if err != nil {
+		return out, err
+	}
+
+	p4s := gen.phaseSpace(p4, masses)
+	out.Channel = ichan
+	out.Daughters = make([]DecayProduct, len(p4s))
+	for i, pid := range decays[ichan].Products {
+		out.Daughters[i], err = gen.decay(pid, p4s[i], depth+1)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// channel samples a kinematically allowed decay channel, and returns its
+// index and the masses of its decay products.
+func (gen *DecayGen) channel(pid PID, mass float64, decays []Decay) (int, []float64, error) {
+	var (
+		cdf    = make([]float64, len(decays))
+		sum    = 0.0
+		masses = make([][]float64, len(decays))
+	)
+	for i, dcy := range decays {
+		var (
+			ms   = make([]float64, len(dcy.Products))
+			msum = 0.0
+		)
+		for j, pid := range dcy.Products {
+			p := gen.tbl.ParticleByID(pid)
+			if p == nil {
+				return -1, nil, fmt.Errorf("heppdt: unknown particle ID %d", pid)
+			}
+			ms[j] = p.Mass
+			msum += p.Mass
+		}
+		masses[i] = ms
+		if len(ms) >= 2 && msum < mass {
+			sum += dcy.BR
+		}
+		cdf[i] = sum
+	}
+
+	if sum <= 0 {
+		return -1, nil, fmt.Errorf("heppdt: no allowed decay channel for particle %d (m=%v GeV)", pid, mass)
+	}
+
+	v := gen.rnd() * sum
+	i := sort.Search(len(cdf), func(i int) bool { return cdf[i] > v })
+	if i == len(cdf) {
+		// protect against rounding errors.
+		i = sort.SearchFloat64s(cdf, sum)
+	}
+	return i, masses[i], nil
+}
+
+// phaseSpace returns the four-momenta of particles with the provided
+// masses, sampled uniformly in the phase space of the decay of a particle
+// with four-momentum p4.
+// phaseSpace implements the GENBOD algorithm (F. James, CERN 68-15).
+func (gen *DecayGen) phaseSpace(p4 fmom.PxPyPzE, masses []float64) []fmom.PxPyPzE {
+	var (
+		n    = len(masses)
+		tecm = p4.M()
+		msum = 0.0
+	)
+	for _, m := range masses {
+		msum += m
+	}
+	tecm -= msum
+
+	// maximum weight of a phase space configuration.
+	wmax := 1.0
+	{
+		var (
+			emin = 0.0
+			emax = tecm + masses[0]
+		)
+		for i := 1; i < n; i++ {
+			emin += masses[i-1]
+			emax += masses[i]
+			wmax *= pdk(emax, emin, masses[i])
+		}
+	}
+
+	var (
+		rnos = make([]float64, n)
+		invm = make([]float64, n)
+		pds  = make([]float64, n-1)
+	)
+	for {
+		rnos[0] = 0
+		rnos[n-1] = 1
+		for i := 1; i < n-1; i++ {
+			rnos[i] = gen.rnd()
+		}
+		sort.Float64s(rnos[1 : n-1])
+
+		sum := 0.0
+		for i := range invm {
+			sum += masses[i]
+			invm[i] = rnos[i]*tecm + sum
+		}
+
+		w := 1.0
+		for i := range pds {
+			pds[i] = pdk(invm[i+1], invm[i], masses[i+1])
+			w *= pds[i]
+		}
+		if gen.rnd()*wmax <= w {
+			break
+		}
+	}
+
+	// build the momenta of the decay products, recursively from the rest
+	// frame of the first two products.
+	p4s := make([]fmom.PxPyPzE, n)
+	p4s[0] = gen.along(pds[0], masses[0])
+	p4s[1] = fmom.NewPxPyPzE(-p4s[0].Px(), -p4s[0].Py(), -p4s[0].Pz(), math.Hypot(pds[0], masses[1]))
+	for i := 2; i < n; i++ {
+		var (
+			pi  = gen.along(pds[i-1], masses[i])
+			esb = math.Hypot(pds[i-1], invm[i-1]) // energy of the (0..i-1) sub-system
+			vec = r3.Scale(-1/esb, fmom.VecOf(&pi))
+		)
+		for j := 0; j < i; j++ {
+			p4s[j] = boost(p4s[j], vec)
+		}
+		p4s[i] = pi
+	}
+
+	vec := fmom.BoostOf(&p4)
+	for i := range p4s {
+		p4s[i] = boost(p4s[i], vec)
+	}
+	return p4s
+}
+
+// along returns the four-momentum of a particle of mass m and momentum p,
+// in an isotropic random direction.
+func (gen *DecayGen) along(p, m float64) fmom.PxPyPzE {
+	var (
+		cost = 2*gen.rnd() - 1
+		sint = math.Sqrt(1 - cost*cost)
+		phi  = 2 * math.Pi * gen.rnd()
+	)
+	return fmom.NewPxPyPzE(
+		p*sint*math.Cos(phi),
+		p*sint*math.Sin(phi),
+		p*cost,
+		math.Hypot(p, m),
+	)
+}
+
+func boost(p4 fmom.PxPyPzE, vec r3.Vec) fmom.PxPyPzE {
+	return *fmom.Boost(&p4, vec).(*fmom.PxPyPzE)
+}
+
+// pdk returns the momentum of the decay products of a two-body decay of
+// a particle with mass a into particles with masses b and c.
+func pdk(a, b, c float64) float64 {
+	x := (a - b - c) * (a + b + c) * (a - b + c) * (a + b - c)
+	if x <= 0 {
+		return 0
+	}
+	return math.Sqrt(x) / (2 * a)
+}
@@ -5,6 +5,8 @@
 // Package heppdt provides access to the HEP Particle Data Table.
 package heppdt // import "go-hep.org/x/hep/heppdt"
 
+import "io"
+
 // Name returns the name of the default particle data table
 func Name() string {
 	return defaultTable.Name()
@@ -29,3 +31,15 @@ func ParticleByID(pid PID) *Particle {
 func ParticleByName(n string) *Particle {
 	return defaultTable.ParticleByName(n)
 }
+
+// LoadDecays loads the decay channels described in r into the default
+// particle data table
+func LoadDecays(r io.Reader) error {
+	return defaultTable.LoadDecays(r)
+}
+
+// Decays returns the decay channels of a particle from the default
+// particle data table
+func Decays(pid PID) []Decay {
+	return defaultTable.Decays(pid)
+}
@@ -16,6 +16,8 @@ type Table struct {
 	name string
 	pdt  map[PID]*Particle
 	pid  map[string]PID
+
+	decays map[PID][]Decay // decay channels of particles
 }
 
 // New returns a new particle data table, initialized from r
@@ -0,0 +1,326 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/color/palette"
+	imgdraw "image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// Animation is a sequence of plots rendered as the frames of an animated
+// image, e.g. to monitor the evolution of a histogram per run or per
+// time slice.
+type Animation struct {
+	Delay time.Duration  // Delay between frames
+	Loop  int            // Number of times the animation is played. 0 means forever.
+	DPI   float64        // Resolution of the frames
+	Label draw.TextStyle // Text style of the frame labels
+
+	frames []animFrame
+}
+
+type animFrame struct {
+	plot  Drawer
+	label string
+}
+
+// NewAnimation returns a new empty animation, with a 500ms delay between
+// frames, looping forever.
+func NewAnimation() *Animation {
+	return &Animation{
+		Delay: 500 * time.Millisecond,
+		DPI:   vgimg.DefaultDPI,
+		Label: draw.TextStyle{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Label,
+			Handler: DefaultStyle.TextHandler,
+			XAlign:  draw.XRight,
+			YAlign:  draw.YTop,
+		},
+	}
+}
+
+// Add appends a new frame to the animation.
+// The provided label is displayed in the top-right corner of the frame.
+func (a *Animation) Add(p Drawer, label string) {
+	a.frames = append(a.frames, animFrame{plot: p, label: label})
+}
+
+// Len returns the number of frames of the animation.
+func (a *Animation) Len() int {
+	return len(a.frames)
+}
+
+// Save saves the animation to an image file.  The file format is
+// determined by the extension.
+//
+// Supported extensions are:
+//
+//  .gif, .apng and .png (as APNG).
+//
+// If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
+// If w and h are <= 0, the values are chosen such that they follow the Golden Ratio
+// (the width is defaulted to vgimg.DefaultWidth).
+func (a *Animation) Save(w, h vg.Length, fname string) error {
+	var write func(io.Writer, vg.Length, vg.Length) error
+	switch ext := strings.ToLower(filepath.Ext(fname)); ext {
+	case ".gif":
+		write = a.WriteGIF
+	case ".apng", ".png":
+		write = a.WriteAPNG
+	default:
+		return fmt.Errorf("hplot: unsupported animation format: %q", ext)
+	}
+
+	f, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("hplot: could not create animation file: %w", err)
+	}
+	defer f.Close()
+
+	err = write(f, w, h)
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("hplot: could not close animation file: %w", err)
+	}
+
+	return nil
+}
+
+// WriteGIF writes the animation as an animated GIF to the provided writer.
+//
+// Frames are quantized to the Plan9 palette.
+func (a *Animation) WriteGIF(o io.Writer, w, h vg.Length) error {
+	imgs, err := a.render(w, h)
+	if err != nil {
+		return err
+	}
+
+	var (
+		anim  gif.GIF
+		delay = int(a.Delay / (10 * time.Millisecond))
+	)
+	switch a.Loop {
+	case 0:
+		anim.LoopCount = 0
+	case 1:
+		anim.LoopCount = -1
+	default:
+		anim.LoopCount = a.Loop - 1
+	}
+
+	for _, img := range imgs {
+		dst := image.NewPaletted(img.Bounds(), palette.Plan9)
+		imgdraw.FloydSteinberg.Draw(dst, img.Bounds(), img, image.Point{})
+		anim.Image = append(anim.Image, dst)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	err = gif.EncodeAll(o, &anim)
+	if err != nil {
+		return fmt.Errorf("hplot: could not encode GIF animation: %w", err)
+	}
+
+	return nil
+}
+
+// WriteAPNG writes the animation as an animated PNG to the provided writer.
+//
+// Viewers without APNG support display the first frame.
+func (a *Animation) WriteAPNG(o io.Writer, w, h vg.Length) error {
+	imgs, err := a.render(w, h)
+	if err != nil {
+		return err
+	}
+
+	aw := apngWriter{w: o}
+	aw.write(pngHeader)
+
+	var (
+		ihdr  []byte
+		seq   uint32
+		delay = uint16(a.Delay / time.Millisecond)
+	)
+	for i, img := range imgs {
+		buf := new(bytes.Buffer)
+		err = png.Encode(buf, img)
+		if err != nil {
+			return fmt.Errorf("hplot: could not encode frame %d: %w", i, err)
+		}
+		chunks, err := pngChunks(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("hplot: could not decode frame %d: %w", i, err)
+		}
+
+		if i == 0 {
+			ihdr = chunks[0].data
+			aw.chunk("IHDR", ihdr)
+
+			actl := make([]byte, 8)
+			binary.BigEndian.PutUint32(actl[0:], uint32(len(imgs)))
+			binary.BigEndian.PutUint32(actl[4:], uint32(a.Loop))
+			aw.chunk("acTL", actl)
+		}
+		if !bytes.Equal(chunks[0].data, ihdr) {
+			return fmt.Errorf("hplot: frame %d has an inconsistent PNG header", i)
+		}
+
+		bnd := img.Bounds()
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(bnd.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(bnd.Dy()))
+		binary.BigEndian.PutUint32(fctl[12:], 0) // x-offset
+		binary.BigEndian.PutUint32(fctl[16:], 0) // y-offset
+		binary.BigEndian.PutUint16(fctl[20:], delay)
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		fctl[24] = 0 // dispose-op: none
+		fctl[25] = 0 // blend-op: source
+		aw.chunk("fcTL", fctl)
+		seq++
+
+		for _, c := range chunks {
+			if c.typ != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				aw.chunk("IDAT", c.data)
+				continue
+			}
+			fdat := make([]byte, 4+len(c.data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			copy(fdat[4:], c.data)
+			aw.chunk("fdAT", fdat)
+			seq++
+		}
+	}
+	aw.chunk("IEND", nil)
+
+	if aw.err != nil {
+		return fmt.Errorf("hplot: could not write APNG animation: %w", aw.err)
+	}
+
+	return nil
+}
+
+// render draws all the frames of the animation into opaque images.
+func (a *Animation) render(w, h vg.Length) ([]*image.RGBA, error) {
+	if len(a.frames) == 0 {
+		return nil, fmt.Errorf("hplot: animation has no frame")
+	}
+
+	w, h = Dims(w, h)
+	dpi := a.DPI
+	if dpi <= 0 {
+		dpi = vgimg.DefaultDPI
+	}
+
+	imgs := make([]*image.RGBA, len(a.frames))
+	for i, frame := range a.frames {
+		c := vgimg.NewWith(
+			vgimg.UseDPI(int(dpi)),
+			vgimg.UseWH(w, h),
+		)
+		dc := draw.New(c)
+		frame.plot.Draw(dc)
+		if frame.label != "" {
+			pad := a.Label.Font.Size
+			dc.FillText(a.Label, vg.Point{X: dc.Max.X - pad, Y: dc.Max.Y - pad}, frame.label)
+		}
+
+		src := c.Image()
+		dst := image.NewRGBA(src.Bounds())
+		imgdraw.Draw(dst, dst.Bounds(), image.White, image.Point{}, imgdraw.Src)
+		imgdraw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, imgdraw.Over)
+		imgs[i] = dst
+	}
+
+	return imgs, nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// pngChunks splits a PNG stream into its chunks.
+func pngChunks(p []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(p, pngHeader) {
+		return nil, fmt.Errorf("invalid PNG header")
+	}
+	p = p[len(pngHeader):]
+
+	var chunks []pngChunk
+	for len(p) > 0 {
+		if len(p) < 12 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		n := int(binary.BigEndian.Uint32(p))
+		if len(p) < 12+n {
+			return nil, io.ErrUnexpectedEOF
+		}
+		chunks = append(chunks, pngChunk{
+			typ:  string(p[4:8]),
+			data: p[8 : 8+n],
+		})
+		p = p[12+n:]
+	}
+	if len(chunks) == 0 || chunks[0].typ != "IHDR" {
+		return nil, fmt.Errorf("missing IHDR chunk")
+	}
+	return chunks, nil
+}
+
+type apngWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *apngWriter) write(p []byte) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.Write(p)
+}
+
+func (w *apngWriter) chunk(typ string, data []byte) {
+	var hdr [8]byte
+	binary.BigEndian.PutUint32(hdr[:4], uint32(len(data)))
+	copy(hdr[4:], typ)
+
+	crc := crc32.NewIEEE()
+	crc.Write(hdr[4:])
+	crc.Write(data)
+
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+
+	w.write(hdr[:])
+	w.write(data)
+	w.write(sum[:])
+}
@@ -0,0 +1,130 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/vg"
+)
+
+func newAnimation(n int) *hplot.Animation {
+	anim := hplot.NewAnimation()
+	anim.Delay = 200 * time.Millisecond
+	for i := 0; i < n; i++ {
+		h := hbook.NewH1D(10, 0, 10)
+		for j := 0; j <= i; j++ {
+			h.Fill(float64(j), 1)
+		}
+		p := hplot.New()
+		p.Title.Text = "evolution"
+		p.Add(hplot.NewH1D(h))
+		anim.Add(p, fmt.Sprintf("run %d", i))
+	}
+	return anim
+}
+
+func TestAnimationGIF(t *testing.T) {
+	const n = 3
+	anim := newAnimation(n)
+
+	buf := new(bytes.Buffer)
+	err := anim.WriteGIF(buf, 5*vg.Centimeter, -1)
+	if err != nil {
+		t.Fatalf("could not write GIF animation: %+v", err)
+	}
+
+	img, err := gif.DecodeAll(buf)
+	if err != nil {
+		t.Fatalf("could not decode GIF animation: %+v", err)
+	}
+
+	if got, want := len(img.Image), n; got != want {
+		t.Fatalf("invalid number of frames: got=%d, want=%d", got, want)
+	}
+	for i, delay := range img.Delay {
+		if got, want := delay, 20; got != want {
+			t.Fatalf("invalid delay for frame %d: got=%d, want=%d", i, got, want)
+		}
+	}
+	if got, want := img.LoopCount, 0; got != want {
+		t.Fatalf("invalid loop count: got=%d, want=%d", got, want)
+	}
+}
+
+func TestAnimationAPNG(t *testing.T) {
+	const n = 3
+	anim := newAnimation(n)
+
+	buf := new(bytes.Buffer)
+	err := anim.WriteAPNG(buf, 5*vg.Centimeter, -1)
+	if err != nil {
+		t.Fatalf("could not write APNG animation: %+v", err)
+	}
+
+	raw := buf.Bytes()
+	_, err = png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not decode APNG default image: %+v", err)
+	}
+
+	var (
+		actl = -1
+		fctl = 0
+	)
+	for p := raw[8:]; len(p) >= 12; {
+		sz := int(binary.BigEndian.Uint32(p))
+		switch string(p[4:8]) {
+		case "acTL":
+			actl = int(binary.BigEndian.Uint32(p[8:]))
+		case "fcTL":
+			fctl++
+		}
+		p = p[12+sz:]
+	}
+
+	if got, want := actl, n; got != want {
+		t.Fatalf("invalid acTL number of frames: got=%d, want=%d", got, want)
+	}
+	if got, want := fctl, n; got != want {
+		t.Fatalf("invalid number of fcTL chunks: got=%d, want=%d", got, want)
+	}
+}
+
+func TestAnimationSave(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "hplot-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	anim := newAnimation(2)
+	for _, name := range []string{"anim.gif", "anim.apng"} {
+		err := anim.Save(5*vg.Centimeter, -1, filepath.Join(tmp, name))
+		if err != nil {
+			t.Fatalf("could not save animation %q: %+v", name, err)
+		}
+	}
+
+	err = anim.Save(-1, -1, filepath.Join(tmp, "anim.txt"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	err = hplot.NewAnimation().WriteGIF(new(bytes.Buffer), -1, -1)
+	if err == nil {
+		t.Fatalf("expected an error for an empty animation")
+	}
+}
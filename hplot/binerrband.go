@@ -40,6 +40,11 @@ type BinnedErrBand struct {
 	// arbitrary amount of height for the smallest bin entry so it is visible
 	// on the final plot.
 	LogY bool
+
+	// LogX allows rendering with a log-scaled X axis.
+	// When enabled, bins with non-positive edges are clipped to the
+	// left of the plot.
+	LogX bool
 }
 
 // NewBinnedErrBand creates a binned error band
@@ -65,6 +70,14 @@ func (b *BinnedErrBand) Plot(c draw.Canvas, plt *plot.Plot) {
 		if b.LogY && y-ydo <= 0 {
 			continue
 		}
+		if b.LogX {
+			if xmax <= 0 {
+				continue
+			}
+			if xmin <= 0 {
+				xmin = plt.X.Min
+			}
+		}
 
 		// Polygon
 		xys := plotter.XYs{
@@ -94,6 +107,16 @@ func (b *BinnedErrBand) DataRange() (xmin, xmax, ymin, ymax float64) {
 
 	n := len(b.Counts) - 1
 	xmin, xmax = b.Counts[0].XRange.Min, b.Counts[n].XRange.Max
+	if b.LogX && xmin <= 0 {
+		xmin = math.Inf(+1)
+		for _, c := range b.Counts {
+			for _, x := range []float64{c.XRange.Min, c.XRange.Max} {
+				if x > 0 {
+					xmin = math.Min(xmin, x)
+				}
+			}
+		}
+	}
 
 	ymin, ymax = math.Inf(+1), math.Inf(-1)
 	for _, c := range b.Counts {
@@ -0,0 +1,162 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ColorBarPlot draws a plot together with the color bar of a 2-dim
+// histogram, on the right-hand side of the plot, as done by ROOT's
+// COLZ drawing option.
+type ColorBarPlot struct {
+	Plot     *Plot
+	ColorBar *Plot
+
+	// Tiles controls the layout of the 1x2 color-bar plot grid.
+	// Tiles can be used to customize the padding between plots.
+	Tiles draw.Tiles
+
+	// Ratio controls how the horizontal space is partioned between
+	// the plot and the color bar.
+	// The color bar will take ratio*width.
+	// Default is 0.2.
+	Ratio float64
+}
+
+// NewColorBarPlot returns a new color-bar plot, displaying the plot p
+// and the color bar of the 2-dim histogram h.
+//
+// The palette, the dynamic range (Min, Max) and the LogZ scale of h are
+// used to create the color bar: they should be configured before calling
+// NewColorBarPlot.
+// The label of the color bar is taken from the Z-axis title and unit of
+// the histogram.
+func NewColorBarPlot(p *Plot, h *H2D) *ColorBarPlot {
+	cp := &ColorBarPlot{
+		Plot:     p,
+		ColorBar: New(),
+		Ratio:    0.2,
+		Tiles:    draw.Tiles{Rows: 1, Cols: 2},
+	}
+
+	const pad = 1
+	for _, v := range []*vg.Length{
+		&cp.Tiles.PadTop, &cp.Tiles.PadBottom,
+		&cp.Tiles.PadRight, &cp.Tiles.PadLeft,
+		&cp.Tiles.PadX, &cp.Tiles.PadY,
+	} {
+		if *v == 0 {
+			*v = pad
+		}
+	}
+
+	cp.ColorBar.HideX()
+	if h.LogZ {
+		cp.ColorBar.Y.Scale = plot.LogScale{}
+		cp.ColorBar.Y.Tick.Marker = plot.LogTicks{}
+	}
+	if ax := h.H.ZAxis(); ax.Title != "" {
+		cp.ColorBar.Y.Label.Text = ax.Title
+		if ax.Unit != "" {
+			cp.ColorBar.Y.Label.Text += " [" + ax.Unit + "]"
+		}
+	}
+	cp.ColorBar.Add(&colorBar{zmap: h.zmap()})
+
+	return cp
+}
+
+// Draw draws a color-bar plot to a draw.Canvas.
+//
+// Plotters are drawn in the order in which they were
+// added to the plot.  Plotters that  implement the
+// GlyphBoxer interface will have their GlyphBoxes
+// taken into account when padding the plot so that
+// none of their glyphs are clipped.
+func (cp *ColorBarPlot) Draw(dc draw.Canvas) {
+	var (
+		plt, bar = cp.align(dc)
+	)
+
+	cp.Plot.Draw(plt)
+	cp.ColorBar.Draw(bar)
+}
+
+func (cp *ColorBarPlot) align(dc draw.Canvas) (plt, bar draw.Canvas) {
+	var (
+		ratio = vg.Length(cp.Ratio)
+		xmin  = dc.Min.X
+		w     = dc.Size().X
+		ps    = [][]*plot.Plot{
+			{cp.Plot.Plot, cp.ColorBar.Plot},
+		}
+		cs = plot.Align(ps, cp.Tiles, dc)
+	)
+
+	plt = cs[0][0]
+	bar = cs[0][1]
+
+	plt.Rectangle.Min.X = xmin
+	plt.Rectangle.Max.X = xmin + (1-ratio)*w
+	bar.Rectangle.Min.X = xmin + (1-ratio)*w
+	bar.Rectangle.Max.X = xmin + w
+
+	return plt, bar
+}
+
+// colorBar draws the colors of a palette along the Y-axis.
+type colorBar struct {
+	zmap zmapper
+}
+
+// Plot implements the Plotter interface, drawing one band per color
+// of the palette.
+func (cb *colorBar) Plot(c draw.Canvas, p *plot.Plot) {
+	var (
+		trX, trY = p.Transforms(&c)
+		n        = len(cb.zmap.colors)
+		xmin     = trX(0)
+		xmax     = trX(1)
+	)
+	for i, col := range cb.zmap.colors {
+		var (
+			tmin = 0.0
+			tmax = 1.0
+		)
+		if n > 1 {
+			tmin = math.Max(0, (float64(i)-0.5)/float64(n-1))
+			tmax = math.Min(1, (float64(i)+0.5)/float64(n-1))
+		}
+		var (
+			ymin = trY(cb.zmap.value(tmin))
+			ymax = trY(cb.zmap.value(tmax))
+			pts  = []vg.Point{
+				{X: xmin, Y: ymin},
+				{X: xmax, Y: ymin},
+				{X: xmax, Y: ymax},
+				{X: xmin, Y: ymax},
+			}
+		)
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (cb *colorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, cb.zmap.min, cb.zmap.max
+}
+
+var (
+	_ Drawer = (*ColorBarPlot)(nil)
+
+	_ plot.Plotter    = (*colorBar)(nil)
+	_ plot.DataRanger = (*colorBar)(nil)
+)
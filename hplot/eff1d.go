@@ -0,0 +1,26 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"go-hep.org/x/hep/hbook"
+)
+
+// NewEff1D creates a 2-dim scatter plot from the efficiencies of the
+// non-empty bins of a 1-dim efficiency.
+//
+// By default, the efficiencies are drawn with binomial y error bars and a
+// stepped error band.
+// Other uncertainties (e.g. Bayesian ones) can be displayed with:
+//
+//	hplot.NewS2D(e.S2D(hbook.DivBayesian(cl, alpha, beta)), opts...)
+func NewEff1D(e *hbook.Eff1D, opts ...Options) *S2D {
+	opts = append([]Options{
+		WithYErrBars(true),
+		WithBand(true),
+		WithStepsKind(HiSteps),
+	}, opts...)
+	return NewS2D(e.S2D(), opts...)
+}
@@ -0,0 +1,52 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of an efficiency plotted with an error band.
+func ExampleNewEff1D() {
+	const npoints = 2000
+
+	// a turn-on curve.
+	var (
+		src  = rand.New(rand.NewSource(1234))
+		dist = distuv.Uniform{Min: 0, Max: 10, Src: src}
+		eff  = hbook.NewEff1D(20, 0, 10)
+	)
+	for i := 0; i < npoints; i++ {
+		x := dist.Rand()
+		eff.Fill(x, src.Float64() < 1-1/(1+x*x), 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Efficiency"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Efficiency"
+	p.Y.Min = 0
+	p.Y.Max = 1.1
+	p.Add(plotter.NewGrid())
+
+	s := hplot.NewEff1D(eff)
+	s.GlyphStyle.Color = color.Black
+	s.GlyphStyle.Radius = vg.Points(2)
+
+	p.Add(s)
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/eff1d.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -61,6 +61,14 @@ func WithDPI(dpi float64) FigOption {
 	}
 }
 
+// WithSVGData enables or disables the embedding of the identifiers and
+// values of the data points into SVG plots.
+func WithSVGData(v bool) FigOption {
+	return func(fig *Fig) {
+		fig.SVGData = v
+	}
+}
+
 // Fig is a figure, holding a plot and figure-level customizations.
 type Fig struct {
 	// Plot is a gonum/plot.Plot like value.
@@ -79,6 +87,16 @@ type Fig struct {
 
 	// DPI is the dot-per-inch for PNG,JPEG,... plots.
 	DPI float64
+
+	// SVGData enables the embedding of the data points (and bins) of the
+	// S2D, H1D and H2D plotters into SVG plots.
+	// Each data point is embedded as an invisible <rect> element covering
+	// the drawn point, with an id attribute (e.g. "s2d0-42" for the 42nd
+	// point of the first S2D plotter), a class attribute (e.g. "hplot-s2d")
+	// and one data-xxx attribute per value of the point (e.g. data-x and
+	// data-y), so web tools can attach tooltips or interactions to the
+	// data points.
+	SVGData bool
 }
 
 func (fig *Fig) Draw(dc draw.Canvas) {
@@ -0,0 +1,208 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// FitPlot displays the result of a fit: the fitted data and the model
+// evaluated with the best-fit parameters in the top plot, the pulls of
+// the data with regard to the model in the bottom plot, and a box with
+// the values of the fitted parameters.
+//
+// The pull of a data point is (y-f(x))/σ(y).
+// Data points without uncertainties are not displayed in the pulls plot.
+type FitPlot struct {
+	RatioPlot
+
+	Data   plot.Plotter // fitted data
+	Model  *Function    // model evaluated with the best-fit parameters
+	Pulls  *S2D         // pulls of the data with regard to the model
+	Params *Label       // box with the values of the fitted parameters
+
+	Chi2 float64 // sum of the squared pulls
+	NDF  int     // number of degrees of freedom
+}
+
+// FitOption configures a FitPlot.
+type FitOption func(cfg *fitConfig)
+
+type fitConfig struct {
+	names []string
+	errs  []float64
+	color color.Color
+}
+
+// WithFitParamNames sets the names of the fitted parameters displayed
+// in the parameters box.
+// Parameters are named p0, p1, ... by default.
+func WithFitParamNames(names ...string) FitOption {
+	return func(cfg *fitConfig) {
+		cfg.names = names
+	}
+}
+
+// WithFitParamErrs sets the uncertainties on the fitted parameters
+// displayed in the parameters box.
+func WithFitParamErrs(errs []float64) FitOption {
+	return func(cfg *fitConfig) {
+		cfg.errs = errs
+	}
+}
+
+// WithFitColor sets the color of the model curve.
+// The default is red.
+func WithFitColor(c color.Color) FitOption {
+	return func(cfg *fitConfig) {
+		cfg.color = c
+	}
+}
+
+// NewFitH1D creates a fit plot from the fitted histogram h, the model f
+// and the fit result res.
+// The uncertainty of each bin is the error on its sum of weights.
+func NewFitH1D(h *hbook.H1D, f func(x float64, ps []float64) float64, res *optimize.Result, opts ...FitOption) *FitPlot {
+	var (
+		n   = h.Len()
+		xs  = make([]float64, 0, n)
+		ys  = make([]float64, 0, n)
+		elo = make([]float64, 0, n)
+		ehi = make([]float64, 0, n)
+	)
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		err := bin.ErrW()
+		xs = append(xs, bin.XMid())
+		ys = append(ys, bin.SumW())
+		elo = append(elo, err)
+		ehi = append(ehi, err)
+	}
+
+	data := NewH1D(h, WithYErrBars(true))
+	data.Infos.Style = HInfoNone
+	return newFitPlot(data, xs, ys, elo, ehi, f, res, opts)
+}
+
+// NewFitS2D creates a fit plot from the fitted data points s, the model f
+// and the fit result res.
+func NewFitS2D(s *hbook.S2D, f func(x float64, ps []float64) float64, res *optimize.Result, opts ...FitOption) *FitPlot {
+	var (
+		n   = s.Len()
+		xs  = make([]float64, 0, n)
+		ys  = make([]float64, 0, n)
+		elo = make([]float64, 0, n)
+		ehi = make([]float64, 0, n)
+	)
+	for _, pt := range s.Points() {
+		xs = append(xs, pt.X)
+		ys = append(ys, pt.Y)
+		elo = append(elo, pt.ErrY.Min)
+		ehi = append(ehi, pt.ErrY.Max)
+	}
+
+	data := NewS2D(s, WithYErrBars(true))
+	return newFitPlot(data, xs, ys, elo, ehi, f, res, opts)
+}
+
+func newFitPlot(data plot.Plotter, xs, ys, elo, ehi []float64, f func(x float64, ps []float64) float64, res *optimize.Result, opts []FitOption) *FitPlot {
+	cfg := fitConfig{
+		color: color.NRGBA{R: 255, A: 255},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		ps    = res.X
+		model = NewFunction(func(x float64) float64 { return f(x, ps) })
+		pulls = make(plotter.XYs, 0, len(xs))
+		chi2  = 0.0
+	)
+	model.Color = cfg.color
+	model.Width *= 2
+	model.Samples = 1000
+
+	for i, x := range xs {
+		var (
+			y   = ys[i]
+			fx  = f(x, ps)
+			err = elo[i]
+		)
+		if y < fx {
+			// data below the model: use the upper uncertainty.
+			err = ehi[i]
+		}
+		if err == 0 {
+			continue
+		}
+		pull := (y - fx) / err
+		pulls = append(pulls, plotter.XY{X: x, Y: pull})
+		chi2 += pull * pull
+	}
+
+	fp := &FitPlot{
+		RatioPlot: *NewRatioPlot(),
+		Data:      data,
+		Model:     model,
+		Pulls:     NewS2D(pulls),
+		Chi2:      chi2,
+		NDF:       len(pulls) - len(ps),
+	}
+	fp.Pulls.GlyphStyle.Shape = draw.CircleGlyph{}
+
+	fp.Params = NewLabel(
+		0.7, 0.95, fitParamsText(ps, fp.Chi2, fp.NDF, cfg),
+		WithLabelNormalized(true),
+		WithLabelAutoAdjust(true),
+	)
+
+	fp.Top.Add(fp.Data, fp.Model, fp.Params, NewGrid())
+
+	zero := HLine(0, nil, nil)
+	zero.Line.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	fp.Bottom.Y.Label.Text = "Pull"
+	fp.Bottom.Add(zero, fp.Pulls, NewGrid())
+
+	// share the X-axis range between the data and the pulls plots.
+	fp.Bottom.X.Min = math.Min(fp.Top.X.Min, fp.Bottom.X.Min)
+	fp.Bottom.X.Max = math.Max(fp.Top.X.Max, fp.Bottom.X.Max)
+	fp.Top.X.Min = fp.Bottom.X.Min
+	fp.Top.X.Max = fp.Bottom.X.Max
+
+	return fp
+}
+
+func fitParamsText(ps []float64, chi2 float64, ndf int, cfg fitConfig) string {
+	o := new(strings.Builder)
+	fmt.Fprintf(o, "χ²/ndf = %.4g / %d\n", chi2, ndf)
+	for i, p := range ps {
+		name := fmt.Sprintf("p%d", i)
+		if i < len(cfg.names) {
+			name = cfg.names[i]
+		}
+		switch {
+		case i < len(cfg.errs) && !math.IsNaN(cfg.errs[i]):
+			fmt.Fprintf(o, "%s = %.4g ± %.2g\n", name, p, cfg.errs[i])
+		default:
+			fmt.Fprintf(o, "%s = %.4g\n", name, p)
+		}
+	}
+	return strings.TrimRight(o.String(), "\n")
+}
+
+var (
+	_ Drawer = (*FitPlot)(nil)
+)
@@ -0,0 +1,66 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleNewFitH1D() {
+	const npoints = 10000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    2,
+		Sigma: 4,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	hist := hbook.NewH1D(50, -20, +25)
+	for i := 0; i < npoints; i++ {
+		hist.Fill(dist.Rand(), 1)
+	}
+
+	gauss := func(x float64, ps []float64) float64 {
+		v := (x - ps[1]) / ps[2]
+		return ps[0] * math.Exp(-0.5*v*v)
+	}
+
+	res, err := fit.H1D(
+		hist,
+		fit.Func1D{F: gauss, N: 3},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		log.Fatalf("could not fit histogram: %+v", err)
+	}
+
+	fp := hplot.NewFitH1D(
+		hist, gauss, res,
+		hplot.WithFitParamNames("cst", "mu", "sigma"),
+	)
+	fp.Top.Title.Text = "Gaussian fit"
+	fp.Top.Y.Label.Text = "Entries"
+	fp.Bottom.X.Label.Text = "X"
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err = hplot.Save(fp, width, height, "testdata/fit_h1d.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestFitH1D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleNewFitH1D, t, "fit_h1d.png")
+}
+
+func TestFitS2D(t *testing.T) {
+	s := hbook.NewS2D(
+		hbook.Point2D{X: 1, Y: 2, ErrY: hbook.Range{Min: 1, Max: 1}},
+		hbook.Point2D{X: 2, Y: 5, ErrY: hbook.Range{Min: 2, Max: 1}},
+		hbook.Point2D{X: 3, Y: 6, ErrY: hbook.Range{Min: 0.5, Max: 4}},
+		hbook.Point2D{X: 4, Y: 8},
+	)
+	line := func(x float64, ps []float64) float64 {
+		return ps[0] * x
+	}
+
+	fp := hplot.NewFitS2D(s, line, &optimize.Result{
+		Location: optimize.Location{X: []float64{2}},
+	})
+
+	// pulls: (2-2)/1, (5-4)/2, (6-6)/0.5.
+	// the last point has no uncertainty.
+	if got, want := fp.Pulls.Data.Len(), 3; got != want {
+		t.Fatalf("invalid number of pulls: got=%d, want=%d", got, want)
+	}
+	for i, want := range []float64{0, 0.5, 0} {
+		_, got := fp.Pulls.Data.XY(i)
+		if math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid pull[%d]: got=%v, want=%v", i, got, want)
+		}
+	}
+	if got, want := fp.Chi2, 0.25; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid chi2: got=%v, want=%v", got, want)
+	}
+	if got, want := fp.NDF, 2; got != want {
+		t.Fatalf("invalid ndf: got=%d, want=%d", got, want)
+	}
+	if got, want := fp.Params.Text, "χ²/ndf = 0.25 / 2\np0 = 2"; got != want {
+		t.Fatalf("invalid params box:\ngot= %q\nwant=%q", got, want)
+	}
+}
@@ -34,6 +34,11 @@ type Function struct {
 	// When enabled, function values returning 0 will be discarded from
 	// the final plot.
 	LogY bool
+
+	// LogX allows rendering with a log-scaled X axis.
+	// When enabled, the function is sampled at geometrically spaced
+	// values of x, and non-positive x values are discarded.
+	LogX bool
 }
 
 // NewFunction returns a Function that plots F using
@@ -57,6 +62,18 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 		max = p.X.Max
 	}
 	d := (max - min) / float64(f.Samples-1)
+	xfct := func(i int) float64 {
+		return min + float64(i)*d
+	}
+	if f.LogX {
+		if min <= 0 {
+			min = math.Max(p.X.Min, math.SmallestNonzeroFloat64)
+		}
+		r := math.Pow(max/min, 1/float64(f.Samples-1))
+		xfct = func(i int) float64 {
+			return min * math.Pow(r, float64(i))
+		}
+	}
 	switch {
 	case f.LogY:
 		var (
@@ -64,7 +81,7 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 			lines = [][]vg.Point{make([]vg.Point, 0, f.Samples)}
 		)
 		for i := 0; i < f.Samples; i++ {
-			x := min + float64(i)*d
+			x := xfct(i)
 			y := f.F(x)
 			switch {
 			case math.IsInf(y, -1) || y <= 0:
@@ -87,7 +104,7 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 	default:
 		line := make([]vg.Point, f.Samples)
 		for i := range line {
-			x := min + float64(i)*d
+			x := xfct(i)
 			y := f.F(x)
 			line[i].X = trX(x)
 			line[i].Y = trY(y)
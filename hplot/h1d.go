@@ -46,6 +46,12 @@ type H1D struct {
 	// on the final plot.
 	LogY bool
 
+	// LogX allows rendering with a log-scaled X axis.
+	// When enabled, histogram bins with non-positive edges are clipped to
+	// the left of the plot, and the lowest X value for the DataRange is
+	// the smallest positive bin edge.
+	LogX bool
+
 	// InfoStyle is the style of infos displayed for
 	// the histogram (entries, mean, rms).
 	Infos HInfos
@@ -116,6 +122,7 @@ func NewH1D(h *hbook.H1D, opts ...Options) *H1D {
 	cfg := newConfig(opts)
 
 	h1.LogY = cfg.log.y
+	h1.LogX = cfg.log.x
 	h1.Infos = cfg.hinfos
 
 	if cfg.band {
@@ -145,6 +152,12 @@ func (h *H1D) withYErrBars(yoffs []float64) *plotter.YErrorBars {
 		if bin.Entries() == 0 {
 			continue
 		}
+		if h.LogX && bin.XMid() <= 0 {
+			continue
+		}
+		if h.LogY && yoffs[i]+bin.SumW() <= 0 {
+			continue
+		}
 		data = append(data, plotter.XY{
 			X: bin.XMid(),
 			Y: yoffs[i] + bin.SumW(),
@@ -173,12 +186,30 @@ func (h1 *H1D) withBand() *BinnedErrBand {
 	b := NewBinnedErrBand(h1.Hist.Counts())
 	b.FillColor = color.Gray{200}
 	b.LogY = h1.LogY
+	b.LogX = h1.LogX
 	return b
 }
 
 // DataRange returns the minimum and maximum X and Y values
 func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = h.dataRange()
+	if h.LogX && xmin <= 0 {
+		for _, bin := range h.Hist.Binning.Bins {
+			if x := bin.XMax(); x > 0 {
+				// bins are sorted: the first positive upper edge is
+				// the lowest one, unless its lower edge is positive too.
+				xmin = x
+				if x := bin.XMin(); x > 0 {
+					xmin = x
+				}
+				break
+			}
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
 
+func (h *H1D) dataRange() (xmin, xmax, ymin, ymax float64) {
 	if !h.LogY {
 		xmin, xmax, ymin, ymax = h.Hist.DataRange()
 		if h.YErrs != nil {
@@ -213,7 +244,7 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 		}
 	}
 
-	if ymin == 0 && !math.IsInf(ylow, +1) {
+	if ymin <= 0 && !math.IsInf(ylow, +1) {
 		// Reserve a bit of space for the smallest bin to be displayed still.
 		ymin = ylow * 0.5
 	}
@@ -241,6 +272,15 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 // that connects each point in the Line.
 func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	if h.LogX {
+		tr := trX
+		trX = func(x float64) vg.Length {
+			if x <= 0 {
+				return c.Min.X
+			}
+			return tr(x)
+		}
+	}
 	var pts []vg.Point
 	hist := h.Hist
 	bins := h.Hist.Binning.Bins
@@ -253,7 +293,7 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 		yfct = func(sumw float64) (ymin, ymax vg.Length) {
 			ymin = c.Min.Y
 			ymax = c.Min.Y
-			if sumw != 0 {
+			if sumw > 0 {
 				ymax = trY(sumw)
 			}
 			return ymin, ymax
@@ -262,11 +302,22 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 
 	var glyphs []vg.Point
 
+	svg := newSVGSeries(c, "h1d")
 	for i, bin := range bins {
 		xmin := trX(bin.XMin())
 		xmax := trX(bin.XMax())
 		sumw := bin.SumW()
 		ymin, ymax := yfct(sumw)
+		svg.add(i,
+			vg.Rectangle{
+				Min: vg.Point{X: xmin, Y: ymin},
+				Max: vg.Point{X: xmax, Y: ymax},
+			},
+			svgAttr{"xmin", bin.XMin()},
+			svgAttr{"xmax", bin.XMax()},
+			svgAttr{"y", sumw},
+			svgAttr{"yerr", bin.ErrW()},
+		)
 		switch i {
 		case 0:
 			pts = append(pts, vg.Point{X: xmin, Y: ymin})
@@ -291,7 +342,7 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 			pts = append(pts, vg.Point{X: xmax, Y: ymax})
 		}
 
-		if h.GlyphStyle.Radius != 0 {
+		if h.GlyphStyle.Radius != 0 && !(h.LogX && bin.XMid() <= 0) {
 			x := trX(bin.XMid())
 			_, y := yfct(bin.SumW())
 			// capture glyph location, to be drawn after
@@ -359,12 +410,15 @@ func (h *H1D) GlyphBoxes(p *plot.Plot) []plot.GlyphBox {
 	for i := range bins {
 		bin := bins[i]
 		y := bin.SumW()
-		if h.LogY && y == 0 {
+		if h.LogY && y <= 0 {
 			continue
 		}
 		var box plot.GlyphBox
 		xmin := bin.XMin()
 		w := p.X.Norm(bin.XWidth())
+		if h.LogX && xmin+0.5*w <= 0 {
+			continue
+		}
 		box.X = p.X.Norm(xmin + 0.5*w)
 		box.Y = p.Y.Norm(y)
 		box.Rectangle.Min.X = vg.Length(xmin - 0.5*w)
@@ -5,16 +5,25 @@
 package hplot
 
 import (
+	"image/color"
+	"math"
+
 	"go-hep.org/x/hep/hbook"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/palette/brewer"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
 // H2D implements the plotter.Plotter interface,
 // drawing a 2-dim histogram of the data.
+//
+// Each bin is drawn as a rectangle spanning its edges, so histograms with
+// variable-width bins are correctly displayed.
+// The color of a bin is the color of its value on the palette of the
+// HeatMap, between HeatMap.Min and HeatMap.Max.
 type H2D struct {
 	// H is the histogramming data
 	H *hbook.H2D
@@ -23,9 +32,21 @@ type H2D struct {
 	// the histogram (entries, mean, rms)
 	Infos HInfos
 
-	// HeatMap implements the Plotter interface, drawing
-	// a heat map of the values in the 2-d histogram.
+	// HeatMap holds the palette, the dynamic range (Min, Max) and
+	// the Underflow, Overflow and NaN colors used to draw the bins of
+	// the 2-d histogram.
+	//
+	// When HeatMap.Rasterized is set, the bins are drawn by the
+	// HeatMap, as an image: this assumes bins of uniform widths and a
+	// linear color scale.
 	HeatMap *plotter.HeatMap
+
+	// LogZ enables a logarithmic mapping of the bin values onto the
+	// palette colors.
+	// With LogZ, bins with a non-positive value are not drawn, and a
+	// non-positive HeatMap.Min is replaced by the smallest positive
+	// bin value.
+	LogZ bool
 }
 
 // NewH2D returns a new 2-dim histogram from a hbook.H2D.
@@ -39,16 +60,98 @@ func NewH2D(h *hbook.H2D, p palette.Palette) *H2D {
 	}
 }
 
-// Plot implements the Plotter interface, drawing a line
-// that connects each point in the Line.
+// Plot implements the Plotter interface, drawing the bins of the
+// 2-dim histogram.
 func (h *H2D) Plot(c draw.Canvas, p *plot.Plot) {
-	h.HeatMap.Plot(c, p)
+	if h.HeatMap.Rasterized && !h.LogZ {
+		h.HeatMap.Plot(c, p)
+	} else {
+		h.plot(c, p)
+	}
+
+	svg := newSVGSeries(c, "h2d")
+	if svg == nil {
+		return
+	}
+	trX, trY := p.Transforms(&c)
+	for i, bin := range h.H.Binning.Bins {
+		svg.add(i,
+			vg.Rectangle{
+				Min: vg.Point{X: trX(bin.XMin()), Y: trY(bin.YMin())},
+				Max: vg.Point{X: trX(bin.XMax()), Y: trY(bin.YMax())},
+			},
+			svgAttr{"xmin", bin.XMin()},
+			svgAttr{"xmax", bin.XMax()},
+			svgAttr{"ymin", bin.YMin()},
+			svgAttr{"ymax", bin.YMax()},
+			svgAttr{"z", bin.SumW()},
+		)
+	}
+}
+
+func (h *H2D) plot(c draw.Canvas, p *plot.Plot) {
+	var (
+		trX, trY = p.Transforms(&c)
+		zmap     = h.zmap()
+		bng      = h.H.Binning
+	)
+	// bins are drawn column by column, as done by plotter.HeatMap.
+	for i := 0; i < bng.Nx; i++ {
+		for j := 0; j < bng.Ny; j++ {
+			bin := bng.Bins[j*bng.Nx+i]
+			col := zmap.color(bin.SumW())
+			if col == nil {
+				continue
+			}
+			var (
+				xmin = trX(bin.XMin())
+				xmax = trX(bin.XMax())
+				ymin = trY(bin.YMin())
+				ymax = trY(bin.YMax())
+				pts  = []vg.Point{
+					{X: xmin, Y: ymin},
+					{X: xmax, Y: ymin},
+					{X: xmax, Y: ymax},
+					{X: xmin, Y: ymax},
+				}
+			)
+			c.FillPolygon(col, c.ClipPolygonXY(pts))
+		}
+	}
+}
+
+// zmap returns the mapping of bin values onto the palette colors.
+func (h *H2D) zmap() zmapper {
+	zmap := zmapper{
+		colors: h.HeatMap.Palette.Colors(),
+		min:    h.HeatMap.Min,
+		max:    h.HeatMap.Max,
+		uflow:  h.HeatMap.Underflow,
+		oflow:  h.HeatMap.Overflow,
+		nan:    h.HeatMap.NaN,
+		log:    h.LogZ,
+	}
+	if len(zmap.colors) == 0 {
+		panic("hplot: empty palette")
+	}
+	if zmap.log && zmap.min <= 0 {
+		zmap.min = math.Inf(+1)
+		for _, bin := range h.H.Binning.Bins {
+			if v := bin.SumW(); v > 0 {
+				zmap.min = math.Min(zmap.min, v)
+			}
+		}
+		if math.IsInf(zmap.min, +1) {
+			zmap.min = 1
+		}
+	}
+	return zmap
 }
 
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (h *H2D) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return h.HeatMap.DataRange()
+	return h.H.XMin(), h.H.XMax(), h.H.YMin(), h.H.YMax()
 }
 
 // GlyphBoxes returns a slice of GlyphBoxes,
@@ -58,6 +161,53 @@ func (h *H2D) GlyphBoxes(p *plot.Plot) []plot.GlyphBox {
 	return h.HeatMap.GlyphBoxes(p)
 }
 
+// zmapper maps values onto the colors of a palette.
+type zmapper struct {
+	colors []color.Color
+	min    float64
+	max    float64
+	uflow  color.Color
+	oflow  color.Color
+	nan    color.Color
+	log    bool // whether to use a logarithmic scale
+}
+
+// norm returns the position of v in the dynamic range of the mapping,
+// 0 for the minimum and 1 for the maximum.
+func (zmap zmapper) norm(v float64) float64 {
+	if zmap.log {
+		return math.Log(v/zmap.min) / math.Log(zmap.max/zmap.min)
+	}
+	return (v - zmap.min) / (zmap.max - zmap.min)
+}
+
+// value returns the value at the normalized position t of the
+// dynamic range of the mapping.
+func (zmap zmapper) value(t float64) float64 {
+	if zmap.log {
+		return zmap.min * math.Pow(zmap.max/zmap.min, t)
+	}
+	return zmap.min + t*(zmap.max-zmap.min)
+}
+
+// color returns the color of the provided value, or nil if the
+// value should not be drawn.
+func (zmap zmapper) color(v float64) color.Color {
+	switch {
+	case zmap.log && v <= 0:
+		return nil
+	case v < zmap.min:
+		return zmap.uflow
+	case v > zmap.max:
+		return zmap.oflow
+	}
+	t := zmap.norm(v)
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return zmap.nan
+	}
+	return zmap.colors[int(t*float64(len(zmap.colors)-1)+0.5)]
+}
+
 // check interfaces
 var _ plot.Plotter = (*H2D)(nil)
 var _ plot.DataRanger = (*H2D)(nil)
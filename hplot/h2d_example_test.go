@@ -12,6 +12,7 @@ import (
 	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
@@ -50,3 +51,46 @@ func ExampleH2D() {
 		log.Fatal(err)
 	}
 }
+
+func ExampleH2D_withColorBar() {
+	// variable-width bins, denser at the center.
+	edges := []float64{-10, -6, -4, -3, -2, -1.5, -1, -0.5, 0, 0.5, 1, 1.5, 2, 3, 4, 6, 10}
+	h := hbook.NewH2DFromEdges(edges, edges)
+	h.SetZAxis(hbook.Axis{Title: "entries"})
+
+	const npoints = 10000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 0, 0, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	v := make([]float64, 2)
+	// Draw some random values from the standard
+	// normal distribution.
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		h.Fill(v[0], v[1], 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Hist-2D"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	h2 := hplot.NewH2D(h, palette.Heat(16, 1))
+	h2.LogZ = true
+	p.Add(h2)
+	p.Add(plotter.NewGrid())
+
+	cp := hplot.NewColorBarPlot(p, h2)
+
+	err := hplot.Save(cp, 12*vg.Centimeter, 10*vg.Centimeter, "testdata/h2d_colorbar.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
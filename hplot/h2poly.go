@@ -0,0 +1,135 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/brewer"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PolyBinner describes 2-dim data binned with bins of arbitrary polygonal
+// shapes, such as a groot/rhist.H2Poly histogram.
+type PolyBinner interface {
+	// Len returns the number of bins.
+	Len() int
+
+	// Polygons returns the vertices of the polygons making up the i-th bin.
+	Polygons(i int) (xs, ys [][]float64)
+
+	// Value returns the value of the i-th bin.
+	Value(i int) float64
+}
+
+// H2Poly implements the plotter.Plotter interface,
+// drawing a 2-dim histogram with polygonal bins.
+//
+// Bins are filled with the color of their value on the palette, and
+// outlined with LineStyle. Bins with a zero value are only outlined.
+type H2Poly struct {
+	// Bins is the histogramming data.
+	Bins PolyBinner
+
+	// Palette is the color palette used to fill the bins.
+	Palette palette.Palette
+
+	// Min and Max define the range of values mapped
+	// onto the palette colors.
+	Min, Max float64
+
+	// LineStyle is the style of the bins outlines.
+	LineStyle draw.LineStyle
+}
+
+// NewH2Poly returns a new 2-dim histogram with polygonal bins.
+func NewH2Poly(bins PolyBinner, p palette.Palette) *H2Poly {
+	if p == nil {
+		p, _ = brewer.GetPalette(brewer.TypeAny, "RdYlBu", 11)
+	}
+	h := &H2Poly{
+		Bins:      bins,
+		Palette:   p,
+		Min:       math.Inf(+1),
+		Max:       math.Inf(-1),
+		LineStyle: plotter.DefaultLineStyle,
+	}
+	for i := 0; i < bins.Len(); i++ {
+		v := bins.Value(i)
+		h.Min = math.Min(h.Min, v)
+		h.Max = math.Max(h.Max, v)
+	}
+	return h
+}
+
+// Plot implements the Plotter interface, drawing the bins of
+// the histogram.
+func (h *H2Poly) Plot(c draw.Canvas, p *plot.Plot) {
+	var (
+		trX, trY = p.Transforms(&c)
+		colors   = h.Palette.Colors()
+		ncolors  = len(colors)
+	)
+
+	for i := 0; i < h.Bins.Len(); i++ {
+		var (
+			v      = h.Bins.Value(i)
+			xs, ys = h.Bins.Polygons(i)
+		)
+		for j := range xs {
+			pts := make([]vg.Point, len(xs[j]))
+			for k := range pts {
+				pts[k] = vg.Point{X: trX(xs[j][k]), Y: trY(ys[j][k])}
+			}
+			if v != 0 && ncolors > 0 {
+				var idx int
+				if h.Max > h.Min {
+					idx = int((v - h.Min) / (h.Max - h.Min) * float64(ncolors-1))
+				}
+				switch {
+				case idx < 0:
+					idx = 0
+				case idx >= ncolors:
+					idx = ncolors - 1
+				}
+				c.FillPolygon(colors[idx], c.ClipPolygonXY(pts))
+			}
+			if h.LineStyle.Width > 0 && len(pts) > 0 {
+				pts = append(pts, pts[0])
+				c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+			}
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (h *H2Poly) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin = math.Inf(+1)
+	xmax = math.Inf(-1)
+	ymin = math.Inf(+1)
+	ymax = math.Inf(-1)
+	for i := 0; i < h.Bins.Len(); i++ {
+		xs, ys := h.Bins.Polygons(i)
+		for j := range xs {
+			for k := range xs[j] {
+				xmin = math.Min(xmin, xs[j][k])
+				xmax = math.Max(xmax, xs[j][k])
+				ymin = math.Min(ymin, ys[j][k])
+				ymax = math.Max(ymax, ys[j][k])
+			}
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+var (
+	_ plot.Plotter    = (*H2Poly)(nil)
+	_ plot.DataRanger = (*H2Poly)(nil)
+)
@@ -0,0 +1,69 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleH2Poly() {
+	// create a honeycomb of hexagonal bins.
+	const (
+		r  = 1.0
+		nx = 6
+		ny = 6
+	)
+	var (
+		dx = 1.5 * r
+		dy = math.Sqrt(3) * r
+		h  = rhist.NewH2Poly("h2poly", "honeycomb", -r, nx*dx, -dy, ny*dy)
+	)
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			var (
+				x0 = float64(ix) * dx
+				y0 = float64(iy) * dy
+				xs = make([]float64, 6)
+				ys = make([]float64, 6)
+			)
+			if ix%2 == 1 {
+				y0 += 0.5 * dy
+			}
+			for i := range xs {
+				phi := float64(i) * math.Pi / 3
+				xs[i] = x0 + r*math.Cos(phi)
+				ys[i] = y0 + r*math.Sin(phi)
+			}
+			h.AddBin(xs, ys)
+		}
+	}
+
+	var (
+		src = rand.New(rand.NewSource(1234))
+		xd  = distuv.Normal{Mu: 4, Sigma: 2, Src: src}
+		yd  = distuv.Normal{Mu: 5, Sigma: 2, Src: src}
+	)
+	for i := 0; i < 10000; i++ {
+		h.Fill(xd.Rand(), yd.Rand(), 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Hist-2D with polygonal bins"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	p.Add(hplot.NewH2Poly(h, nil))
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/h2poly_plot.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+var _ hplot.PolyBinner = (*rhist.H2Poly)(nil)
+
+func TestH2Poly(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH2Poly, t, "h2poly_plot.png")
+}
@@ -0,0 +1,158 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// htmlCanvas is a canvas that writes a self-contained interactive HTML
+// page.
+//
+// The plot is embedded as a SVG document recording its data points
+// (see svgCanvas), together with a script that:
+//   - displays the values of the data point (or bin) under the mouse,
+//   - zooms in and out the plot with the mouse wheel,
+//   - pans the plot by dragging it with the mouse,
+//   - resets the view on a double click.
+type htmlCanvas struct {
+	*svgCanvas
+}
+
+func newHTMLCanvas(w, h vg.Length) *htmlCanvas {
+	return &htmlCanvas{svgCanvas: newSVGCanvas(w, h)}
+}
+
+// WriteTo writes the canvas to an io.Writer.
+func (c *htmlCanvas) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+	_, err := c.svgCanvas.WriteTo(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	// drop the XML prolog: the SVG document is inlined in the page.
+	raw := buf.Bytes()
+	i := bytes.Index(raw, []byte("<svg"))
+	if i < 0 {
+		return 0, fmt.Errorf("hplot: invalid SVG document")
+	}
+	raw = raw[i:]
+
+	out := new(bytes.Buffer)
+	out.WriteString(htmlHeader)
+	out.Write(raw)
+	out.WriteString(htmlFooter)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>hplot</title>
+<style>
+#hplot svg { cursor: move; user-select: none; }
+#hplot-data rect:hover { stroke: black; stroke-width: 0.5; }
+#hplot-tooltip {
+	display: none;
+	position: absolute;
+	padding: 2px 4px;
+	font: 12px monospace;
+	background: #ffffe0;
+	border: 1px solid #888;
+	pointer-events: none;
+	white-space: pre;
+}
+</style>
+</head>
+<body>
+<div id="hplot">
+`
+
+const htmlFooter = `</div>
+<div id="hplot-tooltip"></div>
+<script>
+(function() {
+	var svg = document.querySelector("#hplot svg");
+	var tip = document.getElementById("hplot-tooltip");
+	var vb = svg.viewBox.baseVal;
+	var orig = {x: vb.x, y: vb.y, width: vb.width, height: vb.height};
+	var drag = null;
+
+	// pos returns the position of the mouse in the SVG user space.
+	function pos(evt) {
+		var pt = svg.createSVGPoint();
+		pt.x = evt.clientX;
+		pt.y = evt.clientY;
+		return pt.matrixTransform(svg.getScreenCTM().inverse());
+	}
+
+	svg.querySelectorAll("#hplot-data rect").forEach(function(r) {
+		r.addEventListener("mousemove", function(evt) {
+			if (drag) {
+				return;
+			}
+			var txt = [];
+			for (var i = 0; i < r.attributes.length; i++) {
+				var a = r.attributes[i];
+				if (a.name.indexOf("data-") == 0) {
+					txt.push(a.name.slice(5) + ": " + a.value);
+				}
+			}
+			tip.textContent = txt.join("\n");
+			tip.style.left = (evt.pageX + 12) + "px";
+			tip.style.top = (evt.pageY + 12) + "px";
+			tip.style.display = "block";
+		});
+		r.addEventListener("mouseleave", function() {
+			tip.style.display = "none";
+		});
+	});
+
+	svg.addEventListener("wheel", function(evt) {
+		evt.preventDefault();
+		var p = pos(evt);
+		var f = evt.deltaY < 0 ? 0.8 : 1.25;
+		vb.x = p.x - (p.x - vb.x) * f;
+		vb.y = p.y - (p.y - vb.y) * f;
+		vb.width *= f;
+		vb.height *= f;
+	});
+	svg.addEventListener("mousedown", function(evt) {
+		drag = pos(evt);
+		tip.style.display = "none";
+	});
+	svg.addEventListener("mousemove", function(evt) {
+		if (!drag) {
+			return;
+		}
+		var p = pos(evt);
+		vb.x -= p.x - drag.x;
+		vb.y -= p.y - drag.y;
+	});
+	svg.addEventListener("mouseup", function() { drag = null; });
+	svg.addEventListener("mouseleave", function() { drag = null; });
+	svg.addEventListener("dblclick", function() {
+		vb.x = orig.x;
+		vb.y = orig.y;
+		vb.width = orig.width;
+		vb.height = orig.height;
+	});
+})();
+</script>
+</body>
+</html>
+`
+
+var (
+	_ vg.CanvasWriterTo = (*htmlCanvas)(nil)
+)
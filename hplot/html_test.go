@@ -0,0 +1,87 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestHTML(t *testing.T) {
+	h1 := hbook.NewH1D(4, 0, 4)
+	h1.Fill(1.5, 2)
+	h1.Fill(2.5, 3)
+
+	p := New()
+	p.Add(NewH1D(h1))
+
+	fname := filepath.Join(t.TempDir(), "plot.html")
+	err := Save(p, -1, -1, fname)
+	if err != nil {
+		t.Fatalf("could not save plot: %+v", err)
+	}
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read plot: %+v", err)
+	}
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<script>",
+		`<div id="hplot-tooltip">`,
+	} {
+		if !bytes.Contains(raw, []byte(want)) {
+			t.Fatalf("missing %q in HTML page", want)
+		}
+	}
+	if bytes.Contains(raw, []byte("<?xml")) {
+		t.Fatalf("unexpected XML prolog in HTML page")
+	}
+
+	beg := bytes.Index(raw, []byte("<svg"))
+	end := bytes.Index(raw, []byte("</svg>"))
+	if beg < 0 || end < 0 {
+		t.Fatalf("could not find SVG document")
+	}
+
+	type rect struct {
+		ID string `xml:"id,attr"`
+		X  string `xml:"data-x,attr"`
+		Y  string `xml:"data-y,attr"`
+	}
+	type group struct {
+		ID    string `xml:"id,attr"`
+		Rects []rect `xml:"rect"`
+	}
+	type doc struct {
+		Groups []group `xml:"g"`
+	}
+
+	var svg doc
+	err = xml.Unmarshal(raw[beg:end+len("</svg>")], &svg)
+	if err != nil {
+		t.Fatalf("could not parse SVG document: %+v", err)
+	}
+
+	var ids []string
+	for _, g := range svg.Groups {
+		if g.ID != "hplot-data" {
+			continue
+		}
+		for _, r := range g.Rects {
+			ids = append(ids, r.ID)
+		}
+	}
+	if got, want := strings.Join(ids, ","), "h1d0-0,h1d0-1,h1d0-2,h1d0-3"; got != want {
+		t.Fatalf("invalid data points:\ngot= %q\nwant=%q", got, want)
+	}
+}
@@ -32,7 +32,11 @@ type Drawer interface {
 //
 // Supported extensions are:
 //
-//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tex, .tif and .tiff.
+//  .eps, .html, .jpg, .jpeg, .pdf, .png, .svg, .tex, .tif and .tiff.
+//
+// The .html extension creates a self-contained interactive HTML page,
+// displaying the values of the data points under the mouse, and allowing
+// to zoom and pan the plot.
 //
 // If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
 // If w and h are <= 0, the values are chosen such that they follow the Golden Ratio
@@ -103,14 +107,29 @@ func Save(p Drawer, w, h vg.Length, fnames ...string) (err error) {
 func WriterTo(p Drawer, w, h vg.Length, format string) (io.WriterTo, error) {
 	w, h = Dims(w, h)
 
-	dpi := float64(vgimg.DefaultDPI)
+	var (
+		dpi     = float64(vgimg.DefaultDPI)
+		svgData = false
+	)
 	if fig, ok := p.(*Fig); ok {
 		dpi = fig.DPI
+		svgData = fig.SVGData
 	}
 
-	c, err := newFormattedCanvas(w, h, format, dpi)
-	if err != nil {
-		return nil, fmt.Errorf("hplot: could not create canvas: %w", err)
+	var (
+		c   vg.CanvasWriterTo
+		err error
+	)
+	switch {
+	case svgData && format == "svg":
+		c = newSVGCanvas(w, h)
+	case format == "html":
+		c = newHTMLCanvas(w, h)
+	default:
+		c, err = newFormattedCanvas(w, h, format, dpi)
+		if err != nil {
+			return nil, fmt.Errorf("hplot: could not create canvas: %w", err)
+		}
 	}
 	p.Draw(draw.New(c))
 
@@ -0,0 +1,539 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Legend implements the plot.Plotter interface, drawing a legend
+// describing the meaning of the data elements of a plot.
+//
+// Contrary to the plot.Legend of a plot, a Legend can lay its entries out
+// over multiple columns, and can be automatically placed where it
+// overlaps the least with the plotted data.
+// Each entry is drawn with its thumbnails, followed by its text.
+//
+// A Legend should be added to a plot after all the other plotters,
+// so it is drawn on top of them and so they are taken into account for
+// its automatic placement.
+type Legend struct {
+	// TextStyle is the style given to the legend entry texts.
+	TextStyle text.Style
+
+	// Padding is the amount of padding to add between
+	// each row of the legend.
+	Padding vg.Length
+
+	// Columns is the number of columns of the legend.
+	// Entries are laid out row by row.
+	// The default is one column.
+	Columns int
+
+	// ColumnPadding is the amount of padding to add between
+	// each column of the legend.
+	// If ColumnPadding is zero then columns are spaced based
+	// on the font size.
+	ColumnPadding vg.Length
+
+	// Top and Left specify the location of the legend, along
+	// the top or bottom, and left or right edges of the plot.
+	Top, Left bool
+
+	// AutoPlace enables the automatic placement of the legend.
+	// When AutoPlace is set, the legend is placed in the corner
+	// (or at the top or bottom center) of the plot where it overlaps
+	// the least with the plotted data, preferring the location
+	// specified by Top and Left.
+	AutoPlace bool
+
+	// XOffs and YOffs are added to the legend's
+	// final position.
+	XOffs, YOffs vg.Length
+
+	// YPosition specifies the vertical position of a legend entry.
+	// Valid values are [-1,+1], with +1 being the top of the
+	// entry vertical space, and -1 the bottom.
+	YPosition float64
+
+	// ThumbnailWidth is the width of legend thumbnails.
+	ThumbnailWidth vg.Length
+
+	entries []legendItem
+	plt     *Plot // plot described by the legend
+}
+
+type legendItem struct {
+	text   string
+	thumbs []plot.Thumbnailer
+}
+
+// NewLegend returns a new legend with the default settings of the
+// hplot style.
+func NewLegend() *Legend {
+	return &Legend{
+		TextStyle: text.Style{
+			Font:    DefaultStyle.Fonts.Legend,
+			Handler: DefaultStyle.TextHandler,
+		},
+		Columns:        1,
+		YPosition:      draw.PosCenter,
+		ThumbnailWidth: vg.Points(20),
+	}
+}
+
+// Add adds an entry to the legend with the given name.
+// The entry's thumbnail is drawn as the composite of all of the
+// thumbnails.
+func (l *Legend) Add(name string, thumbs ...plot.Thumbnailer) {
+	l.entries = append(l.entries, legendItem{text: name, thumbs: thumbs})
+}
+
+// Plot implements the Plotter interface, drawing the legend.
+func (l *Legend) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(l.entries) == 0 {
+		return
+	}
+
+	sty := l.TextStyle
+	if sty.Handler == nil {
+		sty.Handler = plt.TextHandler
+	}
+	lay := l.layout(sty)
+
+	pos := legendPos{top: l.Top, left: l.Left}
+	if l.AutoPlace && l.plt != nil {
+		pos = l.place(c, plt, lay)
+	}
+	l.draw(c, sty, lay, l.rectangle(c, lay, pos))
+}
+
+// legendLayout describes the sizes of the legend rows and columns.
+type legendLayout struct {
+	rows    int
+	cols    []vg.Length // widths of the columns
+	colpad  vg.Length
+	entry   vg.Length // height of an entry
+	em      vg.Length
+	descent vg.Length
+}
+
+func (lay legendLayout) size(pad vg.Length) vg.Point {
+	var w vg.Length
+	for _, cw := range lay.cols {
+		w += cw
+	}
+	w += vg.Length(len(lay.cols)-1) * lay.colpad
+	h := vg.Length(lay.rows)*lay.entry + vg.Length(lay.rows-1)*pad
+	return vg.Point{X: w, Y: h}
+}
+
+func (l *Legend) layout(sty text.Style) legendLayout {
+	ncols := l.Columns
+	switch {
+	case ncols <= 0:
+		ncols = 1
+	case ncols > len(l.entries):
+		ncols = len(l.entries)
+	}
+
+	lay := legendLayout{
+		rows:    (len(l.entries) + ncols - 1) / ncols,
+		cols:    make([]vg.Length, ncols),
+		colpad:  l.ColumnPadding,
+		em:      sty.Rectangle(" ").Max.X,
+		descent: sty.FontExtents().Descent,
+	}
+	if lay.colpad == 0 {
+		lay.colpad = lay.em
+	}
+	for i, e := range l.entries {
+		r := sty.Rectangle(e.text)
+		lay.entry = vg.Length(math.Max(float64(lay.entry), float64(r.Max.Y)))
+		w := l.ThumbnailWidth + lay.em + r.Max.X
+		if j := i % ncols; w > lay.cols[j] {
+			lay.cols[j] = w
+		}
+	}
+	return lay
+}
+
+// legendPos is a location of a legend on a plot.
+type legendPos struct {
+	top    bool
+	left   bool
+	center bool // centered along the X-axis.
+}
+
+// rectangle returns the area covered by the legend at the provided
+// location.
+func (l *Legend) rectangle(c draw.Canvas, lay legendLayout, pos legendPos) vg.Rectangle {
+	var (
+		sz = lay.size(l.Padding)
+		r  vg.Rectangle
+	)
+	switch {
+	case pos.center:
+		r.Min.X = c.Min.X + 0.5*(c.Size().X-sz.X)
+	case pos.left:
+		r.Min.X = c.Min.X
+	default:
+		r.Min.X = c.Max.X - sz.X
+	}
+	switch {
+	case pos.top:
+		r.Min.Y = c.Max.Y - sz.Y
+	default:
+		r.Min.Y = c.Min.Y
+	}
+	r.Min.X += l.XOffs
+	r.Min.Y += l.YOffs
+	r.Max = r.Min.Add(sz)
+	return r
+}
+
+func (l *Legend) draw(c draw.Canvas, sty text.Style, lay legendLayout, r vg.Rectangle) {
+	if l.YPosition < draw.PosBottom || draw.PosTop < l.YPosition {
+		panic("hplot: invalid vertical offset for the legend's entries")
+	}
+	yoff := vg.Length(l.YPosition-draw.PosBottom) / 2
+	yoff += lay.descent
+
+	ncols := len(lay.cols)
+	for i, e := range l.entries {
+		var (
+			row = i / ncols
+			col = i % ncols
+			x   = r.Min.X
+			y   = r.Max.Y - vg.Length(row)*(lay.entry+l.Padding) - lay.entry
+		)
+		for _, cw := range lay.cols[:col] {
+			x += cw + lay.colpad
+		}
+		icon := &draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: x, Y: y},
+				Max: vg.Point{X: x + l.ThumbnailWidth, Y: y + lay.entry},
+			},
+		}
+		for _, t := range e.thumbs {
+			t.Thumbnail(icon)
+		}
+		yoffs := (lay.entry-lay.descent-sty.Rectangle(e.text).Max.Y)/2 + yoff
+		c.FillText(sty, vg.Point{X: x + l.ThumbnailWidth + lay.em, Y: y + yoffs}, e.text)
+	}
+}
+
+// place returns the location of the legend overlapping the least with
+// the data drawn by the plotters of the plot.
+func (l *Legend) place(c draw.Canvas, plt *plot.Plot, lay legendLayout) legendPos {
+	grid := newOccGrid(c, 50, 50)
+	trX, trY := plt.Transforms(&c)
+	for _, p := range l.plt.plotters {
+		if p == plot.Plotter(l) {
+			continue
+		}
+		grid.addPlotter(c, plt, trX, trY, p)
+	}
+
+	var (
+		best = legendPos{top: l.Top, left: l.Left}
+		cost = grid.count(l.rectangle(c, lay, best))
+	)
+	for _, pos := range []legendPos{
+		{top: true, left: false},
+		{top: true, left: true},
+		{top: false, left: false},
+		{top: false, left: true},
+		{top: true, center: true},
+		{top: false, center: true},
+	} {
+		if cost == 0 {
+			break
+		}
+		if v := grid.count(l.rectangle(c, lay, pos)); v < cost {
+			best, cost = pos, v
+		}
+	}
+	return best
+}
+
+// occGrid is an occupancy grid of a canvas, recording the cells
+// covered by the plotted data.
+type occGrid struct {
+	r     vg.Rectangle
+	nx    int
+	ny    int
+	dx    vg.Length
+	dy    vg.Length
+	cells []bool
+}
+
+func newOccGrid(c draw.Canvas, nx, ny int) *occGrid {
+	return &occGrid{
+		r:     c.Rectangle,
+		nx:    nx,
+		ny:    ny,
+		dx:    c.Size().X / vg.Length(nx),
+		dy:    c.Size().Y / vg.Length(ny),
+		cells: make([]bool, nx*ny),
+	}
+}
+
+// indices returns the range of cells [ix0,ix1)x[iy0,iy1) overlapping
+// with the provided rectangle.
+func (g *occGrid) indices(r vg.Rectangle) (ix0, ix1, iy0, iy1 int) {
+	idx := func(v, min, d vg.Length, n int, up bool) int {
+		f := float64((v - min) / d)
+		if up {
+			f = math.Ceil(f)
+		}
+		switch {
+		case math.IsNaN(f) || f < 0:
+			return 0
+		case f > float64(n):
+			return n
+		}
+		return int(f)
+	}
+	ix0 = idx(r.Min.X, g.r.Min.X, g.dx, g.nx, false)
+	ix1 = idx(r.Max.X, g.r.Min.X, g.dx, g.nx, true)
+	iy0 = idx(r.Min.Y, g.r.Min.Y, g.dy, g.ny, false)
+	iy1 = idx(r.Max.Y, g.r.Min.Y, g.dy, g.ny, true)
+	return ix0, ix1, iy0, iy1
+}
+
+// fill marks the cells overlapping with the provided rectangle.
+func (g *occGrid) fill(r vg.Rectangle) {
+	if r.Min.X > r.Max.X {
+		r.Min.X, r.Max.X = r.Max.X, r.Min.X
+	}
+	if r.Min.Y > r.Max.Y {
+		r.Min.Y, r.Max.Y = r.Max.Y, r.Min.Y
+	}
+	ix0, ix1, iy0, iy1 := g.indices(r)
+	for iy := iy0; iy < iy1; iy++ {
+		for ix := ix0; ix < ix1; ix++ {
+			g.cells[iy*g.nx+ix] = true
+		}
+	}
+}
+
+// line marks the cells crossed by the provided line.
+func (g *occGrid) line(pts []vg.Point) {
+	step := 0.5 * math.Min(float64(g.dx), float64(g.dy))
+	for i := 1; i < len(pts); i++ {
+		var (
+			p = pts[i-1]
+			q = pts[i]
+			n = int(math.Ceil(math.Hypot(float64(q.X-p.X), float64(q.Y-p.Y)) / step))
+		)
+		if n > 10*(g.nx+g.ny) || n < 0 {
+			// non-finite or very long segment.
+			n = 10 * (g.nx + g.ny)
+		}
+		for j := 0; j <= n; j++ {
+			f := 1.0
+			if n > 0 {
+				f = float64(j) / float64(n)
+			}
+			pt := vg.Point{
+				X: p.X + vg.Length(f)*(q.X-p.X),
+				Y: p.Y + vg.Length(f)*(q.Y-p.Y),
+			}
+			g.mark(pt)
+		}
+	}
+}
+
+// mark marks the cell containing the provided point.
+func (g *occGrid) mark(pt vg.Point) {
+	var (
+		fx = float64((pt.X - g.r.Min.X) / g.dx)
+		fy = float64((pt.Y - g.r.Min.Y) / g.dy)
+	)
+	if !(0 <= fx && fx <= float64(g.nx) && 0 <= fy && fy <= float64(g.ny)) {
+		return
+	}
+	ix := int(math.Min(fx, float64(g.nx-1)))
+	iy := int(math.Min(fy, float64(g.ny-1)))
+	g.cells[iy*g.nx+ix] = true
+}
+
+// count returns the number of marked cells overlapping with the
+// provided rectangle.
+func (g *occGrid) count(r vg.Rectangle) int {
+	n := 0
+	ix0, ix1, iy0, iy1 := g.indices(r)
+	for iy := iy0; iy < iy1; iy++ {
+		for ix := ix0; ix < ix1; ix++ {
+			if g.cells[iy*g.nx+ix] {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// addPlotter marks the cells covered by the data drawn by the plotter.
+func (g *occGrid) addPlotter(c draw.Canvas, plt *plot.Plot, trX, trY func(float64) vg.Length, p plot.Plotter) {
+	// bar returns the area between 0 and y, for the [xmin,xmax] range.
+	bar := func(xmin, xmax, y float64) vg.Rectangle {
+		y0 := trY(0)
+		if math.IsInf(float64(y0), 0) || math.IsNaN(float64(y0)) {
+			y0 = c.Min.Y
+		}
+		return vg.Rectangle{
+			Min: vg.Point{X: trX(xmin), Y: y0},
+			Max: vg.Point{X: trX(xmax), Y: trY(y)},
+		}
+	}
+	line := func(xys plotter.XYer) {
+		pts := make([]vg.Point, xys.Len())
+		for i := range pts {
+			x, y := xys.XY(i)
+			pts[i] = vg.Point{X: trX(x), Y: trY(y)}
+		}
+		g.line(pts)
+	}
+
+	switch p := p.(type) {
+	case *H1D:
+		for _, bin := range p.Hist.Binning.Bins {
+			g.fill(bar(bin.XMin(), bin.XMax(), bin.SumW()))
+		}
+
+	case *HStack:
+		bins := p.hs[0].Hist.Binning.Bins
+		ys := make([]float64, len(bins))
+		for _, h := range p.hs {
+			for i, bin := range h.Hist.Binning.Bins {
+				switch p.Stack {
+				case HStackOn:
+					ys[i] += bin.SumW()
+				default:
+					ys[i] = math.Max(ys[i], bin.SumW())
+				}
+			}
+		}
+		for i, bin := range bins {
+			g.fill(bar(bin.XMin(), bin.XMax(), ys[i]))
+		}
+
+	case *H2D:
+		for _, bin := range p.H.Binning.Bins {
+			if bin.SumW() == 0 {
+				continue
+			}
+			g.fill(vg.Rectangle{
+				Min: vg.Point{X: trX(bin.XMin()), Y: trY(bin.YMin())},
+				Max: vg.Point{X: trX(bin.XMax()), Y: trY(bin.YMax())},
+			})
+		}
+
+	case *BinnedErrBand:
+		for _, cnt := range p.Counts {
+			g.fill(vg.Rectangle{
+				Min: vg.Point{X: trX(cnt.XRange.Min), Y: trY(cnt.Val - cnt.Err.Low)},
+				Max: vg.Point{X: trX(cnt.XRange.Max), Y: trY(cnt.Val + cnt.Err.High)},
+			})
+		}
+
+	case *SigmaBand:
+		lo, hi := p.Lo1, p.Hi1
+		if len(p.Lo2) != 0 {
+			lo, hi = p.Lo2, p.Hi2
+		}
+		for i, pt := range p.Central {
+			xmin, xmax := pt.X, pt.X
+			switch {
+			case len(p.Edges) != 0:
+				xmin, xmax = p.Edges[i], p.Edges[i+1]
+			case i+1 < len(p.Central):
+				xmax = p.Central[i+1].X
+			}
+			g.fill(vg.Rectangle{
+				Min: vg.Point{X: trX(xmin), Y: trY(math.Min(lo[i], hi[i]))},
+				Max: vg.Point{X: trX(xmax), Y: trY(math.Max(lo[i], hi[i]))},
+			})
+		}
+
+	case *S2D:
+		line(p.Data)
+
+	case plotter.XYer:
+		line(p)
+
+	case plot.GlyphBoxer:
+		for _, b := range p.GlyphBoxes(plt) {
+			pt := vg.Point{X: c.X(b.X), Y: c.Y(b.Y)}
+			g.fill(vg.Rectangle{
+				Min: pt.Add(b.Rectangle.Min),
+				Max: pt.Add(b.Rectangle.Max),
+			})
+		}
+	}
+}
+
+// LegendThumbnail implements the plot.Thumbnailer interface, drawing a
+// legend thumbnail made of a filled box, a line and a marker.
+type LegendThumbnail struct {
+	// Fill is the color filling the thumbnail.
+	// Use nil to disable the filling.
+	Fill color.Color
+
+	// Line is the style of the line drawn across the thumbnail.
+	// Use zero width to disable.
+	Line draw.LineStyle
+
+	// Glyph is the style of the marker drawn at the center of
+	// the thumbnail.
+	// Use a nil shape to disable.
+	Glyph draw.GlyphStyle
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (t LegendThumbnail) Thumbnail(c *draw.Canvas) {
+	var (
+		xmin = c.Min.X
+		xmax = c.Max.X
+		ymin = c.Min.Y
+		ymax = c.Max.Y
+	)
+
+	if t.Fill != nil {
+		pts := []vg.Point{
+			{X: xmin, Y: ymin},
+			{X: xmax, Y: ymin},
+			{X: xmax, Y: ymax},
+			{X: xmin, Y: ymax},
+			{X: xmin, Y: ymin},
+		}
+		c.FillPolygon(t.Fill, c.ClipPolygonXY(pts))
+	}
+
+	if t.Line.Width != 0 {
+		ymid := c.Center().Y
+		line := []vg.Point{{X: xmin, Y: ymid}, {X: xmax, Y: ymid}}
+		c.StrokeLines(t.Line, c.ClipLinesX(line)...)
+	}
+
+	if t.Glyph.Shape != nil {
+		c.DrawGlyph(t.Glyph, c.Center())
+	}
+}
+
+var (
+	_ plot.Plotter     = (*Legend)(nil)
+	_ plot.Thumbnailer = LegendThumbnail{}
+)
@@ -0,0 +1,81 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// An example of a multi-column legend, automatically placed where it
+// does not overlap with the histograms.
+func ExampleLegend() {
+	const npoints = 10000
+
+	colors := []color.Color{
+		color.NRGBA{R: 255, A: 255},
+		color.NRGBA{G: 180, A: 255},
+		color.NRGBA{B: 255, A: 255},
+		color.NRGBA{R: 255, B: 255, A: 255},
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Samples"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	leg := hplot.NewLegend()
+	leg.Columns = 2
+	// the preferred location, at the top-left of the plot, overlaps
+	// with the histograms: the legend is moved elsewhere.
+	leg.Top = true
+	leg.Left = true
+	leg.AutoPlace = true
+
+	for i, col := range colors {
+		dist := distuv.Normal{
+			Mu:    -1 + 0.5*float64(i),
+			Sigma: 1,
+			Src:   rand.New(rand.NewSource(uint64(i))),
+		}
+		h := hbook.NewH1D(40, -5, +10)
+		for j := 0; j < npoints; j++ {
+			h.Fill(dist.Rand(), 1)
+		}
+		hh := hplot.NewH1D(h)
+		hh.LineStyle.Color = col
+		p.Add(hh)
+		leg.Add("sample-"+string(rune('A'+i)), hh)
+	}
+
+	// an entry with a custom thumbnail, made of a marker and a line.
+	leg.Add("model", hplot.LegendThumbnail{
+		Line: draw.LineStyle{
+			Color:  color.Black,
+			Width:  vg.Points(1),
+			Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+		},
+		Glyph: draw.GlyphStyle{
+			Color:  color.Black,
+			Radius: vg.Points(2.5),
+			Shape:  draw.CircleGlyph{},
+		},
+	})
+
+	p.Add(hplot.NewGrid())
+	p.Add(leg)
+
+	err := p.Save(15*vg.Centimeter, 10*vg.Centimeter, "testdata/legend_autoplace.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -27,6 +27,7 @@ type config struct {
 	band   bool
 	hinfos HInfos
 	log    struct {
+		x bool
 		y bool
 	}
 	glyph draw.GlyphStyle
@@ -42,6 +43,13 @@ func newConfig(opts []Options) *config {
 	return cfg
 }
 
+// WithLogX sets whether the plotter in X should handle log-scale.
+func WithLogX(v bool) Options {
+	return func(c *config) {
+		c.log.x = v
+	}
+}
+
 // WithLogY sets whether the plotter in Y should handle log-scale.
 func WithLogY(v bool) Options {
 	return func(c *config) {
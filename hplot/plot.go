@@ -18,6 +18,8 @@ import (
 type Plot struct {
 	*plot.Plot
 	Style Style
+
+	plotters []plot.Plotter // plotters added with Add.
 }
 
 // muNewPlot protects access to gonum/plot.DefaultFont
@@ -52,6 +54,9 @@ func New() *Plot {
 // order in which they were added to the plot.
 func (p *Plot) Add(ps ...plot.Plotter) {
 	for _, d := range ps {
+		if leg, ok := d.(*Legend); ok {
+			leg.plt = p
+		}
 		if x, ok := d.(plot.DataRanger); ok {
 			xmin, xmax, ymin, ymax := x.DataRange()
 			p.Plot.X.Min = math.Min(p.Plot.X.Min, xmin)
@@ -61,6 +66,7 @@ func (p *Plot) Add(ps ...plot.Plotter) {
 		}
 	}
 
+	p.plotters = append(p.plotters, ps...)
 	p.Plot.Add(ps...)
 }
 
@@ -69,7 +75,7 @@ func (p *Plot) Add(ps ...plot.Plotter) {
 //
 // Supported extensions are:
 //
-//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tex, .tif and .tiff.
+//  .eps, .html, .jpg, .jpeg, .pdf, .png, .svg, .tex, .tif and .tiff.
 //
 // If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
 // If w and h are <= 0, the values are chosen such that they follow the Golden Ratio
@@ -83,7 +89,7 @@ func (p *Plot) Save(w, h vg.Length, file string) error {
 //
 // Supported formats are:
 //
-//  eps, jpg|jpeg, pdf, png, svg, tex and tif|tiff.
+//  eps, html, jpg|jpeg, pdf, png, svg, tex and tif|tiff.
 func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 	return WriterTo(p, w, h, format)
 }
@@ -0,0 +1,20 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rootcnv_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestCanvas(t *testing.T) {
+	cmpimg.CheckPlot(ExampleCanvas, t, "tcanvas.png")
+	if !t.Failed() {
+		_ = os.Remove(path.Join("testdata", "tcanvas.png"))
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rootcnv provides tools to convert ROOT canvases and their
+// graphics primitives into hplot figures.
+//
+// The conversion is approximate: histograms, graphs, functions, pave
+// texts, texts, lines and boxes are converted into their hplot
+// counterparts, and primitives without such a counterpart are ignored.
+// The returned plots can then be re-styled with the hplot API.
+package rootcnv // import "go-hep.org/x/hep/hplot/rootcnv"
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rcolors"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rpad"
+	hbookcnv "go-hep.org/x/hep/hbook/rootcnv"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Layout draws plots in rectangular areas of a canvas.
+type Layout struct {
+	// Plots holds the plots of the layout, in drawing order.
+	Plots []*hplot.Plot
+	// Areas holds the lower-left and upper-right corners of the area
+	// of each plot, in normalized coordinates of the canvas:
+	// {xlow, ylow, xup, yup}.
+	Areas [][4]float64
+}
+
+// Draw draws the plots of the layout on the provided canvas.
+func (l *Layout) Draw(c draw.Canvas) {
+	var (
+		w = c.Max.X - c.Min.X
+		h = c.Max.Y - c.Min.Y
+	)
+	for i, p := range l.Plots {
+		area := l.Areas[i]
+		sub := draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{
+					X: c.Min.X + vg.Length(area[0])*w,
+					Y: c.Min.Y + vg.Length(area[1])*h,
+				},
+				Max: vg.Point{
+					X: c.Min.X + vg.Length(area[2])*w,
+					Y: c.Min.Y + vg.Length(area[3])*h,
+				},
+			},
+		}
+		p.Draw(sub)
+	}
+}
+
+// Canvas converts a ROOT canvas into an hplot figure.
+//
+// The plot of the returned figure is a *Layout, holding one plot per pad
+// of the canvas, drawn in the area the pad occupies in the canvas.
+// Sub-pads are converted recursively.
+func Canvas(c *rpad.Canvas) (*hplot.Fig, error) {
+	var lay Layout
+	err := addPad(&lay, &c.Pad, [4]float64{0, 0, 1, 1}, true)
+	if err != nil {
+		return nil, fmt.Errorf("rootcnv: could not convert canvas %q: %w", c.Name(), err)
+	}
+	return hplot.Figure(&lay), nil
+}
+
+// addPad converts the pad p, displayed in the provided area of the canvas,
+// and its sub-pads into plots of the layout.
+func addPad(lay *Layout, p *rpad.Pad, area [4]float64, top bool) error {
+	var (
+		prims = p.Primitives()
+		pads  []*rpad.Pad
+		draw  = 0
+	)
+	for _, prim := range prims {
+		switch prim := prim.(type) {
+		case *rpad.Canvas:
+			pads = append(pads, &prim.Pad)
+		case *rpad.Pad:
+			pads = append(pads, prim)
+		default:
+			draw++
+		}
+	}
+
+	if draw > 0 || len(pads) == 0 || !top {
+		plt, err := Pad(p)
+		if err != nil {
+			return err
+		}
+		lay.Plots = append(lay.Plots, plt)
+		lay.Areas = append(lay.Areas, area)
+	}
+
+	var (
+		w = area[2] - area[0]
+		h = area[3] - area[1]
+	)
+	for _, sub := range pads {
+		xlow, ylow, xup, yup := sub.NDC()
+		err := addPad(lay, sub, [4]float64{
+			area[0] + xlow*w, area[1] + ylow*h,
+			area[0] + xup*w, area[1] + yup*h,
+		}, false)
+		if err != nil {
+			return fmt.Errorf("could not convert pad %q: %w", sub.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Pad converts the graphics primitives of a ROOT pad into an hplot plot.
+// Sub-pads of the pad are ignored.
+func Pad(p *rpad.Pad) (*hplot.Plot, error) {
+	cnv := newPadCnv(p)
+	for _, prim := range p.Primitives() {
+		err := cnv.add(prim)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cnv.plt, nil
+}
+
+type padCnv struct {
+	pad *rpad.Pad
+	plt *hplot.Plot
+
+	frame bool // whether the pad has been painted by ROOT, with a frame.
+	title bool // whether the title of the plot has been set.
+	axes  bool // whether the labels of the axes have been set.
+}
+
+func newPadCnv(p *rpad.Pad) *padCnv {
+	cnv := &padCnv{
+		pad: p,
+		plt: hplot.New(),
+	}
+
+	for _, prim := range p.Primitives() {
+		if prim.Class() == "TFrame" {
+			cnv.frame = true
+		}
+	}
+
+	if p.Logx() {
+		cnv.plt.X.Scale = plot.LogScale{}
+		cnv.plt.X.Tick.Marker = plot.LogTicks{Prec: -1}
+	}
+	if p.Logy() {
+		cnv.plt.Y.Scale = plot.LogScale{}
+		cnv.plt.Y.Tick.Marker = plot.LogTicks{Prec: -1}
+	}
+
+	if p.Gridx() || p.Gridy() {
+		grid := hplot.NewGrid()
+		if !p.Gridx() {
+			grid.Vertical.Color = nil
+		}
+		if !p.Gridy() {
+			grid.Horizontal.Color = nil
+		}
+		cnv.plt.Add(grid)
+	}
+
+	return cnv
+}
+
+func (cnv *padCnv) add(obj root.Object) error {
+	switch obj := obj.(type) {
+	case *rpad.Canvas, *rpad.Pad:
+		// sub-pads are handled by Canvas.
+	case rhist.H2:
+		cnv.setTitle(obj.Title())
+		cnv.setAxes(obj.XAxis(), obj.YAxis())
+		cnv.plt.Add(hplot.NewH2D(hbookcnv.H2D(obj), nil))
+	case rhist.H1:
+		cnv.setTitle(obj.Title())
+		if h, ok := obj.(interface{ YAxis() rhist.Axis }); ok {
+			cnv.setAxes(obj.XAxis(), h.YAxis())
+		}
+		h := hplot.NewH1D(hbookcnv.H1D(obj), hplot.WithLogY(cnv.pad.Logy()))
+		if att, ok := obj.(lineAtter); ok {
+			h.LineStyle.Color = rootColor(att.AttLine().Color)
+			h.LineStyle.Width = lineWidth(att.AttLine().Width)
+		}
+		cnv.plt.Add(h)
+		return cnv.addFuncs(obj)
+	case rhist.Graph:
+		cnv.setTitle(obj.Title())
+		cnv.setAxes(obj.XAxis(), obj.YAxis())
+		_, errs := obj.(rhist.GraphErrors)
+		s := hplot.NewS2D(
+			hbookcnv.S2D(obj),
+			hplot.WithXErrBars(errs), hplot.WithYErrBars(errs),
+		)
+		if att, ok := obj.(markerAtter); ok {
+			s.GlyphStyle.Color = rootColor(att.AttMarker().Color)
+		}
+		cnv.plt.Add(s)
+		return cnv.addFuncs(obj)
+	case *rhist.F1:
+		return cnv.addFunc(obj)
+	case *rpad.PaveText:
+		cnv.addPaveText(obj)
+	case *rpad.Pave:
+		cnv.addBox(obj.CornersNDC())
+	case *rpad.Latex:
+		cnv.addText(&obj.Text)
+	case *rpad.Text:
+		cnv.addText(obj)
+	case *rpad.Line:
+		cnv.addLine(obj)
+	case *rpad.Box:
+		x1, y1, x2, y2 := obj.Corners()
+		poly, err := plotter.NewPolygon(plotter.XYs{{X: x1, Y: y1}, {X: x2, Y: y1}, {X: x2, Y: y2}, {X: x1, Y: y2}})
+		if err != nil {
+			return fmt.Errorf("could not create box: %w", err)
+		}
+		poly.LineStyle.Color = rootColor(obj.AttLine().Color)
+		poly.Color = fillColor(obj.AttFill())
+		cnv.plt.Add(poly)
+	}
+
+	if cnv.frame {
+		xmin, ymin, xmax, ymax := cnv.pad.RangeAxis()
+		if cnv.pad.Logx() {
+			xmin, xmax = math.Pow(10, xmin), math.Pow(10, xmax)
+		}
+		if cnv.pad.Logy() {
+			ymin, ymax = math.Pow(10, ymin), math.Pow(10, ymax)
+		}
+		cnv.plt.X.Min, cnv.plt.X.Max = xmin, xmax
+		cnv.plt.Y.Min, cnv.plt.Y.Max = ymin, ymax
+	}
+
+	return nil
+}
+
+type lineAtter interface {
+	AttLine() *rbase.AttLine
+}
+
+type markerAtter interface {
+	AttMarker() *rbase.AttMarker
+}
+
+type funcser interface {
+	Functions() []root.Object
+}
+
+func (cnv *padCnv) setTitle(title string) {
+	if cnv.title || title == "" {
+		return
+	}
+	cnv.plt.Title.Text = title
+	cnv.title = true
+}
+
+func (cnv *padCnv) setAxes(xaxis, yaxis rhist.Axis) {
+	if cnv.axes {
+		return
+	}
+	cnv.plt.X.Label.Text = xaxis.Title()
+	cnv.plt.Y.Label.Text = yaxis.Title()
+	cnv.axes = true
+}
+
+func (cnv *padCnv) addFuncs(obj root.Object) error {
+	fs, ok := obj.(funcser)
+	if !ok {
+		return nil
+	}
+	for _, f := range fs.Functions() {
+		f, ok := f.(*rhist.F1)
+		if !ok {
+			continue
+		}
+		err := cnv.addFunc(f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cnv *padCnv) addFunc(f1 *rhist.F1) error {
+	fct, err := f1.Func()
+	if err != nil {
+		return fmt.Errorf("could not convert function %q: %w", f1.Name(), err)
+	}
+	f := hplot.NewFunction(fct)
+	f.XMin, f.XMax = f1.Range()
+	f.Samples = 100
+	f.LineStyle.Color = rootColor(f1.AttLine().Color)
+	f.LineStyle.Width = lineWidth(f1.AttLine().Width)
+	f.LogY = cnv.pad.Logy()
+	cnv.plt.Add(f)
+	return nil
+}
+
+func (cnv *padCnv) addPaveText(pt *rpad.PaveText) {
+	var lines []string
+	for _, line := range pt.Lines() {
+		switch line := line.(type) {
+		case *rpad.Latex:
+			lines = append(lines, line.Text.Text())
+		case *rpad.Text:
+			lines = append(lines, line.Text())
+		}
+	}
+
+	if pt.Name() == "title" {
+		if len(lines) > 0 {
+			cnv.plt.Title.Text = lines[0]
+			cnv.title = true
+		}
+		return
+	}
+
+	x1, y1, x2, y2 := pt.CornersNDC()
+	cnv.addBox(x1, y1, x2, y2)
+	var (
+		att = pt.AttText()
+		dy  = (y2 - y1) / float64(len(lines))
+	)
+	for i, txt := range lines {
+		x := x1 + 0.05*(x2-x1)
+		y := y2 - (float64(i)+0.5)*dy
+		cnv.plt.Add(hplot.NewLabel(
+			clamp(x), clamp(y), txt,
+			hplot.WithLabelNormalized(true),
+			hplot.WithLabelTextStyle(textStyle(att)),
+		))
+	}
+}
+
+// addBox adds a box with the provided corners, in normalized coordinates.
+func (cnv *padCnv) addBox(x1, y1, x2, y2 float64) {
+	cnv.plt.Add(&ndcBox{x1: x1, y1: y1, x2: x2, y2: y2})
+}
+
+func (cnv *padCnv) addText(txt *rpad.Text) {
+	x, y := txt.Pos()
+	opts := []hplot.LabelOption{
+		hplot.WithLabelTextStyle(textStyle(txt.AttText())),
+	}
+	if txt.NDC() {
+		x, y = clamp(x), clamp(y)
+		opts = append(opts, hplot.WithLabelNormalized(true))
+	}
+	cnv.plt.Add(hplot.NewLabel(x, y, txt.Text(), opts...))
+}
+
+func (cnv *padCnv) addLine(l *rpad.Line) {
+	x1, y1, x2, y2 := l.Points()
+	if l.NDC() {
+		if !cnv.frame {
+			// no axes range to convert normalized coordinates from.
+			return
+		}
+		x1, y1 = cnv.fromNDC(x1, y1)
+		x2, y2 = cnv.fromNDC(x2, y2)
+	}
+	line, err := hplot.NewLine(plotter.XYs{{X: x1, Y: y1}, {X: x2, Y: y2}})
+	if err != nil {
+		return
+	}
+	line.LineStyle.Color = rootColor(l.AttLine().Color)
+	line.LineStyle.Width = lineWidth(l.AttLine().Width)
+	cnv.plt.Add(line)
+}
+
+// fromNDC converts normalized pad coordinates into user coordinates,
+// using the margins and axes range of the pad.
+func (cnv *padCnv) fromNDC(u, v float64) (x, y float64) {
+	var (
+		left, right, bottom, top = cnv.pad.Margins()
+		xmin, ymin, xmax, ymax   = cnv.pad.RangeAxis()
+	)
+	x = xmin + (u-left)/(1-left-right)*(xmax-xmin)
+	y = ymin + (v-bottom)/(1-bottom-top)*(ymax-ymin)
+	if cnv.pad.Logx() {
+		x = math.Pow(10, x)
+	}
+	if cnv.pad.Logy() {
+		y = math.Pow(10, y)
+	}
+	return x, y
+}
+
+// ndcBox draws a box whose corners are in normalized coordinates of the plot.
+type ndcBox struct {
+	x1, y1, x2, y2 float64
+}
+
+func (b *ndcBox) Plot(c draw.Canvas, plt *plot.Plot) {
+	var (
+		w  = c.Max.X - c.Min.X
+		h  = c.Max.Y - c.Min.Y
+		pt = func(u, v float64) vg.Point {
+			return vg.Point{
+				X: c.Min.X + vg.Length(u)*w,
+				Y: c.Min.Y + vg.Length(v)*h,
+			}
+		}
+		pts = []vg.Point{
+			pt(b.x1, b.y1), pt(b.x2, b.y1),
+			pt(b.x2, b.y2), pt(b.x1, b.y2),
+			pt(b.x1, b.y1),
+		}
+	)
+	c.FillPolygon(color.White, pts)
+	c.StrokeLines(plotter.DefaultLineStyle, pts)
+}
+
+func textStyle(att *rbase.AttText) draw.TextStyle {
+	sty := draw.TextStyle{
+		Color:   rootColor(att.Color),
+		Font:    hplot.DefaultStyle.Fonts.Tick,
+		Handler: hplot.DefaultStyle.TextHandler,
+	}
+	return sty
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func lineWidth(w int16) vg.Length {
+	if w <= 0 {
+		return 0
+	}
+	return vg.Length(w) * plotter.DefaultLineStyle.Width
+}
+
+func fillColor(att *rbase.AttFill) color.Color {
+	if att.Style == 0 {
+		// hollow.
+		return nil
+	}
+	return rootColor(att.Color)
+}
+
+// rootColor returns the color corresponding to a ROOT color index.
+// Only the basic colors (indices 0 to 9) and the base colors of the ROOT
+// color wheel are supported: colors of the wheel (kRed+2, kAzure-4, ...)
+// are mapped to their nearest base color.
+func rootColor(idx int16) color.Color {
+	if idx >= 0 && int(idx) < len(basicColors) {
+		return basicColors[idx]
+	}
+
+	var (
+		col  color.Color = color.Black
+		dist             = 11
+	)
+	for _, wc := range wheelColors {
+		d := int(idx) - wc.idx
+		if d < 0 {
+			d = -d
+		}
+		if d < dist {
+			col = wc.col
+			dist = d
+		}
+	}
+	return col
+}
+
+var basicColors = []color.Color{
+	color.White,
+	color.Black,
+	color.NRGBA{R: 255, A: 255},
+	color.NRGBA{G: 255, A: 255},
+	color.NRGBA{B: 255, A: 255},
+	color.NRGBA{R: 255, G: 255, A: 255},
+	color.NRGBA{R: 255, B: 255, A: 255},
+	color.NRGBA{G: 255, B: 255, A: 255},
+	color.NRGBA{R: 89, G: 212, B: 84, A: 255},
+	color.NRGBA{R: 89, G: 84, B: 217, A: 255},
+}
+
+var wheelColors = []struct {
+	idx int
+	col color.Color
+}{
+	{rcolors.Yellow, color.NRGBA{R: 255, G: 255, A: 255}},
+	{rcolors.Green, color.NRGBA{G: 255, A: 255}},
+	{rcolors.Cyan, color.NRGBA{G: 255, B: 255, A: 255}},
+	{rcolors.Blue, color.NRGBA{B: 255, A: 255}},
+	{rcolors.Magenta, color.NRGBA{R: 255, B: 255, A: 255}},
+	{rcolors.Red, color.NRGBA{R: 255, A: 255}},
+	{rcolors.Orange, color.NRGBA{R: 255, G: 204, A: 255}},
+	{rcolors.Spring, color.NRGBA{R: 204, G: 255, A: 255}},
+	{rcolors.Teal, color.NRGBA{G: 255, B: 204, A: 255}},
+	{rcolors.Azure, color.NRGBA{G: 204, B: 255, A: 255}},
+	{rcolors.Violet, color.NRGBA{R: 204, B: 255, A: 255}},
+	{rcolors.Pink, color.NRGBA{R: 255, B: 204, A: 255}},
+	{rcolors.Gray, color.NRGBA{R: 204, G: 204, B: 204, A: 255}},
+}
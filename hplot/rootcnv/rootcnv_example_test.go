@@ -0,0 +1,45 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rootcnv_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rpad"
+	"go-hep.org/x/hep/hplot"
+	"go-hep.org/x/hep/hplot/rootcnv"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of re-drawing a canvas created by ROOT: a graph and its
+// linear fit.
+func ExampleCanvas() {
+	f, err := groot.Open("../../groot/testdata/tcanvas.root")
+	if err != nil {
+		log.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("c1")
+	if err != nil {
+		log.Fatalf("could not retrieve canvas: %+v", err)
+	}
+
+	fig, err := rootcnv.Canvas(obj.(*rpad.Canvas))
+	if err != nil {
+		log.Fatalf("could not convert canvas: %+v", err)
+	}
+
+	// re-style the plot of the canvas.
+	plt := fig.Plot.(*rootcnv.Layout).Plots[0]
+	plt.X.Label.Text = "x"
+	plt.Y.Label.Text = "y"
+
+	err = hplot.Save(fig, 10*vg.Centimeter, 12.5*vg.Centimeter, "testdata/tcanvas.png")
+	if err != nil {
+		log.Fatalf("could not save figure: %+v", err)
+	}
+}
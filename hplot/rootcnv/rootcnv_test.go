@@ -0,0 +1,76 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rootcnv
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rpad"
+	"go-hep.org/x/hep/hbook"
+	hbookcnv "go-hep.org/x/hep/hbook/rootcnv"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestPrimitives(t *testing.T) {
+	h := hbook.NewH1D(10, 0, 10)
+	for i := 0; i < 10; i++ {
+		h.Fill(float64(i)+0.5, float64(i+1))
+	}
+	h.Annotation()["name"] = "h1"
+	h.Annotation()["title"] = "histogram title"
+
+	title := rpad.NewPaveText(0.3, 0.9, 0.7, 0.99, "blNDC")
+	title.SetName("title")
+	title.AddText("pave title")
+
+	stats := rpad.NewPaveText(0.15, 0.6, 0.45, 0.85, "NDC")
+	stats.AddText("entries = 10")
+	stats.AddText("mean = 6.5")
+	stats.AttText().Color = 4
+
+	line := rpad.NewLine(0, 5, 10, 5)
+	line.AttLine().Color = 2
+
+	box := rpad.NewBox(2, 8, 4, 9)
+	box.AttFill().Color = 3
+
+	ltx := rpad.NewLatex(0.6, 0.3, "NDC text")
+	ltx.SetNDC(true)
+
+	cnv := newPadCnv(&rpad.Pad{})
+	for _, prim := range []root.Object{
+		hbookcnv.FromH1D(h),
+		title,
+		stats,
+		line,
+		box,
+		ltx,
+		rpad.NewText(7, 2, "user text"),
+	} {
+		err := cnv.add(prim)
+		if err != nil {
+			t.Fatalf("could not convert %T: %+v", prim, err)
+		}
+	}
+
+	if got, want := cnv.plt.Title.Text, "pave title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+
+	cmpimg.CheckPlot(func() {
+		err := hplot.Save(cnv.plt, 10*vg.Centimeter, 10*vg.Centimeter, "testdata/primitives.png")
+		if err != nil {
+			t.Fatalf("could not save plot: %+v", err)
+		}
+	}, t, "primitives.png")
+	if !t.Failed() {
+		_ = os.Remove(path.Join("testdata", "primitives.png"))
+	}
+}
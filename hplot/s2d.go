@@ -39,6 +39,11 @@ type S2D struct {
 	// Steps controls the style of the connecting
 	// line (NoSteps, HiSteps, etc...)
 	Steps StepsKind
+
+	// LogX and LogY allow rendering with log-scaled X and Y axes.
+	// When enabled, points with non-positive coordinates are discarded
+	// and error bars extending below the axis are clipped.
+	LogX, LogY bool
 }
 
 // withXErrBars enables the X error bars
@@ -145,6 +150,8 @@ func NewS2D(data plotter.XYer, opts ...Options) *S2D {
 	cfg := newConfig(opts)
 
 	s.Steps = cfg.steps
+	s.LogX = cfg.log.x
+	s.LogY = cfg.log.y
 
 	if cfg.bars.xerrs {
 		_ = s.withXErrBars()
@@ -173,9 +180,17 @@ func (pts *S2D) Plot(c draw.Canvas, plt *plot.Plot) {
 		pts.Band.Plot(c, plt)
 	}
 
+	svg := newSVGSeries(c, "s2d")
 	for i := 0; i < pts.Data.Len(); i++ {
 		x, y := pts.Data.XY(i)
-		c.DrawGlyph(pts.GlyphStyle, vg.Point{X: trX(x), Y: trY(y)})
+		if !pts.valid(x, y) {
+			continue
+		}
+		pt := vg.Point{X: trX(x), Y: trY(y)}
+		c.DrawGlyph(pts.GlyphStyle, pt)
+		if svg != nil && c.Contains(pt) {
+			svg.add(i, pts.glyphRect(pt), pts.svgAttrs(i, x, y)...)
+		}
 	}
 
 	if pts.LineStyle.Width > 0 {
@@ -196,27 +211,152 @@ func (pts *S2D) Plot(c draw.Canvas, plt *plot.Plot) {
 			data = dsteps
 		}
 
-		line := plotter.Line{
-			XYs:       data,
-			LineStyle: pts.LineStyle,
+		for _, data := range pts.segments(data) {
+			line := plotter.Line{
+				XYs:       data,
+				LineStyle: pts.LineStyle,
+			}
+			line.Plot(c, plt)
 		}
-		line.Plot(c, plt)
 	}
 
-	if pts.XErrs != nil {
+	xerrs, yerrs := pts.errBars(plt)
+	if xerrs != nil {
 		pts.XErrs.LineStyle.Color = pts.GlyphStyle.Color
-		pts.XErrs.Plot(c, plt)
+		xerrs.LineStyle = pts.XErrs.LineStyle
+		xerrs.Plot(c, plt)
 	}
-	if pts.YErrs != nil {
+	if yerrs != nil {
 		pts.YErrs.LineStyle.Color = pts.GlyphStyle.Color
-		pts.YErrs.Plot(c, plt)
+		yerrs.LineStyle = pts.YErrs.LineStyle
+		yerrs.Plot(c, plt)
+	}
+}
+
+// valid returns whether the (x,y) point can be displayed on the axes.
+func (pts *S2D) valid(x, y float64) bool {
+	return !(pts.LogX && x <= 0) && !(pts.LogY && y <= 0)
+}
+
+// segments splits data into the runs of points that can be displayed
+// on the axes.
+func (pts *S2D) segments(data plotter.XYs) []plotter.XYs {
+	if !pts.LogX && !pts.LogY {
+		return []plotter.XYs{data}
+	}
+	var (
+		segs []plotter.XYs
+		cur  plotter.XYs
+	)
+	for _, pt := range data {
+		if !pts.valid(pt.X, pt.Y) {
+			if len(cur) > 0 {
+				segs = append(segs, cur)
+			}
+			cur = nil
+			continue
+		}
+		cur = append(cur, pt)
+	}
+	if len(cur) > 0 {
+		segs = append(segs, cur)
 	}
+	return segs
+}
+
+// errBars returns the error bars to display.
+// With log-scaled axes, the error bars of the points that can not be
+// displayed are dropped and the others are clipped to the minimum of
+// the axes.
+func (pts *S2D) errBars(plt *plot.Plot) (*plotter.XErrorBars, *plotter.YErrorBars) {
+	if !pts.LogX && !pts.LogY {
+		return pts.XErrs, pts.YErrs
+	}
+	if pts.XErrs == nil && pts.YErrs == nil {
+		return nil, nil
+	}
+
+	var (
+		data plotter.XYs
+		xerr plotter.XErrors
+		yerr plotter.YErrors
+	)
+	for i := 0; i < pts.Data.Len(); i++ {
+		x, y := pts.Data.XY(i)
+		if !pts.valid(x, y) {
+			continue
+		}
+		data = append(data, plotter.XY{X: x, Y: y})
+		if pts.XErrs != nil {
+			lo, hi := pts.XErrs.XError(i)
+			if pts.LogX && x-lo < plt.X.Min {
+				lo = x - plt.X.Min
+			}
+			xerr = append(xerr, struct{ Low, High float64 }{lo, hi})
+		}
+		if pts.YErrs != nil {
+			lo, hi := pts.YErrs.YError(i)
+			if pts.LogY && y-lo < plt.Y.Min {
+				lo = y - plt.Y.Min
+			}
+			yerr = append(yerr, struct{ Low, High float64 }{lo, hi})
+		}
+	}
+
+	var (
+		xerrs *plotter.XErrorBars
+		yerrs *plotter.YErrorBars
+	)
+	if pts.XErrs != nil {
+		xerrs = &plotter.XErrorBars{
+			XYs:       data,
+			XErrors:   xerr,
+			LineStyle: pts.XErrs.LineStyle,
+			CapWidth:  pts.XErrs.CapWidth,
+		}
+	}
+	if pts.YErrs != nil {
+		yerrs = &plotter.YErrorBars{
+			XYs:       data,
+			YErrors:   yerr,
+			LineStyle: pts.YErrs.LineStyle,
+			CapWidth:  pts.YErrs.CapWidth,
+		}
+	}
+	return xerrs, yerrs
+}
+
+// glyphRect returns the area covered by the glyph drawn at pt.
+func (pts *S2D) glyphRect(pt vg.Point) vg.Rectangle {
+	r := pts.GlyphStyle.Radius
+	return vg.Rectangle{
+		Min: vg.Point{X: pt.X - r, Y: pt.Y - r},
+		Max: vg.Point{X: pt.X + r, Y: pt.Y + r},
+	}
+}
+
+// svgAttrs returns the values of the i-th data point, embedded in SVG plots.
+func (pts *S2D) svgAttrs(i int, x, y float64) []svgAttr {
+	attrs := []svgAttr{{"x", x}, {"y", y}}
+	if xerr, ok := pts.Data.(plotter.XErrorer); ok {
+		lo, hi := xerr.XError(i)
+		attrs = append(attrs, svgAttr{"xerr-lo", lo}, svgAttr{"xerr-hi", hi})
+	}
+	if yerr, ok := pts.Data.(plotter.YErrorer); ok {
+		lo, hi := yerr.YError(i)
+		attrs = append(attrs, svgAttr{"yerr-lo", lo}, svgAttr{"yerr-hi", hi})
+	}
+	return attrs
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
 func (pts *S2D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if pts.LogX || pts.LogY {
+		return pts.logDataRange()
+	}
+
 	if dr, ok := pts.Data.(plot.DataRanger); ok {
 		xmin, xmax, ymin, ymax = dr.DataRange()
 	} else {
@@ -242,21 +382,61 @@ func (pts *S2D) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return xmin, xmax, ymin, ymax
 }
 
+// logDataRange returns the range of the data points that can be displayed
+// on log-scaled axes.
+func (pts *S2D) logDataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(+1), math.Inf(-1)
+	ymin, ymax = math.Inf(+1), math.Inf(-1)
+	for i := 0; i < pts.Data.Len(); i++ {
+		x, y := pts.Data.XY(i)
+		if !pts.valid(x, y) {
+			continue
+		}
+		xlo, xhi := x, x
+		ylo, yhi := y, y
+		if pts.XErrs != nil {
+			lo, hi := pts.XErrs.XError(i)
+			xlo, xhi = x-lo, x+hi
+		}
+		if pts.YErrs != nil {
+			lo, hi := pts.YErrs.YError(i)
+			ylo, yhi = y-lo, y+hi
+		}
+		if pts.LogX && xlo <= 0 {
+			xlo = x
+		}
+		if pts.LogY && ylo <= 0 {
+			ylo = y
+		}
+		xmin = math.Min(xmin, xlo)
+		xmax = math.Max(xmax, xhi)
+		ymin = math.Min(ymin, ylo)
+		ymax = math.Max(ymax, yhi)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
 // GlyphBoxes returns a slice of plot.GlyphBoxes,
 // implementing the plot.GlyphBoxer interface.
 func (pts *S2D) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
-	bs := make([]plot.GlyphBox, pts.Data.Len())
+	bs := make([]plot.GlyphBox, 0, pts.Data.Len())
 	for i := 0; i < pts.Data.Len(); i++ {
 		x, y := pts.Data.XY(i)
-		bs[i].X = plt.X.Norm(x)
-		bs[i].Y = plt.Y.Norm(y)
-		bs[i].Rectangle = pts.GlyphStyle.Rectangle()
+		if !pts.valid(x, y) {
+			continue
+		}
+		bs = append(bs, plot.GlyphBox{
+			X:         plt.X.Norm(x),
+			Y:         plt.Y.Norm(y),
+			Rectangle: pts.GlyphStyle.Rectangle(),
+		})
 	}
-	if pts.XErrs != nil {
-		bs = append(bs, pts.XErrs.GlyphBoxes(plt)...)
+	xerrs, yerrs := pts.errBars(plt)
+	if xerrs != nil {
+		bs = append(bs, xerrs.GlyphBoxes(plt)...)
 	}
-	if pts.YErrs != nil {
-		bs = append(bs, pts.YErrs.GlyphBoxes(plt)...)
+	if yerrs != nil {
+		bs = append(bs, yerrs.GlyphBoxes(plt)...)
 	}
 	return bs
 }
@@ -0,0 +1,350 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SigmaBand implements the plot.Plotter interface, drawing the ±1σ and
+// ±2σ uncertainty bands around a central curve or histogram.
+//
+// SigmaBand can be used to display the expected limits of a search
+// ("Brazil" plots) or the systematic uncertainties of a distribution.
+// The ±2σ band is drawn first, then the ±1σ band and the central line.
+type SigmaBand struct {
+	// Central holds the central (nominal or median) values.
+	Central plotter.XYs
+
+	// Lo1 and Hi1 hold the values at -1σ and +1σ, one per central value.
+	Lo1, Hi1 []float64
+
+	// Lo2 and Hi2 hold the values at -2σ and +2σ, one per central value.
+	// The ±2σ band is not drawn when Lo2 and Hi2 are empty.
+	Lo2, Hi2 []float64
+
+	// Edges holds the edges of the bins of a binned quantity.
+	// When Edges is not empty, the band and the central line are drawn
+	// as steps, the i-th values spanning the [Edges[i], Edges[i+1]]
+	// range.
+	Edges []float64
+
+	// LineStyle is the style of the central line.
+	// Use zero width to disable.
+	LineStyle draw.LineStyle
+
+	// Fill1 and Fill2 are the colors filling the ±1σ and ±2σ bands.
+	// Use nil to disable the filling.
+	Fill1, Fill2 color.Color
+
+	// Hatch1 and Hatch2 are the hatching styles of the ±1σ and ±2σ
+	// bands.
+	Hatch1, Hatch2 Hatch
+}
+
+// Hatch describes the hatching of an area, made of parallel lines.
+type Hatch struct {
+	// LineStyle is the style of the hatching lines.
+	// Use zero width to disable the hatching.
+	draw.LineStyle
+
+	// Angle is the angle of the lines, in degrees, counter-clockwise
+	// from the X-axis.
+	Angle float64
+
+	// Spacing is the distance between two lines.
+	Spacing vg.Length
+}
+
+// ExpectedCLser is the interface implemented by values holding the
+// expected confidence levels of a limit computation, such as a
+// groot/rhist.ConfidenceLevel.
+type ExpectedCLser interface {
+	// ExpectedCLsB returns the expected CLs under the background-only
+	// hypothesis, for the band at sigma standard deviations from the
+	// median.
+	ExpectedCLsB(sigma int) float64
+}
+
+// NewSigmaBand returns a new ±1σ/±2σ band around the central values.
+// lo2 and hi2 may be nil to only draw the ±1σ band.
+//
+// NewSigmaBand panics if the number of values at ±1σ or ±2σ differs from
+// the number of central values.
+func NewSigmaBand(central plotter.XYer, lo1, hi1, lo2, hi2 []float64) *SigmaBand {
+	n := central.Len()
+	for _, v := range []struct {
+		name string
+		vs   []float64
+		ok   bool
+	}{
+		{"-1σ", lo1, len(lo1) == n},
+		{"+1σ", hi1, len(hi1) == n},
+		{"-2σ", lo2, len(lo2) == n || (lo2 == nil && hi2 == nil)},
+		{"+2σ", hi2, len(hi2) == n || (lo2 == nil && hi2 == nil)},
+	} {
+		if !v.ok {
+			panic(fmt.Errorf(
+				"hplot: invalid number of %s values (got=%d, want=%d)",
+				v.name, len(v.vs), n,
+			))
+		}
+	}
+
+	band := &SigmaBand{
+		Central: make(plotter.XYs, n),
+		Lo1:     append([]float64(nil), lo1...),
+		Hi1:     append([]float64(nil), hi1...),
+		Lo2:     append([]float64(nil), lo2...),
+		Hi2:     append([]float64(nil), hi2...),
+		LineStyle: draw.LineStyle{
+			Color:  color.Black,
+			Width:  vg.Points(1),
+			Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+		},
+		Fill1: color.NRGBA{G: 200, A: 255},
+		Fill2: color.NRGBA{R: 255, G: 230, A: 255},
+	}
+	for i := range band.Central {
+		band.Central[i].X, band.Central[i].Y = central.XY(i)
+	}
+
+	return band
+}
+
+// NewSigmaBandFromH1D returns a new band around the content of the
+// provided histogram, drawn as steps following its bins.
+// err1 and err2 hold the ±1σ and ±2σ uncertainties of each bin.
+// err2 may be nil to only draw the ±1σ band.
+func NewSigmaBandFromH1D(h *hbook.H1D, err1, err2 []float64) *SigmaBand {
+	var (
+		bins    = h.Binning.Bins
+		n       = len(bins)
+		central = make(plotter.XYs, n)
+		lo1     = make([]float64, len(err1))
+		hi1     = make([]float64, len(err1))
+		lo2     []float64
+		hi2     []float64
+		edges   = make([]float64, n+1)
+	)
+	if err2 != nil {
+		lo2 = make([]float64, len(err2))
+		hi2 = make([]float64, len(err2))
+	}
+	for i, bin := range bins {
+		central[i].X = bin.XMid()
+		central[i].Y = bin.SumW()
+		edges[i] = bin.XMin()
+		edges[i+1] = bin.XMax()
+	}
+	for i, e := range err1 {
+		if i < n {
+			lo1[i] = central[i].Y - e
+			hi1[i] = central[i].Y + e
+		}
+	}
+	for i, e := range err2 {
+		if i < n {
+			lo2[i] = central[i].Y - e
+			hi2[i] = central[i].Y + e
+		}
+	}
+
+	band := NewSigmaBand(central, lo1, hi1, lo2, hi2)
+	band.Edges = edges
+	return band
+}
+
+// NewSigmaBandFromCLs returns a new band of the expected CLs values, under
+// the background-only hypothesis, as a function of x, as displayed on
+// "Brazil" plots.
+// The central line is the median expected CLs.
+//
+// NewSigmaBandFromCLs panics if xs and cls do not have the same length.
+func NewSigmaBandFromCLs(xs []float64, cls []ExpectedCLser) *SigmaBand {
+	if len(xs) != len(cls) {
+		panic(fmt.Errorf(
+			"hplot: invalid number of confidence levels (got=%d, want=%d)",
+			len(cls), len(xs),
+		))
+	}
+
+	var (
+		n       = len(xs)
+		central = make(plotter.XYs, n)
+		lo1     = make([]float64, n)
+		hi1     = make([]float64, n)
+		lo2     = make([]float64, n)
+		hi2     = make([]float64, n)
+	)
+	for i, cl := range cls {
+		central[i].X = xs[i]
+		central[i].Y = cl.ExpectedCLsB(0)
+		v1, v2 := cl.ExpectedCLsB(-1), cl.ExpectedCLsB(+1)
+		lo1[i], hi1[i] = math.Min(v1, v2), math.Max(v1, v2)
+		v1, v2 = cl.ExpectedCLsB(-2), cl.ExpectedCLsB(+2)
+		lo2[i], hi2[i] = math.Min(v1, v2), math.Max(v1, v2)
+	}
+
+	return NewSigmaBand(central, lo1, hi1, lo2, hi2)
+}
+
+// Plot implements the Plotter interface, drawing the bands and the
+// central line.
+func (band *SigmaBand) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	if len(band.Lo2) != 0 {
+		pts := band.polygon(trX, trY, band.Lo2, band.Hi2)
+		if band.Fill2 != nil {
+			c.FillPolygon(band.Fill2, c.ClipPolygonXY(pts))
+		}
+		band.Hatch2.draw(c, pts)
+	}
+
+	pts := band.polygon(trX, trY, band.Lo1, band.Hi1)
+	if band.Fill1 != nil {
+		c.FillPolygon(band.Fill1, c.ClipPolygonXY(pts))
+	}
+	band.Hatch1.draw(c, pts)
+
+	if band.LineStyle.Width == 0 || len(band.Central) == 0 {
+		return
+	}
+	ys := make([]float64, len(band.Central))
+	for i, pt := range band.Central {
+		ys[i] = pt.Y
+	}
+	c.StrokeLines(band.LineStyle, c.ClipLinesXY(band.line(trX, trY, ys))...)
+}
+
+// line returns the canvas points of the line going through the
+// provided values.
+func (band *SigmaBand) line(trX, trY func(float64) vg.Length, ys []float64) []vg.Point {
+	if len(band.Edges) == 0 {
+		pts := make([]vg.Point, len(ys))
+		for i, y := range ys {
+			pts[i] = vg.Point{X: trX(band.Central[i].X), Y: trY(y)}
+		}
+		return pts
+	}
+
+	pts := make([]vg.Point, 0, 2*len(ys))
+	for i, y := range ys {
+		pts = append(pts,
+			vg.Point{X: trX(band.Edges[i]), Y: trY(y)},
+			vg.Point{X: trX(band.Edges[i+1]), Y: trY(y)},
+		)
+	}
+	return pts
+}
+
+// polygon returns the canvas points of the polygon enclosing the area
+// between the lo and hi values.
+func (band *SigmaBand) polygon(trX, trY func(float64) vg.Length, lo, hi []float64) []vg.Point {
+	var (
+		bot = band.line(trX, trY, lo)
+		top = band.line(trX, trY, hi)
+		pts = make([]vg.Point, 0, len(bot)+len(top))
+	)
+	pts = append(pts, bot...)
+	for i := range top {
+		pts = append(pts, top[len(top)-1-i])
+	}
+	return pts
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger interface.
+func (band *SigmaBand) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = plotter.XYRange(band.Central)
+	if n := len(band.Edges); n != 0 {
+		xmin = band.Edges[0]
+		xmax = band.Edges[n-1]
+	}
+	for _, vs := range [][]float64{band.Lo1, band.Hi1, band.Lo2, band.Hi2} {
+		for _, v := range vs {
+			ymin = math.Min(ymin, v)
+			ymax = math.Max(ymax, v)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// draw draws the hatching lines inside the provided polygon.
+func (h Hatch) draw(c draw.Canvas, poly []vg.Point) {
+	if h.Width == 0 || h.Spacing <= 0 || len(poly) < 3 {
+		return
+	}
+
+	var (
+		rad    = h.Angle * math.Pi / 180
+		dx, dy = math.Cos(rad), math.Sin(rad) // direction of the lines
+		nx, ny = -dy, dx                      // normal to the lines
+		step   = float64(h.Spacing)
+		smin   = math.Inf(+1)
+		smax   = math.Inf(-1)
+	)
+	proj := func(p vg.Point, x, y float64) float64 {
+		return float64(p.X)*x + float64(p.Y)*y
+	}
+	for _, p := range poly {
+		s := proj(p, nx, ny)
+		smin = math.Min(smin, s)
+		smax = math.Max(smax, s)
+	}
+
+	var ts []float64
+	for s := math.Ceil(smin/step) * step; s <= smax; s += step {
+		// find the crossings of the line with the edges of the polygon,
+		// and draw the segments inside the polygon (even-odd rule.)
+		ts = ts[:0]
+		for i := range poly {
+			var (
+				p  = poly[i]
+				q  = poly[(i+1)%len(poly)]
+				sp = proj(p, nx, ny) - s
+				sq = proj(q, nx, ny) - s
+			)
+			if (sp > 0) == (sq > 0) {
+				continue
+			}
+			f := sp / (sp - sq)
+			pt := vg.Point{
+				X: p.X + vg.Length(f)*(q.X-p.X),
+				Y: p.Y + vg.Length(f)*(q.Y-p.Y),
+			}
+			ts = append(ts, proj(pt, dx, dy))
+		}
+		sort.Float64s(ts)
+		for i := 0; i+1 < len(ts); i += 2 {
+			var (
+				beg = vg.Point{
+					X: vg.Length(s*nx + ts[i]*dx),
+					Y: vg.Length(s*ny + ts[i]*dy),
+				}
+				end = vg.Point{
+					X: vg.Length(s*nx + ts[i+1]*dx),
+					Y: vg.Length(s*ny + ts[i+1]*dy),
+				}
+			)
+			c.StrokeLines(h.LineStyle, c.ClipLinesXY([]vg.Point{beg, end})...)
+		}
+	}
+}
+
+var (
+	_ plot.Plotter    = (*SigmaBand)(nil)
+	_ plot.DataRanger = (*SigmaBand)(nil)
+)
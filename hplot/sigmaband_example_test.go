@@ -0,0 +1,128 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of making a "Brazil" plot, displaying the expected CLs of a
+// search as a function of the signal strength.
+func ExampleSigmaBand() {
+	newH1 := func(vs ...float64) *rhist.H1D {
+		h := hbook.NewH1D(len(vs), 0, float64(len(vs)))
+		for i, v := range vs {
+			h.Fill(float64(i)+0.5, v)
+		}
+		return rhist.NewH1DFrom(h)
+	}
+
+	var (
+		sig  = []float64{1, 2, 4, 2, 1}
+		bkg  = []float64{10, 10, 10, 10, 10}
+		data = []float64{11, 10, 13, 9, 10}
+
+		mus = []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4}
+		cls = make([]hplot.ExpectedCLser, len(mus))
+		obs = make(plotter.XYs, len(mus))
+	)
+
+	for i, mu := range mus {
+		s := make([]float64, len(sig))
+		for j, v := range sig {
+			s[j] = mu * v
+		}
+		src := rhist.NewLimitDataSource()
+		err := src.AddChannel(newH1(s...), newH1(bkg...), newH1(data...))
+		if err != nil {
+			log.Fatalf("could not add channel: %+v", err)
+		}
+
+		cl, err := rhist.ComputeLimit(src, 2000, false, rand.NewSource(1234))
+		if err != nil {
+			log.Fatalf("could not compute limit: %+v", err)
+		}
+		cls[i] = cl
+		obs[i].X = mu
+		obs[i].Y = cl.CLs(false)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Expected CLs"
+	p.X.Label.Text = "Signal strength"
+	p.Y.Label.Text = "CLs"
+
+	p.Add(hplot.NewSigmaBandFromCLs(mus, cls))
+
+	line, err := hplot.NewLine(obs)
+	if err != nil {
+		log.Fatalf("could not create line: %+v", err)
+	}
+	line.LineStyle.Width = vg.Points(1.5)
+	p.Add(line)
+
+	cl95 := hplot.HLine(0.05, nil, nil)
+	cl95.Line.Color = color.NRGBA{R: 255, A: 255}
+	p.Add(cl95)
+	p.Add(hplot.NewGrid())
+
+	err = p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/sigmaband_cls.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// An example of displaying a systematic uncertainty band around a
+// histogram.
+func ExampleSigmaBand_fromH1D() {
+	const npoints = 10000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	h := hbook.NewH1D(20, -4, +4)
+	for i := 0; i < npoints; i++ {
+		h.Fill(dist.Rand(), 1)
+	}
+
+	// a 10% systematic uncertainty.
+	syst := make([]float64, h.Len())
+	for i := range syst {
+		syst[i] = 0.1 * h.Value(i)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Systematic uncertainties"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	band := hplot.NewSigmaBandFromH1D(h, syst, nil)
+	band.Fill1 = color.NRGBA{B: 255, A: 60}
+	band.Hatch1.Color = color.NRGBA{B: 255, A: 255}
+	band.Hatch1.Width = vg.Points(0.5)
+	band.Hatch1.Angle = 45
+	band.Hatch1.Spacing = vg.Points(4)
+	band.LineStyle.Dashes = nil
+	p.Add(band)
+	p.Add(hplot.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/sigmaband_h1d.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestSigmaBand(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleSigmaBand, t, "sigmaband_cls.png")
+}
+
+func TestSigmaBandFromH1D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleSigmaBand_fromH1D, t, "sigmaband_h1d.png")
+}
@@ -0,0 +1,156 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// svgCanvas is a SVG canvas that records the location and the values of
+// the data points drawn by the plotters, and embeds them in the SVG
+// document.
+//
+// Each data point is embedded as an invisible rectangle covering the
+// drawn point (or bin), with an id attribute identifying the point and
+// one data-xxx attribute per value of the point.
+// All these rectangles are held in a group with the "hplot-data" id,
+// drawn on top of the plot.
+type svgCanvas struct {
+	*vgsvg.Canvas
+
+	nseries int
+	pts     []svgPoint
+}
+
+func newSVGCanvas(w, h vg.Length) *svgCanvas {
+	return &svgCanvas{Canvas: vgsvg.New(w, h)}
+}
+
+// svgPoint describes a data point embedded in a SVG document.
+type svgPoint struct {
+	id    string
+	class string
+	rect  vg.Rectangle // area covered by the data point.
+	attrs []svgAttr
+}
+
+// svgAttr is a value of a data point, embedded as a data-<name> attribute.
+type svgAttr struct {
+	name  string
+	value float64
+}
+
+// WriteTo writes the canvas to an io.Writer.
+func (c *svgCanvas) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+	_, err := c.Canvas.WriteTo(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	// insert the data points before the closing </svg>.
+	raw := bytes.TrimRight(buf.Bytes(), "\n")
+	if !bytes.HasSuffix(raw, []byte("</svg>")) {
+		return 0, fmt.Errorf("hplot: invalid SVG document")
+	}
+	raw = raw[:len(raw)-len("</svg>")]
+
+	out := bytes.NewBuffer(raw)
+	_, h := c.Size()
+	fmt.Fprintf(out, "<g id=\"hplot-data\" fill=\"transparent\" stroke=\"none\">\n")
+	for _, pt := range c.pts {
+		fmt.Fprintf(out,
+			"<rect id=%q class=%q x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"",
+			pt.id, pt.class,
+			svgFloat(pt.rect.Min.X.Points()),
+			svgFloat((h - pt.rect.Max.Y).Points()),
+			svgFloat(pt.rect.Size().X.Points()),
+			svgFloat(pt.rect.Size().Y.Points()),
+		)
+		for _, attr := range pt.attrs {
+			fmt.Fprintf(out, " data-%s=\"%s\"", attr.name, svgFloat(attr.value))
+		}
+		fmt.Fprintf(out, "/>\n")
+	}
+	fmt.Fprintf(out, "</g>\n</svg>\n")
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+func svgFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// svgSeries records the data points drawn by a plotter on a svgCanvas.
+// Data points of a series are identified by <kind><series>-<index>.
+type svgSeries struct {
+	c    *svgCanvas
+	kind string
+	id   int
+}
+
+// newSVGSeries returns a new series of data points for the given kind of
+// plotter, or nil if the canvas does not record data points.
+func newSVGSeries(c draw.Canvas, kind string) *svgSeries {
+	// draw.Canvas values may wrap other draw.Canvas values.
+	var sc *svgCanvas
+	for vc := c.Canvas; sc == nil; {
+		switch v := vc.(type) {
+		case *svgCanvas:
+			sc = v
+		case *htmlCanvas:
+			sc = v.svgCanvas
+		case draw.Canvas:
+			vc = v.Canvas
+		case *draw.Canvas:
+			vc = v.Canvas
+		default:
+			return nil
+		}
+	}
+	s := &svgSeries{c: sc, kind: kind, id: sc.nseries}
+	sc.nseries++
+	return s
+}
+
+// add records the i-th data point of the series, covering the provided
+// area of the canvas, with the provided values.
+// add is a no-op for a nil series.
+func (s *svgSeries) add(i int, rect vg.Rectangle, attrs ...svgAttr) {
+	if s == nil {
+		return
+	}
+	if rect.Min.X > rect.Max.X {
+		rect.Min.X, rect.Max.X = rect.Max.X, rect.Min.X
+	}
+	if rect.Min.Y > rect.Max.Y {
+		rect.Min.Y, rect.Max.Y = rect.Max.Y, rect.Min.Y
+	}
+	s.c.pts = append(s.c.pts, svgPoint{
+		id:    s.kind + strconv.Itoa(s.id) + "-" + strconv.Itoa(i),
+		class: "hplot-" + s.kind,
+		rect:  rect,
+		attrs: attrs,
+	})
+}
+
+var (
+	_ vg.CanvasWriterTo = (*svgCanvas)(nil)
+)
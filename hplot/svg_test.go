@@ -0,0 +1,118 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestSVGData(t *testing.T) {
+	h1 := hbook.NewH1D(4, 0, 4)
+	h1.Fill(1.5, 2)
+	h1.Fill(2.5, 3)
+
+	h2 := hbook.NewH2D(2, 0, 4, 2, 0, 4)
+	h2.Fill(1, 1, 1)
+	h2.Fill(3, 3, 2)
+
+	p := New()
+	p.Add(
+		NewS2D(plotter.XYs{{X: 1, Y: 1}, {X: 2, Y: 4}, {X: 3, Y: 9}}),
+		NewH1D(h1),
+		NewH2D(h2, nil),
+	)
+
+	type rect struct {
+		ID    string     `xml:"id,attr"`
+		Class string     `xml:"class,attr"`
+		Attrs []xml.Attr `xml:",any,attr"`
+	}
+	type group struct {
+		ID    string `xml:"id,attr"`
+		Rects []rect `xml:"rect"`
+	}
+	type doc struct {
+		Groups []group `xml:"g"`
+	}
+
+	parse := func(fig *Fig) []rect {
+		t.Helper()
+		w, err := WriterTo(fig, -1, -1, "svg")
+		if err != nil {
+			t.Fatalf("could not create writer: %+v", err)
+		}
+		buf := new(bytes.Buffer)
+		_, err = w.WriteTo(buf)
+		if err != nil {
+			t.Fatalf("could not write svg: %+v", err)
+		}
+
+		var svg doc
+		err = xml.Unmarshal(buf.Bytes(), &svg)
+		if err != nil {
+			t.Fatalf("could not parse svg: %+v", err)
+		}
+		for _, g := range svg.Groups {
+			if g.ID == "hplot-data" {
+				return g.Rects
+			}
+		}
+		return nil
+	}
+
+	if rects := parse(Figure(p)); rects != nil {
+		t.Fatalf("unexpected data points: %v", rects)
+	}
+
+	rects := parse(Figure(p, WithSVGData(true)))
+
+	var ids []string
+	data := make(map[string]map[string]string)
+	for _, r := range rects {
+		ids = append(ids, r.ID)
+		data[r.ID] = make(map[string]string)
+		for _, attr := range r.Attrs {
+			data[r.ID][attr.Name.Local] = attr.Value
+		}
+		if want := "hplot-" + r.ID[:3]; r.Class != want {
+			t.Fatalf("invalid class for %q: got=%q, want=%q", r.ID, r.Class, want)
+		}
+	}
+
+	want := []string{
+		"s2d0-0", "s2d0-1", "s2d0-2",
+		"h1d1-0", "h1d1-1", "h1d1-2", "h1d1-3",
+		"h2d2-0", "h2d2-1", "h2d2-2", "h2d2-3",
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("invalid ids:\ngot= %q\nwant=%q", ids, want)
+	}
+
+	for _, tc := range []struct {
+		id    string
+		attrs map[string]string
+	}{
+		{"s2d0-1", map[string]string{"data-x": "2", "data-y": "4"}},
+		{"h1d1-2", map[string]string{"data-xmin": "2", "data-xmax": "3", "data-y": "3", "data-yerr": "3"}},
+		{"h2d2-3", map[string]string{"data-xmin": "2", "data-xmax": "4", "data-ymin": "2", "data-ymax": "4", "data-z": "2"}},
+	} {
+		for k, v := range tc.attrs {
+			if got := data[tc.id][k]; got != v {
+				t.Fatalf("invalid %s for %q: got=%q, want=%q", k, tc.id, got, v)
+			}
+		}
+		for _, k := range []string{"x", "y", "width", "height"} {
+			if _, ok := data[tc.id][k]; !ok {
+				t.Fatalf("missing %s attribute for %q", k, tc.id)
+			}
+		}
+	}
+}
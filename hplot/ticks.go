@@ -94,3 +94,91 @@ func (tck Ticks) Ticks(min, max float64) []plot.Tick {
 	}
 	return ticks
 }
+
+// LogTicks implements plot.Ticker for log-scaled axes.
+//
+// LogTicks displays a labeled major tick at each power of ten and
+// unlabeled minor ticks at its 2 to 9 multiples.
+// Minor ticks are labeled as well when the range contains less than two
+// powers of ten.
+// When the range spans more than N decades, only one decade every few
+// is labeled and minor ticks are not displayed.
+//
+// LogTicks panics if min or max are not strictly positive.
+type LogTicks struct {
+	N int // N is the maximum number of labeled decades. The default is 8.
+
+	// Format is an optional tick formatter.
+	// If nil, ticks are labeled in scientific notation (e.g. "1e+03".)
+	Format func(v float64) string
+}
+
+// Ticks returns Ticks in the specified range.
+func (tck LogTicks) Ticks(min, max float64) []plot.Tick {
+	if min <= 0 || max <= 0 {
+		panic("hplot: values must be strictly positive for a log scale")
+	}
+	if tck.N <= 0 {
+		tck.N = 8
+	}
+	format := tck.Format
+	if format == nil {
+		format = formatLogTick
+	}
+
+	var (
+		lo   = math.Floor(math.Log10(min))
+		hi   = math.Ceil(math.Log10(max))
+		ndec = int(hi - lo)
+		step = 1
+	)
+	if ndec > tck.N {
+		step = (ndec + tck.N - 1) / tck.N
+	}
+
+	// tolerance on the range boundaries, to cope with rounding errors.
+	var (
+		tmin = min * (1 - 1e-9)
+		tmax = max * (1 + 1e-9)
+	)
+
+	var ticks []plot.Tick
+	for e := lo; e <= hi; e++ {
+		base := math.Pow(10, e)
+		if tmin <= base && base <= tmax {
+			tick := plot.Tick{Value: base}
+			if int(e)%step == 0 {
+				tick.Label = format(base)
+			}
+			ticks = append(ticks, tick)
+		}
+		if step != 1 {
+			continue
+		}
+		for k := 2.0; k < 10; k++ {
+			v := k * base
+			if v < tmin || tmax < v {
+				continue
+			}
+			ticks = append(ticks, plot.Tick{Value: v})
+		}
+	}
+
+	labels := 0
+	for _, tick := range ticks {
+		if tick.Label != "" {
+			labels++
+		}
+	}
+	if labels < 2 {
+		for i := range ticks {
+			ticks[i].Label = format(ticks[i].Value)
+		}
+	}
+	return ticks
+}
+
+// formatLogTick returns the scientific notation of v.
+func formatLogTick(v float64) string {
+	return strconv.FormatFloat(v, 'e', -1, 64)
+}
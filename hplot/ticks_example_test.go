@@ -5,9 +5,14 @@
 package hplot_test
 
 import (
+	"image/color"
 	"log"
+	"math"
 
+	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -75,3 +80,60 @@ func ExampleTicks() {
 		log.Fatalf("error: %+v\n", err)
 	}
 }
+
+// An example of a plot with log-scaled X and Y axes, displaying a
+// histogram, a scatter plot and a function.
+// Bins, points and error bars extending to non-positive values are
+// clipped.
+func ExampleLogTicks() {
+	p := hplot.New()
+	p.Title.Text = "Log-log plot"
+	p.X.Label.Text = "Energy [GeV]"
+	p.Y.Label.Text = "Events"
+	p.X.Scale = plot.LogScale{}
+	p.X.Tick.Marker = hplot.LogTicks{}
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = hplot.LogTicks{}
+
+	h := hbook.NewH1DFromEdges([]float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000})
+	for i, v := range []float64{2000, 1500, 800, 300, 120, 40, 12, 3, 0.5, 0} {
+		h.Fill(h.Binning.Bins[i].XMid(), v)
+	}
+	hh := hplot.NewH1D(h, hplot.WithLogX(true), hplot.WithLogY(true))
+	hh.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+	p.Add(hh)
+
+	pts := hbook.NewS2D(
+		hbook.Point2D{X: -1, Y: 100},
+		hbook.Point2D{X: 1.5, Y: 1000, ErrY: hbook.Range{Min: 300, Max: 300}},
+		hbook.Point2D{X: 4, Y: 500, ErrY: hbook.Range{Min: 100, Max: 100}},
+		hbook.Point2D{X: 15, Y: 80, ErrY: hbook.Range{Min: 30, Max: 30}},
+		hbook.Point2D{X: 40, Y: 0},
+		hbook.Point2D{X: 80, Y: 8, ErrY: hbook.Range{Min: 10, Max: 10}},
+		hbook.Point2D{X: 300, Y: 1, ErrY: hbook.Range{Min: 2, Max: 2}},
+	)
+	sp := hplot.NewS2D(pts,
+		hplot.WithYErrBars(true),
+		hplot.WithLogX(true),
+		hplot.WithLogY(true),
+	)
+	sp.GlyphStyle.Shape = draw.CircleGlyph{}
+	sp.LineStyle = plotter.DefaultLineStyle
+	sp.LineStyle.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	p.Add(sp)
+
+	f := hplot.NewFunction(func(x float64) float64 {
+		return 2000 * math.Pow(x, -1.5)
+	})
+	f.LogX = true
+	f.LogY = true
+	f.Color = color.NRGBA{R: 255, A: 255}
+	p.Add(f)
+
+	p.Add(hplot.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/logticks.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
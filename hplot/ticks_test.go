@@ -5,11 +5,40 @@
 package hplot_test
 
 import (
+	"reflect"
 	"testing"
 
+	"go-hep.org/x/hep/hplot"
+
 	"gonum.org/v1/plot/cmpimg"
 )
 
 func TestTicks(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleTicks, t, "ticks.png")
 }
+
+func TestLogTicks(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleLogTicks, t, "logticks.png")
+}
+
+func TestLogTicksLabels(t *testing.T) {
+	for _, tc := range []struct {
+		min, max float64
+		want     []string
+	}{
+		{1, 1000, []string{"1e+00", "1e+01", "1e+02", "1e+03"}},
+		{0.5, 20, []string{"1e+00", "1e+01"}},
+		{2, 30, []string{"2e+00", "3e+00", "4e+00", "5e+00", "6e+00", "7e+00", "8e+00", "9e+00", "1e+01", "2e+01", "3e+01"}},
+		{1e-10, 1e10, []string{"1e-09", "1e-06", "1e-03", "1e+00", "1e+03", "1e+06", "1e+09"}},
+	} {
+		var got []string
+		for _, tick := range (hplot.LogTicks{}).Ticks(tc.min, tc.max) {
+			if tick.Label != "" {
+				got = append(got, tick.Label)
+			}
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("invalid labels for [%v, %v]:\ngot= %q\nwant=%q", tc.min, tc.max, got, tc.want)
+		}
+	}
+}
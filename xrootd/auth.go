@@ -7,17 +7,20 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 
 	"go-hep.org/x/hep/xrootd/xrdproto/auth"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/host"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/krb5"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
 )
 
 // defaultProviders is the list of authentification providers a xrootd client will use by default.
 var defaultProviders = []auth.Auther{
 	krb5.Default,
+	ztn.Default,
 	unix.Default,
 	host.Default,
 }
@@ -42,6 +45,12 @@ func (sess *cliSession) auth(ctx context.Context, securityInformation []byte) er
 			errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: provider was not found", provider))
 			continue
 		}
+		if ta, ok := auther.(auth.TLSAuther); ok && ta.RequireTLS() {
+			if _, ok := sess.conn.(*tls.Conn); !ok {
+				errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: provider requires TLS", provider))
+				continue
+			}
+		}
 		r, err := auther.Request(params)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("xrootd: could not authorize using %s: %w", provider, err))
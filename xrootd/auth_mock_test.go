@@ -0,0 +1,58 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/unix"
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+)
+
+func TestSession_Auth_TokenWithoutTLS_Mock(t *testing.T) {
+	serverFunc := func(cancel func(), conn net.Conn) {
+		data, err := xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotRequest auth.Request
+		gotHeader, err := unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		// the token must not be sent over a connection without TLS.
+		if got, want := gotRequest.Type, unix.Type; got != want {
+			cancel()
+			t.Fatalf("invalid auth type: got=%q, want=%q", got, want)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, nil)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		client.auths = map[string]auth.Auther{
+			"ztn":  &ztn.Auth{Token: "s3cr3t"},
+			"unix": &unix.Auth{User: "gopher", Group: "golang"},
+		}
+		err := client.sessions[client.initialSessionID].auth(context.Background(), []byte("&P=ztn,0:4096:&P=unix"))
+		if err != nil {
+			t.Fatalf("invalid auth call: %v", err)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
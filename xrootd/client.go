@@ -6,9 +6,11 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth"
@@ -30,6 +32,9 @@ type Client struct {
 	sessions         map[string]*cliSession
 
 	maxRedirections int
+	retry           RetryPolicy // retry is the policy for re-issuing requests failing with a transient error
+
+	tls *tls.Config // TLS configuration, if connections are switched to TLS
 }
 
 // Option configures an XRootD client.
@@ -44,6 +49,18 @@ func WithAuth(a auth.Auther) Option {
 	}
 }
 
+// WithMaxRedirections sets the maximum number of redirections followed
+// by the XRootD client to complete a request.
+func WithMaxRedirections(n int) Option {
+	return func(client *Client) error {
+		if n < 0 {
+			return fmt.Errorf("xrootd: invalid number of redirections (%d)", n)
+		}
+		client.maxRedirections = n
+		return nil
+	}
+}
+
 func (client *Client) addAuth(auth auth.Auther) error {
 	client.auths[auth.Provider()] = auth
 	return nil
@@ -71,6 +88,7 @@ func NewClient(ctx context.Context, address string, username string, opts ...Opt
 		username:        username,
 		sessions:        make(map[string]*cliSession),
 		maxRedirections: 10,
+		retry:           DefaultRetryPolicy,
 	}
 
 	client.initSecurityProviders()
@@ -128,7 +146,41 @@ func (client *Client) Send(ctx context.Context, resp xrdproto.Response, req xrdp
 	return client.sendSession(ctx, client.initialSessionID, resp, req)
 }
 
+// sendSession sends the request to the server identified by sessionID,
+// following redirections and re-issuing the request on transient failures
+// according to the retry policy of the client.
+// Errors are reported as *RequestError values.
 func (client *Client) sendSession(ctx context.Context, sessionID string, resp xrdproto.Response, req xrdproto.Request) (string, error) {
+	var (
+		rerr      = RequestError{ReqID: req.ReqID()}
+		retryable = isIdempotent(req)
+	)
+	for {
+		rerr.Attempts++
+		id, err := client.redirect(ctx, sessionID, resp, req, &rerr.Servers)
+		if err == nil {
+			return id, nil
+		}
+		rerr.Err = err
+
+		if !retryable || rerr.Attempts > client.retry.MaxRetries || !isTransient(err) {
+			return id, &rerr
+		}
+
+		timer := time.NewTimer(client.retry.backoff(rerr.Attempts))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return id, &rerr
+		}
+	}
+}
+
+// redirect sends the request to the server identified by sessionID and
+// follows the chain of redirections, recording the addresses of the
+// contacted servers into servers.
+func (client *Client) redirect(ctx context.Context, sessionID string, resp xrdproto.Response, req xrdproto.Request, servers *[]string) (string, error) {
 	client.mu.RLock()
 	session, ok := client.sessions[sessionID]
 	client.mu.RUnlock()
@@ -136,6 +188,7 @@ func (client *Client) sendSession(ctx context.Context, sessionID string, resp xr
 		return "", fmt.Errorf("xrootd: session with id = %q was not found", sessionID)
 	}
 
+	*servers = append(*servers, sessionID)
 	redirection, err := session.Send(ctx, resp, req)
 	if err != nil {
 		return sessionID, err
@@ -143,6 +196,7 @@ func (client *Client) sendSession(ctx context.Context, sessionID string, resp xr
 
 	for cnt := client.maxRedirections; redirection != nil && cnt > 0; cnt-- {
 		sessionID = redirection.Addr
+		*servers = append(*servers, sessionID)
 		session, err = client.getSession(ctx, sessionID, redirection.Token)
 		if err != nil {
 			return sessionID, err
@@ -17,16 +17,22 @@
 //  $> xrd-cp root://server.example.com/some/file1.txt - > foo.txt
 //  $> xrd-cp -r root://server.example.com/some/dir .
 //  $> xrd-cp -r root://server.example.com/some/dir outdir
+//  $> xrd-cp roots://server.example.com/some/file1.txt .
+//  $> xrd-cp -cksum=adler32 root://server.example.com/some/file1.txt .
 //
 // Options:
+//   -cksum string
+//     	verify the copied files against the server checksum computed with the given algorithm (adler32, crc32c, md5)
 //   -r	copy directories recursively
 //   -v	enable verbose mode
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
+	"hash"
 	"io"
 	"log"
 	"os"
@@ -53,6 +59,8 @@ Example:
  $> xrd-cp root://server.example.com/some/file1.txt - > foo.txt
  $> xrd-cp -r root://server.example.com/some/dir .
  $> xrd-cp -r root://server.example.com/some/dir outdir
+ $> xrd-cp roots://server.example.com/some/file1.txt .
+ $> xrd-cp -cksum=adler32 root://server.example.com/some/file1.txt .
 
 Options:
 `)
@@ -67,6 +75,7 @@ func main() {
 	var (
 		recFlag     = flag.Bool("r", false, "copy directories recursively")
 		verboseFlag = flag.Bool("v", false, "enable verbose mode")
+		cksumFlag   = flag.String("cksum", "", "verify the copied files against the server checksum computed with the given algorithm (adler32, crc32c, md5)")
 	)
 
 	flag.Parse()
@@ -79,14 +88,14 @@ func main() {
 		flag.Usage()
 		log.Fatalf("missing destination file operand after %q", flag.Arg(0))
 	case 2:
-		err := xrdcopy(flag.Arg(1), flag.Arg(0), *recFlag, *verboseFlag)
+		err := xrdcopy(flag.Arg(1), flag.Arg(0), *cksumFlag, *recFlag, *verboseFlag)
 		if err != nil {
 			log.Fatalf("could not copy %q to %q: %v", flag.Arg(0), flag.Arg(1), err)
 		}
 	default:
 		dst := flag.Arg(flag.NArg() - 1)
 		for _, src := range flag.Args()[:flag.NArg()-1] {
-			err := xrdcopy(dst, src, *recFlag, *verboseFlag)
+			err := xrdcopy(dst, src, *cksumFlag, *recFlag, *verboseFlag)
 			if err != nil {
 				log.Fatalf("could not copy %q to %q: %v", src, dst, err)
 			}
@@ -94,7 +103,7 @@ func main() {
 	}
 }
 
-func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
+func xrdcopy(dst, srcPath, cksum string, recursive, verbose bool) error {
 	cli, src, err := xrdremote(srcPath)
 	if err != nil {
 		return err
@@ -135,9 +144,10 @@ func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
 			}
 		default:
 			jobs.add(job{
-				fs:  fs,
-				src: src,
-				dst: stdpath.Join(root, stdpath.Base(src)),
+				fs:    fs,
+				src:   src,
+				dst:   stdpath.Join(root, stdpath.Base(src)),
+				cksum: cksum,
 			})
 		}
 		return nil
@@ -188,9 +198,10 @@ func xrdcopy(dst, srcPath string, recursive, verbose bool) error {
 		}
 
 		jobs.add(job{
-			fs:  fs,
-			src: src,
-			dst: dst,
+			fs:    fs,
+			src:   src,
+			dst:   dst,
+			cksum: cksum,
 		})
 	}
 
@@ -208,14 +219,19 @@ func xrdremote(name string) (client *xrootd.Client, path string, err error) {
 	}
 
 	path = url.Path
-	client, err = xrootd.NewClient(context.Background(), url.Addr, url.User)
+	var opts []xrootd.Option
+	if url.TLS {
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+	client, err = xrootd.NewClient(context.Background(), url.Addr, url.User, opts...)
 	return client, path, err
 }
 
 type job struct {
-	fs  xrdfs.FileSystem
-	src string
-	dst string
+	fs    xrdfs.FileSystem
+	src   string
+	dst   string
+	cksum string // checksum algorithm used to verify the copy, if any
 }
 
 func (j job) run(ctx context.Context) (int, error) {
@@ -243,9 +259,21 @@ func (j job) run(ctx context.Context) (int, error) {
 	}
 	defer f.Close()
 
+	var (
+		w    io.Writer = o
+		hsum hash.Hash
+	)
+	if j.cksum != "" {
+		hsum, err = xrdfs.NewHash(j.cksum)
+		if err != nil {
+			return 0, err
+		}
+		w = io.MultiWriter(o, hsum)
+	}
+
 	// TODO(sbinet): make buffer a field of job to reduce memory pressure.
 	// TODO(sbinet): use clever heuristics for buffer size?
-	n, err := io.CopyBuffer(o, f, make([]byte, 16*1024*1024))
+	n, err := io.CopyBuffer(w, f, make([]byte, 16*1024*1024))
 	if err != nil {
 		return int(n), fmt.Errorf("could not copy to output file: %w", err)
 	}
@@ -255,6 +283,17 @@ func (j job) run(ctx context.Context) (int, error) {
 		return int(n), fmt.Errorf("could not close output file: %w", err)
 	}
 
+	if hsum != nil {
+		want, err := f.Checksum(j.cksum)
+		if err != nil {
+			return int(n), err
+		}
+		got := xrdfs.Checksum{Type: want.Type, Value: hsum.Sum(nil)}
+		if !bytes.Equal(got.Value, want.Value) {
+			return int(n), fmt.Errorf("checksum mismatch for %q: got=%v, want=%v", j.src, got, want)
+		}
+	}
+
 	return int(n), nil
 }
 
@@ -21,11 +21,12 @@ func TestXrdCp(t *testing.T) {
 	src := "root://ccxrootdgotest.in2p3.fr:9001/tmp/rootio/testdata/chain.1.root"
 
 	const (
+		cksum     = ""
 		recursive = false
 		verbose   = true
 	)
 
-	err = xrdcopy(dst, src, recursive, verbose)
+	err = xrdcopy(dst, src, cksum, recursive, verbose)
 	if err != nil {
 		t.Fatalf("could not copy remote file: %v", err)
 	}
@@ -53,6 +54,7 @@ func benchmarkXrdCp(b *testing.B, src string) {
 	dst := filepath.Join(dir, filepath.Base(src))
 
 	const (
+		cksum     = ""
 		recursive = false
 		verbose   = false
 	)
@@ -60,7 +62,7 @@ func benchmarkXrdCp(b *testing.B, src string) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		os.RemoveAll(dst)
-		err = xrdcopy(dst, src, recursive, verbose)
+		err = xrdcopy(dst, src, cksum, recursive, verbose)
 		if err != nil {
 			b.Fatalf("could not copy remote file: %v", err)
 		}
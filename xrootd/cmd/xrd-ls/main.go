@@ -14,6 +14,7 @@
 //  $> xrd-ls -l root://server.example.com/some/dir
 //  $> xrd-ls -R root://server.example.com/some/dir
 //  $> xrd-ls -l -R root://server.example.com/some/dir
+//  $> xrd-ls roots://server.example.com/some/dir
 //
 // Options:
 //   -R	list subdirectories recursively
@@ -49,6 +50,7 @@ Example:
  $> xrd-ls -l root://server.example.com/some/dir
  $> xrd-ls -R root://server.example.com/some/dir
  $> xrd-ls -l -R root://server.example.com/some/dir
+ $> xrd-ls roots://server.example.com/some/dir
 
 Options:
 `)
@@ -92,7 +94,12 @@ func xrdls(name string, long, recursive bool) error {
 
 	ctx := context.Background()
 
-	c, err := xrootd.NewClient(ctx, url.Addr, url.User)
+	var opts []xrootd.Option
+	if url.TLS {
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+
+	c, err := xrootd.NewClient(ctx, url.Addr, url.User, opts...)
 	if err != nil {
 		return fmt.Errorf("could not create client: %w", err)
 	}
@@ -3,10 +3,14 @@
 // license that can be found in the LICENSE file.
 
 // Command xrd-srv serves data from a local filesystem over the XRootD protocol.
+//
+// xrd-srv can also run as a caching proxy of a remote XRootD server,
+// storing the blocks of the files read through it in a local directory.
 package main // import "go-hep.org/x/hep/xrootd/cmd/xrd-srv"
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
@@ -15,6 +19,7 @@ import (
 	"os/signal"
 
 	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdio"
 )
 
 func init() {
@@ -24,11 +29,14 @@ func init() {
 Usage:
 
  $> xrd-srv [OPTIONS] <base-dir>
+ $> xrd-srv [OPTIONS] -proxy=<remote-server> <cache-dir>
 
 Example:
 
  $> xrd-srv /tmp
  $> xrd-srv -addr=0.0.0.0:1094 /tmp
+ $> xrd-srv -tls-cert=cert.pem -tls-key=key.pem /tmp
+ $> xrd-srv -proxy=root://ccxrootdgotest.in2p3.fr:9001 /tmp/xcache
 
 Options:
 `)
@@ -40,7 +48,12 @@ func main() {
 	log.SetPrefix("xrd-srv: ")
 	log.SetFlags(0)
 
-	addr := flag.String("addr", "0.0.0.0:1094", "listen to the provided address")
+	var (
+		addr    = flag.String("addr", "0.0.0.0:1094", "listen to the provided address")
+		tlsCert = flag.String("tls-cert", "", "path to the PEM certificate used for roots:// clients")
+		tlsKey  = flag.String("tls-key", "", "path to the PEM key of the certificate used for roots:// clients")
+		proxy   = flag.String("proxy", "", "remote server to proxy, caching data in the base dir")
+	)
 
 	flag.Parse()
 
@@ -51,12 +64,42 @@ func main() {
 
 	baseDir := flag.Arg(0)
 
+	var tlsCfg *tls.Config
+	if *tlsCert != "" || *tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(*tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("could not load TLS certificate: %v", err)
+		}
+		tlsCfg = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
 	listener, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("could not listen on %q: %v", *addr, err)
 	}
 
-	srv := xrootd.NewServer(xrootd.NewFSHandler(baseDir), func(err error) {
+	handler := xrootd.NewFSHandler(baseDir)
+	if *proxy != "" {
+		url, err := xrdio.Parse(*proxy)
+		if err != nil {
+			log.Fatalf("could not parse proxied server %q: %v", *proxy, err)
+		}
+
+		var opts []xrootd.Option
+		if url.TLS {
+			opts = append(opts, xrootd.WithTLS(nil))
+		}
+
+		cli, err := xrootd.NewClient(context.Background(), url.Addr, url.User, opts...)
+		if err != nil {
+			log.Fatalf("could not connect to proxied server %q: %v", *proxy, err)
+		}
+		defer cli.Close()
+
+		handler = xrootd.NewProxyHandler(cli, baseDir)
+	}
+
+	srv := xrootd.NewServer(handler, func(err error) {
 		log.Printf("an error occured: %v", err)
 	})
 
@@ -65,7 +108,13 @@ func main() {
 
 	go func() {
 		log.Printf("listening on %v...", listener.Addr())
-		if err = srv.Serve(listener); err != nil {
+		switch tlsCfg {
+		case nil:
+			err = srv.Serve(listener)
+		default:
+			err = srv.ServeTLS(listener, tlsCfg)
+		}
+		if err != nil {
 			log.Fatalf("could not serve: %v", err)
 		}
 	}()
@@ -0,0 +1,129 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command xrd-verify compares local files with files on a remote xrootd server.
+//
+// xrd-verify first compares the checksum of the remote file, computed by the
+// xrootd server, with the checksum of the local file.
+// If they differ, xrd-verify computes digests of blocks of the local and
+// remote files, reading the remote blocks with vector reads, and reports the
+// ranges of bytes that differ.
+// xrd-verify exits with a non-zero status if any of the files differ.
+//
+// Usage:
+//
+//  $> xrd-verify [OPTIONS] <local-file> <remote-file>
+//
+// Example:
+//
+//  $> xrd-verify ./file1.root root://server.example.com/some/file1.root
+//  $> xrd-verify -bs=1048576 ./file1.root root://server.example.com/some/file1.root
+//
+// Options:
+//   -bs int
+//     	block size (in bytes) used to compute digests (default 16777216)
+//   -v	enable verbose mode
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, `xrd-verify compares local files with files on a remote xrootd server.
+
+Usage:
+
+ $> xrd-verify [OPTIONS] <local-file> <remote-file>
+
+Example:
+
+ $> xrd-verify ./file1.root root://server.example.com/some/file1.root
+ $> xrd-verify -bs=1048576 ./file1.root root://server.example.com/some/file1.root
+
+Options:
+`)
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	log.SetPrefix("xrd-verify: ")
+	log.SetFlags(0)
+
+	var (
+		bsFlag      = flag.Int("bs", xrdio.DefaultBlockSize, "block size (in bytes) used to compute digests")
+		verboseFlag = flag.Bool("v", false, "enable verbose mode")
+	)
+
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		log.Fatalf("invalid number of arguments (got=%d, want=2)", flag.NArg())
+	}
+
+	ok, err := xrdverify(os.Stdout, flag.Arg(0), flag.Arg(1), *bsFlag, *verboseFlag)
+	if err != nil {
+		log.Fatalf("could not verify %q against %q: %+v", flag.Arg(0), flag.Arg(1), err)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func xrdverify(w io.Writer, local, remote string, blksz int, verbose bool) (bool, error) {
+	lf, err := os.Open(local)
+	if err != nil {
+		return false, fmt.Errorf("could not open local file: %w", err)
+	}
+	defer lf.Close()
+
+	lfi, err := lf.Stat()
+	if err != nil {
+		return false, fmt.Errorf("could not stat local file: %w", err)
+	}
+
+	rf, err := xrdio.Open(remote)
+	if err != nil {
+		return false, fmt.Errorf("could not open remote file: %w", err)
+	}
+	defer rf.Close()
+
+	rfi, err := rf.Stat()
+	if err != nil {
+		return false, fmt.Errorf("could not stat remote file: %w", err)
+	}
+
+	if verbose {
+		log.Printf("local:  %q (%d bytes)", local, lfi.Size())
+		log.Printf("remote: %q (%d bytes)", remote, rfi.Size())
+	}
+
+	diffs, err := xrdio.VerifyFile(lf, lfi.Size(), rf, blksz)
+	if err != nil {
+		return false, err
+	}
+
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "mismatch: [%d, %d) (%d bytes)\n", diff.Beg, diff.End, diff.End-diff.Beg)
+	}
+
+	if lfi.Size() != rfi.Size() {
+		fmt.Fprintf(w, "size mismatch: local=%d, remote=%d\n", lfi.Size(), rfi.Size())
+	}
+
+	if verbose && len(diffs) == 0 {
+		log.Printf("files are identical")
+	}
+
+	return len(diffs) == 0, nil
+}
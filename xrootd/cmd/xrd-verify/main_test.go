@@ -0,0 +1,111 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+)
+
+func TestXrdVerify(t *testing.T) {
+	dir := t.TempDir()
+	srv := filepath.Join(dir, "srv")
+	err := os.Mkdir(srv, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref := make([]byte, 100)
+	for i := range ref {
+		ref[i] = byte(i)
+	}
+	err = os.WriteFile(filepath.Join(srv, "file.bin"), ref, 0644)
+	if err != nil {
+		t.Fatalf("could not create remote file: %+v", err)
+	}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	server := xrootd.NewServer(xrootd.NewFSHandler(srv), func(err error) {
+		t.Errorf("server error: %+v", err)
+	})
+	go func() {
+		err := server.Serve(l)
+		if err != nil && err != xrootd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	defer server.Shutdown(context.Background())
+
+	remote := "root://" + l.Addr().String() + "/file.bin"
+
+	modify := func(n int, idx ...int) []byte {
+		o := make([]byte, n)
+		copy(o, ref)
+		for _, i := range idx {
+			o[i]++
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		ok   bool
+		want string
+	}{
+		{
+			name: "same",
+			data: modify(100),
+			ok:   true,
+		},
+		{
+			name: "modified",
+			data: modify(100, 15, 25, 75),
+			want: "mismatch: [10, 30) (20 bytes)\nmismatch: [70, 80) (10 bytes)\n",
+		},
+		{
+			name: "short",
+			data: modify(95),
+			want: "mismatch: [95, 100) (5 bytes)\nsize mismatch: local=95, remote=100\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			local := filepath.Join(dir, tc.name+".bin")
+			err := os.WriteFile(local, tc.data, 0644)
+			if err != nil {
+				t.Fatalf("could not create local file: %+v", err)
+			}
+
+			const (
+				blksz   = 10
+				verbose = false
+			)
+
+			out := new(strings.Builder)
+			ok, err := xrdverify(out, local, remote, blksz, verbose)
+			if err != nil {
+				t.Fatalf("could not verify file: %+v", err)
+			}
+
+			if ok != tc.ok {
+				t.Fatalf("invalid verification status: got=%v, want=%v", ok, tc.ok)
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
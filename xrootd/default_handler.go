@@ -14,7 +14,9 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
 	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -84,6 +86,12 @@ func (h *defaultHandler) Read(sessionID [16]byte, request *read.Request) (xrdpro
 	return resp, xrdproto.Error
 }
 
+// ReadV implements Handler.ReadV.
+func (h *defaultHandler) ReadV(sessionID [16]byte, request *readv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "ReadV request is not implemented"}
+	return resp, xrdproto.Error
+}
+
 // Write implements Handler.Write.
 func (h *defaultHandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "Write request is not implemented"}
@@ -131,3 +139,9 @@ func (h *defaultHandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (
 	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "RemoveDir request is not implemented"}
 	return resp, xrdproto.Error
 }
+
+// Query implements Handler.Query.
+func (h *defaultHandler) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	resp := xrdproto.ServerError{Code: xrdproto.InvalidRequest, Message: "Query request is not implemented"}
+	return resp, xrdproto.Error
+}
@@ -6,16 +6,19 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"fmt"
 	rsync "sync"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 	"go-hep.org/x/hep/xrootd/xrdproto/sync"
 	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
 	"go-hep.org/x/hep/xrootd/xrdproto/verifyw"
 	"go-hep.org/x/hep/xrootd/xrdproto/write"
 	"go-hep.org/x/hep/xrootd/xrdproto/xrdclose"
+	"golang.org/x/sync/errgroup"
 )
 
 // File implements access to a content and meta information of file over XRootD.
@@ -85,6 +88,90 @@ func (f *file) ReadAt(p []byte, off int64) (n int, err error) {
 	return f.ReadAtContext(context.Background(), p, off)
 }
 
+// ReadV reads the provided chunks of the file with vector reads.
+// Chunks larger than readv.MaxLength are split into several segments and
+// requests of at most readv.MaxSegments segments are sent concurrently.
+func (f *file) ReadV(ctx context.Context, chunks []xrdfs.Chunk) (n int, err error) {
+	type segment struct {
+		chunk int    // index of the chunk holding this segment
+		off   int64  // offset of the segment in the file
+		data  []byte // region of the chunk data read by this segment
+	}
+
+	var segs []segment
+	for i, c := range chunks {
+		beg := 0
+		for {
+			end := beg + readv.MaxLength
+			if end > len(c.Data) {
+				end = len(c.Data)
+			}
+			segs = append(segs, segment{chunk: i, off: c.Offset + int64(beg), data: c.Data[beg:end:end]})
+			beg = end
+			if beg == len(c.Data) {
+				break
+			}
+		}
+	}
+
+	nbytes := make([]int, len(segs))
+	grp, ctx := errgroup.WithContext(ctx)
+	for beg := 0; beg < len(segs); beg += readv.MaxSegments {
+		end := beg + readv.MaxSegments
+		if end > len(segs) {
+			end = len(segs)
+		}
+		beg := beg
+		grp.Go(func() error {
+			var (
+				req  = readv.Request{Segments: make([]readv.Segment, end-beg)}
+				resp = readv.Response{Chunks: make([]readv.Chunk, end-beg)}
+			)
+			for i, seg := range segs[beg:end] {
+				req.Segments[i] = readv.Segment{Handle: f.handle, Length: int32(len(seg.data)), Offset: seg.off}
+				// data is read in place, directly into the chunks.
+				resp.Chunks[i].Data = seg.data
+			}
+
+			err := f.do(ctx, func(ctx context.Context, sid string) (string, error) {
+				return f.fs.c.sendSession(ctx, sid, &resp, &req)
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(resp.Chunks) != len(req.Segments) {
+				return fmt.Errorf("xrootd: invalid number of readv chunks (got=%d, want=%d)", len(resp.Chunks), len(req.Segments))
+			}
+			for i, c := range resp.Chunks {
+				seg := req.Segments[i]
+				if c.Offset != seg.Offset || len(c.Data) > int(seg.Length) {
+					return fmt.Errorf(
+						"xrootd: invalid readv chunk %d (offset=%d, len=%d), want (offset=%d, len<=%d)",
+						beg+i, c.Offset, len(c.Data), seg.Offset, seg.Length,
+					)
+				}
+				nbytes[beg+i] = len(c.Data)
+			}
+			return nil
+		})
+	}
+	err = grp.Wait()
+	if err != nil {
+		return 0, err
+	}
+
+	sizes := make([]int, len(chunks))
+	for i, seg := range segs {
+		sizes[seg.chunk] += nbytes[i]
+		n += nbytes[i]
+	}
+	for i := range chunks {
+		chunks[i].Data = chunks[i].Data[:sizes[i]]
+	}
+	return n, nil
+}
+
 // WriteAtContext writes len(p) bytes from p to the file at offset off.
 func (f *file) WriteAtContext(ctx context.Context, p []byte, off int64) error {
 	return f.do(ctx, func(ctx context.Context, sid string) (string, error) {
@@ -15,6 +15,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 	"go-hep.org/x/hep/xrootd/xrdproto/sync"
 	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
@@ -180,6 +181,82 @@ func TestFile_ReadAt_Mock(t *testing.T) {
 	testClientWithMockServer(serverFunc, clientFunc)
 }
 
+func TestFile_ReadV_Mock(t *testing.T) {
+	t.Parallel()
+
+	handle := xrdfs.FileHandle{1, 2, 3, 4}
+	content := []byte("Hello XRootD.\n")
+
+	wantRequest := readv.Request{
+		Segments: []readv.Segment{
+			{Handle: handle, Length: 5, Offset: 0},
+			{Handle: handle, Length: 10, Offset: 6},
+		},
+	}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		data, err := xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotRequest readv.Request
+		gotHeader, err := unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		if !reflect.DeepEqual(gotRequest, wantRequest) {
+			cancel()
+			t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotRequest, wantRequest)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.OkSoFar, readv.Response{
+			Chunks: []readv.Chunk{{Handle: handle, Offset: 0, Data: content[:5]}},
+		})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+
+		// short read, at the end of the file.
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, readv.Response{
+			Chunks: []readv.Chunk{{Handle: handle, Offset: 6, Data: content[6:]}},
+		})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		file := file{fs: client.FS().(*fileSystem), handle: handle, sessionID: client.initialSessionID}
+		chunks := []xrdfs.Chunk{
+			{Offset: 0, Data: make([]byte, 5)},
+			{Offset: 6, Data: make([]byte, 10)},
+		}
+
+		n, err := file.ReadV(context.Background(), chunks)
+		if err != nil {
+			t.Fatalf("invalid readv call: %v", err)
+		}
+		if want := len(content) - 1; n != want {
+			t.Fatalf("read count does not match:\ngot = %v\nwant = %v", n, want)
+		}
+
+		if got, want := string(chunks[0].Data), "Hello"; got != want {
+			t.Fatalf("read data does not match:\ngot = %q\nwant = %q", got, want)
+		}
+		if got, want := string(chunks[1].Data), "XRootD.\n"; got != want {
+			t.Fatalf("read data does not match:\ngot = %q\nwant = %q", got, want)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
 func TestFile_WriteAt_Mock(t *testing.T) {
 	t.Parallel()
 
@@ -6,7 +6,9 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"fmt"
 	stdpath "path"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto/chmod"
@@ -14,6 +16,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -157,6 +160,36 @@ func (fs *fileSystem) Statx(ctx context.Context, paths []string) ([]xrdfs.StatFl
 	return resp.StatFlags, nil
 }
 
+// Checksum returns the checksum of the file at path, computed by the
+// XRootD server with the typ algorithm (e.g. adler32, md5).
+// The default algorithm of the server is used if typ is empty.
+func (fs *fileSystem) Checksum(ctx context.Context, path, typ string) (xrdfs.Checksum, error) {
+	args := path
+	if typ != "" {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		args += sep + "cks.type=" + typ
+	}
+
+	var resp query.Response
+	_, err := fs.c.Send(ctx, &resp, &query.Request{Query: query.Checksum, Args: []byte(args)})
+	if err != nil {
+		return xrdfs.Checksum{}, err
+	}
+
+	cs, err := xrdfs.ParseChecksum(string(resp.Data))
+	if err != nil {
+		return cs, err
+	}
+
+	if typ != "" && !strings.EqualFold(cs.Type, typ) {
+		return cs, fmt.Errorf("xrootd: server returned a %s checksum instead of %s", cs.Type, typ)
+	}
+	return cs, nil
+}
+
 var (
 	_ xrdfs.FileSystem = (*fileSystem)(nil)
 )
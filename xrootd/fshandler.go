@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"sync"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
@@ -18,7 +20,9 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -55,7 +59,7 @@ func NewFSHandler(basePath string) Handler {
 
 // Dirlist implements server.Handler.Dirlist.
 func (h *fshandler) Dirlist(sessionID [16]byte, request *dirlist.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	files, err := os.ReadDir(path.Join(h.basePath, request.Path))
+	files, err := os.ReadDir(h.path(request.Path))
 	if err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
@@ -105,7 +109,7 @@ func (h *fshandler) Open(sessionID [16]byte, request *open.Request) (xrdproto.Ma
 		}
 	}
 
-	filePath := path.Join(h.basePath, request.Path)
+	filePath := h.path(request.Path)
 	if request.Options&xrdfs.OpenOptionsMkPath != 0 {
 		if err := os.MkdirAll(path.Dir(filePath), os.FileMode(request.Mode)); err != nil {
 			return xrdproto.ServerError{
@@ -231,6 +235,45 @@ func (h *fshandler) Read(sessionID [16]byte, request *read.Request) (xrdproto.Ma
 	return read.Response{Data: buf[:n]}, xrdproto.Ok
 }
 
+// ReadV implements server.Handler.ReadV.
+func (h *fshandler) ReadV(sessionID [16]byte, request *readv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if len(request.Segments) > readv.MaxSegments {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Too many segments: %d (max=%d)", len(request.Segments), readv.MaxSegments),
+		}, xrdproto.Error
+	}
+
+	resp := readv.Response{Chunks: make([]readv.Chunk, len(request.Segments))}
+	for i, seg := range request.Segments {
+		file := h.getFile(sessionID, seg.Handle)
+		if file == nil {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid file handle: %v", seg.Handle),
+			}, xrdproto.Error
+		}
+		if seg.Length < 0 || seg.Length > readv.MaxLength {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid segment length: %d", seg.Length),
+			}, xrdproto.Error
+		}
+
+		buf := make([]byte, seg.Length)
+		n, err := file.ReadAt(buf, seg.Offset)
+		if err != nil && err != io.EOF {
+			return xrdproto.ServerError{
+				Code:    xrdproto.IOError,
+				Message: fmt.Sprintf("An IO error occurred: %v", err),
+			}, xrdproto.Error
+		}
+		resp.Chunks[i] = readv.Chunk{Handle: seg.Handle, Offset: seg.Offset, Data: buf[:n]}
+	}
+
+	return resp, xrdproto.Ok
+}
+
 // Write implements server.Handler.Write.
 func (h *fshandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	file := h.getFile(sessionID, request.Handle)
@@ -252,6 +295,15 @@ func (h *fshandler) Write(sessionID [16]byte, request *write.Request) (xrdproto.
 	return nil, xrdproto.Ok
 }
 
+// path returns the location on the backing filesystem of the provided request path.
+// Opaque data is discarded and the path can not escape basePath.
+func (h *fshandler) path(p string) string {
+	if i := strings.Index(p, "?"); i >= 0 {
+		p = p[:i]
+	}
+	return path.Join(h.basePath, path.Clean("/"+p))
+}
+
 func (h *fshandler) getFile(sessionID [16]byte, handle xrdfs.FileHandle) *os.File {
 	h.mu.RLock()
 	sess, ok := h.sessions[sessionID]
@@ -271,11 +323,7 @@ func (h *fshandler) getFile(sessionID [16]byte, handle xrdfs.FileHandle) *os.Fil
 // Stat implements server.Handler.Stat.
 func (h *fshandler) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	if request.Options&stat.OptionsVFS != 0 {
-		// TODO: handle virtual stat info.
-		return xrdproto.ServerError{
-			Code:    xrdproto.InvalidRequest,
-			Message: "Stat request with OptionsVFS is not implemented",
-		}, xrdproto.Error
+		return h.statVFS(sessionID, request)
 	}
 
 	var fi os.FileInfo
@@ -290,7 +338,7 @@ func (h *fshandler) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Ma
 		}
 		fi, err = file.Stat()
 	} else {
-		fi, err = os.Stat(path.Join(h.basePath, request.Path))
+		fi, err = os.Stat(h.path(request.Path))
 	}
 
 	if err != nil {
@@ -303,6 +351,31 @@ func (h *fshandler) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Ma
 	return stat.DefaultResponse{EntryStat: xrdfs.EntryStatFrom(fi)}, xrdproto.Ok
 }
 
+// statVFS handles a stat request for the virtual file system information.
+func (h *fshandler) statVFS(sessionID [16]byte, request *stat.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	name := h.path(request.Path)
+	if len(request.Path) == 0 {
+		file := h.getFile(sessionID, request.FileHandle)
+		if file == nil {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid file handle: %v", request.FileHandle),
+			}, xrdproto.Error
+		}
+		name = file.Name()
+	}
+
+	vfs, err := statVFS(name)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+
+	return stat.VirtualFSResponse{VirtualFSStat: vfs}, xrdproto.Ok
+}
+
 // Truncate implements server.Handler.Truncate.
 func (h *fshandler) Truncate(sessionID [16]byte, request *truncate.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
 	var err error
@@ -316,7 +389,7 @@ func (h *fshandler) Truncate(sessionID [16]byte, request *truncate.Request) (xrd
 		}
 		err = file.Truncate(request.Size)
 	} else {
-		err = os.Truncate(path.Join(h.basePath, request.Path), request.Size)
+		err = os.Truncate(h.path(request.Path), request.Size)
 	}
 
 	if err != nil {
@@ -351,7 +424,7 @@ func (h *fshandler) Sync(sessionID [16]byte, request *xrdsync.Request) (xrdproto
 
 // Rename implements server.Handler.Rename.
 func (h *fshandler) Rename(sessionID [16]byte, request *mv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Rename(path.Join(h.basePath, request.OldPath), path.Join(h.basePath, request.NewPath)); err != nil {
+	if err := os.Rename(h.path(request.OldPath), h.path(request.NewPath)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -368,7 +441,7 @@ func (h *fshandler) Mkdir(sessionID [16]byte, request *mkdir.Request) (xrdproto.
 		mkdirFunc = os.MkdirAll
 	}
 
-	if err := mkdirFunc(path.Join(h.basePath, request.Path), os.FileMode(request.Mode)); err != nil {
+	if err := mkdirFunc(h.path(request.Path), os.FileMode(request.Mode)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -379,7 +452,7 @@ func (h *fshandler) Mkdir(sessionID [16]byte, request *mkdir.Request) (xrdproto.
 
 // Remove implements server.Handler.Remove.
 func (h *fshandler) Remove(sessionID [16]byte, request *rm.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Remove(path.Join(h.basePath, request.Path)); err != nil {
+	if err := os.Remove(h.path(request.Path)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -390,7 +463,7 @@ func (h *fshandler) Remove(sessionID [16]byte, request *rm.Request) (xrdproto.Ma
 
 // RemoveDir implements server.Handler.RemoveDir.
 func (h *fshandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
-	if err := os.Remove(path.Join(h.basePath, request.Path)); err != nil {
+	if err := os.Remove(h.path(request.Path)); err != nil {
 		return xrdproto.ServerError{
 			Code:    xrdproto.IOError,
 			Message: fmt.Sprintf("An IO error occurred: %v", err),
@@ -399,6 +472,54 @@ func (h *fshandler) RemoveDir(sessionID [16]byte, request *rmdir.Request) (xrdpr
 	return nil, xrdproto.Ok
 }
 
+// Query implements server.Handler.Query.
+// Only checksum queries are supported.
+func (h *fshandler) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Query != query.Checksum {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Query request of type %d is not implemented", request.Query),
+		}, xrdproto.Error
+	}
+
+	name := string(request.Args)
+	typ := "adler32"
+	if i := strings.Index(name, "?"); i >= 0 {
+		opaque, err := url.ParseQuery(name[i+1:])
+		if err == nil && opaque.Get("cks.type") != "" {
+			typ = opaque.Get("cks.type")
+		}
+	}
+
+	hsum, err := xrdfs.NewHash(typ)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid checksum type: %v", err),
+		}, xrdproto.Error
+	}
+
+	f, err := os.Open(h.path(name))
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+	defer f.Close()
+
+	_, err = io.Copy(hsum, f)
+	if err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+
+	cs := xrdfs.Checksum{Type: strings.ToLower(typ), Value: hsum.Sum(nil)}
+	return query.Response{Data: []byte(strings.Replace(cs.String(), ":", " ", 1) + "\x00")}, xrdproto.Ok
+}
+
 // CloseSession implements server.Handler.CloseSession.
 func (h *fshandler) CloseSession(sessionID [16]byte) error {
 	h.mu.Lock()
@@ -6,12 +6,15 @@ package xrootd_test // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"sync"
 	"testing"
 
@@ -133,8 +136,8 @@ func TestHandler_Dirlist_WhenPathIsInvalid(t *testing.T) {
 	defer cli.Close()
 
 	_, err = cli.FS().Dirlist(context.Background(), "/path/not/exist")
-	serverError, ok := err.(xrdproto.ServerError)
-	if !ok {
+	var serverError xrdproto.ServerError
+	if !errors.As(err, &serverError) {
 		t.Fatalf("could not cast err to ServerError: %v", err)
 	}
 	if serverError.Code != xrdproto.IOError {
@@ -306,7 +309,8 @@ func TestHandler_Open(t *testing.T) {
 
 			got, err := cli.FS().Open(context.Background(), tc.file, tc.mode, tc.options)
 			if err != nil {
-				if serverError, ok := err.(xrdproto.ServerError); ok {
+				var serverError xrdproto.ServerError
+				if errors.As(err, &serverError) {
 					if serverError.Code != tc.errCode {
 						t.Fatalf("wrong error code:\ngot = %v\nwant = %v\nerror message = %q", serverError.Code, tc.errCode, serverError.Message)
 					}
@@ -629,6 +633,113 @@ func TestHandler_Stat(t *testing.T) {
 	}
 }
 
+func TestHandler_StatVirtualFS(t *testing.T) {
+	switch runtime.GOOS {
+	case "darwin", "freebsd", "linux":
+	default:
+		t.Skipf("virtual file system information not supported on %s", runtime.GOOS)
+	}
+
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err = os.WriteFile(path.Join(baseDir, "file1.txt"), []byte{1, 2, 3, 4, 5}, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	got, err := cli.FS().VirtualStat(context.Background(), "file1.txt")
+	if err != nil {
+		t.Fatalf("could not call VirtualStat: %v", err)
+	}
+	if got.NumberRW != 1 {
+		t.Fatalf("wrong NumberRW:\ngot = %v\nwant = %v", got.NumberRW, 1)
+	}
+	if got.UtilizationRW < 0 || got.UtilizationRW > 100 {
+		t.Fatalf("wrong UtilizationRW: %v", got.UtilizationRW)
+	}
+
+	file, err := cli.FS().Open(context.Background(), "file1.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		t.Fatalf("could not call Open: %v", err)
+	}
+	defer file.Close(context.Background())
+
+	_, err = file.StatVirtualFS(context.Background())
+	if err != nil {
+		t.Fatalf("could not call StatVirtualFS: %v", err)
+	}
+}
+
+func TestHandler_PathOutsideBaseDir(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	outDir, err := os.MkdirTemp("", "xrd-srv-out-")
+	if err != nil {
+		t.Fatalf("could not create test dir: %v", err)
+	}
+	defer os.RemoveAll(outDir)
+
+	err = os.WriteFile(path.Join(outDir, "file1.txt"), []byte{1, 2, 3, 4, 5}, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	name, err := filepath.Rel(baseDir, path.Join(outDir, "file1.txt"))
+	if err != nil {
+		t.Fatalf("could not compute relative path: %v", err)
+	}
+
+	err = cli.FS().RemoveFile(context.Background(), name)
+	if err == nil {
+		t.Fatalf("expected an error removing %q", name)
+	}
+
+	_, err = os.Stat(path.Join(outDir, "file1.txt"))
+	if err != nil {
+		t.Fatalf("file outside of the served directory was modified: %v", err)
+	}
+
+	err = cli.FS().Mkdir(context.Background(), "dir1?oss.asize=42", xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite|xrdfs.OpenModeOwnerExecute)
+	if err != nil {
+		t.Fatalf("could not call Mkdir: %v", err)
+	}
+
+	_, err = os.Stat(path.Join(baseDir, "dir1"))
+	if err != nil {
+		t.Fatalf("could not stat created directory: %v", err)
+	}
+}
+
 func TestHandler_Truncate(t *testing.T) {
 	for _, tc := range []struct {
 		testName string
@@ -854,7 +965,8 @@ func TestHandler_Mkdir(t *testing.T) {
 				err = cli.FS().Mkdir(context.Background(), tc.path, xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite|xrdfs.OpenModeOwnerExecute)
 			}
 			if err != nil {
-				if serverError, ok := err.(xrdproto.ServerError); ok {
+				var serverError xrdproto.ServerError
+				if errors.As(err, &serverError) {
 					if serverError.Code != tc.errCode {
 						t.Fatalf("wrong error code:\ngot = %v\nwant = %v\nerror message = %q", serverError.Code, tc.errCode, serverError.Message)
 					}
@@ -919,7 +1031,8 @@ func TestHandler_Remove(t *testing.T) {
 
 			err = cli.FS().RemoveFile(context.Background(), tc.path)
 			if err != nil {
-				if serverError, ok := err.(xrdproto.ServerError); ok {
+				var serverError xrdproto.ServerError
+				if errors.As(err, &serverError) {
 					if serverError.Code != tc.errCode {
 						t.Fatalf("wrong error code:\ngot = %v\nwant = %v\nerror message = %q", serverError.Code, tc.errCode, serverError.Message)
 					}
@@ -1002,7 +1115,8 @@ func TestHandler_RemoveDir(t *testing.T) {
 
 			err = cli.FS().RemoveDir(context.Background(), tc.path)
 			if err != nil {
-				if serverError, ok := err.(xrdproto.ServerError); ok {
+				var serverError xrdproto.ServerError
+				if errors.As(err, &serverError) {
 					if serverError.Code != tc.errCode {
 						t.Fatalf("wrong error code:\ngot = %v\nwant = %v\nerror message = %q", serverError.Code, tc.errCode, serverError.Message)
 					}
@@ -1017,6 +1131,59 @@ func TestHandler_RemoveDir(t *testing.T) {
 	}
 }
 
+func TestHandler_Checksum(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	err = os.WriteFile(path.Join(baseDir, "file1.txt"), []byte("Wikipedia"), 0644)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	for _, tc := range []struct {
+		name string
+		typ  string
+		want string
+		err  bool
+	}{
+		{name: "file1.txt", want: "adler32:11e60398"},
+		{name: "file1.txt", typ: "adler32", want: "adler32:11e60398"},
+		{name: "file1.txt", typ: "md5", want: "md5:9c677286866aad38f8e9b660f5411814"},
+		{name: "file1.txt", typ: "sha3", err: true},
+		{name: "file2.txt", typ: "adler32", err: true},
+	} {
+		t.Run(tc.name+"-"+tc.typ, func(t *testing.T) {
+			got, err := cli.FS().Checksum(context.Background(), tc.name, tc.typ)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not call Checksum: %v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			case err != nil:
+				return
+			}
+
+			if got.String() != tc.want {
+				t.Fatalf("wrong checksum:\ngot = %v\nwant = %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestHandler_Ping(t *testing.T) {
 	srv, addr, baseDir, err := createServer(func(err error) {
 		t.Error(err)
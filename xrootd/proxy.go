@@ -0,0 +1,561 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
+	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
+	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
+	"go-hep.org/x/hep/xrootd/xrdproto/xrdclose"
+)
+
+// proxyBlockSize is the size of the blocks fetched from the remote server and stored in the cache.
+const proxyBlockSize = 1 << 20
+
+// proxyHandler implements server.Handler API by forwarding requests to a remote
+// XRootD server, caching the blocks of the files read through it on local disk.
+//
+// The proxy is read-only: requests modifying the remote server are rejected.
+type proxyHandler struct {
+	Handler
+	client *Client
+	dir    string // dir is the local directory holding the cached files.
+
+	mu       sync.Mutex
+	next     uint32                                       // next is the next file handle to be returned.
+	files    map[string]*cacheFile                        // files are the opened cached files, by remote path.
+	sessions map[[16]byte]map[xrdfs.FileHandle]*cacheFile // sessions are the opened file handles, by session.
+}
+
+// NewProxyHandler creates a Handler serving the files of the remote XRootD server
+// the provided client is connected to.
+// Blocks of the files read through the handler are cached under the dir local
+// directory, so that repeated reads of the same files are served from the local disk.
+// The cache persists across handlers using the same local directory.
+func NewProxyHandler(client *Client, dir string) Handler {
+	return &proxyHandler{
+		Handler:  Default(),
+		client:   client,
+		dir:      dir,
+		files:    make(map[string]*cacheFile),
+		sessions: make(map[[16]byte]map[xrdfs.FileHandle]*cacheFile),
+	}
+}
+
+// Dirlist implements server.Handler.Dirlist.
+func (h *proxyHandler) Dirlist(sessionID [16]byte, request *dirlist.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	entries, err := h.client.FS().Dirlist(context.Background(), request.Path)
+	if err != nil {
+		return proxyError(err), xrdproto.Error
+	}
+
+	resp := &dirlist.Response{
+		WithStatInfo: request.Options&dirlist.WithStatInfo != 0,
+		Entries:      entries,
+	}
+	for i := range resp.Entries {
+		resp.Entries[i].HasStatInfo = resp.WithStatInfo
+	}
+
+	return resp, xrdproto.Ok
+}
+
+// Open implements server.Handler.Open.
+func (h *proxyHandler) Open(sessionID [16]byte, request *open.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	const modify = xrdfs.OpenOptionsDelete | xrdfs.OpenOptionsNew |
+		xrdfs.OpenOptionsOpenUpdate | xrdfs.OpenOptionsMkPath | xrdfs.OpenOptionsOpenAppend
+	if request.Options&modify != 0 {
+		return xrdproto.ServerError{
+			Code:    xrdproto.NotAuthorized,
+			Message: "Proxy is read-only",
+		}, xrdproto.Error
+	}
+
+	file, err := h.open(request.Path)
+	if err != nil {
+		return proxyError(err), xrdproto.Error
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	handles, ok := h.sessions[sessionID]
+	if !ok {
+		handles = make(map[xrdfs.FileHandle]*cacheFile)
+		h.sessions[sessionID] = handles
+	}
+
+	var handle xrdfs.FileHandle
+	binary.BigEndian.PutUint32(handle[:], h.next)
+	h.next++
+	handles[handle] = file
+
+	resp := open.Response{FileHandle: handle}
+	if request.Options&xrdfs.OpenOptionsReturnStatus != 0 {
+		es := file.stat
+		resp.Stat = &es
+		if request.Options&xrdfs.OpenOptionsCompress == 0 {
+			resp.Compression = &xrdfs.FileCompression{}
+		}
+	}
+
+	return resp, xrdproto.Ok
+}
+
+// Close implements server.Handler.Close.
+func (h *proxyHandler) Close(sessionID [16]byte, request *xrdclose.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	h.mu.Lock()
+	file, ok := h.sessions[sessionID][request.Handle]
+	if ok {
+		delete(h.sessions[sessionID], request.Handle)
+	}
+	h.mu.Unlock()
+
+	if !ok {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid file handle: %v", request.Handle),
+		}, xrdproto.Error
+	}
+
+	if err := h.release(file); err != nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.IOError,
+			Message: fmt.Sprintf("An IO error occurred: %v", err),
+		}, xrdproto.Error
+	}
+	return nil, xrdproto.Ok
+}
+
+// Read implements server.Handler.Read.
+func (h *proxyHandler) Read(sessionID [16]byte, request *read.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	file := h.getFile(sessionID, request.Handle)
+	if file == nil {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Invalid file handle: %v", request.Handle),
+		}, xrdproto.Error
+	}
+
+	buf := make([]byte, request.Length)
+	n, err := file.ReadAt(buf, request.Offset)
+	if err != nil && err != io.EOF {
+		return proxyError(err), xrdproto.Error
+	}
+
+	return read.Response{Data: buf[:n]}, xrdproto.Ok
+}
+
+// ReadV implements server.Handler.ReadV.
+func (h *proxyHandler) ReadV(sessionID [16]byte, request *readv.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if len(request.Segments) > readv.MaxSegments {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Too many segments: %d (max=%d)", len(request.Segments), readv.MaxSegments),
+		}, xrdproto.Error
+	}
+
+	resp := readv.Response{Chunks: make([]readv.Chunk, len(request.Segments))}
+	for i, seg := range request.Segments {
+		file := h.getFile(sessionID, seg.Handle)
+		if file == nil {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid file handle: %v", seg.Handle),
+			}, xrdproto.Error
+		}
+		if seg.Length < 0 || seg.Length > readv.MaxLength {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid segment length: %d", seg.Length),
+			}, xrdproto.Error
+		}
+
+		buf := make([]byte, seg.Length)
+		n, err := file.ReadAt(buf, seg.Offset)
+		if err != nil && err != io.EOF {
+			return proxyError(err), xrdproto.Error
+		}
+		resp.Chunks[i] = readv.Chunk{Handle: seg.Handle, Offset: seg.Offset, Data: buf[:n]}
+	}
+
+	return resp, xrdproto.Ok
+}
+
+// Stat implements server.Handler.Stat.
+func (h *proxyHandler) Stat(sessionID [16]byte, request *stat.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	name := request.Path
+	if len(name) == 0 {
+		file := h.getFile(sessionID, request.FileHandle)
+		if file == nil {
+			return xrdproto.ServerError{
+				Code:    xrdproto.InvalidRequest,
+				Message: fmt.Sprintf("Invalid file handle: %v", request.FileHandle),
+			}, xrdproto.Error
+		}
+		if request.Options&stat.OptionsVFS == 0 {
+			return stat.DefaultResponse{EntryStat: file.stat}, xrdproto.Ok
+		}
+		name = file.name
+	}
+
+	ctx := context.Background()
+	if request.Options&stat.OptionsVFS != 0 {
+		vfs, err := h.client.FS().VirtualStat(ctx, name)
+		if err != nil {
+			return proxyError(err), xrdproto.Error
+		}
+		return stat.VirtualFSResponse{VirtualFSStat: vfs}, xrdproto.Ok
+	}
+
+	es, err := h.client.FS().Stat(ctx, name)
+	if err != nil {
+		return proxyError(err), xrdproto.Error
+	}
+	return stat.DefaultResponse{EntryStat: es}, xrdproto.Ok
+}
+
+// Query implements server.Handler.Query.
+// Only checksum queries are supported, and forwarded to the remote server.
+func (h *proxyHandler) Query(sessionID [16]byte, request *query.Request) (xrdproto.Marshaler, xrdproto.ResponseStatus) {
+	if request.Query != query.Checksum {
+		return xrdproto.ServerError{
+			Code:    xrdproto.InvalidRequest,
+			Message: fmt.Sprintf("Query request of type %d is not implemented", request.Query),
+		}, xrdproto.Error
+	}
+
+	var resp query.Response
+	_, err := h.client.Send(context.Background(), &resp, request)
+	if err != nil {
+		return proxyError(err), xrdproto.Error
+	}
+	return resp, xrdproto.Ok
+}
+
+// CloseSession implements server.Handler.CloseSession.
+func (h *proxyHandler) CloseSession(sessionID [16]byte) error {
+	h.mu.Lock()
+	handles := h.sessions[sessionID]
+	delete(h.sessions, sessionID)
+	h.mu.Unlock()
+
+	var err error
+	for _, f := range handles {
+		if cerr := h.release(f); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (h *proxyHandler) getFile(sessionID [16]byte, handle xrdfs.FileHandle) *cacheFile {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sessions[sessionID][handle]
+}
+
+// open returns the cached file for the provided remote file, opening it if needed.
+func (h *proxyHandler) open(name string) (*cacheFile, error) {
+	key := name
+	if i := strings.Index(key, "?"); i >= 0 {
+		key = key[:i]
+	}
+	key = path.Clean("/" + key)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if f, ok := h.files[key]; ok {
+		f.refs++
+		return f, nil
+	}
+
+	ctx := context.Background()
+	remote, err := h.client.FS().Open(ctx, name, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := remote.Stat(ctx)
+	if err != nil {
+		_ = remote.Close(ctx)
+		return nil, err
+	}
+
+	f, err := openCacheFile(filepath.Join(h.dir, filepath.FromSlash(key)), remote, es)
+	if err != nil {
+		_ = remote.Close(ctx)
+		return nil, err
+	}
+	f.name = name
+	f.key = key
+	f.refs = 1
+	h.files[key] = f
+
+	return f, nil
+}
+
+// release releases a reference to the provided cached file, closing it
+// when no handle refers to it anymore.
+func (h *proxyHandler) release(f *cacheFile) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f.refs--
+	if f.refs > 0 {
+		return nil
+	}
+	delete(h.files, f.key)
+	return f.close()
+}
+
+// proxyError converts an error received from the remote server into an error
+// sent to the client of the proxy.
+func proxyError(err error) xrdproto.ServerError {
+	var serr xrdproto.ServerError
+	if errors.As(err, &serr) {
+		return serr
+	}
+	return xrdproto.ServerError{
+		Code:    xrdproto.IOError,
+		Message: fmt.Sprintf("An IO error occurred: %v", err),
+	}
+}
+
+// cacheFile is a remote file whose blocks are cached in a local file.
+//
+// The blocks already fetched are recorded in a companion ".cinfo" file,
+// together with the size and modification time of the remote file,
+// so the cache can be reused as long as the remote file is unchanged.
+type cacheFile struct {
+	name string // name is the path of the remote file.
+	key  string // key is the cleaned path of the remote file.
+	refs int    // refs is the number of handles referring to this file.
+
+	remote xrdfs.File
+	stat   xrdfs.EntryStat
+
+	data *os.File // data holds the cached blocks.
+	info *os.File // info holds the cache metadata.
+
+	mu     sync.Mutex
+	blocks []byte // blocks is the bitmap of the cached blocks.
+}
+
+// cacheMagic identifies the format of the cache metadata files.
+var cacheMagic = [4]byte{'x', 'r', 'd', 'c'}
+
+// cacheHeaderLen is the size of the header of the cache metadata files:
+// magic, block size, size and modification time of the remote file.
+const cacheHeaderLen = 4 + 3*8
+
+func openCacheFile(fname string, remote xrdfs.File, es xrdfs.EntryStat) (*cacheFile, error) {
+	err := os.MkdirAll(filepath.Dir(fname), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("xrootd: could not create cache directory: %w", err)
+	}
+
+	f := &cacheFile{
+		remote: remote,
+		stat:   es,
+		blocks: make([]byte, (nblocks(es.EntrySize)+7)/8),
+	}
+
+	f.data, err = os.OpenFile(fname, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("xrootd: could not open cache file: %w", err)
+	}
+
+	f.info, err = os.OpenFile(fname+".cinfo", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		_ = f.data.Close()
+		return nil, fmt.Errorf("xrootd: could not open cache metadata file: %w", err)
+	}
+
+	if !f.load() {
+		// stale or missing cache: discard its content.
+		for _, err := range []error{
+			f.data.Truncate(0),
+			f.data.Truncate(es.EntrySize),
+			f.info.Truncate(0),
+			f.flush(),
+		} {
+			if err != nil {
+				_ = f.close()
+				return nil, fmt.Errorf("xrootd: could not reset cache file: %w", err)
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// load loads the cache metadata from disk and reports whether
+// it describes the current version of the remote file.
+func (f *cacheFile) load() bool {
+	buf := make([]byte, cacheHeaderLen+len(f.blocks))
+	_, err := f.info.ReadAt(buf, 0)
+	if err != nil {
+		return false
+	}
+
+	var (
+		magic = buf[:4]
+		bsize = int64(binary.BigEndian.Uint64(buf[4:]))
+		size  = int64(binary.BigEndian.Uint64(buf[12:]))
+		mtime = int64(binary.BigEndian.Uint64(buf[20:]))
+	)
+	if string(magic) != string(cacheMagic[:]) ||
+		bsize != proxyBlockSize ||
+		size != f.stat.EntrySize ||
+		mtime != f.stat.Mtime {
+		return false
+	}
+
+	copy(f.blocks, buf[cacheHeaderLen:])
+	return true
+}
+
+// flush writes the cache metadata to disk.
+func (f *cacheFile) flush() error {
+	buf := make([]byte, cacheHeaderLen, cacheHeaderLen+len(f.blocks))
+	copy(buf, cacheMagic[:])
+	binary.BigEndian.PutUint64(buf[4:], proxyBlockSize)
+	binary.BigEndian.PutUint64(buf[12:], uint64(f.stat.EntrySize))
+	binary.BigEndian.PutUint64(buf[20:], uint64(f.stat.Mtime))
+	buf = append(buf, f.blocks...)
+	_, err := f.info.WriteAt(buf, 0)
+	return err
+}
+
+func (f *cacheFile) close() error {
+	var errs []error
+	for _, err := range []error{
+		f.remote.Close(context.Background()),
+		f.data.Close(),
+		f.info.Close(),
+	} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("xrootd: could not close cache file: %v", errs)
+	}
+	return nil
+}
+
+// ReadAt reads len(p) bytes into p starting at offset off,
+// fetching the missing blocks from the remote file.
+func (f *cacheFile) ReadAt(p []byte, off int64) (int, error) {
+	size := f.stat.EntrySize
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	end := off + int64(len(p))
+	if end > size {
+		end = size
+	}
+
+	err := f.fetch(off, end)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := f.data.ReadAt(p[:end-off], off)
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// fetch makes sure the blocks covering the [beg, end) range are cached.
+func (f *cacheFile) fetch(beg, end int64) error {
+	last := (end - 1) / proxyBlockSize
+	for i := beg / proxyBlockSize; i <= last; i++ {
+		if f.has(i) {
+			continue
+		}
+		j := i
+		for j < last && !f.has(j+1) {
+			j++
+		}
+		err := f.fill(i, j)
+		if err != nil {
+			return err
+		}
+		i = j
+	}
+	return nil
+}
+
+// fill fetches the blocks [i, j] from the remote file and stores them in the cache.
+func (f *cacheFile) fill(i, j int64) error {
+	beg := i * proxyBlockSize
+	end := (j + 1) * proxyBlockSize
+	if end > f.stat.EntrySize {
+		end = f.stat.EntrySize
+	}
+
+	buf := make([]byte, end-beg)
+	for n := 0; n < len(buf); {
+		sub := buf[n:]
+		if len(sub) > proxyBlockSize {
+			sub = sub[:proxyBlockSize]
+		}
+		k, err := f.remote.ReadAt(sub, beg+int64(n))
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if k == 0 {
+			return io.ErrUnexpectedEOF
+		}
+		n += k
+	}
+
+	_, err := f.data.WriteAt(buf, beg)
+	if err != nil {
+		return fmt.Errorf("xrootd: could not write to cache file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for k := i; k <= j; k++ {
+		f.blocks[k/8] |= 1 << (k % 8)
+	}
+	return f.flush()
+}
+
+func (f *cacheFile) has(i int64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.blocks[i/8]&(1<<(i%8)) != 0
+}
+
+func nblocks(size int64) int64 {
+	return (size + proxyBlockSize - 1) / proxyBlockSize
+}
+
+var (
+	_ io.ReaderAt = (*cacheFile)(nil)
+)
@@ -0,0 +1,177 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/adler32"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+func createProxy(remote, dir string, errorHandler func(err error)) (srv *xrootd.Server, addr string, err error) {
+	cli, err := createClient(remote)
+	if err != nil {
+		return nil, "", fmt.Errorf("xrd-proxy: could not create client: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		cli.Close()
+		return nil, "", fmt.Errorf("xrd-proxy: could not listen: %w", err)
+	}
+
+	srv = xrootd.NewServer(xrootd.NewProxyHandler(cli, dir), func(err error) {
+		errorHandler(fmt.Errorf("xrd-proxy: an error occured: %w", err))
+	})
+
+	go func() {
+		defer cli.Close()
+		if err := srv.Serve(listener); err != nil && err != xrootd.ErrServerClosed {
+			errorHandler(fmt.Errorf("xrd-proxy: could not serve: %w", err))
+		}
+	}()
+
+	return srv, listener.Addr().String(), nil
+}
+
+func TestProxyHandler(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	want := make([]byte, 3<<20+42)
+	for i := range want {
+		want[i] = byte(i % 251)
+	}
+	err = os.MkdirAll(path.Join(baseDir, "dir1"), 0755)
+	if err != nil {
+		t.Fatalf("could not create test dir: %v", err)
+	}
+	err = os.WriteFile(path.Join(baseDir, "dir1", "file1.bin"), want, 0644)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	proxy, paddr, err := createProxy(addr, cacheDir, func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = proxy.Shutdown(context.Background())
+	}()
+
+	cli, err := createClient(paddr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	ctx := context.Background()
+
+	ents, err := cli.FS().Dirlist(ctx, "dir1")
+	if err != nil {
+		t.Fatalf("could not call Dirlist: %v", err)
+	}
+	if len(ents) != 1 || ents[0].Name() != "file1.bin" || ents[0].Size() != int64(len(want)) {
+		t.Fatalf("invalid dirlist: %#v", ents)
+	}
+
+	st, err := cli.FS().Stat(ctx, "dir1/file1.bin")
+	if err != nil {
+		t.Fatalf("could not call Stat: %v", err)
+	}
+	if got, want := st.Size(), int64(len(want)); got != want {
+		t.Fatalf("invalid stat size: got=%d, want=%d", got, want)
+	}
+
+	cksum, err := cli.FS().Checksum(ctx, "dir1/file1.bin", "adler32")
+	if err != nil {
+		t.Fatalf("could not call Checksum: %v", err)
+	}
+	if got, want := cksum.Value, adler32.Checksum(want); binary.BigEndian.Uint32(got) != want {
+		t.Fatalf("invalid checksum: got=%x, want=%x", got, want)
+	}
+
+	_, err = cli.FS().Open(ctx, "dir1/file2.bin", xrdfs.OpenModeOwnerWrite, xrdfs.OpenOptionsNew)
+	if err == nil {
+		t.Fatalf("expected an error creating a file through the proxy")
+	}
+
+	f, err := cli.FS().Open(ctx, "dir1/file1.bin", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		t.Fatalf("could not call Open: %v", err)
+	}
+	defer f.Close(ctx)
+
+	read := func(off, n int64) []byte {
+		t.Helper()
+		buf := make([]byte, n)
+		var i int64
+		for i < n {
+			k, err := f.ReadAt(buf[i:], off+i)
+			if err != nil && err != io.EOF {
+				t.Fatalf("could not read [%d, %d): %+v", off+i, off+n, err)
+			}
+			if k == 0 {
+				break
+			}
+			i += int64(k)
+		}
+		return buf[:i]
+	}
+
+	if got := read(1<<20-10, 20); !bytes.Equal(got, want[1<<20-10:1<<20+10]) {
+		t.Fatalf("invalid data across blocks")
+	}
+
+	// drop the remote data: subsequent reads of cached blocks must be served
+	// from the cache, the other ones fail.
+	err = os.WriteFile(path.Join(baseDir, "dir1", "file1.bin"), make([]byte, len(want)), 0644)
+	if err != nil {
+		t.Fatalf("could not overwrite test file: %v", err)
+	}
+
+	if got := read(0, 2<<20); !bytes.Equal(got, want[:2<<20]) {
+		t.Fatalf("cached data not served from cache")
+	}
+
+	if got := read(2<<20, int64(len(want))); bytes.Equal(got, want[2<<20:]) {
+		t.Fatalf("uncached data served from cache")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(cacheDir, "dir1", "file1.bin"))
+	if err != nil {
+		t.Fatalf("could not read cache file: %v", err)
+	}
+	if !bytes.Equal(raw[:2<<20], want[:2<<20]) {
+		t.Fatalf("invalid cache file content")
+	}
+
+	_, err = os.Stat(filepath.Join(cacheDir, "dir1", "file1.bin.cinfo"))
+	if err != nil {
+		t.Fatalf("could not stat cache metadata file: %v", err)
+	}
+}
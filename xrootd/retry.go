@@ -0,0 +1,132 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
+	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
+	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
+	"go-hep.org/x/hep/xrootd/xrdproto/statx"
+)
+
+// RetryPolicy describes how idempotent requests failing with a transient
+// error (e.g. an overloaded server or a failed connection to a data server)
+// are re-issued by the client.
+//
+// The delay before the n-th retry is MinBackoff * 2^(n-1), capped at MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries int           // MaxRetries is the maximum number of times a request is re-issued.
+	MinBackoff time.Duration // MinBackoff is the delay before the first retry.
+	MaxBackoff time.Duration // MaxBackoff is the maximum delay between two retries.
+}
+
+// DefaultRetryPolicy is the retry policy used by clients created without the WithRetry option.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// WithRetry configures how the XRootD client retries requests failing
+// with a transient error.
+// A zero RetryPolicy disables retries.
+func WithRetry(p RetryPolicy) Option {
+	return func(client *Client) error {
+		switch {
+		case p.MaxRetries < 0:
+			return fmt.Errorf("xrootd: invalid number of retries (%d)", p.MaxRetries)
+		case p.MinBackoff < 0 || p.MaxBackoff < 0:
+			return fmt.Errorf("xrootd: invalid retry backoff (min=%v, max=%v)", p.MinBackoff, p.MaxBackoff)
+		}
+		client.retry = p
+		return nil
+	}
+}
+
+// backoff returns the delay before the n-th retry.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// RequestError is the error returned by the client when a request could not be completed.
+type RequestError struct {
+	ReqID    uint16   // ReqID is the identifier of the failed request.
+	Servers  []string // Servers are the addresses of the servers the request was sent to, in order.
+	Attempts int      // Attempts is the number of times the request was issued.
+	Err      error    // Err is the error returned by the last server.
+}
+
+func (e *RequestError) Error() string {
+	var o strings.Builder
+	fmt.Fprintf(&o, "xrootd: request %d", e.ReqID)
+	if len(e.Servers) > 0 {
+		fmt.Fprintf(&o, " to %s", strings.Join(e.Servers, " -> "))
+	}
+	o.WriteString(" failed")
+	if e.Attempts > 1 {
+		fmt.Fprintf(&o, " after %d attempts", e.Attempts)
+	}
+	fmt.Fprintf(&o, ": %v", e.Err)
+	return o.String()
+}
+
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// isTransient returns whether the provided error is worth retrying the request.
+func isTransient(err error) bool {
+	var serr xrdproto.ServerError
+	if errors.As(err, &serr) {
+		switch serr.Code {
+		case xrdproto.NoServer, xrdproto.InProgress, xrdproto.Overloaded:
+			return true
+		}
+		return false
+	}
+
+	var oerr *net.OpError
+	if errors.As(err, &oerr) {
+		return oerr.Op == "dial" || oerr.Timeout()
+	}
+	return false
+}
+
+// isIdempotent returns whether the provided request can safely be re-issued.
+func isIdempotent(req xrdproto.Request) bool {
+	switch req := req.(type) {
+	case *open.Request:
+		const modify = xrdfs.OpenOptionsDelete | xrdfs.OpenOptionsNew |
+			xrdfs.OpenOptionsOpenUpdate | xrdfs.OpenOptionsMkPath | xrdfs.OpenOptionsOpenAppend
+		return req.Options&modify == 0
+	}
+
+	switch req.ReqID() {
+	case dirlist.RequestID, locate.RequestID, ping.RequestID,
+		protocol.RequestID, query.RequestID, read.RequestID,
+		readv.RequestID, stat.RequestID, statx.RequestID:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+)
+
+type rawResponse []byte
+
+func (raw rawResponse) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(raw)
+	return nil
+}
+
+func TestClient_Retry_Mock(t *testing.T) {
+	overloaded := xrdproto.ServerError{Code: xrdproto.Overloaded, Message: "overloaded"}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		for i := 0; i < 2; i++ {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request: %v", err)
+			}
+
+			var gotRequest ping.Request
+			gotHeader, err := unmarshalRequest(data, &gotRequest)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+
+			switch i {
+			case 0:
+				err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Error, overloaded)
+			default:
+				err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, nil)
+			}
+			if err != nil {
+				cancel()
+				t.Fatalf("could not write response: %v", err)
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		client.retry = RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond}
+		_, err := client.Send(context.Background(), nil, &ping.Request{})
+		if err != nil {
+			t.Fatalf("invalid ping call: %v", err)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+func TestClient_NoRetry_Mock(t *testing.T) {
+	overloaded := xrdproto.ServerError{Code: xrdproto.Overloaded, Message: "overloaded"}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		data, err := xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotRequest mkdir.Request
+		gotHeader, err := unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Error, overloaded)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		client.retry = RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond}
+		_, err := client.Send(context.Background(), nil, &mkdir.Request{Path: "/tmp/dir"})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		var rerr *RequestError
+		if !errors.As(err, &rerr) {
+			t.Fatalf("could not cast err to RequestError: %v", err)
+		}
+		want := &RequestError{
+			ReqID:    mkdir.RequestID,
+			Servers:  []string{client.initialSessionID},
+			Attempts: 1,
+			Err:      overloaded,
+		}
+		if !reflect.DeepEqual(rerr, want) {
+			t.Fatalf("invalid error:\ngot = %#v\nwant= %#v", rerr, want)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+func TestSession_AsyncResponse_Mock(t *testing.T) {
+	serverFunc := func(cancel func(), conn net.Conn) {
+		data, err := xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotRequest ping.Request
+		gotHeader, err := unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.WaitResp, rawResponse{0, 0, 0, 10})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+
+		var wBuffer xrdenc.WBuffer
+		wBuffer.WriteI32(xrdproto.AsyncResponse)
+		wBuffer.WriteI32(0) // reserved
+		hdr := xrdproto.ResponseHeader{StreamID: gotHeader.StreamID, Status: xrdproto.Ok}
+		if err := hdr.MarshalXrd(&wBuffer); err != nil {
+			cancel()
+			t.Fatalf("could not marshal response header: %v", err)
+		}
+
+		err = xrdproto.WriteResponse(conn, xrdproto.StreamID{}, xrdproto.Attn, rawResponse(wBuffer.Bytes()))
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		_, err := client.Send(context.Background(), nil, &ping.Request{})
+		if err != nil {
+			t.Fatalf("invalid ping call: %v", err)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for _, tc := range []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	} {
+		if got := p.backoff(tc.n); got != tc.want {
+			t.Errorf("invalid backoff for retry #%d: got=%v, want=%v", tc.n, got, tc.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 import (
 	"context"
 	"crypto/rand"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -24,7 +25,9 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
 	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -105,6 +108,21 @@ func (s *Server) Shutdown(ctx context.Context) error {
 // new service goroutine for each. The service goroutines read requests and
 // then call s.handler to handle them.
 func (s *Server) Serve(l net.Listener) error {
+	return s.serve(l, nil)
+}
+
+// ServeTLS accepts incoming connections on the Listener l, like Serve.
+// Connections of clients asking for TLS in their initial protocol request,
+// as roots:// clients do, are switched to TLS using the provided
+// configuration, which must contain at least one certificate.
+func (s *Server) ServeTLS(l net.Listener, cfg *tls.Config) error {
+	if cfg == nil || (len(cfg.Certificates) == 0 && cfg.GetCertificate == nil) {
+		return fmt.Errorf("xrootd: TLS configuration without certificate")
+	}
+	return s.serve(l, cfg)
+}
+
+func (s *Server) serve(l net.Listener, cfg *tls.Config) error {
 	s.mu.Lock()
 	s.listeners = append(s.listeners, l)
 	s.mu.Unlock()
@@ -123,15 +141,16 @@ func (s *Server) Serve(l net.Listener) error {
 		s.activeConn[conn] = struct{}{}
 		s.connMu.Unlock()
 
-		go s.handleConnection(conn)
+		go s.handleConnection(conn, cfg)
 	}
 }
 
 // handleConnection handles the client connection.
 // handleConnection reads the handshake and checks it correctness.
-// In case of success, main loop is started that reads requests and
-// handles them. Otherwise, connection is aborted.
-func (s *Server) handleConnection(conn net.Conn) {
+// In case of success, the connection is switched to TLS if the client asks
+// for it and TLS is configured, and the main loop is started that reads
+// requests and handles them. Otherwise, connection is aborted.
+func (s *Server) handleConnection(conn net.Conn, cfg *tls.Config) {
 	defer conn.Close()
 	defer func() {
 		s.connMu.Lock()
@@ -155,6 +174,15 @@ func (s *Server) handleConnection(conn net.Conn) {
 		return
 	}
 
+	if cfg != nil {
+		tconn, err := s.handleTLS(conn, cfg, sessionID)
+		if err != nil {
+			s.errorHandler(fmt.Errorf("could not switch to TLS: %w", err))
+			return
+		}
+		conn = tconn
+	}
+
 	for {
 		// We are using conn for read access only in that place
 		// and only once at time for each conn, so no additional
@@ -194,21 +222,82 @@ func (s *Server) handleConnection(conn net.Conn) {
 				resp, status = s.handleRequest(sessionID, reqHeader.RequestID, rBuffer)
 			}
 
-			if err := xrdproto.WriteResponse(conn, reqHeader.StreamID, status, resp); err != nil {
-				s.closedMu.RLock()
-				defer s.closedMu.RUnlock()
-				// TODO: wait for active requests to be processed while closing.
-				if !s.closed {
-					s.errorHandler(fmt.Errorf("could not close connection: %w", err))
-				}
-				// Abort the connection if an error occurred during
-				// the writing phase because we can't recover from it.
-				return
-			}
+			s.writeResponse(conn, reqHeader.StreamID, status, resp)
 		}(reqData)
 	}
 }
 
+func (s *Server) writeResponse(conn net.Conn, streamID xrdproto.StreamID, status xrdproto.ResponseStatus, resp xrdproto.Marshaler) {
+	if err := xrdproto.WriteResponse(conn, streamID, status, resp); err != nil {
+		s.closedMu.RLock()
+		defer s.closedMu.RUnlock()
+		// TODO: wait for active requests to be processed while closing.
+		if !s.closed {
+			s.errorHandler(fmt.Errorf("could not close connection: %w", err))
+		}
+	}
+}
+
+// handleTLS handles the first request of the client, switching the connection
+// to TLS if that request is a protocol request asking for TLS.
+// handleTLS returns the connection to use for the following requests.
+func (s *Server) handleTLS(conn net.Conn, cfg *tls.Config, sessionID [16]byte) (net.Conn, error) {
+	reqData, err := xrdproto.ReadRequest(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqHeader xrdproto.RequestHeader
+	rBuffer := xrdenc.NewRBuffer(reqData)
+	err = reqHeader.UnmarshalXrd(rBuffer)
+	if err != nil {
+		resp, status := newUnmarshalingErrorResponse(err)
+		s.writeResponse(conn, reqHeader.StreamID, status, resp)
+		return conn, nil
+	}
+
+	if reqHeader.RequestID != protocol.RequestID {
+		resp, status := s.handleRequest(sessionID, reqHeader.RequestID, rBuffer)
+		s.writeResponse(conn, reqHeader.StreamID, status, resp)
+		return conn, nil
+	}
+
+	var req protocol.Request
+	err = req.UnmarshalXrd(rBuffer)
+	if err != nil {
+		resp, status := newUnmarshalingErrorResponse(err)
+		s.writeResponse(conn, reqHeader.StreamID, status, resp)
+		return conn, nil
+	}
+
+	resp, status := s.handler.Protocol(sessionID, &req)
+	presp, ok := resp.(*protocol.Response)
+	if status != xrdproto.Ok || !ok {
+		s.writeResponse(conn, reqHeader.StreamID, status, resp)
+		return conn, nil
+	}
+
+	goTLS := req.Options&protocol.WantTLS != 0 && req.ClientProtocolVersion >= protocol.TLSVersion
+	presp.Flags |= protocol.HaveTLS
+	if goTLS {
+		presp.Flags |= protocol.GotoTLS
+	}
+	err = xrdproto.WriteResponse(conn, reqHeader.StreamID, status, presp)
+	if err != nil {
+		return nil, err
+	}
+	if !goTLS {
+		return conn, nil
+	}
+
+	tconn := tls.Server(conn, cfg)
+	err = tconn.Handshake()
+	if err != nil {
+		return nil, err
+	}
+	return tconn, nil
+}
+
 func (s *Server) handleHandshake(conn net.Conn) error {
 	data := make([]byte, handshake.RequestLength)
 	if _, err := io.ReadFull(conn, data); err != nil {
@@ -283,6 +372,13 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 			return newUnmarshalingErrorResponse(err)
 		}
 		return s.handler.Read(sessionID, &request)
+	case readv.RequestID:
+		var request readv.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.handler.ReadV(sessionID, &request)
 	case write.RequestID:
 		var request write.Request
 		err := request.UnmarshalXrd(rBuffer)
@@ -339,6 +435,13 @@ func (s *Server) handleRequest(sessionID [16]byte, requestID uint16, rBuffer *xr
 			return newUnmarshalingErrorResponse(err)
 		}
 		return s.handler.Remove(sessionID, &request)
+	case query.RequestID:
+		var request query.Request
+		err := request.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return newUnmarshalingErrorResponse(err)
+		}
+		return s.handler.Query(sessionID, &request)
 	case rmdir.RequestID:
 		var request rmdir.Request
 		err := request.UnmarshalXrd(rBuffer)
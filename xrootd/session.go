@@ -16,6 +16,7 @@ import (
 	"go-hep.org/x/hep/xrootd/internal/mux"
 	"go-hep.org/x/hep/xrootd/internal/xrdenc"
 	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
 	"go-hep.org/x/hep/xrootd/xrdproto/signing"
 	"go-hep.org/x/hep/xrootd/xrdproto/sigver"
 )
@@ -101,9 +102,7 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 		maxSubs:   8, // TODO: The value of 8 is just a guess. Change it?
 	}
 
-	go sess.consume()
-
-	if err := sess.handshake(ctx); err != nil {
+	if err := sess.connect(ctx, protocol.ExpectLogin); err != nil {
 		sess.Close()
 		return nil, err
 	}
@@ -135,6 +134,22 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 	return sess, nil
 }
 
+// connect performs the handshake with the server, switching the connection
+// to TLS if needed, and starts consuming the responses of the server.
+func (sess *cliSession) connect(ctx context.Context, expect protocol.Expect) error {
+	if sess.client.tls != nil {
+		err := sess.startTLS(ctx, expect)
+		if err != nil {
+			return err
+		}
+		go sess.consume()
+		return nil
+	}
+
+	go sess.consume()
+	return sess.handshake(ctx)
+}
+
 // Close closes the connection. Any blocked operation will be unblocked and return error.
 func (sess *cliSession) Close() error {
 	if sess == nil {
@@ -205,7 +220,13 @@ func (sess *cliSession) handleWaitResponse(streamID xrdproto.StreamID, data []by
 	}
 
 	go func(req pendingRequest) {
-		time.Sleep(resp.Duration)
+		timer := time.NewTimer(resp.Duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-sess.ctx.Done():
+			return
+		}
 		if err := sess.writeRequest(req); err != nil {
 			resp := mux.ServerResponse{Err: fmt.Errorf("xrootd: could not send data to the server: %w", err)}
 			err := sess.mux.SendData(streamID, resp)
@@ -218,6 +239,32 @@ func (sess *cliSession) handleWaitResponse(streamID xrdproto.StreamID, data []by
 	return nil
 }
 
+// parseAsyncResponse extracts the response embedded in the data of a "kXR_attn" response.
+// parseAsyncResponse returns false if the response does not carry an asynchronous response.
+// See http://xrootd.org/doc/dev45/XRdv310.pdf, p. 29 for the specification of the response.
+func parseAsyncResponse(data []byte) (xrdproto.ResponseHeader, []byte, bool) {
+	var hdr xrdproto.ResponseHeader
+	if len(data) < 8+xrdproto.ResponseHeaderLength {
+		return hdr, nil, false
+	}
+
+	rBuffer := xrdenc.NewRBuffer(data)
+	if rBuffer.ReadI32() != xrdproto.AsyncResponse {
+		return hdr, nil, false
+	}
+	rBuffer.Skip(4) // reserved
+
+	if err := hdr.UnmarshalXrd(rBuffer); err != nil {
+		return hdr, nil, false
+	}
+
+	body := rBuffer.Bytes()
+	if hdr.DataLength < 0 || int(hdr.DataLength) > len(body) {
+		return hdr, nil, false
+	}
+	return hdr, body[:hdr.DataLength], true
+}
+
 func (sess *cliSession) consume() {
 	var header xrdproto.ResponseHeader
 	var headerBytes = make([]byte, xrdproto.ResponseHeaderLength)
@@ -242,6 +289,15 @@ func (sess *cliSession) consume() {
 			resp.Err = nil
 			resp.Redirection = nil
 
+			if header.Status == xrdproto.Attn {
+				var ok bool
+				header, resp.Data, ok = parseAsyncResponse(resp.Data)
+				if !ok {
+					// unsolicited message not tied to a request.
+					continue
+				}
+			}
+
 			switch header.Status {
 			case xrdproto.Error:
 				resp.Err = header.Error(resp.Data)
@@ -250,6 +306,10 @@ func (sess *cliSession) consume() {
 				if resp.Err == nil {
 					continue
 				}
+			case xrdproto.WaitResp:
+				// the actual response will be delivered later on,
+				// as part of an asynchronous response.
+				continue
 			case xrdproto.Redirect:
 				resp.Redirection, resp.Err = mux.ParseRedirection(resp.Data)
 			}
@@ -468,9 +528,7 @@ func newSubSession(ctx context.Context, parent *cliSession) (*cliSession, error)
 		isSub:     true,
 	}
 
-	go sess.consume()
-
-	if err := sess.handshake(ctx); err != nil {
+	if err := sess.connect(ctx, protocol.ExpectBind); err != nil {
 		sess.Close()
 		return nil, err
 	}
@@ -0,0 +1,19 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(darwin || freebsd || linux)
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"fmt"
+	"runtime"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// statVFS returns the virtual file system information of the partition holding name.
+func statVFS(name string) (xrdfs.VirtualFSStat, error) {
+	return xrdfs.VirtualFSStat{}, fmt.Errorf("xrootd: virtual file system information not supported on %s", runtime.GOOS)
+}
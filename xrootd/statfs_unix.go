@@ -0,0 +1,39 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || freebsd || linux
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"syscall"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// statVFS returns the virtual file system information of the partition holding name.
+func statVFS(name string) (xrdfs.VirtualFSStat, error) {
+	var st syscall.Statfs_t
+	err := syscall.Statfs(name, &st)
+	if err != nil {
+		return xrdfs.VirtualFSStat{}, err
+	}
+
+	var (
+		bsize = uint64(st.Bsize)
+		total = uint64(st.Blocks) * bsize
+		used  = (uint64(st.Blocks) - uint64(st.Bfree)) * bsize
+		free  = uint64(st.Bavail) * bsize
+		util  = 0
+	)
+	if total > 0 {
+		util = int(float64(used) / float64(total) * 100)
+	}
+
+	return xrdfs.VirtualFSStat{
+		NumberRW:      1,
+		FreeRW:        int(free >> 20),
+		UtilizationRW: util,
+	}, nil
+}
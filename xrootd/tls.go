@@ -0,0 +1,156 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/handshake"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+)
+
+// WithTLS configures the XRootD client to switch all its connections to
+// TLS, as required by roots:// endpoints, right after the initial handshake.
+// The connection fails if the server does not support TLS.
+//
+// If cfg is nil, the certificates of the system and of the certificate
+// authorities found in the directory named by the X509_CERT_DIR
+// environment variable (or /etc/grid-security/certificates by default)
+// are used to verify the servers.
+func WithTLS(cfg *tls.Config) Option {
+	return func(client *Client) error {
+		if cfg == nil {
+			var err error
+			cfg, err = defaultTLSConfig()
+			if err != nil {
+				return err
+			}
+		}
+		client.tls = cfg
+		return nil
+	}
+}
+
+// defaultTLSConfig returns the TLS configuration used to connect to
+// roots:// endpoints.
+func defaultTLSConfig() (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	dir := os.Getenv("X509_CERT_DIR")
+	if dir == "" {
+		dir = "/etc/grid-security/certificates"
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("xrootd: could not list certificates in %q: %w", dir, err)
+	}
+	for _, name := range names {
+		raw, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("xrootd: could not read certificate: %w", err)
+		}
+		pool.AppendCertsFromPEM(raw)
+	}
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// startTLS performs the handshake with the server, asks the server to switch
+// the connection to TLS with a protocol request and upgrades the connection.
+// startTLS must be called before the session starts consuming responses.
+func (sess *cliSession) startTLS(ctx context.Context, expect protocol.Expect) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = sess.conn.SetDeadline(deadline)
+		defer sess.conn.SetDeadline(time.Time{})
+	}
+
+	var (
+		wBuffer xrdenc.WBuffer
+		header  = xrdproto.RequestHeader{StreamID: xrdproto.StreamID{0, 1}, RequestID: protocol.RequestID}
+		req     = protocol.Request{
+			ClientProtocolVersion: protocol.TLSVersion,
+			Options:               protocol.AbleTLS | protocol.WantTLS,
+			Expect:                expect,
+		}
+	)
+	// the protocol request directly follows the handshake.
+	err := handshake.NewRequest().MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	err = header.MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	err = req.MarshalXrd(&wBuffer)
+	if err != nil {
+		return err
+	}
+	_, err = sess.conn.Write(wBuffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("xrootd: could not send TLS negotiation: %w", err)
+	}
+
+	var hs handshake.Response
+	err = readTLSResponse(sess.conn, &hs)
+	if err != nil {
+		return fmt.Errorf("xrootd: could not read handshake response: %w", err)
+	}
+	sess.protocolVersion = hs.ProtocolVersion
+
+	var resp protocol.Response
+	err = readTLSResponse(sess.conn, &resp)
+	if err != nil {
+		return fmt.Errorf("xrootd: could not read protocol response: %w", err)
+	}
+	if !resp.HasTLS() {
+		return fmt.Errorf("xrootd: server %q does not support TLS", sess.addr)
+	}
+
+	cfg := sess.client.tls.Clone()
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(sess.addr)
+		if err != nil {
+			return fmt.Errorf("xrootd: could not extract host from %q: %w", sess.addr, err)
+		}
+		cfg.ServerName = host
+	}
+
+	conn := tls.Client(sess.conn, cfg)
+	err = conn.HandshakeContext(ctx)
+	if err != nil {
+		return fmt.Errorf("xrootd: could not perform TLS handshake with %q: %w", sess.addr, err)
+	}
+	sess.conn = conn
+
+	return nil
+}
+
+func readTLSResponse(conn net.Conn, resp xrdproto.Unmarshaler) error {
+	header, data, err := xrdproto.ReadResponse(conn)
+	if err != nil {
+		return err
+	}
+	if header.Status != xrdproto.Ok {
+		err := header.Error(data)
+		if err == nil {
+			err = fmt.Errorf("xrootd: unexpected response status %d", header.Status)
+		}
+		return err
+	}
+	return resp.UnmarshalXrd(xrdenc.NewRBuffer(data))
+}
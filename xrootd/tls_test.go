@@ -0,0 +1,174 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// newTLSCert creates a self-signed certificate for localhost and returns it,
+// together with its PEM encoding.
+func newTLSCert(t *testing.T) (tls.Certificate, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key: %+v", err)
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("could not create certificate: %+v", err)
+	}
+
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("Hello XRootD.\n"), 0644)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	cert, certPEM := newTLSCert(t)
+
+	serve := func(tlsCfg *tls.Config) string {
+		l, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("could not listen: %+v", err)
+		}
+		srv := xrootd.NewServer(xrootd.NewFSHandler(dir), nil)
+		go func() {
+			switch tlsCfg {
+			case nil:
+				_ = srv.Serve(l)
+			default:
+				_ = srv.ServeTLS(l, tlsCfg)
+			}
+		}()
+		t.Cleanup(func() {
+			_ = srv.Shutdown(context.Background())
+		})
+		return l.Addr().String()
+	}
+
+	var (
+		tlsAddr = serve(&tls.Config{Certificates: []tls.Certificate{cert}})
+		tcpAddr = serve(nil)
+		roots   = x509.NewCertPool()
+	)
+	roots.AppendCertsFromPEM(certPEM)
+
+	certDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(certDir, "ca.pem"), certPEM, 0644)
+	if err != nil {
+		t.Fatalf("could not write certificate: %+v", err)
+	}
+
+	read := func(t *testing.T, cli *xrootd.Client) {
+		t.Helper()
+		ctx := context.Background()
+		f, err := cli.FS().Open(ctx, "/file.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close(ctx)
+
+		buf := make([]byte, 6)
+		_, err = f.ReadAt(buf, 6)
+		if err != nil {
+			t.Fatalf("could not read file: %+v", err)
+		}
+		if got, want := string(buf), "XRootD"; got != want {
+			t.Fatalf("invalid data: got=%q, want=%q", got, want)
+		}
+	}
+
+	t.Run("tls", func(t *testing.T) {
+		cli, err := xrootd.NewClient(context.Background(), tlsAddr, "gopher", xrootd.WithTLS(&tls.Config{RootCAs: roots}))
+		if err != nil {
+			t.Fatalf("could not create client: %+v", err)
+		}
+		defer cli.Close()
+		read(t, cli)
+	})
+
+	t.Run("tls-cert-dir", func(t *testing.T) {
+		t.Setenv("X509_CERT_DIR", certDir)
+		cli, err := xrootd.NewClient(context.Background(), tlsAddr, "gopher", xrootd.WithTLS(nil))
+		if err != nil {
+			t.Fatalf("could not create client: %+v", err)
+		}
+		defer cli.Close()
+		read(t, cli)
+	})
+
+	t.Run("no-tls-client", func(t *testing.T) {
+		cli, err := xrootd.NewClient(context.Background(), tlsAddr, "gopher")
+		if err != nil {
+			t.Fatalf("could not create client: %+v", err)
+		}
+		defer cli.Close()
+		read(t, cli)
+	})
+
+	t.Run("untrusted-server", func(t *testing.T) {
+		_, err := xrootd.NewClient(context.Background(), tlsAddr, "gopher", xrootd.WithTLS(&tls.Config{RootCAs: x509.NewCertPool()}))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("no-tls-server", func(t *testing.T) {
+		_, err := xrootd.NewClient(context.Background(), tcpAddr, "gopher", xrootd.WithTLS(&tls.Config{RootCAs: roots}))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("no-certificate", func(t *testing.T) {
+		l, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("could not listen: %+v", err)
+		}
+		defer l.Close()
+
+		srv := xrootd.NewServer(xrootd.Default(), nil)
+		err = srv.ServeTLS(l, &tls.Config{})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
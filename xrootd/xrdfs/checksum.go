@@ -0,0 +1,57 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdfs
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"strings"
+)
+
+// Checksum is the checksum of a file, as computed by the XRootD server.
+type Checksum struct {
+	Type  string // Type is the name of the checksum algorithm (e.g. adler32, md5).
+	Value []byte // Value is the checksum value.
+}
+
+func (cs Checksum) String() string {
+	return cs.Type + ":" + hex.EncodeToString(cs.Value)
+}
+
+// ParseChecksum parses a checksum in the "<type> <hex-value>" format
+// used by XRootD servers.
+func ParseChecksum(s string) (Checksum, error) {
+	s = strings.TrimRight(s, "\x00\n ")
+	toks := strings.Fields(s)
+	if len(toks) != 2 {
+		return Checksum{}, fmt.Errorf("xrdfs: invalid checksum %q", s)
+	}
+
+	v, err := hex.DecodeString(toks[1])
+	if err != nil {
+		return Checksum{}, fmt.Errorf("xrdfs: invalid checksum value %q: %w", s, err)
+	}
+
+	return Checksum{Type: strings.ToLower(toks[0]), Value: v}, nil
+}
+
+// NewHash returns a hash.Hash computing checksums with the named algorithm.
+// The supported algorithms are adler32, crc32c and md5.
+func NewHash(typ string) (hash.Hash, error) {
+	switch strings.ToLower(typ) {
+	case "adler32":
+		return adler32.New(), nil
+	case "crc32c":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case "md5":
+		return md5.New(), nil
+	default:
+		return nil, fmt.Errorf("xrdfs: unknown checksum algorithm %q", typ)
+	}
+}
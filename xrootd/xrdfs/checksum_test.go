@@ -0,0 +1,96 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdfs_test
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+func TestParseChecksum(t *testing.T) {
+	for _, tc := range []struct {
+		raw  string
+		want xrdfs.Checksum
+		str  string
+		err  bool
+	}{
+		{
+			raw:  "adler32 11e60398\x00",
+			want: xrdfs.Checksum{Type: "adler32", Value: []byte{0x11, 0xe6, 0x03, 0x98}},
+			str:  "adler32:11e60398",
+		},
+		{
+			raw:  "MD5 d41d8cd98f00b204e9800998ecf8427e\n",
+			want: xrdfs.Checksum{Type: "md5", Value: mustHex("d41d8cd98f00b204e9800998ecf8427e")},
+			str:  "md5:d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			raw: "adler32",
+			err: true,
+		},
+		{
+			raw: "adler32 xyz",
+			err: true,
+		},
+	} {
+		t.Run(tc.raw, func(t *testing.T) {
+			got, err := xrdfs.ParseChecksum(tc.raw)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not parse checksum: %+v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			case err != nil:
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid checksum:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+			if got, want := got.String(), tc.str; got != want {
+				t.Fatalf("invalid checksum string: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestNewHash(t *testing.T) {
+	for _, tc := range []struct {
+		typ  string
+		want string
+	}{
+		{"adler32", "11e60398"},
+		{"ADLER32", "11e60398"},
+		{"crc32c", "2d0e3663"},
+		{"md5", "9c677286866aad38f8e9b660f5411814"},
+	} {
+		t.Run(tc.typ, func(t *testing.T) {
+			h, err := xrdfs.NewHash(tc.typ)
+			if err != nil {
+				t.Fatalf("could not create hash: %+v", err)
+			}
+			_, _ = h.Write([]byte("Wikipedia"))
+			if got, want := hex.EncodeToString(h.Sum(nil)), tc.want; got != want {
+				t.Fatalf("invalid checksum: got=%s, want=%s", got, want)
+			}
+		})
+	}
+
+	_, err := xrdfs.NewHash("sha3")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func mustHex(s string) []byte {
+	v, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
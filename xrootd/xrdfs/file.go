@@ -39,6 +39,13 @@ type File interface {
 	// ReadAtContext reads len(p) bytes into p starting at offset off.
 	ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error)
 
+	// ReadV reads the provided chunks of the file with vector reads,
+	// fetching many scattered regions of the file in a single round-trip.
+	// The Data of each chunk is resliced to the number of bytes actually
+	// read, which may be shorter at the end of the file.
+	// ReadV returns the total number of bytes read.
+	ReadV(ctx context.Context, chunks []Chunk) (n int, err error)
+
 	// WriteAtContext writes len(p) bytes from p to the file at offset off.
 	WriteAtContext(ctx context.Context, p []byte, off int64) error
 
@@ -63,6 +70,12 @@ type File interface {
 // FileHandle is the file handle, which should be treated as opaque data.
 type FileHandle [4]byte
 
+// Chunk describes a contiguous region of a file to be read.
+type Chunk struct {
+	Offset int64  // Offset of the region in the file.
+	Data   []byte // Data receives the len(Data) bytes of the region.
+}
+
 // FileCompression holds the compression parameters such as the page size and the type of compression.
 type FileCompression struct {
 	PageSize int32
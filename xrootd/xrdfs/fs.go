@@ -58,6 +58,11 @@ type FileSystem interface {
 	// Statx obtains type information for one or more paths.
 	// Only a limited number of flags is meaningful such as StatIsExecutable, StatIsDir, StatIsOther, StatIsOffline.
 	Statx(ctx context.Context, paths []string) ([]StatFlags, error)
+
+	// Checksum returns the checksum of the file at path, computed by the
+	// XRootD server with the typ algorithm (e.g. adler32, md5).
+	// The default algorithm of the server is used if typ is empty.
+	Checksum(ctx context.Context, path, typ string) (Checksum, error)
 }
 
 // OpenMode is the mode in which path is to be opened.
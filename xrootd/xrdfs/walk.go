@@ -0,0 +1,215 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdfs
+
+import (
+	"context"
+	"errors"
+	stdpath "path"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// SkipDir is used as a return value from WalkFuncs to indicate that
+// the directory named in the call is to be skipped. It is not returned
+// as an error by any function.
+var SkipDir = errors.New("xrdfs: skip this directory") //lint:ignore ST1012 EOF-like sentry
+
+// WalkFunc is the type of the function called for each file or directory
+// visited by Walk. The path argument contains the argument to Walk as a
+// prefix; that is, if Walk is called with "dir", which is a directory
+// containing the file "a", the walk function will be called with argument
+// "dir/a". The info argument is the EntryStat for the named path.
+//
+// If there was a problem walking to the file or directory named by path, the
+// incoming error will describe the problem and the function can decide how
+// to handle that error (and Walk will not descend into that directory).
+// If an error is returned, processing stops. The sole exception is when the
+// function returns the special value SkipDir. If the function returns SkipDir
+// when invoked on a directory, Walk skips the directory's contents entirely.
+// If the function returns SkipDir when invoked on a non-directory file, Walk
+// skips the remaining files in the containing directory.
+type WalkFunc func(path string, info EntryStat, err error) error
+
+// Walk walks the remote file tree rooted at root, calling fn for each file or
+// directory in the tree, including root.
+// The entries of a directory are walked in lexical order.
+// The stat information of the entries is retrieved together with the
+// directory listing, so Walk issues a single request per directory.
+func Walk(ctx context.Context, fs FileSystem, root string, fn WalkFunc) error {
+	info, err := fs.Stat(ctx, root)
+	if err != nil {
+		err = fn(root, info, err)
+	} else {
+		err = walk(ctx, fs, root, info, fn)
+	}
+	if err == SkipDir {
+		return nil
+	}
+	return err
+}
+
+// walk recursively descends path, calling fn.
+func walk(ctx context.Context, fs FileSystem, path string, info EntryStat, fn WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	ents, err := fs.Dirlist(ctx, path)
+	err1 := fn(path, info, err)
+	if err != nil || err1 != nil {
+		// The caller's behavior is controlled by the return value, which is
+		// decided by fn. fn may ignore err and return nil.
+		// If fn returns SkipDir, it will be handled by the caller.
+		// So walk should return whatever fn returns.
+		return err1
+	}
+
+	sort.Slice(ents, func(i, j int) bool { return ents[i].Name() < ents[j].Name() })
+	for _, ent := range ents {
+		name := stdpath.Join(path, ent.Name())
+		err = walk(ctx, fs, name, ent, fn)
+		if err != nil {
+			if !ent.IsDir() || err != SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Glob returns the names of all remote files matching pattern or nil if
+// there is no matching file. The syntax of patterns is the same as in
+// path.Match, and matching is applied to each element of the path:
+//
+//	names, err := xrdfs.Glob(ctx, fs, "/store/user/*/*.root")
+//
+// Glob returns path.ErrBadPattern when pattern is malformed, and the first
+// error encountered while listing the directories of the remote file tree.
+func Glob(ctx context.Context, fs FileSystem, pattern string) ([]string, error) {
+	// check pattern is well-formed.
+	if _, err := stdpath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	if !hasMeta(pattern) {
+		if _, err := fs.Stat(ctx, pattern); err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+
+	dir, file := stdpath.Split(pattern)
+	dir = cleanGlobPath(dir)
+
+	if !hasMeta(dir) {
+		return glob(ctx, fs, dir, file, nil)
+	}
+
+	// prevent infinite recursion.
+	if dir == pattern {
+		return nil, stdpath.ErrBadPattern
+	}
+
+	dirs, err := Glob(ctx, fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	for _, d := range dirs {
+		matches, err = glob(ctx, fs, d, file, matches)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// glob searches for files matching pattern in the directory dir
+// and appends them to matches.
+// Entries that are not directories are silently skipped.
+func glob(ctx context.Context, fs FileSystem, dir, pattern string, matches []string) ([]string, error) {
+	st, err := fs.Stat(ctx, dir)
+	if err != nil || !st.IsDir() {
+		return matches, nil
+	}
+
+	ents, err := fs.Dirlist(ctx, dir)
+	if err != nil {
+		return matches, err
+	}
+
+	names := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		names = append(names, ent.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ok, err := stdpath.Match(pattern, name)
+		if err != nil {
+			return matches, err
+		}
+		if ok {
+			matches = append(matches, stdpath.Join(dir, name))
+		}
+	}
+	return matches, nil
+}
+
+// cleanGlobPath prepares path for glob matching.
+func cleanGlobPath(path string) string {
+	switch path {
+	case "":
+		return "."
+	case "/":
+		return path
+	default:
+		return path[:len(path)-1] // chop off trailing separator
+	}
+}
+
+// hasMeta reports whether path contains any of the magic characters
+// recognized by path.Match.
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[\`)
+}
+
+// maxConcurrentStats is the maximum number of stat requests issued concurrently by StatAll.
+const maxConcurrentStats = 16
+
+// StatAll returns the entry stat info of all the provided paths, in order.
+// The stat requests are issued concurrently.
+// StatAll returns the first error encountered, if any.
+func StatAll(ctx context.Context, fs FileSystem, paths []string) ([]EntryStat, error) {
+	var (
+		sts = make([]EntryStat, len(paths))
+		sem = make(chan struct{}, maxConcurrentStats)
+	)
+
+	grp, ctx := errgroup.WithContext(ctx)
+	for i := range paths {
+		i := i
+		sem <- struct{}{}
+		grp.Go(func() error {
+			defer func() { <-sem }()
+			st, err := fs.Stat(ctx, paths[i])
+			if err != nil {
+				return err
+			}
+			sts[i] = st
+			return nil
+		})
+	}
+
+	err := grp.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
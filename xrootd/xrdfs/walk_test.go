@@ -0,0 +1,254 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdfs_test
+
+import (
+	"context"
+	"fmt"
+	stdpath "path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// memFS is an in-memory read-only xrdfs.FileSystem.
+type memFS struct {
+	xrdfs.FileSystem
+
+	mu   sync.Mutex
+	ents map[string]xrdfs.EntryStat
+}
+
+func newMemFS(names ...string) *memFS {
+	fs := &memFS{
+		ents: map[string]xrdfs.EntryStat{
+			"/": {EntryName: "/", HasStatInfo: true, Flags: xrdfs.StatIsDir},
+		},
+	}
+	for _, name := range names {
+		dir := strings.HasSuffix(name, "/")
+		name = stdpath.Clean(name)
+		for p := stdpath.Dir(name); p != "/"; p = stdpath.Dir(p) {
+			fs.ents[p] = xrdfs.EntryStat{EntryName: stdpath.Base(p), HasStatInfo: true, Flags: xrdfs.StatIsDir}
+		}
+		es := xrdfs.EntryStat{EntryName: stdpath.Base(name), HasStatInfo: true, EntrySize: int64(len(name))}
+		if dir {
+			es.Flags = xrdfs.StatIsDir
+		}
+		fs.ents[name] = es
+	}
+	return fs
+}
+
+func (fs *memFS) Stat(ctx context.Context, path string) (xrdfs.EntryStat, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	es, ok := fs.ents[stdpath.Clean(path)]
+	if !ok {
+		return es, fmt.Errorf("no such file %q", path)
+	}
+	return es, nil
+}
+
+func (fs *memFS) Dirlist(ctx context.Context, path string) ([]xrdfs.EntryStat, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	path = stdpath.Clean(path)
+	es, ok := fs.ents[path]
+	if !ok || !es.IsDir() {
+		return nil, fmt.Errorf("no such directory %q", path)
+	}
+	var ents []xrdfs.EntryStat
+	for name, es := range fs.ents {
+		if name != "/" && stdpath.Dir(name) == path {
+			ents = append(ents, es)
+		}
+	}
+	return ents, nil
+}
+
+var testFS = []string{
+	"/store/user/alice/f1.root",
+	"/store/user/alice/f2.root",
+	"/store/user/alice/notes.txt",
+	"/store/user/bob/f1.root",
+	"/store/user/bob/sub/f3.root",
+	"/store/user/carol/",
+	"/store/data/run1.root",
+}
+
+func TestWalk(t *testing.T) {
+	fs := newMemFS(testFS...)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		root string
+		skip string
+		want []string
+	}{
+		{
+			root: "/store/user",
+			want: []string{
+				"/store/user",
+				"/store/user/alice",
+				"/store/user/alice/f1.root",
+				"/store/user/alice/f2.root",
+				"/store/user/alice/notes.txt",
+				"/store/user/bob",
+				"/store/user/bob/f1.root",
+				"/store/user/bob/sub",
+				"/store/user/bob/sub/f3.root",
+				"/store/user/carol",
+			},
+		},
+		{
+			root: "/store/user",
+			skip: "/store/user/bob",
+			want: []string{
+				"/store/user",
+				"/store/user/alice",
+				"/store/user/alice/f1.root",
+				"/store/user/alice/f2.root",
+				"/store/user/alice/notes.txt",
+				"/store/user/bob",
+				"/store/user/carol",
+			},
+		},
+		{
+			root: "/store/user/alice",
+			skip: "/store/user/alice/f1.root",
+			want: []string{
+				"/store/user/alice",
+				"/store/user/alice/f1.root",
+			},
+		},
+		{
+			root: "/store/data/run1.root",
+			want: []string{"/store/data/run1.root"},
+		},
+	} {
+		t.Run(tc.root, func(t *testing.T) {
+			var got []string
+			err := xrdfs.Walk(ctx, fs, tc.root, func(path string, info xrdfs.EntryStat, err error) error {
+				if err != nil {
+					return err
+				}
+				if got, want := info.Name(), stdpath.Base(path); got != want {
+					return fmt.Errorf("invalid entry name: got=%q, want=%q", got, want)
+				}
+				got = append(got, path)
+				if path == tc.skip {
+					return xrdfs.SkipDir
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not walk %q: %+v", tc.root, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid walk:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+
+	err := xrdfs.Walk(ctx, fs, "/not-there", func(path string, info xrdfs.EntryStat, err error) error {
+		return err
+	})
+	if err == nil {
+		t.Fatalf("expected an error walking a missing directory")
+	}
+}
+
+func TestGlob(t *testing.T) {
+	fs := newMemFS(testFS...)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		pattern string
+		want    []string
+		err     error
+	}{
+		{
+			pattern: "/store/user/*/*.root",
+			want: []string{
+				"/store/user/alice/f1.root",
+				"/store/user/alice/f2.root",
+				"/store/user/bob/f1.root",
+			},
+		},
+		{
+			pattern: "/store/*/*/f1.root",
+			want: []string{
+				"/store/user/alice/f1.root",
+				"/store/user/bob/f1.root",
+			},
+		},
+		{
+			pattern: "/store/user/[ab]*",
+			want: []string{
+				"/store/user/alice",
+				"/store/user/bob",
+			},
+		},
+		{
+			pattern: "/store/data/run1.root",
+			want:    []string{"/store/data/run1.root"},
+		},
+		{
+			pattern: "/store/data/run2.root",
+		},
+		{
+			pattern: "/store/*/*.txt",
+		},
+		{
+			pattern: "/store/user/[",
+			err:     stdpath.ErrBadPattern,
+		},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			got, err := xrdfs.Glob(ctx, fs, tc.pattern)
+			if err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid glob:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatAll(t *testing.T) {
+	fs := newMemFS(testFS...)
+	ctx := context.Background()
+
+	names := []string{
+		"/store/user/alice/f1.root",
+		"/store/user/bob/sub",
+		"/store/data/run1.root",
+	}
+	got, err := xrdfs.StatAll(ctx, fs, names)
+	if err != nil {
+		t.Fatalf("could not stat files: %+v", err)
+	}
+
+	if len(got) != len(names) {
+		t.Fatalf("invalid number of stats: got=%d, want=%d", len(got), len(names))
+	}
+	for i, name := range names {
+		if got, want := got[i], fs.ents[name]; got != want {
+			t.Fatalf("invalid stat for %q:\ngot= %#v\nwant=%#v", name, got, want)
+		}
+	}
+
+	_, err = xrdfs.StatAll(ctx, fs, append(names, "/not-there"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
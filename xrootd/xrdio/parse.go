@@ -15,6 +15,7 @@ type URL struct {
 	Addr string // address (host [:port]) of the server
 	User string // user name to use to log in
 	Path string // path to the remote file or directory
+	TLS  bool   // whether the connection to the server uses TLS (roots:// or xroots://)
 }
 
 // Parse parses name into an xrootd URL structure.
@@ -23,6 +24,7 @@ func Parse(name string) (URL, error) {
 		user string
 		addr string
 		path string
+		tls  bool
 		err  error
 	)
 
@@ -31,6 +33,10 @@ func Parse(name string) (URL, error) {
 	case -1:
 		path = name
 	default:
+		switch name[:idx] {
+		case "roots", "xroots":
+			tls = true
+		}
 		uri := name[idx+len("://"):]
 		tok := strings.SplitN(uri, "/", 2)
 		user, addr, err = parseUA(tok[0])
@@ -44,7 +50,7 @@ func Parse(name string) (URL, error) {
 		path = path[1:]
 	}
 
-	return URL{Addr: addr, User: user, Path: path}, nil
+	return URL{Addr: addr, User: user, Path: path, TLS: tls}, nil
 }
 
 func parseUA(s string) (user, addr string, err error) {
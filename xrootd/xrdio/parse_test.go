@@ -31,6 +31,24 @@ func TestParse(t *testing.T) {
 				Path: "/file1.root",
 			},
 		},
+		{
+			name: "roots://example.org/file1.root",
+			want: URL{
+				Addr: "example.org",
+				User: "",
+				Path: "/file1.root",
+				TLS:  true,
+			},
+		},
+		{
+			name: "xroots://bob@example.org:1094//file1.root",
+			want: URL{
+				Addr: "example.org:1094",
+				User: "bob",
+				Path: "/file1.root",
+				TLS:  true,
+			},
+		},
 		{
 			name: "root://example.org//file1.root",
 			want: URL{
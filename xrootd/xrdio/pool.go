@@ -0,0 +1,189 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go-hep.org/x/hep/xrootd"
+)
+
+// DefaultPoolSize is the default maximum number of XRootD clients
+// a Pool opens to a given server.
+const DefaultPoolSize = 4
+
+// defaultPool is the pool of clients used by Open.
+var defaultPool = NewPool(DefaultPoolSize)
+
+// Pool is a set of XRootD clients shared among the remote files opened
+// through it.
+//
+// Files located on the same server and opened with the same user share
+// at most n clients, and thus n TCP sessions.
+// The requests of the files sharing a client are interleaved over its
+// session, each request being identified by its own stream ID.
+// A new file is assigned to the least loaded client of its server, so
+// files are evenly spread over the sessions of the pool.
+// A client is closed when the last file using it is closed.
+//
+// A Pool is safe for concurrent use.
+type Pool struct {
+	mu    sync.Mutex
+	max   int
+	conns map[string][]*poolConn // clients, indexed by [tls:]user@addr
+}
+
+// poolConn is a client of a pool, shared by nfiles files.
+type poolConn struct {
+	key    string
+	cli    *xrootd.Client
+	nfiles int
+}
+
+// NewPool creates a new pool of XRootD clients, opening at most n
+// clients per server.
+// If n is not strictly positive, DefaultPoolSize is used.
+func NewPool(n int) *Pool {
+	if n <= 0 {
+		n = DefaultPoolSize
+	}
+	return &Pool{
+		max:   n,
+		conns: make(map[string][]*poolConn),
+	}
+}
+
+// Open opens the name file, where name is the absolute location of that file
+// (xrootd server address and path to the file on that server), using one
+// of the clients of the pool.
+func (p *Pool) Open(name string) (*File, error) {
+	urn, err := Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %q: %w", name, err)
+	}
+
+	pc, err := p.acquire(urn.Addr, urn.User, urn.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("xrdio: could not connect to xrootd server %q: %w", urn.Addr, err)
+	}
+
+	f, err := OpenFrom(pc.cli.FS(), urn.Path)
+	if err != nil {
+		_ = p.release(pc)
+		return nil, err
+	}
+	f.pool = p
+	f.conn = pc
+
+	return f, nil
+}
+
+// Len returns the number of clients currently opened by the pool.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := 0
+	for _, conns := range p.conns {
+		n += len(conns)
+	}
+	return n
+}
+
+// Close closes all the clients of the pool.
+// The files opened through the pool are unusable afterwards.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for key, conns := range p.conns {
+		for _, pc := range conns {
+			err := pc.cli.Close()
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		delete(p.conns, key)
+	}
+	if errs != nil {
+		return fmt.Errorf("xrdio: could not close pool: %v", errs)
+	}
+	return nil
+}
+
+// acquire returns the least loaded client connected to addr with user,
+// over TLS if requested.
+// A new client is created if no client is connected to addr yet, or if
+// all of them already serve files and the pool is not full.
+func (p *Pool) acquire(addr, user string, tls bool) (*poolConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		key   = user + "@" + addr
+		opts  []xrootd.Option
+		conns []*poolConn
+		best  *poolConn
+	)
+	if tls {
+		key = "tls:" + key
+		opts = append(opts, xrootd.WithTLS(nil))
+	}
+	conns = p.conns[key]
+	for _, pc := range conns {
+		if best == nil || pc.nfiles < best.nfiles {
+			best = pc
+		}
+	}
+
+	if best == nil || (best.nfiles > 0 && len(conns) < p.max) {
+		cli, err := xrootd.NewClient(context.Background(), addr, user, opts...)
+		switch {
+		case err != nil && best == nil:
+			return nil, err
+		case err != nil:
+			// fallback to sharing one of the sessions already opened.
+		default:
+			best = &poolConn{key: key, cli: cli}
+			p.conns[key] = append(conns, best)
+		}
+	}
+
+	best.nfiles++
+	return best, nil
+}
+
+// release releases the provided client, closing it if it does not serve
+// any file anymore.
+func (p *Pool) release(pc *poolConn) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc.nfiles--
+	if pc.nfiles > 0 {
+		return nil
+	}
+
+	conns := p.conns[pc.key]
+	for i, v := range conns {
+		if v != pc {
+			continue
+		}
+		conns = append(conns[:i], conns[i+1:]...)
+		switch len(conns) {
+		case 0:
+			delete(p.conns, pc.key)
+		default:
+			p.conns[pc.key] = conns
+		}
+		return pc.cli.Close()
+	}
+
+	// client already closed by Pool.Close.
+	return nil
+}
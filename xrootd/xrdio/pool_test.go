@@ -0,0 +1,114 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+func TestPool(t *testing.T) {
+	dir, err := os.MkdirTemp("", "xrdio-pool-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	srv := xrootd.NewServer(xrootd.NewFSHandler(dir), func(err error) {
+		t.Errorf("server error: %+v", err)
+	})
+	go func() {
+		err := srv.Serve(l)
+		if err != nil && err != xrootd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	const (
+		nfiles = 20
+		nconns = 3
+	)
+
+	for i := 0; i < nfiles; i++ {
+		name := filepath.Join(dir, fmt.Sprintf("file-%02d.txt", i))
+		err := os.WriteFile(name, []byte(fmt.Sprintf("data-%02d", i)), 0644)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+	}
+
+	pool := xrdio.NewPool(nconns)
+	defer pool.Close()
+
+	files := make([]*xrdio.File, nfiles)
+	for i := range files {
+		f, err := pool.Open(fmt.Sprintf("root://gopher@%s//file-%02d.txt", l.Addr(), i))
+		if err != nil {
+			t.Fatalf("could not open file %d: %+v", i, err)
+		}
+		files[i] = f
+	}
+
+	if got, want := pool.Len(), nconns; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+
+	var wg sync.WaitGroup
+	for i := range files {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				buf := make([]byte, 7)
+				_, err := files[i].ReadAt(buf, 0)
+				if err != nil {
+					t.Errorf("could not read file %d: %+v", i, err)
+					return
+				}
+				if got, want := string(buf), fmt.Sprintf("data-%02d", i); got != want {
+					t.Errorf("invalid data for file %d: got=%q, want=%q", i, got, want)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, f := range files {
+		err := f.Close()
+		if err != nil {
+			t.Fatalf("could not close file %d: %+v", i, err)
+		}
+	}
+
+	if got, want := pool.Len(), 0; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+
+	_, err = pool.Open(fmt.Sprintf("root://gopher@%s//missing.txt", l.Addr()))
+	if err == nil {
+		t.Fatalf("expected an error opening a missing file")
+	}
+
+	if got, want := pool.Len(), 0; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"golang.org/x/sync/errgroup"
+)
+
+// minSplit is the minimum size of a read to be split among the replicas
+// of a file.
+const minSplit = 64 * 1024
+
+// OpenReplicas opens a file available from several data servers, where
+// names are the absolute locations of the replicas of that file.
+//
+// Reads of the returned File are spread over all the replicas: large reads
+// and vector reads are split and performed concurrently on the replicas.
+// Writes are only performed on the first replica.
+//
+// Example:
+//
+//  f, err := xrdio.OpenReplicas(
+//      "root://server1.example.com:1094//some/path/to/file",
+//      "root://server2.example.com:1094//some/path/to/file",
+//  )
+func OpenReplicas(names ...string) (*File, error) {
+	return defaultPool.OpenReplicas(names...)
+}
+
+// OpenReplicas opens a file available from several data servers, using
+// the clients of the pool.
+// See the OpenReplicas function for details.
+func (p *Pool) OpenReplicas(names ...string) (*File, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("xrdio: no replica to open")
+	}
+
+	f, err := p.Open(names[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names[1:] {
+		r, err := p.Open(name)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		f.reps = append(f.reps, r)
+		if r.size != f.size {
+			_ = f.Close()
+			return nil, fmt.Errorf(
+				"xrdio: replica %q has an invalid size (got=%d, want=%d)",
+				name, r.size, f.size,
+			)
+		}
+	}
+
+	return f, nil
+}
+
+// srcs returns the files from which data can be read.
+func (f *File) srcs() []xrdfs.File {
+	srcs := make([]xrdfs.File, 1+len(f.reps))
+	srcs[0] = f.f
+	for i, r := range f.reps {
+		srcs[i+1] = r.f
+	}
+	return srcs
+}
+
+// readAt reads data from the replicas of the file.
+func (f *File) readAt(data []byte, offset int64) (int, error) {
+	srcs := f.srcs()
+	if len(data) < minSplit {
+		i := atomic.AddUint32(&f.next, 1)
+		return srcs[int(i)%len(srcs)].ReadAt(data, offset)
+	}
+
+	var (
+		grp   errgroup.Group
+		size  = (len(data) + len(srcs) - 1) / len(srcs)
+		sizes = make([]int, len(srcs))
+	)
+	for i := range srcs {
+		beg := i * size
+		end := beg + size
+		if end > len(data) {
+			end = len(data)
+		}
+		if beg >= end {
+			break
+		}
+		i := i
+		grp.Go(func() error {
+			n, err := srcs[i].ReadAt(data[beg:end], offset+int64(beg))
+			sizes[i] = n
+			return err
+		})
+	}
+	err := grp.Wait()
+
+	n := 0
+	for _, v := range sizes {
+		n += v
+	}
+	return n, err
+}
+
+// ReadV reads the provided chunks of the file with vector reads.
+// The Data of each chunk is resliced to the number of bytes actually read.
+// For files opened with OpenReplicas, the chunks are spread over the
+// replicas and read concurrently.
+// ReadV returns the total number of bytes read.
+func (f *File) ReadV(chunks []xrdfs.Chunk) (int, error) {
+	var (
+		ctx  = context.Background()
+		srcs = f.srcs()
+	)
+	if len(srcs) == 1 {
+		return f.f.ReadV(ctx, chunks)
+	}
+
+	total := 0
+	for _, c := range chunks {
+		total += len(c.Data)
+	}
+
+	// split the chunks into contiguous groups of about the same size.
+	var (
+		grp   errgroup.Group
+		size  = (total + len(srcs) - 1) / len(srcs)
+		sizes = make([]int, len(srcs))
+		beg   = 0
+	)
+	for i := range srcs {
+		if beg == len(chunks) {
+			break
+		}
+		end, n := beg, 0
+		for end < len(chunks) && (n < size || i == len(srcs)-1) {
+			n += len(chunks[end].Data)
+			end++
+		}
+		var (
+			src   = srcs[i]
+			group = chunks[beg:end]
+			i     = i
+		)
+		grp.Go(func() error {
+			n, err := src.ReadV(ctx, group)
+			sizes[i] = n
+			return err
+		})
+		beg = end
+	}
+	err := grp.Wait()
+	if err != nil {
+		return 0, fmt.Errorf("xrdio: could not read chunks of %q: %w", f.name, err)
+	}
+
+	n := 0
+	for _, v := range sizes {
+		n += v
+	}
+	return n, nil
+}
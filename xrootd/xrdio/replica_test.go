@@ -0,0 +1,197 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+func TestOpenReplicas(t *testing.T) {
+	const nsrvs = 3
+
+	data := make([]byte, 1<<20+42)
+	rand.New(rand.NewSource(1234)).Read(data)
+
+	addrs := make([]string, nsrvs)
+	for i := range addrs {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "file.bin"), data, 0644)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		if i == nsrvs-1 {
+			err = os.WriteFile(filepath.Join(dir, "short.bin"), data[:10], 0644)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+		}
+		addrs[i] = newServer(t, dir)
+	}
+
+	pool := xrdio.NewPool(1)
+	defer pool.Close()
+
+	names := make([]string, nsrvs)
+	for i, addr := range addrs {
+		names[i] = fmt.Sprintf("root://gopher@%s//file.bin", addr)
+	}
+
+	f, err := pool.OpenReplicas(names...)
+	if err != nil {
+		t.Fatalf("could not open replicas: %+v", err)
+	}
+	defer f.Close()
+
+	if got, want := pool.Len(), nsrvs; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		off int64
+		n   int
+	}{
+		{0, 10},
+		{5, 100},
+		{0, len(data)},
+		{1024, 3*64*1024 + 1},
+		{int64(len(data)) - 64*1024, 64 * 1024},
+	} {
+		t.Run(fmt.Sprintf("readat-%d-%d", tc.off, tc.n), func(t *testing.T) {
+			buf := make([]byte, tc.n)
+			n, err := f.ReadAt(buf, tc.off)
+			if err != nil {
+				t.Fatalf("could not read: %+v", err)
+			}
+			if n != tc.n {
+				t.Fatalf("invalid number of bytes read: got=%d, want=%d", n, tc.n)
+			}
+			if !bytes.Equal(buf, data[tc.off:tc.off+int64(tc.n)]) {
+				t.Fatalf("invalid data read")
+			}
+		})
+	}
+
+	t.Run("readv", func(t *testing.T) {
+		var (
+			chunks []xrdfs.Chunk
+			want   = 0
+		)
+		for off := 0; off < len(data); off += 40000 {
+			n := 1000 + off%7000
+			chunks = append(chunks, xrdfs.Chunk{Offset: int64(off), Data: make([]byte, n)})
+			want += n
+		}
+		// a chunk crossing the end of the file.
+		chunks = append(chunks, xrdfs.Chunk{Offset: int64(len(data) - 2), Data: make([]byte, 10)})
+		want += 2
+
+		n, err := f.ReadV(chunks)
+		if err != nil {
+			t.Fatalf("could not read chunks: %+v", err)
+		}
+		if n != want {
+			t.Fatalf("invalid number of bytes read: got=%d, want=%d", n, want)
+		}
+		for i, c := range chunks {
+			end := int(c.Offset) + len(c.Data)
+			if !bytes.Equal(c.Data, data[c.Offset:end]) {
+				t.Fatalf("invalid data for chunk %d", i)
+			}
+		}
+		if got, want := len(chunks[len(chunks)-1].Data), 2; got != want {
+			t.Fatalf("invalid size of last chunk: got=%d, want=%d", got, want)
+		}
+	})
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	if got, want := pool.Len(), 0; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+
+	_, err = pool.OpenReplicas(names[0], fmt.Sprintf("root://gopher@%s//short.bin", addrs[nsrvs-1]))
+	if err == nil {
+		t.Fatalf("expected an error opening replicas of different sizes")
+	}
+
+	_, err = pool.OpenReplicas()
+	if err == nil {
+		t.Fatalf("expected an error opening no replica")
+	}
+
+	if got, want := pool.Len(), 0; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+}
+
+func TestReadV(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("Hello XRootD.\n"), 0644)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	pool := xrdio.NewPool(1)
+	defer pool.Close()
+
+	f, err := pool.Open(fmt.Sprintf("root://gopher@%s//file.txt", newServer(t, dir)))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	chunks := []xrdfs.Chunk{
+		{Offset: 6, Data: make([]byte, 6)},
+		{Offset: 0, Data: make([]byte, 5)},
+	}
+	n, err := f.ReadV(chunks)
+	if err != nil {
+		t.Fatalf("could not read chunks: %+v", err)
+	}
+	if got, want := n, 11; got != want {
+		t.Fatalf("invalid number of bytes read: got=%d, want=%d", got, want)
+	}
+	if got, want := string(chunks[0].Data)+" "+string(chunks[1].Data), "XRootD Hello"; got != want {
+		t.Fatalf("invalid data: got=%q, want=%q", got, want)
+	}
+}
+
+func newServer(t *testing.T, dir string) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+
+	srv := xrootd.NewServer(xrootd.NewFSHandler(dir), func(err error) {
+		t.Errorf("server error: %+v", err)
+	})
+	go func() {
+		err := srv.Serve(l)
+		if err != nil && err != xrootd.ErrServerClosed {
+			t.Errorf("could not serve: %+v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	return l.Addr().String()
+}
@@ -0,0 +1,110 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+func TestPoolTLS(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("Hello XRootD.\n"), 0644)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key: %+v", err)
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("could not create certificate: %+v", err)
+	}
+
+	certDir := t.TempDir()
+	err = os.WriteFile(
+		filepath.Join(certDir, "ca.pem"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		0644,
+	)
+	if err != nil {
+		t.Fatalf("could not write certificate: %+v", err)
+	}
+	t.Setenv("X509_CERT_DIR", certDir)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %+v", err)
+	}
+	srv := xrootd.NewServer(xrootd.NewFSHandler(dir), nil)
+	go func() {
+		cfg := &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		}
+		_ = srv.ServeTLS(l, cfg)
+	}()
+	defer func() {
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		t.Fatalf("could not parse address: %+v", err)
+	}
+
+	pool := xrdio.NewPool(1)
+	defer pool.Close()
+
+	for _, scheme := range []string{"roots", "root"} {
+		f, err := pool.Open(fmt.Sprintf("%s://gopher@localhost:%s//file.txt", scheme, port))
+		if err != nil {
+			t.Fatalf("could not open %s file: %+v", scheme, err)
+		}
+		defer f.Close()
+
+		buf := make([]byte, 6)
+		_, err = f.ReadAt(buf, 6)
+		if err != nil {
+			t.Fatalf("could not read %s file: %+v", scheme, err)
+		}
+		if got, want := string(buf), "XRootD"; got != want {
+			t.Fatalf("invalid %s data: got=%q, want=%q", scheme, got, want)
+		}
+	}
+
+	// TLS and plain connections are not shared.
+	if got, want := pool.Len(), 2; got != want {
+		t.Fatalf("invalid number of clients: got=%d, want=%d", got, want)
+	}
+}
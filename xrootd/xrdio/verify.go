@@ -0,0 +1,221 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// DefaultBlockSize is the default size of blocks used to compute digests
+// of files.
+const DefaultBlockSize = 16 * 1024 * 1024
+
+// Range is a half-open [Beg, End) range of bytes.
+type Range struct {
+	Beg int64
+	End int64
+}
+
+// VectorReader is the interface implemented by files that can read
+// several chunks of data with a single vector read request.
+type VectorReader interface {
+	ReadV(chunks []xrdfs.Chunk) (int, error)
+}
+
+// maxBatch is the maximum number of bytes read with a single call to
+// VectorReader.ReadV when computing block digests.
+const maxBatch = 64 * 1024 * 1024
+
+// BlockDigests computes the CRC-32 (IEEE) digests of the consecutive
+// blocks of size blksz of the first size bytes of r.
+// The last block may be shorter than blksz.
+// If r implements VectorReader, batches of blocks are read with vector reads.
+// If blksz <= 0, DefaultBlockSize is used.
+func BlockDigests(r io.ReaderAt, size int64, blksz int) ([]uint32, error) {
+	if blksz <= 0 {
+		blksz = DefaultBlockSize
+	}
+
+	if r, ok := r.(VectorReader); ok {
+		return blockDigestsV(r, size, blksz)
+	}
+
+	var (
+		n   = (size + int64(blksz) - 1) / int64(blksz)
+		sum = make([]uint32, 0, n)
+		buf = make([]byte, blksz)
+	)
+
+	for beg := int64(0); beg < size; beg += int64(blksz) {
+		blk := buf
+		if rem := size - beg; rem < int64(len(blk)) {
+			blk = blk[:rem]
+		}
+		_, err := r.ReadAt(blk, beg)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("xrdio: could not read block [%d, %d): %w", beg, beg+int64(len(blk)), err)
+		}
+		sum = append(sum, crc32.ChecksumIEEE(blk))
+	}
+
+	return sum, nil
+}
+
+// blockDigestsV computes the block digests of r, reading batches of blocks
+// with vector reads.
+func blockDigestsV(r VectorReader, size int64, blksz int) ([]uint32, error) {
+	nblks := maxBatch / blksz
+	if nblks < 1 {
+		nblks = 1
+	}
+
+	var (
+		n      = (size + int64(blksz) - 1) / int64(blksz)
+		sum    = make([]uint32, 0, n)
+		buf    = make([]byte, nblks*blksz)
+		chunks = make([]xrdfs.Chunk, 0, nblks)
+	)
+
+	for beg := int64(0); beg < size; {
+		chunks = chunks[:0]
+		for i := 0; i < nblks && beg < size; i++ {
+			blk := buf[i*blksz : (i+1)*blksz]
+			if rem := size - beg; rem < int64(len(blk)) {
+				blk = blk[:rem]
+			}
+			chunks = append(chunks, xrdfs.Chunk{Offset: beg, Data: blk})
+			beg += int64(len(blk))
+		}
+
+		_, err := r.ReadV(chunks)
+		if err != nil {
+			var (
+				first = chunks[0]
+				last  = chunks[len(chunks)-1]
+			)
+			return nil, fmt.Errorf("xrdio: could not read blocks [%d, %d): %w", first.Offset, last.Offset+int64(cap(last.Data)), err)
+		}
+		for _, c := range chunks {
+			sum = append(sum, crc32.ChecksumIEEE(c.Data))
+		}
+	}
+
+	return sum, nil
+}
+
+// Verify compares the contents of the local and remote files, block by block,
+// using block digests of size blksz.
+// Verify returns the list of mismatching ranges of bytes, where adjacent
+// mismatching blocks are merged together.
+// If the files have different sizes, the extra bytes of the largest one are
+// reported as a mismatching range.
+// If blksz <= 0, DefaultBlockSize is used.
+func Verify(local io.ReaderAt, lsize int64, remote io.ReaderAt, rsize int64, blksz int) ([]Range, error) {
+	if blksz <= 0 {
+		blksz = DefaultBlockSize
+	}
+
+	size := lsize
+	if rsize < size {
+		size = rsize
+	}
+
+	lsum, err := BlockDigests(local, size, blksz)
+	if err != nil {
+		return nil, fmt.Errorf("xrdio: could not compute local digests: %w", err)
+	}
+
+	rsum, err := BlockDigests(remote, size, blksz)
+	if err != nil {
+		return nil, fmt.Errorf("xrdio: could not compute remote digests: %w", err)
+	}
+
+	var diffs []Range
+	add := func(beg, end int64) {
+		if n := len(diffs); n > 0 && diffs[n-1].End == beg {
+			diffs[n-1].End = end
+			return
+		}
+		diffs = append(diffs, Range{Beg: beg, End: end})
+	}
+
+	for i := range lsum {
+		if lsum[i] == rsum[i] {
+			continue
+		}
+		beg := int64(i) * int64(blksz)
+		end := beg + int64(blksz)
+		if end > size {
+			end = size
+		}
+		add(beg, end)
+	}
+
+	if lsize != rsize {
+		end := lsize
+		if rsize > end {
+			end = rsize
+		}
+		add(size, end)
+	}
+
+	return diffs, nil
+}
+
+// VerifyFile compares the content of the local file with the content of the
+// remote file.
+//
+// When both files have the same size, VerifyFile first compares the checksum
+// of the remote file, as computed by the XRootD server, with the checksum of
+// the local file: if they match, no data is transferred from the server.
+// Otherwise, or if the server can not compute checksums, VerifyFile compares
+// the files block by block, as Verify does, reading the remote blocks with
+// vector reads.
+// If blksz <= 0, DefaultBlockSize is used.
+func VerifyFile(local io.ReaderAt, lsize int64, remote *File, blksz int) ([]Range, error) {
+	fi, err := remote.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("xrdio: could not stat remote file: %w", err)
+	}
+	rsize := fi.Size()
+
+	if lsize == rsize {
+		cs, err := remote.Checksum("")
+		if err == nil {
+			err = VerifyChecksum(io.NewSectionReader(local, 0, lsize), cs)
+			if err == nil {
+				return nil, nil
+			}
+		}
+	}
+
+	return Verify(local, lsize, remote, rsize, blksz)
+}
+
+// VerifyChecksum checks that the content read from r matches the provided
+// checksum, as returned by File.Checksum.
+func VerifyChecksum(r io.Reader, want xrdfs.Checksum) error {
+	h, err := xrdfs.NewHash(want.Type)
+	if err != nil {
+		return fmt.Errorf("xrdio: could not verify checksum: %w", err)
+	}
+
+	_, err = io.Copy(h, r)
+	if err != nil {
+		return fmt.Errorf("xrdio: could not read content to verify: %w", err)
+	}
+
+	got := xrdfs.Checksum{Type: want.Type, Value: h.Sum(nil)}
+	if !bytes.Equal(got.Value, want.Value) {
+		return fmt.Errorf("xrdio: checksum mismatch: got=%v, want=%v", got, want)
+	}
+	return nil
+}
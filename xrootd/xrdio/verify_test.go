@@ -0,0 +1,250 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrdio_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdio"
+)
+
+// vreader is a reader that can only be read with vector reads.
+type vreader struct {
+	r *bytes.Reader
+	n int // number of vector reads
+}
+
+func (r *vreader) ReadAt(p []byte, off int64) (int, error) {
+	panic("xrdio: ReadAt called on a vector reader")
+}
+
+func (r *vreader) ReadV(chunks []xrdfs.Chunk) (int, error) {
+	r.n++
+	n := 0
+	for _, c := range chunks {
+		nn, err := r.r.ReadAt(c.Data, c.Offset)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func TestBlockDigestsVector(t *testing.T) {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	for _, blksz := range []int{1, 7, 10, 64, 100, 200} {
+		want, err := xrdio.BlockDigests(bytes.NewReader(data), int64(len(data)), blksz)
+		if err != nil {
+			t.Fatalf("could not compute reference digests: %+v", err)
+		}
+
+		r := &vreader{r: bytes.NewReader(data)}
+		got, err := xrdio.BlockDigests(r, int64(len(data)), blksz)
+		if err != nil {
+			t.Fatalf("blksz=%d: could not compute digests: %+v", blksz, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("blksz=%d: invalid digests:\ngot= %v\nwant=%v", blksz, got, want)
+		}
+		if r.n != 1 {
+			t.Fatalf("blksz=%d: invalid number of vector reads: got=%d, want=1", blksz, r.n)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ref := make([]byte, 100)
+	for i := range ref {
+		ref[i] = byte(i)
+	}
+
+	modify := func(n int, idx ...int) []byte {
+		o := make([]byte, n)
+		copy(o, ref)
+		for _, i := range idx {
+			o[i]++
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		name  string
+		data  []byte
+		blksz int
+		want  []xrdio.Range
+	}{
+		{
+			name:  "same",
+			data:  modify(100),
+			blksz: 10,
+		},
+		{
+			name:  "same-default-block",
+			data:  modify(100),
+			blksz: 0,
+		},
+		{
+			name:  "one-block",
+			data:  modify(100, 15),
+			blksz: 10,
+			want:  []xrdio.Range{{10, 20}},
+		},
+		{
+			name:  "adjacent-blocks",
+			data:  modify(100, 15, 25, 75),
+			blksz: 10,
+			want:  []xrdio.Range{{10, 30}, {70, 80}},
+		},
+		{
+			name:  "last-block",
+			data:  modify(100, 99),
+			blksz: 16,
+			want:  []xrdio.Range{{96, 100}},
+		},
+		{
+			name:  "short",
+			data:  modify(95),
+			blksz: 10,
+			want:  []xrdio.Range{{95, 100}},
+		},
+		{
+			name:  "short-modified",
+			data:  modify(95, 91),
+			blksz: 10,
+			want:  []xrdio.Range{{90, 100}},
+		},
+		{
+			name:  "long",
+			data:  append(modify(100), 1, 2, 3),
+			blksz: 10,
+			want:  []xrdio.Range{{100, 103}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := xrdio.Verify(
+				bytes.NewReader(ref), int64(len(ref)),
+				bytes.NewReader(tc.data), int64(len(tc.data)),
+				tc.blksz,
+			)
+			if err != nil {
+				t.Fatalf("could not verify: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid mismatches:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	dir := t.TempDir()
+	addr := newServer(t, dir)
+
+	want := []byte("Wikipedia")
+	err := os.WriteFile(filepath.Join(dir, "file.txt"), want, 0644)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	f, err := xrdio.Open("root://" + addr + "/file.txt")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, typ := range []string{"", "adler32", "md5", "crc32c"} {
+		t.Run(typ, func(t *testing.T) {
+			cs, err := f.Checksum(typ)
+			if err != nil {
+				t.Fatalf("could not retrieve checksum: %+v", err)
+			}
+
+			err = xrdio.VerifyChecksum(bytes.NewReader(want), cs)
+			if err != nil {
+				t.Fatalf("could not verify checksum: %+v", err)
+			}
+
+			err = xrdio.VerifyChecksum(bytes.NewReader([]byte("wikipedia")), cs)
+			if err == nil {
+				t.Fatalf("expected a checksum mismatch")
+			}
+		})
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	dir := t.TempDir()
+	addr := newServer(t, dir)
+
+	ref := make([]byte, 100)
+	for i := range ref {
+		ref[i] = byte(i)
+	}
+	err := os.WriteFile(filepath.Join(dir, "file.bin"), ref, 0644)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+
+	f, err := xrdio.Open("root://" + addr + "/file.bin")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	modify := func(n int, idx ...int) []byte {
+		o := make([]byte, n)
+		copy(o, ref)
+		for _, i := range idx {
+			o[i]++
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want []xrdio.Range
+	}{
+		{
+			name: "same",
+			data: modify(100),
+		},
+		{
+			name: "adjacent-blocks",
+			data: modify(100, 15, 25, 75),
+			want: []xrdio.Range{{10, 30}, {70, 80}},
+		},
+		{
+			name: "short-modified",
+			data: modify(95, 91),
+			want: []xrdio.Range{{90, 100}},
+		},
+		{
+			name: "long",
+			data: append(modify(100), 1, 2, 3),
+			want: []xrdio.Range{{100, 103}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := xrdio.VerifyFile(bytes.NewReader(tc.data), int64(len(tc.data)), f, 10)
+			if err != nil {
+				t.Fatalf("could not verify: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid mismatches:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"io"
 	"os"
 
-	"go-hep.org/x/hep/xrootd"
 	"go-hep.org/x/hep/xrootd/xrdfs"
 )
 
@@ -23,48 +22,30 @@ import (
 //  - io.WriterAt
 //  - io.Seeker
 type File struct {
-	cli *xrootd.Client
-	fs  xrdfs.FileSystem
-	f   xrdfs.File
+	pool *Pool
+	conn *poolConn // client of the pool used by this file
+	fs   xrdfs.FileSystem
+	f    xrdfs.File
 
 	name string
 	pos  int64
 	size int64
+
+	reps []*File // other replicas of the file, see OpenReplicas
+	next uint32  // replica serving the next small read
 }
 
 // Open opens the name file, where name is the absolute location of that file
 // (xrootd server address and path to the file on that server.)
 //
+// Files opened with Open share the clients, and thus the TCP sessions,
+// of a default Pool of at most DefaultPoolSize clients per server.
+//
 // Example:
 //
 //  f, err := xrdio.Open("root://server.example.com:1094//some/path/to/file")
 func Open(name string) (*File, error) {
-	urn, err := Parse(name)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse %q: %w", name, err)
-	}
-
-	xrd, err := xrootd.NewClient(context.Background(), urn.Addr, urn.User)
-	if err != nil {
-		return nil, fmt.Errorf("xrdio: could not connect to xrootd server %q: %w", urn.Addr, err)
-	}
-
-	fs := xrd.FS()
-	f, err := fs.Open(context.Background(), urn.Path, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
-	if err != nil {
-		xrd.Close()
-		return nil, fmt.Errorf("xrdio: could not open %q: %w", name, err)
-	}
-
-	xf := &File{cli: xrd, fs: fs, f: f, name: urn.Path}
-	fi, err := xf.Stat()
-	if err != nil {
-		xrd.Close()
-		return nil, fmt.Errorf("xrdio: could not stat %q: %w", name, err)
-	}
-	xf.size = fi.Size()
-
-	return xf, nil
+	return defaultPool.Open(name)
 }
 
 // OpenFrom opens the file name via the given filesystem handle.
@@ -103,10 +84,17 @@ func (f *File) Close() error {
 	var (
 		err1 = f.f.Close(context.Background())
 		err2 error
+		err3 error
 	)
 
-	if f.cli != nil {
-		err2 = f.cli.Close()
+	if f.pool != nil {
+		err2 = f.pool.release(f.conn)
+	}
+	for _, r := range f.reps {
+		err := r.Close()
+		if err != nil && err3 == nil {
+			err3 = err
+		}
 	}
 	if err1 != nil {
 		return fmt.Errorf("xrdio: could not close file %q: %w", f.name, err1)
@@ -114,12 +102,15 @@ func (f *File) Close() error {
 	if err2 != nil {
 		return fmt.Errorf("xrdio: could not close xrd-client: %w", err2)
 	}
+	if err3 != nil {
+		return fmt.Errorf("xrdio: could not close replica: %w", err3)
+	}
 	return nil
 }
 
 // Read implements io.Reader.
 func (f *File) Read(data []byte) (int, error) {
-	n, err := f.f.ReadAt(data, f.pos)
+	n, err := f.ReadAt(data, f.pos)
 	f.pos += int64(n)
 	if err != nil {
 		return n, err
@@ -132,6 +123,9 @@ func (f *File) Read(data []byte) (int, error) {
 
 // ReadAt implements io.ReaderAt.
 func (f *File) ReadAt(data []byte, offset int64) (int, error) {
+	if len(f.reps) > 0 {
+		return f.readAt(data, offset)
+	}
 	return f.f.ReadAt(data, offset)
 }
 
@@ -170,6 +164,17 @@ func (f *File) Stat() (os.FileInfo, error) {
 	return v, err
 }
 
+// Checksum returns the checksum of the file, computed by the XRootD server
+// with the typ algorithm (e.g. adler32, md5).
+// The default algorithm of the server is used if typ is empty.
+func (f *File) Checksum(typ string) (xrdfs.Checksum, error) {
+	cs, err := f.fs.Checksum(context.Background(), f.name, typ)
+	if err != nil {
+		return cs, fmt.Errorf("xrdio: could not retrieve checksum of %q: %w", f.name, err)
+	}
+	return cs, nil
+}
+
 var (
 	_ io.Closer   = (*File)(nil)
 	_ io.Reader   = (*File)(nil)
@@ -47,3 +47,11 @@ type Auther interface {
 	Provider() string                          // Provider returns the name of the security provider.
 	Request(params []string) (*Request, error) // Request forms an authorization Request according to passed parameters.
 }
+
+// TLSAuther is the interface implemented by security providers that may
+// only be used over connections secured with TLS, e.g. because they send
+// bearer tokens.
+type TLSAuther interface {
+	Auther
+	RequireTLS() bool // RequireTLS returns whether the provider requires a TLS connection.
+}
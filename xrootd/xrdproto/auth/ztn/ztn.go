@@ -0,0 +1,152 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ztn contains the implementation of the ztn (token) security provider.
+//
+// The ztn security provider authenticates clients with bearer tokens,
+// such as SciTokens or WLCG JSON Web Tokens.
+// Tokens are only sent over connections secured with TLS.
+//
+// Unless explicitly provided, the token is discovered following the WLCG
+// Bearer Token Discovery specification, in order:
+//  - the content of the BEARER_TOKEN environment variable,
+//  - the content of the file named by the BEARER_TOKEN_FILE environment variable,
+//  - the content of the file $XDG_RUNTIME_DIR/bt_u$UID,
+//  - the content of the file bt_u$UID in the temporary directory (e.g. /tmp/bt_u$UID).
+package ztn // import "go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/xrootd/xrdproto/auth"
+)
+
+// Default is a ztn security provider discovering the token from the
+// environment each time a request is made.
+var Default auth.Auther = &Auth{}
+
+// Auth implements the ztn (token) security provider.
+type Auth struct {
+	// Token is the bearer token to send to the server.
+	// If empty, the token is discovered from the environment.
+	Token string
+}
+
+// Provider implements auth.Auther
+func (*Auth) Provider() string {
+	return "ztn"
+}
+
+// RequireTLS implements auth.TLSAuther
+func (*Auth) RequireTLS() bool {
+	return true
+}
+
+// Type indicates that the ztn authentication protocol is used.
+var Type = [4]byte{'z', 't', 'n', 0}
+
+const (
+	version   = 0   // version of the ztn protocol
+	tokenResp = 'T' // operation code of a token response
+)
+
+// Request implements auth.Auther
+//
+// The parameters sent by the server are of the form "V:N:", with V the
+// version of the protocol and N the maximum size of a token.
+func (a *Auth) Request(params []string) (*auth.Request, error) {
+	tok := a.Token
+	if tok == "" {
+		var err error
+		tok, err = Discover()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if max := maxTokenSize(params); max > 0 && len(tok)+1 > max {
+		return nil, fmt.Errorf("auth/ztn: token too long (size=%d, max=%d)", len(tok)+1, max)
+	}
+	if len(tok)+1 > 0xffff {
+		return nil, fmt.Errorf("auth/ztn: token too long (size=%d)", len(tok)+1)
+	}
+
+	var hdr [10]byte
+	copy(hdr[:4], Type[:])
+	hdr[4] = version
+	hdr[5] = tokenResp
+	binary.BigEndian.PutUint16(hdr[8:], uint16(len(tok)+1))
+
+	return &auth.Request{Type: Type, Credentials: string(hdr[:]) + tok + "\000"}, nil
+}
+
+func maxTokenSize(params []string) int {
+	if len(params) == 0 {
+		return 0
+	}
+	toks := strings.Split(params[0], ":")
+	if len(toks) < 2 {
+		return 0
+	}
+	v, err := strconv.Atoi(toks[1])
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// Discover returns the bearer token found in the environment, following
+// the WLCG Bearer Token Discovery specification.
+func Discover() (string, error) {
+	if tok := strings.TrimSpace(os.Getenv("BEARER_TOKEN")); tok != "" {
+		return tok, nil
+	}
+
+	if fname := os.Getenv("BEARER_TOKEN_FILE"); fname != "" {
+		return readToken(fname)
+	}
+
+	name := "bt_u" + strconv.Itoa(os.Getuid())
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		tok, err := readToken(filepath.Join(dir, name))
+		if err == nil {
+			return tok, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	tok, err := readToken(filepath.Join(os.TempDir(), name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("auth/ztn: could not find a bearer token")
+		}
+		return "", err
+	}
+	return tok, nil
+}
+
+func readToken(fname string) (string, error) {
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		return "", fmt.Errorf("auth/ztn: could not read bearer token: %w", err)
+	}
+	tok := strings.TrimSpace(string(raw))
+	if tok == "" {
+		return "", fmt.Errorf("auth/ztn: empty bearer token in %q", fname)
+	}
+	return tok, nil
+}
+
+var (
+	_ auth.Auther    = (*Auth)(nil)
+	_ auth.TLSAuther = (*Auth)(nil)
+)
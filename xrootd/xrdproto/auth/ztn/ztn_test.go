@@ -0,0 +1,134 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ztn_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdproto/auth/ztn"
+)
+
+func TestAuth(t *testing.T) {
+	v := ztn.Auth{Token: "abc.def.ghi"}
+	if got, want := v.Provider(), "ztn"; got != want {
+		t.Fatalf("invalid provider: got=%q, want=%q", got, want)
+	}
+
+	if got, want := v.Provider()+"\000", string(ztn.Type[:]); got != want {
+		t.Fatalf("invalid provider type: got=%q, want=%q", got, want)
+	}
+
+	if !v.RequireTLS() {
+		t.Fatalf("ztn provider should require TLS")
+	}
+
+	got, err := v.Request([]string{"0:4096:"})
+	if err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+
+	want := "ztn\000\000T\000\000\000\014abc.def.ghi\000"
+	if got.Credentials != want {
+		t.Fatalf("invalid credentials:\ngot= %q\nwant=%q\n", got.Credentials, want)
+	}
+
+	if got.Type != ztn.Type {
+		t.Fatalf("invalid type: got=%q, want=%q", got.Type, ztn.Type)
+	}
+
+	_, err = v.Request([]string{"0:8:"})
+	if err == nil {
+		t.Fatalf("expected an error for a too long token")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	var (
+		tmp  = t.TempDir()
+		xdg  = t.TempDir()
+		name = "bt_u" + strconv.Itoa(os.Getuid())
+		file = filepath.Join(t.TempDir(), "token")
+	)
+
+	write := func(fname, tok string) {
+		t.Helper()
+		err := os.WriteFile(fname, []byte(tok), 0600)
+		if err != nil {
+			t.Fatalf("could not write token: %+v", err)
+		}
+	}
+	write(filepath.Join(tmp, name), "tok-tmp\n")
+	write(filepath.Join(xdg, name), "tok-xdg\n")
+	write(file, " tok-file\n")
+
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		want string
+		err  string
+	}{
+		{
+			name: "env",
+			env:  map[string]string{"BEARER_TOKEN": "tok-env", "BEARER_TOKEN_FILE": file},
+			want: "tok-env",
+		},
+		{
+			name: "file",
+			env:  map[string]string{"BEARER_TOKEN_FILE": file, "XDG_RUNTIME_DIR": xdg},
+			want: "tok-file",
+		},
+		{
+			name: "xdg",
+			env:  map[string]string{"XDG_RUNTIME_DIR": xdg},
+			want: "tok-xdg",
+		},
+		{
+			name: "tmp",
+			env:  map[string]string{"XDG_RUNTIME_DIR": t.TempDir()},
+			want: "tok-tmp",
+		},
+		{
+			name: "missing-file",
+			env:  map[string]string{"BEARER_TOKEN_FILE": filepath.Join(tmp, "not-there")},
+			err:  "could not read bearer token",
+		},
+		{
+			name: "no-token",
+			env:  map[string]string{"TMPDIR": t.TempDir()},
+			err:  "could not find a bearer token",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{"BEARER_TOKEN", "BEARER_TOKEN_FILE", "XDG_RUNTIME_DIR"} {
+				t.Setenv(k, "")
+			}
+			t.Setenv("TMPDIR", tmp)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := ztn.Discover()
+			switch {
+			case err != nil && tc.err == "":
+				t.Fatalf("could not discover token: %+v", err)
+			case err != nil:
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("invalid error: got=%q, want=%q", err, tc.err)
+				}
+				return
+			case tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("invalid token: got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+}
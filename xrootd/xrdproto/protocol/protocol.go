@@ -26,6 +26,9 @@ import (
 // See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
 const RequestID uint16 = 3006
 
+// TLSVersion is the first version of the XRootD protocol supporting TLS.
+const TLSVersion int32 = 0x500
+
 // Flags are the Flags that define xrootd server type. See xrootd protocol specification for further info.
 type Flags int32
 
@@ -35,6 +38,14 @@ const (
 	IsMeta       Flags = 0x00000100 // IsMeta indicates whether this server has meta attribute.
 	IsProxy      Flags = 0x00000200 // IsProxy indicates whether this server has proxy attribute.
 	IsSupervisor Flags = 0x00000400 // IsSupervisor indicates whether this server has supervisor attribute.
+
+	TLSData  Flags = 0x01000000 // TLSData indicates that the server requires TLS for data connections.
+	TLSGPF   Flags = 0x02000000 // TLSGPF indicates that the server requires TLS for get and put file requests.
+	TLSLogin Flags = 0x04000000 // TLSLogin indicates that the server requires TLS before login.
+	TLSSess  Flags = 0x08000000 // TLSSess indicates that the server requires TLS after login.
+	TLSTPC   Flags = 0x10000000 // TLSTPC indicates that the server requires TLS for third-party copies.
+	GotoTLS  Flags = 0x40000000 // GotoTLS indicates that the connection must be switched to TLS after the response.
+	HaveTLS  Flags = -1 << 31   // HaveTLS indicates whether this server supports TLS (0x80000000).
 )
 
 // SecurityOptions are the security-related options.
@@ -56,13 +67,28 @@ const (
 	// ReturnSecurityRequirements specifies that security requirements should be returned
 	// if that's supported by the server.
 	ReturnSecurityRequirements RequestOptions = 1
+	// AbleTLS specifies that the client is able to use TLS.
+	AbleTLS RequestOptions = 2
+	// WantTLS specifies that the client wants the connection to be switched to TLS.
+	WantTLS RequestOptions = 4
+)
+
+// Expect specifies the next request the client will send after the protocol request.
+// It is used by the server to decide whether the connection must be switched to TLS.
+type Expect byte
+
+const (
+	ExpectNone  Expect = 0 // ExpectNone specifies that the next request is unknown.
+	ExpectBind  Expect = 1 // ExpectBind specifies that the next request is a bind request.
+	ExpectLogin Expect = 3 // ExpectLogin specifies that the next request is a login request.
 )
 
 // Request holds protocol request parameters.
 type Request struct {
 	ClientProtocolVersion int32
 	Options               RequestOptions
-	_                     [11]byte
+	Expect                Expect
+	_                     [10]byte
 	_                     int32
 }
 
@@ -82,7 +108,8 @@ func (req *Request) ReqID() uint16 { return RequestID }
 func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
 	wBuffer.WriteI32(o.ClientProtocolVersion)
 	wBuffer.WriteU8(byte(o.Options))
-	wBuffer.Next(15)
+	wBuffer.WriteU8(byte(o.Expect))
+	wBuffer.Next(14)
 	return nil
 }
 
@@ -90,7 +117,8 @@ func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
 func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
 	o.ClientProtocolVersion = rBuffer.ReadI32()
 	o.Options = RequestOptions(rBuffer.ReadU8())
-	rBuffer.Skip(15)
+	o.Expect = Expect(rBuffer.ReadU8())
+	rBuffer.Skip(14)
 	return nil
 }
 
@@ -132,6 +160,16 @@ func (resp *Response) IsSupervisor() bool {
 	return resp.Flags&IsSupervisor != 0
 }
 
+// HasTLS indicates whether this server supports TLS.
+func (resp *Response) HasTLS() bool {
+	return resp.Flags&HaveTLS != 0
+}
+
+// GoToTLS indicates whether the connection must be switched to TLS after this response.
+func (resp *Response) GoToTLS() bool {
+	return resp.Flags&GotoTLS != 0
+}
+
 // ForceSecurity indicates whether signing is required even if the authentication
 // protocol does not support generic encryption.
 func (resp *Response) ForceSecurity() bool {
@@ -0,0 +1,170 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package readv contains the structures describing request and response for readv request.
+// See xrootd protocol specification (http://xrootd.org/doc/dev45/XRdv310.pdf, p. 105) for details.
+package readv // import "go-hep.org/x/hep/xrootd/xrdproto/readv"
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// RequestID is the id of the request, it is sent as part of message.
+// See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
+const RequestID uint16 = 3025
+
+const (
+	// MaxSegments is the maximum number of segments a server accepts
+	// in a single readv request (readv_iov_max).
+	MaxSegments = 1024
+
+	// MaxLength is the maximum number of bytes a server accepts to read
+	// for a single segment of a readv request (readv_ior_max).
+	MaxLength = 2097136
+)
+
+// segmentLength is the length of the marshaled form of a Segment.
+const segmentLength = 16
+
+// Segment describes a contiguous region of a file to read.
+type Segment struct {
+	Handle xrdfs.FileHandle
+	Length int32
+	Offset int64
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Segment) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(o.Handle[:])
+	wBuffer.WriteI32(o.Length)
+	wBuffer.WriteI64(o.Offset)
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Segment) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.ReadBytes(o.Handle[:])
+	o.Length = rBuffer.ReadI32()
+	o.Offset = rBuffer.ReadI64()
+	return nil
+}
+
+// Request holds readv request parameters.
+type Request struct {
+	_        [15]uint8
+	PathID   xrdproto.PathID
+	Segments []Segment
+}
+
+// ReqID implements xrdproto.Request.ReqID.
+func (req *Request) ReqID() uint16 { return RequestID }
+
+// ShouldSign implements xrdproto.Request.ShouldSign.
+func (req *Request) ShouldSign() bool { return false }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.Next(15)
+	wBuffer.WriteU8(uint8(o.PathID))
+	wBuffer.WriteLen(len(o.Segments) * segmentLength)
+	for _, seg := range o.Segments {
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.Skip(15)
+	o.PathID = xrdproto.PathID(rBuffer.ReadU8())
+	alen := rBuffer.ReadLen()
+	if alen%segmentLength != 0 || alen > rBuffer.Len() {
+		return fmt.Errorf("xrootd: invalid readv request length %d", alen)
+	}
+	o.Segments = make([]Segment, alen/segmentLength)
+	for i := range o.Segments {
+		err := o.Segments[i].UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Chunk is the data read for one segment of a readv request.
+type Chunk struct {
+	Handle xrdfs.FileHandle
+	Offset int64
+	Data   []uint8
+}
+
+// Response is a response for the readv request, which contains the data
+// read for each segment, in the order of the request.
+//
+// The Data buffers of pre-allocated Chunks are reused when unmarshaling,
+// whenever they are large enough.
+type Response struct {
+	Chunks []Chunk
+}
+
+// RespID implements xrdproto.Response.RespID.
+func (resp *Response) RespID() uint16 { return RequestID }
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Response) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	for _, c := range o.Chunks {
+		seg := Segment{Handle: c.Handle, Length: int32(len(c.Data)), Offset: c.Offset}
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+		wBuffer.WriteBytes(c.Data)
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Response) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	i := 0
+	for ; rBuffer.Len() > 0; i++ {
+		if rBuffer.Len() < segmentLength {
+			return fmt.Errorf("xrootd: truncated readv response header (%d bytes)", rBuffer.Len())
+		}
+		var seg Segment
+		err := seg.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+		n := int(seg.Length)
+		if n < 0 || n > rBuffer.Len() {
+			return fmt.Errorf("xrootd: invalid readv response segment length %d", n)
+		}
+
+		if i == len(o.Chunks) {
+			o.Chunks = append(o.Chunks, Chunk{})
+		}
+		c := &o.Chunks[i]
+		c.Handle = seg.Handle
+		c.Offset = seg.Offset
+		if cap(c.Data) < n {
+			c.Data = make([]uint8, n)
+		}
+		c.Data = c.Data[:n]
+		rBuffer.ReadBytes(c.Data)
+	}
+	o.Chunks = o.Chunks[:i]
+	return nil
+}
+
+var (
+	_ xrdproto.Request  = (*Request)(nil)
+	_ xrdproto.Response = (*Response)(nil)
+)
@@ -0,0 +1,170 @@
+// Copyright ©2022 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package readv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
+)
+
+func TestRequest(t *testing.T) {
+	for _, want := range []readv.Request{
+		{},
+		{
+			Segments: []readv.Segment{
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Length: 10, Offset: 0},
+			},
+		},
+		{
+			PathID: 2,
+			Segments: []readv.Segment{
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Length: 10, Offset: 0},
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Length: 1024, Offset: 1 << 40},
+				{Handle: xrdfs.FileHandle{5, 6, 7, 8}, Length: 0, Offset: 42},
+			},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			var (
+				err error
+				w   = new(xrdenc.WBuffer)
+				got readv.Request
+			)
+
+			if want.ReqID() != readv.RequestID {
+				t.Fatalf("invalid request ID: got=%d want=%d", want.ReqID(), readv.RequestID)
+			}
+
+			if want.ShouldSign() {
+				t.Fatalf("invalid")
+			}
+
+			err = want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal request: %v", err)
+			}
+
+			r := xrdenc.NewRBuffer(w.Bytes())
+			err = got.UnmarshalXrd(r)
+			if err != nil {
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+
+			if len(want.Segments) == 0 {
+				want.Segments = []readv.Segment{}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+			}
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	for _, want := range []readv.Response{
+		{},
+		{
+			Chunks: []readv.Chunk{
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Offset: 0, Data: []byte("hello")},
+			},
+		},
+		{
+			Chunks: []readv.Chunk{
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Offset: 0, Data: []byte("hello")},
+				{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Offset: 1 << 40, Data: []byte("XRootD")},
+				{Handle: xrdfs.FileHandle{5, 6, 7, 8}, Offset: 42, Data: nil},
+			},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			var (
+				err error
+				w   = new(xrdenc.WBuffer)
+				got readv.Response
+			)
+
+			if want.RespID() != readv.RequestID {
+				t.Fatalf("invalid response ID: got=%d want=%d", want.RespID(), readv.RequestID)
+			}
+
+			err = want.MarshalXrd(w)
+			if err != nil {
+				t.Fatalf("could not marshal response: %v", err)
+			}
+
+			r := xrdenc.NewRBuffer(w.Bytes())
+			err = got.UnmarshalXrd(r)
+			if err != nil {
+				t.Fatalf("could not unmarshal response: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip failed:\ngot = %#v\nwant= %#v\n", got, want)
+			}
+		})
+	}
+}
+
+func TestResponseReuse(t *testing.T) {
+	want := readv.Response{
+		Chunks: []readv.Chunk{
+			{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Offset: 0, Data: []byte("hello")},
+			{Handle: xrdfs.FileHandle{1, 2, 3, 4}, Offset: 10, Data: []byte("XRootD")},
+		},
+	}
+
+	w := new(xrdenc.WBuffer)
+	err := want.MarshalXrd(w)
+	if err != nil {
+		t.Fatalf("could not marshal response: %v", err)
+	}
+
+	buf := make([]byte, 16)
+	got := readv.Response{
+		Chunks: []readv.Chunk{{Data: buf[:5]}, {Data: buf[5:]}},
+	}
+	err = got.UnmarshalXrd(xrdenc.NewRBuffer(w.Bytes()))
+	if err != nil {
+		t.Fatalf("could not unmarshal response: %v", err)
+	}
+
+	if got, want := string(buf[:11]), "helloXRootD"; got != want {
+		t.Fatalf("data not read into provided buffers: got=%q, want=%q", got, want)
+	}
+}
+
+func TestResponseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "truncated-header",
+			data: make([]byte, 10),
+		},
+		{
+			name: "truncated-data",
+			data: []byte{1, 2, 3, 4, 0, 0, 0, 10, 0, 0, 0, 0, 0, 0, 0, 0, 'a'},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp readv.Response
+			err := resp.UnmarshalXrd(xrdenc.NewRBuffer(tc.data))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+var (
+	_ xrdproto.Request  = (*readv.Request)(nil)
+	_ xrdproto.Response = (*readv.Response)(nil)
+)
@@ -15,6 +15,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -78,6 +79,7 @@ func New(level xrdproto.SecurityLevel, overrides []xrdproto.SecurityOverride) Re
 		// TODO: set requirements
 		sr.requirements[dirlist.RequestID] = xrdproto.SignNeeded
 		sr.requirements[read.RequestID] = xrdproto.SignNeeded
+		sr.requirements[readv.RequestID] = xrdproto.SignNeeded
 		sr.requirements[stat.RequestID] = xrdproto.SignNeeded
 		sr.requirements[statx.RequestID] = xrdproto.SignNeeded
 		sr.requirements[sync.RequestID] = xrdproto.SignNeeded
@@ -64,6 +64,9 @@ const (
 	// OkSoFar indicates that server provides partial response and client should be prepared
 	// to receive additional responses on same stream.
 	OkSoFar ResponseStatus = 4000
+	// Attn indicates an unsolicited response that the server sends to the client.
+	// Only the asynchronous response action (see AsyncResponse) is understood by the client.
+	Attn ResponseStatus = 4001
 	// Error indicates that an error occurred during request handling.
 	// Error code and error message are sent as part of response (see xrootd protocol specification v3.1.0, p. 27).
 	Error ResponseStatus = 4003
@@ -71,8 +74,15 @@ const (
 	Redirect ResponseStatus = 4004
 	// Wait indicates that the client must wait the indicated number of seconds and retry the request.
 	Wait ResponseStatus = 4005
+	// WaitResp indicates that the client must wait for an asynchronous response
+	// to the request, delivered later on as part of an Attn response.
+	WaitResp ResponseStatus = 4006
 )
 
+// AsyncResponse is the action code of an Attn response carrying the response
+// to a request previously answered with the WaitResp status.
+const AsyncResponse int32 = 5008
+
 // WaitResponse is the response indicating that the client must wait and retry the request.
 // See http://xrootd.org/doc/dev45/XRdv310.pdf, p. 35 for details.
 type WaitResponse struct {
@@ -105,6 +115,9 @@ const (
 	IOError        ServerErrorCode = 3007 // IOError indicates that an IO error has occurred on the server side.
 	NotAuthorized  ServerErrorCode = 3010 // NotAuthorized indicates that user was not authorized for operation.
 	NotFound       ServerErrorCode = 3011 // NotFound indicates that path was not found on the remote server.
+	NoServer       ServerErrorCode = 3014 // NoServer indicates that no server is currently able to handle the request.
+	InProgress     ServerErrorCode = 3020 // InProgress indicates that the operation is already in progress.
+	Overloaded     ServerErrorCode = 3024 // Overloaded indicates that the server is temporarily overloaded.
 )
 
 func (err ServerError) Error() string {